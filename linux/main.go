@@ -52,7 +52,6 @@ func runServer(port int) (*server.Server, error) {
 		FilesDriver:             "local",
 		FilesPath:               path.Join(executableDir, "focalboard_files"),
 		Telemetry:               true,
-		WebhookUpdate:           []string{},
 		SessionExpireTime:       259200000000,
 		SessionRefreshTime:      18000,
 		LocalOnly:               false,
@@ -2,6 +2,8 @@ package main
 
 import (
 	"reflect"
+
+	"github.com/mattermost/focalboard/server/model"
 )
 
 // configuration captures the plugin's external configuration as exposed in the Mattermost server
@@ -84,7 +86,10 @@ func (p *Plugin) OnConfigurationChange() error { //nolint
 		EnablePublicSharedBoards: enableShareBoards,
 	}
 	p.setConfiguration(configuration)
-	p.server.Config().EnablePublicSharedBoards = enableShareBoards
+	p.server.SetPublicSharedBoards(enableShareBoards)
+	p.server.Config().SharedBoardsAllowedCIDRs = getPluginSettingList(*mmconfig, sharedBoardsCIDRsName)
+	p.server.Config().EnableWelcomeBoard = getPluginSettingBool(*mmconfig, welcomeBoardName, false)
+	p.server.Config().WelcomeBoardTemplateID = getPluginSettingString(*mmconfig, welcomeBoardTemplName, "")
 
 	// handle feature flags
 	p.server.Config().FeatureFlags = parseFeatureFlags(mmconfig.FeatureFlags.ToMap())
@@ -99,5 +104,12 @@ func (p *Plugin) OnConfigurationChange() error { //nolint
 
 	p.server.UpdateAppConfig()
 	p.wsPluginAdapter.BroadcastConfigChange(*p.server.App().GetClientConfig())
+
+	// handle board limits, which are broadcast to the clients on change
+	p.server.App().UpdateBoardLimits(model.BoardLimits{
+		CardLimit:              getPluginSettingInt(*mmconfig, cardLimitKey, 0),
+		ViewLimit:              getPluginSettingInt(*mmconfig, viewLimitKey, 0),
+		AttachmentStorageLimit: int64(getPluginSettingInt(*mmconfig, attachmentStorageLimitKey, 0)),
+	})
 	return nil
 }
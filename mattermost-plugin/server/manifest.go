@@ -43,9 +43,17 @@ const manifestStr = `
         "key": "EnablePublicSharedBoards",
         "display_name": "Enable Publicly-Shared Boards:",
         "type": "bool",
-        "help_text": "This allows board editors to share boards that can be accessed by anyone with the link.",
+        "help_text": "This allows board editors to share boards that can be accessed by anyone with the link. Disabling it revokes the existing links, which don't work again if it's enabled back.",
         "placeholder": "",
         "default": false
+      },
+      {
+        "key": "SharedBoardsAllowedCIDRs",
+        "display_name": "Shared Boards Allowed Networks:",
+        "type": "text",
+        "help_text": "Comma separated CIDR ranges, like 10.0.0.0/8, that publicly-shared boards can only be accessed from. Any address can access them when empty.",
+        "placeholder": "",
+        "default": ""
       }
     ]
   }
@@ -6,7 +6,12 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/notify/notifyassignments"
+	"github.com/mattermost/focalboard/server/services/notify/notifyautoarchive"
+	"github.com/mattermost/focalboard/server/services/notify/notifydormant"
+	"github.com/mattermost/focalboard/server/services/notify/notifyescalations"
 	"github.com/mattermost/focalboard/server/services/notify/notifymentions"
+	"github.com/mattermost/focalboard/server/services/notify/notifyreminders"
 	"github.com/mattermost/focalboard/server/services/notify/notifysubscriptions"
 	"github.com/mattermost/focalboard/server/services/notify/plugindelivery"
 	"github.com/mattermost/focalboard/server/services/permissions"
@@ -45,6 +50,7 @@ func createMentionsNotifyBackend(params notifyBackendParams) (*notifymentions.Ba
 		Permissions: params.permissions,
 		Delivery:    delivery,
 		Logger:      params.logger,
+		ReminderAge: time.Duration(params.cfg.MentionReminderHours) * time.Hour,
 	}
 
 	backend := notifymentions.New(backendParams)
@@ -52,6 +58,93 @@ func createMentionsNotifyBackend(params notifyBackendParams) (*notifymentions.Ba
 	return backend, nil
 }
 
+func createAssignmentsNotifyBackend(params notifyBackendParams) (*notifyassignments.Backend, error) {
+	delivery, err := createDelivery(params.client, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyassignments.BackendParams{
+		AppAPI:      params.appAPI,
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+
+	backend := notifyassignments.New(backendParams)
+
+	return backend, nil
+}
+
+func createEscalationsNotifyBackend(params notifyBackendParams) (*notifyescalations.Backend, error) {
+	delivery, err := createDelivery(params.client, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyescalations.BackendParams{
+		AppAPI:      params.appAPI,
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+
+	backend := notifyescalations.New(backendParams)
+
+	return backend, nil
+}
+
+func createAutoArchiveNotifyBackend(params notifyBackendParams) (*notifyautoarchive.Backend, error) {
+	delivery, err := createDelivery(params.client, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyautoarchive.BackendParams{
+		Delivery: delivery,
+		Logger:   params.logger,
+	}
+
+	backend := notifyautoarchive.New(backendParams)
+
+	return backend, nil
+}
+
+func createDormantNotifyBackend(params notifyBackendParams) (*notifydormant.Backend, error) {
+	delivery, err := createDelivery(params.client, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifydormant.BackendParams{
+		AppAPI:      params.appAPI,
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+
+	backend := notifydormant.New(backendParams)
+
+	return backend, nil
+}
+
+func createRemindersNotifyBackend(params notifyBackendParams) (*notifyreminders.Backend, error) {
+	delivery, err := createDelivery(params.client, params.serverRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	backendParams := notifyreminders.BackendParams{
+		Permissions: params.permissions,
+		Delivery:    delivery,
+		Logger:      params.logger,
+	}
+
+	backend := notifyreminders.New(backendParams)
+
+	return backend, nil
+}
+
 func createSubscriptionsNotifyBackend(params notifyBackendParams) (*notifysubscriptions.Backend, error) {
 	delivery, err := createDelivery(params.client, params.serverRoot)
 	if err != nil {
@@ -160,6 +253,10 @@ func (a *appAPI) GetUserByID(userID string) (*model.User, error) {
 	return a.store.GetUserByID(userID)
 }
 
+func (a *appAPI) GetUserAvailability(userID string) (*model.UserAvailability, error) {
+	return a.store.GetUserAvailability(userID)
+}
+
 func (a *appAPI) CreateSubscription(sub *model.Subscription) (*model.Subscription, error) {
 	return a.app.CreateSubscription(sub)
 }
@@ -180,6 +277,26 @@ func (a *appAPI) GetNextNotificationHint(remove bool) (*model.NotificationHint,
 	return a.store.GetNextNotificationHint(remove)
 }
 
+func (a *appAPI) SaveNotificationThread(thread *model.NotificationThread) error {
+	return a.store.SaveNotificationThread(thread)
+}
+
+func (a *appAPI) GetNotificationThread(cardID, channelID string) (*model.NotificationThread, error) {
+	return a.store.GetNotificationThread(cardID, channelID)
+}
+
+func (a *appAPI) CreateMention(mention *model.Mention) (*model.Mention, error) {
+	return a.store.CreateMention(mention)
+}
+
+func (a *appAPI) GetMentionsToRemind(createdBefore int64) ([]*model.Mention, error) {
+	return a.store.GetMentionsToRemind(createdBefore)
+}
+
+func (a *appAPI) MarkMentionsReminded(mentionIDs []string) error {
+	return a.store.MarkMentionsReminded(mentionIDs)
+}
+
 func (a *appAPI) GetMemberForBoard(boardID, userID string) (*model.BoardMember, error) {
 	return a.store.GetMemberForBoard(boardID, userID)
 }
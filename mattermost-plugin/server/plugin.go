@@ -33,9 +33,19 @@ const (
 	boardsFeatureFlagName = "BoardsFeatureFlags"
 	pluginName            = "focalboard"
 	sharedBoardsName      = "enablepublicsharedboards"
+	sharedBoardsCIDRsName = "sharedboardsallowedcidrs"
+	welcomeBoardName      = "enablewelcomeboard"
+	welcomeBoardTemplName = "welcomeboardtemplateid"
 
 	notifyFreqCardSecondsKey  = "notify_freq_card_seconds"
 	notifyFreqBoardSecondsKey = "notify_freq_board_seconds"
+	mentionReminderHoursKey   = "mention_reminder_hours"
+	dormantBoardDaysKey       = "dormant_board_days"
+	trashRetentionDaysKey     = "trash_retention_days"
+	cardLimitKey              = "card_limit"
+	viewLimitKey              = "view_limit"
+	attachmentStorageLimitKey = "attachment_storage_limit"
+	stripImageMetadataKey     = "strip_image_metadata"
 )
 
 type BoardsEmbed struct {
@@ -136,6 +146,36 @@ func (p *Plugin) OnActivate() error {
 	}
 	notifyBackends = append(notifyBackends, mentionsBackend)
 
+	assignmentsBackend, err := createAssignmentsNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating assignment notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, assignmentsBackend)
+
+	escalationsBackend, err := createEscalationsNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating escalation notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, escalationsBackend)
+
+	autoArchiveBackend, err := createAutoArchiveNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating auto-archive notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, autoArchiveBackend)
+
+	dormantBackend, err := createDormantNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating dormant board notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, dormantBackend)
+
+	remindersBackend, err := createRemindersNotifyBackend(backendParams)
+	if err != nil {
+		return fmt.Errorf("error creating card reminder notifications backend: %w", err)
+	}
+	notifyBackends = append(notifyBackends, remindersBackend)
+
 	subscriptionsBackend, err2 := createSubscriptionsNotifyBackend(backendParams)
 	if err2 != nil {
 		return fmt.Errorf("error creating subscription notifications backend: %w", err2)
@@ -143,6 +183,11 @@ func (p *Plugin) OnActivate() error {
 	notifyBackends = append(notifyBackends, subscriptionsBackend)
 	mentionsBackend.AddListener(subscriptionsBackend)
 
+	jobsLocker, err := cluster.NewMutex(p.API, "Boards_jobsScheduler")
+	if err != nil {
+		return fmt.Errorf("error creating the jobs scheduler mutex: %w", err)
+	}
+
 	params := server.Params{
 		Cfg:                cfg,
 		SingleUserToken:    "",
@@ -152,6 +197,7 @@ func (p *Plugin) OnActivate() error {
 		WSAdapter:          p.wsPluginAdapter,
 		NotifyBackends:     notifyBackends,
 		PermissionsService: permissionsService,
+		JobsLocker:         jobsLocker,
 	}
 
 	server, err := server.New(params)
@@ -229,9 +275,9 @@ func (p *Plugin) createBoardsConfig(mmconfig mmModel.Config, baseURL string, ser
 		FilesPath:                *mmconfig.FileSettings.Directory,
 		FilesS3Config:            filesS3Config,
 		MaxFileSize:              *mmconfig.FileSettings.MaxFileSize,
+		StripImageMetadata:       getPluginSettingBool(mmconfig, stripImageMetadataKey, true),
 		Telemetry:                enableTelemetry,
 		TelemetryID:              serverID,
-		WebhookUpdate:            []string{},
 		SessionExpireTime:        2592000,
 		SessionRefreshTime:       18000,
 		LocalOnly:                false,
@@ -239,9 +285,18 @@ func (p *Plugin) createBoardsConfig(mmconfig mmModel.Config, baseURL string, ser
 		LocalModeSocketLocation:  "",
 		AuthMode:                 "mattermost",
 		EnablePublicSharedBoards: enablePublicSharedBoards,
+		SharedBoardsAllowedCIDRs: getPluginSettingList(mmconfig, sharedBoardsCIDRsName),
+		EnableWelcomeBoard:       getPluginSettingBool(mmconfig, welcomeBoardName, false),
+		WelcomeBoardTemplateID:   getPluginSettingString(mmconfig, welcomeBoardTemplName, ""),
 		FeatureFlags:             featureFlags,
 		NotifyFreqCardSeconds:    getPluginSettingInt(mmconfig, notifyFreqCardSecondsKey, 120),
 		NotifyFreqBoardSeconds:   getPluginSettingInt(mmconfig, notifyFreqBoardSecondsKey, 86400),
+		MentionReminderHours:     getPluginSettingInt(mmconfig, mentionReminderHoursKey, 24),
+		DormantBoardDays:         getPluginSettingInt(mmconfig, dormantBoardDaysKey, 90),
+		TrashRetentionDays:       getPluginSettingInt(mmconfig, trashRetentionDaysKey, 30),
+		CardLimit:                getPluginSettingInt(mmconfig, cardLimitKey, 0),
+		ViewLimit:                getPluginSettingInt(mmconfig, viewLimitKey, 0),
+		AttachmentStorageLimit:   int64(getPluginSettingInt(mmconfig, attachmentStorageLimitKey, 0)),
 		EnableDataRetention:      enableBoardsDeletion,
 		DataRetentionDays:        *mmconfig.DataRetentionSettings.BoardsRetentionDays,
 	}
@@ -272,6 +327,50 @@ func getPluginSettingInt(mmConfig mmModel.Config, key string, def int) int {
 	return int(math.Round(valFloat))
 }
 
+func getPluginSettingBool(mmConfig mmModel.Config, key string, def bool) bool {
+	val, ok := getPluginSetting(mmConfig, key)
+	if !ok {
+		return def
+	}
+	valBool, ok := val.(bool)
+	if !ok {
+		return def
+	}
+	return valBool
+}
+
+func getPluginSettingString(mmConfig mmModel.Config, key string, def string) string {
+	val, ok := getPluginSetting(mmConfig, key)
+	if !ok {
+		return def
+	}
+	valString, ok := val.(string)
+	if !ok {
+		return def
+	}
+	return strings.TrimSpace(valString)
+}
+
+// getPluginSettingList returns the values of a comma separated setting,
+// without the empty ones.
+func getPluginSettingList(mmConfig mmModel.Config, key string) []string {
+	values := []string{}
+	val, ok := getPluginSetting(mmConfig, key)
+	if !ok {
+		return values
+	}
+	valString, ok := val.(string)
+	if !ok {
+		return values
+	}
+	for _, value := range strings.Split(valString, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func parseFeatureFlags(configFeatureFlags map[string]string) map[string]string {
 	featureFlags := make(map[string]string)
 	for key, value := range configFeatureFlags {
@@ -354,6 +453,34 @@ func defaultLoggingConfig() string {
 	}`
 }
 
+// UserHasBeenCreated provisions the welcome board of the new user, in their
+// first team. Users created without a team get it when they join one.
+func (p *Plugin) UserHasBeenCreated(_ *plugin.Context, user *mmModel.User) {
+	teamID := ""
+	teams, appErr := p.API.GetTeamsForUser(user.Id)
+	if appErr != nil {
+		p.server.Logger().Error("Unable to get the teams of the new user", mlog.String("userID", user.Id), mlog.Err(appErr))
+	} else if len(teams) > 0 {
+		teamID = teams[0].Id
+	}
+
+	if _, err := p.server.App().ProvisionWelcomeBoard(user.Id, teamID); err != nil {
+		p.server.Logger().Error("Unable to provision the welcome board of the new user", mlog.String("userID", user.Id), mlog.Err(err))
+	}
+}
+
+// UserHasJoinedTeam provisions the welcome board of the users who had no
+// team when they were created.
+func (p *Plugin) UserHasJoinedTeam(_ *plugin.Context, teamMember *mmModel.TeamMember, _ *mmModel.User) {
+	if _, err := p.server.App().ProvisionPendingWelcomeBoard(teamMember.UserId, teamMember.TeamId); err != nil {
+		p.server.Logger().Error("Unable to provision the welcome board of the user joining the team",
+			mlog.String("userID", teamMember.UserId),
+			mlog.String("teamID", teamMember.TeamId),
+			mlog.Err(err),
+		)
+	}
+}
+
 func (p *Plugin) MessageWillBePosted(_ *plugin.Context, post *mmModel.Post) (*mmModel.Post, string) {
 	return postWithBoardsEmbed(post), ""
 }
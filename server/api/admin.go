@@ -42,13 +42,13 @@ func (a *API) handleAdminSetPassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = a.app.UpdateUserPassword(username, requestData.Password)
+	err = a.requestApp(r).UpdateUserPassword(username, requestData.Password)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("AdminSetPassword, username: %s", mlog.String("username", username))
+	a.requestLogger(r).Debug("AdminSetPassword, username: %s", mlog.String("username", username))
 
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
@@ -17,7 +17,9 @@ import (
 	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/auth"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/ratelimit"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -26,6 +28,11 @@ import (
 const (
 	HeaderRequestedWith    = "X-Requested-With"
 	HeaderRequestedWithXML = "XMLHttpRequest"
+	HeaderTotalCount       = "X-Total-Count"
+	HeaderNextAfterID      = "X-Next-After-Id"
+	HeaderEtagServer       = "ETag"
+	HeaderEtagClient       = "If-None-Match"
+	HeaderRequestID        = "X-Request-ID"
 	UploadFormFileKey      = "file"
 )
 
@@ -53,6 +60,8 @@ type API struct {
 	MattermostAuth  bool
 	logger          *mlog.Logger
 	audit           *audit.Audit
+	requestLimiter  ratelimit.Store
+	loginThrottle   *loginThrottle
 }
 
 func NewAPI(app *app.App, singleUserToken string, authService string, permissions permissions.PermissionsService,
@@ -64,17 +73,28 @@ func NewAPI(app *app.App, singleUserToken string, authService string, permission
 		permissions:     permissions,
 		logger:          logger,
 		audit:           audit,
+		requestLimiter:  newRequestLimiter(app.GetConfig()),
+		loginThrottle:   newLoginThrottle(),
 	}
 }
 
 func (a *API) RegisterRoutes(r *mux.Router) {
+	r.Use(a.requestIDHandler)
+	r.Use(a.embedFrameHeaders)
+
 	apiv2 := r.PathPrefix("/api/v2").Subrouter()
 	apiv2.Use(a.panicHandler)
+	apiv2.Use(a.rateLimitByIP)
 	apiv2.Use(a.requireCSRFToken)
 
 	// Board APIs
 	apiv2.HandleFunc("/teams/{teamID}/boards", a.sessionRequired(a.handleGetBoards)).Methods("GET")
 	apiv2.HandleFunc("/teams/{teamID}/boards/search", a.sessionRequired(a.handleSearchBoards)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/search", a.sessionRequired(a.handleSearch)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/cards/search", a.sessionRequired(a.handleSearchCards)).Methods("GET")
+	apiv2.HandleFunc("/graphql", a.sessionRequired(a.handleGraphQL)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/boards/changes", a.sessionRequired(a.handleGetBoardChanges)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/boards/dormant", a.sessionRequired(a.handleGetDormantBoards)).Methods("GET")
 	apiv2.HandleFunc("/teams/{teamID}/templates", a.sessionRequired(a.handleGetTemplates)).Methods("GET")
 	apiv2.HandleFunc("/boards", a.sessionRequired(a.handleCreateBoard)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}", a.attachSession(a.handleGetBoard, false)).Methods("GET")
@@ -85,11 +105,17 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2.HandleFunc("/boards/{boardID}/blocks", a.attachSession(a.handleGetBlocks, false)).Methods("GET")
 	apiv2.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(a.handlePostBlocks)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/blocks", a.sessionRequired(a.handlePatchBlocks)).Methods("PATCH")
+	apiv2.HandleFunc("/teams/{teamID}/blocks/patch", a.sessionRequired(a.handlePatchBlocks)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handleDeleteBlock)).Methods("DELETE")
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}", a.sessionRequired(a.handlePatchBlock)).Methods("PATCH")
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/undelete", a.sessionRequired(a.handleUndeleteBlock)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/history", a.sessionRequired(a.handleGetBlockHistory)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/history/{version}", a.sessionRequired(a.handleGetBlockVersion)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/history/{version}/restore", a.sessionRequired(a.handleRestoreBlockVersion)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/blocks/{blockID}/duplicate", a.sessionRequired(a.handleDuplicateBlock)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/metadata", a.sessionRequired(a.handleGetBoardMetadata)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/presence", a.sessionRequired(a.handleGetBoardPresence)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/limits_exempt", a.recentSessionRequired(a.handlePatchBoardLimitsExempt)).Methods("PATCH")
 
 	// Member APIs
 	apiv2.HandleFunc("/boards/{boardID}/members", a.sessionRequired(a.handleGetMembersForBoard)).Methods("GET")
@@ -99,6 +125,96 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2.HandleFunc("/boards/{boardID}/join", a.sessionRequired(a.handleJoinBoard)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/leave", a.sessionRequired(a.handleLeaveBoard)).Methods("POST")
 
+	// Card property APIs
+	apiv2.HandleFunc("/boards/{boardID}/properties", a.sessionRequired(a.handleGetCardProperties)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/properties", a.sessionRequired(a.handleAddCardProperty)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/properties/order", a.sessionRequired(a.handleReorderCardProperties)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/properties/usage", a.sessionRequired(a.handleGetCardPropertyOptionUsage)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/properties/{propertyID}", a.sessionRequired(a.handlePatchCardProperty)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/properties/{propertyID}", a.sessionRequired(a.handleDeleteCardProperty)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/properties/{propertyID}/aggregate", a.sessionRequired(a.handleGetCardPropertyAggregates)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/properties/{propertyID}/options", a.sessionRequired(a.handleAddCardPropertyOption)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/properties/{propertyID}/options/{optionID}", a.sessionRequired(a.handlePatchCardPropertyOption)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/properties/{propertyID}/options/{optionID}", a.sessionRequired(a.handleDeleteCardPropertyOption)).Methods("DELETE")
+
+	// Card template APIs
+	apiv2.HandleFunc("/boards/{boardID}/card-templates", a.sessionRequired(a.handleGetCardTemplates)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/card-templates", a.sessionRequired(a.handleCreateCardTemplate)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/card-templates/{templateID}", a.sessionRequired(a.handlePatchCardTemplate)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/card-templates/{templateID}", a.sessionRequired(a.handleDeleteCardTemplate)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/card-templates/{templateID}/cards", a.sessionRequired(a.handleCreateCardFromTemplate)).Methods("POST")
+
+	// Card archive APIs
+	apiv2.HandleFunc("/boards/{boardID}/cards/archived", a.sessionRequired(a.handleGetArchivedCards)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/archive", a.sessionRequired(a.handleArchiveCard)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/unarchive", a.sessionRequired(a.handleUnarchiveCard)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/shift_dependents", a.sessionRequired(a.handleShiftDependentCardDates)).Methods("POST")
+
+	// Card APIs
+	apiv2.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleGetCards)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/cards", a.sessionRequired(a.handleCreateCard)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}", a.sessionRequired(a.handleGetCard)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}", a.sessionRequired(a.handlePatchCard)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}", a.sessionRequired(a.handleDeleteCard)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/milestone/{milestoneID}", a.sessionRequired(a.handleSetCardMilestone)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/milestone", a.sessionRequired(a.handleDeleteCardMilestone)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/reminders", a.sessionRequired(a.handleCreateCardReminder)).Methods("POST")
+
+	// Comment APIs
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/comments", a.sessionRequired(a.handleGetComments)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/comments", a.sessionRequired(a.handleAddComment)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/comments/{commentID}", a.sessionRequired(a.handleEditComment)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/comments/{commentID}", a.sessionRequired(a.handleDeleteComment)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/cards/{cardID}/images", a.sessionRequired(a.handlePasteImage)).Methods("POST")
+
+	// Anonymous submission APIs
+	apiv2.HandleFunc("/boards/{boardID}/submissions", a.attachSession(a.handleSubmitCard, false)).Methods("POST")
+
+	// Notification settings APIs
+	apiv2.HandleFunc("/boards/{boardID}/mute", a.sessionRequired(a.handleMuteBoard)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/mute", a.sessionRequired(a.handleUnmuteBoard)).Methods("DELETE")
+
+	// Escalation policy APIs
+	apiv2.HandleFunc("/boards/{boardID}/escalations", a.sessionRequired(a.handleGetEscalationPolicies)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/escalations", a.sessionRequired(a.handleCreateEscalationPolicy)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/escalations/{policyID}", a.sessionRequired(a.handleUpdateEscalationPolicy)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/escalations/{policyID}", a.sessionRequired(a.handleDeleteEscalationPolicy)).Methods("DELETE")
+
+	// Auto-archive APIs
+	apiv2.HandleFunc("/boards/{boardID}/auto_archive", a.sessionRequired(a.handleGetAutoArchiveRule)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/auto_archive", a.sessionRequired(a.handleSetAutoArchiveRule)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/auto_archive", a.sessionRequired(a.handleDeleteAutoArchiveRule)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/appearance", a.sessionRequired(a.handleGetBoardAppearance)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/appearance", a.sessionRequired(a.handleSetBoardAppearance)).Methods("PUT")
+	apiv2.HandleFunc("/boards/{boardID}/formatting", a.sessionRequired(a.handleGetBoardFormatting)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/formatting", a.sessionRequired(a.handleSetBoardFormatting)).Methods("PUT")
+
+	// Board view APIs
+	apiv2.HandleFunc("/boards/{boardID}/view", a.sessionRequired(a.handleMarkBoardViewed)).Methods("POST")
+
+	// Sync APIs
+	apiv2.HandleFunc("/boards/{boardID}/sync", a.sessionRequired(a.handleSyncBoard)).Methods("POST")
+
+	// Board diff APIs
+	apiv2.HandleFunc("/boards/{boardID}/diff", a.sessionRequired(a.handleGetBoardDiff)).Methods("GET")
+
+	// Board activity APIs
+	apiv2.HandleFunc("/boards/{boardID}/activity", a.sessionRequired(a.handleGetBoardActivity)).Methods("GET")
+
+	// Embed APIs
+	apiv2.HandleFunc("/boards/{boardID}/embeds", a.sessionRequired(a.handleGetBoardEmbeds)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/embeds", a.sessionRequired(a.handleCreateBoardEmbed)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/embeds/{embedID}", a.sessionRequired(a.handleDeleteBoardEmbed)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/embeds/{embedID}/content", a.attachSession(a.handleGetEmbedContent, false)).Methods("GET")
+
+	// Intake form APIs
+	apiv2.HandleFunc("/boards/{boardID}/forms", a.sessionRequired(a.handleGetIntakeForms)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/forms", a.sessionRequired(a.handleCreateIntakeForm)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/forms/{formID}", a.sessionRequired(a.handlePatchIntakeForm)).Methods("PATCH")
+	apiv2.HandleFunc("/boards/{boardID}/forms/{formID}", a.sessionRequired(a.handleDeleteIntakeForm)).Methods("DELETE")
+	apiv2.HandleFunc("/boards/{boardID}/forms/{formID}/public", a.attachSession(a.handleGetPublicIntakeForm, false)).Methods("GET")
+	apiv2.HandleFunc("/boards/{boardID}/forms/{formID}/submit", a.attachSession(a.handleSubmitIntakeForm, false)).Methods("POST")
+
 	// Sharing APIs
 	apiv2.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handlePostSharing)).Methods("POST")
 	apiv2.HandleFunc("/boards/{boardID}/sharing", a.sessionRequired(a.handleGetSharing)).Methods("GET")
@@ -108,15 +224,63 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2.HandleFunc("/teams/{teamID}", a.sessionRequired(a.handleGetTeam)).Methods("GET")
 	apiv2.HandleFunc("/teams/{teamID}/regenerate_signup_token", a.sessionRequired(a.handlePostTeamRegenerateSignupToken)).Methods("POST")
 	apiv2.HandleFunc("/teams/{teamID}/users", a.sessionRequired(a.handleGetTeamUsers)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/users/directory", a.sessionRequired(a.handleSearchUserDirectory)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/usage", a.sessionRequired(a.handleGetTeamUsage)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/feature_flags", a.sessionRequired(a.handleGetTeamFeatureFlags)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/feature_flags", a.recentSessionRequired(a.handleUpdateTeamFeatureFlags)).Methods("PUT")
+	apiv2.HandleFunc("/teams/{teamID}/upload_restrictions", a.sessionRequired(a.handleGetTeamUploadRestrictions)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/upload_restrictions", a.recentSessionRequired(a.handleUpdateTeamUploadRestrictions)).Methods("PUT")
+	apiv2.HandleFunc("/teams/{teamID}/board_policy", a.sessionRequired(a.handleGetTeamBoardPolicy)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/board_policy", a.recentSessionRequired(a.handleUpdateTeamBoardPolicy)).Methods("PUT")
+
+	// Invite APIs
+	apiv2.HandleFunc("/teams/{teamID}/invites", a.sessionRequired(a.handleGetInvites)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/invites", a.recentSessionRequired(a.handleCreateInvite)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/invites/{inviteID}", a.recentSessionRequired(a.handleDeleteInvite)).Methods("DELETE")
+
+	// Webhook APIs
+	apiv2.HandleFunc("/teams/{teamID}/webhooks", a.sessionRequired(a.handleGetWebhooks)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/webhooks", a.recentSessionRequired(a.handleCreateWebhook)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/webhooks/{webhookID}", a.recentSessionRequired(a.handleUpdateWebhook)).Methods("PUT")
+	apiv2.HandleFunc("/teams/{teamID}/webhooks/{webhookID}", a.recentSessionRequired(a.handleDeleteWebhook)).Methods("DELETE")
 	apiv2.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeam)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/archive/export", a.sessionRequired(a.handleArchiveExportTeamBoards)).Methods("POST")
 	apiv2.HandleFunc("/teams/{teamID}/{boardID}/files", a.sessionRequired(a.handleUploadFile)).Methods("POST")
 
+	// Milestone APIs
+	apiv2.HandleFunc("/teams/{teamID}/milestones", a.sessionRequired(a.handleGetMilestones)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/milestones", a.sessionRequired(a.handleCreateMilestone)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/milestones/progress", a.sessionRequired(a.handleGetMilestonesProgress)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/milestones/{milestoneID}", a.sessionRequired(a.handleUpdateMilestone)).Methods("PUT")
+	apiv2.HandleFunc("/teams/{teamID}/milestones/{milestoneID}", a.sessionRequired(a.handleDeleteMilestone)).Methods("DELETE")
+
+	// Portfolio APIs
+	apiv2.HandleFunc("/teams/{teamID}/portfolio", a.sessionRequired(a.handleGetBoardsPortfolio)).Methods("GET")
+
+	// Trash APIs
+	apiv2.HandleFunc("/teams/{teamID}/trash", a.sessionRequired(a.handleGetTeamTrash)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/trash/boards/{boardID}/restore", a.sessionRequired(a.handleRestoreTrashBoard)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/trash/boards/{boardID}", a.recentSessionRequired(a.handlePurgeTrashBoard)).Methods("DELETE")
+	apiv2.HandleFunc("/teams/{teamID}/trash/cards/{cardID}/restore", a.sessionRequired(a.handleRestoreTrashCard)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/trash/cards/{cardID}", a.recentSessionRequired(a.handlePurgeTrashCard)).Methods("DELETE")
+
 	// User APIs
 	apiv2.HandleFunc("/users/me", a.sessionRequired(a.handleGetMe)).Methods("GET")
 	apiv2.HandleFunc("/users/me/memberships", a.sessionRequired(a.handleGetMyMemberships)).Methods("GET")
+	apiv2.HandleFunc("/users/me/notifications", a.sessionRequired(a.handleGetMyNotificationSettings)).Methods("GET")
+	apiv2.HandleFunc("/users/me/notifications", a.sessionRequired(a.handleUpdateMyNotificationSettings)).Methods("PUT")
+	apiv2.HandleFunc("/users/me/mentions", a.sessionRequired(a.handleGetMyUnreadMentions)).Methods("GET")
+	apiv2.HandleFunc("/users/me/mentions/read", a.sessionRequired(a.handleMarkMyMentionsRead)).Methods("POST")
+	apiv2.HandleFunc("/users/me/reminders", a.sessionRequired(a.handleGetMyCardReminders)).Methods("GET")
+	apiv2.HandleFunc("/users/me/reminders/{reminderID}/snooze", a.sessionRequired(a.handleSnoozeCardReminder)).Methods("POST")
+	apiv2.HandleFunc("/users/me/reminders/{reminderID}", a.sessionRequired(a.handleCancelCardReminder)).Methods("DELETE")
+	apiv2.HandleFunc("/users/me/verify_email", a.sessionRequired(a.handleSendEmailVerification)).Methods("POST")
+	apiv2.HandleFunc("/users/me/avatar", a.sessionRequired(a.handleSetMyAvatar)).Methods("POST")
+	apiv2.HandleFunc("/users/me/avatar", a.sessionRequired(a.handleDeleteMyAvatar)).Methods("DELETE")
 	apiv2.HandleFunc("/users/{userID}", a.sessionRequired(a.handleGetUser)).Methods("GET")
-	apiv2.HandleFunc("/users/{userID}/changepassword", a.sessionRequired(a.handleChangePassword)).Methods("POST")
+	apiv2.HandleFunc("/users/{userID}/changepassword", a.recentSessionRequired(a.handleChangePassword)).Methods("POST")
 	apiv2.HandleFunc("/users/{userID}/config", a.sessionRequired(a.handleUpdateUserConfig)).Methods(http.MethodPut)
+	apiv2.HandleFunc("/users/{userID}/avatar", a.attachSession(a.handleGetUserAvatar, false)).Methods("GET")
 
 	// BoardsAndBlocks APIs
 	apiv2.HandleFunc("/boards-and-blocks", a.sessionRequired(a.handleCreateBoardsAndBlocks)).Methods("POST")
@@ -127,7 +291,11 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	apiv2.HandleFunc("/login", a.handleLogin).Methods("POST")
 	apiv2.HandleFunc("/logout", a.sessionRequired(a.handleLogout)).Methods("POST")
 	apiv2.HandleFunc("/register", a.handleRegister).Methods("POST")
-	apiv2.HandleFunc("/clientConfig", a.getClientConfig).Methods("GET")
+	apiv2.HandleFunc("/password_reset", a.handleRequestPasswordReset).Methods("POST")
+	apiv2.HandleFunc("/password_reset/complete", a.handleCompletePasswordReset).Methods("POST")
+	apiv2.HandleFunc("/verify_email", a.handleVerifyEmail).Methods("POST")
+	apiv2.HandleFunc("/clientConfig", a.attachSession(a.getClientConfig, false)).Methods("GET")
+	apiv2.HandleFunc("/openapi.json", a.handleGetOpenAPISpec).Methods("GET")
 
 	// Category APIs
 	apiv2.HandleFunc("/teams/{teamID}/categories", a.sessionRequired(a.handleCreateCategory)).Methods(http.MethodPost)
@@ -152,13 +320,32 @@ func (a *API) RegisterRoutes(r *mux.Router) {
 	// Archive APIs
 	apiv2.HandleFunc("/boards/{boardID}/archive/export", a.sessionRequired(a.handleArchiveExportBoard)).Methods("GET")
 	apiv2.HandleFunc("/teams/{teamID}/archive/import", a.sessionRequired(a.handleArchiveImport)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/migration/export", a.recentSessionRequired(a.handleExportTeamMigration)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/migration/import", a.recentSessionRequired(a.handleImportTeamMigration)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/export", a.sessionRequired(a.handleExportBoardView)).Methods("GET")
+	apiv2.HandleFunc("/teams/{teamID}/import/trello", a.sessionRequired(a.handleImportTrello)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/import/notion", a.sessionRequired(a.handleImportNotion)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/import/jira/preview", a.sessionRequired(a.handlePreviewImportJira)).Methods("POST")
+	apiv2.HandleFunc("/teams/{teamID}/import/jira", a.sessionRequired(a.handleImportJira)).Methods("POST")
+	apiv2.HandleFunc("/boards/{boardID}/import/csv", a.sessionRequired(a.handleImportCSV)).Methods("POST")
+
+	// Jobs APIs
+	apiv2.HandleFunc("/jobs/{jobID}", a.sessionRequired(a.handleGetJob)).Methods("GET")
+	apiv2.HandleFunc("/jobs/{jobID}/file", a.sessionRequired(a.handleGetJobFile)).Methods("GET")
 
 	// System APIs
 	r.HandleFunc("/hello", a.handleHello).Methods("GET")
 }
 
 func (a *API) RegisterAdminRoutes(r *mux.Router) {
+	r.Use(a.requestIDHandler)
+
 	r.HandleFunc("/api/v2/admin/users/{username}/password", a.adminRequired(a.handleAdminSetPassword)).Methods("POST")
+	r.HandleFunc("/api/v2/admin/jobs", a.adminRequired(a.handleAdminGetJobs)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/jobs/{jobID}", a.adminRequired(a.handleAdminGetJob)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/backups", a.adminRequired(a.handleAdminGetBackups)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/backups/{teamID}/{name}", a.adminRequired(a.handleAdminGetBackupFile)).Methods("GET")
+	r.HandleFunc("/api/v2/admin/search/reindex", a.adminRequired(a.handleAdminReindexSearch)).Methods("POST")
 }
 
 func getUserID(r *http.Request) string {
@@ -174,7 +361,7 @@ func (a *API) panicHandler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if p := recover(); p != nil {
-				a.logger.Error("Http handler panic",
+				a.requestLogger(r).Error("Http handler panic",
 					mlog.Any("panic", p),
 					mlog.String("stack", string(debug.Stack())),
 					mlog.String("uri", r.URL.Path),
@@ -190,7 +377,7 @@ func (a *API) panicHandler(next http.Handler) http.Handler {
 func (a *API) requireCSRFToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !a.checkCSRFToken(r) {
-			a.logger.Error("checkCSRFToken FAILED")
+			a.requestLogger(r).Error("checkCSRFToken FAILED")
 			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "checkCSRFToken FAILED", nil)
 			return
 		}
@@ -202,11 +389,18 @@ func (a *API) requireCSRFToken(next http.Handler) http.Handler {
 func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /clientConfig getClientConfig
 	//
-	// Returns the client configuration
+	// Returns the client configuration. The feature flags are the ones of
+	// the given team, for its members
 	//
 	// ---
 	// produces:
 	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: query
+	//   description: ID of the team to get the feature flags of
+	//   required: false
+	//   type: string
 	// responses:
 	//   '200':
 	//     description: success
@@ -217,8 +411,27 @@ func (a *API) getClientConfig(w http.ResponseWriter, r *http.Request) {
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 
-	clientConfig := a.app.GetClientConfig()
+	teamID := r.URL.Query().Get("teamID")
+	if teamID == "" {
+		a.writeClientConfig(w, r, a.requestApp(r).GetClientConfig())
+		return
+	}
+
+	userID := getUserID(r)
+	if userID == "" || !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	clientConfig, err := a.requestApp(r).GetClientConfigForTeam(teamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	a.writeClientConfig(w, r, clientConfig)
+}
 
+func (a *API) writeClientConfig(w http.ResponseWriter, r *http.Request, clientConfig *model.ClientConfig) {
 	configData, err := json.Marshal(clientConfig)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
@@ -240,13 +453,29 @@ func (a *API) hasValidReadTokenForBoard(r *http.Request, boardID string) bool {
 		return false
 	}
 
-	isValid, err := a.app.IsValidReadToken(boardID, readToken)
+	isValid, err := a.requestApp(r).IsValidReadToken(boardID, readToken)
+	if err != nil {
+		a.requestLogger(r).Error("IsValidReadTokenForBoard ERROR", mlog.Err(err))
+		return false
+	}
+	if !isValid {
+		return false
+	}
+
+	remoteIP := a.getClientIP(r)
+	isAllowed, err := a.requestApp(r).IsSharedBoardAddressAllowed(boardID, remoteIP)
 	if err != nil {
-		a.logger.Error("IsValidReadTokenForBoard ERROR", mlog.Err(err))
+		a.requestLogger(r).Error("IsSharedBoardAddressAllowed ERROR", mlog.Err(err))
 		return false
 	}
+	if !isAllowed {
+		a.requestLogger(r).Debug("Read token used from an address not allowed",
+			mlog.String("boardID", boardID),
+			mlog.String("remoteIP", remoteIP),
+		)
+	}
 
-	return isValid
+	return isAllowed
 }
 
 func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
@@ -273,15 +502,59 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	//   description: Type of blocks to return, omit to specify all types
 	//   required: false
 	//   type: string
+	// - name: near
+	//   in: query
+	//   description: Only return cards with a location within radius of this point, as "lat,lng"
+	//   required: false
+	//   type: string
+	// - name: radius
+	//   in: query
+	//   description: Radius in meters around the near point, required with near
+	//   required: false
+	//   type: number
+	// - name: bbox
+	//   in: query
+	//   description: Only return cards with a location inside this bounding box, as "swLat,swLng,neLat,neLng"
+	//   required: false
+	//   type: string
+	// - name: location_property
+	//   in: query
+	//   description: ID of the location property used by near and bbox, omit to match any location property
+	//   required: false
+	//   type: string
+	// - name: include_archived
+	//   in: query
+	//   description: Also return the archived cards and their content, which are left out by default
+	//   required: false
+	//   type: boolean
+	// - name: limit
+	//   in: query
+	//   description: Return a page of at most this many blocks sorted by id, omit to return all the blocks
+	//   required: false
+	//   type: integer
+	// - name: after_id
+	//   in: query
+	//   description: Only return the blocks with an id greater than this one, used with limit to get the next page
+	//   required: false
+	//   type: string
+	// - name: If-None-Match
+	//   in: header
+	//   description: The ETag of a previous response, to get a 304 if the blocks didn't change since
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, with the X-Total-Count header set to the number of blocks over all the pages and the X-Next-After-Id header set to the after_id of the next page if there is one, when limit is given
 	//     schema:
 	//       type: array
 	//       items:
 	//         "$ref": "#/definitions/Block"
+	//   '304':
+	//     description: the blocks didn't change since the response with the ETag of the If-None-Match header
+	//   '400':
+	//     description: invalid location filter or limit
 	//   '404':
 	//     description: board not found
 	//   default:
@@ -296,6 +569,22 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	blockID := query.Get("block_id")
 	boardID := mux.Vars(r)["boardID"]
 
+	locationFilter, err := parseCardLocationFilter(query)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	var limit uint64
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err = strconv.ParseUint(limitParam, 10, 64)
+		if err != nil || limit == 0 || limit > model.MaxBlocksPageLimit {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+	}
+	afterID := query.Get("after_id")
+
 	userID := getUserID(r)
 
 	hasValidReadToken := a.hasValidReadTokenForBoard(r, boardID)
@@ -304,7 +593,7 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -336,17 +625,52 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("all", all)
 	auditRec.AddMeta("blockID", blockID)
 
+	etag, err := a.requestApp(r).GetBlocksETag(board, userID, r.URL.RawQuery)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if checkNotModified(w, r, etag) {
+		auditRec.AddMeta("notModified", true)
+		auditRec.Success()
+		return
+	}
+
+	includeArchived := query.Get("include_archived") == "true"
+	paged := limit != 0 && blockID == ""
+
 	var blocks []model.Block
 	var block *model.Block
 	switch {
+	case paged:
+		// the blocks the user can't see are left out in the store, so that
+		// the pages are full and the total only counts the visible blocks
+		var hiddenIDs []string
+		hiddenIDs, err = a.requestApp(r).GetHiddenBlockIDs(board, userID, includeArchived)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		var total int64
+		blocks, total, err = a.requestApp(r).GetBlocksPage(boardID, parentID, blockType, all != "", hiddenIDs, afterID, limit)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		w.Header().Set(HeaderTotalCount, strconv.FormatInt(total, 10))
+		if uint64(len(blocks)) == limit {
+			w.Header().Set(HeaderNextAfterID, blocks[len(blocks)-1].ID)
+		}
+		auditRec.AddMeta("limit", limit)
+		auditRec.AddMeta("afterID", afterID)
 	case all != "":
-		blocks, err = a.app.GetBlocksForBoard(boardID)
+		blocks, err = a.requestApp(r).GetBlocksForBoard(boardID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
 		}
 	case blockID != "":
-		block, err = a.app.GetBlockByID(blockID)
+		block, err = a.requestApp(r).GetBlockByID(blockID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
@@ -360,14 +684,36 @@ func (a *API) handleGetBlocks(w http.ResponseWriter, r *http.Request) {
 			blocks = append(blocks, *block)
 		}
 	default:
-		blocks, err = a.app.GetBlocks(boardID, parentID, blockType)
+		blocks, err = a.requestApp(r).GetBlocks(boardID, parentID, blockType)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+	}
+
+	if !paged {
+		blocks, err = a.requestApp(r).FilterRestrictedBlocks(board, blocks, userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+
+		// a block requested by id is returned even if it's an archived card
+		if blockID == "" && !includeArchived {
+			blocks = model.FilterArchivedCards(blocks)
+		}
+	}
+
+	if locationFilter != nil {
+		blocks, err = a.requestApp(r).FilterCardsByLocation(board, blocks, locationFilter)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
 		}
+		auditRec.AddMeta("locationFilter", true)
 	}
 
-	a.logger.Debug("GetBlocks",
+	a.requestLogger(r).Debug("GetBlocks",
 		mlog.String("boardID", boardID),
 		mlog.String("parentID", parentID),
 		mlog.String("blockType", blockType),
@@ -465,7 +811,7 @@ func (a *API) handleCreateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createdCategory, err := a.app.CreateCategory(&category)
+	createdCategory, err := a.requestApp(r).CreateCategory(&category)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -564,7 +910,7 @@ func (a *API) handleUpdateCategory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedCategory, err := a.app.UpdateCategory(&category)
+	updatedCategory, err := a.requestApp(r).UpdateCategory(&category)
 	if err != nil {
 		switch {
 		case errors.Is(err, app.ErrorCategoryDeleted):
@@ -629,7 +975,7 @@ func (a *API) handleDeleteCategory(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "deleteCategory", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
-	deletedCategory, err := a.app.DeleteCategory(categoryID, userID, teamID)
+	deletedCategory, err := a.requestApp(r).DeleteCategory(categoryID, userID, teamID)
 	if err != nil {
 		switch {
 		case errors.Is(err, app.ErrorInvalidCategory):
@@ -692,7 +1038,7 @@ func (a *API) handleGetUserCategoryBoards(w http.ResponseWriter, r *http.Request
 	auditRec := a.makeAuditRecord(r, "getUserCategoryBoards", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 
-	categoryBlocks, err := a.app.GetUserCategoryBoards(userID, teamID)
+	categoryBlocks, err := a.requestApp(r).GetUserCategoryBoards(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -755,7 +1101,7 @@ func (a *API) handleUpdateCategoryBoard(w http.ResponseWriter, r *http.Request)
 	userID := session.UserID
 
 	// TODO: Check the category and the team matches
-	err := a.app.AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID)
+	err := a.requestApp(r).AddUpdateUserCategoryBoard(teamID, userID, categoryID, boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -798,6 +1144,10 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	//       items:
 	//         $ref: '#/definitions/Block'
 	//       type: array
+	//   '413':
+	//     description: the board has reached its card limit
+	//     schema:
+	//       "$ref": "#/definitions/CardLimitReachedResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -852,6 +1202,10 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, message, nil)
 			return
 		}
+
+		if !a.checkRestrictedView(w, r, userID, &block, nil) {
+			return
+		}
 	}
 
 	blocks = model.GenerateBlockIDs(blocks, a.logger)
@@ -867,19 +1221,22 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 	// this query param exists when creating template from board, or board from template
 	sourceBoardID := r.URL.Query().Get("sourceBoardID")
 	if sourceBoardID != "" {
-		if updateFileIDsErr := a.app.CopyCardFiles(sourceBoardID, blocks); updateFileIDsErr != nil {
+		if updateFileIDsErr := a.requestApp(r).CopyCardFiles(sourceBoardID, blocks); updateFileIDsErr != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", updateFileIDsErr)
 			return
 		}
 	}
 
-	newBlocks, err := a.app.InsertBlocks(blocks, session.UserID, true)
+	newBlocks, err := a.requestApp(r).InsertBlocks(blocks, session.UserID, true)
 	if err != nil {
+		if a.cardValidationErrorResponse(w, r, err) {
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("POST Blocks", mlog.Int("block_count", len(blocks)))
+	a.requestLogger(r).Debug("POST Blocks", mlog.Int("block_count", len(blocks)))
 
 	json, err := json.Marshal(newBlocks)
 	if err != nil {
@@ -894,7 +1251,7 @@ func (a *API) handlePostBlocks(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *API) handleUpdateUserConfig(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation PATCH /users/{userID}/config updateUserConfig
+	// swagger:operation PUT /users/{userID}/config updateUserConfig
 	//
 	// Updates user config
 	//
@@ -951,7 +1308,7 @@ func (a *API) handleUpdateUserConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedConfig, err := a.app.UpdateUserConfig(userID, *patch)
+	updatedConfig, err := a.requestApp(r).UpdateUserConfig(userID, *patch)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1000,7 +1357,7 @@ func (a *API) handleGetUser(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("userID", userID)
 
-	user, err := a.app.GetUser(userID)
+	user, err := a.requestApp(r).GetUser(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1045,7 +1402,7 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
 	if userID == model.SingleUser {
-		ws, _ := a.app.GetRootTeam()
+		ws, _ := a.requestApp(r).GetRootTeam()
 		now := utils.GetMillis()
 		user = &model.User{
 			ID:       model.SingleUser,
@@ -1055,7 +1412,7 @@ func (a *API) handleGetMe(w http.ResponseWriter, r *http.Request) {
 			UpdateAt: now,
 		}
 	} else {
-		user, err = a.app.GetUser(userID)
+		user, err = a.requestApp(r).GetUser(userID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
@@ -1102,7 +1459,7 @@ func (a *API) handleGetMyMemberships(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("userID", userID)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	members, err := a.app.GetMembersForUser(userID)
+	members, err := a.requestApp(r).GetMembersForUser(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1160,7 +1517,7 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	block, err := a.requestApp(r).GetBlockByID(blockID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1170,18 +1527,22 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !a.checkRestrictedView(w, r, userID, block, nil) {
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "deleteBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("blockID", blockID)
 
-	err = a.app.DeleteBlock(blockID, userID)
+	err = a.requestApp(r).DeleteBlock(blockID, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
+	a.requestLogger(r).Debug("DELETE Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
 	jsonStringResponse(w, http.StatusOK, "{}")
 
 	auditRec.Success()
@@ -1190,7 +1551,8 @@ func (a *API) handleDeleteBlock(w http.ResponseWriter, r *http.Request) {
 func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/undelete undeleteBlock
 	//
-	// Undeletes a block
+	// Undeletes a block along with the blocks deleted with it, if it was
+	// deleted within the trash retention period
 	//
 	// ---
 	// produces:
@@ -1228,7 +1590,7 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	blockID := vars["blockID"]
 	boardID := vars["boardID"]
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1238,7 +1600,7 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := a.app.GetLastBlockHistoryEntry(blockID)
+	block, err := a.requestApp(r).GetLastBlockHistoryEntry(blockID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1262,7 +1624,11 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("blockID", blockID)
 
-	undeletedBlock, err := a.app.UndeleteBlock(blockID, userID)
+	undeletedBlock, err := a.requestApp(r).UndeleteBlock(blockID, userID)
+	if model.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1274,7 +1640,7 @@ func (a *API) handleUndeleteBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.logger.Debug("UNDELETE Block", mlog.String("blockID", blockID))
+	a.requestLogger(r).Debug("UNDELETE Block", mlog.String("blockID", blockID))
 	jsonBytesResponse(w, http.StatusOK, undeletedBlockData)
 
 	auditRec.Success()
@@ -1320,13 +1686,13 @@ func (a *API) handleUndeleteBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := a.app.UndeleteBoard(boardID, userID)
+	err := a.requestApp(r).UndeleteBoard(boardID, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("UNDELETE Board", mlog.String("boardID", boardID))
+	a.requestLogger(r).Debug("UNDELETE Board", mlog.String("boardID", boardID))
 	jsonStringResponse(w, http.StatusOK, "{}")
 
 	auditRec.Success()
@@ -1364,6 +1730,10 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//   '404':
 	//     description: block not found
+	//   '409':
+	//     description: the patch conflicts with a concurrent change
+	//     schema:
+	//       "$ref": "#/definitions/BlockPatchConflictResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -1379,7 +1749,7 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	block, err := a.requestApp(r).GetBlockByID(blockID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1402,27 +1772,37 @@ func (a *API) handlePatchBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !a.checkLockedCardProperties(w, r, userID, block, patch) {
+		return
+	}
+	if !a.checkRestrictedView(w, r, userID, block, patch) {
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "patchBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("blockID", blockID)
 
-	err = a.app.PatchBlock(blockID, patch, userID)
+	err = a.requestApp(r).PatchBlock(blockID, patch, userID)
 	if err != nil {
+		if a.cardValidationErrorResponse(w, r, err) {
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("PATCH Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
+	a.requestLogger(r).Debug("PATCH Block", mlog.String("boardID", boardID), mlog.String("blockID", blockID))
 	jsonStringResponse(w, http.StatusOK, "{}")
 
 	auditRec.Success()
 }
 
 func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation PATCH /boards/{boardID}/blocks/ patchBlocks
+	// swagger:operation PATCH /boards/{boardID}/blocks patchBlocks
 	//
-	// Partially updates batch of blocks
+	// Partially updates batch of blocks of a board in one transaction
 	//
 	// ---
 	// produces:
@@ -1430,7 +1810,49 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 	// parameters:
 	// - name: boardID
 	//   in: path
-	//   description: Workspace ID
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: block Ids and block patches to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BlockPatchBatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid batch
+	//   '404':
+	//     description: a block isn't a block of the board
+	//   '409':
+	//     description: a patch conflicts with a concurrent change
+	//     schema:
+	//       "$ref": "#/definitions/BlockPatchConflictResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	// swagger:operation POST /teams/{teamID}/blocks/patch patchTeamBlocks
+	//
+	// Partially updates batch of blocks of any boards of a team in one
+	// transaction, so that either all the patches are applied or none
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
 	//   required: true
 	//   type: string
 	// - name: Body
@@ -1444,6 +1866,18 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 	// responses:
 	//   '200':
 	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid batch
+	//   '404':
+	//     description: a block isn't a block of the team
+	//   '409':
+	//     description: a patch conflicts with a concurrent change
+	//     schema:
+	//       "$ref": "#/definitions/BlockPatchConflictResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -1455,6 +1889,12 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
 	teamID := vars["teamID"]
+	boardID := vars["boardID"]
+
+	if teamID != "" && !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
 
 	requestBody, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -1463,9 +1903,12 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var patches *model.BlockPatchBatch
-	err = json.Unmarshal(requestBody, &patches)
-	if err != nil {
-		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	if err = json.Unmarshal(requestBody, &patches); err != nil || patches == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err = patches.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
@@ -1475,10 +1918,11 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 		auditRec.AddMeta("block_"+strconv.FormatInt(int64(i), 10), patches.BlockIDs[i])
 	}
 
-	for _, blockID := range patches.BlockIDs {
+	boards := map[string]*model.Board{}
+	for i, blockID := range patches.BlockIDs {
 		var block *model.Block
-		block, err = a.app.GetBlockByID(blockID)
-		if err != nil {
+		block, err = a.requestApp(r).GetBlockByID(blockID)
+		if err != nil || block == nil {
 			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
 			return
 		}
@@ -1486,16 +1930,58 @@ func (a *API) handlePatchBlocks(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
 			return
 		}
+
+		board, ok := boards[block.BoardID]
+		if !ok {
+			if board, err = a.requestApp(r).GetBoard(block.BoardID); err != nil {
+				a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+				return
+			}
+			boards[block.BoardID] = board
+		}
+		// every block of the batch must belong to the board, or to the
+		// team, of the route
+		if (boardID != "" && block.BoardID != boardID) || (teamID != "" && board.TeamID != teamID) {
+			a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrNotFound(blockID))
+			return
+		}
+		teamID = board.TeamID
+
+		if !a.checkLockedCardProperties(w, r, userID, block, &patches.BlockPatches[i]) {
+			return
+		}
+		if !a.checkRestrictedView(w, r, userID, block, &patches.BlockPatches[i]) {
+			return
+		}
 	}
 
-	err = a.app.PatchBlocks(teamID, patches, userID)
+	err = a.requestApp(r).PatchBlocks(teamID, patches, userID)
 	if err != nil {
+		if a.cardValidationErrorResponse(w, r, err) {
+			return
+		}
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("PATCH Blocks", mlog.String("patches", strconv.Itoa(len(patches.BlockIDs))))
-	jsonStringResponse(w, http.StatusOK, "{}")
+	patched := make([]model.Block, 0, len(patches.BlockIDs))
+	for _, blockID := range patches.BlockIDs {
+		block, err := a.requestApp(r).GetBlockByID(blockID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		patched = append(patched, *block)
+	}
+
+	data, err := json.Marshal(patched)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.requestLogger(r).Debug("PATCH Blocks", mlog.String("patches", strconv.Itoa(len(patches.BlockIDs))))
+	jsonBytesResponse(w, http.StatusOK, data)
 
 	auditRec.Success()
 }
@@ -1543,7 +2029,7 @@ func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	sharing, err := a.app.GetSharing(boardID)
+	sharing, err := a.requestApp(r).GetSharing(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1561,7 +2047,7 @@ func (a *API) handleGetSharing(w http.ResponseWriter, r *http.Request) {
 
 	jsonBytesResponse(w, http.StatusOK, sharingData)
 
-	a.logger.Debug("GET sharing",
+	a.requestLogger(r).Debug("GET sharing",
 		mlog.String("boardID", boardID),
 		mlog.String("shareID", sharing.ID),
 		mlog.Bool("enabled", sharing.Enabled),
@@ -1625,6 +2111,11 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 	// Stamp boardID from the URL
 	sharing.ID = boardID
 
+	if err = sharing.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
 	auditRec := a.makeAuditRecord(r, "postSharing", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("shareID", sharing.ID)
@@ -1641,8 +2132,8 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 		userID = ""
 	}
 
-	if !a.app.GetClientConfig().EnablePublicSharedBoards {
-		a.logger.Warn(
+	if !a.requestApp(r).GetClientConfig().EnablePublicSharedBoards {
+		a.requestLogger(r).Warn(
 			"Attempt to turn on sharing for board via API failed, sharing off in configuration.",
 			mlog.String("boardID", sharing.ID),
 			mlog.String("userID", userID))
@@ -1650,9 +2141,22 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if sharing.Enabled {
+		var allowed bool
+		allowed, err = a.requestApp(r).IsPublicSharingAllowed(boardID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		if !allowed {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"public sharing is not allowed in this team"})
+			return
+		}
+	}
+
 	sharing.ModifiedBy = userID
 
-	err = a.app.UpsertSharing(sharing)
+	err = a.requestApp(r).UpsertSharing(sharing)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1660,7 +2164,7 @@ func (a *API) handlePostSharing(w http.ResponseWriter, r *http.Request) {
 
 	jsonStringResponse(w, http.StatusOK, "{}")
 
-	a.logger.Debug("POST sharing", mlog.String("sharingID", sharing.ID))
+	a.requestLogger(r).Debug("POST sharing", mlog.String("sharingID", sharing.ID))
 	auditRec.Success()
 }
 
@@ -1690,7 +2194,7 @@ func (a *API) handleGetTeams(w http.ResponseWriter, r *http.Request) {
 
 	userID := getUserID(r)
 
-	teams, err := a.app.GetTeamsForUser(userID)
+	teams, err := a.requestApp(r).GetTeamsForUser(userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 	}
@@ -1748,7 +2252,7 @@ func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 	var err error
 
 	if a.MattermostAuth {
-		team, err = a.app.GetTeam(teamID)
+		team, err = a.requestApp(r).GetTeam(teamID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		}
@@ -1757,7 +2261,7 @@ func (a *API) handleGetTeam(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	} else {
-		team, err = a.app.GetRootTeam()
+		team, err = a.requestApp(r).GetRootTeam()
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
@@ -1806,7 +2310,7 @@ func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http
 		return
 	}
 
-	team, err := a.app.GetRootTeam()
+	team, err := a.requestApp(r).GetRootTeam()
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1817,7 +2321,7 @@ func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http
 
 	team.SignupToken = utils.NewID(utils.IDTypeToken)
 
-	err = a.app.UpsertTeamSignupToken(*team)
+	err = a.requestApp(r).UpsertTeamSignupToken(*team)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1830,7 +2334,7 @@ func (a *API) handlePostTeamRegenerateSignupToken(w http.ResponseWriter, r *http
 // File upload
 
 func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation GET "api/v2/files/teams/{teamID}/{boardID}/{filename} getFile
+	// swagger:operation GET /files/teams/{teamID}/{boardID}/{filename} getFile
 	//
 	// Returns the contents of an uploaded file
 	//
@@ -1884,7 +2388,7 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1913,7 +2417,7 @@ func (a *API) handleServeFile(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", contentType)
 
-	fileReader, err := a.app.GetFileReader(board.TeamID, boardID, filename)
+	fileReader, err := a.requestApp(r).GetFileReader(board.TeamID, boardID, filename)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1941,7 +2445,7 @@ func FileUploadResponseFromJSON(data io.Reader) (*FileUploadResponse, error) {
 }
 
 func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /teams/{teamID}/boards/{boardID}/files uploadFile
+	// swagger:operation POST /teams/{teamID}/{boardID}/files uploadFile
 	//
 	// Upload a binary file, attached to a root block
 	//
@@ -1974,6 +2478,14 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/FileUploadResponse"
 	//   '404':
 	//     description: board not found
+	//   '413':
+	//     description: the file is larger than the maximum file size, with an UploadRestrictedResponse, or the attachment storage limit is reached
+	//     schema:
+	//       "$ref": "#/definitions/AttachmentStorageLimitReachedResponse"
+	//   '415':
+	//     description: the extension of the file is blocked or not allowed
+	//     schema:
+	//       "$ref": "#/definitions/UploadRestrictedResponse"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -1988,7 +2500,7 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -1998,14 +2510,22 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if a.app.GetConfig().MaxFileSize > 0 {
-		r.Body = http.MaxBytesReader(w, r.Body, a.app.GetConfig().MaxFileSize)
+	restrictions, err := a.requestApp(r).GetTeamUploadRestrictions(board.TeamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if restrictions.Restrictions.MaxFileSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, restrictions.Restrictions.MaxFileSize)
 	}
 
 	file, handle, err := r.FormFile(UploadFormFileKey)
 	if err != nil {
 		if strings.HasSuffix(err.Error(), "http: request body too large") {
-			a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "", err)
+			a.uploadRestrictedResponse(w, r, &model.ErrUploadRestricted{
+				Reason:       model.UploadRestrictedFileTooLarge,
+				Restrictions: restrictions.Restrictions,
+			})
 			return
 		}
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
@@ -2019,16 +2539,45 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", board.TeamID)
 	auditRec.AddMeta("filename", handle.Filename)
 
-	fileID, err := a.app.SaveFile(file, board.TeamID, boardID, handle.Filename)
+	if err = restrictions.Restrictions.Check(handle.Filename, handle.Size); err != nil {
+		var errRestricted *model.ErrUploadRestricted
+		if errors.As(err, &errRestricted) {
+			a.uploadRestrictedResponse(w, r, errRestricted)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if err = a.requestApp(r).CheckAttachmentStorageLimit(handle.Size); err != nil {
+		var errLimit *model.ErrAttachmentStorageLimitReached
+		if errors.As(err, &errLimit) {
+			a.attachmentStorageLimitResponse(w, r, errLimit)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	fileID, err := a.requestApp(r).SaveFile(file, board.TeamID, boardID, handle.Filename)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("uploadFile",
+	a.requestLogger(r).Debug("uploadFile",
 		mlog.String("filename", handle.Filename),
 		mlog.String("fileID", fileID),
 	)
+
+	// the file is attached even if its content cannot be made searchable
+	if indexErr := a.requestApp(r).EnqueueFileContentIndexing(board.TeamID, boardID, fileID); indexErr != nil {
+		a.requestLogger(r).Warn("Cannot enqueue the indexing of the uploaded file",
+			mlog.String("fileID", fileID),
+			mlog.Err(indexErr),
+		)
+	}
+
 	data, err := json.Marshal(FileUploadResponse{FileID: fileID})
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
@@ -2041,6 +2590,27 @@ func (a *API) handleUploadFile(w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 }
 
+// attachmentStorageLimitResponse writes the response for an upload that
+// would exceed the attachment storage limit.
+func (a *API) attachmentStorageLimitResponse(w http.ResponseWriter, r *http.Request, errLimit *model.ErrAttachmentStorageLimitReached) {
+	a.requestLogger(r).Debug("API DEBUG",
+		mlog.Int("code", http.StatusRequestEntityTooLarge),
+		mlog.Err(errLimit),
+		mlog.String("api", r.URL.Path),
+	)
+	data, err := json.Marshal(model.AttachmentStorageLimitReachedResponse{
+		Error:        errLimit.Error(),
+		ErrorCode:    http.StatusRequestEntityTooLarge,
+		StorageUsed:  errLimit.Used,
+		StorageLimit: errLimit.Limit,
+	})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusRequestEntityTooLarge, data)
+}
+
 func (a *API) handleGetTeamUsers(w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /teams/{teamID}/users getTeamUsers
 	//
@@ -2088,7 +2658,7 @@ func (a *API) handleGetTeamUsers(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "getUsers", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 
-	users, err := a.app.SearchTeamUsers(teamID, searchQuery)
+	users, err := a.requestApp(r).SearchTeamUsers(teamID, searchQuery)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "searchQuery="+searchQuery, err)
 		return
@@ -2147,13 +2717,13 @@ func (a *API) handleGetBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.GetBoardsForUserAndTeam(userID, teamID)
+	boards, err := a.requestApp(r).GetBoardsForUserAndTeam(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("GetBoards",
+	a.requestLogger(r).Debug("GetBoards",
 		mlog.String("teamID", teamID),
 		mlog.Int("boardsCount", len(boards)),
 	)
@@ -2212,7 +2782,7 @@ func (a *API) handleGetTemplates(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.GetTemplateBoards(teamID, userID)
+	boards, err := a.requestApp(r).GetTemplateBoards(teamID, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2227,7 +2797,7 @@ func (a *API) handleGetTemplates(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	a.logger.Debug("GetTemplates",
+	a.requestLogger(r).Debug("GetTemplates",
 		mlog.String("teamID", teamID),
 		mlog.Int("boardsCount", len(results)),
 	)
@@ -2307,19 +2877,19 @@ func (a *API) handleCreateSubscription(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// check for valid block
-	block, err := a.app.GetBlockByID(sub.BlockID)
+	block, err := a.requestApp(r).GetBlockByID(sub.BlockID)
 	if err != nil || block == nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid blockID", err)
 		return
 	}
 
-	subNew, err := a.app.CreateSubscription(&sub)
+	subNew, err := a.requestApp(r).CreateSubscription(&sub)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("CREATE subscription",
+	a.requestLogger(r).Debug("CREATE subscription",
 		mlog.String("subscriber_id", subNew.SubscriberID),
 		mlog.String("block_id", subNew.BlockID),
 	)
@@ -2381,13 +2951,13 @@ func (a *API) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err := a.app.DeleteSubscription(blockID, subscriberID)
+	_, err := a.requestApp(r).DeleteSubscription(blockID, subscriberID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE subscription",
+	a.requestLogger(r).Debug("DELETE subscription",
 		mlog.String("blockID", blockID),
 		mlog.String("subscriberID", subscriberID),
 	)
@@ -2439,13 +3009,13 @@ func (a *API) handleGetSubscriptions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	subs, err := a.app.GetSubscriptions(subscriberID)
+	subs, err := a.requestApp(r).GetSubscriptions(subscriberID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("GET subscriptions",
+	a.requestLogger(r).Debug("GET subscriptions",
 		mlog.String("subscriberID", subscriberID),
 		mlog.Int("count", len(subs)),
 	)
@@ -2514,6 +3084,22 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	policy, err := a.requestApp(r).GetTeamBoardPolicy(newBoard.TeamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if policy.OnlyAdminsCreateBoards() && !a.permissions.HasPermissionToTeam(userID, newBoard.TeamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"only team admins can create boards"})
+		return
+	}
+	if newBoard.MinimumRole == model.BoardRoleNone {
+		newBoard.MinimumRole = policy.DefaultMemberRole
+	}
+
+	// the limits exemption can only be set by admins through its own endpoint
+	delete(newBoard.Properties, model.BoardPropertyLimitsExempt)
+
 	if err = newBoard.IsValid(); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
@@ -2525,13 +3111,13 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardType", newBoard.Type)
 
 	// create board
-	board, err := a.app.CreateBoard(newBoard, userID, true)
+	board, err := a.requestApp(r).CreateBoard(newBoard, userID, true)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("CreateBoard",
+	a.requestLogger(r).Debug("CreateBoard",
 		mlog.String("teamID", board.TeamID),
 		mlog.String("boardID", board.ID),
 		mlog.String("boardType", string(board.Type)),
@@ -2551,7 +3137,7 @@ func (a *API) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
-	// swagger:operation POST /team/{teamID}/onboard onboard
+	// swagger:operation POST /teams/{teamID}/onboard onboard
 	//
 	// Onboards a user on Boards.
 	//
@@ -2590,7 +3176,7 @@ func (a *API) handleOnboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	teamID, boardID, err := a.app.PrepareOnboardingTour(userID, teamID)
+	teamID, boardID, err := a.requestApp(r).PrepareOnboardingTour(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2623,6 +3209,11 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Board ID
 	//   required: true
 	//   type: string
+	// - name: If-None-Match
+	//   in: header
+	//   description: The ETag of a previous response, to get a 304 if the board didn't change since
+	//   required: false
+	//   type: string
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -2630,6 +3221,8 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	//     description: success
 	//     schema:
 	//       "$ref": "#/definitions/Board"
+	//   '304':
+	//     description: the board didn't change since the response with the ETag of the If-None-Match header
 	//   '404':
 	//     description: board not found
 	//   default:
@@ -2646,7 +3239,7 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2674,7 +3267,7 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	a.logger.Debug("GetBoard",
+	a.requestLogger(r).Debug("GetBoard",
 		mlog.String("boardID", boardID),
 	)
 
@@ -2684,6 +3277,11 @@ func (a *API) handleGetBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if checkNotModified(w, r, utils.ETag(string(data))) {
+		auditRec.Success()
+		return
+	}
+
 	// response
 	jsonBytesResponse(w, http.StatusOK, data)
 
@@ -2725,7 +3323,7 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 	//       "$ref": "#/definitions/ErrorResponse"
 
 	boardID := mux.Vars(r)["boardID"]
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2772,13 +3370,13 @@ func (a *API) handlePatchBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("userID", userID)
 
 	// patch board
-	updatedBoard, err := a.app.PatchBoard(patch, boardID, userID)
+	updatedBoard, err := a.requestApp(r).PatchBoard(patch, boardID, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("PatchBoard",
+	a.requestLogger(r).Debug("PatchBoard",
 		mlog.String("boardID", boardID),
 		mlog.String("userID", userID),
 	)
@@ -2825,7 +3423,7 @@ func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
 	userID := getUserID(r)
 
 	// Check if board exists
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2844,12 +3442,12 @@ func (a *API) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	if err := a.app.DeleteBoard(boardID, userID); err != nil {
+	if err := a.requestApp(r).DeleteBoard(boardID, userID); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE Board", mlog.String("boardID", boardID))
+	a.requestLogger(r).Debug("DELETE Board", mlog.String("boardID", boardID))
 	jsonStringResponse(w, http.StatusOK, "{}")
 
 	auditRec.Success()
@@ -2869,6 +3467,11 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Board ID
 	//   required: true
 	//   type: string
+	// - name: async
+	//   in: query
+	//   description: Run the duplication as a background job and return the job
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -2878,6 +3481,10 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 	//       $ref: '#/definitions/BoardsAndBlocks'
 	//   '404':
 	//     description: board not found
+	//   '202':
+	//     description: the job running the duplication
+	//     schema:
+	//       "$ref": "#/definitions/Job"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -2894,7 +3501,7 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -2930,11 +3537,23 @@ func (a *API) handleDuplicateBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	a.logger.Debug("DuplicateBoard",
+	a.requestLogger(r).Debug("DuplicateBoard",
 		mlog.String("boardID", boardID),
 	)
 
-	boardsAndBlocks, _, err := a.app.DuplicateBoard(boardID, userID, toTeam, asTemplate == "true")
+	if isAsyncRequest(r) {
+		job, err := a.requestApp(r).DuplicateBoardAsync(boardID, userID, toTeam, asTemplate == "true")
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		a.jobAcceptedResponse(w, r, job)
+		auditRec.AddMeta("jobID", job.ID)
+		auditRec.Success()
+		return
+	}
+
+	boardsAndBlocks, _, err := a.requestApp(r).DuplicateBoard(boardID, userID, toTeam, asTemplate == "true")
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
 		return
@@ -2993,7 +3612,7 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
 	asTemplate := query.Get("asTemplate")
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3007,7 +3626,7 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block, err := a.app.GetBlockByID(blockID)
+	block, err := a.requestApp(r).GetBlockByID(blockID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3026,18 +3645,21 @@ func (a *API) handleDuplicateBlock(w http.ResponseWriter, r *http.Request) {
 		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board members"})
 		return
 	}
+	if !a.checkRestrictedView(w, r, userID, block, nil) {
+		return
+	}
 
 	auditRec := a.makeAuditRecord(r, "duplicateBlock", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("blockID", blockID)
 
-	a.logger.Debug("DuplicateBlock",
+	a.requestLogger(r).Debug("DuplicateBlock",
 		mlog.String("boardID", boardID),
 		mlog.String("blockID", blockID),
 	)
 
-	blocks, err := a.app.DuplicateBlock(boardID, blockID, userID, asTemplate == "true")
+	blocks, err := a.requestApp(r).DuplicateBlock(boardID, blockID, userID, asTemplate == "true")
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
 		return
@@ -3088,7 +3710,7 @@ func (a *API) handleGetBoardMetadata(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, boardMetadata, err := a.app.GetBoardMetadata(boardID)
+	board, boardMetadata, err := a.requestApp(r).GetBoardMetadata(boardID)
 	if errors.Is(err, app.ErrInsufficientLicense) {
 		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", err)
 		return
@@ -3182,13 +3804,13 @@ func (a *API) handleSearchBoards(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("teamID", teamID)
 
 	// retrieve boards list
-	boards, err := a.app.SearchBoardsForUser(term, userID)
+	boards, err := a.requestApp(r).SearchBoardsForUser(term, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("SearchBoards",
+	a.requestLogger(r).Debug("SearchBoards",
 		mlog.String("teamID", teamID),
 		mlog.Int("boardsCount", len(boards)),
 	)
@@ -3246,13 +3868,13 @@ func (a *API) handleGetMembersForBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelModify, auditRec)
 	auditRec.AddMeta("boardID", boardID)
 
-	members, err := a.app.GetMembersForBoard(boardID)
+	members, err := a.requestApp(r).GetMembersForBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("GetMembersForBoard",
+	a.requestLogger(r).Debug("GetMembersForBoard",
 		mlog.String("boardID", boardID),
 		mlog.Int("membersCount", len(members)),
 	)
@@ -3306,7 +3928,7 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	boardID := mux.Vars(r)["boardID"]
 	userID := getUserID(r)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3350,13 +3972,13 @@ func (a *API) handleAddMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", reqBoardMember.UserID)
 
-	member, err := a.app.AddMemberToBoard(newBoardMember)
+	member, err := a.requestApp(r).AddMemberToBoard(newBoardMember)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("AddMember",
+	a.requestLogger(r).Debug("AddMember",
 		mlog.String("boardID", board.ID),
 		mlog.String("addedUserID", reqBoardMember.UserID),
 	)
@@ -3410,7 +4032,7 @@ func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	boardID := mux.Vars(r)["boardID"]
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3443,13 +4065,13 @@ func (a *API) handleJoinBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", userID)
 
-	member, err := a.app.AddMemberToBoard(newBoardMember)
+	member, err := a.requestApp(r).AddMemberToBoard(newBoardMember)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("JoinBoard",
+	a.requestLogger(r).Debug("JoinBoard",
 		mlog.String("boardID", board.ID),
 		mlog.String("addedUserID", userID),
 	)
@@ -3507,7 +4129,7 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3522,7 +4144,7 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", userID)
 
-	err = a.app.DeleteBoardMember(boardID, userID)
+	err = a.requestApp(r).DeleteBoardMember(boardID, userID)
 	if errors.Is(err, app.ErrBoardMemberIsLastAdmin) {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
@@ -3532,7 +4154,7 @@ func (a *API) handleLeaveBoard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.logger.Debug("LeaveBoard",
+	a.requestLogger(r).Debug("LeaveBoard",
 		mlog.String("boardID", board.ID),
 		mlog.String("addedUserID", userID),
 	)
@@ -3614,7 +4236,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("patchedUserID", paramsUserID)
 
-	member, err := a.app.UpdateBoardMember(newBoardMember)
+	member, err := a.requestApp(r).UpdateBoardMember(newBoardMember)
 	if errors.Is(err, app.ErrBoardMemberIsLastAdmin) {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
 		return
@@ -3624,7 +4246,7 @@ func (a *API) handleUpdateMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.logger.Debug("PatchMember",
+	a.requestLogger(r).Debug("PatchMember",
 		mlog.String("boardID", boardID),
 		mlog.String("patchedUserID", paramsUserID),
 	)
@@ -3676,7 +4298,7 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	paramsUserID := mux.Vars(r)["userID"]
 	userID := getUserID(r)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -3696,7 +4318,7 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("boardID", boardID)
 	auditRec.AddMeta("addedUserID", paramsUserID)
 
-	deleteErr := a.app.DeleteBoardMember(boardID, paramsUserID)
+	deleteErr := a.requestApp(r).DeleteBoardMember(boardID, paramsUserID)
 	if errors.Is(deleteErr, app.ErrBoardMemberIsLastAdmin) {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", deleteErr)
 		return
@@ -3706,7 +4328,7 @@ func (a *API) handleDeleteMember(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	a.logger.Debug("DeleteMember",
+	a.requestLogger(r).Debug("DeleteMember",
 		mlog.String("boardID", boardID),
 		mlog.String("addedUserID", paramsUserID),
 	)
@@ -3836,13 +4458,13 @@ func (a *API) handleCreateBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	auditRec.AddMeta("blocksCount", len(newBab.Blocks))
 
 	// create boards and blocks
-	bab, err := a.app.CreateBoardsAndBlocks(newBab, userID, true)
+	bab, err := a.requestApp(r).CreateBoardsAndBlocks(newBab, userID, true)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, err.Error(), err)
 		return
 	}
 
-	a.logger.Debug("CreateBoardsAndBlocks",
+	a.requestLogger(r).Debug("CreateBoardsAndBlocks",
 		mlog.String("teamID", teamID),
 		mlog.String("userID", userID),
 		mlog.Int("boardCount", len(bab.Boards)),
@@ -3930,7 +4552,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 			}
 		}
 
-		board, err2 := a.app.GetBoard(boardID)
+		board, err2 := a.requestApp(r).GetBoard(boardID)
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -3950,7 +4572,7 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	}
 
 	for _, blockID := range pbab.BlockIDs {
-		block, err2 := a.app.GetBlockByID(blockID)
+		block, err2 := a.requestApp(r).GetBlockByID(blockID)
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -3976,13 +4598,13 @@ func (a *API) handlePatchBoardsAndBlocks(w http.ResponseWriter, r *http.Request)
 	auditRec.AddMeta("boardsCount", len(pbab.BoardIDs))
 	auditRec.AddMeta("blocksCount", len(pbab.BlockIDs))
 
-	bab, err := a.app.PatchBoardsAndBlocks(pbab, userID)
+	bab, err := a.requestApp(r).PatchBoardsAndBlocks(pbab, userID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("PATCH BoardsAndBlocks",
+	a.requestLogger(r).Debug("PATCH BoardsAndBlocks",
 		mlog.Int("boardsCount", len(pbab.BoardIDs)),
 		mlog.Int("blocksCount", len(pbab.BlockIDs)),
 	)
@@ -4045,7 +4667,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	for _, boardID := range dbab.Boards {
 		boardIDMap[boardID] = true
 		// all boards in the request should belong to the same team
-		board, err := a.app.GetBoard(boardID)
+		board, err := a.requestApp(r).GetBoard(boardID)
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 			return
@@ -4070,7 +4692,7 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	}
 
 	for _, blockID := range dbab.Blocks {
-		block, err2 := a.app.GetBlockByID(blockID)
+		block, err2 := a.requestApp(r).GetBlockByID(blockID)
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -4101,12 +4723,12 @@ func (a *API) handleDeleteBoardsAndBlocks(w http.ResponseWriter, r *http.Request
 	auditRec.AddMeta("boardsCount", len(dbab.Boards))
 	auditRec.AddMeta("blocksCount", len(dbab.Blocks))
 
-	if err := a.app.DeleteBoardsAndBlocks(dbab, userID); err != nil {
+	if err := a.requestApp(r).DeleteBoardsAndBlocks(dbab, userID); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
 	}
 
-	a.logger.Debug("DELETE BoardsAndBlocks",
+	a.requestLogger(r).Debug("DELETE BoardsAndBlocks",
 		mlog.Int("boardsCount", len(dbab.Boards)),
 		mlog.Int("blocksCount", len(dbab.Blocks)),
 	)
@@ -4133,15 +4755,22 @@ func (a *API) handleHello(w http.ResponseWriter, r *http.Request) {
 // Response helpers
 
 func (a *API) errorResponse(w http.ResponseWriter, api string, code int, message string, sourceError error) {
-	if code == http.StatusUnauthorized || code == http.StatusForbidden {
-		a.logger.Debug("API DEBUG",
+	// the request ID is set in the response headers by the requestIDHandler
+	requestID := w.Header().Get(HeaderRequestID)
+	logger := a.logger
+	if requestID != "" {
+		logger = a.logger.With(mlog.String("request_id", requestID))
+	}
+
+	if code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusTooManyRequests {
+		logger.Debug("API DEBUG",
 			mlog.Int("code", code),
 			mlog.Err(sourceError),
 			mlog.String("msg", message),
 			mlog.String("api", api),
 		)
 	} else {
-		a.logger.Error("API ERROR",
+		logger.Error("API ERROR",
 			mlog.Int("code", code),
 			mlog.Err(sourceError),
 			mlog.String("msg", message),
@@ -4149,8 +4778,14 @@ func (a *API) errorResponse(w http.ResponseWriter, api string, code int, message
 		)
 	}
 
+	var details []string
+	var invalidPassword *auth.InvalidPasswordError
+	if errors.As(sourceError, &invalidPassword) {
+		details = invalidPassword.FailingCriterias
+	}
+
 	w.Header().Set("Content-Type", "application/json")
-	data, err := json.Marshal(model.ErrorResponse{Error: message, ErrorCode: code})
+	data, err := json.Marshal(model.ErrorResponse{Error: message, ErrorCode: code, RequestID: requestID, Details: details})
 	if err != nil {
 		data = []byte("{}")
 	}
@@ -4158,6 +4793,105 @@ func (a *API) errorResponse(w http.ResponseWriter, api string, code int, message
 	_, _ = w.Write(data)
 }
 
+// cardValidationErrorResponse writes the response for the errors returned
+// when card property values are rejected, the board card or view limit
+// is reached or a block patch conflicts with a concurrent change, and
+// returns false for any other error so the caller can handle it.
+func (a *API) cardValidationErrorResponse(w http.ResponseWriter, r *http.Request, err error) bool {
+	var errMissing *model.ErrMissingRequiredProperties
+	var errLimit *model.ErrCardLimitReached
+	var errViewLimit *model.ErrViewLimitReached
+	var errConflict *model.ErrBlockPatchConflict
+	switch {
+	case errors.As(err, &errMissing):
+		a.requestLogger(r).Debug("API DEBUG",
+			mlog.Int("code", http.StatusBadRequest),
+			mlog.Err(err),
+			mlog.String("api", r.URL.Path),
+		)
+		data, jsonErr := json.Marshal(model.MissingRequiredPropertiesResponse{
+			Error:             err.Error(),
+			ErrorCode:         http.StatusBadRequest,
+			CardID:            errMissing.CardID,
+			MissingProperties: errMissing.Missing,
+		})
+		if jsonErr != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", jsonErr)
+			return true
+		}
+		jsonBytesResponse(w, http.StatusBadRequest, data)
+		return true
+	case errors.As(err, &errLimit):
+		a.requestLogger(r).Debug("API DEBUG",
+			mlog.Int("code", http.StatusRequestEntityTooLarge),
+			mlog.Err(err),
+			mlog.String("api", r.URL.Path),
+		)
+		data, jsonErr := json.Marshal(model.CardLimitReachedResponse{
+			Error:     err.Error(),
+			ErrorCode: http.StatusRequestEntityTooLarge,
+			CardCount: errLimit.Count,
+			CardLimit: errLimit.Limit,
+		})
+		if jsonErr != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", jsonErr)
+			return true
+		}
+		jsonBytesResponse(w, http.StatusRequestEntityTooLarge, data)
+		return true
+	case errors.As(err, &errViewLimit):
+		a.requestLogger(r).Debug("API DEBUG",
+			mlog.Int("code", http.StatusRequestEntityTooLarge),
+			mlog.Err(err),
+			mlog.String("api", r.URL.Path),
+		)
+		data, jsonErr := json.Marshal(model.ViewLimitReachedResponse{
+			Error:     err.Error(),
+			ErrorCode: http.StatusRequestEntityTooLarge,
+			ViewCount: errViewLimit.Count,
+			ViewLimit: errViewLimit.Limit,
+		})
+		if jsonErr != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", jsonErr)
+			return true
+		}
+		jsonBytesResponse(w, http.StatusRequestEntityTooLarge, data)
+		return true
+	case errors.As(err, &errConflict):
+		a.requestLogger(r).Debug("API DEBUG",
+			mlog.Int("code", http.StatusConflict),
+			mlog.Err(err),
+			mlog.String("api", r.URL.Path),
+		)
+		// the current block is returned for the client to merge its
+		// changes with, if the user can still see it
+		block, blockErr := a.requestApp(r).GetBlockByID(errConflict.BlockID)
+		if blockErr != nil {
+			a.requestLogger(r).Warn("Cannot get the conflicting block", mlog.String("blockID", errConflict.BlockID), mlog.Err(blockErr))
+		}
+		if block != nil && !a.requestApp(r).CanUserSeeBlock(getUserID(r), *block) {
+			block = nil
+		}
+		data, jsonErr := json.Marshal(model.BlockPatchConflictResponse{
+			Error:             err.Error(),
+			ErrorCode:         http.StatusConflict,
+			BlockID:           errConflict.BlockID,
+			ConflictingFields: errConflict.Fields,
+			Block:             block,
+		})
+		if jsonErr != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", jsonErr)
+			return true
+		}
+		jsonBytesResponse(w, http.StatusConflict, data)
+		return true
+	case model.IsErrInvalidCardProperty(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return true
+	}
+	return false
+}
+
 func stringResponse(w http.ResponseWriter, message string) {
 	w.Header().Set("Content-Type", "text/plain")
 	_, _ = fmt.Fprint(w, message)
@@ -4174,3 +4908,16 @@ func jsonBytesResponse(w http.ResponseWriter, code int, json []byte) { //nolint:
 	w.WriteHeader(code)
 	_, _ = w.Write(json)
 }
+
+// checkNotModified sets the entity tag of the response and, if the request
+// already has this version, responds with 304 Not Modified and returns
+// true. The clients have to revalidate the responses before reusing them.
+func checkNotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set(HeaderEtagServer, etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if !utils.ETagMatches(r.Header.Get(HeaderEtagClient), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"time"
 
@@ -30,6 +32,11 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	//   description: Id of board to export
 	//   required: true
 	//   type: string
+	// - name: async
+	//   in: query
+	//   description: Run the export as a background job and return the job
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -39,6 +46,10 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	//       application-octet-stream:
 	//         type: string
 	//         format: binary
+	//   '202':
+	//     description: the job running the export
+	//     schema:
+	//       "$ref": "#/definitions/Job"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -57,7 +68,7 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("BoardID", boardID)
 
-	board, err := a.app.GetBoard(boardID)
+	board, err := a.requestApp(r).GetBoard(boardID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -70,6 +81,19 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	opts := model.ExportArchiveOptions{
 		TeamID:   board.TeamID,
 		BoardIDs: []string{board.ID},
+		UserID:   userID,
+	}
+
+	if isAsyncRequest(r) {
+		job, err := a.requestApp(r).ExportArchiveAsync(opts, userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		a.jobAcceptedResponse(w, r, job)
+		auditRec.AddMeta("jobID", job.ID)
+		auditRec.Success()
+		return
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
@@ -77,7 +101,7 @@ func (a *API) handleArchiveExportBoard(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	if err := a.requestApp(r).ExportArchive(w, opts); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 	}
 
@@ -98,6 +122,11 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	//   description: Id of team
 	//   required: true
 	//   type: string
+	// - name: async
+	//   in: query
+	//   description: Run the export as a background job and return the job
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
@@ -107,6 +136,10 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	//       application-octet-stream:
 	//         type: string
 	//         format: binary
+	//   '202':
+	//     description: the job running the export
+	//     schema:
+	//       "$ref": "#/definitions/Job"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -126,7 +159,7 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelRead, auditRec)
 	auditRec.AddMeta("TeamID", teamID)
 
-	boards, err := a.app.GetBoardsForUserAndTeam(userID, teamID)
+	boards, err := a.requestApp(r).GetBoardsForUserAndTeam(userID, teamID)
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 		return
@@ -139,6 +172,128 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	opts := model.ExportArchiveOptions{
 		TeamID:   teamID,
 		BoardIDs: ids,
+		UserID:   userID,
+	}
+	a.exportArchive(w, r, opts, userID, auditRec)
+}
+
+func (a *API) handleArchiveExportTeamBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/archive/export archiveExportTeamBoards
+	//
+	// Exports an archive of the given boards of a team, with the files
+	// referenced by them.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Id of team
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the boards to export
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ArchiveExportRequest"
+	// - name: async
+	//   in: query
+	//   description: Run the export as a background job and return the job
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       application-octet-stream:
+	//         type: string
+	//         format: binary
+	//   '202':
+	//     description: the job running the export
+	//     schema:
+	//       "$ref": "#/definitions/Job"
+	//   '400':
+	//     description: no boards to export
+	//   '404':
+	//     description: a board was not found in the team
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var req model.ArchiveExportRequest
+	if err = json.Unmarshal(requestBody, &req); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err = req.IsValid(); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "archiveExportTeamBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("TeamID", teamID)
+	auditRec.AddMeta("boardCount", len(req.BoardIDs))
+
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, boardID := range req.BoardIDs {
+		if seen[boardID] {
+			continue
+		}
+		seen[boardID] = true
+
+		board, err := a.requestApp(r).GetBoard(boardID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		if board == nil || board.TeamID != teamID {
+			a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", model.NewErrNotFound(boardID))
+			return
+		}
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+			return
+		}
+		ids = append(ids, boardID)
+	}
+
+	opts := model.ExportArchiveOptions{
+		TeamID:   teamID,
+		BoardIDs: ids,
+		UserID:   userID,
+	}
+	a.exportArchive(w, r, opts, userID, auditRec)
+}
+
+// exportArchive writes the archive of the boards to the response, or
+// enqueues its export if the request is asynchronous.
+func (a *API) exportArchive(w http.ResponseWriter, r *http.Request, opts model.ExportArchiveOptions, userID string, auditRec *audit.Record) {
+	if isAsyncRequest(r) {
+		job, err := a.requestApp(r).ExportArchiveAsync(opts, userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		a.jobAcceptedResponse(w, r, job)
+		auditRec.AddMeta("jobID", job.ID)
+		auditRec.Success()
+		return
 	}
 
 	filename := fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension)
@@ -146,7 +301,7 @@ func (a *API) handleArchiveExportTeam(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
 	w.Header().Set("Content-Transfer-Encoding", "binary")
 
-	if err := a.app.ExportArchive(w, opts); err != nil {
+	if err := a.requestApp(r).ExportArchive(w, opts); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
 	}
 
@@ -174,11 +329,27 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	//   description: archive file to import
 	//   required: true
 	//   type: file
+	// - name: async
+	//   in: query
+	//   description: Run the import as a background job and return the job
+	//   required: false
+	//   type: boolean
+	// - name: dryRun
+	//   in: query
+	//   description: Only validate the archive, returning the report of the problems found without importing it
+	//   required: false
+	//   type: boolean
 	// security:
 	// - BearerAuth: []
 	// responses:
 	//   '200':
-	//     description: success
+	//     description: success, with the validation report on a dry run
+	//     schema:
+	//       "$ref": "#/definitions/ArchiveImportReport"
+	//   '202':
+	//     description: the job running the import
+	//     schema:
+	//       "$ref": "#/definitions/Job"
 	//   default:
 	//     description: internal error
 	//     schema:
@@ -211,10 +382,25 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 	opt := model.ImportArchiveOptions{
 		TeamID:     teamID,
 		ModifiedBy: userID,
+		DryRun:     r.URL.Query().Get("dryRun") == "true",
+	}
+	auditRec.AddMeta("dryRun", opt.DryRun)
+
+	if isAsyncRequest(r) && !opt.DryRun {
+		job, err := a.requestApp(r).ImportArchiveAsync(file, opt)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		a.jobAcceptedResponse(w, r, job)
+		auditRec.AddMeta("jobID", job.ID)
+		auditRec.Success()
+		return
 	}
 
-	if err := a.app.ImportArchive(file, opt); err != nil {
-		a.logger.Debug("Error importing archive",
+	report, err := a.requestApp(r).ImportArchive(file, opt)
+	if err != nil {
+		a.requestLogger(r).Debug("Error importing archive",
 			mlog.String("team_id", teamID),
 			mlog.Err(err),
 		)
@@ -222,6 +408,17 @@ func (a *API) handleArchiveImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if opt.DryRun {
+		data, err := json.Marshal(report)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		jsonBytesResponse(w, http.StatusOK, data)
+		auditRec.Success()
+		return
+	}
+
 	jsonStringResponse(w, http.StatusOK, "{}")
 	auditRec.Success()
 }
@@ -28,6 +28,9 @@ func (a *API) makeAuditRecord(r *http.Request, event string, initialStatus strin
 		IPAddress: r.RemoteAddr,
 		Meta:      []audit.Meta{{K: audit.KeyTeamID, V: teamID}},
 	}
+	if requestID := getRequestID(r); requestID != "" {
+		rec.AddMeta("requestID", requestID)
+	}
 
 	return rec
 }
@@ -3,7 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net"
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
 	"github.com/mattermost/focalboard/server/services/auth"
@@ -19,10 +20,6 @@ import (
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-const (
-	MinimumPasswordLength = 8
-)
-
 type ParamError struct {
 	msg string
 }
@@ -87,9 +84,13 @@ type RegisterRequest struct {
 	// required: true
 	Password string `json:"password"`
 
-	// Registration authorization token
-	// required: true
+	// Shared signup token of the team, only accepted if enabled in the config
+	// required: false
 	Token string `json:"token"`
+
+	// Token of an invite link to the team
+	// required: false
+	InviteToken string `json:"inviteToken"`
 }
 
 func (rd *RegisterRequest) IsValid() error {
@@ -105,7 +106,7 @@ func (rd *RegisterRequest) IsValid() error {
 	if rd.Password == "" {
 		return ParamError{"password is required"}
 	}
-	return isValidPassword(rd.Password)
+	return nil
 }
 
 // ChangePasswordRequest is a user password change request
@@ -128,13 +129,6 @@ func (rd *ChangePasswordRequest) IsValid() error {
 	if rd.NewPassword == "" {
 		return ParamError{"new password is required"}
 	}
-	return isValidPassword(rd.NewPassword)
-}
-
-func isValidPassword(password string) error {
-	if len(password) < MinimumPasswordLength {
-		return ParamError{fmt.Sprintf("password must be at least %d characters", MinimumPasswordLength)}
-	}
 	return nil
 }
 
@@ -162,6 +156,10 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	//     description: invalid login
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
+	//   '429':
+	//     description: too many failed login attempts from the address or for the account
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   '500':
 	//     description: internal error
 	//     schema:
@@ -200,11 +198,19 @@ func (a *API) handleLogin(w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("type", loginData.Type)
 
 	if loginData.Type == "normal" {
-		token, err := a.app.Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
+		if !a.checkLoginLockout(w, r, loginData) {
+			auditRec.AddMeta("lockedOut", true)
+			return
+		}
+
+		token, err := a.requestApp(r).Login(loginData.Username, loginData.Email, loginData.Password, loginData.MfaToken)
 		if err != nil {
+			a.recordLoginFailure(r, loginData)
 			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "incorrect login", err)
 			return
 		}
+		a.resetLoginFailures(r, loginData)
+
 		json, err := json.Marshal(LoginResponse{Token: token})
 		if err != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
@@ -259,7 +265,7 @@ func (a *API) handleLogout(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("userID", session.UserID)
 
-	if err := a.app.Logout(session.ID); err != nil {
+	if err := a.requestApp(r).Logout(session.ID); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "incorrect login", err)
 		return
 	}
@@ -288,8 +294,12 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	// responses:
 	//   '200':
 	//     description: success
+	//   '400':
+	//     description: invalid request, or password not meeting the password policy
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
 	//   '401':
-	//     description: invalid registration token
+	//     description: invalid, disabled, expired or used up registration token
 	//   '500':
 	//     description: internal error
 	//     schema:
@@ -325,8 +335,16 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	registerData.Username = strings.TrimSpace(registerData.Username)
 
 	// Validate token
-	if len(registerData.Token) > 0 {
-		team, err2 := a.app.GetRootTeam()
+	switch {
+	case len(registerData.InviteToken) > 0:
+		// the invite is checked and used with the registration
+	case len(registerData.Token) > 0:
+		if !a.requestApp(r).GetConfig().EnableSignupToken {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "sign-up tokens are disabled, use an invite link", nil)
+			return
+		}
+
+		team, err2 := a.requestApp(r).GetRootTeam()
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -336,9 +354,9 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid token", nil)
 			return
 		}
-	} else {
+	default:
 		// No signup token, check if no active users
-		userCount, err2 := a.app.GetRegisteredUserCount()
+		userCount, err2 := a.requestApp(r).GetRegisteredUserCount()
 		if err2 != nil {
 			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err2)
 			return
@@ -358,7 +376,15 @@ func (a *API) handleRegister(w http.ResponseWriter, r *http.Request) {
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 	auditRec.AddMeta("username", registerData.Username)
 
-	err = a.app.RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	if len(registerData.InviteToken) > 0 {
+		err = a.requestApp(r).RegisterUserWithInvite(registerData.Username, registerData.Email, registerData.Password, registerData.InviteToken)
+	} else {
+		err = a.requestApp(r).RegisterUser(registerData.Username, registerData.Email, registerData.Password)
+	}
+	if errors.Is(err, app.ErrInviteUnusable) {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, err.Error(), err)
+		return
+	}
 	if err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
@@ -394,7 +420,7 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	//   '200':
 	//     description: success
 	//   '400':
-	//     description: invalid request
+	//     description: invalid request, or new password not meeting the password policy
 	//     schema:
 	//       "$ref": "#/definitions/ErrorResponse"
 	//   '500':
@@ -439,7 +465,7 @@ func (a *API) handleChangePassword(w http.ResponseWriter, r *http.Request) {
 	auditRec := a.makeAuditRecord(r, "changePassword", audit.Fail)
 	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
 
-	if err = a.app.ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
+	if err = a.requestApp(r).ChangePassword(userID, requestData.OldPassword, requestData.NewPassword); err != nil {
 		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
 		return
 	}
@@ -453,10 +479,11 @@ func (a *API) sessionRequired(handler func(w http.ResponseWriter, r *http.Reques
 }
 
 func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request), required bool) func(w http.ResponseWriter, r *http.Request) {
+	handler = a.rateLimitByUser(handler)
 	return func(w http.ResponseWriter, r *http.Request) {
 		token, _ := auth.ParseAuthTokenFromRequest(r)
 
-		a.logger.Debug(`attachSession`, mlog.Bool("single_user", len(a.singleUserToken) > 0))
+		a.requestLogger(r).Debug(`attachSession`, mlog.Bool("single_user", len(a.singleUserToken) > 0))
 		if len(a.singleUserToken) > 0 {
 			if required && (token != a.singleUserToken) {
 				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "invalid single user token", nil)
@@ -491,7 +518,7 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 				UpdateAt:    now,
 			}
 
-			user, err := a.app.GetUser(userID)
+			user, err := a.requestApp(r).GetUser(userID)
 			if err != nil {
 				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
 				return
@@ -507,7 +534,7 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
-		session, err := a.app.GetSession(token)
+		session, err := a.requestApp(r).GetSession(token)
 		if err != nil {
 			if required {
 				a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", err)
@@ -520,7 +547,7 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 
 		authService := session.AuthService
 		if authService != a.authService {
-			a.logger.Error(`Session authService mismatch`,
+			a.requestLogger(r).Error(`Session authService mismatch`,
 				mlog.String("sessionID", session.ID),
 				mlog.String("want", a.authService),
 				mlog.String("got", authService),
@@ -534,6 +561,26 @@ func (a *API) attachSession(handler func(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// recentSessionRequired is sessionRequired for the sensitive endpoints,
+// which also require the user to have logged in recently when the
+// configuration sets a reauthentication time.
+func (a *API) recentSessionRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return a.sessionRequired(func(w http.ResponseWriter, r *http.Request) {
+		session, _ := r.Context().Value(sessionContextKey).(*model.Session)
+		if session == nil {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", nil)
+			return
+		}
+
+		if err := a.requestApp(r).CheckRecentSession(session); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, err.Error(), err)
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
 func (a *API) adminRequired(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Currently, admin APIs require local unix connections
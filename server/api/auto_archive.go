@@ -0,0 +1,205 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetAutoArchiveRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/auto_archive getAutoArchiveRule
+	//
+	// Returns the auto-archive rule of a board, or null if the board has none
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/AutoArchiveRule"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getAutoArchiveRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	rule, err := a.requestApp(r).GetAutoArchiveRule(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetAutoArchiveRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/auto_archive setAutoArchiveRule
+	//
+	// Sets the auto-archive rule of a board. The cards that stay in the
+	// status of the rule for longer than its days are archived by the
+	// scheduler, and reported to the channel linked to the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the auto-archive rule
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/AutoArchiveRule"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/AutoArchiveRule"
+	//   '400':
+	//     description: invalid rule
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board auto-archive rule"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var rule *model.AutoArchiveRule
+	if err = json.Unmarshal(requestBody, &rule); err != nil || rule == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setAutoArchiveRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("days", rule.Days)
+
+	saved, err := a.requestApp(r).SetAutoArchiveRule(boardID, rule, userID)
+	if err != nil {
+		a.autoArchiveErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("SetAutoArchiveRule",
+		mlog.String("boardID", boardID),
+		mlog.Int("days", saved.Days),
+	)
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteAutoArchiveRule(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/auto_archive deleteAutoArchiveRule
+	//
+	// Removes the auto-archive rule of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board auto-archive rule"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteAutoArchiveRule", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if _, err := a.requestApp(r).SetAutoArchiveRule(boardID, nil, userID); err != nil {
+		a.autoArchiveErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) autoArchiveErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidAutoArchiveRule(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// avatarCacheMaxAge is how long, in seconds, the clients can use an avatar
+// before checking with its ETag whether it changed.
+const avatarCacheMaxAge = "600"
+
+var errAvatarsNotSupported = errors.New("avatars are served by Mattermost when using Mattermost authentication")
+
+func (a *API) handleGetUserAvatar(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/{userID}/avatar getUserAvatar
+	//
+	// Returns the avatar of a user, the image they uploaded or one with
+	// their initials. It doesn't require a session, so that it can be used
+	// as the source of an image, on shared boards too
+	//
+	// ---
+	// produces:
+	// - image/svg+xml
+	// - image/png
+	// - image/jpeg
+	// - image/gif
+	// - image/webp
+	// parameters:
+	// - name: userID
+	//   in: path
+	//   description: User ID
+	//   required: true
+	//   type: string
+	// - name: If-None-Match
+	//   in: header
+	//   description: The ETag of a previous response, to get a 304 if the avatar didn't change since
+	//   required: false
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//   '304':
+	//     description: the avatar didn't change since the response with the ETag of the If-None-Match header
+	//   '404':
+	//     description: user not found
+	//   '501':
+	//     description: not supported with Mattermost authentication
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", errAvatarsNotSupported)
+		return
+	}
+
+	userID := mux.Vars(r)["userID"]
+
+	avatar, err := a.requestApp(r).GetUserAvatar(userID)
+	if err != nil {
+		if model.IsErrNotFound(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	w.Header().Set(HeaderEtagServer, avatar.ETag)
+	w.Header().Set("Cache-Control", "public, max-age="+avatarCacheMaxAge)
+	if utils.ETagMatches(r.Header.Get(HeaderEtagClient), avatar.ETag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", avatar.ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// the generated avatars are SVG documents, which must not run anything
+	// if opened directly
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; sandbox")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(avatar.Data)
+}
+
+func (a *API) handleSetMyAvatar(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/avatar setMyAvatar
+	//
+	// Uploads an image as the avatar of the current user, in place of the
+	// one with their initials. The image is sent as the request body
+	//
+	// ---
+	// consumes:
+	// - image/png
+	// - image/jpeg
+	// - image/gif
+	// - image/webp
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the image bytes, 1MB at most
+	//   required: true
+	//   schema:
+	//     type: string
+	//     format: binary
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: the body is not a supported image
+	//   '413':
+	//     description: the image is too large
+	//   '501':
+	//     description: not supported with Mattermost authentication
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", errAvatarsNotSupported)
+		return
+	}
+
+	userID := getUserID(r)
+
+	// one more byte than allowed is read to tell the images too large
+	r.Body = http.MaxBytesReader(w, r.Body, model.MaxAvatarSize+1)
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "http: request body too large") {
+			a.errorResponse(w, r.URL.Path, http.StatusRequestEntityTooLarge, "", err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setMyAvatar", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("size", len(data))
+
+	if err = a.requestApp(r).SetUserAvatar(userID, data); err != nil {
+		if model.IsErrInvalidAvatar(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteMyAvatar(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /users/me/avatar deleteMyAvatar
+	//
+	// Removes the uploaded avatar of the current user, who gets the one
+	// with their initials back
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '501':
+	//     description: not supported with Mattermost authentication
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", errAvatarsNotSupported)
+		return
+	}
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "deleteMyAvatar", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	if err := a.requestApp(r).DeleteUserAvatar(userID); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
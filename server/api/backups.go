@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/backup"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleAdminGetBackups(w http.ResponseWriter, r *http.Request) {
+	teamID := r.URL.Query().Get("teamID")
+
+	auditRec := a.makeAuditRecord(r, "adminGetBackups", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	backups, err := a.requestApp(r).GetBackups(teamID)
+	if errors.Is(err, app.ErrBackupsUnavailable) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(backups)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("backupCount", len(backups))
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetBackupFile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	name := vars["name"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetBackupFile", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("name", name)
+
+	file, err := a.requestApp(r).GetBackupReader(teamID, name)
+	if errors.Is(err, backup.ErrInvalidBackupName) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if model.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if errors.Is(err, app.ErrBackupsUnavailable) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	defer file.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", "attachment; filename="+name)
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+
+	if _, err := io.Copy(w, file); err != nil {
+		a.requestLogger(r).Error("Cannot send the backup", mlog.String("teamID", teamID), mlog.String("name", name), mlog.Err(err))
+		return
+	}
+
+	auditRec.Success()
+}
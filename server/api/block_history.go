@@ -0,0 +1,280 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetBlockHistory(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/blocks/{blockID}/history getBlockHistory
+	//
+	// Returns a page of the versions of a block, newest first. The history
+	// of deleted blocks is kept
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// - name: before
+	//   in: query
+	//   description: Only return the versions older than this one, the nextBefore of the previous page
+	//   required: false
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: The number of versions per page, 20 by default
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BlockHistoryPage"
+	//   '400':
+	//     description: invalid before or limit
+	//   '404':
+	//     description: block not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	query := r.URL.Query()
+	var before int64
+	if beforeParam := query.Get("before"); beforeParam != "" {
+		var err error
+		before, err = strconv.ParseInt(beforeParam, 10, 64)
+		if err != nil || before < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid before", err)
+			return
+		}
+	}
+	var limit uint64
+	if limitParam := query.Get("limit"); limitParam != "" {
+		var err error
+		limit, err = strconv.ParseUint(limitParam, 10, 64)
+		if err != nil || limit == 0 || limit > model.MaxBlockHistoryLimit {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBlockHistory", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+
+	page, err := a.requestApp(r).GetBlockHistory(boardID, blockID, before, limit, userID)
+	if err != nil {
+		a.blockHistoryErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("versionCount", len(page.Versions))
+	auditRec.Success()
+}
+
+func (a *API) handleGetBlockVersion(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/blocks/{blockID}/history/{version} getBlockVersion
+	//
+	// Returns a version of a block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// - name: version
+	//   in: path
+	//   description: The version, as listed in the history of the block
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BlockVersion"
+	//   '400':
+	//     description: invalid version
+	//   '404':
+	//     description: block or version not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	version, err := strconv.ParseInt(vars["version"], 10, 64)
+	if err != nil || version <= 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid version", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBlockVersion", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("version", version)
+
+	blockVersion, err := a.requestApp(r).GetBlockVersion(boardID, blockID, version, userID)
+	if err != nil {
+		a.blockHistoryErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(blockVersion)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleRestoreBlockVersion(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/blocks/{blockID}/history/{version}/restore restoreBlockVersion
+	//
+	// Rolls a block back to one of its versions, undeleting it if needed.
+	// The restore is a new change in the history of the block
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: blockID
+	//   in: path
+	//   description: Block ID
+	//   required: true
+	//   type: string
+	// - name: version
+	//   in: path
+	//   description: The version to restore
+	//   required: true
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid version, or a version deleting the block
+	//   '404':
+	//     description: block or version not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	blockID := vars["blockID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modify board cards"})
+		return
+	}
+
+	version, err := strconv.ParseInt(vars["version"], 10, 64)
+	if err != nil || version <= 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid version", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "restoreBlockVersion", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("blockID", blockID)
+	auditRec.AddMeta("version", version)
+
+	block, err := a.requestApp(r).RestoreBlockVersion(boardID, blockID, version, userID)
+	if err != nil {
+		a.blockHistoryErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(block)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) blockHistoryErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if a.cardValidationErrorResponse(w, r, err) {
+		return
+	}
+	switch {
+	case model.IsErrInvalidBlockVersion(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
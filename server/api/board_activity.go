@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetBoardActivity(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/activity getBoardActivity
+	//
+	// Returns the number of edits of a board and of its blocks per day over
+	// the last weeks, from the board history
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: weeks
+	//   in: query
+	//   description: The number of weeks ending today to cover, 12 by default and up to 53
+	//   required: false
+	//   type: integer
+	// - name: tz
+	//   in: query
+	//   description: The IANA time zone the days are computed in, UTC by default
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardActivity"
+	//   '400':
+	//     description: invalid number of weeks or time zone
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	query := r.URL.Query()
+	weeks := model.DefaultActivityWeeks
+	if weeksParam := query.Get("weeks"); weeksParam != "" {
+		var err error
+		if weeks, err = strconv.Atoi(weeksParam); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid weeks", err)
+			return
+		}
+	}
+	loc := time.UTC
+	if tz := query.Get("tz"); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid time zone", err)
+			return
+		}
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardActivity", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("weeks", weeks)
+
+	activity, err := a.requestApp(r).GetBoardActivity(boardID, weeks, time.Now().In(loc))
+	if err != nil {
+		if model.IsErrInvalidActivityRange(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(activity)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetBoardAppearance(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/appearance getBoardAppearance
+	//
+	// Returns the appearance settings of a board: its cover image, color,
+	// icon and card preview style
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAppearance"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardAppearance", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	appearance, err := a.requestApp(r).GetBoardAppearance(boardID)
+	if err != nil {
+		a.boardAppearanceErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(appearance)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetBoardAppearance(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/appearance setBoardAppearance
+	//
+	// Replaces the appearance settings of a board. The cover image must be
+	// a file uploaded to the board. The settings are kept when the board is
+	// duplicated, used as a template or exported
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the appearance settings
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardAppearance"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardAppearance"
+	//   '400':
+	//     description: invalid settings
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board appearance"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var appearance *model.BoardAppearance
+	if err = json.Unmarshal(requestBody, &appearance); err != nil || appearance == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setBoardAppearance", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("coverFileID", appearance.CoverFileID)
+
+	saved, err := a.requestApp(r).SetBoardAppearance(boardID, appearance, userID)
+	if err != nil {
+		a.boardAppearanceErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) boardAppearanceErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidBoardAppearance(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
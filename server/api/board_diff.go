@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+var errInvalidDiffRange = errors.New("from_ts is required and must not be after to_ts")
+
+func (a *API) handleGetBoardDiff(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/diff getBoardDiff
+	//
+	// Returns a summary of the card changes of a board between two times,
+	// computed from the block history
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: from_ts
+	//   in: query
+	//   description: Start of the period in milliseconds since the current epoch
+	//   required: true
+	//   type: integer
+	// - name: to_ts
+	//   in: query
+	//   description: End of the period in milliseconds since the current epoch, defaults to now
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardDiff"
+	//   '400':
+	//     description: invalid period
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	query := r.URL.Query()
+	fromTS, err := strconv.ParseInt(query.Get("from_ts"), 10, 64)
+	if err != nil || fromTS < 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, errInvalidDiffRange.Error(), errInvalidDiffRange)
+		return
+	}
+	var toTS int64
+	if toParam := query.Get("to_ts"); toParam != "" {
+		toTS, err = strconv.ParseInt(toParam, 10, 64)
+		if err != nil || toTS < fromTS {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, errInvalidDiffRange.Error(), errInvalidDiffRange)
+			return
+		}
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardDiff", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("fromTS", fromTS)
+	auditRec.AddMeta("toTS", toTS)
+
+	diff, err := a.requestApp(r).GetBoardDiff(boardID, fromTS, toTS, userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(diff)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetDormantBoards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/boards/dormant getDormantBoards
+	//
+	// Returns the boards of the team without activity for a number of days,
+	// least recently active first, as candidates to archive or export.
+	// Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: days
+	//   in: query
+	//   description: The number of days without activity, the configured one by default
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/DormantBoard"
+	//   '400':
+	//     description: invalid number of days
+	//   '403':
+	//     description: the user can't manage the team
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	days := a.requestApp(r).DormantBoardDays()
+	if daysParam := r.URL.Query().Get("days"); daysParam != "" {
+		var err error
+		if days, err = strconv.Atoi(daysParam); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid days", err)
+			return
+		}
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to dormant boards report"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getDormantBoards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("days", days)
+
+	dormant, err := a.requestApp(r).GetDormantBoards(teamID, days, time.Now())
+	if err != nil {
+		if model.IsErrInvalidDormantBoardDays(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(dormant)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("boardCount", len(dormant))
+	auditRec.Success()
+}
@@ -0,0 +1,324 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetBoardEmbeds(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/embeds getBoardEmbeds
+	//
+	// Returns the embeds of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardEmbed"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardEmbeds", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	embeds, err := a.requestApp(r).GetBoardEmbeds(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(embeds)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("embedCount", len(embeds))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateBoardEmbed(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/embeds createBoardEmbed
+	//
+	// Creates an embed giving read only access to a view of the board. The
+	// id and the token of the embed are generated by the server
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the view to embed and the allowed origins
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardEmbed"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardEmbed"
+	//   '400':
+	//     description: invalid embed
+	//   '404':
+	//     description: view not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
+		return
+	}
+
+	if !a.requestApp(r).GetClientConfig().EnablePublicSharedBoards {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"public shared boards are disabled"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var embed *model.BoardEmbed
+	if err = json.Unmarshal(requestBody, &embed); err != nil || embed == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createBoardEmbed", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", embed.ViewID)
+
+	newEmbed, err := a.requestApp(r).CreateBoardEmbed(boardID, embed, userID)
+	if err != nil {
+		a.boardEmbedErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateBoardEmbed",
+		mlog.String("boardID", boardID),
+		mlog.String("embedID", newEmbed.ID),
+	)
+
+	data, err := json.Marshal(newEmbed)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("embedID", newEmbed.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteBoardEmbed(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/embeds/{embedID} deleteBoardEmbed
+	//
+	// Deletes an embed, revoking its token
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: embedID
+	//   in: path
+	//   description: Embed ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: embed not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	embedID := vars["embedID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionShareBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to sharing the board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteBoardEmbed", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("embedID", embedID)
+
+	if err := a.requestApp(r).DeleteBoardEmbed(boardID, embedID, userID); err != nil {
+		a.boardEmbedErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetEmbedContent(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/embeds/{embedID}/content getEmbedContent
+	//
+	// Returns the view and the cards shown by an embed. No session is
+	// needed, the token of the embed gives access to it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: embedID
+	//   in: path
+	//   description: Embed ID
+	//   required: true
+	//   type: string
+	// - name: token
+	//   in: query
+	//   description: Token of the embed
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/EmbedContent"
+	//   '404':
+	//     description: embed not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	embedID := vars["embedID"]
+	token := r.URL.Query().Get("token")
+
+	if !a.requestApp(r).GetClientConfig().EnablePublicSharedBoards {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"public shared boards are disabled"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getEmbedContent", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("embedID", embedID)
+
+	content, err := a.requestApp(r).GetEmbedContent(boardID, embedID, token)
+	if err != nil {
+		a.boardEmbedErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardCount", len(content.Cards))
+	auditRec.Success()
+}
+
+func (a *API) boardEmbedErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidEmbed(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
+
+// embedFrameHeaders allows the allowed origins of an embed to show the
+// embed page in an iframe. The page path ends with
+// /embed/{boardID}/{embedID} and carries the token of the embed.
+func (a *API) embedFrameHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if boardID, embedID, ok := parseEmbedPagePath(r.URL.Path); ok {
+			embed, err := a.requestApp(r).GetBoardEmbed(boardID, embedID, r.URL.Query().Get("token"))
+			if err == nil {
+				ancestors := append([]string{"'self'"}, embed.AllowedOrigins...)
+				w.Header().Set("Content-Security-Policy", "frame-ancestors "+strings.Join(ancestors, " "))
+				w.Header().Del("X-Frame-Options")
+			} else if !model.IsErrNotFound(err) {
+				a.requestLogger(r).Error("cannot get embed for frame headers", mlog.String("embedID", embedID), mlog.Err(err))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func parseEmbedPagePath(path string) (string, string, bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	n := len(parts)
+	if n < 3 || parts[n-3] != "embed" {
+		return "", "", false
+	}
+	return parts[n-2], parts[n-1], true
+}
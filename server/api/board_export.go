@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+var exportContentTypes = map[string]string{
+	model.ExportFormatCSV:  "text/csv",
+	model.ExportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+func (a *API) handleExportBoardView(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/export exportBoardView
+	//
+	// Exports the cards of a board view as a spreadsheet, with a column for
+	// each property shown by the view.
+	//
+	// ---
+	// produces:
+	// - text/csv
+	// - application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: The format of the export, csv or xlsx. Defaults to csv
+	//   required: false
+	//   type: string
+	// - name: viewId
+	//   in: query
+	//   description: The view to export. Defaults to the first view of the board
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       application-octet-stream:
+	//         type: string
+	//         format: binary
+	//   '400':
+	//     description: invalid format
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '404':
+	//     description: board or view not found
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+	query := r.URL.Query()
+	viewID := query.Get("viewId")
+	format := query.Get("format")
+	if format == "" {
+		format = model.ExportFormatCSV
+	}
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	if !model.IsValidExportFormat(format) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", model.ErrInvalidExportFormat)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportBoardView", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("viewID", viewID)
+	auditRec.AddMeta("format", format)
+
+	board, err := a.requestApp(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := a.requestApp(r).ExportBoardView(&buf, board, viewID, format, userID); err != nil {
+		switch {
+		case model.IsErrNotFound(err):
+			a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		case errors.Is(err, model.ErrInvalidExportFormat):
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		default:
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		}
+		return
+	}
+
+	filename := fmt.Sprintf("board-%s.%s", time.Now().Format("2006-01-02"), format)
+	w.Header().Set("Content-Type", exportContentTypes[format])
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+
+	auditRec.Success()
+}
@@ -0,0 +1,157 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetBoardFormatting(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/formatting getBoardFormatting
+	//
+	// Returns the formatting settings of a board: the decimal and thousands
+	// separators of its numbers and the format of its dates
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardFormatting"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardFormatting", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	formatting, err := a.requestApp(r).GetBoardFormatting(boardID)
+	if err != nil {
+		a.boardFormattingErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(formatting)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSetBoardFormatting(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/formatting setBoardFormatting
+	//
+	// Replaces the formatting settings of a board, used to format the
+	// numbers and dates of its cards in the exports and notifications
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the formatting settings
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BoardFormatting"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/BoardFormatting"
+	//   '400':
+	//     description: invalid settings
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board formatting"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var formatting *model.BoardFormatting
+	if err = json.Unmarshal(requestBody, &formatting); err != nil || formatting == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "setBoardFormatting", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("dateFormat", formatting.DateFormat)
+
+	saved, err := a.requestApp(r).SetBoardFormatting(boardID, formatting, userID)
+	if err != nil {
+		a.boardFormattingErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) boardFormattingErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidBoardFormatting(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetBoardsPortfolio(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/portfolio getBoardsPortfolio
+	//
+	// Returns the status summaries of a set of boards of the team: card
+	// counts per status, percentage done, last activity and upcoming
+	// milestones. The cards are counted by the property of the doneStatus
+	// of each board, or else by its first select property
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: board_id
+	//   in: query
+	//   description: The ids of the boards to summarize, up to 50
+	//   required: true
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/BoardSummary"
+	//   '400':
+	//     description: invalid set of boards
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+	boardIDs := r.URL.Query()["board_id"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+	for _, boardID := range boardIDs {
+		if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+			a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board " + boardID})
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "getBoardsPortfolio", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("boardCount", len(boardIDs))
+
+	summaries, err := a.requestApp(r).GetBoardsPortfolio(teamID, boardIDs, userID, time.Now())
+	if err != nil {
+		if model.IsErrInvalidPortfolio(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(summaries)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleShiftDependentCardDates(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/{cardID}/shift_dependents shiftDependentCardDates
+	//
+	// Moves the dates of the cards that depend on a card, directly or
+	// through other cards, by the delta the date of the card moved by. The
+	// cards are changed in a single transaction
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: ID of the card whose date moved
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the date property and the delta to shift the dates by
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardDateShift"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: the shifted cards
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid shift
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var shift *model.CardDateShift
+	if err = json.Unmarshal(requestBody, &shift); err != nil || shift == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "shiftDependentCardDates", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("delta", shift.Delta)
+
+	shifted, err := a.requestApp(r).ShiftDependentCardDates(boardID, cardID, shift, userID)
+	switch {
+	case model.IsErrInvalidDateShift(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	case err != nil:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(shifted)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardCount", len(shifted))
+	auditRec.Success()
+}
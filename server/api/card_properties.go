@@ -0,0 +1,846 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetCardProperties(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/properties getCardProperties
+	//
+	// Returns the typed card property schema of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardProperty"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardProperties", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	properties, err := a.requestApp(r).GetCardProperties(boardID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(properties)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("propertyCount", len(properties))
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardPropertyOptionUsage(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/properties/usage getCardPropertyOptionUsage
+	//
+	// Returns, for each select and multiSelect option of a board, how many cards use it and when it was last set on a card
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardPropertyOptionUsage"
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardPropertyOptionUsage", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	usage, err := a.requestApp(r).GetCardPropertyOptionUsage(boardID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(usage)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("optionCount", len(usage))
+	auditRec.Success()
+}
+
+func (a *API) handleGetCardPropertyAggregates(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/properties/{propertyID}/aggregate getCardPropertyAggregates
+	//
+	// Returns the totals of a number property over the cards of a board, respecting its unit
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: ID of the number property to sum
+	//   required: true
+	//   type: string
+	// - name: group_by
+	//   in: query
+	//   description: ID of a select or multiSelect property to group the totals by
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardPropertyAggregate"
+	//   '400':
+	//     description: the property is not a number or cannot be grouped by
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	propertyID := mux.Vars(r)["propertyID"]
+	groupByID := r.URL.Query().Get("group_by")
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardPropertyAggregates", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+	auditRec.AddMeta("groupBy", groupByID)
+
+	aggregates, err := a.requestApp(r).GetCardPropertyAggregates(boardID, propertyID, groupByID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(aggregates)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleAddCardProperty(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/properties addCardProperty
+	//
+	// Adds a new card property to the board schema
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the property to add
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardProperty"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardProperty"
+	//   '400':
+	//     description: invalid property
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var property *model.CardProperty
+	if err = json.Unmarshal(requestBody, &property); err != nil || property == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "addCardProperty", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newProperty, err := a.requestApp(r).AddCardProperty(boardID, property, userID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("AddCardProperty",
+		mlog.String("boardID", boardID),
+		mlog.String("propertyID", newProperty.ID),
+	)
+
+	data, err := json.Marshal(newProperty)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("propertyID", newProperty.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleReorderCardProperties(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/properties/order reorderCardProperties
+	//
+	// Sets the order of the card properties of the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the ids of every property of the board in the new order
+	//   required: true
+	//   schema:
+	//     type: array
+	//     items:
+	//       type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardProperty"
+	//   '400':
+	//     description: invalid property order
+	//   '404':
+	//     description: board not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var propertyIDs []string
+	if err = json.Unmarshal(requestBody, &propertyIDs); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "reorderCardProperties", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	properties, err := a.requestApp(r).ReorderCardProperties(boardID, propertyIDs, userID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(properties)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardProperty(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/properties/{propertyID} patchCardProperty
+	//
+	// Renames a card property or changes its type
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the property patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardPropertyPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardProperty"
+	//   '400':
+	//     description: invalid property
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patch *model.CardPropertyPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardProperty", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+
+	property, err := a.requestApp(r).PatchCardProperty(boardID, propertyID, patch, userID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(property)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardProperty(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/properties/{propertyID} deleteCardProperty
+	//
+	// Removes a card property from the board schema
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardProperty", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+
+	if err := a.requestApp(r).DeleteCardProperty(boardID, propertyID, userID); err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleAddCardPropertyOption(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/properties/{propertyID}/options addCardPropertyOption
+	//
+	// Adds an option to a select or multiSelect card property
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the option to add
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardPropertyOption"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardPropertyOption"
+	//   '400':
+	//     description: invalid option
+	//   '404':
+	//     description: board or property not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var option *model.CardPropertyOption
+	if err = json.Unmarshal(requestBody, &option); err != nil || option == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "addCardPropertyOption", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+
+	newOption, err := a.requestApp(r).AddCardPropertyOption(boardID, propertyID, option, userID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(newOption)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("optionID", newOption.ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardPropertyOption(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/properties/{propertyID}/options/{optionID} patchCardPropertyOption
+	//
+	// Changes the value or the color of a card property option
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// - name: optionID
+	//   in: path
+	//   description: Option ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the option patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardPropertyOptionPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardPropertyOption"
+	//   '400':
+	//     description: invalid option
+	//   '404':
+	//     description: board, property or option not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+	optionID := vars["optionID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patch *model.CardPropertyOptionPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardPropertyOption", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+	auditRec.AddMeta("optionID", optionID)
+
+	option, err := a.requestApp(r).PatchCardPropertyOption(boardID, propertyID, optionID, patch, userID)
+	if err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(option)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardPropertyOption(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/properties/{propertyID}/options/{optionID} deleteCardPropertyOption
+	//
+	// Removes an option from a card property
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: propertyID
+	//   in: path
+	//   description: Property ID
+	//   required: true
+	//   type: string
+	// - name: optionID
+	//   in: path
+	//   description: Option ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board, property or option not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	propertyID := vars["propertyID"]
+	optionID := vars["optionID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to modifying board properties"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardPropertyOption", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("propertyID", propertyID)
+	auditRec.AddMeta("optionID", optionID)
+
+	if err := a.requestApp(r).DeleteCardPropertyOption(boardID, propertyID, optionID, userID); err != nil {
+		a.cardPropertyErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// cardPropertyErrorResponse maps the errors returned by the card
+// property app methods to their response code.
+func (a *API) cardPropertyErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidCardProperty(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
+
+// parseCardLocationFilter reads the location filter of a card listing
+// from the `near`, `radius`, `bbox` and `location_property` query
+// parameters. It returns nil if no location filter was requested.
+func parseCardLocationFilter(query url.Values) (*model.CardLocationFilter, error) {
+	near := query.Get("near")
+	bbox := query.Get("bbox")
+	if near == "" && bbox == "" {
+		return nil, nil
+	}
+
+	filter := &model.CardLocationFilter{
+		PropertyID: query.Get("location_property"),
+	}
+
+	if near != "" {
+		coords, err := parseCoordinates(near, 2)
+		if err != nil {
+			return nil, err
+		}
+		filter.Center = &model.CardLocation{Lat: coords[0], Lng: coords[1]}
+
+		filter.Radius, err = strconv.ParseFloat(query.Get("radius"), 64)
+		if err != nil {
+			return nil, model.ErrInvalidLocationFilter
+		}
+	}
+
+	if bbox != "" {
+		coords, err := parseCoordinates(bbox, 4)
+		if err != nil {
+			return nil, err
+		}
+		filter.SouthWest = &model.CardLocation{Lat: coords[0], Lng: coords[1]}
+		filter.NorthEast = &model.CardLocation{Lat: coords[2], Lng: coords[3]}
+	}
+
+	if err := filter.IsValid(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func parseCoordinates(s string, count int) ([]float64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != count {
+		return nil, model.ErrInvalidLocationFilter
+	}
+
+	coords := make([]float64, 0, count)
+	for _, part := range parts {
+		coord, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, model.ErrInvalidLocationFilter
+		}
+		coords = append(coords, coord)
+	}
+	return coords, nil
+}
+
+// checkLockedCardProperties ensures that only the users allowed to edit
+// locked properties change their values. It writes the error response
+// and returns false if the patch can't be applied.
+func (a *API) checkLockedCardProperties(w http.ResponseWriter, r *http.Request, userID string, block *model.Block, patch *model.BlockPatch) bool {
+	if block.Type != model.TypeCard {
+		return true
+	}
+
+	properties, err := a.requestApp(r).GetCardProperties(block.BoardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return false
+	}
+
+	changed := model.ChangedLockedCardProperties(properties, block, patch)
+	if len(changed) == 0 || a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionEditLockedProperties) {
+		return true
+	}
+
+	names := make([]string, 0, len(changed))
+	for _, property := range changed {
+		names = append(names, property.Name)
+	}
+	a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to locked properties: " + strings.Join(names, ", ")})
+	return false
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleCreateCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/{cardID}/reminders createCardReminder
+	//
+	// Sets a reminder about a card for the current user. Only the user is
+	// reminded, unlike with the due dates of the card
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the time of the reminder and an optional note
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReminder"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardReminder"
+	//   '400':
+	//     description: invalid reminder
+	//   '404':
+	//     description: card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var reminder *model.CardReminder
+	if err = json.Unmarshal(requestBody, &reminder); err != nil || reminder == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+
+	created, err := a.requestApp(r).CreateCardReminder(boardID, cardID, userID, reminder, time.Now())
+	if err != nil {
+		a.cardReminderErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(created)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("reminderID", created.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleGetMyCardReminders(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/reminders getMyCardReminders
+	//
+	// Returns the pending card reminders of the current user, the soonest first
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: card_id
+	//   in: query
+	//   description: only the reminders about this card
+	//   required: false
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/CardReminder"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	cardID := r.URL.Query().Get("card_id")
+
+	reminders, err := a.requestApp(r).GetCardReminders(userID, cardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(reminders)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleSnoozeCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/reminders/{reminderID}/snooze snoozeCardReminder
+	//
+	// Postpones a card reminder of the current user, which is pending again
+	// if it was delivered
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: reminderID
+	//   in: path
+	//   description: Reminder ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the new time of the reminder
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardReminderSnooze"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardReminder"
+	//   '400':
+	//     description: invalid time
+	//   '404':
+	//     description: reminder not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	reminderID := mux.Vars(r)["reminderID"]
+	userID := getUserID(r)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var snooze model.CardReminderSnooze
+	if err = json.Unmarshal(requestBody, &snooze); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "snoozeCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("reminderID", reminderID)
+
+	reminder, err := a.requestApp(r).SnoozeCardReminder(userID, reminderID, snooze.RemindAt, time.Now())
+	if err != nil {
+		a.cardReminderErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(reminder)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleCancelCardReminder(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /users/me/reminders/{reminderID} cancelCardReminder
+	//
+	// Cancels a card reminder of the current user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: reminderID
+	//   in: path
+	//   description: Reminder ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: reminder not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	reminderID := mux.Vars(r)["reminderID"]
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "cancelCardReminder", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("reminderID", reminderID)
+
+	if err := a.requestApp(r).CancelCardReminder(userID, reminderID); err != nil {
+		a.cardReminderErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) cardReminderErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidCardReminder(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
@@ -0,0 +1,411 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetCardTemplates(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/card-templates getCardTemplates
+	//
+	// Returns the card templates of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getCardTemplates", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	templates, err := a.requestApp(r).GetCardTemplates(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(templates)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("templateCount", len(templates))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateCardTemplate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/card-templates createCardTemplate
+	//
+	// Creates a card template on the board, either from an existing card or from
+	// the given title, default property values and content blocks
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the card template to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardTemplate"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, the template card followed by its content blocks
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid card template
+	//   '404':
+	//     description: source card not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var template *model.CardTemplate
+	if err = json.Unmarshal(requestBody, &template); err != nil || template == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardTemplate", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("sourceCardID", template.SourceCardID)
+
+	blocks, err := a.requestApp(r).CreateCardTemplate(boardID, template, userID)
+	if err != nil {
+		a.cardTemplateErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateCardTemplate",
+		mlog.String("boardID", boardID),
+		mlog.String("templateID", blocks[0].ID),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("templateID", blocks[0].ID)
+	auditRec.Success()
+}
+
+func (a *API) handleCreateCardFromTemplate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/card-templates/{templateID}/cards createCardFromTemplate
+	//
+	// Creates a new card from a card template, with its content and default property values
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: templateID
+	//   in: path
+	//   description: ID of the card template
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: values overriding the template ones
+	//   required: false
+	//   schema:
+	//     "$ref": "#/definitions/CardFromTemplate"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, the new card followed by its content blocks
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid property values
+	//   '404':
+	//     description: card template not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	templateID := mux.Vars(r)["templateID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var overrides *model.CardFromTemplate
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &overrides); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "createCardFromTemplate", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("templateID", templateID)
+
+	blocks, err := a.requestApp(r).CreateCardFromTemplate(boardID, templateID, overrides, userID)
+	if err != nil {
+		a.cardTemplateErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateCardFromTemplate",
+		mlog.String("boardID", boardID),
+		mlog.String("templateID", templateID),
+		mlog.String("cardID", blocks[0].ID),
+	)
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardID", blocks[0].ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchCardTemplate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/card-templates/{templateID} patchCardTemplate
+	//
+	// Partially updates a card template
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: templateID
+	//   in: path
+	//   description: ID of the card template
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: block patch to apply
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/BlockPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid property values
+	//   '404':
+	//     description: card template not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	templateID := mux.Vars(r)["templateID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patch *model.BlockPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchCardTemplate", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("templateID", templateID)
+
+	template, err := a.requestApp(r).PatchCardTemplate(boardID, templateID, patch, userID)
+	if err != nil {
+		a.cardTemplateErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(template)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteCardTemplate(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/card-templates/{templateID} deleteCardTemplate
+	//
+	// Deletes a card template
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: templateID
+	//   in: path
+	//   description: ID of the card template
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: card template not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	templateID := mux.Vars(r)["templateID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteCardTemplate", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("templateID", templateID)
+
+	if err := a.requestApp(r).DeleteCardTemplate(boardID, templateID, userID); err != nil {
+		a.cardTemplateErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("DeleteCardTemplate",
+		mlog.String("boardID", boardID),
+		mlog.String("templateID", templateID),
+	)
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// cardTemplateErrorResponse maps the errors returned by the card
+// template app methods to their response code.
+func (a *API) cardTemplateErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if a.cardValidationErrorResponse(w, r, err) {
+		return
+	}
+
+	var errInvalid *model.ErrInvalidCardTemplate
+	switch {
+	case errors.As(err, &errInvalid):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
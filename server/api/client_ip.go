@@ -0,0 +1,13 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// getClientIP returns the address of the client of the request, as seen
+// through the trusted proxies of the configuration.
+func (a *API) getClientIP(r *http.Request) string {
+	return utils.ClientIP(r, a.requestApp(r).GetConfig().TrustedProxies)
+}
@@ -11,6 +11,9 @@ type contextKey int
 const (
 	httpConnContextKey contextKey = iota
 	sessionContextKey
+	requestIDContextKey
+	loggerContextKey
+	appContextKey
 )
 
 // SetContextConn stores the connection in the request context.
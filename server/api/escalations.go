@@ -0,0 +1,302 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetEscalationPolicies(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/escalations getEscalationPolicies
+	//
+	// Returns the escalation policies of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/EscalationPolicy"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionViewBoard) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getEscalationPolicies", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	policies, err := a.requestApp(r).GetEscalationPolicies(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(policies)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("policyCount", len(policies))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/escalations createEscalationPolicy
+	//
+	// Creates an escalation policy on the board. The id of the policy is
+	// generated by the server
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the policy to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EscalationPolicy"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/EscalationPolicy"
+	//   '400':
+	//     description: invalid policy
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board escalation policies"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var policy *model.EscalationPolicy
+	if err = json.Unmarshal(requestBody, &policy); err != nil || policy == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createEscalationPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newPolicy, err := a.requestApp(r).CreateEscalationPolicy(boardID, policy, userID)
+	if err != nil {
+		a.escalationErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateEscalationPolicy",
+		mlog.String("boardID", boardID),
+		mlog.String("policyID", newPolicy.ID),
+	)
+
+	data, err := json.Marshal(newPolicy)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("policyID", newPolicy.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /boards/{boardID}/escalations/{policyID} updateEscalationPolicy
+	//
+	// Replaces an escalation policy
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: policyID
+	//   in: path
+	//   description: Policy ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated policy
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/EscalationPolicy"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/EscalationPolicy"
+	//   '400':
+	//     description: invalid policy
+	//   '404':
+	//     description: policy not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	policyID := vars["policyID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board escalation policies"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var policy *model.EscalationPolicy
+	if err = json.Unmarshal(requestBody, &policy); err != nil || policy == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	policy.ID = policyID
+
+	auditRec := a.makeAuditRecord(r, "updateEscalationPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("policyID", policyID)
+
+	updated, err := a.requestApp(r).UpdateEscalationPolicy(boardID, policy, userID)
+	if err != nil {
+		a.escalationErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteEscalationPolicy(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/escalations/{policyID} deleteEscalationPolicy
+	//
+	// Deletes an escalation policy
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: policyID
+	//   in: path
+	//   description: Policy ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: policy not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	policyID := vars["policyID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board escalation policies"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteEscalationPolicy", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("policyID", policyID)
+
+	if err := a.requestApp(r).DeleteEscalationPolicy(boardID, policyID, userID); err != nil {
+		a.escalationErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) escalationErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidEscalationPolicy(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
@@ -0,0 +1,391 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/graphql"
+)
+
+const (
+	// maxGraphQLRequestSize is the largest GraphQL request body accepted.
+	maxGraphQLRequestSize = 64 * 1024
+
+	// maxGraphQLDepth is the deepest nesting of the fields of a GraphQL
+	// query, enough to go from the boards to the comments of their cards.
+	maxGraphQLDepth = 6
+)
+
+var (
+	errGraphQLAccessDenied = errors.New("access denied")
+	errGraphQLInvalidPage  = errors.New("invalid page or perPage")
+)
+
+func (a *API) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /graphql graphQL
+	//
+	// Runs a GraphQL query on the boards, views, cards, comments and members
+	// the user can see, returning only the selected fields. Mutations,
+	// fragments, directives and introspection are not supported. The
+	// endpoint is available when enable_graphql is set in the configuration
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: The query, with its variables and the name of the operation to run
+	//   required: true
+	//   schema:
+	//     type: object
+	//     properties:
+	//       query:
+	//         type: string
+	//       operationName:
+	//         type: string
+	//       variables:
+	//         type: object
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the errors of the fields that could not be resolved
+	//     schema:
+	//       type: object
+	//       properties:
+	//         data:
+	//           type: object
+	//         errors:
+	//           type: array
+	//           items:
+	//             type: object
+	//   '400':
+	//     description: invalid query
+	//   '404':
+	//     description: GraphQL is disabled
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if !a.requestApp(r).GetConfig().EnableGraphQL {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "GraphQL is disabled", nil)
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxGraphQLRequestSize))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	var req graphql.Request
+	if err = json.Unmarshal(requestBody, &req); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "graphQL", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("operationName", req.OperationName)
+
+	resp, err := a.graphQLSchema(userID).Execute(r.Context(), req)
+	if graphql.IsErrInvalidQuery(err) {
+		// GraphQL clients expect the errors in the same shape as the ones of
+		// the fields
+		resp = &graphql.Response{Errors: []*graphql.Error{{Message: err.Error()}}}
+		data, _ := json.Marshal(resp)
+		jsonBytesResponse(w, http.StatusBadRequest, data)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("errors", len(resp.Errors))
+	auditRec.Success()
+}
+
+// graphQLResolver resolves the fields of the GraphQL queries of a user,
+// checking the permissions of the user like the REST endpoints do.
+type graphQLResolver struct {
+	api    *API
+	userID string
+
+	// boards caches the boards of the cards and comments of the query
+	boards map[string]*model.Board
+}
+
+// graphQLSchema returns the schema of the GraphQL queries of the user.
+func (a *API) graphQLSchema(userID string) *graphql.Schema {
+	res := &graphQLResolver{api: a, userID: userID, boards: map[string]*model.Board{}}
+
+	member := &graphql.Object{
+		Name: "Member",
+		Fields: map[string]*graphql.Field{
+			"boardId":         memberField(func(m *model.BoardMember) interface{} { return m.BoardID }),
+			"userId":          memberField(func(m *model.BoardMember) interface{} { return m.UserID }),
+			"roles":           memberField(func(m *model.BoardMember) interface{} { return m.Roles }),
+			"schemeAdmin":     memberField(func(m *model.BoardMember) interface{} { return m.SchemeAdmin }),
+			"schemeEditor":    memberField(func(m *model.BoardMember) interface{} { return m.SchemeEditor }),
+			"schemeCommenter": memberField(func(m *model.BoardMember) interface{} { return m.SchemeCommenter }),
+			"schemeViewer":    memberField(func(m *model.BoardMember) interface{} { return m.SchemeViewer }),
+		},
+	}
+
+	comment := &graphql.Object{
+		Name: "Comment",
+		Fields: map[string]*graphql.Field{
+			"id":              commentField(func(c *model.Comment) interface{} { return c.ID }),
+			"boardId":         commentField(func(c *model.Comment) interface{} { return c.BoardID }),
+			"cardId":          commentField(func(c *model.Comment) interface{} { return c.CardID }),
+			"parentCommentId": commentField(func(c *model.Comment) interface{} { return c.ParentCommentID }),
+			"text":            commentField(func(c *model.Comment) interface{} { return c.Text }),
+			"createdBy":       commentField(func(c *model.Comment) interface{} { return c.CreatedBy }),
+			"createAt":        commentField(func(c *model.Comment) interface{} { return c.CreateAt }),
+			"updateAt":        commentField(func(c *model.Comment) interface{} { return c.UpdateAt }),
+		},
+	}
+
+	view := &graphql.Object{
+		Name: "View",
+		Fields: map[string]*graphql.Field{
+			"id":       viewField(func(v *model.Block) interface{} { return v.ID }),
+			"boardId":  viewField(func(v *model.Block) interface{} { return v.BoardID }),
+			"title":    viewField(func(v *model.Block) interface{} { return v.Title }),
+			"viewType": viewField(func(v *model.Block) interface{} { return v.Fields["viewType"] }),
+			"fields":   viewField(func(v *model.Block) interface{} { return v.Fields }),
+			"createAt": viewField(func(v *model.Block) interface{} { return v.CreateAt }),
+			"updateAt": viewField(func(v *model.Block) interface{} { return v.UpdateAt }),
+		},
+	}
+
+	board := &graphql.Object{Name: "Board"}
+	card := &graphql.Object{
+		Name: "Card",
+		Fields: map[string]*graphql.Field{
+			"id":           cardField(func(c *model.Card) interface{} { return c.ID }),
+			"boardId":      cardField(func(c *model.Card) interface{} { return c.BoardID }),
+			"title":        cardField(func(c *model.Card) interface{} { return c.Title }),
+			"icon":         cardField(func(c *model.Card) interface{} { return c.Icon }),
+			"isTemplate":   cardField(func(c *model.Card) interface{} { return c.IsTemplate }),
+			"contentOrder": cardField(func(c *model.Card) interface{} { return c.ContentOrder }),
+			"properties":   cardField(func(c *model.Card) interface{} { return c.Properties }),
+			"createdBy":    cardField(func(c *model.Card) interface{} { return c.CreatedBy }),
+			"modifiedBy":   cardField(func(c *model.Card) interface{} { return c.ModifiedBy }),
+			"createAt":     cardField(func(c *model.Card) interface{} { return c.CreateAt }),
+			"updateAt":     cardField(func(c *model.Card) interface{} { return c.UpdateAt }),
+			"board":        {Type: board, Resolve: res.cardBoard},
+			"comments":     {Type: comment, Resolve: res.cardComments},
+		},
+	}
+
+	board.Fields = map[string]*graphql.Field{
+		"id":             boardField(func(b *model.Board) interface{} { return b.ID }),
+		"teamId":         boardField(func(b *model.Board) interface{} { return b.TeamID }),
+		"channelId":      boardField(func(b *model.Board) interface{} { return b.ChannelID }),
+		"type":           boardField(func(b *model.Board) interface{} { return b.Type }),
+		"title":          boardField(func(b *model.Board) interface{} { return b.Title }),
+		"description":    boardField(func(b *model.Board) interface{} { return b.Description }),
+		"icon":           boardField(func(b *model.Board) interface{} { return b.Icon }),
+		"isTemplate":     boardField(func(b *model.Board) interface{} { return b.IsTemplate }),
+		"properties":     boardField(func(b *model.Board) interface{} { return b.Properties }),
+		"cardProperties": boardField(func(b *model.Board) interface{} { return b.CardProperties }),
+		"createdBy":      boardField(func(b *model.Board) interface{} { return b.CreatedBy }),
+		"modifiedBy":     boardField(func(b *model.Board) interface{} { return b.ModifiedBy }),
+		"createAt":       boardField(func(b *model.Board) interface{} { return b.CreateAt }),
+		"updateAt":       boardField(func(b *model.Board) interface{} { return b.UpdateAt }),
+		"views":          {Type: view, Resolve: res.boardViews},
+		"cards":          {Type: card, Args: map[string]bool{"page": false, "perPage": false}, Resolve: res.boardCards},
+		"members":        {Type: member, Resolve: res.boardMembers},
+	}
+
+	return &graphql.Schema{
+		MaxDepth: maxGraphQLDepth,
+		Query: &graphql.Object{
+			Name: "Query",
+			Fields: map[string]*graphql.Field{
+				"board":  {Type: board, Args: map[string]bool{"id": true}, Resolve: res.board},
+				"boards": {Type: board, Args: map[string]bool{"teamId": true}, Resolve: res.teamBoards},
+				"card":   {Type: card, Args: map[string]bool{"boardId": true, "id": true}, Resolve: res.card},
+			},
+		},
+	}
+}
+
+// getBoard returns a board the user can see, nil if it doesn't exist.
+func (res *graphQLResolver) getBoard(boardID string) (*model.Board, error) {
+	if board, ok := res.boards[boardID]; ok {
+		return board, nil
+	}
+	if !res.api.permissions.HasPermissionToBoard(res.userID, boardID, model.PermissionViewBoard) {
+		return nil, errGraphQLAccessDenied
+	}
+
+	board, err := res.api.app.GetBoard(boardID)
+	if board == nil || err != nil {
+		return nil, err
+	}
+	res.boards[boardID] = board
+	return board, nil
+}
+
+func (res *graphQLResolver) board(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	boardID, err := graphql.StringArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	return res.getBoard(boardID)
+}
+
+func (res *graphQLResolver) teamBoards(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	teamID, err := graphql.StringArg(args, "teamId")
+	if err != nil {
+		return nil, err
+	}
+	if !res.api.permissions.HasPermissionToTeam(res.userID, teamID, model.PermissionViewTeam) {
+		return nil, errGraphQLAccessDenied
+	}
+	return res.api.app.GetBoardsForUserAndTeam(res.userID, teamID)
+}
+
+func (res *graphQLResolver) card(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+	boardID, err := graphql.StringArg(args, "boardId")
+	if err != nil {
+		return nil, err
+	}
+	cardID, err := graphql.StringArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := res.getBoard(boardID)
+	if board == nil || err != nil {
+		return nil, err
+	}
+	card, err := res.api.app.GetCard(board, cardID, res.userID)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	return card, err
+}
+
+// visibleBoard returns the board of a query result after checking that
+// the user can see it, as the boards of a team include the open boards
+// the user isn't a member of.
+func (res *graphQLResolver) visibleBoard(source interface{}) (*model.Board, error) {
+	board, err := res.getBoard(source.(*model.Board).ID)
+	if err == nil && board == nil {
+		err = model.NewErrNotFound(source.(*model.Board).ID)
+	}
+	return board, err
+}
+
+func (res *graphQLResolver) boardViews(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+	board, err := res.visibleBoard(source)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := res.api.app.GetBlocks(board.ID, "", model.TypeView)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err = res.api.app.FilterRestrictedBlocks(board, blocks, res.userID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]*model.Block, 0, len(blocks))
+	for i := range blocks {
+		views = append(views, &blocks[i])
+	}
+	return views, nil
+}
+
+func (res *graphQLResolver) boardCards(_ context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	board, err := res.visibleBoard(source)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := model.QueryCardsOptions{}
+	if opts.Page, err = graphql.IntArg(args, "page", 0); err != nil {
+		return nil, err
+	}
+	if opts.PerPage, err = graphql.IntArg(args, "perPage", model.DefaultCardsPerPage); err != nil {
+		return nil, err
+	}
+	if opts.Page < 0 || opts.PerPage <= 0 || opts.PerPage > model.MaxCardsPerPage {
+		return nil, errGraphQLInvalidPage
+	}
+	return res.api.app.GetCards(board, opts, res.userID)
+}
+
+func (res *graphQLResolver) boardMembers(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+	board, err := res.visibleBoard(source)
+	if err != nil {
+		return nil, err
+	}
+	return res.api.app.GetMembersForBoard(board.ID)
+}
+
+func (res *graphQLResolver) cardBoard(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+	return res.getBoard(source.(*model.Card).BoardID)
+}
+
+func (res *graphQLResolver) cardComments(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+	card := source.(*model.Card)
+	board, err := res.getBoard(card.BoardID)
+	if board == nil || err != nil {
+		return nil, err
+	}
+	return res.api.app.GetComments(board, card.ID, res.userID)
+}
+
+func boardField(value func(*model.Board) interface{}) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return value(source.(*model.Board)), nil
+	}}
+}
+
+func cardField(value func(*model.Card) interface{}) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return value(source.(*model.Card)), nil
+	}}
+}
+
+func viewField(value func(*model.Block) interface{}) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return value(source.(*model.Block)), nil
+	}}
+}
+
+func commentField(value func(*model.Comment) interface{}) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return value(source.(*model.Comment)), nil
+	}}
+}
+
+func memberField(value func(*model.BoardMember) interface{}) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+		return value(source.(*model.BoardMember)), nil
+	}}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// CSVMappingFormKey is the form field holding the JSON column mapping of
+// a CSV import.
+const CSVMappingFormKey = "mapping"
+
+func (a *API) handleImportCSV(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/import/csv importCSV
+	//
+	// Creates a card in the board for every row of a CSV file, mapping its
+	// columns to card properties. Rows that can't be imported are skipped and
+	// reported in the response.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: file
+	//   in: formData
+	//   description: the CSV file, with a header row
+	//   required: true
+	//   type: file
+	// - name: mapping
+	//   in: formData
+	//   description: the CSVImportMapping of the columns, as JSON
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CSVImportResult"
+	//   '400':
+	//     description: invalid CSV file or mapping
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '413':
+	//     description: the card limit of the board would be exceeded
+	//     schema:
+	//       "$ref": "#/definitions/CardLimitReachedResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	if a.requestApp(r).GetConfig().MaxFileSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, a.requestApp(r).GetConfig().MaxFileSize)
+	}
+
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	defer file.Close()
+
+	var mapping *model.CSVImportMapping
+	if err = json.Unmarshal([]byte(r.FormValue(CSVMappingFormKey)), &mapping); err != nil || mapping == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid mapping", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "importCSV", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+
+	result, err := a.requestApp(r).ImportCSV(boardID, file, mapping, userID)
+	if err != nil {
+		if a.cardValidationErrorResponse(w, r, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, model.ErrInvalidCSV), model.IsErrInvalidCSVMapping(err):
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		default:
+			a.cardPropertyErrorResponse(w, r, err)
+		}
+		return
+	}
+
+	a.requestLogger(r).Debug("ImportCSV",
+		mlog.String("boardID", boardID),
+		mlog.Int("cards", len(result.CardIDs)),
+		mlog.Int("errors", len(result.Errors)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("cardCount", len(result.CardIDs))
+	auditRec.Success()
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/import/jira"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handlePreviewImportJira(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/import/jira/preview previewImportJira
+	//
+	// Reads the Jira issues and returns the proposed mapping of their fields to
+	// board properties, without importing them.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the Jira export or site to read the issues from
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/JiraImportRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/JiraImportPreview"
+	//   '400':
+	//     description: invalid Jira export or site
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '502':
+	//     description: the issues could not be fetched from the Jira site
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
+		return
+	}
+
+	req, ok := a.decodeJiraImportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	preview, err := a.requestApp(r).PreviewJiraImport(r.Context(), req)
+	if err != nil {
+		a.jiraImportErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(preview)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleImportJira(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/import/jira importJira
+	//
+	// Imports the Jira issues as a new board of the team, using the given
+	// mapping or the proposed one if none is given.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the Jira export or site to read the issues from, and their mapping
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/JiraImportRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '400':
+	//     description: invalid Jira export, site or mapping
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '502':
+	//     description: the issues could not be fetched from the Jira site
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+	teamID := mux.Vars(r)["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
+		return
+	}
+
+	req, ok := a.decodeJiraImportRequest(w, r)
+	if !ok {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "importJira", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	board, err := a.requestApp(r).ImportJira(r.Context(), req, teamID, userID)
+	if err != nil {
+		a.jiraImportErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("ImportJira",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", board.ID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardID", board.ID)
+	auditRec.Success()
+}
+
+func (a *API) decodeJiraImportRequest(w http.ResponseWriter, r *http.Request) (*model.JiraImportRequest, bool) {
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return nil, false
+	}
+
+	var req *model.JiraImportRequest
+	if err = json.Unmarshal(requestBody, &req); err != nil || req == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return nil, false
+	}
+	return req, true
+}
+
+func (a *API) jiraImportErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, jira.ErrInvalidExport), errors.Is(err, jira.ErrInvalidMapping), errors.Is(err, jira.ErrInvalidSource):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, jira.ErrFetchFailed):
+		a.errorResponse(w, r.URL.Path, http.StatusBadGateway, err.Error(), err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/import/notion"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleImportNotion(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/import/notion importNotion
+	//
+	// Imports the databases of a Notion "Markdown & CSV" export zip as new
+	// boards of the team. Items that cannot be converted are skipped and
+	// listed in the response.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: file
+	//   in: formData
+	//   description: Notion export zip to import
+	//   required: true
+	//   type: file
+	// - name: async
+	//   in: query
+	//   description: Run the import as a background job and return the job
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/ImportResult"
+	//   '400':
+	//     description: invalid Notion export
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '202':
+	//     description: the job running the import
+	//     schema:
+	//       "$ref": "#/definitions/Job"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session, _ := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
+		return
+	}
+
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	defer file.Close()
+
+	auditRec := a.makeAuditRecord(r, "importNotion", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+
+	if isAsyncRequest(r) {
+		job, err := a.requestApp(r).ImportNotionAsync(file, teamID, userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		a.jobAcceptedResponse(w, r, job)
+		auditRec.AddMeta("jobID", job.ID)
+		auditRec.Success()
+		return
+	}
+
+	result, err := a.requestApp(r).ImportNotion(file, handle.Size, teamID, userID)
+	if errors.Is(err, notion.ErrInvalidExport) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.requestLogger(r).Debug("ImportNotion",
+		mlog.String("teamID", teamID),
+		mlog.Int("boardsCount", len(result.Boards)),
+		mlog.Int("errorsCount", len(result.Errors)),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardsCount", len(result.Boards))
+	auditRec.Success()
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/import/trello"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleImportTrello(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/import/trello importTrello
+	//
+	// Imports a Trello board export as a new board of the team.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the JSON export of a Trello board
+	//   required: true
+	//   schema:
+	//     type: object
+	// - name: async
+	//   in: query
+	//   description: Run the import as a background job and return the job
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '400':
+	//     description: invalid Trello export
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '202':
+	//     description: the job running the import
+	//     schema:
+	//       "$ref": "#/definitions/Job"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	ctx := r.Context()
+	session, _ := ctx.Value(sessionContextKey).(*model.Session)
+	userID := session.UserID
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to create board"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "importTrello", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	if isAsyncRequest(r) {
+		job, err := a.requestApp(r).ImportTrelloAsync(r.Body, teamID, userID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return
+		}
+		a.jobAcceptedResponse(w, r, job)
+		auditRec.AddMeta("jobID", job.ID)
+		auditRec.Success()
+		return
+	}
+
+	board, err := a.requestApp(r).ImportTrello(r.Body, teamID, userID)
+	if errors.Is(err, trello.ErrInvalidExport) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.requestLogger(r).Debug("ImportTrello",
+		mlog.String("teamID", teamID),
+		mlog.String("boardID", board.ID),
+	)
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardID", board.ID)
+	auditRec.Success()
+}
@@ -0,0 +1,451 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetIntakeForms(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/forms getIntakeForms
+	//
+	// Returns the intake forms of a board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/IntakeForm"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	// the forms hold their access tokens, so only the users that can
+	// manage them can list them
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board intake forms"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getIntakeForms", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	forms, err := a.requestApp(r).GetIntakeForms(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(forms)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("formCount", len(forms))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateIntakeForm(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/forms createIntakeForm
+	//
+	// Creates an intake form on the board. The id and the token of the form
+	// are generated by the server
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the form to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IntakeForm"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IntakeForm"
+	//   '400':
+	//     description: invalid form
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board intake forms"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var form *model.IntakeForm
+	if err = json.Unmarshal(requestBody, &form); err != nil || form == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createIntakeForm", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	newForm, err := a.requestApp(r).CreateIntakeForm(boardID, form, userID)
+	if err != nil {
+		a.submissionErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateIntakeForm",
+		mlog.String("boardID", boardID),
+		mlog.String("formID", newForm.ID),
+	)
+
+	data, err := json.Marshal(newForm)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("formID", newForm.ID)
+	auditRec.Success()
+}
+
+func (a *API) handlePatchIntakeForm(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PATCH /boards/{boardID}/forms/{formID} patchIntakeForm
+	//
+	// Partially updates an intake form
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: formID
+	//   in: path
+	//   description: Form ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the form patch
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IntakeFormPatch"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IntakeForm"
+	//   '400':
+	//     description: invalid form
+	//   '404':
+	//     description: form not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	formID := vars["formID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board intake forms"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var patch *model.IntakeFormPatch
+	if err = json.Unmarshal(requestBody, &patch); err != nil || patch == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "patchIntakeForm", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("formID", formID)
+	auditRec.AddMeta("regenerateToken", patch.RegenerateToken)
+
+	form, err := a.requestApp(r).PatchIntakeForm(boardID, formID, patch, userID)
+	if err != nil {
+		a.submissionErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(form)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteIntakeForm(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /boards/{boardID}/forms/{formID} deleteIntakeForm
+	//
+	// Deletes an intake form
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: formID
+	//   in: path
+	//   description: Form ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: form not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	formID := vars["formID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardProperties) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board intake forms"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteIntakeForm", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("formID", formID)
+
+	if err := a.requestApp(r).DeleteIntakeForm(boardID, formID, userID); err != nil {
+		a.submissionErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleGetPublicIntakeForm(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /boards/{boardID}/forms/{formID}/public getPublicIntakeForm
+	//
+	// Returns an intake form as shown to the people filling it. No session
+	// is needed, the token of the form gives access to it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: formID
+	//   in: path
+	//   description: Form ID
+	//   required: true
+	//   type: string
+	// - name: token
+	//   in: query
+	//   description: Token of the form
+	//   required: true
+	//   type: string
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/IntakeFormView"
+	//   '404':
+	//     description: form not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	formID := vars["formID"]
+	token := r.URL.Query().Get("token")
+
+	auditRec := a.makeAuditRecord(r, "getPublicIntakeForm", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("formID", formID)
+
+	form, err := a.requestApp(r).GetPublicIntakeForm(boardID, formID, token)
+	if err != nil {
+		a.submissionErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(form)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleSubmitIntakeForm(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/forms/{formID}/submit submitIntakeForm
+	//
+	// Submits the values of an intake form, creating a card on the board. No
+	// session is needed, the token of the form gives access to it
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: formID
+	//   in: path
+	//   description: Form ID
+	//   required: true
+	//   type: string
+	// - name: token
+	//   in: query
+	//   description: Token of the form
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the submitted values
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/IntakeFormSubmission"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: invalid values
+	//   '404':
+	//     description: form not found
+	//   '429':
+	//     description: too many submissions
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	formID := vars["formID"]
+	token := r.URL.Query().Get("token")
+
+	if !a.allowSubmission(w, r, formID) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSubmissionBodySize))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var submission *model.IntakeFormSubmission
+	if err = json.Unmarshal(requestBody, &submission); err != nil || submission == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid submission", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "submitIntakeForm", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("formID", formID)
+
+	if !a.verifySubmissionCaptcha(w, r, submission.CaptchaResponse) {
+		return
+	}
+
+	card, err := a.requestApp(r).SubmitIntakeForm(boardID, formID, token, submission)
+	if err != nil {
+		a.submissionErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var errInvitesNotSupported = errors.New("invites are not supported when using Mattermost authentication")
+
+func (a *API) handleGetInvites(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/invites getInvites
+	//
+	// Returns the invite links of a team. Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Invite"
+	//   '501':
+	//     description: not supported with Mattermost authentication
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", errInvitesNotSupported)
+		return
+	}
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team invites"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getInvites", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	invites, err := a.requestApp(r).GetInvites(teamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(invites)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("inviteCount", len(invites))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateInvite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/invites createInvite
+	//
+	// Creates an invite link that lets new users register directly into
+	// the team, and join its open boards with the role of the invite. The
+	// invite can expire and be limited to a number of registrations.
+	// Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the role and the limits of the invite
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Invite"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Invite"
+	//   '400':
+	//     description: invalid invite
+	//   '501':
+	//     description: not supported with Mattermost authentication
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", errInvitesNotSupported)
+		return
+	}
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team invites"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var invite *model.Invite
+	if err = json.Unmarshal(requestBody, &invite); err != nil || invite == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("role", invite.Role)
+
+	newInvite, err := a.requestApp(r).CreateInvite(teamID, invite, userID)
+	if err != nil {
+		a.inviteErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateInvite",
+		mlog.String("teamID", teamID),
+		mlog.String("inviteID", newInvite.ID),
+	)
+
+	data, err := json.Marshal(newInvite)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("inviteID", newInvite.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteInvite(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/invites/{inviteID} deleteInvite
+	//
+	// Revokes an invite link. Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: inviteID
+	//   in: path
+	//   description: Invite ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: invite not found
+	//   '501':
+	//     description: not supported with Mattermost authentication
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "", errInvitesNotSupported)
+		return
+	}
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	inviteID := vars["inviteID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team invites"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteInvite", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("inviteID", inviteID)
+
+	if err := a.requestApp(r).DeleteInvite(teamID, inviteID); err != nil {
+		a.inviteErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) inviteErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidInvite(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
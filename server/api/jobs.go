@@ -0,0 +1,231 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const defaultJobsLimit = 100
+
+// isAsyncRequest returns whether the client asked to run the operation as a
+// background job, whose progress is then polled with GET /jobs/{jobID}.
+func isAsyncRequest(r *http.Request) bool {
+	return r.URL.Query().Get("async") == "true"
+}
+
+// jobAcceptedResponse answers a request whose operation was enqueued with
+// the job running it.
+func (a *API) jobAcceptedResponse(w http.ResponseWriter, r *http.Request, job *model.Job) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	jsonBytesResponse(w, http.StatusAccepted, data)
+}
+
+// getJobForUser returns the job, answering the request with an error if it
+// cannot be read or wasn't requested by the user.
+func (a *API) getJobForUser(w http.ResponseWriter, r *http.Request, jobID, userID string) *model.Job {
+	job, err := a.requestApp(r).GetJob(jobID)
+	if model.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return nil
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return nil
+	}
+	if job.CreatedBy != userID {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to job"})
+		return nil
+	}
+	return job
+}
+
+func (a *API) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /jobs/{jobID} getJob
+	//
+	// Returns the status, progress and result of a job requested by the user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: jobID
+	//   in: path
+	//   description: Job ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Job"
+	//   '404':
+	//     description: job not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	jobID := mux.Vars(r)["jobID"]
+	userID := getUserID(r)
+
+	job := a.getJobForUser(w, r, jobID, userID)
+	if job == nil {
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleGetJobFile(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /jobs/{jobID}/file getJobFile
+	//
+	// Downloads the file produced by a job requested by the user, like the
+	// archive of an export
+	//
+	// ---
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	// - name: jobID
+	//   in: path
+	//   description: Job ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       application-octet-stream:
+	//         type: string
+	//         format: binary
+	//   '404':
+	//     description: job or file not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	jobID := mux.Vars(r)["jobID"]
+	userID := getUserID(r)
+
+	job := a.getJobForUser(w, r, jobID, userID)
+	if job == nil {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getJobFile", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("jobID", jobID)
+
+	file, err := a.requestApp(r).GetJobFileReader(job)
+	if errors.Is(err, app.ErrJobHasNoFile) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	defer file.Close()
+
+	filename, _ := job.Result["filename"].(string)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+
+	if _, err := io.Copy(w, file); err != nil {
+		a.requestLogger(r).Error("Cannot send the job file", mlog.String("jobID", jobID), mlog.Err(err))
+		return
+	}
+
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetJobs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := model.QueryJobsOptions{
+		Type:   query.Get("type"),
+		Status: model.JobStatus(query.Get("status")),
+		Limit:  defaultJobsLimit,
+	}
+	if limitParam := query.Get("limit"); limitParam != "" {
+		limit, err := strconv.ParseUint(limitParam, 10, 64)
+		if err != nil || limit == 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+		opts.Limit = limit
+	}
+
+	auditRec := a.makeAuditRecord(r, "adminGetJobs", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("type", opts.Type)
+	auditRec.AddMeta("status", opts.Status)
+
+	jobs, err := a.requestApp(r).GetJobs(opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("jobCount", len(jobs))
+	auditRec.Success()
+}
+
+func (a *API) handleAdminGetJob(w http.ResponseWriter, r *http.Request) {
+	jobID := mux.Vars(r)["jobID"]
+
+	auditRec := a.makeAuditRecord(r, "adminGetJob", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("jobID", jobID)
+
+	job, err := a.requestApp(r).GetJob(jobID)
+	if model.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// loginThrottle counts the failed login attempts of each key and locks the
+// key out for a while once it reaches the limit.
+type loginThrottle struct {
+	mu       sync.Mutex
+	failures map[string]*loginFailures
+}
+
+type loginFailures struct {
+	count       int
+	resetAt     time.Time
+	lockedUntil time.Time
+}
+
+func newLoginThrottle() *loginThrottle {
+	return &loginThrottle{
+		failures: map[string]*loginFailures{},
+	}
+}
+
+// LockedUntil returns the end of the lockout of the key, if any.
+func (lt *loginThrottle) LockedUntil(key string, now time.Time) (time.Time, bool) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	failures, ok := lt.failures[key]
+	if !ok || !now.Before(failures.lockedUntil) {
+		return time.Time{}, false
+	}
+	return failures.lockedUntil, true
+}
+
+// Fail records a failed attempt for the key and returns true if it locks
+// the key out. Failures older than the window are forgotten.
+func (lt *loginThrottle) Fail(key string, limit int, window, lockout time.Duration, now time.Time) bool {
+	if limit <= 0 {
+		return false
+	}
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	failures, ok := lt.failures[key]
+	if !ok || (now.After(failures.resetAt) && !now.Before(failures.lockedUntil)) {
+		lt.removeExpired(now)
+		failures = &loginFailures{resetAt: now.Add(window)}
+		lt.failures[key] = failures
+	}
+
+	failures.count++
+	if failures.count < limit {
+		return false
+	}
+	failures.count = 0
+	failures.resetAt = now.Add(lockout)
+	failures.lockedUntil = now.Add(lockout)
+	return true
+}
+
+// Reset forgets the failed attempts of the key.
+func (lt *loginThrottle) Reset(key string) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	delete(lt.failures, key)
+}
+
+// removeExpired drops the keys with neither a running window nor a
+// lockout so that the map doesn't grow with every client seen.
+func (lt *loginThrottle) removeExpired(now time.Time) {
+	for key, failures := range lt.failures {
+		if now.After(failures.resetAt) && !now.Before(failures.lockedUntil) {
+			delete(lt.failures, key)
+		}
+	}
+}
+
+// loginThrottleKeys returns the keys the login attempts of the request are
+// counted under: the client address, as seen through the trusted proxies,
+// and the account.
+func (a *API) loginThrottleKeys(r *http.Request, loginData LoginRequest) (string, string) {
+	account := loginData.Username
+	if account == "" {
+		account = loginData.Email
+	}
+	return "ip:" + a.getClientIP(r), "account:" + strings.ToLower(account)
+}
+
+// checkLoginLockout writes a 429 response when the address or the account
+// of the login attempt is locked out.
+func (a *API) checkLoginLockout(w http.ResponseWriter, r *http.Request, loginData LoginRequest) bool {
+	now := time.Now()
+	ipKey, accountKey := a.loginThrottleKeys(r, loginData)
+	for _, key := range []string{ipKey, accountKey} {
+		lockedUntil, locked := a.loginThrottle.LockedUntil(key, now)
+		if !locked {
+			continue
+		}
+
+		retryAfter := int(math.Ceil(lockedUntil.Sub(now).Seconds()))
+		if retryAfter < 1 {
+			retryAfter = 1
+		}
+		w.Header().Set(HeaderRetryAfter, strconv.Itoa(retryAfter))
+		a.errorResponse(w, r.URL.Path, http.StatusTooManyRequests, "too many failed login attempts, try again later", nil)
+		return false
+	}
+	return true
+}
+
+// recordLoginFailure counts the failed attempt against the address and the
+// account, and audits the lockouts it triggers.
+func (a *API) recordLoginFailure(r *http.Request, loginData LoginRequest) {
+	cfg := a.requestApp(r).GetConfig()
+	window := time.Duration(cfg.LoginAttemptWindow) * time.Second
+	lockout := time.Duration(cfg.LoginLockoutDuration) * time.Second
+	if lockout <= 0 {
+		return
+	}
+
+	now := time.Now()
+	ipKey, accountKey := a.loginThrottleKeys(r, loginData)
+	limits := map[string]int{
+		ipKey:      cfg.LoginMaxAttemptsPerIP,
+		accountKey: cfg.LoginMaxAttemptsPerAccount,
+	}
+	for key, limit := range limits {
+		if !a.loginThrottle.Fail(key, limit, window, lockout, now) {
+			continue
+		}
+
+		a.requestLogger(r).Warn("Login attempts locked out",
+			mlog.String("key", key),
+			mlog.Int("attempts", limit),
+		)
+		auditRec := a.makeAuditRecord(r, "loginLockout", audit.Success)
+		auditRec.AddMeta("key", key)
+		auditRec.AddMeta("attempts", limit)
+		auditRec.AddMeta("lockoutSeconds", cfg.LoginLockoutDuration)
+		a.audit.LogRecord(audit.LevelAuth, auditRec)
+	}
+}
+
+// resetLoginFailures forgets the failed attempts of the account after a
+// successful login. The address keeps its count since it may be trying
+// several accounts.
+func (a *API) resetLoginFailures(r *http.Request, loginData LoginRequest) {
+	_, accountKey := a.loginThrottleKeys(r, loginData)
+	a.loginThrottle.Reset(accountKey)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetMyUnreadMentions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /users/me/mentions getMyUnreadMentions
+	//
+	// Returns the mentions of the current user that weren't read yet, oldest first
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Mention"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	mentions, err := a.requestApp(r).GetUnreadMentions(userID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(mentions)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleMarkMyMentionsRead(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/mentions/read markMyMentionsRead
+	//
+	// Marks mentions of the current user as read
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: Body
+	//   in: body
+	//   description: the mentions to mark, all unread mentions if empty
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/MentionsReadRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	userID := getUserID(r)
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var request model.MentionsReadRequest
+	if len(requestBody) != 0 {
+		if err = json.Unmarshal(requestBody, &request); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "markMentionsRead", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("mentionCount", len(request.MentionIDs))
+
+	if err = a.requestApp(r).MarkMentionsRead(userID, request.MentionIDs); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
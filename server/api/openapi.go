@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/swagger"
+)
+
+func (a *API) handleGetOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /openapi.json getOpenAPISpec
+	//
+	// Returns the OpenAPI 3 specification of the API, to generate clients
+	//
+	// ---
+	// produces:
+	// - application/json
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: object
+	jsonBytesResponse(w, http.StatusOK, swagger.OpenAPISpec)
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+// maxDataURLPrefixLength is the room left for the data:image/...;base64,
+// prefix when limiting the size of a pasted image sent as a data URL.
+const maxDataURLPrefixLength = 256
+
+func (a *API) handlePasteImage(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/cards/{cardID}/images pasteImage
+	//
+	// Saves an image pasted into a card and adds an image block for it at
+	// the end of the card's content, in a single request. The image is sent
+	// as the request body, either as is or as a base64 data URL, and its
+	// filename is generated from its detected type
+	//
+	// ---
+	// consumes:
+	// - image/png
+	// - image/jpeg
+	// - image/gif
+	// - image/webp
+	// - text/plain
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the image bytes, or a data URL like data:image/png;base64,...
+	//   required: true
+	//   schema:
+	//     type: string
+	//     format: binary
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success, with the created image block
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '400':
+	//     description: the body is not a supported image
+	//   '404':
+	//     description: card not found
+	//   '413':
+	//     description: the image is larger than the maximum file size, with an UploadRestrictedResponse, or the attachment storage limit is reached
+	//     schema:
+	//       "$ref": "#/definitions/AttachmentStorageLimitReachedResponse"
+	//   '415':
+	//     description: the image extension is blocked or not allowed
+	//     schema:
+	//       "$ref": "#/definitions/UploadRestrictedResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	boardID := vars["boardID"]
+	cardID := vars["cardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	board, err := a.requestApp(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if board == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", nil)
+		return
+	}
+
+	restrictions, err := a.requestApp(r).GetTeamUploadRestrictions(board.TeamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	if maxSize := restrictions.Restrictions.MaxFileSize; maxSize > 0 {
+		// the exact size is checked once the image is decoded
+		r.Body = http.MaxBytesReader(w, r.Body, int64(base64.StdEncoding.EncodedLen(int(maxSize)))+maxDataURLPrefixLength)
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		if strings.HasSuffix(err.Error(), "http: request body too large") {
+			a.uploadRestrictedResponse(w, r, &model.ErrUploadRestricted{
+				Reason:       model.UploadRestrictedFileTooLarge,
+				Restrictions: restrictions.Restrictions,
+			})
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	data, err := model.DecodePastedImage(body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "pasteImage", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("cardID", cardID)
+	auditRec.AddMeta("size", len(data))
+
+	block, err := a.requestApp(r).PasteImage(board, cardID, data, userID)
+	if err != nil {
+		var errRestricted *model.ErrUploadRestricted
+		var errLimit *model.ErrAttachmentStorageLimitReached
+		switch {
+		case model.IsErrInvalidPastedImage(err):
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		case model.IsErrNotFound(err):
+			a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		case errors.As(err, &errRestricted):
+			a.uploadRestrictedResponse(w, r, errRestricted)
+		case errors.As(err, &errLimit):
+			a.attachmentStorageLimitResponse(w, r, errLimit)
+		default:
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		}
+		return
+	}
+
+	blockData, err := json.Marshal(block)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, blockData)
+	auditRec.AddMeta("blockID", block.ID)
+	auditRec.AddMeta("fileID", block.Fields["fileId"])
+	auditRec.Success()
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/ratelimit"
+	"github.com/mattermost/focalboard/server/services/redis"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	HeaderRetryAfter = "Retry-After"
+
+	rateLimitRedisPrefix = "focalboard:ratelimit:"
+)
+
+// newRequestLimiter returns the store of the API rate limits, shared through
+// Redis when configured.
+func newRequestLimiter(cfg *config.Configuration) ratelimit.Store {
+	if cfg == nil || cfg.RateLimitRedisAddress == "" {
+		return ratelimit.NewMemoryStore()
+	}
+	client := redis.NewClient(redis.Options{
+		Address:  cfg.RateLimitRedisAddress,
+		Password: cfg.RateLimitRedisPassword,
+	})
+	return ratelimit.NewRedisStore(client, rateLimitRedisPrefix)
+}
+
+// rateLimitByIP rejects the requests of the client addresses exceeding the
+// configured rate.
+func (a *API) rateLimitByIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := a.app.GetConfig()
+		rate := ratelimit.Rate{PerSecond: cfg.RateLimitIPPerSecond, Burst: cfg.RateLimitIPBurst}
+		if a.allowRequest(w, r, "ip:"+a.getClientIP(r), rate, "too many requests, try again later") {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// rateLimitByUser rejects the requests of the users exceeding the configured
+// rate. Requests without a session are only limited by address.
+func (a *API) rateLimitByUser(handler func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		userID := getUserID(r)
+		if userID != "" {
+			cfg := a.requestApp(r).GetConfig()
+			rate := ratelimit.Rate{PerSecond: cfg.RateLimitUserPerSecond, Burst: cfg.RateLimitUserBurst}
+			if !a.allowRequest(w, r, "user:"+userID, rate, "too many requests, try again later") {
+				return
+			}
+		}
+		handler(w, r)
+	}
+}
+
+// allowRequest takes a token for the key and writes a 429 response with
+// the message when there is none left. Requests are allowed if the limits can't be checked
+// so that an unavailable Redis doesn't take the API down.
+func (a *API) allowRequest(w http.ResponseWriter, r *http.Request, key string, rate ratelimit.Rate, message string) bool {
+	if !rate.Enabled() {
+		return true
+	}
+
+	result, err := a.requestLimiter.Take(key, rate, time.Now())
+	if err != nil {
+		a.requestLogger(r).Warn("Cannot check the API rate limit", mlog.String("key", key), mlog.Err(err))
+		return true
+	}
+	if result.Allowed {
+		return true
+	}
+
+	retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set(HeaderRetryAfter, strconv.Itoa(retryAfter))
+	a.errorResponse(w, r.URL.Path, http.StatusTooManyRequests, message, nil)
+	return false
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// requestIDPattern matches the request IDs accepted from the clients, so
+// that they can't inject arbitrary content in the logs.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// requestIDHandler assigns an ID to each request, the one sent by the
+// client if valid, and returns it in the response headers. The ID is
+// included in the logs of the request, including the ones of the app
+// and the store, and in its error responses.
+func (a *API) requestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(HeaderRequestID)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = utils.NewID(utils.IDTypeNone)
+		}
+		w.Header().Set(HeaderRequestID, requestID)
+
+		ctx := context.WithValue(r.Context(), requestIDContextKey, requestID)
+		logger := a.logger.With(mlog.String("request_id", requestID))
+		ctx = context.WithValue(ctx, loggerContextKey, logger)
+		ctx = context.WithValue(ctx, appContextKey, a.app.WithLogger(logger))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// getRequestID returns the ID of the request, empty if it has none.
+func getRequestID(r *http.Request) string {
+	requestID, _ := r.Context().Value(requestIDContextKey).(string)
+	return requestID
+}
+
+// requestLogger returns the logger of the request, which adds its ID to
+// the log lines.
+func (a *API) requestLogger(r *http.Request) *mlog.Logger {
+	if logger, ok := r.Context().Value(loggerContextKey).(*mlog.Logger); ok {
+		return logger
+	}
+	return a.logger
+}
+
+// requestApp returns the app to serve the request with, which logs with
+// the logger of the request.
+func (a *API) requestApp(r *http.Request) *app.App {
+	if requestApp, ok := r.Context().Value(appContextKey).(*app.App); ok {
+		return requestApp
+	}
+	return a.app
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleSearch(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/search search
+	//
+	// Returns a page of the boards, cards, comments and attachments of the
+	// team that match a search, most relevant first, with the number of
+	// results of each type
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The search terms, separated by spaces, and the filters property:Name=Value, assignee:@username (or @me), updated_after:YYYY-MM-DD and updated_before:YYYY-MM-DD. Names and values with spaces go between double quotes
+	//   required: true
+	//   type: string
+	// - name: type
+	//   in: query
+	//   description: The type of results to return, one of board, card, comment or attachment. Can be repeated, all types by default
+	//   required: false
+	//   type: array
+	//   items:
+	//     type: string
+	//   collectionFormat: multi
+	// - name: page
+	//   in: query
+	//   description: The page to return, starting at 0
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: The number of results per page, 20 by default
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/SearchResults"
+	//   '400':
+	//     description: invalid type, filter or paging
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	query := r.URL.Query()
+	opts := model.SearchOptions{
+		PerPage: model.DefaultSearchResultsPerPage,
+	}
+	for _, typeParam := range query["type"] {
+		resultType, err := model.ParseSearchResultType(typeParam)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		opts.Types = append(opts.Types, resultType)
+	}
+	if pageParam := query.Get("page"); pageParam != "" {
+		page, err := strconv.Atoi(pageParam)
+		if err != nil || page < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid page", err)
+			return
+		}
+		opts.Page = page
+	}
+	if perPageParam := query.Get("per_page"); perPageParam != "" {
+		perPage, err := strconv.Atoi(perPageParam)
+		if err != nil || perPage <= 0 || perPage > model.MaxSearchResultsPerPage {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid per_page", err)
+			return
+		}
+		opts.PerPage = perPage
+	}
+
+	terms, filters, err := model.ParseSearchQuery(query.Get("q"))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	opts.Terms = terms
+	opts.Filters = filters
+
+	auditRec := a.makeAuditRecord(r, "search", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	results, err := a.requestApp(r).Search(teamID, userID, opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("total", results.Total)
+	auditRec.Success()
+}
+
+func (a *API) handleSearchCards(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/cards/search searchCards
+	//
+	// Returns a page of the cards of the team whose title, text or comments
+	// match a full-text search, most relevant first, with the matching
+	// fragments highlighted
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: The search terms, separated by spaces, and the filters property:Name=Value, assignee:@username (or @me), updated_after:YYYY-MM-DD and updated_before:YYYY-MM-DD. Names and values with spaces go between double quotes
+	//   required: true
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to return, starting at 0
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: The number of results per page, 20 by default
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/CardSearchResults"
+	//   '400':
+	//     description: invalid filter or paging
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	query := r.URL.Query()
+	opts := model.SearchOptions{
+		PerPage: model.DefaultSearchResultsPerPage,
+	}
+	if pageParam := query.Get("page"); pageParam != "" {
+		page, err := strconv.Atoi(pageParam)
+		if err != nil || page < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid page", err)
+			return
+		}
+		opts.Page = page
+	}
+	if perPageParam := query.Get("per_page"); perPageParam != "" {
+		perPage, err := strconv.Atoi(perPageParam)
+		if err != nil || perPage <= 0 || perPage > model.MaxSearchResultsPerPage {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid per_page", err)
+			return
+		}
+		opts.PerPage = perPage
+	}
+
+	terms, filters, err := model.ParseSearchQuery(query.Get("q"))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	opts.Terms = terms
+	opts.Filters = filters
+
+	auditRec := a.makeAuditRecord(r, "searchCards", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	results, err := a.requestApp(r).SearchCards(teamID, userID, opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("total", results.Total)
+	auditRec.Success()
+}
+
+func (a *API) handleAdminReindexSearch(w http.ResponseWriter, r *http.Request) {
+	auditRec := a.makeAuditRecord(r, "adminReindexSearch", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+
+	job, err := a.requestApp(r).ReindexSearchAsync(model.SystemUserID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.jobAcceptedResponse(w, r, job)
+	auditRec.AddMeta("jobID", job.ID)
+	auditRec.Success()
+}
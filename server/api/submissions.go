@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/ratelimit"
+)
+
+const (
+	defaultSubmissionsPerMinute = 5
+	maxSubmissionBodySize       = 64 * 1024
+)
+
+func (a *API) handleSubmitCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/submissions submitCard
+	//
+	// Creates a card in the intake group of a publicly shared board that
+	// accepts anonymous submissions
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: read_token
+	//   in: query
+	//   description: Read token of the shared board
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the submitted card
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CardSubmission"
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '429':
+	//     description: too many submissions
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+
+	if !a.requestApp(r).GetClientConfig().EnablePublicSharedBoards {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"public shared boards are disabled"})
+		return
+	}
+
+	if !a.hasValidReadTokenForBoard(r, boardID) {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "", PermissionError{"access denied to board"})
+		return
+	}
+
+	if !a.allowSubmission(w, r, boardID) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(io.LimitReader(r.Body, maxSubmissionBodySize))
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var submission *model.CardSubmission
+	if err = json.Unmarshal(requestBody, &submission); err != nil || submission == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid submission", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "submitCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+
+	if !a.verifySubmissionCaptcha(w, r, submission.CaptchaResponse) {
+		return
+	}
+
+	card, err := a.requestApp(r).SubmitCard(boardID, submission)
+	if err != nil {
+		a.submissionErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("cardID", card.ID)
+	auditRec.Success()
+}
+
+// allowSubmission applies the rate limit of the anonymous submissions
+// for the client and the given key, writing the error response if the
+// limit is reached.
+func (a *API) allowSubmission(w http.ResponseWriter, r *http.Request, key string) bool {
+	limit := a.requestApp(r).GetConfig().SubmissionsPerMinute
+	if limit <= 0 {
+		limit = defaultSubmissionsPerMinute
+	}
+	// the limit is spread over the minute, with bursts of up to the limit
+	rate := ratelimit.Rate{PerSecond: float64(limit) / 60, Burst: limit}
+	return a.allowRequest(w, r, "submission:"+key+"/"+a.getClientIP(r), rate, "too many submissions, try again later")
+}
+
+// verifySubmissionCaptcha checks the captcha response of an anonymous
+// submission if the server requires one, writing the error response if
+// the check fails.
+func (a *API) verifySubmissionCaptcha(w http.ResponseWriter, r *http.Request, response string) bool {
+	if !a.requestApp(r).IsCaptchaRequired() {
+		return true
+	}
+
+	if err := a.requestApp(r).VerifyCaptcha(response, a.getClientIP(r)); err != nil {
+		if errors.Is(err, model.ErrInvalidCaptcha) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return false
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return false
+	}
+	return true
+}
+
+func (a *API) submissionErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, model.ErrSubmissionsDisabled):
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, err.Error(), err)
+	case model.IsErrInvalidSubmission(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		if a.cardValidationErrorResponse(w, r, err) {
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
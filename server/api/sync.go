@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleSyncBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /boards/{boardID}/sync syncBoard
+	//
+	// Applies a batch of operations with client generated ids on top of
+	// the base revision of the client, and returns the authoritative
+	// operations of the board since that revision. Operations that
+	// conflict with changes made after the base revision are not applied
+	// and are returned as conflicts
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the operations to apply and the base revision
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/SyncRequest"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/SyncResponse"
+	//   '400':
+	//     description: invalid request
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	boardID := mux.Vars(r)["boardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionManageBoardCards) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to make board changes"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var req model.SyncRequest
+	if err = json.Unmarshal(requestBody, &req); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if err = req.IsValid(boardID); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	if !a.checkSyncOperations(w, r, userID, boardID, req.Operations) {
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "syncBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("boardID", boardID)
+	auditRec.AddMeta("baseRevision", req.BaseRevision)
+	auditRec.AddMeta("operationCount", len(req.Operations))
+
+	resp, err := a.requestApp(r).SyncBoard(boardID, &req, userID)
+	if err != nil {
+		if model.IsErrInvalidSyncRequest(err) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.requestLogger(r).Debug("SyncBoard",
+		mlog.String("boardID", boardID),
+		mlog.Int("applied", len(resp.Applied)),
+		mlog.Int("conflicts", len(resp.Conflicts)),
+	)
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("revision", resp.Revision)
+	auditRec.Success()
+}
+
+// checkSyncOperations makes, for every operation of a sync request, the
+// checks the block APIs make before the change: restricted views can only
+// be changed by the users allowed to manage them, and locked properties by
+// the users allowed to edit them. It writes the error response and returns
+// false if the request can't be applied.
+func (a *API) checkSyncOperations(w http.ResponseWriter, r *http.Request, userID, boardID string, operations []*model.SyncOperation) bool {
+	for _, op := range operations {
+		if op.Type == model.SyncOperationInsert {
+			if !a.checkRestrictedView(w, r, userID, op.Block, nil) {
+				return false
+			}
+			continue
+		}
+
+		block, err := a.requestApp(r).GetBlockByID(op.BlockID)
+		if err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+			return false
+		}
+		if block == nil || block.BoardID != boardID {
+			// the sync reports the operation as a conflict
+			continue
+		}
+
+		var patch *model.BlockPatch
+		if op.Type == model.SyncOperationPatch {
+			patch = op.Patch
+			if !a.checkLockedCardProperties(w, r, userID, block, patch) {
+				return false
+			}
+		}
+		if !a.checkRestrictedView(w, r, userID, block, patch) {
+			return false
+		}
+	}
+	return true
+}
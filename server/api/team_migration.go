@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// UserMapFormKey is the form field holding the JSON user map of a team
+// migration import.
+const UserMapFormKey = "userMap"
+
+// MapUsersByEmailFormKey is the form field that enables matching the users
+// of a team migration import by email.
+const MapUsersByEmailFormKey = "mapUsersByEmail"
+
+func (a *API) handleExportTeamMigration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/migration/export exportTeamMigration
+	//
+	// Exports the boards of a team the user can view with their files,
+	// members, sharing settings, subscriptions and the categories of the
+	// users, to move the team to another server. The users of the team are
+	// listed in the archive to build the user map of the import. Only the
+	// team admins can export a team.
+	//
+	// ---
+	// produces:
+	// - application/octet-stream
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     content:
+	//       application-octet-stream:
+	//         type: string
+	//         format: binary
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team migration"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "exportTeamMigration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	filename := fmt.Sprintf("team-%s-%s%s", teamID, time.Now().Format("2006-01-02"), archiveExtension)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", "attachment; filename="+filename)
+	w.Header().Set("Content-Transfer-Encoding", "binary")
+
+	if err := a.requestApp(r).ExportTeamMigration(w, teamID, userID); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	auditRec.Success()
+}
+
+func (a *API) handleImportTeamMigration(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/migration/import importTeamMigration
+	//
+	// Imports a team migration archive into the team. The boards keep their
+	// ids and the ones that already exist are skipped. The users of the
+	// source server are translated with the user map, users missing from it
+	// keep their id if it exists on this server. Only the team admins can
+	// import a team.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// consumes:
+	// - multipart/form-data
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: file
+	//   in: formData
+	//   description: the team migration archive
+	//   required: true
+	//   type: file
+	// - name: userMap
+	//   in: formData
+	//   description: a JSON object mapping the user ids of the source server to the ones of this server
+	//   required: false
+	//   type: string
+	// - name: mapUsersByEmail
+	//   in: formData
+	//   description: match the users missing from the user map by email
+	//   required: false
+	//   type: boolean
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamMigrationResult"
+	//   '400':
+	//     description: invalid archive or user map
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team migration"})
+		return
+	}
+
+	file, handle, err := r.FormFile(UploadFormFileKey)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	defer file.Close()
+
+	userMap := map[string]string{}
+	if data := r.FormValue(UserMapFormKey); data != "" {
+		if err = json.Unmarshal([]byte(data), &userMap); err != nil {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid user map", err)
+			return
+		}
+	}
+
+	auditRec := a.makeAuditRecord(r, "importTeamMigration", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("filename", handle.Filename)
+	auditRec.AddMeta("size", handle.Size)
+
+	result, err := a.requestApp(r).ImportTeamMigration(file, model.TeamMigrationOptions{
+		TeamID:          teamID,
+		ModifiedBy:      userID,
+		UserMap:         userMap,
+		MapUsersByEmail: r.FormValue(MapUsersByEmailFormKey) == "true",
+	})
+	if err != nil {
+		var errVersion model.ErrUnsupportedArchiveVersion
+		if errors.Is(err, model.ErrInvalidMigrationArchive) || errors.As(err, &errVersion) {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	a.requestLogger(r).Debug("ImportTeamMigration",
+		mlog.String("teamID", teamID),
+		mlog.Int("boards", len(result.BoardIDs)),
+		mlog.Int("skipped", len(result.SkippedBoardIDs)),
+		mlog.Int("blocks", result.BlockCount),
+	)
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+
+	auditRec.AddMeta("boardCount", len(result.BoardIDs))
+	auditRec.Success()
+}
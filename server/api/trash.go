@@ -0,0 +1,366 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleGetTeamTrash(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/trash getTeamTrash
+	//
+	// Returns the boards and cards of a team deleted within the trash
+	// retention period, the most recently deleted first. The team admins
+	// get all the items, the other users the items of the boards they
+	// administer
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/TrashItem"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team trash"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getTeamTrash", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	items, err := a.requestApp(r).GetTeamTrash(teamID, time.Now())
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		managed := []*model.TrashItem{}
+		for _, item := range items {
+			if a.permissions.HasPermissionToBoard(userID, item.BoardID, model.PermissionDeleteBoard) {
+				managed = append(managed, item)
+			}
+		}
+		items = managed
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("itemCount", len(items))
+	auditRec.Success()
+}
+
+func (a *API) handleRestoreTrashBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/trash/boards/{boardID}/restore restoreTrashBoard
+	//
+	// Restores a board from the trash of a team. Restricted to the team
+	// admins and the admins of the board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Board"
+	//   '404':
+	//     description: board not in the trash
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	boardID := vars["boardID"]
+	userID := getUserID(r)
+
+	if !a.canManageTrash(userID, teamID, boardID) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board trash"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "restoreTrashBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.requestApp(r).RestoreTrashBoard(teamID, boardID, userID, time.Now()); err != nil {
+		a.trashErrorResponse(w, r, err)
+		return
+	}
+
+	board, err := a.requestApp(r).GetBoard(boardID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(board)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePurgeTrashBoard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/trash/boards/{boardID} purgeTrashBoard
+	//
+	// Permanently deletes a board from the trash of a team, along with its
+	// cards and files. Restricted to the team admins and the admins of the
+	// board
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: boardID
+	//   in: path
+	//   description: Board ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: board not in the trash
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	boardID := vars["boardID"]
+	userID := getUserID(r)
+
+	if !a.canManageTrash(userID, teamID, boardID) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board trash"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "purgeTrashBoard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("boardID", boardID)
+
+	if err := a.requestApp(r).PurgeTrashBoard(teamID, boardID, time.Now()); err != nil {
+		a.trashErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleRestoreTrashCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/trash/cards/{cardID}/restore restoreTrashCard
+	//
+	// Restores a card from the trash of a team, along with the content
+	// deleted with it. Restricted to the team admins and the admins of the
+	// board of the card
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Block"
+	//   '404':
+	//     description: card not in the trash
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	cardID := vars["cardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team trash"})
+		return
+	}
+
+	card, err := a.requestApp(r).GetTrashCard(teamID, cardID, time.Now())
+	if err != nil {
+		a.trashErrorResponse(w, r, err)
+		return
+	}
+	if !a.canManageTrash(userID, teamID, card.BoardID) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board trash"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "restoreTrashCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("cardID", cardID)
+
+	card, err = a.requestApp(r).RestoreTrashCard(teamID, cardID, userID, time.Now())
+	if err != nil {
+		a.trashErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handlePurgeTrashCard(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/trash/cards/{cardID} purgeTrashCard
+	//
+	// Permanently deletes a card from the trash of a team, along with the
+	// content and files deleted with it. Restricted to the team admins and
+	// the admins of the board of the card
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: cardID
+	//   in: path
+	//   description: Card ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: card not in the trash
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	cardID := vars["cardID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team trash"})
+		return
+	}
+
+	card, err := a.requestApp(r).GetTrashCard(teamID, cardID, time.Now())
+	if err != nil {
+		a.trashErrorResponse(w, r, err)
+		return
+	}
+	if !a.canManageTrash(userID, teamID, card.BoardID) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to board trash"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "purgeTrashCard", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("cardID", cardID)
+
+	if err := a.requestApp(r).PurgeTrashCard(teamID, cardID, time.Now()); err != nil {
+		a.trashErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// canManageTrash returns true if the user can restore and purge the
+// deleted items of the board, as an admin of the team or of the board.
+func (a *API) canManageTrash(userID, teamID, boardID string) bool {
+	return a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) ||
+		a.permissions.HasPermissionToBoard(userID, boardID, model.PermissionDeleteBoard)
+}
+
+func (a *API) trashErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	if model.IsErrNotFound(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+		return
+	}
+	a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetTeamUploadRestrictions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/upload_restrictions getTeamUploadRestrictions
+	//
+	// Returns the maximum size and the allowed and blocked extensions of the
+	// files uploaded to the team, with the overrides of the team
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamUploadRestrictions"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	restrictions, err := a.requestApp(r).GetTeamUploadRestrictions(teamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(restrictions)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+}
+
+func (a *API) handleUpdateTeamUploadRestrictions(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /teams/{teamID}/upload_restrictions updateTeamUploadRestrictions
+	//
+	// Replaces the upload restrictions of the team that override the ones
+	// of the server configuration. Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the overridden restrictions, the others are the ones of the configuration
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/UploadRestrictionsOverrides"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/TeamUploadRestrictions"
+	//   '400':
+	//     description: invalid size or extension
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team upload restrictions"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var overrides model.UploadRestrictionsOverrides
+	if err = json.Unmarshal(requestBody, &overrides); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "updateTeamUploadRestrictions", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("overrides", overrides)
+
+	restrictions, err := a.requestApp(r).UpdateTeamUploadRestrictions(teamID, overrides)
+	if model.IsErrInvalidUploadRestrictions(err) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(restrictions)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+// uploadRestrictedResponse writes the response for an upload rejected by
+// the upload restrictions, with the restrictions so that clients can tell
+// the user which files are accepted.
+func (a *API) uploadRestrictedResponse(w http.ResponseWriter, r *http.Request, errRestricted *model.ErrUploadRestricted) {
+	code := http.StatusUnsupportedMediaType
+	if errRestricted.Reason == model.UploadRestrictedFileTooLarge {
+		code = http.StatusRequestEntityTooLarge
+	}
+
+	a.requestLogger(r).Debug("API DEBUG",
+		mlog.Int("code", code),
+		mlog.Err(errRestricted),
+		mlog.String("api", r.URL.Path),
+	)
+	data, err := json.Marshal(model.UploadRestrictedResponse{
+		Error:        errRestricted.Error(),
+		ErrorCode:    code,
+		Reason:       errRestricted.Reason,
+		Extension:    errRestricted.Extension,
+		Restrictions: errRestricted.Restrictions,
+	})
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+	jsonBytesResponse(w, code, data)
+}
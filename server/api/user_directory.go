@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+)
+
+func (a *API) handleSearchUserDirectory(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/users/directory searchUserDirectory
+	//
+	// Returns a page of the team members whose username, nickname, first or
+	// last name starts with a prefix, sorted by username, for the mention
+	// autocomplete and the person property pickers
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: prefix
+	//   in: query
+	//   description: The prefix to match, case insensitive. All the members are returned when empty
+	//   required: false
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: The page to return, starting at 0
+	//   required: false
+	//   type: integer
+	// - name: per_page
+	//   in: query
+	//   description: The number of users per page, 20 by default
+	//   required: false
+	//   type: integer
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/UserDirectoryPage"
+	//   '400':
+	//     description: invalid paging
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team"})
+		return
+	}
+
+	query := r.URL.Query()
+	opts := model.UserDirectoryOptions{
+		Prefix:  query.Get("prefix"),
+		PerPage: model.DefaultUserDirectoryPerPage,
+	}
+	if pageParam := query.Get("page"); pageParam != "" {
+		page, err := strconv.Atoi(pageParam)
+		if err != nil || page < 0 {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid page", err)
+			return
+		}
+		opts.Page = page
+	}
+	if perPageParam := query.Get("per_page"); perPageParam != "" {
+		perPage, err := strconv.Atoi(perPageParam)
+		if err != nil || perPage <= 0 || perPage > model.MaxUserDirectoryPerPage {
+			a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid per_page", err)
+			return
+		}
+		opts.PerPage = perPage
+	}
+
+	auditRec := a.makeAuditRecord(r, "searchUserDirectory", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	page, err := a.requestApp(r).SearchUserDirectory(teamID, opts)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("userCount", len(page.Users))
+	auditRec.Success()
+}
@@ -0,0 +1,296 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/auth"
+)
+
+// PasswordResetRequest is a request to email a password reset link
+// swagger:model
+type PasswordResetRequest struct {
+	// The email address of the user
+	// required: true
+	Email string `json:"email"`
+}
+
+// CompletePasswordResetRequest sets a new password with a password reset token
+// swagger:model
+type CompletePasswordResetRequest struct {
+	// The token of the password reset link
+	// required: true
+	Token string `json:"token"`
+
+	// New password
+	// required: true
+	NewPassword string `json:"newPassword"`
+}
+
+// VerifyEmailRequest verifies an email address with a verification token
+// swagger:model
+type VerifyEmailRequest struct {
+	// The token of the email verification link
+	// required: true
+	Token string `json:"token"`
+}
+
+func (a *API) handleRequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /password_reset requestPasswordReset
+	//
+	// Emails a link to reset their password to the user with the email
+	// address. Succeeds whether a user has the address or not.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Password reset request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/PasswordResetRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: invalid request
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '501':
+	//     description: email not configured, or plugin mode
+	//   '500':
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkUserTokensAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var requestData PasswordResetRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	requestData.Email = strings.TrimSpace(requestData.Email)
+	if !auth.IsEmailValid(requestData.Email) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "invalid email format", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "requestPasswordReset", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+	auditRec.AddMeta("email", requestData.Email)
+
+	err = a.requestApp(r).SendPasswordReset(requestData.Email)
+	if errors.Is(err, app.ErrMailUnavailable) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleCompletePasswordReset(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /password_reset/complete completePasswordReset
+	//
+	// Sets a new password with the token of a password reset link, and logs
+	// the user out of all their sessions
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Password reset completion request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/CompletePasswordResetRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: invalid or expired token, or new password not meeting the password policy
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '501':
+	//     description: plugin mode
+	//   '500':
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkUserTokensAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var requestData CompletePasswordResetRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	if requestData.NewPassword == "" {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "new password is required", nil)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "completePasswordReset", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	err = a.requestApp(r).ResetPassword(requestData.Token, requestData.NewPassword)
+	var invalidPassword *auth.InvalidPasswordError
+	if errors.Is(err, app.ErrInvalidUserToken) || errors.As(err, &invalidPassword) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleSendEmailVerification(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /users/me/verify_email sendEmailVerification
+	//
+	// Emails a link to verify their email address to the current user
+	//
+	// ---
+	// produces:
+	// - application/json
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '501':
+	//     description: email not configured, or plugin mode
+	//   '500':
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkUserTokensAllowed(w, r) {
+		return
+	}
+
+	userID := getUserID(r)
+
+	auditRec := a.makeAuditRecord(r, "sendEmailVerification", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	err := a.requestApp(r).SendEmailVerification(userID)
+	if errors.Is(err, app.ErrMailUnavailable) {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) handleVerifyEmail(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /verify_email verifyEmail
+	//
+	// Marks the email address of a user as verified with the token of an
+	// email verification link
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Email verification request
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/VerifyEmailRequest"
+	// responses:
+	//   '200':
+	//     description: success
+	//   '400':
+	//     description: invalid or expired token
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	//   '501':
+	//     description: plugin mode
+	//   '500':
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+	if !a.checkUserTokensAllowed(w, r) {
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var requestData VerifyEmailRequest
+	if err = json.Unmarshal(requestBody, &requestData); err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "verifyEmail", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelAuth, auditRec)
+
+	err = a.requestApp(r).VerifyEmail(requestData.Token)
+	if errors.Is(err, app.ErrInvalidUserToken) {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+// checkUserTokensAllowed writes an error response and returns false in the
+// modes without native accounts: the Mattermost users are managed by
+// Mattermost and the single user has no password.
+func (a *API) checkUserTokensAllowed(w http.ResponseWriter, r *http.Request) bool {
+	if a.MattermostAuth {
+		a.errorResponse(w, r.URL.Path, http.StatusNotImplemented, "not permitted in plugin mode", nil)
+		return false
+	}
+
+	if len(a.singleUserToken) > 0 {
+		a.errorResponse(w, r.URL.Path, http.StatusUnauthorized, "not permitted in single-user mode", nil)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// checkRestrictedView ensures that only the users allowed to manage
+// restricted views change them, or restrict a view or lift its restriction.
+// The patch is nil when the block is created or deleted. It writes the error
+// response and returns false if the change can't be applied.
+func (a *API) checkRestrictedView(w http.ResponseWriter, r *http.Request, userID string, block *model.Block, patch *model.BlockPatch) bool {
+	if !model.IsViewRestricted(block) && !model.ChangesViewRestriction(block, patch) {
+		return true
+	}
+	if a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionManageRestrictedViews) {
+		return true
+	}
+
+	a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to restricted view"})
+	return false
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/audit"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *API) handleGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /teams/{teamID}/webhooks getWebhooks
+	//
+	// Returns the webhooks of a team, without their secrets. Restricted to
+	// team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       type: array
+	//       items:
+	//         "$ref": "#/definitions/Webhook"
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team webhooks"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "getWebhooks", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelRead, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+
+	webhooks, err := a.requestApp(r).GetWebhooks(teamID)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	data, err := json.Marshal(webhooks)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("webhookCount", len(webhooks))
+	auditRec.Success()
+}
+
+func (a *API) handleCreateWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /teams/{teamID}/webhooks createWebhook
+	//
+	// Registers a webhook on the team. The payloads are signed with
+	// HMAC-SHA256 in the X-Focalboard-Signature header, using the secret
+	// of the webhook, which is generated if not given and only returned
+	// by this call. Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the webhook to create
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Webhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Webhook"
+	//   '400':
+	//     description: invalid webhook
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	teamID := mux.Vars(r)["teamID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team webhooks"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var webhook *model.Webhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil || webhook == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "createWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("url", webhook.URL)
+
+	newWebhook, err := a.requestApp(r).CreateWebhook(teamID, webhook, userID)
+	if err != nil {
+		a.webhookErrorResponse(w, r, err)
+		return
+	}
+
+	a.requestLogger(r).Debug("CreateWebhook",
+		mlog.String("teamID", teamID),
+		mlog.String("webhookID", newWebhook.ID),
+	)
+
+	data, err := json.Marshal(newWebhook)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.AddMeta("webhookID", newWebhook.ID)
+	auditRec.Success()
+}
+
+func (a *API) handleUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /teams/{teamID}/webhooks/{webhookID} updateWebhook
+	//
+	// Replaces the URL and the filters of a webhook. The secret can't be
+	// changed. Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// - name: Body
+	//   in: body
+	//   description: the updated webhook
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/Webhook"
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//     schema:
+	//       "$ref": "#/definitions/Webhook"
+	//   '400':
+	//     description: invalid webhook
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	webhookID := vars["webhookID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team webhooks"})
+		return
+	}
+
+	requestBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	var webhook *model.Webhook
+	if err = json.Unmarshal(requestBody, &webhook); err != nil || webhook == nil {
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, "", err)
+		return
+	}
+	webhook.ID = webhookID
+
+	auditRec := a.makeAuditRecord(r, "updateWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	updated, err := a.requestApp(r).UpdateWebhook(teamID, webhook)
+	if err != nil {
+		a.webhookErrorResponse(w, r, err)
+		return
+	}
+
+	data, err := json.Marshal(updated)
+	if err != nil {
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+		return
+	}
+
+	jsonBytesResponse(w, http.StatusOK, data)
+	auditRec.Success()
+}
+
+func (a *API) handleDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /teams/{teamID}/webhooks/{webhookID} deleteWebhook
+	//
+	// Deletes a webhook. Restricted to team admins
+	//
+	// ---
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: teamID
+	//   in: path
+	//   description: Team ID
+	//   required: true
+	//   type: string
+	// - name: webhookID
+	//   in: path
+	//   description: Webhook ID
+	//   required: true
+	//   type: string
+	// security:
+	// - BearerAuth: []
+	// responses:
+	//   '200':
+	//     description: success
+	//   '404':
+	//     description: webhook not found
+	//   default:
+	//     description: internal error
+	//     schema:
+	//       "$ref": "#/definitions/ErrorResponse"
+
+	vars := mux.Vars(r)
+	teamID := vars["teamID"]
+	webhookID := vars["webhookID"]
+	userID := getUserID(r)
+
+	if !a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionManageTeam) {
+		a.errorResponse(w, r.URL.Path, http.StatusForbidden, "", PermissionError{"access denied to team webhooks"})
+		return
+	}
+
+	auditRec := a.makeAuditRecord(r, "deleteWebhook", audit.Fail)
+	defer a.audit.LogRecord(audit.LevelModify, auditRec)
+	auditRec.AddMeta("teamID", teamID)
+	auditRec.AddMeta("webhookID", webhookID)
+
+	if err := a.requestApp(r).DeleteWebhook(teamID, webhookID); err != nil {
+		a.webhookErrorResponse(w, r, err)
+		return
+	}
+
+	jsonStringResponse(w, http.StatusOK, "{}")
+	auditRec.Success()
+}
+
+func (a *API) webhookErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case model.IsErrInvalidWebhook(err):
+		a.errorResponse(w, r.URL.Path, http.StatusBadRequest, err.Error(), err)
+	case model.IsErrNotFound(err):
+		a.errorResponse(w, r.URL.Path, http.StatusNotFound, "", err)
+	default:
+		a.errorResponse(w, r.URL.Path, http.StatusInternalServerError, "", err)
+	}
+}
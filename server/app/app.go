@@ -1,13 +1,18 @@
 package app
 
 import (
+	"sync"
 	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/services/backup"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/featureflags"
+	"github.com/mattermost/focalboard/server/services/jobs"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/mattermost/focalboard/server/services/search"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/utils"
@@ -33,6 +38,11 @@ type Services struct {
 	Notifications    *notify.Service
 	Logger           *mlog.Logger
 	Permissions      permissions.PermissionsService
+	Jobs             *jobs.Service
+	FeatureFlags     *featureflags.Service
+	Backups          *backup.Service
+	SearchBackend    search.Backend
+	SearchCluster    *search.Cluster
 	SkipTemplateInit bool
 }
 
@@ -43,14 +53,44 @@ type App struct {
 	wsAdapter           ws.Adapter
 	filesBackend        filestore.FileBackend
 	webhook             *webhook.Client
+	permissions         permissions.PermissionsService
 	metrics             *metrics.Metrics
 	notifications       *notify.Service
+	jobs                *jobs.Service
+	featureFlags        *featureflags.Service
+	backups             *backup.Service
 	logger              *mlog.Logger
 	blockChangeNotifier *utils.CallbackQueue
+	searchIndex         search.Backend
+	searchCluster       *search.Cluster
+
+	// serializes the provisioning of the welcome boards of new users
+	welcomeBoardMu *sync.Mutex
+}
+
+// requestLoggerStore is implemented by the stores that can log with
+// the logger of a request.
+type requestLoggerStore interface {
+	WithLogger(logger *mlog.Logger) store.Store
+}
+
+// WithLogger returns a copy of the app that logs, and makes its store
+// log, with the given logger, so that the log lines of a request carry
+// its ID. The copy shares the services and state of the app.
+func (a *App) WithLogger(logger *mlog.Logger) *App {
+	app := *a
+	app.logger = logger
+	if s, ok := a.store.(requestLoggerStore); ok {
+		app.store = s.WithLogger(logger)
+	}
+	return &app
 }
 
 func (a *App) SetConfig(config *config.Configuration) {
 	a.config = config
+	if a.featureFlags != nil {
+		a.featureFlags.SetConfigFlags(config.FeatureFlags)
+	}
 }
 
 func (a *App) GetConfig() *config.Configuration {
@@ -65,10 +105,25 @@ func New(config *config.Configuration, wsAdapter ws.Adapter, services Services)
 		wsAdapter:           wsAdapter,
 		filesBackend:        services.FilesBackend,
 		webhook:             services.Webhook,
+		permissions:         services.Permissions,
 		metrics:             services.Metrics,
 		notifications:       services.Notifications,
+		jobs:                services.Jobs,
+		featureFlags:        services.FeatureFlags,
+		backups:             services.Backups,
 		logger:              services.Logger,
 		blockChangeNotifier: utils.NewCallbackQueue("blockChangeNotifier", blockChangeNotifierQueueSize, blockChangeNotifierPoolSize, services.Logger),
+		searchIndex:         services.SearchBackend,
+		searchCluster:       services.SearchCluster,
+		welcomeBoardMu:      &sync.Mutex{},
+	}
+	if app.searchIndex == nil {
+		// without a backend, the boards are indexed in memory
+		index, err := search.NewIndex("")
+		if err != nil {
+			services.Logger.Fatal("Unable to create the search index", mlog.Err(err))
+		}
+		app.searchIndex = index
 	}
 	app.initialize(services.SkipTemplateInit)
 	return app
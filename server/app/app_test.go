@@ -5,6 +5,8 @@ import (
 
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 func TestSetConfig(t *testing.T) {
@@ -20,3 +22,17 @@ func TestSetConfig(t *testing.T) {
 		require.True(t, th.App.config.EnablePublicSharedBoards)
 	})
 }
+
+func TestWithLogger(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	logger := th.logger.With(mlog.String("request_id", "request-1"))
+	requestApp := th.App.WithLogger(logger)
+
+	require.Same(t, logger, requestApp.logger)
+	require.NotSame(t, th.logger, logger)
+	require.Same(t, th.logger, th.App.logger)
+	require.Same(t, th.App.welcomeBoardMu, requestApp.welcomeBoardMu)
+	require.Equal(t, th.App.store, requestApp.store)
+}
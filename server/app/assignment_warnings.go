@@ -0,0 +1,44 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// assignmentWarnings returns warnings about the users newly assigned to
+// the card who may be away. oldCard is nil for new cards. The users whose
+// availability can't be checked are left out, as the assignment itself
+// has already been saved.
+func (a *App) assignmentWarnings(board *model.Board, oldCard, newCard *model.Block) []model.AssignmentWarning {
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil
+	}
+
+	var warnings []model.AssignmentWarning
+	for _, change := range model.ChangedCardAssignments(properties, oldCard, newCard) {
+		if change.AssignedID == "" {
+			continue
+		}
+
+		availability, err := a.store.GetUserAvailability(change.AssignedID)
+		if err != nil {
+			a.logger.Warn("cannot get availability of assigned user",
+				mlog.String("userID", change.AssignedID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		if !availability.IsAway() {
+			continue
+		}
+
+		warnings = append(warnings, model.AssignmentWarning{
+			PropertyID:   change.Property.ID,
+			PropertyName: change.Property.Name,
+			Availability: *availability,
+		})
+	}
+	return warnings
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignmentWarnings(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "assignee": true},
+			{"id": "reviewer", "name": "Reviewer", "type": model.PropertyTypePerson, "assignee": true},
+		},
+	}
+	cardWith := func(values map[string]interface{}) *model.Block {
+		return &model.Block{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard,
+			Fields: map[string]interface{}{"properties": values}}
+	}
+
+	t.Run("assigned user away", func(t *testing.T) {
+		away := &model.UserAvailability{UserID: "alice-id", StatusEmoji: "palm_tree", StatusText: "On a vacation"}
+		th.Store.EXPECT().GetUserAvailability("alice-id").Return(away, nil)
+
+		warnings := th.App.assignmentWarnings(board, nil, cardWith(map[string]interface{}{"owner": "alice-id"}))
+		require.Len(t, warnings, 1)
+		require.Equal(t, "owner", warnings[0].PropertyID)
+		require.Equal(t, "Owner", warnings[0].PropertyName)
+		require.Equal(t, *away, warnings[0].Availability)
+	})
+
+	t.Run("only new assignments", func(t *testing.T) {
+		oldCard := cardWith(map[string]interface{}{"owner": "alice-id"})
+		newCard := cardWith(map[string]interface{}{"owner": "alice-id", "reviewer": "bob-id"})
+		th.Store.EXPECT().GetUserAvailability("bob-id").Return(&model.UserAvailability{UserID: "bob-id", OutOfOffice: true}, nil)
+
+		warnings := th.App.assignmentWarnings(board, oldCard, newCard)
+		require.Len(t, warnings, 1)
+		require.Equal(t, "reviewer", warnings[0].PropertyID)
+		require.True(t, warnings[0].Availability.OutOfOffice)
+	})
+
+	t.Run("available users and unknown availability", func(t *testing.T) {
+		th.Store.EXPECT().GetUserAvailability("alice-id").Return(&model.UserAvailability{UserID: "alice-id"}, nil)
+		th.Store.EXPECT().GetUserAvailability("bob-id").Return(nil, errors.New("status unavailable"))
+
+		warnings := th.App.assignmentWarnings(board, nil, cardWith(map[string]interface{}{"owner": "alice-id", "reviewer": "bob-id"}))
+		require.Empty(t, warnings)
+	})
+}
@@ -16,6 +16,8 @@ const (
 	HoursPerDay      = 24
 	MinutesPerHour   = 60
 	SecondsPerMinute = 60
+
+	defaultPasswordMinimumLength = 8
 )
 
 // GetSession Get a user active session and refresh the session if is needed.
@@ -23,11 +25,23 @@ func (a *App) GetSession(token string) (*model.Session, error) {
 	return a.auth.GetSession(token)
 }
 
+// CheckRecentSession returns an error unless the session was created
+// recently enough for the endpoints that require a recent login.
+func (a *App) CheckRecentSession(session *model.Session) error {
+	return a.auth.CheckRecentSession(session)
+}
+
 // IsValidReadToken validates the read token for a block.
 func (a *App) IsValidReadToken(boardID string, readToken string) (bool, error) {
 	return a.auth.IsValidReadToken(boardID, readToken)
 }
 
+// IsSharedBoardAddressAllowed returns true if the share link of the board
+// can be used from the address.
+func (a *App) IsSharedBoardAddressAllowed(boardID string, remoteIP string) (bool, error) {
+	return a.auth.IsSharedBoardAddressAllowed(boardID, remoteIP)
+}
+
 // GetRegisteredUserCount returns the number of registered users.
 func (a *App) GetRegisteredUserCount() (int, error) {
 	return a.store.GetRegisteredUserCount()
@@ -130,8 +144,37 @@ func (a *App) Logout(sessionID string) error {
 	return nil
 }
 
+// passwordSettings returns the requirements of the configuration for the
+// passwords of the users.
+func (a *App) passwordSettings() auth.PasswordSettings {
+	minimumLength := a.config.PasswordMinimumLength
+	if minimumLength <= 0 {
+		minimumLength = defaultPasswordMinimumLength
+	}
+	return auth.PasswordSettings{
+		MinimumLength: minimumLength,
+		Lowercase:     a.config.PasswordRequireLowercase,
+		Uppercase:     a.config.PasswordRequireUppercase,
+		Number:        a.config.PasswordRequireNumber,
+		Symbol:        a.config.PasswordRequireSymbol,
+		BanCommon:     a.config.PasswordBanCommon,
+		Banned:        a.config.PasswordBannedList,
+	}
+}
+
 // RegisterUser creates a new user if the provided data is valid.
 func (a *App) RegisterUser(username, email, password string) error {
+	if err := a.checkNewUser(username, email, password); err != nil {
+		return err
+	}
+
+	_, err := a.createUser(username, email, password)
+	return err
+}
+
+// checkNewUser returns an error if the username or the email are taken or
+// the password doesn't meet the password policy.
+func (a *App) checkNewUser(username, email, password string) error {
 	var user *model.User
 	if username != "" {
 		var err error
@@ -149,17 +192,15 @@ func (a *App) RegisterUser(username, email, password string) error {
 		}
 	}
 
-	// TODO: Move this into the config
-	passwordSettings := auth.PasswordSettings{
-		MinimumLength: 6,
-	}
-
-	err := auth.IsPasswordValid(password, passwordSettings)
+	err := auth.IsPasswordValid(password, a.passwordSettings())
 	if err != nil {
 		return errors.Wrap(err, "Invalid password")
 	}
+	return nil
+}
 
-	err = a.store.CreateUser(&model.User{
+func (a *App) createUser(username, email, password string) (*model.User, error) {
+	user := &model.User{
 		ID:          utils.NewID(utils.IDTypeUser),
 		Username:    username,
 		Email:       email,
@@ -168,12 +209,13 @@ func (a *App) RegisterUser(username, email, password string) error {
 		AuthService: a.config.AuthMode,
 		AuthData:    "",
 		Props:       map[string]interface{}{},
-	})
-	if err != nil {
-		return errors.Wrap(err, "Unable to create the new user")
+	}
+	if err := a.store.CreateUser(user); err != nil {
+		return nil, errors.Wrap(err, "Unable to create the new user")
 	}
 
-	return nil
+	a.provisionWelcomeBoardForNewUser(user.ID)
+	return user, nil
 }
 
 func (a *App) UpdateUserPassword(username, password string) error {
@@ -204,6 +246,10 @@ func (a *App) ChangePassword(userID, oldPassword, newPassword string) error {
 		return errors.New("invalid username or password")
 	}
 
+	if err := auth.IsPasswordValid(newPassword, a.passwordSettings()); err != nil {
+		return errors.Wrap(err, "invalid new password")
+	}
+
 	err := a.store.UpdateUserPasswordByID(userID, auth.HashPassword(newPassword))
 	if err != nil {
 		return errors.Wrap(err, "unable to update password")
@@ -170,11 +170,12 @@ func TestChangePassword(t *testing.T) {
 		{"fail, missing login information", "", "", "", true},
 		{"fail, invalid userId", "badID", "", "", true},
 		{"fail, invalid password", mockUser.ID, "wrongPassword", "newPassword", true},
+		{"fail, new password too short", mockUser.ID, "testPassword", "short", true},
 		{"success, using username", mockUser.ID, "testPassword", "newPassword", false},
 	}
 
 	th.Store.EXPECT().GetUserByID("badID").Return(nil, errors.New("userID not found"))
-	th.Store.EXPECT().GetUserByID(mockUser.ID).Return(mockUser, nil).Times(2)
+	th.Store.EXPECT().GetUserByID(mockUser.ID).Return(mockUser, nil).Times(3)
 	th.Store.EXPECT().UpdateUserPasswordByID(mockUser.ID, gomock.Any()).Return(nil)
 
 	for _, test := range testcases {
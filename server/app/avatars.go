@@ -0,0 +1,147 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// KeyAvatarFileID holds the name of the image the user uploaded as
+	// their avatar, if any.
+	KeyAvatarFileID = KeyPrefix + "avatarFileID"
+
+	// avatarsDirectory is the directory of the files storage the uploaded
+	// avatars are kept in, in a directory per user.
+	avatarsDirectory = "avatars"
+
+	avatarSVGContentType = "image/svg+xml"
+)
+
+// UserAvatar is the image of the avatar of a user, with the entity tag
+// identifying its version for the caches.
+type UserAvatar struct {
+	Data        []byte
+	ContentType string
+	ETag        string
+}
+
+// GetUserAvatar returns the image the user uploaded as their avatar, or
+// an avatar with their initials if they didn't or if it can't be read.
+func (a *App) GetUserAvatar(userID string) (*UserAvatar, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, model.NewErrNotFound(userID)
+	}
+
+	if fileID, _ := user.Props[KeyAvatarFileID].(string); fileID != "" {
+		data, err := a.filesBackend.ReadFile(avatarPath(userID, fileID))
+		if err == nil {
+			return &UserAvatar{
+				Data:        data,
+				ContentType: http.DetectContentType(data),
+				ETag:        utils.ETag(userID, fileID),
+			}, nil
+		}
+		a.logger.Error("Cannot read the uploaded avatar, generating one",
+			mlog.String("userID", userID),
+			mlog.String("fileID", fileID),
+			mlog.Err(err),
+		)
+	}
+
+	data := model.GenerateAvatar(userID, user.Username)
+	return &UserAvatar{
+		Data:        data,
+		ContentType: avatarSVGContentType,
+		ETag:        utils.ETag(userID, data),
+	}, nil
+}
+
+// SetUserAvatar stores an image as the avatar of the user, in place of
+// the previous one.
+func (a *App) SetUserAvatar(userID string, data []byte) error {
+	ext, err := model.AvatarImageExtension(data)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader = bytes.NewReader(data)
+	if a.config.StripImageMetadata && strippedMetadataExtensions[ext] {
+		if reader, err = a.stripImageMetadata(reader); err != nil {
+			return fmt.Errorf("unable to read the avatar: %w", err)
+		}
+	}
+
+	fileID := utils.NewID(utils.IDTypeNone) + ext
+	if _, err = a.filesBackend.WriteFile(reader, avatarPath(userID, fileID)); err != nil {
+		return fmt.Errorf("unable to store the avatar in the files storage: %w", err)
+	}
+
+	patch := model.UserPropPatch{
+		UpdatedFields: map[string]string{KeyAvatarFileID: fileID},
+	}
+	if err = a.store.PatchUserProps(userID, patch); err != nil {
+		return err
+	}
+
+	a.removeAvatarFile(user)
+	return nil
+}
+
+// DeleteUserAvatar removes the uploaded avatar of the user, who gets the
+// avatar with their initials back.
+func (a *App) DeleteUserAvatar(userID string) error {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if _, ok := user.Props[KeyAvatarFileID]; !ok {
+		return nil
+	}
+
+	patch := model.UserPropPatch{
+		DeletedFields: []string{KeyAvatarFileID},
+	}
+	if err = a.store.PatchUserProps(userID, patch); err != nil {
+		return err
+	}
+
+	a.removeAvatarFile(user)
+	return nil
+}
+
+// removeAvatarFile removes the file of the avatar the user had uploaded,
+// if any. Failures are only logged, as the file isn't used anymore.
+func (a *App) removeAvatarFile(user *model.User) {
+	fileID, _ := user.Props[KeyAvatarFileID].(string)
+	if fileID == "" {
+		return
+	}
+	if err := a.filesBackend.RemoveFile(avatarPath(user.ID, fileID)); err != nil {
+		a.logger.Error("Cannot remove the previous avatar",
+			mlog.String("userID", user.ID),
+			mlog.String("fileID", fileID),
+			mlog.Err(err),
+		)
+	}
+}
+
+func avatarPath(userID, fileID string) string {
+	return filepath.Join(avatarsDirectory, userID, fileID)
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserAvatar(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("generated avatar", func(t *testing.T) {
+		user := &model.User{ID: "user-id", Username: "john.doe"}
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+
+		avatar, err := th.App.GetUserAvatar("user-id")
+		require.NoError(t, err)
+		require.Equal(t, avatarSVGContentType, avatar.ContentType)
+		require.Equal(t, model.GenerateAvatar("user-id", "john.doe"), avatar.Data)
+		require.NotEmpty(t, avatar.ETag)
+	})
+
+	t.Run("uploaded avatar", func(t *testing.T) {
+		user := &model.User{
+			ID:       "user-id",
+			Username: "john.doe",
+			Props:    map[string]interface{}{KeyAvatarFileID: "file.png"},
+		}
+		data := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+		th.FilesBackend.On("ReadFile", "avatars/user-id/file.png").Return(data, nil).Once()
+
+		avatar, err := th.App.GetUserAvatar("user-id")
+		require.NoError(t, err)
+		require.Equal(t, "image/png", avatar.ContentType)
+		require.Equal(t, data, avatar.Data)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByID("unknown").Return(nil, nil)
+
+		_, err := th.App.GetUserAvatar("unknown")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestSetUserAvatar(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2))))
+	pngData := buf.Bytes()
+
+	t.Run("replaces the previous avatar", func(t *testing.T) {
+		user := &model.User{
+			ID:    "user-id",
+			Props: map[string]interface{}{KeyAvatarFileID: "old.png"},
+		}
+		var fileID string
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+		th.FilesBackend.On("WriteFile", mock.Anything, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+			path := args.String(1)
+			require.True(t, strings.HasPrefix(path, "avatars/user-id/"))
+			require.True(t, strings.HasSuffix(path, ".png"))
+			fileID = strings.TrimPrefix(path, "avatars/user-id/")
+		}).Return(int64(len(pngData)), nil).Once()
+		th.Store.EXPECT().PatchUserProps("user-id", gomock.Any()).DoAndReturn(func(_ string, patch model.UserPropPatch) error {
+			require.Equal(t, fileID, patch.UpdatedFields[KeyAvatarFileID])
+			return nil
+		})
+		th.FilesBackend.On("RemoveFile", "avatars/user-id/old.png").Return(nil).Once()
+
+		require.NoError(t, th.App.SetUserAvatar("user-id", pngData))
+	})
+
+	t.Run("invalid image", func(t *testing.T) {
+		err := th.App.SetUserAvatar("user-id", []byte("not an image"))
+		require.True(t, model.IsErrInvalidAvatar(err))
+	})
+}
+
+func TestDeleteUserAvatar(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("removes the uploaded avatar", func(t *testing.T) {
+		user := &model.User{
+			ID:    "user-id",
+			Props: map[string]interface{}{KeyAvatarFileID: "file.png"},
+		}
+		th.Store.EXPECT().GetUserByID("user-id").Return(user, nil)
+		th.Store.EXPECT().PatchUserProps("user-id", model.UserPropPatch{
+			DeletedFields: []string{KeyAvatarFileID},
+		}).Return(nil)
+		th.FilesBackend.On("RemoveFile", "avatars/user-id/file.png").Return(nil).Once()
+
+		require.NoError(t, th.App.DeleteUserAvatar("user-id"))
+	})
+
+	t.Run("nothing to remove", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id"}, nil)
+
+		require.NoError(t, th.App.DeleteUserAvatar("user-id"))
+	})
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var ErrBackupsUnavailable = errors.New("backups are not available")
+
+// BackupTeams writes a backup of each team that has boards. A failed
+// team doesn't prevent the backup of the others.
+func (a *App) BackupTeams() error {
+	if a.backups == nil {
+		return ErrBackupsUnavailable
+	}
+
+	teamIDs, err := a.getAllTeamIDs()
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, teamID := range teamIDs {
+		backup, err := a.BackupTeam(teamID)
+		if err != nil {
+			failed++
+			a.logger.Error("unable to back up team", mlog.String("teamID", teamID), mlog.Err(err))
+			continue
+		}
+		if backup != nil {
+			a.logger.Info("backed up team",
+				mlog.String("teamID", teamID),
+				mlog.String("name", backup.Name),
+				mlog.Int64("size", backup.Size),
+			)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("unable to back up %d of %d teams", failed, len(teamIDs))
+	}
+	return nil
+}
+
+// BackupTeam writes a backup of the boards of the team. Teams without
+// boards, and the global team when it only holds the built-in templates,
+// are not backed up and nil is returned.
+func (a *App) BackupTeam(teamID string) (*model.Backup, error) {
+	if a.backups == nil {
+		return nil, ErrBackupsUnavailable
+	}
+
+	boards, err := a.store.GetBoardsForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	opt := model.ExportArchiveOptions{TeamID: teamID}
+	hasBoards := false
+	for _, board := range boards {
+		opt.BoardIDs = append(opt.BoardIDs, board.ID)
+		hasBoards = hasBoards || !board.IsTemplate || teamID != model.GlobalTeamID
+	}
+	if !hasBoards {
+		return nil, nil
+	}
+
+	return a.backups.Save(teamID, func(w io.Writer) error {
+		return a.ExportArchive(w, opt)
+	}, time.Now())
+}
+
+// GetBackups returns the backups of a team, the most recent first, or the
+// ones of all the teams if teamID is empty.
+func (a *App) GetBackups(teamID string) ([]*model.Backup, error) {
+	if a.backups == nil {
+		return nil, ErrBackupsUnavailable
+	}
+	if teamID != "" {
+		return a.backups.List(teamID)
+	}
+
+	teamIDs, err := a.getAllTeamIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	backups := []*model.Backup{}
+	for _, id := range teamIDs {
+		teamBackups, err := a.backups.List(id)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, teamBackups...)
+	}
+	return backups, nil
+}
+
+// GetBackupReader returns a reader of a backup of the team.
+func (a *App) GetBackupReader(teamID, name string) (filestore.ReadCloseSeeker, error) {
+	if a.backups == nil {
+		return nil, ErrBackupsUnavailable
+	}
+	return a.backups.Open(teamID, name)
+}
+
+// getAllTeamIDs returns the ids of all the teams. The global
+// team is included as it holds the boards of standalone servers.
+func (a *App) getAllTeamIDs() ([]string, error) {
+	teams, err := a.store.GetAllTeams()
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	teamIDs := []string{model.GlobalTeamID}
+	for _, team := range teams {
+		if team.ID != model.GlobalTeamID {
+			teamIDs = append(teamIDs, team.ID)
+		}
+	}
+	return teamIDs, nil
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetBlockHistory returns a page of the versions of a block of the board,
+// newest first, from the ones before the given version, or the latest ones
+// if before is 0. Deleted blocks keep their history.
+func (a *App) GetBlockHistory(boardID, blockID string, before int64, limit uint64, userID string) (*model.BlockHistoryPage, error) {
+	if _, err := a.getBlockHistoryBoard(boardID, blockID, userID); err != nil {
+		return nil, err
+	}
+
+	if limit == 0 {
+		limit = model.DefaultBlockHistoryLimit
+	}
+	// one more version tells if there is a next page
+	blocks, err := a.store.GetBlockHistory(blockID, model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: before,
+		Limit:          limit + 1,
+		Descending:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.BlockHistoryPage{Versions: []*model.BlockVersion{}}
+	if uint64(len(blocks)) > limit {
+		blocks = blocks[:limit]
+		page.NextBefore = blocks[len(blocks)-1].UpdateAt
+	}
+	for _, block := range blocks {
+		page.Versions = append(page.Versions, model.NewBlockVersion(block))
+	}
+	return page, nil
+}
+
+// GetBlockVersion returns the version of a block of the board.
+func (a *App) GetBlockVersion(boardID, blockID string, version int64, userID string) (*model.BlockVersion, error) {
+	if _, err := a.getBlockHistoryBoard(boardID, blockID, userID); err != nil {
+		return nil, err
+	}
+
+	block, err := a.getBlockVersion(blockID, version)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewBlockVersion(*block), nil
+}
+
+// RestoreBlockVersion rolls a block back to one of its versions, as a new
+// change made by the user. A deleted block is undeleted first, and a
+// version that deleted the block can't be restored.
+func (a *App) RestoreBlockVersion(boardID, blockID string, version int64, userID string) (*model.Block, error) {
+	if _, err := a.getBlockHistoryBoard(boardID, blockID, userID); err != nil {
+		return nil, err
+	}
+
+	versionBlock, err := a.getBlockVersion(blockID, version)
+	if err != nil {
+		return nil, err
+	}
+	if versionBlock.DeleteAt != 0 {
+		return nil, model.NewErrInvalidBlockVersion("a version deleting the block can't be restored")
+	}
+
+	current, err := a.store.GetBlock(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		if current, err = a.UndeleteBlock(blockID, userID); err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, model.NewErrNotFound(blockID)
+		}
+	}
+
+	if err = a.PatchBlock(blockID, model.BlockVersionPatch(versionBlock, current), userID); err != nil {
+		return nil, err
+	}
+	return a.store.GetBlock(blockID)
+}
+
+// getBlockHistoryBoard returns the board of the block, checking from the
+// latest version of the block that it belongs to the board and that the
+// user can see it.
+func (a *App) getBlockHistoryBoard(boardID, blockID, userID string) (*model.Board, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := a.GetLastBlockHistoryEntry(blockID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil || latest.BoardID != board.ID {
+		return nil, model.NewErrNotFound(blockID)
+	}
+
+	visible, err := a.FilterRestrictedBlocks(board, []model.Block{*latest}, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == 0 {
+		return nil, model.NewErrNotFound(blockID)
+	}
+	return board, nil
+}
+
+// getBlockVersion returns the block as it was at the version.
+func (a *App) getBlockVersion(blockID string, version int64) (*model.Block, error) {
+	blocks, err := a.store.GetBlockHistory(blockID, model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: version + 1,
+		AfterUpdateAt:  version - 1,
+		Limit:          1,
+		Descending:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 {
+		return nil, model.NewErrNotFound(blockID)
+	}
+	return &blocks[0], nil
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// mergeBlockPatch rebases a patch made on an older version of the block on
+// top of the current one, so concurrent patches changing different fields
+// don't overwrite each other. Patches without a base version, or whose
+// base version is no longer in the history, are applied as they are. The
+// rebased patch is based on the current version, so the store rejects it
+// if the block changes again before it is written.
+func (a *App) mergeBlockPatch(current *model.Block, patch *model.BlockPatch) (*model.BlockPatch, error) {
+	if patch.BaseUpdateAt == 0 || current.UpdateAt <= patch.BaseUpdateAt {
+		return patch, nil
+	}
+
+	opts := model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: patch.BaseUpdateAt + 1,
+		Limit:          1,
+		Descending:     true,
+	}
+	versions, err := a.store.GetBlockHistory(current.ID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged *model.BlockPatch
+	if len(versions) == 0 {
+		a.logger.Debug("base version of patched block not found, applying patch as is",
+			mlog.String("blockID", current.ID),
+			mlog.Int64("baseUpdateAt", patch.BaseUpdateAt),
+		)
+		unmerged := *patch
+		merged = &unmerged
+	} else if merged, err = patch.Merge(&versions[0], current); err != nil {
+		return nil, err
+	}
+
+	merged.BaseUpdateAt = current.UpdateAt
+	return merged, nil
+}
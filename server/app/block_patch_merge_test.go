@@ -0,0 +1,94 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchBlockMerge(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+	base := model.Block{
+		ID:       "block-id",
+		BoardID:  testBoardID,
+		Type:     model.TypeText,
+		Title:    "title",
+		Fields:   map[string]interface{}{"value": "a"},
+		UpdateAt: 100,
+	}
+	current := base
+	current.Title = "new title"
+	current.UpdateAt = 200
+
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+	historyOpts := model.QueryBlockHistoryOptions{BeforeUpdateAt: 101, Limit: 1, Descending: true}
+
+	t.Run("concurrent change to another field", func(t *testing.T) {
+		patch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"value": "b"},
+			BaseUpdateAt:  100,
+		}
+		merged := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"value": "b"},
+			BaseUpdateAt:  200,
+		}
+		th.Store.EXPECT().GetBlock("block-id").Return(&current, nil).Times(2)
+		th.Store.EXPECT().GetBlockHistory("block-id", historyOpts).Return([]model.Block{base}, nil)
+		th.Store.EXPECT().PatchBlock("block-id", merged, "user-id").Return(nil)
+
+		require.NoError(t, th.App.PatchBlock("block-id", patch, "user-id"))
+	})
+
+	t.Run("change written while merging", func(t *testing.T) {
+		patch := &model.BlockPatch{
+			UpdatedFields: map[string]interface{}{"value": "b"},
+			BaseUpdateAt:  100,
+		}
+		th.Store.EXPECT().GetBlock("block-id").Return(&current, nil)
+		th.Store.EXPECT().GetBlockHistory("block-id", historyOpts).Return([]model.Block{base}, nil)
+		th.Store.EXPECT().PatchBlock("block-id", gomock.Any(), "user-id").
+			Return(model.NewErrBlockPatchConflict("block-id", []string{}))
+
+		err := th.App.PatchBlock("block-id", patch, "user-id")
+		require.True(t, model.IsErrBlockPatchConflict(err))
+	})
+
+	t.Run("concurrent change to the same field", func(t *testing.T) {
+		title := "other title"
+		patch := &model.BlockPatch{Title: &title, BaseUpdateAt: 100}
+		th.Store.EXPECT().GetBlock("block-id").Return(&current, nil)
+		th.Store.EXPECT().GetBlockHistory("block-id", historyOpts).Return([]model.Block{base}, nil)
+
+		err := th.App.PatchBlock("block-id", patch, "user-id")
+		require.True(t, model.IsErrBlockPatchConflict(err))
+	})
+
+	t.Run("no concurrent change", func(t *testing.T) {
+		title := "other title"
+		patch := &model.BlockPatch{Title: &title, BaseUpdateAt: 200}
+		th.Store.EXPECT().GetBlock("block-id").Return(&current, nil).Times(2)
+		th.Store.EXPECT().PatchBlock("block-id", patch, "user-id").Return(nil)
+
+		require.NoError(t, th.App.PatchBlock("block-id", patch, "user-id"))
+	})
+
+	t.Run("patch batch", func(t *testing.T) {
+		title := "other title"
+		patches := &model.BlockPatchBatch{
+			BlockIDs:     []string{"block-id"},
+			BlockPatches: []model.BlockPatch{{Title: &title, BaseUpdateAt: 100}},
+		}
+		th.Store.EXPECT().GetBlock("block-id").Return(&current, nil)
+		th.Store.EXPECT().GetBlockHistory("block-id", historyOpts).Return([]model.Block{base}, nil)
+
+		err := th.App.PatchBlocks("team-id", patches, "user-id")
+		require.True(t, model.IsErrBlockPatchConflict(err))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
@@ -30,6 +31,61 @@ func (a *App) GetBlocks(boardID, parentID string, blockType string) ([]model.Blo
 	return a.store.GetBlocksWithParent(boardID, parentID)
 }
 
+// GetBlocksPage returns a page of the blocks that GetBlocks would return,
+// sorted by id, along with the number of those blocks over all the pages.
+// If all is true the blocks of the whole board are paged instead. The
+// excluded blocks and their children are left out of the page and of the
+// count.
+func (a *App) GetBlocksPage(boardID, parentID string, blockType string, all bool, excludedIDs []string, afterID string, limit uint64) ([]model.Block, int64, error) {
+	if boardID == "" {
+		return []model.Block{}, 0, nil
+	}
+
+	opts := model.QueryBlocksPageOptions{
+		ExcludedIDs: excludedIDs,
+		AfterID:     afterID,
+		Limit:       limit,
+	}
+	if !all {
+		opts.BlockType = model.BlockType(blockType)
+		if blockType == "" || parentID != "" {
+			opts.ParentID = &parentID
+		}
+	}
+
+	blocks, err := a.store.GetBlocksForBoardPage(boardID, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := a.store.GetBlockCountForBoard(boardID, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	return blocks, total, nil
+}
+
+// GetBlocksETag returns the entity tag of the blocks of the board read by
+// the user, which changes with the board, its blocks and whether the user
+// sees their restricted blocks. The variant tells apart the different
+// reads of the blocks of a board.
+func (a *App) GetBlocksETag(board *model.Board, userID string, variant string) (string, error) {
+	version, err := a.store.GetBlocksVersionForBoard(board.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ETag(
+		board.ID,
+		board.UpdateAt,
+		version.Count,
+		version.LastUpdateAt,
+		userID,
+		a.canSeeRestrictedBlocks(board.ID, userID),
+		variant,
+	), nil
+}
+
 func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error) {
 	board, err := a.GetBoard(boardID)
 	if err != nil {
@@ -43,6 +99,7 @@ func (a *App) DuplicateBlock(boardID string, blockID string, userID string, asTe
 	if err != nil {
 		return nil, err
 	}
+	a.updateSearchIndex(searchIndexChange{Blocks: blocks})
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		for _, block := range blocks {
@@ -68,6 +125,15 @@ func (a *App) PatchBlock(blockID string, blockPatch *model.BlockPatch, modifiedB
 		return err
 	}
 
+	blockPatch, err = a.mergeBlockPatch(oldBlock, blockPatch)
+	if err != nil {
+		return err
+	}
+
+	if err = a.validatePatchedCardPropertyValues(board, oldBlock, blockPatch); err != nil {
+		return err
+	}
+
 	err = a.store.PatchBlock(blockID, blockPatch, modifiedByID)
 	if err != nil {
 		return err
@@ -78,12 +144,16 @@ func (a *App) PatchBlock(blockID string, blockPatch *model.BlockPatch, modifiedB
 	if err != nil {
 		return nil
 	}
+	a.updateSearchIndex(searchIndexChange{Blocks: []model.Block{*block}})
+	a.updateParentCardsProgress([]model.Block{*block}, modifiedByID)
+	a.assignMentionedUsers(block, oldBlock, modifiedByID)
+
 	a.blockChangeNotifier.Enqueue(func() error {
 		// broadcast on websocket
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, *block)
 
 		// broadcast on webhooks
-		a.webhook.NotifyUpdate(*block)
+		a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionUpdate, *block)
 
 		// send notifications
 		a.notifyBlockChanged(notify.Update, block, oldBlock, modifiedByID)
@@ -92,20 +162,60 @@ func (a *App) PatchBlock(blockID string, blockPatch *model.BlockPatch, modifiedB
 	return nil
 }
 
+// PatchBlocks applies a batch of patches in one store transaction, so
+// either every block of the batch is patched or none is.
 func (a *App) PatchBlocks(teamID string, blockPatches *model.BlockPatchBatch, modifiedByID string) error {
+	if len(blockPatches.BlockIDs) != len(blockPatches.BlockPatches) {
+		return model.NewErrInvalidBlockPatchBatch(fmt.Sprintf("the batch has %d block ids but %d patches", len(blockPatches.BlockIDs), len(blockPatches.BlockPatches)))
+	}
+
 	oldBlocks := make([]model.Block, 0, len(blockPatches.BlockIDs))
-	for _, blockID := range blockPatches.BlockIDs {
+	boards := map[string]*model.Board{}
+	blockPatches = &model.BlockPatchBatch{
+		BlockIDs:     blockPatches.BlockIDs,
+		BlockPatches: append([]model.BlockPatch(nil), blockPatches.BlockPatches...),
+	}
+	for i, blockID := range blockPatches.BlockIDs {
 		oldBlock, err := a.store.GetBlock(blockID)
 		if err != nil {
-			return nil
+			return err
 		}
 		oldBlocks = append(oldBlocks, *oldBlock)
+
+		var merged *model.BlockPatch
+		if merged, err = a.mergeBlockPatch(oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
+		blockPatches.BlockPatches[i] = *merged
+
+		if oldBlock.Type != model.TypeCard {
+			continue
+		}
+		board, ok := boards[oldBlock.BoardID]
+		if !ok {
+			if board, err = a.store.GetBoard(oldBlock.BoardID); err != nil {
+				return err
+			}
+			boards[oldBlock.BoardID] = board
+		}
+		if err = a.validatePatchedCardPropertyValues(board, oldBlock, &blockPatches.BlockPatches[i]); err != nil {
+			return err
+		}
 	}
 
 	err := a.store.PatchBlocks(blockPatches, modifiedByID)
 	if err != nil {
 		return err
 	}
+	a.updateParentCardsProgress(oldBlocks, modifiedByID)
+	for i := range oldBlocks {
+		if oldBlocks[i].Type != model.TypeText {
+			continue
+		}
+		if block, bErr := a.store.GetBlock(oldBlocks[i].ID); bErr == nil && block != nil {
+			a.assignMentionedUsers(block, &oldBlocks[i], modifiedByID)
+		}
+	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.metrics.IncrementBlocksPatched(len(oldBlocks))
@@ -114,8 +224,9 @@ func (a *App) PatchBlocks(teamID string, blockPatches *model.BlockPatchBatch, mo
 			if err != nil {
 				return nil
 			}
+			a.updateSearchIndex(searchIndexChange{Blocks: []model.Block{*newBlock}})
 			a.wsAdapter.BroadcastBlockChange(teamID, *newBlock)
-			a.webhook.NotifyUpdate(*newBlock)
+			a.webhook.NotifyBlockChange(teamID, model.WebhookActionUpdate, *newBlock)
 			a.notifyBlockChanged(notify.Update, newBlock, &oldBlocks[i], modifiedByID)
 		}
 		return nil
@@ -129,12 +240,23 @@ func (a *App) InsertBlock(block model.Block, modifiedByID string) error {
 		return bErr
 	}
 
+	if err := a.checkBoardLimits(board, []model.Block{block}); err != nil {
+		return err
+	}
+
+	if err := a.validateCardPropertyValues(board, &block, nil); err != nil {
+		return err
+	}
+
 	err := a.store.InsertBlock(&block, modifiedByID)
 	if err == nil {
+		a.updateSearchIndex(searchIndexChange{Blocks: []model.Block{block}})
+		a.updateParentCardsProgress([]model.Block{block}, modifiedByID)
+		a.assignMentionedUsers(&block, nil, modifiedByID)
 		a.blockChangeNotifier.Enqueue(func() error {
 			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
 			a.metrics.IncrementBlocksInserted(1)
-			a.webhook.NotifyUpdate(block)
+			a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionAdd, block)
 			a.notifyBlockChanged(notify.Add, &block, nil, modifiedByID)
 			return nil
 		})
@@ -160,6 +282,17 @@ func (a *App) InsertBlocks(blocks []model.Block, modifiedByID string, allowNotif
 		return nil, err
 	}
 
+	if err = a.checkBoardLimits(board, blocks); err != nil {
+		return nil, err
+	}
+
+	a.applyCardPropertyDefaults(board, blocks, modifiedByID)
+	for i := range blocks {
+		if err = a.validateCardPropertyValues(board, &blocks[i], nil); err != nil {
+			return nil, err
+		}
+	}
+
 	needsNotify := make([]model.Block, 0, len(blocks))
 	for i := range blocks {
 		err := a.store.InsertBlock(&blocks[i], modifiedByID)
@@ -171,11 +304,18 @@ func (a *App) InsertBlocks(blocks []model.Block, modifiedByID string, allowNotif
 		a.wsAdapter.BroadcastBlockChange(board.TeamID, blocks[i])
 		a.metrics.IncrementBlocksInserted(1)
 	}
+	a.updateSearchIndex(searchIndexChange{Blocks: blocks})
+	a.updateParentCardsProgress(blocks, modifiedByID)
+	if allowNotifications {
+		for i := range blocks {
+			a.assignMentionedUsers(&blocks[i], nil, modifiedByID)
+		}
+	}
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		for _, b := range needsNotify {
 			block := b
-			a.webhook.NotifyUpdate(block)
+			a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionAdd, block)
 			if allowNotifications {
 				a.notifyBlockChanged(notify.Add, &block, nil, modifiedByID)
 			}
@@ -239,6 +379,8 @@ func (a *App) CopyCardFiles(sourceBoardID string, copiedBlocks []model.Block) er
 				mlog.String("destinationFilePath", destinationFilePath),
 				mlog.Err(err),
 			)
+		} else if size, err := a.filesBackend.FileSize(destinationFilePath); err == nil {
+			a.recordFile(destTeamID, block.BoardID, destFilename, size)
 		}
 		block.Fields["fileId"] = destFilename
 	}
@@ -266,28 +408,21 @@ func (a *App) DeleteBlock(blockID string, modifiedBy string) error {
 		return nil
 	}
 
-	err = a.store.DeleteBlock(blockID, modifiedBy)
+	deleted, err := a.store.DeleteBlockTree(blockID, modifiedBy)
 	if err != nil {
 		return err
 	}
+	a.updateSearchIndex(searchIndexChange{DeletedBlocks: deleted})
+	a.updateParentCardsProgress([]model.Block{*block}, modifiedBy)
 
-	if block.Type == model.TypeImage {
-		fileName, fileIDExists := block.Fields["fileId"]
-		if fileName, fileIDIsString := fileName.(string); fileIDExists && fileIDIsString {
-			filePath := filepath.Join(block.BoardID, fileName)
-			err = a.filesBackend.RemoveFile(filePath)
-
-			if err != nil {
-				a.logger.Error("Error deleting image file",
-					mlog.String("FilePath", filePath),
-					mlog.Err(err))
-			}
-		}
-	}
-
+	// the files of the deleted blocks are kept until they are purged, so that
+	// the blocks can be restored
 	a.blockChangeNotifier.Enqueue(func() error {
-		a.wsAdapter.BroadcastBlockDelete(board.TeamID, blockID, block.BoardID)
-		a.metrics.IncrementBlocksDeleted(1)
+		for i := range deleted {
+			a.wsAdapter.BroadcastBlockDelete(board.TeamID, deleted[i].ID, deleted[i].BoardID, deleted[i].ParentID)
+		}
+		a.metrics.IncrementBlocksDeleted(len(deleted))
+		a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionDelete, *block)
 		a.notifyBlockChanged(notify.Delete, block, block, modifiedBy)
 		return nil
 	})
@@ -316,7 +451,12 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		return nil, nil
 	}
 
-	err = a.store.UndeleteBlock(blockID, modifiedBy)
+	if blocks[0].DeleteAt != 0 && blocks[0].DeleteAt < a.trashCutoff(time.Now()) {
+		// the block is about to be purged
+		return nil, model.NewErrNotFound(blockID)
+	}
+
+	restored, err := a.store.UndeleteBlockTree(blockID, modifiedBy)
 	if err != nil {
 		return nil, err
 	}
@@ -330,16 +470,23 @@ func (a *App) UndeleteBlock(blockID string, modifiedBy string) (*model.Block, er
 		a.logger.Error("Error loading the block after undelete, not propagating through websockets or notifications")
 		return nil, nil
 	}
+	if len(restored) == 0 {
+		restored = []model.Block{*block}
+	}
 
 	board, err := a.store.GetBoard(block.BoardID)
 	if err != nil {
 		return nil, err
 	}
+	a.updateSearchIndex(searchIndexChange{Blocks: restored})
+	a.updateParentCardsProgress([]model.Block{*block}, modifiedBy)
 
 	a.blockChangeNotifier.Enqueue(func() error {
-		a.wsAdapter.BroadcastBlockChange(board.TeamID, *block)
-		a.metrics.IncrementBlocksInserted(1)
-		a.webhook.NotifyUpdate(*block)
+		for i := range restored {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, restored[i])
+		}
+		a.metrics.IncrementBlocksInserted(len(restored))
+		a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionAdd, *block)
 		a.notifyBlockChanged(notify.Add, block, nil, modifiedBy)
 		return nil
 	})
@@ -60,6 +60,83 @@ func TestPatchBlocks(t *testing.T) {
 		err := th.App.PatchBlocks("team-id", &blockPatches, "user-id-1")
 		require.Error(t, err, "error")
 	})
+
+	t.Run("patchBlocks with fewer patches than blocks", func(t *testing.T) {
+		blockPatches := model.BlockPatchBatch{BlockIDs: []string{"block-1", "block-2"}, BlockPatches: []model.BlockPatch{{}}}
+		err := th.App.PatchBlocks("team-id", &blockPatches, "user-id-1")
+		require.True(t, model.IsErrInvalidBlockPatchBatch(err))
+	})
+}
+
+func TestGetBlocksPage(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	page := []model.Block{{ID: "block-1", BoardID: "board-id"}}
+
+	t.Run("root blocks", func(t *testing.T) {
+		rootID := ""
+		opts := model.QueryBlocksPageOptions{ParentID: &rootID, AfterID: "block-0", Limit: 1}
+		th.Store.EXPECT().GetBlocksForBoardPage("board-id", opts).Return(page, nil)
+		th.Store.EXPECT().GetBlockCountForBoard("board-id", opts).Return(int64(3), nil)
+
+		blocks, total, err := th.App.GetBlocksPage("board-id", "", "", false, nil, "block-0", 1)
+		require.NoError(t, err)
+		require.Equal(t, page, blocks)
+		require.Equal(t, int64(3), total)
+	})
+
+	t.Run("blocks of a type", func(t *testing.T) {
+		opts := model.QueryBlocksPageOptions{BlockType: model.TypeCard, Limit: 1}
+		th.Store.EXPECT().GetBlocksForBoardPage("board-id", opts).Return(page, nil)
+		th.Store.EXPECT().GetBlockCountForBoard("board-id", opts).Return(int64(1), nil)
+
+		_, total, err := th.App.GetBlocksPage("board-id", "", string(model.TypeCard), false, nil, "", 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), total)
+	})
+
+	t.Run("all blocks", func(t *testing.T) {
+		opts := model.QueryBlocksPageOptions{ExcludedIDs: []string{"hidden-card"}, Limit: 1}
+		th.Store.EXPECT().GetBlocksForBoardPage("board-id", opts).Return(page, nil)
+		th.Store.EXPECT().GetBlockCountForBoard("board-id", opts).Return(int64(5), nil)
+
+		_, total, err := th.App.GetBlocksPage("board-id", "parent-id", string(model.TypeCard), true, []string{"hidden-card"}, "", 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), total)
+	})
+}
+
+func TestGetBlocksETag(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-id", UpdateAt: 10}
+	version := &model.BlocksVersion{Count: 2, LastUpdateAt: 20}
+
+	getETag := func(board *model.Board, version *model.BlocksVersion, userID, variant string) string {
+		th.Store.EXPECT().GetBlocksVersionForBoard(board.ID).Return(version, nil)
+		th.Store.EXPECT().GetMemberForBoard(board.ID, userID).Return(&model.BoardMember{}, nil)
+		etag, err := th.App.GetBlocksETag(board, userID, variant)
+		require.NoError(t, err)
+		return etag
+	}
+
+	etag := getETag(board, version, "user-id", "")
+	require.NotEmpty(t, etag)
+	require.Equal(t, etag, getETag(board, version, "user-id", ""))
+
+	require.NotEqual(t, etag, getETag(board, version, "other-user-id", ""))
+	require.NotEqual(t, etag, getETag(board, version, "user-id", "all=true"))
+	require.NotEqual(t, etag, getETag(&model.Board{ID: "board-id", UpdateAt: 11}, version, "user-id", ""))
+	require.NotEqual(t, etag, getETag(board, &model.BlocksVersion{Count: 1, LastUpdateAt: 20}, "user-id", ""))
+	require.NotEqual(t, etag, getETag(board, &model.BlocksVersion{Count: 2, LastUpdateAt: 21}, "user-id", ""))
+
+	t.Run("error scenerio", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksVersionForBoard(board.ID).Return(nil, blockError{"error"})
+		_, err := th.App.GetBlocksETag(board, "user-id", "")
+		require.Error(t, err)
+	})
 }
 
 func TestDeleteBlock(t *testing.T) {
@@ -74,7 +151,7 @@ func TestDeleteBlock(t *testing.T) {
 			BoardID: board.ID,
 		}
 		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(&block, nil)
-		th.Store.EXPECT().DeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
+		th.Store.EXPECT().DeleteBlockTree(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return([]model.Block{block}, nil)
 		th.Store.EXPECT().GetBoard(gomock.Eq(testBoardID)).Return(board, nil)
 		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
 		err := th.App.DeleteBlock("block-id", "user-id-1")
@@ -89,7 +166,7 @@ func TestDeleteBlock(t *testing.T) {
 			BoardID: board.ID,
 		}
 		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(&block, nil)
-		th.Store.EXPECT().DeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(blockError{"error"})
+		th.Store.EXPECT().DeleteBlockTree(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil, blockError{"error"})
 		th.Store.EXPECT().GetBoard(gomock.Eq(testBoardID)).Return(board, nil)
 		err := th.App.DeleteBlock("block-id", "user-id-1")
 		require.Error(t, err, "error")
@@ -111,7 +188,7 @@ func TestUndeleteBlock(t *testing.T) {
 			gomock.Eq("block-id"),
 			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
 		).Return([]model.Block{block}, nil)
-		th.Store.EXPECT().UndeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil)
+		th.Store.EXPECT().UndeleteBlockTree(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return([]model.Block{block}, nil)
 		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(&block, nil)
 		th.Store.EXPECT().GetBoard(boardID).Return(board, nil)
 		th.Store.EXPECT().GetMembersForBoard(boardID).Return([]*model.BoardMember{}, nil)
@@ -127,7 +204,7 @@ func TestUndeleteBlock(t *testing.T) {
 			gomock.Eq("block-id"),
 			gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
 		).Return([]model.Block{block}, nil)
-		th.Store.EXPECT().UndeleteBlock(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(blockError{"error"})
+		th.Store.EXPECT().UndeleteBlockTree(gomock.Eq("block-id"), gomock.Eq("user-id-1")).Return(nil, blockError{"error"})
 		th.Store.EXPECT().GetBlock(gomock.Eq("block-id")).Return(&block, nil)
 		_, err := th.App.UndeleteBlock("block-id", "user-id-1")
 		require.Error(t, err, "error")
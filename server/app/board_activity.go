@@ -0,0 +1,38 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetBoardActivity counts the edits of a board and of its blocks per day,
+// from the board and block history, over the given number of weeks ending
+// on the day of now. The days are those of the location of now.
+func (a *App) GetBoardActivity(boardID string, weeks int, now time.Time) (*model.BoardActivity, error) {
+	if err := model.ValidateActivityWeeks(weeks); err != nil {
+		return nil, err
+	}
+
+	since := model.GetMillisForTime(model.ActivityStart(weeks, now)) - 1
+
+	blocks, err := a.store.GetBlockHistoryDescendants(boardID, model.QueryBlockHistoryOptions{AfterUpdateAt: since})
+	if err != nil {
+		return nil, fmt.Errorf("could not get blocks history descendants for board: %w", err)
+	}
+	boards, err := a.store.GetBoardHistory(boardID, model.QueryBoardHistoryOptions{AfterUpdateAt: since})
+	if err != nil {
+		return nil, fmt.Errorf("could not get board history: %w", err)
+	}
+
+	updateTimes := make([]int64, 0, len(blocks)+len(boards))
+	for i := range blocks {
+		updateTimes = append(updateTimes, blocks[i].UpdateAt)
+	}
+	for _, board := range boards {
+		updateTimes = append(updateTimes, board.UpdateAt)
+	}
+
+	return model.NewBoardActivity(boardID, weeks, now, updateTimes), nil
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoardActivity(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	since := model.GetMillisForTime(time.Date(2022, 5, 20, 0, 0, 0, 0, time.UTC)) - 1
+	today := model.GetMillisForTime(now)
+
+	t.Run("edits of the board and its blocks", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockHistoryDescendants(testBoardID, model.QueryBlockHistoryOptions{AfterUpdateAt: since}).Return([]model.Block{
+			{ID: "card-1", UpdateAt: today},
+			{ID: "card-1", UpdateAt: today - 1000},
+		}, nil)
+		th.Store.EXPECT().GetBoardHistory(testBoardID, model.QueryBoardHistoryOptions{AfterUpdateAt: since}).Return([]*model.Board{
+			{ID: testBoardID, UpdateAt: since + 1},
+		}, nil)
+
+		activity, err := th.App.GetBoardActivity(testBoardID, 1, now)
+		require.NoError(t, err)
+		require.Len(t, activity.Days, 7)
+		require.Equal(t, 1, activity.Days[0].Count)
+		require.Equal(t, 2, activity.Days[6].Count)
+		require.Equal(t, 3, activity.Total)
+		require.Equal(t, today, activity.LastActivityAt)
+	})
+
+	t.Run("invalid number of weeks", func(t *testing.T) {
+		_, err := th.App.GetBoardActivity(testBoardID, 0, now)
+		require.True(t, model.IsErrInvalidActivityRange(err))
+	})
+}
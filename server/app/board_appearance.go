@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetBoardAppearance returns the appearance settings of a board.
+func (a *App) GetBoardAppearance(boardID string) (*model.BoardAppearance, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.BoardAppearanceFromBoard(board)
+}
+
+// SetBoardAppearance replaces the appearance settings of a board. The
+// cover image must be a file uploaded to the board.
+func (a *App) SetBoardAppearance(boardID string, appearance *model.BoardAppearance, userID string) (*model.BoardAppearance, error) {
+	if err := appearance.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if appearance.CoverFileID != "" {
+		var exists bool
+		exists, err = a.filesBackend.FileExists(filepath.Join(board.TeamID, board.ID, appearance.CoverFileID))
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, model.NewErrInvalidBoardAppearance(fmt.Sprintf("cover file %q isn't a file of the board", appearance.CoverFileID))
+		}
+	}
+
+	appearance.ApplyTo(board)
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return model.BoardAppearanceFromBoard(updatedBoard)
+}
+
+// copyBoardCoverFiles copies the cover images of the boards duplicated from
+// the source board to the directories of the new boards, keeping their
+// file ids so that the appearance settings of the copies stay valid.
+func (a *App) copyBoardCoverFiles(sourceBoardID string, boards []*model.Board) {
+	sourceBoard, err := a.store.GetBoard(sourceBoardID)
+	if err != nil {
+		a.logger.Error("Cannot read the source board to copy its cover", mlog.String("boardID", sourceBoardID), mlog.Err(err))
+		return
+	}
+	coverFileID := model.BoardCoverFileID(sourceBoard)
+	if coverFileID == "" {
+		return
+	}
+
+	sourcePath := filepath.Join(sourceBoard.TeamID, sourceBoard.ID, coverFileID)
+	for _, board := range boards {
+		if model.BoardCoverFileID(board) != coverFileID {
+			continue
+		}
+		destinationPath := filepath.Join(board.TeamID, board.ID, coverFileID)
+		if err := a.filesBackend.CopyFile(sourcePath, destinationPath); err != nil {
+			a.logger.Error("Cannot copy the cover of a duplicated board",
+				mlog.String("sourceFilePath", sourcePath),
+				mlog.String("destinationFilePath", destinationPath),
+				mlog.Err(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// GetBoardDiff summarizes the changes of the cards of the board between
+// fromTS and toTS from the block history. A zero toTS means now.
+func (a *App) GetBoardDiff(boardID string, fromTS, toTS int64, userID string) (*model.BoardDiff, error) {
+	if toTS == 0 {
+		toTS = utils.GetMillis()
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+
+	// history entries are ordered by insertion, so the last entry of
+	// each card is its version at the end of the period
+	opts := model.QueryBlockHistoryOptions{
+		AfterUpdateAt:  fromTS,
+		BeforeUpdateAt: toTS + 1,
+	}
+	history, err := a.store.GetBlockHistoryDescendants(boardID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not get blocks history descendants for board: %w", err)
+	}
+
+	cardIDs := []string{}
+	latest := map[string]*model.Block{}
+	for i := range history {
+		if history[i].Type != model.TypeCard {
+			continue
+		}
+		if _, ok := latest[history[i].ID]; !ok {
+			cardIDs = append(cardIDs, history[i].ID)
+		}
+		latest[history[i].ID] = &history[i]
+	}
+
+	isAdmin := userID == model.SingleUser
+	if !isAdmin && userID != "" {
+		member, _ := a.store.GetMemberForBoard(boardID, userID)
+		isAdmin = member != nil && member.SchemeAdmin
+	}
+
+	diff := model.NewBoardDiff(boardID, fromTS, toTS)
+	for _, cardID := range cardIDs {
+		after := latest[cardID]
+
+		var before *model.Block
+		previous, err := a.store.GetBlockHistory(cardID, model.QueryBlockHistoryOptions{
+			BeforeUpdateAt: fromTS + 1,
+			Limit:          1,
+			Descending:     true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(previous) > 0 {
+			before = &previous[0]
+		}
+
+		if !model.CanUserSeeCard(properties, after, userID, isAdmin) ||
+			(before != nil && !model.CanUserSeeCard(properties, before, userID, isAdmin)) {
+			continue
+		}
+		diff.AddCard(properties, before, after)
+	}
+	diff.Sort()
+
+	return diff, nil
+}
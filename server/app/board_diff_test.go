@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoardDiff(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": model.PropertyTypeSelect},
+		},
+	}
+	card := func(id string, status string, updateAt int64) model.Block {
+		return model.Block{
+			ID: id, BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard, UpdateAt: updateAt,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"status": status}},
+		}
+	}
+
+	history := []model.Block{
+		card("moved-card", "review", 150),
+		{ID: "text-block", BoardID: testBoardID, ParentID: "moved-card", Type: model.TypeText, UpdateAt: 160},
+		card("new-card", "todo", 170),
+		card("moved-card", "done", 180),
+	}
+
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+	th.Store.EXPECT().GetBlockHistoryDescendants(testBoardID, model.QueryBlockHistoryOptions{
+		AfterUpdateAt:  100,
+		BeforeUpdateAt: 201,
+	}).Return(history, nil)
+	th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(&model.BoardMember{}, nil)
+	th.Store.EXPECT().GetBlockHistory("moved-card", model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: 101,
+		Limit:          1,
+		Descending:     true,
+	}).Return([]model.Block{card("moved-card", "todo", 50)}, nil)
+	th.Store.EXPECT().GetBlockHistory("new-card", model.QueryBlockHistoryOptions{
+		BeforeUpdateAt: 101,
+		Limit:          1,
+		Descending:     true,
+	}).Return([]model.Block{}, nil)
+
+	diff, err := th.App.GetBoardDiff(testBoardID, 100, 200, "user-id")
+	require.NoError(t, err)
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "new-card", diff.Added[0].CardID)
+	require.Len(t, diff.Moved, 1)
+	require.Equal(t, "moved-card", diff.Moved[0].CardID)
+	require.Equal(t, "todo", diff.Moved[0].PropertyChanges[0].OldValue)
+	require.Equal(t, "done", diff.Moved[0].PropertyChanges[0].NewValue)
+	require.Empty(t, diff.Removed)
+	require.Empty(t, diff.Updated)
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// DormantBoardDays returns the configured number of days without activity
+// after which a board is dormant.
+func (a *App) DormantBoardDays() int {
+	if a.config.DormantBoardDays > 0 {
+		return a.config.DormantBoardDays
+	}
+	return model.DefaultDormantBoardDays
+}
+
+// GetDormantBoards returns the boards of the team without activity for at
+// least the number of days, least recently active first.
+func (a *App) GetDormantBoards(teamID string, days int, now time.Time) ([]*model.DormantBoard, error) {
+	if err := model.ValidateDormantBoardDays(days); err != nil {
+		return nil, err
+	}
+
+	boards, err := a.store.GetBoardsForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastBlockUpdates, err := a.store.GetLastBlockUpdatesForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	return model.FindDormantBoards(boards, lastBlockUpdates, days, now), nil
+}
+
+// NotifyDormantBoards suggests the admins of the boards of every team that
+// became dormant during the interval before now to archive or export them.
+// Running it every interval notifies the admins of a board once, when the
+// board becomes dormant.
+func (a *App) NotifyDormantBoards(days int, interval time.Duration, now time.Time) error {
+	if a.notifications == nil {
+		return nil
+	}
+
+	teamIDs, err := a.getAllTeamIDs()
+	if err != nil {
+		return err
+	}
+
+	since := model.DormantBoardsCutoff(days, now.Add(-interval))
+	for _, teamID := range teamIDs {
+		dormant, err := a.GetDormantBoards(teamID, days, now)
+		if err != nil {
+			a.logger.Error("Cannot get dormant boards", mlog.String("teamID", teamID), mlog.Err(err))
+			continue
+		}
+
+		for _, d := range dormant {
+			if d.LastActivityAt < since {
+				continue
+			}
+			if err := a.notifyBoardDormant(d, days); err != nil {
+				a.logger.Error("Cannot notify dormant board",
+					mlog.String("boardID", d.BoardID),
+					mlog.Err(err),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func (a *App) notifyBoardDormant(dormant *model.DormantBoard, days int) error {
+	board, err := a.store.GetBoard(dormant.BoardID)
+	if err != nil {
+		return err
+	}
+
+	members, err := a.store.GetMembersForBoard(board.ID)
+	if err != nil {
+		return err
+	}
+
+	adminIDs := []string{}
+	for _, member := range members {
+		if member.SchemeAdmin {
+			adminIDs = append(adminIDs, member.UserID)
+		}
+	}
+	if len(adminIDs) == 0 {
+		return nil
+	}
+
+	evt := notify.BoardDormantEvent{
+		TeamID:         board.TeamID,
+		Board:          board,
+		LastActivityAt: dormant.LastActivityAt,
+		Days:           days,
+		AdminIDs:       adminIDs,
+	}
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.notifications.BoardDormant(evt)
+		return nil
+	})
+	return nil
+}
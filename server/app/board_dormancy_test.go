@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDormantBoards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return model.GetMillisForTime(now.AddDate(0, 0, -days))
+	}
+
+	t.Run("boards of the team", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForTeam("team-id").Return([]*model.Board{
+			{ID: "active", TeamID: "team-id", UpdateAt: daysAgo(100)},
+			{ID: "dormant", TeamID: "team-id", UpdateAt: daysAgo(100)},
+		}, nil)
+		th.Store.EXPECT().GetLastBlockUpdatesForTeam("team-id").Return(map[string]int64{
+			"active":  daysAgo(1),
+			"dormant": daysAgo(95),
+		}, nil)
+
+		dormant, err := th.App.GetDormantBoards("team-id", model.DefaultDormantBoardDays, now)
+		require.NoError(t, err)
+		require.Len(t, dormant, 1)
+		require.Equal(t, "dormant", dormant[0].BoardID)
+		require.Equal(t, 95, dormant[0].DormantDays)
+	})
+
+	t.Run("invalid number of days", func(t *testing.T) {
+		_, err := th.App.GetDormantBoards("team-id", 0, now)
+		require.True(t, model.IsErrInvalidDormantBoardDays(err))
+	})
+}
+
+func TestDormantBoardDays(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	require.Equal(t, model.DefaultDormantBoardDays, th.App.DormantBoardDays())
+
+	th.App.config.DormantBoardDays = 30
+	require.Equal(t, 30, th.App.DormantBoardDays())
+}
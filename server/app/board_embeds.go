@@ -0,0 +1,137 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *App) GetBoardEmbeds(boardID string) ([]model.BoardEmbed, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.BoardEmbedsFromBoard(board)
+}
+
+func (a *App) CreateBoardEmbed(boardID string, embed *model.BoardEmbed, userID string) (*model.BoardEmbed, error) {
+	embed.Hydrate(userID)
+	if err := embed.IsValid(); err != nil {
+		return nil, err
+	}
+
+	view, err := a.store.GetBlock(embed.ViewID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil || view.BoardID != boardID || view.Type != model.TypeView {
+		return nil, model.NewErrNotFound(embed.ViewID)
+	}
+	if model.IsViewRestricted(view) {
+		return nil, model.ErrRestrictedViewEmbed
+	}
+
+	err = a.updateBoardEmbeds(boardID, userID, func(embeds []model.BoardEmbed) ([]model.BoardEmbed, error) {
+		return append(embeds, *embed), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return embed, nil
+}
+
+func (a *App) DeleteBoardEmbed(boardID, embedID string, userID string) error {
+	return a.updateBoardEmbeds(boardID, userID, func(embeds []model.BoardEmbed) ([]model.BoardEmbed, error) {
+		idx := model.FindBoardEmbed(embeds, embedID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(embedID)
+		}
+		return append(embeds[:idx], embeds[idx+1:]...), nil
+	})
+}
+
+// GetBoardEmbed returns the embed if the token gives access to it.
+func (a *App) GetBoardEmbed(boardID, embedID, token string) (*model.BoardEmbed, error) {
+	_, embed, err := a.getBoardEmbed(boardID, embedID, token)
+	return embed, err
+}
+
+// GetEmbedContent returns the view and the cards shown by an embed. The
+// restricted and archived cards are never part of it, and restricted views can't be
+// embedded.
+func (a *App) GetEmbedContent(boardID, embedID, token string) (*model.EmbedContent, error) {
+	board, embed, err := a.getBoardEmbed(boardID, embedID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := a.store.GetBlock(embed.ViewID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil || view.BoardID != board.ID || model.IsViewRestricted(view) {
+		return nil, model.NewErrNotFound(embed.ViewID)
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cards, err = a.FilterRestrictedBlocks(board, cards, "")
+	if err != nil {
+		return nil, err
+	}
+	cards = model.FilterArchivedCards(cards)
+
+	return model.NewEmbedContent(board, view, cards), nil
+}
+
+func (a *App) getBoardEmbed(boardID, embedID, token string) (*model.Board, *model.BoardEmbed, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	embeds, err := model.BoardEmbedsFromBoard(board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idx := model.FindBoardEmbed(embeds, embedID)
+	if idx == -1 || !embeds[idx].HasToken(token) {
+		return nil, nil, model.NewErrNotFound(embedID)
+	}
+	return board, &embeds[idx], nil
+}
+
+// updateBoardEmbeds loads the embeds of a board, applies the modifier and
+// saves them back to the board and broadcasts the change.
+func (a *App) updateBoardEmbeds(boardID, userID string, modifier func([]model.BoardEmbed) ([]model.BoardEmbed, error)) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	embeds, err := model.BoardEmbedsFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	embeds, err = modifier(embeds)
+	if err != nil {
+		return err
+	}
+
+	if board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+	board.Properties[model.BoardPropertyEmbeds] = embeds
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEmbedContent(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		Properties: map[string]interface{}{
+			model.BoardPropertyEmbeds: []interface{}{
+				map[string]interface{}{"id": "embed-id", "viewId": "view-id", "token": "embed-token"},
+			},
+		},
+	}
+
+	t.Run("wrong token", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		_, err := th.App.GetEmbedContent(testBoardID, "embed-id", "other-token")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("restricted cards are not embedded", func(t *testing.T) {
+		cards := []model.Block{
+			{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard},
+			{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{model.CardFieldRestricted: true}},
+		}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetBlock("view-id").Return(&model.Block{ID: "view-id", BoardID: testBoardID, Type: model.TypeView}, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil).Times(2)
+
+		content, err := th.App.GetEmbedContent(testBoardID, "embed-id", "embed-token")
+		require.NoError(t, err)
+		require.Len(t, content.Cards, 1)
+		require.Equal(t, "card-1", content.Cards[0].ID)
+	})
+}
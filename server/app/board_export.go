@@ -0,0 +1,108 @@
+package app
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/export/xlsx"
+)
+
+// ExportBoardView writes the cards of a board view as a spreadsheet in
+// the given format, with a column for each property shown by the view.
+// Without a view id the first view of the board that the user can see is
+// exported. The restricted cards that the user can't see and the archived
+// cards are left out.
+func (a *App) ExportBoardView(w io.Writer, board *model.Board, viewID, format, userID string) error {
+	if !model.IsValidExportFormat(format) {
+		return model.ErrInvalidExportFormat
+	}
+
+	view, err := a.getExportView(board, viewID, userID)
+	if err != nil {
+		return err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return err
+	}
+	columns := model.ExportColumns(properties, view)
+
+	formatting, err := model.BoardFormattingFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return err
+	}
+	cards, err = a.FilterRestrictedBlocks(board, cards, userID)
+	if err != nil {
+		return err
+	}
+	cards = model.FilterArchivedCards(cards)
+	model.SortCardsForView(cards, view)
+
+	usernames := map[string]string{}
+	for _, id := range model.ExportUserIDs(columns, cards) {
+		user, err := a.store.GetUserByID(id)
+		if err == nil && user != nil {
+			usernames[id] = user.Username
+		}
+	}
+
+	rows := model.NewExportTable(columns, cards, usernames, formatting)
+	if format == model.ExportFormatXLSX {
+		return xlsx.Write(w, board.Title, rows)
+	}
+
+	cw := csv.NewWriter(w)
+	return cw.WriteAll(rows)
+}
+
+// getExportView returns the view to export, or nil if the board has no
+// views the user can see. Restricted views are only exported for the
+// board admins.
+func (a *App) getExportView(board *model.Board, viewID, userID string) (*model.Block, error) {
+	if viewID != "" {
+		view, err := a.store.GetBlock(viewID)
+		if err != nil {
+			return nil, err
+		}
+		if view == nil || view.BoardID != board.ID || view.Type != model.TypeView {
+			return nil, model.NewErrNotFound(viewID)
+		}
+		if model.IsViewRestricted(view) && !a.canSeeRestrictedBlocks(board.ID, userID) {
+			return nil, model.NewErrNotFound(viewID)
+		}
+		return view, nil
+	}
+
+	views, err := a.store.GetBlocksWithType(board.ID, model.TypeView)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasRestrictedView(views) && !a.canSeeRestrictedBlocks(board.ID, userID) {
+		views = model.FilterRestrictedViews(views)
+	}
+
+	var first *model.Block
+	for i := range views {
+		if first == nil || views[i].CreateAt < first.CreateAt {
+			first = &views[i]
+		}
+	}
+	return first, nil
+}
+
+func hasRestrictedView(views []model.Block) bool {
+	for i := range views {
+		if model.IsViewRestricted(&views[i]) {
+			return true
+		}
+	}
+	return false
+}
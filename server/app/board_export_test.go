@@ -0,0 +1,72 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportBoardView(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:    testBoardID,
+		Title: "Tasks",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "status",
+				"name": "Status",
+				"type": model.PropertyTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "todo", "value": "To Do", "color": model.PropertyColorDefault},
+				},
+			},
+			{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson},
+			{"id": "estimate", "name": "Estimate", "type": model.PropertyTypeNumber},
+		},
+	}
+	cards := []model.Block{
+		{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Title: "First", CreateAt: 2, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "owner": "user-2", "estimate": "3"},
+		}},
+		{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard, Title: "Second, with a comma", CreateAt: 1},
+	}
+	views := []model.Block{
+		{ID: "view-2", BoardID: testBoardID, Type: model.TypeView, CreateAt: 2},
+		{ID: "view-1", BoardID: testBoardID, Type: model.TypeView, CreateAt: 1, Fields: map[string]interface{}{
+			"visiblePropertyIds": []interface{}{"owner", "status"},
+			"cardOrder":          []interface{}{"card-1"},
+		}},
+	}
+	member := &model.BoardMember{BoardID: testBoardID, UserID: "user-id", SchemeAdmin: true}
+
+	t.Run("the first view is exported to csv", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeView).Return(views, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(member, nil)
+		th.Store.EXPECT().GetUserByID("user-2").Return(&model.User{ID: "user-2", Username: "jdoe"}, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportBoardView(&buf, board, "", model.ExportFormatCSV, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, "Title,Status,Owner\nFirst,To Do,jdoe\n\"Second, with a comma\",,\n", buf.String())
+	})
+
+	t.Run("a view of another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("other-view").Return(&model.Block{ID: "other-view", BoardID: "other-board", Type: model.TypeView}, nil)
+
+		var buf bytes.Buffer
+		err := th.App.ExportBoardView(&buf, board, "other-view", model.ExportFormatXLSX, "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := th.App.ExportBoardView(&buf, board, "", "pdf", "user-id")
+		require.ErrorIs(t, err, model.ErrInvalidExportFormat)
+	})
+}
@@ -0,0 +1,43 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetBoardFormatting returns the number and date formatting settings of a
+// board.
+func (a *App) GetBoardFormatting(boardID string) (*model.BoardFormatting, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.BoardFormattingFromBoard(board)
+}
+
+// SetBoardFormatting replaces the number and date formatting settings of a
+// board, used by its exports and notifications.
+func (a *App) SetBoardFormatting(boardID string, formatting *model.BoardFormatting, userID string) (*model.BoardFormatting, error) {
+	if err := formatting.IsValid(); err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+	board.Properties[model.BoardPropertyFormatting] = formatting
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return model.BoardFormattingFromBoard(updatedBoard)
+}
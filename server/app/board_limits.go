@@ -0,0 +1,128 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// CardLimit returns the maximum number of cards of a board, 0 meaning
+// no limit.
+func (a *App) CardLimit() int {
+	if a.config == nil || a.config.CardLimit < 0 {
+		return 0
+	}
+	return a.config.CardLimit
+}
+
+// ViewLimit returns the maximum number of views of a board, 0 meaning
+// no limit.
+func (a *App) ViewLimit() int {
+	if a.config == nil || a.config.ViewLimit < 0 {
+		return 0
+	}
+	return a.config.ViewLimit
+}
+
+// AttachmentStorageLimit returns the maximum bytes of all the stored
+// attachments, 0 meaning no limit.
+func (a *App) AttachmentStorageLimit() int64 {
+	if a.config == nil || a.config.AttachmentStorageLimit < 0 {
+		return 0
+	}
+	return a.config.AttachmentStorageLimit
+}
+
+// GetBoardLimits returns the configured card, view and attachment storage
+// limits.
+func (a *App) GetBoardLimits() model.BoardLimits {
+	return model.BoardLimits{
+		CardLimit:              a.CardLimit(),
+		ViewLimit:              a.ViewLimit(),
+		AttachmentStorageLimit: a.AttachmentStorageLimit(),
+	}
+}
+
+// UpdateBoardLimits sets the card, view and attachment storage limits
+// and, if they changed, broadcasts them so the connected clients can
+// re-render the limited cards without reloading.
+func (a *App) UpdateBoardLimits(limits model.BoardLimits) {
+	if a.GetBoardLimits() == limits {
+		return
+	}
+
+	a.config.CardLimit = limits.CardLimit
+	a.config.ViewLimit = limits.ViewLimit
+	a.config.AttachmentStorageLimit = limits.AttachmentStorageLimit
+	a.wsAdapter.BroadcastBoardLimitsChange(a.GetBoardLimits())
+}
+
+// SetBoardLimitsExempt exempts the board from the card and view limits,
+// or removes the exemption.
+func (a *App) SetBoardLimitsExempt(boardID string, exempt bool, userID string) (*model.Board, error) {
+	patch := &model.BoardPatch{}
+	if exempt {
+		patch.UpdatedProperties = map[string]interface{}{model.BoardPropertyLimitsExempt: true}
+	} else {
+		patch.DeletedProperties = []string{model.BoardPropertyLimitsExempt}
+	}
+	return a.PatchBoard(patch, boardID, userID)
+}
+
+// checkBoardLimits returns an ErrCardLimitReached or ErrViewLimitReached
+// if inserting the blocks would take the board over the card or view
+// limit. Card templates don't count towards the card limit, and boards
+// exempted by an admin have no limits.
+func (a *App) checkBoardLimits(board *model.Board, blocks []model.Block) error {
+	if model.IsBoardLimitsExempt(board) {
+		return nil
+	}
+	boardID := board.ID
+
+	if limit := a.CardLimit(); limit != 0 {
+		if newCards := model.CountNewCards(blocks); newCards != 0 {
+			cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+			if err != nil {
+				return err
+			}
+			if count := model.CountNewCards(cards); count+newCards > limit {
+				return model.NewErrCardLimitReached(boardID, count, limit)
+			}
+		}
+	}
+
+	if limit := a.ViewLimit(); limit != 0 {
+		if newViews := model.CountNewViews(blocks); newViews != 0 {
+			views, err := a.store.GetBlocksWithType(boardID, model.TypeView)
+			if err != nil {
+				return err
+			}
+			if count := len(views); count+newViews > limit {
+				return model.NewErrViewLimitReached(boardID, count, limit)
+			}
+		}
+	}
+	return nil
+}
+
+// GetAttachmentStorageUsed returns the bytes of the attachments stored
+// for all the teams, summed from the recorded sizes of their files.
+func (a *App) GetAttachmentStorageUsed() (int64, error) {
+	return a.store.GetFileStorageUsed()
+}
+
+// CheckAttachmentStorageLimit returns an ErrAttachmentStorageLimitReached
+// if storing a file of the given size would exceed the storage limit.
+func (a *App) CheckAttachmentStorageLimit(size int64) error {
+	limit := a.AttachmentStorageLimit()
+	if limit == 0 {
+		return nil
+	}
+
+	used, err := a.GetAttachmentStorageUsed()
+	if err != nil {
+		return err
+	}
+	if used+size > limit {
+		return model.NewErrAttachmentStorageLimitReached(used, size, limit)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertBlocksCardLimit(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.CardLimit = 2
+	board := &model.Board{ID: testBoardID}
+	existing := []model.Block{
+		{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard},
+		{ID: "template-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{"isTemplate": true}},
+	}
+
+	t.Run("rejects cards over the limit", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard},
+			{ID: "card-3", BoardID: testBoardID, Type: model.TypeCard},
+		}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(existing, nil)
+
+		_, err := th.App.InsertBlocks(blocks, "user-id", false)
+		var errLimit *model.ErrCardLimitReached
+		require.True(t, errors.As(err, &errLimit))
+		require.Equal(t, 1, errLimit.Count)
+		require.Equal(t, 2, errLimit.Limit)
+	})
+
+	t.Run("allows content blocks at the limit", func(t *testing.T) {
+		blocks := []model.Block{{ID: "text-1", BoardID: testBoardID, ParentID: "card-1", Type: model.TypeText}}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().InsertBlock(&blocks[0], "user-id").Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		_, err := th.App.InsertBlocks(blocks, "user-id", false)
+		require.NoError(t, err)
+	})
+
+	t.Run("exempt boards have no limit", func(t *testing.T) {
+		exemptBoard := &model.Board{ID: testBoardID, Properties: map[string]interface{}{model.BoardPropertyLimitsExempt: true}}
+		blocks := []model.Block{{ID: "card-2", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard}}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(exemptBoard, nil)
+		th.Store.EXPECT().InsertBlock(&blocks[0], "user-id").Return(nil)
+
+		_, err := th.App.InsertBlocks(blocks, "user-id", false)
+		require.NoError(t, err)
+	})
+}
+
+func TestInsertBlocksViewLimit(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.ViewLimit = 1
+	blocks := []model.Block{{ID: "view-2", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeView}}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID}, nil)
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeView).Return([]model.Block{{ID: "view-1"}}, nil)
+
+	_, err := th.App.InsertBlocks(blocks, "user-id", false)
+	var errLimit *model.ErrViewLimitReached
+	require.True(t, errors.As(err, &errLimit))
+	require.Equal(t, 1, errLimit.Count)
+	require.Equal(t, 1, errLimit.Limit)
+}
+
+func TestCheckAttachmentStorageLimit(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("no limit", func(t *testing.T) {
+		require.NoError(t, th.App.CheckAttachmentStorageLimit(1000))
+	})
+
+	th.App.config.AttachmentStorageLimit = 1000
+
+	t.Run("within the limit", func(t *testing.T) {
+		th.Store.EXPECT().GetFileStorageUsed().Return(int64(600), nil)
+		require.NoError(t, th.App.CheckAttachmentStorageLimit(400))
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		th.Store.EXPECT().GetFileStorageUsed().Return(int64(600), nil)
+		err := th.App.CheckAttachmentStorageLimit(401)
+		var errLimit *model.ErrAttachmentStorageLimitReached
+		require.True(t, errors.As(err, &errLimit))
+		require.EqualValues(t, 600, errLimit.Used)
+		require.EqualValues(t, 1000, errLimit.Limit)
+	})
+}
+
+func TestUpdateBoardLimits(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	require.Equal(t, model.BoardLimits{}, th.App.GetBoardLimits())
+
+	limits := model.BoardLimits{CardLimit: 10, ViewLimit: 2, AttachmentStorageLimit: 1000}
+	th.App.UpdateBoardLimits(limits)
+	require.Equal(t, limits, th.App.GetBoardLimits())
+	require.Equal(t, 10, th.App.config.CardLimit)
+}
+
+func TestSetBoardLimitsExempt(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID, TeamID: "team-id"}
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("exempt", func(t *testing.T) {
+		patch := &model.BoardPatch{UpdatedProperties: map[string]interface{}{model.BoardPropertyLimitsExempt: true}}
+		th.Store.EXPECT().PatchBoard(testBoardID, patch, "user-id").Return(board, nil)
+
+		_, err := th.App.SetBoardLimitsExempt(testBoardID, true, "user-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("remove exemption", func(t *testing.T) {
+		patch := &model.BoardPatch{DeletedProperties: []string{model.BoardPropertyLimitsExempt}}
+		th.Store.EXPECT().PatchBoard(testBoardID, patch, "user-id").Return(board, nil)
+
+		_, err := th.App.SetBoardLimitsExempt(testBoardID, false, "user-id")
+		require.NoError(t, err)
+	})
+}
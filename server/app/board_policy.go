@@ -0,0 +1,61 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// boardPolicyKeyPrefix prefixes the system setting holding the board
+// policy of a team, as a JSON object.
+const boardPolicyKeyPrefix = "BoardPolicy_"
+
+// GetTeamBoardPolicy returns the board policy of the team, the default one
+// if the team hasn't set any.
+func (a *App) GetTeamBoardPolicy(teamID string) (*model.TeamBoardPolicy, error) {
+	value, err := a.store.GetSystemSetting(boardPolicyKeyPrefix + teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := model.DefaultBoardPolicy()
+	if strings.TrimSpace(value) != "" {
+		if err := json.Unmarshal([]byte(value), &policy); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.TeamBoardPolicy{TeamID: teamID, BoardPolicy: policy}, nil
+}
+
+// UpdateTeamBoardPolicy replaces the board policy of the team.
+func (a *App) UpdateTeamBoardPolicy(teamID string, policy model.BoardPolicy) (*model.TeamBoardPolicy, error) {
+	if err := policy.IsValid(); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.store.SetSystemSetting(boardPolicyKeyPrefix+teamID, string(value)); err != nil {
+		return nil, err
+	}
+
+	return &model.TeamBoardPolicy{TeamID: teamID, BoardPolicy: policy}, nil
+}
+
+// IsPublicSharingAllowed returns true if the board policy of the team of
+// the board allows sharing it with a public link.
+func (a *App) IsPublicSharingAllowed(boardID string) (bool, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return false, err
+	}
+	policy, err := a.GetTeamBoardPolicy(board.TeamID)
+	if err != nil {
+		return false, err
+	}
+	return policy.AllowPublicSharing, nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTeamBoardPolicy(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("the default policy", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(boardPolicyKeyPrefix+"team-id").Return("", nil)
+
+		policy, err := th.App.GetTeamBoardPolicy("team-id")
+		require.NoError(t, err)
+		require.Equal(t, "team-id", policy.TeamID)
+		require.Equal(t, model.DefaultBoardPolicy(), policy.BoardPolicy)
+	})
+
+	t.Run("the policy of the team", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(boardPolicyKeyPrefix+"team-id").
+			Return(`{"boardCreators":"admins","defaultMemberRole":"viewer","allowPublicSharing":false}`, nil)
+
+		policy, err := th.App.GetTeamBoardPolicy("team-id")
+		require.NoError(t, err)
+		require.True(t, policy.OnlyAdminsCreateBoards())
+		require.Equal(t, model.BoardRoleViewer, policy.DefaultMemberRole)
+		require.False(t, policy.AllowPublicSharing)
+	})
+}
+
+func TestUpdateTeamBoardPolicy(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().SetSystemSetting(boardPolicyKeyPrefix+"team-id",
+		`{"boardCreators":"all","defaultMemberRole":"editor","allowPublicSharing":false}`).Return(nil)
+
+	policy, err := th.App.UpdateTeamBoardPolicy("team-id", model.BoardPolicy{
+		BoardCreators:     model.BoardCreatorsAll,
+		DefaultMemberRole: model.BoardRoleEditor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.BoardRoleEditor, policy.DefaultMemberRole)
+
+	_, err = th.App.UpdateTeamBoardPolicy("team-id", model.BoardPolicy{BoardCreators: "nobody"})
+	require.True(t, model.IsErrInvalidBoardPolicy(err))
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetBoardsPortfolio returns the status summaries of a set of boards of
+// the team, in the given order, counting only the cards the user can see.
+func (a *App) GetBoardsPortfolio(teamID string, boardIDs []string, userID string, now time.Time) ([]*model.BoardSummary, error) {
+	if len(boardIDs) == 0 {
+		return nil, model.NewErrInvalidPortfolio("at least one board is required")
+	}
+	if len(boardIDs) > model.MaxPortfolioBoards {
+		return nil, model.NewErrInvalidPortfolio(fmt.Sprintf("a portfolio cannot have more than %d boards", model.MaxPortfolioBoards))
+	}
+
+	boards := make([]*model.Board, 0, len(boardIDs))
+	seen := map[string]bool{}
+	for _, boardID := range boardIDs {
+		if seen[boardID] {
+			continue
+		}
+		seen[boardID] = true
+
+		board, err := a.store.GetBoard(boardID)
+		if model.IsErrNotFound(err) || (err == nil && board.TeamID != teamID) {
+			return nil, model.NewErrInvalidPortfolio(fmt.Sprintf("board %q doesn't belong to the team", boardID))
+		}
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, board)
+	}
+
+	milestones, err := a.store.GetMilestonesForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*model.BoardSummary, 0, len(boards))
+	for _, board := range boards {
+		blocks, err := a.store.GetBlocksWithBoardID(board.ID)
+		if err != nil {
+			return nil, err
+		}
+		blocks, err = a.FilterRestrictedBlocks(board, blocks, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		properties, err := model.CardPropertiesFromBoard(board)
+		if err != nil {
+			// the board is still summarized, without status counts
+			a.logger.Warn("cannot read card properties for portfolio",
+				mlog.String("boardID", board.ID),
+				mlog.Err(err),
+			)
+		}
+
+		summaries = append(summaries, model.NewBoardSummary(board, properties, blocks, milestones, utils.GetMillisForTime(now)))
+	}
+	return summaries, nil
+}
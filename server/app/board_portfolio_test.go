@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoardsPortfolio(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+
+	t.Run("no boards", func(t *testing.T) {
+		_, err := th.App.GetBoardsPortfolio("team-id", nil, "user-id", now)
+		require.True(t, model.IsErrInvalidPortfolio(err))
+	})
+
+	t.Run("board of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard("board-1").Return(&model.Board{ID: "board-1", TeamID: "other-team-id"}, nil)
+
+		_, err := th.App.GetBoardsPortfolio("team-id", []string{"board-1"}, "user-id", now)
+		require.True(t, model.IsErrInvalidPortfolio(err))
+	})
+
+	t.Run("summaries in order", func(t *testing.T) {
+		board1 := &model.Board{ID: "board-1", TeamID: "team-id", Title: "Roadmap"}
+		board2 := &model.Board{ID: "board-2", TeamID: "team-id", Title: "Bugs"}
+		admin := &model.BoardMember{UserID: "user-id", SchemeAdmin: true}
+
+		th.Store.EXPECT().GetBoard("board-2").Return(board2, nil)
+		th.Store.EXPECT().GetBoard("board-1").Return(board1, nil)
+		th.Store.EXPECT().GetMilestonesForTeam("team-id").Return([]*model.Milestone{}, nil)
+		th.Store.EXPECT().GetBlocksWithBoardID("board-2").Return([]model.Block{
+			{ID: "card-1", BoardID: "board-2", Type: model.TypeCard, Fields: map[string]interface{}{}},
+		}, nil)
+		th.Store.EXPECT().GetMemberForBoard("board-2", "user-id").Return(admin, nil)
+		th.Store.EXPECT().GetBlocksWithBoardID("board-1").Return([]model.Block{}, nil)
+		th.Store.EXPECT().GetMemberForBoard("board-1", "user-id").Return(admin, nil)
+
+		summaries, err := th.App.GetBoardsPortfolio("team-id", []string{"board-2", "board-1", "board-2"}, "user-id", now)
+		require.NoError(t, err)
+		require.Len(t, summaries, 2)
+		require.Equal(t, "Bugs", summaries[0].Title)
+		require.Equal(t, 1, summaries[0].CardCount)
+		require.Equal(t, "Roadmap", summaries[1].Title)
+		require.Equal(t, 0, summaries[1].CardCount)
+	})
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/ws"
+)
+
+var ErrPresenceUnavailable = errors.New("board presence is not available")
+
+// GetBoardPresence returns the users viewing the board.
+func (a *App) GetBoardPresence(boardID string) (*model.BoardPresence, error) {
+	tracker, ok := a.wsAdapter.(ws.PresenceTracker)
+	if !ok {
+		return nil, ErrPresenceUnavailable
+	}
+
+	return &model.BoardPresence{
+		BoardID: boardID,
+		UserIDs: tracker.GetBoardPresence(boardID),
+	}, nil
+}
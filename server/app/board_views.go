@@ -0,0 +1,50 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// MarkBoardViewed records that the user viewed the board now.
+func (a *App) MarkBoardViewed(boardID, userID string) (*model.BoardView, error) {
+	view := &model.BoardView{
+		BoardID:  boardID,
+		UserID:   userID,
+		ViewedAt: utils.GetMillis(),
+	}
+	if err := a.store.SaveBoardView(view); err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+// GetBoardChangesForUser returns, for the boards of the team the user can
+// access and has viewed before, the number of blocks changed by other
+// users since the last view. Boards never viewed are left out so they
+// don't all show as changed.
+func (a *App) GetBoardChangesForUser(userID, teamID string) ([]*model.BoardChanges, error) {
+	changes, err := a.store.GetBoardChangesForUser(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return changes, nil
+	}
+
+	boards, err := a.GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	boardIDs := make(map[string]bool, len(boards))
+	for _, board := range boards {
+		boardIDs[board.ID] = true
+	}
+
+	accessible := make([]*model.BoardChanges, 0, len(changes))
+	for _, boardChanges := range changes {
+		if boardIDs[boardChanges.BoardID] {
+			accessible = append(accessible, boardChanges)
+		}
+	}
+	return accessible, nil
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBoardChangesForUser(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	changes := []*model.BoardChanges{
+		{BoardID: "board-1", LastViewedAt: 100, ChangeCount: 3},
+		{BoardID: "board-2", LastViewedAt: 200, ChangeCount: 1},
+	}
+	th.Store.EXPECT().GetBoardChangesForUser("user-id", "team-id").Return(changes, nil)
+	th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return([]*model.Board{{ID: "board-1"}}, nil)
+
+	result, err := th.App.GetBoardChangesForUser("user-id", "team-id")
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Equal(t, "board-1", result[0].BoardID)
+	require.EqualValues(t, 3, result[0].ChangeCount)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -143,45 +144,53 @@ func (a *App) getBoardDescendantModifiedInfo(boardID string, latest bool) (int64
 }
 
 func (a *App) DuplicateBoard(boardID, userID, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
-	bab, members, err := a.store.DuplicateBoard(boardID, userID, toTeam, asTemplate)
-	if err != nil {
-		return nil, nil, err
-	}
+	var bab *model.BoardsAndBlocks
+	var members []*model.BoardMember
+	err := a.store.RunInTransaction(func(tx store.TxStore) error {
+		var err error
+		bab, members, err = tx.DuplicateBoard(boardID, userID, toTeam, asTemplate)
+		if err != nil {
+			return err
+		}
 
-	// copy any file attachments from the duplicated blocks.
-	if err = a.CopyCardFiles(boardID, bab.Blocks); err != nil {
-		a.logger.Error("Could not copy files while duplicating board", mlog.String("BoardID", boardID), mlog.Err(err))
-	}
+		// copy any file attachments from the duplicated blocks.
+		if err = a.CopyCardFiles(boardID, bab.Blocks); err != nil {
+			a.logger.Error("Could not copy files while duplicating board", mlog.String("BoardID", boardID), mlog.Err(err))
+		}
 
-	// bab.Blocks now has updated file ids for any blocks containing files.  We need to store them.
-	blockIDs := make([]string, 0)
-	blockPatches := make([]model.BlockPatch, 0)
+		// bab.Blocks now has updated file ids for any blocks containing files.  We need to store them.
+		blockIDs := make([]string, 0)
+		blockPatches := make([]model.BlockPatch, 0)
 
-	for _, block := range bab.Blocks {
-		if fileID, ok := block.Fields["fileId"]; ok {
-			blockIDs = append(blockIDs, block.ID)
-			blockPatches = append(blockPatches, model.BlockPatch{
-				UpdatedFields: map[string]interface{}{
-					"fileId": fileID,
-				},
-			})
+		for _, block := range bab.Blocks {
+			if fileID, ok := block.Fields["fileId"]; ok {
+				blockIDs = append(blockIDs, block.ID)
+				blockPatches = append(blockPatches, model.BlockPatch{
+					UpdatedFields: map[string]interface{}{
+						"fileId": fileID,
+					},
+				})
+			}
 		}
-	}
-	a.logger.Debug("Duplicate boards patching file IDs", mlog.Int("count", len(blockIDs)))
+		a.logger.Debug("Duplicate boards patching file IDs", mlog.Int("count", len(blockIDs)))
 
-	if len(blockIDs) != 0 {
-		patches := &model.BlockPatchBatch{
-			BlockIDs:     blockIDs,
-			BlockPatches: blockPatches,
-		}
-		if err = a.store.PatchBlocks(patches, userID); err != nil {
-			dbab := model.NewDeleteBoardsAndBlocksFromBabs(bab)
-			if err = a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
-				a.logger.Error("Cannot delete board after duplication error when updating block's file info", mlog.String("boardID", bab.Boards[0].ID), mlog.Err(err))
+		if len(blockIDs) != 0 {
+			patches := &model.BlockPatchBatch{
+				BlockIDs:     blockIDs,
+				BlockPatches: blockPatches,
+			}
+			// the duplicated board is rolled back with the transaction
+			if err = tx.PatchBlocks(patches, userID); err != nil {
+				return fmt.Errorf("could not patch file IDs while duplicating board %s: %w", boardID, err)
 			}
-			return nil, nil, fmt.Errorf("could not patch file IDs while duplicating board %s: %w", boardID, err)
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+	a.copyBoardCoverFiles(boardID, bab.Boards)
+	a.updateSearchIndex(searchIndexChange{Blocks: bab.Blocks})
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		teamID := ""
@@ -267,6 +276,7 @@ func (a *App) DeleteBoard(boardID, userID string) error {
 	if err := a.store.DeleteBoard(boardID, userID); err != nil {
 		return err
 	}
+	a.updateSearchIndex(searchIndexChange{DeletedBoardIDs: []string{boardID}})
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBoardDelete(board.TeamID, boardID)
@@ -445,6 +455,7 @@ func (a *App) UndeleteBoard(boardID string, modifiedBy string) error {
 		a.logger.Error("Error loading the board after undelete, not propagating through websockets or notifications")
 		return nil
 	}
+	a.updateSearchIndex(searchIndexChange{BoardIDs: []string{boardID}})
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		a.wsAdapter.BroadcastBoardChange(board.TeamID, board)
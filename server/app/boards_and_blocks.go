@@ -22,29 +22,32 @@ func (a *App) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string, a
 		return nil, err
 	}
 
-	// all new boards should belong to the same team
-	teamID := newBab.Boards[0].TeamID
+	a.notifyBoardsAndBlocksCreated(newBab, members, userID)
+	return newBab, nil
+}
+
+// notifyBoardsAndBlocksCreated broadcasts the new boards, blocks and
+// members, which should all belong to the same team.
+func (a *App) notifyBoardsAndBlocksCreated(bab *model.BoardsAndBlocks, members []*model.BoardMember, userID string) {
+	teamID := bab.Boards[0].TeamID
+	a.updateSearchIndex(searchIndexChange{Blocks: bab.Blocks})
 
 	// This can be synchronous because this action is not common
-	for _, board := range newBab.Boards {
+	for _, board := range bab.Boards {
 		a.wsAdapter.BroadcastBoardChange(teamID, board)
 	}
 
-	for _, block := range newBab.Blocks {
+	for _, block := range bab.Blocks {
 		b := block
 		a.wsAdapter.BroadcastBlockChange(teamID, b)
 		a.metrics.IncrementBlocksInserted(1)
-		a.webhook.NotifyUpdate(b)
+		a.webhook.NotifyBlockChange(teamID, model.WebhookActionAdd, b)
 		a.notifyBlockChanged(notify.Add, &b, nil, userID)
 	}
 
-	if addMember {
-		for _, member := range members {
-			a.wsAdapter.BroadcastMemberChange(teamID, member.BoardID, member)
-		}
+	for _, member := range members {
+		a.wsAdapter.BroadcastMemberChange(teamID, member.BoardID, member)
 	}
-
-	return newBab, nil
 }
 
 func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
@@ -61,6 +64,7 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 	if err != nil {
 		return nil, err
 	}
+	a.updateSearchIndex(searchIndexChange{Blocks: bab.Blocks})
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		teamID := bab.Boards[0].TeamID
@@ -75,7 +79,7 @@ func (a *App) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID stri
 			b := block
 			a.metrics.IncrementBlocksPatched(1)
 			a.wsAdapter.BroadcastBlockChange(teamID, b)
-			a.webhook.NotifyUpdate(b)
+			a.webhook.NotifyBlockChange(teamID, model.WebhookActionUpdate, b)
 			a.notifyBlockChanged(notify.Update, &b, oldBlock, userID)
 		}
 
@@ -108,11 +112,17 @@ func (a *App) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID st
 	if err := a.store.DeleteBoardsAndBlocks(dbab, userID); err != nil {
 		return err
 	}
+	deletedBlocks := make([]model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		deletedBlocks = append(deletedBlocks, *block)
+	}
+	a.updateSearchIndex(searchIndexChange{DeletedBlocks: deletedBlocks, DeletedBoardIDs: dbab.Boards})
 
 	a.blockChangeNotifier.Enqueue(func() error {
 		for _, block := range blocks {
-			a.wsAdapter.BroadcastBlockDelete(firstBoard.TeamID, block.ID, block.BoardID)
+			a.wsAdapter.BroadcastBlockDelete(firstBoard.TeamID, block.ID, block.BoardID, block.ParentID)
 			a.metrics.IncrementBlocksDeleted(1)
+			a.webhook.NotifyBlockChange(firstBoard.TeamID, model.WebhookActionDelete, *block)
 			a.notifyBlockChanged(notify.Update, block, block, userID)
 		}
 
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetArchivedCards returns the archived cards of a board.
+func (a *App) GetArchivedCards(boardID string) ([]model.Block, error) {
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := []model.Block{}
+	for i := range cards {
+		if model.IsCardArchived(&cards[i]) {
+			archived = append(archived, cards[i])
+		}
+	}
+	return archived, nil
+}
+
+// ArchiveCard marks a card of the board as archived, leaving it out of the
+// board views without deleting it.
+func (a *App) ArchiveCard(boardID, cardID, userID string) (*model.Block, error) {
+	return a.setCardArchived(boardID, cardID, true, userID)
+}
+
+// UnarchiveCard brings an archived card of the board back to the board
+// views.
+func (a *App) UnarchiveCard(boardID, cardID, userID string) (*model.Block, error) {
+	return a.setCardArchived(boardID, cardID, false, userID)
+}
+
+func (a *App) setCardArchived(boardID, cardID string, archived bool, userID string) (*model.Block, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil || card.BoardID != boardID || card.Type != model.TypeCard {
+		return nil, model.NewErrNotFound(cardID)
+	}
+	if model.IsCardArchived(card) == archived {
+		return card, nil
+	}
+
+	patch := &model.BlockPatch{}
+	if archived {
+		patch.UpdatedFields = map[string]interface{}{model.CardFieldArchived: true}
+	} else {
+		patch.DeletedFields = []string{model.CardFieldArchived}
+	}
+
+	// the card change is still broadcast so that the clients drop it from
+	// the board, or bring it back
+	if err := a.PatchBlock(cardID, patch, userID); err != nil {
+		return nil, err
+	}
+	return a.store.GetBlock(cardID)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetArchivedCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	cards := []model.Block{
+		{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}},
+		{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{model.CardFieldArchived: true}},
+	}
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+
+	archived, err := th.App.GetArchivedCards(testBoardID)
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	require.Equal(t, "card-2", archived[0].ID)
+}
+
+func TestArchiveCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("already archived", func(t *testing.T) {
+		card := &model.Block{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{model.CardFieldArchived: true}}
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+
+		block, err := th.App.ArchiveCard(testBoardID, "card-1", "user-id")
+		require.NoError(t, err)
+		require.Equal(t, card, block)
+	})
+
+	t.Run("not archived", func(t *testing.T) {
+		card := &model.Block{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+
+		block, err := th.App.UnarchiveCard(testBoardID, "card-1", "user-id")
+		require.NoError(t, err)
+		require.Equal(t, card, block)
+	})
+
+	t.Run("card from another board", func(t *testing.T) {
+		card := &model.Block{ID: "card-2", BoardID: "other-board", Type: model.TypeCard, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("card-2").Return(card, nil)
+
+		_, err := th.App.ArchiveCard(testBoardID, "card-2", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("not a card", func(t *testing.T) {
+		view := &model.Block{ID: "view-1", BoardID: testBoardID, Type: model.TypeView, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("view-1").Return(view, nil)
+
+		_, err := th.App.ArchiveCard(testBoardID, "view-1", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetAutoArchiveRule returns the auto-archive rule of a board, or nil if
+// the board has none.
+func (a *App) GetAutoArchiveRule(boardID string) (*model.AutoArchiveRule, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.AutoArchiveRuleFromBoard(board)
+}
+
+// SetAutoArchiveRule validates the rule against the property schema of the
+// board and saves it, or removes the rule of the board if it's nil.
+func (a *App) SetAutoArchiveRule(boardID string, rule *model.AutoArchiveRule, userID string) (*model.AutoArchiveRule, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+
+	if rule == nil {
+		if _, ok := board.Properties[model.BoardPropertyAutoArchive]; !ok {
+			return nil, nil
+		}
+		delete(board.Properties, model.BoardPropertyAutoArchive)
+	} else {
+		properties, err := model.CardPropertiesFromBoard(board)
+		if err != nil {
+			return nil, err
+		}
+		if err := rule.IsValid(properties); err != nil {
+			return nil, err
+		}
+		board.Properties[model.BoardPropertyAutoArchive] = rule
+	}
+
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return rule, nil
+}
+
+// RunAutoArchiveRules archives the cards of every board that stayed in the
+// status of the board auto-archive rule for longer than its days, and
+// reports them to the channel linked to the board.
+func (a *App) RunAutoArchiveRules(now time.Time) error {
+	boards, err := a.store.GetBoardsWithProperty(model.BoardPropertyAutoArchive)
+	if err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		if err := a.runBoardAutoArchiveRule(board, now); err != nil {
+			a.logger.Error("Cannot run auto-archive rule",
+				mlog.String("boardID", board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+	return nil
+}
+
+func (a *App) runBoardAutoArchiveRule(board *model.Board, now time.Time) error {
+	rule, err := model.AutoArchiveRuleFromBoard(board)
+	if err != nil || rule == nil {
+		return err
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return err
+	}
+
+	archived := []*model.Block{}
+	for i := range cards {
+		card := &cards[i]
+		if model.IsCardTemplate(card) || model.IsCardArchived(card) {
+			continue
+		}
+		if model.CardPropertyValues(card)[rule.PropertyID] != rule.OptionID {
+			continue
+		}
+
+		history, err := a.store.GetBlockHistory(card.ID, model.QueryBlockHistoryOptions{Descending: true})
+		if err != nil {
+			return err
+		}
+		if !rule.IsDue(rule.StatusEnteredAt(card, history), now) {
+			continue
+		}
+
+		archivedCard, err := a.ArchiveCard(board.ID, card.ID, model.SystemUserID)
+		if err != nil {
+			a.logger.Error("Cannot auto-archive card",
+				mlog.String("boardID", board.ID),
+				mlog.String("cardID", card.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		archived = append(archived, archivedCard)
+	}
+
+	if a.notifications != nil && len(archived) != 0 {
+		evt := notify.CardsAutoArchivedEvent{
+			TeamID: board.TeamID,
+			Board:  board,
+			Cards:  archived,
+			Days:   rule.Days,
+		}
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.notifications.CardsAutoArchived(evt)
+			return nil
+		})
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAutoArchiveRules(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Now()
+	daysAgo := func(days int) int64 {
+		return utils.GetMillisForTime(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": model.PropertyTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "doing", "value": "Doing", "color": model.PropertyColorDefault},
+					map[string]interface{}{"id": "done", "value": "Done", "color": model.PropertyColorDefault},
+				},
+			},
+		},
+		Properties: map[string]interface{}{
+			model.BoardPropertyAutoArchive: map[string]interface{}{"propertyId": "status", "optionId": "done", "days": 7},
+		},
+	}
+	oldDone := model.Block{
+		ID: "old-card", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard, UpdateAt: daysAgo(10),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "done"}},
+	}
+	recentDone := model.Block{
+		ID: "recent-card", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard, UpdateAt: daysAgo(2),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "done"}},
+	}
+	doing := model.Block{
+		ID: "doing-card", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard, UpdateAt: daysAgo(30),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "doing"}},
+	}
+
+	th.Store.EXPECT().GetBoardsWithProperty(model.BoardPropertyAutoArchive).Return([]*model.Board{board}, nil)
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return([]model.Block{oldDone, recentDone, doing}, nil)
+	th.Store.EXPECT().GetBlockHistory("old-card", gomock.Any()).Return([]model.Block{oldDone}, nil)
+	th.Store.EXPECT().GetBlockHistory("recent-card", gomock.Any()).Return([]model.Block{recentDone}, nil)
+	th.Store.EXPECT().GetBlock("old-card").Return(&oldDone, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().PatchBlock("old-card", gomock.Any(), model.SystemUserID).DoAndReturn(
+		func(blockID string, patch *model.BlockPatch, userID string) error {
+			require.Equal(t, true, patch.UpdatedFields[model.CardFieldArchived])
+			return nil
+		},
+	)
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+	require.NoError(t, th.App.RunAutoArchiveRules(now))
+}
+
+func TestSetAutoArchiveRule(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": model.PropertyTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "done", "value": "Done", "color": model.PropertyColorDefault},
+				},
+			},
+		},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, err := th.App.SetAutoArchiveRule(testBoardID, &model.AutoArchiveRule{PropertyID: "status", OptionID: "todo", Days: 7}, "user-id")
+		require.True(t, model.IsErrInvalidAutoArchiveRule(err))
+	})
+
+	t.Run("valid rule", func(t *testing.T) {
+		rule := &model.AutoArchiveRule{PropertyID: "status", OptionID: "done", Days: 7}
+		th.Store.EXPECT().InsertBoard(board, "user-id").Return(board, nil)
+
+		saved, err := th.App.SetAutoArchiveRule(testBoardID, rule, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, rule, saved)
+		require.Equal(t, rule, board.Properties[model.BoardPropertyAutoArchive])
+	})
+
+	t.Run("remove the rule", func(t *testing.T) {
+		th.Store.EXPECT().InsertBoard(board, "user-id").Return(board, nil)
+
+		_, err := th.App.SetAutoArchiveRule(testBoardID, nil, "user-id")
+		require.NoError(t, err)
+		require.NotContains(t, board.Properties, model.BoardPropertyAutoArchive)
+	})
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+// ShiftDependentCardDates moves the dates of the cards that depend on the
+// card, directly or through other cards, by the delta of the shift in a
+// single transaction, and returns the shifted cards. The dependent cards
+// without a date are left unchanged.
+func (a *App) ShiftDependentCardDates(boardID, cardID string, shift *model.CardDateShift, userID string) ([]model.Block, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+	if err = shift.IsValid(properties); err != nil {
+		return nil, err
+	}
+
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil || card.BoardID != boardID || card.Type != model.TypeCard {
+		return nil, model.NewErrNotFound(cardID)
+	}
+
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &model.BlockPatchBatch{}
+	oldCards := []model.Block{}
+	for _, dependent := range model.DependentCards(cards, cardID) {
+		patch := shift.DateShiftPatch(dependent)
+		if patch == nil {
+			continue
+		}
+		batch.BlockIDs = append(batch.BlockIDs, dependent.ID)
+		batch.BlockPatches = append(batch.BlockPatches, *patch)
+		oldCards = append(oldCards, *dependent)
+	}
+
+	if len(batch.BlockIDs) == 0 {
+		return []model.Block{}, nil
+	}
+
+	shifted := make([]model.Block, 0, len(batch.BlockIDs))
+	err = a.store.RunInTransaction(func(tx store.TxStore) error {
+		if err := tx.PatchBlocks(batch, userID); err != nil {
+			return err
+		}
+		for _, blockID := range batch.BlockIDs {
+			block, err := tx.GetBlock(blockID)
+			if err != nil {
+				return err
+			}
+			if block == nil {
+				return model.NewErrNotFound(blockID)
+			}
+			shifted = append(shifted, *block)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.updateSearchIndex(searchIndexChange{Blocks: shifted})
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.metrics.IncrementBlocksPatched(len(shifted))
+		for i := range shifted {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, shifted[i])
+			a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionUpdate, shifted[i])
+			a.notifyBlockChanged(notify.Update, &shifted[i], &oldCards[i], userID)
+		}
+		return nil
+	})
+	return shifted, nil
+}
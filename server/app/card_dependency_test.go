@@ -0,0 +1,62 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftDependentCardDates(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{"id": "due", "name": "Due", "type": model.PropertyTypeDate, "options": []interface{}{}},
+		},
+	}
+	newCard := func(id, due string, dependsOn ...interface{}) model.Block {
+		return model.Block{
+			ID: id, BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardFieldDependsOn: dependsOn,
+				"properties":             map[string]interface{}{"due": due},
+			},
+		}
+	}
+	design := newCard("design", `{"from":1000}`)
+	build := newCard("build", `{"from":2000,"to":3000}`, "design")
+	test := newCard("test", `{"from":4000}`, "build")
+
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+	t.Run("invalid shift", func(t *testing.T) {
+		_, err := th.App.ShiftDependentCardDates(testBoardID, "design", &model.CardDateShift{PropertyID: "unknown", Delta: 1000}, "user-id")
+		require.True(t, model.IsErrInvalidDateShift(err))
+	})
+
+	t.Run("shift the dependent cards", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("design").Return(&design, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return([]model.Block{design, build, test}, nil)
+		th.Store.EXPECT().PatchBlocks(gomock.Any(), "user-id").DoAndReturn(
+			func(batch *model.BlockPatchBatch, userID string) error {
+				require.Equal(t, []string{"build", "test"}, batch.BlockIDs)
+				values := batch.BlockPatches[0].UpdatedFields["properties"].(map[string]interface{})
+				require.Equal(t, `{"from":2500,"to":3500}`, values["due"])
+				return nil
+			},
+		)
+		th.Store.EXPECT().GetBlock("build").Return(&build, nil)
+		th.Store.EXPECT().GetBlock("test").Return(&test, nil)
+
+		shifted, err := th.App.ShiftDependentCardDates(testBoardID, "design", &model.CardDateShift{PropertyID: "due", Delta: 500}, "user-id")
+		require.NoError(t, err)
+		require.Len(t, shifted, 2)
+	})
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// updateParentCardsProgress recomputes the progress properties of the
+// cards that are parents of the given blocks. It is called after child
+// blocks are changed, and failures are logged as the change itself has
+// already been saved.
+func (a *App) updateParentCardsProgress(blocks []model.Block, modifiedByID string) {
+	updated := map[string]bool{}
+	for i := range blocks {
+		block := blocks[i]
+		if block.Type != model.TypeCheckbox && block.Type != model.TypeCard {
+			continue
+		}
+		if block.ParentID == "" || block.ParentID == block.BoardID || updated[block.ParentID] {
+			continue
+		}
+		updated[block.ParentID] = true
+
+		board, err := a.store.GetBoard(block.BoardID)
+		if err != nil {
+			a.logger.Error("cannot get board to update card progress", mlog.String("boardID", block.BoardID), mlog.Err(err))
+			continue
+		}
+
+		properties, err := model.CardPropertiesFromBoard(board)
+		if err != nil || !model.HasProgressProperties(properties) {
+			continue
+		}
+
+		children, err := a.store.GetBlocksWithParent(board.ID, block.ParentID)
+		if err != nil {
+			a.logger.Error("cannot get card children to update card progress", mlog.String("cardID", block.ParentID), mlog.Err(err))
+			continue
+		}
+
+		if err := a.updateCardProgress(board, properties, block.ParentID, children, modifiedByID); err != nil {
+			a.logger.Error("cannot update card progress", mlog.String("cardID", block.ParentID), mlog.Err(err))
+		}
+	}
+}
+
+// updateBoardProgress recomputes the progress properties of every card
+// of a board, used when the property schema changes.
+func (a *App) updateBoardProgress(board *model.Board, properties []model.CardProperty, modifiedByID string) error {
+	if !model.HasProgressProperties(properties) {
+		return nil
+	}
+
+	blocks, err := a.store.GetBlocksWithBoardID(board.ID)
+	if err != nil {
+		return err
+	}
+
+	children := map[string][]model.Block{}
+	for _, block := range blocks {
+		children[block.ParentID] = append(children[block.ParentID], block)
+	}
+
+	for _, block := range blocks {
+		if block.Type != model.TypeCard {
+			continue
+		}
+		if err := a.updateCardProgress(board, properties, block.ID, children[block.ID], modifiedByID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateCardProgress saves the progress values of a card computed from
+// its children, if they changed, and broadcasts the updated card.
+func (a *App) updateCardProgress(board *model.Board, properties []model.CardProperty, cardID string, children []model.Block, modifiedByID string) error {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		if model.IsErrNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if card == nil || card.Type != model.TypeCard {
+		return nil
+	}
+
+	values, changed := model.ApplyCardProgress(properties, card, children)
+	if !changed {
+		return nil
+	}
+
+	patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{"properties": values}}
+	if err := a.store.PatchBlock(cardID, patch, modifiedByID); err != nil {
+		return err
+	}
+
+	updatedCard, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+	a.updateSearchIndex(searchIndexChange{Blocks: []model.Block{*updatedCard}})
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, *updatedCard)
+		return nil
+	})
+	return nil
+}
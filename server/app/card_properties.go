@@ -0,0 +1,308 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *App) GetCardProperties(boardID string) ([]model.CardProperty, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.CardPropertiesFromBoard(board)
+}
+
+func (a *App) AddCardProperty(boardID string, property *model.CardProperty, userID string) (*model.CardProperty, error) {
+	property.Hydrate()
+
+	var added model.CardProperty
+	err := a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		added = *property
+		return append(properties, added), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &added, nil
+}
+
+func (a *App) PatchCardProperty(boardID, propertyID string, patch *model.CardPropertyPatch, userID string) (*model.CardProperty, error) {
+	var patched model.CardProperty
+	err := a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		idx := model.FindCardProperty(properties, propertyID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(propertyID)
+		}
+		patched = *patch.Patch(&properties[idx])
+		return properties, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+func (a *App) DeleteCardProperty(boardID, propertyID string, userID string) error {
+	return a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		idx := model.FindCardProperty(properties, propertyID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(propertyID)
+		}
+		return append(properties[:idx], properties[idx+1:]...), nil
+	})
+}
+
+func (a *App) ReorderCardProperties(boardID string, propertyIDs []string, userID string) ([]model.CardProperty, error) {
+	var reordered []model.CardProperty
+	err := a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		var err error
+		reordered, err = model.ReorderCardProperties(properties, propertyIDs)
+		return reordered, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reordered, nil
+}
+
+func (a *App) AddCardPropertyOption(boardID, propertyID string, option *model.CardPropertyOption, userID string) (*model.CardPropertyOption, error) {
+	option.Hydrate()
+
+	err := a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		idx := model.FindCardProperty(properties, propertyID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(propertyID)
+		}
+		properties[idx].Options = append(properties[idx].Options, *option)
+		return properties, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return option, nil
+}
+
+func (a *App) PatchCardPropertyOption(boardID, propertyID, optionID string, patch *model.CardPropertyOptionPatch, userID string) (*model.CardPropertyOption, error) {
+	var patched model.CardPropertyOption
+	err := a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		idx := model.FindCardProperty(properties, propertyID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(propertyID)
+		}
+		optionIdx := properties[idx].FindOption(optionID)
+		if optionIdx == -1 {
+			return nil, model.NewErrNotFound(optionID)
+		}
+		patched = *patch.Patch(&properties[idx].Options[optionIdx])
+		return properties, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+func (a *App) DeleteCardPropertyOption(boardID, propertyID, optionID string, userID string) error {
+	return a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		idx := model.FindCardProperty(properties, propertyID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(propertyID)
+		}
+		optionIdx := properties[idx].FindOption(optionID)
+		if optionIdx == -1 {
+			return nil, model.NewErrNotFound(optionID)
+		}
+		options := properties[idx].Options
+		properties[idx].Options = append(options[:optionIdx], options[optionIdx+1:]...)
+		properties[idx].DefaultValue = removeDefaultOption(properties[idx].DefaultValue, optionID)
+		return properties, nil
+	})
+}
+
+// updateCardProperties loads the typed property schema of a board,
+// applies the modifier and, if the resulting schema is valid, saves it
+// back to the board and broadcasts the change.
+func (a *App) updateCardProperties(boardID, userID string, modifier func([]model.CardProperty) ([]model.CardProperty, error)) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	properties, err = modifier(properties)
+	if err != nil {
+		return err
+	}
+
+	if err = model.ValidateCardProperties(properties); err != nil {
+		return err
+	}
+
+	board.CardProperties = model.CardPropertiesToMaps(properties)
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+
+	if err := a.updateBoardProgress(updatedBoard, properties, userID); err != nil {
+		a.logger.Error("cannot update card progress after schema change", mlog.String("boardID", boardID), mlog.Err(err))
+	}
+	return nil
+}
+
+// GetCardPropertyOptionUsage returns how many cards of the board use each
+// select and multiSelect option and when it was last set on a card.
+func (a *App) GetCardPropertyOptionUsage(boardID string) ([]model.CardPropertyOptionUsage, error) {
+	properties, err := a.GetCardProperties(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := a.store.GetBlockHistoryDescendants(boardID, model.QueryBlockHistoryOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ComputeCardPropertyOptionUsage(properties, cards, history), nil
+}
+
+// FilterCardsByLocation returns the cards of the board with a location
+// property value inside the area described by the filter.
+func (a *App) FilterCardsByLocation(board *model.Board, cards []model.Block, filter *model.CardLocationFilter) ([]model.Block, error) {
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+	return model.FilterCardsByLocation(properties, cards, filter), nil
+}
+
+// validateCardPropertyValues checks the property values of a card block
+// against the schema of its board, and enforces the required properties
+// following the board settings. oldBlock is the current version of the
+// block for updates, or nil for new blocks. Other block types are not
+// checked.
+func (a *App) validateCardPropertyValues(board *model.Board, block *model.Block, oldBlock *model.Block) error {
+	if block.Type != model.TypeCard {
+		return nil
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		// a board with an unreadable schema should not prevent its cards
+		// from being edited, so the values can't be checked
+		a.logger.Warn("cannot validate card property values",
+			mlog.String("boardID", board.ID),
+			mlog.String("blockID", block.ID),
+			mlog.Err(err),
+		)
+		return nil
+	}
+
+	if err := model.ValidateCardPropertyValues(properties, block); err != nil {
+		return err
+	}
+	return checkRequiredCardProperties(board, properties, block, oldBlock)
+}
+
+// checkRequiredCardProperties rejects new cards, and for the transition
+// mode cards whose select values change, if they miss required values.
+func checkRequiredCardProperties(board *model.Board, properties []model.CardProperty, block *model.Block, oldBlock *model.Block) error {
+	mode := model.GetRequiredEnforcement(board)
+	if mode == model.RequiredEnforcementNone || model.IsCardTemplate(block) {
+		return nil
+	}
+
+	if oldBlock != nil {
+		if mode != model.RequiredEnforcementTransition || !model.CardOptionValuesChanged(properties, oldBlock, block) {
+			return nil
+		}
+	}
+
+	if missing := model.MissingRequiredCardProperties(properties, block); len(missing) != 0 {
+		return model.NewErrMissingRequiredProperties(block.ID, missing)
+	}
+	return nil
+}
+
+// validatePatchedCardPropertyValues checks the property values that a
+// card block would have once the patch is applied.
+func (a *App) validatePatchedCardPropertyValues(board *model.Board, block *model.Block, patch *model.BlockPatch) error {
+	patched := *block
+	patched.Fields = make(map[string]interface{}, len(block.Fields))
+	for key, value := range block.Fields {
+		patched.Fields[key] = value
+	}
+	return a.validateCardPropertyValues(board, patch.Patch(&patched), block)
+}
+
+// GetCardPropertyAggregates returns the totals of a number property over
+// the cards of the board, optionally grouped by an option property.
+func (a *App) GetCardPropertyAggregates(boardID, propertyID, groupByID string) ([]model.CardPropertyAggregate, error) {
+	properties, err := a.GetCardProperties(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.AggregateCardPropertyNumbers(properties, cards, propertyID, groupByID)
+}
+
+// removeDefaultOption removes a deleted option from the default value of
+// a select or multiSelect property.
+func removeDefaultOption(defaultValue interface{}, optionID string) interface{} {
+	switch v := defaultValue.(type) {
+	case string:
+		if v == optionID {
+			return nil
+		}
+	case []interface{}:
+		ids := make([]interface{}, 0, len(v))
+		for _, id := range v {
+			if id != optionID {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return ids
+	}
+	return defaultValue
+}
+
+// applyCardPropertyDefaults sets the default property values of the board
+// on the new cards that don't have them.
+func (a *App) applyCardPropertyDefaults(board *model.Board, blocks []model.Block, userID string) {
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		a.logger.Warn("cannot apply card property defaults", mlog.String("boardID", board.ID), mlog.Err(err))
+		return
+	}
+
+	now := time.Now()
+	for i := range blocks {
+		model.ApplyCardPropertyDefaults(properties, &blocks[i], userID, now)
+	}
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCardProperty(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("success scenario", func(t *testing.T) {
+		board := &model.Board{
+			ID:     testBoardID,
+			TeamID: "team-id",
+			CardProperties: []map[string]interface{}{
+				{"id": "p1", "name": "Notes", "type": model.PropertyTypeText},
+			},
+		}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().InsertBoard(gomock.Any(), "user-id-1").DoAndReturn(
+			func(board *model.Board, userID string) (*model.Board, error) {
+				require.Len(t, board.CardProperties, 2)
+				require.Equal(t, "Status", board.CardProperties[1]["name"])
+				return board, nil
+			},
+		)
+		th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		property := &model.CardProperty{
+			Name:    "Status",
+			Type:    model.PropertyTypeSelect,
+			Options: []model.CardPropertyOption{{Value: "Done"}},
+		}
+		added, err := th.App.AddCardProperty(testBoardID, property, "user-id-1")
+		require.NoError(t, err)
+		require.NotEmpty(t, added.ID)
+		require.Equal(t, model.PropertyColorDefault, added.Options[0].Color)
+	})
+
+	t.Run("invalid property is not saved", func(t *testing.T) {
+		board := &model.Board{ID: testBoardID}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		property := &model.CardProperty{Name: "Status", Type: "unknown"}
+		_, err := th.App.AddCardProperty(testBoardID, property, "user-id-1")
+		var errInvalid *model.ErrInvalidCardProperty
+		require.ErrorAs(t, err, &errInvalid)
+	})
+}
+
+func TestDeleteCardPropertyOption(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "p1",
+				"name": "Status",
+				"type": model.PropertyTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "o1", "value": "Done", "color": model.PropertyColorGreen},
+				},
+			},
+		},
+	}
+
+	t.Run("unknown option", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		err := th.App.DeleteCardPropertyOption(testBoardID, "p1", "o2", "user-id-1")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestPatchCardPropertyOptionIcon(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	newBoard := func() *model.Board {
+		return &model.Board{
+			ID:     testBoardID,
+			TeamID: "team-id",
+			CardProperties: []map[string]interface{}{
+				{
+					"id":   "p1",
+					"name": "Status",
+					"type": model.PropertyTypeSelect,
+					"options": []interface{}{
+						map[string]interface{}{"id": "o1", "value": "Done", "color": model.PropertyColorGreen},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("icon is saved with the option", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(newBoard(), nil)
+		th.Store.EXPECT().InsertBoard(gomock.Any(), "user-id-1").DoAndReturn(
+			func(board *model.Board, userID string) (*model.Board, error) {
+				options := board.CardProperties[0]["options"].([]interface{})
+				require.Equal(t, "check-circle", options[0].(map[string]interface{})["icon"])
+				return board, nil
+			},
+		)
+		th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		icon := "check-circle"
+		patched, err := th.App.PatchCardPropertyOption(testBoardID, "p1", "o1", &model.CardPropertyOptionPatch{Icon: &icon}, "user-id-1")
+		require.NoError(t, err)
+		require.Equal(t, "check-circle", patched.Icon)
+	})
+
+	t.Run("invalid icon is not saved", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(newBoard(), nil)
+
+		icon := "not an icon"
+		_, err := th.App.PatchCardPropertyOption(testBoardID, "p1", "o1", &model.CardPropertyOptionPatch{Icon: &icon}, "user-id-1")
+		require.True(t, model.IsErrInvalidCardProperty(err))
+	})
+}
+
+func TestInsertBlocksRequiredProperties(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:         testBoardID,
+		Properties: map[string]interface{}{model.BoardPropertyRequiredEnforcement: model.RequiredEnforcementCreate},
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "required": true},
+		},
+	}
+
+	t.Run("card missing a required value", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		blocks := []model.Block{{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}}
+		_, err := th.App.InsertBlocks(blocks, "user-id-1", false)
+
+		var errMissing *model.ErrMissingRequiredProperties
+		require.ErrorAs(t, err, &errMissing)
+		require.Equal(t, "card-id", errMissing.CardID)
+		require.Equal(t, "owner", errMissing.Missing[0].ID)
+	})
+}
@@ -0,0 +1,148 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// GetCardReminders returns the pending reminders of the user, the soonest
+// first, only those about the card if cardID isn't empty.
+func (a *App) GetCardReminders(userID, cardID string) ([]*model.CardReminder, error) {
+	return a.store.GetCardRemindersForUser(userID, cardID)
+}
+
+// CreateCardReminder sets a reminder for the user about a card of the
+// board, at a time after now.
+func (a *App) CreateCardReminder(boardID, cardID, userID string, reminder *model.CardReminder, now time.Time) (*model.CardReminder, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil || card.BoardID != boardID || card.Type != model.TypeCard {
+		return nil, model.NewErrNotFound(cardID)
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminder.RemindAt <= utils.GetMillisForTime(now) {
+		return nil, model.NewErrInvalidCardReminder("remind time must be in the future")
+	}
+
+	reminder.UserID = userID
+	reminder.TeamID = board.TeamID
+	reminder.BoardID = boardID
+	reminder.CardID = cardID
+	return a.store.CreateCardReminder(reminder)
+}
+
+// SnoozeCardReminder postpones a reminder of the user to a time after now.
+// Delivered reminders can be snoozed too, which makes them pending again.
+func (a *App) SnoozeCardReminder(userID, reminderID string, remindAt int64, now time.Time) (*model.CardReminder, error) {
+	reminder, err := a.getUserCardReminder(userID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if remindAt <= utils.GetMillisForTime(now) {
+		return nil, model.NewErrInvalidCardReminder("remind time must be in the future")
+	}
+
+	reminder.RemindAt = remindAt
+	if err := a.store.UpdateCardReminder(reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// CancelCardReminder deletes a reminder of the user.
+func (a *App) CancelCardReminder(userID, reminderID string) error {
+	if _, err := a.getUserCardReminder(userID, reminderID); err != nil {
+		return err
+	}
+	return a.store.DeleteCardReminder(reminderID)
+}
+
+// getUserCardReminder returns a reminder of the user; the reminders of
+// other users aren't found.
+func (a *App) getUserCardReminder(userID, reminderID string) (*model.CardReminder, error) {
+	reminder, err := a.store.GetCardReminder(reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder.UserID != userID {
+		return nil, model.NewErrNotFound(reminderID)
+	}
+	return reminder, nil
+}
+
+// DeliverDueCardReminders sends the reminders due at the given time
+// through the notification service. The reminders about cards that no
+// longer exist are dropped.
+func (a *App) DeliverDueCardReminders(now time.Time) error {
+	if a.notifications == nil {
+		return nil
+	}
+
+	reminders, err := a.store.GetDueCardReminders(utils.GetMillisForTime(now))
+	if err != nil {
+		return err
+	}
+
+	deliveredIDs := make([]string, 0, len(reminders))
+	for _, reminder := range reminders {
+		evt, err := a.cardReminderEvent(reminder)
+		if err != nil {
+			// left pending to be retried
+			a.logger.Error("Cannot deliver card reminder",
+				mlog.String("reminderID", reminder.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		deliveredIDs = append(deliveredIDs, reminder.ID)
+		if evt == nil {
+			continue
+		}
+
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.notifications.CardReminderDue(*evt)
+			return nil
+		})
+	}
+	return a.store.MarkCardRemindersDelivered(deliveredIDs)
+}
+
+// cardReminderEvent returns the event of a due reminder, or nil if its
+// card doesn't exist anymore.
+func (a *App) cardReminderEvent(reminder *model.CardReminder) (*notify.CardReminderEvent, error) {
+	card, err := a.store.GetBlock(reminder.CardID)
+	if model.IsErrNotFound(err) || (err == nil && card == nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	board, err := a.store.GetBoard(card.BoardID)
+	if model.IsErrNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &notify.CardReminderEvent{
+		TeamID:   board.TeamID,
+		Board:    board,
+		Card:     card,
+		Reminder: reminder,
+	}, nil
+}
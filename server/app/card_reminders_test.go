@@ -0,0 +1,105 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCardReminder(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	card := &model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard}
+
+	t.Run("card from another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+
+		_, err := th.App.CreateCardReminder("other-board", "card-id", "user-id", &model.CardReminder{}, now)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("reminder in the past", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+
+		reminder := &model.CardReminder{RemindAt: utils.GetMillisForTime(now.Add(-time.Minute))}
+		_, err := th.App.CreateCardReminder(testBoardID, "card-id", "user-id", reminder, now)
+		require.True(t, model.IsErrInvalidCardReminder(err))
+	})
+
+	t.Run("sets the user and the team", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+
+		remindAt := utils.GetMillisForTime(now.Add(time.Hour))
+		expected := &model.CardReminder{
+			UserID:   "user-id",
+			TeamID:   "team-id",
+			BoardID:  testBoardID,
+			CardID:   "card-id",
+			RemindAt: remindAt,
+		}
+		th.Store.EXPECT().CreateCardReminder(expected).Return(expected, nil)
+
+		reminder, err := th.App.CreateCardReminder(testBoardID, "card-id", "user-id", &model.CardReminder{RemindAt: remindAt}, now)
+		require.NoError(t, err)
+		require.Equal(t, "team-id", reminder.TeamID)
+	})
+}
+
+func TestSnoozeCardReminder(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	later := utils.GetMillisForTime(now.Add(time.Hour))
+
+	t.Run("reminder of another user", func(t *testing.T) {
+		th.Store.EXPECT().GetCardReminder("reminder-id").Return(&model.CardReminder{ID: "reminder-id", UserID: "other-user"}, nil)
+
+		_, err := th.App.SnoozeCardReminder("user-id", "reminder-id", later, now)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("snooze to the past", func(t *testing.T) {
+		th.Store.EXPECT().GetCardReminder("reminder-id").Return(&model.CardReminder{ID: "reminder-id", UserID: "user-id"}, nil)
+
+		_, err := th.App.SnoozeCardReminder("user-id", "reminder-id", utils.GetMillisForTime(now), now)
+		require.True(t, model.IsErrInvalidCardReminder(err))
+	})
+
+	t.Run("snooze a delivered reminder", func(t *testing.T) {
+		delivered := &model.CardReminder{ID: "reminder-id", UserID: "user-id", DeliveredAt: 1}
+		th.Store.EXPECT().GetCardReminder("reminder-id").Return(delivered, nil)
+		th.Store.EXPECT().UpdateCardReminder(delivered).Return(nil)
+
+		reminder, err := th.App.SnoozeCardReminder("user-id", "reminder-id", later, now)
+		require.NoError(t, err)
+		require.Equal(t, later, reminder.RemindAt)
+	})
+}
+
+func TestCancelCardReminder(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("reminder of another user", func(t *testing.T) {
+		th.Store.EXPECT().GetCardReminder("reminder-id").Return(&model.CardReminder{ID: "reminder-id", UserID: "other-user"}, nil)
+
+		err := th.App.CancelCardReminder("user-id", "reminder-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("own reminder", func(t *testing.T) {
+		th.Store.EXPECT().GetCardReminder("reminder-id").Return(&model.CardReminder{ID: "reminder-id", UserID: "user-id"}, nil)
+		th.Store.EXPECT().DeleteCardReminder("reminder-id").Return(nil)
+
+		require.NoError(t, th.App.CancelCardReminder("user-id", "reminder-id"))
+	})
+}
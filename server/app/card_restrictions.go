@@ -0,0 +1,122 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// FilterRestrictedBlocks removes the restricted cards of the board that
+// the user can't see from the list, together with their content. Restricted
+// views are removed for everyone but the board admins.
+func (a *App) FilterRestrictedBlocks(board *model.Board, blocks []model.Block, userID string) ([]model.Block, error) {
+	if a.canSeeRestrictedBlocks(board.ID, userID) {
+		return blocks, nil
+	}
+
+	hiddenCardIDs, err := a.getHiddenCardIDs(board, userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.FilterRestrictedViews(model.FilterRestrictedBlocks(blocks, hiddenCardIDs)), nil
+}
+
+// CanUserSeeBlock returns true if the user can view the board of the block
+// and the block isn't a restricted card or view hidden from the user.
+func (a *App) CanUserSeeBlock(userID string, block model.Block) bool {
+	if a.permissions == nil || !a.permissions.HasPermissionToBoard(userID, block.BoardID, model.PermissionViewBoard) {
+		return false
+	}
+
+	board, err := a.store.GetBoard(block.BoardID)
+	if err != nil {
+		return false
+	}
+
+	// a deleted card isn't in the store anymore, check it directly
+	if block.Type == model.TypeCard && !a.canSeeRestrictedBlocks(board.ID, userID) {
+		properties, err := model.CardPropertiesFromBoard(board)
+		if err != nil || !model.CanUserSeeCard(properties, &block, userID, false) {
+			return false
+		}
+	}
+
+	blocks, err := a.FilterRestrictedBlocks(board, []model.Block{block}, userID)
+	return err == nil && len(blocks) == 1
+}
+
+// canSeeRestrictedBlocks returns true if the user sees all the restricted
+// cards and views of the board, as an admin of the board.
+func (a *App) canSeeRestrictedBlocks(boardID, userID string) bool {
+	if userID == model.SingleUser {
+		return true
+	}
+	if userID == "" {
+		return false
+	}
+	member, _ := a.store.GetMemberForBoard(boardID, userID)
+	return member != nil && member.SchemeAdmin
+}
+
+// getHiddenCardIDs returns the ids of the restricted cards of the board
+// that the user, who isn't an admin of the board, isn't allowed to see.
+func (a *App) getHiddenCardIDs(board *model.Board, userID string) (map[string]bool, error) {
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenCardIDs := map[string]bool{}
+	for i := range cards {
+		if !model.CanUserSeeCard(properties, &cards[i], userID, false) {
+			hiddenCardIDs[cards[i].ID] = true
+		}
+	}
+	return hiddenCardIDs, nil
+}
+
+// GetHiddenBlockIDs returns the ids of the cards and views of the board
+// that FilterRestrictedBlocks removes for the user, together with the
+// archived cards unless includeArchived is set, so that paged queries
+// can leave them and their content out in the store.
+func (a *App) GetHiddenBlockIDs(board *model.Board, userID string, includeArchived bool) ([]string, error) {
+	canSeeRestricted := a.canSeeRestrictedBlocks(board.ID, userID)
+	if canSeeRestricted && includeArchived {
+		return nil, nil
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	hiddenIDs := []string{}
+	for i := range cards {
+		if !canSeeRestricted && !model.CanUserSeeCard(properties, &cards[i], userID, false) {
+			hiddenIDs = append(hiddenIDs, cards[i].ID)
+		} else if !includeArchived && model.IsCardArchived(&cards[i]) {
+			hiddenIDs = append(hiddenIDs, cards[i].ID)
+		}
+	}
+	if canSeeRestricted {
+		return hiddenIDs, nil
+	}
+
+	views, err := a.store.GetBlocksWithType(board.ID, model.TypeView)
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		if model.IsViewRestricted(&views[i]) {
+			hiddenIDs = append(hiddenIDs, views[i].ID)
+		}
+	}
+	return hiddenIDs, nil
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/permissions/localpermissions"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterRestrictedBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": model.PropertyTypePerson},
+		},
+	}
+	cards := []model.Block{
+		{ID: "public-card", BoardID: testBoardID, Type: model.TypeCard},
+		{
+			ID:      "restricted-card",
+			BoardID: testBoardID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardFieldRestricted: true,
+				"properties":              map[string]interface{}{"assignee": "user-id-2"},
+			},
+		},
+	}
+	blocks := append([]model.Block{
+		{ID: "comment", BoardID: testBoardID, ParentID: "restricted-card", Type: model.TypeComment},
+	}, cards...)
+
+	t.Run("member that isn't assigned", func(t *testing.T) {
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-1").Return(&model.BoardMember{UserID: "user-id-1", SchemeEditor: true}, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+
+		filtered, err := th.App.FilterRestrictedBlocks(board, blocks, "user-id-1")
+		require.NoError(t, err)
+		require.Len(t, filtered, 1)
+		require.Equal(t, "public-card", filtered[0].ID)
+	})
+
+	t.Run("assignee", func(t *testing.T) {
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-2").Return(nil, sql.ErrNoRows)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+
+		filtered, err := th.App.FilterRestrictedBlocks(board, blocks, "user-id-2")
+		require.NoError(t, err)
+		require.Len(t, filtered, 3)
+	})
+
+	t.Run("board admin", func(t *testing.T) {
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-3").Return(&model.BoardMember{UserID: "user-id-3", SchemeAdmin: true}, nil)
+
+		filtered, err := th.App.FilterRestrictedBlocks(board, blocks, "user-id-3")
+		require.NoError(t, err)
+		require.Len(t, filtered, 3)
+	})
+
+	t.Run("restricted view", func(t *testing.T) {
+		views := []model.Block{
+			{ID: "view", BoardID: testBoardID, Type: model.TypeView},
+			{
+				ID:      "salaries",
+				BoardID: testBoardID,
+				Type:    model.TypeView,
+				Fields:  map[string]interface{}{model.ViewFieldRestricted: true},
+			},
+		}
+
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-1").Return(&model.BoardMember{UserID: "user-id-1", SchemeEditor: true}, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+
+		filtered, err := th.App.FilterRestrictedBlocks(board, views, "user-id-1")
+		require.NoError(t, err)
+		require.Len(t, filtered, 1)
+		require.Equal(t, "view", filtered[0].ID)
+
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-3").Return(&model.BoardMember{UserID: "user-id-3", SchemeAdmin: true}, nil)
+
+		filtered, err = th.App.FilterRestrictedBlocks(board, views, "user-id-3")
+		require.NoError(t, err)
+		require.Len(t, filtered, 2)
+	})
+}
+
+func TestGetHiddenBlockIDs(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": model.PropertyTypePerson},
+		},
+	}
+	cards := []model.Block{
+		{ID: "public-card", BoardID: testBoardID, Type: model.TypeCard},
+		{
+			ID:      "restricted-card",
+			BoardID: testBoardID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardFieldRestricted: true,
+				"properties":              map[string]interface{}{"assignee": "user-id-2"},
+			},
+		},
+		{ID: "archived-card", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{model.CardFieldArchived: true}},
+	}
+	views := []model.Block{
+		{ID: "view", BoardID: testBoardID, Type: model.TypeView},
+		{ID: "salaries", BoardID: testBoardID, Type: model.TypeView, Fields: map[string]interface{}{model.ViewFieldRestricted: true}},
+	}
+
+	t.Run("member that isn't assigned", func(t *testing.T) {
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-1").Return(&model.BoardMember{UserID: "user-id-1", SchemeEditor: true}, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeView).Return(views, nil)
+
+		hiddenIDs, err := th.App.GetHiddenBlockIDs(board, "user-id-1", false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"restricted-card", "archived-card", "salaries"}, hiddenIDs)
+	})
+
+	t.Run("board admin including the archived cards", func(t *testing.T) {
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-3").Return(&model.BoardMember{UserID: "user-id-3", SchemeAdmin: true}, nil)
+
+		hiddenIDs, err := th.App.GetHiddenBlockIDs(board, "user-id-3", true)
+		require.NoError(t, err)
+		require.Empty(t, hiddenIDs)
+	})
+}
+
+func TestCanUserSeeBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+	th.App.permissions = localpermissions.New(th.Store, th.logger, nil)
+
+	board := &model.Board{
+		ID: testBoardID,
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": model.PropertyTypePerson},
+		},
+	}
+	restrictedCard := model.Block{
+		ID:      "restricted-card",
+		BoardID: testBoardID,
+		Type:    model.TypeCard,
+		Fields: map[string]interface{}{
+			model.CardFieldRestricted: true,
+			"properties":              map[string]interface{}{"assignee": "user-id-2"},
+		},
+	}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return([]model.Block{restrictedCard}, nil).AnyTimes()
+
+	t.Run("user that isn't a member", func(t *testing.T) {
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-4").Return(nil, sql.ErrNoRows)
+
+		require.False(t, th.App.CanUserSeeBlock("user-id-4", model.Block{ID: "public-card", BoardID: testBoardID, Type: model.TypeCard}))
+	})
+
+	t.Run("member that isn't assigned", func(t *testing.T) {
+		member := &model.BoardMember{UserID: "user-id-1", SchemeEditor: true}
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-1").Return(member, nil).AnyTimes()
+
+		require.True(t, th.App.CanUserSeeBlock("user-id-1", model.Block{ID: "public-card", BoardID: testBoardID, Type: model.TypeCard}))
+		require.False(t, th.App.CanUserSeeBlock("user-id-1", restrictedCard))
+		require.False(t, th.App.CanUserSeeBlock("user-id-1", model.Block{ID: "comment", BoardID: testBoardID, ParentID: restrictedCard.ID, Type: model.TypeComment}))
+	})
+
+	t.Run("deleted restricted card", func(t *testing.T) {
+		deletedCard := restrictedCard
+		deletedCard.ID = "deleted-card"
+		deletedCard.DeleteAt = 1
+
+		require.False(t, th.App.CanUserSeeBlock("user-id-1", deletedCard))
+	})
+
+	t.Run("assignee", func(t *testing.T) {
+		member := &model.BoardMember{UserID: "user-id-2", SchemeViewer: true}
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id-2").Return(member, nil).AnyTimes()
+
+		require.True(t, th.App.CanUserSeeBlock("user-id-2", restrictedCard))
+	})
+}
@@ -0,0 +1,326 @@
+package app
+
+import (
+	"context"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/jobs"
+	"github.com/mattermost/focalboard/server/services/search"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// jobTypeReindexSearch is the job loading all the boards in the search
+// backend.
+const jobTypeReindexSearch = "reindexSearch"
+
+// SearchCards returns the page of the cards of the team matching the
+// full-text search for the user, most relevant first, with the fragments
+// of their titles, texts and comments that match. Only the cards of the
+// boards the user is a member of and can see match, narrowed by the
+// filters of the search.
+func (a *App) SearchCards(teamID, userID string, opts model.SearchOptions) (*model.CardSearchResults, error) {
+	page, perPage := opts.Paging()
+	results := &model.CardSearchResults{
+		Results: []*model.CardSearchHit{},
+		Page:    page,
+		PerPage: perPage,
+	}
+
+	assigneeIDs, ok, err := a.searchAssigneeIDs(opts.Filters.Assignees, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return results, nil
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberBoardIDs := map[string]bool{}
+	for _, member := range members {
+		memberBoardIDs[member.BoardID] = true
+	}
+
+	boardsByID := map[string]*model.Board{}
+	cardFilters := map[string]*model.CardSearchFilter{}
+	boardIDs := []string{}
+	for _, board := range boards {
+		if !memberBoardIDs[board.ID] {
+			continue
+		}
+		if opts.Filters.HasCardFilters() {
+			var filter *model.CardSearchFilter
+			filter, err = a.cardSearchFilter(board, opts.Filters, assigneeIDs)
+			if err != nil {
+				return nil, err
+			}
+			if filter == nil {
+				continue
+			}
+			cardFilters[board.ID] = filter
+		}
+		boardsByID[board.ID] = board
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	if err = a.loadSearchIndex(boardIDs); err != nil {
+		return nil, err
+	}
+	hits, err := a.searchIndex.Search(boardIDs, opts.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cards are read to leave out the templates and the cards hidden
+	// from the user
+	cardsByID := map[string]*model.Block{}
+	boardsRead := map[string]bool{}
+	for _, hit := range hits {
+		board, ok := boardsByID[hit.BoardID]
+		if !ok || boardsRead[board.ID] {
+			continue
+		}
+		boardsRead[board.ID] = true
+
+		var cards []model.Block
+		if cards, err = a.store.GetBlocksWithType(board.ID, model.TypeCard); err != nil {
+			return nil, err
+		}
+		if cards, err = a.FilterRestrictedBlocks(board, cards, userID); err != nil {
+			return nil, err
+		}
+		for i := range cards {
+			if !model.IsCardTemplate(&cards[i]) {
+				cardsByID[cards[i].ID] = &cards[i]
+			}
+		}
+	}
+
+	matching := []*model.CardSearchHit{}
+	for _, hit := range hits {
+		card, ok := cardsByID[hit.CardID]
+		if !ok || !opts.Filters.MatchesUpdateAt(card.UpdateAt) {
+			continue
+		}
+		if filter := cardFilters[hit.BoardID]; filter != nil && !filter.Matches(card) {
+			continue
+		}
+		board := boardsByID[hit.BoardID]
+		icon, _ := card.Fields["icon"].(string)
+
+		result := &model.CardSearchHit{
+			CardID:     card.ID,
+			Title:      card.Title,
+			Icon:       icon,
+			BoardID:    board.ID,
+			BoardTitle: board.Title,
+			Score:      hit.Score,
+			Highlights: make([]*model.CardSearchHighlight, 0, len(hit.Highlights)),
+		}
+		for _, highlight := range hit.Highlights {
+			result.Highlights = append(result.Highlights, &model.CardSearchHighlight{
+				BlockID:  highlight.DocumentID,
+				Type:     model.BlockType(highlight.Type),
+				Fragment: highlight.Fragment,
+			})
+		}
+		matching = append(matching, result)
+	}
+
+	results.Total = len(matching)
+	if start := page * perPage; start < len(matching) {
+		end := start + perPage
+		if end > len(matching) {
+			end = len(matching)
+		}
+		results.Results = matching[start:end]
+	}
+	return results, nil
+}
+
+// loadSearchIndex reads the blocks of the boards that are not in the
+// search index yet into it.
+func (a *App) loadSearchIndex(boardIDs []string) error {
+	for _, boardID := range boardIDs {
+		loaded, err := a.searchIndex.IsLoaded(boardID)
+		if err != nil {
+			return err
+		}
+		if loaded {
+			continue
+		}
+
+		docs, err := a.searchDocumentsForBoard(boardID)
+		if err != nil {
+			return err
+		}
+		if err = a.searchIndex.LoadBoard(boardID, docs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// searchDocumentsForBoard returns the searchable texts of the cards of the
+// board.
+func (a *App) searchDocumentsForBoard(boardID string) ([]search.Document, error) {
+	blocks, err := a.store.GetBlocksForBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]search.Document, 0, len(blocks))
+	for i := range blocks {
+		if doc, ok := searchDocumentForBlock(&blocks[i]); ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// ReindexSearchAsync enqueues the reindex of all the boards of all the
+// teams, which fills a persistent search backend like Elasticsearch.
+func (a *App) ReindexSearchAsync(userID string) (*model.Job, error) {
+	return a.enqueueJob(jobTypeReindexSearch, struct{}{}, userID)
+}
+
+func (a *App) runReindexSearchJob(ctx context.Context, _ *model.Job, progress jobs.ProgressFunc) (map[string]interface{}, error) {
+	teamIDs, err := a.getAllTeamIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	boards := 0
+	for i, teamID := range teamIDs {
+		var teamBoards []*model.Board
+		teamBoards, err = a.store.GetBoardsForTeam(teamID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, err
+		}
+		for _, board := range teamBoards {
+			if err = ctx.Err(); err != nil {
+				return nil, err
+			}
+			var docs []search.Document
+			if docs, err = a.searchDocumentsForBoard(board.ID); err != nil {
+				return nil, err
+			}
+			if err = a.searchIndex.LoadBoard(board.ID, docs); err != nil {
+				return nil, err
+			}
+			boards++
+		}
+		if progress != nil {
+			progress((i + 1) * 100 / len(teamIDs))
+		}
+	}
+	return map[string]interface{}{"boards": boards}, nil
+}
+
+// searchIndexChange lists the writes of blocks and boards to apply to the
+// search index.
+type searchIndexChange struct {
+	// Blocks are the blocks inserted or changed, as written.
+	Blocks []model.Block
+	// DeletedBlocks are the blocks deleted.
+	DeletedBlocks []model.Block
+	// BoardIDs are the boards whose blocks are all indexed again, like the
+	// duplicated, imported or restored boards.
+	BoardIDs []string
+	// DeletedBoardIDs are the boards deleted.
+	DeletedBoardIDs []string
+}
+
+// updateSearchIndex is the hook every write of blocks and boards goes
+// through once it is stored, so that the search index follows them. The
+// changed boards are shared with the other servers of the cluster, which
+// load them again. The search doesn't fail the changes, the errors are
+// logged.
+func (a *App) updateSearchIndex(change searchIndexChange) {
+	changedBoardIDs := map[string]bool{}
+
+	blocks := change.Blocks
+	for i := range blocks {
+		doc, ok := searchDocumentForBlock(&blocks[i])
+		if !ok {
+			continue
+		}
+		changedBoardIDs[doc.BoardID] = true
+		var err error
+		if blocks[i].DeleteAt != 0 {
+			err = a.searchIndex.Delete(doc.ID)
+		} else {
+			err = a.searchIndex.Update(doc)
+		}
+		if err != nil {
+			a.logger.Warn("Cannot update the search index", mlog.String("blockID", doc.ID), mlog.Err(err))
+		}
+	}
+
+	for i := range change.DeletedBlocks {
+		if _, ok := searchDocumentForBlock(&change.DeletedBlocks[i]); !ok {
+			continue
+		}
+		changedBoardIDs[change.DeletedBlocks[i].BoardID] = true
+		if err := a.searchIndex.Delete(change.DeletedBlocks[i].ID); err != nil {
+			a.logger.Warn("Cannot remove a block from the search index", mlog.String("blockID", change.DeletedBlocks[i].ID), mlog.Err(err))
+		}
+	}
+
+	for _, boardID := range change.BoardIDs {
+		changedBoardIDs[boardID] = true
+		docs, err := a.searchDocumentsForBoard(boardID)
+		if err == nil {
+			err = a.searchIndex.LoadBoard(boardID, docs)
+		}
+		if err != nil {
+			a.logger.Warn("Cannot index a board in the search index", mlog.String("boardID", boardID), mlog.Err(err))
+		}
+	}
+
+	for _, boardID := range change.DeletedBoardIDs {
+		changedBoardIDs[boardID] = true
+		if err := a.searchIndex.RemoveBoard(boardID); err != nil {
+			a.logger.Warn("Cannot remove a board from the search index", mlog.String("boardID", boardID), mlog.Err(err))
+		}
+	}
+
+	if a.searchCluster != nil && len(changedBoardIDs) > 0 {
+		boardIDs := make([]string, 0, len(changedBoardIDs))
+		for boardID := range changedBoardIDs {
+			boardIDs = append(boardIDs, boardID)
+		}
+		a.searchCluster.BoardsChanged(boardIDs)
+	}
+}
+
+// searchDocumentForBlock returns the searchable text of the block, and
+// false if the block is not the title, a text or a comment of a card.
+func searchDocumentForBlock(block *model.Block) (search.Document, bool) {
+	doc := search.Document{
+		ID:       block.ID,
+		BoardID:  block.BoardID,
+		CardID:   block.ParentID,
+		Text:     block.Title,
+		UpdateAt: block.UpdateAt,
+	}
+	switch block.Type {
+	case model.TypeCard:
+		doc.Type = search.DocumentTypeTitle
+		doc.CardID = block.ID
+	case model.TypeText:
+		doc.Type = search.DocumentTypeText
+	case model.TypeComment:
+		doc.Type = search.DocumentTypeComment
+	default:
+		return doc, false
+	}
+	return doc, doc.CardID != ""
+}
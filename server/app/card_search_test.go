@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boards := []*model.Board{
+		{ID: "board-1", TeamID: "team-id", Title: "Release board"},
+		{ID: "board-2", TeamID: "team-id", Title: "Roadmap", Type: model.BoardTypeOpen},
+	}
+	cards := []model.Block{
+		{ID: "card-1", BoardID: "board-1", Type: model.TypeCard, Title: "Login page", Fields: map[string]interface{}{"icon": "🔑"}},
+		{ID: "card-template", BoardID: "board-1", Type: model.TypeCard, Title: "Release template", Fields: map[string]interface{}{"isTemplate": true}},
+	}
+	blocks := append([]model.Block{
+		{ID: "text-1", BoardID: "board-1", ParentID: "card-1", Type: model.TypeText, Title: "Needed for the release"},
+		{ID: "view-1", BoardID: "board-1", ParentID: "board-1", Type: model.TypeView, Title: "Release view"},
+	}, cards...)
+
+	th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return(boards, nil).Times(2)
+	th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{{BoardID: "board-1", UserID: "user-id"}}, nil).Times(2)
+	th.Store.EXPECT().GetMemberForBoard("board-1", "user-id").Return(&model.BoardMember{SchemeAdmin: true}, nil).Times(2)
+	th.Store.EXPECT().GetBlocksWithType("board-1", model.TypeCard).Return(cards, nil).Times(2)
+	// the board is read once, then kept up to date by the block changes
+	th.Store.EXPECT().GetBlocksForBoard("board-1").Return(blocks, nil)
+
+	results, err := th.App.SearchCards("team-id", "user-id", model.SearchOptions{Terms: "release"})
+	require.NoError(t, err)
+	require.Equal(t, 1, results.Total)
+	require.Equal(t, &model.CardSearchHit{
+		CardID:     "card-1",
+		Title:      "Login page",
+		Icon:       "🔑",
+		BoardID:    "board-1",
+		BoardTitle: "Release board",
+		Score:      results.Results[0].Score,
+		Highlights: []*model.CardSearchHighlight{
+			{BlockID: "text-1", Type: model.TypeText, Fragment: "Needed for the <mark>release</mark>"},
+		},
+	}, results.Results[0])
+
+	th.App.updateSearchIndex(searchIndexChange{Blocks: []model.Block{
+		{ID: "text-1", BoardID: "board-1", ParentID: "card-1", Type: model.TypeText, Title: "Needed for the launch"},
+	}})
+
+	results, err = th.App.SearchCards("team-id", "user-id", model.SearchOptions{Terms: "launch"})
+	require.NoError(t, err)
+	require.Equal(t, 1, results.Total)
+	require.Equal(t, "card-1", results.Results[0].CardID)
+}
+
+func TestReindexSearchJob(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetAllTeams().Return([]*model.Team{{ID: "team-id"}}, nil)
+	th.Store.EXPECT().GetBoardsForTeam(model.GlobalTeamID).Return([]*model.Board{}, nil)
+	th.Store.EXPECT().GetBoardsForTeam("team-id").Return([]*model.Board{{ID: "board-1", TeamID: "team-id"}}, nil)
+	th.Store.EXPECT().GetBlocksForBoard("board-1").Return([]model.Block{
+		{ID: "card-1", BoardID: "board-1", Type: model.TypeCard, Title: "Release notes"},
+	}, nil)
+
+	percents := []int{}
+	result, err := th.App.runReindexSearchJob(context.Background(), &model.Job{}, func(percent int) {
+		percents = append(percents, percent)
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"boards": 1}, result)
+	require.Equal(t, []int{50, 100}, percents)
+
+	loaded, err := th.App.searchIndex.IsLoaded("board-1")
+	require.NoError(t, err)
+	require.True(t, loaded)
+}
+
+func TestSearchIndexBoardChanges(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: "board-1", TeamID: "team-id"}
+	th.Store.EXPECT().GetBoard("board-1").Return(board, nil).Times(2)
+	th.Store.EXPECT().GetMembersForBoard("board-1").Return([]*model.BoardMember{}, nil).AnyTimes()
+	th.Store.EXPECT().DeleteBoard("board-1", "user-id").Return(nil)
+	th.Store.EXPECT().GetBoardHistory("board-1", gomock.Any()).Return([]*model.Board{board}, nil)
+	th.Store.EXPECT().UndeleteBoard("board-1", "user-id").Return(nil)
+	th.Store.EXPECT().GetBlocksForBoard("board-1").Return([]model.Block{
+		{ID: "card-1", BoardID: "board-1", Type: model.TypeCard, Title: "Release notes"},
+	}, nil)
+
+	require.NoError(t, th.App.DeleteBoard("board-1", "user-id"))
+	loaded, err := th.App.searchIndex.IsLoaded("board-1")
+	require.NoError(t, err)
+	require.False(t, loaded)
+
+	// the restored board is indexed again
+	require.NoError(t, th.App.UndeleteBoard("board-1", "user-id"))
+	loaded, err = th.App.searchIndex.IsLoaded("board-1")
+	require.NoError(t, err)
+	require.True(t, loaded)
+
+	hits, err := th.App.searchIndex.Search([]string{"board-1"}, "release")
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "card-1", hits[0].CardID)
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetCardTemplates returns the card templates of a board.
+func (a *App) GetCardTemplates(boardID string) ([]model.Block, error) {
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []model.Block{}
+	for i := range cards {
+		if model.IsCardTemplate(&cards[i]) {
+			templates = append(templates, cards[i])
+		}
+	}
+	return templates, nil
+}
+
+// GetCardTemplate returns a card template of a board, or a not found
+// error if the block doesn't exist, belongs to a different board or is
+// not a card template.
+func (a *App) GetCardTemplate(boardID, templateID string) (*model.Block, error) {
+	template, err := a.store.GetBlock(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if template == nil || template.BoardID != boardID || !model.IsCardTemplate(template) {
+		return nil, model.NewErrNotFound(templateID)
+	}
+	return template, nil
+}
+
+// CreateCardTemplate creates a card template on the board, either copying
+// an existing card or from the given title, properties and content.
+// It returns the template card followed by its content blocks.
+func (a *App) CreateCardTemplate(boardID string, template *model.CardTemplate, userID string) ([]model.Block, error) {
+	if err := template.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if template.SourceCardID != "" {
+		card, err := a.store.GetBlock(template.SourceCardID)
+		if err != nil {
+			return nil, err
+		}
+		if card == nil || card.BoardID != boardID || card.Type != model.TypeCard {
+			return nil, model.NewErrNotFound(template.SourceCardID)
+		}
+		return a.DuplicateBlock(boardID, template.SourceCardID, userID, true)
+	}
+
+	return a.InsertBlocks(template.ToBlocks(boardID), userID, false)
+}
+
+// CreateCardFromTemplate creates a new card on the board copying the
+// template content and default property values, with the overrides
+// applied on top. It returns the new card followed by its content blocks.
+func (a *App) CreateCardFromTemplate(boardID, templateID string, overrides *model.CardFromTemplate, userID string) ([]model.Block, error) {
+	if _, err := a.GetCardTemplate(boardID, templateID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.DuplicateBlock(boardID, templateID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if overrides == nil || (overrides.Title == nil && len(overrides.Properties) == 0) {
+		return blocks, nil
+	}
+
+	if err := a.PatchBlock(blocks[0].ID, overrides.Patch(&blocks[0]), userID); err != nil {
+		return nil, err
+	}
+
+	card, err := a.store.GetBlock(blocks[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	blocks[0] = *card
+	return blocks, nil
+}
+
+// PatchCardTemplate updates a card template of the board.
+func (a *App) PatchCardTemplate(boardID, templateID string, patch *model.BlockPatch, userID string) (*model.Block, error) {
+	if _, err := a.GetCardTemplate(boardID, templateID); err != nil {
+		return nil, err
+	}
+
+	// a template can't be turned into a regular card or moved to another board
+	delete(patch.UpdatedFields, "isTemplate")
+	patch.BoardID = nil
+	patch.ParentID = nil
+	patch.Type = nil
+
+	if err := a.PatchBlock(templateID, patch, userID); err != nil {
+		return nil, err
+	}
+	return a.store.GetBlock(templateID)
+}
+
+// DeleteCardTemplate deletes a card template of the board.
+func (a *App) DeleteCardTemplate(boardID, templateID string, userID string) error {
+	if _, err := a.GetCardTemplate(boardID, templateID); err != nil {
+		return err
+	}
+	return a.DeleteBlock(templateID, userID)
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCardTemplates(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	cards := []model.Block{
+		{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}},
+		{ID: "template-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{"isTemplate": true}},
+	}
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(cards, nil)
+
+	templates, err := th.App.GetCardTemplates(testBoardID)
+	require.NoError(t, err)
+	require.Len(t, templates, 1)
+	require.Equal(t, "template-1", templates[0].ID)
+}
+
+func TestGetCardTemplate(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("success scenario", func(t *testing.T) {
+		template := &model.Block{ID: "template-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{"isTemplate": true}}
+		th.Store.EXPECT().GetBlock("template-1").Return(template, nil)
+
+		block, err := th.App.GetCardTemplate(testBoardID, "template-1")
+		require.NoError(t, err)
+		require.Equal(t, template, block)
+	})
+
+	t.Run("regular card", func(t *testing.T) {
+		card := &model.Block{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+
+		_, err := th.App.GetCardTemplate(testBoardID, "card-1")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("template from another board", func(t *testing.T) {
+		template := &model.Block{ID: "template-2", BoardID: "other-board", Type: model.TypeCard, Fields: map[string]interface{}{"isTemplate": true}}
+		th.Store.EXPECT().GetBlock("template-2").Return(template, nil)
+
+		_, err := th.App.GetCardTemplate(testBoardID, "template-2")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetCards returns a page of the cards of the board that the user can see,
+// leaving out the templates and the archived cards. The cards are sorted
+// by creation time so that the pages are stable.
+func (a *App) GetCards(board *model.Board, opts model.QueryCardsOptions, userID string) ([]*model.Card, error) {
+	blocks, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err = a.FilterRestrictedBlocks(board, blocks, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := []model.Block{}
+	for i := range blocks {
+		if model.IsCardTemplate(&blocks[i]) || model.IsCardArchived(&blocks[i]) {
+			continue
+		}
+		if model.CardMatchesPropertyFilters(&blocks[i], opts.PropertyFilters) {
+			matching = append(matching, blocks[i])
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].CreateAt != matching[j].CreateAt {
+			return matching[i].CreateAt < matching[j].CreateAt
+		}
+		return matching[i].ID < matching[j].ID
+	})
+
+	page := model.PaginateCards(matching, opts)
+	cards := make([]*model.Card, 0, len(page))
+	for i := range page {
+		cards = append(cards, model.Block2Card(&page[i]))
+	}
+	return cards, nil
+}
+
+// GetCard returns a card of the board if the user can see it.
+func (a *App) GetCard(board *model.Board, cardID, userID string) (*model.Card, error) {
+	block, err := a.getVisibleCardBlock(board, cardID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.Block2Card(block), nil
+}
+
+// CreateCard adds a new card to the board, as a card block placed at the
+// root of the board.
+func (a *App) CreateCard(boardID string, card *model.Card, userID string) (*model.Card, error) {
+	card.ID = ""
+	card.BoardID = boardID
+	if err := card.IsValid(); err != nil {
+		return nil, err
+	}
+
+	block := card.ToBlock()
+	if err := a.InsertBlock(block, userID); err != nil {
+		return nil, err
+	}
+
+	created, err := a.store.GetBlock(block.ID)
+	if err != nil {
+		return nil, err
+	}
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	newCard := model.Block2Card(created)
+	newCard.AssignmentWarnings = a.assignmentWarnings(board, nil, created)
+	return newCard, nil
+}
+
+// PatchCard applies the patch to a card of the board that the user can
+// see.
+func (a *App) PatchCard(board *model.Board, cardID string, patch *model.CardPatch, userID string) (*model.Card, error) {
+	if err := patch.IsValid(); err != nil {
+		return nil, err
+	}
+
+	block, err := a.getVisibleCardBlock(board, cardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.PatchBlock(block.ID, patch.BlockPatch(block), userID); err != nil {
+		return nil, err
+	}
+
+	patched, err := a.store.GetBlock(block.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	patchedCard := model.Block2Card(patched)
+	patchedCard.AssignmentWarnings = a.assignmentWarnings(board, block, patched)
+	return patchedCard, nil
+}
+
+// DeleteCard deletes a card of the board that the user can see.
+func (a *App) DeleteCard(board *model.Board, cardID, userID string) error {
+	block, err := a.getVisibleCardBlock(board, cardID, userID)
+	if err != nil {
+		return err
+	}
+	return a.DeleteBlock(block.ID, userID)
+}
+
+// getVisibleCardBlock returns the card block of a card of the board, or a
+// not found error if it's not a card of the board or the user can't see
+// it.
+func (a *App) getVisibleCardBlock(board *model.Board, cardID, userID string) (*model.Block, error) {
+	block, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.BoardID != board.ID || block.Type != model.TypeCard {
+		return nil, model.NewErrNotFound(cardID)
+	}
+
+	visible, err := a.FilterRestrictedBlocks(board, []model.Block{*block}, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(visible) == 0 {
+		return nil, model.NewErrNotFound(cardID)
+	}
+	return block, nil
+}
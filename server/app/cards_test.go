@@ -0,0 +1,103 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCards(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+	admin := &model.BoardMember{UserID: "user-id", SchemeAdmin: true}
+	blocks := []model.Block{
+		{ID: "card-3", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 3, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done"},
+		}},
+		{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 1, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "done"},
+		}},
+		{ID: "card-2", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 2, Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo"},
+		}},
+		{ID: "template", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 4, Fields: map[string]interface{}{
+			"isTemplate": true,
+		}},
+		{ID: "archived", BoardID: testBoardID, Type: model.TypeCard, CreateAt: 5, Fields: map[string]interface{}{
+			model.CardFieldArchived: true,
+		}},
+	}
+
+	t.Run("all cards", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(blocks, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+
+		cards, err := th.App.GetCards(board, model.QueryCardsOptions{}, "user-id")
+		require.NoError(t, err)
+		require.Len(t, cards, 3)
+		require.Equal(t, "card-1", cards[0].ID)
+		require.Equal(t, "card-2", cards[1].ID)
+		require.Equal(t, "card-3", cards[2].ID)
+	})
+
+	t.Run("filtered and paginated", func(t *testing.T) {
+		th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return(blocks, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+
+		opts := model.QueryCardsOptions{
+			Page:            1,
+			PerPage:         1,
+			PropertyFilters: map[string]string{"status": "done"},
+		}
+		cards, err := th.App.GetCards(board, opts, "user-id")
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "card-3", cards[0].ID)
+	})
+}
+
+func TestGetCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+
+	t.Run("card", func(t *testing.T) {
+		block := &model.Block{ID: "card-1", BoardID: testBoardID, Type: model.TypeCard, Title: "My card", Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("card-1").Return(block, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(&model.BoardMember{SchemeAdmin: true}, nil)
+
+		card, err := th.App.GetCard(board, "card-1", "user-id")
+		require.NoError(t, err)
+		require.Equal(t, "My card", card.Title)
+	})
+
+	t.Run("card from another board", func(t *testing.T) {
+		block := &model.Block{ID: "card-2", BoardID: "other-board", Type: model.TypeCard, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("card-2").Return(block, nil)
+
+		_, err := th.App.GetCard(board, "card-2", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("not a card", func(t *testing.T) {
+		block := &model.Block{ID: "view-1", BoardID: testBoardID, Type: model.TypeView, Fields: map[string]interface{}{}}
+		th.Store.EXPECT().GetBlock("view-1").Return(block, nil)
+
+		_, err := th.App.GetCard(board, "view-1", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestPatchCardInvalid(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	patch := &model.CardPatch{UpdatedProperties: map[string]interface{}{"": "value"}}
+	_, err := th.App.PatchCard(&model.Board{ID: testBoardID}, "card-1", patch, "user-id")
+	require.True(t, model.IsErrInvalidCard(err))
+}
@@ -10,5 +10,30 @@ func (a *App) GetClientConfig() *model.ClientConfig {
 		TelemetryID:              a.config.TelemetryID,
 		EnablePublicSharedBoards: a.config.EnablePublicSharedBoards,
 		FeatureFlags:             a.config.FeatureFlags,
+		Capabilities:             a.GetServerCapabilities(),
 	}
 }
+
+// GetServerCapabilities returns what the server supports.
+func (a *App) GetServerCapabilities() model.ServerCapabilities {
+	return model.ServerCapabilities{
+		BlockTypes:               model.KnownBlockTypes(),
+		ArchiveVersion:           archiveVersion,
+		SearchOperators:          []string{model.SearchOperatorAnyWord},
+		WebsocketProtocolVersion: model.WebsocketProtocolVersion,
+		GraphQL:                  a.config.EnableGraphQL,
+	}
+}
+
+// GetClientConfigForTeam returns the client configuration with the
+// feature flags of the team.
+func (a *App) GetClientConfigForTeam(teamID string) (*model.ClientConfig, error) {
+	flags, err := a.GetFeatureFlags(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig := a.GetClientConfig()
+	clientConfig.FeatureFlags = flags
+	return clientConfig, nil
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"testing"
 
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/require"
 )
@@ -27,4 +28,13 @@ func TestGetClientConfig(t *testing.T) {
 		require.Equal(t, "abcde", clientConfig.TelemetryID)
 		require.Equal(t, 2, len(clientConfig.FeatureFlags))
 	})
+
+	t.Run("Test Get Client Config Capabilities", func(t *testing.T) {
+		capabilities := th.App.GetClientConfig().Capabilities
+		require.Equal(t, archiveVersion, capabilities.ArchiveVersion)
+		require.Equal(t, model.WebsocketProtocolVersion, capabilities.WebsocketProtocolVersion)
+		require.Contains(t, capabilities.BlockTypes, model.BlockType(model.TypeCard))
+		require.Contains(t, capabilities.SearchOperators, model.SearchOperatorAnyWord)
+		require.Equal(t, th.App.GetConfig().EnableGraphQL, capabilities.GraphQL)
+	})
 }
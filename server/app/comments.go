@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetComments returns the comments of a card of the board that the user
+// can see, oldest first. Replies are listed along with the top level
+// comments and point to them with their ParentCommentID.
+func (a *App) GetComments(board *model.Board, cardID, userID string) ([]*model.Comment, error) {
+	if _, err := a.getVisibleCardBlock(board, cardID, userID); err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.store.GetBlocksWithParentAndType(board.ID, cardID, model.TypeComment)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].CreateAt != blocks[j].CreateAt {
+			return blocks[i].CreateAt < blocks[j].CreateAt
+		}
+		return blocks[i].ID < blocks[j].ID
+	})
+
+	comments := make([]*model.Comment, 0, len(blocks))
+	for i := range blocks {
+		comments = append(comments, model.Block2Comment(&blocks[i]))
+	}
+	return comments, nil
+}
+
+// AddComment adds a comment by the user to a card of the board, or a reply
+// if the comment has a ParentCommentID. The new comment block is
+// broadcast to the clients of the team like any other block.
+func (a *App) AddComment(board *model.Board, cardID string, comment *model.Comment, userID string) (*model.Comment, error) {
+	if _, err := a.getVisibleCardBlock(board, cardID, userID); err != nil {
+		return nil, err
+	}
+
+	comment.ID = ""
+	comment.BoardID = board.ID
+	comment.CardID = cardID
+	comment.CreatedBy = userID
+	if err := comment.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if comment.ParentCommentID != "" {
+		parent, err := a.store.GetBlock(comment.ParentCommentID)
+		if model.IsErrNotFound(err) || (err == nil && (parent == nil || parent.Type != model.TypeComment || parent.ParentID != cardID)) {
+			return nil, model.NewErrInvalidComment(fmt.Sprintf("comment %q isn't a comment of the card", comment.ParentCommentID))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block := comment.ToBlock()
+	if err := a.InsertBlock(block, userID); err != nil {
+		return nil, err
+	}
+
+	created, err := a.store.GetBlock(block.ID)
+	if err != nil {
+		return nil, err
+	}
+	return model.Block2Comment(created), nil
+}
+
+// EditComment replaces the text of a comment of a card. Only the author
+// of the comment can edit it.
+func (a *App) EditComment(board *model.Board, cardID, commentID, text, userID string) (*model.Comment, error) {
+	if err := model.ValidateCommentText(text); err != nil {
+		return nil, err
+	}
+
+	block, err := a.getOwnComment(board, cardID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.PatchBlock(block.ID, &model.BlockPatch{Title: &text}, userID); err != nil {
+		return nil, err
+	}
+
+	edited, err := a.store.GetBlock(block.ID)
+	if err != nil {
+		return nil, err
+	}
+	return model.Block2Comment(edited), nil
+}
+
+// DeleteComment deletes a comment of a card along with the replies under
+// it. Only the author of the comment can delete it.
+func (a *App) DeleteComment(board *model.Board, cardID, commentID, userID string) error {
+	if _, err := a.getOwnComment(board, cardID, commentID, userID); err != nil {
+		return err
+	}
+
+	comments, err := a.store.GetBlocksWithParentAndType(board.ID, cardID, model.TypeComment)
+	if err != nil {
+		return err
+	}
+
+	for _, blockID := range model.CommentThread(commentID, comments) {
+		if err := a.DeleteBlock(blockID, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOwnComment returns the comment block of a comment of a card the user
+// can see, or ErrCommentNotOwned if someone else wrote it.
+func (a *App) getOwnComment(board *model.Board, cardID, commentID, userID string) (*model.Block, error) {
+	if _, err := a.getVisibleCardBlock(board, cardID, userID); err != nil {
+		return nil, err
+	}
+
+	block, err := a.store.GetBlock(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil || block.Type != model.TypeComment || block.ParentID != cardID {
+		return nil, model.NewErrNotFound(commentID)
+	}
+	if block.CreatedBy != userID {
+		return nil, model.ErrCommentNotOwned
+	}
+	return block, nil
+}
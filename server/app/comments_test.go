@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetComments(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+	card := &model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+	admin := &model.BoardMember{UserID: "user-id", SchemeAdmin: true}
+
+	th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+	th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+	th.Store.EXPECT().GetBlocksWithParentAndType(testBoardID, "card-id", model.TypeComment).Return([]model.Block{
+		{ID: "reply", BoardID: testBoardID, ParentID: "card-id", Type: model.TypeComment, Title: "Agreed", CreateAt: 2, Fields: map[string]interface{}{
+			model.CommentFieldParentComment: "comment",
+		}},
+		{ID: "comment", BoardID: testBoardID, ParentID: "card-id", Type: model.TypeComment, Title: "Ship it", CreateAt: 1, Fields: map[string]interface{}{}},
+	}, nil)
+
+	comments, err := th.App.GetComments(board, "card-id", "user-id")
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	require.Equal(t, "Ship it", comments[0].Text)
+	require.Equal(t, "card-id", comments[0].CardID)
+	require.Equal(t, "comment", comments[1].ParentCommentID)
+}
+
+func TestAddCommentReply(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+	card := &model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+	admin := &model.BoardMember{UserID: "user-id", SchemeAdmin: true}
+
+	t.Run("reply to a comment of another card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+		th.Store.EXPECT().GetBlock("other-comment").Return(&model.Block{
+			ID: "other-comment", BoardID: testBoardID, ParentID: "other-card", Type: model.TypeComment,
+		}, nil)
+
+		comment := &model.Comment{ParentCommentID: "other-comment", Text: "Agreed"}
+		_, err := th.App.AddComment(board, "card-id", comment, "user-id")
+		require.True(t, model.IsErrInvalidComment(err))
+	})
+
+	t.Run("reply to a missing comment", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+		th.Store.EXPECT().GetBlock("missing").Return(nil, model.NewErrNotFound("missing"))
+
+		comment := &model.Comment{ParentCommentID: "missing", Text: "Agreed"}
+		_, err := th.App.AddComment(board, "card-id", comment, "user-id")
+		require.True(t, model.IsErrInvalidComment(err))
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+
+		_, err := th.App.AddComment(board, "card-id", &model.Comment{Text: " "}, "user-id")
+		require.True(t, model.IsErrInvalidComment(err))
+	})
+}
+
+func TestEditComment(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID}
+	card := &model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+	admin := &model.BoardMember{UserID: "user-id", SchemeAdmin: true}
+
+	t.Run("comment of another user", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+		th.Store.EXPECT().GetBlock("comment").Return(&model.Block{
+			ID: "comment", BoardID: testBoardID, ParentID: "card-id", Type: model.TypeComment, CreatedBy: "other-user",
+		}, nil)
+
+		_, err := th.App.EditComment(board, "card-id", "comment", "Edited", "user-id")
+		require.ErrorIs(t, err, model.ErrCommentNotOwned)
+	})
+
+	t.Run("comment of another card", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(admin, nil)
+		th.Store.EXPECT().GetBlock("comment").Return(&model.Block{
+			ID: "comment", BoardID: testBoardID, ParentID: "other-card", Type: model.TypeComment, CreatedBy: "user-id",
+		}, nil)
+
+		_, err := th.App.EditComment(board, "card-id", "comment", "Edited", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
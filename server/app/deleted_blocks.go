@@ -0,0 +1,65 @@
+package app
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const purgeDeletedBlocksBatchSize = 100
+
+// trashCutoff returns the time before which deleted blocks can't be
+// restored anymore, or zero if they are kept forever.
+func (a *App) trashCutoff(now time.Time) int64 {
+	if a.config.TrashRetentionDays <= 0 {
+		return 0
+	}
+	return now.AddDate(0, 0, -a.config.TrashRetentionDays).UnixNano() / int64(time.Millisecond)
+}
+
+// PurgeDeletedBlocks permanently removes the blocks deleted for longer than
+// the trash retention period, along with their files.
+func (a *App) PurgeDeletedBlocks(now time.Time) error {
+	cutoff := a.trashCutoff(now)
+	if cutoff == 0 {
+		return nil
+	}
+
+	for {
+		purged, err := a.store.PurgeDeletedBlocks(cutoff, purgeDeletedBlocksBatchSize)
+		if err != nil {
+			return err
+		}
+		a.removeBlockFiles(purged)
+
+		if len(purged) < purgeDeletedBlocksBatchSize {
+			return nil
+		}
+	}
+}
+
+// removeBlockFiles removes the files of the image blocks.
+func (a *App) removeBlockFiles(blocks []model.Block) {
+	for i := range blocks {
+		block := blocks[i]
+		if block.Type != model.TypeImage {
+			continue
+		}
+		fileName, ok := block.Fields["fileId"].(string)
+		if !ok || fileName == "" {
+			continue
+		}
+
+		filePath := filepath.Join(block.BoardID, fileName)
+		if err := a.filesBackend.RemoveFile(filePath); err != nil {
+			a.logger.Error("Error deleting image file",
+				mlog.String("FilePath", filePath),
+				mlog.Err(err))
+			continue
+		}
+		a.forgetFile(fileName)
+	}
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeDeletedBlocks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -30).UnixNano() / int64(time.Millisecond)
+
+	t.Run("deleted blocks kept forever", func(t *testing.T) {
+		th.App.config.TrashRetentionDays = 0
+		require.NoError(t, th.App.PurgeDeletedBlocks(now))
+	})
+
+	t.Run("purges the blocks and their files", func(t *testing.T) {
+		th.App.config.TrashRetentionDays = 30
+		defer func() { th.App.config.TrashRetentionDays = 0 }()
+
+		image := model.Block{
+			ID:      "image-id",
+			BoardID: testBoardID,
+			Type:    model.TypeImage,
+			Fields:  map[string]interface{}{"fileId": "file.png"},
+		}
+		text := model.Block{ID: "text-id", BoardID: testBoardID, Type: model.TypeText}
+		th.Store.EXPECT().PurgeDeletedBlocks(cutoff, uint64(purgeDeletedBlocksBatchSize)).Return([]model.Block{image, text}, nil)
+		th.FilesBackend.On("RemoveFile", testBoardID+"/file.png").Return(nil).Once()
+		th.Store.EXPECT().DeleteFileInfo("file.png").Return(nil)
+
+		require.NoError(t, th.App.PurgeDeletedBlocks(now))
+		th.FilesBackend.AssertExpectations(t)
+	})
+
+	t.Run("purges in batches", func(t *testing.T) {
+		th.App.config.TrashRetentionDays = 30
+		defer func() { th.App.config.TrashRetentionDays = 0 }()
+
+		batch := make([]model.Block, purgeDeletedBlocksBatchSize)
+		gomock.InOrder(
+			th.Store.EXPECT().PurgeDeletedBlocks(cutoff, uint64(purgeDeletedBlocksBatchSize)).Return(batch, nil),
+			th.Store.EXPECT().PurgeDeletedBlocks(cutoff, uint64(purgeDeletedBlocksBatchSize)).Return([]model.Block{}, nil),
+		)
+
+		require.NoError(t, th.App.PurgeDeletedBlocks(now))
+	})
+}
+
+func TestUndeleteExpiredBlock(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.TrashRetentionDays = 30
+	block := model.Block{
+		ID:       "block-id",
+		BoardID:  testBoardID,
+		DeleteAt: time.Now().AddDate(0, 0, -31).UnixNano() / int64(time.Millisecond),
+	}
+	th.Store.EXPECT().GetBlockHistory(
+		gomock.Eq("block-id"),
+		gomock.Eq(model.QueryBlockHistoryOptions{Limit: 1, Descending: true}),
+	).Return([]model.Block{block}, nil)
+
+	_, err := th.App.UndeleteBlock("block-id", "user-id-1")
+	require.True(t, model.IsErrNotFound(err))
+}
@@ -0,0 +1,233 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func (a *App) GetEscalationPolicies(boardID string) ([]model.EscalationPolicy, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.EscalationPoliciesFromBoard(board)
+}
+
+func (a *App) CreateEscalationPolicy(boardID string, policy *model.EscalationPolicy, userID string) (*model.EscalationPolicy, error) {
+	policy.Hydrate()
+
+	err := a.updateEscalationPolicies(boardID, userID, func(policies []model.EscalationPolicy, properties []model.CardProperty) ([]model.EscalationPolicy, error) {
+		if err := policy.IsValid(properties); err != nil {
+			return nil, err
+		}
+		return append(policies, *policy), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (a *App) UpdateEscalationPolicy(boardID string, policy *model.EscalationPolicy, userID string) (*model.EscalationPolicy, error) {
+	if policy.NotifyUserIDs == nil {
+		policy.NotifyUserIDs = []string{}
+	}
+
+	err := a.updateEscalationPolicies(boardID, userID, func(policies []model.EscalationPolicy, properties []model.CardProperty) ([]model.EscalationPolicy, error) {
+		idx := model.FindEscalationPolicy(policies, policy.ID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(policy.ID)
+		}
+		if err := policy.IsValid(properties); err != nil {
+			return nil, err
+		}
+		policies[idx] = *policy
+		return policies, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (a *App) DeleteEscalationPolicy(boardID, policyID string, userID string) error {
+	return a.updateEscalationPolicies(boardID, userID, func(policies []model.EscalationPolicy, _ []model.CardProperty) ([]model.EscalationPolicy, error) {
+		idx := model.FindEscalationPolicy(policies, policyID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(policyID)
+		}
+		return append(policies[:idx], policies[idx+1:]...), nil
+	})
+}
+
+// updateEscalationPolicies loads the escalation policies of a board,
+// applies the modifier, which gets the property schema to validate the
+// changed policies, and saves them back to the board and broadcasts the
+// change.
+func (a *App) updateEscalationPolicies(boardID, userID string, modifier func([]model.EscalationPolicy, []model.CardProperty) ([]model.EscalationPolicy, error)) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	policies, err := model.EscalationPoliciesFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	policies, err = modifier(policies, properties)
+	if err != nil {
+		return err
+	}
+
+	if board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+	board.Properties[model.BoardPropertyEscalations] = policies
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return nil
+}
+
+// RunEscalationPolicies escalates the cards of every board that stayed in
+// the status of one of the board policies for longer than its days.
+func (a *App) RunEscalationPolicies(now time.Time) error {
+	boards, err := a.store.GetBoardsWithProperty(model.BoardPropertyEscalations)
+	if err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		if err := a.runBoardEscalationPolicies(board, now); err != nil {
+			a.logger.Error("Cannot run escalation policies",
+				mlog.String("boardID", board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+	return nil
+}
+
+func (a *App) runBoardEscalationPolicies(board *model.Board, now time.Time) error {
+	policies, err := model.EscalationPoliciesFromBoard(board)
+	if err != nil || len(policies) == 0 {
+		return err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+	if err != nil {
+		return err
+	}
+
+	for i := range cards {
+		card := &cards[i]
+		if model.IsCardTemplate(card) {
+			continue
+		}
+
+		var history []model.Block
+		for j := range policies {
+			policy := &policies[j]
+			if model.CardPropertyValues(card)[policy.PropertyID] != policy.OptionID {
+				continue
+			}
+
+			if history == nil {
+				history, err = a.store.GetBlockHistory(card.ID, model.QueryBlockHistoryOptions{Descending: true})
+				if err != nil {
+					return err
+				}
+			}
+
+			if !policy.IsDue(card, policy.StatusEnteredAt(card, history), now) {
+				continue
+			}
+
+			escalated, err := a.escalateCard(board, properties, card, policy, now)
+			if err != nil {
+				a.logger.Error("Cannot escalate card",
+					mlog.String("boardID", board.ID),
+					mlog.String("cardID", card.ID),
+					mlog.String("policyID", policy.ID),
+					mlog.Err(err),
+				)
+				continue
+			}
+			// the following policies apply to the escalated version
+			card = escalated
+			history = nil
+		}
+	}
+	return nil
+}
+
+// escalateCard applies the actions of the policy to the card and notifies
+// the users of the policy.
+func (a *App) escalateCard(board *model.Board, properties []model.CardProperty, card *model.Block, policy *model.EscalationPolicy, now time.Time) (*model.Block, error) {
+	patch := policy.EscalationPatch(properties, card, now)
+	if err := a.PatchBlock(card.ID, patch, model.SystemUserID); err != nil {
+		return nil, err
+	}
+
+	escalated, err := a.store.GetBlock(card.ID)
+	if err != nil {
+		return nil, err
+	}
+	if escalated == nil {
+		return nil, model.NewErrNotFound(card.ID)
+	}
+
+	userIDs := append([]string{}, policy.NotifyUserIDs...)
+	if policy.NotifyAssignees {
+		userIDs = append(userIDs, model.CardAssignees(properties, card)...)
+	}
+
+	if a.notifications != nil && len(userIDs) != 0 {
+		evt := notify.CardEscalationEvent{
+			TeamID:     board.TeamID,
+			Board:      board,
+			Card:       escalated,
+			PolicyName: policy.Name,
+			Days:       policy.Days,
+			UserIDs:    uniqueStrings(userIDs),
+		}
+		a.blockChangeNotifier.Enqueue(func() error {
+			a.notifications.CardEscalated(evt)
+			return nil
+		})
+	}
+	return escalated, nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunEscalationPolicies(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Now()
+	daysAgo := func(days int) int64 {
+		return utils.GetMillisForTime(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+
+	board := &model.Board{
+		ID:     testBoardID,
+		TeamID: "team-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id": "status", "name": "Status", "type": model.PropertyTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "review", "value": "Review", "color": model.PropertyColorDefault},
+					map[string]interface{}{"id": "blocked", "value": "Blocked", "color": model.PropertyColorDefault},
+				},
+			},
+		},
+		Properties: map[string]interface{}{
+			model.BoardPropertyEscalations: []interface{}{
+				map[string]interface{}{
+					"id": "policy-id", "name": "Stale reviews", "propertyId": "status",
+					"optionId": "review", "days": 3, "moveToOptionId": "blocked",
+				},
+			},
+		},
+	}
+	stale := model.Block{
+		ID: "stale-card", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard, UpdateAt: daysAgo(5),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "review"}},
+	}
+	recent := model.Block{
+		ID: "recent-card", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard, UpdateAt: daysAgo(1),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "review"}},
+	}
+
+	th.Store.EXPECT().GetBoardsWithProperty(model.BoardPropertyEscalations).Return([]*model.Board{board}, nil)
+	th.Store.EXPECT().GetBlocksWithType(testBoardID, model.TypeCard).Return([]model.Block{stale, recent}, nil)
+	th.Store.EXPECT().GetBlockHistory("stale-card", gomock.Any()).Return([]model.Block{stale}, nil)
+	th.Store.EXPECT().GetBlockHistory("recent-card", gomock.Any()).Return([]model.Block{recent}, nil)
+	th.Store.EXPECT().GetBlock("stale-card").Return(&stale, nil).AnyTimes()
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).AnyTimes()
+	th.Store.EXPECT().PatchBlock("stale-card", gomock.Any(), model.SystemUserID).DoAndReturn(
+		func(blockID string, patch *model.BlockPatch, userID string) error {
+			values := patch.UpdatedFields["properties"].(map[string]interface{})
+			require.Equal(t, "blocked", values["status"])
+			return nil
+		},
+	)
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+	require.NoError(t, th.App.RunEscalationPolicies(now))
+}
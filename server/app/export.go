@@ -16,12 +16,14 @@ var (
 	newline = []byte{'\n'}
 )
 
-func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs error) {
-	boards, err := a.getBoardsForArchive(opt.BoardIDs)
-	if err != nil {
-		return err
-	}
+// archiveExportPageSize is the number of blocks read from the store at a
+// time when exporting a board, so large boards are never held in memory.
+const archiveExportPageSize = 1000
 
+// ExportArchive writes the boards to an archive. The boards are read and
+// written one at a time, and their blocks in pages, so the archive is
+// streamed to the writer as it is built.
+func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs error) {
 	merr := merror.New()
 	defer func() {
 		errs = merr.ErrorOrNil()
@@ -38,11 +40,27 @@ func (a *App) ExportArchive(w io.Writer, opt model.ExportArchiveOptions) (errs e
 		return
 	}
 
-	for _, board := range boards {
-		if err := a.writeArchiveBoard(zw, board, opt); err != nil {
+	for _, boardID := range opt.BoardIDs {
+		board, err := a.GetBoard(boardID)
+		if err == nil && board == nil {
+			err = model.NewErrNotFound(boardID)
+		}
+		if err != nil {
+			merr.Append(fmt.Errorf("could not fetch board %s: %w", boardID, err))
+			return
+		}
+
+		if err := a.writeArchiveBoard(zw, *board, opt); err != nil {
 			merr.Append(fmt.Errorf("cannot export board %s: %w", board.ID, err))
 			return
 		}
+
+		// send what was written so far, instead of buffering it until
+		// the end of the archive
+		if err := zw.Flush(); err != nil {
+			merr.Append(err)
+			return
+		}
 	}
 	return nil
 }
@@ -79,25 +97,51 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 		return err
 	}
 
+	// only the cover image and the files referenced by the blocks of the
+	// board are exported, once each
 	var files []string
-	// write the board's blocks
-	// TODO: paginate this
-	blocks, err := a.GetBlocksWithBoardID(board.ID)
+	seenFiles := map[string]bool{}
+	if coverFileID := model.BoardCoverFileID(&board); coverFileID != "" {
+		seenFiles[coverFileID] = true
+		files = append(files, coverFileID)
+	}
+
+	hiddenIDs, err := a.getArchiveHiddenBlockIDs(&board, opt.UserID)
 	if err != nil {
 		return err
 	}
 
-	for _, block := range blocks {
-		if err = a.writeArchiveBlockLine(w, block); err != nil {
+	// write the board's blocks, a page at a time
+	opts := model.QueryBlocksPageOptions{Limit: archiveExportPageSize}
+	for {
+		page, err := a.store.GetBlocksForBoardPage(board.ID, opts)
+		if err != nil {
 			return err
 		}
-		if block.Type == model.TypeImage {
-			filename, err := extractImageFilename(block)
-			if err != nil {
+
+		// the restricted cards and views the user can't see are left out,
+		// together with their content and files
+		blocks := model.FilterRestrictedBlocks(page, hiddenIDs)
+		for _, block := range blocks {
+			if err = a.writeArchiveBlockLine(w, block); err != nil {
 				return err
 			}
-			files = append(files, filename)
+			if block.Type == model.TypeImage {
+				filename, err := extractImageFilename(block)
+				if err != nil {
+					return err
+				}
+				if !seenFiles[filename] {
+					seenFiles[filename] = true
+					files = append(files, filename)
+				}
+			}
+		}
+
+		if len(page) < archiveExportPageSize {
+			break
 		}
+		opts.AfterID = page[len(page)-1].ID
 	}
 
 	// write the files
@@ -109,6 +153,25 @@ func (a *App) writeArchiveBoard(zw *zip.Writer, board model.Board, opt model.Exp
 	return nil
 }
 
+// getArchiveHiddenBlockIDs returns the ids of the restricted cards and views
+// of the board that the user exporting it can't see. System exports, with
+// no user, include every block.
+func (a *App) getArchiveHiddenBlockIDs(board *model.Board, userID string) (map[string]bool, error) {
+	hiddenIDs := map[string]bool{}
+	if userID == "" {
+		return hiddenIDs, nil
+	}
+
+	ids, err := a.GetHiddenBlockIDs(board, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		hiddenIDs[id] = true
+	}
+	return hiddenIDs, nil
+}
+
 // writeArchiveBlockLine writes a single block to the archive.
 func (a *App) writeArchiveBlockLine(w io.Writer, block model.Block) error {
 	b, err := json.Marshal(&block)
@@ -184,21 +247,6 @@ func (a *App) writeArchiveFile(zw *zip.Writer, filename string, boardID string,
 	return err
 }
 
-// getBoardsForArchive fetches all the specified boards.
-func (a *App) getBoardsForArchive(boardIDs []string) ([]model.Board, error) {
-	boards := make([]model.Board, 0, len(boardIDs))
-
-	for _, id := range boardIDs {
-		b, err := a.GetBoard(id)
-		if err != nil {
-			return nil, fmt.Errorf("could not fetch board %s: %w", id, err)
-		}
-
-		boards = append(boards, *b)
-	}
-	return boards, nil
-}
-
 func extractImageFilename(imageBlock model.Block) (string, error) {
 	f, ok := imageBlock.Fields["fileId"]
 	if !ok {
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_ExportArchive(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Title:  "Large board",
+	}
+
+	// one full page and a partial one
+	blocks := make([]model.Block, archiveExportPageSize+1)
+	for i := range blocks {
+		blocks[i] = model.Block{
+			ID:       fmt.Sprintf("block-%05d", i),
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+		}
+	}
+
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetBlocksForBoardPage(board.ID, model.QueryBlocksPageOptions{
+		Limit: archiveExportPageSize,
+	}).Return(blocks[:archiveExportPageSize], nil)
+	th.Store.EXPECT().GetBlocksForBoardPage(board.ID, model.QueryBlocksPageOptions{
+		AfterID: blocks[archiveExportPageSize-1].ID,
+		Limit:   archiveExportPageSize,
+	}).Return(blocks[archiveExportPageSize:], nil)
+
+	var buf bytes.Buffer
+	err := th.App.ExportArchive(&buf, model.ExportArchiveOptions{TeamID: board.TeamID, BoardIDs: []string{board.ID}})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	var lines int
+	for _, f := range zr.File {
+		if f.Name != board.ID+"/board.jsonl" {
+			continue
+		}
+		rc, err := f.Open()
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(rc)
+		for scanner.Scan() {
+			lines++
+		}
+		require.NoError(t, scanner.Err())
+		rc.Close()
+	}
+	// the board line followed by all the blocks
+	require.Equal(t, len(blocks)+1, lines)
+}
+
+func TestApp_ExportArchiveRestrictedCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:     "board-id",
+		TeamID: "team-id",
+		Title:  "Board with a restricted card",
+		CardProperties: []map[string]interface{}{
+			{"id": "assignee", "name": "Assignee", "type": model.PropertyTypePerson},
+		},
+	}
+	cards := []model.Block{
+		{ID: "public-card", ParentID: board.ID, BoardID: board.ID, Type: model.TypeCard},
+		{
+			ID:       "restricted-card",
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardFieldRestricted: true,
+				"properties":              map[string]interface{}{"assignee": "assignee-id"},
+			},
+		},
+	}
+	views := []model.Block{
+		{ID: "view", ParentID: board.ID, BoardID: board.ID, Type: model.TypeView},
+		{
+			ID:       "restricted-view",
+			ParentID: board.ID,
+			BoardID:  board.ID,
+			Type:     model.TypeView,
+			Fields:   map[string]interface{}{model.ViewFieldRestricted: true},
+		},
+	}
+	blocks := append(append([]model.Block{}, cards...), views...)
+	blocks = append(blocks,
+		model.Block{ID: "comment", ParentID: "restricted-card", BoardID: board.ID, Type: model.TypeComment},
+		model.Block{
+			ID:       "image",
+			ParentID: "restricted-card",
+			BoardID:  board.ID,
+			Type:     model.TypeImage,
+			Fields:   map[string]interface{}{"fileId": "restricted.png"},
+		},
+	)
+
+	th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
+	th.Store.EXPECT().GetMemberForBoard(board.ID, "viewer-id").Return(&model.BoardMember{UserID: "viewer-id", SchemeViewer: true}, nil)
+	th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeCard).Return(cards, nil)
+	th.Store.EXPECT().GetBlocksWithType(board.ID, model.TypeView).Return(views, nil)
+	th.Store.EXPECT().GetBlocksForBoardPage(board.ID, model.QueryBlocksPageOptions{
+		Limit: archiveExportPageSize,
+	}).Return(blocks, nil)
+
+	var buf bytes.Buffer
+	err := th.App.ExportArchive(&buf, model.ExportArchiveOptions{
+		TeamID:   board.TeamID,
+		BoardIDs: []string{board.ID},
+		UserID:   "viewer-id",
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	var names []string
+	var content bytes.Buffer
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.Name != board.ID+"/board.jsonl" {
+			continue
+		}
+		rc, err := f.Open()
+		require.NoError(t, err)
+		_, err = content.ReadFrom(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+
+	// the restricted card is left out with its comment and image file,
+	// and so is the restricted view
+	require.ElementsMatch(t, []string{"version.json", board.ID + "/board.jsonl"}, names)
+	require.Contains(t, content.String(), "public-card")
+	require.Contains(t, content.String(), `"id":"view"`)
+	require.NotContains(t, content.String(), "restricted-card")
+	require.NotContains(t, content.String(), "restricted-view")
+	require.NotContains(t, content.String(), `"id":"comment"`)
+	require.NotContains(t, content.String(), "restricted.png")
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+var ErrFeatureFlagsUnavailable = errors.New("team feature flags are not available")
+
+// GetFeatureFlags returns the feature flags of the configuration with the
+// overrides of the team applied.
+func (a *App) GetFeatureFlags(teamID string) (map[string]string, error) {
+	if a.featureFlags == nil {
+		return model.MergeFeatureFlags(a.config.FeatureFlags, nil), nil
+	}
+	return a.featureFlags.GetFlags(teamID)
+}
+
+// IsFeatureEnabled returns true if the feature flag is enabled for the team.
+func (a *App) IsFeatureEnabled(teamID, flag string) bool {
+	if a.featureFlags == nil {
+		return a.config.FeatureFlags[flag] == model.FeatureFlagEnabled
+	}
+	return a.featureFlags.IsEnabled(teamID, flag)
+}
+
+// GetTeamFeatureFlags returns the feature flag overrides of the team.
+func (a *App) GetTeamFeatureFlags(teamID string) (*model.TeamFeatureFlags, error) {
+	overrides := map[string]string{}
+	if a.featureFlags != nil {
+		var err error
+		if overrides, err = a.featureFlags.GetTeamOverrides(teamID); err != nil {
+			return nil, err
+		}
+	}
+	return &model.TeamFeatureFlags{TeamID: teamID, Overrides: overrides}, nil
+}
+
+// UpdateTeamFeatureFlags replaces the feature flag overrides of the team.
+func (a *App) UpdateTeamFeatureFlags(teamID string, overrides map[string]string) (*model.TeamFeatureFlags, error) {
+	if overrides == nil {
+		overrides = map[string]string{}
+	}
+	flags := &model.TeamFeatureFlags{TeamID: teamID, Overrides: overrides}
+	if err := flags.IsValid(); err != nil {
+		return nil, err
+	}
+	if a.featureFlags == nil {
+		return nil, ErrFeatureFlagsUnavailable
+	}
+
+	if err := a.featureFlags.SetTeamOverrides(teamID, overrides); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/jobs"
+	"github.com/mattermost/focalboard/server/services/textextract"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// jobTypeIndexFileContent is the job extracting the text of an uploaded
+// file to make it searchable.
+const jobTypeIndexFileContent = "indexFileContent"
+
+// fileContentIndexingAttempts is the number of times the extraction of
+// the text of a file is tried, as it can be run again safely.
+const fileContentIndexingAttempts = 3
+
+type indexFileContentJobData struct {
+	TeamID  string `json:"teamId"`
+	BoardID string `json:"boardId"`
+	FileID  string `json:"fileId"`
+}
+
+// EnqueueFileContentIndexing enqueues the extraction of the text of an
+// uploaded file, if its format is supported, so that searching its
+// content finds the card the file is attached to. Without background jobs
+// the files are not indexed.
+func (a *App) EnqueueFileContentIndexing(teamID, boardID, fileID string) error {
+	if !textextract.Supported(fileID) || a.jobs == nil {
+		return nil
+	}
+
+	data, err := encodeJobData(indexFileContentJobData{TeamID: teamID, BoardID: boardID, FileID: fileID})
+	if err != nil {
+		return err
+	}
+	_, err = a.jobs.Enqueue(&model.Job{
+		Type:        jobTypeIndexFileContent,
+		Data:        data,
+		MaxAttempts: fileContentIndexingAttempts,
+	})
+	return err
+}
+
+func (a *App) runIndexFileContentJob(_ context.Context, job *model.Job, _ jobs.ProgressFunc) (map[string]interface{}, error) {
+	var data indexFileContentJobData
+	if err := decodeJobData(job.Data, &data); err != nil {
+		return nil, err
+	}
+
+	content, err := a.IndexFileContent(data.TeamID, data.BoardID, data.FileID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"length": len(content.Content)}, nil
+}
+
+// IndexFileContent extracts the text of an uploaded file and stores it
+// for search.
+func (a *App) IndexFileContent(teamID, boardID, fileID string) (*model.FileContent, error) {
+	file, err := a.filesBackend.Reader(filepath.Join(teamID, boardID, fileID))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the file to index: %w", err)
+	}
+	defer file.Close()
+
+	text, err := textextract.Extract(fileID, file, model.MaxFileContentLength)
+	if errors.Is(err, textextract.ErrDocumentTooLarge) {
+		// too large files are attached without their content being searchable
+		text, err = "", nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot extract the text of the file: %w", err)
+	}
+
+	content := &model.FileContent{
+		FileID:   fileID,
+		TeamID:   teamID,
+		BoardID:  boardID,
+		Content:  text,
+		UpdateAt: utils.GetMillis(),
+	}
+	if err := a.store.SaveFileContent(content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// searchFileContents returns the attachments of the boards whose files
+// contain any of the words of the search, with the text of their files
+// by block id.
+func (a *App) searchFileContents(boardIDs []string, term string) ([]model.Block, map[string]string, error) {
+	contents, err := a.store.SearchFileContents(boardIDs, term)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contentsByBoard := map[string]map[string]string{}
+	for _, content := range contents {
+		if contentsByBoard[content.BoardID] == nil {
+			contentsByBoard[content.BoardID] = map[string]string{}
+		}
+		contentsByBoard[content.BoardID][content.FileID] = content.Content
+	}
+
+	attachments := []model.Block{}
+	texts := map[string]string{}
+	for boardID, fileContents := range contentsByBoard {
+		blocks, err := a.store.GetBlocksWithType(boardID, model.TypeImage)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, block := range blocks {
+			fileID, _ := block.Fields["fileId"].(string)
+			if text, ok := fileContents[fileID]; ok {
+				attachments = append(attachments, block)
+				texts[block.ID] = text
+			}
+		}
+	}
+	return attachments, texts, nil
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_EnqueueFileContentIndexing(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("enqueues the indexing of a document", func(t *testing.T) {
+		th.Store.EXPECT().CreateJob(gomock.Any()).DoAndReturn(func(job *model.Job) (*model.Job, error) {
+			require.Equal(t, jobTypeIndexFileContent, job.Type)
+			require.Equal(t, fileContentIndexingAttempts, job.MaxAttempts)
+			require.Equal(t, "team-id", job.Data["teamId"])
+			require.Equal(t, "board-id", job.Data["boardId"])
+			require.Equal(t, "7spec.pdf", job.Data["fileId"])
+			return job, nil
+		})
+
+		require.NoError(t, th.App.EnqueueFileContentIndexing("team-id", "board-id", "7spec.pdf"))
+	})
+
+	t.Run("ignores the files without text", func(t *testing.T) {
+		require.NoError(t, th.App.EnqueueFileContentIndexing("team-id", "board-id", "7photo.png"))
+	})
+}
+
+func TestApp_RunIndexFileContentJob(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	job := &model.Job{
+		ID:   "job-id",
+		Type: jobTypeIndexFileContent,
+		Data: map[string]interface{}{"teamId": "team-id", "boardId": "board-id", "fileId": "7notes.md"},
+	}
+
+	th.FilesBackend.On("Reader", filepath.Join("team-id", "board-id", "7notes.md")).
+		Return(readCloseSeeker{bytes.NewReader([]byte("# Payments\n\nAPI spec"))}, nil).Once()
+	th.Store.EXPECT().SaveFileContent(gomock.Any()).DoAndReturn(func(content *model.FileContent) error {
+		require.Equal(t, "7notes.md", content.FileID)
+		require.Equal(t, "team-id", content.TeamID)
+		require.Equal(t, "board-id", content.BoardID)
+		require.Equal(t, "# Payments API spec", content.Content)
+		return nil
+	})
+
+	result, err := th.App.runIndexFileContentJob(context.Background(), job, nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"length": 19}, result)
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/jobs"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// jobTypeSeedFileInfo is the job recording the size of the files stored
+// before the sizes were recorded.
+const jobTypeSeedFileInfo = "seedFileInfo"
+
+// fileInfoSeededKey is the system setting set once the files stored
+// before the sizes were recorded are counted.
+const fileInfoSeededKey = "FileInfoSeedComplete"
+
+// recordFile records the size of a file stored for a board, which counts
+// it in the attachment storage used. Failures are only logged as the file
+// is already stored.
+func (a *App) recordFile(teamID, boardID, fileID string, size int64) {
+	info := &model.FileInfo{
+		FileID:   fileID,
+		TeamID:   teamID,
+		BoardID:  boardID,
+		Size:     size,
+		CreateAt: utils.GetMillis(),
+	}
+	if err := a.store.SaveFileInfo(info); err != nil {
+		a.logger.Error("Cannot record the size of a stored file", mlog.String("fileID", fileID), mlog.Err(err))
+	}
+}
+
+// forgetFile stops counting a removed file in the attachment storage used.
+func (a *App) forgetFile(fileID string) {
+	if err := a.store.DeleteFileInfo(fileID); err != nil {
+		a.logger.Error("Cannot delete the size of a removed file", mlog.String("fileID", fileID), mlog.Err(err))
+	}
+}
+
+// EnqueueFileInfoSeed enqueues the recording of the size of the files
+// stored before the sizes were recorded, unless it is done already.
+func (a *App) EnqueueFileInfoSeed() error {
+	if a.jobs == nil {
+		return nil
+	}
+
+	seeded, err := a.isFileInfoSeeded()
+	if err != nil || seeded {
+		return err
+	}
+	_, err = a.jobs.Enqueue(&model.Job{Type: jobTypeSeedFileInfo, MaxAttempts: model.DefaultJobMaxAttempts})
+	return err
+}
+
+func (a *App) isFileInfoSeeded() (bool, error) {
+	value, err := a.store.GetSystemSetting(fileInfoSeededKey)
+	if err != nil {
+		return false, err
+	}
+	seeded, _ := strconv.ParseBool(value)
+	return seeded, nil
+}
+
+func (a *App) runSeedFileInfoJob(ctx context.Context, _ *model.Job, progress jobs.ProgressFunc) (map[string]interface{}, error) {
+	files, err := a.SeedFileInfo(ctx, progress)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"files": files}, nil
+}
+
+// SeedFileInfo records the size of the files of the attachments of all
+// the boards, for the files stored before the sizes were recorded, and
+// returns the number of files found. It can be run again safely, the
+// sizes being replaced. The files of the attachments in the trash are
+// not counted.
+func (a *App) SeedFileInfo(ctx context.Context, progress jobs.ProgressFunc) (int, error) {
+	seeded, err := a.isFileInfoSeeded()
+	if err != nil || seeded {
+		return 0, err
+	}
+
+	teamIDs, err := a.getAllTeamIDs()
+	if err != nil {
+		return 0, err
+	}
+
+	files := 0
+	for i, teamID := range teamIDs {
+		boards, err := a.store.GetBoardsForTeam(teamID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return 0, err
+		}
+		for _, board := range boards {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+			blocks, err := a.store.GetBlocksWithType(board.ID, model.TypeImage)
+			if err != nil {
+				return 0, err
+			}
+			for _, block := range blocks {
+				fileID, _ := block.Fields["fileId"].(string)
+				if fileID == "" {
+					continue
+				}
+				size, err := a.filesBackend.FileSize(filepath.Join(teamID, board.ID, fileID))
+				if err != nil {
+					a.logger.Debug("Cannot get the size of an attached file",
+						mlog.String("boardID", board.ID),
+						mlog.String("fileID", fileID),
+						mlog.Err(err),
+					)
+					continue
+				}
+				if err := a.store.SaveFileInfo(&model.FileInfo{FileID: fileID, TeamID: teamID, BoardID: board.ID, Size: size}); err != nil {
+					return 0, err
+				}
+				files++
+			}
+		}
+		if progress != nil {
+			progress((i + 1) * 100 / len(teamIDs))
+		}
+	}
+
+	if err := a.store.SetSystemSetting(fileInfoSeededKey, "true"); err != nil {
+		return 0, err
+	}
+	return files, nil
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedFileInfo(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("already seeded", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(fileInfoSeededKey).Return("true", nil)
+
+		files, err := th.App.SeedFileInfo(context.Background(), nil)
+		require.NoError(t, err)
+		require.Zero(t, files)
+	})
+
+	t.Run("records the files of the attachments", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(fileInfoSeededKey).Return("", nil)
+		th.Store.EXPECT().GetAllTeams().Return([]*model.Team{{ID: "team-id"}}, nil)
+		th.Store.EXPECT().GetBoardsForTeam(model.GlobalTeamID).Return([]*model.Board{}, nil)
+		th.Store.EXPECT().GetBoardsForTeam("team-id").Return([]*model.Board{{ID: "board-1", TeamID: "team-id"}}, nil)
+		th.Store.EXPECT().GetBlocksWithType("board-1", model.TypeImage).Return([]model.Block{
+			{ID: "image-1", BoardID: "board-1", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "spec.pdf"}},
+			{ID: "image-2", BoardID: "board-1", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "missing.png"}},
+			{ID: "image-3", BoardID: "board-1", Type: model.TypeImage},
+		}, nil)
+		th.FilesBackend.On("FileSize", "team-id/board-1/spec.pdf").Return(int64(100), nil)
+		th.FilesBackend.On("FileSize", "team-id/board-1/missing.png").Return(int64(0), errors.New("not found"))
+		th.Store.EXPECT().SaveFileInfo(&model.FileInfo{FileID: "spec.pdf", TeamID: "team-id", BoardID: "board-1", Size: 100}).Return(nil)
+		th.Store.EXPECT().SetSystemSetting(fileInfoSeededKey, "true").Return(nil)
+
+		files, err := th.App.SeedFileInfo(context.Background(), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, files)
+	})
+}
+
+func TestEnqueueFileInfoSeed(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("already seeded", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(fileInfoSeededKey).Return("true", nil)
+		require.NoError(t, th.App.EnqueueFileInfoSeed())
+	})
+
+	t.Run("enqueues the seed", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(fileInfoSeededKey).Return("", nil)
+		th.Store.EXPECT().CreateJob(gomock.Any()).DoAndReturn(func(job *model.Job) (*model.Job, error) {
+			require.Equal(t, jobTypeSeedFileInfo, job.Type)
+			return job, nil
+		})
+		require.NoError(t, th.App.EnqueueFileInfoSeed())
+	})
+}
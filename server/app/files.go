@@ -1,11 +1,13 @@
 package app
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"path/filepath"
 	"strings"
 
+	"github.com/mattermost/focalboard/server/services/imagemeta"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
@@ -23,14 +25,46 @@ func (a *App) SaveFile(reader io.Reader, teamID, rootID, filename string) (strin
 	createdFilename := fmt.Sprintf(`%s%s`, utils.NewID(utils.IDTypeNone), fileExtension)
 	filePath := filepath.Join(teamID, rootID, createdFilename)
 
-	_, appErr := a.filesBackend.WriteFile(reader, filePath)
+	if a.config.StripImageMetadata && strippedMetadataExtensions[fileExtension] {
+		var err error
+		if reader, err = a.stripImageMetadata(reader); err != nil {
+			return "", fmt.Errorf("unable to read the file: %w", err)
+		}
+	}
+
+	written, appErr := a.filesBackend.WriteFile(reader, filePath)
 	if appErr != nil {
 		return "", fmt.Errorf("unable to store the file in the files storage: %w", appErr)
 	}
+	a.recordFile(teamID, rootID, createdFilename, written)
 
 	return createdFilename, nil
 }
 
+// strippedMetadataExtensions are the extensions of the images whose
+// metadata is removed before storing them.
+var strippedMetadataExtensions = map[string]bool{
+	".jpg": true,
+	".png": true,
+}
+
+// stripImageMetadata returns the image without its metadata, such as the
+// location of the photos, and with its orientation applied. Files that
+// can't be parsed as images are kept as uploaded.
+func (a *App) stripImageMetadata(reader io.Reader) (io.Reader, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	stripped, err := imagemeta.Strip(data)
+	if err != nil {
+		a.logger.Warn("Cannot strip the metadata of an uploaded image", mlog.Err(err))
+		return bytes.NewReader(data), nil
+	}
+	return bytes.NewReader(stripped), nil
+}
+
 func (a *App) GetFileReader(teamID, rootID, filename string) (filestore.ReadCloseSeeker, error) {
 	filePath := filepath.Join(teamID, rootID, filename)
 	exists, err := a.filesBackend.FileExists(filePath)
@@ -1,14 +1,22 @@
 package app
 
 import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/mattermost/focalboard/server/model"
+
 	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore"
 	"github.com/mattermost/mattermost-server/v6/shared/filestore/mocks"
@@ -200,6 +208,13 @@ func TestSaveFile(t *testing.T) {
 		}
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).DoAndReturn(func(info *model.FileInfo) error {
+			assert.Equal(t, "1", info.TeamID)
+			assert.Equal(t, testBoardID, info.BoardID)
+			assert.Equal(t, fileName, info.FileID)
+			assert.EqualValues(t, 10, info.Size)
+			return nil
+		})
 		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", testBoardID, fileName)
 		assert.Equal(t, fileName, actual)
 		assert.Nil(t, err)
@@ -223,6 +238,7 @@ func TestSaveFile(t *testing.T) {
 		}
 
 		mockedFileBackend.On("WriteFile", mockedReadCloseSeek, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
 		actual, err := th.App.SaveFile(mockedReadCloseSeek, "1", "test-board-id", fileName)
 		assert.Nil(t, err)
 		assert.NotNil(t, actual)
@@ -251,4 +267,40 @@ func TestSaveFile(t *testing.T) {
 		assert.Equal(t, "", actual)
 		assert.Equal(t, "unable to store the file in the files storage: Mocked File backend error", err.Error())
 	})
+
+	t.Run("should strip the metadata of images when enabled", func(t *testing.T) {
+		th.App.config.StripImageMetadata = true
+		defer func() { th.App.config.StripImageMetadata = false }()
+		mockedFileBackend := &mocks.FileBackend{}
+		th.App.filesBackend = mockedFileBackend
+
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2))))
+		plain := buf.Bytes()
+
+		// a text chunk with the location, after the 25 bytes of the header chunk
+		text := []byte("tEXtLocation\x0048.85,2.35")
+		chunk := make([]byte, 4, 8+len(text))
+		binary.BigEndian.PutUint32(chunk, uint32(len(text)-4))
+		chunk = append(chunk, text...)
+		crc := make([]byte, 4)
+		binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(text))
+		chunk = append(chunk, crc...)
+		withText := append(append(append([]byte{}, plain[:33]...), chunk...), plain[33:]...)
+
+		var written []byte
+		writeFileFunc := func(reader io.Reader, path string) int64 {
+			written, _ = io.ReadAll(reader)
+			return int64(len(written))
+		}
+		writeFileErrorFunc := func(reader io.Reader, filePath string) error {
+			return nil
+		}
+
+		mockedFileBackend.On("WriteFile", mock.Anything, mock.Anything).Return(writeFileFunc, writeFileErrorFunc)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).Return(nil)
+		_, err := th.App.SaveFile(bytes.NewReader(withText), "1", testBoardID, "photo.png")
+		assert.NoError(t, err)
+		assert.Equal(t, plain, written)
+	})
 }
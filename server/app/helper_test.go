@@ -9,7 +9,9 @@ import (
 
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/jobs"
 	"github.com/mattermost/focalboard/server/services/metrics"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/store/mockstore"
 	"github.com/mattermost/focalboard/server/services/webhook"
 	"github.com/mattermost/focalboard/server/ws"
@@ -29,22 +31,27 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	cfg := config.Configuration{}
-	store := mockstore.NewMockStore(ctrl)
+	mockStore := mockstore.NewMockStore(ctrl)
+	// transactions run directly on the mock store
+	mockStore.EXPECT().RunInTransaction(gomock.Any()).DoAndReturn(func(fn func(tx store.TxStore) error) error {
+		return fn(mockStore)
+	}).AnyTimes()
 	filesBackend := &mocks.FileBackend{}
-	auth := auth.New(&cfg, store, nil)
+	auth := auth.New(&cfg, mockStore, nil)
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
 	sessionToken := "TESTTOKEN"
-	wsserver := ws.NewServer(auth, sessionToken, false, logger, store)
-	webhook := webhook.NewClient(&cfg, logger)
+	wsserver := ws.NewServer(auth, sessionToken, false, logger, mockStore)
+	webhook := webhook.NewClient(nil, logger)
 	metricsService := metrics.NewMetrics(metrics.InstanceInfo{})
 
 	appServices := Services{
 		Auth:             auth,
-		Store:            store,
+		Store:            mockStore,
 		FilesBackend:     filesBackend,
 		Webhook:          webhook,
 		Metrics:          metricsService,
 		Logger:           logger,
+		Jobs:             jobs.New(jobs.Params{Store: mockStore, Logger: logger}),
 		SkipTemplateInit: true,
 	}
 	app2 := New(&cfg, wsserver, appServices)
@@ -58,7 +65,7 @@ func SetupTestHelper(t *testing.T) (*TestHelper, func()) {
 
 	return &TestHelper{
 		App:          app2,
-		Store:        store,
+		Store:        mockStore,
 		FilesBackend: filesBackend,
 		logger:       logger,
 	}, tearDown
@@ -3,6 +3,7 @@ package app
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,14 +15,19 @@ import (
 	"github.com/krolaw/zipstream"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/import/jira"
+	"github.com/mattermost/focalboard/server/services/import/notion"
+	"github.com/mattermost/focalboard/server/services/import/trello"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
 const (
-	archiveVersion  = 2
-	legacyFileBegin = "{\"version\":1"
+	archiveVersion   = 2
+	archiveExtension = ".boardarchive"
+	legacyFileBegin  = "{\"version\":1"
 )
 
 var (
@@ -33,17 +39,22 @@ var (
 //
 // Archives are ZIP files containing a `version.json` file and zero or more
 // directories, each containing a `board.jsonl` and zero or more image files.
-func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
+//
+// The returned report lists the integrity problems found in the archive. With
+// `opt.DryRun` set the archive is only parsed and validated, nothing is written.
+func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) (*model.ArchiveImportReport, error) {
+	v := newArchiveValidator(opt.DryRun)
+
 	// peek at the first bytes to see if this is a legacy archive format
 	br := bufio.NewReader(r)
 	peek, err := br.Peek(len(legacyFileBegin))
 	if err == nil && string(peek) == legacyFileBegin {
 		a.logger.Debug("importing legacy archive")
-		_, errImport := a.ImportBoardJSONL(br, opt)
-		return errImport
+		_, errImport := a.importBoardJSONL(br, opt, v)
+		return v.report, errImport
 	}
 
-	a.logger.Debug("importing archive")
+	a.logger.Debug("importing archive", mlog.Bool("dry_run", opt.DryRun))
 	zr := zipstream.NewReader(br)
 
 	boardMap := make(map[string]string) // maps old board ids to new
@@ -52,10 +63,13 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 		hdr, err := zr.Next()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
-				a.logger.Debug("import archive - done", mlog.Int("boards_imported", len(boardMap)))
-				return nil
+				a.logger.Debug("import archive - done",
+					mlog.Int("boards_imported", len(boardMap)),
+					mlog.Int("issues", len(v.report.Issues)),
+				)
+				return v.report, nil
 			}
-			return err
+			return nil, err
 		}
 
 		dir, filename := filepath.Split(hdr.Name)
@@ -65,15 +79,15 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 		case "version.json":
 			ver, errVer := parseVersionFile(zr)
 			if errVer != nil {
-				return errVer
+				return nil, errVer
 			}
 			if ver != archiveVersion {
-				return model.NewErrUnsupportedArchiveVersion(ver, archiveVersion)
+				return nil, model.NewErrUnsupportedArchiveVersion(ver, archiveVersion)
 			}
 		case "board.jsonl":
-			boardID, err := a.ImportBoardJSONL(zr, opt)
+			boardID, err := a.importBoardJSONL(zr, opt, v)
 			if err != nil {
-				return fmt.Errorf("cannot import board %s: %w", dir, err)
+				return nil, fmt.Errorf("cannot import board %s: %w", dir, err)
 			}
 			boardMap[dir] = boardID
 		default:
@@ -84,14 +98,21 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 					mlog.String("dir", dir),
 					mlog.String("filename", filename),
 				)
+				v.report.AddIssue(model.ArchiveIssueOrphanedFile, dir, "",
+					fmt.Sprintf("file %s does not belong to a board of the archive", filename))
+				continue
+			}
+			v.report.Files++
+			if opt.DryRun {
 				continue
 			}
 			// save file with original filename so it matches name in image block.
 			filePath := filepath.Join(opt.TeamID, boardID, filename)
-			_, err := a.filesBackend.WriteFile(zr, filePath)
+			written, err := a.filesBackend.WriteFile(zr, filePath)
 			if err != nil {
-				return fmt.Errorf("cannot import file %s for board %s: %w", filename, dir, err)
+				return nil, fmt.Errorf("cannot import file %s for board %s: %w", filename, dir, err)
 			}
+			a.recordFile(opt.TeamID, boardID, filename, written)
 		}
 
 		a.logger.Trace("import archive file",
@@ -104,6 +125,81 @@ func (a *App) ImportArchive(r io.Reader, opt model.ImportArchiveOptions) error {
 // ImportBoardJSONL imports a JSONL file containing blocks for one board. The resulting
 // board id is returned.
 func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (string, error) {
+	return a.importBoardJSONL(r, opt, newArchiveValidator(opt.DryRun))
+}
+
+func (a *App) importBoardJSONL(r io.Reader, opt model.ImportArchiveOptions, v *archiveValidator) (string, error) {
+	boardsAndBlocks, err := a.parseBoardJSONL(r, opt, v)
+	if err != nil {
+		return "", err
+	}
+	v.validate(boardsAndBlocks)
+
+	if opt.DryRun {
+		// with nothing inserted the board keeps the id of the archive
+		for _, board := range boardsAndBlocks.Boards {
+			return board.ID, nil
+		}
+		return "", nil
+	}
+
+	a.fixBoardsandBlocks(boardsAndBlocks, opt)
+
+	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
+	if err != nil {
+		return "", fmt.Errorf("error generating archive block IDs: %w", err)
+	}
+
+	// the boards are created with their members in one transaction so a
+	// failed import doesn't leave boards nobody can access
+	var members []*model.BoardMember
+	err = a.store.RunInTransaction(func(tx store.TxStore) error {
+		var txErr error
+		boardsAndBlocks, txErr = tx.CreateBoardsAndBlocks(boardsAndBlocks, opt.ModifiedBy)
+		if txErr != nil {
+			return fmt.Errorf("error inserting archive blocks: %w", txErr)
+		}
+
+		// add user to all the new boards.
+		for _, board := range boardsAndBlocks.Boards {
+			existingMember, txErr := tx.GetMemberForBoard(board.ID, opt.ModifiedBy)
+			if txErr != nil && !model.IsErrNotFound(txErr) {
+				return fmt.Errorf("cannot get member of board: %w", txErr)
+			}
+			if existingMember != nil {
+				continue
+			}
+
+			boardMember := &model.BoardMember{
+				BoardID:     board.ID,
+				UserID:      opt.ModifiedBy,
+				SchemeAdmin: true,
+			}
+			newMember, txErr := tx.SaveMember(boardMember)
+			if txErr != nil {
+				return fmt.Errorf("cannot add member to board: %w", txErr)
+			}
+			members = append(members, newMember)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	a.notifyBoardsAndBlocksCreated(boardsAndBlocks, members, opt.ModifiedBy)
+
+	// find new board id
+	for _, board := range boardsAndBlocks.Boards {
+		return board.ID, nil
+	}
+	return "", fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
+}
+
+// parseBoardJSONL reads the board and blocks of a JSONL file of an archive.
+// Blocks whose board ID doesn't match the board of the file are reported to
+// the validator, and moved to that board.
+func (a *App) parseBoardJSONL(r io.Reader, opt model.ImportArchiveOptions, v *archiveValidator) (*model.BoardsAndBlocks, error) {
 	// TODO: Stream this once `model.GenerateBlockIDs` can take a stream of blocks.
 	//       We don't want to load the whole file in memory, even though it's a single board.
 	boardsAndBlocks := &model.BoardsAndBlocks{
@@ -135,7 +231,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 			if !skip {
 				var archiveLine model.ArchiveLine
 				if err := json.Unmarshal(line, &archiveLine); err != nil {
-					return "", fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
+					return nil, fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
 				}
 
 				// first line must be a board
@@ -147,7 +243,7 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 				case "board":
 					var board model.Board
 					if err2 := json.Unmarshal(archiveLine.Data, &board); err2 != nil {
-						return "", fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid board in archive line %d: %w", lineNum, err2)
 					}
 					board.ModifiedBy = userID
 					board.UpdateAt = now
@@ -158,27 +254,31 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 					// legacy archives encoded boards as blocks; we need to convert them to real boards.
 					var block model.Block
 					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid board block in archive line %d: %w", lineNum, err2)
 					}
 					block.ModifiedBy = userID
 					block.UpdateAt = now
 					board, err := a.blockToBoard(&block, opt)
 					if err != nil {
-						return "", fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
+						return nil, fmt.Errorf("cannot convert archive line %d to block: %w", lineNum, err)
 					}
 					boardsAndBlocks.Boards = append(boardsAndBlocks.Boards, board)
 					boardID = board.ID
 				case "block":
 					var block model.Block
 					if err2 := json.Unmarshal(archiveLine.Data, &block); err2 != nil {
-						return "", fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
+						return nil, fmt.Errorf("invalid block in archive line %d: %w", lineNum, err2)
+					}
+					if block.BoardID != "" && block.BoardID != boardID {
+						v.report.AddIssue(model.ArchiveIssueBadBoardID, boardID, block.ID,
+							fmt.Sprintf("block of archive line %d belongs to board %s", lineNum, block.BoardID))
 					}
 					block.ModifiedBy = userID
 					block.UpdateAt = now
 					block.BoardID = boardID
 					boardsAndBlocks.Blocks = append(boardsAndBlocks.Blocks, block)
 				default:
-					return "", model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
+					return nil, model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
 				}
 				firstLine = false
 			}
@@ -188,41 +288,65 @@ func (a *App) ImportBoardJSONL(r io.Reader, opt model.ImportArchiveOptions) (str
 			if errors.Is(errRead, io.EOF) {
 				break
 			}
-			return "", fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
+			return nil, fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
 		}
 		lineNum++
 	}
+	return boardsAndBlocks, nil
+}
 
-	a.fixBoardsandBlocks(boardsAndBlocks, opt)
+// archiveValidator checks the integrity of the boards and blocks of an
+// archive as they are read, filling the report of the import.
+type archiveValidator struct {
+	report *model.ArchiveImportReport
+	ids    map[string]string // maps the ids seen so far to the board holding them
+}
 
-	var err error
-	boardsAndBlocks, err = model.GenerateBoardsAndBlocksIDs(boardsAndBlocks, a.logger)
-	if err != nil {
-		return "", fmt.Errorf("error generating archive block IDs: %w", err)
+func newArchiveValidator(dryRun bool) *archiveValidator {
+	return &archiveValidator{
+		report: &model.ArchiveImportReport{
+			DryRun: dryRun,
+			Issues: []model.ArchiveImportIssue{},
+		},
+		ids: make(map[string]string),
 	}
+}
 
-	boardsAndBlocks, err = a.CreateBoardsAndBlocks(boardsAndBlocks, opt.ModifiedBy, false)
-	if err != nil {
-		return "", fmt.Errorf("error inserting archive blocks: %w", err)
+// validate reports the duplicate IDs of the boards and blocks of one board
+// file, and the blocks whose parent is neither the board nor one of its
+// blocks.
+func (v *archiveValidator) validate(bab *model.BoardsAndBlocks) {
+	v.report.Boards += len(bab.Boards)
+	v.report.Blocks += len(bab.Blocks)
+
+	if len(bab.Boards) == 0 {
+		v.report.AddIssue(model.ArchiveIssueMissingBoard, "", "", "board file has no board")
 	}
 
-	// add user to all the new boards.
-	for _, board := range boardsAndBlocks.Boards {
-		boardMember := &model.BoardMember{
-			BoardID:     board.ID,
-			UserID:      opt.ModifiedBy,
-			SchemeAdmin: true,
-		}
-		if _, err := a.AddMemberToBoard(boardMember); err != nil {
-			return "", fmt.Errorf("cannot add member to board: %w", err)
+	known := make(map[string]bool, len(bab.Boards)+len(bab.Blocks))
+	see := func(id, boardID string) {
+		if other, ok := v.ids[id]; ok {
+			v.report.AddIssue(model.ArchiveIssueDuplicateID, boardID, id,
+				fmt.Sprintf("id already used in board %s", other))
+			return
 		}
+		v.ids[id] = boardID
+	}
+	for _, board := range bab.Boards {
+		see(board.ID, board.ID)
+		known[board.ID] = true
+	}
+	for _, block := range bab.Blocks {
+		see(block.ID, block.BoardID)
+		known[block.ID] = true
 	}
 
-	// find new board id
-	for _, board := range boardsAndBlocks.Boards {
-		return board.ID, nil
+	for _, block := range bab.Blocks {
+		if block.ParentID != "" && !known[block.ParentID] {
+			v.report.AddIssue(model.ArchiveIssueOrphanedParent, block.BoardID, block.ID,
+				fmt.Sprintf("parent %s is not in the board", block.ParentID))
+		}
 	}
-	return "", fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
 }
 
 // fixBoardsandBlocks allows the caller of `ImportArchive` to modify or filters boards and blocks being
@@ -394,3 +518,98 @@ func readLine(r *bufio.Reader) ([]byte, error) {
 	line = bytes.TrimSpace(line)
 	return line, err
 }
+
+// ImportTrello converts a Trello board export into a new board of the team,
+// creating the board, its blocks and the importing user's membership in one
+// transaction.
+func (a *App) ImportTrello(r io.Reader, teamID, userID string) (*model.Board, error) {
+	bab, err := trello.Convert(r, trello.Options{TeamID: teamID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	bab, err = a.CreateBoardsAndBlocks(bab, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting trello board: %w", err)
+	}
+	return bab.Boards[0], nil
+}
+
+// ImportNotion converts the databases of a Notion export zip into new boards
+// of the team, created in one transaction. Items of the export that cannot be
+// converted are skipped and listed in the result.
+func (a *App) ImportNotion(r io.ReaderAt, size int64, teamID, userID string) (*model.ImportResult, error) {
+	bab, itemErrors, err := notion.Convert(r, size, notion.Options{TeamID: teamID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.ImportResult{
+		Boards: []*model.Board{},
+		Errors: itemErrors,
+	}
+	if len(bab.Boards) == 0 {
+		return result, nil
+	}
+
+	bab, err = a.CreateBoardsAndBlocks(bab, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting notion boards: %w", err)
+	}
+	result.Boards = bab.Boards
+	return result, nil
+}
+
+// loadJiraExport reads the issues of the export of the request, or fetches
+// them from the Jira site of the request.
+func (a *App) loadJiraExport(ctx context.Context, req *model.JiraImportRequest) (*jira.Export, error) {
+	if req.Export != "" {
+		return jira.Parse(strings.NewReader(req.Export))
+	}
+	return jira.Fetch(ctx, nil, jira.Source{
+		URL:   req.URL,
+		Email: req.Email,
+		Token: req.Token,
+		JQL:   req.JQL,
+	})
+}
+
+// PreviewJiraImport returns the proposed mapping of the Jira issues of the
+// request to a board, for the user to adjust it before importing them.
+func (a *App) PreviewJiraImport(ctx context.Context, req *model.JiraImportRequest) (*model.JiraImportPreview, error) {
+	export, err := a.loadJiraExport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.JiraImportPreview{
+		IssueCount: len(export.Issues),
+		Mapping:    jira.ProposeMapping(export),
+	}, nil
+}
+
+// ImportJira converts the Jira issues of the request into a new board of the
+// team following the mapping of the request, or the proposed one if it has
+// none.
+func (a *App) ImportJira(ctx context.Context, req *model.JiraImportRequest, teamID, userID string) (*model.Board, error) {
+	export, err := a.loadJiraExport(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := jira.ProposeMapping(export)
+	if req.Mapping != nil {
+		mapping = *req.Mapping
+	}
+
+	bab, err := jira.Convert(export, mapping, jira.Options{TeamID: teamID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	bab, err = a.CreateBoardsAndBlocks(bab, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("error inserting jira board: %w", err)
+	}
+	return bab.Boards[0], nil
+}
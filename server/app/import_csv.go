@@ -0,0 +1,351 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const maxCSVImportRows = 5000
+
+var csvDateLayouts = []string{"2006-01-02", time.RFC3339, "2006-01-02 15:04", "01/02/2006"}
+
+var csvCheckboxValues = map[string]string{
+	"true":  "true",
+	"yes":   "true",
+	"x":     "true",
+	"1":     "true",
+	"false": "false",
+	"no":    "false",
+	"0":     "false",
+}
+
+// csvImportableTypes are the property types whose values can be read
+// from a CSV cell. The other types are computed or hold structured values.
+var csvImportableTypes = map[string]bool{
+	model.PropertyTypeText:        true,
+	model.PropertyTypeNumber:      true,
+	model.PropertyTypeSelect:      true,
+	model.PropertyTypeMultiSelect: true,
+	model.PropertyTypeDate:        true,
+	model.PropertyTypePerson:      true,
+	model.PropertyTypeCheckbox:    true,
+	model.PropertyTypeURL:         true,
+	model.PropertyTypeEmail:       true,
+	model.PropertyTypePhone:       true,
+}
+
+type csvColumn struct {
+	index    int
+	name     string
+	property *model.CardProperty
+}
+
+// csvImport holds the state of a CSV import while its rows are converted
+// to cards.
+type csvImport struct {
+	app        *App
+	mapping    *model.CSVImportMapping
+	titleIndex int
+	columns    []csvColumn
+	newOptions map[string][]model.CardPropertyOption
+	users      map[string]string
+}
+
+// ImportCSV creates a card in the board for every row of the CSV file,
+// mapping its columns to card properties. Rows that can't be converted or
+// fail validation are skipped and reported, the other cards are inserted
+// in a single transaction.
+func (a *App) ImportCSV(boardID string, r io.Reader, mapping *model.CSVImportMapping, userID string) (*model.CSVImportResult, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalidCSV, err)
+	}
+
+	imp, err := newCSVImport(a, header, properties, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.CSVImportResult{CardIDs: []string{}, Errors: []model.CSVRowError{}}
+	cards := []model.Block{}
+	rows := []int{}
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			result.Errors = append(result.Errors, model.CSVRowError{Row: row, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", model.ErrInvalidCSV, err)
+		}
+		if row-1 > maxCSVImportRows {
+			return nil, fmt.Errorf("%w: more than %d rows", model.ErrInvalidCSV, maxCSVImportRows)
+		}
+
+		card, rowErr := imp.convertRow(board.ID, record)
+		if rowErr != nil {
+			rowErr.Row = row
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+		cards = append(cards, card)
+		rows = append(rows, row)
+	}
+
+	if len(imp.newOptions) != 0 {
+		if board, err = a.addCSVOptions(board.ID, imp.newOptions, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	a.applyCardPropertyDefaults(board, cards, userID)
+	valid := make([]model.Block, 0, len(cards))
+	for i := range cards {
+		if err := a.validateCardPropertyValues(board, &cards[i], nil); err != nil {
+			result.Errors = append(result.Errors, model.CSVRowError{Row: rows[i], Message: err.Error()})
+			continue
+		}
+		valid = append(valid, cards[i])
+	}
+
+	if len(valid) == 0 {
+		return result, nil
+	}
+
+	if err := a.checkBoardLimits(board, valid); err != nil {
+		return nil, err
+	}
+
+	if err := a.store.InsertBlocks(valid, userID); err != nil {
+		return nil, err
+	}
+	a.updateSearchIndex(searchIndexChange{Blocks: valid})
+
+	for i := range valid {
+		result.CardIDs = append(result.CardIDs, valid[i].ID)
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		for _, block := range valid {
+			a.wsAdapter.BroadcastBlockChange(board.TeamID, block)
+			a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionAdd, block)
+		}
+		a.metrics.IncrementBlocksInserted(len(valid))
+		return nil
+	})
+
+	return result, nil
+}
+
+// addCSVOptions appends the options found in the CSV file to the select
+// properties of the board and returns the updated board.
+func (a *App) addCSVOptions(boardID string, newOptions map[string][]model.CardPropertyOption, userID string) (*model.Board, error) {
+	err := a.updateCardProperties(boardID, userID, func(properties []model.CardProperty) ([]model.CardProperty, error) {
+		for propertyID, options := range newOptions {
+			idx := model.FindCardProperty(properties, propertyID)
+			if idx == -1 {
+				return nil, model.NewErrNotFound(propertyID)
+			}
+			properties[idx].Options = append(properties[idx].Options, options...)
+		}
+		return properties, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.store.GetBoard(boardID)
+}
+
+func newCSVImport(a *App, header []string, properties []model.CardProperty, mapping *model.CSVImportMapping) (*csvImport, error) {
+	headerIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		// spreadsheets often save the file with a byte order mark
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, ok := headerIndex[name]; !ok {
+			headerIndex[name] = i
+		}
+	}
+
+	titleIndex, ok := headerIndex[strings.TrimSpace(mapping.TitleColumn)]
+	if !ok {
+		return nil, model.NewErrInvalidCSVMapping("title column %q not found", mapping.TitleColumn)
+	}
+
+	imp := &csvImport{
+		app:        a,
+		mapping:    mapping,
+		titleIndex: titleIndex,
+		newOptions: map[string][]model.CardPropertyOption{},
+		users:      map[string]string{},
+	}
+
+	mapped := map[string]bool{}
+	for _, cm := range mapping.Columns {
+		index, ok := headerIndex[strings.TrimSpace(cm.Column)]
+		if !ok {
+			return nil, model.NewErrInvalidCSVMapping("column %q not found", cm.Column)
+		}
+		idx := model.FindCardProperty(properties, cm.PropertyID)
+		if idx == -1 {
+			return nil, model.NewErrInvalidCSVMapping("property %q not found", cm.PropertyID)
+		}
+		property := &properties[idx]
+		if !csvImportableTypes[property.Type] {
+			return nil, model.NewErrInvalidCSVMapping("property %q of type %q cannot be imported", property.Name, property.Type)
+		}
+		if mapped[property.ID] {
+			return nil, model.NewErrInvalidCSVMapping("property %q is mapped more than once", property.Name)
+		}
+		mapped[property.ID] = true
+		imp.columns = append(imp.columns, csvColumn{index: index, name: cm.Column, property: property})
+	}
+	return imp, nil
+}
+
+func (imp *csvImport) convertRow(boardID string, record []string) (model.Block, *model.CSVRowError) {
+	title := ""
+	if imp.titleIndex < len(record) {
+		title = strings.TrimSpace(record[imp.titleIndex])
+	}
+	if title == "" {
+		return model.Block{}, &model.CSVRowError{Column: imp.mapping.TitleColumn, Message: "title cannot be empty"}
+	}
+
+	values := map[string]interface{}{}
+	for _, column := range imp.columns {
+		if column.index >= len(record) {
+			continue
+		}
+		cell := strings.TrimSpace(record[column.index])
+		if cell == "" {
+			continue
+		}
+		value, err := imp.convertValue(column.property, cell)
+		if err != nil {
+			return model.Block{}, &model.CSVRowError{Column: column.name, Message: err.Error()}
+		}
+		values[column.property.ID] = value
+	}
+
+	return model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     model.TypeCard,
+		Title:    title,
+		Fields: map[string]interface{}{
+			"properties":   values,
+			"contentOrder": []interface{}{},
+		},
+	}, nil
+}
+
+func (imp *csvImport) convertValue(property *model.CardProperty, cell string) (interface{}, error) {
+	switch property.Type {
+	case model.PropertyTypeNumber:
+		if _, _, err := model.ParseCardNumber(cell); err != nil {
+			return nil, fmt.Errorf("invalid number %q", cell)
+		}
+		return cell, nil
+	case model.PropertyTypeSelect:
+		return imp.optionID(property, cell)
+	case model.PropertyTypeMultiSelect:
+		ids := []interface{}{}
+		seen := map[string]bool{}
+		for _, v := range strings.Split(cell, ",") {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			}
+			id, err := imp.optionID(property, v)
+			if err != nil {
+				return nil, err
+			}
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	case model.PropertyTypeDate:
+		for _, layout := range csvDateLayouts {
+			if t, err := time.Parse(layout, cell); err == nil {
+				date, _ := json.Marshal(map[string]int64{"from": utils.GetMillisForTime(t)})
+				return string(date), nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q", cell)
+	case model.PropertyTypePerson:
+		return imp.userID(cell)
+	case model.PropertyTypeCheckbox:
+		value, ok := csvCheckboxValues[strings.ToLower(cell)]
+		if !ok {
+			return nil, fmt.Errorf("invalid checkbox value %q", cell)
+		}
+		return value, nil
+	}
+	return cell, nil
+}
+
+// optionID returns the id of the option matching the value, ignoring
+// case, creating the option if the mapping allows it.
+func (imp *csvImport) optionID(property *model.CardProperty, value string) (string, error) {
+	for _, option := range property.Options {
+		if strings.EqualFold(option.Value, value) {
+			return option.ID, nil
+		}
+	}
+	for _, option := range imp.newOptions[property.ID] {
+		if strings.EqualFold(option.Value, value) {
+			return option.ID, nil
+		}
+	}
+	if !imp.mapping.CreateOptions {
+		return "", fmt.Errorf("%q is not an option of property %q", value, property.Name)
+	}
+
+	option := model.CardPropertyOption{Value: value}
+	option.Hydrate()
+	imp.newOptions[property.ID] = append(imp.newOptions[property.ID], option)
+	return option.ID, nil
+}
+
+// userID returns the id of the user with the given username.
+func (imp *csvImport) userID(username string) (string, error) {
+	username = strings.TrimPrefix(username, "@")
+	if id, ok := imp.users[username]; ok {
+		return id, nil
+	}
+
+	user, err := imp.app.store.GetUserByUsername(username)
+	if err != nil && !model.IsErrNotFound(err) {
+		return "", err
+	}
+	if user == nil {
+		return "", fmt.Errorf("user %q not found", username)
+	}
+	imp.users[username] = user.ID
+	return user.ID, nil
+}
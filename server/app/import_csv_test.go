@@ -0,0 +1,131 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+	cardProperties := []map[string]interface{}{
+		{
+			"id":   "status",
+			"name": "Status",
+			"type": model.PropertyTypeSelect,
+			"options": []interface{}{
+				map[string]interface{}{"id": "todo", "value": "To Do", "color": model.PropertyColorDefault},
+			},
+		},
+		{"id": "estimate", "name": "Estimate", "type": model.PropertyTypeNumber},
+		{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson},
+		{"id": "due", "name": "Due", "type": model.PropertyTypeDate},
+		{"id": "created", "name": "Created", "type": model.PropertyTypeCreatedTime},
+	}
+
+	mapping := &model.CSVImportMapping{
+		TitleColumn: "Name",
+		Columns: []model.CSVColumnMapping{
+			{Column: "Status", PropertyID: "status"},
+			{Column: "Estimate", PropertyID: "estimate"},
+			{Column: "Owner", PropertyID: "owner"},
+			{Column: "Due", PropertyID: "due"},
+		},
+	}
+
+	data := "\ufeffName,Status,Estimate,Owner,Due\n" +
+		"Write docs,to do,3,@jdoe,2022-05-26\n" +
+		"Fix bug,Done,1,,\n" +
+		"Ship it,To Do,lots,,\n" +
+		",To Do,1,,\n" +
+		"\"Plan\nthe release\",,,,\n"
+
+	t.Run("valid rows are imported and the others reported", func(t *testing.T) {
+		board := &model.Board{ID: testBoardID, CardProperties: cardProperties}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetUserByUsername("jdoe").Return(&model.User{ID: "user-jdoe"}, nil)
+		th.Store.EXPECT().InsertBlocks(gomock.Any(), "user-id").DoAndReturn(func(blocks []model.Block, userID string) error {
+			require.Len(t, blocks, 2)
+			values := model.CardPropertyValues(&blocks[0])
+			require.Equal(t, "Write docs", blocks[0].Title)
+			require.Equal(t, "todo", values["status"])
+			require.Equal(t, "3", values["estimate"])
+			require.Equal(t, "user-jdoe", values["owner"])
+			require.Equal(t, `{"from":1653523200000}`, values["due"])
+			require.Equal(t, "Plan\nthe release", blocks[1].Title)
+			return nil
+		})
+
+		result, err := th.App.ImportCSV(testBoardID, strings.NewReader(data), mapping, "user-id")
+		require.NoError(t, err)
+		require.Len(t, result.CardIDs, 2)
+		require.Equal(t, []model.CSVRowError{
+			{Row: 3, Column: "Status", Message: `"Done" is not an option of property "Status"`},
+			{Row: 4, Column: "Estimate", Message: `invalid number "lots"`},
+			{Row: 5, Column: "Name", Message: "title cannot be empty"},
+		}, result.Errors)
+	})
+
+	t.Run("missing options are created", func(t *testing.T) {
+		board := &model.Board{ID: testBoardID, CardProperties: cardProperties}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).Times(3)
+		th.Store.EXPECT().InsertBoard(gomock.Any(), "user-id").DoAndReturn(func(board *model.Board, userID string) (*model.Board, error) {
+			properties, err := model.CardPropertiesFromBoard(board)
+			require.NoError(t, err)
+			require.Len(t, properties[0].Options, 2)
+			require.Equal(t, "Done", properties[0].Options[1].Value)
+			return board, nil
+		})
+		th.Store.EXPECT().InsertBlocks(gomock.Any(), "user-id").DoAndReturn(func(blocks []model.Block, userID string) error {
+			require.Len(t, blocks, 2)
+			require.NotEqual(t, model.CardPropertyValues(&blocks[0])["status"], model.CardPropertyValues(&blocks[1])["status"])
+			return nil
+		})
+
+		csvData := "Name,Status\nFirst,To Do\nSecond,Done\n"
+		result, err := th.App.ImportCSV(testBoardID, strings.NewReader(csvData), &model.CSVImportMapping{
+			TitleColumn:   "Name",
+			Columns:       []model.CSVColumnMapping{{Column: "Status", PropertyID: "status"}},
+			CreateOptions: true,
+		}, "user-id")
+		require.NoError(t, err)
+		require.Len(t, result.CardIDs, 2)
+		require.Empty(t, result.Errors)
+	})
+
+	t.Run("invalid mappings", func(t *testing.T) {
+		mappings := []*model.CSVImportMapping{
+			{TitleColumn: "Title"},
+			{TitleColumn: "Name", Columns: []model.CSVColumnMapping{{Column: "Priority", PropertyID: "status"}}},
+			{TitleColumn: "Name", Columns: []model.CSVColumnMapping{{Column: "Status", PropertyID: "priority"}}},
+			{TitleColumn: "Name", Columns: []model.CSVColumnMapping{{Column: "Due", PropertyID: "created"}}},
+			{TitleColumn: "Name", Columns: []model.CSVColumnMapping{
+				{Column: "Status", PropertyID: "status"},
+				{Column: "Estimate", PropertyID: "status"},
+			}},
+		}
+		for _, m := range mappings {
+			board := &model.Board{ID: testBoardID, CardProperties: cardProperties}
+			th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+			_, err := th.App.ImportCSV(testBoardID, strings.NewReader(data), m, "user-id")
+			require.True(t, model.IsErrInvalidCSVMapping(err), err)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		board := &model.Board{ID: testBoardID, CardProperties: cardProperties}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		_, err := th.App.ImportCSV(testBoardID, strings.NewReader(""), mapping, "user-id")
+		require.ErrorIs(t, err, model.ErrInvalidCSV)
+	})
+}
@@ -1,11 +1,16 @@
 package app
 
 import (
+	"archive/zip"
 	"bytes"
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/import/jira"
+	"github.com/mattermost/focalboard/server/services/import/trello"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,11 +50,190 @@ func TestApp_ImportArchive(t *testing.T) {
 
 		th.Store.EXPECT().CreateBoardsAndBlocks(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").Return(babs, nil)
 		th.Store.EXPECT().GetMembersForBoard(board.ID).AnyTimes().Return([]*model.BoardMember{boardMember}, nil)
-		th.Store.EXPECT().GetBoard(board.ID).Return(board, nil)
 		th.Store.EXPECT().GetMemberForBoard(board.ID, "user").Return(boardMember, nil)
 
-		err := th.App.ImportArchive(r, opts)
+		report, err := th.App.ImportArchive(r, opts)
 		require.NoError(t, err, "import archive should not fail")
+		require.Equal(t, 1, report.Boards)
+	})
+
+	t.Run("dry run reports integrity issues without writing", func(t *testing.T) {
+		lines := []string{
+			`{"type":"board","data":{"id":"board1","title":"Board"}}`,
+			`{"type":"block","data":{"id":"card1","parentId":"board1","boardId":"board1","type":"card"}}`,
+			`{"type":"block","data":{"id":"text1","parentId":"card2","boardId":"board1","type":"text"}}`,
+			`{"type":"block","data":{"id":"card1","parentId":"board1","boardId":"board1","type":"card"}}`,
+			`{"type":"block","data":{"id":"view1","parentId":"board1","boardId":"board2","type":"view"}}`,
+		}
+
+		buf := &bytes.Buffer{}
+		w := zip.NewWriter(buf)
+		files := map[string]string{
+			"version.json":       `{"version":2,"date":1614714686842}`,
+			"board1/board.jsonl": strings.Join(lines, "\n"),
+			"board1/image.png":   "image",
+			"other/image.png":    "image",
+		}
+		for _, name := range []string{"version.json", "board1/board.jsonl", "board1/image.png", "other/image.png"} {
+			f, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(files[name]))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+
+		// the store and files backend have no expectations, so any write fails the test
+		opts := model.ImportArchiveOptions{
+			TeamID:     "test-team",
+			ModifiedBy: "user",
+			DryRun:     true,
+		}
+		report, err := th.App.ImportArchive(buf, opts)
+		require.NoError(t, err)
+		require.True(t, report.DryRun)
+		require.Equal(t, 1, report.Boards)
+		require.Equal(t, 4, report.Blocks)
+		require.Equal(t, 1, report.Files)
+
+		issues := map[string]string{}
+		for _, issue := range report.Issues {
+			issues[issue.Type] = issue.BlockID
+		}
+		require.Equal(t, map[string]string{
+			model.ArchiveIssueBadBoardID:     "view1",
+			model.ArchiveIssueDuplicateID:    "card1",
+			model.ArchiveIssueOrphanedParent: "text1",
+			model.ArchiveIssueOrphanedFile:   "",
+		}, issues)
+	})
+}
+
+func TestApp_ImportTrello(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("import trello board", func(t *testing.T) {
+		export := `{"name": "Trello board", "lists": [{"id": "list", "name": "To Do"}], "cards": [{"id": "card", "name": "Card", "idList": "list"}]}`
+
+		th.Store.EXPECT().CreateBoardsAndBlocksWithAdmin(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").DoAndReturn(
+			func(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+				require.Len(t, bab.Boards, 1)
+				require.Len(t, bab.Blocks, 2)
+				member := &model.BoardMember{BoardID: bab.Boards[0].ID, UserID: userID, SchemeAdmin: true}
+				return bab, []*model.BoardMember{member}, nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		board, err := th.App.ImportTrello(bytes.NewReader([]byte(export)), "test-team", "user")
+		require.NoError(t, err)
+		require.Equal(t, "Trello board", board.Title)
+		require.Equal(t, "test-team", board.TeamID)
+	})
+
+	t.Run("invalid export", func(t *testing.T) {
+		board, err := th.App.ImportTrello(bytes.NewReader([]byte("{")), "test-team", "user")
+		require.ErrorIs(t, err, trello.ErrInvalidExport)
+		require.Nil(t, board)
+	})
+}
+
+func TestApp_ImportNotion(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	buildExport := func(files map[string]string) *bytes.Reader {
+		buf := &bytes.Buffer{}
+		w := zip.NewWriter(buf)
+		for name, content := range files {
+			f, err := w.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, w.Close())
+		return bytes.NewReader(buf.Bytes())
+	}
+
+	t.Run("import notion databases", func(t *testing.T) {
+		r := buildExport(map[string]string{
+			"Tasks.csv": "Name,Status\nFirst,Done\n",
+			"Notes.md":  "# Notes\n",
+		})
+
+		th.Store.EXPECT().CreateBoardsAndBlocksWithAdmin(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").DoAndReturn(
+			func(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+				require.Len(t, bab.Boards, 1)
+				require.Len(t, bab.Blocks, 2)
+				return bab, []*model.BoardMember{}, nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		result, err := th.App.ImportNotion(r, r.Size(), "test-team", "user")
+		require.NoError(t, err)
+		require.Len(t, result.Boards, 1)
+		require.Equal(t, "Tasks", result.Boards[0].Title)
+		require.Len(t, result.Errors, 1)
+		require.Equal(t, "Notes.md", result.Errors[0].Item)
+	})
+
+	t.Run("nothing to import", func(t *testing.T) {
+		r := buildExport(map[string]string{"Empty.csv": ""})
+
+		result, err := th.App.ImportNotion(r, r.Size(), "test-team", "user")
+		require.NoError(t, err)
+		require.Empty(t, result.Boards)
+		require.Len(t, result.Errors, 1)
+	})
+}
+
+func TestApp_ImportJira(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	export := `{"issues": [{"key": "PROJ-1", "fields": {"summary": "Fix login", "status": {"name": "To Do"}, "labels": ["auth"]}}]}`
+
+	t.Run("preview", func(t *testing.T) {
+		preview, err := th.App.PreviewJiraImport(context.Background(), &model.JiraImportRequest{Export: export})
+		require.NoError(t, err)
+		require.Equal(t, 1, preview.IssueCount)
+		require.Equal(t, model.JiraFieldStatus, preview.Mapping.GroupBy)
+	})
+
+	t.Run("import with an adjusted mapping", func(t *testing.T) {
+		req := &model.JiraImportRequest{
+			Export: export,
+			Mapping: &model.JiraImportMapping{
+				Title: "Login",
+				Fields: []model.JiraFieldMapping{
+					{Field: model.JiraFieldLabels, Property: "Tags", Type: "multiSelect"},
+				},
+			},
+		}
+
+		th.Store.EXPECT().CreateBoardsAndBlocksWithAdmin(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user").DoAndReturn(
+			func(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+				require.Len(t, bab.Boards, 1)
+				// tags and original URL
+				require.Len(t, bab.Boards[0].CardProperties, 2)
+				require.Len(t, bab.Blocks, 2)
+				return bab, []*model.BoardMember{}, nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		board, err := th.App.ImportJira(context.Background(), req, "test-team", "user")
+		require.NoError(t, err)
+		require.Equal(t, "Login", board.Title)
+		require.Equal(t, "test-team", board.TeamID)
+	})
+
+	t.Run("invalid mapping", func(t *testing.T) {
+		req := &model.JiraImportRequest{
+			Export:  export,
+			Mapping: &model.JiraImportMapping{GroupBy: model.JiraFieldStatus},
+		}
+		board, err := th.App.ImportJira(context.Background(), req, "test-team", "user")
+		require.ErrorIs(t, err, jira.ErrInvalidMapping)
+		require.Nil(t, board)
 	})
 }
 
@@ -8,6 +8,12 @@ import (
 
 // initialize is called when the App is first created.
 func (a *App) initialize(skipTemplateInit bool) {
+	if a.jobs != nil {
+		a.registerJobWorkers()
+	}
+
+	a.migrateConfigWebhooks()
+
 	if !skipTemplateInit {
 		if err := a.InitTemplates(); err != nil {
 			a.logger.Error(`InitializeTemplates failed`, mlog.Err(err))
@@ -23,4 +29,12 @@ func (a *App) Shutdown() {
 			a.logger.Warn("blockChangeNotifier shutdown timed out")
 		}
 	}
+
+	if a.webhook != nil {
+		a.webhook.Shutdown()
+	}
+
+	if err := a.searchIndex.Close(); err != nil {
+		a.logger.Warn("Cannot close the search backend", mlog.Err(err))
+	}
 }
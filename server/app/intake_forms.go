@@ -0,0 +1,155 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (a *App) GetIntakeForms(boardID string) ([]model.IntakeForm, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+	return model.IntakeFormsFromBoard(board)
+}
+
+func (a *App) CreateIntakeForm(boardID string, form *model.IntakeForm, userID string) (*model.IntakeForm, error) {
+	form.Hydrate()
+
+	err := a.updateIntakeForms(boardID, userID, func(forms []model.IntakeForm, properties []model.CardProperty) ([]model.IntakeForm, error) {
+		if err := form.IsValid(properties); err != nil {
+			return nil, err
+		}
+		return append(forms, *form), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return form, nil
+}
+
+func (a *App) PatchIntakeForm(boardID, formID string, patch *model.IntakeFormPatch, userID string) (*model.IntakeForm, error) {
+	var patched model.IntakeForm
+	err := a.updateIntakeForms(boardID, userID, func(forms []model.IntakeForm, properties []model.CardProperty) ([]model.IntakeForm, error) {
+		idx := model.FindIntakeForm(forms, formID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(formID)
+		}
+		patched = *patch.Patch(&forms[idx])
+		if err := patched.IsValid(properties); err != nil {
+			return nil, err
+		}
+		return forms, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &patched, nil
+}
+
+func (a *App) DeleteIntakeForm(boardID, formID string, userID string) error {
+	return a.updateIntakeForms(boardID, userID, func(forms []model.IntakeForm, _ []model.CardProperty) ([]model.IntakeForm, error) {
+		idx := model.FindIntakeForm(forms, formID)
+		if idx == -1 {
+			return nil, model.NewErrNotFound(formID)
+		}
+		return append(forms[:idx], forms[idx+1:]...), nil
+	})
+}
+
+// GetPublicIntakeForm returns the form as shown to the people filling it.
+// Forms that are disabled or accessed with a wrong token are not found.
+func (a *App) GetPublicIntakeForm(boardID, formID, token string) (*model.IntakeFormView, error) {
+	board, form, err := a.getEnabledIntakeForm(boardID, formID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+	return form.View(properties), nil
+}
+
+// SubmitIntakeForm validates the values submitted to a form and creates
+// the resulting card on the board.
+func (a *App) SubmitIntakeForm(boardID, formID, token string, submission *model.IntakeFormSubmission) (*model.Block, error) {
+	board, form, err := a.getEnabledIntakeForm(boardID, formID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := form.ToCard(board.ID, properties, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.InsertBlocks([]model.Block{*card}, model.SystemUserID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &blocks[0], nil
+}
+
+func (a *App) getEnabledIntakeForm(boardID, formID, token string) (*model.Board, *model.IntakeForm, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	forms, err := model.IntakeFormsFromBoard(board)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	idx := model.FindIntakeForm(forms, formID)
+	if idx == -1 || !forms[idx].Enabled || !forms[idx].HasToken(token) {
+		return nil, nil, model.NewErrNotFound(formID)
+	}
+	return board, &forms[idx], nil
+}
+
+// updateIntakeForms loads the intake forms of a board, applies the
+// modifier, which gets the property schema to validate the changed forms,
+// and saves them back to the board and broadcasts the change.
+func (a *App) updateIntakeForms(boardID, userID string, modifier func([]model.IntakeForm, []model.CardProperty) ([]model.IntakeForm, error)) error {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+
+	forms, err := model.IntakeFormsFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	forms, err = modifier(forms, properties)
+	if err != nil {
+		return err
+	}
+
+	if board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+	board.Properties[model.BoardPropertyIntakeForms] = forms
+	updatedBoard, err := a.store.InsertBoard(board, userID)
+	if err != nil {
+		return err
+	}
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBoardChange(updatedBoard.TeamID, updatedBoard)
+		return nil
+	})
+	return nil
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitIntakeForm(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID: testBoardID,
+		Properties: map[string]interface{}{
+			model.BoardPropertyIntakeForms: []interface{}{
+				map[string]interface{}{
+					"id":      "form-id",
+					"title":   "Requests",
+					"enabled": true,
+					"token":   "form-token",
+					"fields":  []interface{}{map[string]interface{}{"propertyId": model.IntakeFormTitleField, "required": true}},
+				},
+			},
+		},
+	}
+
+	t.Run("wrong token", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		_, err := th.App.SubmitIntakeForm(testBoardID, "form-id", "other-token", &model.IntakeFormSubmission{})
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("card created", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).Times(2)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), model.SystemUserID).Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		submission := &model.IntakeFormSubmission{Values: map[string]interface{}{model.IntakeFormTitleField: "New laptop"}}
+		card, err := th.App.SubmitIntakeForm(testBoardID, "form-id", "form-token", submission)
+		require.NoError(t, err)
+		require.Equal(t, "New laptop", card.Title)
+	})
+}
+
+func TestCreateIntakeForm(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{ID: testBoardID, TeamID: "team-id"}
+	th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+	th.Store.EXPECT().InsertBoard(gomock.Any(), "user-id-1").DoAndReturn(
+		func(board *model.Board, userID string) (*model.Board, error) {
+			forms, err := model.IntakeFormsFromBoard(board)
+			require.NoError(t, err)
+			require.Len(t, forms, 1)
+			return board, nil
+		},
+	)
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+	form, err := th.App.CreateIntakeForm(testBoardID, &model.IntakeForm{Title: "Requests"}, "user-id-1")
+	require.NoError(t, err)
+	require.NotEmpty(t, form.ID)
+	require.NotEmpty(t, form.Token)
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+var ErrInviteUnusable = errors.New("the invite is unknown, expired or used up")
+
+// GetInvites returns the invites of a team.
+func (a *App) GetInvites(teamID string) ([]*model.Invite, error) {
+	return a.store.GetInvitesForTeam(teamID)
+}
+
+// CreateInvite creates an invite link to the team.
+func (a *App) CreateInvite(teamID string, invite *model.Invite, userID string) (*model.Invite, error) {
+	invite.Hydrate(teamID, userID)
+	return a.store.CreateInvite(invite)
+}
+
+// DeleteInvite revokes an invite of the team.
+func (a *App) DeleteInvite(teamID, inviteID string) error {
+	invite, err := a.store.GetInvite(inviteID)
+	if err != nil {
+		return err
+	}
+	if invite.TeamID != teamID {
+		return model.NewErrNotFound(inviteID)
+	}
+	return a.store.DeleteInvite(inviteID)
+}
+
+// RegisterUserWithInvite creates a new user with an invite, and adds them
+// to the open boards of the team of the invite with its role.
+func (a *App) RegisterUserWithInvite(username, email, password, inviteToken string) error {
+	invite, err := a.store.GetInviteByToken(inviteToken)
+	if model.IsErrNotFound(err) {
+		return ErrInviteUnusable
+	}
+	if err != nil {
+		return err
+	}
+	if !invite.IsUsable(utils.GetMillis()) {
+		return ErrInviteUnusable
+	}
+
+	if err = a.checkNewUser(username, email, password); err != nil {
+		return err
+	}
+
+	// the invite is used before creating the user, so that concurrent
+	// registrations can't exceed its number of uses
+	used, err := a.store.UseInvite(inviteToken, utils.GetMillis())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return ErrInviteUnusable
+	}
+
+	user, err := a.createUser(username, email, password)
+	if err != nil {
+		return err
+	}
+
+	return a.addInvitedUserToBoards(invite, user.ID)
+}
+
+func (a *App) addInvitedUserToBoards(invite *model.Invite, userID string) error {
+	boards, err := a.store.GetBoardsForTeam(invite.TeamID)
+	if err != nil {
+		return fmt.Errorf("unable to get the boards of the team: %w", err)
+	}
+
+	for _, board := range boards {
+		if board.IsTemplate || board.Type != model.BoardTypeOpen {
+			continue
+		}
+		if _, err := a.AddMemberToBoard(invite.BoardMember(userID, board.ID)); err != nil {
+			return fmt.Errorf("unable to add the invited user to board %s: %w", board.ID, err)
+		}
+	}
+	return nil
+}
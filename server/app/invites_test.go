@@ -0,0 +1,87 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteInvite(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	invite := &model.Invite{ID: "invite-id", TeamID: "team-id", Role: model.BoardRoleEditor}
+
+	t.Run("invite of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetInvite("invite-id").Return(invite, nil)
+
+		err := th.App.DeleteInvite("other-team-id", "invite-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invite of the team", func(t *testing.T) {
+		th.Store.EXPECT().GetInvite("invite-id").Return(invite, nil)
+		th.Store.EXPECT().DeleteInvite("invite-id").Return(nil)
+
+		require.NoError(t, th.App.DeleteInvite("team-id", "invite-id"))
+	})
+}
+
+func TestRegisterUserWithInvite(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("unknown invite", func(t *testing.T) {
+		th.Store.EXPECT().GetInviteByToken("unknown").Return(nil, model.NewErrNotFound("invite"))
+
+		err := th.App.RegisterUserWithInvite("newUsername", "new@example.com", "testPassword", "unknown")
+		require.ErrorIs(t, err, ErrInviteUnusable)
+	})
+
+	t.Run("used up invite", func(t *testing.T) {
+		invite := &model.Invite{TeamID: "team-id", Token: "used-up", Role: model.BoardRoleEditor, MaxUses: 1, UseCount: 1}
+		th.Store.EXPECT().GetInviteByToken("used-up").Return(invite, nil)
+
+		err := th.App.RegisterUserWithInvite("newUsername", "new@example.com", "testPassword", "used-up")
+		require.ErrorIs(t, err, ErrInviteUnusable)
+	})
+
+	t.Run("invite used up concurrently", func(t *testing.T) {
+		invite := &model.Invite{TeamID: "team-id", Token: "last-use", Role: model.BoardRoleEditor, MaxUses: 1}
+		th.Store.EXPECT().GetInviteByToken("last-use").Return(invite, nil)
+		th.Store.EXPECT().GetUserByUsername("newUsername").Return(nil, model.NewErrNotFound("user"))
+		th.Store.EXPECT().GetUserByEmail("new@example.com").Return(nil, model.NewErrNotFound("user"))
+		th.Store.EXPECT().UseInvite("last-use", gomock.Any()).Return(false, nil)
+
+		err := th.App.RegisterUserWithInvite("newUsername", "new@example.com", "testPassword", "last-use")
+		require.ErrorIs(t, err, ErrInviteUnusable)
+	})
+
+	t.Run("the user joins the open boards with the role", func(t *testing.T) {
+		invite := &model.Invite{TeamID: "team-id", Token: "token", Role: model.BoardRoleCommenter}
+		openBoard := &model.Board{ID: "open-board", TeamID: "team-id", Type: model.BoardTypeOpen}
+		privateBoard := &model.Board{ID: "private-board", TeamID: "team-id", Type: model.BoardTypePrivate}
+
+		th.Store.EXPECT().GetInviteByToken("token").Return(invite, nil)
+		th.Store.EXPECT().GetUserByUsername("newUsername").Return(nil, model.NewErrNotFound("user"))
+		th.Store.EXPECT().GetUserByEmail("new@example.com").Return(nil, model.NewErrNotFound("user"))
+		th.Store.EXPECT().UseInvite("token", gomock.Any()).Return(true, nil)
+		th.Store.EXPECT().CreateUser(gomock.Any()).Return(nil)
+		th.Store.EXPECT().GetBoardsForTeam("team-id").Return([]*model.Board{openBoard, privateBoard}, nil)
+		th.Store.EXPECT().GetBoard("open-board").Return(openBoard, nil)
+		th.Store.EXPECT().GetMemberForBoard("open-board", gomock.Any()).Return(nil, model.NewErrNotFound("member"))
+		th.Store.EXPECT().SaveMember(gomock.Any()).DoAndReturn(func(member *model.BoardMember) (*model.BoardMember, error) {
+			require.Equal(t, "open-board", member.BoardID)
+			require.True(t, member.SchemeCommenter)
+			require.False(t, member.SchemeEditor)
+			return member, nil
+		})
+		th.Store.EXPECT().GetMembersForBoard("open-board").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+		err := th.App.RegisterUserWithInvite("newUsername", "new@example.com", "testPassword", "token")
+		require.NoError(t, err)
+	})
+}
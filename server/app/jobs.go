@@ -0,0 +1,293 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/jobs"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// the jobs of the long running operations requested by the users.
+const (
+	jobTypeImportArchive  = "importArchive"
+	jobTypeImportTrello   = "importTrello"
+	jobTypeImportNotion   = "importNotion"
+	jobTypeExportArchive  = "exportArchive"
+	jobTypeDuplicateBoard = "duplicateBoard"
+
+	// jobFilesPath is the directory of the files storage holding the
+	// uploads and the outputs of the jobs.
+	jobFilesPath = "jobs"
+)
+
+var (
+	ErrJobsUnavailable = errors.New("background jobs are not available")
+	ErrJobHasNoFile    = errors.New("job has no file")
+)
+
+// jobFileData is the data of the jobs processing an uploaded file.
+type jobFileData struct {
+	File   string `json:"file"`
+	TeamID string `json:"teamId"`
+}
+
+type exportJobData struct {
+	TeamID   string   `json:"teamId"`
+	BoardIDs []string `json:"boardIds"`
+}
+
+type duplicateJobData struct {
+	BoardID    string `json:"boardId"`
+	ToTeam     string `json:"toTeam"`
+	AsTemplate bool   `json:"asTemplate"`
+}
+
+// encodeJobData converts a job data struct to the map stored with the job.
+func encodeJobData(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// decodeJobData converts the map stored with a job to a job data struct.
+func decodeJobData(data map[string]interface{}, v interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (a *App) GetJobs(opts model.QueryJobsOptions) ([]*model.Job, error) {
+	return a.store.GetJobs(opts)
+}
+
+func (a *App) GetJob(jobID string) (*model.Job, error) {
+	return a.store.GetJob(jobID)
+}
+
+// GetJobFileReader returns the file produced by a successful job, like the
+// archive of an export.
+func (a *App) GetJobFileReader(job *model.Job) (filestore.ReadCloseSeeker, error) {
+	file, _ := job.Result["file"].(string)
+	if job.Status != model.JobStatusSuccess || file == "" {
+		return nil, ErrJobHasNoFile
+	}
+	return a.filesBackend.Reader(file)
+}
+
+// registerJobWorkers registers the workers of the jobs enqueued by the app.
+func (a *App) registerJobWorkers() {
+	a.jobs.RegisterWorker(jobTypeImportArchive, a.runImportArchiveJob)
+	a.jobs.RegisterWorker(jobTypeImportTrello, a.runImportTrelloJob)
+	a.jobs.RegisterWorker(jobTypeImportNotion, a.runImportNotionJob)
+	a.jobs.RegisterWorker(jobTypeExportArchive, a.runExportArchiveJob)
+	a.jobs.RegisterWorker(jobTypeDuplicateBoard, a.runDuplicateBoardJob)
+	a.jobs.RegisterWorker(jobTypeIndexFileContent, a.runIndexFileContentJob)
+	a.jobs.RegisterWorker(jobTypeReindexSearch, a.runReindexSearchJob)
+	a.jobs.RegisterWorker(jobTypeSeedFileInfo, a.runSeedFileInfoJob)
+}
+
+// enqueueJob enqueues a job of the user. The operations behind these jobs
+// are not idempotent, so they run only once.
+func (a *App) enqueueJob(jobType string, data interface{}, userID string) (*model.Job, error) {
+	if a.jobs == nil {
+		return nil, ErrJobsUnavailable
+	}
+
+	jobData, err := encodeJobData(data)
+	if err != nil {
+		return nil, err
+	}
+	return a.jobs.Enqueue(&model.Job{
+		Type:        jobType,
+		Data:        jobData,
+		CreatedBy:   userID,
+		MaxAttempts: 1,
+	})
+}
+
+// enqueueFileJob saves the uploaded file for the job to read it from any
+// node, and enqueues the job.
+func (a *App) enqueueFileJob(jobType string, r io.Reader, teamID, userID string) (*model.Job, error) {
+	if a.jobs == nil {
+		return nil, ErrJobsUnavailable
+	}
+
+	filePath := filepath.Join(jobFilesPath, utils.NewID(utils.IDTypeNone))
+	if _, err := a.filesBackend.WriteFile(r, filePath); err != nil {
+		return nil, fmt.Errorf("unable to store the job file in the files storage: %w", err)
+	}
+
+	job, err := a.enqueueJob(jobType, jobFileData{File: filePath, TeamID: teamID}, userID)
+	if err != nil {
+		a.removeJobFile(filePath)
+		return nil, err
+	}
+	return job, nil
+}
+
+func (a *App) removeJobFile(filePath string) {
+	if err := a.filesBackend.RemoveFile(filePath); err != nil {
+		a.logger.Warn("Cannot remove job file", mlog.String("file", filePath), mlog.Err(err))
+	}
+}
+
+// ImportArchiveAsync enqueues the import of an archive, see ImportArchive.
+func (a *App) ImportArchiveAsync(r io.Reader, opt model.ImportArchiveOptions) (*model.Job, error) {
+	return a.enqueueFileJob(jobTypeImportArchive, r, opt.TeamID, opt.ModifiedBy)
+}
+
+// ImportTrelloAsync enqueues the import of a Trello export, see ImportTrello.
+func (a *App) ImportTrelloAsync(r io.Reader, teamID, userID string) (*model.Job, error) {
+	return a.enqueueFileJob(jobTypeImportTrello, r, teamID, userID)
+}
+
+// ImportNotionAsync enqueues the import of a Notion export, see ImportNotion.
+func (a *App) ImportNotionAsync(r io.Reader, teamID, userID string) (*model.Job, error) {
+	return a.enqueueFileJob(jobTypeImportNotion, r, teamID, userID)
+}
+
+// ExportArchiveAsync enqueues the export of an archive, which is kept in the
+// files storage for the user to download it once the job is done.
+func (a *App) ExportArchiveAsync(opt model.ExportArchiveOptions, userID string) (*model.Job, error) {
+	return a.enqueueJob(jobTypeExportArchive, exportJobData{TeamID: opt.TeamID, BoardIDs: opt.BoardIDs}, userID)
+}
+
+// DuplicateBoardAsync enqueues the duplication of a board, see
+// DuplicateBoard.
+func (a *App) DuplicateBoardAsync(boardID, userID, toTeam string, asTemplate bool) (*model.Job, error) {
+	return a.enqueueJob(jobTypeDuplicateBoard, duplicateJobData{BoardID: boardID, ToTeam: toTeam, AsTemplate: asTemplate}, userID)
+}
+
+// runFileJob runs fn with the uploaded file of the job, and removes the
+// file once the job is done.
+func (a *App) runFileJob(job *model.Job, fn func(file filestore.ReadCloseSeeker, data jobFileData) (map[string]interface{}, error)) (map[string]interface{}, error) {
+	var data jobFileData
+	if err := decodeJobData(job.Data, &data); err != nil {
+		return nil, err
+	}
+
+	file, err := a.filesBackend.Reader(data.File)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the job file: %w", err)
+	}
+	result, err := fn(file, data)
+	file.Close()
+
+	if err == nil || job.Attempts >= job.MaxAttempts {
+		a.removeJobFile(data.File)
+	}
+	return result, err
+}
+
+func (a *App) runImportArchiveJob(_ context.Context, job *model.Job, _ jobs.ProgressFunc) (map[string]interface{}, error) {
+	return a.runFileJob(job, func(file filestore.ReadCloseSeeker, data jobFileData) (map[string]interface{}, error) {
+		opt := model.ImportArchiveOptions{
+			TeamID:     data.TeamID,
+			ModifiedBy: job.CreatedBy,
+		}
+		report, err := a.ImportArchive(file, opt)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"boards": report.Boards, "issues": len(report.Issues)}, nil
+	})
+}
+
+func (a *App) runImportTrelloJob(_ context.Context, job *model.Job, _ jobs.ProgressFunc) (map[string]interface{}, error) {
+	return a.runFileJob(job, func(file filestore.ReadCloseSeeker, data jobFileData) (map[string]interface{}, error) {
+		board, err := a.ImportTrello(file, data.TeamID, job.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"boardId": board.ID}, nil
+	})
+}
+
+func (a *App) runImportNotionJob(_ context.Context, job *model.Job, _ jobs.ProgressFunc) (map[string]interface{}, error) {
+	return a.runFileJob(job, func(file filestore.ReadCloseSeeker, data jobFileData) (map[string]interface{}, error) {
+		// the export is a zip, which needs random access
+		buf, err := io.ReadAll(file)
+		if err != nil {
+			return nil, err
+		}
+		result, err := a.ImportNotion(bytes.NewReader(buf), int64(len(buf)), data.TeamID, job.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		boardIDs := make([]string, 0, len(result.Boards))
+		for _, board := range result.Boards {
+			boardIDs = append(boardIDs, board.ID)
+		}
+		return encodeJobData(map[string]interface{}{
+			"boardIds": boardIDs,
+			"errors":   result.Errors,
+		})
+	})
+}
+
+func (a *App) runExportArchiveJob(_ context.Context, job *model.Job, _ jobs.ProgressFunc) (map[string]interface{}, error) {
+	var data exportJobData
+	if err := decodeJobData(job.Data, &data); err != nil {
+		return nil, err
+	}
+
+	// the archive is streamed to the files storage while it is written
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(a.ExportArchive(pw, model.ExportArchiveOptions{
+			TeamID:   data.TeamID,
+			BoardIDs: data.BoardIDs,
+			UserID:   job.CreatedBy,
+		}))
+	}()
+
+	filePath := filepath.Join(jobFilesPath, job.ID, "export"+archiveExtension)
+	_, err := a.filesBackend.WriteFile(pr, filePath)
+	pr.CloseWithError(err)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store the archive in the files storage: %w", err)
+	}
+
+	return map[string]interface{}{
+		"file":     filePath,
+		"filename": fmt.Sprintf("archive-%s%s", time.Now().Format("2006-01-02"), archiveExtension),
+	}, nil
+}
+
+func (a *App) runDuplicateBoardJob(_ context.Context, job *model.Job, _ jobs.ProgressFunc) (map[string]interface{}, error) {
+	var data duplicateJobData
+	if err := decodeJobData(job.Data, &data); err != nil {
+		return nil, err
+	}
+
+	bab, _, err := a.DuplicateBoard(data.BoardID, job.CreatedBy, data.ToTeam, data.AsTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	boardIDs := make([]string, 0, len(bab.Boards))
+	for _, board := range bab.Boards {
+		boardIDs = append(boardIDs, board.ID)
+	}
+	return encodeJobData(map[string]interface{}{"boardIds": boardIDs})
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// readCloseSeeker serves a job file from memory.
+type readCloseSeeker struct {
+	*bytes.Reader
+}
+
+func (readCloseSeeker) Close() error {
+	return nil
+}
+
+func TestApp_ExportArchiveAsync(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.Store.EXPECT().CreateJob(gomock.Any()).DoAndReturn(func(job *model.Job) (*model.Job, error) {
+		require.Equal(t, jobTypeExportArchive, job.Type)
+		require.Equal(t, "user-id", job.CreatedBy)
+		require.Equal(t, 1, job.MaxAttempts)
+		require.Equal(t, "team-id", job.Data["teamId"])
+		require.Equal(t, []interface{}{"board-id"}, job.Data["boardIds"])
+		created := *job
+		created.ID = "job-id"
+		return &created, nil
+	})
+
+	job, err := th.App.ExportArchiveAsync(model.ExportArchiveOptions{TeamID: "team-id", BoardIDs: []string{"board-id"}}, "user-id")
+	require.NoError(t, err)
+	require.Equal(t, "job-id", job.ID)
+}
+
+func TestApp_ImportTrelloAsync(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	export := `{"name": "Trello board", "lists": [{"id": "list", "name": "To Do"}], "cards": [{"id": "card", "name": "Card", "idList": "list"}]}`
+
+	t.Run("enqueues the import with the uploaded file", func(t *testing.T) {
+		var filePath string
+		th.FilesBackend.On("WriteFile", mock.Anything, mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+			filePath = args.String(1)
+		}).Return(int64(len(export)), nil).Once()
+		th.Store.EXPECT().CreateJob(gomock.Any()).DoAndReturn(func(job *model.Job) (*model.Job, error) {
+			require.Equal(t, jobTypeImportTrello, job.Type)
+			require.Equal(t, filePath, job.Data["file"])
+			require.Equal(t, "team-id", job.Data["teamId"])
+			return job, nil
+		})
+
+		_, err := th.App.ImportTrelloAsync(bytes.NewReader([]byte(export)), "team-id", "user-id")
+		require.NoError(t, err)
+	})
+
+	t.Run("removes the uploaded file if the job cannot be created", func(t *testing.T) {
+		th.FilesBackend.On("WriteFile", mock.Anything, mock.AnythingOfType("string")).Return(int64(len(export)), nil).Once()
+		th.FilesBackend.On("RemoveFile", mock.AnythingOfType("string")).Return(nil).Once()
+		th.Store.EXPECT().CreateJob(gomock.Any()).Return(nil, errors.New("db error"))
+
+		_, err := th.App.ImportTrelloAsync(bytes.NewReader([]byte(export)), "team-id", "user-id")
+		require.Error(t, err)
+	})
+
+	t.Run("runs the import and removes the file", func(t *testing.T) {
+		job := &model.Job{
+			ID:          "job-id",
+			Type:        jobTypeImportTrello,
+			Data:        map[string]interface{}{"file": "jobs/upload", "teamId": "team-id"},
+			CreatedBy:   "user-id",
+			Attempts:    1,
+			MaxAttempts: 1,
+		}
+
+		th.FilesBackend.On("Reader", "jobs/upload").Return(readCloseSeeker{bytes.NewReader([]byte(export))}, nil).Once()
+		th.FilesBackend.On("RemoveFile", "jobs/upload").Return(nil).Once()
+		th.Store.EXPECT().CreateBoardsAndBlocksWithAdmin(gomock.AssignableToTypeOf(&model.BoardsAndBlocks{}), "user-id").DoAndReturn(
+			func(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+				require.Equal(t, "team-id", bab.Boards[0].TeamID)
+				return bab, []*model.BoardMember{}, nil
+			})
+		th.Store.EXPECT().GetMembersForBoard(gomock.Any()).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		result, err := th.App.runImportTrelloJob(context.Background(), job, func(int) {})
+		require.NoError(t, err)
+		require.NotEmpty(t, result["boardId"])
+		th.FilesBackend.AssertExpectations(t)
+	})
+}
+
+func TestApp_GetJobFileReader(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("job without file", func(t *testing.T) {
+		_, err := th.App.GetJobFileReader(&model.Job{Status: model.JobStatusSuccess})
+		require.ErrorIs(t, err, ErrJobHasNoFile)
+	})
+
+	t.Run("job not done", func(t *testing.T) {
+		job := &model.Job{
+			Status: model.JobStatusRunning,
+			Result: map[string]interface{}{"file": "jobs/job-id/export.boardarchive"},
+		}
+		_, err := th.App.GetJobFileReader(job)
+		require.ErrorIs(t, err, ErrJobHasNoFile)
+	})
+
+	t.Run("exported archive", func(t *testing.T) {
+		job := &model.Job{
+			Status: model.JobStatusSuccess,
+			Result: map[string]interface{}{"file": "jobs/job-id/export.boardarchive"},
+		}
+		th.FilesBackend.On("Reader", "jobs/job-id/export.boardarchive").Return(readCloseSeeker{bytes.NewReader(nil)}, nil).Once()
+
+		file, err := th.App.GetJobFileReader(job)
+		require.NoError(t, err)
+		require.NotNil(t, file)
+	})
+}
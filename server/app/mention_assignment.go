@@ -0,0 +1,114 @@
+package app
+
+import (
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// assignMentionedUsers makes the board members newly @mentioned in the
+// description of a card its assignees, on the boards with the
+// assignMentioned option. oldBlock is nil for new blocks. It is called
+// after text blocks are changed, and failures are logged as the change
+// itself has already been saved.
+func (a *App) assignMentionedUsers(block, oldBlock *model.Block, modifiedByID string) {
+	if block.Type != model.TypeText || block.ParentID == "" || block.ParentID == block.BoardID {
+		return
+	}
+
+	mentions := model.ExtractMentions(block.Title)
+	if oldBlock != nil {
+		for username := range model.ExtractMentions(oldBlock.Title) {
+			delete(mentions, username)
+		}
+	}
+	if len(mentions) == 0 {
+		return
+	}
+
+	board, err := a.store.GetBoard(block.BoardID)
+	if err != nil {
+		a.logger.Error("cannot get board to assign mentioned users", mlog.String("boardID", block.BoardID), mlog.Err(err))
+		return
+	}
+	if !model.AssignsMentioned(board) {
+		return
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return
+	}
+
+	usernames := make([]string, 0, len(mentions))
+	for username := range mentions {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	for _, username := range usernames {
+		if err := a.assignMentionedUser(board, properties, block.ParentID, username, modifiedByID); err != nil {
+			a.logger.Error("cannot assign mentioned user",
+				mlog.String("cardID", block.ParentID),
+				mlog.String("username", username),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// assignMentionedUser sets the user in the first empty assignee property
+// of the card, if the user is a member of the board and isn't assigned to
+// the card yet.
+func (a *App) assignMentionedUser(board *model.Board, properties []model.CardProperty, cardID, username, modifiedByID string) error {
+	user, err := a.store.GetUserByUsername(username)
+	if model.IsErrNotFound(err) || user == nil {
+		// not every @text is a username
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if member, _ := a.store.GetMemberForBoard(board.ID, user.ID); member == nil {
+		return nil
+	}
+
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		if model.IsErrNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if card == nil || card.Type != model.TypeCard {
+		return nil
+	}
+
+	values, ok := model.AssignMentionedUser(properties, card, user.ID)
+	if !ok {
+		return nil
+	}
+
+	patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{"properties": values}}
+	if err = a.store.PatchBlock(cardID, patch, modifiedByID); err != nil {
+		return err
+	}
+
+	updatedCard, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return err
+	}
+	a.updateSearchIndex(searchIndexChange{Blocks: []model.Block{*updatedCard}})
+
+	a.blockChangeNotifier.Enqueue(func() error {
+		a.wsAdapter.BroadcastBlockChange(board.TeamID, *updatedCard)
+		a.webhook.NotifyBlockChange(board.TeamID, model.WebhookActionUpdate, *updatedCard)
+		a.notifyBlockChanged(notify.Update, updatedCard, card, modifiedByID)
+		return nil
+	})
+	return nil
+}
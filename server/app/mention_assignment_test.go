@@ -0,0 +1,74 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignMentionedUsers(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	board := &model.Board{
+		ID:         testBoardID,
+		Properties: map[string]interface{}{model.BoardPropertyAssignMentioned: true},
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "assignee": true},
+		},
+	}
+	card := &model.Block{ID: "card-1", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard}
+	text := &model.Block{ID: "text-1", BoardID: testBoardID, ParentID: "card-1", Type: model.TypeText, Title: "@bob and @alice, please have a look"}
+
+	th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	t.Run("assigns the first mentioned member", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetUserByUsername("alice").Return(&model.User{ID: "alice-id", Username: "alice"}, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "alice-id").Return(&model.BoardMember{UserID: "alice-id"}, nil)
+		th.Store.EXPECT().GetBlock("card-1").Return(card, nil)
+		th.Store.EXPECT().PatchBlock("card-1", gomock.Any(), "user-id").DoAndReturn(
+			func(blockID string, patch *model.BlockPatch, userID string) error {
+				require.Equal(t, map[string]interface{}{"owner": "alice-id"}, patch.UpdatedFields["properties"])
+				return nil
+			},
+		)
+		assigned := &model.Block{ID: "card-1", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"owner": "alice-id"}}}
+		th.Store.EXPECT().GetBlock("card-1").Return(assigned, nil).Times(2)
+		// bob is mentioned too, but the card has no empty assignee property left
+		th.Store.EXPECT().GetUserByUsername("bob").Return(&model.User{ID: "bob-id", Username: "bob"}, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "bob-id").Return(&model.BoardMember{UserID: "bob-id"}, nil)
+
+		th.App.assignMentionedUsers(text, nil, "user-id")
+	})
+
+	t.Run("only new mentions", func(t *testing.T) {
+		old := *text
+		th.App.assignMentionedUsers(text, &old, "user-id")
+	})
+
+	t.Run("non members and unknown usernames", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetUserByUsername("alice").Return(nil, nil)
+		th.Store.EXPECT().GetUserByUsername("bob").Return(&model.User{ID: "bob-id", Username: "bob"}, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "bob-id").Return(nil, model.NewErrNotFound("bob-id"))
+
+		th.App.assignMentionedUsers(text, nil, "user-id")
+	})
+
+	t.Run("boards without the option", func(t *testing.T) {
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, CardProperties: board.CardProperties}, nil)
+
+		th.App.assignMentionedUsers(text, nil, "user-id")
+	})
+
+	t.Run("only card descriptions", func(t *testing.T) {
+		comment := *text
+		comment.Type = model.TypeComment
+		th.App.assignMentionedUsers(&comment, nil, "user-id")
+	})
+}
@@ -0,0 +1,14 @@
+package app
+
+import "github.com/mattermost/focalboard/server/model"
+
+// GetUnreadMentions returns the mentions the user didn't read, oldest first.
+func (a *App) GetUnreadMentions(userID string) ([]*model.Mention, error) {
+	return a.store.GetUnreadMentionsForUser(userID)
+}
+
+// MarkMentionsRead marks mentions of the user as read, or all of them if
+// mentionIDs is empty.
+func (a *App) MarkMentionsRead(userID string, mentionIDs []string) error {
+	return a.store.MarkMentionsRead(userID, mentionIDs)
+}
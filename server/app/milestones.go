@@ -0,0 +1,151 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetMilestones returns the milestones of a team, by due date.
+func (a *App) GetMilestones(teamID string) ([]*model.Milestone, error) {
+	return a.store.GetMilestonesForTeam(teamID)
+}
+
+// CreateMilestone adds a milestone to the team.
+func (a *App) CreateMilestone(teamID string, milestone *model.Milestone, userID string) (*model.Milestone, error) {
+	milestone.ID = ""
+	milestone.TeamID = teamID
+	milestone.CreatedBy = userID
+	if err := milestone.IsValid(); err != nil {
+		return nil, err
+	}
+	return a.store.CreateMilestone(milestone)
+}
+
+// UpdateMilestone replaces the title, the description and the due date
+// of a milestone of the team.
+func (a *App) UpdateMilestone(teamID string, milestone *model.Milestone) (*model.Milestone, error) {
+	existing, err := a.getTeamMilestone(teamID, milestone.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Title = milestone.Title
+	existing.Description = milestone.Description
+	existing.DueDate = milestone.DueDate
+	if err := existing.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if err := a.store.UpdateMilestone(existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// DeleteMilestone removes a milestone of the team. The cards still
+// pointing to it are left out of the progress summaries.
+func (a *App) DeleteMilestone(teamID, milestoneID string) error {
+	if _, err := a.getTeamMilestone(teamID, milestoneID); err != nil {
+		return err
+	}
+	return a.store.DeleteMilestone(milestoneID)
+}
+
+// SetCardMilestone attaches a card to a milestone of the team of its
+// board, or detaches it if milestoneID is empty.
+func (a *App) SetCardMilestone(boardID, cardID, milestoneID, userID string) (*model.Block, error) {
+	card, err := a.store.GetBlock(cardID)
+	if err != nil {
+		return nil, err
+	}
+	if card == nil || card.BoardID != boardID || card.Type != model.TypeCard {
+		return nil, model.NewErrNotFound(cardID)
+	}
+
+	patch := &model.BlockPatch{}
+	if milestoneID == "" {
+		patch.DeletedFields = []string{model.CardFieldMilestone}
+	} else {
+		board, err := a.store.GetBoard(boardID)
+		if err != nil {
+			return nil, err
+		}
+		milestone, err := a.store.GetMilestone(milestoneID)
+		if model.IsErrNotFound(err) || (err == nil && milestone.TeamID != board.TeamID) {
+			return nil, model.NewErrInvalidMilestone(fmt.Sprintf("milestone %q doesn't belong to the team of the board", milestoneID))
+		}
+		if err != nil {
+			return nil, err
+		}
+		patch.UpdatedFields = map[string]interface{}{model.CardFieldMilestone: milestoneID}
+	}
+
+	if err := a.PatchBlock(cardID, patch, userID); err != nil {
+		return nil, err
+	}
+	return a.store.GetBlock(cardID)
+}
+
+// GetMilestonesProgress summarizes the progress of every milestone of the
+// team from the cards attached to it on the boards the user can see. A
+// card is done when it has the done status of its board.
+func (a *App) GetMilestonesProgress(teamID, userID string, now time.Time) ([]*model.MilestoneProgress, error) {
+	milestones, err := a.store.GetMilestonesForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	progressByID := make(map[string]*model.MilestoneProgress, len(milestones))
+	progress := make([]*model.MilestoneProgress, 0, len(milestones))
+	for _, milestone := range milestones {
+		p := &model.MilestoneProgress{Milestone: milestone, Boards: []*model.MilestoneBoardProgress{}}
+		progressByID[milestone.ID] = p
+		progress = append(progress, p)
+	}
+	if len(milestones) == 0 {
+		return progress, nil
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, board := range boards {
+		cards, err := a.store.GetBlocksWithType(board.ID, model.TypeCard)
+		if err != nil {
+			return nil, err
+		}
+		cards, err = a.FilterRestrictedBlocks(board, cards, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		doneStatus := model.DoneStatusFromBoard(board)
+		for i := range cards {
+			p, ok := progressByID[model.CardMilestone(&cards[i])]
+			if !ok || model.IsCardTemplate(&cards[i]) {
+				continue
+			}
+			p.AddCard(board.ID, doneStatus.IsCardDone(&cards[i]))
+		}
+	}
+
+	for _, p := range progress {
+		p.UpdateRisk(now)
+	}
+	return progress, nil
+}
+
+func (a *App) getTeamMilestone(teamID, milestoneID string) (*model.Milestone, error) {
+	milestone, err := a.store.GetMilestone(milestoneID)
+	if err != nil {
+		return nil, err
+	}
+	if milestone.TeamID != teamID {
+		return nil, model.NewErrNotFound(milestoneID)
+	}
+	return milestone, nil
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateMilestone(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("milestone of another team", func(t *testing.T) {
+		existing := &model.Milestone{ID: "milestone-id", TeamID: "team-id", Title: "Beta"}
+		th.Store.EXPECT().GetMilestone("milestone-id").Return(existing, nil)
+
+		_, err := th.App.UpdateMilestone("other-team-id", &model.Milestone{ID: "milestone-id", Title: "GA"})
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid title", func(t *testing.T) {
+		existing := &model.Milestone{ID: "milestone-id", TeamID: "team-id", Title: "Beta"}
+		th.Store.EXPECT().GetMilestone("milestone-id").Return(existing, nil)
+
+		_, err := th.App.UpdateMilestone("team-id", &model.Milestone{ID: "milestone-id", Title: ""})
+		require.True(t, model.IsErrInvalidMilestone(err))
+	})
+}
+
+func TestSetCardMilestone(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	card := &model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, Fields: map[string]interface{}{}}
+
+	t.Run("milestone of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+		th.Store.EXPECT().GetMilestone("milestone-id").Return(&model.Milestone{ID: "milestone-id", TeamID: "other-team-id"}, nil)
+
+		_, err := th.App.SetCardMilestone(testBoardID, "card-id", "milestone-id", "user-id")
+		require.True(t, model.IsErrInvalidMilestone(err))
+	})
+
+	t.Run("unknown milestone", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+		th.Store.EXPECT().GetMilestone("unknown").Return(nil, model.NewErrNotFound("unknown"))
+
+		_, err := th.App.SetCardMilestone(testBoardID, "card-id", "unknown", "user-id")
+		require.True(t, model.IsErrInvalidMilestone(err))
+	})
+
+	t.Run("card from another board", func(t *testing.T) {
+		th.Store.EXPECT().GetBlock("card-id").Return(card, nil)
+
+		_, err := th.App.SetCardMilestone("other-board", "card-id", "milestone-id", "user-id")
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func TestGetMilestonesProgress(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	dueSoon := now.Add(48*time.Hour).UnixNano() / int64(time.Millisecond)
+	milestones := []*model.Milestone{
+		{ID: "beta", TeamID: "team-id", Title: "Beta", DueDate: dueSoon},
+		{ID: "ga", TeamID: "team-id", Title: "GA"},
+	}
+	boards := []*model.Board{
+		{ID: "board-1", TeamID: "team-id", Properties: map[string]interface{}{
+			model.BoardPropertyDoneStatus: map[string]interface{}{"propertyId": "status", "optionId": "done"},
+		}},
+		{ID: "board-2", TeamID: "team-id"},
+	}
+	card := func(id, milestoneID, status string) model.Block {
+		return model.Block{ID: id, Type: model.TypeCard, Fields: map[string]interface{}{
+			model.CardFieldMilestone: milestoneID,
+			"properties":             map[string]interface{}{"status": status},
+		}}
+	}
+	admin := &model.BoardMember{UserID: "user-id", SchemeAdmin: true}
+
+	th.Store.EXPECT().GetMilestonesForTeam("team-id").Return(milestones, nil)
+	th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return(boards, nil)
+	th.Store.EXPECT().GetBlocksWithType("board-1", model.TypeCard).Return([]model.Block{
+		card("card-1", "beta", "done"),
+		card("card-2", "beta", "todo"),
+		card("card-3", "", "done"),
+	}, nil)
+	th.Store.EXPECT().GetMemberForBoard("board-1", "user-id").Return(admin, nil)
+	th.Store.EXPECT().GetBlocksWithType("board-2", model.TypeCard).Return([]model.Block{
+		card("card-4", "beta", "done"),
+		card("card-5", "deleted-milestone", "done"),
+	}, nil)
+	th.Store.EXPECT().GetMemberForBoard("board-2", "user-id").Return(admin, nil)
+
+	progress, err := th.App.GetMilestonesProgress("team-id", "user-id", now)
+	require.NoError(t, err)
+	require.Len(t, progress, 2)
+
+	beta := progress[0]
+	require.Equal(t, "beta", beta.Milestone.ID)
+	require.Equal(t, 3, beta.Total)
+	// board-2 has no done status, so none of its cards are done
+	require.Equal(t, 1, beta.Done)
+	require.True(t, beta.AtRisk)
+	require.False(t, beta.Overdue)
+	require.Len(t, beta.Boards, 2)
+
+	ga := progress[1]
+	require.Equal(t, 0, ga.Total)
+	require.False(t, ga.AtRisk)
+	require.Empty(t, ga.Boards)
+}
@@ -0,0 +1,42 @@
+package app
+
+import "github.com/mattermost/focalboard/server/model"
+
+// GetUserNotificationSettings returns the board mute and quiet hours
+// settings of the user.
+func (a *App) GetUserNotificationSettings(userID string) (*model.UserNotificationSettings, error) {
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.NotificationSettingsFromUser(user), nil
+}
+
+// UpdateUserNotificationSettings replaces the notification settings of the user.
+func (a *App) UpdateUserNotificationSettings(userID string, settings *model.UserNotificationSettings) (*model.UserNotificationSettings, error) {
+	if settings.MutedBoards == nil {
+		settings.MutedBoards = []string{}
+	}
+	if err := settings.IsValid(); err != nil {
+		return nil, err
+	}
+
+	patch, err := settings.ToUserPropPatch()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.store.PatchUserProps(userID, *patch); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SetBoardMuted mutes or unmutes the notifications of a board for the user.
+func (a *App) SetBoardMuted(userID, boardID string, muted bool) (*model.UserNotificationSettings, error) {
+	settings, err := a.GetUserNotificationSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	settings.SetBoardMuted(boardID, muted)
+	return a.UpdateUserNotificationSettings(userID, settings)
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetBoardMuted(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("mute a board", func(t *testing.T) {
+		user := &model.User{
+			ID: "user-id-1",
+			Props: map[string]interface{}{
+				model.UserPropNotificationSettings: `{"mutedBoards":["board-id-2"]}`,
+			},
+		}
+		th.Store.EXPECT().GetUserByID("user-id-1").Return(user, nil)
+		th.Store.EXPECT().PatchUserProps("user-id-1", model.UserPropPatch{
+			UpdatedFields: map[string]string{
+				model.UserPropNotificationSettings: `{"mutedBoards":["board-id-2","` + testBoardID + `"],"mentionsBreakThrough":false}`,
+			},
+		}).Return(nil)
+
+		settings, err := th.App.SetBoardMuted("user-id-1", testBoardID, true)
+		require.NoError(t, err)
+		require.True(t, settings.IsBoardMuted(testBoardID))
+	})
+
+	t.Run("invalid quiet hours are not saved", func(t *testing.T) {
+		settings := &model.UserNotificationSettings{
+			QuietHours: &model.QuietHours{Start: "25:00", End: "08:00"},
+		}
+		_, err := th.App.UpdateUserNotificationSettings("user-id-1", settings)
+		require.True(t, model.IsErrInvalidNotificationSettings(err))
+	})
+}
@@ -72,7 +72,13 @@ func (a *App) createWelcomeBoard(userID, teamID string) (string, error) {
 		return "", err
 	}
 
-	bab, _, err := a.DuplicateBoard(onboardingBoardID, userID, teamID, false)
+	return a.createPrivateBoardFromTemplate(onboardingBoardID, userID, teamID)
+}
+
+// createPrivateBoardFromTemplate copies the template into a private board
+// of the user in the team, and returns the id of the board.
+func (a *App) createPrivateBoardFromTemplate(templateID, userID, teamID string) (string, error) {
+	bab, _, err := a.DuplicateBoard(templateID, userID, teamID, false)
 	if err != nil {
 		return "", err
 	}
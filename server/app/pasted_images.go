@@ -0,0 +1,69 @@
+package app
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// PasteImage saves an image pasted into a card and adds it to the end of
+// the card's content, in a single operation. The image gets a generated
+// filename with the extension of its type, and is checked against the
+// upload restrictions of the team and the attachment storage limit like
+// any other upload.
+func (a *App) PasteImage(board *model.Board, cardID string, data []byte, userID string) (*model.Block, error) {
+	card, err := a.getVisibleCardBlock(board, cardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filename, err := model.PastedImageFilename(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	restrictions, err := a.GetTeamUploadRestrictions(board.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	if err = restrictions.Restrictions.Check(filename, int64(len(data))); err != nil {
+		return nil, err
+	}
+	if err = a.CheckAttachmentStorageLimit(int64(len(data))); err != nil {
+		return nil, err
+	}
+
+	fileID, err := a.SaveFile(bytes.NewReader(data), board.TeamID, board.ID, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	now := utils.GetMillis()
+	block := model.Block{
+		ID:         utils.NewID(utils.IDTypeBlock),
+		BoardID:    board.ID,
+		ParentID:   card.ID,
+		CreatedBy:  userID,
+		ModifiedBy: userID,
+		Schema:     1,
+		Type:       model.TypeImage,
+		Title:      filename,
+		Fields:     map[string]interface{}{"fileId": fileID},
+		CreateAt:   now,
+		UpdateAt:   now,
+	}
+	if err = a.InsertBlock(block, userID); err != nil {
+		return nil, err
+	}
+
+	contentOrder, _ := card.Fields["contentOrder"].([]interface{})
+	contentOrder = append(contentOrder, block.ID)
+	patch := &model.BlockPatch{UpdatedFields: map[string]interface{}{"contentOrder": contentOrder}}
+	if err = a.PatchBlock(card.ID, patch, userID); err != nil {
+		return nil, err
+	}
+
+	return a.store.GetBlock(block.ID)
+}
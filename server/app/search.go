@@ -0,0 +1,211 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// Search returns the page of the boards, cards, comments and attachments
+// of the team that match the search terms for the user, most relevant
+// first. Boards match when they are open or the user is a member, and
+// their content only when the user is a member and can see it. The
+// filters of the search narrow the results, and without terms every
+// result that the filters select is returned, most recently updated first.
+func (a *App) Search(teamID, userID string, opts model.SearchOptions) (*model.SearchResults, error) {
+	terms := model.SearchTerms(opts.Terms)
+	if len(terms) == 0 && opts.Filters.IsEmpty() {
+		return model.RankSearchResults(nil, opts), nil
+	}
+
+	assigneeIDs, ok, err := a.searchAssigneeIDs(opts.Filters.Assignees, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return model.RankSearchResults(nil, opts), nil
+	}
+
+	boards, err := a.store.GetBoardsForUserAndTeam(userID, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := a.store.GetMembersForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberBoardIDs := map[string]bool{}
+	for _, member := range members {
+		memberBoardIDs[member.BoardID] = true
+	}
+
+	results := []*model.SearchResult{}
+	boardsByID := map[string]*model.Board{}
+	cardFilters := map[string]*model.CardSearchFilter{}
+	boardIDs := []string{}
+	for _, board := range boards {
+		// boards have no card properties to filter by
+		boardMatches := !opts.Filters.HasCardFilters() && opts.Filters.MatchesUpdateAt(board.UpdateAt)
+		if score := model.ScoreSearchMatch(board.Title, terms); score > 0 && boardMatches {
+			results = append(results, &model.SearchResult{
+				Type:       model.SearchResultTypeBoard,
+				ID:         board.ID,
+				BoardID:    board.ID,
+				BoardTitle: board.Title,
+				Title:      board.Title,
+				Score:      score,
+				UpdateAt:   board.UpdateAt,
+			})
+		}
+		if !memberBoardIDs[board.ID] {
+			continue
+		}
+		if opts.Filters.HasCardFilters() {
+			var filter *model.CardSearchFilter
+			filter, err = a.cardSearchFilter(board, opts.Filters, assigneeIDs)
+			if err != nil {
+				return nil, err
+			}
+			if filter == nil {
+				continue
+			}
+			cardFilters[board.ID] = filter
+		}
+		boardsByID[board.ID] = board
+		boardIDs = append(boardIDs, board.ID)
+	}
+
+	blocks, err := a.store.SearchBlocksForBoards(boardIDs, opts.Terms, model.SearchableBlockTypes, opts.Filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// attachments also match by the text of their files
+	attachments, fileTexts, err := a.searchFileContents(boardIDs, opts.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksByBoard := map[string][]model.Block{}
+	found := map[string]bool{}
+	for _, block := range append(blocks, attachments...) {
+		if found[block.ID] {
+			continue
+		}
+		found[block.ID] = true
+		blocksByBoard[block.BoardID] = append(blocksByBoard[block.BoardID], block)
+	}
+
+	for boardID, boardBlocks := range blocksByBoard {
+		board := boardsByID[boardID]
+		visible, err := a.FilterRestrictedBlocks(board, boardBlocks, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		var cardsByID map[string]*model.Block
+		filter := cardFilters[board.ID]
+		if filter != nil {
+			if cardsByID, err = a.cardsByID(board.ID); err != nil {
+				return nil, err
+			}
+		}
+
+		for i := range visible {
+			block := &visible[i]
+			resultType, ok := model.SearchResultTypeForBlock(block)
+			if !ok {
+				continue
+			}
+			if !opts.Filters.MatchesUpdateAt(block.UpdateAt) {
+				continue
+			}
+			if filter != nil {
+				// comments and attachments match by the properties of
+				// their card
+				card := cardsByID[block.ID]
+				if resultType != model.SearchResultTypeCard {
+					card = cardsByID[block.ParentID]
+				}
+				if card == nil || !filter.Matches(card) {
+					continue
+				}
+			}
+
+			score := model.ScoreSearchMatch(block.Title, terms)
+			if contentScore := model.ScoreSearchMatch(fileTexts[block.ID], terms); contentScore > score {
+				score = contentScore
+			}
+			if len(terms) == 0 {
+				score = 1
+			}
+			if score == 0 {
+				continue
+			}
+
+			result := &model.SearchResult{
+				Type:       resultType,
+				ID:         block.ID,
+				BoardID:    board.ID,
+				BoardTitle: board.Title,
+				Title:      block.Title,
+				Score:      score,
+				UpdateAt:   block.UpdateAt,
+			}
+			if resultType != model.SearchResultTypeCard {
+				result.ParentID = block.ParentID
+			}
+			results = append(results, result)
+		}
+	}
+
+	return model.RankSearchResults(results, opts), nil
+}
+
+// searchAssigneeIDs returns the ids of the users of the assignee filters of
+// a search, in the same order, and false if one of them doesn't exist.
+func (a *App) searchAssigneeIDs(usernames []string, userID string) ([]string, bool, error) {
+	ids := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		if username == model.SearchAssigneeMe {
+			ids = append(ids, userID)
+			continue
+		}
+		user, err := a.store.GetUserByUsername(username)
+		if model.IsErrNotFound(err) || (err == nil && user == nil) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		ids = append(ids, user.ID)
+	}
+	return ids, true, nil
+}
+
+// cardSearchFilter resolves the card filters of a search against the
+// properties of the board, and returns nil if no card of the board can
+// match them.
+func (a *App) cardSearchFilter(board *model.Board, filters model.SearchFilters, assigneeIDs []string) (*model.CardSearchFilter, error) {
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+	filter, ok := model.NewCardSearchFilter(properties, filters, assigneeIDs)
+	if !ok {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+// cardsByID returns the cards of the board by their ids.
+func (a *App) cardsByID(boardID string) (map[string]*model.Block, error) {
+	cards, err := a.store.GetBlocksWithType(boardID, model.TypeCard)
+	if err != nil {
+		return nil, err
+	}
+	cardsByID := make(map[string]*model.Block, len(cards))
+	for i := range cards {
+		cardsByID[cards[i].ID] = &cards[i]
+	}
+	return cardsByID, nil
+}
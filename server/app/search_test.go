@@ -0,0 +1,136 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	boards := []*model.Board{
+		{ID: "board-1", TeamID: "team-id", Title: "Release board", UpdateAt: 1},
+		{ID: "board-2", TeamID: "team-id", Title: "Roadmap", Type: model.BoardTypeOpen, UpdateAt: 2},
+	}
+	blocks := []model.Block{
+		{ID: "card-1", BoardID: "board-1", Type: model.TypeCard, Title: "Release", UpdateAt: 3},
+		{ID: "comment-1", BoardID: "board-1", ParentID: "card-1", Type: model.TypeComment, Title: "After the release", UpdateAt: 4},
+		{ID: "card-template", BoardID: "board-1", Type: model.TypeCard, Title: "Release", Fields: map[string]interface{}{"isTemplate": true}},
+	}
+
+	t.Run("ranked results", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return(boards, nil)
+		th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{{BoardID: "board-1", UserID: "user-id"}}, nil)
+		th.Store.EXPECT().SearchBlocksForBoards([]string{"board-1"}, "release", model.SearchableBlockTypes, model.SearchFilters{}).Return(blocks, nil)
+		th.Store.EXPECT().SearchFileContents([]string{"board-1"}, "release").Return([]*model.FileContent{}, nil)
+		th.Store.EXPECT().GetMemberForBoard("board-1", "user-id").Return(&model.BoardMember{SchemeAdmin: true}, nil)
+
+		results, err := th.App.Search("team-id", "user-id", model.SearchOptions{Terms: "release"})
+		require.NoError(t, err)
+		require.Equal(t, 3, results.Total)
+		require.Len(t, results.Results, 3)
+		require.Equal(t, "card-1", results.Results[0].ID)
+		require.Equal(t, "board-1", results.Results[1].ID)
+		require.Equal(t, "comment-1", results.Results[2].ID)
+		require.Equal(t, "card-1", results.Results[2].ParentID)
+		require.Equal(t, "Release board", results.Results[2].BoardTitle)
+		require.Equal(t, 1, results.Facets[model.SearchResultTypeComment])
+	})
+
+	t.Run("filtered by type", func(t *testing.T) {
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return(boards, nil)
+		th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{}, nil)
+		th.Store.EXPECT().SearchBlocksForBoards([]string{}, "road", model.SearchableBlockTypes, model.SearchFilters{}).Return([]model.Block{}, nil)
+		th.Store.EXPECT().SearchFileContents([]string{}, "road").Return([]*model.FileContent{}, nil)
+
+		results, err := th.App.Search("team-id", "user-id", model.SearchOptions{
+			Terms: "road",
+			Types: []model.SearchResultType{model.SearchResultTypeCard},
+		})
+		require.NoError(t, err)
+		require.Zero(t, results.Total)
+		require.Empty(t, results.Results)
+		require.Equal(t, 1, results.Facets[model.SearchResultTypeBoard])
+	})
+
+	t.Run("attachments by the text of their files", func(t *testing.T) {
+		attachment := model.Block{ID: "image-1", BoardID: "board-1", ParentID: "card-1", Type: model.TypeImage, Title: "scan.pdf", Fields: map[string]interface{}{"fileId": "7scan.pdf"}, UpdateAt: 5}
+		other := model.Block{ID: "image-2", BoardID: "board-1", ParentID: "card-1", Type: model.TypeImage, Fields: map[string]interface{}{"fileId": "7other.pdf"}}
+
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return(boards, nil)
+		th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{{BoardID: "board-1", UserID: "user-id"}}, nil)
+		th.Store.EXPECT().SearchBlocksForBoards([]string{"board-1"}, "payments spec", model.SearchableBlockTypes, model.SearchFilters{}).Return([]model.Block{}, nil)
+		th.Store.EXPECT().SearchFileContents([]string{"board-1"}, "payments spec").Return([]*model.FileContent{
+			{FileID: "7scan.pdf", BoardID: "board-1", Content: "Payments API spec"},
+		}, nil)
+		th.Store.EXPECT().GetBlocksWithType("board-1", model.TypeImage).Return([]model.Block{attachment, other}, nil)
+		th.Store.EXPECT().GetMemberForBoard("board-1", "user-id").Return(&model.BoardMember{SchemeAdmin: true}, nil)
+
+		results, err := th.App.Search("team-id", "user-id", model.SearchOptions{Terms: "payments spec"})
+		require.NoError(t, err)
+		require.Equal(t, 1, results.Total)
+		require.Equal(t, "image-1", results.Results[0].ID)
+		require.Equal(t, model.SearchResultTypeAttachment, results.Results[0].Type)
+		require.Equal(t, "card-1", results.Results[0].ParentID)
+	})
+
+	t.Run("filtered by card properties", func(t *testing.T) {
+		board := &model.Board{
+			ID:     "board-3",
+			TeamID: "team-id",
+			Title:  "Release tracking",
+			CardProperties: []map[string]interface{}{
+				{"id": "status", "name": "Status", "type": model.PropertyTypeSelect, "options": []interface{}{
+					map[string]interface{}{"id": "status-done", "value": "Done"},
+					map[string]interface{}{"id": "status-todo", "value": "To do"},
+				}},
+				{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "assignee": true},
+			},
+		}
+		done := model.Block{ID: "card-done", BoardID: "board-3", Type: model.TypeCard, Title: "Release notes", UpdateAt: 6,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "status-done", "owner": "alice-id"}}}
+		todo := model.Block{ID: "card-todo", BoardID: "board-3", Type: model.TypeCard, Title: "Release party", UpdateAt: 7,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "status-todo", "owner": "alice-id"}}}
+		comment := model.Block{ID: "comment-done", BoardID: "board-3", ParentID: "card-done", Type: model.TypeComment, Title: "the release shipped", UpdateAt: 8}
+		filters := model.SearchFilters{
+			Properties: []model.SearchPropertyFilter{{Name: "status", Value: "done"}},
+			Assignees:  []string{"alice"},
+		}
+
+		th.Store.EXPECT().GetUserByUsername("alice").Return(&model.User{ID: "alice-id"}, nil)
+		th.Store.EXPECT().GetBoardsForUserAndTeam("user-id", "team-id").Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetMembersForUser("user-id").Return([]*model.BoardMember{{BoardID: "board-3", UserID: "user-id"}}, nil)
+		th.Store.EXPECT().SearchBlocksForBoards([]string{"board-3"}, "release", model.SearchableBlockTypes, filters).Return([]model.Block{done, todo, comment}, nil)
+		th.Store.EXPECT().SearchFileContents([]string{"board-3"}, "release").Return([]*model.FileContent{}, nil)
+		th.Store.EXPECT().GetMemberForBoard("board-3", "user-id").Return(&model.BoardMember{SchemeAdmin: true}, nil)
+		th.Store.EXPECT().GetBlocksWithType("board-3", model.TypeCard).Return([]model.Block{done, todo}, nil)
+
+		results, err := th.App.Search("team-id", "user-id", model.SearchOptions{Terms: "release", Filters: filters})
+		require.NoError(t, err)
+		require.Equal(t, 2, results.Total)
+		require.Equal(t, "card-done", results.Results[0].ID)
+		require.Equal(t, "comment-done", results.Results[1].ID)
+		require.Zero(t, results.Facets[model.SearchResultTypeBoard])
+	})
+
+	t.Run("unknown assignee", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByUsername("nobody").Return(nil, nil)
+
+		results, err := th.App.Search("team-id", "user-id", model.SearchOptions{
+			Filters: model.SearchFilters{Assignees: []string{"nobody"}},
+		})
+		require.NoError(t, err)
+		require.Zero(t, results.Total)
+	})
+
+	t.Run("no terms", func(t *testing.T) {
+		results, err := th.App.Search("team-id", "user-id", model.SearchOptions{Terms: "  "})
+		require.NoError(t, err)
+		require.Zero(t, results.Total)
+		require.Empty(t, results.Results)
+	})
+}
@@ -18,3 +18,10 @@ func (a *App) GetSharing(boardID string) (*model.Sharing, error) {
 func (a *App) UpsertSharing(sharing model.Sharing) error {
 	return a.store.UpsertSharing(sharing)
 }
+
+// RevokeSharing disables the sharing of all the boards and clears their
+// tokens, so that the existing share links stop working. It returns the
+// number of boards whose sharing was revoked.
+func (a *App) RevokeSharing() (int64, error) {
+	return a.store.RevokeAllSharing(model.SystemUserID)
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+const captchaVerifyTimeout = 10 * time.Second
+
+// SubmitCard creates the card submitted by an anonymous visitor in the
+// intake group of the board.
+func (a *App) SubmitCard(boardID string, submission *model.CardSubmission) (*model.Block, error) {
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := model.GetBoardSubmissionSettings(board)
+	if settings == nil || !settings.Enabled {
+		return nil, model.ErrSubmissionsDisabled
+	}
+
+	if err = submission.IsValid(); err != nil {
+		return nil, err
+	}
+
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, err
+	}
+	if err = settings.IsValid(properties); err != nil {
+		return nil, err
+	}
+
+	blocks, err := a.InsertBlocks(submission.ToBlocks(board.ID, settings), model.SystemUserID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &blocks[0], nil
+}
+
+// IsCaptchaRequired returns true if the server is configured to verify
+// captcha responses.
+func (a *App) IsCaptchaRequired() bool {
+	return a.config.CaptchaVerifyURL != "" && a.config.CaptchaSecret != ""
+}
+
+// VerifyCaptcha checks a captcha response against the configured
+// verification endpoint, which follows the siteverify protocol used by
+// reCAPTCHA and hCaptcha.
+func (a *App) VerifyCaptcha(response, remoteIP string) error {
+	if response == "" {
+		return model.ErrInvalidCaptcha
+	}
+
+	client := &http.Client{Timeout: captchaVerifyTimeout}
+	resp, err := client.PostForm(a.config.CaptchaVerifyURL, url.Values{
+		"secret":   {a.config.CaptchaSecret},
+		"response": {response},
+		"remoteip": {remoteIP},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		return model.ErrInvalidCaptcha
+	}
+	return nil
+}
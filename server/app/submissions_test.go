@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	cardProperties := []map[string]interface{}{
+		{
+			"id":   "status",
+			"name": "Status",
+			"type": model.PropertyTypeSelect,
+			"options": []interface{}{
+				map[string]interface{}{"id": "new", "value": "New", "color": model.PropertyColorDefault},
+			},
+		},
+	}
+
+	t.Run("submissions disabled", func(t *testing.T) {
+		board := &model.Board{ID: testBoardID, CardProperties: cardProperties}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+
+		_, err := th.App.SubmitCard(testBoardID, &model.CardSubmission{Title: "Feedback"})
+		require.ErrorIs(t, err, model.ErrSubmissionsDisabled)
+	})
+
+	t.Run("card created in the intake group", func(t *testing.T) {
+		board := &model.Board{
+			ID:             testBoardID,
+			CardProperties: cardProperties,
+			Properties: map[string]interface{}{
+				model.BoardPropertySubmissions: map[string]interface{}{"enabled": true, "propertyId": "status", "optionId": "new"},
+			},
+		}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil).Times(2)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), model.SystemUserID).Return(nil).Times(2)
+		th.Store.EXPECT().GetMembersForBoard(testBoardID).AnyTimes().Return([]*model.BoardMember{}, nil)
+
+		card, err := th.App.SubmitCard(testBoardID, &model.CardSubmission{Title: "Feedback", Description: "Some details"})
+		require.NoError(t, err)
+		require.EqualValues(t, model.TypeCard, card.Type)
+		require.Equal(t, "new", model.CardPropertyValues(card)["status"])
+	})
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// SyncBoard applies a batch of operations submitted by an offline client
+// and returns the authoritative operations of the board log after the
+// client base revision.
+//
+// An operation conflicts, and isn't applied, if its block was changed
+// after the base revision by another operation of the log or through the
+// other APIs. Operations already in the log, because the client is
+// retrying a batch whose response it didn't get, are reported as applied
+// without applying them again.
+func (a *App) SyncBoard(boardID string, req *model.SyncRequest, userID string) (*model.SyncResponse, error) {
+	if err := req.IsValid(boardID); err != nil {
+		return nil, err
+	}
+
+	var baseTime int64
+	var since []*model.SyncOperation
+	if req.BaseRevision > 0 {
+		operations, err := a.store.GetSyncOperations(boardID, req.BaseRevision-1)
+		if err != nil {
+			return nil, err
+		}
+		if len(operations) == 0 || operations[0].Revision != req.BaseRevision {
+			return nil, model.NewErrInvalidSyncRequest(fmt.Sprintf("unknown base revision %d", req.BaseRevision))
+		}
+		baseTime = operations[0].CreateAt
+		since = operations[1:]
+	}
+
+	requested := make(map[string]bool, len(req.Operations))
+	for _, op := range req.Operations {
+		requested[op.ID] = true
+	}
+	alreadyApplied := map[string]bool{}
+	changedBlocks := map[string]bool{}
+	for _, op := range since {
+		if requested[op.ID] {
+			alreadyApplied[op.ID] = true
+		} else {
+			changedBlocks[op.BlockID] = true
+		}
+	}
+
+	resp := &model.SyncResponse{
+		Applied:   []string{},
+		Conflicts: []model.SyncConflict{},
+	}
+	batchBlocks := map[string]bool{}
+	for _, op := range req.Operations {
+		if alreadyApplied[op.ID] {
+			resp.Applied = append(resp.Applied, op.ID)
+			batchBlocks[op.BlockID] = true
+			continue
+		}
+
+		conflict, err := a.syncConflict(boardID, op, baseTime, changedBlocks, batchBlocks)
+		if err != nil {
+			return nil, err
+		}
+		if conflict == nil {
+			conflict, err = a.applySyncOperation(op, userID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if conflict != nil {
+			resp.Conflicts = append(resp.Conflicts, *conflict)
+			continue
+		}
+
+		op.BoardID = boardID
+		op.UserID = userID
+		if _, err := a.store.InsertSyncOperation(op); err != nil {
+			return nil, err
+		}
+		resp.Applied = append(resp.Applied, op.ID)
+		batchBlocks[op.BlockID] = true
+	}
+
+	operations, err := a.store.GetSyncOperations(boardID, req.BaseRevision)
+	if err != nil {
+		return nil, err
+	}
+	resp.Operations = operations
+	resp.Revision = req.BaseRevision
+	if len(operations) > 0 {
+		resp.Revision = operations[len(operations)-1].Revision
+	}
+
+	if err := a.filterSyncResponse(boardID, resp, userID); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// filterSyncResponse removes the operations on the restricted cards and
+// views the user can't see, and on their content, from the response. The
+// conflicts on these blocks are kept without the block. The revision of
+// the response is left unchanged, so the client doesn't get the hidden
+// operations again.
+func (a *App) filterSyncResponse(boardID string, resp *model.SyncResponse, userID string) error {
+	if a.canSeeRestrictedBlocks(boardID, userID) {
+		return nil
+	}
+
+	board, err := a.store.GetBoard(boardID)
+	if err != nil {
+		return err
+	}
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return err
+	}
+
+	visible := map[string]bool{}
+	var isVisible func(blockID string) (bool, error)
+	isVisible = func(blockID string) (bool, error) {
+		if v, ok := visible[blockID]; ok {
+			return v, nil
+		}
+		// guards against loops in the block hierarchy
+		visible[blockID] = false
+
+		// deleted blocks are checked with their last version
+		history, err := a.store.GetBlockHistory(blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+		if err != nil {
+			return false, err
+		}
+
+		v := true
+		if len(history) > 0 {
+			block := &history[0]
+			switch {
+			case model.IsViewRestricted(block):
+				v = false
+			case block.Type == model.TypeCard:
+				v = model.CanUserSeeCard(properties, block, userID, false)
+			case block.ParentID != "" && block.ParentID != block.BoardID:
+				if v, err = isVisible(block.ParentID); err != nil {
+					return false, err
+				}
+			}
+		}
+		visible[blockID] = v
+		return v, nil
+	}
+
+	operations := make([]*model.SyncOperation, 0, len(resp.Operations))
+	for _, op := range resp.Operations {
+		v, err := isVisible(op.BlockID)
+		if err != nil {
+			return err
+		}
+		if v {
+			operations = append(operations, op)
+		}
+	}
+	resp.Operations = operations
+
+	for i := range resp.Conflicts {
+		if resp.Conflicts[i].Block == nil {
+			continue
+		}
+		v, err := isVisible(resp.Conflicts[i].Block.ID)
+		if err != nil {
+			return err
+		}
+		if !v {
+			resp.Conflicts[i].Block = nil
+		}
+	}
+	return nil
+}
+
+// syncConflict returns the conflict of an operation with the current
+// state of its block, or nil if the operation can be applied.
+func (a *App) syncConflict(boardID string, op *model.SyncOperation, baseTime int64, changedBlocks, batchBlocks map[string]bool) (*model.SyncConflict, error) {
+	block, err := a.store.GetBlock(op.BlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.Type == model.SyncOperationInsert {
+		if block != nil {
+			return &model.SyncConflict{OperationID: op.ID, Reason: "block already exists"}, nil
+		}
+		return nil, nil
+	}
+
+	if block == nil || block.BoardID != boardID {
+		return &model.SyncConflict{OperationID: op.ID, Reason: "block does not exist"}, nil
+	}
+	if batchBlocks[op.BlockID] {
+		return nil, nil
+	}
+	if changedBlocks[op.BlockID] || (baseTime > 0 && block.UpdateAt > baseTime) {
+		return &model.SyncConflict{OperationID: op.ID, Reason: "block was changed after the base revision", Block: block}, nil
+	}
+	return nil, nil
+}
+
+// applySyncOperation applies the operation through the regular block
+// methods, so validation, broadcasts and notifications happen as for any
+// other change. Operations rejected by the validation are returned as
+// conflicts.
+func (a *App) applySyncOperation(op *model.SyncOperation, userID string) (*model.SyncConflict, error) {
+	var err error
+	switch op.Type {
+	case model.SyncOperationInsert:
+		now := utils.GetMillis()
+		if op.Block.CreateAt == 0 {
+			op.Block.CreateAt = now
+		}
+		op.Block.UpdateAt = now
+		op.Block.ModifiedBy = userID
+		_, err = a.InsertBlocks([]model.Block{*op.Block}, userID, true)
+	case model.SyncOperationPatch:
+		err = a.PatchBlock(op.BlockID, op.Patch, userID)
+	case model.SyncOperationDelete:
+		err = a.DeleteBlock(op.BlockID, userID)
+	}
+
+	if err == nil {
+		return nil, nil
+	}
+
+	var errMissing *model.ErrMissingRequiredProperties
+	var errLimit *model.ErrCardLimitReached
+	if model.IsErrInvalidCardProperty(err) || model.IsErrBlockPatchConflict(err) || errors.As(err, &errMissing) || errors.As(err, &errLimit) {
+		return &model.SyncConflict{OperationID: op.ID, Reason: err.Error()}, nil
+	}
+	return nil, err
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	title := "new title"
+	base := &model.SyncOperation{ID: "base-op", BoardID: testBoardID, Revision: 1, BlockID: "card-0", CreateAt: 1000}
+	remote := &model.SyncOperation{ID: "remote-op", BoardID: testBoardID, Revision: 2, BlockID: "changed-card", CreateAt: 2000}
+	retried := &model.SyncOperation{ID: "retried-op", BoardID: testBoardID, Revision: 3, BlockID: "card-0", CreateAt: 3000}
+
+	t.Run("applies, deduplicates and detects conflicts", func(t *testing.T) {
+		changed := &model.Block{ID: "changed-card", BoardID: testBoardID, Type: model.TypeCard, UpdateAt: 2000}
+		edited := &model.Block{ID: "edited-card", BoardID: testBoardID, Type: model.TypeCard, UpdateAt: 2500}
+
+		req := &model.SyncRequest{
+			BaseRevision: 1,
+			Operations: []*model.SyncOperation{
+				{ID: "retried-op", Type: model.SyncOperationDelete, BlockID: "card-0"},
+				{ID: "remote-conflict", Type: model.SyncOperationPatch, BlockID: "changed-card", Patch: &model.BlockPatch{Title: &title}},
+				{ID: "rest-conflict", Type: model.SyncOperationDelete, BlockID: "edited-card"},
+				{ID: "new-op", Type: model.SyncOperationInsert, BlockID: "new-card",
+					Block: &model.Block{ID: "new-card", BoardID: testBoardID, ParentID: testBoardID, Type: model.TypeCard}},
+			},
+		}
+
+		th.Store.EXPECT().GetSyncOperations(testBoardID, int64(0)).Return([]*model.SyncOperation{base, remote, retried}, nil)
+		th.Store.EXPECT().GetBlock("changed-card").Return(changed, nil)
+		th.Store.EXPECT().GetBlock("edited-card").Return(edited, nil)
+		th.Store.EXPECT().GetBlock("new-card").Return(nil, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID}, nil)
+		th.Store.EXPECT().InsertBlock(gomock.Any(), "user-id").Return(nil)
+		th.Store.EXPECT().GetMembersForBoard(testBoardID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().InsertSyncOperation(gomock.Any()).DoAndReturn(func(op *model.SyncOperation) (*model.SyncOperation, error) {
+			require.Equal(t, "new-op", op.ID)
+			require.Equal(t, testBoardID, op.BoardID)
+			require.Equal(t, "user-id", op.UserID)
+			op.Revision = 4
+			return op, nil
+		})
+		newOp := &model.SyncOperation{ID: "new-op", BoardID: testBoardID, Revision: 4, BlockID: "new-card"}
+		th.Store.EXPECT().GetSyncOperations(testBoardID, int64(1)).Return([]*model.SyncOperation{remote, retried, newOp}, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(&model.BoardMember{UserID: "user-id", SchemeAdmin: true}, nil)
+
+		resp, err := th.App.SyncBoard(testBoardID, req, "user-id")
+		require.NoError(t, err)
+		require.Equal(t, []string{"retried-op", "new-op"}, resp.Applied)
+		require.Len(t, resp.Conflicts, 2)
+		require.Equal(t, "remote-conflict", resp.Conflicts[0].OperationID)
+		require.Equal(t, changed, resp.Conflicts[0].Block)
+		require.Equal(t, "rest-conflict", resp.Conflicts[1].OperationID)
+		require.EqualValues(t, 4, resp.Revision)
+		require.Len(t, resp.Operations, 3)
+	})
+
+	t.Run("hides the operations on restricted cards", func(t *testing.T) {
+		board := &model.Board{
+			ID: testBoardID,
+			CardProperties: []map[string]interface{}{
+				{"id": "assignee", "name": "Assignee", "type": model.PropertyTypePerson},
+			},
+		}
+		restricted := model.Block{
+			ID:      "restricted-card",
+			BoardID: testBoardID,
+			Type:    model.TypeCard,
+			Fields: map[string]interface{}{
+				model.CardFieldRestricted: true,
+				"properties":              map[string]interface{}{"assignee": "user-id-2"},
+			},
+		}
+		operations := []*model.SyncOperation{
+			{ID: "public-op", BoardID: testBoardID, Revision: 1, BlockID: "public-card"},
+			{ID: "restricted-op", BoardID: testBoardID, Revision: 2, BlockID: "restricted-card"},
+			{ID: "comment-op", BoardID: testBoardID, Revision: 3, BlockID: "comment"},
+		}
+		history := model.QueryBlockHistoryOptions{Limit: 1, Descending: true}
+
+		th.Store.EXPECT().GetSyncOperations(testBoardID, int64(0)).Return(operations, nil)
+		th.Store.EXPECT().GetMemberForBoard(testBoardID, "user-id").Return(&model.BoardMember{UserID: "user-id", SchemeEditor: true}, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(board, nil)
+		th.Store.EXPECT().GetBlockHistory("public-card", history).Return([]model.Block{{ID: "public-card", BoardID: testBoardID, Type: model.TypeCard}}, nil)
+		th.Store.EXPECT().GetBlockHistory("restricted-card", history).Return([]model.Block{restricted}, nil)
+		th.Store.EXPECT().GetBlockHistory("comment", history).Return([]model.Block{{ID: "comment", BoardID: testBoardID, ParentID: "restricted-card", Type: model.TypeComment}}, nil)
+
+		resp, err := th.App.SyncBoard(testBoardID, &model.SyncRequest{}, "user-id")
+		require.NoError(t, err)
+		require.Len(t, resp.Operations, 1)
+		require.Equal(t, "public-op", resp.Operations[0].ID)
+		require.EqualValues(t, 3, resp.Revision)
+	})
+
+	t.Run("unknown base revision", func(t *testing.T) {
+		th.Store.EXPECT().GetSyncOperations(testBoardID, int64(9)).Return([]*model.SyncOperation{}, nil)
+
+		_, err := th.App.SyncBoard(testBoardID, &model.SyncRequest{BaseRevision: 10}, "user-id")
+		require.True(t, model.IsErrInvalidSyncRequest(err))
+	})
+}
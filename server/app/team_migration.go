@@ -0,0 +1,661 @@
+package app
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/krolaw/zipstream"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// migrationUsersFile is the file of a team migration archive listing the
+// users, so they can be mapped before the boards are imported.
+const migrationUsersFile = "users.jsonl"
+
+// migrationTeamFile is the file of a team migration archive holding the
+// memberships, sharing settings, subscriptions and categories. It is
+// written after the boards so they exist when it's imported.
+const migrationTeamFile = "team.jsonl"
+
+// ExportTeamMigration writes an archive of the boards of a team, with
+// their files, members, sharing settings, subscriptions and the categories
+// of the users, to move the team to another server with
+// ImportTeamMigration. The boards are stored as in a regular archive. When
+// a user requests the export, only the boards they can export are
+// included, without the cards restricted from them; an empty userID
+// exports all the boards of the team.
+func (a *App) ExportTeamMigration(w io.Writer, teamID, userID string) (errs error) {
+	boards, err := a.getMigrationBoards(teamID, userID)
+	if err != nil {
+		return err
+	}
+	members := []*model.BoardMember{}
+	for _, board := range boards {
+		boardMembers, err := a.store.GetMembersForBoard(board.ID)
+		if err != nil {
+			return err
+		}
+		members = append(members, boardMembers...)
+	}
+	users, err := a.getMigrationUsers(teamID, members)
+	if err != nil {
+		return err
+	}
+
+	merr := merror.New()
+	defer func() {
+		errs = merr.ErrorOrNil()
+	}()
+
+	zw := zip.NewWriter(w)
+	defer func() {
+		merr.Append(zw.Close())
+	}()
+
+	if err := a.writeArchiveVersion(zw); err != nil {
+		merr.Append(err)
+		return
+	}
+
+	uw, err := zw.Create(migrationUsersFile)
+	if err != nil {
+		merr.Append(err)
+		return
+	}
+	for _, user := range users {
+		mu := model.MigrationUser{ID: user.ID, Username: user.Username, Email: user.Email}
+		if err := writeMigrationLine(uw, model.MigrationLineUser, mu); err != nil {
+			merr.Append(err)
+			return
+		}
+	}
+
+	opt := model.ExportArchiveOptions{TeamID: teamID, UserID: userID}
+	boardIDs := make(map[string]bool, len(boards))
+	for _, board := range boards {
+		if err := a.writeArchiveBoard(zw, *board, opt); err != nil {
+			merr.Append(fmt.Errorf("cannot export board %s: %w", board.ID, err))
+			return
+		}
+		boardIDs[board.ID] = true
+	}
+
+	tw, err := zw.Create(migrationTeamFile)
+	if err != nil {
+		merr.Append(err)
+		return
+	}
+	if err := a.writeMigrationTeam(tw, teamID, boards, boardIDs, members, users); err != nil {
+		merr.Append(fmt.Errorf("cannot export team %s: %w", teamID, err))
+	}
+	return nil
+}
+
+// getMigrationBoards returns the boards and templates of the team the user
+// can export: the open ones and the ones they are a member of. All the
+// boards are returned if userID is empty.
+func (a *App) getMigrationBoards(teamID, userID string) ([]*model.Board, error) {
+	boards, err := a.store.GetBoardsForTeam(teamID)
+	if err != nil || userID == "" {
+		return boards, err
+	}
+
+	exportable := make([]*model.Board, 0, len(boards))
+	for _, board := range boards {
+		if board.Type != model.BoardTypeOpen {
+			_, err := a.store.GetMemberForBoard(board.ID, userID)
+			if model.IsErrNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+		exportable = append(exportable, board)
+	}
+	return exportable, nil
+}
+
+// getMigrationUsers returns the users of the team together with the
+// members of its boards, as they can include users that left the team.
+func (a *App) getMigrationUsers(teamID string, members []*model.BoardMember) ([]*model.User, error) {
+	users, err := a.store.GetUsersByTeam(teamID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	userIDs := map[string]bool{}
+	for _, user := range users {
+		userIDs[user.ID] = true
+	}
+	for _, member := range members {
+		if userIDs[member.UserID] {
+			continue
+		}
+		user, err := a.store.GetUserByID(member.UserID)
+		if err != nil && !model.IsErrNotFound(err) {
+			return nil, err
+		}
+		if user != nil {
+			users = append(users, user)
+		}
+		userIDs[member.UserID] = true
+	}
+	return users, nil
+}
+
+func (a *App) writeMigrationTeam(w io.Writer, teamID string, boards []*model.Board, boardIDs map[string]bool, members []*model.BoardMember, users []*model.User) error {
+	sharings := []*model.Sharing{}
+	for _, board := range boards {
+		sharing, err := a.GetSharing(board.ID)
+		if err != nil {
+			return err
+		}
+		if sharing != nil {
+			sharings = append(sharings, sharing)
+		}
+	}
+
+	for _, member := range members {
+		if err := writeMigrationLine(w, model.MigrationLineMember, member); err != nil {
+			return err
+		}
+	}
+	for _, sharing := range sharings {
+		if err := writeMigrationLine(w, model.MigrationLineSharing, sharing); err != nil {
+			return err
+		}
+	}
+
+	for _, user := range users {
+		subscriptions, err := a.store.GetSubscriptions(user.ID)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subscriptions {
+			ok, err := a.isBlockOfBoards(sub.BlockID, boardIDs)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if err := writeMigrationLine(w, model.MigrationLineSubscription, sub); err != nil {
+				return err
+			}
+		}
+
+		categories, err := a.store.GetUserCategoryBoards(user.ID, teamID)
+		if err != nil {
+			return err
+		}
+		for _, category := range categories {
+			if err := writeMigrationLine(w, model.MigrationLineCategory, category); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isBlockOfBoards returns true if the block is one of the boards or a
+// card of one of them.
+func (a *App) isBlockOfBoards(blockID string, boardIDs map[string]bool) (bool, error) {
+	if boardIDs[blockID] {
+		return true, nil
+	}
+	board, _, err := a.store.GetBoardAndCardByID(blockID)
+	if model.IsErrNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return boardIDs[board.ID], nil
+}
+
+func writeMigrationLine(w io.Writer, lineType string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(&model.ArchiveLine{Type: lineType, Data: data})
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(b); err != nil {
+		return err
+	}
+	_, err = w.Write(newline)
+	return err
+}
+
+// ImportTeamMigration imports an archive written by ExportTeamMigration
+// into a team. The boards and blocks keep their ids so links to them keep
+// working, and the boards that already exist are skipped. The users are
+// translated with the user map of the options.
+func (a *App) ImportTeamMigration(r io.Reader, opt model.TeamMigrationOptions) (*model.TeamMigrationResult, error) {
+	imp := &migrationImport{
+		app:      a,
+		opt:      opt,
+		users:    map[string]string{},
+		emails:   map[string]string{},
+		imported: map[string]bool{},
+		members:  map[string]bool{},
+		result: &model.TeamMigrationResult{
+			BoardIDs:        []string{},
+			SkippedBoardIDs: []string{},
+			UnmappedUserIDs: []string{},
+		},
+	}
+
+	zr := zipstream.NewReader(r)
+	for {
+		hdr, err := zr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", model.ErrInvalidMigrationArchive, err)
+		}
+
+		dir, filename := filepath.Split(hdr.Name)
+		dir = path.Clean(dir)
+
+		switch {
+		case hdr.Name == "version.json":
+			ver, err := parseVersionFile(zr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", model.ErrInvalidMigrationArchive, err)
+			}
+			if ver != archiveVersion {
+				return nil, model.NewErrUnsupportedArchiveVersion(ver, archiveVersion)
+			}
+		case hdr.Name == migrationUsersFile:
+			if err := imp.importUsers(zr); err != nil {
+				return nil, err
+			}
+		case hdr.Name == migrationTeamFile:
+			if err := imp.importTeam(zr); err != nil {
+				return nil, err
+			}
+		case filename == "board.jsonl":
+			if err := imp.importBoard(zr); err != nil {
+				return nil, fmt.Errorf("cannot import board %s: %w", dir, err)
+			}
+		default:
+			// files are stored in the directory of their board
+			if !imp.imported[dir] || filename == "" || filename == ".." {
+				continue
+			}
+			filePath := filepath.Join(opt.TeamID, dir, filename)
+			if _, err := a.filesBackend.WriteFile(zr, filePath); err != nil {
+				return nil, fmt.Errorf("cannot import file %s for board %s: %w", filename, dir, err)
+			}
+		}
+	}
+
+	// boards must keep an admin to stay reachable
+	for _, boardID := range imp.result.BoardIDs {
+		if imp.members[boardID] {
+			continue
+		}
+		member := &model.BoardMember{BoardID: boardID, UserID: opt.ModifiedBy, SchemeAdmin: true}
+		if _, err := a.store.SaveMember(member); err != nil {
+			return nil, fmt.Errorf("cannot add member to board %s: %w", boardID, err)
+		}
+	}
+
+	sort.Strings(imp.result.UnmappedUserIDs)
+	return imp.result, nil
+}
+
+// migrationImport holds the state of a team migration import.
+type migrationImport struct {
+	app *App
+	opt model.TeamMigrationOptions
+
+	// users caches the translated user ids, empty for unmapped users
+	users map[string]string
+	// emails holds the emails of the source users listed in the archive
+	emails map[string]string
+	// imported holds the ids of the boards created by the import
+	imported map[string]bool
+	// members holds the ids of the imported boards that got members
+	members map[string]bool
+	result  *model.TeamMigrationResult
+}
+
+// mapUser translates a user id of the source server, returning false if
+// the user doesn't exist on this server.
+func (imp *migrationImport) mapUser(userID string) (string, bool) {
+	if userID == "" || userID == model.SystemUserID || userID == model.SingleUser {
+		return userID, true
+	}
+	if mapped, ok := imp.users[userID]; ok {
+		return mapped, mapped != ""
+	}
+
+	mapped := imp.opt.UserMap[userID]
+	if email := imp.emails[userID]; mapped == "" && imp.opt.MapUsersByEmail && email != "" {
+		user, err := imp.app.store.GetUserByEmail(email)
+		if err == nil && user != nil {
+			mapped = user.ID
+		}
+	}
+	if mapped == "" {
+		user, err := imp.app.store.GetUserByID(userID)
+		if err == nil && user != nil {
+			mapped = user.ID
+		}
+	}
+	imp.users[userID] = mapped
+	if mapped == "" {
+		imp.result.UnmappedUserIDs = append(imp.result.UnmappedUserIDs, userID)
+		return "", false
+	}
+	return mapped, true
+}
+
+// mapAuthor translates the author of a board or block, falling back to
+// the user running the import.
+func (imp *migrationImport) mapAuthor(userID string) string {
+	if mapped, ok := imp.mapUser(userID); ok && mapped != "" {
+		return mapped
+	}
+	return imp.opt.ModifiedBy
+}
+
+func (imp *migrationImport) importBoard(r io.Reader) error {
+	var board *model.Board
+	blocks := []model.Block{}
+
+	lineReader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, errRead := readLine(lineReader)
+		if len(line) != 0 {
+			var archiveLine model.ArchiveLine
+			if err := json.Unmarshal(line, &archiveLine); err != nil {
+				return fmt.Errorf("error parsing archive line %d: %w", lineNum, err)
+			}
+			switch archiveLine.Type {
+			case "board":
+				if err := json.Unmarshal(archiveLine.Data, &board); err != nil {
+					return fmt.Errorf("invalid board in archive line %d: %w", lineNum, err)
+				}
+			case "block":
+				var block model.Block
+				if err := json.Unmarshal(archiveLine.Data, &block); err != nil {
+					return fmt.Errorf("invalid block in archive line %d: %w", lineNum, err)
+				}
+				blocks = append(blocks, block)
+			default:
+				return model.NewErrUnsupportedArchiveLineType(lineNum, archiveLine.Type)
+			}
+		}
+		if errors.Is(errRead, io.EOF) {
+			break
+		}
+		if errRead != nil {
+			return fmt.Errorf("error reading archive line %d: %w", lineNum, errRead)
+		}
+	}
+	if board == nil {
+		return fmt.Errorf("missing board in archive: %w", model.ErrInvalidBoardBlock)
+	}
+	// the id names the directory of the files of the board
+	if board.ID == "" || strings.ContainsAny(board.ID, `./\`) {
+		return fmt.Errorf("%w: invalid board id %q", model.ErrInvalidMigrationArchive, board.ID)
+	}
+
+	existing, err := imp.app.store.GetBoard(board.ID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+	if existing != nil {
+		imp.result.SkippedBoardIDs = append(imp.result.SkippedBoardIDs, board.ID)
+		return nil
+	}
+
+	board.TeamID = imp.opt.TeamID
+	creator := imp.mapAuthor(board.CreatedBy)
+	imp.mapPersonValues(board, blocks)
+
+	// the store sets the author of the new blocks to the inserting user,
+	// so the blocks are inserted by author to keep it
+	authors := []string{}
+	blocksByAuthor := map[string][]model.Block{}
+	for i := range blocks {
+		blocks[i].BoardID = board.ID
+		author := imp.mapAuthor(blocks[i].CreatedBy)
+		if _, ok := blocksByAuthor[author]; !ok {
+			authors = append(authors, author)
+		}
+		blocksByAuthor[author] = append(blocksByAuthor[author], blocks[i])
+	}
+
+	var inserted []model.Block
+	err = imp.app.store.RunInTransaction(func(tx store.TxStore) error {
+		if _, err := tx.InsertBoard(board, creator); err != nil {
+			return err
+		}
+		for _, author := range authors {
+			if err := tx.InsertBlocks(blocksByAuthor[author], author); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if inserted, err = tx.GetBlocksForBoard(board.ID); err != nil {
+			return err
+		}
+		if len(inserted) != len(blocks) {
+			return fmt.Errorf("%w: %d blocks imported out of %d", model.ErrMigrationCountMismatch, len(inserted), len(blocks))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	imp.app.updateSearchIndex(searchIndexChange{Blocks: inserted})
+
+	imp.imported[board.ID] = true
+	imp.result.BoardIDs = append(imp.result.BoardIDs, board.ID)
+	imp.result.BlockCount += len(blocks)
+	imp.app.logger.Debug("imported migrated board",
+		mlog.String("boardID", board.ID),
+		mlog.Int("blocks", len(blocks)),
+	)
+	return nil
+}
+
+// mapPersonValues translates the users set on the person properties of
+// the cards, dropping the ones that can't be mapped.
+func (imp *migrationImport) mapPersonValues(board *model.Board, blocks []model.Block) {
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return
+	}
+	personIDs := []string{}
+	for _, property := range properties {
+		if property.Type == model.PropertyTypePerson {
+			personIDs = append(personIDs, property.ID)
+		}
+	}
+	if len(personIDs) == 0 {
+		return
+	}
+
+	for i := range blocks {
+		values := model.CardPropertyValues(&blocks[i])
+		for _, id := range personIDs {
+			userID, ok := values[id].(string)
+			if !ok {
+				continue
+			}
+			if mapped, ok := imp.mapUser(userID); ok {
+				values[id] = mapped
+			} else {
+				delete(values, id)
+			}
+		}
+	}
+}
+
+func (imp *migrationImport) importUsers(r io.Reader) error {
+	lineReader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, errRead := readLine(lineReader)
+		if len(line) != 0 {
+			var archiveLine model.ArchiveLine
+			var user model.MigrationUser
+			if err := json.Unmarshal(line, &archiveLine); err != nil || archiveLine.Type != model.MigrationLineUser {
+				return fmt.Errorf("%w: invalid user line %d", model.ErrInvalidMigrationArchive, lineNum)
+			}
+			if err := json.Unmarshal(archiveLine.Data, &user); err != nil {
+				return fmt.Errorf("%w: invalid user line %d: %s", model.ErrInvalidMigrationArchive, lineNum, err)
+			}
+			imp.emails[user.ID] = user.Email
+		}
+		if errors.Is(errRead, io.EOF) {
+			return nil
+		}
+		if errRead != nil {
+			return fmt.Errorf("%w: line %d: %s", model.ErrInvalidMigrationArchive, lineNum, errRead)
+		}
+	}
+}
+
+func (imp *migrationImport) importTeam(r io.Reader) error {
+	lineReader := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, errRead := readLine(lineReader)
+		if len(line) != 0 {
+			var archiveLine model.ArchiveLine
+			if err := json.Unmarshal(line, &archiveLine); err != nil {
+				return fmt.Errorf("%w: line %d: %s", model.ErrInvalidMigrationArchive, lineNum, err)
+			}
+			if err := imp.importTeamLine(archiveLine); err != nil {
+				return fmt.Errorf("cannot import team line %d: %w", lineNum, err)
+			}
+		}
+		if errors.Is(errRead, io.EOF) {
+			return nil
+		}
+		if errRead != nil {
+			return fmt.Errorf("%w: line %d: %s", model.ErrInvalidMigrationArchive, lineNum, errRead)
+		}
+	}
+}
+
+func (imp *migrationImport) importTeamLine(line model.ArchiveLine) error {
+	s := imp.app.store
+
+	switch line.Type {
+	case model.MigrationLineMember:
+		var member model.BoardMember
+		if err := json.Unmarshal(line.Data, &member); err != nil {
+			return err
+		}
+		userID, ok := imp.mapUser(member.UserID)
+		if !ok || !imp.imported[member.BoardID] {
+			return nil
+		}
+		member.UserID = userID
+		if _, err := s.SaveMember(&member); err != nil {
+			return err
+		}
+		imp.members[member.BoardID] = true
+	case model.MigrationLineSharing:
+		var sharing model.Sharing
+		if err := json.Unmarshal(line.Data, &sharing); err != nil {
+			return err
+		}
+		if !imp.imported[sharing.ID] {
+			return nil
+		}
+		sharing.ModifiedBy = imp.mapAuthor(sharing.ModifiedBy)
+		return s.UpsertSharing(sharing)
+	case model.MigrationLineSubscription:
+		var sub model.Subscription
+		if err := json.Unmarshal(line.Data, &sub); err != nil {
+			return err
+		}
+		subscriberID, ok := imp.mapUser(sub.SubscriberID)
+		if !ok {
+			return nil
+		}
+		ok, err := imp.app.isBlockOfBoards(sub.BlockID, imp.imported)
+		if err != nil || !ok {
+			return err
+		}
+		sub.SubscriberID = subscriberID
+		_, err = s.CreateSubscription(&sub)
+		return err
+	case model.MigrationLineCategory:
+		var category model.CategoryBoards
+		if err := json.Unmarshal(line.Data, &category); err != nil {
+			return err
+		}
+		userID, ok := imp.mapUser(category.UserID)
+		if !ok {
+			return nil
+		}
+		return imp.importCategory(category, userID)
+	default:
+		return fmt.Errorf("%w: unsupported line type %q", model.ErrInvalidMigrationArchive, line.Type)
+	}
+	return nil
+}
+
+func (imp *migrationImport) importCategory(category model.CategoryBoards, userID string) error {
+	s := imp.app.store
+
+	boardIDs := []string{}
+	for _, boardID := range category.BoardIDs {
+		if imp.imported[boardID] {
+			boardIDs = append(boardIDs, boardID)
+		}
+	}
+	if len(boardIDs) == 0 {
+		return nil
+	}
+
+	existing, err := s.GetCategory(category.ID)
+	if err != nil && !model.IsErrNotFound(err) {
+		return err
+	}
+	if existing != nil && (existing.UserID != userID || existing.TeamID != imp.opt.TeamID) {
+		// the id is taken by another category, which is left untouched
+		existing = nil
+		category.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if existing == nil {
+		category.UserID = userID
+		category.TeamID = imp.opt.TeamID
+		if err := s.CreateCategory(category.Category); err != nil {
+			return err
+		}
+	}
+
+	for _, boardID := range boardIDs {
+		if err := s.AddUpdateCategoryBoard(userID, category.ID, boardID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
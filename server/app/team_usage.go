@@ -0,0 +1,20 @@
+package app
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetTeamUsage returns the number of boards, cards and views of the team
+// and the bytes of its stored attachments, along with the configured
+// limits, so admins can see how close they are to them.
+func (a *App) GetTeamUsage(teamID string) (*model.TeamUsage, error) {
+	usage, err := a.store.GetTeamUsage(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage.CardLimit = a.CardLimit()
+	usage.ViewLimit = a.ViewLimit()
+	usage.AttachmentStorageLimit = a.AttachmentStorageLimit()
+	return usage, nil
+}
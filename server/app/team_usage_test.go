@@ -0,0 +1,31 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTeamUsage(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.CardLimit = 100
+	th.App.config.AttachmentStorageLimit = 1000
+
+	th.Store.EXPECT().GetTeamUsage("team-id").Return(&model.TeamUsage{TeamID: "team-id", Boards: 2, Cards: 30, Views: 4, UsedFileStorage: 250}, nil)
+
+	usage, err := th.App.GetTeamUsage("team-id")
+	require.NoError(t, err)
+	require.Equal(t, &model.TeamUsage{
+		TeamID:                 "team-id",
+		Boards:                 2,
+		Cards:                  30,
+		Views:                  4,
+		UsedFileStorage:        250,
+		CardLimit:              100,
+		AttachmentStorageLimit: 1000,
+	}, usage)
+}
@@ -51,6 +51,7 @@ func TestApp_initializeTemplates(t *testing.T) {
 		th.Store.EXPECT().GetMemberForBoard(gomock.Any(), gomock.Any()).AnyTimes().Return(boardMember, nil)
 
 		th.FilesBackend.On("WriteFile", mock.Anything, mock.Anything).Return(int64(1), nil)
+		th.Store.EXPECT().SaveFileInfo(gomock.Any()).AnyTimes().Return(nil)
 
 		done, err := th.App.initializeTemplates()
 		require.NoError(t, err, "initializeTemplates should not error")
@@ -0,0 +1,20 @@
+package app
+
+import "github.com/mattermost/focalboard/server/model"
+
+// SaveBlockText saves the text of a block merged from collaborative
+// edits. It implements ws.TextPersister.
+func (a *App) SaveBlockText(blockID, text, userID string) error {
+	return a.PatchBlock(blockID, &model.BlockPatch{Title: &text}, userID)
+}
+
+// SaveBlockCRDTText saves the text of a block edited collaboratively with
+// CRDT updates, with the state of its document. It implements
+// ws.CRDTTextPersister.
+func (a *App) SaveBlockCRDTText(blockID, text string, state *model.TextCRDTState, userID string) error {
+	patch := &model.BlockPatch{
+		Title:         &text,
+		UpdatedFields: map[string]interface{}{model.TextFieldCRDT: state.FieldValue()},
+	}
+	return a.PatchBlock(blockID, patch, userID)
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// GetTeamTrash returns the boards and cards of the team deleted within the
+// trash retention period, the most recently deleted first.
+func (a *App) GetTeamTrash(teamID string, now time.Time) ([]*model.TrashItem, error) {
+	cutoff := a.trashCutoff(now)
+
+	boards, err := a.store.GetDeletedBoardsForTeam(teamID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	cards, err := a.store.GetDeletedCardsForTeam(teamID, cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*model.TrashItem, 0, len(boards)+len(cards))
+	for _, board := range boards {
+		items = append(items, model.TrashItemForBoard(board))
+	}
+	for _, card := range cards {
+		items = append(items, model.TrashItemForCard(card))
+	}
+	if a.config.TrashRetentionDays > 0 {
+		for _, item := range items {
+			deleteAt := time.Unix(0, item.DeleteAt*int64(time.Millisecond))
+			item.PurgeAt = deleteAt.AddDate(0, 0, a.config.TrashRetentionDays).UnixNano() / int64(time.Millisecond)
+		}
+	}
+	model.SortTrashItems(items)
+	return items, nil
+}
+
+// RestoreTrashBoard restores a board from the trash of the team.
+func (a *App) RestoreTrashBoard(teamID, boardID, userID string, now time.Time) error {
+	if _, err := a.getTrashBoard(teamID, boardID, now); err != nil {
+		return err
+	}
+	return a.UndeleteBoard(boardID, userID)
+}
+
+// RestoreTrashCard restores a card from the trash of the team, along with
+// the content deleted with it.
+func (a *App) RestoreTrashCard(teamID, cardID, userID string, now time.Time) (*model.Block, error) {
+	if _, err := a.GetTrashCard(teamID, cardID, now); err != nil {
+		return nil, err
+	}
+	return a.UndeleteBlock(cardID, userID)
+}
+
+// PurgeTrashBoard permanently deletes a board from the trash of the team,
+// along with its cards and files.
+func (a *App) PurgeTrashBoard(teamID, boardID string, now time.Time) error {
+	if _, err := a.getTrashBoard(teamID, boardID, now); err != nil {
+		return err
+	}
+
+	purged, err := a.store.PurgeDeletedBoard(boardID)
+	if err != nil {
+		return err
+	}
+	a.removeBlockFiles(purged)
+	return nil
+}
+
+// PurgeTrashCard permanently deletes a card from the trash of the team,
+// along with the content and files deleted with it.
+func (a *App) PurgeTrashCard(teamID, cardID string, now time.Time) error {
+	if _, err := a.GetTrashCard(teamID, cardID, now); err != nil {
+		return err
+	}
+
+	purged, err := a.store.PurgeDeletedBlockTree(cardID)
+	if err != nil {
+		return err
+	}
+	a.removeBlockFiles(purged)
+	return nil
+}
+
+// getTrashBoard returns a board of the team deleted within the trash
+// retention period, as it was when deleted.
+func (a *App) getTrashBoard(teamID, boardID string, now time.Time) (*model.Board, error) {
+	boards, err := a.store.GetBoardHistory(boardID, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(boards) == 0 || boards[0].TeamID != teamID || !a.inTrash(boards[0].DeleteAt, now) {
+		return nil, model.NewErrNotFound(boardID)
+	}
+	return boards[0], nil
+}
+
+// GetTrashCard returns a card of a board of the team deleted within the
+// trash retention period, as it was when deleted.
+func (a *App) GetTrashCard(teamID, cardID string, now time.Time) (*model.Block, error) {
+	blocks, err := a.store.GetBlockHistory(cardID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(blocks) == 0 || blocks[0].Type != model.TypeCard || !a.inTrash(blocks[0].DeleteAt, now) {
+		return nil, model.NewErrNotFound(cardID)
+	}
+
+	board, err := a.store.GetBoard(blocks[0].BoardID)
+	if err != nil {
+		return nil, err
+	}
+	if board.TeamID != teamID {
+		return nil, model.NewErrNotFound(cardID)
+	}
+	return &blocks[0], nil
+}
+
+func (a *App) inTrash(deleteAt int64, now time.Time) bool {
+	return deleteAt != 0 && deleteAt >= a.trashCutoff(now)
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTeamTrash(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC)
+	deleteAt := now.AddDate(0, 0, -1).UnixNano() / int64(time.Millisecond)
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Title: "Roadmap", ModifiedBy: "user-id", DeleteAt: deleteAt}
+	card := model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, ModifiedBy: "user-id", DeleteAt: deleteAt + 1}
+
+	t.Run("items kept forever", func(t *testing.T) {
+		th.App.config.TrashRetentionDays = 0
+		th.Store.EXPECT().GetDeletedBoardsForTeam("team-id", int64(0)).Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetDeletedCardsForTeam("team-id", int64(0)).Return([]model.Block{card}, nil)
+
+		items, err := th.App.GetTeamTrash("team-id", now)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, "card-id", items[0].ID)
+		require.Equal(t, "board-id", items[1].ID)
+		require.Zero(t, items[0].PurgeAt)
+	})
+
+	t.Run("items within the retention period", func(t *testing.T) {
+		th.App.config.TrashRetentionDays = 30
+		defer func() { th.App.config.TrashRetentionDays = 0 }()
+
+		cutoff := now.AddDate(0, 0, -30).UnixNano() / int64(time.Millisecond)
+		th.Store.EXPECT().GetDeletedBoardsForTeam("team-id", cutoff).Return([]*model.Board{board}, nil)
+		th.Store.EXPECT().GetDeletedCardsForTeam("team-id", cutoff).Return([]model.Block{}, nil)
+
+		items, err := th.App.GetTeamTrash("team-id", now)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		require.Equal(t, now.AddDate(0, 0, 29).UnixNano()/int64(time.Millisecond), items[0].PurgeAt)
+	})
+}
+
+func TestPurgeTrashCard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	now := time.Date(2022, 6, 30, 12, 0, 0, 0, time.UTC)
+	deleteAt := now.AddDate(0, 0, -1).UnixNano() / int64(time.Millisecond)
+	card := model.Block{ID: "card-id", BoardID: testBoardID, Type: model.TypeCard, DeleteAt: deleteAt}
+	historyOpts := model.QueryBlockHistoryOptions{Limit: 1, Descending: true}
+
+	t.Run("card not deleted", func(t *testing.T) {
+		live := card
+		live.DeleteAt = 0
+		th.Store.EXPECT().GetBlockHistory("card-id", historyOpts).Return([]model.Block{live}, nil)
+
+		err := th.App.PurgeTrashCard("team-id", "card-id", now)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("card of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetBlockHistory("card-id", historyOpts).Return([]model.Block{card}, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "other-team-id"}, nil)
+
+		err := th.App.PurgeTrashCard("team-id", "card-id", now)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("purges the card and its files", func(t *testing.T) {
+		image := model.Block{
+			ID:      "image-id",
+			BoardID: testBoardID,
+			Type:    model.TypeImage,
+			Fields:  map[string]interface{}{"fileId": "file.png"},
+		}
+		th.Store.EXPECT().GetBlockHistory("card-id", historyOpts).Return([]model.Block{card}, nil)
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+		th.Store.EXPECT().PurgeDeletedBlockTree("card-id").Return([]model.Block{card, image}, nil)
+		th.FilesBackend.On("RemoveFile", testBoardID+"/file.png").Return(nil).Once()
+		th.Store.EXPECT().DeleteFileInfo("file.png").Return(nil)
+
+		require.NoError(t, th.App.PurgeTrashCard("team-id", "card-id", now))
+		th.FilesBackend.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// uploadRestrictionsKeyPrefix prefixes the system setting holding the
+// upload restriction overrides of a team, as a JSON object.
+const uploadRestrictionsKeyPrefix = "UploadRestrictions_"
+
+// UploadRestrictions returns the upload restrictions of the server
+// configuration. Invalid extensions in the configuration are ignored.
+func (a *App) UploadRestrictions() model.UploadRestrictions {
+	restrictions := model.UploadRestrictions{
+		AllowedExtensions: configFileExtensions(a.config.AllowedFileExtensions),
+		BlockedExtensions: configFileExtensions(a.config.BlockedFileExtensions),
+	}
+	if a.config.MaxFileSize > 0 {
+		restrictions.MaxFileSize = a.config.MaxFileSize
+	}
+	return restrictions
+}
+
+func configFileExtensions(extensions []string) []string {
+	valid := []string{}
+	for _, extension := range extensions {
+		if normalized, err := model.NormalizeFileExtensions([]string{extension}); err == nil {
+			valid = append(valid, normalized...)
+		}
+	}
+	return valid
+}
+
+// GetTeamUploadRestrictions returns the upload restrictions of the team,
+// which are the ones of the configuration with the overrides of the team.
+func (a *App) GetTeamUploadRestrictions(teamID string) (*model.TeamUploadRestrictions, error) {
+	value, err := a.store.GetSystemSetting(uploadRestrictionsKeyPrefix + teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides model.UploadRestrictionsOverrides
+	if strings.TrimSpace(value) != "" {
+		if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.TeamUploadRestrictions{
+		TeamID:       teamID,
+		Overrides:    overrides,
+		Restrictions: overrides.Apply(a.UploadRestrictions()),
+	}, nil
+}
+
+// UpdateTeamUploadRestrictions replaces the upload restriction overrides
+// of the team.
+func (a *App) UpdateTeamUploadRestrictions(teamID string, overrides model.UploadRestrictionsOverrides) (*model.TeamUploadRestrictions, error) {
+	if err := overrides.IsValid(); err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal(overrides)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.store.SetSystemSetting(uploadRestrictionsKeyPrefix+teamID, string(value)); err != nil {
+		return nil, err
+	}
+
+	return &model.TeamUploadRestrictions{
+		TeamID:       teamID,
+		Overrides:    overrides,
+		Restrictions: overrides.Apply(a.UploadRestrictions()),
+	}, nil
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTeamUploadRestrictions(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.MaxFileSize = 1000
+	th.App.config.BlockedFileExtensions = []string{".EXE", "not/valid"}
+
+	t.Run("the configuration without overrides", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(uploadRestrictionsKeyPrefix+"team-id").Return("", nil)
+
+		restrictions, err := th.App.GetTeamUploadRestrictions("team-id")
+		require.NoError(t, err)
+		require.Equal(t, model.UploadRestrictions{
+			MaxFileSize:       1000,
+			AllowedExtensions: []string{},
+			BlockedExtensions: []string{"exe"},
+		}, restrictions.Restrictions)
+	})
+
+	t.Run("with the overrides of the team", func(t *testing.T) {
+		th.Store.EXPECT().GetSystemSetting(uploadRestrictionsKeyPrefix+"team-id").Return(`{"maxFileSize":0,"allowedExtensions":["pdf"]}`, nil)
+
+		restrictions, err := th.App.GetTeamUploadRestrictions("team-id")
+		require.NoError(t, err)
+		require.Equal(t, model.UploadRestrictions{
+			MaxFileSize:       0,
+			AllowedExtensions: []string{"pdf"},
+			BlockedExtensions: []string{"exe"},
+		}, restrictions.Restrictions)
+	})
+}
+
+func TestUpdateTeamUploadRestrictions(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	blocked := []string{".MOV"}
+	th.Store.EXPECT().SetSystemSetting(uploadRestrictionsKeyPrefix+"team-id", `{"blockedExtensions":["mov"]}`).Return(nil)
+
+	restrictions, err := th.App.UpdateTeamUploadRestrictions("team-id", model.UploadRestrictionsOverrides{BlockedExtensions: &blocked})
+	require.NoError(t, err)
+	require.Equal(t, []string{"mov"}, restrictions.Restrictions.BlockedExtensions)
+
+	invalid := []string{"a b"}
+	_, err = th.App.UpdateTeamUploadRestrictions("team-id", model.UploadRestrictionsOverrides{BlockedExtensions: &invalid})
+	require.True(t, model.IsErrInvalidUploadRestrictions(err))
+}
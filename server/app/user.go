@@ -10,6 +10,30 @@ func (a *App) SearchTeamUsers(teamID string, searchQuery string) ([]*model.User,
 	return a.store.SearchUsersByTeam(teamID, searchQuery)
 }
 
+// SearchUserDirectory returns a page of the members of the team matching
+// the prefix, for the mention autocomplete and the person property
+// pickers.
+func (a *App) SearchUserDirectory(teamID string, opts model.UserDirectoryOptions) (*model.UserDirectoryPage, error) {
+	page, perPage := opts.Paging()
+
+	// one more user is requested to know if there is a next page
+	users, err := a.store.SearchUserDirectory(teamID, opts.Prefix, page*perPage, perPage+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(users) > perPage
+	if hasNext {
+		users = users[:perPage]
+	}
+	return &model.UserDirectoryPage{
+		Users:   users,
+		Page:    page,
+		PerPage: perPage,
+		HasNext: hasNext,
+	}, nil
+}
+
 func (a *App) UpdateUserConfig(userID string, patch model.UserPropPatch) (map[string]interface{}, error) {
 	if err := a.store.PatchUserProps(userID, patch); err != nil {
 		return nil, err
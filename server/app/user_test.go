@@ -0,0 +1,41 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchUserDirectory(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	users := []*model.UserDirectoryEntry{
+		{ID: "user-1", Username: "john"},
+		{ID: "user-2", Username: "johnny"},
+		{ID: "user-3", Username: "johnson"},
+	}
+
+	t.Run("a page followed by more users", func(t *testing.T) {
+		th.Store.EXPECT().SearchUserDirectory(testTeamID, "jo", 2, 3).Return(users, nil)
+
+		page, err := th.App.SearchUserDirectory(testTeamID, model.UserDirectoryOptions{Prefix: "jo", Page: 1, PerPage: 2})
+		require.NoError(t, err)
+		require.Len(t, page.Users, 2)
+		require.True(t, page.HasNext)
+		require.Equal(t, 1, page.Page)
+		require.Equal(t, 2, page.PerPage)
+	})
+
+	t.Run("the last page", func(t *testing.T) {
+		th.Store.EXPECT().SearchUserDirectory(testTeamID, "", 0, model.DefaultUserDirectoryPerPage+1).Return(users, nil)
+
+		page, err := th.App.SearchUserDirectory(testTeamID, model.UserDirectoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, page.Users, 3)
+		require.False(t, page.HasNext)
+		require.Equal(t, model.DefaultUserDirectoryPerPage, page.PerPage)
+	})
+}
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/auth"
+	"github.com/mattermost/focalboard/server/services/mail"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	passwordResetTokenExpiry     = time.Hour
+	emailVerificationTokenExpiry = 48 * time.Hour
+)
+
+var (
+	ErrMailUnavailable  = errors.New("email is not configured")
+	ErrInvalidUserToken = errors.New("invalid or expired token")
+)
+
+// SendPasswordReset emails a link to reset their password to the user with
+// the email address. It succeeds without sending anything when no user has
+// the address, so that the callers can't find out which addresses are
+// registered.
+func (a *App) SendPasswordReset(email string) error {
+	mailService := a.mailService()
+	if !mailService.IsConfigured() {
+		return ErrMailUnavailable
+	}
+
+	user, err := a.store.GetUserByEmail(email)
+	if model.IsErrNotFound(err) || (err == nil && user == nil) {
+		a.logger.Debug("Password reset requested for an unknown email address")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get the user: %w", err)
+	}
+
+	token, err := a.createUserToken(user, model.UserTokenTypePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("A password reset was requested for your account %s.\n\n"+
+		"Follow this link within an hour to choose a new password:\n\n%s\n\n"+
+		"If you didn't request it, you can ignore this email.\n",
+		user.Username, a.userTokenLink("reset_password", token))
+	if err := mailService.Send(user.Email, "Reset your password", body); err != nil {
+		return fmt.Errorf("unable to send the password reset email: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword sets the password of the user the password reset token was
+// issued for and logs the user out of all their sessions, unless the user
+// changed their email address since.
+func (a *App) ResetPassword(token, newPassword string) error {
+	userToken, err := a.getUserToken(token, model.UserTokenTypePasswordReset, passwordResetTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.GetUser(userToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !strings.EqualFold(user.Email, userToken.Email) {
+		return ErrInvalidUserToken
+	}
+
+	if err := auth.IsPasswordValid(newPassword, a.passwordSettings()); err != nil {
+		return fmt.Errorf("invalid new password: %w", err)
+	}
+
+	if err := a.store.UpdateUserPasswordByID(userToken.UserID, auth.HashPassword(newPassword)); err != nil {
+		return fmt.Errorf("unable to update password: %w", err)
+	}
+	if err := a.store.DeleteUserTokensForUser(userToken.UserID, model.UserTokenTypePasswordReset); err != nil {
+		return fmt.Errorf("unable to delete the password reset tokens: %w", err)
+	}
+	if err := a.store.DeleteSessionsForUser(userToken.UserID); err != nil {
+		return fmt.Errorf("unable to delete the sessions: %w", err)
+	}
+	return nil
+}
+
+// SendEmailVerification emails a link to verify their email address to the
+// user.
+func (a *App) SendEmailVerification(userID string) error {
+	mailService := a.mailService()
+	if !mailService.IsConfigured() {
+		return ErrMailUnavailable
+	}
+
+	user, err := a.GetUser(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return model.NewErrNotFound(userID)
+	}
+
+	token, err := a.createUserToken(user, model.UserTokenTypeVerifyEmail)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Follow this link to verify the email address of your account %s:\n\n%s\n",
+		user.Username, a.userTokenLink("verify_email", token))
+	if err := mailService.Send(user.Email, "Verify your email address", body); err != nil {
+		return fmt.Errorf("unable to send the email verification email: %w", err)
+	}
+	return nil
+}
+
+// VerifyEmail marks the email address the verification token was sent to
+// as verified, unless the user changed their address since.
+func (a *App) VerifyEmail(token string) error {
+	userToken, err := a.getUserToken(token, model.UserTokenTypeVerifyEmail, emailVerificationTokenExpiry)
+	if err != nil {
+		return err
+	}
+
+	user, err := a.GetUser(userToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !strings.EqualFold(user.Email, userToken.Email) {
+		return ErrInvalidUserToken
+	}
+
+	patch := model.UserPropPatch{
+		UpdatedFields: map[string]string{
+			model.UserPropEmailVerified: "true",
+		},
+	}
+	if err := a.store.PatchUserProps(user.ID, patch); err != nil {
+		return err
+	}
+	return a.store.DeleteUserTokensForUser(user.ID, model.UserTokenTypeVerifyEmail)
+}
+
+// CleanUpUserTokens deletes the expired user tokens.
+func (a *App) CleanUpUserTokens() error {
+	expiry := passwordResetTokenExpiry
+	if emailVerificationTokenExpiry > expiry {
+		expiry = emailVerificationTokenExpiry
+	}
+	return a.store.CleanUpUserTokens(utils.GetMillis() - expiry.Milliseconds())
+}
+
+// createUserToken issues a token of the type for the user, invalidating
+// the tokens of the same type issued before. Only the hash of the returned
+// secret token is stored.
+func (a *App) createUserToken(user *model.User, tokenType string) (string, error) {
+	if err := a.store.DeleteUserTokensForUser(user.ID, tokenType); err != nil {
+		return "", fmt.Errorf("unable to delete the previous tokens: %w", err)
+	}
+
+	token := utils.NewID(utils.IDTypeToken)
+	userToken := &model.UserToken{
+		Token:  model.HashUserToken(token),
+		Type:   tokenType,
+		UserID: user.ID,
+		Email:  user.Email,
+	}
+	if err := a.store.CreateUserToken(userToken); err != nil {
+		return "", fmt.Errorf("unable to create the token: %w", err)
+	}
+	return token, nil
+}
+
+// getUserToken returns the stored token of the secret token if it is of
+// the type and not expired.
+func (a *App) getUserToken(token, tokenType string, expiry time.Duration) (*model.UserToken, error) {
+	if token == "" {
+		return nil, ErrInvalidUserToken
+	}
+
+	hash := model.HashUserToken(token)
+	userToken, err := a.store.GetUserToken(hash)
+	if model.IsErrNotFound(err) {
+		return nil, ErrInvalidUserToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if userToken.Type != tokenType {
+		return nil, ErrInvalidUserToken
+	}
+	if utils.GetMillis()-userToken.CreateAt > expiry.Milliseconds() {
+		if err := a.store.DeleteUserToken(hash); err != nil {
+			a.logger.Warn("Cannot delete the expired user token", mlog.String("userID", userToken.UserID), mlog.Err(err))
+		}
+		return nil, ErrInvalidUserToken
+	}
+	return userToken, nil
+}
+
+// mailService returns a service sending the emails with the SMTP settings
+// of the configuration.
+func (a *App) mailService() *mail.Service {
+	return mail.New(mail.Settings{
+		Server:             a.config.SMTPServer,
+		Port:               a.config.SMTPPort,
+		Username:           a.config.SMTPUsername,
+		Password:           a.config.SMTPPassword,
+		ConnectionSecurity: a.config.SMTPConnectionSecurity,
+		FromAddress:        a.config.SMTPFromAddress,
+	})
+}
+
+func (a *App) userTokenLink(page, token string) string {
+	return fmt.Sprintf("%s/%s?t=%s", strings.TrimSuffix(a.config.ServerRoot, "/"), page, token)
+}
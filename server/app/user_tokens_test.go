@@ -0,0 +1,169 @@
+package app
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/mail/mailtest"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendPasswordReset(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("without email", func(t *testing.T) {
+		err := th.App.SendPasswordReset("user@example.com")
+		require.ErrorIs(t, err, ErrMailUnavailable)
+	})
+
+	server := mailtest.NewServer(t)
+	th.App.config.SMTPServer = server.Host()
+	th.App.config.SMTPPort = server.Port()
+	th.App.config.SMTPFromAddress = "boards@example.com"
+	th.App.config.ServerRoot = "http://boards.example.com/"
+
+	t.Run("unknown email address", func(t *testing.T) {
+		th.Store.EXPECT().GetUserByEmail("unknown@example.com").Return(nil, nil)
+
+		require.NoError(t, th.App.SendPasswordReset("unknown@example.com"))
+		require.Empty(t, server.Messages())
+
+		th.Store.EXPECT().GetUserByEmail("unknown@example.com").Return(nil, sql.ErrNoRows)
+
+		require.NoError(t, th.App.SendPasswordReset("unknown@example.com"))
+		require.Empty(t, server.Messages())
+	})
+
+	t.Run("sends the link", func(t *testing.T) {
+		user := &model.User{ID: "user-id", Username: "user", Email: "user@example.com"}
+		th.Store.EXPECT().GetUserByEmail("user@example.com").Return(user, nil)
+		th.Store.EXPECT().DeleteUserTokensForUser("user-id", model.UserTokenTypePasswordReset).Return(nil)
+
+		var token *model.UserToken
+		th.Store.EXPECT().CreateUserToken(gomock.Any()).DoAndReturn(func(userToken *model.UserToken) error {
+			token = userToken
+			return nil
+		})
+
+		require.NoError(t, th.App.SendPasswordReset("user@example.com"))
+		require.Equal(t, model.UserTokenTypePasswordReset, token.Type)
+		require.Equal(t, "user-id", token.UserID)
+		require.Equal(t, "user@example.com", token.Email)
+
+		messages := server.WaitForMessages(1, 5*time.Second)
+		require.Len(t, messages, 1)
+		require.Equal(t, []string{"user@example.com"}, messages[0].To)
+
+		// only the hash of the token of the link is stored
+		matches := regexp.MustCompile(`http://boards\.example\.com/reset_password\?t=(\w+)`).FindStringSubmatch(messages[0].Data)
+		require.Len(t, matches, 2)
+		require.NotEqual(t, matches[1], token.Token)
+		require.Equal(t, model.HashUserToken(matches[1]), token.Token)
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	hash := model.HashUserToken("token")
+
+	t.Run("unknown token", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(model.HashUserToken("unknown")).Return(nil, model.NewErrNotFound("user token"))
+
+		err := th.App.ResetPassword("unknown", "new-password")
+		require.ErrorIs(t, err, ErrInvalidUserToken)
+	})
+
+	t.Run("token of another type", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(hash).Return(&model.UserToken{
+			Token:    hash,
+			Type:     model.UserTokenTypeVerifyEmail,
+			UserID:   "user-id",
+			CreateAt: utils.GetMillis(),
+		}, nil)
+
+		err := th.App.ResetPassword("token", "new-password")
+		require.ErrorIs(t, err, ErrInvalidUserToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(hash).Return(&model.UserToken{
+			Token:    hash,
+			Type:     model.UserTokenTypePasswordReset,
+			UserID:   "user-id",
+			CreateAt: utils.GetMillis() - 2*time.Hour.Milliseconds(),
+		}, nil)
+		th.Store.EXPECT().DeleteUserToken(hash).Return(nil)
+
+		err := th.App.ResetPassword("token", "new-password")
+		require.ErrorIs(t, err, ErrInvalidUserToken)
+	})
+
+	token := &model.UserToken{
+		Token:    hash,
+		Type:     model.UserTokenTypePasswordReset,
+		UserID:   "user-id",
+		Email:    "user@example.com",
+		CreateAt: utils.GetMillis(),
+	}
+
+	t.Run("email address changed since", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(hash).Return(token, nil)
+		th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", Email: "other@example.com"}, nil)
+
+		err := th.App.ResetPassword("token", "a new password")
+		require.ErrorIs(t, err, ErrInvalidUserToken)
+	})
+
+	t.Run("resets the password and the sessions", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(hash).Return(token, nil)
+		th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", Email: "User@example.com"}, nil)
+		th.Store.EXPECT().UpdateUserPasswordByID("user-id", gomock.Any()).Return(nil)
+		th.Store.EXPECT().DeleteUserTokensForUser("user-id", model.UserTokenTypePasswordReset).Return(nil)
+		th.Store.EXPECT().DeleteSessionsForUser("user-id").Return(nil)
+
+		require.NoError(t, th.App.ResetPassword("token", "a new password"))
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	hash := model.HashUserToken("token")
+	token := &model.UserToken{
+		Token:    hash,
+		Type:     model.UserTokenTypeVerifyEmail,
+		UserID:   "user-id",
+		Email:    "user@example.com",
+		CreateAt: utils.GetMillis(),
+	}
+
+	t.Run("email address changed since", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(hash).Return(token, nil)
+		th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", Email: "other@example.com"}, nil)
+
+		err := th.App.VerifyEmail("token")
+		require.ErrorIs(t, err, ErrInvalidUserToken)
+	})
+
+	t.Run("verifies the email address", func(t *testing.T) {
+		th.Store.EXPECT().GetUserToken(hash).Return(token, nil)
+		th.Store.EXPECT().GetUserByID("user-id").Return(&model.User{ID: "user-id", Email: "user@example.com"}, nil)
+		th.Store.EXPECT().PatchUserProps("user-id", model.UserPropPatch{
+			UpdatedFields: map[string]string{model.UserPropEmailVerified: "true"},
+		}).Return(nil)
+		th.Store.EXPECT().DeleteUserTokensForUser("user-id", model.UserTokenTypeVerifyEmail).Return(nil)
+
+		require.NoError(t, th.App.VerifyEmail("token"))
+	})
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// migrateConfigWebhooks registers the URLs of the deprecated webhook_update
+// setting as webhooks of the team of the server, which keep receiving all
+// the block changes as before.
+func (a *App) migrateConfigWebhooks() {
+	if a.config == nil || len(a.config.WebhookUpdate) == 0 {
+		return
+	}
+	a.logger.Warn("The webhook_update setting is deprecated, its URLs are registered as webhooks of the team, remove them from the configuration",
+		mlog.Int("count", len(a.config.WebhookUpdate)),
+	)
+
+	webhooks, err := a.store.GetWebhooksForTeam(model.GlobalTeamID)
+	if err != nil {
+		a.logger.Error("Unable to migrate the webhook_update setting", mlog.Err(err))
+		return
+	}
+	registered := map[string]bool{}
+	for _, webhook := range webhooks {
+		registered[webhook.URL] = true
+	}
+
+	for _, url := range a.config.WebhookUpdate {
+		if registered[url] {
+			continue
+		}
+		if _, err := a.CreateWebhook(model.GlobalTeamID, &model.Webhook{URL: url}, model.SystemUserID); err != nil {
+			a.logger.Error("Unable to migrate the webhook_update setting", mlog.String("url", url), mlog.Err(err))
+			continue
+		}
+		registered[url] = true
+	}
+}
+
+// GetWebhooks returns the webhooks of a team, without their secrets.
+func (a *App) GetWebhooks(teamID string) ([]*model.Webhook, error) {
+	webhooks, err := a.store.GetWebhooksForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+	for _, webhook := range webhooks {
+		webhook.Sanitize()
+	}
+	return webhooks, nil
+}
+
+// CreateWebhook registers a webhook on the team. The returned webhook
+// holds the secret used to sign the payloads, which is not returned again.
+func (a *App) CreateWebhook(teamID string, webhook *model.Webhook, userID string) (*model.Webhook, error) {
+	webhook.Hydrate(teamID, userID)
+	if err := a.validateWebhook(webhook); err != nil {
+		return nil, err
+	}
+	return a.store.CreateWebhook(webhook)
+}
+
+// UpdateWebhook replaces the URL and the filters of a webhook of the team.
+func (a *App) UpdateWebhook(teamID string, webhook *model.Webhook) (*model.Webhook, error) {
+	existing, err := a.getTeamWebhook(teamID, webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.URL = webhook.URL
+	existing.BlockTypes = webhook.BlockTypes
+	existing.BoardIDs = webhook.BoardIDs
+	existing.Actions = webhook.Actions
+	if err := a.validateWebhook(existing); err != nil {
+		return nil, err
+	}
+
+	if err := a.store.UpdateWebhook(existing); err != nil {
+		return nil, err
+	}
+	existing.Sanitize()
+	return existing, nil
+}
+
+// DeleteWebhook removes a webhook of the team.
+func (a *App) DeleteWebhook(teamID, webhookID string) error {
+	if _, err := a.getTeamWebhook(teamID, webhookID); err != nil {
+		return err
+	}
+	return a.store.DeleteWebhook(webhookID)
+}
+
+func (a *App) getTeamWebhook(teamID, webhookID string) (*model.Webhook, error) {
+	webhook, err := a.store.GetWebhook(webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.TeamID != teamID {
+		return nil, model.NewErrNotFound(webhookID)
+	}
+	return webhook, nil
+}
+
+// validateWebhook checks the webhook and that the boards of its filter
+// belong to its team.
+func (a *App) validateWebhook(webhook *model.Webhook) error {
+	if err := webhook.IsValid(); err != nil {
+		return err
+	}
+
+	for _, boardID := range webhook.BoardIDs {
+		board, err := a.store.GetBoard(boardID)
+		if model.IsErrNotFound(err) || (err == nil && board.TeamID != webhook.TeamID) {
+			return model.NewErrInvalidWebhook(fmt.Sprintf("board %q doesn't belong to the team", boardID))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
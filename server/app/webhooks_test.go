@@ -0,0 +1,83 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	t.Run("webhook with a board of the team", func(t *testing.T) {
+		webhook := &model.Webhook{URL: "https://example.com/hook", BoardIDs: []string{testBoardID}}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "team-id"}, nil)
+		th.Store.EXPECT().CreateWebhook(gomock.Any()).DoAndReturn(func(webhook *model.Webhook) (*model.Webhook, error) {
+			return webhook, nil
+		})
+
+		created, err := th.App.CreateWebhook("team-id", webhook, "user-id")
+		require.NoError(t, err)
+		require.NotEmpty(t, created.ID)
+		require.NotEmpty(t, created.Secret)
+		require.Equal(t, "team-id", created.TeamID)
+		require.Equal(t, "user-id", created.CreatedBy)
+	})
+
+	t.Run("webhook with a board of another team", func(t *testing.T) {
+		webhook := &model.Webhook{URL: "https://example.com/hook", BoardIDs: []string{testBoardID}}
+		th.Store.EXPECT().GetBoard(testBoardID).Return(&model.Board{ID: testBoardID, TeamID: "other-team-id"}, nil)
+
+		_, err := th.App.CreateWebhook("team-id", webhook, "user-id")
+		require.True(t, model.IsErrInvalidWebhook(err))
+	})
+}
+
+func TestUpdateWebhook(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	existing := &model.Webhook{ID: "webhook-id", TeamID: "team-id", URL: "https://example.com/hook", Secret: "secret"}
+
+	t.Run("webhook of another team", func(t *testing.T) {
+		th.Store.EXPECT().GetWebhook("webhook-id").Return(existing, nil)
+
+		_, err := th.App.UpdateWebhook("other-team-id", &model.Webhook{ID: "webhook-id", URL: "https://example.com/updated"})
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("update the url", func(t *testing.T) {
+		th.Store.EXPECT().GetWebhook("webhook-id").Return(existing, nil)
+		th.Store.EXPECT().UpdateWebhook(gomock.Any()).DoAndReturn(func(webhook *model.Webhook) error {
+			require.Equal(t, "secret", webhook.Secret)
+			return nil
+		})
+
+		updated, err := th.App.UpdateWebhook("team-id", &model.Webhook{ID: "webhook-id", URL: "https://example.com/updated"})
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/updated", updated.URL)
+		require.Empty(t, updated.Secret)
+	})
+}
+
+func TestMigrateConfigWebhooks(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	th.App.config.WebhookUpdate = []string{"https://example.com/registered", "https://example.com/new"}
+	th.Store.EXPECT().GetWebhooksForTeam(model.GlobalTeamID).Return([]*model.Webhook{
+		{ID: "webhook-id", TeamID: model.GlobalTeamID, URL: "https://example.com/registered"},
+	}, nil)
+	th.Store.EXPECT().CreateWebhook(gomock.Any()).DoAndReturn(func(webhook *model.Webhook) (*model.Webhook, error) {
+		require.Equal(t, "https://example.com/new", webhook.URL)
+		require.Equal(t, model.GlobalTeamID, webhook.TeamID)
+		require.Equal(t, model.SystemUserID, webhook.CreatedBy)
+		return webhook, nil
+	})
+
+	th.App.migrateConfigWebhooks()
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	// KeyWelcomeBoardID holds the id of the welcome board created for the
+	// user, or ValueWelcomeBoardPending until they join a team.
+	KeyWelcomeBoardID = KeyPrefix + "welcomeBoardID"
+
+	ValueWelcomeBoardPending = "pending"
+)
+
+var errWelcomeBoardNotTemplate = errors.New("the configured welcome board template is not a template")
+
+// ProvisionWelcomeBoard creates the personal welcome board of a new user
+// in the team from the configured template, if enabled. It runs once per
+// user, as the id of the board is kept in the user props. Users without a
+// team yet get their board when they join one, through
+// ProvisionPendingWelcomeBoard. It returns the id of the created board, or
+// an empty string if none was created.
+func (a *App) ProvisionWelcomeBoard(userID, teamID string) (string, error) {
+	return a.provisionWelcomeBoard(userID, teamID, false)
+}
+
+// ProvisionPendingWelcomeBoard creates the welcome board of a user who had
+// no team when created, in the first team they join.
+func (a *App) ProvisionPendingWelcomeBoard(userID, teamID string) (string, error) {
+	return a.provisionWelcomeBoard(userID, teamID, true)
+}
+
+func (a *App) provisionWelcomeBoard(userID, teamID string, onlyPending bool) (string, error) {
+	if !a.config.EnableWelcomeBoard {
+		return "", nil
+	}
+
+	a.welcomeBoardMu.Lock()
+	defer a.welcomeBoardMu.Unlock()
+
+	user, err := a.store.GetUserByID(userID)
+	if err != nil {
+		return "", err
+	}
+	state, _ := user.Props[KeyWelcomeBoardID].(string)
+	switch {
+	case state == ValueWelcomeBoardPending:
+		if teamID == "" {
+			return "", nil
+		}
+	case state != "" || onlyPending:
+		// already provisioned, or not a new user
+		return "", nil
+	case teamID == "":
+		return "", a.setWelcomeBoardID(userID, ValueWelcomeBoardPending)
+	}
+
+	templateID, err := a.welcomeBoardTemplateID()
+	if err != nil {
+		return "", err
+	}
+
+	boardID, err := a.createPrivateBoardFromTemplate(templateID, userID, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := a.setWelcomeBoardID(userID, boardID); err != nil {
+		return "", err
+	}
+	return boardID, nil
+}
+
+// welcomeBoardTemplateID returns the configured template of the welcome
+// boards, or the built-in welcome board if none is.
+func (a *App) welcomeBoardTemplateID() (string, error) {
+	templateID := a.config.WelcomeBoardTemplateID
+	if templateID == "" {
+		return a.getOnboardingBoardID()
+	}
+
+	template, err := a.store.GetBoard(templateID)
+	if err != nil {
+		return "", fmt.Errorf("unable to get the welcome board template %s: %w", templateID, err)
+	}
+	if !template.IsTemplate {
+		return "", fmt.Errorf("%w: %s", errWelcomeBoardNotTemplate, templateID)
+	}
+	return template.ID, nil
+}
+
+func (a *App) setWelcomeBoardID(userID, value string) error {
+	patch := model.UserPropPatch{
+		UpdatedFields: map[string]string{KeyWelcomeBoardID: value},
+	}
+	return a.store.PatchUserProps(userID, patch)
+}
+
+// provisionWelcomeBoardForNewUser creates the welcome board of a user who
+// just registered, logging the failures so that they don't fail the
+// registration.
+func (a *App) provisionWelcomeBoardForNewUser(userID string) {
+	if _, err := a.ProvisionWelcomeBoard(userID, model.GlobalTeamID); err != nil {
+		a.logger.Error("Unable to provision the welcome board of the new user",
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+	}
+}
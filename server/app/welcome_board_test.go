@@ -0,0 +1,105 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvisionWelcomeBoard(t *testing.T) {
+	th, tearDown := SetupTestHelper(t)
+	defer tearDown()
+
+	template := &model.Board{ID: "template-id", TeamID: model.GlobalTeamID, IsTemplate: true}
+	welcomeBoard := &model.Board{ID: "welcome-board-id", TeamID: testTeamID}
+
+	expectBoardFromTemplate := func(userID string) {
+		th.Store.EXPECT().GetBoard("template-id").Return(template, nil).AnyTimes()
+		th.Store.EXPECT().DuplicateBoard("template-id", userID, testTeamID, false).
+			Return(&model.BoardsAndBlocks{Boards: []*model.Board{welcomeBoard}}, nil, nil)
+		th.Store.EXPECT().GetMembersForBoard(welcomeBoard.ID).Return([]*model.BoardMember{}, nil).AnyTimes()
+		th.Store.EXPECT().PatchBoard(welcomeBoard.ID, gomock.Any(), userID).Return(welcomeBoard, nil)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		boardID, err := th.App.ProvisionWelcomeBoard("user-id", testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, boardID)
+	})
+
+	th.App.GetConfig().EnableWelcomeBoard = true
+	th.App.GetConfig().WelcomeBoardTemplateID = "template-id"
+
+	t.Run("new user", func(t *testing.T) {
+		user := &model.User{ID: "user-1", Props: map[string]interface{}{}}
+		th.Store.EXPECT().GetUserByID("user-1").Return(user, nil)
+		expectBoardFromTemplate("user-1")
+		th.Store.EXPECT().PatchUserProps("user-1", model.UserPropPatch{
+			UpdatedFields: map[string]string{KeyWelcomeBoardID: welcomeBoard.ID},
+		}).Return(nil)
+
+		boardID, err := th.App.ProvisionWelcomeBoard("user-1", testTeamID)
+		require.NoError(t, err)
+		require.Equal(t, welcomeBoard.ID, boardID)
+	})
+
+	t.Run("user with a welcome board already", func(t *testing.T) {
+		user := &model.User{ID: "user-2", Props: map[string]interface{}{KeyWelcomeBoardID: "board-id"}}
+		th.Store.EXPECT().GetUserByID("user-2").Return(user, nil).Times(2)
+
+		boardID, err := th.App.ProvisionWelcomeBoard("user-2", testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, boardID)
+
+		boardID, err = th.App.ProvisionPendingWelcomeBoard("user-2", testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, boardID)
+	})
+
+	t.Run("existing user joining a team", func(t *testing.T) {
+		user := &model.User{ID: "user-3", Props: map[string]interface{}{}}
+		th.Store.EXPECT().GetUserByID("user-3").Return(user, nil)
+
+		boardID, err := th.App.ProvisionPendingWelcomeBoard("user-3", testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, boardID)
+	})
+
+	t.Run("new user without a team", func(t *testing.T) {
+		user := &model.User{ID: "user-4", Props: map[string]interface{}{}}
+		th.Store.EXPECT().GetUserByID("user-4").Return(user, nil)
+		th.Store.EXPECT().PatchUserProps("user-4", model.UserPropPatch{
+			UpdatedFields: map[string]string{KeyWelcomeBoardID: ValueWelcomeBoardPending},
+		}).Return(nil)
+
+		boardID, err := th.App.ProvisionWelcomeBoard("user-4", "")
+		require.NoError(t, err)
+		require.Empty(t, boardID)
+
+		pending := &model.User{ID: "user-4", Props: map[string]interface{}{KeyWelcomeBoardID: ValueWelcomeBoardPending}}
+		th.Store.EXPECT().GetUserByID("user-4").Return(pending, nil)
+		expectBoardFromTemplate("user-4")
+		th.Store.EXPECT().PatchUserProps("user-4", model.UserPropPatch{
+			UpdatedFields: map[string]string{KeyWelcomeBoardID: welcomeBoard.ID},
+		}).Return(nil)
+
+		boardID, err = th.App.ProvisionPendingWelcomeBoard("user-4", testTeamID)
+		require.NoError(t, err)
+		require.Equal(t, welcomeBoard.ID, boardID)
+	})
+
+	t.Run("configured board not a template", func(t *testing.T) {
+		th.App.GetConfig().WelcomeBoardTemplateID = "board-id"
+		defer func() { th.App.GetConfig().WelcomeBoardTemplateID = "template-id" }()
+
+		user := &model.User{ID: "user-5", Props: map[string]interface{}{}}
+		th.Store.EXPECT().GetUserByID("user-5").Return(user, nil)
+		th.Store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id"}, nil)
+
+		_, err := th.App.ProvisionWelcomeBoard("user-5", testTeamID)
+		require.ErrorIs(t, err, errWelcomeBoardNotTemplate)
+	})
+}
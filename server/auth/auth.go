@@ -2,6 +2,8 @@
 package auth
 
 import (
+	"net/http"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/mattermost/focalboard/server/services/permissions"
@@ -10,9 +12,23 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ErrSessionIdle is returned for sessions unused for longer than the idle
+// timeout of the configuration.
+var ErrSessionIdle = errors.New("session expired after being idle")
+
+// ErrSessionLifetimeExceeded is returned for sessions created longer ago
+// than the maximum lifetime of the configuration.
+var ErrSessionLifetimeExceeded = errors.New("session exceeded its maximum lifetime")
+
+// ErrSessionNotRecent is returned for sessions created longer ago than
+// the reauthentication time of the configuration when a recent login is
+// required.
+var ErrSessionNotRecent = errors.New("a recent login is required")
+
 type AuthInterface interface {
 	GetSession(token string) (*model.Session, error)
 	IsValidReadToken(boardID string, readToken string) (bool, error)
+	IsSharedBoardAddressAllowed(boardID string, remoteIP string) (bool, error)
 	DoesUserHaveTeamAccess(userID string, teamID string) bool
 }
 
@@ -30,6 +46,28 @@ func New(config *config.Configuration, store store.Store, permissions permission
 
 // GetSession Get a user active session and refresh the session if needed.
 func (a *Auth) GetSession(token string) (*model.Session, error) {
+	session, err := a.getActiveSession(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(a.sessionRefreshTime())) {
+		_ = a.store.RefreshSession(session)
+	}
+	return session, nil
+}
+
+// CheckSession returns an error if the session of the token was revoked
+// or expired, without refreshing it, so that checking the connections
+// the clients keep open doesn't count as an activity of their user.
+func (a *Auth) CheckSession(token string) error {
+	_, err := a.getActiveSession(token)
+	return err
+}
+
+// getActiveSession returns the session of the token, deleting it if it is
+// expired by the session policies.
+func (a *Auth) getActiveSession(token string) (*model.Session, error) {
 	if len(token) < 1 {
 		return nil, errors.New("no session token")
 	}
@@ -38,14 +76,60 @@ func (a *Auth) GetSession(token string) (*model.Session, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to get the session for the token")
 	}
-	if session.UpdateAt < (utils.GetMillis() - utils.SecondsToMillis(a.config.SessionRefreshTime)) {
-		_ = a.store.RefreshSession(session)
+
+	if err := a.checkSessionPolicies(session, utils.GetMillis()); err != nil {
+		if errDelete := a.store.DeleteSession(session.ID); errDelete != nil {
+			return nil, errors.Wrap(errDelete, "unable to delete the expired session")
+		}
+		return nil, err
 	}
 	return session, nil
 }
 
+// checkSessionPolicies returns an error if the session is expired by the
+// idle timeout or the maximum lifetime of the configuration.
+func (a *Auth) checkSessionPolicies(session *model.Session, now int64) error {
+	if a.config.SessionIdleTimeout > 0 && session.UpdateAt < now-utils.SecondsToMillis(a.config.SessionIdleTimeout) {
+		return ErrSessionIdle
+	}
+	if a.config.SessionMaxLifetime > 0 && session.CreateAt < now-utils.SecondsToMillis(a.config.SessionMaxLifetime) {
+		return ErrSessionLifetimeExceeded
+	}
+	return nil
+}
+
+// sessionRefreshTime returns how often, in seconds, the last activity
+// time of the sessions is updated. With an idle timeout it's updated more
+// often, so that sessions don't expire much earlier than the timeout.
+func (a *Auth) sessionRefreshTime() int64 {
+	refreshTime := a.config.SessionRefreshTime
+	if idleRefreshTime := a.config.SessionIdleTimeout / 10; idleRefreshTime > 0 && idleRefreshTime < refreshTime {
+		refreshTime = idleRefreshTime
+	}
+	return refreshTime
+}
+
+// CheckRecentSession returns ErrSessionNotRecent unless the session was
+// created, by logging in, within the reauthentication time of the
+// configuration, for the endpoints that require a recent login.
+func (a *Auth) CheckRecentSession(session *model.Session) error {
+	if a.config.SessionReauthTime <= 0 {
+		return nil
+	}
+	if session.CreateAt < utils.GetMillis()-utils.SecondsToMillis(a.config.SessionReauthTime) {
+		return ErrSessionNotRecent
+	}
+	return nil
+}
+
 // IsValidReadToken validates the read token for a board.
+// The read tokens are not valid while the public sharing of boards is
+// disabled.
 func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error) {
+	if !a.config.EnablePublicSharedBoards {
+		return false, nil
+	}
+
 	sharing, err := a.store.GetSharing(boardID)
 	if model.IsErrNotFound(err) {
 		return false, nil
@@ -61,6 +145,30 @@ func (a *Auth) IsValidReadToken(boardID string, readToken string) (bool, error)
 	return false, nil
 }
 
+// IsSharedBoardAddressAllowed returns true if the share link of a board
+// can be used from the address, which must be in the allowed ranges of
+// the configuration and of the link when they are set.
+func (a *Auth) IsSharedBoardAddressAllowed(boardID string, remoteIP string) (bool, error) {
+	if !model.IsIPInCIDRs(remoteIP, a.config.SharedBoardsAllowedCIDRs) {
+		return false, nil
+	}
+
+	sharing, err := a.store.GetSharing(boardID)
+	if model.IsErrNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return model.IsIPInCIDRs(remoteIP, sharing.AllowedCIDRs), nil
+}
+
+// ClientIP returns the address of the client of the request, as seen
+// through the trusted proxies of the configuration.
+func (a *Auth) ClientIP(r *http.Request) string {
+	return utils.ClientIP(r, a.config.TrustedProxies)
+}
+
 func (a *Auth) DoesUserHaveTeamAccess(userID string, teamID string) bool {
 	return a.permissions.HasPermissionToTeam(userID, teamID, model.PermissionViewTeam)
 }
@@ -40,7 +40,7 @@ func setupTestHelper(t *testing.T) *TestHelper {
 	mockPermissions := mockpermissions.NewMockStore(ctrlPermissions)
 	logger, err := mlog.NewLogger()
 	require.NoError(t, err)
-	newAuth := New(&cfg, mockStore, localpermissions.New(mockPermissions, logger))
+	newAuth := New(&cfg, mockStore, localpermissions.New(mockPermissions, logger, nil))
 
 	// called during default template setup for every test
 	mockStore.EXPECT().GetTemplateBoards("0", "").AnyTimes()
@@ -144,3 +144,85 @@ func TestIsValidReadToken(t *testing.T) {
 	// 	})
 	// }
 }
+
+func TestGetSessionPolicies(t *testing.T) {
+	now := utils.GetMillis()
+
+	t.Run("idle session", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Auth.config.SessionIdleTimeout = 60
+		session := &model.Session{ID: "idle-session", Token: "idleToken", CreateAt: now, UpdateAt: now - utils.SecondsToMillis(120)}
+		th.Store.EXPECT().GetSession("idleToken", gomock.Any()).Return(session, nil)
+		th.Store.EXPECT().DeleteSession("idle-session").Return(nil)
+
+		_, err := th.Auth.GetSession("idleToken")
+		require.ErrorIs(t, err, ErrSessionIdle)
+	})
+
+	t.Run("session past its lifetime", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Auth.config.SessionMaxLifetime = 3600
+		session := &model.Session{ID: "old-session", Token: "oldToken", CreateAt: now - utils.SecondsToMillis(7200), UpdateAt: now}
+		th.Store.EXPECT().GetSession("oldToken", gomock.Any()).Return(session, nil)
+		th.Store.EXPECT().DeleteSession("old-session").Return(nil)
+
+		_, err := th.Auth.GetSession("oldToken")
+		require.ErrorIs(t, err, ErrSessionLifetimeExceeded)
+	})
+
+	t.Run("active session is refreshed more often with an idle timeout", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Auth.config.SessionRefreshTime = 60 * 60 * 5
+		th.Auth.config.SessionIdleTimeout = 600
+		th.Auth.config.SessionMaxLifetime = 3600
+		session := &model.Session{ID: "active-session", Token: "activeToken", CreateAt: now, UpdateAt: now - utils.SecondsToMillis(120)}
+		th.Store.EXPECT().GetSession("activeToken", gomock.Any()).Return(session, nil)
+		th.Store.EXPECT().RefreshSession(session).Return(nil)
+
+		got, err := th.Auth.GetSession("activeToken")
+		require.NoError(t, err)
+		require.Equal(t, session, got)
+	})
+}
+
+func TestCheckSession(t *testing.T) {
+	now := utils.GetMillis()
+
+	t.Run("active session isn't refreshed", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Auth.config.SessionRefreshTime = 60
+		session := &model.Session{ID: "active-session", Token: "activeToken", CreateAt: now, UpdateAt: now - utils.SecondsToMillis(120)}
+		th.Store.EXPECT().GetSession("activeToken", gomock.Any()).Return(session, nil)
+
+		require.NoError(t, th.Auth.CheckSession("activeToken"))
+	})
+
+	t.Run("idle session", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Auth.config.SessionIdleTimeout = 60
+		session := &model.Session{ID: "idle-session", Token: "idleToken", CreateAt: now, UpdateAt: now - utils.SecondsToMillis(120)}
+		th.Store.EXPECT().GetSession("idleToken", gomock.Any()).Return(session, nil)
+		th.Store.EXPECT().DeleteSession("idle-session").Return(nil)
+
+		require.ErrorIs(t, th.Auth.CheckSession("idleToken"), ErrSessionIdle)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		th := setupTestHelper(t)
+		th.Store.EXPECT().GetSession("revokedToken", gomock.Any()).Return(nil, model.NewErrNotFound("session"))
+
+		require.Error(t, th.Auth.CheckSession("revokedToken"))
+	})
+}
+
+func TestCheckRecentSession(t *testing.T) {
+	th := setupTestHelper(t)
+	now := utils.GetMillis()
+	oldSession := &model.Session{CreateAt: now - utils.SecondsToMillis(3600)}
+
+	require.NoError(t, th.Auth.CheckRecentSession(oldSession))
+
+	th.Auth.config.SessionReauthTime = 600
+	require.ErrorIs(t, th.Auth.CheckRecentSession(oldSession), ErrSessionNotRecent)
+	require.NoError(t, th.Auth.CheckRecentSession(&model.Session{CreateAt: now}))
+}
@@ -63,6 +63,21 @@ func (mr *MockAuthInterfaceMockRecorder) GetSession(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockAuthInterface)(nil).GetSession), arg0)
 }
 
+// IsSharedBoardAddressAllowed mocks base method.
+func (m *MockAuthInterface) IsSharedBoardAddressAllowed(arg0, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSharedBoardAddressAllowed", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSharedBoardAddressAllowed indicates an expected call of IsSharedBoardAddressAllowed.
+func (mr *MockAuthInterfaceMockRecorder) IsSharedBoardAddressAllowed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSharedBoardAddressAllowed", reflect.TypeOf((*MockAuthInterface)(nil).IsSharedBoardAddressAllowed), arg0, arg1)
+}
+
 // IsValidReadToken mocks base method.
 func (m *MockAuthInterface) IsValidReadToken(arg0, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
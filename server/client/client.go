@@ -8,10 +8,13 @@ import (
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/focalboard/server/api"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/graphql"
 )
 
 const (
@@ -111,12 +114,16 @@ func (c *Client) DoAPIRequest(method, url, data, etag string) (*http.Response, e
 
 type requestOption func(r *http.Request)
 
-func (c *Client) doAPIRequestReader(method, url string, data io.Reader, _ /* etag */ string, opts ...requestOption) (*http.Response, error) {
+func (c *Client) doAPIRequestReader(method, url string, data io.Reader, etag string, opts ...requestOption) (*http.Response, error) {
 	rq, err := http.NewRequest(method, url, data)
 	if err != nil {
 		return nil, err
 	}
 
+	if etag != "" {
+		rq.Header.Set(api.HeaderEtagClient, etag)
+	}
+
 	for _, opt := range opts {
 		opt(rq)
 	}
@@ -216,6 +223,20 @@ func (c *Client) GetBlocksForBoard(boardID string) ([]model.Block, *Response) {
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+// GetBlocksForBoardWithEtag returns the blocks of the board, or no blocks
+// and the 304 status code if they didn't change since the response with
+// the etag. The etag of the blocks is in the api.HeaderEtagServer header
+// of the response.
+func (c *Client) GetBlocksForBoardWithEtag(boardID, etag string) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID), etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) GetAllBlocksForBoard(boardID string) ([]model.Block, *Response) {
 	r, err := c.DoAPIGet(c.GetAllBlocksRoute(boardID), "")
 	if err != nil {
@@ -226,6 +247,27 @@ func (c *Client) GetAllBlocksForBoard(boardID string) ([]model.Block, *Response)
 	return model.BlocksFromJSON(r.Body), BuildResponse(r)
 }
 
+// GetAllBlocksPage returns a page of at most limit blocks of the board
+// with an id greater than afterID. The number of blocks of the board and
+// the afterID of the next page are returned in the HeaderTotalCount and
+// HeaderNextAfterID headers of the response.
+func (c *Client) GetAllBlocksPage(boardID, afterID string, limit uint64) ([]model.Block, *Response) {
+	query := url.Values{}
+	query.Set("all", "true")
+	query.Set("limit", strconv.FormatUint(limit, 10))
+	if afterID != "" {
+		query.Set("after_id", afterID)
+	}
+
+	r, err := c.DoAPIGet(c.GetBlocksRoute(boardID)+"?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatch) (bool, *Response) {
 	r, err := c.DoAPIPatch(c.GetBlockRoute(boardID, blockID), toJSON(blockPatch))
 	if err != nil {
@@ -236,6 +278,30 @@ func (c *Client) PatchBlock(boardID, blockID string, blockPatch *model.BlockPatc
 	return true, BuildResponse(r)
 }
 
+// PatchBlocks applies a batch of patches to blocks of a board in one
+// transaction and returns the patched blocks.
+func (c *Client) PatchBlocks(boardID string, patches *model.BlockPatchBatch) ([]model.Block, *Response) {
+	r, err := c.DoAPIPatch(c.GetBlocksRoute(boardID), toJSON(patches))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+// PatchTeamBlocks applies a batch of patches to blocks of any boards of a
+// team in one transaction and returns the patched blocks.
+func (c *Client) PatchTeamBlocks(teamID string, patches *model.BlockPatchBatch) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/blocks/patch", toJSON(patches))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
 func (c *Client) DuplicateBoard(boardID string, asTemplate bool, teamID string) (*model.BoardsAndBlocks, *Response) {
 	queryParams := "?asTemplate=false&"
 	if asTemplate {
@@ -277,6 +343,63 @@ func (c *Client) UndeleteBlock(boardID, blockID string) (bool, *Response) {
 	return true, BuildResponse(r)
 }
 
+func (c *Client) GetBlockHistoryRoute(boardID, blockID string) string {
+	return fmt.Sprintf("%s/history", c.GetBlockRoute(boardID, blockID))
+}
+
+// GetBlockHistory returns a page of the versions of a block, newest first,
+// from the ones older than before if it isn't 0. A zero limit uses the
+// server default.
+func (c *Client) GetBlockHistory(boardID, blockID string, before int64, limit uint64) (*model.BlockHistoryPage, *Response) {
+	query := url.Values{}
+	if before != 0 {
+		query.Set("before", strconv.FormatInt(before, 10))
+	}
+	if limit != 0 {
+		query.Set("limit", strconv.FormatUint(limit, 10))
+	}
+
+	r, err := c.DoAPIGet(c.GetBlockHistoryRoute(boardID, blockID)+"?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var page *model.BlockHistoryPage
+	if err := json.NewDecoder(r.Body).Decode(&page); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return page, BuildResponse(r)
+}
+
+func (c *Client) GetBlockVersion(boardID, blockID string, version int64) (*model.BlockVersion, *Response) {
+	r, err := c.DoAPIGet(fmt.Sprintf("%s/%d", c.GetBlockHistoryRoute(boardID, blockID), version), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var blockVersion *model.BlockVersion
+	if err := json.NewDecoder(r.Body).Decode(&blockVersion); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return blockVersion, BuildResponse(r)
+}
+
+func (c *Client) RestoreBlockVersion(boardID, blockID string, version int64) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(fmt.Sprintf("%s/%d/restore", c.GetBlockHistoryRoute(boardID, blockID), version), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return block, BuildResponse(r)
+}
+
 func (c *Client) InsertBlocks(boardID string, blocks []model.Block) ([]model.Block, *Response) {
 	r, err := c.DoAPIPost(c.GetBlocksRoute(boardID), toJSON(blocks))
 	if err != nil {
@@ -436,6 +559,44 @@ func (c *Client) GetUser(id string) (*model.User, *Response) {
 	return user, BuildResponse(r)
 }
 
+// GetUserAvatar returns the avatar of a user, or no data with a 304 if it
+// didn't change since the response with the etag.
+func (c *Client) GetUserAvatar(id, etag string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(c.GetUserRoute(id)+"/avatar", etag)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return data, BuildResponse(r)
+}
+
+// SetMyAvatar uploads an image as the avatar of the current user.
+func (c *Client) SetMyAvatar(data []byte) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetMeRoute()+"/avatar", string(data))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+// DeleteMyAvatar removes the uploaded avatar of the current user.
+func (c *Client) DeleteMyAvatar() (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetMeRoute()+"/avatar", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
 func (c *Client) GetUserChangePasswordRoute(id string) string {
 	return fmt.Sprintf("/users/%s/changepassword", id)
 }
@@ -450,6 +611,47 @@ func (c *Client) UserChangePassword(id string, data *api.ChangePasswordRequest)
 	return true, BuildResponse(r)
 }
 
+func (c *Client) RequestPasswordReset(email string) (bool, *Response) {
+	r, err := c.DoAPIPost("/password_reset", toJSON(&api.PasswordResetRequest{Email: email}))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) CompletePasswordReset(token, newPassword string) (bool, *Response) {
+	data := &api.CompletePasswordResetRequest{Token: token, NewPassword: newPassword}
+	r, err := c.DoAPIPost("/password_reset/complete", toJSON(data))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) SendEmailVerification() (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetMeRoute()+"/verify_email", "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) VerifyEmail(token string) (bool, *Response) {
+	r, err := c.DoAPIPost("/verify_email", toJSON(&api.VerifyEmailRequest{Token: token}))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
 func (c *Client) CreateBoard(board *model.Board) (*model.Board, *Response) {
 	r, err := c.DoAPIPost(c.GetBoardsRoute(), toJSON(board))
 	if err != nil {
@@ -540,6 +742,70 @@ func (c *Client) SearchBoardsForTeam(teamID, term string) ([]*model.Board, *Resp
 	return model.BoardsFromJSON(r.Body), BuildResponse(r)
 }
 
+// SearchUserDirectory returns a page of the members of the team whose
+// names start with the prefix.
+func (c *Client) SearchUserDirectory(teamID, prefix string, page, perPage int) (*model.UserDirectoryPage, *Response) {
+	query := url.Values{}
+	query.Set("prefix", prefix)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/users/directory?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var userPage *model.UserDirectoryPage
+	if err := json.NewDecoder(r.Body).Decode(&userPage); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return userPage, BuildResponse(r)
+}
+
+// Search returns a page of the ranked search results of the team, only of
+// the given types if any.
+func (c *Client) Search(teamID, terms string, types []model.SearchResultType, page, perPage int) (*model.SearchResults, *Response) {
+	query := url.Values{}
+	query.Set("q", terms)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+	for _, resultType := range types {
+		query.Add("type", string(resultType))
+	}
+
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/search?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var results *model.SearchResults
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return results, BuildResponse(r)
+}
+
+func (c *Client) SearchCards(teamID, terms string, page, perPage int) (*model.CardSearchResults, *Response) {
+	query := url.Values{}
+	query.Set("q", terms)
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/cards/search?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var results *model.CardSearchResults
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return results, BuildResponse(r)
+}
+
 func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Response) {
 	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/members", "")
 	if err != nil {
@@ -550,6 +816,20 @@ func (c *Client) GetMembersForBoard(boardID string) ([]*model.BoardMember, *Resp
 	return model.BoardMembersFromJSON(r.Body), BuildResponse(r)
 }
 
+func (c *Client) GetBoardPresence(boardID string) (*model.BoardPresence, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/presence", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var presence *model.BoardPresence
+	if err := json.NewDecoder(r.Body).Decode(&presence); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return presence, BuildResponse(r)
+}
+
 func (c *Client) AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, *Response) {
 	r, err := c.DoAPIPost(c.GetBoardRoute(member.BoardID)+"/members", toJSON(member))
 	if err != nil {
@@ -605,9 +885,13 @@ func (c *Client) GetTeamUploadFileRoute(teamID, boardID string) string {
 }
 
 func (c *Client) TeamUploadFile(teamID, boardID string, data io.Reader) (*api.FileUploadResponse, *Response) {
+	return c.TeamUploadFileWithName(teamID, boardID, "file", data)
+}
+
+func (c *Client) TeamUploadFileWithName(teamID, boardID, filename string, data io.Reader) (*api.FileUploadResponse, *Response) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, filename)
 	if err != nil {
 		return nil, &Response{Error: err}
 	}
@@ -706,15 +990,58 @@ func (c *Client) ExportBoardArchive(boardID string) ([]byte, *Response) {
 	return buf, BuildResponse(r)
 }
 
+// ExportTeamBoardsArchive returns an archive of the given boards of the
+// team.
+func (c *Client) ExportTeamBoardsArchive(teamID string, boardIDs []string) ([]byte, *Response) {
+	req := model.ArchiveExportRequest{BoardIDs: boardIDs}
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/archive/export", toJSON(req))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
 func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
+	r, err := c.doArchiveImport(teamID, data, "")
+	if err != nil {
+		return BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return BuildResponse(r)
+}
+
+// ValidateArchive runs a dry run import of the archive, returning the report
+// of the problems found in it without importing anything.
+func (c *Client) ValidateArchive(teamID string, data io.Reader) (*model.ArchiveImportReport, *Response) {
+	r, err := c.doArchiveImport(teamID, data, "?dryRun=true")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var report *model.ArchiveImportReport
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return report, BuildResponse(r)
+}
+
+func (c *Client) doArchiveImport(teamID string, data io.Reader, query string) (*http.Response, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
 	if err != nil {
-		return &Response{Error: err}
+		return nil, err
 	}
 	if _, err = io.Copy(part, data); err != nil {
-		return &Response{Error: err}
+		return nil, err
 	}
 	writer.Close()
 
@@ -722,11 +1049,1597 @@ func (c *Client) ImportArchive(teamID string, data io.Reader) *Response {
 		r.Header.Add("Content-Type", writer.FormDataContentType())
 	}
 
-	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import", body, "", opt)
+	return c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/archive/import"+query, body, "", opt)
+}
+
+func (c *Client) ExportTeamMigration(teamID string) ([]byte, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/migration/export", "")
 	if err != nil {
-		return BuildErrorResponse(r, err)
+		return nil, BuildErrorResponse(r, err)
 	}
 	defer closeBody(r)
 
-	return BuildResponse(r)
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+func (c *Client) ImportTeamMigration(teamID string, data io.Reader, userMap map[string]string, mapUsersByEmail bool) (*model.TeamMigrationResult, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "file")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	if err = writer.WriteField(api.UserMapFormKey, toJSON(userMap)); err != nil {
+		return nil, &Response{Error: err}
+	}
+	if err = writer.WriteField(api.MapUsersByEmailFormKey, fmt.Sprintf("%t", mapUsersByEmail)); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/migration/import", body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.TeamMigrationResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return result, BuildResponse(r)
+}
+
+func (c *Client) GetCardPropertiesRoute(boardID string) string {
+	return fmt.Sprintf("%s/properties", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetCardPropertyRoute(boardID, propertyID string) string {
+	return fmt.Sprintf("%s/%s", c.GetCardPropertiesRoute(boardID), propertyID)
+}
+
+func (c *Client) GetCardProperties(boardID string) ([]model.CardProperty, *Response) {
+	r, err := c.DoAPIGet(c.GetCardPropertiesRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var properties []model.CardProperty
+	if err := json.NewDecoder(r.Body).Decode(&properties); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return properties, BuildResponse(r)
+}
+
+func (c *Client) AddCardProperty(boardID string, property *model.CardProperty) (*model.CardProperty, *Response) {
+	r, err := c.DoAPIPost(c.GetCardPropertiesRoute(boardID), toJSON(property))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var added *model.CardProperty
+	if err := json.NewDecoder(r.Body).Decode(&added); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return added, BuildResponse(r)
+}
+
+func (c *Client) PatchCardProperty(boardID, propertyID string, patch *model.CardPropertyPatch) (*model.CardProperty, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardPropertyRoute(boardID, propertyID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var patched *model.CardProperty
+	if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return patched, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardProperty(boardID, propertyID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardPropertyRoute(boardID, propertyID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) ReorderCardProperties(boardID string, propertyIDs []string) ([]model.CardProperty, *Response) {
+	r, err := c.DoAPIPut(c.GetCardPropertiesRoute(boardID)+"/order", toJSON(propertyIDs))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var properties []model.CardProperty
+	if err := json.NewDecoder(r.Body).Decode(&properties); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return properties, BuildResponse(r)
+}
+
+func (c *Client) AddCardPropertyOption(boardID, propertyID string, option *model.CardPropertyOption) (*model.CardPropertyOption, *Response) {
+	r, err := c.DoAPIPost(c.GetCardPropertyRoute(boardID, propertyID)+"/options", toJSON(option))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var added *model.CardPropertyOption
+	if err := json.NewDecoder(r.Body).Decode(&added); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return added, BuildResponse(r)
+}
+
+func (c *Client) PatchCardPropertyOption(boardID, propertyID, optionID string, patch *model.CardPropertyOptionPatch) (*model.CardPropertyOption, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardPropertyRoute(boardID, propertyID)+"/options/"+optionID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var patched *model.CardPropertyOption
+	if err := json.NewDecoder(r.Body).Decode(&patched); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return patched, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardPropertyOption(boardID, propertyID, optionID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardPropertyRoute(boardID, propertyID)+"/options/"+optionID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetCardPropertyOptionUsage(boardID string) ([]model.CardPropertyOptionUsage, *Response) {
+	r, err := c.DoAPIGet(c.GetCardPropertiesRoute(boardID)+"/usage", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var usage []model.CardPropertyOptionUsage
+	if err := json.NewDecoder(r.Body).Decode(&usage); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return usage, BuildResponse(r)
+}
+
+func (c *Client) GetCardPropertyAggregates(boardID, propertyID, groupByID string) ([]model.CardPropertyAggregate, *Response) {
+	route := c.GetCardPropertyRoute(boardID, propertyID) + "/aggregate"
+	if groupByID != "" {
+		route += "?group_by=" + groupByID
+	}
+
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var aggregates []model.CardPropertyAggregate
+	if err := json.NewDecoder(r.Body).Decode(&aggregates); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return aggregates, BuildResponse(r)
+}
+
+func (c *Client) GetCardTemplatesRoute(boardID string) string {
+	return fmt.Sprintf("%s/card-templates", c.GetBoardRoute(boardID))
+}
+
+func (c *Client) GetCardTemplates(boardID string) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetCardTemplatesRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) CreateCardTemplate(boardID string, template *model.CardTemplate) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCardTemplatesRoute(boardID), toJSON(template))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) CreateCardFromTemplate(boardID, templateID string, overrides *model.CardFromTemplate) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCardTemplatesRoute(boardID)+"/"+templateID+"/cards", toJSON(overrides))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) PatchCardTemplate(boardID, templateID string, patch *model.BlockPatch) (*model.Block, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardTemplatesRoute(boardID)+"/"+templateID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var template *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return template, BuildResponse(r)
+}
+
+func (c *Client) DeleteCardTemplate(boardID, templateID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardTemplatesRoute(boardID)+"/"+templateID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetCardsRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/cards"
+}
+
+func (c *Client) GetCardRoute(boardID, cardID string) string {
+	return c.GetCardsRoute(boardID) + "/" + cardID
+}
+
+// GetCards returns a page of the cards of a board. The filters are the
+// property values the cards must have, keyed by property id.
+func (c *Client) GetCards(boardID string, page, perPage int, filters map[string]string) ([]*model.Card, *Response) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+	for propertyID, value := range filters {
+		query.Add("filter", propertyID+":"+value)
+	}
+
+	r, err := c.DoAPIGet(c.GetCardsRoute(boardID)+"?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []*model.Card
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) CreateCard(boardID string, card *model.Card) (*model.Card, *Response) {
+	r, err := c.DoAPIPost(c.GetCardsRoute(boardID), toJSON(card))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeCard(r)
+}
+
+func (c *Client) GetCard(boardID, cardID string) (*model.Card, *Response) {
+	r, err := c.DoAPIGet(c.GetCardRoute(boardID, cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeCard(r)
+}
+
+func (c *Client) PatchCard(boardID, cardID string, patch *model.CardPatch) (*model.Card, *Response) {
+	r, err := c.DoAPIPatch(c.GetCardRoute(boardID, cardID), toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeCard(r)
+}
+
+func (c *Client) DeleteCard(boardID, cardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCardRoute(boardID, cardID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func decodeCard(r *http.Response) (*model.Card, *Response) {
+	var card *model.Card
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetCommentsRoute(boardID, cardID string) string {
+	return c.GetCardRoute(boardID, cardID) + "/comments"
+}
+
+func (c *Client) GetComments(boardID, cardID string) ([]*model.Comment, *Response) {
+	r, err := c.DoAPIGet(c.GetCommentsRoute(boardID, cardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var comments []*model.Comment
+	if err := json.NewDecoder(r.Body).Decode(&comments); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return comments, BuildResponse(r)
+}
+
+func (c *Client) AddComment(boardID, cardID string, comment *model.Comment) (*model.Comment, *Response) {
+	r, err := c.DoAPIPost(c.GetCommentsRoute(boardID, cardID), toJSON(comment))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeComment(r)
+}
+
+func (c *Client) EditComment(boardID, cardID, commentID, text string) (*model.Comment, *Response) {
+	r, err := c.DoAPIPatch(c.GetCommentsRoute(boardID, cardID)+"/"+commentID, toJSON(model.CommentPatch{Text: text}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return decodeComment(r)
+}
+
+func (c *Client) DeleteComment(boardID, cardID, commentID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetCommentsRoute(boardID, cardID)+"/"+commentID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func decodeComment(r *http.Response) (*model.Comment, *Response) {
+	var comment *model.Comment
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return comment, BuildResponse(r)
+}
+
+// PasteImage adds an image to the end of a card, sending its bytes or a
+// base64 data URL as the request body.
+func (c *Client) PasteImage(boardID, cardID string, data []byte) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(boardID, cardID)+"/images", string(data))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var block *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&block); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return block, BuildResponse(r)
+}
+
+func (c *Client) GetArchivedCards(boardID string) ([]model.Block, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/cards/archived", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BlocksFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ArchiveCard(boardID, cardID string) (*model.Block, *Response) {
+	return c.setCardArchived(boardID, cardID, "archive")
+}
+
+func (c *Client) UnarchiveCard(boardID, cardID string) (*model.Block, *Response) {
+	return c.setCardArchived(boardID, cardID, "unarchive")
+}
+
+func (c *Client) ShiftDependentCardDates(boardID, cardID string, shift *model.CardDateShift) ([]model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/"+cardID+"/shift_dependents", toJSON(shift))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var cards []model.Block
+	if err := json.NewDecoder(r.Body).Decode(&cards); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return cards, BuildResponse(r)
+}
+
+func (c *Client) setCardArchived(boardID, cardID, action string) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/cards/"+cardID+"/"+action, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) SubmitCard(boardID, readToken string, submission *model.CardSubmission) (*model.Block, *Response) {
+	url := c.GetBoardRoute(boardID) + "/submissions" + fmt.Sprintf("?read_token=%s", readToken)
+	r, err := c.DoAPIPost(url, toJSON(submission))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetIntakeFormsRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/forms"
+}
+
+func (c *Client) GetIntakeForms(boardID string) ([]model.IntakeForm, *Response) {
+	r, err := c.DoAPIGet(c.GetIntakeFormsRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var forms []model.IntakeForm
+	if err := json.NewDecoder(r.Body).Decode(&forms); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return forms, BuildResponse(r)
+}
+
+func (c *Client) CreateIntakeForm(boardID string, form *model.IntakeForm) (*model.IntakeForm, *Response) {
+	r, err := c.DoAPIPost(c.GetIntakeFormsRoute(boardID), toJSON(form))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newForm *model.IntakeForm
+	if err := json.NewDecoder(r.Body).Decode(&newForm); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newForm, BuildResponse(r)
+}
+
+func (c *Client) PatchIntakeForm(boardID, formID string, patch *model.IntakeFormPatch) (*model.IntakeForm, *Response) {
+	r, err := c.DoAPIPatch(c.GetIntakeFormsRoute(boardID)+"/"+formID, toJSON(patch))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var form *model.IntakeForm
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return form, BuildResponse(r)
+}
+
+func (c *Client) DeleteIntakeForm(boardID, formID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetIntakeFormsRoute(boardID)+"/"+formID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetPublicIntakeForm(boardID, formID, token string) (*model.IntakeFormView, *Response) {
+	r, err := c.DoAPIGet(c.GetIntakeFormsRoute(boardID)+"/"+formID+"/public?token="+token, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var form *model.IntakeFormView
+	if err := json.NewDecoder(r.Body).Decode(&form); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return form, BuildResponse(r)
+}
+
+func (c *Client) SubmitIntakeForm(boardID, formID, token string, submission *model.IntakeFormSubmission) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetIntakeFormsRoute(boardID)+"/"+formID+"/submit?token="+token, toJSON(submission))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetBoardEmbedsRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/embeds"
+}
+
+func (c *Client) GetBoardEmbeds(boardID string) ([]model.BoardEmbed, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardEmbedsRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var embeds []model.BoardEmbed
+	if err := json.NewDecoder(r.Body).Decode(&embeds); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return embeds, BuildResponse(r)
+}
+
+func (c *Client) CreateBoardEmbed(boardID string, embed *model.BoardEmbed) (*model.BoardEmbed, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardEmbedsRoute(boardID), toJSON(embed))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newEmbed *model.BoardEmbed
+	if err := json.NewDecoder(r.Body).Decode(&newEmbed); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newEmbed, BuildResponse(r)
+}
+
+func (c *Client) DeleteBoardEmbed(boardID, embedID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardEmbedsRoute(boardID)+"/"+embedID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetEmbedContent(boardID, embedID, token string) (*model.EmbedContent, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardEmbedsRoute(boardID)+"/"+embedID+"/content?token="+token, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var content *model.EmbedContent
+	if err := json.NewDecoder(r.Body).Decode(&content); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return content, BuildResponse(r)
+}
+
+func (c *Client) GetMyNotificationSettingsRoute() string {
+	return c.GetMeRoute() + "/notifications"
+}
+
+func (c *Client) GetMyNotificationSettings() (*model.UserNotificationSettings, *Response) {
+	r, err := c.DoAPIGet(c.GetMyNotificationSettingsRoute(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var settings *model.UserNotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return settings, BuildResponse(r)
+}
+
+func (c *Client) UpdateMyNotificationSettings(settings *model.UserNotificationSettings) (*model.UserNotificationSettings, *Response) {
+	r, err := c.DoAPIPut(c.GetMyNotificationSettingsRoute(), toJSON(settings))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.UserNotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) MuteBoard(boardID string) (*model.UserNotificationSettings, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/mute", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var settings *model.UserNotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return settings, BuildResponse(r)
+}
+
+func (c *Client) UnmuteBoard(boardID string) (*model.UserNotificationSettings, *Response) {
+	r, err := c.DoAPIDelete(c.GetBoardRoute(boardID)+"/mute", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var settings *model.UserNotificationSettings
+	if err := json.NewDecoder(r.Body).Decode(&settings); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return settings, BuildResponse(r)
+}
+
+func (c *Client) GetMyUnreadMentions() ([]*model.Mention, *Response) {
+	r, err := c.DoAPIGet(c.GetMeRoute()+"/mentions", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var mentions []*model.Mention
+	if err := json.NewDecoder(r.Body).Decode(&mentions); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return mentions, BuildResponse(r)
+}
+
+func (c *Client) MarkMyMentionsRead(mentionIDs []string) (bool, *Response) {
+	r, err := c.DoAPIPost(c.GetMeRoute()+"/mentions/read", toJSON(model.MentionsReadRequest{MentionIDs: mentionIDs}))
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) CreateCardReminder(boardID, cardID string, reminder *model.CardReminder) (*model.CardReminder, *Response) {
+	r, err := c.DoAPIPost(c.GetCardRoute(boardID, cardID)+"/reminders", toJSON(reminder))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var created *model.CardReminder
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return created, BuildResponse(r)
+}
+
+// GetMyCardReminders returns the pending reminders of the current user,
+// only those about the card if cardID isn't empty.
+func (c *Client) GetMyCardReminders(cardID string) ([]*model.CardReminder, *Response) {
+	route := c.GetMeRoute() + "/reminders"
+	if cardID != "" {
+		route += "?card_id=" + cardID
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var reminders []*model.CardReminder
+	if err := json.NewDecoder(r.Body).Decode(&reminders); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return reminders, BuildResponse(r)
+}
+
+func (c *Client) SnoozeCardReminder(reminderID string, remindAt int64) (*model.CardReminder, *Response) {
+	r, err := c.DoAPIPost(c.GetMeRoute()+"/reminders/"+reminderID+"/snooze", toJSON(model.CardReminderSnooze{RemindAt: remindAt}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var reminder *model.CardReminder
+	if err := json.NewDecoder(r.Body).Decode(&reminder); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return reminder, BuildResponse(r)
+}
+
+func (c *Client) CancelCardReminder(reminderID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetMeRoute()+"/reminders/"+reminderID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetEscalationPoliciesRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/escalations"
+}
+
+func (c *Client) GetEscalationPolicies(boardID string) ([]model.EscalationPolicy, *Response) {
+	r, err := c.DoAPIGet(c.GetEscalationPoliciesRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var policies []model.EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policies); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return policies, BuildResponse(r)
+}
+
+func (c *Client) CreateEscalationPolicy(boardID string, policy *model.EscalationPolicy) (*model.EscalationPolicy, *Response) {
+	r, err := c.DoAPIPost(c.GetEscalationPoliciesRoute(boardID), toJSON(policy))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newPolicy *model.EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&newPolicy); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newPolicy, BuildResponse(r)
+}
+
+func (c *Client) UpdateEscalationPolicy(boardID string, policy *model.EscalationPolicy) (*model.EscalationPolicy, *Response) {
+	r, err := c.DoAPIPut(c.GetEscalationPoliciesRoute(boardID)+"/"+policy.ID, toJSON(policy))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteEscalationPolicy(boardID, policyID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetEscalationPoliciesRoute(boardID)+"/"+policyID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetAutoArchiveRuleRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/auto_archive"
+}
+
+func (c *Client) GetAutoArchiveRule(boardID string) (*model.AutoArchiveRule, *Response) {
+	r, err := c.DoAPIGet(c.GetAutoArchiveRuleRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var rule *model.AutoArchiveRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return rule, BuildResponse(r)
+}
+
+func (c *Client) SetAutoArchiveRule(boardID string, rule *model.AutoArchiveRule) (*model.AutoArchiveRule, *Response) {
+	r, err := c.DoAPIPut(c.GetAutoArchiveRuleRoute(boardID), toJSON(rule))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var saved *model.AutoArchiveRule
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return saved, BuildResponse(r)
+}
+
+func (c *Client) DeleteAutoArchiveRule(boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetAutoArchiveRuleRoute(boardID), "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetBoardAppearanceRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/appearance"
+}
+
+func (c *Client) GetBoardAppearance(boardID string) (*model.BoardAppearance, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardAppearanceRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var appearance *model.BoardAppearance
+	if err := json.NewDecoder(r.Body).Decode(&appearance); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return appearance, BuildResponse(r)
+}
+
+func (c *Client) SetBoardAppearance(boardID string, appearance *model.BoardAppearance) (*model.BoardAppearance, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardAppearanceRoute(boardID), toJSON(appearance))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var saved *model.BoardAppearance
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return saved, BuildResponse(r)
+}
+
+func (c *Client) GetBoardFormattingRoute(boardID string) string {
+	return c.GetBoardRoute(boardID) + "/formatting"
+}
+
+func (c *Client) GetBoardFormatting(boardID string) (*model.BoardFormatting, *Response) {
+	r, err := c.DoAPIGet(c.GetBoardFormattingRoute(boardID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var formatting *model.BoardFormatting
+	if err := json.NewDecoder(r.Body).Decode(&formatting); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return formatting, BuildResponse(r)
+}
+
+func (c *Client) SetBoardFormatting(boardID string, formatting *model.BoardFormatting) (*model.BoardFormatting, *Response) {
+	r, err := c.DoAPIPut(c.GetBoardFormattingRoute(boardID), toJSON(formatting))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var saved *model.BoardFormatting
+	if err := json.NewDecoder(r.Body).Decode(&saved); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return saved, BuildResponse(r)
+}
+
+func (c *Client) GetWebhooksRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/webhooks"
+}
+
+func (c *Client) GetWebhooks(teamID string) ([]*model.Webhook, *Response) {
+	r, err := c.DoAPIGet(c.GetWebhooksRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var webhooks []*model.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&webhooks); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return webhooks, BuildResponse(r)
+}
+
+func (c *Client) CreateWebhook(teamID string, webhook *model.Webhook) (*model.Webhook, *Response) {
+	r, err := c.DoAPIPost(c.GetWebhooksRoute(teamID), toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newWebhook *model.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&newWebhook); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newWebhook, BuildResponse(r)
+}
+
+func (c *Client) UpdateWebhook(teamID string, webhook *model.Webhook) (*model.Webhook, *Response) {
+	r, err := c.DoAPIPut(c.GetWebhooksRoute(teamID)+"/"+webhook.ID, toJSON(webhook))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.Webhook
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteWebhook(teamID, webhookID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetWebhooksRoute(teamID)+"/"+webhookID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetInvitesRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/invites"
+}
+
+func (c *Client) GetInvites(teamID string) ([]*model.Invite, *Response) {
+	r, err := c.DoAPIGet(c.GetInvitesRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var invites []*model.Invite
+	if err := json.NewDecoder(r.Body).Decode(&invites); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return invites, BuildResponse(r)
+}
+
+func (c *Client) CreateInvite(teamID string, invite *model.Invite) (*model.Invite, *Response) {
+	r, err := c.DoAPIPost(c.GetInvitesRoute(teamID), toJSON(invite))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newInvite *model.Invite
+	if err := json.NewDecoder(r.Body).Decode(&newInvite); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newInvite, BuildResponse(r)
+}
+
+func (c *Client) DeleteInvite(teamID, inviteID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetInvitesRoute(teamID)+"/"+inviteID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetMilestonesRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/milestones"
+}
+
+func (c *Client) GetMilestones(teamID string) ([]*model.Milestone, *Response) {
+	r, err := c.DoAPIGet(c.GetMilestonesRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var milestones []*model.Milestone
+	if err := json.NewDecoder(r.Body).Decode(&milestones); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return milestones, BuildResponse(r)
+}
+
+func (c *Client) CreateMilestone(teamID string, milestone *model.Milestone) (*model.Milestone, *Response) {
+	r, err := c.DoAPIPost(c.GetMilestonesRoute(teamID), toJSON(milestone))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var newMilestone *model.Milestone
+	if err := json.NewDecoder(r.Body).Decode(&newMilestone); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return newMilestone, BuildResponse(r)
+}
+
+func (c *Client) UpdateMilestone(teamID string, milestone *model.Milestone) (*model.Milestone, *Response) {
+	r, err := c.DoAPIPut(c.GetMilestonesRoute(teamID)+"/"+milestone.ID, toJSON(milestone))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var updated *model.Milestone
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return updated, BuildResponse(r)
+}
+
+func (c *Client) DeleteMilestone(teamID, milestoneID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetMilestonesRoute(teamID)+"/"+milestoneID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) GetMilestonesProgress(teamID string) ([]*model.MilestoneProgress, *Response) {
+	r, err := c.DoAPIGet(c.GetMilestonesRoute(teamID)+"/progress", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var progress []*model.MilestoneProgress
+	if err := json.NewDecoder(r.Body).Decode(&progress); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return progress, BuildResponse(r)
+}
+
+// SetCardMilestone attaches a card to a milestone, or detaches it from its
+// milestone if milestoneID is empty.
+func (c *Client) SetCardMilestone(boardID, cardID, milestoneID string) (*model.Block, *Response) {
+	var r *http.Response
+	var err error
+	if milestoneID == "" {
+		r, err = c.DoAPIDelete(c.GetCardRoute(boardID, cardID)+"/milestone", "")
+	} else {
+		r, err = c.DoAPIPut(c.GetCardRoute(boardID, cardID)+"/milestone/"+milestoneID, "")
+	}
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) GetBoardsPortfolio(teamID string, boardIDs []string) ([]*model.BoardSummary, *Response) {
+	query := url.Values{}
+	for _, boardID := range boardIDs {
+		query.Add("board_id", boardID)
+	}
+
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/portfolio?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var summaries []*model.BoardSummary
+	if err := json.NewDecoder(r.Body).Decode(&summaries); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return summaries, BuildResponse(r)
+}
+
+// GetBoardActivity returns the edits of a board per day over the last
+// weeks. An empty time zone means UTC.
+func (c *Client) GetBoardActivity(boardID string, weeks int, timeZone string) (*model.BoardActivity, *Response) {
+	query := url.Values{}
+	query.Set("weeks", strconv.Itoa(weeks))
+	if timeZone != "" {
+		query.Set("tz", timeZone)
+	}
+
+	r, err := c.DoAPIGet(c.GetBoardRoute(boardID)+"/activity?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var activity *model.BoardActivity
+	if err := json.NewDecoder(r.Body).Decode(&activity); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return activity, BuildResponse(r)
+}
+
+// GetDormantBoards returns the boards of the team without activity for
+// the number of days, or the configured number of days if days is 0.
+func (c *Client) GetDormantBoards(teamID string, days int) ([]*model.DormantBoard, *Response) {
+	route := c.GetTeamRoute(teamID) + "/boards/dormant"
+	if days != 0 {
+		route += "?days=" + strconv.Itoa(days)
+	}
+
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var dormant []*model.DormantBoard
+	if err := json.NewDecoder(r.Body).Decode(&dormant); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return dormant, BuildResponse(r)
+}
+
+func (c *Client) GetBoardDiff(boardID string, fromTS, toTS int64) (*model.BoardDiff, *Response) {
+	route := fmt.Sprintf("%s/diff?from_ts=%d", c.GetBoardRoute(boardID), fromTS)
+	if toTS != 0 {
+		route += fmt.Sprintf("&to_ts=%d", toTS)
+	}
+
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var diff *model.BoardDiff
+	if err := json.NewDecoder(r.Body).Decode(&diff); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return diff, BuildResponse(r)
+}
+
+func (c *Client) MarkBoardViewed(boardID string) (*model.BoardView, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/view", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var view *model.BoardView
+	if err := json.NewDecoder(r.Body).Decode(&view); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return view, BuildResponse(r)
+}
+
+func (c *Client) GetBoardChangesForTeam(teamID string) ([]*model.BoardChanges, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/boards/changes", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var changes []*model.BoardChanges
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return changes, BuildResponse(r)
+}
+
+func (c *Client) SyncBoard(boardID string, req *model.SyncRequest) (*model.SyncResponse, *Response) {
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/sync", toJSON(req))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var resp *model.SyncResponse
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return resp, BuildResponse(r)
+}
+
+func (c *Client) GetTeamUsage(teamID string) (*model.TeamUsage, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/usage", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var usage *model.TeamUsage
+	if err := json.NewDecoder(r.Body).Decode(&usage); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return usage, BuildResponse(r)
+}
+
+func (c *Client) GetTeamFeatureFlags(teamID string) (*model.TeamFeatureFlags, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/feature_flags", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var flags *model.TeamFeatureFlags
+	if err := json.NewDecoder(r.Body).Decode(&flags); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return flags, BuildResponse(r)
+}
+
+func (c *Client) UpdateTeamFeatureFlags(teamID string, overrides map[string]string) (*model.TeamFeatureFlags, *Response) {
+	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/feature_flags", toJSON(overrides))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var flags *model.TeamFeatureFlags
+	if err := json.NewDecoder(r.Body).Decode(&flags); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return flags, BuildResponse(r)
+}
+
+func (c *Client) GetTeamUploadRestrictions(teamID string) (*model.TeamUploadRestrictions, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/upload_restrictions", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var restrictions *model.TeamUploadRestrictions
+	if err := json.NewDecoder(r.Body).Decode(&restrictions); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return restrictions, BuildResponse(r)
+}
+
+func (c *Client) UpdateTeamUploadRestrictions(teamID string, overrides model.UploadRestrictionsOverrides) (*model.TeamUploadRestrictions, *Response) {
+	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/upload_restrictions", toJSON(overrides))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var restrictions *model.TeamUploadRestrictions
+	if err := json.NewDecoder(r.Body).Decode(&restrictions); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return restrictions, BuildResponse(r)
+}
+
+func (c *Client) GetTeamBoardPolicy(teamID string) (*model.TeamBoardPolicy, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamRoute(teamID)+"/board_policy", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var policy *model.TeamBoardPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return policy, BuildResponse(r)
+}
+
+func (c *Client) UpdateTeamBoardPolicy(teamID string, policy model.BoardPolicy) (*model.TeamBoardPolicy, *Response) {
+	r, err := c.DoAPIPut(c.GetTeamRoute(teamID)+"/board_policy", toJSON(policy))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var teamPolicy *model.TeamBoardPolicy
+	if err := json.NewDecoder(r.Body).Decode(&teamPolicy); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return teamPolicy, BuildResponse(r)
+}
+
+func (c *Client) GetClientConfig(teamID string) (*model.ClientConfig, *Response) {
+	route := "/clientConfig"
+	if teamID != "" {
+		route += "?teamID=" + teamID
+	}
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var clientConfig *model.ClientConfig
+	if err := json.NewDecoder(r.Body).Decode(&clientConfig); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return clientConfig, BuildResponse(r)
+}
+
+// GetOpenAPISpec returns the OpenAPI 3 specification of the API.
+func (c *Client) GetOpenAPISpec() (map[string]interface{}, *Response) {
+	r, err := c.DoAPIGet("/openapi.json", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var spec map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return spec, BuildResponse(r)
+}
+
+func (c *Client) GraphQL(query string, variables map[string]interface{}) (*graphql.Response, *Response) {
+	r, err := c.DoAPIPost("/graphql", toJSON(graphql.Request{Query: query, Variables: variables}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var resp *graphql.Response
+	if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return resp, BuildResponse(r)
+}
+
+func (c *Client) SetBoardLimitsExempt(boardID string, exempt bool) (*model.Board, *Response) {
+	r, err := c.DoAPIPatch(c.GetBoardRoute(boardID)+"/limits_exempt", toJSON(model.BoardLimitsExemptPatch{Exempt: exempt}))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var board *model.Board
+	if err := json.NewDecoder(r.Body).Decode(&board); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return board, BuildResponse(r)
+}
+
+func (c *Client) ImportTrello(teamID string, export string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/import/trello", export)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ImportNotion(teamID string, data io.Reader) (*model.ImportResult, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "export.zip")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetTeamRoute(teamID)+"/import/notion", body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.ImportResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return result, BuildResponse(r)
+}
+
+func (c *Client) PreviewImportJira(teamID string, req *model.JiraImportRequest) (*model.JiraImportPreview, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/import/jira/preview", toJSON(req))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var preview *model.JiraImportPreview
+	if err := json.NewDecoder(r.Body).Decode(&preview); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return preview, BuildResponse(r)
+}
+
+func (c *Client) ImportJira(teamID string, req *model.JiraImportRequest) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamRoute(teamID)+"/import/jira", toJSON(req))
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return model.BoardFromJSON(r.Body), BuildResponse(r)
+}
+
+func (c *Client) ExportBoardView(boardID, viewID, format string) ([]byte, *Response) {
+	route := fmt.Sprintf("%s/export?format=%s", c.GetBoardRoute(boardID), format)
+	if viewID != "" {
+		route += fmt.Sprintf("&viewId=%s", viewID)
+	}
+
+	r, err := c.DoAPIGet(route, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	buf, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return buf, BuildResponse(r)
+}
+
+func (c *Client) ImportCSV(boardID string, data io.Reader, mapping *model.CSVImportMapping) (*model.CSVImportResult, *Response) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile(api.UploadFormFileKey, "cards.csv")
+	if err != nil {
+		return nil, &Response{Error: err}
+	}
+	if _, err = io.Copy(part, data); err != nil {
+		return nil, &Response{Error: err}
+	}
+	if err = writer.WriteField(api.CSVMappingFormKey, toJSON(mapping)); err != nil {
+		return nil, &Response{Error: err}
+	}
+	writer.Close()
+
+	opt := func(r *http.Request) {
+		r.Header.Add("Content-Type", writer.FormDataContentType())
+	}
+
+	r, err := c.doAPIRequestReader(http.MethodPost, c.APIURL+c.GetBoardRoute(boardID)+"/import/csv", body, "", opt)
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var result *model.CSVImportResult
+	if err := json.NewDecoder(r.Body).Decode(&result); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return result, BuildResponse(r)
+}
+
+func (c *Client) DuplicateBoardAsync(boardID string, asTemplate bool, teamID string) (*model.Job, *Response) {
+	queryParams := fmt.Sprintf("?async=true&asTemplate=%t", asTemplate)
+	if len(teamID) > 0 {
+		queryParams = queryParams + "&toTeam=" + teamID
+	}
+	r, err := c.DoAPIPost(c.GetBoardRoute(boardID)+"/duplicate"+queryParams, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var job *model.Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return job, BuildResponse(r)
+}
+
+func (c *Client) GetJob(jobID string) (*model.Job, *Response) {
+	r, err := c.DoAPIGet("/jobs/"+jobID, "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var job *model.Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return job, BuildResponse(r)
+}
+
+func (c *Client) GetTeamTrashRoute(teamID string) string {
+	return c.GetTeamRoute(teamID) + "/trash"
+}
+
+func (c *Client) GetTeamTrash(teamID string) ([]*model.TrashItem, *Response) {
+	r, err := c.DoAPIGet(c.GetTeamTrashRoute(teamID), "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var items []*model.TrashItem
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return items, BuildResponse(r)
+}
+
+func (c *Client) RestoreTrashBoard(teamID, boardID string) (*model.Board, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamTrashRoute(teamID)+"/boards/"+boardID+"/restore", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var board *model.Board
+	if err := json.NewDecoder(r.Body).Decode(&board); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return board, BuildResponse(r)
+}
+
+func (c *Client) PurgeTrashBoard(teamID, boardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetTeamTrashRoute(teamID)+"/boards/"+boardID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
+}
+
+func (c *Client) RestoreTrashCard(teamID, cardID string) (*model.Block, *Response) {
+	r, err := c.DoAPIPost(c.GetTeamTrashRoute(teamID)+"/cards/"+cardID+"/restore", "")
+	if err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	var card *model.Block
+	if err := json.NewDecoder(r.Body).Decode(&card); err != nil {
+		return nil, BuildErrorResponse(r, err)
+	}
+	return card, BuildResponse(r)
+}
+
+func (c *Client) PurgeTrashCard(teamID, cardID string) (bool, *Response) {
+	r, err := c.DoAPIDelete(c.GetTeamTrashRoute(teamID)+"/cards/"+cardID, "")
+	if err != nil {
+		return false, BuildErrorResponse(r, err)
+	}
+	defer closeBody(r)
+
+	return true, BuildResponse(r)
 }
@@ -4,6 +4,8 @@ go 1.16
 
 require (
 	github.com/Masterminds/squirrel v1.5.2
+	github.com/blevesearch/bleve/v2 v2.3.0
+	github.com/go-redis/redis/v8 v8.11.5
 	github.com/go-sql-driver/mysql v1.6.0
 	github.com/golang/mock v1.6.0
 	github.com/gorilla/mux v1.8.0
@@ -31,7 +33,7 @@ require (
 	github.com/wiggin77/merror v1.0.3
 	github.com/yuin/goldmark v1.4.11 // indirect
 	golang.org/x/crypto v0.0.0-20220321153916-2c7772ba3064
-	golang.org/x/net v0.0.0-20220325170049-de3da57026de // indirect
+	golang.org/x/net v0.0.0-20220325170049-de3da57026de
 	golang.org/x/sys v0.0.0-20220319134239-a9b59b0215f8 // indirect
 	golang.org/x/tools v0.1.10 // indirect
 	google.golang.org/genproto v0.0.0-20220324131243-acbaeb5b85eb // indirect
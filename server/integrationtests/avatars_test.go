@@ -0,0 +1,80 @@
+package integrationtests
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAvatar(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2))))
+	pngData := buf.Bytes()
+
+	me := th.GetUser1()
+
+	t.Run("generated avatar without a session", func(t *testing.T) {
+		anonymous := client.NewClient(th.Server.Config().ServerRoot, "")
+		data, resp := anonymous.GetUserAvatar(me.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, "image/svg+xml", resp.Header.Get("Content-Type"))
+		require.Equal(t, model.GenerateAvatar(me.ID, me.Username), data)
+
+		etag := resp.Header.Get("ETag")
+		require.NotEmpty(t, etag)
+		_, resp = anonymous.GetUserAvatar(me.ID, etag)
+		require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, resp := th.Client.GetUserAvatar("unknown-user", "")
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("upload and delete an avatar", func(t *testing.T) {
+		_, resp := th.Client.GetUserAvatar(me.ID, "")
+		th.CheckOK(resp)
+		generatedETag := resp.Header.Get("ETag")
+
+		success, resp := th.Client.SetMyAvatar(pngData)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		data, resp := th.Client2.GetUserAvatar(me.ID, generatedETag)
+		th.CheckOK(resp)
+		require.Equal(t, "image/png", resp.Header.Get("Content-Type"))
+		require.Equal(t, pngData, data)
+
+		success, resp = th.Client.DeleteMyAvatar()
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		data, resp = th.Client.GetUserAvatar(me.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, model.GenerateAvatar(me.ID, me.Username), data)
+	})
+
+	t.Run("invalid images", func(t *testing.T) {
+		_, resp := th.Client.SetMyAvatar([]byte("not an image"))
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.SetMyAvatar(append(pngData, make([]byte, model.MaxAvatarSize)...))
+		th.CheckRequestEntityTooLarge(resp)
+	})
+
+	t.Run("a session is required to upload", func(t *testing.T) {
+		anonymous := client.NewClient(th.Server.Config().ServerRoot, "")
+		_, resp := anonymous.SetMyAvatar(pngData)
+		th.CheckUnauthorized(resp)
+	})
+}
@@ -0,0 +1,127 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockHistory(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeCard,
+		Title:    "first title",
+		CreateAt: utils.GetMillis(),
+		UpdateAt: utils.GetMillis(),
+		Fields:   map[string]interface{}{"icon": "🚀"},
+	}
+	inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+	th.CheckOK(resp)
+	require.Len(t, inserted, 1)
+	card = inserted[0]
+
+	for _, title := range []string{"second title", "third title"} {
+		time.Sleep(10 * time.Millisecond)
+		title := title
+		_, resp = th.Client.PatchBlock(board.ID, card.ID, &model.BlockPatch{Title: &title})
+		th.CheckOK(resp)
+	}
+
+	var firstVersion int64
+
+	t.Run("list the history", func(t *testing.T) {
+		page, resp := th.Client.GetBlockHistory(board.ID, card.ID, 0, 0)
+		th.CheckOK(resp)
+		require.Len(t, page.Versions, 3)
+		require.Zero(t, page.NextBefore)
+		require.Equal(t, "third title", page.Versions[0].Block.Title)
+		require.Equal(t, "first title", page.Versions[2].Block.Title)
+		require.Equal(t, th.GetUser1().ID, page.Versions[0].ModifiedBy)
+		firstVersion = page.Versions[2].Version
+	})
+
+	t.Run("page through the history", func(t *testing.T) {
+		page, resp := th.Client.GetBlockHistory(board.ID, card.ID, 0, 2)
+		th.CheckOK(resp)
+		require.Len(t, page.Versions, 2)
+		require.NotZero(t, page.NextBefore)
+
+		page, resp = th.Client.GetBlockHistory(board.ID, card.ID, page.NextBefore, 2)
+		th.CheckOK(resp)
+		require.Len(t, page.Versions, 1)
+		require.Zero(t, page.NextBefore)
+		require.Equal(t, "first title", page.Versions[0].Block.Title)
+	})
+
+	t.Run("get a version", func(t *testing.T) {
+		version, resp := th.Client.GetBlockVersion(board.ID, card.ID, firstVersion)
+		th.CheckOK(resp)
+		require.Equal(t, firstVersion, version.Version)
+		require.Equal(t, "first title", version.Block.Title)
+
+		_, resp = th.Client.GetBlockVersion(board.ID, card.ID, firstVersion+1)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("restore a version", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		restored, resp := th.Client.RestoreBlockVersion(board.ID, card.ID, firstVersion)
+		th.CheckOK(resp)
+		require.Equal(t, "first title", restored.Title)
+
+		// the restore is a new version
+		page, resp := th.Client.GetBlockHistory(board.ID, card.ID, 0, 0)
+		th.CheckOK(resp)
+		require.Len(t, page.Versions, 4)
+		require.Equal(t, "first title", page.Versions[0].Block.Title)
+	})
+
+	t.Run("restore a deleted block", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.DeleteBlock(board.ID, card.ID)
+		th.CheckOK(resp)
+
+		page, resp := th.Client.GetBlockHistory(board.ID, card.ID, 0, 0)
+		th.CheckOK(resp)
+		require.True(t, page.Versions[0].Deleted)
+
+		// the version deleting the block can't be restored
+		_, resp = th.Client.RestoreBlockVersion(board.ID, card.ID, page.Versions[0].Version)
+		th.CheckBadRequest(resp)
+
+		time.Sleep(10 * time.Millisecond)
+		restored, resp := th.Client.RestoreBlockVersion(board.ID, card.ID, page.Versions[1].Version)
+		th.CheckOK(resp)
+		require.Equal(t, card.ID, restored.ID)
+		require.Zero(t, restored.DeleteAt)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, resp := th.Client.GetBlockHistory(board.ID, card.ID, 0, model.MaxBlockHistoryLimit+1)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBlockHistory(board.ID, utils.NewID(utils.IDTypeCard), 0, 0)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("non members can't browse or restore", func(t *testing.T) {
+		_, resp := th.Client2.GetBlockHistory(board.ID, card.ID, 0, 0)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.GetBlockVersion(board.ID, card.ID, firstVersion)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.RestoreBlockVersion(board.ID, card.ID, firstVersion)
+		th.CheckForbidden(resp)
+	})
+}
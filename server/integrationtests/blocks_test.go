@@ -1,9 +1,15 @@
 package integrationtests
 
 import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/mattermost/focalboard/server/api"
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -52,6 +58,142 @@ func TestGetBlocks(t *testing.T) {
 	require.Contains(t, blockIDs, blockID2)
 }
 
+func TestGetBlocksPage(t *testing.T) {
+	th := SetupTestHelperWithToken(t).Start()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	newBlocks := []model.Block{}
+	for i := 0; i < 3; i++ {
+		newBlocks = append(newBlocks, model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+		})
+	}
+	// archived cards are neither returned nor counted
+	newBlocks = append(newBlocks, model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Fields:   map[string]interface{}{model.CardFieldArchived: true},
+	})
+	_, resp := th.Client.InsertBlocks(board.ID, newBlocks)
+	require.NoError(t, resp.Error)
+
+	allBlocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+	require.NoError(t, resp.Error)
+	for _, block := range allBlocks {
+		require.NotEqual(t, newBlocks[3].ID, block.ID)
+	}
+	total := strconv.Itoa(len(allBlocks))
+
+	t.Run("pages of the board", func(t *testing.T) {
+		blockIDs := []string{}
+		afterID := ""
+		for {
+			blocks, resp := th.Client.GetAllBlocksPage(board.ID, afterID, 2)
+			require.NoError(t, resp.Error)
+			require.LessOrEqual(t, len(blocks), 2)
+			require.Equal(t, total, resp.Header.Get(api.HeaderTotalCount))
+			for _, block := range blocks {
+				blockIDs = append(blockIDs, block.ID)
+			}
+
+			afterID = resp.Header.Get(api.HeaderNextAfterID)
+			if afterID == "" {
+				break
+			}
+		}
+		require.Len(t, blockIDs, len(allBlocks))
+		require.True(t, sort.StringsAreSorted(blockIDs))
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, resp := th.Client.GetAllBlocksPage(board.ID, "", model.MaxBlocksPageLimit+1)
+		th.CheckBadRequest(resp)
+	})
+}
+
+func TestGetBlocksETag(t *testing.T) {
+	th := SetupTestHelperWithToken(t).Start()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+	}})
+	th.CheckOK(resp)
+	blockID := newBlocks[0].ID
+
+	blocks, resp := th.Client.GetBlocksForBoardWithEtag(board.ID, "")
+	th.CheckOK(resp)
+	require.Len(t, blocks, 1)
+	etag := resp.Header.Get(api.HeaderEtagServer)
+	require.NotEmpty(t, etag)
+
+	t.Run("unchanged blocks", func(t *testing.T) {
+		blocks, resp := th.Client.GetBlocksForBoardWithEtag(board.ID, etag)
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusNotModified, resp.StatusCode)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("other query", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet(th.Client.GetAllBlocksRoute(board.ID), etag)
+		require.NoError(t, err)
+		r.Body.Close()
+		require.Equal(t, http.StatusOK, r.StatusCode)
+	})
+
+	t.Run("patched block", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		title := "New title"
+		_, resp := th.Client.PatchBlock(board.ID, blockID, &model.BlockPatch{Title: &title})
+		th.CheckOK(resp)
+
+		blocks, resp := th.Client.GetBlocksForBoardWithEtag(board.ID, etag)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		require.Equal(t, title, blocks[0].Title)
+		require.NotEqual(t, etag, resp.Header.Get(api.HeaderEtagServer))
+		etag = resp.Header.Get(api.HeaderEtagServer)
+	})
+
+	t.Run("deleted block", func(t *testing.T) {
+		_, resp := th.Client.DeleteBlock(board.ID, blockID)
+		th.CheckOK(resp)
+
+		blocks, resp := th.Client.GetBlocksForBoardWithEtag(board.ID, etag)
+		th.CheckOK(resp)
+		require.Empty(t, blocks)
+		require.NotEqual(t, etag, resp.Header.Get(api.HeaderEtagServer))
+	})
+
+	t.Run("unchanged board", func(t *testing.T) {
+		r, err := th.Client.DoAPIGet(th.Client.GetBoardRoute(board.ID), "")
+		require.NoError(t, err)
+		r.Body.Close()
+		boardETag := r.Header.Get(api.HeaderEtagServer)
+		require.NotEmpty(t, boardETag)
+
+		r, err = th.Client.DoAPIGet(th.Client.GetBoardRoute(board.ID), boardETag)
+		require.NoError(t, err)
+		r.Body.Close()
+		require.Equal(t, http.StatusNotModified, r.StatusCode)
+	})
+}
+
 func TestPostBlock(t *testing.T) {
 	th := SetupTestHelperWithToken(t).Start()
 	defer th.TearDown()
@@ -257,6 +399,113 @@ func TestPatchBlock(t *testing.T) {
 		require.Equal(t, "test value 2", updatedBlock.Fields["test2"])
 		require.Equal(t, nil, updatedBlock.Fields["test3"])
 	})
+
+	t.Run("Patch a block changed since the base version", func(t *testing.T) {
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		require.Len(t, blocks, 1)
+		baseUpdateAt := blocks[0].UpdateAt
+		time.Sleep(10 * time.Millisecond)
+
+		firstTitle := "First title"
+		_, resp = th.Client.PatchBlock(board.ID, blockID, &model.BlockPatch{Title: &firstTitle, BaseUpdateAt: baseUpdateAt})
+		th.CheckOK(resp)
+
+		secondTitle := "Second title"
+		_, resp = th.Client.PatchBlock(board.ID, blockID, &model.BlockPatch{Title: &secondTitle, BaseUpdateAt: baseUpdateAt})
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+		var conflict model.BlockPatchConflictResponse
+		require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(resp.Error.Error(), "payload: ")), &conflict))
+		require.Equal(t, []string{"title"}, conflict.ConflictingFields)
+		require.NotNil(t, conflict.Block)
+		require.Equal(t, firstTitle, conflict.Block.Title)
+
+		_, resp = th.Client.PatchBlock(board.ID, blockID, &model.BlockPatch{Title: &secondTitle, BaseUpdateAt: conflict.Block.UpdateAt})
+		th.CheckOK(resp)
+	})
+}
+
+func TestPatchBlocks(t *testing.T) {
+	th := SetupTestHelperWithToken(t).Start()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	otherBoard := th.CreateBoard("other-team-id", model.BoardTypeOpen)
+
+	blocks := []model.Block{}
+	for _, title := range []string{"First", "Second", "Third"} {
+		blocks = append(blocks, model.Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  board.ID,
+			CreateAt: 1,
+			UpdateAt: 1,
+			Type:     model.TypeCard,
+			Title:    title,
+			Fields:   map[string]interface{}{},
+		})
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, blocks)
+	th.CheckOK(resp)
+	require.Len(t, newBlocks, 3)
+
+	otherBlocks, resp := th.Client.InsertBlocks(otherBoard.ID, []model.Block{{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  otherBoard.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+		Title:    "Other",
+		Fields:   map[string]interface{}{},
+	}})
+	th.CheckOK(resp)
+
+	batch := func(titles ...string) *model.BlockPatchBatch {
+		patches := &model.BlockPatchBatch{}
+		for i := range titles {
+			title := titles[i]
+			patches.BlockIDs = append(patches.BlockIDs, newBlocks[i].ID)
+			patches.BlockPatches = append(patches.BlockPatches, model.BlockPatch{Title: &title})
+		}
+		return patches
+	}
+
+	t.Run("patch the blocks of a board", func(t *testing.T) {
+		patched, resp := th.Client.PatchBlocks(board.ID, batch("A", "B", "C"))
+		th.CheckOK(resp)
+		require.Len(t, patched, 3)
+		require.Equal(t, "A", patched[0].Title)
+		require.Equal(t, "C", patched[2].Title)
+	})
+
+	t.Run("patch the blocks of a team", func(t *testing.T) {
+		patched, resp := th.Client.PatchTeamBlocks("team-id", batch("X", "Y"))
+		th.CheckOK(resp)
+		require.Len(t, patched, 2)
+		require.Equal(t, "Y", patched[1].Title)
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		patches := batch("A", "B")
+		patches.BlockPatches = patches.BlockPatches[:1]
+		_, resp := th.Client.PatchTeamBlocks("team-id", patches)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("block of another team", func(t *testing.T) {
+		patches := batch("Z")
+		patches.BlockIDs = append(patches.BlockIDs, otherBlocks[0].ID)
+		patches.BlockPatches = append(patches.BlockPatches, patches.BlockPatches[0])
+		_, resp := th.Client.PatchTeamBlocks("team-id", patches)
+		th.CheckNotFound(resp)
+
+		// nothing of the batch was applied
+		blocks, resp := th.Client.GetBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		for _, block := range blocks {
+			require.NotEqual(t, "Z", block.Title)
+		}
+	})
 }
 
 func TestDeleteBlock(t *testing.T) {
@@ -392,3 +641,56 @@ func TestUndeleteBlock(t *testing.T) {
 		require.Len(t, blocks, initialCount)
 	})
 }
+
+func TestUndeleteBlockTree(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeCard,
+	}
+	text := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: card.ID,
+		CreateAt: 1,
+		UpdateAt: 1,
+		Type:     model.TypeText,
+		Title:    "description",
+	}
+	newBlocks, resp := th.Client.InsertBlocks(board.ID, []model.Block{card, text})
+	require.NoError(t, resp.Error)
+	require.Len(t, newBlocks, 2)
+	cardID := newBlocks[0].ID
+
+	blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+	require.NoError(t, resp.Error)
+	initialCount := len(blocks)
+
+	t.Run("deleting a card deletes its contents", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.DeleteBlock(board.ID, cardID)
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, initialCount-2)
+	})
+
+	t.Run("undeleting the card restores its contents", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.UndeleteBlock(board.ID, cardID)
+		require.NoError(t, resp.Error)
+
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		require.NoError(t, resp.Error)
+		require.Len(t, blocks, initialCount)
+	})
+}
@@ -0,0 +1,39 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardActivity(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	_, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+	th.CheckOK(resp)
+
+	t.Run("edits of the board", func(t *testing.T) {
+		activity, resp := th.Client.GetBoardActivity(board.ID, 2, "")
+		th.CheckOK(resp)
+		require.Len(t, activity.Days, 14)
+		require.Equal(t, "UTC", activity.TimeZone)
+		require.Positive(t, activity.Total)
+		total := 0
+		for _, day := range activity.Days {
+			total += day.Count
+		}
+		require.Equal(t, activity.Total, total)
+		require.NotZero(t, activity.LastActivityAt)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		_, resp := th.Client.GetBoardActivity(board.ID, model.MaxActivityWeeks+1, "")
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetBoardActivity(board.ID, 1, "Not/A_Zone")
+		th.CheckBadRequest(resp)
+	})
+}
@@ -0,0 +1,83 @@
+package integrationtests
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardAppearance(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypePrivate)
+
+	t.Run("boards have the default appearance", func(t *testing.T) {
+		appearance, resp := th.Client.GetBoardAppearance(board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, &model.BoardAppearance{Icon: board.Icon, CardPreviewStyle: model.CardPreviewStyleDefault}, appearance)
+	})
+
+	t.Run("set the appearance with a cover image", func(t *testing.T) {
+		file, resp := th.Client.TeamUploadFileWithName(testTeamID, board.ID, "cover.png", bytes.NewBufferString("cover"))
+		th.CheckOK(resp)
+
+		appearance := &model.BoardAppearance{
+			CoverFileID:      file.FileID,
+			Color:            "#1e90ff",
+			Icon:             "🚀",
+			CardPreviewStyle: model.CardPreviewStyleImage,
+		}
+		saved, resp := th.Client.SetBoardAppearance(board.ID, appearance)
+		th.CheckOK(resp)
+		require.Equal(t, appearance, saved)
+
+		// the icon is the one of the board
+		updated, resp := th.Client.GetBoard(board.ID, "")
+		th.CheckOK(resp)
+		require.Equal(t, "🚀", updated.Icon)
+	})
+
+	t.Run("invalid appearances", func(t *testing.T) {
+		_, resp := th.Client.SetBoardAppearance(board.ID, &model.BoardAppearance{Color: "blue"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.SetBoardAppearance(board.ID, &model.BoardAppearance{CoverFileID: "missing.png"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.SetBoardAppearance(board.ID, &model.BoardAppearance{CardPreviewStyle: "huge"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("duplicated boards keep their appearance", func(t *testing.T) {
+		appearance, resp := th.Client.GetBoardAppearance(board.ID)
+		th.CheckOK(resp)
+
+		// only the boards with blocks can be duplicated
+		_, resp = th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+		th.CheckOK(resp)
+
+		bab, resp := th.Client.DuplicateBoard(board.ID, true, testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, bab.Boards, 1)
+
+		duplicated, resp := th.Client.GetBoardAppearance(bab.Boards[0].ID)
+		th.CheckOK(resp)
+		require.Equal(t, appearance, duplicated)
+
+		cover, err := th.Server.App().GetFileReader(testTeamID, bab.Boards[0].ID, appearance.CoverFileID)
+		require.NoError(t, err)
+		cover.Close()
+	})
+
+	t.Run("users without access can't change the appearance", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardAppearance(board.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.SetBoardAppearance(board.ID, &model.BoardAppearance{Color: "#000000"})
+		th.CheckForbidden(resp)
+	})
+}
@@ -0,0 +1,40 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDormantBoards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	t.Run("active boards aren't dormant", func(t *testing.T) {
+		dormant, resp := th.Client.GetDormantBoards("team-id", 1)
+		th.CheckOK(resp)
+		for _, d := range dormant {
+			require.NotEqual(t, board.ID, d.BoardID)
+		}
+
+		dormant, resp = th.Client.GetDormantBoards("team-id", 0)
+		th.CheckOK(resp)
+		require.Empty(t, dormant)
+	})
+
+	t.Run("only team admins get the dormant boards", func(t *testing.T) {
+		_, resp := th.Client2.GetDormantBoards("team-id", 1)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		_, resp := th.Client.GetDormantBoards("team-id", -1)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.GetDormantBoards("team-id", model.MaxDormantBoardDays+1)
+		th.CheckBadRequest(resp)
+	})
+}
@@ -0,0 +1,94 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardFormatting(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: testTeamID,
+		Title:  "Formatting board",
+		Type:   model.BoardTypePrivate,
+		CardProperties: []map[string]interface{}{
+			{"id": "amount", "name": "Amount", "type": model.PropertyTypeNumber, "options": []interface{}{}},
+			{"id": "due", "name": "Due", "type": model.PropertyTypeDate, "options": []interface{}{}},
+		},
+	}
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeCard,
+		Title:    "Invoice",
+		CreateAt: utils.GetMillis(),
+		UpdateAt: utils.GetMillis(),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"amount": "1234567.89",
+			"due":    `{"from":1653523200000}`,
+		}},
+	}
+	babs, resp := th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: []model.Block{card},
+	})
+	th.CheckOK(resp)
+	boardID := babs.Boards[0].ID
+
+	t.Run("boards have no formatting by default", func(t *testing.T) {
+		formatting, resp := th.Client.GetBoardFormatting(boardID)
+		th.CheckOK(resp)
+		require.Equal(t, &model.BoardFormatting{}, formatting)
+
+		data, resp := th.Client.ExportBoardView(boardID, "", model.ExportFormatCSV)
+		th.CheckOK(resp)
+		require.Equal(t, "Title,Amount,Due\nInvoice,1234567.89,2022-05-26\n", string(data))
+	})
+
+	t.Run("exports follow the formatting", func(t *testing.T) {
+		formatting := &model.BoardFormatting{
+			DecimalSeparator:   ",",
+			ThousandsSeparator: ".",
+			DateFormat:         model.DateFormatDotted,
+		}
+		saved, resp := th.Client.SetBoardFormatting(boardID, formatting)
+		th.CheckOK(resp)
+		require.Equal(t, formatting, saved)
+
+		// the formatting is returned with the board
+		updated, resp := th.Client.GetBoard(boardID, "")
+		th.CheckOK(resp)
+		require.NotNil(t, updated.Properties[model.BoardPropertyFormatting])
+
+		data, resp := th.Client.ExportBoardView(boardID, "", model.ExportFormatCSV)
+		th.CheckOK(resp)
+		require.Equal(t, "Title,Amount,Due\nInvoice,\"1.234.567,89\",26.05.2022\n", string(data))
+	})
+
+	t.Run("invalid formatting", func(t *testing.T) {
+		_, resp := th.Client.SetBoardFormatting(boardID, &model.BoardFormatting{DecimalSeparator: ";"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.SetBoardFormatting(boardID, &model.BoardFormatting{DecimalSeparator: ",", ThousandsSeparator: ","})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.SetBoardFormatting(boardID, &model.BoardFormatting{DateFormat: "DD-MM"})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("users without access can't change the formatting", func(t *testing.T) {
+		_, resp := th.Client2.GetBoardFormatting(boardID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.SetBoardFormatting(boardID, &model.BoardFormatting{DateFormat: model.DateFormatISO})
+		th.CheckForbidden(resp)
+	})
+}
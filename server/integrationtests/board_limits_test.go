@@ -0,0 +1,32 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardLimitsExempt(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypeOpen})
+	th.CheckOK(resp)
+
+	t.Run("only team admins exempt a board", func(t *testing.T) {
+		_, resp := th.Client2.SetBoardLimitsExempt(board.ID, true)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("team admins exempt a board", func(t *testing.T) {
+		exempted, resp := th.Client.SetBoardLimitsExempt(board.ID, true)
+		th.CheckOK(resp)
+		require.True(t, model.IsBoardLimitsExempt(exempted))
+
+		exempted, resp = th.Client.SetBoardLimitsExempt(board.ID, false)
+		th.CheckOK(resp)
+		require.False(t, model.IsBoardLimitsExempt(exempted))
+	})
+}
@@ -0,0 +1,118 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardPolicy(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		policy, resp := th.Client.GetTeamBoardPolicy(testTeamID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, policy)
+	})
+
+	t.Run("the default policy and its update", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		policy, resp := th.Client.GetTeamBoardPolicy(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, model.DefaultBoardPolicy(), policy.BoardPolicy)
+
+		policy, resp = th.Client.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{
+			BoardCreators:     model.BoardCreatorsAdmins,
+			DefaultMemberRole: model.BoardRoleCommenter,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, testTeamID, policy.TeamID)
+
+		policy, resp = th.Client.GetTeamBoardPolicy(testTeamID)
+		th.CheckOK(resp)
+		require.True(t, policy.OnlyAdminsCreateBoards())
+		require.Equal(t, model.BoardRoleCommenter, policy.DefaultMemberRole)
+		require.False(t, policy.AllowPublicSharing)
+	})
+
+	t.Run("only team admins update the policy", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client2.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{BoardCreators: model.BoardCreatorsAll})
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{BoardCreators: model.BoardCreatorsAdmins})
+		th.CheckOK(resp)
+
+		_, resp = th.Client2.CreateBoard(&model.Board{Title: "not an admin", TeamID: testTeamID, Type: model.BoardTypeOpen})
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client.CreateBoard(&model.Board{Title: "admin", TeamID: testTeamID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{BoardCreators: "everyone"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{
+			BoardCreators:     model.BoardCreatorsAll,
+			DefaultMemberRole: "owner",
+		})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("new boards get the default member role", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{
+			BoardCreators:      model.BoardCreatorsAll,
+			DefaultMemberRole:  model.BoardRoleViewer,
+			AllowPublicSharing: true,
+		})
+		th.CheckOK(resp)
+
+		board, resp := th.Client.CreateBoard(&model.Board{Title: "default role", TeamID: testTeamID, Type: model.BoardTypeOpen})
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardRoleViewer, board.MinimumRole)
+
+		board, resp = th.Client.CreateBoard(&model.Board{
+			Title:       "explicit role",
+			TeamID:      testTeamID,
+			Type:        model.BoardTypeOpen,
+			MinimumRole: model.BoardRoleEditor,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, model.BoardRoleEditor, board.MinimumRole)
+	})
+
+	t.Run("public sharing can be disallowed", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Server.Config().EnablePublicSharedBoards = true
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		_, resp := th.Client.UpdateTeamBoardPolicy(testTeamID, model.BoardPolicy{BoardCreators: model.BoardCreatorsAll})
+		th.CheckOK(resp)
+
+		success, resp := th.Client.PostSharing(&model.Sharing{ID: board.ID, Enabled: true, Token: "token", UpdateAt: 1})
+		th.CheckForbidden(resp)
+		require.False(t, success)
+
+		success, resp = th.Client.PostSharing(&model.Sharing{ID: board.ID, Enabled: false, Token: "token", UpdateAt: 1})
+		th.CheckOK(resp)
+		require.True(t, success)
+	})
+}
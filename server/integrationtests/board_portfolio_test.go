@@ -0,0 +1,44 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardsPortfolio(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	teamID := "team-id"
+	board1 := th.CreateBoard(teamID, model.BoardTypeOpen)
+	board2 := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+	_, resp := th.Client.CreateCard(board1.ID, &model.Card{Title: "Card 1"})
+	th.CheckOK(resp)
+	_, resp = th.Client.CreateCard(board1.ID, &model.Card{Title: "Card 2"})
+	th.CheckOK(resp)
+
+	t.Run("summaries of the boards", func(t *testing.T) {
+		summaries, resp := th.Client.GetBoardsPortfolio(teamID, []string{board1.ID, board2.ID})
+		th.CheckOK(resp)
+		require.Len(t, summaries, 2)
+		require.Equal(t, board1.ID, summaries[0].BoardID)
+		require.Equal(t, 2, summaries[0].CardCount)
+		require.NotZero(t, summaries[0].LastActivityAt)
+		require.Equal(t, board2.ID, summaries[1].BoardID)
+		require.Equal(t, 0, summaries[1].CardCount)
+	})
+
+	t.Run("no boards", func(t *testing.T) {
+		_, resp := th.Client.GetBoardsPortfolio(teamID, nil)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("board of another team", func(t *testing.T) {
+		other := th.CreateBoard("other-team-id", model.BoardTypeOpen)
+		_, resp := th.Client.GetBoardsPortfolio(teamID, []string{board1.ID, other.ID})
+		th.CheckBadRequest(resp)
+	})
+}
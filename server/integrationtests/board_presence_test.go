@@ -0,0 +1,46 @@
+package integrationtests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardPresence(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	me, resp := th.Client.GetMe()
+	th.CheckOK(resp)
+
+	presence, resp := th.Client.GetBoardPresence(board.ID)
+	th.CheckOK(resp)
+	require.Equal(t, board.ID, presence.BoardID)
+	require.Empty(t, presence.UserIDs)
+
+	wsURL := "ws" + strings.TrimPrefix(th.Server.Config().ServerRoot, "http") + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "AUTH", "token": th.Client.Token}))
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "VIEW_BOARD", "boardId": board.ID}))
+
+	require.Eventually(t, func() bool {
+		presence, resp = th.Client.GetBoardPresence(board.ID)
+		return resp.Error == nil && len(presence.UserIDs) == 1 && presence.UserIDs[0] == me.ID
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// closing the connection leaves the board
+	conn.Close()
+	require.Eventually(t, func() bool {
+		presence, resp = th.Client.GetBoardPresence(board.ID)
+		return resp.Error == nil && len(presence.UserIDs) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -2,6 +2,7 @@ package integrationtests
 
 import (
 	"encoding/json"
+	"net/http"
 	"sort"
 	"testing"
 	"time"
@@ -1936,6 +1937,52 @@ func TestDuplicateBoard(t *testing.T) {
 		require.Equal(t, duplicateBoard.ID, members[0].BoardID)
 		require.True(t, members[0].SchemeAdmin)
 	})
+
+	t.Run("duplicate a board asynchronously", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		board, resp := th.Client.CreateBoard(&model.Board{
+			Title:  "Public board",
+			Type:   model.BoardTypeOpen,
+			TeamID: testTeamID,
+		})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.InsertBlocks(board.ID, []model.Block{
+			{
+				ID:       utils.NewID(utils.IDTypeBlock),
+				BoardID:  board.ID,
+				CreateAt: 1,
+				UpdateAt: 1,
+				Title:    "View 1",
+				Type:     model.TypeView,
+			},
+		})
+		require.NoError(t, resp.Error)
+
+		job, resp := th.Client.DuplicateBoardAsync(board.ID, false, testTeamID)
+		require.NoError(t, resp.Error)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+		require.NotEmpty(t, job.ID)
+
+		require.Eventually(t, func() bool {
+			job, resp = th.Client.GetJob(job.ID)
+			th.CheckOK(resp)
+			return job.IsDone()
+		}, 10*time.Second, 50*time.Millisecond)
+		require.Equal(t, model.JobStatusSuccess, job.Status)
+
+		boardIDs := job.Result["boardIds"].([]interface{})
+		require.Len(t, boardIDs, 1)
+		duplicateBoard, resp := th.Client.GetBoard(boardIDs[0].(string), "")
+		th.CheckOK(resp)
+		require.Equal(t, board.Title+" copy", duplicateBoard.Title)
+
+		// only the user who requested the job can read it
+		_, resp = th.Client2.GetJob(job.ID)
+		th.CheckForbidden(resp)
+	})
 }
 
 func TestJoinBoard(t *testing.T) {
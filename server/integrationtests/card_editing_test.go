@@ -0,0 +1,73 @@
+package integrationtests
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardEditing(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+	th.CheckOK(resp)
+	_, resp = th.Client.AddMemberToBoard(&model.BoardMember{
+		UserID:       th.GetUser2().ID,
+		BoardID:      board.ID,
+		SchemeEditor: true,
+	})
+	th.CheckOK(resp)
+
+	wsURL := "ws" + strings.TrimPrefix(th.Server.Config().ServerRoot, "http") + "/ws"
+	dial := func(token string) *websocket.Conn {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+		require.NoError(t, err)
+		require.NoError(t, conn.WriteJSON(map[string]string{"action": "AUTH", "token": token}))
+		require.NoError(t, conn.WriteJSON(map[string]string{"action": "SUBSCRIBE_TEAM", "teamId": testTeamID}))
+		return conn
+	}
+	// readCardEditing skips the other messages of the board
+	readCardEditing := func(conn *websocket.Conn) map[string]interface{} {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		for {
+			var message map[string]interface{}
+			require.NoError(t, conn.ReadJSON(&message))
+			if message["action"] == "CARD_EDITING" {
+				return message
+			}
+		}
+	}
+
+	editor := dial(th.Client.Token)
+	defer editor.Close()
+	watcher := dial(th.Client2.Token)
+	defer watcher.Close()
+
+	// lets the server process the subscriptions before announcing
+	time.Sleep(200 * time.Millisecond)
+
+	require.NoError(t, editor.WriteJSON(map[string]string{
+		"action":  "START_EDITING_CARD",
+		"blockId": card.ID,
+		"field":   "description",
+	}))
+	message := readCardEditing(watcher)
+	require.Equal(t, card.ID, message["cardId"])
+	require.Equal(t, board.ID, message["boardId"])
+	require.Equal(t, th.GetUser1().ID, message["userId"])
+	require.Equal(t, "description", message["field"])
+	require.Equal(t, true, message["editing"])
+
+	// disconnecting stops the editing
+	editor.Close()
+	message = readCardEditing(watcher)
+	require.Equal(t, card.ID, message["cardId"])
+	require.Equal(t, false, message["editing"])
+}
@@ -0,0 +1,77 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardReminders(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+	th.CheckOK(resp)
+
+	t.Run("reminder in the past", func(t *testing.T) {
+		remindAt := utils.GetMillisForTime(time.Now().Add(-time.Hour))
+		_, resp := th.Client.CreateCardReminder(board.ID, card.ID, &model.CardReminder{RemindAt: remindAt})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("unknown card", func(t *testing.T) {
+		remindAt := utils.GetMillisForTime(time.Now().Add(time.Hour))
+		_, resp := th.Client.CreateCardReminder(board.ID, "unknown", &model.CardReminder{RemindAt: remindAt})
+		th.CheckNotFound(resp)
+	})
+
+	remindAt := utils.GetMillisForTime(time.Now().Add(24 * time.Hour))
+	reminder, resp := th.Client.CreateCardReminder(board.ID, card.ID, &model.CardReminder{RemindAt: remindAt, Note: "Follow up"})
+	th.CheckOK(resp)
+	require.NotEmpty(t, reminder.ID)
+	require.Equal(t, card.ID, reminder.CardID)
+	require.Equal(t, "Follow up", reminder.Note)
+
+	t.Run("reminders are private", func(t *testing.T) {
+		reminders, resp := th.Client.GetMyCardReminders("")
+		th.CheckOK(resp)
+		require.Len(t, reminders, 1)
+
+		reminders, resp = th.Client2.GetMyCardReminders("")
+		th.CheckOK(resp)
+		require.Empty(t, reminders)
+
+		_, resp = th.Client2.SnoozeCardReminder(reminder.ID, remindAt+1000)
+		th.CheckNotFound(resp)
+		_, resp = th.Client2.CancelCardReminder(reminder.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("snooze", func(t *testing.T) {
+		snoozed, resp := th.Client.SnoozeCardReminder(reminder.ID, remindAt+time.Hour.Milliseconds())
+		th.CheckOK(resp)
+		require.Equal(t, remindAt+time.Hour.Milliseconds(), snoozed.RemindAt)
+		require.Equal(t, "Follow up", snoozed.Note)
+
+		reminders, resp := th.Client.GetMyCardReminders(card.ID)
+		th.CheckOK(resp)
+		require.Len(t, reminders, 1)
+		require.Equal(t, snoozed.RemindAt, reminders[0].RemindAt)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		_, resp := th.Client.CancelCardReminder(reminder.ID)
+		th.CheckOK(resp)
+
+		reminders, resp := th.Client.GetMyCardReminders("")
+		th.CheckOK(resp)
+		require.Empty(t, reminders)
+
+		_, resp = th.Client.CancelCardReminder(reminder.ID)
+		th.CheckNotFound(resp)
+	})
+}
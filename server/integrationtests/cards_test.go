@@ -0,0 +1,80 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCards(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+
+	t.Run("create cards", func(t *testing.T) {
+		for _, status := range []string{"todo", "done", "done"} {
+			card, resp := th.Client.CreateCard(board.ID, &model.Card{
+				Title:      "Card " + status,
+				Properties: map[string]interface{}{"status": status},
+			})
+			th.CheckOK(resp)
+			require.NotEmpty(t, card.ID)
+			require.Equal(t, board.ID, card.BoardID)
+			require.Equal(t, status, card.Properties["status"])
+		}
+
+		blocks, resp := th.Client.GetAllBlocksForBoard(board.ID)
+		th.CheckOK(resp)
+		cardBlocks := 0
+		for _, block := range blocks {
+			if block.Type == model.TypeCard {
+				require.Equal(t, board.ID, block.ParentID)
+				cardBlocks++
+			}
+		}
+		require.Equal(t, 3, cardBlocks)
+	})
+
+	t.Run("list cards", func(t *testing.T) {
+		cards, resp := th.Client.GetCards(board.ID, 0, 10, nil)
+		th.CheckOK(resp)
+		require.Len(t, cards, 3)
+
+		cards, resp = th.Client.GetCards(board.ID, 0, 10, map[string]string{"status": "done"})
+		th.CheckOK(resp)
+		require.Len(t, cards, 2)
+
+		cards, resp = th.Client.GetCards(board.ID, 1, 2, nil)
+		th.CheckOK(resp)
+		require.Len(t, cards, 1)
+
+		_, resp = th.Client.GetCards(board.ID, 0, model.MaxCardsPerPage+1, nil)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("patch and delete a card", func(t *testing.T) {
+		card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "To patch"})
+		th.CheckOK(resp)
+
+		title := "Patched"
+		patched, resp := th.Client.PatchCard(board.ID, card.ID, &model.CardPatch{
+			Title:             &title,
+			UpdatedProperties: map[string]interface{}{"status": "done"},
+		})
+		th.CheckOK(resp)
+		require.Equal(t, "Patched", patched.Title)
+		require.Equal(t, "done", patched.Properties["status"])
+
+		fetched, resp := th.Client.GetCard(board.ID, card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, "Patched", fetched.Title)
+
+		_, resp = th.Client.DeleteCard(board.ID, card.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.GetCard(board.ID, card.ID)
+		th.CheckNotFound(resp)
+	})
+}
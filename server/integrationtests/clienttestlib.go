@@ -27,6 +27,7 @@ import (
 const (
 	user1Username = "user1"
 	user2Username = "user2"
+	sysadminUser  = "sysadmin"
 	password      = "Pa$$word"
 )
 
@@ -118,6 +119,7 @@ func getTestConfig() (*config.Configuration, error) {
 		LoggingCfgJSON:    logging,
 		SessionExpireTime: int64(30 * time.Second),
 		AuthMode:          "native",
+		AdminUsers:        []string{user1Username, sysadminUser},
 	}, nil
 }
 
@@ -154,7 +156,7 @@ func newTestServerWithLicense(singleUserToken string, licenseType LicenseType) *
 		db = innerStore
 	}
 
-	permissionsService := localpermissions.New(db, logger)
+	permissionsService := localpermissions.New(db, logger, cfg.AdminUsers)
 
 	params := server.Params{
 		Cfg:                cfg,
@@ -225,7 +227,7 @@ func newTestServerLocalMode() *server.Server {
 		panic(err)
 	}
 
-	permissionsService := localpermissions.New(db, logger)
+	permissionsService := localpermissions.New(db, logger, cfg.AdminUsers)
 
 	params := server.Params{
 		Cfg:                cfg,
@@ -326,14 +328,8 @@ func (th *TestHelper) InitBasic() *TestHelper {
 	// user1
 	th.RegisterAndLogin(th.Client, user1Username, "user1@sample.com", password, "")
 
-	// get token
-	team, resp := th.Client.GetTeam(model.GlobalTeamID)
-	th.CheckOK(resp)
-	require.NotNil(th.T, team)
-	require.NotNil(th.T, team.SignupToken)
-
 	// user2
-	th.RegisterAndLogin(th.Client2, user2Username, "user2@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(th.Client2, user2Username, "user2@sample.com", password, th.CreateInvite().Token)
 
 	return th
 }
@@ -355,12 +351,21 @@ func (th *TestHelper) TearDown() {
 	}
 }
 
-func (th *TestHelper) RegisterAndLogin(client *client.Client, username, email, password, token string) {
+// CreateInvite creates an invite to the global team with the first client,
+// to register more users.
+func (th *TestHelper) CreateInvite() *model.Invite {
+	invite, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{})
+	th.CheckOK(resp)
+	require.NotNil(th.T, invite)
+	return invite
+}
+
+func (th *TestHelper) RegisterAndLogin(client *client.Client, username, email, password, inviteToken string) {
 	req := &api.RegisterRequest{
-		Username: username,
-		Email:    email,
-		Password: password,
-		Token:    token,
+		Username:    username,
+		Email:       email,
+		Password:    password,
+		InviteToken: inviteToken,
 	}
 
 	success, resp := th.Client.Register(req)
@@ -0,0 +1,63 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComments(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard("team-id", model.BoardTypeOpen)
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+	th.CheckOK(resp)
+
+	var comment, reply *model.Comment
+
+	t.Run("add a comment and a reply", func(t *testing.T) {
+		comment, resp = th.Client.AddComment(board.ID, card.ID, &model.Comment{Text: "Ship it"})
+		th.CheckOK(resp)
+		require.NotEmpty(t, comment.ID)
+		require.Equal(t, card.ID, comment.CardID)
+		require.Empty(t, comment.ParentCommentID)
+
+		reply, resp = th.Client.AddComment(board.ID, card.ID, &model.Comment{Text: "Agreed", ParentCommentID: comment.ID})
+		th.CheckOK(resp)
+		require.Equal(t, comment.ID, reply.ParentCommentID)
+
+		comments, resp := th.Client.GetComments(board.ID, card.ID)
+		th.CheckOK(resp)
+		require.Len(t, comments, 2)
+	})
+
+	t.Run("invalid comments", func(t *testing.T) {
+		_, resp := th.Client.AddComment(board.ID, card.ID, &model.Comment{Text: ""})
+		th.CheckBadRequest(resp)
+
+		other, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Other card"})
+		th.CheckOK(resp)
+		_, resp = th.Client.AddComment(board.ID, other.ID, &model.Comment{Text: "Agreed", ParentCommentID: comment.ID})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("edit a comment", func(t *testing.T) {
+		edited, resp := th.Client.EditComment(board.ID, card.ID, comment.ID, "Ship it today")
+		th.CheckOK(resp)
+		require.Equal(t, "Ship it today", edited.Text)
+	})
+
+	t.Run("delete a comment with its replies", func(t *testing.T) {
+		_, resp := th.Client.DeleteComment(board.ID, card.ID, comment.ID)
+		th.CheckOK(resp)
+
+		comments, resp := th.Client.GetComments(board.ID, card.ID)
+		th.CheckOK(resp)
+		require.Empty(t, comments)
+
+		_, resp = th.Client.EditComment(board.ID, card.ID, reply.ID, "Edited")
+		th.CheckNotFound(resp)
+	})
+}
@@ -63,4 +63,94 @@ func TestExportBoard(t *testing.T) {
 		require.Len(t, blocksImported, 1)
 		require.Equal(t, block.Title, blocksImported[0].Title)
 	})
+
+	t.Run("export selected boards of a team", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		exported := th.CreateBoard("test-team", model.BoardTypeOpen)
+		th.CreateBoard("test-team", model.BoardTypeOpen)
+
+		buf, resp := th.Client.ExportTeamBoardsArchive("test-team", []string{exported.ID})
+		th.CheckOK(resp)
+		require.NotNil(t, buf)
+
+		report, resp := th.Client.ValidateArchive("test-team", bytes.NewReader(buf))
+		th.CheckOK(resp)
+		require.Equal(t, 1, report.Boards)
+		require.Empty(t, report.Issues)
+
+		_, resp = th.Client.ExportTeamBoardsArchive("test-team", []string{})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.ExportTeamBoardsArchive("other-team", []string{exported.ID})
+		th.CheckNotFound(resp)
+	})
+}
+
+func TestExportBoardView(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: "test-team",
+		Title:  "Export Test Board",
+		Type:   model.BoardTypeOpen,
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "options": []interface{}{}},
+			{"id": "notes", "name": "Notes", "type": model.PropertyTypeText, "options": []interface{}{}},
+		},
+	}
+	view := model.Block{
+		ID:       utils.NewID(utils.IDTypeView),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeView,
+		Title:    "Table",
+		CreateAt: utils.GetMillis(),
+		UpdateAt: utils.GetMillis(),
+		Fields:   map[string]interface{}{"visiblePropertyIds": []interface{}{"owner"}},
+	}
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeCard,
+		Title:    "Test card",
+		CreateAt: utils.GetMillis(),
+		UpdateAt: utils.GetMillis(),
+		Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"owner": th.GetUser1().ID,
+			"notes": "hidden",
+		}},
+	}
+	babs, resp := th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: []model.Block{view, card},
+	})
+	th.CheckOK(resp)
+	boardID := babs.Boards[0].ID
+
+	t.Run("csv", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardView(boardID, "", model.ExportFormatCSV)
+		th.CheckOK(resp)
+		require.Equal(t, "Title,Owner\nTest card,"+th.GetUser1().Username+"\n", string(data))
+	})
+
+	t.Run("xlsx", func(t *testing.T) {
+		data, resp := th.Client.ExportBoardView(boardID, "", model.ExportFormatXLSX)
+		th.CheckOK(resp)
+		require.Equal(t, "PK", string(data[:2]))
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardView(boardID, "", "pdf")
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("unknown view", func(t *testing.T) {
+		_, resp := th.Client.ExportBoardView(boardID, "missing-view", model.ExportFormatCSV)
+		th.CheckNotFound(resp)
+	})
 }
@@ -0,0 +1,30 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamFeatureFlags(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("team admins override the flags", func(t *testing.T) {
+		flags, resp := th.Client.UpdateTeamFeatureFlags(testTeamID, map[string]string{"automation": "true"})
+		th.CheckOK(resp)
+		require.Equal(t, testTeamID, flags.TeamID)
+
+		flags, resp = th.Client.GetTeamFeatureFlags(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, map[string]string{"automation": "true"}, flags.Overrides)
+	})
+
+	t.Run("only team admins manage the flags", func(t *testing.T) {
+		_, resp := th.Client2.GetTeamFeatureFlags(testTeamID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.UpdateTeamFeatureFlags(testTeamID, map[string]string{"automation": "false"})
+		th.CheckForbidden(resp)
+	})
+}
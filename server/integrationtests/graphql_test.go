@@ -0,0 +1,103 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func enableGraphQL(th *TestHelper) {
+	config := th.Server.App().GetConfig()
+	config.EnableGraphQL = true
+	th.Server.App().SetConfig(config)
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		resp, clientResp := th.Client.GraphQL(`{ boards(teamId: "team-id") { id } }`, nil)
+		th.CheckNotFound(clientResp)
+		require.Nil(t, resp)
+	})
+
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		enableGraphQL(th)
+		th.Logout(th.Client)
+
+		resp, clientResp := th.Client.GraphQL(`{ boards(teamId: "team-id") { id } }`, nil)
+		th.CheckUnauthorized(clientResp)
+		require.Nil(t, resp)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		enableGraphQL(th)
+
+		_, clientResp := th.Client.GraphQL(`{ boards(teamId: "team-id") { name } }`, nil)
+		th.CheckBadRequest(clientResp)
+
+		_, clientResp = th.Client.GraphQL(`mutation { boards(teamId: "team-id") { id } }`, nil)
+		th.CheckBadRequest(clientResp)
+	})
+
+	t.Run("the selected fields of a board and its cards", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		enableGraphQL(th)
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		card, clientResp := th.Client.CreateCard(board.ID, &model.Card{Title: "Launch"})
+		th.CheckOK(clientResp)
+		_, clientResp = th.Client.AddComment(board.ID, card.ID, &model.Comment{Text: "ready"})
+		th.CheckOK(clientResp)
+
+		query := `query Board($id: String!) {
+			board(id: $id) {
+				id
+				cards(perPage: 10) { title comments { text createdBy } }
+				members { userId schemeAdmin }
+			}
+		}`
+		resp, clientResp := th.Client.GraphQL(query, map[string]interface{}{"id": board.ID})
+		th.CheckOK(clientResp)
+		require.Empty(t, resp.Errors)
+		require.Equal(t, map[string]interface{}{
+			"board": map[string]interface{}{
+				"id": board.ID,
+				"cards": []interface{}{
+					map[string]interface{}{
+						"title":    "Launch",
+						"comments": []interface{}{map[string]interface{}{"text": "ready", "createdBy": th.GetUser1().ID}},
+					},
+				},
+				"members": []interface{}{map[string]interface{}{"userId": th.GetUser1().ID, "schemeAdmin": true}},
+			},
+		}, resp.Data)
+	})
+
+	t.Run("boards of other users are denied", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		enableGraphQL(th)
+
+		board, err := th.Server.App().CreateBoard(&model.Board{
+			Title:  "Private board",
+			Type:   model.BoardTypePrivate,
+			TeamID: testTeamID,
+		}, th.GetUser2().ID, true)
+		require.NoError(t, err)
+
+		resp, clientResp := th.Client.GraphQL(`query ($id: String!) { board(id: $id) { title } }`, map[string]interface{}{"id": board.ID})
+		th.CheckOK(clientResp)
+		require.Equal(t, map[string]interface{}{"board": nil}, resp.Data)
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, []interface{}{"board"}, resp.Errors[0].Path)
+	})
+}
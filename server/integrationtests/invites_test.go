@@ -0,0 +1,180 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvites(t *testing.T) {
+	t.Run("not supported in plugin mode", func(t *testing.T) {
+		th := SetupTestHelperPluginMode(t)
+		defer th.TearDown()
+
+		clients := setupClients(th)
+		_, resp := clients.Admin.GetInvites("team-id")
+		th.CheckNotImplemented(resp)
+	})
+
+	t.Run("only admins manage invites", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		invite, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{})
+		th.CheckOK(resp)
+
+		_, resp = th.Client2.GetInvites(model.GlobalTeamID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.CreateInvite(model.GlobalTeamID, &model.Invite{})
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.DeleteInvite(model.GlobalTeamID, invite.ID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invalid invite", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{Role: "owner"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{MaxUses: -1})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("register with a limited-use invite", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		invite, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{MaxUses: 1})
+		th.CheckOK(resp)
+		require.NotEmpty(t, invite.Token)
+		require.Equal(t, model.BoardRoleEditor, invite.Role)
+
+		th.RegisterAndLogin(client.NewClient(th.Server.Config().ServerRoot, ""), "user3", "user3@sample.com", password, invite.Token)
+
+		success, resp := th.Client.Register(&api.RegisterRequest{
+			Username:    "user4",
+			Email:       "user4@sample.com",
+			Password:    password,
+			InviteToken: invite.Token,
+		})
+		th.CheckUnauthorized(resp)
+		require.False(t, success)
+
+		invites, resp := th.Client.GetInvites(model.GlobalTeamID)
+		th.CheckOK(resp)
+		var used *model.Invite
+		for _, i := range invites {
+			if i.ID == invite.ID {
+				used = i
+			}
+		}
+		require.NotNil(t, used)
+		require.Equal(t, 1, used.UseCount)
+	})
+
+	t.Run("expired invite", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		invite, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{ExpiresAt: 1})
+		th.CheckOK(resp)
+
+		success, resp := th.Client.Register(&api.RegisterRequest{
+			Username:    "user3",
+			Email:       "user3@sample.com",
+			Password:    password,
+			InviteToken: invite.Token,
+		})
+		th.CheckUnauthorized(resp)
+		require.False(t, success)
+	})
+
+	t.Run("invited users join the open boards with the role", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		openBoard := th.CreateBoard(model.GlobalTeamID, model.BoardTypeOpen)
+		privateBoard := th.CreateBoard(model.GlobalTeamID, model.BoardTypePrivate)
+
+		invite, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{Role: model.BoardRoleCommenter})
+		th.CheckOK(resp)
+
+		client3 := client.NewClient(th.Server.Config().ServerRoot, "")
+		th.RegisterAndLogin(client3, "user3", "user3@sample.com", password, invite.Token)
+		user3 := th.Me(client3)
+
+		members, resp := th.Client.GetMembersForBoard(openBoard.ID)
+		th.CheckOK(resp)
+		var member *model.BoardMember
+		for _, m := range members {
+			if m.UserID == user3.ID {
+				member = m
+			}
+		}
+		require.NotNil(t, member)
+		require.True(t, member.SchemeCommenter)
+		require.False(t, member.SchemeEditor)
+		require.False(t, member.SchemeAdmin)
+
+		members, resp = th.Client.GetMembersForBoard(privateBoard.ID)
+		th.CheckOK(resp)
+		for _, m := range members {
+			require.NotEqual(t, user3.ID, m.UserID)
+		}
+	})
+
+	t.Run("deleted invite", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		invite, resp := th.Client.CreateInvite(model.GlobalTeamID, &model.Invite{})
+		th.CheckOK(resp)
+
+		success, resp := th.Client.DeleteInvite(model.GlobalTeamID, invite.ID)
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		_, resp = th.Client.DeleteInvite(model.GlobalTeamID, invite.ID)
+		th.CheckNotFound(resp)
+
+		success, resp = th.Client.Register(&api.RegisterRequest{
+			Username:    "user3",
+			Email:       "user3@sample.com",
+			Password:    password,
+			InviteToken: invite.Token,
+		})
+		th.CheckUnauthorized(resp)
+		require.False(t, success)
+	})
+
+	t.Run("the shared signup token is disabled by default", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		team, resp := th.Client.GetTeam(model.GlobalTeamID)
+		th.CheckOK(resp)
+
+		registerRequest := &api.RegisterRequest{
+			Username: "user3",
+			Email:    "user3@sample.com",
+			Password: password,
+			Token:    team.SignupToken,
+		}
+		success, resp := th.Client.Register(registerRequest)
+		th.CheckUnauthorized(resp)
+		require.False(t, success)
+
+		th.Server.Config().EnableSignupToken = true
+
+		success, resp = th.Client.Register(registerRequest)
+		th.CheckOK(resp)
+		require.True(t, success)
+	})
+}
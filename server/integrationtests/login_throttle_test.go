@@ -0,0 +1,102 @@
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/api"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottle(t *testing.T) {
+	badLogin := func(username string) *api.LoginRequest {
+		return &api.LoginRequest{Type: "normal", Username: username, Password: "wrong-password"}
+	}
+
+	t.Run("accounts are locked out after too many failed logins", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.Config()
+		config.LoginMaxAttemptsPerAccount = 3
+		config.LoginAttemptWindow = 60
+		config.LoginLockoutDuration = 100
+
+		for i := 0; i < 3; i++ {
+			_, resp := th.Client.Login(badLogin(user1Username))
+			th.CheckUnauthorized(resp)
+		}
+
+		// even the right password is rejected during the lockout
+		data, resp := th.Client.Login(&api.LoginRequest{Type: "normal", Username: user1Username, Password: password})
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Nil(t, data)
+		require.Equal(t, "100", resp.Header.Get(api.HeaderRetryAfter))
+
+		// the other accounts are not locked out
+		th.Login2()
+	})
+
+	t.Run("a successful login resets the failures of the account", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.Config()
+		config.LoginMaxAttemptsPerAccount = 3
+		config.LoginAttemptWindow = 60
+		config.LoginLockoutDuration = 100
+
+		for i := 0; i < 2; i++ {
+			_, resp := th.Client.Login(badLogin(user1Username))
+			th.CheckUnauthorized(resp)
+		}
+		th.Login1()
+
+		for i := 0; i < 2; i++ {
+			_, resp := th.Client.Login(badLogin(user1Username))
+			th.CheckUnauthorized(resp)
+		}
+		th.Login1()
+	})
+
+	t.Run("addresses are locked out after too many failed logins", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.Config()
+		config.LoginMaxAttemptsPerIP = 2
+		config.LoginAttemptWindow = 60
+		config.LoginLockoutDuration = 100
+
+		_, resp := th.Client.Login(badLogin("someone"))
+		th.CheckUnauthorized(resp)
+		_, resp = th.Client.Login(badLogin("someone-else"))
+		th.CheckUnauthorized(resp)
+
+		_, resp = th.Client2.Login(&api.LoginRequest{Type: "normal", Username: user2Username, Password: password})
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	})
+
+	t.Run("clients behind a trusted proxy are locked out by their own address", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.Config()
+		config.LoginMaxAttemptsPerIP = 2
+		config.LoginAttemptWindow = 60
+		config.LoginLockoutDuration = 100
+		config.TrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+
+		th.Client.HTTPHeader["X-Forwarded-For"] = "198.51.100.1"
+		_, resp := th.Client.Login(badLogin("someone"))
+		th.CheckUnauthorized(resp)
+		_, resp = th.Client.Login(badLogin("someone-else"))
+		th.CheckUnauthorized(resp)
+		_, resp = th.Client.Login(badLogin("someone"))
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		th.Client2.HTTPHeader["X-Forwarded-For"] = "198.51.100.2"
+		th.Login2()
+	})
+}
@@ -0,0 +1,80 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMilestones(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	teamID := "team-id"
+	board1 := th.CreateBoard(teamID, model.BoardTypeOpen)
+	board2 := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+	t.Run("invalid milestone", func(t *testing.T) {
+		_, resp := th.Client.CreateMilestone(teamID, &model.Milestone{Title: " "})
+		th.CheckBadRequest(resp)
+	})
+
+	dueDate := utils.GetMillisForTime(time.Now().Add(72 * time.Hour))
+	milestone, resp := th.Client.CreateMilestone(teamID, &model.Milestone{Title: "Beta", DueDate: dueDate})
+	th.CheckOK(resp)
+	require.NotEmpty(t, milestone.ID)
+	require.Equal(t, teamID, milestone.TeamID)
+
+	t.Run("list and update milestones", func(t *testing.T) {
+		milestones, resp := th.Client.GetMilestones(teamID)
+		th.CheckOK(resp)
+		require.Len(t, milestones, 1)
+
+		milestone.Description = "First public release"
+		updated, resp := th.Client.UpdateMilestone(teamID, milestone)
+		th.CheckOK(resp)
+		require.Equal(t, "First public release", updated.Description)
+	})
+
+	t.Run("attach cards of several boards", func(t *testing.T) {
+		card1, resp := th.Client.CreateCard(board1.ID, &model.Card{Title: "Card 1"})
+		th.CheckOK(resp)
+		card2, resp := th.Client.CreateCard(board2.ID, &model.Card{Title: "Card 2"})
+		th.CheckOK(resp)
+
+		_, resp = th.Client.SetCardMilestone(board1.ID, card1.ID, milestone.ID)
+		th.CheckOK(resp)
+		_, resp = th.Client.SetCardMilestone(board2.ID, card2.ID, milestone.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.SetCardMilestone(board1.ID, card1.ID, "unknown")
+		th.CheckBadRequest(resp)
+
+		progress, resp := th.Client.GetMilestonesProgress(teamID)
+		th.CheckOK(resp)
+		require.Len(t, progress, 1)
+		require.Equal(t, 2, progress[0].Total)
+		require.Equal(t, 0, progress[0].Done)
+		require.True(t, progress[0].AtRisk)
+		require.Len(t, progress[0].Boards, 2)
+
+		card, resp := th.Client.SetCardMilestone(board2.ID, card2.ID, "")
+		th.CheckOK(resp)
+		require.Empty(t, model.CardMilestone(card))
+
+		progress, resp = th.Client.GetMilestonesProgress(teamID)
+		th.CheckOK(resp)
+		require.Equal(t, 1, progress[0].Total)
+	})
+
+	t.Run("delete the milestone", func(t *testing.T) {
+		_, resp := th.Client.DeleteMilestone(teamID, milestone.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.DeleteMilestone(teamID, milestone.ID)
+		th.CheckNotFound(resp)
+	})
+}
@@ -0,0 +1,73 @@
+package integrationtests
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/focalboard/server/client"
+
+	"github.com/stretchr/testify/require"
+)
+
+var swaggerOperationRegexp = regexp.MustCompile(`swagger:operation (\w+) (\S+) \w+`)
+
+func TestGetOpenAPISpec(t *testing.T) {
+	th := SetupTestHelper(t).Start()
+	defer th.TearDown()
+
+	spec, resp := th.Client.GetOpenAPISpec()
+	th.CheckOK(resp)
+	require.Equal(t, "3.0.3", spec["openapi"])
+
+	paths, ok := spec["paths"].(map[string]interface{})
+	require.True(t, ok)
+	require.Contains(t, paths, "/openapi.json")
+	require.Contains(t, paths, "/boards/{boardID}/blocks")
+}
+
+// TestAPIRoutesDocumented checks that every route of the API has a swagger
+// comment in its handler, from which `make swagger` generates the spec,
+// and that every swagger comment documents a route.
+func TestAPIRoutesDocumented(t *testing.T) {
+	th := SetupTestHelper(t)
+	defer th.TearDown()
+
+	routes := map[string]bool{}
+	err := th.Server.GetRootRouter().Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, pathErr := route.GetPathTemplate()
+		methods, methodsErr := route.GetMethods()
+		if pathErr != nil || methodsErr != nil {
+			// path prefixes and routes for any method aren't API routes
+			return nil
+		}
+		for _, method := range methods {
+			routes[method+" "+strings.TrimPrefix(path, client.APIURLSuffix)] = true
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, routes)
+
+	files, err := filepath.Glob(filepath.Join("..", "api", "*.go"))
+	require.NoError(t, err)
+
+	documented := map[string]bool{}
+	for _, file := range files {
+		data, readErr := ioutil.ReadFile(file)
+		require.NoError(t, readErr)
+		for _, match := range swaggerOperationRegexp.FindAllStringSubmatch(string(data), -1) {
+			documented[match[1]+" "+match[2]] = true
+		}
+	}
+
+	for route := range routes {
+		require.True(t, documented[route], "route %s has no swagger:operation comment", route)
+	}
+	for operation := range documented {
+		require.True(t, routes[operation], "swagger:operation %s has no route", operation)
+	}
+}
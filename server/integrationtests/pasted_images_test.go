@@ -0,0 +1,82 @@
+package integrationtests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasteImage(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 2, 2))))
+	pngData := buf.Bytes()
+
+	board := th.CreateBoard("team-id", model.BoardTypePrivate)
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+	th.CheckOK(resp)
+
+	t.Run("paste image bytes", func(t *testing.T) {
+		block, resp := th.Client.PasteImage(board.ID, card.ID, pngData)
+		th.CheckOK(resp)
+		require.Equal(t, model.BlockType(model.TypeImage), block.Type)
+		require.Equal(t, card.ID, block.ParentID)
+		require.True(t, strings.HasPrefix(block.Title, "pasted-image-"))
+		require.True(t, strings.HasSuffix(block.Title, ".png"))
+		require.NotEmpty(t, block.Fields["fileId"])
+
+		updated, resp := th.Client.GetCard(board.ID, card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, []string{block.ID}, updated.ContentOrder)
+	})
+
+	t.Run("paste a data URL", func(t *testing.T) {
+		dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(pngData)
+		block, resp := th.Client.PasteImage(board.ID, card.ID, []byte(dataURL))
+		th.CheckOK(resp)
+
+		updated, resp := th.Client.GetCard(board.ID, card.ID)
+		th.CheckOK(resp)
+		require.Len(t, updated.ContentOrder, 2)
+		require.Equal(t, block.ID, updated.ContentOrder[1])
+	})
+
+	t.Run("invalid images", func(t *testing.T) {
+		_, resp := th.Client.PasteImage(board.ID, card.ID, []byte("not an image"))
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.PasteImage(board.ID, card.ID, []byte("data:image/png,raw"))
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.PasteImage(board.ID, "unknown-card", pngData)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("too large images", func(t *testing.T) {
+		config := th.Server.App().GetConfig()
+		maxFileSize := config.MaxFileSize
+		config.MaxFileSize = 10
+		th.Server.App().SetConfig(config)
+		defer func() {
+			config.MaxFileSize = maxFileSize
+			th.Server.App().SetConfig(config)
+		}()
+
+		_, resp := th.Client.PasteImage(board.ID, card.ID, pngData)
+		th.CheckRequestEntityTooLarge(resp)
+	})
+
+	t.Run("users without access can't paste", func(t *testing.T) {
+		_, resp := th.Client2.PasteImage(board.ID, card.ID, pngData)
+		th.CheckForbidden(resp)
+	})
+}
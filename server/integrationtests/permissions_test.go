@@ -76,7 +76,7 @@ func setupClients(th *TestHelper) Clients {
 
 func setupLocalClients(th *TestHelper) Clients {
 	th.Client = client.NewClient(th.Server.Config().ServerRoot, "")
-	th.RegisterAndLogin(th.Client, "sysadmin", "sysadmin@sample.com", password, "")
+	th.RegisterAndLogin(th.Client, sysadminUser, sysadminUser+"@sample.com", password, "")
 
 	clients := Clients{
 		Anon:         client.NewClient(th.Server.Config().ServerRoot, ""),
@@ -88,28 +88,24 @@ func setupLocalClients(th *TestHelper) Clients {
 		Admin:        client.NewClient(th.Server.Config().ServerRoot, ""),
 	}
 
-	// get token
-	team, resp := th.Client.GetTeam(model.GlobalTeamID)
-	th.CheckOK(resp)
-	require.NotNil(th.T, team)
-	require.NotNil(th.T, team.SignupToken)
+	inviteToken := th.CreateInvite().Token
 
-	th.RegisterAndLogin(clients.NoTeamMember, userNoTeamMember, userNoTeamMember+"@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(clients.NoTeamMember, userNoTeamMember, userNoTeamMember+"@sample.com", password, inviteToken)
 	userNoTeamMemberID = clients.NoTeamMember.GetUserID()
 
-	th.RegisterAndLogin(clients.TeamMember, userTeamMember, userTeamMember+"@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(clients.TeamMember, userTeamMember, userTeamMember+"@sample.com", password, inviteToken)
 	userTeamMemberID = clients.TeamMember.GetUserID()
 
-	th.RegisterAndLogin(clients.Viewer, userViewer, userViewer+"@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(clients.Viewer, userViewer, userViewer+"@sample.com", password, inviteToken)
 	userViewerID = clients.Viewer.GetUserID()
 
-	th.RegisterAndLogin(clients.Commenter, userCommenter, userCommenter+"@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(clients.Commenter, userCommenter, userCommenter+"@sample.com", password, inviteToken)
 	userCommenterID = clients.Commenter.GetUserID()
 
-	th.RegisterAndLogin(clients.Editor, userEditor, userEditor+"@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(clients.Editor, userEditor, userEditor+"@sample.com", password, inviteToken)
 	userEditorID = clients.Editor.GetUserID()
 
-	th.RegisterAndLogin(clients.Admin, userAdmin, userAdmin+"@sample.com", password, team.SignupToken)
+	th.RegisterAndLogin(clients.Admin, userAdmin, userAdmin+"@sample.com", password, inviteToken)
 	userAdminID = clients.Admin.GetUserID()
 
 	return clients
@@ -1004,32 +1000,32 @@ func TestPermissionsPatchBoardBlocks(t *testing.T) {
 		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userViewer, http.StatusForbidden, 0},
 		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userCommenter, http.StatusForbidden, 0},
-		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userEditor, http.StatusOK, 0},
-		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userAdmin, http.StatusOK, 0},
+		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userEditor, http.StatusOK, 1},
+		{"/boards/{PRIVATE_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-4"), userAdmin, http.StatusOK, 1},
 
 		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userAnon, http.StatusUnauthorized, 0},
 		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userNoTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userViewer, http.StatusForbidden, 0},
 		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userCommenter, http.StatusForbidden, 0},
-		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userEditor, http.StatusOK, 0},
-		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userAdmin, http.StatusOK, 0},
+		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userEditor, http.StatusOK, 1},
+		{"/boards/{PUBLIC_BOARD_ID}/blocks", methodPatch, newBlocksPatchJSON("block-3"), userAdmin, http.StatusOK, 1},
 
 		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userAnon, http.StatusUnauthorized, 0},
 		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userNoTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userViewer, http.StatusForbidden, 0},
 		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userCommenter, http.StatusForbidden, 0},
-		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userEditor, http.StatusOK, 0},
-		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userAdmin, http.StatusOK, 0},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userEditor, http.StatusOK, 1},
+		{"/boards/{PRIVATE_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-2"), userAdmin, http.StatusOK, 1},
 
 		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userAnon, http.StatusUnauthorized, 0},
 		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userNoTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userTeamMember, http.StatusForbidden, 0},
 		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userViewer, http.StatusForbidden, 0},
 		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userCommenter, http.StatusForbidden, 0},
-		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userEditor, http.StatusOK, 0},
-		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userAdmin, http.StatusOK, 0},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userEditor, http.StatusOK, 1},
+		{"/boards/{PUBLIC_TEMPLATE_ID}/blocks", methodPatch, newBlocksPatchJSON("block-1"), userAdmin, http.StatusOK, 1},
 	}
 
 	t.Run("plugin", func(t *testing.T) {
@@ -2539,15 +2535,23 @@ func TestPermissionsRegister(t *testing.T) {
 		require.NotNil(th.T, team)
 		require.NotNil(th.T, team.SignupToken)
 
-		postData := toJSON(t, api.RegisterRequest{
+		signupTokenData := toJSON(t, api.RegisterRequest{
 			Username: "newuser",
 			Email:    "newuser@test.com",
 			Password: password,
 			Token:    team.SignupToken,
 		})
 
+		inviteData := toJSON(t, api.RegisterRequest{
+			Username:    "newuser",
+			Email:       "newuser@test.com",
+			Password:    password,
+			InviteToken: th.CreateInvite().Token,
+		})
+
 		ttCases := []TestCase{
-			{"/register", methodPost, postData, userAnon, http.StatusOK, 0},
+			{"/register", methodPost, signupTokenData, userAnon, http.StatusUnauthorized, 0},
+			{"/register", methodPost, inviteData, userAnon, http.StatusOK, 0},
 		}
 		runTestCases(t, ttCases, testData, clients)
 	})
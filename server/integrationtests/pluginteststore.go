@@ -2,7 +2,9 @@ package integrationtests
 
 import (
 	"errors"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/store"
@@ -158,6 +160,14 @@ func (s *PluginTestStore) PatchUserProps(userID string, patch model.UserPropPatc
 	return nil
 }
 
+func (s *PluginTestStore) GetUserAvailability(userID string) (*model.UserAvailability, error) {
+	user, err := s.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.UserAvailabilityFromProps(userID, user.Props, time.Now()), nil
+}
+
 func (s *PluginTestStore) GetUsersByTeam(teamID string) ([]*model.User, error) {
 	switch {
 	case teamID == s.testTeam.ID:
@@ -196,3 +206,27 @@ func (s *PluginTestStore) SearchUsersByTeam(teamID string, searchQuery string) (
 	}
 	return users, nil
 }
+
+func (s *PluginTestStore) SearchUserDirectory(teamID string, prefix string, offset int, limit int) ([]*model.UserDirectoryEntry, error) {
+	teamUsers, err := s.GetUsersByTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []*model.UserDirectoryEntry{}
+	for _, user := range teamUsers {
+		if strings.HasPrefix(strings.ToLower(user.Username), strings.ToLower(prefix)) {
+			users = append(users, &model.UserDirectoryEntry{ID: user.ID, Username: user.Username, IsBot: user.IsBot})
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+
+	if offset >= len(users) {
+		return []*model.UserDirectoryEntry{}, nil
+	}
+	users = users[offset:]
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
@@ -0,0 +1,88 @@
+package integrationtests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Run("requests are not limited by default", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		for i := 0; i < 20; i++ {
+			_, resp := th.Client.GetMe()
+			th.CheckOK(resp)
+		}
+	})
+
+	t.Run("users exceeding their rate are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.App().GetConfig()
+		config.RateLimitUserPerSecond = 0.01
+		config.RateLimitUserBurst = 2
+		th.Server.App().SetConfig(config)
+
+		for i := 0; i < 2; i++ {
+			_, resp := th.Client.GetMe()
+			th.CheckOK(resp)
+		}
+
+		me, resp := th.Client.GetMe()
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Error(t, resp.Error)
+		require.Nil(t, me)
+		require.Equal(t, "100", resp.Header.Get(api.HeaderRetryAfter))
+
+		// the other users have their own limit
+		_, resp = th.Client2.GetMe()
+		th.CheckOK(resp)
+	})
+
+	t.Run("addresses exceeding their rate are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.App().GetConfig()
+		config.RateLimitIPPerSecond = 0.01
+		config.RateLimitIPBurst = 1
+		th.Server.App().SetConfig(config)
+
+		_, resp := th.Client.GetMe()
+		th.CheckOK(resp)
+
+		// all the users of the address share the limit
+		_, resp = th.Client2.GetMe()
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, "100", resp.Header.Get(api.HeaderRetryAfter))
+	})
+	t.Run("anonymous submissions exceeding their rate are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Server.Config().EnablePublicSharedBoards = true
+		th.Server.Config().SubmissionsPerMinute = 1
+
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		sharingToken := utils.NewID(utils.IDTypeToken)
+		_, resp := th.Client.PostSharing(&model.Sharing{ID: board.ID, Enabled: true, Token: sharingToken, UpdateAt: 1})
+		th.CheckOK(resp)
+		th.Logout(th.Client)
+
+		// the limit is checked before the submission, which takes a token
+		// even if it is rejected
+		_, resp = th.Client.SubmitCard(board.ID, sharingToken, &model.CardSubmission{Title: "Feedback"})
+		require.NotEqual(t, http.StatusTooManyRequests, resp.StatusCode)
+
+		_, resp = th.Client.SubmitCard(board.ID, sharingToken, &model.CardSubmission{Title: "Feedback"})
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.Equal(t, "60", resp.Header.Get(api.HeaderRetryAfter))
+	})
+}
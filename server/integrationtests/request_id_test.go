@@ -0,0 +1,48 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/api"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("each request gets an ID", func(t *testing.T) {
+		_, resp := th.Client.GetMe()
+		th.CheckOK(resp)
+		first := resp.Header.Get(api.HeaderRequestID)
+		require.NotEmpty(t, first)
+
+		_, resp = th.Client.GetMe()
+		th.CheckOK(resp)
+		require.NotEqual(t, first, resp.Header.Get(api.HeaderRequestID))
+	})
+
+	t.Run("the ID is in the error responses", func(t *testing.T) {
+		_, resp := th.Client.GetBoard("not-a-board", "")
+		require.Error(t, resp.Error)
+		requestID := resp.Header.Get(api.HeaderRequestID)
+		require.NotEmpty(t, requestID)
+		require.Contains(t, resp.Error.Error(), `"requestId":"`+requestID+`"`)
+	})
+
+	t.Run("the ID of the client is kept if valid", func(t *testing.T) {
+		th.Client.HTTPHeader[api.HeaderRequestID] = "client-request-1"
+		defer delete(th.Client.HTTPHeader, api.HeaderRequestID)
+
+		_, resp := th.Client.GetMe()
+		th.CheckOK(resp)
+		require.Equal(t, "client-request-1", resp.Header.Get(api.HeaderRequestID))
+
+		th.Client.HTTPHeader[api.HeaderRequestID] = "not a valid id!"
+		_, resp = th.Client.GetMe()
+		th.CheckOK(resp)
+		require.NotEqual(t, "not a valid id!", resp.Header.Get(api.HeaderRequestID))
+		require.NotEmpty(t, resp.Header.Get(api.HeaderRequestID))
+	})
+}
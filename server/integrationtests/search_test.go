@@ -0,0 +1,230 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		results, resp := th.Client.Search(testTeamID, "term", nil, 0, 10)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, results)
+	})
+
+	t.Run("invalid type or paging", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.Search(testTeamID, "term", []model.SearchResultType{"view"}, 0, 10)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.Search(testTeamID, "term", nil, 0, model.MaxSearchResultsPerPage+1)
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.Search(testTeamID, "term updated_after:yesterday", nil, 0, 10)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("ranked results of the boards the user can see", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user1 := th.GetUser1()
+		user2 := th.GetUser2()
+
+		board, err := th.Server.App().CreateBoard(&model.Board{
+			Title:  "Launch board",
+			Type:   model.BoardTypePrivate,
+			TeamID: testTeamID,
+		}, user1.ID, true)
+		require.NoError(t, err)
+
+		otherBoard, err := th.Server.App().CreateBoard(&model.Board{
+			Title:  "Launch secrets",
+			Type:   model.BoardTypePrivate,
+			TeamID: testTeamID,
+		}, user2.ID, true)
+		require.NoError(t, err)
+
+		cardID := utils.NewID(utils.IDTypeCard)
+		inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{
+			{ID: cardID, BoardID: board.ID, Type: model.TypeCard, Title: "Launch", CreateAt: 1, UpdateAt: 1},
+			{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: cardID, Type: model.TypeComment, Title: "ready to launch", CreateAt: 1, UpdateAt: 1},
+		})
+		th.CheckOK(resp)
+		// the server assigns new IDs to the inserted blocks
+		cardID = inserted[0].ID
+
+		_, err = th.Server.App().InsertBlocks([]model.Block{
+			{ID: utils.NewID(utils.IDTypeCard), BoardID: otherBoard.ID, Type: model.TypeCard, Title: "Launch", CreateAt: 1, UpdateAt: 1},
+		}, user2.ID, false)
+		require.NoError(t, err)
+
+		results, resp := th.Client.Search(testTeamID, "launch", nil, 0, 2)
+		th.CheckOK(resp)
+		require.Equal(t, 3, results.Total)
+		require.Len(t, results.Results, 2)
+		require.Equal(t, cardID, results.Results[0].ID)
+		require.Equal(t, model.SearchResultTypeCard, results.Results[0].Type)
+		require.Equal(t, board.ID, results.Results[1].ID)
+		require.Equal(t, map[model.SearchResultType]int{
+			model.SearchResultTypeBoard:      1,
+			model.SearchResultTypeCard:       1,
+			model.SearchResultTypeComment:    1,
+			model.SearchResultTypeAttachment: 0,
+		}, results.Facets)
+
+		results, resp = th.Client.Search(testTeamID, "launch", nil, 1, 2)
+		th.CheckOK(resp)
+		require.Len(t, results.Results, 1)
+		require.Equal(t, model.SearchResultTypeComment, results.Results[0].Type)
+		require.Equal(t, cardID, results.Results[0].ParentID)
+
+		results, resp = th.Client.Search(testTeamID, "launch", []model.SearchResultType{model.SearchResultTypeComment}, 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 1, results.Total)
+		require.Equal(t, "ready to launch", results.Results[0].Title)
+	})
+
+	t.Run("results filtered by card properties and assignee", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user1 := th.GetUser1()
+
+		board, err := th.Server.App().CreateBoard(&model.Board{
+			Title:  "Launch board",
+			Type:   model.BoardTypePrivate,
+			TeamID: testTeamID,
+			CardProperties: []map[string]interface{}{
+				{"id": "status", "name": "Status", "type": model.PropertyTypeSelect, "options": []interface{}{
+					map[string]interface{}{"id": "status-done", "value": "Done", "color": "propColorGreen"},
+					map[string]interface{}{"id": "status-todo", "value": "To do", "color": "propColorGray"},
+				}},
+				{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "assignee": true},
+			},
+		}, user1.ID, true)
+		require.NoError(t, err)
+
+		doneCardID := utils.NewID(utils.IDTypeCard)
+		inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{
+			{ID: doneCardID, BoardID: board.ID, Type: model.TypeCard, Title: "Launch", CreateAt: 1, UpdateAt: 1,
+				Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "status-done", "owner": user1.ID}}},
+			{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, Type: model.TypeCard, Title: "Launch party", CreateAt: 1, UpdateAt: 1,
+				Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "status-todo", "owner": user1.ID}}},
+		})
+		th.CheckOK(resp)
+		doneCardID = inserted[0].ID
+
+		results, resp := th.Client.Search(testTeamID, "launch property:Status=done", nil, 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 1, results.Total)
+		require.Equal(t, doneCardID, results.Results[0].ID)
+
+		results, resp = th.Client.Search(testTeamID, "assignee:@"+user1.Username, nil, 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 2, results.Total)
+
+		results, resp = th.Client.Search(testTeamID, `property:Status="To do" assignee:@me`, nil, 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 1, results.Total)
+		require.Equal(t, "Launch party", results.Results[0].Title)
+
+		results, resp = th.Client.Search(testTeamID, "launch property:Priority=High", nil, 0, 10)
+		th.CheckOK(resp)
+		require.Zero(t, results.Total)
+
+		results, resp = th.Client.Search(testTeamID, "launch updated_after:2999-01-01", nil, 0, 10)
+		th.CheckOK(resp)
+		require.Zero(t, results.Total)
+	})
+}
+
+func TestSearchCards(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		results, resp := th.Client.SearchCards(testTeamID, "term", 0, 10)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, results)
+	})
+
+	t.Run("cards matching by their title, text and comments", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		user2 := th.GetUser2()
+		board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+		otherBoard, err := th.Server.App().CreateBoard(&model.Board{
+			Title:  "Secrets",
+			Type:   model.BoardTypePrivate,
+			TeamID: testTeamID,
+		}, user2.ID, true)
+		require.NoError(t, err)
+
+		cardID := utils.NewID(utils.IDTypeCard)
+		otherCardID := utils.NewID(utils.IDTypeCard)
+		commentID := utils.NewID(utils.IDTypeBlock)
+		inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{
+			{ID: cardID, BoardID: board.ID, Type: model.TypeCard, Title: "Release planning", CreateAt: 1, UpdateAt: 1},
+			{ID: utils.NewID(utils.IDTypeBlock), BoardID: board.ID, ParentID: cardID, Type: model.TypeText, Title: "Write the release notes", CreateAt: 1, UpdateAt: 1},
+			{ID: otherCardID, BoardID: board.ID, Type: model.TypeCard, Title: "Login page", CreateAt: 1, UpdateAt: 1},
+			{ID: commentID, BoardID: board.ID, ParentID: otherCardID, Type: model.TypeComment, Title: "Blocked by the release", CreateAt: 1, UpdateAt: 1},
+			{ID: utils.NewID(utils.IDTypeCard), BoardID: board.ID, Type: model.TypeCard, Title: "Release template", Fields: map[string]interface{}{"isTemplate": true}, CreateAt: 1, UpdateAt: 1},
+		})
+		th.CheckOK(resp)
+		// the server assigns new IDs to the inserted blocks
+		cardID, otherCardID, commentID = inserted[0].ID, inserted[2].ID, inserted[3].ID
+
+		_, err = th.Server.App().InsertBlocks([]model.Block{
+			{ID: utils.NewID(utils.IDTypeCard), BoardID: otherBoard.ID, Type: model.TypeCard, Title: "Release secrets", CreateAt: 1, UpdateAt: 1},
+		}, user2.ID, false)
+		require.NoError(t, err)
+
+		results, resp := th.Client.SearchCards(testTeamID, "release", 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 2, results.Total)
+		require.Equal(t, cardID, results.Results[0].CardID)
+		require.Equal(t, board.ID, results.Results[0].BoardID)
+		require.Len(t, results.Results[0].Highlights, 2)
+		require.Equal(t, "<mark>Release</mark> planning", results.Results[0].Highlights[0].Fragment)
+
+		require.Equal(t, otherCardID, results.Results[1].CardID)
+		require.Equal(t, []*model.CardSearchHighlight{
+			{BlockID: commentID, Type: model.TypeComment, Fragment: "Blocked by the <mark>release</mark>"},
+		}, results.Results[1].Highlights)
+
+		results, resp = th.Client.SearchCards(testTeamID, "release", 1, 1)
+		th.CheckOK(resp)
+		require.Equal(t, 2, results.Total)
+		require.Len(t, results.Results, 1)
+		require.Equal(t, otherCardID, results.Results[0].CardID)
+
+		// the index follows the changes of the blocks
+		newTitle := "Signup page"
+		_, resp = th.Client.PatchBlock(board.ID, otherCardID, &model.BlockPatch{Title: &newTitle})
+		th.CheckOK(resp)
+		_, resp = th.Client.DeleteBlock(board.ID, commentID)
+		th.CheckOK(resp)
+
+		results, resp = th.Client.SearchCards(testTeamID, "release", 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 1, results.Total)
+
+		results, resp = th.Client.SearchCards(testTeamID, "signup", 0, 10)
+		th.CheckOK(resp)
+		require.Equal(t, 1, results.Total)
+		require.Equal(t, "Signup page", results.Results[0].Title)
+	})
+}
@@ -0,0 +1,48 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func TestSessionPolicies(t *testing.T) {
+	t.Run("sensitive endpoints require a recent login", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		policy := model.DefaultBoardPolicy()
+		_, resp := th.Client.UpdateTeamBoardPolicy(testTeamID, policy)
+		th.CheckOK(resp)
+
+		th.Server.Config().SessionReauthTime = 1
+		time.Sleep(1100 * time.Millisecond)
+
+		_, resp = th.Client.UpdateTeamBoardPolicy(testTeamID, policy)
+		th.CheckUnauthorized(resp)
+
+		// other endpoints still work with the session
+		_, resp = th.Client.GetTeamBoardPolicy(testTeamID)
+		th.CheckOK(resp)
+
+		th.Login1()
+		_, resp = th.Client.UpdateTeamBoardPolicy(testTeamID, policy)
+		th.CheckOK(resp)
+	})
+
+	t.Run("sessions past their lifetime are rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		th.Server.Config().SessionMaxLifetime = 1
+		time.Sleep(1100 * time.Millisecond)
+
+		_, resp := th.Client.GetMe()
+		th.CheckUnauthorized(resp)
+
+		th.Login1()
+		_, resp = th.Client.GetMe()
+		th.CheckOK(resp)
+	})
+}
@@ -96,3 +96,115 @@ func TestSharing(t *testing.T) {
 		})
 	})
 }
+
+func TestDisablePublicSharing(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	th.Server.SetPublicSharedBoards(true)
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	token := utils.NewID(utils.IDTypeToken)
+	success, resp := th.Client.PostSharing(&model.Sharing{ID: board.ID, Token: token, Enabled: true, UpdateAt: 1})
+	th.CheckOK(resp)
+	require.True(t, success)
+
+	th.Logout(th.Client)
+
+	rBoard, resp := th.Client.GetBoard(board.ID, token)
+	th.CheckOK(resp)
+	require.Equal(t, board.ID, rBoard.ID)
+
+	t.Run("disabling public sharing invalidates the links", func(t *testing.T) {
+		th.Server.SetPublicSharedBoards(false)
+
+		rBoard, resp := th.Client.GetBoard(board.ID, token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, rBoard)
+
+		sharing, err := th.Server.App().GetSharing(board.ID)
+		require.NoError(t, err)
+		require.False(t, sharing.Enabled)
+		require.Empty(t, sharing.Token)
+		require.Equal(t, model.SystemUserID, sharing.ModifiedBy)
+	})
+
+	t.Run("the links stay invalid once enabled back", func(t *testing.T) {
+		th.Server.SetPublicSharedBoards(true)
+
+		rBoard, resp := th.Client.GetBoard(board.ID, token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, rBoard)
+	})
+}
+
+func TestSharingAllowedCIDRs(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+	th.Server.Config().EnablePublicSharedBoards = true
+
+	localCIDRs := []string{"127.0.0.0/8", "::1/128"}
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	token := utils.NewID(utils.IDTypeToken)
+
+	t.Run("invalid ranges are rejected", func(t *testing.T) {
+		success, resp := th.Client.PostSharing(&model.Sharing{
+			ID:           board.ID,
+			Token:        token,
+			Enabled:      true,
+			AllowedCIDRs: []string{"10.0.0.1"},
+			UpdateAt:     1,
+		})
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+	})
+
+	// the board is accessed by a client without a session
+	sharingClient := th.Client2
+	th.Logout(sharingClient)
+
+	t.Run("the link works from the allowed ranges of the link", func(t *testing.T) {
+		_, resp := th.Client.PostSharing(&model.Sharing{
+			ID:           board.ID,
+			Token:        token,
+			Enabled:      true,
+			AllowedCIDRs: localCIDRs,
+			UpdateAt:     1,
+		})
+		th.CheckOK(resp)
+
+		rBoard, resp := sharingClient.GetBoard(board.ID, token)
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, rBoard.ID)
+	})
+
+	t.Run("the link doesn't work from other addresses", func(t *testing.T) {
+		_, resp := th.Client.PostSharing(&model.Sharing{
+			ID:           board.ID,
+			Token:        token,
+			Enabled:      true,
+			AllowedCIDRs: []string{"10.0.0.0/8"},
+			UpdateAt:     1,
+		})
+		th.CheckOK(resp)
+
+		rBoard, resp := sharingClient.GetBoard(board.ID, token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, rBoard)
+	})
+
+	t.Run("the ranges of the configuration apply to all links", func(t *testing.T) {
+		_, resp := th.Client.PostSharing(&model.Sharing{ID: board.ID, Token: token, Enabled: true, UpdateAt: 1})
+		th.CheckOK(resp)
+
+		th.Server.Config().SharedBoardsAllowedCIDRs = []string{"10.0.0.0/8"}
+		rBoard, resp := sharingClient.GetBoard(board.ID, token)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, rBoard)
+
+		th.Server.Config().SharedBoardsAllowedCIDRs = localCIDRs
+		rBoard, resp = sharingClient.GetBoard(board.ID, token)
+		th.CheckOK(resp)
+		require.Equal(t, board.ID, rBoard.ID)
+	})
+}
@@ -0,0 +1,196 @@
+package integrationtests
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamMigration(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	app := th.Server.App()
+	user1 := th.GetUser1()
+	user2 := th.GetUser2()
+	teamID := "source-team"
+	targetTeamID := "target-team"
+
+	board := &model.Board{
+		ID:     utils.NewID(utils.IDTypeBoard),
+		TeamID: teamID,
+		Title:  "Migrated board",
+		Type:   model.BoardTypePrivate,
+		CardProperties: []map[string]interface{}{
+			{"id": "owner", "name": "Owner", "type": model.PropertyTypePerson, "options": []interface{}{}},
+		},
+	}
+	card := model.Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  board.ID,
+		ParentID: board.ID,
+		Type:     model.TypeCard,
+		Title:    "Card",
+		CreateAt: utils.GetMillis(),
+		UpdateAt: utils.GetMillis(),
+		Fields:   map[string]interface{}{"properties": map[string]interface{}{"owner": "ghost-user"}},
+	}
+	bab, resp := th.Client.CreateBoardsAndBlocks(&model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: []model.Block{card},
+	})
+	th.CheckOK(resp)
+	require.Len(t, bab.Boards, 1)
+	require.Len(t, bab.Blocks, 1)
+	board = bab.Boards[0]
+	card = bab.Blocks[0]
+
+	_, err := app.AddMemberToBoard(&model.BoardMember{BoardID: board.ID, UserID: user2.ID, SchemeEditor: true})
+	require.NoError(t, err)
+	comment := model.Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  board.ID,
+		ParentID: card.ID,
+		Type:     model.TypeComment,
+		Title:    "A comment",
+		CreateAt: utils.GetMillis(),
+		UpdateAt: utils.GetMillis(),
+	}
+	require.NoError(t, app.InsertBlock(comment, user2.ID))
+	require.NoError(t, app.UpsertSharing(model.Sharing{ID: board.ID, Enabled: true, Token: "token", ModifiedBy: user1.ID}))
+	_, err = app.CreateSubscription(&model.Subscription{
+		BlockType:      model.TypeCard,
+		BlockID:        card.ID,
+		SubscriberType: model.SubTypeUser,
+		SubscriberID:   user2.ID,
+	})
+	require.NoError(t, err)
+	category, err := app.CreateCategory(&model.Category{Name: "Work", UserID: user1.ID, TeamID: teamID})
+	require.NoError(t, err)
+	require.NoError(t, app.AddUpdateUserCategoryBoard(teamID, user1.ID, category.ID, board.ID))
+
+	// a private board of another user isn't exported
+	otherBoard, err := app.CreateBoard(&model.Board{TeamID: teamID, Title: "Private board", Type: model.BoardTypePrivate}, user2.ID, true)
+	require.NoError(t, err)
+
+	archive, resp := th.Client.ExportTeamMigration(teamID)
+	th.CheckOK(resp)
+	require.NotEmpty(t, archive)
+
+	t.Run("only the boards of the user are exported", func(t *testing.T) {
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		require.NoError(t, err)
+		names := []string{}
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		require.Contains(t, names, board.ID+"/board.jsonl")
+		require.NotContains(t, names, otherBoard.ID+"/board.jsonl")
+	})
+
+	t.Run("only admins can migrate teams", func(t *testing.T) {
+		_, resp := th.Client2.ExportTeamMigration(teamID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.ImportTeamMigration(targetTeamID, bytes.NewReader(archive), nil, false)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("existing boards are skipped", func(t *testing.T) {
+		result, resp := th.Client.ImportTeamMigration(targetTeamID, bytes.NewReader(archive), nil, false)
+		th.CheckOK(resp)
+		require.Empty(t, result.BoardIDs)
+		require.Equal(t, []string{board.ID}, result.SkippedBoardIDs)
+	})
+
+	t.Run("the team is imported with its users mapped", func(t *testing.T) {
+		require.NoError(t, app.DeleteBoard(board.ID, user1.ID))
+
+		result, resp := th.Client.ImportTeamMigration(targetTeamID, bytes.NewReader(archive), map[string]string{user2.ID: user2.ID}, false)
+		th.CheckOK(resp)
+		require.Equal(t, []string{board.ID}, result.BoardIDs)
+		require.Equal(t, []string{"ghost-user"}, result.UnmappedUserIDs)
+
+		imported, err := app.GetBoard(board.ID)
+		require.NoError(t, err)
+		require.Equal(t, targetTeamID, imported.TeamID)
+		require.Equal(t, "Migrated board", imported.Title)
+
+		importedComment, err := app.GetBlockByID(comment.ID)
+		require.NoError(t, err)
+		require.Equal(t, user2.ID, importedComment.CreatedBy)
+
+		importedCard, err := app.GetBlockByID(card.ID)
+		require.NoError(t, err)
+		require.NotContains(t, model.CardPropertyValues(importedCard), "owner")
+
+		members, err := app.GetMembersForBoard(board.ID)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+
+		sharing, err := app.GetSharing(board.ID)
+		require.NoError(t, err)
+		require.True(t, sharing.Enabled)
+
+		categories, err := app.GetUserCategoryBoards(user1.ID, targetTeamID)
+		require.NoError(t, err)
+		found := false
+		for _, c := range categories {
+			if c.Name == "Work" {
+				require.Equal(t, []string{board.ID}, c.BoardIDs)
+				found = true
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("users are matched by email", func(t *testing.T) {
+		require.NoError(t, app.DeleteBoard(board.ID, user1.ID))
+		// the ids of the users differ between the servers
+		sourceArchive := replaceInArchive(t, archive, user2.ID, "source-user-2")
+
+		result, resp := th.Client.ImportTeamMigration(targetTeamID, bytes.NewReader(sourceArchive), nil, true)
+		th.CheckOK(resp)
+		require.Equal(t, []string{board.ID}, result.BoardIDs)
+		require.Equal(t, []string{"ghost-user"}, result.UnmappedUserIDs)
+		require.Equal(t, 2, result.BlockCount)
+
+		importedComment, err := app.GetBlockByID(comment.ID)
+		require.NoError(t, err)
+		require.Equal(t, user2.ID, importedComment.CreatedBy)
+	})
+
+	t.Run("invalid archive", func(t *testing.T) {
+		_, resp := th.Client.ImportTeamMigration(targetTeamID, bytes.NewReader([]byte("not an archive")), nil, false)
+		th.CheckBadRequest(resp)
+	})
+}
+
+// replaceInArchive returns a copy of the archive with the string replaced
+// in all its files.
+func replaceInArchive(t *testing.T, archive []byte, old, new string) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		fw, err := zw.Create(f.Name)
+		require.NoError(t, err)
+		_, err = fw.Write(bytes.ReplaceAll(data, []byte(old), []byte(new)))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
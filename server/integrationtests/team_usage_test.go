@@ -0,0 +1,31 @@
+package integrationtests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamUsage(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	_, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Card"})
+	th.CheckOK(resp)
+
+	t.Run("team admins get the usage", func(t *testing.T) {
+		usage, resp := th.Client.GetTeamUsage(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, testTeamID, usage.TeamID)
+		require.EqualValues(t, 1, usage.Boards)
+		require.EqualValues(t, 1, usage.Cards)
+	})
+
+	t.Run("only team admins get the usage", func(t *testing.T) {
+		_, resp := th.Client2.GetTeamUsage(testTeamID)
+		th.CheckForbidden(resp)
+	})
+}
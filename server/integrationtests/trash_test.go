@@ -0,0 +1,159 @@
+package integrationtests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamTrash(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	user := th.GetUser1()
+	board := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	card, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Launch"})
+	th.CheckOK(resp)
+	otherCard, resp := th.Client.CreateCard(board.ID, &model.Card{Title: "Draft"})
+	th.CheckOK(resp)
+
+	t.Run("empty trash", func(t *testing.T) {
+		items, resp := th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, items)
+	})
+
+	t.Run("restore a deleted card", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.DeleteCard(board.ID, card.ID)
+		th.CheckOK(resp)
+
+		items, resp := th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, items, 1)
+		require.Equal(t, model.TrashItemTypeCard, items[0].Type)
+		require.Equal(t, card.ID, items[0].ID)
+		require.Equal(t, "Launch", items[0].Title)
+		require.Equal(t, user.ID, items[0].DeletedBy)
+		require.NotZero(t, items[0].DeleteAt)
+
+		time.Sleep(10 * time.Millisecond)
+		restored, resp := th.Client.RestoreTrashCard(testTeamID, card.ID)
+		th.CheckOK(resp)
+		require.Equal(t, card.ID, restored.ID)
+
+		items, resp = th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, items)
+
+		_, resp = th.Client.RestoreTrashCard(testTeamID, card.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("permanently delete a card", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.DeleteCard(board.ID, otherCard.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.PurgeTrashCard(testTeamID, otherCard.ID)
+		th.CheckOK(resp)
+
+		items, resp := th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, items)
+
+		_, resp = th.Client.RestoreTrashCard(testTeamID, otherCard.ID)
+		th.CheckNotFound(resp)
+	})
+
+	t.Run("restore a deleted board", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.DeleteBoard(board.ID)
+		th.CheckOK(resp)
+
+		items, resp := th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, items, 1)
+		require.Equal(t, model.TrashItemTypeBoard, items[0].Type)
+		require.Equal(t, board.ID, items[0].ID)
+
+		_, resp = th.Client.RestoreTrashBoard("other-team-id", board.ID)
+		th.CheckNotFound(resp)
+
+		time.Sleep(10 * time.Millisecond)
+		restored, resp := th.Client.RestoreTrashBoard(testTeamID, board.ID)
+		th.CheckOK(resp)
+		require.Equal(t, board.Title, restored.Title)
+
+		cards, resp := th.Client.GetCards(board.ID, 0, 10, nil)
+		th.CheckOK(resp)
+		require.Len(t, cards, 1)
+	})
+
+	t.Run("permanently delete a board", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		_, resp := th.Client.DeleteBoard(board.ID)
+		th.CheckOK(resp)
+
+		_, resp = th.Client.PurgeTrashBoard(testTeamID, board.ID)
+		th.CheckOK(resp)
+
+		items, resp := th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Empty(t, items)
+
+		_, resp = th.Client.RestoreTrashBoard(testTeamID, board.ID)
+		th.CheckNotFound(resp)
+	})
+}
+
+func TestTeamTrashPermissions(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	adminBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+	adminCard, resp := th.Client.CreateCard(adminBoard.ID, &model.Card{Title: "Admin card"})
+	th.CheckOK(resp)
+	_, resp = th.Client.DeleteCard(adminBoard.ID, adminCard.ID)
+	th.CheckOK(resp)
+
+	userBoard, resp := th.Client2.CreateBoard(&model.Board{TeamID: testTeamID, Type: model.BoardTypeOpen})
+	th.CheckOK(resp)
+	userCard, resp := th.Client2.CreateCard(userBoard.ID, &model.Card{Title: "User card"})
+	th.CheckOK(resp)
+	_, resp = th.Client2.DeleteCard(userBoard.ID, userCard.ID)
+	th.CheckOK(resp)
+
+	t.Run("team admins get all the items", func(t *testing.T) {
+		items, resp := th.Client.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, items, 2)
+	})
+
+	t.Run("board admins get the items of their boards", func(t *testing.T) {
+		items, resp := th.Client2.GetTeamTrash(testTeamID)
+		th.CheckOK(resp)
+		require.Len(t, items, 1)
+		require.Equal(t, userCard.ID, items[0].ID)
+	})
+
+	t.Run("board admins only restore and purge the items of their boards", func(t *testing.T) {
+		_, resp := th.Client2.RestoreTrashCard(testTeamID, adminCard.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.PurgeTrashCard(testTeamID, adminCard.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.RestoreTrashBoard(testTeamID, adminBoard.ID)
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.PurgeTrashBoard(testTeamID, adminBoard.ID)
+		th.CheckForbidden(resp)
+
+		restored, resp := th.Client2.RestoreTrashCard(testTeamID, userCard.ID)
+		th.CheckOK(resp)
+		require.Equal(t, userCard.ID, restored.ID)
+	})
+}
@@ -0,0 +1,106 @@
+package integrationtests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadRestrictions(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		restrictions, resp := th.Client.GetTeamUploadRestrictions(testTeamID)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, restrictions)
+	})
+
+	t.Run("only team admins update the restrictions", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		maxFileSize := int64(1024)
+		_, resp := th.Client2.UpdateTeamUploadRestrictions(testTeamID, model.UploadRestrictionsOverrides{MaxFileSize: &maxFileSize})
+		th.CheckForbidden(resp)
+
+		// the restrictions apply to all the users uploading files
+		_, resp = th.Client2.GetTeamUploadRestrictions(testTeamID)
+		th.CheckOK(resp)
+	})
+
+	t.Run("team overrides replace the configuration", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.App().GetConfig()
+		config.BlockedFileExtensions = []string{"exe"}
+		th.Server.App().SetConfig(config)
+
+		restrictions, resp := th.Client.GetTeamUploadRestrictions(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, []string{"exe"}, restrictions.Restrictions.BlockedExtensions)
+		require.Empty(t, restrictions.Restrictions.AllowedExtensions)
+
+		maxFileSize := int64(1024)
+		allowed := []string{".PDF", "png"}
+		restrictions, resp = th.Client.UpdateTeamUploadRestrictions(testTeamID, model.UploadRestrictionsOverrides{
+			MaxFileSize:       &maxFileSize,
+			AllowedExtensions: &allowed,
+		})
+		th.CheckOK(resp)
+		require.Equal(t, int64(1024), restrictions.Restrictions.MaxFileSize)
+		require.Equal(t, []string{"pdf", "png"}, restrictions.Restrictions.AllowedExtensions)
+		require.Equal(t, []string{"exe"}, restrictions.Restrictions.BlockedExtensions)
+
+		restrictions, resp = th.Client.GetTeamUploadRestrictions(testTeamID)
+		th.CheckOK(resp)
+		require.Equal(t, []string{"pdf", "png"}, restrictions.Restrictions.AllowedExtensions)
+	})
+
+	t.Run("invalid overrides", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		invalid := []string{"tar/gz"}
+		_, resp := th.Client.UpdateTeamUploadRestrictions(testTeamID, model.UploadRestrictionsOverrides{BlockedExtensions: &invalid})
+		th.CheckBadRequest(resp)
+
+		negative := int64(-1)
+		_, resp = th.Client.UpdateTeamUploadRestrictions(testTeamID, model.UploadRestrictionsOverrides{MaxFileSize: &negative})
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("uploads are checked against the restrictions of the team", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		testBoard := th.CreateBoard(testTeamID, model.BoardTypeOpen)
+
+		blocked := []string{"exe"}
+		_, resp := th.Client.UpdateTeamUploadRestrictions(testTeamID, model.UploadRestrictionsOverrides{BlockedExtensions: &blocked})
+		th.CheckOK(resp)
+
+		file, resp := th.Client.TeamUploadFileWithName(testTeamID, testBoard.ID, "setup.EXE", bytes.NewBuffer([]byte("test")))
+		require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+		require.Error(t, resp.Error)
+		require.Nil(t, file)
+
+		file, resp = th.Client.TeamUploadFileWithName(testTeamID, testBoard.ID, "spec.pdf", bytes.NewBuffer([]byte("test")))
+		th.CheckOK(resp)
+		require.NotNil(t, file)
+
+		maxFileSize := int64(1)
+		_, resp = th.Client.UpdateTeamUploadRestrictions(testTeamID, model.UploadRestrictionsOverrides{MaxFileSize: &maxFileSize})
+		th.CheckOK(resp)
+
+		file, resp = th.Client.TeamUploadFileWithName(testTeamID, testBoard.ID, "spec.pdf", bytes.NewBuffer([]byte("test")))
+		th.CheckRequestEntityTooLarge(resp)
+		require.Nil(t, file)
+	})
+}
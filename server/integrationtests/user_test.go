@@ -204,6 +204,49 @@ func TestUserChangePassword(t *testing.T) {
 	require.True(t, success)
 }
 
+func TestUserPasswordPolicy(t *testing.T) {
+	t.Run("registering with a short password", func(t *testing.T) {
+		th := SetupTestHelper(t).Start()
+		defer th.TearDown()
+
+		success, resp := th.Client.Register(&api.RegisterRequest{
+			Username: fakeUsername,
+			Email:    fakeEmail,
+			Password: "short",
+		})
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+		require.Contains(t, resp.Error.Error(), `"details":["min-length"]`)
+	})
+
+	t.Run("changing to a password failing the configured requirements", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		config := th.Server.Config()
+		config.PasswordRequireNumber = true
+		config.PasswordBannedList = []string{"BoardsForever"}
+
+		me, resp := th.Client.GetMe()
+		th.CheckOK(resp)
+
+		success, resp := th.Client.UserChangePassword(me.ID, &api.ChangePasswordRequest{
+			OldPassword: password,
+			NewPassword: "boardsforever",
+		})
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+		require.Contains(t, resp.Error.Error(), `"details":["number","banned"]`)
+
+		success, resp = th.Client.UserChangePassword(me.ID, &api.ChangePasswordRequest{
+			OldPassword: password,
+			NewPassword: "boardsforever2",
+		})
+		th.CheckOK(resp)
+		require.True(t, success)
+	})
+}
+
 func randomBytes(t *testing.T, n int) []byte {
 	bb := make([]byte, n)
 	_, err := rand.Read(bb)
@@ -266,3 +309,44 @@ func TestTeamUploadFile(t *testing.T) {
 		require.Nil(t, result)
 	})
 }
+
+func TestSearchUserDirectory(t *testing.T) {
+	t.Run("a non authenticated user should be rejected", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		th.Logout(th.Client)
+
+		userPage, resp := th.Client.SearchUserDirectory(testTeamID, "", 0, 10)
+		th.CheckUnauthorized(resp)
+		require.Nil(t, userPage)
+	})
+
+	t.Run("invalid paging", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		_, resp := th.Client.SearchUserDirectory(testTeamID, "", 0, model.MaxUserDirectoryPerPage+1)
+		th.CheckBadRequest(resp)
+	})
+
+	t.Run("the members matching the prefix, page by page", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		userPage, resp := th.Client.SearchUserDirectory(testTeamID, "USER", 0, 1)
+		th.CheckOK(resp)
+		require.Len(t, userPage.Users, 1)
+		require.True(t, userPage.HasNext)
+		require.Equal(t, th.GetUser1().ID, userPage.Users[0].ID)
+
+		userPage, resp = th.Client.SearchUserDirectory(testTeamID, "USER", 1, 1)
+		th.CheckOK(resp)
+		require.Len(t, userPage.Users, 1)
+		require.False(t, userPage.HasNext)
+		require.Equal(t, user2Username, userPage.Users[0].Username)
+
+		userPage, resp = th.Client.SearchUserDirectory(testTeamID, "nobody", 0, 10)
+		th.CheckOK(resp)
+		require.Empty(t, userPage.Users)
+	})
+}
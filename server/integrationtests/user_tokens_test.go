@@ -0,0 +1,154 @@
+package integrationtests
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/api"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/mail/mailtest"
+
+	"github.com/stretchr/testify/require"
+)
+
+var userTokenLinkRegexp = regexp.MustCompile(`\?t=(\w+)`)
+
+// setupMail sends the emails of the server to a test SMTP server.
+func setupMail(th *TestHelper) *mailtest.Server {
+	server := mailtest.NewServer(th.T)
+	config := th.Server.Config()
+	config.SMTPServer = server.Host()
+	config.SMTPPort = server.Port()
+	config.SMTPFromAddress = "boards@example.com"
+	return server
+}
+
+// tokenOfMessage returns the token of the link in the email.
+func tokenOfMessage(t *testing.T, message mailtest.Message) string {
+	matches := userTokenLinkRegexp.FindStringSubmatch(message.Data)
+	require.Len(t, matches, 2, "no link in the email: %s", message.Data)
+	return matches[1]
+}
+
+func TestPasswordReset(t *testing.T) {
+	t.Run("without email configured", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		success, resp := th.Client.RequestPasswordReset("user1@sample.com")
+		th.CheckNotImplemented(resp)
+		require.False(t, success)
+	})
+
+	t.Run("unknown email address", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		mailServer := setupMail(th)
+
+		success, resp := th.Client.RequestPasswordReset("nobody@sample.com")
+		th.CheckOK(resp)
+		require.True(t, success)
+		require.Empty(t, mailServer.Messages())
+	})
+
+	t.Run("reset the password with the emailed token", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		mailServer := setupMail(th)
+
+		success, resp := th.Client.RequestPasswordReset("user1@sample.com")
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		messages := mailServer.WaitForMessages(1, 5*time.Second)
+		require.Len(t, messages, 1)
+		require.Equal(t, []string{"user1@sample.com"}, messages[0].To)
+		token := tokenOfMessage(t, messages[0])
+
+		success, resp = th.Client.CompletePasswordReset(token, "short")
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+
+		success, resp = th.Client.CompletePasswordReset(token, "a new password")
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		// the sessions are logged out
+		_, resp = th.Client.GetMe()
+		th.CheckUnauthorized(resp)
+
+		// the token can be used only once
+		success, resp = th.Client.CompletePasswordReset(token, "another new password")
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+
+		_, resp = th.Client.Login(&api.LoginRequest{Type: "normal", Username: user1Username, Password: password})
+		th.CheckUnauthorized(resp)
+
+		th.Login(th.Client, user1Username, "a new password")
+	})
+
+	t.Run("a new request invalidates the previous token", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		mailServer := setupMail(th)
+
+		_, resp := th.Client.RequestPasswordReset("user1@sample.com")
+		th.CheckOK(resp)
+		_, resp = th.Client.RequestPasswordReset("user1@sample.com")
+		th.CheckOK(resp)
+
+		messages := mailServer.WaitForMessages(2, 5*time.Second)
+		require.Len(t, messages, 2)
+
+		success, resp := th.Client.CompletePasswordReset(tokenOfMessage(t, messages[0]), "a new password")
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+
+		success, resp = th.Client.CompletePasswordReset(tokenOfMessage(t, messages[1]), "a new password")
+		th.CheckOK(resp)
+		require.True(t, success)
+	})
+}
+
+func TestEmailVerification(t *testing.T) {
+	t.Run("without email configured", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+
+		success, resp := th.Client.SendEmailVerification()
+		th.CheckNotImplemented(resp)
+		require.False(t, success)
+	})
+
+	t.Run("verify the email address with the emailed token", func(t *testing.T) {
+		th := SetupTestHelper(t).InitBasic()
+		defer th.TearDown()
+		mailServer := setupMail(th)
+
+		me, resp := th.Client.GetMe()
+		th.CheckOK(resp)
+		require.Nil(t, me.Props[model.UserPropEmailVerified])
+
+		success, resp := th.Client.SendEmailVerification()
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		messages := mailServer.WaitForMessages(1, 5*time.Second)
+		require.Len(t, messages, 1)
+		require.Equal(t, []string{"user1@sample.com"}, messages[0].To)
+
+		success, resp = th.Client.VerifyEmail("invalid")
+		th.CheckBadRequest(resp)
+		require.False(t, success)
+
+		success, resp = th.Client.VerifyEmail(tokenOfMessage(t, messages[0]))
+		th.CheckOK(resp)
+		require.True(t, success)
+
+		me, resp = th.Client.GetMe()
+		th.CheckOK(resp)
+		require.Equal(t, "true", me.Props[model.UserPropEmailVerified])
+	})
+}
@@ -0,0 +1,127 @@
+package integrationtests
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/webhook"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhooks(t *testing.T) {
+	th := SetupTestHelper(t).InitBasic()
+	defer th.TearDown()
+
+	teamID := "team-id"
+	board := th.CreateBoard(teamID, model.BoardTypeOpen)
+
+	type delivery struct {
+		body      []byte
+		signature string
+	}
+	deliveries := make(chan delivery, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		deliveries <- delivery{body: body, signature: r.Header.Get(webhook.SignatureHeader)}
+	}))
+	defer ts.Close()
+
+	t.Run("only admins manage webhooks", func(t *testing.T) {
+		_, resp := th.Client2.CreateWebhook(teamID, &model.Webhook{URL: ts.URL})
+		th.CheckForbidden(resp)
+
+		_, resp = th.Client2.GetWebhooks(teamID)
+		th.CheckForbidden(resp)
+	})
+
+	t.Run("invalid webhook", func(t *testing.T) {
+		_, resp := th.Client.CreateWebhook(teamID, &model.Webhook{URL: "not a url"})
+		th.CheckBadRequest(resp)
+
+		_, resp = th.Client.CreateWebhook(teamID, &model.Webhook{URL: ts.URL, BoardIDs: []string{"unknown-board"}})
+		th.CheckBadRequest(resp)
+	})
+
+	created, resp := th.Client.CreateWebhook(teamID, &model.Webhook{
+		URL:        ts.URL,
+		Secret:     "secret",
+		BlockTypes: []model.BlockType{model.TypeCard},
+		Actions:    []model.WebhookAction{model.WebhookActionAdd},
+	})
+	th.CheckOK(resp)
+	require.NotEmpty(t, created.ID)
+	require.Equal(t, "secret", created.Secret)
+
+	t.Run("list webhooks without secrets", func(t *testing.T) {
+		webhooks, resp := th.Client.GetWebhooks(teamID)
+		th.CheckOK(resp)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, created.ID, webhooks[0].ID)
+		require.Empty(t, webhooks[0].Secret)
+	})
+
+	t.Run("deliver a matching block change", func(t *testing.T) {
+		// the changes of the boards the owner of the webhook can't see
+		// aren't delivered
+		privateBoard, resp := th.Client2.CreateBoard(&model.Board{TeamID: teamID, Type: model.BoardTypePrivate})
+		th.CheckOK(resp)
+		privateCard := model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  privateBoard.ID,
+			ParentID: privateBoard.ID,
+			Type:     model.TypeCard,
+			CreateAt: utils.GetMillis(),
+			UpdateAt: utils.GetMillis(),
+		}
+		_, resp = th.Client2.InsertBlocks(privateBoard.ID, []model.Block{privateCard})
+		th.CheckOK(resp)
+
+		card := model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  board.ID,
+			ParentID: board.ID,
+			Type:     model.TypeCard,
+			CreateAt: utils.GetMillis(),
+			UpdateAt: utils.GetMillis(),
+		}
+		inserted, resp := th.Client.InsertBlocks(board.ID, []model.Block{card})
+		th.CheckOK(resp)
+		require.Len(t, inserted, 1)
+
+		select {
+		case d := <-deliveries:
+			require.Equal(t, webhook.Sign("secret", d.body), d.signature)
+
+			var payload model.WebhookPayload
+			require.NoError(t, json.Unmarshal(d.body, &payload))
+			require.Equal(t, model.WebhookActionAdd, payload.Action)
+			require.Equal(t, board.ID, payload.BoardID)
+			require.Equal(t, inserted[0].ID, payload.Block.ID)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "webhook not called")
+		}
+	})
+
+	t.Run("update and delete the webhook", func(t *testing.T) {
+		created.Actions = []model.WebhookAction{model.WebhookActionDelete}
+		updated, resp := th.Client.UpdateWebhook(teamID, created)
+		th.CheckOK(resp)
+		require.Equal(t, []model.WebhookAction{model.WebhookActionDelete}, updated.Actions)
+
+		_, resp = th.Client.UpdateWebhook("other-team-id", created)
+		th.CheckNotFound(resp)
+
+		_, resp = th.Client.DeleteWebhook(teamID, created.ID)
+		th.CheckOK(resp)
+
+		webhooks, resp := th.Client.GetWebhooks(teamID)
+		th.CheckOK(resp)
+		require.Empty(t, webhooks)
+	})
+}
@@ -146,7 +146,7 @@ func main() {
 		logger.Fatal("server.NewStore ERROR", mlog.Err(err))
 	}
 
-	permissionsService := localpermissions.New(db, logger)
+	permissionsService := localpermissions.New(db, logger, config.AdminUsers)
 
 	params := server.Params{
 		Cfg:                config,
@@ -238,7 +238,7 @@ func startServer(webPath string, filesPath string, port int, singleUserToken, db
 		logger.Fatal("server.NewStore ERROR", mlog.Err(err))
 	}
 
-	permissionsService := localpermissions.New(db, logger)
+	permissionsService := localpermissions.New(db, logger, config.AdminUsers)
 
 	params := server.Params{
 		Cfg:                config,
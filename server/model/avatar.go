@@ -0,0 +1,106 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// MaxAvatarSize is the largest image, in bytes, that can be uploaded as
+// an avatar.
+const MaxAvatarSize = 1 << 20
+
+// avatarImageExtensions are the extensions of the image types that can be
+// uploaded as avatars, by their content type.
+var avatarImageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// avatarColors are the background colors of the generated avatars.
+var avatarColors = []string{
+	"#1e325c", "#3d3c82", "#6e4da3", "#a3334e", "#c43a2b",
+	"#cc6b1f", "#8a7a12", "#2f7a3a", "#167b7a", "#1f6eb5",
+}
+
+// ErrInvalidAvatar is returned when an uploaded avatar is empty, too
+// large or not a supported image.
+type ErrInvalidAvatar struct {
+	msg string
+}
+
+// NewErrInvalidAvatar returns an error for an avatar that can't be used.
+func NewErrInvalidAvatar(msg string) *ErrInvalidAvatar {
+	return &ErrInvalidAvatar{msg: msg}
+}
+
+func (e *ErrInvalidAvatar) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidAvatar returns true if the error is an ErrInvalidAvatar.
+func IsErrInvalidAvatar(err error) bool {
+	var errInvalid *ErrInvalidAvatar
+	return errors.As(err, &errInvalid)
+}
+
+// AvatarImageExtension returns the extension of an uploaded avatar, from
+// its type detected from its bytes.
+func AvatarImageExtension(data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", NewErrInvalidAvatar("the image is empty")
+	}
+	if len(data) > MaxAvatarSize {
+		return "", NewErrInvalidAvatar(fmt.Sprintf("the image is larger than %d bytes", MaxAvatarSize))
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := avatarImageExtensions[contentType]
+	if !ok {
+		return "", NewErrInvalidAvatar(fmt.Sprintf("%s is not a supported image type", strings.SplitN(contentType, ";", 2)[0]))
+	}
+	return ext, nil
+}
+
+// AvatarInitials returns up to two uppercase initials of a username, from
+// its parts separated by dots, dashes, underscores or spaces, like JD for
+// john.doe.
+func AvatarInitials(username string) string {
+	parts := strings.FieldsFunc(username, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	initials := []rune{}
+	for _, part := range parts {
+		initials = append(initials, unicode.ToUpper([]rune(part)[0]))
+		if len(initials) == 2 {
+			break
+		}
+	}
+	if len(initials) == 0 {
+		return "?"
+	}
+	return string(initials)
+}
+
+// GenerateAvatar returns a square SVG avatar with the initials of the
+// username, on a background color picked from the user ID so that it
+// doesn't change with the username.
+func GenerateAvatar(userID, username string) []byte {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(userID))
+	color := avatarColors[h.Sum32()%uint32(len(avatarColors))]
+
+	return []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="128" height="128" viewBox="0 0 128 128">`+
+			`<rect width="128" height="128" fill="%s"/>`+
+			`<text x="50%%" y="50%%" dy=".35em" fill="#ffffff" font-family="sans-serif" font-size="52" text-anchor="middle">%s</text>`+
+			`</svg>`,
+		color, html.EscapeString(AvatarInitials(username)),
+	))
+}
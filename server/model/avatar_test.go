@@ -0,0 +1,48 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvatarImageExtension(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	ext, err := AvatarImageExtension(png)
+	require.NoError(t, err)
+	require.Equal(t, ".png", ext)
+
+	_, err = AvatarImageExtension(nil)
+	require.True(t, IsErrInvalidAvatar(err))
+
+	_, err = AvatarImageExtension([]byte("<svg></svg>"))
+	require.True(t, IsErrInvalidAvatar(err))
+
+	_, err = AvatarImageExtension(append(png, bytes.Repeat([]byte{0}, MaxAvatarSize)...))
+	require.True(t, IsErrInvalidAvatar(err))
+}
+
+func TestAvatarInitials(t *testing.T) {
+	testCases := map[string]string{
+		"john.doe":        "JD",
+		"jane":            "J",
+		"mary-ann_o.neil": "MA",
+		"élodie dupont":   "ÉD",
+		"...":             "?",
+		"":                "?",
+	}
+	for username, initials := range testCases {
+		require.Equal(t, initials, AvatarInitials(username), username)
+	}
+}
+
+func TestGenerateAvatar(t *testing.T) {
+	avatar := GenerateAvatar("user-id", "john.doe")
+	require.Contains(t, string(avatar), ">JD</text>")
+	require.Equal(t, avatar, GenerateAvatar("user-id", "john.doe"))
+
+	renamed := GenerateAvatar("user-id", "jd")
+	require.Contains(t, string(renamed), ">J</text>")
+}
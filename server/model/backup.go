@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// Backup is an archive of the boards of a team written by the scheduled
+// backups
+// swagger:model
+type Backup struct {
+	// The team ID
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The file name of the backup
+	// required: true
+	Name string `json:"name"`
+
+	// The size of the backup in bytes
+	// required: true
+	Size int64 `json:"size"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
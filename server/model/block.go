@@ -94,6 +94,13 @@ type BlockPatch struct {
 	// The board id that the block belongs to
 	// required: false
 	BoardID *string `json:"boardId"`
+
+	// The update time of the block version the patch was made on. If the
+	// block changed since, the patch is merged with the changes, and it is
+	// rejected with a conflict if the block changes again before the merged
+	// patch is written
+	// required: false
+	BaseUpdateAt int64 `json:"baseUpdateAt,omitempty"`
 }
 
 // BlockPatchBatch is a batch of IDs and patches for modify blocks
@@ -179,6 +186,27 @@ type QuerySubtreeOptions struct {
 	Limit          uint64 // if non-zero then limit the number of returned records
 }
 
+// MaxBlocksPageLimit is the largest page of blocks that can be requested
+// from the blocks API.
+const MaxBlocksPageLimit = 1000
+
+// QueryBlocksPageOptions are query options that can be passed to
+// GetBlocksForBoardPage. The blocks are sorted by id.
+type QueryBlocksPageOptions struct {
+	ParentID    *string   // if non-nil then filter for records with this parent id
+	BlockType   BlockType // if non-empty then filter for records of this type
+	ExcludedIDs []string  // if non-empty then filter out the records with these ids and their children
+	AfterID     string    // if non-empty then filter for records with id greater than AfterID
+	Limit       uint64    // if non-zero then limit the number of returned records
+}
+
+// BlocksVersion identifies the state of the blocks of a board, as
+// inserting, updating or deleting any of them changes it.
+type BlocksVersion struct {
+	Count        int64 // the number of blocks of the board
+	LastUpdateAt int64 // the time of the last update of a block of the board
+}
+
 // QueryBlockHistoryOptions are query options that can be passed to GetBlockHistory.
 type QueryBlockHistoryOptions struct {
 	BeforeUpdateAt int64  // if non-zero then filter for records with update_at less than BeforeUpdateAt
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+)
+
+const (
+	// DefaultBlockHistoryLimit is the number of versions of a page of the
+	// history of a block when none is given.
+	DefaultBlockHistoryLimit = 20
+
+	// MaxBlockHistoryLimit is the largest page of the history of a block
+	// that can be requested.
+	MaxBlockHistoryLimit = 200
+)
+
+// BlockVersion is a version of a block recorded in its history
+// swagger:model
+type BlockVersion struct {
+	// The version, the update time of the block in miliseconds since the
+	// current epoch
+	// required: true
+	Version int64 `json:"version"`
+
+	// The id of the user who made the change
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// True if the change deleted the block
+	// required: true
+	Deleted bool `json:"deleted"`
+
+	// The block as it was after the change
+	// required: true
+	Block Block `json:"block"`
+}
+
+// BlockHistoryPage is a page of the versions of a block, newest first
+// swagger:model
+type BlockHistoryPage struct {
+	// The versions of the page
+	// required: true
+	Versions []*BlockVersion `json:"versions"`
+
+	// The version to pass as before to get the next page, 0 if this page
+	// is the last one
+	// required: true
+	NextBefore int64 `json:"nextBefore"`
+}
+
+// ErrInvalidBlockVersion is returned when a version of a block can't be
+// restored.
+type ErrInvalidBlockVersion struct {
+	msg string
+}
+
+// NewErrInvalidBlockVersion returns an error for a version of a block that
+// can't be restored.
+func NewErrInvalidBlockVersion(msg string) *ErrInvalidBlockVersion {
+	return &ErrInvalidBlockVersion{msg: msg}
+}
+
+func (e *ErrInvalidBlockVersion) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidBlockVersion returns true if the error is an
+// ErrInvalidBlockVersion.
+func IsErrInvalidBlockVersion(err error) bool {
+	var errInvalid *ErrInvalidBlockVersion
+	return errors.As(err, &errInvalid)
+}
+
+// NewBlockVersion returns the version that a block of the history is.
+func NewBlockVersion(block Block) *BlockVersion {
+	return &BlockVersion{
+		Version:    block.UpdateAt,
+		ModifiedBy: block.ModifiedBy,
+		Deleted:    block.DeleteAt != 0,
+		Block:      block,
+	}
+}
+
+// BlockVersionPatch returns the patch that brings the current block back to
+// the version: its parent, title and fields, removing the fields added
+// since.
+func BlockVersionPatch(version, current *Block) *BlockPatch {
+	parentID := version.ParentID
+	title := version.Title
+	patch := &BlockPatch{
+		ParentID:      &parentID,
+		Title:         &title,
+		UpdatedFields: map[string]interface{}{},
+		DeletedFields: []string{},
+	}
+	for key, value := range version.Fields {
+		patch.UpdatedFields[key] = value
+	}
+	for key := range current.Fields {
+		if _, ok := version.Fields[key]; !ok {
+			patch.DeletedFields = append(patch.DeletedFields, key)
+		}
+	}
+	return patch
+}
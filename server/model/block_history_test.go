@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlockVersion(t *testing.T) {
+	version := NewBlockVersion(Block{ID: "card", ModifiedBy: "user-1", UpdateAt: 10})
+	require.Equal(t, int64(10), version.Version)
+	require.Equal(t, "user-1", version.ModifiedBy)
+	require.False(t, version.Deleted)
+
+	require.True(t, NewBlockVersion(Block{ID: "card", UpdateAt: 20, DeleteAt: 20}).Deleted)
+}
+
+func TestBlockVersionPatch(t *testing.T) {
+	version := &Block{
+		ParentID: "board",
+		Title:    "Old title",
+		Fields:   map[string]interface{}{"icon": "📋", "properties": map[string]interface{}{"status": "todo"}},
+	}
+	current := &Block{
+		ParentID: "board",
+		Title:    "New title",
+		Fields:   map[string]interface{}{"icon": "🚀", "properties": map[string]interface{}{"status": "done"}, "isTemplate": false},
+	}
+
+	patch := BlockVersionPatch(version, current)
+	require.Equal(t, "Old title", *patch.Title)
+	require.Equal(t, "board", *patch.ParentID)
+	require.Equal(t, version.Fields, patch.UpdatedFields)
+	require.Equal(t, []string{"isTemplate"}, patch.DeletedFields)
+
+	restored := patch.Patch(current)
+	require.Equal(t, "Old title", restored.Title)
+	require.Equal(t, version.Fields, restored.Fields)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBlockPatchBatchSize is the largest number of blocks a batch can patch
+// in one transaction.
+const MaxBlockPatchBatchSize = 1000
+
+// ErrInvalidBlockPatchBatch is returned when a batch of block patches
+// can't be applied.
+type ErrInvalidBlockPatchBatch struct {
+	msg string
+}
+
+func NewErrInvalidBlockPatchBatch(msg string) *ErrInvalidBlockPatchBatch {
+	return &ErrInvalidBlockPatchBatch{msg: msg}
+}
+
+func (e *ErrInvalidBlockPatchBatch) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidBlockPatchBatch returns true if the error is an
+// ErrInvalidBlockPatchBatch.
+func IsErrInvalidBlockPatchBatch(err error) bool {
+	var errInvalid *ErrInvalidBlockPatchBatch
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks that the batch has one patch per block, patches each
+// block once and isn't larger than MaxBlockPatchBatchSize.
+func (b *BlockPatchBatch) IsValid() error {
+	if len(b.BlockIDs) == 0 {
+		return NewErrInvalidBlockPatchBatch("the batch must patch at least one block")
+	}
+	if len(b.BlockIDs) > MaxBlockPatchBatchSize {
+		return NewErrInvalidBlockPatchBatch(fmt.Sprintf("a batch cannot patch more than %d blocks", MaxBlockPatchBatchSize))
+	}
+	if len(b.BlockIDs) != len(b.BlockPatches) {
+		return NewErrInvalidBlockPatchBatch(fmt.Sprintf("the batch has %d block ids but %d patches", len(b.BlockIDs), len(b.BlockPatches)))
+	}
+
+	seen := make(map[string]bool, len(b.BlockIDs))
+	for _, blockID := range b.BlockIDs {
+		if blockID == "" {
+			return NewErrInvalidBlockPatchBatch("block id cannot be empty")
+		}
+		if seen[blockID] {
+			return NewErrInvalidBlockPatchBatch(fmt.Sprintf("block %q is patched more than once", blockID))
+		}
+		seen[blockID] = true
+	}
+	return nil
+}
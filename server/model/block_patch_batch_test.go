@@ -0,0 +1,47 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockPatchBatchIsValid(t *testing.T) {
+	title := "title"
+
+	batch := &BlockPatchBatch{
+		BlockIDs:     []string{"block-1", "block-2"},
+		BlockPatches: []BlockPatch{{Title: &title}, {Title: &title}},
+	}
+	require.NoError(t, batch.IsValid())
+
+	t.Run("empty", func(t *testing.T) {
+		require.True(t, IsErrInvalidBlockPatchBatch((&BlockPatchBatch{}).IsValid()))
+	})
+
+	t.Run("missing patch", func(t *testing.T) {
+		batch := &BlockPatchBatch{
+			BlockIDs:     []string{"block-1", "block-2"},
+			BlockPatches: []BlockPatch{{Title: &title}},
+		}
+		require.True(t, IsErrInvalidBlockPatchBatch(batch.IsValid()))
+	})
+
+	t.Run("duplicate block", func(t *testing.T) {
+		batch := &BlockPatchBatch{
+			BlockIDs:     []string{"block-1", "block-1"},
+			BlockPatches: []BlockPatch{{Title: &title}, {Title: &title}},
+		}
+		require.True(t, IsErrInvalidBlockPatchBatch(batch.IsValid()))
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		batch := &BlockPatchBatch{}
+		for i := 0; i <= MaxBlockPatchBatchSize; i++ {
+			batch.BlockIDs = append(batch.BlockIDs, fmt.Sprintf("block-%d", i))
+			batch.BlockPatches = append(batch.BlockPatches, BlockPatch{Title: &title})
+		}
+		require.True(t, IsErrInvalidBlockPatchBatch(batch.IsValid()))
+	})
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ErrBlockPatchConflict is returned when a patch changes a block field
+// that was also changed since the version of the block the patch is
+// based on.
+type ErrBlockPatchConflict struct {
+	BlockID string
+	Fields  []string
+}
+
+func NewErrBlockPatchConflict(blockID string, fields []string) *ErrBlockPatchConflict {
+	return &ErrBlockPatchConflict{
+		BlockID: blockID,
+		Fields:  fields,
+	}
+}
+
+func (e *ErrBlockPatchConflict) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("block %s was changed concurrently", e.BlockID)
+	}
+	return fmt.Sprintf("block %s was changed concurrently: %s", e.BlockID, strings.Join(e.Fields, ", "))
+}
+
+// IsErrBlockPatchConflict returns true if `err` is or wraps an ErrBlockPatchConflict.
+func IsErrBlockPatchConflict(err error) bool {
+	var ebpc *ErrBlockPatchConflict
+	return errors.As(err, &ebpc)
+}
+
+// BlockPatchConflictResponse is the error response returned when a block
+// patch conflicts with a concurrent change
+// swagger:model
+type BlockPatchConflictResponse struct {
+	// The error message
+	// required: false
+	Error string `json:"error"`
+
+	// The error code
+	// required: false
+	ErrorCode int `json:"errorCode"`
+
+	// The id of the block
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The fields changed both by the patch and concurrently. Keys of map
+	// fields are given as field.key, e.g. properties.<property id>. Empty
+	// if the block changed while the patch was being applied
+	// required: true
+	ConflictingFields []string `json:"conflictingFields"`
+
+	// The current version of the block, for the client to merge its
+	// changes with
+	// required: false
+	Block *Block `json:"block,omitempty"`
+}
+
+// Merge rebases the patch, made on the base version of a block, on top of
+// the current version. Fields changed only by the patch or only
+// concurrently are kept, map fields like the card properties being merged
+// key by key, and an ErrBlockPatchConflict is returned if the patch and
+// the concurrent changes set the same field to different values.
+func (p *BlockPatch) Merge(base, current *Block) (*BlockPatch, error) {
+	conflicts := []string{}
+	checkField := func(name string, patched, baseValue, currentValue interface{}) {
+		if !reflect.DeepEqual(baseValue, currentValue) && !reflect.DeepEqual(patched, currentValue) {
+			conflicts = append(conflicts, name)
+		}
+	}
+
+	if p.ParentID != nil {
+		checkField("parentId", *p.ParentID, base.ParentID, current.ParentID)
+	}
+	if p.BoardID != nil {
+		checkField("boardId", *p.BoardID, base.BoardID, current.BoardID)
+	}
+	if p.Schema != nil {
+		checkField("schema", *p.Schema, base.Schema, current.Schema)
+	}
+	if p.Type != nil {
+		checkField("type", *p.Type, base.Type, current.Type)
+	}
+	if p.Title != nil {
+		checkField("title", *p.Title, base.Title, current.Title)
+	}
+
+	merged := *p
+	merged.UpdatedFields = make(map[string]interface{}, len(p.UpdatedFields))
+	for key, value := range p.UpdatedFields {
+		baseValue, currentValue := base.Fields[key], current.Fields[key]
+		if reflect.DeepEqual(baseValue, currentValue) || reflect.DeepEqual(value, currentValue) {
+			merged.UpdatedFields[key] = value
+			continue
+		}
+
+		patchMap, ok1 := value.(map[string]interface{})
+		baseMap, ok2 := baseValue.(map[string]interface{})
+		currentMap, ok3 := currentValue.(map[string]interface{})
+		if !ok1 || !ok2 || !ok3 {
+			conflicts = append(conflicts, key)
+			continue
+		}
+
+		mergedMap, mapConflicts := mergeFieldMaps(baseMap, currentMap, patchMap)
+		for _, mapKey := range mapConflicts {
+			conflicts = append(conflicts, key+"."+mapKey)
+		}
+		merged.UpdatedFields[key] = mergedMap
+	}
+
+	for _, key := range p.DeletedFields {
+		currentValue, ok := current.Fields[key]
+		if ok && !reflect.DeepEqual(base.Fields[key], currentValue) {
+			conflicts = append(conflicts, key)
+		}
+	}
+
+	if len(conflicts) != 0 {
+		sort.Strings(conflicts)
+		return nil, NewErrBlockPatchConflict(current.ID, conflicts)
+	}
+	return &merged, nil
+}
+
+// mergeFieldMaps applies the keys the patch added, changed or removed
+// compared to the base map on top of the current map, and returns the
+// keys that were also changed to a different value in the current map.
+func mergeFieldMaps(base, current, patch map[string]interface{}) (map[string]interface{}, []string) {
+	merged := make(map[string]interface{}, len(current))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	keys := make(map[string]bool, len(base)+len(patch))
+	for key := range base {
+		keys[key] = true
+	}
+	for key := range patch {
+		keys[key] = true
+	}
+
+	conflicts := []string{}
+	for key := range keys {
+		patchValue, inPatch := patch[key]
+		baseValue, inBase := base[key]
+		if inPatch == inBase && reflect.DeepEqual(patchValue, baseValue) {
+			continue
+		}
+
+		currentValue, inCurrent := current[key]
+		unchanged := inCurrent == inBase && reflect.DeepEqual(currentValue, baseValue)
+		same := inCurrent == inPatch && reflect.DeepEqual(currentValue, patchValue)
+		if !unchanged && !same {
+			conflicts = append(conflicts, key)
+			continue
+		}
+
+		if inPatch {
+			merged[key] = patchValue
+		} else {
+			delete(merged, key)
+		}
+	}
+	return merged, conflicts
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockPatchMerge(t *testing.T) {
+	base := &Block{
+		ID:    "card-id",
+		Type:  TypeCard,
+		Title: "title",
+		Fields: map[string]interface{}{
+			"icon": "🎉",
+			"properties": map[string]interface{}{
+				"status":   "todo",
+				"assignee": "user-1",
+			},
+		},
+	}
+
+	t.Run("different fields", func(t *testing.T) {
+		current := *base
+		current.Title = "new title"
+
+		icon := "🚀"
+		patch := &BlockPatch{UpdatedFields: map[string]interface{}{"icon": icon}}
+		merged, err := patch.Merge(base, &current)
+		require.NoError(t, err)
+		require.Equal(t, icon, merged.UpdatedFields["icon"])
+		require.Nil(t, merged.Title)
+	})
+
+	t.Run("different properties", func(t *testing.T) {
+		current := *base
+		current.Fields = map[string]interface{}{
+			"icon": "🎉",
+			"properties": map[string]interface{}{
+				"status":   "done",
+				"assignee": "user-1",
+			},
+		}
+
+		patch := &BlockPatch{UpdatedFields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status":   "todo",
+				"priority": "high",
+			},
+		}}
+		merged, err := patch.Merge(base, &current)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{
+			"status":   "done",
+			"priority": "high",
+		}, merged.UpdatedFields["properties"])
+	})
+
+	t.Run("same change", func(t *testing.T) {
+		current := *base
+		current.Title = "new title"
+
+		title := "new title"
+		merged, err := (&BlockPatch{Title: &title}).Merge(base, &current)
+		require.NoError(t, err)
+		require.Equal(t, title, *merged.Title)
+	})
+
+	t.Run("conflicting changes", func(t *testing.T) {
+		current := *base
+		current.Title = "new title"
+		current.Fields = map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status":   "done",
+				"assignee": "user-1",
+			},
+		}
+
+		title := "other title"
+		patch := &BlockPatch{
+			Title: &title,
+			UpdatedFields: map[string]interface{}{
+				"properties": map[string]interface{}{
+					"status":   "in progress",
+					"assignee": "user-1",
+				},
+			},
+			DeletedFields: []string{"icon"},
+		}
+		_, err := patch.Merge(base, &current)
+		require.True(t, IsErrBlockPatchConflict(err))
+
+		var errConflict *ErrBlockPatchConflict
+		require.ErrorAs(t, err, &errConflict)
+		require.Equal(t, "card-id", errConflict.BlockID)
+		require.Equal(t, []string{"properties.status", "title"}, errConflict.Fields)
+	})
+}
@@ -14,15 +14,21 @@ import (
 type BlockType string
 
 const (
-	TypeUnknown = "unknown"
-	TypeBoard   = "board"
-	TypeCard    = "card"
-	TypeView    = "view"
-	TypeText    = "text"
-	TypeComment = "comment"
-	TypeImage   = "image"
+	TypeUnknown  = "unknown"
+	TypeBoard    = "board"
+	TypeCard     = "card"
+	TypeView     = "view"
+	TypeText     = "text"
+	TypeComment  = "comment"
+	TypeImage    = "image"
+	TypeCheckbox = "checkbox"
 )
 
+// KnownBlockTypes returns the block types that can be stored.
+func KnownBlockTypes() []BlockType {
+	return []BlockType{TypeBoard, TypeCard, TypeView, TypeText, TypeComment, TypeImage, TypeCheckbox}
+}
+
 func (bt BlockType) String() string {
 	return string(bt)
 }
@@ -42,6 +48,8 @@ func BlockTypeFromString(s string) (BlockType, error) {
 		return TypeComment, nil
 	case "image":
 		return TypeImage, nil
+	case "checkbox":
+		return TypeCheckbox, nil
 	}
 	return TypeUnknown, ErrInvalidBlockType{s}
 }
@@ -55,7 +63,7 @@ func BlockType2IDType(blockType BlockType) utils.IDType {
 		return utils.IDTypeCard
 	case TypeView:
 		return utils.IDTypeView
-	case TypeText, TypeComment:
+	case TypeText, TypeComment, TypeCheckbox:
 		return utils.IDTypeBlock
 	}
 	return utils.IDTypeNone
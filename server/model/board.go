@@ -258,6 +258,9 @@ func (p *BoardPatch) Patch(board *Board) *Board {
 		board.ShowDescription = *p.ShowDescription
 	}
 
+	if len(p.UpdatedProperties) != 0 && board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
 	for key, property := range p.UpdatedProperties {
 		board.Properties[key] = property
 	}
@@ -334,6 +337,43 @@ func (p *BoardPatch) IsValid() error {
 		return InvalidBoardErr{"invalid-board-minimum-role"}
 	}
 
+	if !IsValidRequiredEnforcement(p.UpdatedProperties[BoardPropertyRequiredEnforcement]) {
+		return InvalidBoardErr{"invalid-required-properties-enforcement"}
+	}
+
+	if _, err := parseBoardSubmissionSettings(p.UpdatedProperties[BoardPropertySubmissions]); err != nil {
+		return InvalidBoardErr{"invalid-submission-settings"}
+	}
+
+	if appearance, err := parseBoardAppearance(p.UpdatedProperties[BoardPropertyAppearance]); err != nil || (appearance != nil && appearance.IsValid() != nil) {
+		return InvalidBoardErr{"invalid-appearance"}
+	}
+
+	if formatting, err := parseBoardFormatting(p.UpdatedProperties[BoardPropertyFormatting]); err != nil || (formatting != nil && formatting.IsValid() != nil) {
+		return InvalidBoardErr{"invalid-formatting"}
+	}
+
+	if value, ok := p.UpdatedProperties[BoardPropertyNotifyUnassigned]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-notify-unassigned"}
+		}
+	}
+
+	if value, ok := p.UpdatedProperties[BoardPropertyAssignMentioned]; ok {
+		if _, isBool := value.(bool); !isBool {
+			return InvalidBoardErr{"invalid-assign-mentioned"}
+		}
+	}
+
+	if _, ok := p.UpdatedProperties[BoardPropertyLimitsExempt]; ok {
+		return InvalidBoardErr{"limits-exempt-not-patchable"}
+	}
+	for _, key := range p.DeletedProperties {
+		if key == BoardPropertyLimitsExempt {
+			return InvalidBoardErr{"limits-exempt-not-patchable"}
+		}
+	}
+
 	return nil
 }
 
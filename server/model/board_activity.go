@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultActivityWeeks is the number of weeks of a board activity
+	// when none is given.
+	DefaultActivityWeeks = 12
+
+	// MaxActivityWeeks is the longest board activity that can be
+	// requested, a bit more than a year.
+	MaxActivityWeeks = 53
+)
+
+// ActivityDateFormat is the format of the days of a board activity.
+const ActivityDateFormat = "2006-01-02"
+
+// BoardActivity is the number of edits of a board per day over a number of
+// weeks ending today, as shown by a contribution heatmap
+// swagger:model
+type BoardActivity struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of weeks covered
+	// required: true
+	Weeks int `json:"weeks"`
+
+	// The time zone the days are computed in
+	// required: true
+	TimeZone string `json:"timeZone"`
+
+	// The number of edits over the whole period
+	// required: true
+	Total int `json:"total"`
+
+	// The time of the last edit of the board or any of its blocks, in
+	// miliseconds since the current epoch. 0 if there was no edit in the
+	// period
+	// required: true
+	LastActivityAt int64 `json:"lastActivityAt"`
+
+	// The edits per day, from the oldest day to today, including the days
+	// without edits
+	// required: true
+	Days []BoardActivityDay `json:"days"`
+}
+
+// BoardActivityDay is the number of edits of a board on one day
+// swagger:model
+type BoardActivityDay struct {
+	// The day, as YYYY-MM-DD
+	// required: true
+	Date string `json:"date"`
+
+	// The number of edits of the board and its blocks that day
+	// required: true
+	Count int `json:"count"`
+}
+
+// ErrInvalidActivityRange is returned when the period of a board activity
+// can't be computed.
+type ErrInvalidActivityRange struct {
+	msg string
+}
+
+// NewErrInvalidActivityRange returns an error for a board activity period
+// that can't be computed.
+func NewErrInvalidActivityRange(msg string) *ErrInvalidActivityRange {
+	return &ErrInvalidActivityRange{msg: msg}
+}
+
+func (e *ErrInvalidActivityRange) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidActivityRange returns true if the error is an
+// ErrInvalidActivityRange.
+func IsErrInvalidActivityRange(err error) bool {
+	var errInvalid *ErrInvalidActivityRange
+	return errors.As(err, &errInvalid)
+}
+
+// ValidateActivityWeeks checks that a board activity can cover the number
+// of weeks.
+func ValidateActivityWeeks(weeks int) error {
+	if weeks < 1 || weeks > MaxActivityWeeks {
+		return NewErrInvalidActivityRange(fmt.Sprintf("weeks must be between 1 and %d", MaxActivityWeeks))
+	}
+	return nil
+}
+
+// ActivityStart returns the start of the first day of a board activity of
+// the given number of weeks ending on the day of now.
+func ActivityStart(weeks int, now time.Time) time.Time {
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	return today.AddDate(0, 0, 1-weeks*7)
+}
+
+// NewBoardActivity counts the edits of a board per day of the location of
+// now, from the update times of its history entries. The times before
+// the period are ignored.
+func NewBoardActivity(boardID string, weeks int, now time.Time, updateTimes []int64) *BoardActivity {
+	start := ActivityStart(weeks, now)
+	activity := &BoardActivity{
+		BoardID:  boardID,
+		Weeks:    weeks,
+		TimeZone: now.Location().String(),
+		Days:     make([]BoardActivityDay, 0, weeks*7),
+	}
+
+	index := make(map[string]int, weeks*7)
+	for day := start; len(activity.Days) < weeks*7; day = day.AddDate(0, 0, 1) {
+		date := day.Format(ActivityDateFormat)
+		index[date] = len(activity.Days)
+		activity.Days = append(activity.Days, BoardActivityDay{Date: date})
+	}
+
+	for _, updateAt := range updateTimes {
+		idx, ok := index[GetTimeForMillis(updateAt).In(now.Location()).Format(ActivityDateFormat)]
+		if !ok {
+			continue
+		}
+		activity.Days[idx].Count++
+		activity.Total++
+		if updateAt > activity.LastActivityAt {
+			activity.LastActivityAt = updateAt
+		}
+	}
+	return activity
+}
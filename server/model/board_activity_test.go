@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateActivityWeeks(t *testing.T) {
+	require.NoError(t, ValidateActivityWeeks(1))
+	require.NoError(t, ValidateActivityWeeks(MaxActivityWeeks))
+	require.True(t, IsErrInvalidActivityRange(ValidateActivityWeeks(0)))
+	require.True(t, IsErrInvalidActivityRange(ValidateActivityWeeks(MaxActivityWeeks+1)))
+}
+
+func TestNewBoardActivity(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	now := time.Date(2022, 5, 26, 15, 0, 0, 0, loc)
+	millis := func(t time.Time) int64 {
+		return GetMillisForTime(t)
+	}
+
+	updates := []int64{
+		millis(time.Date(2022, 5, 26, 9, 0, 0, 0, loc)),
+		millis(time.Date(2022, 5, 26, 10, 0, 0, 0, loc)),
+		// 23:30 UTC on the 19th is already the 20th in the location
+		millis(time.Date(2022, 5, 19, 23, 30, 0, 0, time.UTC)),
+		millis(time.Date(2022, 5, 13, 0, 0, 0, 0, loc)),
+		// before the period
+		millis(time.Date(2022, 5, 12, 23, 59, 0, 0, loc)),
+	}
+
+	activity := NewBoardActivity("board-id", 2, now, updates)
+	require.Equal(t, "board-id", activity.BoardID)
+	require.Equal(t, "UTC+2", activity.TimeZone)
+	require.Len(t, activity.Days, 14)
+	require.Equal(t, "2022-05-13", activity.Days[0].Date)
+	require.Equal(t, 1, activity.Days[0].Count)
+	require.Equal(t, "2022-05-20", activity.Days[7].Date)
+	require.Equal(t, 1, activity.Days[7].Count)
+	require.Equal(t, "2022-05-26", activity.Days[13].Date)
+	require.Equal(t, 2, activity.Days[13].Count)
+	require.Equal(t, 4, activity.Total)
+	require.Equal(t, updates[1], activity.LastActivityAt)
+}
+
+func TestNewBoardActivityDaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	// the clocks went forward on the 27th of March 2022
+	now := time.Date(2022, 4, 2, 12, 0, 0, 0, loc)
+	activity := NewBoardActivity("board-id", 1, now, nil)
+	require.Len(t, activity.Days, 7)
+	require.Equal(t, "2022-03-27", activity.Days[0].Date)
+	require.Equal(t, "2022-04-02", activity.Days[6].Date)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"unicode/utf8"
+)
+
+// BoardPropertyAppearance is the key of the board property that holds the
+// appearance settings of the board, except its icon which is a field of
+// the board.
+const BoardPropertyAppearance = "appearance"
+
+// MaxBoardIconLength is the maximum number of characters of the emoji icon
+// of a board, which can be a sequence of emojis joined together.
+const MaxBoardIconLength = 16
+
+// CardPreviewStyle is how the cards of a board are previewed in the gallery
+// and board views.
+type CardPreviewStyle string
+
+const (
+	// CardPreviewStyleDefault shows the title and the visible properties.
+	CardPreviewStyleDefault CardPreviewStyle = "default"
+	// CardPreviewStyleCompact shows only the title.
+	CardPreviewStyleCompact CardPreviewStyle = "compact"
+	// CardPreviewStyleImage shows the first image of the card above its
+	// title.
+	CardPreviewStyleImage CardPreviewStyle = "image"
+)
+
+var boardColorRegexp = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// BoardAppearance is the look of a board in the sidebar, the board header
+// and the template picker
+// swagger:model
+type BoardAppearance struct {
+	// The id of the file of the cover image, uploaded to the board
+	// required: false
+	CoverFileID string `json:"coverFileId"`
+
+	// The accent color, as #rrggbb
+	// required: false
+	Color string `json:"color"`
+
+	// The emoji icon of the board
+	// required: false
+	Icon string `json:"icon"`
+
+	// How the cards are previewed: default, compact or image
+	// required: false
+	CardPreviewStyle CardPreviewStyle `json:"cardPreviewStyle"`
+}
+
+// ErrInvalidBoardAppearance is returned when the appearance settings of a
+// board can't be used.
+type ErrInvalidBoardAppearance struct {
+	msg string
+}
+
+// NewErrInvalidBoardAppearance returns an error for appearance settings
+// that can't be used.
+func NewErrInvalidBoardAppearance(msg string) *ErrInvalidBoardAppearance {
+	return &ErrInvalidBoardAppearance{msg: msg}
+}
+
+func (e *ErrInvalidBoardAppearance) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidBoardAppearance returns true if the error is an
+// ErrInvalidBoardAppearance.
+func IsErrInvalidBoardAppearance(err error) bool {
+	var errInvalid *ErrInvalidBoardAppearance
+	return errors.As(err, &errInvalid)
+}
+
+// BoardAppearanceFromBoard returns the appearance settings of a board, the
+// defaults if the board has none.
+func BoardAppearanceFromBoard(board *Board) (*BoardAppearance, error) {
+	appearance, err := parseBoardAppearance(board.Properties[BoardPropertyAppearance])
+	if err != nil {
+		return nil, err
+	}
+	if appearance == nil {
+		appearance = &BoardAppearance{}
+	}
+	appearance.Icon = board.Icon
+	if appearance.CardPreviewStyle == "" {
+		appearance.CardPreviewStyle = CardPreviewStyleDefault
+	}
+	return appearance, nil
+}
+
+// ApplyTo sets the appearance settings on the board: its icon field, and
+// the other settings in its properties.
+func (a *BoardAppearance) ApplyTo(board *Board) {
+	if board.Properties == nil {
+		board.Properties = map[string]interface{}{}
+	}
+	board.Icon = a.Icon
+
+	stored := *a
+	stored.Icon = ""
+	board.Properties[BoardPropertyAppearance] = &stored
+}
+
+func parseBoardAppearance(raw interface{}) (*BoardAppearance, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var appearance *BoardAppearance
+	if err := json.Unmarshal(data, &appearance); err != nil {
+		return nil, err
+	}
+	return appearance, nil
+}
+
+// IsValid checks the format of the settings. Whether the cover file exists
+// is checked when saving them.
+func (a *BoardAppearance) IsValid() error {
+	if a.CoverFileID != "" && (filepath.Base(a.CoverFileID) != a.CoverFileID || a.CoverFileID == "." || a.CoverFileID == "..") {
+		return NewErrInvalidBoardAppearance(fmt.Sprintf("invalid cover file id %q", a.CoverFileID))
+	}
+	if a.Color != "" && !boardColorRegexp.MatchString(a.Color) {
+		return NewErrInvalidBoardAppearance(fmt.Sprintf("color %q is not a #rrggbb color", a.Color))
+	}
+	if utf8.RuneCountInString(a.Icon) > MaxBoardIconLength {
+		return NewErrInvalidBoardAppearance(fmt.Sprintf("the icon can't have more than %d characters", MaxBoardIconLength))
+	}
+	switch a.CardPreviewStyle {
+	case "", CardPreviewStyleDefault, CardPreviewStyleCompact, CardPreviewStyleImage:
+	default:
+		return NewErrInvalidBoardAppearance(fmt.Sprintf("unknown card preview style %q", a.CardPreviewStyle))
+	}
+	return nil
+}
+
+// BoardCoverFileID returns the id of the cover image file of the board, or
+// an empty string if it has none.
+func BoardCoverFileID(board *Board) string {
+	appearance, err := parseBoardAppearance(board.Properties[BoardPropertyAppearance])
+	if err != nil || appearance == nil {
+		return ""
+	}
+	return appearance.CoverFileID
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardAppearanceIsValid(t *testing.T) {
+	valid := &BoardAppearance{CoverFileID: "7abc.png", Color: "#A0b1C2", Icon: "👩‍💻", CardPreviewStyle: CardPreviewStyleCompact}
+	require.NoError(t, valid.IsValid())
+	require.NoError(t, (&BoardAppearance{}).IsValid())
+
+	for _, appearance := range []*BoardAppearance{
+		{CoverFileID: "../other-board/7abc.png"},
+		{CoverFileID: ".."},
+		{Color: "red"},
+		{Color: "#abc"},
+		{Icon: "🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀🚀"},
+		{CardPreviewStyle: "large"},
+	} {
+		require.True(t, IsErrInvalidBoardAppearance(appearance.IsValid()), "%+v", appearance)
+	}
+}
+
+func TestBoardAppearanceFromBoard(t *testing.T) {
+	board := &Board{Icon: "📋"}
+	appearance, err := BoardAppearanceFromBoard(board)
+	require.NoError(t, err)
+	require.Equal(t, &BoardAppearance{Icon: "📋", CardPreviewStyle: CardPreviewStyleDefault}, appearance)
+	require.Empty(t, BoardCoverFileID(board))
+
+	(&BoardAppearance{CoverFileID: "cover.png", Color: "#000000", Icon: "🚀"}).ApplyTo(board)
+	require.Equal(t, "🚀", board.Icon)
+	require.Equal(t, "cover.png", BoardCoverFileID(board))
+
+	appearance, err = BoardAppearanceFromBoard(board)
+	require.NoError(t, err)
+	require.Equal(t, &BoardAppearance{CoverFileID: "cover.png", Color: "#000000", Icon: "🚀", CardPreviewStyle: CardPreviewStyleDefault}, appearance)
+}
+
+func TestBoardPatchAppearance(t *testing.T) {
+	patch := &BoardPatch{UpdatedProperties: map[string]interface{}{
+		BoardPropertyAppearance: map[string]interface{}{"color": "#123456"},
+	}}
+	require.NoError(t, patch.IsValid())
+
+	patch.UpdatedProperties[BoardPropertyAppearance] = map[string]interface{}{"color": "blue"}
+	require.Error(t, patch.IsValid())
+}
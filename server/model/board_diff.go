@@ -0,0 +1,171 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"reflect"
+	"sort"
+)
+
+// BoardDiff summarizes the changes of the cards of a board between two times
+// swagger:model
+type BoardDiff struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The start of the period in milliseconds since the current epoch
+	// required: true
+	FromTS int64 `json:"fromTs"`
+
+	// The end of the period in milliseconds since the current epoch
+	// required: true
+	ToTS int64 `json:"toTs"`
+
+	// The cards created during the period
+	// required: true
+	Added []CardDiff `json:"added"`
+
+	// The cards deleted during the period
+	// required: true
+	Removed []CardDiff `json:"removed"`
+
+	// The cards whose select values changed, moving them between groups
+	// required: true
+	Moved []CardDiff `json:"moved"`
+
+	// The cards with other changes
+	// required: true
+	Updated []CardDiff `json:"updated"`
+}
+
+// CardDiff describes the changes of a card
+// swagger:model
+type CardDiff struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The current title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The previous title of the card, if it changed
+	// required: false
+	OldTitle string `json:"oldTitle,omitempty"`
+
+	// The id of the user who last modified the card during the period
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The time of the last change in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The properties whose value changed
+	// required: true
+	PropertyChanges []CardPropertyChange `json:"propertyChanges"`
+}
+
+// CardPropertyChange describes the change of a property value of a card
+// swagger:model
+type CardPropertyChange struct {
+	// The id of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+
+	// The value before the period
+	// required: false
+	OldValue interface{} `json:"oldValue"`
+
+	// The value at the end of the period
+	// required: false
+	NewValue interface{} `json:"newValue"`
+}
+
+// NewBoardDiff returns an empty diff of the board for the period.
+func NewBoardDiff(boardID string, fromTS, toTS int64) *BoardDiff {
+	return &BoardDiff{
+		BoardID: boardID,
+		FromTS:  fromTS,
+		ToTS:    toTS,
+		Added:   []CardDiff{},
+		Removed: []CardDiff{},
+		Moved:   []CardDiff{},
+		Updated: []CardDiff{},
+	}
+}
+
+// AddCard classifies the change of a card between its version at the
+// start of the period, nil if it didn't exist yet, and its last version
+// of the period.
+func (d *BoardDiff) AddCard(properties []CardProperty, before, after *Block) {
+	existedBefore := before != nil && before.DeleteAt == 0
+	existsAfter := after.DeleteAt == 0
+
+	diff := CardDiff{
+		CardID:          after.ID,
+		Title:           after.Title,
+		ModifiedBy:      after.ModifiedBy,
+		UpdateAt:        after.UpdateAt,
+		PropertyChanges: []CardPropertyChange{},
+	}
+
+	switch {
+	case !existedBefore && existsAfter:
+		d.Added = append(d.Added, diff)
+	case existedBefore && !existsAfter:
+		diff.Title = before.Title
+		d.Removed = append(d.Removed, diff)
+	case existedBefore && existsAfter:
+		if before.Title != after.Title {
+			diff.OldTitle = before.Title
+		}
+		moved := false
+		diff.PropertyChanges, moved = cardPropertyChanges(properties, before, after)
+		if moved {
+			d.Moved = append(d.Moved, diff)
+		} else if diff.OldTitle != "" || len(diff.PropertyChanges) != 0 {
+			d.Updated = append(d.Updated, diff)
+		}
+	}
+}
+
+// Sort orders the cards of every list by the time of their last change.
+func (d *BoardDiff) Sort() {
+	for _, list := range [][]CardDiff{d.Added, d.Removed, d.Moved, d.Updated} {
+		sort.SliceStable(list, func(i, j int) bool { return list[i].UpdateAt < list[j].UpdateAt })
+	}
+}
+
+// cardPropertyChanges returns the property values that differ between two
+// versions of a card, and whether a select value changed.
+func cardPropertyChanges(properties []CardProperty, before, after *Block) ([]CardPropertyChange, bool) {
+	oldValues := CardPropertyValues(before)
+	newValues := CardPropertyValues(after)
+
+	changes := []CardPropertyChange{}
+	moved := false
+	for i := range properties {
+		oldValue := oldValues[properties[i].ID]
+		newValue := newValues[properties[i].ID]
+		if reflect.DeepEqual(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, CardPropertyChange{
+			PropertyID: properties[i].ID,
+			Name:       properties[i].Name,
+			OldValue:   oldValue,
+			NewValue:   newValue,
+		})
+		if properties[i].Type == PropertyTypeSelect {
+			moved = true
+		}
+	}
+	return changes, moved
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardDiffAddCard(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "status", Name: "Status", Type: PropertyTypeSelect},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+	card := func(title, status, notes string, deleteAt int64) *Block {
+		return &Block{
+			ID:       "card-id",
+			Type:     TypeCard,
+			Title:    title,
+			DeleteAt: deleteAt,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"status": status, "notes": notes},
+			},
+		}
+	}
+
+	t.Run("added card", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, nil, card("Card", "todo", "", 0))
+		require.Len(t, diff.Added, 1)
+		require.Equal(t, "Card", diff.Added[0].Title)
+	})
+
+	t.Run("restored card is added", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, card("Card", "todo", "", 1), card("Card", "todo", "", 0))
+		require.Len(t, diff.Added, 1)
+	})
+
+	t.Run("removed card", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, card("Old", "todo", "", 0), card("New", "todo", "", 2))
+		require.Len(t, diff.Removed, 1)
+		require.Equal(t, "Old", diff.Removed[0].Title)
+	})
+
+	t.Run("card created and removed during the period", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, nil, card("Card", "todo", "", 2))
+		require.Empty(t, diff.Added)
+		require.Empty(t, diff.Removed)
+	})
+
+	t.Run("moved card", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, card("Card", "todo", "", 0), card("Card", "done", "text", 0))
+		require.Len(t, diff.Moved, 1)
+		require.Len(t, diff.Moved[0].PropertyChanges, 2)
+		require.Equal(t, "todo", diff.Moved[0].PropertyChanges[0].OldValue)
+		require.Equal(t, "done", diff.Moved[0].PropertyChanges[0].NewValue)
+	})
+
+	t.Run("updated card", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, card("Old", "todo", "", 0), card("New", "todo", "", 0))
+		require.Len(t, diff.Updated, 1)
+		require.Equal(t, "Old", diff.Updated[0].OldTitle)
+		require.Empty(t, diff.Updated[0].PropertyChanges)
+	})
+
+	t.Run("unchanged card", func(t *testing.T) {
+		diff := NewBoardDiff("board-id", 1, 2)
+		diff.AddCard(properties, card("Card", "todo", "", 0), card("Card", "todo", "", 0))
+		require.Empty(t, diff.Added)
+		require.Empty(t, diff.Removed)
+		require.Empty(t, diff.Moved)
+		require.Empty(t, diff.Updated)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultDormantBoardDays is the number of days without activity after
+	// which a board is dormant, when the configuration doesn't set one.
+	DefaultDormantBoardDays = 90
+
+	// MaxDormantBoardDays is the longest period without activity that can
+	// be reported on, about ten years.
+	MaxDormantBoardDays = 3650
+)
+
+// DormantBoard is a board without activity for a number of days, which
+// could be archived or exported
+// swagger:model
+type DormantBoard struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The title of the board
+	// required: true
+	Title string `json:"title"`
+
+	// The time of the last edit of the board or any of its blocks, in
+	// miliseconds since the current epoch
+	// required: true
+	LastActivityAt int64 `json:"lastActivityAt"`
+
+	// The number of whole days since the last edit
+	// required: true
+	DormantDays int `json:"dormantDays"`
+}
+
+// ErrInvalidDormantBoardDays is returned when boards can't be reported as
+// dormant after the requested number of days.
+type ErrInvalidDormantBoardDays struct {
+	msg string
+}
+
+func newErrInvalidDormantBoardDays(msg string) *ErrInvalidDormantBoardDays {
+	return &ErrInvalidDormantBoardDays{msg: msg}
+}
+
+func (e *ErrInvalidDormantBoardDays) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidDormantBoardDays returns true if the error is an
+// ErrInvalidDormantBoardDays.
+func IsErrInvalidDormantBoardDays(err error) bool {
+	var errInvalid *ErrInvalidDormantBoardDays
+	return errors.As(err, &errInvalid)
+}
+
+// ValidateDormantBoardDays checks that boards can be reported as dormant
+// after the number of days.
+func ValidateDormantBoardDays(days int) error {
+	if days < 1 || days > MaxDormantBoardDays {
+		return newErrInvalidDormantBoardDays(fmt.Sprintf("days must be between 1 and %d", MaxDormantBoardDays))
+	}
+	return nil
+}
+
+// DormantBoardsCutoff returns the time before which the last activity of
+// a board must be for the board to be dormant after the number of days.
+func DormantBoardsCutoff(days int, now time.Time) int64 {
+	return GetMillisForTime(now.AddDate(0, 0, -days))
+}
+
+// FindDormantBoards returns the boards without activity for at least the
+// number of days, least recently active first. The last activity of a
+// board is its own last update or the last update of one of its blocks,
+// given by board id. Templates and deleted boards are never dormant.
+func FindDormantBoards(boards []*Board, lastBlockUpdates map[string]int64, days int, now time.Time) []*DormantBoard {
+	cutoff := DormantBoardsCutoff(days, now)
+	nowMillis := GetMillisForTime(now)
+
+	dormant := []*DormantBoard{}
+	for _, board := range boards {
+		if board.IsTemplate || board.DeleteAt != 0 {
+			continue
+		}
+
+		lastActivityAt := board.UpdateAt
+		if lastBlockUpdates[board.ID] > lastActivityAt {
+			lastActivityAt = lastBlockUpdates[board.ID]
+		}
+		if lastActivityAt >= cutoff {
+			continue
+		}
+
+		dormant = append(dormant, &DormantBoard{
+			BoardID:        board.ID,
+			TeamID:         board.TeamID,
+			Title:          board.Title,
+			LastActivityAt: lastActivityAt,
+			DormantDays:    int((nowMillis - lastActivityAt) / int64(24*time.Hour/time.Millisecond)),
+		})
+	}
+
+	sort.Slice(dormant, func(i, j int) bool {
+		if dormant[i].LastActivityAt != dormant[j].LastActivityAt {
+			return dormant[i].LastActivityAt < dormant[j].LastActivityAt
+		}
+		return dormant[i].BoardID < dormant[j].BoardID
+	})
+	return dormant
+}
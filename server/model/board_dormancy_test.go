@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDormantBoardDays(t *testing.T) {
+	require.NoError(t, ValidateDormantBoardDays(1))
+	require.NoError(t, ValidateDormantBoardDays(MaxDormantBoardDays))
+	require.True(t, IsErrInvalidDormantBoardDays(ValidateDormantBoardDays(0)))
+	require.True(t, IsErrInvalidDormantBoardDays(ValidateDormantBoardDays(MaxDormantBoardDays+1)))
+}
+
+func TestFindDormantBoards(t *testing.T) {
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return GetMillisForTime(now.AddDate(0, 0, -days))
+	}
+
+	boards := []*Board{
+		{ID: "active", TeamID: "team-id", UpdateAt: daysAgo(1)},
+		{ID: "active-blocks", TeamID: "team-id", UpdateAt: daysAgo(100)},
+		{ID: "dormant", TeamID: "team-id", Title: "Old plans", UpdateAt: daysAgo(40)},
+		{ID: "dormant-blocks", TeamID: "team-id", UpdateAt: daysAgo(60)},
+		{ID: "template", TeamID: "team-id", IsTemplate: true, UpdateAt: daysAgo(100)},
+		{ID: "deleted", TeamID: "team-id", DeleteAt: daysAgo(50), UpdateAt: daysAgo(100)},
+	}
+	lastBlockUpdates := map[string]int64{
+		"active-blocks":  daysAgo(2),
+		"dormant-blocks": daysAgo(45),
+	}
+
+	dormant := FindDormantBoards(boards, lastBlockUpdates, 30, now)
+	require.Len(t, dormant, 2)
+	require.Equal(t, "dormant-blocks", dormant[0].BoardID)
+	require.Equal(t, daysAgo(45), dormant[0].LastActivityAt)
+	require.Equal(t, 45, dormant[0].DormantDays)
+	require.Equal(t, "dormant", dormant[1].BoardID)
+	require.Equal(t, "Old plans", dormant[1].Title)
+	require.Equal(t, 40, dormant[1].DormantDays)
+
+	require.Empty(t, FindDormantBoards(boards, lastBlockUpdates, 50, now))
+}
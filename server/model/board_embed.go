@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// BoardPropertyEmbeds is the key of the board property that holds the
+// embed tokens of the board.
+const BoardPropertyEmbeds = "embeds"
+
+// BoardEmbed gives read only access to a single view of a board, to be
+// shown in an iframe of the allowed origins
+// swagger:model
+type BoardEmbed struct {
+	// The id of the embed
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the embedded view
+	// required: true
+	ViewID string `json:"viewId"`
+
+	// The token that gives access to the embed
+	// required: true
+	Token string `json:"token"`
+
+	// The origins of the pages allowed to embed the view, e.g. https://docs.example.com
+	// required: false
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// The id of the user that created the embed
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// Created time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// EmbedContent is what an embed shows: the view and the cards of the
+// board, with only the property values visible in the view
+// swagger:model
+type EmbedContent struct {
+	// The embedded board
+	// required: true
+	Board EmbedBoard `json:"board"`
+
+	// The embedded view
+	// required: true
+	View Block `json:"view"`
+
+	// The cards of the board
+	// required: true
+	Cards []Block `json:"cards"`
+}
+
+// EmbedBoard is the part of a board needed to render an embedded view
+// swagger:model
+type EmbedBoard struct {
+	// The id of the board
+	// required: true
+	ID string `json:"id"`
+
+	// The title of the board
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the board
+	// required: false
+	Icon string `json:"icon,omitempty"`
+
+	// The card properties shown in the view
+	// required: true
+	CardProperties []map[string]interface{} `json:"cardProperties"`
+}
+
+// ErrInvalidEmbed is returned when the settings of an embed are not valid.
+type ErrInvalidEmbed struct {
+	msg string
+}
+
+func newErrInvalidEmbed(msg string) *ErrInvalidEmbed {
+	return &ErrInvalidEmbed{msg: msg}
+}
+
+func (e *ErrInvalidEmbed) Error() string {
+	return e.msg
+}
+
+// ErrRestrictedViewEmbed is returned when embedding a view restricted to
+// the board admins.
+var ErrRestrictedViewEmbed = newErrInvalidEmbed("restricted views cannot be embedded")
+
+// IsErrInvalidEmbed returns true if the error is an ErrInvalidEmbed.
+func IsErrInvalidEmbed(err error) bool {
+	var errInvalid *ErrInvalidEmbed
+	return errors.As(err, &errInvalid)
+}
+
+// BoardEmbedsFromBoard returns the embeds of a board.
+func BoardEmbedsFromBoard(board *Board) ([]BoardEmbed, error) {
+	raw := board.Properties[BoardPropertyEmbeds]
+	if raw == nil {
+		return []BoardEmbed{}, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	embeds := []BoardEmbed{}
+	if err := json.Unmarshal(data, &embeds); err != nil {
+		return nil, err
+	}
+	return embeds, nil
+}
+
+// FindBoardEmbed returns the index of the embed with the given id, or -1.
+func FindBoardEmbed(embeds []BoardEmbed, embedID string) int {
+	for i := range embeds {
+		if embeds[i].ID == embedID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Hydrate sets the id, the token and the creation details of a new embed.
+func (e *BoardEmbed) Hydrate(userID string) {
+	e.ID = utils.NewID(utils.IDTypeNone)
+	e.Token = utils.NewID(utils.IDTypeToken)
+	e.CreatedBy = userID
+	e.CreateAt = utils.GetMillis()
+	if e.AllowedOrigins == nil {
+		e.AllowedOrigins = []string{}
+	}
+}
+
+// HasToken returns true if the token gives access to the embed.
+func (e *BoardEmbed) HasToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(e.Token), []byte(token)) == 1
+}
+
+// IsValid checks that the allowed origins are plain http or https origins.
+func (e *BoardEmbed) IsValid() error {
+	if e.ViewID == "" {
+		return newErrInvalidEmbed("view id cannot be empty")
+	}
+
+	for _, origin := range e.AllowedOrigins {
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" ||
+			(u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return newErrInvalidEmbed(fmt.Sprintf("invalid allowed origin %q", origin))
+		}
+	}
+	return nil
+}
+
+// IsOriginAllowed returns true if the origin can embed the view.
+func (e *BoardEmbed) IsOriginAllowed(origin string) bool {
+	for _, allowed := range e.AllowedOrigins {
+		if trimOrigin(allowed) == trimOrigin(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func trimOrigin(origin string) string {
+	if len(origin) > 0 && origin[len(origin)-1] == '/' {
+		return origin[:len(origin)-1]
+	}
+	return origin
+}
+
+// NewEmbedContent returns the content of an embedded view, keeping only
+// the property values that the view shows and skipping card templates.
+func NewEmbedContent(board *Board, view *Block, cards []Block) *EmbedContent {
+	visible := map[string]bool{}
+	if ids, ok := view.Fields["visiblePropertyIds"].([]interface{}); ok {
+		for _, id := range ids {
+			if propertyID, ok := id.(string); ok {
+				visible[propertyID] = true
+			}
+		}
+	}
+	if groupByID, ok := view.Fields["groupById"].(string); ok {
+		visible[groupByID] = true
+	}
+
+	content := &EmbedContent{
+		Board: EmbedBoard{
+			ID:             board.ID,
+			Title:          board.Title,
+			Icon:           board.Icon,
+			CardProperties: []map[string]interface{}{},
+		},
+		View:  *view,
+		Cards: make([]Block, 0, len(cards)),
+	}
+
+	for _, property := range board.CardProperties {
+		if id, _ := property["id"].(string); visible[id] {
+			content.Board.CardProperties = append(content.Board.CardProperties, property)
+		}
+	}
+
+	for i := range cards {
+		if cards[i].Type != TypeCard || IsCardTemplate(&cards[i]) {
+			continue
+		}
+
+		values := map[string]interface{}{}
+		for propertyID, value := range CardPropertyValues(&cards[i]) {
+			if visible[propertyID] {
+				values[propertyID] = value
+			}
+		}
+
+		card := cards[i]
+		card.Fields = map[string]interface{}{"properties": values}
+		if icon, ok := cards[i].Fields["icon"]; ok {
+			card.Fields["icon"] = icon
+		}
+		content.Cards = append(content.Cards, card)
+	}
+	return content
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardEmbedIsValid(t *testing.T) {
+	embed := &BoardEmbed{ViewID: "view-id", AllowedOrigins: []string{"https://docs.example.com", "http://localhost:3000/"}}
+	require.NoError(t, embed.IsValid())
+	require.True(t, embed.IsOriginAllowed("http://localhost:3000"))
+	require.False(t, embed.IsOriginAllowed("https://example.com"))
+
+	invalidOrigins := []string{"*", "javascript:alert(1)", "https://docs.example.com/page", "docs.example.com", "https://user@docs.example.com"}
+	for _, origin := range invalidOrigins {
+		embed.AllowedOrigins = []string{origin}
+		require.True(t, IsErrInvalidEmbed(embed.IsValid()), origin)
+	}
+
+	require.True(t, IsErrInvalidEmbed((&BoardEmbed{}).IsValid()))
+}
+
+func TestNewEmbedContent(t *testing.T) {
+	board := &Board{
+		ID:    "board-id",
+		Title: "Roadmap",
+		CardProperties: []map[string]interface{}{
+			{"id": "status", "name": "Status", "type": PropertyTypeSelect},
+			{"id": "quarter", "name": "Quarter", "type": PropertyTypeText},
+			{"id": "cost", "name": "Cost", "type": PropertyTypeNumber},
+		},
+	}
+	view := &Block{ID: "view-id", Type: TypeView, Fields: map[string]interface{}{
+		"groupById":          "status",
+		"visiblePropertyIds": []interface{}{"quarter"},
+	}}
+	cards := []Block{
+		{ID: "card-1", Type: TypeCard, Fields: map[string]interface{}{
+			"icon":         "🚀",
+			"contentOrder": []interface{}{"text-1"},
+			"properties":   map[string]interface{}{"status": "done", "quarter": "Q3", "cost": "1000"},
+		}},
+		{ID: "template", Type: TypeCard, Fields: map[string]interface{}{"isTemplate": true}},
+	}
+
+	content := NewEmbedContent(board, view, cards)
+	require.Len(t, content.Board.CardProperties, 2)
+	require.Len(t, content.Cards, 1)
+	require.Equal(t, map[string]interface{}{"status": "done", "quarter": "Q3"}, CardPropertyValues(&content.Cards[0]))
+	require.Equal(t, "🚀", content.Cards[0].Fields["icon"])
+	require.NotContains(t, content.Cards[0].Fields, "contentOrder")
+	require.Contains(t, cards[0].Fields, "contentOrder")
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// The formats a board view can be exported to.
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// ErrInvalidExportFormat is returned when a board view is exported to an
+// unknown format.
+var ErrInvalidExportFormat = errors.New("invalid export format")
+
+const (
+	exportTitleColumn = "Title"
+	exportDateLayout  = "2006-01-02"
+	exportTimeLayout  = " 15:04"
+)
+
+// IsValidExportFormat returns true if a board view can be exported to the format.
+func IsValidExportFormat(format string) bool {
+	return format == ExportFormatCSV || format == ExportFormatXLSX
+}
+
+// ExportColumns returns the properties shown by the view, in the order of
+// the board schema. Without a view all the properties are returned.
+func ExportColumns(properties []CardProperty, view *Block) []CardProperty {
+	if view == nil {
+		return properties
+	}
+
+	visible := map[string]bool{}
+	if ids, ok := view.Fields["visiblePropertyIds"].([]interface{}); ok {
+		for _, id := range ids {
+			if propertyID, ok := id.(string); ok {
+				visible[propertyID] = true
+			}
+		}
+	}
+
+	columns := []CardProperty{}
+	for _, property := range properties {
+		if visible[property.ID] {
+			columns = append(columns, property)
+		}
+	}
+	return columns
+}
+
+// SortCardsForView sorts the cards in the manual order of the view, the
+// cards missing from it follow by creation time.
+func SortCardsForView(cards []Block, view *Block) {
+	position := map[string]int{}
+	if view != nil {
+		if ids, ok := view.Fields["cardOrder"].([]interface{}); ok {
+			for i, id := range ids {
+				if cardID, ok := id.(string); ok {
+					if _, exists := position[cardID]; !exists {
+						position[cardID] = i
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(cards, func(i, j int) bool {
+		pi, iok := position[cards[i].ID]
+		pj, jok := position[cards[j].ID]
+		switch {
+		case iok && jok:
+			return pi < pj
+		case iok != jok:
+			return iok
+		}
+		return cards[i].CreateAt < cards[j].CreateAt
+	})
+}
+
+// ExportUserIDs returns the ids of the users that the exported columns
+// reference, so they can be resolved to usernames.
+func ExportUserIDs(columns []CardProperty, cards []Block) []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for i := range cards {
+		for _, property := range columns {
+			switch property.Type {
+			case PropertyTypePerson:
+				if id, ok := CardPropertyValues(&cards[i])[property.ID].(string); ok {
+					add(id)
+				}
+			case PropertyTypeCreatedBy:
+				add(cards[i].CreatedBy)
+			case PropertyTypeUpdatedBy:
+				add(cards[i].ModifiedBy)
+			}
+		}
+	}
+	return ids
+}
+
+// NewExportTable returns the rows of a board view export: a header with
+// the title and the names of the columns, followed by one row per card.
+// Card templates are skipped. Users are shown by username when found in
+// the map, and numbers and dates with the formatting of the board.
+func NewExportTable(columns []CardProperty, cards []Block, usernames map[string]string, formatting *BoardFormatting) [][]string {
+	header := make([]string, 0, len(columns)+1)
+	header = append(header, exportTitleColumn)
+	for _, property := range columns {
+		header = append(header, property.Name)
+	}
+
+	rows := [][]string{header}
+	for i := range cards {
+		if cards[i].Type != TypeCard || IsCardTemplate(&cards[i]) {
+			continue
+		}
+
+		row := make([]string, 0, len(columns)+1)
+		row = append(row, cards[i].Title)
+		for j := range columns {
+			row = append(row, exportCardValue(&columns[j], &cards[i], usernames, formatting))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func exportCardValue(property *CardProperty, card *Block, usernames map[string]string, formatting *BoardFormatting) string {
+	username := func(id string) string {
+		if name, ok := usernames[id]; ok {
+			return name
+		}
+		return id
+	}
+
+	timeLayout := formatting.DateLayout(exportDateLayout) + exportTimeLayout
+	switch property.Type {
+	case PropertyTypeCreatedTime:
+		return utils.GetTimeForMillis(card.CreateAt).UTC().Format(timeLayout)
+	case PropertyTypeUpdatedTime:
+		return utils.GetTimeForMillis(card.UpdateAt).UTC().Format(timeLayout)
+	case PropertyTypeCreatedBy:
+		return username(card.CreatedBy)
+	case PropertyTypeUpdatedBy:
+		return username(card.ModifiedBy)
+	}
+
+	value, ok := CardPropertyValues(card)[property.ID]
+	if !ok || value == nil {
+		return ""
+	}
+
+	switch property.Type {
+	case PropertyTypeSelect, PropertyTypeMultiSelect:
+		values := []string{}
+		for _, id := range CardPropertyOptionIDs(card, property) {
+			if idx := property.FindOption(id); idx != -1 {
+				values = append(values, property.Options[idx].Value)
+			}
+		}
+		return strings.Join(values, ", ")
+	case PropertyTypePerson:
+		if id, ok := value.(string); ok {
+			return username(id)
+		}
+	case PropertyTypeDate:
+		if s, ok := value.(string); ok {
+			return exportDate(s, formatting.DateLayout(exportDateLayout))
+		}
+	case PropertyTypeNumber:
+		if s, ok := value.(string); ok {
+			return formatting.FormatNumber(s)
+		}
+	case PropertyTypeLocation:
+		location, err := ParseCardLocation(value)
+		if err != nil {
+			return ""
+		}
+		if location.Label != "" {
+			return location.Label
+		}
+		return strconv.FormatFloat(location.Lat, 'f', -1, 64) + ", " + strconv.FormatFloat(location.Lng, 'f', -1, 64)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// exportDate formats a date value, a JSON object holding the start and
+// optionally the end of the range in milliseconds.
+func exportDate(value, layout string) string {
+	var date map[string]int64
+	if err := json.Unmarshal([]byte(value), &date); err != nil {
+		return value
+	}
+	from, ok := date["from"]
+	if !ok {
+		return value
+	}
+
+	s := utils.GetTimeForMillis(from).UTC().Format(layout)
+	if to, ok := date["to"]; ok && to != from {
+		s += " -> " + utils.GetTimeForMillis(to).UTC().Format(layout)
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportColumns(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "status", Name: "Status", Type: PropertyTypeSelect},
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson},
+		{ID: "due", Name: "Due", Type: PropertyTypeDate},
+	}
+
+	require.Equal(t, properties, ExportColumns(properties, nil))
+
+	view := &Block{Fields: map[string]interface{}{"visiblePropertyIds": []interface{}{"due", "status", "missing"}}}
+	columns := ExportColumns(properties, view)
+	require.Len(t, columns, 2)
+	require.Equal(t, "status", columns[0].ID)
+	require.Equal(t, "due", columns[1].ID)
+}
+
+func TestSortCardsForView(t *testing.T) {
+	cards := []Block{
+		{ID: "c1", CreateAt: 3},
+		{ID: "c2", CreateAt: 1},
+		{ID: "c3", CreateAt: 2},
+		{ID: "c4", CreateAt: 4},
+	}
+	view := &Block{Fields: map[string]interface{}{"cardOrder": []interface{}{"c4", "c1"}}}
+
+	SortCardsForView(cards, view)
+	ids := []string{}
+	for _, card := range cards {
+		ids = append(ids, card.ID)
+	}
+	require.Equal(t, []string{"c4", "c1", "c2", "c3"}, ids)
+}
+
+func TestNewExportTable(t *testing.T) {
+	columns := []CardProperty{
+		{ID: "tags", Name: "Tags", Type: PropertyTypeMultiSelect, Options: []CardPropertyOption{
+			{ID: "a", Value: "Alpha"},
+			{ID: "b", Value: "Beta"},
+		}},
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson},
+		{ID: "due", Name: "Due", Type: PropertyTypeDate},
+		{ID: "estimate", Name: "Estimate", Type: PropertyTypeNumber},
+		{ID: "place", Name: "Place", Type: PropertyTypeLocation},
+		{ID: "created", Name: "Created", Type: PropertyTypeCreatedTime},
+		{ID: "author", Name: "Author", Type: PropertyTypeCreatedBy},
+	}
+	cards := []Block{
+		{
+			ID:        "c1",
+			Type:      TypeCard,
+			Title:     "First",
+			CreatedBy: "user-1",
+			CreateAt:  1653523200000,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{
+				"tags":     []interface{}{"a", "b", "deleted"},
+				"owner":    "user-2",
+				"due":      `{"from":1653523200000,"to":1653696000000}`,
+				"estimate": "3",
+				"place":    map[string]interface{}{"lat": 48.5, "lng": 2.25},
+			}},
+		},
+		{
+			ID:     "template",
+			Type:   TypeCard,
+			Title:  "Template",
+			Fields: map[string]interface{}{"isTemplate": true},
+		},
+	}
+
+	userIDs := ExportUserIDs(columns, cards)
+	require.ElementsMatch(t, []string{"user-1", "user-2"}, userIDs)
+
+	rows := NewExportTable(columns, cards, map[string]string{"user-1": "jdoe"}, nil)
+	require.Equal(t, [][]string{
+		{"Title", "Tags", "Owner", "Due", "Estimate", "Place", "Created", "Author"},
+		{"First", "Alpha, Beta", "user-2", "2022-05-26 -> 2022-05-28", "3", "48.5, 2.25", "2022-05-26 00:00", "jdoe"},
+	}, rows)
+
+	CardPropertyValues(&cards[0])["estimate"] = "1234.5"
+	formatting := &BoardFormatting{DecimalSeparator: ",", ThousandsSeparator: ".", DateFormat: DateFormatEuropean}
+	rows = NewExportTable(columns, cards, map[string]string{"user-1": "jdoe"}, formatting)
+	require.Equal(t, []string{"First", "Alpha, Beta", "user-2", "26/05/2022 -> 28/05/2022", "1.234,5", "48.5, 2.25", "26/05/2022 00:00", "jdoe"}, rows[1])
+}
@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BoardPropertyFormatting is the key of the board property that holds how
+// the numbers and dates of the cards of the board are formatted.
+const BoardPropertyFormatting = "formatting"
+
+// The date formats a board can use, written the way they are shown to the
+// users.
+const (
+	DateFormatISO      = "YYYY-MM-DD"
+	DateFormatUS       = "MM/DD/YYYY"
+	DateFormatEuropean = "DD/MM/YYYY"
+	DateFormatDotted   = "DD.MM.YYYY"
+	DateFormatLong     = "MMMM D, YYYY"
+)
+
+// boardDateLayouts are the Go layouts of the date formats.
+var boardDateLayouts = map[string]string{
+	DateFormatISO:      "2006-01-02",
+	DateFormatUS:       "01/02/2006",
+	DateFormatEuropean: "02/01/2006",
+	DateFormatDotted:   "02.01.2006",
+	DateFormatLong:     "January 2, 2006",
+}
+
+// boardThousandsSeparators are the separators of the groups of thousands a
+// board can use, the empty one leaving the numbers ungrouped.
+var boardThousandsSeparators = map[string]bool{
+	"":  true,
+	",": true,
+	".": true,
+	" ": true,
+	"'": true,
+}
+
+// BoardFormatting is how the values of the cards of a board are formatted
+// outside of the clients: in the exports and the notifications. Empty
+// settings keep the default format of each of them
+// swagger:model
+type BoardFormatting struct {
+	// The decimal separator of the numbers, . or ,
+	// required: false
+	DecimalSeparator string `json:"decimalSeparator"`
+
+	// The separator of the groups of thousands of the numbers, empty to
+	// leave them ungrouped
+	// required: false
+	ThousandsSeparator string `json:"thousandsSeparator"`
+
+	// The format of the dates: YYYY-MM-DD, MM/DD/YYYY, DD/MM/YYYY,
+	// DD.MM.YYYY or MMMM D, YYYY
+	// required: false
+	DateFormat string `json:"dateFormat"`
+}
+
+// ErrInvalidBoardFormatting is returned when the formatting settings of a
+// board can't be used.
+type ErrInvalidBoardFormatting struct {
+	msg string
+}
+
+// NewErrInvalidBoardFormatting returns an error for formatting settings
+// that can't be used.
+func NewErrInvalidBoardFormatting(msg string) *ErrInvalidBoardFormatting {
+	return &ErrInvalidBoardFormatting{msg: msg}
+}
+
+func (e *ErrInvalidBoardFormatting) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidBoardFormatting returns true if the error is an
+// ErrInvalidBoardFormatting.
+func IsErrInvalidBoardFormatting(err error) bool {
+	var errInvalid *ErrInvalidBoardFormatting
+	return errors.As(err, &errInvalid)
+}
+
+// BoardFormattingFromBoard returns the formatting settings of a board,
+// empty ones if the board has none.
+func BoardFormattingFromBoard(board *Board) (*BoardFormatting, error) {
+	formatting, err := parseBoardFormatting(board.Properties[BoardPropertyFormatting])
+	if err != nil {
+		return nil, err
+	}
+	if formatting == nil {
+		formatting = &BoardFormatting{}
+	}
+	return formatting, nil
+}
+
+func parseBoardFormatting(raw interface{}) (*BoardFormatting, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var formatting *BoardFormatting
+	if err := json.Unmarshal(data, &formatting); err != nil {
+		return nil, err
+	}
+	return formatting, nil
+}
+
+// IsValid checks that the settings are known formats.
+func (f *BoardFormatting) IsValid() error {
+	switch f.DecimalSeparator {
+	case "", ".", ",":
+	default:
+		return NewErrInvalidBoardFormatting(fmt.Sprintf("decimal separator %q must be . or ,", f.DecimalSeparator))
+	}
+	if !boardThousandsSeparators[f.ThousandsSeparator] {
+		return NewErrInvalidBoardFormatting(fmt.Sprintf("unknown thousands separator %q", f.ThousandsSeparator))
+	}
+	if f.ThousandsSeparator != "" && f.ThousandsSeparator == f.decimalSeparator() {
+		return NewErrInvalidBoardFormatting("the thousands separator must differ from the decimal separator")
+	}
+	if _, ok := boardDateLayouts[f.DateFormat]; f.DateFormat != "" && !ok {
+		return NewErrInvalidBoardFormatting(fmt.Sprintf("unknown date format %q", f.DateFormat))
+	}
+	return nil
+}
+
+func (f *BoardFormatting) decimalSeparator() string {
+	if f == nil || f.DecimalSeparator == "" {
+		return "."
+	}
+	return f.DecimalSeparator
+}
+
+// DateLayout returns the Go layout of the date format of the board, or the
+// default layout if the board has none.
+func (f *BoardFormatting) DateLayout(defaultLayout string) string {
+	if f == nil {
+		return defaultLayout
+	}
+	if layout, ok := boardDateLayouts[f.DateFormat]; ok {
+		return layout
+	}
+	return defaultLayout
+}
+
+// FormatNumber formats the raw value of a number property with the
+// separators of the board. Values that aren't numbers are returned as is.
+func (f *BoardFormatting) FormatNumber(value string) string {
+	if f == nil || (f.DecimalSeparator == "" && f.ThousandsSeparator == "") {
+		return value
+	}
+	s := strings.TrimSpace(value)
+	if n, _, err := ParseCardNumber(s); err != nil || n == nil {
+		return value
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	integer, fraction := s, ""
+	if idx := strings.Index(s, "."); idx != -1 {
+		integer, fraction = s[:idx], s[idx+1:]
+	}
+
+	if f.ThousandsSeparator != "" && len(integer) > 3 {
+		var grouped strings.Builder
+		for i, digit := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				grouped.WriteString(f.ThousandsSeparator)
+			}
+			grouped.WriteRune(digit)
+		}
+		integer = grouped.String()
+	}
+	if fraction != "" {
+		return sign + integer + f.decimalSeparator() + fraction
+	}
+	return sign + integer
+}
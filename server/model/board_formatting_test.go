@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardFormattingIsValid(t *testing.T) {
+	require.NoError(t, (&BoardFormatting{}).IsValid())
+	require.NoError(t, (&BoardFormatting{DecimalSeparator: ",", ThousandsSeparator: " ", DateFormat: DateFormatLong}).IsValid())
+
+	for _, formatting := range []*BoardFormatting{
+		{DecimalSeparator: ";"},
+		{ThousandsSeparator: "_"},
+		{ThousandsSeparator: "."},
+		{DecimalSeparator: ",", ThousandsSeparator: ","},
+		{DateFormat: "DD-MM"},
+	} {
+		require.True(t, IsErrInvalidBoardFormatting(formatting.IsValid()), formatting)
+	}
+}
+
+func TestBoardFormattingFromBoard(t *testing.T) {
+	formatting, err := BoardFormattingFromBoard(&Board{})
+	require.NoError(t, err)
+	require.Equal(t, &BoardFormatting{}, formatting)
+
+	formatting, err = BoardFormattingFromBoard(&Board{Properties: map[string]interface{}{
+		BoardPropertyFormatting: map[string]interface{}{"decimalSeparator": ",", "dateFormat": DateFormatUS},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, &BoardFormatting{DecimalSeparator: ",", DateFormat: DateFormatUS}, formatting)
+}
+
+func TestBoardFormattingDateLayout(t *testing.T) {
+	var none *BoardFormatting
+	require.Equal(t, "2006-01-02", none.DateLayout("2006-01-02"))
+	require.Equal(t, "2006-01-02", (&BoardFormatting{}).DateLayout("2006-01-02"))
+	require.Equal(t, "02/01/2006", (&BoardFormatting{DateFormat: DateFormatEuropean}).DateLayout("2006-01-02"))
+}
+
+func TestBoardFormattingFormatNumber(t *testing.T) {
+	var none *BoardFormatting
+	require.Equal(t, "1234.5", none.FormatNumber("1234.5"))
+
+	european := &BoardFormatting{DecimalSeparator: ",", ThousandsSeparator: "."}
+	testCases := map[string]string{
+		"1234567.891": "1.234.567,891",
+		"-1234.5":     "-1.234,5",
+		"999":         "999",
+		"1000":        "1.000",
+		"0.25":        "0,25",
+		"":            "",
+		"n/a":         "n/a",
+	}
+	for value, expected := range testCases {
+		require.Equal(t, expected, european.FormatNumber(value), value)
+	}
+
+	require.Equal(t, "12'345", (&BoardFormatting{ThousandsSeparator: "'"}).FormatNumber("12345"))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "fmt"
+
+// BoardPropertyLimitsExempt is the board property that exempts the board
+// from the card and view limits. It can only be set by admins, through
+// its own endpoint.
+const BoardPropertyLimitsExempt = "limitsExempt"
+
+// IsBoardLimitsExempt returns true if the board is exempted from the card
+// and view limits.
+func IsBoardLimitsExempt(board *Board) bool {
+	exempt, _ := board.Properties[BoardPropertyLimitsExempt].(bool)
+	return exempt
+}
+
+// BoardLimitsExemptPatch sets or removes the limits exemption of a board
+// swagger:model
+type BoardLimitsExemptPatch struct {
+	// Whether the board is exempted from the card and view limits
+	// required: true
+	Exempt bool `json:"exempt"`
+}
+
+// BoardLimits are the configured card, view and attachment storage
+// limits, 0 meaning no limit
+// swagger:model
+type BoardLimits struct {
+	// The maximum number of cards of a board
+	// required: true
+	CardLimit int `json:"cardLimit"`
+
+	// The maximum number of views of a board
+	// required: true
+	ViewLimit int `json:"viewLimit"`
+
+	// The maximum bytes of all the stored attachments
+	// required: true
+	AttachmentStorageLimit int64 `json:"attachmentStorageLimit"`
+}
+
+// ErrCardLimitReached is returned when inserting cards would take a board
+// over the configured card limit.
+type ErrCardLimitReached struct {
+	BoardID string
+	Count   int
+	Limit   int
+}
+
+func NewErrCardLimitReached(boardID string, count, limit int) *ErrCardLimitReached {
+	return &ErrCardLimitReached{
+		BoardID: boardID,
+		Count:   count,
+		Limit:   limit,
+	}
+}
+
+func (e *ErrCardLimitReached) Error() string {
+	return fmt.Sprintf("board %s has reached its limit of %d cards", e.BoardID, e.Limit)
+}
+
+// CardLimitReachedResponse is the error response returned when a board has reached its card limit
+// swagger:model
+type CardLimitReachedResponse struct {
+	// The error message
+	// required: false
+	Error string `json:"error"`
+
+	// The error code
+	// required: false
+	ErrorCode int `json:"errorCode"`
+
+	// The current number of cards of the board
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// The maximum number of cards of the board
+	// required: true
+	CardLimit int `json:"cardLimit"`
+}
+
+// CountNewCards returns the number of blocks of the list that are cards,
+// not counting card templates.
+func CountNewCards(blocks []Block) int {
+	count := 0
+	for i := range blocks {
+		if blocks[i].Type == TypeCard && !IsCardTemplate(&blocks[i]) {
+			count++
+		}
+	}
+	return count
+}
+
+// ErrViewLimitReached is returned when inserting views would take a board
+// over the configured view limit.
+type ErrViewLimitReached struct {
+	BoardID string
+	Count   int
+	Limit   int
+}
+
+func NewErrViewLimitReached(boardID string, count, limit int) *ErrViewLimitReached {
+	return &ErrViewLimitReached{
+		BoardID: boardID,
+		Count:   count,
+		Limit:   limit,
+	}
+}
+
+func (e *ErrViewLimitReached) Error() string {
+	return fmt.Sprintf("board %s has reached its limit of %d views", e.BoardID, e.Limit)
+}
+
+// ViewLimitReachedResponse is the error response returned when a board has reached its view limit
+// swagger:model
+type ViewLimitReachedResponse struct {
+	// The error message
+	// required: false
+	Error string `json:"error"`
+
+	// The error code
+	// required: false
+	ErrorCode int `json:"errorCode"`
+
+	// The current number of views of the board
+	// required: true
+	ViewCount int `json:"viewCount"`
+
+	// The maximum number of views of the board
+	// required: true
+	ViewLimit int `json:"viewLimit"`
+}
+
+// ErrAttachmentStorageLimitReached is returned when storing a file would
+// take the attachments over the configured storage limit.
+type ErrAttachmentStorageLimitReached struct {
+	Used  int64
+	Size  int64
+	Limit int64
+}
+
+func NewErrAttachmentStorageLimitReached(used, size, limit int64) *ErrAttachmentStorageLimitReached {
+	return &ErrAttachmentStorageLimitReached{
+		Used:  used,
+		Size:  size,
+		Limit: limit,
+	}
+}
+
+func (e *ErrAttachmentStorageLimitReached) Error() string {
+	return fmt.Sprintf("storing %d bytes would exceed the attachment storage limit of %d bytes", e.Size, e.Limit)
+}
+
+// AttachmentStorageLimitReachedResponse is the error response returned when the attachment storage limit is reached
+// swagger:model
+type AttachmentStorageLimitReachedResponse struct {
+	// The error message
+	// required: false
+	Error string `json:"error"`
+
+	// The error code
+	// required: false
+	ErrorCode int `json:"errorCode"`
+
+	// The bytes used by the attachments
+	// required: true
+	StorageUsed int64 `json:"storageUsed"`
+
+	// The maximum bytes of the attachments
+	// required: true
+	StorageLimit int64 `json:"storageLimit"`
+}
+
+// CountNewViews returns the number of blocks of the list that are views.
+func CountNewViews(blocks []Block) int {
+	count := 0
+	for i := range blocks {
+		if blocks[i].Type == TypeView {
+			count++
+		}
+	}
+	return count
+}
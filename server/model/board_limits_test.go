@@ -0,0 +1,26 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardLimitsExempt(t *testing.T) {
+	require.False(t, IsBoardLimitsExempt(&Board{}))
+	require.False(t, IsBoardLimitsExempt(&Board{Properties: map[string]interface{}{BoardPropertyLimitsExempt: "true"}}))
+	require.True(t, IsBoardLimitsExempt(&Board{Properties: map[string]interface{}{BoardPropertyLimitsExempt: true}}))
+
+	t.Run("can't be patched", func(t *testing.T) {
+		patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyLimitsExempt: true}}
+		require.Error(t, patch.IsValid())
+
+		patch = &BoardPatch{DeletedProperties: []string{BoardPropertyLimitsExempt}}
+		require.Error(t, patch.IsValid())
+	})
+
+	t.Run("boards without properties are exempted", func(t *testing.T) {
+		patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyLimitsExempt: true}}
+		require.True(t, IsBoardLimitsExempt(patch.Patch(&Board{})))
+	})
+}
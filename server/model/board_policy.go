@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The users that can create the boards of a team.
+const (
+	BoardCreatorsAll    = "all"
+	BoardCreatorsAdmins = "admins"
+)
+
+// BoardPolicy holds the defaults and restrictions of a team for its boards
+// swagger:model
+type BoardPolicy struct {
+	// Who can create boards, "all" the members of the team or the team "admins"
+	// required: true
+	BoardCreators string `json:"boardCreators"`
+
+	// The minimum role of the new boards that don't set one
+	// required: true
+	DefaultMemberRole BoardRole `json:"defaultMemberRole"`
+
+	// Whether the boards of the team can be shared with public links
+	// required: true
+	AllowPublicSharing bool `json:"allowPublicSharing"`
+}
+
+// TeamBoardPolicy is the board policy of a team
+// swagger:model
+type TeamBoardPolicy struct {
+	// The team ID
+	// required: true
+	TeamID string `json:"teamId"`
+
+	BoardPolicy
+}
+
+// DefaultBoardPolicy returns the board policy of the teams that haven't
+// set one, which doesn't restrict anything.
+func DefaultBoardPolicy() BoardPolicy {
+	return BoardPolicy{
+		BoardCreators:      BoardCreatorsAll,
+		DefaultMemberRole:  BoardRoleNone,
+		AllowPublicSharing: true,
+	}
+}
+
+// OnlyAdminsCreateBoards returns true if only the team admins can create
+// boards.
+func (p BoardPolicy) OnlyAdminsCreateBoards() bool {
+	return p.BoardCreators == BoardCreatorsAdmins
+}
+
+// IsValid returns an ErrInvalidBoardPolicy if the policy is not valid.
+func (p BoardPolicy) IsValid() error {
+	if p.BoardCreators != BoardCreatorsAll && p.BoardCreators != BoardCreatorsAdmins {
+		return newErrInvalidBoardPolicy(fmt.Sprintf("board creators must be %q or %q", BoardCreatorsAll, BoardCreatorsAdmins))
+	}
+	if !IsBoardMinimumRoleValid(p.DefaultMemberRole) {
+		return newErrInvalidBoardPolicy(fmt.Sprintf("invalid default member role %q", p.DefaultMemberRole))
+	}
+	return nil
+}
+
+// ErrInvalidBoardPolicy is returned when a board policy is not valid.
+type ErrInvalidBoardPolicy struct {
+	msg string
+}
+
+func newErrInvalidBoardPolicy(msg string) *ErrInvalidBoardPolicy {
+	return &ErrInvalidBoardPolicy{msg: msg}
+}
+
+func (e *ErrInvalidBoardPolicy) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidBoardPolicy returns true if the error is an
+// ErrInvalidBoardPolicy.
+func IsErrInvalidBoardPolicy(err error) bool {
+	var errInvalid *ErrInvalidBoardPolicy
+	return errors.As(err, &errInvalid)
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardPolicyIsValid(t *testing.T) {
+	require.NoError(t, DefaultBoardPolicy().IsValid())
+
+	policy := BoardPolicy{BoardCreators: BoardCreatorsAdmins, DefaultMemberRole: BoardRoleViewer}
+	require.NoError(t, policy.IsValid())
+	require.True(t, policy.OnlyAdminsCreateBoards())
+
+	policy.BoardCreators = "owners"
+	require.True(t, IsErrInvalidBoardPolicy(policy.IsValid()))
+
+	policy.BoardCreators = BoardCreatorsAll
+	policy.DefaultMemberRole = "owner"
+	require.True(t, IsErrInvalidBoardPolicy(policy.IsValid()))
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"sort"
+)
+
+// MaxPortfolioBoards is the largest set of boards a portfolio can
+// summarize in one call.
+const MaxPortfolioBoards = 50
+
+// ErrInvalidPortfolio is returned when the boards of a portfolio can't be
+// summarized together.
+type ErrInvalidPortfolio struct {
+	msg string
+}
+
+// NewErrInvalidPortfolio returns an error for a set of boards that can't be
+// summarized together.
+func NewErrInvalidPortfolio(msg string) *ErrInvalidPortfolio {
+	return &ErrInvalidPortfolio{msg: msg}
+}
+
+func (e *ErrInvalidPortfolio) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidPortfolio returns true if the error is an ErrInvalidPortfolio.
+func IsErrInvalidPortfolio(err error) bool {
+	var errInvalid *ErrInvalidPortfolio
+	return errors.As(err, &errInvalid)
+}
+
+// BoardSummary is the status summary of a board in a portfolio
+// swagger:model
+type BoardSummary struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the board
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the board
+	// required: false
+	Icon string `json:"icon"`
+
+	// The number of cards of the board, leaving out templates and archived cards
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// The id of the select property the cards are counted by
+	// required: false
+	StatusPropertyID string `json:"statusPropertyId"`
+
+	// The number of cards per option of the status property, in option order
+	// required: true
+	StatusCounts []StatusCount `json:"statusCounts"`
+
+	// The number of cards in the done status of the board
+	// required: true
+	DoneCount int `json:"doneCount"`
+
+	// The percentage, from 0 to 100, of the cards that are done. -1 if the
+	// board has no done status or no cards
+	// required: true
+	PercentDone int `json:"percentDone"`
+
+	// The last time the board or any of its blocks changed, in miliseconds
+	// since the current epoch
+	// required: true
+	LastActivityAt int64 `json:"lastActivityAt"`
+
+	// The milestones not yet due that cards of the board are attached to,
+	// by due date
+	// required: true
+	UpcomingMilestones []*Milestone `json:"upcomingMilestones"`
+}
+
+// StatusCount is the number of cards of a board with a status option
+// swagger:model
+type StatusCount struct {
+	// The id of the option, empty for the cards without status
+	// required: true
+	OptionID string `json:"optionId"`
+
+	// The display value of the option
+	// required: true
+	Value string `json:"value"`
+
+	// The number of cards with the option
+	// required: true
+	Count int `json:"count"`
+}
+
+// PortfolioStatusProperty returns the property a board summary counts
+// the cards by: the property of the done status if the board has one,
+// or else the first select property of the board. It returns nil if the
+// board has no select property.
+func PortfolioStatusProperty(properties []CardProperty, doneStatus *DoneStatus) *CardProperty {
+	if doneStatus != nil {
+		if idx := FindCardProperty(properties, doneStatus.PropertyID); idx != -1 && properties[idx].Type == PropertyTypeSelect {
+			return &properties[idx]
+		}
+	}
+	for i := range properties {
+		if properties[i].Type == PropertyTypeSelect {
+			return &properties[i]
+		}
+	}
+	return nil
+}
+
+// NewBoardSummary summarizes a board from its blocks, which can include
+// any type of block, and the milestones of its team.
+func NewBoardSummary(board *Board, properties []CardProperty, blocks []Block, milestones []*Milestone, now int64) *BoardSummary {
+	summary := &BoardSummary{
+		BoardID:            board.ID,
+		Title:              board.Title,
+		Icon:               board.Icon,
+		StatusCounts:       []StatusCount{},
+		PercentDone:        -1,
+		LastActivityAt:     board.UpdateAt,
+		UpcomingMilestones: []*Milestone{},
+	}
+
+	doneStatus := DoneStatusFromBoard(board)
+	statusProperty := PortfolioStatusProperty(properties, doneStatus)
+	counts := map[string]int{}
+	milestoneIDs := map[string]bool{}
+
+	for i := range blocks {
+		if blocks[i].UpdateAt > summary.LastActivityAt {
+			summary.LastActivityAt = blocks[i].UpdateAt
+		}
+		if blocks[i].Type != TypeCard || IsCardTemplate(&blocks[i]) || IsCardArchived(&blocks[i]) {
+			continue
+		}
+
+		summary.CardCount++
+		if doneStatus.IsCardDone(&blocks[i]) {
+			summary.DoneCount++
+		}
+		if statusProperty != nil {
+			optionID, _ := CardPropertyValues(&blocks[i])[statusProperty.ID].(string)
+			counts[optionID]++
+		}
+		if milestoneID := CardMilestone(&blocks[i]); milestoneID != "" {
+			milestoneIDs[milestoneID] = true
+		}
+	}
+
+	if statusProperty != nil {
+		summary.StatusPropertyID = statusProperty.ID
+		for _, option := range statusProperty.Options {
+			summary.StatusCounts = append(summary.StatusCounts, StatusCount{
+				OptionID: option.ID,
+				Value:    option.Value,
+				Count:    counts[option.ID],
+			})
+			delete(counts, option.ID)
+		}
+		// cards without status, or with an option that was removed
+		noStatus := 0
+		for _, count := range counts {
+			noStatus += count
+		}
+		if noStatus > 0 {
+			summary.StatusCounts = append(summary.StatusCounts, StatusCount{Count: noStatus})
+		}
+	}
+
+	if doneStatus != nil && summary.CardCount > 0 {
+		summary.PercentDone = summary.DoneCount * 100 / summary.CardCount
+	}
+
+	for _, milestone := range milestones {
+		if milestoneIDs[milestone.ID] && milestone.DueDate >= now {
+			summary.UpcomingMilestones = append(summary.UpcomingMilestones, milestone)
+		}
+	}
+	sort.SliceStable(summary.UpcomingMilestones, func(i, j int) bool {
+		return summary.UpcomingMilestones[i].DueDate < summary.UpcomingMilestones[j].DueDate
+	})
+
+	return summary
+}
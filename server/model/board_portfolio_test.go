@@ -0,0 +1,76 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBoardSummary(t *testing.T) {
+	board := &Board{
+		ID:       "board-id",
+		Title:    "Roadmap",
+		UpdateAt: 100,
+		Properties: map[string]interface{}{
+			BoardPropertyDoneStatus: map[string]interface{}{"propertyId": "status", "optionId": "done"},
+		},
+	}
+	properties := []CardProperty{
+		{ID: "priority", Type: PropertyTypeSelect, Options: []CardPropertyOption{{ID: "high", Value: "High"}}},
+		{ID: "status", Type: PropertyTypeSelect, Options: []CardPropertyOption{
+			{ID: "todo", Value: "To do"},
+			{ID: "done", Value: "Done"},
+		}},
+	}
+	card := func(id, status, milestoneID string, updateAt int64) Block {
+		return Block{ID: id, Type: TypeCard, UpdateAt: updateAt, Fields: map[string]interface{}{
+			"properties":       map[string]interface{}{"status": status},
+			CardFieldMilestone: milestoneID,
+		}}
+	}
+	blocks := []Block{
+		card("card-1", "done", "beta", 200),
+		card("card-2", "todo", "ga", 150),
+		card("card-3", "", "", 120),
+		{ID: "template", Type: TypeCard, Fields: map[string]interface{}{"isTemplate": true}},
+		{ID: "archived", Type: TypeCard, Fields: map[string]interface{}{CardFieldArchived: true}},
+		{ID: "text", Type: TypeText, UpdateAt: 300},
+	}
+	milestones := []*Milestone{
+		{ID: "ga", DueDate: 5000},
+		{ID: "beta", DueDate: 2000},
+		{ID: "alpha", DueDate: 1500},
+		{ID: "past", DueDate: 500},
+	}
+
+	summary := NewBoardSummary(board, properties, blocks, milestones, 1000)
+	require.Equal(t, "Roadmap", summary.Title)
+	require.Equal(t, 3, summary.CardCount)
+	require.Equal(t, 1, summary.DoneCount)
+	require.Equal(t, 33, summary.PercentDone)
+	require.Equal(t, int64(300), summary.LastActivityAt)
+	require.Equal(t, "status", summary.StatusPropertyID)
+	require.Equal(t, []StatusCount{
+		{OptionID: "todo", Value: "To do", Count: 1},
+		{OptionID: "done", Value: "Done", Count: 1},
+		{Count: 1},
+	}, summary.StatusCounts)
+	require.Len(t, summary.UpcomingMilestones, 2)
+	require.Equal(t, "beta", summary.UpcomingMilestones[0].ID)
+	require.Equal(t, "ga", summary.UpcomingMilestones[1].ID)
+}
+
+func TestNewBoardSummaryWithoutDoneStatus(t *testing.T) {
+	board := &Board{ID: "board-id"}
+	properties := []CardProperty{
+		{ID: "priority", Type: PropertyTypeSelect, Options: []CardPropertyOption{{ID: "high", Value: "High"}}},
+	}
+	blocks := []Block{{ID: "card-1", Type: TypeCard, Fields: map[string]interface{}{
+		"properties": map[string]interface{}{"priority": "high"},
+	}}}
+
+	summary := NewBoardSummary(board, properties, blocks, nil, 0)
+	require.Equal(t, -1, summary.PercentDone)
+	require.Equal(t, "priority", summary.StatusPropertyID)
+	require.Equal(t, []StatusCount{{OptionID: "high", Value: "High", Count: 1}}, summary.StatusCounts)
+}
@@ -0,0 +1,13 @@
+package model
+
+// BoardPresence lists the users viewing a board
+// swagger:model
+type BoardPresence struct {
+	// The ID of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The IDs of the users viewing the board
+	// required: true
+	UserIDs []string `json:"userIds"`
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// BoardPropertySubmissions is the key of the board property that holds
+// the settings of the anonymous submissions.
+const BoardPropertySubmissions = "submissions"
+
+const (
+	maxSubmissionTitleLength       = 255
+	maxSubmissionDescriptionLength = 10000
+)
+
+// BoardSubmissionSettings describes if anonymous visitors of a publicly
+// shared board can submit cards, and where the cards are placed
+// swagger:model
+type BoardSubmissionSettings struct {
+	// Allows anonymous visitors of the shared board to submit cards
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The id of the select property used to group the submitted cards
+	// required: false
+	PropertyID string `json:"propertyId,omitempty"`
+
+	// The id of the option of the intake group the cards are created in
+	// required: false
+	OptionID string `json:"optionId,omitempty"`
+}
+
+// CardSubmission is a card submitted by an anonymous visitor
+// swagger:model
+type CardSubmission struct {
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// An optional description, added as a text block of the card
+	// required: false
+	Description string `json:"description,omitempty"`
+
+	// The response of the captcha challenge, if the server requires one
+	// required: false
+	CaptchaResponse string `json:"captchaResponse,omitempty"`
+}
+
+// ErrSubmissionsDisabled is returned when a card is submitted to a board
+// that doesn't accept submissions.
+var ErrSubmissionsDisabled = errors.New("submissions are disabled for this board")
+
+// ErrInvalidCaptcha is returned when the captcha response of a
+// submission is missing or can't be verified.
+var ErrInvalidCaptcha = errors.New("invalid captcha response")
+
+// ErrInvalidSubmission is returned when the submission or the submission
+// settings of a board are not valid.
+type ErrInvalidSubmission struct {
+	msg string
+}
+
+func newErrInvalidSubmission(msg string) *ErrInvalidSubmission {
+	return &ErrInvalidSubmission{msg: msg}
+}
+
+func (e *ErrInvalidSubmission) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidSubmission returns true if the error is an ErrInvalidSubmission.
+func IsErrInvalidSubmission(err error) bool {
+	var errInvalid *ErrInvalidSubmission
+	return errors.As(err, &errInvalid)
+}
+
+// GetBoardSubmissionSettings returns the submission settings of a board,
+// or nil if none are set or they can't be read.
+func GetBoardSubmissionSettings(board *Board) *BoardSubmissionSettings {
+	settings, err := parseBoardSubmissionSettings(board.Properties[BoardPropertySubmissions])
+	if err != nil {
+		return nil
+	}
+	return settings
+}
+
+func parseBoardSubmissionSettings(raw interface{}) (*BoardSubmissionSettings, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var settings *BoardSubmissionSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// IsValid checks that the intake group of the settings, if set, is an
+// option of a select property of the board.
+func (s *BoardSubmissionSettings) IsValid(properties []CardProperty) error {
+	if s.PropertyID == "" && s.OptionID == "" {
+		return nil
+	}
+
+	idx := FindCardProperty(properties, s.PropertyID)
+	if idx == -1 || properties[idx].Type != PropertyTypeSelect {
+		return newErrInvalidSubmission(fmt.Sprintf("intake property %q is not a select property of the board", s.PropertyID))
+	}
+	if properties[idx].FindOption(s.OptionID) == -1 {
+		return newErrInvalidSubmission(fmt.Sprintf("intake option %q doesn't exist", s.OptionID))
+	}
+	return nil
+}
+
+// IsValid checks the submitted values.
+func (s *CardSubmission) IsValid() error {
+	if strings.TrimSpace(s.Title) == "" {
+		return newErrInvalidSubmission("title cannot be empty")
+	}
+	if utf8.RuneCountInString(s.Title) > maxSubmissionTitleLength {
+		return newErrInvalidSubmission(fmt.Sprintf("title cannot be longer than %d characters", maxSubmissionTitleLength))
+	}
+	if utf8.RuneCountInString(s.Description) > maxSubmissionDescriptionLength {
+		return newErrInvalidSubmission(fmt.Sprintf("description cannot be longer than %d characters", maxSubmissionDescriptionLength))
+	}
+	return nil
+}
+
+// ToBlocks returns the card, and its description block, that the
+// submission creates in the intake group of the board.
+func (s *CardSubmission) ToBlocks(boardID string, settings *BoardSubmissionSettings) []Block {
+	properties := map[string]interface{}{}
+	if settings.PropertyID != "" {
+		properties[settings.PropertyID] = settings.OptionID
+	}
+
+	card := Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     TypeCard,
+		Title:    strings.TrimSpace(s.Title),
+		Fields: map[string]interface{}{
+			"properties":   properties,
+			"contentOrder": []interface{}{},
+		},
+	}
+
+	if strings.TrimSpace(s.Description) == "" {
+		return []Block{card}
+	}
+
+	description := Block{
+		ID:       utils.NewID(utils.IDTypeBlock),
+		BoardID:  boardID,
+		ParentID: card.ID,
+		Type:     TypeText,
+		Title:    s.Description,
+		Fields:   map[string]interface{}{},
+	}
+	card.Fields["contentOrder"] = []interface{}{description.ID}
+	return []Block{card, description}
+}
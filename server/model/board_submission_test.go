@@ -0,0 +1,51 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardSubmissionIsValid(t *testing.T) {
+	require.NoError(t, (&CardSubmission{Title: "Feedback"}).IsValid())
+	require.True(t, IsErrInvalidSubmission((&CardSubmission{Title: "  "}).IsValid()))
+	require.True(t, IsErrInvalidSubmission((&CardSubmission{Title: strings.Repeat("a", maxSubmissionTitleLength+1)}).IsValid()))
+}
+
+func TestBoardSubmissionSettings(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "status", Name: "Status", Type: PropertyTypeSelect, Options: []CardPropertyOption{{ID: "new", Value: "New"}}},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+
+	t.Run("read from the board", func(t *testing.T) {
+		board := &Board{Properties: map[string]interface{}{
+			BoardPropertySubmissions: map[string]interface{}{"enabled": true, "propertyId": "status", "optionId": "new"},
+		}}
+		settings := GetBoardSubmissionSettings(board)
+		require.NotNil(t, settings)
+		require.True(t, settings.Enabled)
+		require.NoError(t, settings.IsValid(properties))
+
+		require.Nil(t, GetBoardSubmissionSettings(&Board{}))
+	})
+
+	t.Run("invalid intake group", func(t *testing.T) {
+		settings := &BoardSubmissionSettings{Enabled: true, PropertyID: "notes", OptionID: "new"}
+		require.True(t, IsErrInvalidSubmission(settings.IsValid(properties)))
+
+		settings = &BoardSubmissionSettings{Enabled: true, PropertyID: "status", OptionID: "done"}
+		require.True(t, IsErrInvalidSubmission(settings.IsValid(properties)))
+	})
+
+	t.Run("blocks of the submission", func(t *testing.T) {
+		settings := &BoardSubmissionSettings{Enabled: true, PropertyID: "status", OptionID: "new"}
+		blocks := (&CardSubmission{Title: " Feedback ", Description: "Details"}).ToBlocks("board-id", settings)
+		require.Len(t, blocks, 2)
+		require.Equal(t, "Feedback", blocks[0].Title)
+		require.Equal(t, "new", CardPropertyValues(&blocks[0])["status"])
+		require.Equal(t, blocks[0].ID, blocks[1].ParentID)
+		require.Equal(t, []interface{}{blocks[1].ID}, blocks[0].Fields["contentOrder"])
+	})
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// BoardView records the last time a user viewed a board
+// swagger:model
+type BoardView struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The last time the user viewed the board in milliseconds since the current epoch
+	// required: true
+	ViewedAt int64 `json:"viewedAt"`
+}
+
+// BoardChanges is the number of blocks of a board changed by other users
+// since the user last viewed it
+// swagger:model
+type BoardChanges struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The last time the user viewed the board in milliseconds since the current epoch
+	// required: true
+	LastViewedAt int64 `json:"lastViewedAt"`
+
+	// The number of blocks changed by other users since the last view
+	// required: true
+	ChangeCount int64 `json:"changeCount"`
+}
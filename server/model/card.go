@@ -0,0 +1,335 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// DefaultCardsPerPage is the size of a page of cards when none is given.
+	DefaultCardsPerPage = 100
+
+	// MaxCardsPerPage is the largest page of cards that can be requested.
+	MaxCardsPerPage = 1000
+)
+
+// Card is a card of a board, as a higher level representation of its
+// card block
+// swagger:model
+type Card struct {
+	// The id of the card
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board the card belongs to
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the user who created the card
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The id of the user who last modified the card
+	// required: true
+	ModifiedBy string `json:"modifiedBy"`
+
+	// The title of the card
+	// required: false
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon"`
+
+	// True if the card is a template
+	// required: false
+	IsTemplate bool `json:"isTemplate"`
+
+	// The ids of the content blocks of the card, in display order
+	// required: false
+	ContentOrder []string `json:"contentOrder"`
+
+	// The values of the card properties, keyed by property id
+	// required: false
+	Properties map[string]interface{} `json:"properties"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// Warnings about the users just assigned to the card who may be away
+	// required: false
+	AssignmentWarnings []AssignmentWarning `json:"assignmentWarnings,omitempty"`
+}
+
+// CardPatch is a patch for modifying a card
+// swagger:model
+type CardPatch struct {
+	// The title of the card
+	// required: false
+	Title *string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon *string `json:"icon"`
+
+	// The ids of the content blocks of the card, in display order
+	// required: false
+	ContentOrder *[]string `json:"contentOrder"`
+
+	// The card property values to set, keyed by property id. A null value
+	// removes the property value from the card
+	// required: false
+	UpdatedProperties map[string]interface{} `json:"updatedProperties"`
+}
+
+// QueryCardsOptions are the paging and filtering options of a card listing.
+type QueryCardsOptions struct {
+	// The page to return, starting at 0
+	Page int
+
+	// The number of cards per page
+	PerPage int
+
+	// The values the card properties must have, keyed by property id
+	PropertyFilters map[string]string
+}
+
+// ErrInvalidCard is returned when a card or a card patch isn't valid.
+type ErrInvalidCard struct {
+	msg string
+}
+
+func newErrInvalidCard(msg string) *ErrInvalidCard {
+	return &ErrInvalidCard{msg: msg}
+}
+
+func (e *ErrInvalidCard) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidCard returns true if the error is an ErrInvalidCard.
+func IsErrInvalidCard(err error) bool {
+	var errInvalid *ErrInvalidCard
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks that the card can be stored as a block of its board.
+func (c *Card) IsValid() error {
+	if c.BoardID == "" {
+		return newErrInvalidCard("card must have a board id")
+	}
+	for propertyID := range c.Properties {
+		if propertyID == "" {
+			return newErrInvalidCard("card property id cannot be empty")
+		}
+	}
+	return nil
+}
+
+// IsValid checks that the patch doesn't set invalid property ids.
+func (p *CardPatch) IsValid() error {
+	for propertyID := range p.UpdatedProperties {
+		if propertyID == "" {
+			return newErrInvalidCard("card property id cannot be empty")
+		}
+	}
+	return nil
+}
+
+// ToBlock returns the card block of the card, with a new id if the card
+// has none.
+func (c *Card) ToBlock() Block {
+	id := c.ID
+	if id == "" {
+		id = utils.NewID(utils.IDTypeCard)
+	}
+
+	contentOrder := make([]interface{}, 0, len(c.ContentOrder))
+	for _, blockID := range c.ContentOrder {
+		contentOrder = append(contentOrder, blockID)
+	}
+	properties := make(map[string]interface{}, len(c.Properties))
+	for propertyID, value := range c.Properties {
+		if value != nil {
+			properties[propertyID] = value
+		}
+	}
+
+	return Block{
+		ID:         id,
+		BoardID:    c.BoardID,
+		ParentID:   c.BoardID,
+		CreatedBy:  c.CreatedBy,
+		ModifiedBy: c.ModifiedBy,
+		Schema:     1,
+		Type:       TypeCard,
+		Title:      c.Title,
+		Fields: map[string]interface{}{
+			"icon":         c.Icon,
+			"isTemplate":   c.IsTemplate,
+			"contentOrder": contentOrder,
+			"properties":   properties,
+		},
+		CreateAt: c.CreateAt,
+		UpdateAt: c.UpdateAt,
+	}
+}
+
+// Block2Card returns the card of a card block.
+func Block2Card(block *Block) *Card {
+	icon, _ := block.Fields["icon"].(string)
+
+	contentOrder := []string{}
+	if raw, ok := block.Fields["contentOrder"].([]interface{}); ok {
+		for _, item := range raw {
+			// content order entries can be nested to lay blocks out in
+			// columns, which the card representation doesn't keep
+			if blockID, ok := item.(string); ok {
+				contentOrder = append(contentOrder, blockID)
+			}
+		}
+	}
+
+	properties := map[string]interface{}{}
+	if raw, ok := block.Fields["properties"].(map[string]interface{}); ok {
+		for propertyID, value := range raw {
+			properties[propertyID] = value
+		}
+	}
+
+	return &Card{
+		ID:           block.ID,
+		BoardID:      block.BoardID,
+		CreatedBy:    block.CreatedBy,
+		ModifiedBy:   block.ModifiedBy,
+		Title:        block.Title,
+		Icon:         icon,
+		IsTemplate:   IsCardTemplate(block),
+		ContentOrder: contentOrder,
+		Properties:   properties,
+		CreateAt:     block.CreateAt,
+		UpdateAt:     block.UpdateAt,
+	}
+}
+
+// BlockPatch returns the patch of the card block that applies the card
+// patch on top of the current values of the card.
+func (p *CardPatch) BlockPatch(card *Block) *BlockPatch {
+	patch := &BlockPatch{
+		Title:         p.Title,
+		UpdatedFields: map[string]interface{}{},
+	}
+
+	if p.Icon != nil {
+		patch.UpdatedFields["icon"] = *p.Icon
+	}
+
+	if p.ContentOrder != nil {
+		contentOrder := make([]interface{}, 0, len(*p.ContentOrder))
+		for _, blockID := range *p.ContentOrder {
+			contentOrder = append(contentOrder, blockID)
+		}
+		patch.UpdatedFields["contentOrder"] = contentOrder
+	}
+
+	if len(p.UpdatedProperties) != 0 {
+		// the properties field is replaced as a whole, so the values that
+		// aren't patched are carried over
+		properties := map[string]interface{}{}
+		if current, ok := card.Fields["properties"].(map[string]interface{}); ok {
+			for propertyID, value := range current {
+				properties[propertyID] = value
+			}
+		}
+		for propertyID, value := range p.UpdatedProperties {
+			if value == nil {
+				delete(properties, propertyID)
+				continue
+			}
+			properties[propertyID] = value
+		}
+		patch.UpdatedFields["properties"] = properties
+	}
+
+	return patch
+}
+
+// ParseCardPropertyFilter parses a card property filter written as
+// propertyID:value.
+func ParseCardPropertyFilter(filter string) (string, string, error) {
+	parts := strings.SplitN(filter, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", newErrInvalidCard(fmt.Sprintf("invalid property filter %q, expected propertyID:value", filter))
+	}
+	return parts[0], parts[1], nil
+}
+
+// CardMatchesPropertyFilters returns true if the card has every value of
+// the filters. A multi-value property matches if any of its values does.
+func CardMatchesPropertyFilters(card *Block, filters map[string]string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	properties, _ := card.Fields["properties"].(map[string]interface{})
+	for propertyID, expected := range filters {
+		if !propertyValueMatches(properties[propertyID], expected) {
+			return false
+		}
+	}
+	return true
+}
+
+func propertyValueMatches(value interface{}, expected string) bool {
+	switch v := value.(type) {
+	case nil:
+		return expected == ""
+	case string:
+		return v == expected
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok && s == expected {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == expected
+	}
+}
+
+// PaginateCards returns the page of the cards the options select.
+func PaginateCards(cards []Block, opts QueryCardsOptions) []Block {
+	perPage := opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultCardsPerPage
+	}
+	if perPage > MaxCardsPerPage {
+		perPage = MaxCardsPerPage
+	}
+	page := opts.Page
+	if page < 0 {
+		page = 0
+	}
+
+	start := page * perPage
+	if start >= len(cards) {
+		return []Block{}
+	}
+	end := start + perPage
+	if end > len(cards) {
+		end = len(cards)
+	}
+	return cards[start:end]
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// CardFieldArchived is the card field that marks a card as archived.
+// Archived cards are left out of the board views until unarchived.
+const CardFieldArchived = "archived"
+
+// IsCardArchived returns true if the block is a card marked as archived.
+func IsCardArchived(block *Block) bool {
+	if block == nil || block.Type != TypeCard {
+		return false
+	}
+	archived, _ := block.Fields[CardFieldArchived].(bool)
+	return archived
+}
+
+// FilterArchivedCards removes the archived cards from the list, together
+// with the blocks of the list that belong to them.
+func FilterArchivedCards(blocks []Block) []Block {
+	archivedCardIDs := map[string]bool{}
+	for i := range blocks {
+		if IsCardArchived(&blocks[i]) {
+			archivedCardIDs[blocks[i].ID] = true
+		}
+	}
+	if len(archivedCardIDs) == 0 {
+		return blocks
+	}
+
+	filtered := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		if archivedCardIDs[block.ID] || archivedCardIDs[block.ParentID] {
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered
+}
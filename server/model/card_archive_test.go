@@ -0,0 +1,23 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterArchivedCards(t *testing.T) {
+	blocks := []Block{
+		{ID: "view", Type: TypeView, Fields: map[string]interface{}{CardFieldArchived: true}},
+		{ID: "card", Type: TypeCard},
+		{ID: "archived-card", Type: TypeCard, Fields: map[string]interface{}{CardFieldArchived: true}},
+		{ID: "comment", ParentID: "archived-card", Type: TypeComment},
+		{ID: "text", ParentID: "card", Type: TypeText},
+	}
+
+	filtered := FilterArchivedCards(blocks)
+	require.Len(t, filtered, 3)
+	require.Equal(t, "view", filtered[0].ID)
+	require.Equal(t, "card", filtered[1].ID)
+	require.Equal(t, "text", filtered[2].ID)
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// BoardPropertyNotifyUnassigned is the key of the board property that
+// enables the notifications of the users unassigned from cards.
+const BoardPropertyNotifyUnassigned = "notifyUnassigned"
+
+// NotifiesUnassigned returns true if the users unassigned from the cards
+// of the board are notified.
+func NotifiesUnassigned(board *Board) bool {
+	notify, _ := board.Properties[BoardPropertyNotifyUnassigned].(bool)
+	return notify
+}
+
+// CardAssignmentChange describes a change of the value of an assignee property of a card.
+type CardAssignmentChange struct {
+	Property     CardProperty
+	AssignedID   string
+	UnassignedID string
+}
+
+// ChangedCardAssignments returns the assignee properties whose value
+// differs between the two versions of a card. oldCard is nil for new
+// cards.
+func ChangedCardAssignments(properties []CardProperty, oldCard, newCard *Block) []CardAssignmentChange {
+	var oldValues map[string]interface{}
+	if oldCard != nil {
+		oldValues = CardPropertyValues(oldCard)
+	}
+	newValues := CardPropertyValues(newCard)
+
+	changes := []CardAssignmentChange{}
+	for i := range properties {
+		if !properties[i].Assignee {
+			continue
+		}
+		oldID, _ := oldValues[properties[i].ID].(string)
+		newID, _ := newValues[properties[i].ID].(string)
+		if oldID == newID {
+			continue
+		}
+		changes = append(changes, CardAssignmentChange{
+			Property:     properties[i],
+			AssignedID:   newID,
+			UnassignedID: oldID,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangedCardAssignments(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson, Assignee: true},
+		{ID: "reviewer", Name: "Reviewer", Type: PropertyTypePerson},
+	}
+	card := func(owner, reviewer string) *Block {
+		return &Block{
+			Type: TypeCard,
+			Fields: map[string]interface{}{
+				"properties": map[string]interface{}{"owner": owner, "reviewer": reviewer},
+			},
+		}
+	}
+
+	t.Run("new card", func(t *testing.T) {
+		changes := ChangedCardAssignments(properties, nil, card("user-1", "user-2"))
+		require.Len(t, changes, 1)
+		require.Equal(t, "owner", changes[0].Property.ID)
+		require.Equal(t, "user-1", changes[0].AssignedID)
+		require.Empty(t, changes[0].UnassignedID)
+	})
+
+	t.Run("reassigned card", func(t *testing.T) {
+		changes := ChangedCardAssignments(properties, card("user-1", ""), card("user-2", ""))
+		require.Len(t, changes, 1)
+		require.Equal(t, "user-2", changes[0].AssignedID)
+		require.Equal(t, "user-1", changes[0].UnassignedID)
+	})
+
+	t.Run("only properties flagged as assignee", func(t *testing.T) {
+		changes := ChangedCardAssignments(properties, card("user-1", ""), card("user-1", "user-2"))
+		require.Empty(t, changes)
+	})
+}
+
+func TestCardPropertyAssigneeIsValid(t *testing.T) {
+	property := &CardProperty{ID: "p1", Name: "Status", Type: PropertyTypeText, Assignee: true}
+	require.Error(t, property.IsValid())
+
+	property.Type = PropertyTypePerson
+	require.NoError(t, property.IsValid())
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// BoardPropertyAutoArchive is the key of the board property that holds
+// the auto-archive rule of the board.
+const BoardPropertyAutoArchive = "autoArchive"
+
+// AutoArchiveRule archives the cards that stay in a status, usually the
+// done one, for longer than a number of days
+// swagger:model
+type AutoArchiveRule struct {
+	// The id of the select property holding the status
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The id of the status option
+	// required: true
+	OptionID string `json:"optionId"`
+
+	// The number of days a card stays in the status before being archived
+	// required: true
+	Days int `json:"days"`
+}
+
+// ErrInvalidAutoArchiveRule is returned when an auto-archive rule doesn't
+// match the property schema of its board.
+type ErrInvalidAutoArchiveRule struct {
+	msg string
+}
+
+func newErrInvalidAutoArchiveRule(msg string) *ErrInvalidAutoArchiveRule {
+	return &ErrInvalidAutoArchiveRule{msg: msg}
+}
+
+func (e *ErrInvalidAutoArchiveRule) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidAutoArchiveRule returns true if the error is an ErrInvalidAutoArchiveRule.
+func IsErrInvalidAutoArchiveRule(err error) bool {
+	var errInvalid *ErrInvalidAutoArchiveRule
+	return errors.As(err, &errInvalid)
+}
+
+// AutoArchiveRuleFromBoard returns the auto-archive rule of a board, or nil
+// if the board has none.
+func AutoArchiveRuleFromBoard(board *Board) (*AutoArchiveRule, error) {
+	raw := board.Properties[BoardPropertyAutoArchive]
+	if raw == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var rule *AutoArchiveRule
+	if err := json.Unmarshal(data, &rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// IsValid checks that the rule refers to a status of the board.
+func (r *AutoArchiveRule) IsValid(properties []CardProperty) error {
+	if r.Days < 1 {
+		return newErrInvalidAutoArchiveRule("days must be at least 1")
+	}
+
+	idx := FindCardProperty(properties, r.PropertyID)
+	if idx == -1 || properties[idx].Type != PropertyTypeSelect {
+		return newErrInvalidAutoArchiveRule(fmt.Sprintf("property %q is not a select property", r.PropertyID))
+	}
+	if properties[idx].FindOption(r.OptionID) == -1 {
+		return newErrInvalidAutoArchiveRule(fmt.Sprintf("option %q doesn't exist", r.OptionID))
+	}
+	return nil
+}
+
+// StatusEnteredAt returns when the card entered the status of the rule,
+// computed from the versions of the card sorted from the newest to the
+// oldest, or zero if the card isn't in the status.
+func (r *AutoArchiveRule) StatusEnteredAt(card *Block, history []Block) int64 {
+	return cardStatusEnteredAt(card, history, r.PropertyID, r.OptionID)
+}
+
+// IsDue returns true if the card stayed in the status for longer than the
+// days of the rule.
+func (r *AutoArchiveRule) IsDue(enteredAt int64, now time.Time) bool {
+	if enteredAt == 0 {
+		return false
+	}
+	limit := utils.GetMillisForTime(now.Add(-time.Duration(r.Days) * 24 * time.Hour))
+	return enteredAt <= limit
+}
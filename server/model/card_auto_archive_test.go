@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoArchiveRuleIsValid(t *testing.T) {
+	properties := testEscalationProperties()
+
+	t.Run("valid rule", func(t *testing.T) {
+		rule := &AutoArchiveRule{PropertyID: "status", OptionID: "review", Days: 7}
+		require.NoError(t, rule.IsValid(properties))
+	})
+
+	t.Run("days under one", func(t *testing.T) {
+		rule := &AutoArchiveRule{PropertyID: "status", OptionID: "review", Days: 0}
+		require.True(t, IsErrInvalidAutoArchiveRule(rule.IsValid(properties)))
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		rule := &AutoArchiveRule{PropertyID: "status", OptionID: "done", Days: 7}
+		require.True(t, IsErrInvalidAutoArchiveRule(rule.IsValid(properties)))
+	})
+
+	t.Run("status that isn't a select", func(t *testing.T) {
+		rule := &AutoArchiveRule{PropertyID: "labels", OptionID: "stale", Days: 7}
+		require.True(t, IsErrInvalidAutoArchiveRule(rule.IsValid(properties)))
+	})
+}
+
+func TestAutoArchiveRuleFromBoard(t *testing.T) {
+	t.Run("board without a rule", func(t *testing.T) {
+		rule, err := AutoArchiveRuleFromBoard(&Board{})
+		require.NoError(t, err)
+		require.Nil(t, rule)
+	})
+
+	t.Run("board with a rule", func(t *testing.T) {
+		board := &Board{Properties: map[string]interface{}{
+			BoardPropertyAutoArchive: map[string]interface{}{"propertyId": "status", "optionId": "review", "days": 7},
+		}}
+		rule, err := AutoArchiveRuleFromBoard(board)
+		require.NoError(t, err)
+		require.Equal(t, &AutoArchiveRule{PropertyID: "status", OptionID: "review", Days: 7}, rule)
+	})
+}
+
+func TestAutoArchiveRuleIsDue(t *testing.T) {
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return utils.GetMillisForTime(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+	rule := &AutoArchiveRule{PropertyID: "status", OptionID: "review", Days: 3}
+
+	t.Run("status entered from the history", func(t *testing.T) {
+		card := testEscalationCard("review", daysAgo(1))
+		history := []Block{card, testEscalationCard("review", daysAgo(4)), testEscalationCard("blocked", daysAgo(6))}
+
+		enteredAt := rule.StatusEnteredAt(&card, history)
+		require.Equal(t, daysAgo(4), enteredAt)
+		require.True(t, rule.IsDue(enteredAt, now))
+	})
+
+	t.Run("card in the status for less than the days", func(t *testing.T) {
+		card := testEscalationCard("review", daysAgo(1))
+		history := []Block{card, testEscalationCard("blocked", daysAgo(4))}
+		require.False(t, rule.IsDue(rule.StatusEnteredAt(&card, history), now))
+	})
+
+	t.Run("card in another status", func(t *testing.T) {
+		card := testEscalationCard("blocked", daysAgo(10))
+		require.False(t, rule.IsDue(rule.StatusEnteredAt(&card, []Block{card}), now))
+	})
+}
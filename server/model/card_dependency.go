@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CardFieldDependsOn holds the ids of the cards of the board that a card
+// depends on, which have to end before the card starts.
+const CardFieldDependsOn = "dependsOn"
+
+// CardDateShift moves the dates of the cards that depend on a card by the
+// delta its date moved by
+// swagger:model
+type CardDateShift struct {
+	// The id of the date property to shift
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The delta to shift the dates by in milliseconds, negative to move
+	// them earlier
+	// required: true
+	Delta int64 `json:"delta"`
+}
+
+// ErrInvalidDateShift is returned when a date shift doesn't match the
+// property schema of its board.
+type ErrInvalidDateShift struct {
+	msg string
+}
+
+func newErrInvalidDateShift(msg string) *ErrInvalidDateShift {
+	return &ErrInvalidDateShift{msg: msg}
+}
+
+func (e *ErrInvalidDateShift) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidDateShift returns true if the error is an ErrInvalidDateShift.
+func IsErrInvalidDateShift(err error) bool {
+	var errInvalid *ErrInvalidDateShift
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks that the shift moves a date property of the board.
+func (s *CardDateShift) IsValid(properties []CardProperty) error {
+	if s.Delta == 0 {
+		return newErrInvalidDateShift("delta cannot be zero")
+	}
+
+	idx := FindCardProperty(properties, s.PropertyID)
+	if idx == -1 || properties[idx].Type != PropertyTypeDate {
+		return newErrInvalidDateShift(fmt.Sprintf("property %q is not a date property", s.PropertyID))
+	}
+	return nil
+}
+
+// CardDependencies returns the ids of the cards the card depends on.
+func CardDependencies(card *Block) []string {
+	raw, _ := card.Fields[CardFieldDependsOn].([]interface{})
+	ids := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if id, ok := item.(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// DependentCards returns the cards that depend on the card, directly or
+// through other cards, closest first. Dependency cycles are cut at the
+// first card seen twice.
+func DependentCards(cards []Block, cardID string) []*Block {
+	dependents := map[string][]*Block{}
+	for i := range cards {
+		for _, id := range CardDependencies(&cards[i]) {
+			dependents[id] = append(dependents[id], &cards[i])
+		}
+	}
+
+	seen := map[string]bool{cardID: true}
+	result := []*Block{}
+	queue := []string{cardID}
+	for len(queue) != 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[id] {
+			if seen[dependent.ID] {
+				continue
+			}
+			seen[dependent.ID] = true
+			result = append(result, dependent)
+			queue = append(queue, dependent.ID)
+		}
+	}
+	return result
+}
+
+// DateShiftPatch returns the patch moving the date of the card by the
+// delta of the shift, or nil if the card has no date for the property.
+func (s *CardDateShift) DateShiftPatch(card *Block) *BlockPatch {
+	raw, ok := CardPropertyValues(card)[s.PropertyID].(string)
+	if !ok {
+		return nil
+	}
+
+	var date map[string]int64
+	if err := json.Unmarshal([]byte(raw), &date); err != nil {
+		return nil
+	}
+	if _, ok := date["from"]; !ok {
+		return nil
+	}
+	for key := range date {
+		date[key] += s.Delta
+	}
+	shifted, err := json.Marshal(date)
+	if err != nil {
+		return nil
+	}
+
+	values := map[string]interface{}{}
+	for key, value := range CardPropertyValues(card) {
+		values[key] = value
+	}
+	values[s.PropertyID] = string(shifted)
+
+	return &BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			"properties": values,
+		},
+	}
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDependencyCard(id string, dependsOn ...string) Block {
+	ids := make([]interface{}, 0, len(dependsOn))
+	for _, dependency := range dependsOn {
+		ids = append(ids, dependency)
+	}
+	return Block{
+		ID:   id,
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			CardFieldDependsOn: ids,
+			"properties":       map[string]interface{}{"due": `{"from":1000,"to":2000}`},
+		},
+	}
+}
+
+func TestDependentCards(t *testing.T) {
+	cards := []Block{
+		testDependencyCard("design"),
+		testDependencyCard("build", "design"),
+		testDependencyCard("test", "build"),
+		testDependencyCard("docs", "design"),
+		testDependencyCard("unrelated"),
+		// cycle back to the design card
+		testDependencyCard("review", "test"),
+	}
+	cards[0].Fields[CardFieldDependsOn] = []interface{}{"review"}
+
+	ids := []string{}
+	for _, card := range DependentCards(cards, "design") {
+		ids = append(ids, card.ID)
+	}
+	require.Equal(t, []string{"build", "docs", "test", "review"}, ids)
+
+	require.Empty(t, DependentCards(cards, "unrelated"))
+}
+
+func TestDateShiftPatch(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "due", Name: "Due", Type: PropertyTypeDate},
+		{ID: "status", Name: "Status", Type: PropertyTypeSelect},
+	}
+
+	t.Run("validation", func(t *testing.T) {
+		require.NoError(t, (&CardDateShift{PropertyID: "due", Delta: 1000}).IsValid(properties))
+		require.True(t, IsErrInvalidDateShift((&CardDateShift{PropertyID: "due"}).IsValid(properties)))
+		require.True(t, IsErrInvalidDateShift((&CardDateShift{PropertyID: "status", Delta: 1000}).IsValid(properties)))
+	})
+
+	t.Run("shift the range", func(t *testing.T) {
+		card := testDependencyCard("build")
+		patch := (&CardDateShift{PropertyID: "due", Delta: -500}).DateShiftPatch(&card)
+		require.NotNil(t, patch)
+		values := patch.UpdatedFields["properties"].(map[string]interface{})
+		require.Equal(t, `{"from":500,"to":1500}`, values["due"])
+
+		// the card itself is not modified
+		require.Equal(t, `{"from":1000,"to":2000}`, CardPropertyValues(&card)["due"])
+	})
+
+	t.Run("card without a date", func(t *testing.T) {
+		card := Block{ID: "card", Type: TypeCard, Fields: map[string]interface{}{}}
+		require.Nil(t, (&CardDateShift{PropertyID: "due", Delta: 1000}).DateShiftPatch(&card))
+	})
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// BoardPropertyEscalations is the key of the board property that holds
+// the escalation policies of the board.
+const BoardPropertyEscalations = "escalations"
+
+// CardFieldEscalations is the card field that holds when each escalation
+// policy last escalated the card, so it isn't escalated again until it
+// leaves and reenters the status.
+const CardFieldEscalations = "escalations"
+
+// EscalationPolicy acts on the cards that stay in a status for longer
+// than a number of days
+// swagger:model
+type EscalationPolicy struct {
+	// The id of the policy
+	// required: true
+	ID string `json:"id"`
+
+	// The name of the policy
+	// required: true
+	Name string `json:"name"`
+
+	// The id of the select property holding the status
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The id of the status option
+	// required: true
+	OptionID string `json:"optionId"`
+
+	// The number of days a card can stay in the status before being escalated
+	// required: true
+	Days int `json:"days"`
+
+	// The ids of the users to notify
+	// required: false
+	NotifyUserIDs []string `json:"notifyUserIds"`
+
+	// Notifies the users assigned to the card
+	// required: false
+	NotifyAssignees bool `json:"notifyAssignees"`
+
+	// The label to add to the card
+	// required: false
+	AddLabel *EscalationLabel `json:"addLabel,omitempty"`
+
+	// The id of the option of the status property to move the card to
+	// required: false
+	MoveToOptionID string `json:"moveToOptionId,omitempty"`
+}
+
+// EscalationLabel is an option of a select or multiSelect property added to escalated cards
+// swagger:model
+type EscalationLabel struct {
+	// The id of the property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The id of the option
+	// required: true
+	OptionID string `json:"optionId"`
+}
+
+// ErrInvalidEscalationPolicy is returned when an escalation policy
+// doesn't match the property schema of its board.
+type ErrInvalidEscalationPolicy struct {
+	msg string
+}
+
+func newErrInvalidEscalationPolicy(msg string) *ErrInvalidEscalationPolicy {
+	return &ErrInvalidEscalationPolicy{msg: msg}
+}
+
+func (e *ErrInvalidEscalationPolicy) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidEscalationPolicy returns true if the error is an ErrInvalidEscalationPolicy.
+func IsErrInvalidEscalationPolicy(err error) bool {
+	var errInvalid *ErrInvalidEscalationPolicy
+	return errors.As(err, &errInvalid)
+}
+
+// EscalationPoliciesFromBoard returns the escalation policies of a board.
+func EscalationPoliciesFromBoard(board *Board) ([]EscalationPolicy, error) {
+	raw := board.Properties[BoardPropertyEscalations]
+	if raw == nil {
+		return []EscalationPolicy{}, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	policies := []EscalationPolicy{}
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// FindEscalationPolicy returns the index of the policy with the given id, or -1.
+func FindEscalationPolicy(policies []EscalationPolicy, policyID string) int {
+	for i := range policies {
+		if policies[i].ID == policyID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Hydrate generates the id of a new policy.
+func (p *EscalationPolicy) Hydrate() {
+	p.ID = utils.NewID(utils.IDTypeNone)
+	if p.NotifyUserIDs == nil {
+		p.NotifyUserIDs = []string{}
+	}
+}
+
+// HasActions returns true if the policy does something to the cards.
+func (p *EscalationPolicy) HasActions() bool {
+	return len(p.NotifyUserIDs) != 0 || p.NotifyAssignees || p.AddLabel != nil || p.MoveToOptionID != ""
+}
+
+// IsValid checks that the policy refers to properties and options of the board.
+func (p *EscalationPolicy) IsValid(properties []CardProperty) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return newErrInvalidEscalationPolicy("policy name cannot be empty")
+	}
+	if p.Days < 1 {
+		return newErrInvalidEscalationPolicy("days must be at least 1")
+	}
+	if !p.HasActions() {
+		return newErrInvalidEscalationPolicy("policy must have at least one action")
+	}
+
+	idx := FindCardProperty(properties, p.PropertyID)
+	if idx == -1 || properties[idx].Type != PropertyTypeSelect {
+		return newErrInvalidEscalationPolicy(fmt.Sprintf("property %q is not a select property", p.PropertyID))
+	}
+	if properties[idx].FindOption(p.OptionID) == -1 {
+		return newErrInvalidEscalationPolicy(fmt.Sprintf("option %q doesn't exist", p.OptionID))
+	}
+	if p.MoveToOptionID != "" {
+		if p.MoveToOptionID == p.OptionID || properties[idx].FindOption(p.MoveToOptionID) == -1 {
+			return newErrInvalidEscalationPolicy(fmt.Sprintf("cannot move cards to option %q", p.MoveToOptionID))
+		}
+	}
+
+	if p.AddLabel != nil {
+		labelIdx := FindCardProperty(properties, p.AddLabel.PropertyID)
+		if labelIdx == -1 || !PropertyTypeHasOptions(properties[labelIdx].Type) {
+			return newErrInvalidEscalationPolicy(fmt.Sprintf("property %q cannot hold labels", p.AddLabel.PropertyID))
+		}
+		if p.AddLabel.PropertyID == p.PropertyID {
+			return newErrInvalidEscalationPolicy("the label cannot be added to the status property")
+		}
+		if properties[labelIdx].FindOption(p.AddLabel.OptionID) == -1 {
+			return newErrInvalidEscalationPolicy(fmt.Sprintf("option %q doesn't exist", p.AddLabel.OptionID))
+		}
+	}
+	return nil
+}
+
+// StatusEnteredAt returns when the card entered the status of the
+// policy, computed from the versions of the card sorted from the newest
+// to the oldest, or zero if the card isn't in the status.
+func (p *EscalationPolicy) StatusEnteredAt(card *Block, history []Block) int64 {
+	return cardStatusEnteredAt(card, history, p.PropertyID, p.OptionID)
+}
+
+// cardStatusEnteredAt returns when the card entered the option of the
+// select property, computed from the versions of the card sorted from
+// the newest to the oldest, or zero if the card doesn't have the option.
+func cardStatusEnteredAt(card *Block, history []Block, propertyID, optionID string) int64 {
+	if CardPropertyValues(card)[propertyID] != optionID {
+		return 0
+	}
+
+	enteredAt := card.UpdateAt
+	for i := range history {
+		if CardPropertyValues(&history[i])[propertyID] != optionID {
+			break
+		}
+		enteredAt = history[i].UpdateAt
+	}
+	return enteredAt
+}
+
+// CardEscalatedAt returns when the policy last escalated the card, or zero.
+func CardEscalatedAt(card *Block, policyID string) int64 {
+	escalations, _ := card.Fields[CardFieldEscalations].(map[string]interface{})
+	switch v := escalations[policyID].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	}
+	return 0
+}
+
+// IsDue returns true if the card stayed in the status for longer than
+// the days of the policy and wasn't escalated since it entered it.
+func (p *EscalationPolicy) IsDue(card *Block, enteredAt int64, now time.Time) bool {
+	if enteredAt == 0 || CardEscalatedAt(card, p.ID) >= enteredAt {
+		return false
+	}
+	limit := utils.GetMillisForTime(now.Add(-time.Duration(p.Days) * 24 * time.Hour))
+	return enteredAt <= limit
+}
+
+// EscalationPatch returns the patch that applies the label and move
+// actions of the policy to the card, and records the escalation.
+func (p *EscalationPolicy) EscalationPatch(properties []CardProperty, card *Block, now time.Time) *BlockPatch {
+	values := map[string]interface{}{}
+	for key, value := range CardPropertyValues(card) {
+		values[key] = value
+	}
+
+	if p.AddLabel != nil {
+		idx := FindCardProperty(properties, p.AddLabel.PropertyID)
+		if idx != -1 {
+			values[p.AddLabel.PropertyID] = addOptionValue(&properties[idx], values[p.AddLabel.PropertyID], p.AddLabel.OptionID)
+		}
+	}
+	if p.MoveToOptionID != "" {
+		values[p.PropertyID] = p.MoveToOptionID
+	}
+
+	escalations := map[string]interface{}{}
+	if current, ok := card.Fields[CardFieldEscalations].(map[string]interface{}); ok {
+		for key, value := range current {
+			escalations[key] = value
+		}
+	}
+	escalations[p.ID] = utils.GetMillisForTime(now)
+
+	return &BlockPatch{
+		UpdatedFields: map[string]interface{}{
+			"properties":         values,
+			CardFieldEscalations: escalations,
+		},
+	}
+}
+
+// addOptionValue adds an option to the value of a select or multiSelect property.
+func addOptionValue(property *CardProperty, value interface{}, optionID string) interface{} {
+	if property.Type != PropertyTypeMultiSelect {
+		return optionID
+	}
+
+	ids, _ := value.([]interface{})
+	for _, id := range ids {
+		if id == optionID {
+			return ids
+		}
+	}
+	updated := make([]interface{}, 0, len(ids)+1)
+	updated = append(updated, ids...)
+	return append(updated, optionID)
+}
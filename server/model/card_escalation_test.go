@@ -0,0 +1,114 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func testEscalationProperties() []CardProperty {
+	return []CardProperty{
+		{
+			ID: "status", Name: "Status", Type: PropertyTypeSelect,
+			Options: []CardPropertyOption{{ID: "review", Value: "Review"}, {ID: "blocked", Value: "Blocked"}},
+		},
+		{
+			ID: "labels", Name: "Labels", Type: PropertyTypeMultiSelect,
+			Options: []CardPropertyOption{{ID: "stale", Value: "Stale"}, {ID: "urgent", Value: "Urgent"}},
+		},
+	}
+}
+
+func testEscalationCard(status string, updateAt int64) Block {
+	return Block{
+		ID:       "card-id",
+		Type:     TypeCard,
+		UpdateAt: updateAt,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": status, "labels": []interface{}{"urgent"}},
+		},
+	}
+}
+
+func TestEscalationPolicyIsValid(t *testing.T) {
+	properties := testEscalationProperties()
+
+	t.Run("valid policy", func(t *testing.T) {
+		policy := &EscalationPolicy{
+			Name: "Stale reviews", PropertyID: "status", OptionID: "review", Days: 3,
+			AddLabel: &EscalationLabel{PropertyID: "labels", OptionID: "stale"}, MoveToOptionID: "blocked",
+		}
+		require.NoError(t, policy.IsValid(properties))
+	})
+
+	t.Run("policy without actions", func(t *testing.T) {
+		policy := &EscalationPolicy{Name: "Stale reviews", PropertyID: "status", OptionID: "review", Days: 3}
+		require.True(t, IsErrInvalidEscalationPolicy(policy.IsValid(properties)))
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		policy := &EscalationPolicy{Name: "Stale reviews", PropertyID: "status", OptionID: "done", Days: 3, NotifyAssignees: true}
+		require.True(t, IsErrInvalidEscalationPolicy(policy.IsValid(properties)))
+	})
+
+	t.Run("status that isn't a select", func(t *testing.T) {
+		policy := &EscalationPolicy{Name: "Stale reviews", PropertyID: "labels", OptionID: "stale", Days: 3, NotifyAssignees: true}
+		require.True(t, IsErrInvalidEscalationPolicy(policy.IsValid(properties)))
+	})
+}
+
+func TestEscalationPolicyIsDue(t *testing.T) {
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) int64 {
+		return utils.GetMillisForTime(now.Add(-time.Duration(days) * 24 * time.Hour))
+	}
+	policy := &EscalationPolicy{ID: "policy-id", PropertyID: "status", OptionID: "review", Days: 3}
+
+	t.Run("status entered from the history", func(t *testing.T) {
+		card := testEscalationCard("review", daysAgo(1))
+		history := []Block{card, testEscalationCard("review", daysAgo(4)), testEscalationCard("blocked", daysAgo(6))}
+
+		enteredAt := policy.StatusEnteredAt(&card, history)
+		require.Equal(t, daysAgo(4), enteredAt)
+		require.True(t, policy.IsDue(&card, enteredAt, now))
+	})
+
+	t.Run("card in the status for less than the days", func(t *testing.T) {
+		card := testEscalationCard("review", daysAgo(1))
+		history := []Block{card, testEscalationCard("blocked", daysAgo(4))}
+		require.False(t, policy.IsDue(&card, policy.StatusEnteredAt(&card, history), now))
+	})
+
+	t.Run("card in another status", func(t *testing.T) {
+		card := testEscalationCard("blocked", daysAgo(10))
+		require.Zero(t, policy.StatusEnteredAt(&card, []Block{card}))
+	})
+
+	t.Run("card already escalated", func(t *testing.T) {
+		card := testEscalationCard("review", daysAgo(5))
+		card.Fields[CardFieldEscalations] = map[string]interface{}{"policy-id": float64(daysAgo(1))}
+		require.False(t, policy.IsDue(&card, daysAgo(5), now))
+	})
+}
+
+func TestEscalationPatch(t *testing.T) {
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	policy := &EscalationPolicy{
+		ID: "policy-id", PropertyID: "status", OptionID: "review", Days: 3,
+		AddLabel: &EscalationLabel{PropertyID: "labels", OptionID: "stale"}, MoveToOptionID: "blocked",
+	}
+	card := testEscalationCard("review", 0)
+
+	patch := policy.EscalationPatch(testEscalationProperties(), &card, now)
+	values := patch.UpdatedFields["properties"].(map[string]interface{})
+	require.Equal(t, "blocked", values["status"])
+	require.Equal(t, []interface{}{"urgent", "stale"}, values["labels"])
+
+	escalations := patch.UpdatedFields[CardFieldEscalations].(map[string]interface{})
+	require.Equal(t, utils.GetMillisForTime(now), escalations["policy-id"])
+
+	// the card itself is not modified
+	require.Equal(t, "review", CardPropertyValues(&card)["status"])
+}
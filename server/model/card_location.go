@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+const earthRadiusMeters = 6371000
+
+var ErrInvalidLocation = errors.New("invalid location")
+var ErrInvalidLocationFilter = errors.New("invalid location filter")
+
+// CardLocation is the value of a location card property
+// swagger:model
+type CardLocation struct {
+	// The latitude, in degrees
+	// required: true
+	Lat float64 `json:"lat"`
+
+	// The longitude, in degrees
+	// required: true
+	Lng float64 `json:"lng"`
+
+	// An optional label for the location
+	// required: false
+	Label string `json:"label,omitempty"`
+}
+
+// IsValid checks that the coordinates are within range.
+func (l *CardLocation) IsValid() error {
+	if math.IsNaN(l.Lat) || l.Lat < -90 || l.Lat > 90 {
+		return ErrInvalidLocation
+	}
+	if math.IsNaN(l.Lng) || l.Lng < -180 || l.Lng > 180 {
+		return ErrInvalidLocation
+	}
+	return nil
+}
+
+// DistanceTo returns the great-circle distance between two locations, in meters.
+func (l *CardLocation) DistanceTo(other *CardLocation) float64 {
+	lat1 := l.Lat * math.Pi / 180
+	lat2 := other.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (other.Lng - l.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// ParseCardLocation converts the raw value of a location property, as
+// stored in a card's fields, into a validated CardLocation.
+func ParseCardLocation(v interface{}) (*CardLocation, error) {
+	if _, ok := v.(map[string]interface{}); !ok {
+		return nil, ErrInvalidLocation
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, ErrInvalidLocation
+	}
+
+	var raw struct {
+		Lat   *float64 `json:"lat"`
+		Lng   *float64 `json:"lng"`
+		Label string   `json:"label"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil || raw.Lat == nil || raw.Lng == nil {
+		return nil, ErrInvalidLocation
+	}
+
+	location := &CardLocation{Lat: *raw.Lat, Lng: *raw.Lng, Label: raw.Label}
+	if err := location.IsValid(); err != nil {
+		return nil, err
+	}
+	return location, nil
+}
+
+// CardLocationFilter restricts a card listing to the cards with a
+// location inside a radius around a point, inside a bounding box, or both.
+type CardLocationFilter struct {
+	// PropertyID limits the filter to one location property. If empty,
+	// any location property of the card can match.
+	PropertyID string
+
+	// Center and Radius (in meters) describe a circle.
+	Center *CardLocation
+	Radius float64
+
+	// SouthWest and NorthEast describe a bounding box.
+	SouthWest *CardLocation
+	NorthEast *CardLocation
+}
+
+// IsValid checks that the filter describes at least one valid area.
+func (f *CardLocationFilter) IsValid() error {
+	if f.Center == nil && f.SouthWest == nil {
+		return ErrInvalidLocationFilter
+	}
+
+	if f.Center != nil {
+		if err := f.Center.IsValid(); err != nil {
+			return ErrInvalidLocationFilter
+		}
+		if f.Radius <= 0 || math.IsNaN(f.Radius) {
+			return ErrInvalidLocationFilter
+		}
+	}
+
+	if f.SouthWest != nil {
+		if f.NorthEast == nil {
+			return ErrInvalidLocationFilter
+		}
+		if f.SouthWest.IsValid() != nil || f.NorthEast.IsValid() != nil {
+			return ErrInvalidLocationFilter
+		}
+		if f.SouthWest.Lat > f.NorthEast.Lat {
+			return ErrInvalidLocationFilter
+		}
+	}
+	return nil
+}
+
+// Contains returns true if the location is inside every area of the filter.
+func (f *CardLocationFilter) Contains(location *CardLocation) bool {
+	if f.Center != nil && f.Center.DistanceTo(location) > f.Radius {
+		return false
+	}
+
+	if f.SouthWest != nil {
+		if location.Lat < f.SouthWest.Lat || location.Lat > f.NorthEast.Lat {
+			return false
+		}
+		// a box whose west edge is east of its east edge crosses the antimeridian
+		if f.SouthWest.Lng <= f.NorthEast.Lng {
+			if location.Lng < f.SouthWest.Lng || location.Lng > f.NorthEast.Lng {
+				return false
+			}
+		} else if location.Lng < f.SouthWest.Lng && location.Lng > f.NorthEast.Lng {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterCardsByLocation returns the cards that have a location value
+// matching the filter. Blocks that are not cards are dropped.
+func FilterCardsByLocation(properties []CardProperty, cards []Block, filter *CardLocationFilter) []Block {
+	filtered := []Block{}
+	for i := range cards {
+		if cards[i].Type != TypeCard {
+			continue
+		}
+
+		values := CardPropertyValues(&cards[i])
+		for j := range properties {
+			if properties[j].Type != PropertyTypeLocation {
+				continue
+			}
+			if filter.PropertyID != "" && filter.PropertyID != properties[j].ID {
+				continue
+			}
+
+			location, err := ParseCardLocation(values[properties[j].ID])
+			if err != nil {
+				continue
+			}
+			if filter.Contains(location) {
+				filtered = append(filtered, cards[i])
+				break
+			}
+		}
+	}
+	return filtered
+}
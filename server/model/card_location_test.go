@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCardLocation(t *testing.T) {
+	location, err := ParseCardLocation(map[string]interface{}{"lat": 48.8566, "lng": 2.3522, "label": "Paris"})
+	require.NoError(t, err)
+	require.Equal(t, "Paris", location.Label)
+
+	_, err = ParseCardLocation(map[string]interface{}{"lat": 91.0, "lng": 0.0})
+	require.ErrorIs(t, err, ErrInvalidLocation)
+
+	_, err = ParseCardLocation(map[string]interface{}{"lat": 10.0})
+	require.ErrorIs(t, err, ErrInvalidLocation)
+
+	_, err = ParseCardLocation("48.8566,2.3522")
+	require.ErrorIs(t, err, ErrInvalidLocation)
+}
+
+func TestValidateCardPropertyValuesLocation(t *testing.T) {
+	properties := []CardProperty{{ID: "where", Name: "Where", Type: PropertyTypeLocation}}
+
+	card := &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+		"where": map[string]interface{}{"lat": 10.0, "lng": 20.0},
+	}}}
+	require.NoError(t, ValidateCardPropertyValues(properties, card))
+
+	card.Fields["properties"] = map[string]interface{}{"where": "somewhere"}
+	require.True(t, IsErrInvalidCardProperty(ValidateCardPropertyValues(properties, card)))
+}
+
+func TestFilterCardsByLocation(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "where", Name: "Where", Type: PropertyTypeLocation},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+
+	newCard := func(id string, lat, lng float64) Block {
+		return Block{ID: id, Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"where": map[string]interface{}{"lat": lat, "lng": lng},
+		}}}
+	}
+	cards := []Block{
+		newCard("paris", 48.8566, 2.3522),
+		newCard("versailles", 48.8049, 2.1204),
+		newCard("london", 51.5072, -0.1276),
+		{ID: "nowhere", Type: TypeCard, Fields: map[string]interface{}{}},
+	}
+
+	t.Run("radius", func(t *testing.T) {
+		filter := &CardLocationFilter{Center: &CardLocation{Lat: 48.8566, Lng: 2.3522}, Radius: 25000}
+		require.NoError(t, filter.IsValid())
+
+		filtered := FilterCardsByLocation(properties, cards, filter)
+		require.Len(t, filtered, 2)
+		require.Equal(t, "paris", filtered[0].ID)
+		require.Equal(t, "versailles", filtered[1].ID)
+	})
+
+	t.Run("bounding box", func(t *testing.T) {
+		filter := &CardLocationFilter{
+			SouthWest: &CardLocation{Lat: 50, Lng: -1},
+			NorthEast: &CardLocation{Lat: 52, Lng: 1},
+		}
+		require.NoError(t, filter.IsValid())
+
+		filtered := FilterCardsByLocation(properties, cards, filter)
+		require.Len(t, filtered, 1)
+		require.Equal(t, "london", filtered[0].ID)
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		filter := &CardLocationFilter{PropertyID: "notes", Center: &CardLocation{Lat: 48.8566, Lng: 2.3522}, Radius: 25000}
+		require.Empty(t, FilterCardsByLocation(properties, cards, filter))
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		require.Error(t, (&CardLocationFilter{}).IsValid())
+		require.Error(t, (&CardLocationFilter{Center: &CardLocation{Lat: 10, Lng: 10}}).IsValid())
+		require.Error(t, (&CardLocationFilter{SouthWest: &CardLocation{Lat: 10, Lng: 10}}).IsValid())
+	})
+}
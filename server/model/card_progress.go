@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// ProgressSourceCheckboxes computes the progress from the checkbox
+	// content blocks of the card.
+	ProgressSourceCheckboxes = "checkboxes"
+
+	// ProgressSourceSubtasks computes the progress from the cards nested
+	// under the card, using a checkbox property to tell if they are done.
+	ProgressSourceSubtasks = "subtasks"
+)
+
+// CardPropertyProgress configures how the value of a progress property is computed
+// swagger:model
+type CardPropertyProgress struct {
+	// The source of the progress, either checkboxes or subtasks
+	// required: true
+	Source string `json:"source"`
+
+	// The checkbox property that marks a subtask as done, only for the subtasks source
+	// required: false
+	DonePropertyID string `json:"donePropertyId,omitempty"`
+}
+
+// IsValid checks the progress configuration in isolation.
+func (p *CardPropertyProgress) IsValid() error {
+	switch p.Source {
+	case ProgressSourceCheckboxes:
+		if p.DonePropertyID != "" {
+			return newErrInvalidCardProperty("checkbox progress cannot have a done property")
+		}
+	case ProgressSourceSubtasks:
+		if p.DonePropertyID == "" {
+			return newErrInvalidCardProperty("subtask progress requires a done property")
+		}
+	default:
+		return newErrInvalidCardProperty(fmt.Sprintf("invalid progress source %q", p.Source))
+	}
+	return nil
+}
+
+// validateProgressReferences checks that the done properties used by the
+// progress properties of a schema exist and are checkboxes.
+func validateProgressReferences(properties []CardProperty) error {
+	for i := range properties {
+		progress := properties[i].Progress
+		if progress == nil || progress.DonePropertyID == "" {
+			continue
+		}
+
+		idx := FindCardProperty(properties, progress.DonePropertyID)
+		if idx == -1 || properties[idx].Type != PropertyTypeCheckbox {
+			return newErrInvalidCardProperty(fmt.Sprintf("done property of %q must be a checkbox property", properties[i].Name))
+		}
+	}
+	return nil
+}
+
+// HasProgressProperties returns true if any property of the schema is computed from child blocks.
+func HasProgressProperties(properties []CardProperty) bool {
+	for i := range properties {
+		if properties[i].Type == PropertyTypeProgress {
+			return true
+		}
+	}
+	return false
+}
+
+// ComputeCardProgress returns the percentage, from 0 to 100, of the
+// done items among the children of a card for the given progress
+// property. It returns false if the card has no items to track.
+func ComputeCardProgress(property *CardProperty, children []Block) (int, bool) {
+	if property.Progress == nil {
+		return 0, false
+	}
+
+	total := 0
+	done := 0
+	for i := range children {
+		switch property.Progress.Source {
+		case ProgressSourceCheckboxes:
+			if children[i].Type != TypeCheckbox {
+				continue
+			}
+			total++
+			if checked, _ := children[i].Fields["value"].(bool); checked {
+				done++
+			}
+		case ProgressSourceSubtasks:
+			if children[i].Type != TypeCard {
+				continue
+			}
+			total++
+			if value, _ := CardPropertyValues(&children[i])[property.Progress.DonePropertyID].(string); value == "true" {
+				done++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0, false
+	}
+	return done * 100 / total, true
+}
+
+// ApplyCardProgress recomputes the progress properties of a card from its
+// children. Values are stored as strings, the same way number properties
+// are, so views can sort and filter on them. It returns the updated
+// property values and whether any of them changed.
+func ApplyCardProgress(properties []CardProperty, card *Block, children []Block) (map[string]interface{}, bool) {
+	current := CardPropertyValues(card)
+	values := make(map[string]interface{}, len(current)+1)
+	for key, value := range current {
+		values[key] = value
+	}
+
+	changed := false
+	for i := range properties {
+		if properties[i].Type != PropertyTypeProgress {
+			continue
+		}
+
+		percent, ok := ComputeCardProgress(&properties[i], children)
+		if !ok {
+			if _, exists := values[properties[i].ID]; exists {
+				delete(values, properties[i].ID)
+				changed = true
+			}
+			continue
+		}
+
+		value := strconv.Itoa(percent)
+		if current[properties[i].ID] != value {
+			values[properties[i].ID] = value
+			changed = true
+		}
+	}
+	return values, changed
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardPropertyProgressIsValid(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "done", Name: "Done", Type: PropertyTypeCheckbox},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+		{ID: "progress", Name: "Progress", Type: PropertyTypeProgress, Progress: &CardPropertyProgress{Source: ProgressSourceSubtasks, DonePropertyID: "done"}},
+	}
+	require.NoError(t, ValidateCardProperties(properties))
+
+	properties[2].Progress.DonePropertyID = "notes"
+	require.Error(t, ValidateCardProperties(properties))
+
+	properties[2].Progress = &CardPropertyProgress{Source: ProgressSourceSubtasks}
+	require.Error(t, ValidateCardProperties(properties))
+
+	properties[2].Progress = nil
+	require.Error(t, ValidateCardProperties(properties))
+
+	properties[2].Progress = &CardPropertyProgress{Source: ProgressSourceCheckboxes}
+	require.NoError(t, ValidateCardProperties(properties))
+
+	properties[1].Progress = &CardPropertyProgress{Source: ProgressSourceCheckboxes}
+	require.Error(t, ValidateCardProperties(properties))
+}
+
+func TestApplyCardProgress(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "done", Name: "Done", Type: PropertyTypeCheckbox},
+		{ID: "checklist", Name: "Checklist", Type: PropertyTypeProgress, Progress: &CardPropertyProgress{Source: ProgressSourceCheckboxes}},
+		{ID: "subtasks", Name: "Subtasks", Type: PropertyTypeProgress, Progress: &CardPropertyProgress{Source: ProgressSourceSubtasks, DonePropertyID: "done"}},
+	}
+
+	card := &Block{ID: "card", Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+		"subtasks": "50",
+	}}}
+	children := []Block{
+		{Type: TypeCheckbox, Fields: map[string]interface{}{"value": true}},
+		{Type: TypeCheckbox, Fields: map[string]interface{}{"value": false}},
+		{Type: TypeCheckbox, Fields: map[string]interface{}{}},
+		{Type: TypeText, Fields: map[string]interface{}{"value": true}},
+	}
+
+	values, changed := ApplyCardProgress(properties, card, children)
+	require.True(t, changed)
+	require.Equal(t, "33", values["checklist"])
+	require.NotContains(t, values, "subtasks")
+
+	children = append(children,
+		Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{"done": "true"}}},
+		Block{Type: TypeCard, Fields: map[string]interface{}{}},
+	)
+	card.Fields["properties"] = values
+	values, changed = ApplyCardProgress(properties, card, children)
+	require.True(t, changed)
+	require.Equal(t, "33", values["checklist"])
+	require.Equal(t, "50", values["subtasks"])
+
+	card.Fields["properties"] = values
+	_, changed = ApplyCardProgress(properties, card, children)
+	require.False(t, changed)
+}
@@ -0,0 +1,718 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	PropertyTypeText        = "text"
+	PropertyTypeNumber      = "number"
+	PropertyTypeSelect      = "select"
+	PropertyTypeMultiSelect = "multiSelect"
+	PropertyTypeDate        = "date"
+	PropertyTypePerson      = "person"
+	PropertyTypeFile        = "file"
+	PropertyTypeCheckbox    = "checkbox"
+	PropertyTypeURL         = "url"
+	PropertyTypeEmail       = "email"
+	PropertyTypePhone       = "phone"
+	PropertyTypeCreatedTime = "createdTime"
+	PropertyTypeCreatedBy   = "createdBy"
+	PropertyTypeUpdatedTime = "updatedTime"
+	PropertyTypeUpdatedBy   = "updatedBy"
+	PropertyTypeLocation    = "location"
+	PropertyTypeProgress    = "progress"
+)
+
+const (
+	PropertyColorDefault = "propColorDefault"
+	PropertyColorGray    = "propColorGray"
+	PropertyColorBrown   = "propColorBrown"
+	PropertyColorOrange  = "propColorOrange"
+	PropertyColorYellow  = "propColorYellow"
+	PropertyColorGreen   = "propColorGreen"
+	PropertyColorBlue    = "propColorBlue"
+	PropertyColorPurple  = "propColorPurple"
+	PropertyColorPink    = "propColorPink"
+	PropertyColorRed     = "propColorRed"
+)
+
+var validPropertyTypes = map[string]bool{
+	PropertyTypeText:        true,
+	PropertyTypeNumber:      true,
+	PropertyTypeSelect:      true,
+	PropertyTypeMultiSelect: true,
+	PropertyTypeDate:        true,
+	PropertyTypePerson:      true,
+	PropertyTypeFile:        true,
+	PropertyTypeCheckbox:    true,
+	PropertyTypeURL:         true,
+	PropertyTypeEmail:       true,
+	PropertyTypePhone:       true,
+	PropertyTypeCreatedTime: true,
+	PropertyTypeCreatedBy:   true,
+	PropertyTypeUpdatedTime: true,
+	PropertyTypeUpdatedBy:   true,
+	PropertyTypeLocation:    true,
+	PropertyTypeProgress:    true,
+}
+
+var validPropertyColors = map[string]bool{
+	PropertyColorDefault: true,
+	PropertyColorGray:    true,
+	PropertyColorBrown:   true,
+	PropertyColorOrange:  true,
+	PropertyColorYellow:  true,
+	PropertyColorGreen:   true,
+	PropertyColorBlue:    true,
+	PropertyColorPurple:  true,
+	PropertyColorPink:    true,
+	PropertyColorRed:     true,
+}
+
+const (
+	maxOptionIconNameLength  = 64
+	maxOptionIconEmojiLength = 16
+)
+
+// optionIconNamePattern matches the names of the icons of the compass
+// icon set, like "check-circle".
+var optionIconNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// IsValidPropertyType returns true if the property type is known to the server.
+func IsValidPropertyType(t string) bool {
+	return validPropertyTypes[t]
+}
+
+// IsValidPropertyColor returns true if the color is one of the option colors.
+func IsValidPropertyColor(c string) bool {
+	return validPropertyColors[c]
+}
+
+// IsValidPropertyOptionIcon returns true if the icon of an option is
+// empty, an emoji or the name of an icon of the compass icon set.
+func IsValidPropertyOptionIcon(icon string) bool {
+	if icon == "" {
+		return true
+	}
+	if optionIconNamePattern.MatchString(icon) {
+		return len(icon) <= maxOptionIconNameLength
+	}
+	if utf8.RuneCountInString(icon) > maxOptionIconEmojiLength {
+		return false
+	}
+
+	// an emoji can be a sequence of joined symbols and modifiers, but it
+	// has no ASCII characters, spaces or control characters
+	hasSymbol := false
+	for _, r := range icon {
+		if r < utf8.RuneSelf || unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+		if unicode.Is(unicode.So, r) {
+			hasSymbol = true
+		}
+	}
+	return hasSymbol
+}
+
+// PropertyTypeHasOptions returns true for the property types that store option ids.
+func PropertyTypeHasOptions(t string) bool {
+	return t == PropertyTypeSelect || t == PropertyTypeMultiSelect
+}
+
+// CardPropertyOption is an option of a select or multiSelect card property
+// swagger:model
+type CardPropertyOption struct {
+	// The id of the option
+	// required: true
+	ID string `json:"id"`
+
+	// The display value of the option
+	// required: true
+	Value string `json:"value"`
+
+	// The color of the option
+	// required: true
+	Color string `json:"color"`
+
+	// The icon of the option, an emoji or the name of a compass icon
+	// required: false
+	Icon string `json:"icon,omitempty"`
+}
+
+// CardProperty is the typed definition of a property that the cards of a board can hold
+// swagger:model
+type CardProperty struct {
+	// The id of the property
+	// required: true
+	ID string `json:"id"`
+
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+
+	// The type of the property
+	// required: true
+	Type string `json:"type"`
+
+	// The options of the property, only for select and multiSelect types
+	// required: false
+	Options []CardPropertyOption `json:"options"`
+
+	// The unit or currency of the values, only for number types
+	// required: false
+	Unit *CardPropertyUnit `json:"unit,omitempty"`
+
+	// How the value is computed, only for progress types
+	// required: false
+	Progress *CardPropertyProgress `json:"progress,omitempty"`
+
+	// The value that new cards get when created without one. Person properties accept
+	// "$currentUser" and date properties accept "$today"
+	// required: false
+	DefaultValue interface{} `json:"defaultValue,omitempty"`
+
+	// Marks the properties that cards must have a value for
+	// required: false
+	Required bool `json:"required,omitempty"`
+
+	// Marks the properties whose values can only be changed by board admins
+	// required: false
+	Locked bool `json:"locked,omitempty"`
+
+	// Marks the person properties that assign cards, whose changes notify the assigned users
+	// required: false
+	Assignee bool `json:"assignee,omitempty"`
+}
+
+// CardPropertyPatch is a patch for modifying a card property
+// swagger:model
+type CardPropertyPatch struct {
+	// The new name of the property
+	// required: false
+	Name *string `json:"name"`
+
+	// The new type of the property
+	// required: false
+	Type *string `json:"type"`
+
+	// The new unit of the property, only for number types
+	// required: false
+	Unit *CardPropertyUnit `json:"unit"`
+
+	// The new progress configuration of the property, only for progress types
+	// required: false
+	Progress *CardPropertyProgress `json:"progress"`
+
+	// The new default value of the property
+	// required: false
+	DefaultValue interface{} `json:"defaultValue"`
+
+	// Set to true to remove the default value of the property
+	// required: false
+	DeleteDefaultValue bool `json:"deleteDefaultValue"`
+
+	// Marks or unmarks the property as required
+	// required: false
+	Required *bool `json:"required"`
+
+	// Locks or unlocks the values of the property
+	// required: false
+	Locked *bool `json:"locked"`
+
+	// Marks or unmarks the property as assigning cards
+	// required: false
+	Assignee *bool `json:"assignee"`
+}
+
+// CardPropertyOptionPatch is a patch for modifying a card property option
+// swagger:model
+type CardPropertyOptionPatch struct {
+	// The new value of the option
+	// required: false
+	Value *string `json:"value"`
+
+	// The new color of the option
+	// required: false
+	Color *string `json:"color"`
+
+	// The new icon of the option. An empty icon removes it
+	// required: false
+	Icon *string `json:"icon"`
+}
+
+// ErrInvalidCardProperty is returned when a property schema fails validation.
+type ErrInvalidCardProperty struct {
+	msg string
+}
+
+func newErrInvalidCardProperty(msg string) *ErrInvalidCardProperty {
+	return &ErrInvalidCardProperty{
+		msg: msg,
+	}
+}
+
+func (e *ErrInvalidCardProperty) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidCardProperty returns true if `err` is or wraps an ErrInvalidCardProperty.
+func IsErrInvalidCardProperty(err error) bool {
+	var errInvalid *ErrInvalidCardProperty
+	return errors.As(err, &errInvalid)
+}
+
+// Hydrate fills the generated values of a new property and its options.
+func (p *CardProperty) Hydrate() {
+	if p.ID == "" {
+		p.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if p.Options == nil {
+		p.Options = []CardPropertyOption{}
+	}
+	for i := range p.Options {
+		p.Options[i].Hydrate()
+	}
+	if p.Unit != nil {
+		p.Unit.Hydrate()
+	}
+}
+
+// Hydrate fills the generated values of a new option.
+func (o *CardPropertyOption) Hydrate() {
+	if o.ID == "" {
+		o.ID = utils.NewID(utils.IDTypeNone)
+	}
+	if o.Color == "" {
+		o.Color = PropertyColorDefault
+	}
+}
+
+// IsValid checks an option in isolation.
+func (o *CardPropertyOption) IsValid() error {
+	if strings.TrimSpace(o.ID) == "" {
+		return newErrInvalidCardProperty("option ID cannot be empty")
+	}
+	if strings.TrimSpace(o.Value) == "" {
+		return newErrInvalidCardProperty("option value cannot be empty")
+	}
+	if !IsValidPropertyColor(o.Color) {
+		return newErrInvalidCardProperty(fmt.Sprintf("invalid option color %q", o.Color))
+	}
+	if !IsValidPropertyOptionIcon(o.Icon) {
+		return newErrInvalidCardProperty(fmt.Sprintf("invalid option icon %q", o.Icon))
+	}
+	return nil
+}
+
+// IsValid checks a property in isolation, including its options.
+func (p *CardProperty) IsValid() error {
+	if strings.TrimSpace(p.ID) == "" {
+		return newErrInvalidCardProperty("property ID cannot be empty")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return newErrInvalidCardProperty("property name cannot be empty")
+	}
+	if !IsValidPropertyType(p.Type) {
+		return newErrInvalidCardProperty(fmt.Sprintf("invalid property type %q", p.Type))
+	}
+	if !PropertyTypeHasOptions(p.Type) && len(p.Options) != 0 {
+		return newErrInvalidCardProperty(fmt.Sprintf("property type %q cannot have options", p.Type))
+	}
+	if p.Unit != nil {
+		if p.Type != PropertyTypeNumber {
+			return newErrInvalidCardProperty(fmt.Sprintf("property type %q cannot have a unit", p.Type))
+		}
+		if err := p.Unit.IsValid(); err != nil {
+			return err
+		}
+	}
+	if p.Type == PropertyTypeProgress {
+		if p.Progress == nil {
+			return newErrInvalidCardProperty("progress properties require a progress source")
+		}
+		if err := p.Progress.IsValid(); err != nil {
+			return err
+		}
+	} else if p.Progress != nil {
+		return newErrInvalidCardProperty(fmt.Sprintf("property type %q cannot have a progress source", p.Type))
+	}
+
+	optionIDs := map[string]bool{}
+	for i := range p.Options {
+		if err := p.Options[i].IsValid(); err != nil {
+			return err
+		}
+		if optionIDs[p.Options[i].ID] {
+			return newErrInvalidCardProperty(fmt.Sprintf("duplicated option ID %q", p.Options[i].ID))
+		}
+		optionIDs[p.Options[i].ID] = true
+	}
+	if p.Required && computedPropertyTypes[p.Type] {
+		return newErrInvalidCardProperty(fmt.Sprintf("property type %q cannot be required", p.Type))
+	}
+	if p.Assignee && p.Type != PropertyTypePerson {
+		return newErrInvalidCardProperty("only person properties can assign cards")
+	}
+	return p.validateDefaultValue()
+}
+
+// FindOption returns the index of the option with the given id, or -1.
+func (p *CardProperty) FindOption(optionID string) int {
+	for i := range p.Options {
+		if p.Options[i].ID == optionID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Patch returns an updated version of the property.
+func (pp *CardPropertyPatch) Patch(property *CardProperty) *CardProperty {
+	if pp.Name != nil {
+		property.Name = *pp.Name
+	}
+
+	if pp.Type != nil && *pp.Type != property.Type {
+		property.Type = *pp.Type
+		if !PropertyTypeHasOptions(property.Type) {
+			property.Options = []CardPropertyOption{}
+		}
+		if property.Type != PropertyTypeNumber {
+			property.Unit = nil
+		}
+		if property.Type != PropertyTypeProgress {
+			property.Progress = nil
+		}
+		property.DefaultValue = nil
+	}
+
+	if pp.Unit != nil {
+		unit := *pp.Unit
+		unit.Hydrate()
+		property.Unit = &unit
+	}
+
+	if pp.Progress != nil {
+		progress := *pp.Progress
+		property.Progress = &progress
+	}
+
+	if pp.DeleteDefaultValue {
+		property.DefaultValue = nil
+	} else if pp.DefaultValue != nil {
+		property.DefaultValue = pp.DefaultValue
+	}
+
+	if pp.Required != nil {
+		property.Required = *pp.Required
+	}
+
+	if pp.Locked != nil {
+		property.Locked = *pp.Locked
+	}
+	if pp.Assignee != nil {
+		property.Assignee = *pp.Assignee
+	}
+
+	return property
+}
+
+// Patch returns an updated version of the option.
+func (op *CardPropertyOptionPatch) Patch(option *CardPropertyOption) *CardPropertyOption {
+	if op.Value != nil {
+		option.Value = *op.Value
+	}
+
+	if op.Color != nil {
+		option.Color = *op.Color
+	}
+
+	if op.Icon != nil {
+		option.Icon = *op.Icon
+	}
+
+	return option
+}
+
+// ValidateCardProperties checks a whole property schema, property by
+// property, and ensures that no property id is repeated and that
+// references between properties are valid.
+func ValidateCardProperties(properties []CardProperty) error {
+	ids := map[string]bool{}
+	for i := range properties {
+		if err := properties[i].IsValid(); err != nil {
+			return err
+		}
+		if ids[properties[i].ID] {
+			return newErrInvalidCardProperty(fmt.Sprintf("duplicated property ID %q", properties[i].ID))
+		}
+		ids[properties[i].ID] = true
+	}
+	return validateProgressReferences(properties)
+}
+
+// CardPropertiesFromBoard converts the untyped card properties of a
+// board into their typed representation.
+func CardPropertiesFromBoard(board *Board) ([]CardProperty, error) {
+	properties := []CardProperty{}
+	if len(board.CardProperties) == 0 {
+		return properties, nil
+	}
+
+	data, err := json.Marshal(board.CardProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &properties); err != nil {
+		return nil, fmt.Errorf("cannot parse card properties for board %s: %w", board.ID, ErrInvalidPropSchema)
+	}
+
+	for i := range properties {
+		if properties[i].Options == nil {
+			properties[i].Options = []CardPropertyOption{}
+		}
+	}
+
+	return properties, nil
+}
+
+// CardPropertiesToMaps converts a typed property schema into the
+// representation stored in the board's CardProperties.
+func CardPropertiesToMaps(properties []CardProperty) []map[string]interface{} {
+	maps := make([]map[string]interface{}, 0, len(properties))
+	for _, property := range properties {
+		maps = append(maps, utils.StructToMap(property))
+	}
+	return maps
+}
+
+// FindCardProperty returns the index of the property with the given
+// id in the schema, or -1.
+func FindCardProperty(properties []CardProperty, propertyID string) int {
+	for i := range properties {
+		if properties[i].ID == propertyID {
+			return i
+		}
+	}
+	return -1
+}
+
+// ReorderCardProperties returns the properties sorted following the
+// given list of ids, which must contain every property exactly once.
+func ReorderCardProperties(properties []CardProperty, propertyIDs []string) ([]CardProperty, error) {
+	if len(propertyIDs) != len(properties) {
+		return nil, newErrInvalidCardProperty("property order must contain every property of the board")
+	}
+
+	reordered := make([]CardProperty, 0, len(properties))
+	seen := map[string]bool{}
+	for _, propertyID := range propertyIDs {
+		if seen[propertyID] {
+			return nil, newErrInvalidCardProperty(fmt.Sprintf("duplicated property ID %q in property order", propertyID))
+		}
+		seen[propertyID] = true
+
+		idx := FindCardProperty(properties, propertyID)
+		if idx == -1 {
+			return nil, newErrInvalidCardProperty(fmt.Sprintf("unknown property ID %q in property order", propertyID))
+		}
+		reordered = append(reordered, properties[idx])
+	}
+	return reordered, nil
+}
+
+// CardPropertyOptionUsage holds the usage statistics of a select or
+// multiSelect option across the cards of a board
+// swagger:model
+type CardPropertyOptionUsage struct {
+	// The id of the property that holds the option
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The id of the option
+	// required: true
+	OptionID string `json:"optionId"`
+
+	// The display value of the option
+	// required: true
+	Value string `json:"value"`
+
+	// The color of the option
+	// required: true
+	Color string `json:"color"`
+
+	// The icon of the option
+	// required: false
+	Icon string `json:"icon,omitempty"`
+
+	// The number of cards that have the option set
+	// required: true
+	CardCount int `json:"cardCount"`
+
+	// The last time the option was set on a card, in miliseconds since the current epoch.
+	// Zero if the option was never used
+	// required: true
+	LastUsedAt int64 `json:"lastUsedAt"`
+}
+
+// CardPropertyValues returns the property values of a card keyed by
+// property id, or nil if the card has no properties.
+func CardPropertyValues(card *Block) map[string]interface{} {
+	values, _ := card.Fields["properties"].(map[string]interface{})
+	return values
+}
+
+// ValidateCardPropertyValues checks the values a card holds against the
+// property schema of its board. Values for unknown properties are ignored.
+func ValidateCardPropertyValues(properties []CardProperty, card *Block) error {
+	values := CardPropertyValues(card)
+	if values == nil {
+		return nil
+	}
+
+	for i := range properties {
+		value, ok := values[properties[i].ID]
+		if !ok || value == nil {
+			continue
+		}
+
+		switch {
+		case properties[i].Type == PropertyTypeLocation:
+			if _, err := ParseCardLocation(value); err != nil {
+				return newErrInvalidCardProperty(fmt.Sprintf("invalid value for property %q: %s", properties[i].Name, err))
+			}
+		case properties[i].Type == PropertyTypeNumber && properties[i].Unit != nil:
+			if _, _, err := ParseCardNumber(value); err != nil {
+				return newErrInvalidCardProperty(fmt.Sprintf("invalid value for property %q: %s", properties[i].Name, err))
+			}
+		}
+	}
+	return nil
+}
+
+// CardPropertyOptionIDs returns the option ids set on a card for the
+// given property, or nil if the property is not an option property.
+func CardPropertyOptionIDs(card *Block, property *CardProperty) []string {
+	if !PropertyTypeHasOptions(property.Type) {
+		return nil
+	}
+
+	switch v := CardPropertyValues(card)[property.ID].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		ids := make([]string, 0, len(v))
+		for _, id := range v {
+			if s, ok := id.(string); ok {
+				ids = append(ids, s)
+			}
+		}
+		return ids
+	case []string:
+		return v
+	}
+	return nil
+}
+
+// ComputeCardPropertyOptionUsage counts, for every option of the select
+// and multiSelect properties, how many of the given cards use it and
+// when it was last set on a card. The history holds the versions of the
+// cards of the board in insertion order. Options are returned in schema
+// order.
+func ComputeCardPropertyOptionUsage(properties []CardProperty, cards []Block, history []Block) []CardPropertyOptionUsage {
+	usage := []CardPropertyOptionUsage{}
+	index := map[string]int{}
+	for i := range properties {
+		for _, option := range properties[i].Options {
+			index[properties[i].ID+"/"+option.ID] = len(usage)
+			usage = append(usage, CardPropertyOptionUsage{
+				PropertyID: properties[i].ID,
+				OptionID:   option.ID,
+				Value:      option.Value,
+				Color:      option.Color,
+				Icon:       option.Icon,
+			})
+		}
+	}
+
+	optionIndexes := func(card *Block) map[int]bool {
+		indexes := map[int]bool{}
+		for j := range properties {
+			for _, optionID := range CardPropertyOptionIDs(card, &properties[j]) {
+				if idx, ok := index[properties[j].ID+"/"+optionID]; ok {
+					indexes[idx] = true
+				}
+			}
+		}
+		return indexes
+	}
+
+	for i := range cards {
+		if cards[i].Type != TypeCard {
+			continue
+		}
+		for idx := range optionIndexes(&cards[i]) {
+			usage[idx].CardCount++
+		}
+	}
+
+	// an option is used when a version of a card sets it while the
+	// previous one didn't
+	previous := map[string]map[int]bool{}
+	for i := range history {
+		if history[i].Type != TypeCard {
+			continue
+		}
+		current := map[int]bool{}
+		if history[i].DeleteAt == 0 {
+			current = optionIndexes(&history[i])
+		}
+		for idx := range current {
+			if !previous[history[i].ID][idx] && history[i].UpdateAt > usage[idx].LastUsedAt {
+				usage[idx].LastUsedAt = history[i].UpdateAt
+			}
+		}
+		previous[history[i].ID] = current
+	}
+	return usage
+}
+
+// ChangedLockedCardProperties returns the locked properties whose value
+// on the card would be changed by the patch.
+func ChangedLockedCardProperties(properties []CardProperty, card *Block, patch *BlockPatch) []CardProperty {
+	patched := *card
+	patched.Fields = make(map[string]interface{}, len(card.Fields))
+	for key, value := range card.Fields {
+		patched.Fields[key] = value
+	}
+	patch.Patch(&patched)
+
+	oldValues := CardPropertyValues(card)
+	newValues := CardPropertyValues(&patched)
+
+	changed := []CardProperty{}
+	for i := range properties {
+		if !properties[i].Locked {
+			continue
+		}
+		if !reflect.DeepEqual(oldValues[properties[i].ID], newValues[properties[i].ID]) {
+			changed = append(changed, properties[i])
+		}
+	}
+	return changed
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// PropertyDefaultCurrentUser is replaced by the id of the user that
+	// creates the card, only for person properties.
+	PropertyDefaultCurrentUser = "$currentUser"
+
+	// PropertyDefaultToday is replaced by the creation date of the card,
+	// only for date properties.
+	PropertyDefaultToday = "$today"
+)
+
+// computedPropertyTypes are the property types whose values are set by
+// the server and can't have a default.
+var computedPropertyTypes = map[string]bool{
+	PropertyTypeCreatedTime: true,
+	PropertyTypeCreatedBy:   true,
+	PropertyTypeUpdatedTime: true,
+	PropertyTypeUpdatedBy:   true,
+	PropertyTypeProgress:    true,
+}
+
+// validateDefaultValue checks that the default value of a property
+// matches its type.
+func (p *CardProperty) validateDefaultValue() error {
+	if p.DefaultValue == nil {
+		return nil
+	}
+
+	if computedPropertyTypes[p.Type] {
+		return newErrInvalidCardProperty(fmt.Sprintf("property type %q cannot have a default value", p.Type))
+	}
+
+	invalid := newErrInvalidCardProperty(fmt.Sprintf("invalid default value for property %q", p.Name))
+	switch p.Type {
+	case PropertyTypeSelect:
+		optionID, ok := p.DefaultValue.(string)
+		if !ok || p.FindOption(optionID) == -1 {
+			return invalid
+		}
+	case PropertyTypeMultiSelect:
+		optionIDs, ok := p.DefaultValue.([]interface{})
+		if !ok {
+			return invalid
+		}
+		for _, id := range optionIDs {
+			optionID, ok := id.(string)
+			if !ok || p.FindOption(optionID) == -1 {
+				return invalid
+			}
+		}
+	case PropertyTypeLocation:
+		if _, err := ParseCardLocation(p.DefaultValue); err != nil {
+			return invalid
+		}
+	case PropertyTypeNumber:
+		if _, _, err := ParseCardNumber(p.DefaultValue); err != nil {
+			return invalid
+		}
+	default:
+		value, ok := p.DefaultValue.(string)
+		if !ok {
+			return invalid
+		}
+		if value == PropertyDefaultCurrentUser && p.Type != PropertyTypePerson {
+			return newErrInvalidCardProperty(fmt.Sprintf("%s can only be the default of person properties", PropertyDefaultCurrentUser))
+		}
+		if value == PropertyDefaultToday && p.Type != PropertyTypeDate {
+			return newErrInvalidCardProperty(fmt.Sprintf("%s can only be the default of date properties", PropertyDefaultToday))
+		}
+	}
+	return nil
+}
+
+// resolveDefaultValue returns the value that a new card gets for the
+// property, replacing the tokens with their actual value.
+func (p *CardProperty) resolveDefaultValue(userID string, now time.Time) interface{} {
+	switch p.DefaultValue {
+	case PropertyDefaultCurrentUser:
+		return userID
+	case PropertyDefaultToday:
+		// the clients store dates at noon UTC so they show on the same
+		// day in every timezone
+		today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.UTC)
+		date, _ := json.Marshal(map[string]int64{"from": utils.GetMillisForTime(today)})
+		return string(date)
+	}
+	return p.DefaultValue
+}
+
+// ApplyCardPropertyDefaults sets the default value of every property that
+// a new card doesn't have a value for. Card templates are left untouched
+// so that tokens are resolved when cards are created from them.
+func ApplyCardPropertyDefaults(properties []CardProperty, card *Block, userID string, now time.Time) {
+	if card.Type != TypeCard || IsCardTemplate(card) {
+		return
+	}
+
+	var values map[string]interface{}
+	for i := range properties {
+		if properties[i].DefaultValue == nil {
+			continue
+		}
+
+		if values == nil {
+			values = make(map[string]interface{})
+			for key, value := range CardPropertyValues(card) {
+				values[key] = value
+			}
+		}
+
+		if _, ok := values[properties[i].ID]; ok {
+			continue
+		}
+		values[properties[i].ID] = properties[i].resolveDefaultValue(userID, now)
+	}
+
+	if values != nil {
+		if card.Fields == nil {
+			card.Fields = make(map[string]interface{})
+		}
+		card.Fields["properties"] = values
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardPropertyDefaultValueIsValid(t *testing.T) {
+	status := CardProperty{
+		ID:      "status",
+		Name:    "Status",
+		Type:    PropertyTypeSelect,
+		Options: []CardPropertyOption{{ID: "new", Value: "New", Color: PropertyColorDefault}},
+	}
+
+	status.DefaultValue = "new"
+	require.NoError(t, status.IsValid())
+
+	status.DefaultValue = "unknown"
+	require.Error(t, status.IsValid())
+
+	owner := CardProperty{ID: "owner", Name: "Owner", Type: PropertyTypePerson, DefaultValue: PropertyDefaultCurrentUser}
+	require.NoError(t, owner.IsValid())
+
+	notes := CardProperty{ID: "notes", Name: "Notes", Type: PropertyTypeText, DefaultValue: PropertyDefaultToday}
+	require.Error(t, notes.IsValid())
+
+	created := CardProperty{ID: "created", Name: "Created", Type: PropertyTypeCreatedTime, DefaultValue: "1"}
+	require.Error(t, created.IsValid())
+}
+
+func TestApplyCardPropertyDefaults(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson, DefaultValue: PropertyDefaultCurrentUser},
+		{ID: "due", Name: "Due", Type: PropertyTypeDate, DefaultValue: PropertyDefaultToday},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText, DefaultValue: "none"},
+		{ID: "estimate", Name: "Estimate", Type: PropertyTypeNumber},
+	}
+	now := time.Date(2022, time.May, 26, 8, 30, 0, 0, time.UTC)
+
+	t.Run("new card", func(t *testing.T) {
+		card := &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"notes": "already set",
+		}}}
+		ApplyCardPropertyDefaults(properties, card, "user-id", now)
+
+		values := CardPropertyValues(card)
+		require.Equal(t, "user-id", values["owner"])
+		require.Equal(t, `{"from":1653566400000}`, values["due"])
+		require.Equal(t, "already set", values["notes"])
+		require.NotContains(t, values, "estimate")
+	})
+
+	t.Run("card without fields", func(t *testing.T) {
+		card := &Block{Type: TypeCard}
+		ApplyCardPropertyDefaults(properties, card, "user-id", now)
+		require.Equal(t, "none", CardPropertyValues(card)["notes"])
+	})
+
+	t.Run("templates and other blocks are not changed", func(t *testing.T) {
+		template := &Block{Type: TypeCard, Fields: map[string]interface{}{"isTemplate": true}}
+		ApplyCardPropertyDefaults(properties, template, "user-id", now)
+		require.Nil(t, CardPropertyValues(template))
+
+		text := &Block{Type: TypeText, Fields: map[string]interface{}{}}
+		ApplyCardPropertyDefaults(properties, text, "user-id", now)
+		require.Nil(t, CardPropertyValues(text))
+	})
+}
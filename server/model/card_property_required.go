@@ -0,0 +1,151 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BoardPropertyRequiredEnforcement is the key of the board property that
+// holds how required card properties are enforced.
+const BoardPropertyRequiredEnforcement = "requiredPropertiesEnforcement"
+
+const (
+	// RequiredEnforcementNone only flags the required properties, the
+	// server doesn't reject cards missing them.
+	RequiredEnforcementNone = ""
+
+	// RequiredEnforcementCreate rejects the creation of cards missing
+	// required properties.
+	RequiredEnforcementCreate = "create"
+
+	// RequiredEnforcementTransition rejects both the creation of cards
+	// and the changes of select values (e.g. status transitions) of cards
+	// missing required properties.
+	RequiredEnforcementTransition = "transition"
+)
+
+// IsValidRequiredEnforcement returns true if the enforcement mode is known to the server.
+func IsValidRequiredEnforcement(mode interface{}) bool {
+	switch mode {
+	case nil, RequiredEnforcementNone, RequiredEnforcementCreate, RequiredEnforcementTransition:
+		return true
+	}
+	return false
+}
+
+// GetRequiredEnforcement returns the enforcement mode of the required
+// properties of a board.
+func GetRequiredEnforcement(board *Board) string {
+	mode, _ := board.Properties[BoardPropertyRequiredEnforcement].(string)
+	return mode
+}
+
+// MissingCardProperty identifies a required property that a card doesn't have a value for
+// swagger:model
+type MissingCardProperty struct {
+	// The id of the property
+	// required: true
+	ID string `json:"id"`
+
+	// The name of the property
+	// required: true
+	Name string `json:"name"`
+}
+
+// ErrMissingRequiredProperties is returned when a card is missing values
+// for required properties.
+type ErrMissingRequiredProperties struct {
+	CardID  string
+	Missing []MissingCardProperty
+}
+
+func NewErrMissingRequiredProperties(cardID string, missing []MissingCardProperty) *ErrMissingRequiredProperties {
+	return &ErrMissingRequiredProperties{
+		CardID:  cardID,
+		Missing: missing,
+	}
+}
+
+func (e *ErrMissingRequiredProperties) Error() string {
+	names := make([]string, 0, len(e.Missing))
+	for _, property := range e.Missing {
+		names = append(names, property.Name)
+	}
+	return fmt.Sprintf("card %s is missing required properties: %s", e.CardID, strings.Join(names, ", "))
+}
+
+// MissingRequiredPropertiesResponse is the error response returned when cards are missing required properties
+// swagger:model
+type MissingRequiredPropertiesResponse struct {
+	// The error message
+	// required: false
+	Error string `json:"error"`
+
+	// The error code
+	// required: false
+	ErrorCode int `json:"errorCode"`
+
+	// The id of the card missing values
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The required properties without a value
+	// required: true
+	MissingProperties []MissingCardProperty `json:"missingProperties"`
+}
+
+// isEmptyCardPropertyValue returns true if a raw property value counts as not set.
+func isEmptyCardPropertyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// MissingRequiredCardProperties returns the required properties that the
+// card doesn't have a value for.
+func MissingRequiredCardProperties(properties []CardProperty, card *Block) []MissingCardProperty {
+	values := CardPropertyValues(card)
+	missing := []MissingCardProperty{}
+	for i := range properties {
+		if !properties[i].Required {
+			continue
+		}
+		if isEmptyCardPropertyValue(values[properties[i].ID]) {
+			missing = append(missing, MissingCardProperty{ID: properties[i].ID, Name: properties[i].Name})
+		}
+	}
+	return missing
+}
+
+// CardOptionValuesChanged returns true if any select or multiSelect value
+// differs between the two versions of a card.
+func CardOptionValuesChanged(properties []CardProperty, oldCard, newCard *Block) bool {
+	for i := range properties {
+		if !PropertyTypeHasOptions(properties[i].Type) {
+			continue
+		}
+
+		oldIDs := CardPropertyOptionIDs(oldCard, &properties[i])
+		newIDs := CardPropertyOptionIDs(newCard, &properties[i])
+		if len(oldIDs) != len(newIDs) {
+			return true
+		}
+		for j := range oldIDs {
+			if oldIDs[j] != newIDs[j] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissingRequiredCardProperties(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson, Required: true},
+		{ID: "labels", Name: "Labels", Type: PropertyTypeMultiSelect, Required: true},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+
+	card := &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+		"owner":  " ",
+		"labels": []interface{}{},
+	}}}
+	missing := MissingRequiredCardProperties(properties, card)
+	require.Equal(t, []MissingCardProperty{{ID: "owner", Name: "Owner"}, {ID: "labels", Name: "Labels"}}, missing)
+
+	card.Fields["properties"] = map[string]interface{}{
+		"owner":  "user-id",
+		"labels": []interface{}{"bug"},
+	}
+	require.Empty(t, MissingRequiredCardProperties(properties, card))
+
+	err := NewErrMissingRequiredProperties("card-id", []MissingCardProperty{{ID: "owner", Name: "Owner"}})
+	require.Equal(t, "card card-id is missing required properties: Owner", err.Error())
+}
+
+func TestCardOptionValuesChanged(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "status", Name: "Status", Type: PropertyTypeSelect},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+	newCard := func(status, notes string) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"status": status,
+			"notes":  notes,
+		}}}
+	}
+
+	require.False(t, CardOptionValuesChanged(properties, newCard("todo", "a"), newCard("todo", "b")))
+	require.True(t, CardOptionValuesChanged(properties, newCard("todo", "a"), newCard("done", "a")))
+}
+
+func TestRequiredEnforcementValidation(t *testing.T) {
+	patch := &BoardPatch{UpdatedProperties: map[string]interface{}{BoardPropertyRequiredEnforcement: RequiredEnforcementTransition}}
+	require.NoError(t, patch.IsValid())
+
+	patch.UpdatedProperties[BoardPropertyRequiredEnforcement] = "sometimes"
+	require.Error(t, patch.IsValid())
+
+	board := &Board{Properties: map[string]interface{}{BoardPropertyRequiredEnforcement: RequiredEnforcementCreate}}
+	require.Equal(t, RequiredEnforcementCreate, GetRequiredEnforcement(board))
+
+	property := &CardProperty{ID: "created", Name: "Created", Type: PropertyTypeCreatedTime, Required: true}
+	require.Error(t, property.IsValid())
+}
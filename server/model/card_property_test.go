@@ -0,0 +1,318 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardPropertyIsValid(t *testing.T) {
+	t.Run("valid select property", func(t *testing.T) {
+		property := &CardProperty{
+			Name: "Status",
+			Type: PropertyTypeSelect,
+			Options: []CardPropertyOption{
+				{Value: "Done", Color: PropertyColorGreen},
+				{Value: "To do"},
+			},
+		}
+		property.Hydrate()
+
+		require.NoError(t, property.IsValid())
+		require.NotEmpty(t, property.ID)
+		require.Equal(t, PropertyColorDefault, property.Options[1].Color)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		property := &CardProperty{ID: "p1", Name: "Status", Type: "unknown"}
+		var errInvalid *ErrInvalidCardProperty
+		require.ErrorAs(t, property.IsValid(), &errInvalid)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		property := &CardProperty{ID: "p1", Name: " ", Type: PropertyTypeText}
+		require.Error(t, property.IsValid())
+	})
+
+	t.Run("options on a type without options", func(t *testing.T) {
+		property := &CardProperty{
+			ID:      "p1",
+			Name:    "Estimate",
+			Type:    PropertyTypeNumber,
+			Options: []CardPropertyOption{{ID: "o1", Value: "1", Color: PropertyColorDefault}},
+		}
+		require.Error(t, property.IsValid())
+	})
+
+	t.Run("invalid option color", func(t *testing.T) {
+		property := &CardProperty{
+			ID:      "p1",
+			Name:    "Status",
+			Type:    PropertyTypeSelect,
+			Options: []CardPropertyOption{{ID: "o1", Value: "Done", Color: "#ff0000"}},
+		}
+		require.Error(t, property.IsValid())
+	})
+
+	t.Run("invalid option icon", func(t *testing.T) {
+		property := &CardProperty{
+			ID:      "p1",
+			Name:    "Status",
+			Type:    PropertyTypeSelect,
+			Options: []CardPropertyOption{{ID: "o1", Value: "Done", Color: PropertyColorGreen, Icon: "<script>"}},
+		}
+		require.Error(t, property.IsValid())
+
+		property.Options[0].Icon = "check-circle"
+		require.NoError(t, property.IsValid())
+	})
+
+	t.Run("duplicated option ids", func(t *testing.T) {
+		property := &CardProperty{
+			ID:   "p1",
+			Name: "Status",
+			Type: PropertyTypeSelect,
+			Options: []CardPropertyOption{
+				{ID: "o1", Value: "Done", Color: PropertyColorDefault},
+				{ID: "o1", Value: "To do", Color: PropertyColorDefault},
+			},
+		}
+		require.Error(t, property.IsValid())
+	})
+}
+
+func TestIsValidPropertyOptionIcon(t *testing.T) {
+	testCases := []struct {
+		icon  string
+		valid bool
+	}{
+		{"", true},
+		{"check-circle", true},
+		{"flag2", true},
+		{"\U0001F680", true},
+		{"\U0001F44D\U0001F3FD", true},
+		{"\U0001F469\u200d\U0001F4BB", true},
+		{"Check", false},
+		{"check circle", false},
+		{"-check", false},
+		{"\U0001F680 go", false},
+		{"été", false},
+		{"éé", false},
+		{strings.Repeat("a", maxOptionIconNameLength+1), false},
+		{strings.Repeat("\U0001F680", maxOptionIconEmojiLength+1), false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.valid, IsValidPropertyOptionIcon(tc.icon), "icon %q", tc.icon)
+	}
+}
+
+func TestCardPropertyOptionPatch(t *testing.T) {
+	option := &CardPropertyOption{ID: "o1", Value: "Done", Color: PropertyColorGreen}
+
+	icon := "check-circle"
+	patched := (&CardPropertyOptionPatch{Icon: &icon}).Patch(option)
+	require.Equal(t, "check-circle", patched.Icon)
+	require.Equal(t, PropertyColorGreen, patched.Color)
+
+	empty := ""
+	patched = (&CardPropertyOptionPatch{Icon: &empty}).Patch(option)
+	require.Empty(t, patched.Icon)
+}
+
+func TestValidateCardProperties(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "p1", Name: "Notes", Type: PropertyTypeText},
+		{ID: "p1", Name: "Other notes", Type: PropertyTypeText},
+	}
+	require.Error(t, ValidateCardProperties(properties))
+
+	properties[1].ID = "p2"
+	require.NoError(t, ValidateCardProperties(properties))
+}
+
+func TestCardPropertyPatch(t *testing.T) {
+	property := &CardProperty{
+		ID:      "p1",
+		Name:    "Status",
+		Type:    PropertyTypeSelect,
+		Options: []CardPropertyOption{{ID: "o1", Value: "Done", Color: PropertyColorDefault}},
+	}
+
+	name := "State"
+	patched := (&CardPropertyPatch{Name: &name}).Patch(property)
+	require.Equal(t, "State", patched.Name)
+	require.Len(t, patched.Options, 1)
+
+	multiSelect := PropertyTypeMultiSelect
+	patched = (&CardPropertyPatch{Type: &multiSelect}).Patch(property)
+	require.Len(t, patched.Options, 1)
+
+	text := PropertyTypeText
+	patched = (&CardPropertyPatch{Type: &text}).Patch(property)
+	require.Empty(t, patched.Options)
+	require.NoError(t, patched.IsValid())
+}
+
+func TestCardPropertiesFromBoard(t *testing.T) {
+	board := &Board{
+		ID: "board-id",
+		CardProperties: []map[string]interface{}{
+			{
+				"id":   "p1",
+				"name": "Status",
+				"type": PropertyTypeSelect,
+				"options": []interface{}{
+					map[string]interface{}{"id": "o1", "value": "Done", "color": PropertyColorGreen},
+				},
+			},
+			{"id": "p2", "name": "Notes", "type": PropertyTypeText},
+		},
+	}
+
+	properties, err := CardPropertiesFromBoard(board)
+	require.NoError(t, err)
+	require.Len(t, properties, 2)
+	require.Equal(t, "Done", properties[0].Options[0].Value)
+	require.NotNil(t, properties[1].Options)
+
+	maps := CardPropertiesToMaps(properties)
+	require.Len(t, maps, 2)
+	require.Equal(t, "p1", maps[0]["id"])
+
+	board.CardProperties = []map[string]interface{}{{"id": 1}}
+	_, err = CardPropertiesFromBoard(board)
+	require.ErrorIs(t, err, ErrInvalidPropSchema)
+}
+
+func TestReorderCardProperties(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "p1", Name: "One", Type: PropertyTypeText},
+		{ID: "p2", Name: "Two", Type: PropertyTypeText},
+		{ID: "p3", Name: "Three", Type: PropertyTypeText},
+	}
+
+	reordered, err := ReorderCardProperties(properties, []string{"p3", "p1", "p2"})
+	require.NoError(t, err)
+	require.Equal(t, "p3", reordered[0].ID)
+	require.Equal(t, "p1", reordered[1].ID)
+	require.Equal(t, "p2", reordered[2].ID)
+
+	_, err = ReorderCardProperties(properties, []string{"p3", "p1"})
+	require.Error(t, err)
+
+	_, err = ReorderCardProperties(properties, []string{"p3", "p1", "p1"})
+	require.Error(t, err)
+
+	_, err = ReorderCardProperties(properties, []string{"p3", "p1", "p4"})
+	require.Error(t, err)
+}
+
+func TestComputeCardPropertyOptionUsage(t *testing.T) {
+	properties := []CardProperty{
+		{
+			ID:   "status",
+			Name: "Status",
+			Type: PropertyTypeSelect,
+			Options: []CardPropertyOption{
+				{ID: "done", Value: "Done", Color: PropertyColorGreen},
+				{ID: "todo", Value: "To do", Color: PropertyColorDefault},
+			},
+		},
+		{
+			ID:   "labels",
+			Name: "Labels",
+			Type: PropertyTypeMultiSelect,
+			Options: []CardPropertyOption{
+				{ID: "bug", Value: "Bug", Color: PropertyColorRed},
+			},
+		},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+
+	card := func(id string, updateAt int64, values map[string]interface{}) Block {
+		return Block{
+			ID:       id,
+			Type:     TypeCard,
+			UpdateAt: updateAt,
+			Fields:   map[string]interface{}{"properties": values},
+		}
+	}
+
+	cards := []Block{
+		card("card-1", 400, map[string]interface{}{
+			"status": "done",
+			"labels": []interface{}{"bug", "unknown"},
+		}),
+		card("card-2", 200, map[string]interface{}{
+			"status": "done",
+			"notes":  "done",
+		}),
+		{
+			ID:       "text",
+			Type:     TypeText,
+			UpdateAt: 300,
+			Fields: map[string]interface{}{"properties": map[string]interface{}{
+				"status": "todo",
+			}},
+		},
+	}
+
+	history := []Block{
+		card("card-1", 100, map[string]interface{}{"labels": []interface{}{"bug"}}),
+		card("card-2", 150, map[string]interface{}{"status": "todo"}),
+		card("card-2", 200, map[string]interface{}{"status": "done", "notes": "done"}),
+		card("card-1", 300, map[string]interface{}{"status": "done", "labels": []interface{}{"bug"}}),
+		// editing the card again doesn't use the options it already has
+		card("card-1", 400, map[string]interface{}{"status": "done", "labels": []interface{}{"bug", "unknown"}}),
+		cards[2],
+	}
+
+	usage := ComputeCardPropertyOptionUsage(properties, cards, history)
+	require.Len(t, usage, 3)
+
+	require.Equal(t, "done", usage[0].OptionID)
+	require.Equal(t, 2, usage[0].CardCount)
+	require.EqualValues(t, 300, usage[0].LastUsedAt)
+
+	require.Equal(t, "todo", usage[1].OptionID)
+	require.Zero(t, usage[1].CardCount)
+	require.EqualValues(t, 150, usage[1].LastUsedAt)
+
+	require.Equal(t, "bug", usage[2].OptionID)
+	require.Equal(t, 1, usage[2].CardCount)
+	require.EqualValues(t, 100, usage[2].LastUsedAt)
+}
+
+func TestChangedLockedCardProperties(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "estimate", Name: "Estimate", Type: PropertyTypeNumber, Locked: true},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{"properties": map[string]interface{}{
+			"estimate": "3",
+			"notes":    "first",
+		}},
+	}
+
+	t.Run("unlocked change", func(t *testing.T) {
+		patch := &BlockPatch{UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{
+			"estimate": "3",
+			"notes":    "second",
+		}}}
+		require.Empty(t, ChangedLockedCardProperties(properties, card, patch))
+	})
+
+	t.Run("locked change", func(t *testing.T) {
+		patch := &BlockPatch{UpdatedFields: map[string]interface{}{"properties": map[string]interface{}{
+			"notes": "first",
+		}}}
+		changed := ChangedLockedCardProperties(properties, card, patch)
+		require.Len(t, changed, 1)
+		require.Equal(t, "estimate", changed[0].ID)
+		require.Equal(t, "3", CardPropertyValues(card)["estimate"])
+	})
+}
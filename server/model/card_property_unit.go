@@ -0,0 +1,224 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	PropertyUnitKindCurrency = "currency"
+	PropertyUnitKindUnit     = "unit"
+
+	defaultCurrencyDecimals = 2
+	maxUnitDecimals         = 8
+	maxUnitCodeLength       = 16
+)
+
+var ErrInvalidNumber = errors.New("invalid number")
+
+var currencyCodeRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// CardPropertyUnit describes the unit or currency of the values of a
+// number property
+// swagger:model
+type CardPropertyUnit struct {
+	// The kind of unit, either currency or unit
+	// required: true
+	Kind string `json:"kind"`
+
+	// The ISO 4217 currency code for currencies (e.g. USD), or the unit symbol (e.g. kg)
+	// required: true
+	Code string `json:"code"`
+
+	// The minimum number of decimals used when displaying totals
+	// required: false
+	Decimals int `json:"decimals"`
+}
+
+// Hydrate fills the default values of a unit.
+func (u *CardPropertyUnit) Hydrate() {
+	if u.Kind == PropertyUnitKindCurrency {
+		u.Code = strings.ToUpper(u.Code)
+		if u.Decimals == 0 {
+			u.Decimals = defaultCurrencyDecimals
+		}
+	}
+}
+
+// IsValid checks the unit kind, code and decimals.
+func (u *CardPropertyUnit) IsValid() error {
+	switch u.Kind {
+	case PropertyUnitKindCurrency:
+		if !currencyCodeRegexp.MatchString(u.Code) {
+			return newErrInvalidCardProperty(fmt.Sprintf("invalid currency code %q", u.Code))
+		}
+	case PropertyUnitKindUnit:
+		code := strings.TrimSpace(u.Code)
+		if code == "" || len(code) > maxUnitCodeLength {
+			return newErrInvalidCardProperty(fmt.Sprintf("invalid unit code %q", u.Code))
+		}
+	default:
+		return newErrInvalidCardProperty(fmt.Sprintf("invalid unit kind %q", u.Kind))
+	}
+
+	if u.Decimals < 0 || u.Decimals > maxUnitDecimals {
+		return newErrInvalidCardProperty(fmt.Sprintf("unit decimals must be between 0 and %d", maxUnitDecimals))
+	}
+	return nil
+}
+
+// ParseCardNumber parses the raw value of a number property, which the
+// clients store as a string. Values are kept as exact rationals so that
+// totals don't accumulate floating point errors. It returns a nil number
+// for empty values, along with the number of decimals of the value.
+func ParseCardNumber(v interface{}) (*big.Rat, int, error) {
+	var s string
+	switch value := v.(type) {
+	case string:
+		s = strings.TrimSpace(value)
+	case float64:
+		s = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return nil, 0, ErrInvalidNumber
+	}
+
+	if s == "" {
+		return nil, 0, nil
+	}
+
+	// only plain decimal notation is accepted, not fractions, exponents or other bases
+	if strings.ContainsAny(s, "/eExXpP_") {
+		return nil, 0, ErrInvalidNumber
+	}
+
+	n, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, 0, ErrInvalidNumber
+	}
+
+	decimals := 0
+	if idx := strings.Index(s, "."); idx != -1 {
+		decimals = len(s) - idx - 1
+	}
+	return n, decimals, nil
+}
+
+// CardPropertyAggregate is the total of a number property over a group of cards
+// swagger:model
+type CardPropertyAggregate struct {
+	// The option id that the group represents, or empty for the cards without a value or when not grouping
+	// required: true
+	GroupID string `json:"groupId"`
+
+	// The number of cards with a value in the group
+	// required: true
+	Count int `json:"count"`
+
+	// The exact sum of the values in the group, as a decimal string
+	// required: true
+	Sum string `json:"sum"`
+
+	// The unit of the sum
+	// required: false
+	Unit *CardPropertyUnit `json:"unit,omitempty"`
+}
+
+// AggregateCardPropertyNumbers sums the values of a number property over
+// the given cards, optionally grouped by the options of a select or
+// multiSelect property. Groups follow the option order, and the group of
+// cards without an option comes last.
+func AggregateCardPropertyNumbers(properties []CardProperty, cards []Block, propertyID, groupByID string) ([]CardPropertyAggregate, error) {
+	idx := FindCardProperty(properties, propertyID)
+	if idx == -1 {
+		return nil, NewErrNotFound(propertyID)
+	}
+	property := properties[idx]
+	if property.Type != PropertyTypeNumber {
+		return nil, newErrInvalidCardProperty(fmt.Sprintf("property %q is not a number", property.Name))
+	}
+
+	var groupBy *CardProperty
+	if groupByID != "" {
+		groupIdx := FindCardProperty(properties, groupByID)
+		if groupIdx == -1 {
+			return nil, NewErrNotFound(groupByID)
+		}
+		groupBy = &properties[groupIdx]
+		if !PropertyTypeHasOptions(groupBy.Type) {
+			return nil, newErrInvalidCardProperty(fmt.Sprintf("cannot group by property %q", groupBy.Name))
+		}
+	}
+
+	groupIDs := []string{}
+	if groupBy != nil {
+		for _, option := range groupBy.Options {
+			groupIDs = append(groupIDs, option.ID)
+		}
+	}
+	groupIDs = append(groupIDs, "")
+
+	sums := make(map[string]*big.Rat, len(groupIDs))
+	counts := make(map[string]int, len(groupIDs))
+	for _, groupID := range groupIDs {
+		sums[groupID] = new(big.Rat)
+	}
+
+	decimals := 0
+	if property.Unit != nil {
+		decimals = property.Unit.Decimals
+	}
+
+	for i := range cards {
+		if cards[i].Type != TypeCard {
+			continue
+		}
+
+		n, valueDecimals, err := ParseCardNumber(CardPropertyValues(&cards[i])[property.ID])
+		if err != nil || n == nil {
+			// values from before the unit was set may not be numbers
+			continue
+		}
+		if valueDecimals > decimals {
+			decimals = valueDecimals
+		}
+
+		cardGroups := []string{""}
+		if groupBy != nil {
+			if optionIDs := CardPropertyOptionIDs(&cards[i], groupBy); len(optionIDs) != 0 {
+				cardGroups = optionIDs
+			}
+		}
+
+		counted := map[string]bool{}
+		for _, groupID := range cardGroups {
+			if _, ok := sums[groupID]; !ok {
+				// the option was deleted from the schema
+				groupID = ""
+			}
+			if counted[groupID] {
+				continue
+			}
+			counted[groupID] = true
+			sums[groupID].Add(sums[groupID], n)
+			counts[groupID]++
+		}
+	}
+
+	aggregates := make([]CardPropertyAggregate, 0, len(groupIDs))
+	for _, groupID := range groupIDs {
+		aggregates = append(aggregates, CardPropertyAggregate{
+			GroupID: groupID,
+			Count:   counts[groupID],
+			Sum:     sums[groupID].FloatString(decimals),
+			Unit:    property.Unit,
+		})
+	}
+	return aggregates, nil
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardPropertyUnitIsValid(t *testing.T) {
+	unit := &CardPropertyUnit{Kind: PropertyUnitKindCurrency, Code: "eur"}
+	unit.Hydrate()
+	require.NoError(t, unit.IsValid())
+	require.Equal(t, "EUR", unit.Code)
+	require.Equal(t, 2, unit.Decimals)
+
+	require.Error(t, (&CardPropertyUnit{Kind: PropertyUnitKindCurrency, Code: "EURO"}).IsValid())
+	require.Error(t, (&CardPropertyUnit{Kind: PropertyUnitKindUnit, Code: " "}).IsValid())
+	require.Error(t, (&CardPropertyUnit{Kind: "weight", Code: "kg"}).IsValid())
+	require.NoError(t, (&CardPropertyUnit{Kind: PropertyUnitKindUnit, Code: "kg"}).IsValid())
+
+	property := &CardProperty{ID: "p1", Name: "Notes", Type: PropertyTypeText, Unit: &CardPropertyUnit{Kind: PropertyUnitKindUnit, Code: "kg"}}
+	require.Error(t, property.IsValid())
+}
+
+func TestParseCardNumber(t *testing.T) {
+	n, decimals, err := ParseCardNumber("12.50")
+	require.NoError(t, err)
+	require.Equal(t, "12.50", n.FloatString(2))
+	require.Equal(t, 2, decimals)
+
+	n, _, err = ParseCardNumber("")
+	require.NoError(t, err)
+	require.Nil(t, n)
+
+	for _, value := range []interface{}{"1e3", "1/3", "abc", true} {
+		_, _, err = ParseCardNumber(value)
+		require.ErrorIs(t, err, ErrInvalidNumber)
+	}
+}
+
+func TestAggregateCardPropertyNumbers(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "cost", Name: "Cost", Type: PropertyTypeNumber, Unit: &CardPropertyUnit{Kind: PropertyUnitKindCurrency, Code: "USD", Decimals: 2}},
+		{ID: "team", Name: "Team", Type: PropertyTypeSelect, Options: []CardPropertyOption{
+			{ID: "a", Value: "A", Color: PropertyColorDefault},
+			{ID: "b", Value: "B", Color: PropertyColorDefault},
+		}},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+
+	newCard := func(cost, team string) Block {
+		values := map[string]interface{}{"cost": cost}
+		if team != "" {
+			values["team"] = team
+		}
+		return Block{Type: TypeCard, Fields: map[string]interface{}{"properties": values}}
+	}
+	cards := []Block{
+		newCard("0.1", "a"),
+		newCard("0.2", "a"),
+		newCard("10", "b"),
+		newCard("5.125", ""),
+		newCard("not a number", "b"),
+	}
+
+	t.Run("not grouped", func(t *testing.T) {
+		aggregates, err := AggregateCardPropertyNumbers(properties, cards, "cost", "")
+		require.NoError(t, err)
+		require.Len(t, aggregates, 1)
+		require.Equal(t, 4, aggregates[0].Count)
+		require.Equal(t, "15.425", aggregates[0].Sum)
+		require.Equal(t, "USD", aggregates[0].Unit.Code)
+	})
+
+	t.Run("grouped", func(t *testing.T) {
+		aggregates, err := AggregateCardPropertyNumbers(properties, cards, "cost", "team")
+		require.NoError(t, err)
+		require.Len(t, aggregates, 3)
+		require.Equal(t, "a", aggregates[0].GroupID)
+		require.Equal(t, "0.300", aggregates[0].Sum)
+		require.Equal(t, "b", aggregates[1].GroupID)
+		require.Equal(t, 1, aggregates[1].Count)
+		require.Equal(t, "", aggregates[2].GroupID)
+		require.Equal(t, "5.125", aggregates[2].Sum)
+	})
+
+	t.Run("invalid properties", func(t *testing.T) {
+		_, err := AggregateCardPropertyNumbers(properties, cards, "notes", "")
+		require.True(t, IsErrInvalidCardProperty(err))
+
+		_, err = AggregateCardPropertyNumbers(properties, cards, "cost", "notes")
+		require.True(t, IsErrInvalidCardProperty(err))
+
+		_, err = AggregateCardPropertyNumbers(properties, cards, "unknown", "")
+		require.True(t, IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+const maxCardReminderNoteLength = 1000
+
+// CardReminder is a reminder about a card that a user set for themselves.
+// Unlike the due dates, which are shared by everyone on the board,
+// reminders are private to the user.
+// swagger:model
+type CardReminder struct {
+	// The id of the reminder
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the user to remind
+	// required: true
+	UserID string `json:"userId"`
+
+	// The id of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// A note for the user
+	// required: false
+	Note string `json:"note"`
+
+	// The time to remind the user in milliseconds since the current epoch
+	// required: true
+	RemindAt int64 `json:"remindAt"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The time the reminder was delivered in milliseconds since the current epoch, or zero if pending
+	// required: true
+	DeliveredAt int64 `json:"deliveredAt"`
+}
+
+// CardReminderSnooze postpones a reminder
+// swagger:model
+type CardReminderSnooze struct {
+	// The new time to remind the user in milliseconds since the current epoch
+	// required: true
+	RemindAt int64 `json:"remindAt"`
+}
+
+// ErrInvalidCardReminder is returned when a card reminder isn't valid.
+type ErrInvalidCardReminder struct {
+	msg string
+}
+
+func newErrInvalidCardReminder(msg string) *ErrInvalidCardReminder {
+	return &ErrInvalidCardReminder{msg: msg}
+}
+
+// NewErrInvalidCardReminder returns an error for a reminder that can't be
+// set, like one in the past.
+func NewErrInvalidCardReminder(msg string) *ErrInvalidCardReminder {
+	return newErrInvalidCardReminder(msg)
+}
+
+func (e *ErrInvalidCardReminder) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidCardReminder returns true if the error is an ErrInvalidCardReminder.
+func IsErrInvalidCardReminder(err error) bool {
+	var errInvalid *ErrInvalidCardReminder
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks the reminder values.
+func (r *CardReminder) IsValid() error {
+	if r == nil {
+		return newErrInvalidCardReminder("cannot be nil")
+	}
+	if r.UserID == "" {
+		return newErrInvalidCardReminder("missing user id")
+	}
+	if r.BoardID == "" {
+		return newErrInvalidCardReminder("missing board id")
+	}
+	if r.CardID == "" {
+		return newErrInvalidCardReminder("missing card id")
+	}
+	if r.RemindAt <= 0 {
+		return newErrInvalidCardReminder("remind time must be set")
+	}
+	if utf8.RuneCountInString(r.Note) > maxCardReminderNoteLength {
+		return newErrInvalidCardReminder(fmt.Sprintf("note cannot be longer than %d characters", maxCardReminderNoteLength))
+	}
+	return nil
+}
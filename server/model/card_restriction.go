@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// CardFieldRestricted is the card field that restricts the visibility of
+// a card to its assignees and the board admins.
+const CardFieldRestricted = "restricted"
+
+// IsCardRestricted returns true if the block is a card only visible to
+// its assignees and the board admins.
+func IsCardRestricted(card *Block) bool {
+	if card == nil || card.Type != TypeCard {
+		return false
+	}
+	restricted, _ := card.Fields[CardFieldRestricted].(bool)
+	return restricted
+}
+
+// CardAssignees returns the ids of the users set in the person
+// properties of the card.
+func CardAssignees(properties []CardProperty, card *Block) []string {
+	values := CardPropertyValues(card)
+	assignees := []string{}
+	for i := range properties {
+		if properties[i].Type != PropertyTypePerson {
+			continue
+		}
+		if userID, ok := values[properties[i].ID].(string); ok && userID != "" {
+			assignees = append(assignees, userID)
+		}
+	}
+	return assignees
+}
+
+// CanUserSeeCard returns true if the user can see the card, either because
+// it isn't restricted or because the user is one of its assignees or an
+// admin of the board.
+func CanUserSeeCard(properties []CardProperty, card *Block, userID string, isAdmin bool) bool {
+	if !IsCardRestricted(card) || isAdmin {
+		return true
+	}
+	if userID == "" {
+		return false
+	}
+	for _, assignee := range CardAssignees(properties, card) {
+		if assignee == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// RestrictedCardViewers returns the ids of the users that can see a
+// restricted card out of the board members.
+func RestrictedCardViewers(properties []CardProperty, card *Block, members []*BoardMember) []string {
+	viewers := map[string]bool{}
+	for _, member := range members {
+		if member.SchemeAdmin {
+			viewers[member.UserID] = true
+		}
+	}
+	for _, assignee := range CardAssignees(properties, card) {
+		viewers[assignee] = true
+	}
+
+	userIDs := make([]string, 0, len(viewers))
+	for userID := range viewers {
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs
+}
+
+// FilterRestrictedBlocks removes the restricted cards that the user can't
+// see from the list, together with their content blocks and comments.
+// hiddenCardIDs contains the restricted cards of the board that the user
+// can't see, as the cards may not be part of the list.
+func FilterRestrictedBlocks(blocks []Block, hiddenCardIDs map[string]bool) []Block {
+	if len(hiddenCardIDs) == 0 {
+		return blocks
+	}
+
+	filtered := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		if hiddenCardIDs[block.ID] || hiddenCardIDs[block.ParentID] {
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanUserSeeCard(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson},
+		{ID: "notes", Name: "Notes", Type: PropertyTypeText},
+	}
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			CardFieldRestricted: true,
+			"properties":        map[string]interface{}{"owner": "user-1", "notes": "user-2"},
+		},
+	}
+
+	require.True(t, CanUserSeeCard(properties, card, "user-1", false))
+	require.False(t, CanUserSeeCard(properties, card, "user-2", false))
+	require.True(t, CanUserSeeCard(properties, card, "user-2", true))
+	require.False(t, CanUserSeeCard(properties, card, "", false))
+
+	card.Fields[CardFieldRestricted] = false
+	require.True(t, CanUserSeeCard(properties, card, "user-2", false))
+}
+
+func TestRestrictedCardViewers(t *testing.T) {
+	properties := []CardProperty{{ID: "owner", Name: "Owner", Type: PropertyTypePerson}}
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			CardFieldRestricted: true,
+			"properties":        map[string]interface{}{"owner": "user-1"},
+		},
+	}
+	members := []*BoardMember{
+		{UserID: "user-1", SchemeEditor: true},
+		{UserID: "user-2", SchemeEditor: true},
+		{UserID: "user-3", SchemeAdmin: true},
+	}
+
+	require.ElementsMatch(t, []string{"user-1", "user-3"}, RestrictedCardViewers(properties, card, members))
+}
+
+func TestFilterRestrictedBlocks(t *testing.T) {
+	blocks := []Block{
+		{ID: "card-1", Type: TypeCard},
+		{ID: "text-1", ParentID: "card-1", Type: TypeText},
+		{ID: "card-2", Type: TypeCard},
+		{ID: "text-2", ParentID: "card-2", Type: TypeText},
+	}
+
+	require.Len(t, FilterRestrictedBlocks(blocks, nil), 4)
+
+	filtered := FilterRestrictedBlocks(blocks, map[string]bool{"card-2": true})
+	require.Len(t, filtered, 2)
+	require.Equal(t, "card-1", filtered[0].ID)
+	require.Equal(t, "text-1", filtered[1].ID)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// CardSearchHighlight is the fragment of the title, a text or a comment of
+// a card that matches a full-text search
+// swagger:model
+type CardSearchHighlight struct {
+	// The id of the block of the fragment, the card itself for its title
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The type of the block of the fragment, one of card, text or comment
+	// required: true
+	Type BlockType `json:"type"`
+
+	// The HTML escaped fragment, with the matching words in <mark> elements
+	// required: true
+	Fragment string `json:"fragment"`
+}
+
+// CardSearchHit is a card matching a full-text search
+// swagger:model
+type CardSearchHit struct {
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The title of the card
+	// required: true
+	Title string `json:"title"`
+
+	// The icon of the card
+	// required: false
+	Icon string `json:"icon,omitempty"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the board of the card
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The relevance of the card to the search, higher first
+	// required: true
+	Score float64 `json:"score"`
+
+	// The fragments of the card matching the search, most relevant first
+	// required: true
+	Highlights []*CardSearchHighlight `json:"highlights"`
+}
+
+// CardSearchResults is a page of the cards matching a full-text search
+// swagger:model
+type CardSearchResults struct {
+	// The cards of the page, most relevant first
+	// required: true
+	Results []*CardSearchHit `json:"results"`
+
+	// The number of matching cards across all pages
+	// required: true
+	Total int `json:"total"`
+
+	// The page of the results, starting at 0
+	// required: true
+	Page int `json:"page"`
+
+	// The number of results per page
+	// required: true
+	PerPage int `json:"perPage"`
+}
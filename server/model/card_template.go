@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+var validCardTemplateContentTypes = map[BlockType]bool{
+	TypeText:     true,
+	TypeCheckbox: true,
+	TypeImage:    true,
+}
+
+// CardTemplate describes a card template to be created on a board
+// swagger:model
+type CardTemplate struct {
+	// The id of an existing card of the board to copy as the template, with all its content.
+	// If set, the rest of the fields are ignored
+	// required: false
+	SourceCardID string `json:"sourceCardId"`
+
+	// The title of the template
+	// required: false
+	Title string `json:"title"`
+
+	// The icon of the template
+	// required: false
+	Icon string `json:"icon"`
+
+	// The default property values of the cards created from the template, keyed by property id
+	// required: false
+	Properties map[string]interface{} `json:"properties"`
+
+	// The content blocks of the cards created from the template, in order
+	// required: false
+	ContentBlocks []CardTemplateContent `json:"contentBlocks"`
+}
+
+// CardTemplateContent is a content block of a card template
+// swagger:model
+type CardTemplateContent struct {
+	// The block type, one of text, checkbox or image
+	// required: true
+	Type BlockType `json:"type"`
+
+	// The display title
+	// required: false
+	Title string `json:"title"`
+
+	// The block fields
+	// required: false
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// CardFromTemplate holds the values that override the template ones when
+// creating a card from a template
+// swagger:model
+type CardFromTemplate struct {
+	// The title of the new card, the template title is used if not set
+	// required: false
+	Title *string `json:"title"`
+
+	// Property values of the new card, merged over the template ones
+	// required: false
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// ErrInvalidCardTemplate is returned when a card template is not valid.
+type ErrInvalidCardTemplate struct {
+	msg string
+}
+
+func newErrInvalidCardTemplate(msg string) *ErrInvalidCardTemplate {
+	return &ErrInvalidCardTemplate{
+		msg: msg,
+	}
+}
+
+func (e *ErrInvalidCardTemplate) Error() string {
+	return e.msg
+}
+
+// IsValid checks a card template before it is created.
+func (t *CardTemplate) IsValid() error {
+	if t.SourceCardID != "" {
+		return nil
+	}
+
+	if strings.TrimSpace(t.Title) == "" {
+		return newErrInvalidCardTemplate("card template title cannot be empty")
+	}
+
+	for i, content := range t.ContentBlocks {
+		if !validCardTemplateContentTypes[content.Type] {
+			return newErrInvalidCardTemplate(fmt.Sprintf("invalid type %q for content block %d", content.Type, i))
+		}
+	}
+	return nil
+}
+
+// ToBlocks returns the template card block followed by its content blocks,
+// ready to be inserted in the board.
+func (t *CardTemplate) ToBlocks(boardID string) []Block {
+	card := Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     TypeCard,
+		Title:    t.Title,
+		Fields: map[string]interface{}{
+			"icon":       t.Icon,
+			"isTemplate": true,
+			"properties": map[string]interface{}{},
+		},
+	}
+	if t.Properties != nil {
+		card.Fields["properties"] = t.Properties
+	}
+
+	blocks := []Block{card}
+	contentOrder := make([]interface{}, 0, len(t.ContentBlocks))
+	for _, content := range t.ContentBlocks {
+		block := Block{
+			ID:       utils.NewID(utils.IDTypeBlock),
+			BoardID:  boardID,
+			ParentID: card.ID,
+			Type:     content.Type,
+			Title:    content.Title,
+			Fields:   content.Fields,
+		}
+		if block.Fields == nil {
+			block.Fields = map[string]interface{}{}
+		}
+		contentOrder = append(contentOrder, block.ID)
+		blocks = append(blocks, block)
+	}
+	blocks[0].Fields["contentOrder"] = contentOrder
+
+	return blocks
+}
+
+// IsCardTemplate returns true if the block is a card marked as template.
+func IsCardTemplate(block *Block) bool {
+	if block.Type != TypeCard {
+		return false
+	}
+	isTemplate, _ := block.Fields["isTemplate"].(bool)
+	return isTemplate
+}
+
+// Patch returns the patch to apply to a card created from a template so
+// that it holds the overridden values.
+func (c *CardFromTemplate) Patch(card *Block) *BlockPatch {
+	patch := &BlockPatch{
+		Title:         c.Title,
+		UpdatedFields: map[string]interface{}{},
+	}
+
+	if len(c.Properties) != 0 {
+		properties := map[string]interface{}{}
+		for key, value := range CardPropertyValues(card) {
+			properties[key] = value
+		}
+		for key, value := range c.Properties {
+			properties[key] = value
+		}
+		patch.UpdatedFields["properties"] = properties
+	}
+	return patch
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardTemplateToBlocks(t *testing.T) {
+	template := &CardTemplate{
+		Title:      "Bug report",
+		Properties: map[string]interface{}{"priority": "high"},
+		ContentBlocks: []CardTemplateContent{
+			{Type: TypeText, Title: "Steps to reproduce"},
+			{Type: TypeCheckbox, Title: "Added a test"},
+		},
+	}
+	require.NoError(t, template.IsValid())
+
+	blocks := template.ToBlocks("board-id")
+	require.Len(t, blocks, 3)
+
+	card := blocks[0]
+	require.True(t, IsCardTemplate(&card))
+	require.Equal(t, "board-id", card.ParentID)
+	require.Equal(t, "high", CardPropertyValues(&card)["priority"])
+	require.Equal(t, []interface{}{blocks[1].ID, blocks[2].ID}, card.Fields["contentOrder"])
+
+	for _, block := range blocks[1:] {
+		require.Equal(t, card.ID, block.ParentID)
+		require.Equal(t, "board-id", block.BoardID)
+		require.NotNil(t, block.Fields)
+	}
+}
+
+func TestCardTemplateIsValid(t *testing.T) {
+	require.Error(t, (&CardTemplate{}).IsValid())
+	require.NoError(t, (&CardTemplate{SourceCardID: "card-id"}).IsValid())
+
+	template := &CardTemplate{
+		Title:         "RFC",
+		ContentBlocks: []CardTemplateContent{{Type: TypeCard}},
+	}
+	var errInvalid *ErrInvalidCardTemplate
+	require.ErrorAs(t, template.IsValid(), &errInvalid)
+}
+
+func TestCardFromTemplatePatch(t *testing.T) {
+	card := &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": map[string]interface{}{
+		"priority": "high",
+		"status":   "new",
+	}}}
+
+	title := "Login fails"
+	patch := (&CardFromTemplate{
+		Title:      &title,
+		Properties: map[string]interface{}{"status": "triaged"},
+	}).Patch(card)
+
+	require.Equal(t, &title, patch.Title)
+	require.Equal(t, map[string]interface{}{"priority": "high", "status": "triaged"}, patch.UpdatedFields["properties"])
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardToBlock(t *testing.T) {
+	card := &Card{
+		BoardID:      "board-id",
+		Title:        "My card",
+		Icon:         "🎯",
+		ContentOrder: []string{"text-1", "image-1"},
+		Properties:   map[string]interface{}{"status": "done", "empty": nil},
+	}
+
+	block := card.ToBlock()
+	require.NotEmpty(t, block.ID)
+	require.Equal(t, BlockType(TypeCard), block.Type)
+	require.Equal(t, "board-id", block.BoardID)
+	require.Equal(t, "board-id", block.ParentID)
+	require.Equal(t, "My card", block.Title)
+	require.Equal(t, []interface{}{"text-1", "image-1"}, block.Fields["contentOrder"])
+	require.Equal(t, map[string]interface{}{"status": "done"}, block.Fields["properties"])
+
+	back := Block2Card(&block)
+	require.Equal(t, block.ID, back.ID)
+	require.Equal(t, "🎯", back.Icon)
+	require.Equal(t, []string{"text-1", "image-1"}, back.ContentOrder)
+	require.Equal(t, map[string]interface{}{"status": "done"}, back.Properties)
+}
+
+func TestCardPatchBlockPatch(t *testing.T) {
+	card := &Block{
+		ID:   "card-id",
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{"status": "todo", "owner": "user-1"},
+		},
+	}
+
+	title := "New title"
+	patch := &CardPatch{
+		Title:             &title,
+		UpdatedProperties: map[string]interface{}{"status": "done", "owner": nil},
+	}
+
+	blockPatch := patch.BlockPatch(card)
+	require.Equal(t, &title, blockPatch.Title)
+	require.Equal(t, map[string]interface{}{"status": "done"}, blockPatch.UpdatedFields["properties"])
+	require.NotContains(t, blockPatch.UpdatedFields, "icon")
+
+	// the current card is left untouched
+	require.Equal(t, "todo", card.Fields["properties"].(map[string]interface{})["status"])
+}
+
+func TestCardMatchesPropertyFilters(t *testing.T) {
+	card := &Block{
+		Type: TypeCard,
+		Fields: map[string]interface{}{
+			"properties": map[string]interface{}{
+				"status": "done",
+				"labels": []interface{}{"bug", "ui"},
+			},
+		},
+	}
+
+	require.True(t, CardMatchesPropertyFilters(card, nil))
+	require.True(t, CardMatchesPropertyFilters(card, map[string]string{"status": "done"}))
+	require.True(t, CardMatchesPropertyFilters(card, map[string]string{"status": "done", "labels": "ui"}))
+	require.False(t, CardMatchesPropertyFilters(card, map[string]string{"labels": "backend"}))
+	require.False(t, CardMatchesPropertyFilters(card, map[string]string{"owner": "user-1"}))
+	require.True(t, CardMatchesPropertyFilters(card, map[string]string{"owner": ""}))
+}
+
+func TestParseCardPropertyFilter(t *testing.T) {
+	propertyID, value, err := ParseCardPropertyFilter("status:in:progress")
+	require.NoError(t, err)
+	require.Equal(t, "status", propertyID)
+	require.Equal(t, "in:progress", value)
+
+	_, _, err = ParseCardPropertyFilter("status")
+	require.True(t, IsErrInvalidCard(err))
+
+	_, _, err = ParseCardPropertyFilter(":done")
+	require.True(t, IsErrInvalidCard(err))
+}
+
+func TestPaginateCards(t *testing.T) {
+	cards := []Block{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	require.Len(t, PaginateCards(cards, QueryCardsOptions{}), 3)
+
+	page := PaginateCards(cards, QueryCardsOptions{Page: 1, PerPage: 2})
+	require.Len(t, page, 1)
+	require.Equal(t, "3", page[0].ID)
+
+	require.Empty(t, PaginateCards(cards, QueryCardsOptions{Page: 2, PerPage: 2}))
+}
@@ -18,4 +18,45 @@ type ClientConfig struct {
 	// The server feature flags
 	// required: true
 	FeatureFlags map[string]string `json:"featureFlags"`
+
+	// What the server supports, so clients and integrations can adapt to it
+	// required: true
+	Capabilities ServerCapabilities `json:"capabilities"`
+}
+
+// WebsocketProtocolVersion is the version of the websocket messages sent
+// and accepted by the server. It changes when messages are changed in a
+// way older clients can't handle.
+const WebsocketProtocolVersion = 1
+
+// The operators supported by the search APIs.
+const (
+	// SearchOperatorAnyWord matches the items containing any of the space
+	// separated words of the search term.
+	SearchOperatorAnyWord = "anyWord"
+)
+
+// ServerCapabilities describes what the server supports
+// swagger:model
+type ServerCapabilities struct {
+	// The block types the server accepts
+	// required: true
+	BlockTypes []BlockType `json:"blockTypes"`
+
+	// The version of the archives the server exports, older versions can
+	// still be imported
+	// required: true
+	ArchiveVersion int `json:"archiveVersion"`
+
+	// The operators supported by the search APIs
+	// required: true
+	SearchOperators []string `json:"searchOperators"`
+
+	// The version of the websocket protocol
+	// required: true
+	WebsocketProtocolVersion int `json:"websocketProtocolVersion"`
+
+	// Whether the GraphQL query endpoint is enabled
+	// required: true
+	GraphQL bool `json:"graphql"`
 }
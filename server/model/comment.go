@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// CommentFieldParentComment is the comment field that holds the id of the
+// comment a reply belongs to.
+const CommentFieldParentComment = "parentCommentId"
+
+const maxCommentTextLength = 16383
+
+// Comment is a comment on a card, as a higher level representation of its
+// comment block
+// swagger:model
+type Comment struct {
+	// The id of the comment
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the card the comment is on
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the comment this one replies to, empty for a top level
+	// comment
+	// required: false
+	ParentCommentID string `json:"parentCommentId"`
+
+	// The text of the comment
+	// required: true
+	Text string `json:"text"`
+
+	// The id of the user who wrote the comment
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// CommentPatch is a patch for editing the text of a comment
+// swagger:model
+type CommentPatch struct {
+	// The new text of the comment
+	// required: true
+	Text string `json:"text"`
+}
+
+// ErrInvalidComment is returned when a comment isn't valid.
+type ErrInvalidComment struct {
+	msg string
+}
+
+func newErrInvalidComment(msg string) *ErrInvalidComment {
+	return &ErrInvalidComment{msg: msg}
+}
+
+// NewErrInvalidComment returns an error for a comment that can't be added,
+// like a reply to a comment of another card.
+func NewErrInvalidComment(msg string) *ErrInvalidComment {
+	return newErrInvalidComment(msg)
+}
+
+func (e *ErrInvalidComment) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidComment returns true if the error is an ErrInvalidComment.
+func IsErrInvalidComment(err error) bool {
+	var errInvalid *ErrInvalidComment
+	return errors.As(err, &errInvalid)
+}
+
+// ErrCommentNotOwned is returned when a user changes a comment written by
+// someone else.
+var ErrCommentNotOwned = errors.New("only the author can change a comment")
+
+// IsValid checks that the comment can be stored as a block of its card.
+func (c *Comment) IsValid() error {
+	if c.BoardID == "" || c.CardID == "" {
+		return newErrInvalidComment("comment must have a board id and a card id")
+	}
+	return ValidateCommentText(c.Text)
+}
+
+// ValidateCommentText checks that the text of a comment isn't empty or too
+// long.
+func ValidateCommentText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return newErrInvalidComment("text cannot be empty")
+	}
+	if utf8.RuneCountInString(text) > maxCommentTextLength {
+		return newErrInvalidComment(fmt.Sprintf("text cannot be longer than %d characters", maxCommentTextLength))
+	}
+	return nil
+}
+
+// ToBlock returns the comment block of the comment, with a new id if the
+// comment has none.
+func (c *Comment) ToBlock() Block {
+	id := c.ID
+	if id == "" {
+		id = utils.NewID(utils.IDTypeBlock)
+	}
+
+	fields := map[string]interface{}{}
+	if c.ParentCommentID != "" {
+		fields[CommentFieldParentComment] = c.ParentCommentID
+	}
+
+	return Block{
+		ID:         id,
+		BoardID:    c.BoardID,
+		ParentID:   c.CardID,
+		CreatedBy:  c.CreatedBy,
+		ModifiedBy: c.CreatedBy,
+		Schema:     1,
+		Type:       TypeComment,
+		Title:      c.Text,
+		Fields:     fields,
+		CreateAt:   c.CreateAt,
+		UpdateAt:   c.UpdateAt,
+	}
+}
+
+// Block2Comment returns the comment of a comment block.
+func Block2Comment(block *Block) *Comment {
+	parentCommentID, _ := block.Fields[CommentFieldParentComment].(string)
+
+	return &Comment{
+		ID:              block.ID,
+		BoardID:         block.BoardID,
+		CardID:          block.ParentID,
+		ParentCommentID: parentCommentID,
+		Text:            block.Title,
+		CreatedBy:       block.CreatedBy,
+		CreateAt:        block.CreateAt,
+		UpdateAt:        block.UpdateAt,
+	}
+}
+
+// CommentThread returns the ids of a comment and of all the replies under
+// it, at any depth, among the comments of its card.
+func CommentThread(commentID string, comments []Block) []string {
+	replies := map[string][]string{}
+	for i := range comments {
+		if parentID, _ := comments[i].Fields[CommentFieldParentComment].(string); parentID != "" {
+			replies[parentID] = append(replies[parentID], comments[i].ID)
+		}
+	}
+
+	thread := []string{commentID}
+	seen := map[string]bool{commentID: true}
+	for i := 0; i < len(thread); i++ {
+		for _, replyID := range replies[thread[i]] {
+			if !seen[replyID] {
+				seen[replyID] = true
+				thread = append(thread, replyID)
+			}
+		}
+	}
+	return thread
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentIsValid(t *testing.T) {
+	comment := &Comment{BoardID: "board-id", CardID: "card-id", Text: "Looks good"}
+	require.NoError(t, comment.IsValid())
+
+	comment.Text = "  "
+	require.True(t, IsErrInvalidComment(comment.IsValid()))
+
+	comment.Text = strings.Repeat("a", maxCommentTextLength+1)
+	require.True(t, IsErrInvalidComment(comment.IsValid()))
+
+	comment = &Comment{BoardID: "board-id", Text: "Looks good"}
+	require.True(t, IsErrInvalidComment(comment.IsValid()))
+}
+
+func TestCommentBlockRoundTrip(t *testing.T) {
+	comment := &Comment{
+		BoardID:         "board-id",
+		CardID:          "card-id",
+		ParentCommentID: "parent-id",
+		Text:            "I agree",
+		CreatedBy:       "user-id",
+	}
+
+	block := comment.ToBlock()
+	require.NotEmpty(t, block.ID)
+	require.Equal(t, BlockType(TypeComment), block.Type)
+	require.Equal(t, "card-id", block.ParentID)
+	require.Equal(t, "I agree", block.Title)
+
+	result := Block2Comment(&block)
+	require.Equal(t, block.ID, result.ID)
+	require.Equal(t, "card-id", result.CardID)
+	require.Equal(t, "parent-id", result.ParentCommentID)
+	require.Equal(t, "I agree", result.Text)
+
+	comment.ParentCommentID = ""
+	block = comment.ToBlock()
+	require.NotContains(t, block.Fields, CommentFieldParentComment)
+}
+
+func TestCommentThread(t *testing.T) {
+	reply := func(id, parentID string) Block {
+		return Block{ID: id, Type: TypeComment, Fields: map[string]interface{}{CommentFieldParentComment: parentID}}
+	}
+	comments := []Block{
+		{ID: "root", Type: TypeComment, Fields: map[string]interface{}{}},
+		{ID: "other", Type: TypeComment, Fields: map[string]interface{}{}},
+		reply("reply-1", "root"),
+		reply("reply-2", "reply-1"),
+		reply("reply-3", "other"),
+	}
+
+	require.Equal(t, []string{"root", "reply-1", "reply-2"}, CommentThread("root", comments))
+	require.Equal(t, []string{"reply-2"}, CommentThread("reply-2", comments))
+
+	t.Run("cycle", func(t *testing.T) {
+		cycle := []Block{reply("a", "b"), reply("b", "a")}
+		require.Equal(t, []string{"a", "b"}, CommentThread("a", cycle))
+	})
+}
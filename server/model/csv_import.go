@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCSV is returned when an uploaded CSV file can't be read or
+// has no header row.
+var ErrInvalidCSV = errors.New("invalid CSV file")
+
+// CSVImportMapping describes how the columns of a CSV file are mapped to
+// the cards of a board
+// swagger:model
+type CSVImportMapping struct {
+	// The header of the column holding the card titles
+	// required: true
+	TitleColumn string `json:"titleColumn"`
+
+	// The mapping of the other columns to card properties, unmapped columns are ignored
+	// required: false
+	Columns []CSVColumnMapping `json:"columns"`
+
+	// Adds the values missing from select and multiSelect properties as new options,
+	// instead of reporting them as row errors
+	// required: false
+	CreateOptions bool `json:"createOptions"`
+}
+
+// CSVColumnMapping maps a CSV column to a card property
+// swagger:model
+type CSVColumnMapping struct {
+	// The header of the column
+	// required: true
+	Column string `json:"column"`
+
+	// The id of the card property the column values are set on
+	// required: true
+	PropertyID string `json:"propertyId"`
+}
+
+// CSVRowError is a row of a CSV file that couldn't be imported
+// swagger:model
+type CSVRowError struct {
+	// The number of the row in the file, the header being row 1
+	// required: true
+	Row int `json:"row"`
+
+	// The header of the column with the invalid value, empty if the error concerns the whole row
+	// required: false
+	Column string `json:"column,omitempty"`
+
+	// The reason the row wasn't imported
+	// required: true
+	Message string `json:"message"`
+}
+
+// CSVImportResult reports the cards created from a CSV file and the rows
+// that were skipped
+// swagger:model
+type CSVImportResult struct {
+	// The ids of the created cards, in row order
+	// required: true
+	CardIDs []string `json:"cardIds"`
+
+	// The rows that were skipped
+	// required: true
+	Errors []CSVRowError `json:"errors"`
+}
+
+// ErrInvalidCSVMapping is returned when a CSV mapping references unknown
+// columns or properties, or properties that can't be imported.
+type ErrInvalidCSVMapping struct {
+	msg string
+}
+
+func NewErrInvalidCSVMapping(format string, args ...interface{}) *ErrInvalidCSVMapping {
+	return &ErrInvalidCSVMapping{msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *ErrInvalidCSVMapping) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidCSVMapping returns true if the error is an ErrInvalidCSVMapping.
+func IsErrInvalidCSVMapping(err error) bool {
+	var errInvalid *ErrInvalidCSVMapping
+	return errors.As(err, &errInvalid)
+}
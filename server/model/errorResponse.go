@@ -10,4 +10,13 @@ type ErrorResponse struct {
 	// The error code
 	// required: false
 	ErrorCode int `json:"errorCode"`
+
+	// The ID of the request, to find it in the server logs
+	// required: false
+	RequestID string `json:"requestId,omitempty"`
+
+	// The failed validation criteria, like the password requirements
+	// not met
+	// required: false
+	Details []string `json:"details,omitempty"`
 }
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FeatureFlagEnabled is the value of an enabled feature flag.
+const FeatureFlagEnabled = "true"
+
+const maxFeatureFlagNameLength = 64
+
+// TeamFeatureFlags are the feature flags of a team that override the ones
+// of the server configuration
+// swagger:model
+type TeamFeatureFlags struct {
+	// The team ID
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The values of the overridden flags, by flag name
+	// required: true
+	Overrides map[string]string `json:"overrides"`
+}
+
+// ErrInvalidFeatureFlag is returned when feature flag overrides are not
+// valid.
+type ErrInvalidFeatureFlag struct {
+	msg string
+}
+
+func newErrInvalidFeatureFlag(msg string) *ErrInvalidFeatureFlag {
+	return &ErrInvalidFeatureFlag{msg: msg}
+}
+
+func (e *ErrInvalidFeatureFlag) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidFeatureFlag returns true if the error is an ErrInvalidFeatureFlag.
+func IsErrInvalidFeatureFlag(err error) bool {
+	var errInvalid *ErrInvalidFeatureFlag
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks the names of the overridden flags.
+func (f *TeamFeatureFlags) IsValid() error {
+	for name := range f.Overrides {
+		if name == "" {
+			return newErrInvalidFeatureFlag("empty feature flag name")
+		}
+		if len(name) > maxFeatureFlagNameLength {
+			return newErrInvalidFeatureFlag(fmt.Sprintf("feature flag name %q is longer than %d characters", name, maxFeatureFlagNameLength))
+		}
+		if strings.ContainsAny(name, " \t\r\n") {
+			return newErrInvalidFeatureFlag(fmt.Sprintf("invalid feature flag name %q", name))
+		}
+	}
+	return nil
+}
+
+// MergeFeatureFlags returns the flags of the configuration with the
+// overrides applied.
+func MergeFeatureFlags(flags, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(flags)+len(overrides))
+	for name, value := range flags {
+		merged[name] = value
+	}
+	for name, value := range overrides {
+		merged[name] = value
+	}
+	return merged
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTeamFeatureFlagsIsValid(t *testing.T) {
+	flags := &TeamFeatureFlags{Overrides: map[string]string{"automation": "true", "search": ""}}
+	require.NoError(t, flags.IsValid())
+
+	for _, name := range []string{"", "with space", strings.Repeat("a", maxFeatureFlagNameLength+1)} {
+		flags := &TeamFeatureFlags{Overrides: map[string]string{name: "true"}}
+		err := flags.IsValid()
+		require.Error(t, err, name)
+		require.True(t, IsErrInvalidFeatureFlag(err))
+	}
+}
+
+func TestMergeFeatureFlags(t *testing.T) {
+	flags := map[string]string{"a": "true", "b": "true"}
+	merged := MergeFeatureFlags(flags, map[string]string{"b": "false", "c": "true"})
+	require.Equal(t, map[string]string{"a": "true", "b": "false", "c": "true"}, merged)
+
+	// the flags of the configuration are not modified
+	require.Equal(t, map[string]string{"a": "true", "b": "true"}, flags)
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// MaxFileContentLength is the number of bytes of the text of a file that
+// are indexed for search.
+const MaxFileContentLength = 60000
+
+// FileContent is the text extracted from an uploaded file, which makes
+// the attachment and its card searchable by the content of the file.
+type FileContent struct {
+	// The id of the file, as stored in the fileId field of the attachment
+	FileID string `json:"fileId"`
+
+	// The id of the team of the file
+	TeamID string `json:"teamId"`
+
+	// The id of the board the file was uploaded to
+	BoardID string `json:"boardId"`
+
+	// The text of the file, with its whitespace collapsed
+	Content string `json:"content"`
+
+	// The time the text was extracted, in miliseconds since the current epoch
+	UpdateAt int64 `json:"updateAt"`
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// FileInfo is the size of a file attached to a board, recorded when the
+// file is stored so that the storage used by the attachments can be
+// summed up by team or for the whole installation.
+type FileInfo struct {
+	// The id of the file, as stored in the fileId field of the attachment
+	FileID string `json:"fileId"`
+
+	// The id of the team of the file
+	TeamID string `json:"teamId"`
+
+	// The id of the board the file belongs to
+	BoardID string `json:"boardId"`
+
+	// The size of the stored file, in bytes
+	Size int64 `json:"size"`
+
+	// The time the file was stored, in miliseconds since the current epoch
+	CreateAt int64 `json:"createAt"`
+}
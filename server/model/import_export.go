@@ -8,6 +8,7 @@ import (
 
 var (
 	ErrInvalidImageBlock = errors.New("invalid image block")
+	ErrNoBoardsToExport  = errors.New("no boards to export")
 )
 
 // Archive is an import / export archive.
@@ -38,6 +39,27 @@ type ExportArchiveOptions struct {
 	// BoardIDs is the list of boards to include in the archive.
 	// Empty slice means export all boards from workspace/team.
 	BoardIDs []string
+
+	// UserID is the user requesting the export. The restricted cards and
+	// views the user can't see are left out of the archive. Empty means
+	// a system export, such as a backup, that includes every block.
+	UserID string
+}
+
+// ArchiveExportRequest lists the boards of a team to export to an archive
+// swagger:model
+type ArchiveExportRequest struct {
+	// The ids of the boards to export
+	// required: true
+	BoardIDs []string `json:"boardIds"`
+}
+
+// IsValid returns an error if the request has no boards to export.
+func (r *ArchiveExportRequest) IsValid() error {
+	if len(r.BoardIDs) == 0 {
+		return ErrNoBoardsToExport
+	}
+	return nil
 }
 
 // ImportArchiveOptions provides options when importing an archive.
@@ -46,6 +68,72 @@ type ImportArchiveOptions struct {
 	ModifiedBy    string
 	BoardModifier BoardModifier
 	BlockModifier BlockModifier
+
+	// DryRun parses and validates the archive without writing anything.
+	DryRun bool
+}
+
+const (
+	ArchiveIssueDuplicateID    = "duplicateId"
+	ArchiveIssueOrphanedParent = "orphanedParent"
+	ArchiveIssueBadBoardID     = "badBoardId"
+	ArchiveIssueMissingBoard   = "missingBoard"
+	ArchiveIssueOrphanedFile   = "orphanedFile"
+)
+
+// ArchiveImportIssue is an integrity problem found in an imported archive
+// swagger:model
+type ArchiveImportIssue struct {
+	// The kind of problem: duplicateId, orphanedParent, badBoardId, missingBoard or orphanedFile
+	// required: true
+	Type string `json:"type"`
+
+	// The ID of the board, as found in the archive, the problem belongs to
+	// required: false
+	BoardID string `json:"boardId,omitempty"`
+
+	// The ID of the block, as found in the archive, the problem belongs to
+	// required: false
+	BlockID string `json:"blockId,omitempty"`
+
+	// A description of the problem
+	// required: true
+	Message string `json:"message"`
+}
+
+// ArchiveImportReport reports what an archive import contains, and the
+// integrity problems found while reading it
+// swagger:model
+type ArchiveImportReport struct {
+	// Whether the archive was only validated, without importing it
+	// required: true
+	DryRun bool `json:"dryRun"`
+
+	// The number of boards of the archive
+	// required: true
+	Boards int `json:"boards"`
+
+	// The number of blocks of the archive
+	// required: true
+	Blocks int `json:"blocks"`
+
+	// The number of files of the archive
+	// required: true
+	Files int `json:"files"`
+
+	// The integrity problems found in the archive
+	// required: true
+	Issues []ArchiveImportIssue `json:"issues"`
+}
+
+// AddIssue records an integrity problem of the archive.
+func (r *ArchiveImportReport) AddIssue(issueType, boardID, blockID, message string) {
+	r.Issues = append(r.Issues, ArchiveImportIssue{
+		Type:    issueType,
+		BoardID: boardID,
+		BlockID: blockID,
+		Message: message,
+	})
 }
 
 // ErrUnsupportedArchiveVersion is an error returned when trying to import an
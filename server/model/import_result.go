@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ImportResult is the outcome of importing an export of another tool
+// swagger:model
+type ImportResult struct {
+	// The boards created by the import
+	// required: true
+	Boards []*Board `json:"boards"`
+
+	// The items of the export that could not be converted
+	// required: true
+	Errors []ImportItemError `json:"errors"`
+}
+
+// ImportItemError describes an item of an export that could not be converted
+// swagger:model
+type ImportItemError struct {
+	// The path of the item in the export, with the row number for table rows
+	// required: true
+	Item string `json:"item"`
+
+	// The reason the item was skipped
+	// required: true
+	Error string `json:"error"`
+}
@@ -0,0 +1,410 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// BoardPropertyIntakeForms is the key of the board property that holds
+// the intake forms of the board.
+const BoardPropertyIntakeForms = "intakeForms"
+
+// IntakeFormTitleField is the property id used by form fields that set
+// the title of the card.
+const IntakeFormTitleField = "title"
+
+const maxIntakeFormValueLength = 10000
+
+// intakeFormPropertyTypes are the property types that a form can ask for.
+var intakeFormPropertyTypes = map[string]bool{
+	PropertyTypeText:        true,
+	PropertyTypeNumber:      true,
+	PropertyTypeSelect:      true,
+	PropertyTypeMultiSelect: true,
+	PropertyTypeDate:        true,
+	PropertyTypeCheckbox:    true,
+	PropertyTypeURL:         true,
+	PropertyTypeEmail:       true,
+	PropertyTypePhone:       true,
+	PropertyTypeLocation:    true,
+}
+
+// IntakeForm is a form that creates cards on a board from the values
+// submitted by people without access to it
+// swagger:model
+type IntakeForm struct {
+	// The id of the form
+	// required: true
+	ID string `json:"id"`
+
+	// The title shown at the top of the form, also used as the title of
+	// the cards when the form doesn't ask for one
+	// required: true
+	Title string `json:"title"`
+
+	// The description shown below the title
+	// required: false
+	Description string `json:"description,omitempty"`
+
+	// Allows submissions to the form
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// The token that gives access to the form
+	// required: true
+	Token string `json:"token"`
+
+	// The fields of the form
+	// required: true
+	Fields []IntakeFormField `json:"fields"`
+}
+
+// IntakeFormField is a field of an intake form
+// swagger:model
+type IntakeFormField struct {
+	// The id of the card property set by the field, or "title" for the card title
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The label of the field, the property name is used if empty
+	// required: false
+	Label string `json:"label,omitempty"`
+
+	// A help text shown with the field
+	// required: false
+	Description string `json:"description,omitempty"`
+
+	// Requires a value for the field
+	// required: false
+	Required bool `json:"required,omitempty"`
+}
+
+// IntakeFormPatch is a patch for modifying an intake form
+// swagger:model
+type IntakeFormPatch struct {
+	// The title of the form
+	// required: false
+	Title *string `json:"title"`
+
+	// The description of the form
+	// required: false
+	Description *string `json:"description"`
+
+	// Allows or stops submissions to the form
+	// required: false
+	Enabled *bool `json:"enabled"`
+
+	// The new fields of the form
+	// required: false
+	Fields *[]IntakeFormField `json:"fields"`
+
+	// Replaces the token of the form, invalidating the links shared so far
+	// required: false
+	RegenerateToken bool `json:"regenerateToken"`
+}
+
+// IntakeFormView is the version of a form shown to the people filling it
+// swagger:model
+type IntakeFormView struct {
+	// The id of the form
+	// required: true
+	ID string `json:"id"`
+
+	// The title of the form
+	// required: true
+	Title string `json:"title"`
+
+	// The description of the form
+	// required: false
+	Description string `json:"description,omitempty"`
+
+	// The fields of the form
+	// required: true
+	Fields []IntakeFormFieldView `json:"fields"`
+}
+
+// IntakeFormFieldView is a field of a form as shown to the people filling it
+// swagger:model
+type IntakeFormFieldView struct {
+	// The id of the property set by the field
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The label of the field
+	// required: true
+	Label string `json:"label"`
+
+	// A help text shown with the field
+	// required: false
+	Description string `json:"description,omitempty"`
+
+	// The type of the field value, text for the card title
+	// required: true
+	Type string `json:"type"`
+
+	// The options of select and multiSelect fields
+	// required: false
+	Options []CardPropertyOption `json:"options,omitempty"`
+
+	// Requires a value for the field
+	// required: false
+	Required bool `json:"required,omitempty"`
+}
+
+// IntakeFormSubmission holds the values submitted to a form
+// swagger:model
+type IntakeFormSubmission struct {
+	// The values keyed by property id, or "title" for the card title
+	// required: true
+	Values map[string]interface{} `json:"values"`
+
+	// The response of the captcha challenge, if the server requires one
+	// required: false
+	CaptchaResponse string `json:"captchaResponse,omitempty"`
+}
+
+// IntakeFormsFromBoard returns the intake forms of a board.
+func IntakeFormsFromBoard(board *Board) ([]IntakeForm, error) {
+	raw := board.Properties[BoardPropertyIntakeForms]
+	if raw == nil {
+		return []IntakeForm{}, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	forms := []IntakeForm{}
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return nil, err
+	}
+	return forms, nil
+}
+
+// FindIntakeForm returns the index of the form with the given id, or -1.
+func FindIntakeForm(forms []IntakeForm, formID string) int {
+	for i := range forms {
+		if forms[i].ID == formID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Hydrate sets the id and the token of a new form.
+func (f *IntakeForm) Hydrate() {
+	f.ID = utils.NewID(utils.IDTypeNone)
+	f.Token = utils.NewID(utils.IDTypeToken)
+	if f.Fields == nil {
+		f.Fields = []IntakeFormField{}
+	}
+}
+
+// HasToken returns true if the token gives access to the form.
+func (f *IntakeForm) HasToken(token string) bool {
+	return token != "" && subtle.ConstantTimeCompare([]byte(f.Token), []byte(token)) == 1
+}
+
+// IsValid checks that the form fields refer to properties of the board
+// that a form can ask for.
+func (f *IntakeForm) IsValid(properties []CardProperty) error {
+	if strings.TrimSpace(f.Title) == "" {
+		return newErrInvalidSubmission("form title cannot be empty")
+	}
+
+	seen := map[string]bool{}
+	for _, field := range f.Fields {
+		if seen[field.PropertyID] {
+			return newErrInvalidSubmission(fmt.Sprintf("property %q is used by more than one field", field.PropertyID))
+		}
+		seen[field.PropertyID] = true
+
+		if field.PropertyID == IntakeFormTitleField {
+			continue
+		}
+		idx := FindCardProperty(properties, field.PropertyID)
+		if idx == -1 {
+			return newErrInvalidSubmission(fmt.Sprintf("property %q doesn't exist", field.PropertyID))
+		}
+		if !intakeFormPropertyTypes[properties[idx].Type] {
+			return newErrInvalidSubmission(fmt.Sprintf("property type %q cannot be used in a form", properties[idx].Type))
+		}
+	}
+	return nil
+}
+
+// Patch returns an updated version of the form.
+func (p *IntakeFormPatch) Patch(form *IntakeForm) *IntakeForm {
+	if p.Title != nil {
+		form.Title = *p.Title
+	}
+	if p.Description != nil {
+		form.Description = *p.Description
+	}
+	if p.Enabled != nil {
+		form.Enabled = *p.Enabled
+	}
+	if p.Fields != nil {
+		form.Fields = *p.Fields
+	}
+	if p.RegenerateToken {
+		form.Token = utils.NewID(utils.IDTypeToken)
+	}
+	return form
+}
+
+// View returns the form as shown to the people filling it.
+func (f *IntakeForm) View(properties []CardProperty) *IntakeFormView {
+	view := &IntakeFormView{
+		ID:          f.ID,
+		Title:       f.Title,
+		Description: f.Description,
+		Fields:      make([]IntakeFormFieldView, 0, len(f.Fields)),
+	}
+
+	for _, field := range f.Fields {
+		fieldView := IntakeFormFieldView{
+			PropertyID:  field.PropertyID,
+			Label:       field.Label,
+			Description: field.Description,
+			Type:        PropertyTypeText,
+			Required:    field.Required,
+		}
+		if field.PropertyID != IntakeFormTitleField {
+			idx := FindCardProperty(properties, field.PropertyID)
+			if idx == -1 {
+				continue
+			}
+			fieldView.Type = properties[idx].Type
+			fieldView.Options = properties[idx].Options
+			if fieldView.Label == "" {
+				fieldView.Label = properties[idx].Name
+			}
+		}
+		view.Fields = append(view.Fields, fieldView)
+	}
+	return view
+}
+
+// ToCard validates the submitted values and returns the card that the
+// submission creates.
+func (f *IntakeForm) ToCard(boardID string, properties []CardProperty, submission *IntakeFormSubmission) (*Block, error) {
+	fields := map[string]bool{}
+	for _, field := range f.Fields {
+		fields[field.PropertyID] = true
+	}
+	for propertyID := range submission.Values {
+		if !fields[propertyID] {
+			return nil, newErrInvalidSubmission(fmt.Sprintf("the form doesn't have a field for %q", propertyID))
+		}
+	}
+
+	title := f.Title
+	values := map[string]interface{}{}
+	for _, field := range f.Fields {
+		value := submission.Values[field.PropertyID]
+		if isEmptyCardPropertyValue(value) || value == false {
+			if field.Required {
+				return nil, newErrInvalidSubmission(fmt.Sprintf("field %q is required", field.PropertyID))
+			}
+			continue
+		}
+
+		if field.PropertyID == IntakeFormTitleField {
+			text, err := intakeFormText(field.PropertyID, value)
+			if err != nil {
+				return nil, err
+			}
+			title = strings.TrimSpace(text)
+			continue
+		}
+
+		idx := FindCardProperty(properties, field.PropertyID)
+		if idx == -1 {
+			return nil, newErrInvalidSubmission(fmt.Sprintf("property %q doesn't exist", field.PropertyID))
+		}
+		cardValue, err := intakeFormValue(&properties[idx], value)
+		if err != nil {
+			return nil, err
+		}
+		values[field.PropertyID] = cardValue
+	}
+
+	return &Block{
+		ID:       utils.NewID(utils.IDTypeCard),
+		BoardID:  boardID,
+		ParentID: boardID,
+		Type:     TypeCard,
+		Title:    title,
+		Fields: map[string]interface{}{
+			"properties":   values,
+			"contentOrder": []interface{}{},
+		},
+	}, nil
+}
+
+func intakeFormText(propertyID string, value interface{}) (string, error) {
+	text, ok := value.(string)
+	if !ok {
+		return "", newErrInvalidSubmission(fmt.Sprintf("invalid value for field %q", propertyID))
+	}
+	if utf8.RuneCountInString(text) > maxIntakeFormValueLength {
+		return "", newErrInvalidSubmission(fmt.Sprintf("value for field %q cannot be longer than %d characters", propertyID, maxIntakeFormValueLength))
+	}
+	return text, nil
+}
+
+// intakeFormValue checks a submitted value against the property type and
+// returns it as stored on cards.
+func intakeFormValue(property *CardProperty, value interface{}) (interface{}, error) {
+	invalid := newErrInvalidSubmission(fmt.Sprintf("invalid value for field %q", property.ID))
+	switch property.Type {
+	case PropertyTypeSelect:
+		optionID, ok := value.(string)
+		if !ok || property.FindOption(optionID) == -1 {
+			return nil, invalid
+		}
+		return optionID, nil
+	case PropertyTypeMultiSelect:
+		optionIDs, ok := value.([]interface{})
+		if !ok {
+			return nil, invalid
+		}
+		for _, id := range optionIDs {
+			optionID, ok := id.(string)
+			if !ok || property.FindOption(optionID) == -1 {
+				return nil, invalid
+			}
+		}
+		return optionIDs, nil
+	case PropertyTypeCheckbox:
+		if checked, ok := value.(bool); !ok || !checked {
+			return nil, invalid
+		}
+		return "true", nil
+	case PropertyTypeNumber:
+		if _, _, err := ParseCardNumber(value); err != nil {
+			return nil, invalid
+		}
+		// numbers are stored as strings by the clients
+		if number, ok := value.(float64); ok {
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+		return value, nil
+	case PropertyTypeLocation:
+		if _, err := ParseCardLocation(value); err != nil {
+			return nil, invalid
+		}
+		return value, nil
+	}
+	return intakeFormText(property.ID, value)
+}
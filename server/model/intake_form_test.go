@@ -0,0 +1,86 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntakeFormIsValid(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "priority", Name: "Priority", Type: PropertyTypeSelect, Options: []CardPropertyOption{{ID: "high", Value: "High"}}},
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson},
+	}
+
+	form := &IntakeForm{Title: "Requests", Fields: []IntakeFormField{
+		{PropertyID: IntakeFormTitleField, Label: "Summary", Required: true},
+		{PropertyID: "priority"},
+	}}
+	require.NoError(t, form.IsValid(properties))
+
+	form.Fields = append(form.Fields, IntakeFormField{PropertyID: "owner"})
+	require.True(t, IsErrInvalidSubmission(form.IsValid(properties)))
+
+	form.Fields = []IntakeFormField{{PropertyID: "priority"}, {PropertyID: "priority"}}
+	require.True(t, IsErrInvalidSubmission(form.IsValid(properties)))
+
+	form.Fields = []IntakeFormField{{PropertyID: "unknown"}}
+	require.True(t, IsErrInvalidSubmission(form.IsValid(properties)))
+}
+
+func TestIntakeFormToCard(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "priority", Name: "Priority", Type: PropertyTypeSelect, Options: []CardPropertyOption{{ID: "high", Value: "High"}}},
+		{ID: "estimate", Name: "Estimate", Type: PropertyTypeNumber},
+		{ID: "urgent", Name: "Urgent", Type: PropertyTypeCheckbox},
+	}
+	form := &IntakeForm{Title: "Requests", Fields: []IntakeFormField{
+		{PropertyID: IntakeFormTitleField, Required: true},
+		{PropertyID: "priority"},
+		{PropertyID: "estimate"},
+		{PropertyID: "urgent"},
+	}}
+
+	t.Run("valid submission", func(t *testing.T) {
+		card, err := form.ToCard("board-id", properties, &IntakeFormSubmission{Values: map[string]interface{}{
+			IntakeFormTitleField: " New laptop ",
+			"priority":           "high",
+			"estimate":           float64(3),
+			"urgent":             true,
+		}})
+		require.NoError(t, err)
+		require.Equal(t, "New laptop", card.Title)
+		require.Equal(t, map[string]interface{}{"priority": "high", "estimate": "3", "urgent": "true"}, CardPropertyValues(card))
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		_, err := form.ToCard("board-id", properties, &IntakeFormSubmission{Values: map[string]interface{}{"priority": "high"}})
+		require.True(t, IsErrInvalidSubmission(err))
+	})
+
+	t.Run("field not in the form", func(t *testing.T) {
+		_, err := form.ToCard("board-id", properties, &IntakeFormSubmission{Values: map[string]interface{}{
+			IntakeFormTitleField: "New laptop",
+			"owner":              "user-id",
+		}})
+		require.True(t, IsErrInvalidSubmission(err))
+	})
+
+	t.Run("unknown option", func(t *testing.T) {
+		_, err := form.ToCard("board-id", properties, &IntakeFormSubmission{Values: map[string]interface{}{
+			IntakeFormTitleField: "New laptop",
+			"priority":           "low",
+		}})
+		require.True(t, IsErrInvalidSubmission(err))
+	})
+}
+
+func TestIntakeFormHasToken(t *testing.T) {
+	form := &IntakeForm{}
+	form.Hydrate()
+
+	require.NotEmpty(t, form.ID)
+	require.True(t, form.HasToken(form.Token))
+	require.False(t, form.HasToken(""))
+	require.False(t, form.HasToken("wrong"))
+}
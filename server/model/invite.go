@@ -0,0 +1,118 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// Invite is a link created by a team admin that lets new users register
+// directly into the team
+// swagger:model
+type Invite struct {
+	// The id of the invite
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the team the users are invited to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The secret token of the invite link
+	// required: true
+	Token string `json:"token"`
+
+	// The role the invited users get on the open boards of the team
+	// required: true
+	Role BoardRole `json:"role"`
+
+	// The number of registrations allowed with the invite, unlimited if zero
+	// required: false
+	MaxUses int `json:"maxUses"`
+
+	// The number of users who registered with the invite
+	// required: true
+	UseCount int `json:"useCount"`
+
+	// The expiration time in milliseconds since the current epoch, never if zero
+	// required: false
+	ExpiresAt int64 `json:"expiresAt"`
+
+	// The id of the user who created the invite
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+}
+
+// ErrInvalidInvite is returned when an invite has an invalid role or limit.
+type ErrInvalidInvite struct {
+	msg string
+}
+
+func NewErrInvalidInvite(msg string) *ErrInvalidInvite {
+	return &ErrInvalidInvite{msg: msg}
+}
+
+func (e *ErrInvalidInvite) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidInvite returns true if the error is an ErrInvalidInvite.
+func IsErrInvalidInvite(err error) bool {
+	var errInvalid *ErrInvalidInvite
+	return errors.As(err, &errInvalid)
+}
+
+// Hydrate sets the id, the token, and the creation details of a new
+// invite. Invites without a role make the users editors, like joining an
+// open board does.
+func (i *Invite) Hydrate(teamID, userID string) {
+	i.ID = utils.NewID(utils.IDTypeNone)
+	i.TeamID = teamID
+	i.Token = utils.NewID(utils.IDTypeToken)
+	if i.Role == BoardRoleNone {
+		i.Role = BoardRoleEditor
+	}
+	i.UseCount = 0
+	i.CreatedBy = userID
+	i.CreateAt = utils.GetMillis()
+}
+
+// IsValid checks the role and the limits of the invite.
+func (i *Invite) IsValid() error {
+	if i.Role == BoardRoleNone || !IsBoardMinimumRoleValid(i.Role) {
+		return NewErrInvalidInvite(fmt.Sprintf("invalid role %q", i.Role))
+	}
+	if i.MaxUses < 0 {
+		return NewErrInvalidInvite("the maximum number of uses can't be negative")
+	}
+	if i.ExpiresAt < 0 {
+		return NewErrInvalidInvite("invalid expiration time")
+	}
+	return nil
+}
+
+// IsUsable returns true if the invite is neither expired at the time nor
+// used up.
+func (i *Invite) IsUsable(now int64) bool {
+	if i.ExpiresAt != 0 && i.ExpiresAt <= now {
+		return false
+	}
+	return i.MaxUses == 0 || i.UseCount < i.MaxUses
+}
+
+// BoardMember returns the membership of the invited user to the board.
+func (i *Invite) BoardMember(userID, boardID string) *BoardMember {
+	return &BoardMember{
+		UserID:          userID,
+		BoardID:         boardID,
+		SchemeAdmin:     i.Role == BoardRoleAdmin,
+		SchemeEditor:    i.Role == BoardRoleEditor,
+		SchemeCommenter: i.Role == BoardRoleCommenter,
+		SchemeViewer:    i.Role == BoardRoleViewer,
+	}
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInviteIsValid(t *testing.T) {
+	t.Run("valid invite", func(t *testing.T) {
+		invite := &Invite{Role: BoardRoleViewer, MaxUses: 5, ExpiresAt: 1000}
+		require.NoError(t, invite.IsValid())
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		for _, role := range []BoardRole{BoardRoleNone, "owner"} {
+			invite := &Invite{Role: role}
+			require.True(t, IsErrInvalidInvite(invite.IsValid()), role)
+		}
+	})
+
+	t.Run("negative limits", func(t *testing.T) {
+		require.True(t, IsErrInvalidInvite((&Invite{Role: BoardRoleEditor, MaxUses: -1}).IsValid()))
+		require.True(t, IsErrInvalidInvite((&Invite{Role: BoardRoleEditor, ExpiresAt: -1}).IsValid()))
+	})
+
+	t.Run("hydrate defaults to editor", func(t *testing.T) {
+		invite := &Invite{UseCount: 3}
+		invite.Hydrate("team-id", "user-id")
+		require.NoError(t, invite.IsValid())
+		require.Equal(t, BoardRoleEditor, invite.Role)
+		require.Equal(t, "team-id", invite.TeamID)
+		require.NotEmpty(t, invite.Token)
+		require.Zero(t, invite.UseCount)
+	})
+}
+
+func TestInviteIsUsable(t *testing.T) {
+	testCases := []struct {
+		name   string
+		invite Invite
+		usable bool
+	}{
+		{"unlimited", Invite{}, true},
+		{"not expired", Invite{ExpiresAt: 2000}, true},
+		{"expired", Invite{ExpiresAt: 1000}, false},
+		{"uses left", Invite{MaxUses: 2, UseCount: 1}, true},
+		{"used up", Invite{MaxUses: 2, UseCount: 2}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.usable, tc.invite.IsUsable(1000))
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// The Jira fields that can be mapped to card properties.
+const (
+	JiraFieldIssueType = "issueType"
+	JiraFieldStatus    = "status"
+	JiraFieldPriority  = "priority"
+	JiraFieldAssignee  = "assignee"
+	JiraFieldLabels    = "labels"
+)
+
+// JiraImportRequest selects the Jira issues to import, either from an export
+// or fetched from a Jira site, and how they are mapped to the board
+// swagger:model
+type JiraImportRequest struct {
+	// The contents of a Jira XML or JSON export, takes precedence over the site
+	// required: false
+	Export string `json:"export"`
+
+	// The base URL of the Jira site to fetch the issues from
+	// required: false
+	URL string `json:"url"`
+
+	// The email of the Jira Cloud account the token belongs to, if empty the
+	// token is sent as a personal access token
+	// required: false
+	Email string `json:"email"`
+
+	// The API token or personal access token to fetch the issues with
+	// required: false
+	Token string `json:"token"`
+
+	// The JQL query selecting the issues to fetch
+	// required: false
+	JQL string `json:"jql"`
+
+	// The mapping of the issues to the board, the proposed mapping is used if empty
+	// required: false
+	Mapping *JiraImportMapping `json:"mapping"`
+}
+
+// JiraImportMapping describes how the Jira issues are mapped to a board
+// swagger:model
+type JiraImportMapping struct {
+	// The title of the board
+	// required: true
+	Title string `json:"title"`
+
+	// The Jira field the board view groups the cards by, it must be mapped to a select property
+	// required: false
+	GroupBy string `json:"groupBy"`
+
+	// The mapping of the Jira fields to card properties
+	// required: true
+	Fields []JiraFieldMapping `json:"fields"`
+}
+
+// JiraFieldMapping maps a Jira field to a card property
+// swagger:model
+type JiraFieldMapping struct {
+	// The Jira field, one of issueType, status, priority, assignee or labels
+	// required: true
+	Field string `json:"field"`
+
+	// The name of the card property, the field is not imported if empty
+	// required: false
+	Property string `json:"property"`
+
+	// The type of the card property, one of select, multiSelect or text
+	// required: true
+	Type string `json:"type"`
+
+	// The values of the field found in the issues, which become the options of select properties
+	// required: false
+	Values []string `json:"values"`
+}
+
+// JiraImportPreview is the proposed mapping of the Jira issues to a board,
+// for the user to adjust it before importing them
+// swagger:model
+type JiraImportPreview struct {
+	// The number of issues found
+	// required: true
+	IssueCount int `json:"issueCount"`
+
+	// The proposed mapping
+	// required: true
+	Mapping JiraImportMapping `json:"mapping"`
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+type JobStatus string
+
+const (
+	JobStatusPending JobStatus = "pending"
+	JobStatusRunning JobStatus = "running"
+	JobStatusSuccess JobStatus = "success"
+	JobStatusError   JobStatus = "error"
+
+	// DefaultJobMaxAttempts is the number of times a failing job runs
+	// before it is marked as failed.
+	DefaultJobMaxAttempts = 3
+)
+
+// Job is a unit of background work, persisted so it survives restarts
+// and can be picked up by any node of a cluster
+// swagger:model
+type Job struct {
+	// The id of the job
+	// required: true
+	ID string `json:"id"`
+
+	// The type of the job, which selects the worker running it
+	// required: true
+	Type string `json:"type"`
+
+	// The status of the job, one of pending, running, success or error
+	// required: true
+	Status JobStatus `json:"status"`
+
+	// The input of the job
+	// required: false
+	Data map[string]interface{} `json:"data"`
+
+	// The progress of a running job in percent
+	// required: false
+	Progress int `json:"progress"`
+
+	// The output of a successful job
+	// required: false
+	Result map[string]interface{} `json:"result"`
+
+	// The error of the last failed attempt
+	// required: false
+	Error string `json:"error"`
+
+	// The number of attempts so far
+	// required: true
+	Attempts int `json:"attempts"`
+
+	// The number of attempts before the job is marked as failed
+	// required: true
+	MaxAttempts int `json:"maxAttempts"`
+
+	// The id of the user who requested the job, empty for scheduled jobs
+	// required: false
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The time after which the job can run in milliseconds since the current epoch
+	// required: true
+	RunAt int64 `json:"runAt"`
+
+	// The time the last attempt started in milliseconds since the current epoch
+	// required: false
+	StartAt int64 `json:"startAt"`
+
+	// The last update time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+
+	// The time the job succeeded or failed for good in milliseconds since the current epoch
+	// required: false
+	CompleteAt int64 `json:"completeAt"`
+}
+
+// IsDone returns whether the job will not run anymore.
+func (j *Job) IsDone() bool {
+	return j.Status == JobStatusSuccess || j.Status == JobStatusError
+}
+
+// QueryJobsOptions filters the jobs returned by GetJobs.
+type QueryJobsOptions struct {
+	Type   string    // if non-empty, only jobs of this type
+	Status JobStatus // if non-empty, only jobs with this status
+	Limit  uint64    // if non-zero, the maximum number of jobs, newest first
+}
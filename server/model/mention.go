@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// Mention records that a user was @mentioned in a block, so that the
+// mentions the user didn't read can be listed and reminded.
+// swagger:model
+type Mention struct {
+	// The id of the mention
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the mentioned user
+	// required: true
+	UserID string `json:"userId"`
+
+	// The id of the team of the board
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The id of the card
+	// required: true
+	CardID string `json:"cardId"`
+
+	// The id of the block containing the mention
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The id of the user who wrote the mention
+	// required: true
+	MentionedBy string `json:"mentionedBy"`
+
+	// The text around the mention
+	// required: false
+	Extract string `json:"extract"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The time the user read the mention in milliseconds since the current epoch, or zero if unread
+	// required: true
+	ReadAt int64 `json:"readAt"`
+
+	// The time the user was reminded of the mention in milliseconds since the current epoch, or zero
+	// required: true
+	RemindedAt int64 `json:"remindedAt"`
+}
+
+// MentionsReadRequest identifies the mentions to mark as read
+// swagger:model
+type MentionsReadRequest struct {
+	// The ids of the mentions, all unread mentions of the user if empty
+	// required: false
+	MentionIDs []string `json:"mentionIds"`
+}
+
+// IsValid checks that the mention has the required ids.
+func (m *Mention) IsValid() error {
+	if m == nil {
+		return ErrInvalidMention{"cannot be nil"}
+	}
+	if m.UserID == "" {
+		return ErrInvalidMention{"missing user id"}
+	}
+	if m.BoardID == "" {
+		return ErrInvalidMention{"missing board id"}
+	}
+	if m.CardID == "" {
+		return ErrInvalidMention{"missing card id"}
+	}
+	if m.BlockID == "" {
+		return ErrInvalidMention{"missing block id"}
+	}
+	return nil
+}
+
+type ErrInvalidMention struct {
+	msg string
+}
+
+func (e ErrInvalidMention) Error() string {
+	return e.msg
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// BoardPropertyAssignMentioned is the key of the board property that
+// makes the users @mentioned in the description of a card its assignees.
+const BoardPropertyAssignMentioned = "assignMentioned"
+
+var atMentionRegexp = regexp.MustCompile(`\B@[[:alnum:]][[:alnum:]\.\-_:]*`)
+
+// AssignsMentioned returns true if the users @mentioned in the description
+// of the cards of the board are assigned to them.
+func AssignsMentioned(board *Board) bool {
+	assign, _ := board.Properties[BoardPropertyAssignMentioned].(bool)
+	return assign
+}
+
+// ExtractMentions returns the normalized usernames @mentioned in a text.
+func ExtractMentions(text string) map[string]struct{} {
+	mentions := make(map[string]struct{})
+	if !strings.Contains(text, "@") {
+		return mentions
+	}
+
+	for _, match := range atMentionRegexp.FindAllString(text, -1) {
+		name := mm_model.NormalizeUsername(match[1:])
+		if mm_model.IsValidUsernameAllowRemote(name) {
+			mentions[name] = struct{}{}
+		}
+	}
+	return mentions
+}
+
+// AssignMentionedUser returns the property values of the card with the
+// user set in its first empty assignee property. It returns false if the
+// user is already assigned to the card, or if the card has no empty
+// assignee property.
+func AssignMentionedUser(properties []CardProperty, card *Block, userID string) (map[string]interface{}, bool) {
+	values := CardPropertyValues(card)
+
+	propertyID := ""
+	for i := range properties {
+		if !properties[i].Assignee {
+			continue
+		}
+		assigned, _ := values[properties[i].ID].(string)
+		if assigned == userID {
+			return nil, false
+		}
+		if assigned == "" && propertyID == "" {
+			propertyID = properties[i].ID
+		}
+	}
+	if propertyID == "" {
+		return nil, false
+	}
+
+	updated := make(map[string]interface{}, len(values)+1)
+	for id, value := range values {
+		updated[id] = value
+	}
+	updated[propertyID] = userID
+	return updated, true
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignsMentioned(t *testing.T) {
+	require.False(t, AssignsMentioned(&Board{}))
+	require.False(t, AssignsMentioned(&Board{Properties: map[string]interface{}{BoardPropertyAssignMentioned: "true"}}))
+	require.True(t, AssignsMentioned(&Board{Properties: map[string]interface{}{BoardPropertyAssignMentioned: true}}))
+}
+
+func TestAssignMentionedUser(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "reporter", Type: PropertyTypePerson},
+		{ID: "owner", Type: PropertyTypePerson, Assignee: true},
+		{ID: "reviewer", Type: PropertyTypePerson, Assignee: true},
+	}
+	card := func(values map[string]interface{}) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": values}}
+	}
+
+	t.Run("first empty assignee property", func(t *testing.T) {
+		values, ok := AssignMentionedUser(properties, card(map[string]interface{}{"reporter": "user-1"}), "user-2")
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"reporter": "user-1", "owner": "user-2"}, values)
+
+		values, ok = AssignMentionedUser(properties, card(map[string]interface{}{"owner": "user-1"}), "user-2")
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"owner": "user-1", "reviewer": "user-2"}, values)
+	})
+
+	t.Run("card without values", func(t *testing.T) {
+		values, ok := AssignMentionedUser(properties, &Block{Type: TypeCard}, "user-2")
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"owner": "user-2"}, values)
+	})
+
+	t.Run("already assigned", func(t *testing.T) {
+		_, ok := AssignMentionedUser(properties, card(map[string]interface{}{"reviewer": "user-2"}), "user-2")
+		require.False(t, ok)
+	})
+
+	t.Run("no empty assignee property", func(t *testing.T) {
+		_, ok := AssignMentionedUser(properties, card(map[string]interface{}{"owner": "user-1", "reviewer": "user-3"}), "user-2")
+		require.False(t, ok)
+
+		_, ok = AssignMentionedUser(properties[:1], card(nil), "user-2")
+		require.False(t, ok)
+	})
+
+	t.Run("the card is left unchanged", func(t *testing.T) {
+		original := card(map[string]interface{}{"reporter": "user-1"})
+		_, ok := AssignMentionedUser(properties, original, "user-2")
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"reporter": "user-1"}, CardPropertyValues(original))
+	})
+}
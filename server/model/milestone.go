@@ -0,0 +1,238 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CardFieldMilestone is the card field that holds the id of the milestone
+// the card is attached to.
+const CardFieldMilestone = "milestoneId"
+
+// BoardPropertyDoneStatus is the key of the board property that holds the
+// select option marking the cards of the board as done, which the
+// milestone progress relies on.
+const BoardPropertyDoneStatus = "doneStatus"
+
+// MilestoneAtRiskWindow is how long before its due date an incomplete
+// milestone is reported at risk.
+const MilestoneAtRiskWindow = 7 * 24 * time.Hour
+
+const maxMilestoneTitleLength = 255
+
+// Milestone is a team milestone that the cards of any board of the team
+// can be attached to
+// swagger:model
+type Milestone struct {
+	// The id of the milestone
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the team the milestone belongs to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The title of the milestone
+	// required: true
+	Title string `json:"title"`
+
+	// The description of the milestone
+	// required: false
+	Description string `json:"description"`
+
+	// The due date of the milestone in miliseconds since the current epoch,
+	// 0 if it has none
+	// required: false
+	DueDate int64 `json:"dueDate"`
+
+	// The id of the user who created the milestone
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in miliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modified time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// MilestoneProgress summarizes the progress of the cards attached to a
+// milestone across the boards of its team
+// swagger:model
+type MilestoneProgress struct {
+	// The milestone
+	// required: true
+	Milestone *Milestone `json:"milestone"`
+
+	// The number of cards attached to the milestone
+	// required: true
+	Total int `json:"total"`
+
+	// The number of attached cards that are done
+	// required: true
+	Done int `json:"done"`
+
+	// True if the milestone isn't complete and is due soon or overdue
+	// required: true
+	AtRisk bool `json:"atRisk"`
+
+	// True if the milestone isn't complete and its due date has passed
+	// required: true
+	Overdue bool `json:"overdue"`
+
+	// The progress of the attached cards per board
+	// required: true
+	Boards []*MilestoneBoardProgress `json:"boards"`
+}
+
+// MilestoneBoardProgress is the progress of the cards of a board attached
+// to a milestone
+// swagger:model
+type MilestoneBoardProgress struct {
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The number of cards of the board attached to the milestone
+	// required: true
+	Total int `json:"total"`
+
+	// The number of those cards that are done
+	// required: true
+	Done int `json:"done"`
+}
+
+// DoneStatus is the select option that marks the cards of a board as done
+// swagger:model
+type DoneStatus struct {
+	// The id of the select property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The id of the option of the property that means done
+	// required: true
+	OptionID string `json:"optionId"`
+}
+
+// ErrInvalidMilestone is returned when a milestone isn't valid.
+type ErrInvalidMilestone struct {
+	msg string
+}
+
+func newErrInvalidMilestone(msg string) *ErrInvalidMilestone {
+	return &ErrInvalidMilestone{msg: msg}
+}
+
+// NewErrInvalidMilestone returns an error for a milestone that can't be
+// used, like one from another team.
+func NewErrInvalidMilestone(msg string) *ErrInvalidMilestone {
+	return newErrInvalidMilestone(msg)
+}
+
+func (e *ErrInvalidMilestone) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidMilestone returns true if the error is an ErrInvalidMilestone.
+func IsErrInvalidMilestone(err error) bool {
+	var errInvalid *ErrInvalidMilestone
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks the milestone values.
+func (m *Milestone) IsValid() error {
+	if m.TeamID == "" {
+		return newErrInvalidMilestone("milestone must have a team id")
+	}
+	if strings.TrimSpace(m.Title) == "" {
+		return newErrInvalidMilestone("title cannot be empty")
+	}
+	if utf8.RuneCountInString(m.Title) > maxMilestoneTitleLength {
+		return newErrInvalidMilestone(fmt.Sprintf("title cannot be longer than %d characters", maxMilestoneTitleLength))
+	}
+	if m.DueDate < 0 {
+		return newErrInvalidMilestone("due date cannot be negative")
+	}
+	return nil
+}
+
+// CardMilestone returns the id of the milestone the card is attached to,
+// or an empty string.
+func CardMilestone(card *Block) string {
+	milestoneID, _ := card.Fields[CardFieldMilestone].(string)
+	return milestoneID
+}
+
+// DoneStatusFromBoard returns the done status of a board, or nil if none
+// is set or it can't be read.
+func DoneStatusFromBoard(board *Board) *DoneStatus {
+	raw, ok := board.Properties[BoardPropertyDoneStatus]
+	if !ok || raw == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var status *DoneStatus
+	if err := json.Unmarshal(data, &status); err != nil || status == nil || status.PropertyID == "" {
+		return nil
+	}
+	return status
+}
+
+// IsCardDone returns true if the card has the done option. A nil status
+// never marks cards as done.
+func (s *DoneStatus) IsCardDone(card *Block) bool {
+	if s == nil {
+		return false
+	}
+	value, _ := CardPropertyValues(card)[s.PropertyID].(string)
+	return value != "" && value == s.OptionID
+}
+
+// AddCard counts a card of a board in the progress of the milestone.
+func (p *MilestoneProgress) AddCard(boardID string, done bool) {
+	var boardProgress *MilestoneBoardProgress
+	for _, bp := range p.Boards {
+		if bp.BoardID == boardID {
+			boardProgress = bp
+			break
+		}
+	}
+	if boardProgress == nil {
+		boardProgress = &MilestoneBoardProgress{BoardID: boardID}
+		p.Boards = append(p.Boards, boardProgress)
+	}
+
+	p.Total++
+	boardProgress.Total++
+	if done {
+		p.Done++
+		boardProgress.Done++
+	}
+}
+
+// UpdateRisk flags an incomplete milestone as at risk when its due date
+// is within MilestoneAtRiskWindow of now, and as overdue once it passed.
+func (p *MilestoneProgress) UpdateRisk(now time.Time) {
+	p.AtRisk = false
+	p.Overdue = false
+	if p.Milestone.DueDate == 0 || (p.Total > 0 && p.Done == p.Total) {
+		return
+	}
+
+	due := GetTimeForMillis(p.Milestone.DueDate)
+	p.Overdue = now.After(due)
+	p.AtRisk = p.Overdue || !now.Before(due.Add(-MilestoneAtRiskWindow))
+}
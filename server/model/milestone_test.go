@@ -0,0 +1,78 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMilestoneIsValid(t *testing.T) {
+	require.NoError(t, (&Milestone{TeamID: "team-id", Title: "Beta"}).IsValid())
+
+	err := (&Milestone{TeamID: "team-id", Title: "  "}).IsValid()
+	require.True(t, IsErrInvalidMilestone(err))
+
+	err = (&Milestone{Title: "Beta"}).IsValid()
+	require.True(t, IsErrInvalidMilestone(err))
+
+	err = (&Milestone{TeamID: "team-id", Title: "Beta", DueDate: -1}).IsValid()
+	require.True(t, IsErrInvalidMilestone(err))
+}
+
+func TestDoneStatusIsCardDone(t *testing.T) {
+	board := &Board{Properties: map[string]interface{}{
+		BoardPropertyDoneStatus: map[string]interface{}{"propertyId": "status", "optionId": "done"},
+	}}
+	status := DoneStatusFromBoard(board)
+	require.NotNil(t, status)
+
+	done := &Block{Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "done"}}}
+	todo := &Block{Fields: map[string]interface{}{"properties": map[string]interface{}{"status": "todo"}}}
+	require.True(t, status.IsCardDone(done))
+	require.False(t, status.IsCardDone(todo))
+
+	var none *DoneStatus
+	require.Nil(t, DoneStatusFromBoard(&Board{}))
+	require.False(t, none.IsCardDone(done))
+}
+
+func TestMilestoneProgress(t *testing.T) {
+	now := time.Date(2022, 5, 26, 12, 0, 0, 0, time.UTC)
+	millis := func(t time.Time) int64 { return t.UnixNano() / int64(time.Millisecond) }
+
+	progress := &MilestoneProgress{Milestone: &Milestone{DueDate: millis(now.Add(72 * time.Hour))}}
+	progress.AddCard("board-1", true)
+	progress.AddCard("board-1", false)
+	progress.AddCard("board-2", true)
+	require.Equal(t, 3, progress.Total)
+	require.Equal(t, 2, progress.Done)
+	require.Len(t, progress.Boards, 2)
+	require.Equal(t, 2, progress.Boards[0].Total)
+	require.Equal(t, 1, progress.Boards[0].Done)
+
+	t.Run("due soon", func(t *testing.T) {
+		progress.UpdateRisk(now)
+		require.True(t, progress.AtRisk)
+		require.False(t, progress.Overdue)
+	})
+
+	t.Run("overdue", func(t *testing.T) {
+		progress.UpdateRisk(now.Add(96 * time.Hour))
+		require.True(t, progress.AtRisk)
+		require.True(t, progress.Overdue)
+	})
+
+	t.Run("far from due", func(t *testing.T) {
+		progress.UpdateRisk(now.Add(-30 * 24 * time.Hour))
+		require.False(t, progress.AtRisk)
+	})
+
+	t.Run("complete", func(t *testing.T) {
+		complete := &MilestoneProgress{Milestone: progress.Milestone}
+		complete.AddCard("board-1", true)
+		complete.UpdateRisk(now.Add(96 * time.Hour))
+		require.False(t, complete.AtRisk)
+		require.False(t, complete.Overdue)
+	})
+}
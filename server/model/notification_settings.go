@@ -0,0 +1,190 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// UserPropNotificationSettings is the user prop that holds the
+// notification settings of the user.
+const UserPropNotificationSettings = "focalboard_notificationSettings"
+
+const quietHoursLayout = "15:04"
+
+// UserNotificationSettings are the settings that every notification
+// backend honors before notifying a user
+// swagger:model
+type UserNotificationSettings struct {
+	// The ids of the boards the user doesn't get notifications for
+	// required: true
+	MutedBoards []string `json:"mutedBoards"`
+
+	// The daily period without notifications
+	// required: false
+	QuietHours *QuietHours `json:"quietHours,omitempty"`
+
+	// Delivers the mentions even for muted boards and during quiet hours
+	// required: false
+	MentionsBreakThrough bool `json:"mentionsBreakThrough"`
+}
+
+// QuietHours is a daily period without notifications, which can span
+// midnight (e.g. from 22:00 to 08:00)
+// swagger:model
+type QuietHours struct {
+	// The start time, as HH:MM
+	// required: true
+	Start string `json:"start"`
+
+	// The end time, as HH:MM
+	// required: true
+	End string `json:"end"`
+
+	// The IANA time zone of the start and end times, UTC if empty
+	// required: false
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// ErrInvalidNotificationSettings is returned when notification settings
+// are not valid.
+type ErrInvalidNotificationSettings struct {
+	msg string
+}
+
+func newErrInvalidNotificationSettings(msg string) *ErrInvalidNotificationSettings {
+	return &ErrInvalidNotificationSettings{msg: msg}
+}
+
+func (e *ErrInvalidNotificationSettings) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidNotificationSettings returns true if the error is an ErrInvalidNotificationSettings.
+func IsErrInvalidNotificationSettings(err error) bool {
+	var errInvalid *ErrInvalidNotificationSettings
+	return errors.As(err, &errInvalid)
+}
+
+// NotificationSettingsFromUser returns the notification settings stored
+// in the props of the user. Users without settings, or with settings
+// that can't be read, get notified for everything.
+func NotificationSettingsFromUser(user *User) *UserNotificationSettings {
+	settings := &UserNotificationSettings{MutedBoards: []string{}}
+	if user == nil {
+		return settings
+	}
+
+	raw, ok := user.Props[UserPropNotificationSettings].(string)
+	if !ok || raw == "" {
+		return settings
+	}
+	if err := json.Unmarshal([]byte(raw), settings); err != nil {
+		return &UserNotificationSettings{MutedBoards: []string{}}
+	}
+	if settings.MutedBoards == nil {
+		settings.MutedBoards = []string{}
+	}
+	return settings
+}
+
+// ToUserPropPatch returns the patch that stores the settings in the
+// props of the user.
+func (s *UserNotificationSettings) ToUserPropPatch() (*UserPropPatch, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return &UserPropPatch{
+		UpdatedFields: map[string]string{UserPropNotificationSettings: string(data)},
+	}, nil
+}
+
+// IsValid checks the quiet hours of the settings.
+func (s *UserNotificationSettings) IsValid() error {
+	if s.QuietHours == nil {
+		return nil
+	}
+	return s.QuietHours.IsValid()
+}
+
+// IsValid checks the times and the time zone of the quiet hours.
+func (q *QuietHours) IsValid() error {
+	if _, err := time.Parse(quietHoursLayout, q.Start); err != nil {
+		return newErrInvalidNotificationSettings(fmt.Sprintf("invalid quiet hours start %q", q.Start))
+	}
+	if _, err := time.Parse(quietHoursLayout, q.End); err != nil {
+		return newErrInvalidNotificationSettings(fmt.Sprintf("invalid quiet hours end %q", q.End))
+	}
+	if _, err := time.LoadLocation(q.Timezone); err != nil {
+		return newErrInvalidNotificationSettings(fmt.Sprintf("invalid quiet hours time zone %q", q.Timezone))
+	}
+	return nil
+}
+
+// Contains returns true if the time is inside the quiet hours.
+func (q *QuietHours) Contains(t time.Time) bool {
+	start, err := time.Parse(quietHoursLayout, q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(quietHoursLayout, q.End)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	startMinute := start.Hour()*60 + start.Minute()
+	endMinute := end.Hour()*60 + end.Minute()
+
+	if startMinute <= endMinute {
+		return minute >= startMinute && minute < endMinute
+	}
+	// the period spans midnight
+	return minute >= startMinute || minute < endMinute
+}
+
+// IsBoardMuted returns true if the user muted the board.
+func (s *UserNotificationSettings) IsBoardMuted(boardID string) bool {
+	for _, id := range s.MutedBoards {
+		if id == boardID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBoardMuted mutes or unmutes a board.
+func (s *UserNotificationSettings) SetBoardMuted(boardID string, muted bool) {
+	boards := make([]string, 0, len(s.MutedBoards)+1)
+	for _, id := range s.MutedBoards {
+		if id != boardID {
+			boards = append(boards, id)
+		}
+	}
+	if muted {
+		boards = append(boards, boardID)
+	}
+	s.MutedBoards = boards
+}
+
+// ShouldNotify returns true if a notification about the board can be
+// delivered to the user at the given time.
+func (s *UserNotificationSettings) ShouldNotify(boardID string, isMention bool, now time.Time) bool {
+	if isMention && s.MentionsBreakThrough {
+		return true
+	}
+	if s.IsBoardMuted(boardID) {
+		return false
+	}
+	return s.QuietHours == nil || !s.QuietHours.Contains(now)
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuietHoursContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2022, 5, 26, hour, minute, 0, 0, time.UTC)
+	}
+
+	t.Run("same day period", func(t *testing.T) {
+		quiet := &QuietHours{Start: "12:00", End: "14:00"}
+		require.True(t, quiet.Contains(at(12, 0)))
+		require.True(t, quiet.Contains(at(13, 59)))
+		require.False(t, quiet.Contains(at(14, 0)))
+		require.False(t, quiet.Contains(at(11, 59)))
+	})
+
+	t.Run("period spanning midnight", func(t *testing.T) {
+		quiet := &QuietHours{Start: "22:00", End: "08:00"}
+		require.True(t, quiet.Contains(at(23, 30)))
+		require.True(t, quiet.Contains(at(7, 0)))
+		require.False(t, quiet.Contains(at(12, 0)))
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		quiet := &QuietHours{Start: "22:00", End: "08:00", Timezone: "America/New_York"}
+		require.NoError(t, quiet.IsValid())
+		// 03:00 UTC is 23:00 in New York
+		require.True(t, quiet.Contains(at(3, 0)))
+		require.False(t, quiet.Contains(at(15, 0)))
+	})
+}
+
+func TestUserNotificationSettingsShouldNotify(t *testing.T) {
+	now := time.Date(2022, 5, 26, 23, 0, 0, 0, time.UTC)
+
+	t.Run("no settings", func(t *testing.T) {
+		settings := NotificationSettingsFromUser(&User{Props: map[string]interface{}{}})
+		require.True(t, settings.ShouldNotify("board-id", false, now))
+	})
+
+	t.Run("muted board", func(t *testing.T) {
+		settings := &UserNotificationSettings{}
+		settings.SetBoardMuted("board-id", true)
+		require.False(t, settings.ShouldNotify("board-id", false, now))
+		require.False(t, settings.ShouldNotify("board-id", true, now))
+		require.True(t, settings.ShouldNotify("other-board-id", false, now))
+
+		settings.SetBoardMuted("board-id", false)
+		require.Empty(t, settings.MutedBoards)
+	})
+
+	t.Run("quiet hours with mentions breaking through", func(t *testing.T) {
+		settings := &UserNotificationSettings{
+			QuietHours:           &QuietHours{Start: "22:00", End: "08:00"},
+			MentionsBreakThrough: true,
+		}
+		require.False(t, settings.ShouldNotify("board-id", false, now))
+		require.True(t, settings.ShouldNotify("board-id", true, now))
+	})
+
+	t.Run("unreadable settings", func(t *testing.T) {
+		user := &User{Props: map[string]interface{}{UserPropNotificationSettings: "{"}}
+		require.True(t, NotificationSettingsFromUser(user).ShouldNotify("board-id", false, now))
+	})
+}
@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// NotificationThread is the root post of a channel under which the change
+// notifications of a card are threaded, so that a channel gets one thread
+// per card instead of a post per change.
+type NotificationThread struct {
+	// The id of the card the notifications are about
+	CardID string `json:"cardId"`
+
+	// The id of the channel the notifications are posted to
+	ChannelID string `json:"channelId"`
+
+	// The id of the first post of the thread
+	RootPostID string `json:"rootPostId"`
+
+	// The time the thread was started, in miliseconds since the current epoch
+	CreateAt int64 `json:"createAt"`
+}
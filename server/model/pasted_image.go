@@ -0,0 +1,78 @@
+package model
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pastedImageExtensions are the extensions of the image types that can be
+// pasted, by their content type.
+var pastedImageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// ErrInvalidPastedImage is returned when the body of a pasted image can't
+// be decoded or isn't an image.
+type ErrInvalidPastedImage struct {
+	msg string
+}
+
+// NewErrInvalidPastedImage returns an error for a pasted image that can't
+// be used.
+func NewErrInvalidPastedImage(msg string) *ErrInvalidPastedImage {
+	return &ErrInvalidPastedImage{msg: msg}
+}
+
+func (e *ErrInvalidPastedImage) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidPastedImage returns true if the error is an
+// ErrInvalidPastedImage.
+func IsErrInvalidPastedImage(err error) bool {
+	var errInvalid *ErrInvalidPastedImage
+	return errors.As(err, &errInvalid)
+}
+
+// DecodePastedImage returns the bytes of a pasted image, sent either as is
+// or as a base64 data URL, like data:image/png;base64,iVBORw0KGgo...
+func DecodePastedImage(body []byte) ([]byte, error) {
+	dataURL := bytes.TrimSpace(body)
+	if !bytes.HasPrefix(dataURL, []byte("data:")) {
+		return body, nil
+	}
+
+	comma := bytes.IndexByte(dataURL, ',')
+	if comma < 0 || !bytes.HasSuffix(dataURL[:comma], []byte(";base64")) {
+		return nil, NewErrInvalidPastedImage("the data URL must be base64 encoded")
+	}
+	data, err := base64.StdEncoding.DecodeString(string(dataURL[comma+1:]))
+	if err != nil {
+		return nil, NewErrInvalidPastedImage(fmt.Sprintf("invalid base64 data: %s", err))
+	}
+	return data, nil
+}
+
+// PastedImageFilename returns the name of a pasted image, with the
+// extension of its type detected from its bytes, like
+// pasted-image-2022-05-26-151705.png.
+func PastedImageFilename(data []byte, now time.Time) (string, error) {
+	if len(data) == 0 {
+		return "", NewErrInvalidPastedImage("the image is empty")
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := pastedImageExtensions[contentType]
+	if !ok {
+		return "", NewErrInvalidPastedImage(fmt.Sprintf("%s is not a supported image type", strings.SplitN(contentType, ";", 2)[0]))
+	}
+	return "pasted-image-" + now.Format("2006-01-02-150405") + ext, nil
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodePastedImage(t *testing.T) {
+	pngHeader := []byte("\x89PNG\r\n\x1a\n")
+
+	data, err := DecodePastedImage(pngHeader)
+	require.NoError(t, err)
+	require.Equal(t, pngHeader, data)
+
+	data, err = DecodePastedImage([]byte("data:image/png;base64," + base64.StdEncoding.EncodeToString(pngHeader) + "\n"))
+	require.NoError(t, err)
+	require.Equal(t, pngHeader, data)
+
+	_, err = DecodePastedImage([]byte("data:image/png,raw"))
+	require.True(t, IsErrInvalidPastedImage(err))
+
+	_, err = DecodePastedImage([]byte("data:image/png;base64,!!!"))
+	require.True(t, IsErrInvalidPastedImage(err))
+}
+
+func TestPastedImageFilename(t *testing.T) {
+	now := time.Date(2022, 5, 26, 15, 17, 5, 0, time.UTC)
+
+	filename, err := PastedImageFilename([]byte("\x89PNG\r\n\x1a\n"), now)
+	require.NoError(t, err)
+	require.Equal(t, "pasted-image-2022-05-26-151705.png", filename)
+
+	filename, err = PastedImageFilename([]byte("\xFF\xD8\xFF\xE0"), now)
+	require.NoError(t, err)
+	require.Equal(t, "pasted-image-2022-05-26-151705.jpg", filename)
+
+	_, err = PastedImageFilename([]byte("<svg></svg>"), now)
+	require.True(t, IsErrInvalidPastedImage(err))
+
+	_, err = PastedImageFilename(nil, now)
+	require.True(t, IsErrInvalidPastedImage(err))
+}
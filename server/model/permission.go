@@ -6,6 +6,7 @@ import (
 
 var (
 	PermissionViewTeam              = mmModel.PermissionViewTeam
+	PermissionManageTeam            = mmModel.PermissionManageTeam
 	PermissionViewMembers           = mmModel.PermissionViewMembers
 	PermissionCreatePublicChannel   = mmModel.PermissionCreatePublicChannel
 	PermissionCreatePrivateChannel  = mmModel.PermissionCreatePrivateChannel
@@ -15,5 +16,8 @@ var (
 	PermissionManageBoardRoles      = &mmModel.Permission{Id: "manage_board_roles", Name: "", Description: "", Scope: ""}
 	PermissionShareBoard            = &mmModel.Permission{Id: "share_board", Name: "", Description: "", Scope: ""}
 	PermissionManageBoardCards      = &mmModel.Permission{Id: "manage_board_cards", Name: "", Description: "", Scope: ""}
+	PermissionCommentBoardCards     = &mmModel.Permission{Id: "comment_board_cards", Name: "", Description: "", Scope: ""}
 	PermissionManageBoardProperties = &mmModel.Permission{Id: "manage_board_properties", Name: "", Description: "", Scope: ""}
+	PermissionEditLockedProperties  = &mmModel.Permission{Id: "edit_locked_properties", Name: "", Description: "", Scope: ""}
+	PermissionManageRestrictedViews = &mmModel.Permission{Id: "manage_restricted_views", Name: "", Description: "", Scope: ""}
 )
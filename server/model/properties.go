@@ -19,6 +19,10 @@ var ErrInvalidPropertyValue = errors.New("invalid property value")
 var ErrInvalidPropertyValueType = errors.New("invalid property value type")
 var ErrInvalidDate = errors.New("invalid date property")
 
+// notificationDateLayout is the layout of the dates of the boards without
+// a date format.
+const notificationDateLayout = "January 02, 2006"
+
 // PropValueResolver allows PropDef.GetValue to further decode property values, such as
 // looking up usernames from ids.
 type PropValueResolver interface {
@@ -54,6 +58,9 @@ type PropDef struct {
 	Name    string                   `json:"name"`
 	Type    string                   `json:"type"`
 	Options map[string]PropDefOption `json:"options"`
+
+	// Formatting is how the numbers and dates of the board are shown
+	Formatting *BoardFormatting `json:"-"`
 }
 
 // GetValue resolves the value of a property if the passed value is an ID for an option,
@@ -120,6 +127,12 @@ func (pd PropDef) GetValue(v interface{}, resolver PropValueResolver) (string, e
 			sb.WriteString(strings.ToUpper(opt.Value))
 		}
 		return sb.String(), nil
+
+	case "number":
+		// v is the number as typed, in a string
+		if s, ok := v.(string); ok {
+			return pd.Formatting.FormatNumber(s), nil
+		}
 	}
 	return fmt.Sprintf("%v", v), nil
 }
@@ -135,10 +148,11 @@ func (pd PropDef) ParseDate(s string) (string, error) {
 	if !ok {
 		return s, ErrInvalidDate
 	}
-	date := utils.GetTimeForMillis(tsFrom).Format("January 02, 2006")
+	layout := pd.Formatting.DateLayout(notificationDateLayout)
+	date := utils.GetTimeForMillis(tsFrom).Format(layout)
 	tsTo, ok := m["to"]
 	if ok {
-		date += " -> " + utils.GetTimeForMillis(tsTo).Format("January 02, 2006")
+		date += " -> " + utils.GetTimeForMillis(tsTo).Format(layout)
 	}
 	return date, nil
 }
@@ -146,17 +160,22 @@ func (pd PropDef) ParseDate(s string) (string, error) {
 // ParsePropertySchema parses a board block's `Fields` to extract the properties
 // schema for all cards within the board.
 // The result is provided as a map for quick lookup, and the original order is
-// preserved via the `Index` field.
+// preserved via the `Index` field. The definitions format the values with
+// the formatting settings of the board, the defaults if they can't be read.
 func ParsePropertySchema(board *Board) (PropSchema, error) {
 	schema := make(map[string]PropDef)
 
+	// malformed settings leave the values in their default format
+	formatting, _ := BoardFormattingFromBoard(board)
+
 	for i, prop := range board.CardProperties {
 		pd := PropDef{
-			ID:      getMapString("id", prop),
-			Index:   i,
-			Name:    getMapString("name", prop),
-			Type:    getMapString("type", prop),
-			Options: make(map[string]PropDefOption),
+			ID:         getMapString("id", prop),
+			Index:      i,
+			Name:       getMapString("name", prop),
+			Type:       getMapString("type", prop),
+			Options:    make(map[string]PropDefOption),
+			Formatting: formatting,
 		}
 		optsIface, ok := prop["options"]
 		if ok {
@@ -42,6 +42,25 @@ func Test_parsePropertySchema(t *testing.T) {
 		assert.Equal(t, "MyDate", prop.Name)
 		assert.Empty(t, prop.Options)
 	})
+
+	t.Run("values follow the board formatting", func(t *testing.T) {
+		board.Properties = map[string]interface{}{
+			BoardPropertyFormatting: map[string]interface{}{"decimalSeparator": ",", "dateFormat": DateFormatISO},
+		}
+		defer func() { board.Properties = nil }()
+
+		schema, err := ParsePropertySchema(board)
+		require.NoError(t, err)
+
+		value, err := schema["a8spou7if43eo1rqzb9qeq488so"].GetValue(`{"from":1653566400000}`, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "2022-05-26", value)
+
+		number := PropDef{Type: PropertyTypeNumber, Formatting: schema["a8spou7if43eo1rqzb9qeq488so"].Formatting}
+		value, err = number.GetValue("3.5", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "3,5", value)
+	})
 }
 
 const (
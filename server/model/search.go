@@ -0,0 +1,276 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultSearchResultsPerPage is the size of a page of search results
+	// when none is given.
+	DefaultSearchResultsPerPage = 20
+
+	// MaxSearchResultsPerPage is the largest page of search results that
+	// can be requested.
+	MaxSearchResultsPerPage = 200
+)
+
+// SearchResultType is the kind of item a search result is.
+type SearchResultType string
+
+const (
+	SearchResultTypeBoard      SearchResultType = "board"
+	SearchResultTypeCard       SearchResultType = "card"
+	SearchResultTypeComment    SearchResultType = "comment"
+	SearchResultTypeAttachment SearchResultType = "attachment"
+)
+
+// SearchResultTypes are all the kinds of search results, in the order
+// their facets are shown.
+var SearchResultTypes = []SearchResultType{
+	SearchResultTypeBoard,
+	SearchResultTypeCard,
+	SearchResultTypeComment,
+	SearchResultTypeAttachment,
+}
+
+// SearchableBlockTypes are the types of the blocks that can be found by
+// a search.
+var SearchableBlockTypes = []BlockType{TypeCard, TypeComment, TypeImage}
+
+// SearchResult is a board, card, comment or attachment matching a search
+// swagger:model
+type SearchResult struct {
+	// The kind of item, one of board, card, comment or attachment
+	// required: true
+	Type SearchResultType `json:"type"`
+
+	// The id of the board or block
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board of the item
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the board of the item
+	// required: true
+	BoardTitle string `json:"boardTitle"`
+
+	// The id of the parent block of the item, for comments and attachments
+	// the card they belong to
+	// required: false
+	ParentID string `json:"parentId,omitempty"`
+
+	// The title of the board or card, or the text of the comment
+	// required: true
+	Title string `json:"title"`
+
+	// The relevance of the item to the search, higher first
+	// required: true
+	Score int `json:"score"`
+
+	// The last update time in miliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// SearchResults is a page of ranked search results
+// swagger:model
+type SearchResults struct {
+	// The results of the page, most relevant first
+	// required: true
+	Results []*SearchResult `json:"results"`
+
+	// The number of results of the requested types across all pages
+	// required: true
+	Total int `json:"total"`
+
+	// The number of results of each type, regardless of the requested types
+	// required: true
+	Facets map[SearchResultType]int `json:"facets"`
+
+	// The page of the results, starting at 0
+	// required: true
+	Page int `json:"page"`
+
+	// The number of results per page
+	// required: true
+	PerPage int `json:"perPage"`
+}
+
+// SearchOptions are the terms, type and card filters and paging of a
+// search.
+type SearchOptions struct {
+	// The search terms, separated by spaces
+	Terms string
+
+	// The kinds of results to return, all of them if empty
+	Types []SearchResultType
+
+	// The filters of the results, parsed from the search query
+	Filters SearchFilters
+
+	// The page to return, starting at 0
+	Page int
+
+	// The number of results per page
+	PerPage int
+}
+
+// Paging returns the page and the number of results per page of the
+// search, with the defaults and limits applied.
+func (opts SearchOptions) Paging() (page int, perPage int) {
+	perPage = opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultSearchResultsPerPage
+	}
+	if perPage > MaxSearchResultsPerPage {
+		perPage = MaxSearchResultsPerPage
+	}
+	page = opts.Page
+	if page < 0 {
+		page = 0
+	}
+	return page, perPage
+}
+
+// ErrInvalidSearchResultType is returned when a search is filtered by an
+// unknown type of result.
+type ErrInvalidSearchResultType struct {
+	msg string
+}
+
+func newErrInvalidSearchResultType(msg string) *ErrInvalidSearchResultType {
+	return &ErrInvalidSearchResultType{msg: msg}
+}
+
+func (e *ErrInvalidSearchResultType) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidSearchResultType returns true if the error is an
+// ErrInvalidSearchResultType.
+func IsErrInvalidSearchResultType(err error) bool {
+	var errInvalid *ErrInvalidSearchResultType
+	return errors.As(err, &errInvalid)
+}
+
+// ParseSearchResultType returns the type of search result with the name.
+func ParseSearchResultType(name string) (SearchResultType, error) {
+	for _, t := range SearchResultTypes {
+		if string(t) == strings.ToLower(name) {
+			return t, nil
+		}
+	}
+	return "", newErrInvalidSearchResultType(fmt.Sprintf("invalid search result type: %s", name))
+}
+
+// SearchResultTypeForBlock returns the type of search result the block
+// is, and false if the block can't be a search result.
+func SearchResultTypeForBlock(block *Block) (SearchResultType, bool) {
+	switch block.Type {
+	case TypeCard:
+		return SearchResultTypeCard, !IsCardTemplate(block)
+	case TypeComment:
+		return SearchResultTypeComment, true
+	case TypeImage:
+		return SearchResultTypeAttachment, true
+	default:
+		return "", false
+	}
+}
+
+// SearchTerms splits a search into its lowercase words.
+func SearchTerms(search string) []string {
+	return strings.Fields(strings.ToLower(search))
+}
+
+// ScoreSearchMatch returns how relevant the text is to the search terms,
+// 0 if none of the terms appear in it. A text that is the whole search
+// ranks above one that starts with it, which ranks above one containing
+// it, and then texts with more of the terms rank higher.
+func ScoreSearchMatch(text string, terms []string) int {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" || len(terms) == 0 {
+		return 0
+	}
+
+	score := 0
+	for _, term := range terms {
+		if strings.Contains(text, term) {
+			score += 10
+		}
+	}
+	if score == 0 {
+		return 0
+	}
+
+	phrase := strings.Join(terms, " ")
+	switch {
+	case text == phrase:
+		score += 100
+	case strings.HasPrefix(text, phrase):
+		score += 60
+	case strings.Contains(text, phrase):
+		score += 40
+	}
+	return score
+}
+
+// RankSearchResults sorts the results by relevance, most recently updated
+// first among equally relevant ones, and returns the page of the results
+// of the requested types with the facets of all of them.
+func RankSearchResults(results []*SearchResult, opts SearchOptions) *SearchResults {
+	page, perPage := opts.Paging()
+
+	types := map[SearchResultType]bool{}
+	for _, t := range opts.Types {
+		types[t] = true
+	}
+
+	facets := map[SearchResultType]int{}
+	for _, t := range SearchResultTypes {
+		facets[t] = 0
+	}
+
+	selected := []*SearchResult{}
+	for _, result := range results {
+		facets[result.Type]++
+		if len(types) == 0 || types[result.Type] {
+			selected = append(selected, result)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Score != selected[j].Score {
+			return selected[i].Score > selected[j].Score
+		}
+		if selected[i].UpdateAt != selected[j].UpdateAt {
+			return selected[i].UpdateAt > selected[j].UpdateAt
+		}
+		return selected[i].ID < selected[j].ID
+	})
+
+	pageResults := []*SearchResult{}
+	if start := page * perPage; start < len(selected) {
+		end := start + perPage
+		if end > len(selected) {
+			end = len(selected)
+		}
+		pageResults = selected[start:end]
+	}
+
+	return &SearchResults{
+		Results: pageResults,
+		Total:   len(selected),
+		Facets:  facets,
+		Page:    page,
+		PerPage: perPage,
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	searchFilterProperty      = "property:"
+	searchFilterAssignee      = "assignee:"
+	searchFilterUpdatedAfter  = "updated_after:"
+	searchFilterUpdatedBefore = "updated_before:"
+
+	// SearchAssigneeMe is the assignee filter value that stands for the
+	// user searching.
+	SearchAssigneeMe = "me"
+)
+
+// SearchPropertyFilter selects the cards whose property with the name has
+// the value, by the name of the option for select properties.
+type SearchPropertyFilter struct {
+	Name  string
+	Value string
+}
+
+// SearchFilters are the structured filters of a search, written in the
+// search query as property:Name=Value, assignee:@username,
+// updated_after:2022-05-26 and updated_before:2022-05-26.
+type SearchFilters struct {
+	// The card properties that must have the values
+	Properties []SearchPropertyFilter
+
+	// The usernames the cards must be assigned to
+	Assignees []string
+
+	// The results must be updated at or after this time, in miliseconds
+	// since the current epoch, 0 for any time
+	UpdatedAfter int64
+
+	// The results must be updated before this time, in miliseconds since
+	// the current epoch, 0 for any time
+	UpdatedBefore int64
+}
+
+// IsEmpty returns true if the filters select every result.
+func (f SearchFilters) IsEmpty() bool {
+	return !f.HasCardFilters() && f.UpdatedAfter == 0 && f.UpdatedBefore == 0
+}
+
+// HasCardFilters returns true if the filters select results by the
+// properties of their cards, which leaves out the boards.
+func (f SearchFilters) HasCardFilters() bool {
+	return len(f.Properties) > 0 || len(f.Assignees) > 0
+}
+
+// MatchesUpdateAt returns true if the update time is within the updated
+// range of the filters.
+func (f SearchFilters) MatchesUpdateAt(updateAt int64) bool {
+	if f.UpdatedAfter != 0 && updateAt < f.UpdatedAfter {
+		return false
+	}
+	if f.UpdatedBefore != 0 && updateAt >= f.UpdatedBefore {
+		return false
+	}
+	return true
+}
+
+// ErrInvalidSearchFilter is returned when a filter of a search query
+// can't be parsed.
+type ErrInvalidSearchFilter struct {
+	msg string
+}
+
+func newErrInvalidSearchFilter(msg string) *ErrInvalidSearchFilter {
+	return &ErrInvalidSearchFilter{msg: msg}
+}
+
+func (e *ErrInvalidSearchFilter) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidSearchFilter returns true if the error is an
+// ErrInvalidSearchFilter.
+func IsErrInvalidSearchFilter(err error) bool {
+	var errInvalid *ErrInvalidSearchFilter
+	return errors.As(err, &errInvalid)
+}
+
+// ParseSearchQuery splits a search query into its search terms and its
+// filters. Names and values with spaces are written between double
+// quotes, like property:"Due date"="In progress". Dates are days, like
+// 2022-05-26, in UTC, or times in miliseconds since the current epoch.
+func ParseSearchQuery(query string) (string, SearchFilters, error) {
+	filters := SearchFilters{}
+	terms := []string{}
+	for _, token := range splitSearchQuery(query) {
+		lower := strings.ToLower(token)
+		switch {
+		case strings.HasPrefix(lower, searchFilterProperty):
+			parts := strings.SplitN(token[len(searchFilterProperty):], "=", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				return "", filters, newErrInvalidSearchFilter(fmt.Sprintf("invalid property filter %q, expected property:Name=Value", token))
+			}
+			filters.Properties = append(filters.Properties, SearchPropertyFilter{
+				Name:  strings.TrimSpace(parts[0]),
+				Value: strings.TrimSpace(parts[1]),
+			})
+		case strings.HasPrefix(lower, searchFilterAssignee):
+			username := strings.TrimPrefix(token[len(searchFilterAssignee):], "@")
+			if username == "" {
+				return "", filters, newErrInvalidSearchFilter(fmt.Sprintf("invalid assignee filter %q, expected assignee:@username", token))
+			}
+			filters.Assignees = append(filters.Assignees, username)
+		case strings.HasPrefix(lower, searchFilterUpdatedAfter):
+			after, err := parseSearchFilterTime(token[len(searchFilterUpdatedAfter):])
+			if err != nil {
+				return "", filters, err
+			}
+			filters.UpdatedAfter = after
+		case strings.HasPrefix(lower, searchFilterUpdatedBefore):
+			before, err := parseSearchFilterTime(token[len(searchFilterUpdatedBefore):])
+			if err != nil {
+				return "", filters, err
+			}
+			filters.UpdatedBefore = before
+		default:
+			terms = append(terms, token)
+		}
+	}
+	return strings.Join(terms, " "), filters, nil
+}
+
+// splitSearchQuery splits the query on the spaces outside of double
+// quotes, removing the quotes.
+func splitSearchQuery(query string) []string {
+	tokens := []string{}
+	var token strings.Builder
+	inQuotes := false
+	inToken := false
+	for _, r := range query {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			inToken = true
+		case unicode.IsSpace(r) && !inQuotes:
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+	return tokens
+}
+
+func parseSearchFilterTime(value string) (int64, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil && millis > 0 {
+		return millis, nil
+	}
+	day, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return 0, newErrInvalidSearchFilter(fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", value))
+	}
+	// the nanoseconds of the dates after 2262 overflow, days are whole seconds
+	return day.Unix() * 1000, nil
+}
+
+// cardSearchPropertyFilter is a filter resolved against the properties of
+// a board. It matches the cards with any of the values in any of the
+// properties.
+type cardSearchPropertyFilter struct {
+	propertyIDs []string
+	values      []string
+}
+
+// CardSearchFilter is the card filters of a search resolved against the
+// card property schema of a board.
+type CardSearchFilter struct {
+	properties []cardSearchPropertyFilter
+}
+
+// NewCardSearchFilter resolves the property names and option values of the
+// filters against the card properties of a board, and the assignees
+// against its assignee properties, or its person properties if none of
+// them assigns cards. assigneeIDs are the ids of the users of the assignee
+// filters, in the same order. It returns false if no card of the board
+// can match, when the board lacks one of the properties or options.
+func NewCardSearchFilter(properties []CardProperty, filters SearchFilters, assigneeIDs []string) (*CardSearchFilter, bool) {
+	filter := &CardSearchFilter{}
+	for _, propertyFilter := range filters.Properties {
+		property := findCardPropertyByName(properties, propertyFilter.Name)
+		if property == nil {
+			return nil, false
+		}
+
+		values := []string{propertyFilter.Value}
+		if PropertyTypeHasOptions(property.Type) && propertyFilter.Value != "" {
+			values = []string{}
+			for _, option := range property.Options {
+				if strings.EqualFold(option.Value, propertyFilter.Value) {
+					values = append(values, option.ID)
+				}
+			}
+			if len(values) == 0 {
+				return nil, false
+			}
+		}
+		filter.properties = append(filter.properties, cardSearchPropertyFilter{propertyIDs: []string{property.ID}, values: values})
+	}
+
+	if len(assigneeIDs) == 0 {
+		return filter, true
+	}
+
+	assigneePropertyIDs := []string{}
+	personPropertyIDs := []string{}
+	for i := range properties {
+		if properties[i].Type != PropertyTypePerson {
+			continue
+		}
+		personPropertyIDs = append(personPropertyIDs, properties[i].ID)
+		if properties[i].Assignee {
+			assigneePropertyIDs = append(assigneePropertyIDs, properties[i].ID)
+		}
+	}
+	if len(assigneePropertyIDs) == 0 {
+		assigneePropertyIDs = personPropertyIDs
+	}
+	if len(assigneePropertyIDs) == 0 {
+		return nil, false
+	}
+
+	for _, assigneeID := range assigneeIDs {
+		filter.properties = append(filter.properties, cardSearchPropertyFilter{propertyIDs: assigneePropertyIDs, values: []string{assigneeID}})
+	}
+	return filter, true
+}
+
+// Matches returns true if the card matches every filter.
+func (f *CardSearchFilter) Matches(card *Block) bool {
+	values := CardPropertyValues(card)
+	for _, propertyFilter := range f.properties {
+		matched := false
+		for _, propertyID := range propertyFilter.propertyIDs {
+			if searchValueMatches(values[propertyID], propertyFilter.values) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func findCardPropertyByName(properties []CardProperty, name string) *CardProperty {
+	for i := range properties {
+		if strings.EqualFold(properties[i].Name, name) {
+			return &properties[i]
+		}
+	}
+	return nil
+}
+
+func searchValueMatches(value interface{}, expected []string) bool {
+	switch v := value.(type) {
+	case nil:
+		return containsEqualFold(expected, "")
+	case []interface{}:
+		if len(v) == 0 {
+			return containsEqualFold(expected, "")
+		}
+		for _, item := range v {
+			if containsEqualFold(expected, fmt.Sprint(item)) {
+				return true
+			}
+		}
+		return false
+	default:
+		return containsEqualFold(expected, fmt.Sprint(v))
+	}
+}
+
+func containsEqualFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	t.Run("terms and filters", func(t *testing.T) {
+		terms, filters, err := ParseSearchQuery(`release property:Status=Done plan assignee:@alice property:"Due date"="next week" updated_after:2022-05-01 updated_before:1654041600000`)
+		require.NoError(t, err)
+		require.Equal(t, "release plan", terms)
+		require.Equal(t, []SearchPropertyFilter{
+			{Name: "Status", Value: "Done"},
+			{Name: "Due date", Value: "next week"},
+		}, filters.Properties)
+		require.Equal(t, []string{"alice"}, filters.Assignees)
+		require.Equal(t, int64(1651363200000), filters.UpdatedAfter)
+		require.Equal(t, int64(1654041600000), filters.UpdatedBefore)
+	})
+
+	t.Run("dates far in the future", func(t *testing.T) {
+		_, filters, err := ParseSearchQuery("updated_after:2999-01-01")
+		require.NoError(t, err)
+		require.Equal(t, int64(32472144000000), filters.UpdatedAfter)
+	})
+
+	t.Run("no filters", func(t *testing.T) {
+		terms, filters, err := ParseSearchQuery("  release  plan ")
+		require.NoError(t, err)
+		require.Equal(t, "release plan", terms)
+		require.True(t, filters.IsEmpty())
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, query := range []string{"property:Status", "property:=Done", "assignee:@", "updated_after:yesterday"} {
+			_, _, err := ParseSearchQuery(query)
+			require.True(t, IsErrInvalidSearchFilter(err), query)
+		}
+	})
+}
+
+func TestSearchFiltersMatchesUpdateAt(t *testing.T) {
+	filters := SearchFilters{UpdatedAfter: 10, UpdatedBefore: 20}
+	require.True(t, filters.MatchesUpdateAt(10))
+	require.True(t, filters.MatchesUpdateAt(19))
+	require.False(t, filters.MatchesUpdateAt(9))
+	require.False(t, filters.MatchesUpdateAt(20))
+	require.True(t, SearchFilters{}.MatchesUpdateAt(0))
+}
+
+func TestCardSearchFilter(t *testing.T) {
+	properties := []CardProperty{
+		{ID: "status", Name: "Status", Type: PropertyTypeSelect, Options: []CardPropertyOption{
+			{ID: "status-done", Value: "Done"},
+			{ID: "status-todo", Value: "To do"},
+		}},
+		{ID: "tags", Name: "Tags", Type: PropertyTypeMultiSelect, Options: []CardPropertyOption{
+			{ID: "tag-bug", Value: "Bug"},
+		}},
+		{ID: "estimate", Name: "Estimate", Type: PropertyTypeNumber},
+		{ID: "reviewer", Name: "Reviewer", Type: PropertyTypePerson},
+		{ID: "owner", Name: "Owner", Type: PropertyTypePerson, Assignee: true},
+	}
+	card := func(values map[string]interface{}) *Block {
+		return &Block{Type: TypeCard, Fields: map[string]interface{}{"properties": values}}
+	}
+
+	t.Run("properties by name and option value", func(t *testing.T) {
+		filter, ok := NewCardSearchFilter(properties, SearchFilters{Properties: []SearchPropertyFilter{
+			{Name: "status", Value: "done"},
+			{Name: "Tags", Value: "bug"},
+			{Name: "Estimate", Value: "3"},
+		}}, nil)
+		require.True(t, ok)
+		require.True(t, filter.Matches(card(map[string]interface{}{"status": "status-done", "tags": []interface{}{"tag-bug"}, "estimate": "3"})))
+		require.False(t, filter.Matches(card(map[string]interface{}{"status": "status-todo", "tags": []interface{}{"tag-bug"}, "estimate": "3"})))
+		require.False(t, filter.Matches(card(map[string]interface{}{"status": "status-done", "estimate": "3"})))
+	})
+
+	t.Run("empty value", func(t *testing.T) {
+		filter, ok := NewCardSearchFilter(properties, SearchFilters{Properties: []SearchPropertyFilter{{Name: "Status", Value: ""}}}, nil)
+		require.True(t, ok)
+		require.True(t, filter.Matches(card(nil)))
+		require.False(t, filter.Matches(card(map[string]interface{}{"status": "status-done"})))
+	})
+
+	t.Run("assignee properties", func(t *testing.T) {
+		filter, ok := NewCardSearchFilter(properties, SearchFilters{Assignees: []string{"alice"}}, []string{"alice-id"})
+		require.True(t, ok)
+		require.True(t, filter.Matches(card(map[string]interface{}{"owner": "alice-id"})))
+		require.False(t, filter.Matches(card(map[string]interface{}{"reviewer": "alice-id"})))
+	})
+
+	t.Run("person properties without assignee ones", func(t *testing.T) {
+		filter, ok := NewCardSearchFilter(properties[:4], SearchFilters{Assignees: []string{"alice"}}, []string{"alice-id"})
+		require.True(t, ok)
+		require.True(t, filter.Matches(card(map[string]interface{}{"reviewer": "alice-id"})))
+	})
+
+	t.Run("boards without the property or option", func(t *testing.T) {
+		_, ok := NewCardSearchFilter(properties, SearchFilters{Properties: []SearchPropertyFilter{{Name: "Priority", Value: "High"}}}, nil)
+		require.False(t, ok)
+
+		_, ok = NewCardSearchFilter(properties, SearchFilters{Properties: []SearchPropertyFilter{{Name: "Status", Value: "Blocked"}}}, nil)
+		require.False(t, ok)
+
+		_, ok = NewCardSearchFilter(properties[:3], SearchFilters{Assignees: []string{"alice"}}, []string{"alice-id"})
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchResultType(t *testing.T) {
+	resultType, err := ParseSearchResultType("Card")
+	require.NoError(t, err)
+	require.Equal(t, SearchResultTypeCard, resultType)
+
+	_, err = ParseSearchResultType("view")
+	require.True(t, IsErrInvalidSearchResultType(err))
+}
+
+func TestSearchResultTypeForBlock(t *testing.T) {
+	resultType, ok := SearchResultTypeForBlock(&Block{Type: TypeCard})
+	require.True(t, ok)
+	require.Equal(t, SearchResultTypeCard, resultType)
+
+	_, ok = SearchResultTypeForBlock(&Block{Type: TypeCard, Fields: map[string]interface{}{"isTemplate": true}})
+	require.False(t, ok)
+
+	resultType, ok = SearchResultTypeForBlock(&Block{Type: TypeImage})
+	require.True(t, ok)
+	require.Equal(t, SearchResultTypeAttachment, resultType)
+
+	_, ok = SearchResultTypeForBlock(&Block{Type: TypeView})
+	require.False(t, ok)
+}
+
+func TestScoreSearchMatch(t *testing.T) {
+	terms := SearchTerms("  Release  Plan ")
+	require.Equal(t, []string{"release", "plan"}, terms)
+
+	exact := ScoreSearchMatch("Release plan", terms)
+	prefix := ScoreSearchMatch("Release plan for Q3", terms)
+	contains := ScoreSearchMatch("The release plan", terms)
+	allWords := ScoreSearchMatch("Plan the release", terms)
+	oneWord := ScoreSearchMatch("Release notes", terms)
+
+	require.Greater(t, exact, prefix)
+	require.Greater(t, prefix, contains)
+	require.Greater(t, contains, allWords)
+	require.Greater(t, allWords, oneWord)
+	require.Greater(t, oneWord, 0)
+
+	require.Zero(t, ScoreSearchMatch("Roadmap", terms))
+	require.Zero(t, ScoreSearchMatch("", terms))
+	require.Zero(t, ScoreSearchMatch("Release plan", nil))
+}
+
+func TestRankSearchResults(t *testing.T) {
+	results := []*SearchResult{
+		{Type: SearchResultTypeCard, ID: "card-1", Score: 10, UpdateAt: 1},
+		{Type: SearchResultTypeBoard, ID: "board-1", Score: 110},
+		{Type: SearchResultTypeComment, ID: "comment-1", Score: 10, UpdateAt: 2},
+		{Type: SearchResultTypeCard, ID: "card-2", Score: 50},
+	}
+
+	t.Run("all types", func(t *testing.T) {
+		ranked := RankSearchResults(results, SearchOptions{PerPage: 2})
+		require.Equal(t, 4, ranked.Total)
+		require.Equal(t, 2, ranked.PerPage)
+		require.Len(t, ranked.Results, 2)
+		require.Equal(t, "board-1", ranked.Results[0].ID)
+		require.Equal(t, "card-2", ranked.Results[1].ID)
+
+		ranked = RankSearchResults(results, SearchOptions{Page: 1, PerPage: 2})
+		require.Len(t, ranked.Results, 2)
+		require.Equal(t, "comment-1", ranked.Results[0].ID)
+		require.Equal(t, "card-1", ranked.Results[1].ID)
+
+		ranked = RankSearchResults(results, SearchOptions{Page: 2, PerPage: 2})
+		require.Empty(t, ranked.Results)
+		require.Equal(t, 4, ranked.Total)
+	})
+
+	t.Run("filtered types", func(t *testing.T) {
+		ranked := RankSearchResults(results, SearchOptions{Types: []SearchResultType{SearchResultTypeCard}})
+		require.Equal(t, 2, ranked.Total)
+		require.Equal(t, DefaultSearchResultsPerPage, ranked.PerPage)
+		require.Len(t, ranked.Results, 2)
+		require.Equal(t, "card-2", ranked.Results[0].ID)
+		require.Equal(t, map[SearchResultType]int{
+			SearchResultTypeBoard:      1,
+			SearchResultTypeCard:       2,
+			SearchResultTypeComment:    1,
+			SearchResultTypeAttachment: 0,
+		}, ranked.Facets)
+	})
+}
@@ -2,7 +2,11 @@ package model
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net"
+	"strings"
 )
 
 // Sharing is sharing information for a root block
@@ -27,6 +31,11 @@ type Sharing struct {
 	// Updated time in miliseconds since the current epoch
 	// required: true
 	UpdateAt int64 `json:"update_at,omitempty"`
+
+	// The CIDR ranges of the addresses the link can be used from, any
+	// address when empty
+	// required: false
+	AllowedCIDRs []string `json:"allowedCidrs,omitempty"`
 }
 
 func SharingFromJSON(data io.Reader) Sharing {
@@ -34,3 +43,59 @@ func SharingFromJSON(data io.Reader) Sharing {
 	_ = json.NewDecoder(data).Decode(&sharing)
 	return sharing
 }
+
+// IsValid returns an ErrInvalidSharing if the allowed ranges of the
+// sharing are not valid CIDR ranges.
+func (s Sharing) IsValid() error {
+	return ValidateCIDRs(s.AllowedCIDRs)
+}
+
+// ValidateCIDRs returns an ErrInvalidSharing if any of the ranges is not a
+// valid CIDR range, like 10.0.0.0/8 or 2001:db8::/32.
+func ValidateCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return newErrInvalidSharing(fmt.Sprintf("invalid CIDR range %q", cidr))
+		}
+	}
+	return nil
+}
+
+// IsIPInCIDRs returns true if the ranges are empty or if the address is in
+// any of them. Invalid ranges and addresses never match.
+func IsIPInCIDRs(ip string, cidrs []string) bool {
+	if len(cidrs) == 0 {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err == nil && ipNet.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrInvalidSharing is returned when the sharing of a board is not valid.
+type ErrInvalidSharing struct {
+	msg string
+}
+
+func newErrInvalidSharing(msg string) *ErrInvalidSharing {
+	return &ErrInvalidSharing{msg: msg}
+}
+
+func (e *ErrInvalidSharing) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidSharing returns true if the error is an ErrInvalidSharing.
+func IsErrInvalidSharing(err error) bool {
+	var errInvalid *ErrInvalidSharing
+	return errors.As(err, &errInvalid)
+}
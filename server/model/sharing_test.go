@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharingIsValid(t *testing.T) {
+	require.NoError(t, Sharing{}.IsValid())
+	require.NoError(t, Sharing{AllowedCIDRs: []string{"10.0.0.0/8", " 2001:db8::/32"}}.IsValid())
+	require.True(t, IsErrInvalidSharing(Sharing{AllowedCIDRs: []string{"10.0.0.1"}}.IsValid()))
+	require.True(t, IsErrInvalidSharing(Sharing{AllowedCIDRs: []string{"10.0.0.0/33"}}.IsValid()))
+}
+
+func TestIsIPInCIDRs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ip       string
+		cidrs    []string
+		expected bool
+	}{
+		{"no ranges", "203.0.113.5", nil, true},
+		{"in range", "10.1.2.3", []string{"192.168.0.0/16", "10.0.0.0/8"}, true},
+		{"out of range", "203.0.113.5", []string{"10.0.0.0/8"}, false},
+		{"ipv6 in range", "2001:db8::1", []string{"2001:db8::/32"}, true},
+		{"invalid address", "not-an-ip", []string{"10.0.0.0/8"}, false},
+		{"invalid range", "10.1.2.3", []string{"10.0.0.0"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsIPInCIDRs(tc.ip, tc.cidrs))
+		})
+	}
+}
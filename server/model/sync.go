@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	SyncOperationInsert = "insert"
+	SyncOperationPatch  = "patch"
+	SyncOperationDelete = "delete"
+
+	// MaxSyncOperations is the maximum number of operations of a sync request.
+	MaxSyncOperations = 100
+)
+
+// SyncOperation is a change to a block of a board submitted by a client
+// through the sync API. Applied operations are kept in the board
+// operation log, ordered by revision.
+// swagger:model
+type SyncOperation struct {
+	// The id of the operation, generated by the client
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The revision of the board the operation produced, set by the server
+	// required: false
+	Revision int64 `json:"revision"`
+
+	// The operation type, one of insert, patch or delete
+	// required: true
+	Type string `json:"type"`
+
+	// The id of the block the operation applies to
+	// required: true
+	BlockID string `json:"blockId"`
+
+	// The block to insert, for insert operations
+	// required: false
+	Block *Block `json:"block,omitempty"`
+
+	// The patch to apply, for patch operations
+	// required: false
+	Patch *BlockPatch `json:"patch,omitempty"`
+
+	// The id of the user who submitted the operation, set by the server
+	// required: false
+	UserID string `json:"userId"`
+
+	// The time the operation was applied in milliseconds since the current epoch, set by the server
+	// required: false
+	CreateAt int64 `json:"createAt"`
+}
+
+// SyncRequest is a batch of operations submitted by a client that last
+// synced the board at BaseRevision
+// swagger:model
+type SyncRequest struct {
+	// The last revision of the board the client knows about
+	// required: true
+	BaseRevision int64 `json:"baseRevision"`
+
+	// The operations to apply, in order. May be empty to only pull changes
+	// required: true
+	Operations []*SyncOperation `json:"operations"`
+}
+
+// SyncConflict describes an operation that wasn't applied
+// swagger:model
+type SyncConflict struct {
+	// The id of the operation
+	// required: true
+	OperationID string `json:"operationId"`
+
+	// The reason the operation wasn't applied
+	// required: true
+	Reason string `json:"reason"`
+
+	// The current version of the block on the server, if it exists
+	// required: false
+	Block *Block `json:"block,omitempty"`
+}
+
+// SyncResponse is the result of a sync request
+// swagger:model
+type SyncResponse struct {
+	// The latest revision of the board
+	// required: true
+	Revision int64 `json:"revision"`
+
+	// The ids of the operations of the request that were applied
+	// required: true
+	Applied []string `json:"applied"`
+
+	// The operations of the request that conflicted with other changes
+	// required: true
+	Conflicts []SyncConflict `json:"conflicts"`
+
+	// The authoritative operations after the base revision, including the applied ones
+	// required: true
+	Operations []*SyncOperation `json:"operations"`
+}
+
+// ErrInvalidSyncRequest is returned when a sync request is not valid.
+type ErrInvalidSyncRequest struct {
+	msg string
+}
+
+func NewErrInvalidSyncRequest(msg string) *ErrInvalidSyncRequest {
+	return &ErrInvalidSyncRequest{msg: msg}
+}
+
+func (e *ErrInvalidSyncRequest) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidSyncRequest returns true if the error is an ErrInvalidSyncRequest.
+func IsErrInvalidSyncRequest(err error) bool {
+	var errInvalid *ErrInvalidSyncRequest
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks the request for the board, before any operation is applied.
+func (r *SyncRequest) IsValid(boardID string) error {
+	if r.BaseRevision < 0 {
+		return NewErrInvalidSyncRequest("base revision cannot be negative")
+	}
+	if len(r.Operations) > MaxSyncOperations {
+		return NewErrInvalidSyncRequest(fmt.Sprintf("a sync request cannot have more than %d operations", MaxSyncOperations))
+	}
+
+	ids := map[string]bool{}
+	for _, op := range r.Operations {
+		if op == nil || op.ID == "" {
+			return NewErrInvalidSyncRequest("operation id is required")
+		}
+		if ids[op.ID] {
+			return NewErrInvalidSyncRequest(fmt.Sprintf("duplicate operation id %s", op.ID))
+		}
+		ids[op.ID] = true
+
+		if err := op.isValid(boardID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *SyncOperation) isValid(boardID string) error {
+	if o.BlockID == "" {
+		return NewErrInvalidSyncRequest(fmt.Sprintf("operation %s has no block id", o.ID))
+	}
+
+	switch o.Type {
+	case SyncOperationInsert:
+		if o.Block == nil {
+			return NewErrInvalidSyncRequest(fmt.Sprintf("insert operation %s has no block", o.ID))
+		}
+		if o.Block.ID != o.BlockID || o.Block.BoardID != boardID {
+			return NewErrInvalidSyncRequest(fmt.Sprintf("insert operation %s block doesn't match the operation", o.ID))
+		}
+		if o.Block.Type == "" {
+			return NewErrInvalidSyncRequest(fmt.Sprintf("insert operation %s block has no type", o.ID))
+		}
+	case SyncOperationPatch:
+		if o.Patch == nil {
+			return NewErrInvalidSyncRequest(fmt.Sprintf("patch operation %s has no patch", o.ID))
+		}
+	case SyncOperationDelete:
+	default:
+		return NewErrInvalidSyncRequest(fmt.Sprintf("operation %s has an unknown type %s", o.ID, o.Type))
+	}
+	return nil
+}
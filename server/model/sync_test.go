@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncRequestIsValid(t *testing.T) {
+	title := "title"
+	valid := func() *SyncRequest {
+		return &SyncRequest{
+			BaseRevision: 3,
+			Operations: []*SyncOperation{
+				{ID: "op-1", Type: SyncOperationInsert, BlockID: "block-1", Block: &Block{ID: "block-1", BoardID: "board-id", Type: TypeCard}},
+				{ID: "op-2", Type: SyncOperationPatch, BlockID: "block-1", Patch: &BlockPatch{Title: &title}},
+				{ID: "op-3", Type: SyncOperationDelete, BlockID: "block-2"},
+			},
+		}
+	}
+
+	require.NoError(t, valid().IsValid("board-id"))
+
+	testCases := []struct {
+		name   string
+		modify func(r *SyncRequest)
+	}{
+		{"negative base revision", func(r *SyncRequest) { r.BaseRevision = -1 }},
+		{"missing operation id", func(r *SyncRequest) { r.Operations[0].ID = "" }},
+		{"duplicate operation id", func(r *SyncRequest) { r.Operations[1].ID = "op-1" }},
+		{"unknown type", func(r *SyncRequest) { r.Operations[2].Type = "move" }},
+		{"insert without block", func(r *SyncRequest) { r.Operations[0].Block = nil }},
+		{"insert into another board", func(r *SyncRequest) { r.Operations[0].Block.BoardID = "other-board" }},
+		{"patch without patch", func(r *SyncRequest) { r.Operations[1].Patch = nil }},
+		{"too many operations", func(r *SyncRequest) {
+			r.Operations = make([]*SyncOperation, MaxSyncOperations+1)
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := valid()
+			tc.modify(r)
+			require.True(t, IsErrInvalidSyncRequest(r.IsValid("board-id")))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "errors"
+
+// ErrInvalidMigrationArchive is returned when a team migration archive
+// can't be read.
+var ErrInvalidMigrationArchive = errors.New("invalid team migration archive")
+
+// ErrMigrationCountMismatch is returned when the blocks stored for an
+// imported board don't match the ones of the archive.
+var ErrMigrationCountMismatch = errors.New("migrated block count mismatch")
+
+// The line types of the users and team files of a migration archive, the
+// boards are stored as in a regular archive.
+const (
+	MigrationLineUser         = "user"
+	MigrationLineMember       = "member"
+	MigrationLineSharing      = "sharing"
+	MigrationLineSubscription = "subscription"
+	MigrationLineCategory     = "category"
+)
+
+// MigrationUser is a user referenced in a team migration archive, listed
+// so the users can be matched with the ones of the target server
+// swagger:model
+type MigrationUser struct {
+	// The id of the user on the source server
+	// required: true
+	ID string `json:"id"`
+
+	// The username of the user
+	// required: true
+	Username string `json:"username"`
+
+	// The email of the user
+	// required: false
+	Email string `json:"email"`
+}
+
+// TeamMigrationOptions provides the options when importing a team
+// migration archive.
+type TeamMigrationOptions struct {
+	TeamID     string
+	ModifiedBy string
+
+	// UserMap translates the user ids of the source server to the ones of
+	// the target server. Users missing from it keep their id if it exists
+	// on the target server.
+	UserMap map[string]string
+
+	// MapUsersByEmail matches the users missing from the user map with the
+	// users of the target server that have the same email.
+	MapUsersByEmail bool
+}
+
+// TeamMigrationResult reports what a team migration import created
+// swagger:model
+type TeamMigrationResult struct {
+	// The ids of the imported boards
+	// required: true
+	BoardIDs []string `json:"boardIds"`
+
+	// The ids of the boards that were skipped as they already exist on this server
+	// required: true
+	SkippedBoardIDs []string `json:"skippedBoardIds"`
+
+	// The ids of the source users that couldn't be mapped to a user of this server.
+	// Their memberships, subscriptions and categories were not imported
+	// required: true
+	UnmappedUserIDs []string `json:"unmappedUserIds"`
+
+	// The number of blocks of the imported boards
+	// required: true
+	BlockCount int `json:"blockCount"`
+}
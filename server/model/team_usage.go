@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// TeamUsage is the number of boards, cards and views of a team and the
+// size of its attachments, along with the configured limits
+// swagger:model
+type TeamUsage struct {
+	// The id of the team
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The number of boards of the team, excluding templates
+	// required: true
+	Boards int64 `json:"boards"`
+
+	// The number of cards in the boards of the team, including card templates
+	// required: true
+	Cards int64 `json:"cards"`
+
+	// The number of views in the boards of the team
+	// required: true
+	Views int64 `json:"views"`
+
+	// The bytes of the attachments stored for the team. The attachment
+	// storage limit applies to the attachments of all the teams
+	// required: true
+	UsedFileStorage int64 `json:"usedFileStorage"`
+
+	// The maximum number of cards of a board, 0 meaning no limit
+	// required: true
+	CardLimit int `json:"cardLimit"`
+
+	// The maximum number of views of a board, 0 meaning no limit
+	// required: true
+	ViewLimit int `json:"viewLimit"`
+
+	// The maximum bytes of the stored attachments, 0 meaning no limit
+	// required: true
+	AttachmentStorageLimit int64 `json:"attachmentStorageLimit"`
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// TextFieldCRDT is the field of the text blocks edited collaboratively
+// with CRDT updates that holds the state of their document.
+const TextFieldCRDT = "crdt"
+
+// TextCRDTUpdate is a Yjs-compatible update of the CRDT document of a
+// text block, with the ID it is known by, the hash of its data.
+type TextCRDTUpdate struct {
+	ID   string `json:"id"`
+	Data []byte `json:"data"`
+}
+
+// NewTextCRDTUpdate returns the update with the given data.
+func NewTextCRDTUpdate(data []byte) TextCRDTUpdate {
+	sum := sha256.Sum256(data)
+	return TextCRDTUpdate{ID: hex.EncodeToString(sum[:]), Data: data}
+}
+
+// TextCRDTState is the state of the CRDT document of a text block edited
+// collaboratively. The clients seed the document with the seed text, then
+// apply the snapshot, the document as merged by a client, and the updates
+// received after it. Base is the ID of the snapshot the snapshot was
+// merged over, as if it was an update, and Merged holds the updates it
+// replaced, so that the copies of the document holding another snapshot
+// can tell what it includes. The generation changes each time the
+// document is seeded again, after the title of the block is changed
+// through other means.
+type TextCRDTState struct {
+	Generation int64            `json:"generation"`
+	Seed       string           `json:"seed"`
+	Snapshot   []byte           `json:"snapshot"`
+	Base       string           `json:"base"`
+	Updates    []TextCRDTUpdate `json:"updates"`
+	Merged     []TextCRDTUpdate `json:"merged"`
+
+	// TextHash identifies the title of the block the state was saved with
+	TextHash string `json:"textHash"`
+}
+
+// TextCRDTStateFromBlock returns the state of the collaborative document
+// saved in the block, or nil if there is none or if the title of the block
+// was changed through other means since, as the state doesn't match it
+// anymore.
+func TextCRDTStateFromBlock(block *Block) *TextCRDTState {
+	value, ok := block.Fields[TextFieldCRDT]
+	if !ok || value == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var state TextCRDTState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	if state.TextHash != TextCRDTHash(block.Title) {
+		return nil
+	}
+	return &state
+}
+
+// FieldValue returns the state as stored in the block fields, with the
+// bytes as base64 strings.
+func (s *TextCRDTState) FieldValue() map[string]interface{} {
+	data, _ := json.Marshal(s)
+	var value map[string]interface{}
+	_ = json.Unmarshal(data, &value)
+	return value
+}
+
+// TextCRDTHash returns the hash identifying the text of a collaborative
+// document, the hex encoded SHA-256 of the text.
+func TextCRDTHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
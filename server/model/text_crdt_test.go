@@ -0,0 +1,46 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextCRDTStateFromBlock(t *testing.T) {
+	state := &TextCRDTState{
+		Generation: 42,
+		Seed:       "hel",
+		Snapshot:   []byte{1, 2, 3},
+		Updates:    []TextCRDTUpdate{NewTextCRDTUpdate([]byte{4}), NewTextCRDTUpdate([]byte{5, 6})},
+		Base:       NewTextCRDTUpdate([]byte{0}).ID,
+		Merged:     []TextCRDTUpdate{NewTextCRDTUpdate([]byte{7})},
+		TextHash:   TextCRDTHash("hello"),
+	}
+
+	t.Run("saved state", func(t *testing.T) {
+		block := &Block{
+			Title:  "hello",
+			Fields: map[string]interface{}{TextFieldCRDT: state.FieldValue()},
+		}
+		require.Equal(t, state, TextCRDTStateFromBlock(block))
+	})
+
+	t.Run("title changed since", func(t *testing.T) {
+		block := &Block{
+			Title:  "hello world",
+			Fields: map[string]interface{}{TextFieldCRDT: state.FieldValue()},
+		}
+		require.Nil(t, TextCRDTStateFromBlock(block))
+	})
+
+	t.Run("no state", func(t *testing.T) {
+		require.Nil(t, TextCRDTStateFromBlock(&Block{Title: "hello"}))
+	})
+}
+
+func TestNewTextCRDTUpdate(t *testing.T) {
+	update := NewTextCRDTUpdate([]byte{1, 2})
+	require.Equal(t, []byte{1, 2}, update.Data)
+	require.Equal(t, update.ID, NewTextCRDTUpdate([]byte{1, 2}).ID)
+	require.NotEqual(t, update.ID, NewTextCRDTUpdate([]byte{2, 1}).ID)
+}
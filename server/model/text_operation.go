@@ -0,0 +1,260 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// TextOperationComponent is a step of a text operation. Exactly one of
+// its fields is set: Retain skips characters, Insert adds text at the
+// current position and Delete removes characters. Lengths are counted in
+// unicode code points.
+// swagger:model
+type TextOperationComponent struct {
+	// The number of characters to keep
+	// required: false
+	Retain int `json:"retain,omitempty"`
+
+	// The text to insert
+	// required: false
+	Insert string `json:"insert,omitempty"`
+
+	// The number of characters to remove
+	// required: false
+	Delete int `json:"delete,omitempty"`
+}
+
+// TextOperation is an operational transformation over a whole text. Its
+// components are applied in order from the start of the text and must
+// cover all of it. The transformation follows the same rules as ot.js so
+// clients can use any compatible implementation.
+type TextOperation []TextOperationComponent
+
+// ErrInvalidTextOperation is returned when a text operation is not valid
+// or can't be applied to a text.
+type ErrInvalidTextOperation struct {
+	msg string
+}
+
+func newErrInvalidTextOperation(msg string) *ErrInvalidTextOperation {
+	return &ErrInvalidTextOperation{msg: msg}
+}
+
+func (e *ErrInvalidTextOperation) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidTextOperation returns true if the error is an ErrInvalidTextOperation.
+func IsErrInvalidTextOperation(err error) bool {
+	var errInvalid *ErrInvalidTextOperation
+	return errors.As(err, &errInvalid)
+}
+
+// IsValid checks that every component does exactly one thing.
+func (o TextOperation) IsValid() error {
+	for i, c := range o {
+		set := 0
+		if c.Retain != 0 {
+			set++
+		}
+		if c.Insert != "" {
+			set++
+		}
+		if c.Delete != 0 {
+			set++
+		}
+		if set != 1 || c.Retain < 0 || c.Delete < 0 {
+			return newErrInvalidTextOperation(fmt.Sprintf("text operation component %d must retain, insert or delete", i))
+		}
+	}
+	return nil
+}
+
+// BaseLength returns the length of the texts the operation applies to.
+func (o TextOperation) BaseLength() int {
+	length := 0
+	for _, c := range o {
+		length += c.Retain + c.Delete
+	}
+	return length
+}
+
+// TargetLength returns the length of the text produced by the operation.
+func (o TextOperation) TargetLength() int {
+	length := 0
+	for _, c := range o {
+		length += c.Retain + utf8.RuneCountInString(c.Insert)
+	}
+	return length
+}
+
+// Apply returns the text resulting from applying the operation.
+func (o TextOperation) Apply(text string) (string, error) {
+	runes := []rune(text)
+	if o.BaseLength() != len(runes) {
+		return "", newErrInvalidTextOperation(fmt.Sprintf("text operation applies to %d characters, text has %d", o.BaseLength(), len(runes)))
+	}
+
+	result := make([]rune, 0, o.TargetLength())
+	pos := 0
+	for _, c := range o {
+		switch {
+		case c.Retain != 0:
+			result = append(result, runes[pos:pos+c.Retain]...)
+			pos += c.Retain
+		case c.Insert != "":
+			result = append(result, []rune(c.Insert)...)
+		case c.Delete != 0:
+			pos += c.Delete
+		}
+	}
+	return string(result), nil
+}
+
+// textOperationBuilder builds normalized operations, merging consecutive
+// components of the same kind and placing inserts before deletes.
+type textOperationBuilder struct {
+	op TextOperation
+}
+
+func (b *textOperationBuilder) retain(n int) {
+	if n == 0 {
+		return
+	}
+	if last := len(b.op) - 1; last >= 0 && b.op[last].Retain != 0 {
+		b.op[last].Retain += n
+		return
+	}
+	b.op = append(b.op, TextOperationComponent{Retain: n})
+}
+
+func (b *textOperationBuilder) insert(text string) {
+	if text == "" {
+		return
+	}
+	last := len(b.op) - 1
+	if last >= 0 && b.op[last].Insert != "" {
+		b.op[last].Insert += text
+		return
+	}
+	if last >= 0 && b.op[last].Delete != 0 {
+		if last > 0 && b.op[last-1].Insert != "" {
+			b.op[last-1].Insert += text
+			return
+		}
+		b.op = append(b.op, b.op[last])
+		b.op[last] = TextOperationComponent{Insert: text}
+		return
+	}
+	b.op = append(b.op, TextOperationComponent{Insert: text})
+}
+
+func (b *textOperationBuilder) delete(n int) {
+	if n == 0 {
+		return
+	}
+	if last := len(b.op) - 1; last >= 0 && b.op[last].Delete != 0 {
+		b.op[last].Delete += n
+		return
+	}
+	b.op = append(b.op, TextOperationComponent{Delete: n})
+}
+
+func (b *textOperationBuilder) result() TextOperation {
+	if b.op == nil {
+		return TextOperation{}
+	}
+	return b.op
+}
+
+// TransformTextOperations transforms two operations made concurrently on
+// the same text, returning op1' and op2' so that applying op1 then op2'
+// gives the same text as applying op2 then op1'. When both insert at the
+// same position, the text of op1 goes first.
+func TransformTextOperations(op1, op2 TextOperation) (TextOperation, TextOperation, error) {
+	if op1.BaseLength() != op2.BaseLength() {
+		return nil, nil, newErrInvalidTextOperation("concurrent text operations must apply to the same text")
+	}
+
+	var prime1, prime2 textOperationBuilder
+	i1, i2 := 0, 0
+	var c1, c2 *TextOperationComponent
+	next1 := func() {
+		c1 = nil
+		if i1 < len(op1) {
+			c := op1[i1]
+			c1 = &c
+			i1++
+		}
+	}
+	next2 := func() {
+		c2 = nil
+		if i2 < len(op2) {
+			c := op2[i2]
+			c2 = &c
+			i2++
+		}
+	}
+	next1()
+	next2()
+
+	for c1 != nil || c2 != nil {
+		if c1 != nil && c1.Insert != "" {
+			prime1.insert(c1.Insert)
+			prime2.retain(utf8.RuneCountInString(c1.Insert))
+			next1()
+			continue
+		}
+		if c2 != nil && c2.Insert != "" {
+			prime1.retain(utf8.RuneCountInString(c2.Insert))
+			prime2.insert(c2.Insert)
+			next2()
+			continue
+		}
+		if c1 == nil || c2 == nil {
+			return nil, nil, newErrInvalidTextOperation("concurrent text operations have different lengths")
+		}
+
+		switch {
+		case c1.Retain != 0 && c2.Retain != 0:
+			n := minInt(c1.Retain, c2.Retain)
+			prime1.retain(n)
+			prime2.retain(n)
+			c1.Retain -= n
+			c2.Retain -= n
+		case c1.Delete != 0 && c2.Delete != 0:
+			n := minInt(c1.Delete, c2.Delete)
+			c1.Delete -= n
+			c2.Delete -= n
+		case c1.Delete != 0 && c2.Retain != 0:
+			n := minInt(c1.Delete, c2.Retain)
+			prime1.delete(n)
+			c1.Delete -= n
+			c2.Retain -= n
+		case c1.Retain != 0 && c2.Delete != 0:
+			n := minInt(c1.Retain, c2.Delete)
+			prime2.delete(n)
+			c1.Retain -= n
+			c2.Delete -= n
+		}
+
+		if c1.Retain == 0 && c1.Delete == 0 {
+			next1()
+		}
+		if c2.Retain == 0 && c2.Delete == 0 {
+			next2()
+		}
+	}
+	return prime1.result(), prime2.result(), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
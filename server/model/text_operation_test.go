@@ -0,0 +1,99 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextOperationApply(t *testing.T) {
+	op := TextOperation{{Retain: 6}, {Insert: "big "}, {Retain: 5}, {Delete: 6}}
+	require.NoError(t, op.IsValid())
+	require.Equal(t, 17, op.BaseLength())
+	require.Equal(t, 15, op.TargetLength())
+
+	text, err := op.Apply("hello world again")
+	require.NoError(t, err)
+	require.Equal(t, "hello big world", text)
+
+	t.Run("counts code points", func(t *testing.T) {
+		text, err := TextOperation{{Retain: 2}, {Insert: "é"}}.Apply("ñö")
+		require.NoError(t, err)
+		require.Equal(t, "ñöé", text)
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		_, err := op.Apply("hello")
+		require.True(t, IsErrInvalidTextOperation(err))
+	})
+
+	t.Run("invalid component", func(t *testing.T) {
+		require.True(t, IsErrInvalidTextOperation(TextOperation{{Retain: 1, Insert: "a"}}.IsValid()))
+		require.True(t, IsErrInvalidTextOperation(TextOperation{{}}.IsValid()))
+		require.True(t, IsErrInvalidTextOperation(TextOperation{{Delete: -1}}.IsValid()))
+	})
+}
+
+func TestTransformTextOperations(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		op1      TextOperation
+		op2      TextOperation
+		expected string
+	}{
+		{
+			name:     "inserts in different places",
+			text:     "the cat",
+			op1:      TextOperation{{Insert: "see "}, {Retain: 7}},
+			op2:      TextOperation{{Retain: 7}, {Insert: " sat"}},
+			expected: "see the cat sat",
+		},
+		{
+			name:     "inserts in the same place",
+			text:     "ab",
+			op1:      TextOperation{{Retain: 1}, {Insert: "1"}, {Retain: 1}},
+			op2:      TextOperation{{Retain: 1}, {Insert: "2"}, {Retain: 1}},
+			expected: "a12b",
+		},
+		{
+			name:     "overlapping deletes",
+			text:     "abcdef",
+			op1:      TextOperation{{Retain: 1}, {Delete: 3}, {Retain: 2}},
+			op2:      TextOperation{{Retain: 2}, {Delete: 3}, {Retain: 1}},
+			expected: "af",
+		},
+		{
+			name:     "insert inside a deleted range",
+			text:     "abcdef",
+			op1:      TextOperation{{Retain: 1}, {Delete: 4}, {Retain: 1}},
+			op2:      TextOperation{{Retain: 3}, {Insert: "X"}, {Retain: 3}},
+			expected: "aXf",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prime1, prime2, err := TransformTextOperations(tc.op1, tc.op2)
+			require.NoError(t, err)
+
+			after1, err := tc.op1.Apply(tc.text)
+			require.NoError(t, err)
+			result1, err := prime2.Apply(after1)
+			require.NoError(t, err)
+
+			after2, err := tc.op2.Apply(tc.text)
+			require.NoError(t, err)
+			result2, err := prime1.Apply(after2)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, result1)
+			require.Equal(t, tc.expected, result2)
+		})
+	}
+
+	t.Run("different base lengths", func(t *testing.T) {
+		_, _, err := TransformTextOperations(TextOperation{{Retain: 1}}, TextOperation{{Retain: 2}})
+		require.True(t, IsErrInvalidTextOperation(err))
+	})
+}
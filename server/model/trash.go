@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "sort"
+
+// TrashItemType is the kind of a deleted item in the trash of a team.
+type TrashItemType string
+
+const (
+	TrashItemTypeBoard TrashItemType = "board"
+	TrashItemTypeCard  TrashItemType = "card"
+)
+
+// TrashItem is a recently deleted board or card, which can be restored or
+// permanently deleted
+// swagger:model
+type TrashItem struct {
+	// The type of the item, board or card
+	// required: true
+	Type TrashItemType `json:"type"`
+
+	// The id of the board or the card
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the board, or of the board of the card
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The title of the item when it was deleted
+	// required: true
+	Title string `json:"title"`
+
+	// The id of the user who deleted the item
+	// required: true
+	DeletedBy string `json:"deletedBy"`
+
+	// The deletion time in milliseconds since the current epoch
+	// required: true
+	DeleteAt int64 `json:"deleteAt"`
+
+	// The time after which the item can't be restored anymore in
+	// milliseconds since the current epoch, or zero if it is kept forever
+	// required: false
+	PurgeAt int64 `json:"purgeAt"`
+}
+
+// TrashItemForBoard returns the trash item of a board as it was when
+// deleted.
+func TrashItemForBoard(board *Board) *TrashItem {
+	return &TrashItem{
+		Type:      TrashItemTypeBoard,
+		ID:        board.ID,
+		BoardID:   board.ID,
+		Title:     board.Title,
+		DeletedBy: board.ModifiedBy,
+		DeleteAt:  board.DeleteAt,
+	}
+}
+
+// TrashItemForCard returns the trash item of a card as it was when
+// deleted.
+func TrashItemForCard(card Block) *TrashItem {
+	return &TrashItem{
+		Type:      TrashItemTypeCard,
+		ID:        card.ID,
+		BoardID:   card.BoardID,
+		Title:     card.Title,
+		DeletedBy: card.ModifiedBy,
+		DeleteAt:  card.DeleteAt,
+	}
+}
+
+// SortTrashItems sorts the items from the most recently deleted.
+func SortTrashItems(items []*TrashItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].DeleteAt != items[j].DeleteAt {
+			return items[i].DeleteAt > items[j].DeleteAt
+		}
+		return items[i].ID < items[j].ID
+	})
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashItems(t *testing.T) {
+	board := &Board{ID: "board-id", Title: "Roadmap", ModifiedBy: "user-1", DeleteAt: 100}
+	card := Block{ID: "card-id", BoardID: "other-board-id", Title: "Launch", ModifiedBy: "user-2", DeleteAt: 200}
+
+	items := []*TrashItem{TrashItemForBoard(board), TrashItemForCard(card)}
+	require.Equal(t, TrashItemTypeBoard, items[0].Type)
+	require.Equal(t, "board-id", items[0].BoardID)
+	require.Equal(t, "user-1", items[0].DeletedBy)
+	require.Equal(t, TrashItemTypeCard, items[1].Type)
+	require.Equal(t, "other-board-id", items[1].BoardID)
+	require.Equal(t, "Launch", items[1].Title)
+
+	SortTrashItems(items)
+	require.Equal(t, "card-id", items[0].ID)
+	require.Equal(t, "board-id", items[1].ID)
+}
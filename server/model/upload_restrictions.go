@@ -0,0 +1,232 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const maxFileExtensionLength = 32
+
+// The reasons an upload is rejected.
+const (
+	UploadRestrictedFileTooLarge        = "fileTooLarge"
+	UploadRestrictedExtensionNotAllowed = "extensionNotAllowed"
+	UploadRestrictedExtensionBlocked    = "extensionBlocked"
+)
+
+// UploadRestrictions limit the files that can be attached to the cards
+// swagger:model
+type UploadRestrictions struct {
+	// The maximum size of a file in bytes, 0 for no limit
+	// required: true
+	MaxFileSize int64 `json:"maxFileSize"`
+
+	// The only extensions, without the dot, that files can have. Any
+	// extension is allowed when empty
+	// required: true
+	AllowedExtensions []string `json:"allowedExtensions"`
+
+	// The extensions, without the dot, that files can't have
+	// required: true
+	BlockedExtensions []string `json:"blockedExtensions"`
+}
+
+// UploadRestrictionsOverrides are the upload restrictions of a team that
+// replace the ones of the server configuration. The restrictions that are
+// not set are the ones of the configuration
+// swagger:model
+type UploadRestrictionsOverrides struct {
+	// The maximum size of a file in bytes, 0 for no limit
+	// required: false
+	MaxFileSize *int64 `json:"maxFileSize,omitempty"`
+
+	// The only extensions that files can have, any when empty
+	// required: false
+	AllowedExtensions *[]string `json:"allowedExtensions,omitempty"`
+
+	// The extensions that files can't have
+	// required: false
+	BlockedExtensions *[]string `json:"blockedExtensions,omitempty"`
+}
+
+// TeamUploadRestrictions are the upload restrictions of a team, with the
+// overrides of the team
+// swagger:model
+type TeamUploadRestrictions struct {
+	// The team ID
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The restrictions of the team that replace the ones of the server
+	// configuration
+	// required: true
+	Overrides UploadRestrictionsOverrides `json:"overrides"`
+
+	// The restrictions that apply to the uploads of the team
+	// required: true
+	Restrictions UploadRestrictions `json:"restrictions"`
+}
+
+// ErrInvalidUploadRestrictions is returned when upload restrictions are
+// not valid.
+type ErrInvalidUploadRestrictions struct {
+	msg string
+}
+
+func newErrInvalidUploadRestrictions(msg string) *ErrInvalidUploadRestrictions {
+	return &ErrInvalidUploadRestrictions{msg: msg}
+}
+
+func (e *ErrInvalidUploadRestrictions) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidUploadRestrictions returns true if the error is an
+// ErrInvalidUploadRestrictions.
+func IsErrInvalidUploadRestrictions(err error) bool {
+	var errInvalid *ErrInvalidUploadRestrictions
+	return errors.As(err, &errInvalid)
+}
+
+// ErrUploadRestricted is returned when a file can't be uploaded because of
+// the upload restrictions.
+type ErrUploadRestricted struct {
+	Reason       string
+	Filename     string
+	Extension    string
+	Size         int64
+	Restrictions UploadRestrictions
+}
+
+func (e *ErrUploadRestricted) Error() string {
+	switch e.Reason {
+	case UploadRestrictedFileTooLarge:
+		return fmt.Sprintf("the file is larger than the maximum size of %d bytes", e.Restrictions.MaxFileSize)
+	case UploadRestrictedExtensionNotAllowed:
+		return fmt.Sprintf("file %q doesn't have an allowed extension", e.Filename)
+	default:
+		return fmt.Sprintf("files with the extension %q are blocked", e.Extension)
+	}
+}
+
+// IsErrUploadRestricted returns true if the error is an
+// ErrUploadRestricted.
+func IsErrUploadRestricted(err error) bool {
+	var errRestricted *ErrUploadRestricted
+	return errors.As(err, &errRestricted)
+}
+
+// UploadRestrictedResponse is the error response returned when a file
+// can't be uploaded because of the upload restrictions
+// swagger:model
+type UploadRestrictedResponse struct {
+	// The error message
+	// required: false
+	Error string `json:"error"`
+
+	// The error code
+	// required: false
+	ErrorCode int `json:"errorCode"`
+
+	// Why the file was rejected, one of fileTooLarge, extensionNotAllowed
+	// or extensionBlocked
+	// required: true
+	Reason string `json:"reason"`
+
+	// The extension of the rejected file
+	// required: false
+	Extension string `json:"extension,omitempty"`
+
+	// The restrictions that apply to the uploads
+	// required: true
+	Restrictions UploadRestrictions `json:"restrictions"`
+}
+
+// FileExtension returns the extension of the file name, in lower case and
+// without the dot.
+func FileExtension(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+}
+
+// NormalizeFileExtensions returns the extensions in lower case, without
+// their dot and duplicates, or an error if one isn't a valid extension.
+func NormalizeFileExtensions(extensions []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, extension := range extensions {
+		ext := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(extension), "."))
+		if ext == "" || len(ext) > maxFileExtensionLength || strings.ContainsAny(ext, "./\\ \t\r\n") {
+			return nil, newErrInvalidUploadRestrictions(fmt.Sprintf("invalid file extension %q", extension))
+		}
+		if !seen[ext] {
+			seen[ext] = true
+			normalized = append(normalized, ext)
+		}
+	}
+	return normalized, nil
+}
+
+// IsValid checks the overrides and normalizes their extensions.
+func (o *UploadRestrictionsOverrides) IsValid() error {
+	if o.MaxFileSize != nil && *o.MaxFileSize < 0 {
+		return newErrInvalidUploadRestrictions("the maximum file size can't be negative")
+	}
+	for _, extensions := range []*[]string{o.AllowedExtensions, o.BlockedExtensions} {
+		if extensions == nil {
+			continue
+		}
+		normalized, err := NormalizeFileExtensions(*extensions)
+		if err != nil {
+			return err
+		}
+		*extensions = normalized
+	}
+	return nil
+}
+
+// Apply returns the restrictions with the overrides applied.
+func (o UploadRestrictionsOverrides) Apply(restrictions UploadRestrictions) UploadRestrictions {
+	if o.MaxFileSize != nil {
+		restrictions.MaxFileSize = *o.MaxFileSize
+	}
+	if o.AllowedExtensions != nil {
+		restrictions.AllowedExtensions = *o.AllowedExtensions
+	}
+	if o.BlockedExtensions != nil {
+		restrictions.BlockedExtensions = *o.BlockedExtensions
+	}
+	return restrictions
+}
+
+// Check returns an ErrUploadRestricted if a file with the name and size
+// can't be uploaded. The blocked extensions win over the allowed ones.
+func (r UploadRestrictions) Check(filename string, size int64) error {
+	ext := FileExtension(filename)
+	restricted := &ErrUploadRestricted{Filename: filename, Extension: ext, Size: size, Restrictions: r}
+
+	if r.MaxFileSize > 0 && size > r.MaxFileSize {
+		restricted.Reason = UploadRestrictedFileTooLarge
+		return restricted
+	}
+	for _, blocked := range r.BlockedExtensions {
+		if ext == blocked {
+			restricted.Reason = UploadRestrictedExtensionBlocked
+			return restricted
+		}
+	}
+	if len(r.AllowedExtensions) == 0 {
+		return nil
+	}
+	for _, allowed := range r.AllowedExtensions {
+		if ext == allowed {
+			return nil
+		}
+	}
+	restricted.Reason = UploadRestrictedExtensionNotAllowed
+	return restricted
+}
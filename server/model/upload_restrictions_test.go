@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadRestrictionsOverridesIsValid(t *testing.T) {
+	allowed := []string{".PDF", " docx", "pdf"}
+	overrides := &UploadRestrictionsOverrides{AllowedExtensions: &allowed}
+	require.NoError(t, overrides.IsValid())
+	require.Equal(t, []string{"pdf", "docx"}, *overrides.AllowedExtensions)
+
+	for _, extension := range []string{"", ".", "tar.gz", "a/b", "with space"} {
+		blocked := []string{extension}
+		err := (&UploadRestrictionsOverrides{BlockedExtensions: &blocked}).IsValid()
+		require.True(t, IsErrInvalidUploadRestrictions(err), extension)
+	}
+
+	negative := int64(-1)
+	require.True(t, IsErrInvalidUploadRestrictions((&UploadRestrictionsOverrides{MaxFileSize: &negative}).IsValid()))
+}
+
+func TestUploadRestrictionsOverridesApply(t *testing.T) {
+	restrictions := UploadRestrictions{MaxFileSize: 100, BlockedExtensions: []string{"exe"}}
+
+	noLimit := int64(0)
+	none := []string{}
+	applied := UploadRestrictionsOverrides{MaxFileSize: &noLimit, BlockedExtensions: &none}.Apply(restrictions)
+	require.Equal(t, UploadRestrictions{BlockedExtensions: []string{}}, applied)
+
+	// the restrictions that are not overridden are kept
+	require.Equal(t, restrictions, UploadRestrictionsOverrides{}.Apply(restrictions))
+}
+
+func TestUploadRestrictionsCheck(t *testing.T) {
+	restrictions := UploadRestrictions{
+		MaxFileSize:       100,
+		AllowedExtensions: []string{"pdf", "exe"},
+		BlockedExtensions: []string{"exe"},
+	}
+
+	require.NoError(t, restrictions.Check("Spec.PDF", 100))
+
+	reasons := map[string]struct {
+		filename string
+		size     int64
+	}{
+		UploadRestrictedFileTooLarge:        {"spec.pdf", 101},
+		UploadRestrictedExtensionBlocked:    {"setup.exe", 10},
+		UploadRestrictedExtensionNotAllowed: {"movie.mp4", 10},
+	}
+	for reason, file := range reasons {
+		err := restrictions.Check(file.filename, file.size)
+		require.True(t, IsErrUploadRestricted(err), reason)
+
+		var errRestricted *ErrUploadRestricted
+		require.True(t, errors.As(err, &errRestricted))
+		require.Equal(t, reason, errRestricted.Reason)
+	}
+
+	require.NoError(t, UploadRestrictions{}.Check("README", 1<<40))
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// UserPropCustomStatus is the user prop that holds the custom status set
+// in Mattermost.
+const UserPropCustomStatus = "customStatus"
+
+// awayCustomStatusEmojis are the emojis of the custom statuses telling
+// that the user is away, like the "On a vacation" and "Out sick" presets.
+var awayCustomStatusEmojis = map[string]bool{
+	"palm_tree":     true,
+	"sneezing_face": true,
+}
+
+// customStatus is the custom status as stored in the user props.
+type customStatus struct {
+	Emoji     string    `json:"emoji"`
+	Text      string    `json:"text"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UserAvailability tells whether a user is around to pick up work
+// swagger:model
+type UserAvailability struct {
+	// The id of the user
+	// required: true
+	UserID string `json:"userId"`
+
+	// Whether the user is out of office
+	// required: true
+	OutOfOffice bool `json:"outOfOffice"`
+
+	// The text of the custom status of the user
+	// required: false
+	StatusText string `json:"statusText"`
+
+	// The emoji of the custom status of the user
+	// required: false
+	StatusEmoji string `json:"statusEmoji"`
+
+	// The time the custom status expires in milliseconds since the current
+	// epoch, or zero if it doesn't
+	// required: false
+	StatusUntil int64 `json:"statusUntil"`
+}
+
+// IsAway returns true if the user is out of office, or has a custom status
+// telling they are away.
+func (ua *UserAvailability) IsAway() bool {
+	return ua.OutOfOffice || awayCustomStatusEmojis[ua.StatusEmoji]
+}
+
+// UserAvailabilityFromProps returns the availability of a user from the
+// custom status in their props. Expired and invalid custom statuses are
+// ignored.
+func UserAvailabilityFromProps(userID string, props map[string]interface{}, now time.Time) *UserAvailability {
+	availability := &UserAvailability{UserID: userID}
+
+	value, ok := props[UserPropCustomStatus].(string)
+	if !ok || value == "" {
+		return availability
+	}
+	var status customStatus
+	if err := json.Unmarshal([]byte(value), &status); err != nil {
+		return availability
+	}
+	if !status.ExpiresAt.IsZero() {
+		if !status.ExpiresAt.After(now) {
+			return availability
+		}
+		availability.StatusUntil = status.ExpiresAt.UnixNano() / int64(time.Millisecond)
+	}
+
+	availability.StatusText = status.Text
+	availability.StatusEmoji = status.Emoji
+	return availability
+}
+
+// AssignmentWarning warns that a user assigned to a card may be away
+// swagger:model
+type AssignmentWarning struct {
+	// The id of the assignee property
+	// required: true
+	PropertyID string `json:"propertyId"`
+
+	// The name of the assignee property
+	// required: true
+	PropertyName string `json:"propertyName"`
+
+	// The availability of the assigned user
+	// required: true
+	Availability UserAvailability `json:"availability"`
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAvailabilityFromProps(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("no custom status", func(t *testing.T) {
+		availability := UserAvailabilityFromProps("user-id", map[string]interface{}{}, now)
+		require.Equal(t, "user-id", availability.UserID)
+		require.False(t, availability.IsAway())
+	})
+
+	t.Run("away custom status", func(t *testing.T) {
+		props := map[string]interface{}{
+			UserPropCustomStatus: `{"emoji":"palm_tree","text":"On a vacation","duration":"date_and_time","expires_at":"2022-06-10T00:00:00Z"}`,
+		}
+		availability := UserAvailabilityFromProps("user-id", props, now)
+		require.True(t, availability.IsAway())
+		require.Equal(t, "On a vacation", availability.StatusText)
+		require.Equal(t, time.Date(2022, 6, 10, 0, 0, 0, 0, time.UTC).UnixNano()/int64(time.Millisecond), availability.StatusUntil)
+	})
+
+	t.Run("custom status without expiry", func(t *testing.T) {
+		props := map[string]interface{}{
+			UserPropCustomStatus: `{"emoji":"sneezing_face","text":"Out sick","duration":"","expires_at":"0001-01-01T00:00:00Z"}`,
+		}
+		availability := UserAvailabilityFromProps("user-id", props, now)
+		require.True(t, availability.IsAway())
+		require.Zero(t, availability.StatusUntil)
+	})
+
+	t.Run("other custom status", func(t *testing.T) {
+		props := map[string]interface{}{
+			UserPropCustomStatus: `{"emoji":"calendar","text":"In a meeting"}`,
+		}
+		availability := UserAvailabilityFromProps("user-id", props, now)
+		require.False(t, availability.IsAway())
+		require.Equal(t, "In a meeting", availability.StatusText)
+	})
+
+	t.Run("expired custom status", func(t *testing.T) {
+		props := map[string]interface{}{
+			UserPropCustomStatus: `{"emoji":"palm_tree","text":"On a vacation","expires_at":"2022-05-31T00:00:00Z"}`,
+		}
+		availability := UserAvailabilityFromProps("user-id", props, now)
+		require.False(t, availability.IsAway())
+		require.Empty(t, availability.StatusText)
+	})
+
+	t.Run("out of office", func(t *testing.T) {
+		availability := &UserAvailability{UserID: "user-id", OutOfOffice: true}
+		require.True(t, availability.IsAway())
+	})
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"strings"
+)
+
+const (
+	// DefaultUserDirectoryPerPage is the size of a page of the user
+	// directory when none is given.
+	DefaultUserDirectoryPerPage = 20
+
+	// MaxUserDirectoryPerPage is the largest page of the user directory
+	// that can be requested.
+	MaxUserDirectoryPerPage = 100
+)
+
+// UserDirectoryEntry is the profile of a team member shown by the mention
+// autocomplete and the person property pickers
+// swagger:model
+type UserDirectoryEntry struct {
+	// The user ID
+	// required: true
+	ID string `json:"id"`
+
+	// The user name
+	// required: true
+	Username string `json:"username"`
+
+	// The nickname of the user, in plugin mode
+	// required: false
+	Nickname string `json:"nickname,omitempty"`
+
+	// The first name of the user, in plugin mode
+	// required: false
+	FirstName string `json:"firstName,omitempty"`
+
+	// The last name of the user, in plugin mode
+	// required: false
+	LastName string `json:"lastName,omitempty"`
+
+	// If the user is a bot or not
+	// required: true
+	IsBot bool `json:"is_bot"`
+}
+
+// UserDirectoryPage is a page of the team members matching a prefix,
+// sorted by username
+// swagger:model
+type UserDirectoryPage struct {
+	// The users of the page
+	// required: true
+	Users []*UserDirectoryEntry `json:"users"`
+
+	// The page of the users, starting at 0
+	// required: true
+	Page int `json:"page"`
+
+	// The number of users per page
+	// required: true
+	PerPage int `json:"perPage"`
+
+	// If there are more users after this page
+	// required: true
+	HasNext bool `json:"hasNext"`
+}
+
+// UserDirectoryOptions are the prefix and paging of a user directory
+// search.
+type UserDirectoryOptions struct {
+	// The prefix of the username, nickname, first or last name of the
+	// users, all the users if empty
+	Prefix string
+
+	// The page to return, starting at 0
+	Page int
+
+	// The number of users per page
+	PerPage int
+}
+
+// Paging returns the page and the number of users per page of the search,
+// with the defaults and limits applied.
+func (opts UserDirectoryOptions) Paging() (page int, perPage int) {
+	perPage = opts.PerPage
+	if perPage <= 0 {
+		perPage = DefaultUserDirectoryPerPage
+	}
+	if perPage > MaxUserDirectoryPerPage {
+		perPage = MaxUserDirectoryPerPage
+	}
+	page = opts.Page
+	if page < 0 {
+		page = 0
+	}
+	return page, perPage
+}
+
+// UserDirectoryEscapeChar escapes the wildcards of the LIKE patterns of
+// the user directory, as there is no escape character common to the
+// databases by default.
+const UserDirectoryEscapeChar = "!"
+
+// UserDirectoryLikePattern returns the LIKE pattern matching the lowered
+// values starting with the prefix, with its wildcards escaped with
+// UserDirectoryEscapeChar.
+func UserDirectoryLikePattern(prefix string) string {
+	replacer := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+	return replacer.Replace(strings.ToLower(prefix)) + "%"
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserDirectoryOptionsPaging(t *testing.T) {
+	page, perPage := UserDirectoryOptions{}.Paging()
+	require.Equal(t, 0, page)
+	require.Equal(t, DefaultUserDirectoryPerPage, perPage)
+
+	page, perPage = UserDirectoryOptions{Page: -1, PerPage: MaxUserDirectoryPerPage + 1}.Paging()
+	require.Equal(t, 0, page)
+	require.Equal(t, MaxUserDirectoryPerPage, perPage)
+
+	page, perPage = UserDirectoryOptions{Page: 2, PerPage: 5}.Paging()
+	require.Equal(t, 2, page)
+	require.Equal(t, 5, perPage)
+}
+
+func TestUserDirectoryLikePattern(t *testing.T) {
+	require.Equal(t, "%", UserDirectoryLikePattern(""))
+	require.Equal(t, "john%", UserDirectoryLikePattern("John"))
+	require.Equal(t, "a!_b!%c!!%", UserDirectoryLikePattern("a_b%c!"))
+}
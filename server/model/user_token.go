@@ -0,0 +1,46 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// UserTokenTypeVerifyEmail is the type of the tokens sent to verify
+	// the email address of a user.
+	UserTokenTypeVerifyEmail = "verify_email"
+	// UserTokenTypePasswordReset is the type of the tokens sent to reset
+	// the password of a user.
+	UserTokenTypePasswordReset = "password_reset"
+
+	// UserPropEmailVerified is the user property set once the user
+	// verified their email address.
+	UserPropEmailVerified = "focalboard_emailVerified"
+)
+
+// UserToken is a single use token sent by email to let a user prove that
+// they own their email address.
+type UserToken struct {
+	// The SHA-256 hash of the secret token, so that the stored tokens
+	// can't be used to reset passwords
+	Token string `json:"token"`
+
+	// The type of the token
+	Type string `json:"type"`
+
+	// The id of the user the token was issued for
+	UserID string `json:"userId"`
+
+	// The email address the token was sent to
+	Email string `json:"email"`
+
+	// The creation time in milliseconds since the current epoch
+	CreateAt int64 `json:"createAt"`
+}
+
+// HashUserToken returns the hash of the secret token under which the token
+// is stored.
+func HashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ViewFieldRestricted is the view field that restricts the visibility of
+// a view to the board admins.
+const ViewFieldRestricted = "restricted"
+
+// IsViewRestricted returns true if the block is a view only visible to the
+// board admins.
+func IsViewRestricted(view *Block) bool {
+	if view == nil || view.Type != TypeView {
+		return false
+	}
+	restricted, _ := view.Fields[ViewFieldRestricted].(bool)
+	return restricted
+}
+
+// ChangesViewRestriction returns true if applying the patch to the block
+// restricts a view or lifts its restriction.
+func ChangesViewRestriction(block *Block, patch *BlockPatch) bool {
+	if block == nil || block.Type != TypeView || patch == nil {
+		return false
+	}
+	current := IsViewRestricted(block)
+	if value, ok := patch.UpdatedFields[ViewFieldRestricted]; ok {
+		restricted, _ := value.(bool)
+		return restricted != current
+	}
+	for _, field := range patch.DeletedFields {
+		if field == ViewFieldRestricted {
+			return current
+		}
+	}
+	return false
+}
+
+// RestrictedViewViewers returns the ids of the users that can see a
+// restricted view out of the board members.
+func RestrictedViewViewers(members []*BoardMember) []string {
+	userIDs := []string{}
+	for _, member := range members {
+		if member.SchemeAdmin {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	return userIDs
+}
+
+// FilterRestrictedViews removes the restricted views from the list,
+// together with their child blocks.
+func FilterRestrictedViews(blocks []Block) []Block {
+	hiddenViewIDs := map[string]bool{}
+	for i := range blocks {
+		if IsViewRestricted(&blocks[i]) {
+			hiddenViewIDs[blocks[i].ID] = true
+		}
+	}
+	if len(hiddenViewIDs) == 0 {
+		return blocks
+	}
+
+	filtered := make([]Block, 0, len(blocks))
+	for _, block := range blocks {
+		if hiddenViewIDs[block.ID] || hiddenViewIDs[block.ParentID] {
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangesViewRestriction(t *testing.T) {
+	view := &Block{ID: "view", Type: TypeView, Fields: map[string]interface{}{}}
+	restrictedView := &Block{ID: "view", Type: TypeView, Fields: map[string]interface{}{ViewFieldRestricted: true}}
+	card := &Block{ID: "card", Type: TypeCard, Fields: map[string]interface{}{}}
+
+	restrict := &BlockPatch{UpdatedFields: map[string]interface{}{ViewFieldRestricted: true}}
+	unrestrict := &BlockPatch{DeletedFields: []string{ViewFieldRestricted}}
+	rename := &BlockPatch{UpdatedFields: map[string]interface{}{"title": "Salaries"}}
+
+	require.True(t, ChangesViewRestriction(view, restrict))
+	require.False(t, ChangesViewRestriction(restrictedView, restrict))
+	require.True(t, ChangesViewRestriction(restrictedView, unrestrict))
+	require.False(t, ChangesViewRestriction(view, unrestrict))
+	require.False(t, ChangesViewRestriction(restrictedView, rename))
+	require.False(t, ChangesViewRestriction(card, restrict))
+}
+
+func TestFilterRestrictedViews(t *testing.T) {
+	blocks := []Block{
+		{ID: "view", Type: TypeView},
+		{ID: "restricted-view", Type: TypeView, Fields: map[string]interface{}{ViewFieldRestricted: true}},
+		{ID: "child", ParentID: "restricted-view", Type: TypeText},
+		{ID: "card", Type: TypeCard, Fields: map[string]interface{}{ViewFieldRestricted: true}},
+	}
+
+	filtered := FilterRestrictedViews(blocks)
+	require.Len(t, filtered, 2)
+	require.Equal(t, "view", filtered[0].ID)
+	require.Equal(t, "card", filtered[1].ID)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+type WebhookAction string
+
+const (
+	WebhookActionAdd    WebhookAction = "add"
+	WebhookActionUpdate WebhookAction = "update"
+	WebhookActionDelete WebhookAction = "delete"
+)
+
+// Webhook is an endpoint of a team that receives the block changes
+// matching its filters. The payloads are signed with the secret of the
+// webhook
+// swagger:model
+type Webhook struct {
+	// The id of the webhook
+	// required: true
+	ID string `json:"id"`
+
+	// The id of the team the webhook belongs to
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The http or https URL the block changes are posted to
+	// required: true
+	URL string `json:"url"`
+
+	// The secret used to sign the payloads, only returned on creation
+	// required: false
+	Secret string `json:"secret,omitempty"`
+
+	// The block types to send, all of them if empty
+	// required: false
+	BlockTypes []BlockType `json:"blockTypes"`
+
+	// The ids of the boards to send the changes of, all of them if empty
+	// required: false
+	BoardIDs []string `json:"boardIds"`
+
+	// The actions to send, one of add, update or delete, all of them if empty
+	// required: false
+	Actions []WebhookAction `json:"actions"`
+
+	// The id of the user who registered the webhook
+	// required: true
+	CreatedBy string `json:"createdBy"`
+
+	// The creation time in milliseconds since the current epoch
+	// required: true
+	CreateAt int64 `json:"createAt"`
+
+	// The last modification time in milliseconds since the current epoch
+	// required: true
+	UpdateAt int64 `json:"updateAt"`
+}
+
+// WebhookPayload is the body posted to the webhooks
+// swagger:model
+type WebhookPayload struct {
+	// The action done on the block
+	// required: true
+	Action WebhookAction `json:"action"`
+
+	// The id of the team of the block
+	// required: true
+	TeamID string `json:"teamId"`
+
+	// The id of the board of the block
+	// required: true
+	BoardID string `json:"boardId"`
+
+	// The block after the change, or before its deletion
+	// required: true
+	Block Block `json:"block"`
+
+	// The time of the notification in milliseconds since the current epoch
+	// required: true
+	Timestamp int64 `json:"timestamp"`
+}
+
+// ErrInvalidWebhook is returned when a webhook has an invalid URL or filter.
+type ErrInvalidWebhook struct {
+	msg string
+}
+
+func NewErrInvalidWebhook(msg string) *ErrInvalidWebhook {
+	return &ErrInvalidWebhook{msg: msg}
+}
+
+func (e *ErrInvalidWebhook) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidWebhook returns true if the error is an ErrInvalidWebhook.
+func IsErrInvalidWebhook(err error) bool {
+	var errInvalid *ErrInvalidWebhook
+	return errors.As(err, &errInvalid)
+}
+
+// Hydrate sets the id, the secret if none was given, and the creation
+// details of a new webhook.
+func (w *Webhook) Hydrate(teamID, userID string) {
+	w.ID = utils.NewID(utils.IDTypeNone)
+	w.TeamID = teamID
+	if w.Secret == "" {
+		w.Secret = utils.NewID(utils.IDTypeToken)
+	}
+	w.CreatedBy = userID
+	w.CreateAt = utils.GetMillis()
+	w.UpdateAt = w.CreateAt
+	w.normalize()
+}
+
+func (w *Webhook) normalize() {
+	if w.BlockTypes == nil {
+		w.BlockTypes = []BlockType{}
+	}
+	if w.BoardIDs == nil {
+		w.BoardIDs = []string{}
+	}
+	if w.Actions == nil {
+		w.Actions = []WebhookAction{}
+	}
+}
+
+// Sanitize removes the secret of the webhook.
+func (w *Webhook) Sanitize() {
+	w.Secret = ""
+}
+
+// IsValid checks the URL and the filters of the webhook.
+func (w *Webhook) IsValid() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewErrInvalidWebhook(fmt.Sprintf("invalid url %q", w.URL))
+	}
+
+	for _, blockType := range w.BlockTypes {
+		if !containsBlockType(KnownBlockTypes(), blockType) {
+			return NewErrInvalidWebhook(fmt.Sprintf("invalid block type %q", blockType))
+		}
+	}
+
+	for _, action := range w.Actions {
+		switch action {
+		case WebhookActionAdd, WebhookActionUpdate, WebhookActionDelete:
+		default:
+			return NewErrInvalidWebhook(fmt.Sprintf("invalid action %q", action))
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the action on the block passes the filters of
+// the webhook.
+func (w *Webhook) Matches(action WebhookAction, block *Block) bool {
+	if len(w.Actions) != 0 && !containsWebhookAction(w.Actions, action) {
+		return false
+	}
+	if len(w.BoardIDs) != 0 && !containsString(w.BoardIDs, block.BoardID) {
+		return false
+	}
+	if len(w.BlockTypes) != 0 && !containsBlockType(w.BlockTypes, block.Type) {
+		return false
+	}
+	return true
+}
+
+func containsWebhookAction(actions []WebhookAction, action WebhookAction) bool {
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsBlockType(blockTypes []BlockType, blockType BlockType) bool {
+	for _, bt := range blockTypes {
+		if bt == blockType {
+			return true
+		}
+	}
+	return false
+}
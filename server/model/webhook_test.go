@@ -0,0 +1,60 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookIsValid(t *testing.T) {
+	t.Run("valid webhook", func(t *testing.T) {
+		webhook := &Webhook{
+			URL:        "https://example.com/hook",
+			BlockTypes: []BlockType{TypeCard, TypeComment},
+			Actions:    []WebhookAction{WebhookActionAdd, WebhookActionDelete},
+		}
+		require.NoError(t, webhook.IsValid())
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		for _, u := range []string{"", "example.com/hook", "ftp://example.com", "https://"} {
+			webhook := &Webhook{URL: u}
+			require.True(t, IsErrInvalidWebhook(webhook.IsValid()), u)
+		}
+	})
+
+	t.Run("invalid block type", func(t *testing.T) {
+		webhook := &Webhook{URL: "https://example.com/hook", BlockTypes: []BlockType{"unknown"}}
+		require.True(t, IsErrInvalidWebhook(webhook.IsValid()))
+	})
+
+	t.Run("invalid action", func(t *testing.T) {
+		webhook := &Webhook{URL: "https://example.com/hook", Actions: []WebhookAction{"move"}}
+		require.True(t, IsErrInvalidWebhook(webhook.IsValid()))
+	})
+}
+
+func TestWebhookMatches(t *testing.T) {
+	card := &Block{ID: "card-id", BoardID: "board-id", Type: TypeCard}
+
+	testCases := []struct {
+		name     string
+		webhook  Webhook
+		action   WebhookAction
+		expected bool
+	}{
+		{"no filters", Webhook{}, WebhookActionUpdate, true},
+		{"matching filters", Webhook{
+			BlockTypes: []BlockType{TypeCard}, BoardIDs: []string{"board-id"}, Actions: []WebhookAction{WebhookActionUpdate},
+		}, WebhookActionUpdate, true},
+		{"other block type", Webhook{BlockTypes: []BlockType{TypeComment}}, WebhookActionUpdate, false},
+		{"other board", Webhook{BoardIDs: []string{"other-board-id"}}, WebhookActionUpdate, false},
+		{"other action", Webhook{Actions: []WebhookAction{WebhookActionAdd}}, WebhookActionUpdate, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.webhook.Matches(tc.action, card))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/jobs"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/services/store"
@@ -21,6 +22,10 @@ type Params struct {
 	WSAdapter          ws.Adapter
 	NotifyBackends     []notify.Backend
 	PermissionsService permissions.PermissionsService
+	// JobsLocker elects the node scheduling the recurring jobs. If nil,
+	// the lock is held in the Redis of the websocket cluster, if any, or
+	// the server assumes it is the only node.
+	JobsLocker jobs.Locker
 }
 
 func (p Params) CheckValid() error {
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
@@ -19,11 +20,16 @@ import (
 	"github.com/mattermost/focalboard/server/auth"
 	appModel "github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/audit"
+	"github.com/mattermost/focalboard/server/services/backup"
 	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/featureflags"
+	"github.com/mattermost/focalboard/server/services/jobs"
 	"github.com/mattermost/focalboard/server/services/metrics"
 	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/notify/notifylogger"
+	"github.com/mattermost/focalboard/server/services/redis"
 	"github.com/mattermost/focalboard/server/services/scheduler"
+	"github.com/mattermost/focalboard/server/services/search"
 	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/focalboard/server/services/store/sqlstore"
 	"github.com/mattermost/focalboard/server/services/telemetry"
@@ -41,10 +47,27 @@ import (
 const (
 	cleanupSessionTaskFrequency = 10 * time.Minute
 	updateMetricsTaskFrequency  = 15 * time.Minute
+	escalationsTaskFrequency    = 24 * time.Hour
+	autoArchiveTaskFrequency    = 24 * time.Hour
+	dormantBoardsTaskFrequency  = 24 * time.Hour
+	purgeDeletedTaskFrequency   = 24 * time.Hour
+	cardRemindersTaskFrequency  = time.Minute
 
 	minSessionExpiryTime = int64(60 * 60 * 24 * 31) // 31 days
 
 	MattermostAuthMod = "mattermost"
+
+	websocketClusterChannel = "focalboard:ws"
+	searchClusterChannel    = "focalboard:search"
+	jobsLockKey             = "focalboard:jobs:lock"
+
+	cleanUpSessionsJob = "cleanUpSessions"
+	escalationsJob     = "runEscalationPolicies"
+	autoArchiveJob     = "runAutoArchiveRules"
+	dormantBoardsJob   = "notifyDormantBoards"
+	backupTeamsJob     = "backupTeams"
+	purgeDeletedJob    = "purgeDeletedBlocks"
+	cardRemindersJob   = "deliverCardReminders"
 )
 
 type Server struct {
@@ -55,12 +78,13 @@ type Server struct {
 	filesBackend           filestore.FileBackend
 	telemetry              *telemetry.Service
 	logger                 *mlog.Logger
-	cleanUpSessionsTask    *scheduler.ScheduledTask
+	jobsService            *jobs.Service
 	metricsServer          *metrics.Service
 	metricsService         *metrics.Metrics
 	metricsUpdaterTask     *scheduler.ScheduledTask
 	auditService           *audit.Audit
 	notificationService    *notify.Service
+	searchCluster          *search.Cluster
 	servicesStartStopMutex sync.Mutex
 
 	localRouter     *mux.Router
@@ -76,34 +100,24 @@ func New(params Params) (*Server, error) {
 
 	authenticator := auth.New(params.Cfg, params.DBStore, params.PermissionsService)
 
+	if params.Cfg.AuthMode != MattermostAuthMod && params.SingleUserToken == "" && len(params.Cfg.AdminUsers) == 0 {
+		params.Logger.Warn("No admin_users configured, the team settings, invites, webhooks, trash and migrations can't be managed")
+	}
+
 	// if no ws adapter is provided, we spin up a websocket server
 	wsAdapter := params.WSAdapter
 	if wsAdapter == nil {
 		wsAdapter = ws.NewServer(authenticator, params.SingleUserToken, params.Cfg.AuthMode == MattermostAuthMod, params.Logger, params.DBStore)
 	}
 
-	filesBackendSettings := filestore.FileBackendSettings{}
-	filesBackendSettings.DriverName = params.Cfg.FilesDriver
-	filesBackendSettings.Directory = params.Cfg.FilesPath
-	filesBackendSettings.AmazonS3AccessKeyId = params.Cfg.FilesS3Config.AccessKeyID
-	filesBackendSettings.AmazonS3SecretAccessKey = params.Cfg.FilesS3Config.SecretAccessKey
-	filesBackendSettings.AmazonS3Bucket = params.Cfg.FilesS3Config.Bucket
-	filesBackendSettings.AmazonS3PathPrefix = params.Cfg.FilesS3Config.PathPrefix
-	filesBackendSettings.AmazonS3Region = params.Cfg.FilesS3Config.Region
-	filesBackendSettings.AmazonS3Endpoint = params.Cfg.FilesS3Config.Endpoint
-	filesBackendSettings.AmazonS3SSL = params.Cfg.FilesS3Config.SSL
-	filesBackendSettings.AmazonS3SignV2 = params.Cfg.FilesS3Config.SignV2
-	filesBackendSettings.AmazonS3SSE = params.Cfg.FilesS3Config.SSE
-	filesBackendSettings.AmazonS3Trace = params.Cfg.FilesS3Config.Trace
-
-	filesBackend, appErr := filestore.NewFileBackend(filesBackendSettings)
+	filesBackend, appErr := NewFilesBackend(params.Cfg)
 	if appErr != nil {
 		params.Logger.Error("Unable to initialize the files storage", mlog.Err(appErr))
 
 		return nil, errors.New("unable to initialize the files storage")
 	}
 
-	webhookClient := webhook.NewClient(params.Cfg, params.Logger)
+	webhookClient := webhook.NewClient(params.DBStore, params.Logger)
 
 	// Init metrics
 	instanceInfo := metrics.InstanceInfo{
@@ -113,6 +127,7 @@ func New(params Params) (*Server, error) {
 		InstallationID: os.Getenv("MM_CLOUD_INSTALLATION_ID"),
 	}
 	metricsService := metrics.NewMetrics(instanceInfo)
+	metricsService.RegisterDBStatsCollector(params.Cfg.DBType, params.DBStore.DBStats)
 
 	// Init audit
 	auditService, errAudit := audit.NewAudit()
@@ -129,6 +144,46 @@ func New(params Params) (*Server, error) {
 		return nil, fmt.Errorf("cannot initialize notification service(s): %w", errNotify)
 	}
 
+	// the servers of a cluster schedule the jobs one at a time through
+	// the Redis of the cluster
+	jobsLocker := params.JobsLocker
+	if jobsLocker == nil && params.Cfg.WebsocketClusterRedisAddress != "" {
+		client := redis.NewClient(redis.Options{
+			Address:  params.Cfg.WebsocketClusterRedisAddress,
+			Password: params.Cfg.WebsocketClusterRedisPassword,
+		})
+		jobsLocker = jobs.NewRedisLocker(client, jobsLockKey)
+	}
+	jobsService := jobs.New(jobs.Params{
+		Store:  params.DBStore,
+		Logger: params.Logger,
+		Locker: jobsLocker,
+	})
+
+	featureFlagsService := featureflags.New(featureflags.Params{
+		Store:  params.DBStore,
+		Logger: params.Logger,
+		Flags:  params.Cfg.FeatureFlags,
+	})
+
+	backupService := backup.New(backup.Params{
+		FilesBackend: filesBackend,
+		Logger:       params.Logger,
+		Path:         params.Cfg.BackupPath,
+		Retention:    params.Cfg.BackupRetention,
+	})
+
+	searchBackend, errSearch := NewSearchBackend(params.Cfg, params.Logger)
+	if errSearch != nil {
+		return nil, fmt.Errorf("unable to initialize the search backend: %w", errSearch)
+	}
+	// each server holds its own Bleve index, kept up to date with the
+	// changes of the other servers through the cluster
+	var searchCluster *search.Cluster
+	if index, ok := searchBackend.(*search.Index); ok {
+		searchCluster = search.NewCluster(index, params.Logger)
+	}
+
 	appServices := app.Services{
 		Auth:             authenticator,
 		Store:            params.DBStore,
@@ -138,10 +193,24 @@ func New(params Params) (*Server, error) {
 		Notifications:    notificationService,
 		Logger:           params.Logger,
 		Permissions:      params.PermissionsService,
+		Jobs:             jobsService,
+		FeatureFlags:     featureFlagsService,
+		Backups:          backupService,
+		SearchBackend:    searchBackend,
+		SearchCluster:    searchCluster,
 		SkipTemplateInit: utils.IsRunningUnitTests(),
 	}
 	app := app.New(params.Cfg, wsAdapter, appServices)
 
+	// the websocket server saves collaborative text edits through the app
+	if wsServer, ok := wsAdapter.(*ws.Server); ok {
+		wsServer.SetTextPersister(app)
+		wsServer.SetCRDTTextPersister(app)
+	}
+
+	// the webhooks only receive the changes their owners can see
+	webhookClient.SetBlockFilter(app.CanUserSeeBlock)
+
 	focalboardAPI := api.NewAPI(app, params.SingleUserToken, params.Cfg.AuthMode, params.PermissionsService, params.Logger, auditService)
 
 	// Local router for admin APIs
@@ -192,10 +261,12 @@ func New(params Params) (*Server, error) {
 		store:               params.DBStore,
 		filesBackend:        filesBackend,
 		telemetry:           telemetryService,
+		jobsService:         jobsService,
 		metricsServer:       metrics.NewMetricsServer(params.Cfg.PrometheusAddress, metricsService, params.Logger),
 		metricsService:      metricsService,
 		auditService:        auditService,
 		notificationService: notificationService,
+		searchCluster:       searchCluster,
 		logger:              params.Logger,
 		localRouter:         localRouter,
 		api:                 focalboardAPI,
@@ -207,6 +278,48 @@ func New(params Params) (*Server, error) {
 	return &server, nil
 }
 
+// NewFilesBackend returns the backend storing the files of the boards.
+func NewFilesBackend(cfg *config.Configuration) (filestore.FileBackend, error) {
+	filesBackendSettings := filestore.FileBackendSettings{}
+	filesBackendSettings.DriverName = cfg.FilesDriver
+	filesBackendSettings.Directory = cfg.FilesPath
+	filesBackendSettings.AmazonS3AccessKeyId = cfg.FilesS3Config.AccessKeyID
+	filesBackendSettings.AmazonS3SecretAccessKey = cfg.FilesS3Config.SecretAccessKey
+	filesBackendSettings.AmazonS3Bucket = cfg.FilesS3Config.Bucket
+	filesBackendSettings.AmazonS3PathPrefix = cfg.FilesS3Config.PathPrefix
+	filesBackendSettings.AmazonS3Region = cfg.FilesS3Config.Region
+	filesBackendSettings.AmazonS3Endpoint = cfg.FilesS3Config.Endpoint
+	filesBackendSettings.AmazonS3SSL = cfg.FilesS3Config.SSL
+	filesBackendSettings.AmazonS3SignV2 = cfg.FilesS3Config.SignV2
+	filesBackendSettings.AmazonS3SSE = cfg.FilesS3Config.SSE
+	filesBackendSettings.AmazonS3Trace = cfg.FilesS3Config.Trace
+
+	return filestore.NewFileBackend(filesBackendSettings)
+}
+
+// NewSearchBackend returns the backend of the full-text search of the
+// cards selected by the configuration.
+func NewSearchBackend(cfg *config.Configuration, logger *mlog.Logger) (search.Backend, error) {
+	switch cfg.SearchBackend {
+	case "", "bleve":
+		return search.NewIndex(cfg.SearchIndexPath)
+	case "memory":
+		return search.NewIndex("")
+	case "elasticsearch":
+		if cfg.ElasticsearchURL == "" {
+			return nil, errors.New("the Elasticsearch search backend needs elasticsearch_url")
+		}
+		return search.NewElasticsearch(search.ElasticsearchOptions{
+			URL:      cfg.ElasticsearchURL,
+			Index:    cfg.ElasticsearchIndex,
+			Username: cfg.ElasticsearchUsername,
+			Password: cfg.ElasticsearchPassword,
+		}, logger), nil
+	default:
+		return nil, fmt.Errorf("unknown search backend %q", cfg.SearchBackend)
+	}
+}
+
 func NewStore(config *config.Configuration, isSingleUser bool, logger *mlog.Logger) (store.Store, error) {
 	sqlDB, err := sql.Open(config.DBType, config.DBConfigString)
 	if err != nil {
@@ -214,6 +327,10 @@ func NewStore(config *config.Configuration, isSingleUser bool, logger *mlog.Logg
 		return nil, err
 	}
 
+	sqlDB.SetMaxOpenConns(config.DBMaxOpenConns)
+	sqlDB.SetMaxIdleConns(config.DBMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(config.DBConnMaxLifetimeSeconds) * time.Second)
+
 	err = sqlDB.Ping()
 	if err != nil {
 		logger.Error(`Database Ping failed`, mlog.Err(err))
@@ -252,19 +369,37 @@ func (s *Server) Start() error {
 		}
 	}
 
-	if s.config.AuthMode != MattermostAuthMod {
-		s.cleanUpSessionsTask = scheduler.CreateRecurringTask("cleanUpSessions", func() {
-			secondsAgo := minSessionExpiryTime
-			if secondsAgo < s.config.SessionExpireTime {
-				secondsAgo = s.config.SessionExpireTime
-			}
+	if !s.config.EnablePublicSharedBoards {
+		// the sharing may have been disabled while the server was stopped
+		s.applyPublicSharedBoards(false)
+	}
 
-			if err := s.store.CleanUpSessions(secondsAgo); err != nil {
-				s.logger.Error("Unable to clean up the sessions", mlog.Err(err))
-			}
-		}, cleanupSessionTaskFrequency)
+	if wsServer, ok := s.wsAdapter.(*ws.Server); ok && s.config.WebsocketClusterRedisAddress != "" {
+		opts := redis.Options{
+			Address:  s.config.WebsocketClusterRedisAddress,
+			Password: s.config.WebsocketClusterRedisPassword,
+		}
+		if err := wsServer.StartCluster(opts, websocketClusterChannel); err != nil {
+			return fmt.Errorf("cannot start the websocket cluster: %w", err)
+		}
 	}
 
+	if s.searchCluster != nil && s.config.WebsocketClusterRedisAddress != "" {
+		opts := redis.Options{
+			Address:  s.config.WebsocketClusterRedisAddress,
+			Password: s.config.WebsocketClusterRedisPassword,
+		}
+		if err := s.searchCluster.Start(opts, searchClusterChannel); err != nil {
+			return fmt.Errorf("cannot start the search cluster: %w", err)
+		}
+	}
+
+	s.registerJobs()
+	if err := s.app.EnqueueFileInfoSeed(); err != nil {
+		s.logger.Error("Cannot enqueue the recording of the size of the stored files", mlog.Err(err))
+	}
+	s.jobsService.Start()
+
 	metricsUpdater := func() {
 		blockCounts, err := s.store.GetBlockCountsByType()
 		if err != nil {
@@ -309,6 +444,82 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// registerJobs registers the workers of the background jobs run by the
+// server and schedules the recurring ones.
+func (s *Server) registerJobs() {
+	if s.config.AuthMode != MattermostAuthMod {
+		s.jobsService.RegisterWorker(cleanUpSessionsJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+			secondsAgo := minSessionExpiryTime
+			if secondsAgo < s.config.SessionExpireTime {
+				secondsAgo = s.config.SessionExpireTime
+			}
+
+			if err := s.store.CleanUpSessions(secondsAgo); err != nil {
+				return nil, fmt.Errorf("unable to clean up the sessions: %w", err)
+			}
+			if err := s.app.CleanUpUserTokens(); err != nil {
+				return nil, fmt.Errorf("unable to clean up the user tokens: %w", err)
+			}
+			return nil, nil
+		})
+		s.jobsService.Schedule(cleanUpSessionsJob, cleanupSessionTaskFrequency)
+	}
+
+	s.jobsService.RegisterWorker(escalationsJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+		if err := s.app.RunEscalationPolicies(time.Now()); err != nil {
+			return nil, fmt.Errorf("unable to run the escalation policies: %w", err)
+		}
+		return nil, nil
+	})
+	s.jobsService.Schedule(escalationsJob, escalationsTaskFrequency)
+
+	s.jobsService.RegisterWorker(autoArchiveJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+		if err := s.app.RunAutoArchiveRules(time.Now()); err != nil {
+			return nil, fmt.Errorf("unable to run the auto-archive rules: %w", err)
+		}
+		return nil, nil
+	})
+	s.jobsService.Schedule(autoArchiveJob, autoArchiveTaskFrequency)
+
+	if s.config.DormantBoardDays > 0 {
+		s.jobsService.RegisterWorker(dormantBoardsJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+			if err := s.app.NotifyDormantBoards(s.config.DormantBoardDays, dormantBoardsTaskFrequency, time.Now()); err != nil {
+				return nil, fmt.Errorf("unable to notify the dormant boards: %w", err)
+			}
+			return nil, nil
+		})
+		s.jobsService.Schedule(dormantBoardsJob, dormantBoardsTaskFrequency)
+	}
+
+	s.jobsService.RegisterWorker(cardRemindersJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+		if err := s.app.DeliverDueCardReminders(time.Now()); err != nil {
+			return nil, fmt.Errorf("unable to deliver the card reminders: %w", err)
+		}
+		return nil, nil
+	})
+	s.jobsService.Schedule(cardRemindersJob, cardRemindersTaskFrequency)
+
+	if s.config.TrashRetentionDays > 0 {
+		s.jobsService.RegisterWorker(purgeDeletedJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+			if err := s.app.PurgeDeletedBlocks(time.Now()); err != nil {
+				return nil, fmt.Errorf("unable to purge the deleted blocks: %w", err)
+			}
+			return nil, nil
+		})
+		s.jobsService.Schedule(purgeDeletedJob, purgeDeletedTaskFrequency)
+	}
+
+	if s.config.BackupIntervalHours > 0 {
+		s.jobsService.RegisterWorker(backupTeamsJob, func(context.Context, *appModel.Job, jobs.ProgressFunc) (map[string]interface{}, error) {
+			if err := s.app.BackupTeams(); err != nil {
+				return nil, fmt.Errorf("unable to back up the teams: %w", err)
+			}
+			return nil, nil
+		})
+		s.jobsService.Schedule(backupTeamsJob, time.Duration(s.config.BackupIntervalHours)*time.Hour)
+	}
+}
+
 func (s *Server) Shutdown() error {
 	if err := s.webServer.Shutdown(); err != nil {
 		return err
@@ -319,9 +530,7 @@ func (s *Server) Shutdown() error {
 	s.servicesStartStopMutex.Lock()
 	defer s.servicesStartStopMutex.Unlock()
 
-	if s.cleanUpSessionsTask != nil {
-		s.cleanUpSessionsTask.Cancel()
-	}
+	s.jobsService.Stop()
 
 	if s.metricsUpdaterTask != nil {
 		s.metricsUpdaterTask.Cancel()
@@ -339,6 +548,16 @@ func (s *Server) Shutdown() error {
 		s.logger.Warn("Error occurred when shutting down notification service", mlog.Err(err))
 	}
 
+	if wsServer, ok := s.wsAdapter.(*ws.Server); ok {
+		// the edits are saved before the text blocks are released for the
+		// other nodes
+		wsServer.FlushTextEdits()
+		wsServer.StopCluster()
+	}
+	if s.searchCluster != nil {
+		s.searchCluster.Stop()
+	}
+
 	s.app.Shutdown()
 
 	defer s.logger.Info("Server.Shutdown")
@@ -366,6 +585,51 @@ func (s *Server) UpdateAppConfig() {
 	s.app.SetConfig(s.config)
 }
 
+// SetPublicSharedBoards enables or disables the public sharing of boards,
+// recording the change in the audit log. Disabling it revokes the existing
+// share links, so that they don't work again if it's enabled back.
+func (s *Server) SetPublicSharedBoards(enabled bool) {
+	if s.config.EnablePublicSharedBoards == enabled {
+		return
+	}
+	s.config.EnablePublicSharedBoards = enabled
+	s.applyPublicSharedBoards(true)
+}
+
+// applyPublicSharedBoards revokes the share links of the boards if public
+// sharing is disabled. It's recorded in the audit log if the setting
+// changed or if any link was revoked.
+func (s *Server) applyPublicSharedBoards(changed bool) {
+	enabled := s.config.EnablePublicSharedBoards
+
+	auditRec := &audit.Record{
+		Event:  "setPublicSharedBoards",
+		Status: audit.Fail,
+		UserID: appModel.SystemUserID,
+	}
+	auditRec.AddMeta("enabled", enabled)
+
+	var revoked int64
+	if !enabled {
+		var err error
+		if revoked, err = s.app.RevokeSharing(); err != nil {
+			s.logger.Error("Unable to revoke the share links of the boards", mlog.Err(err))
+			s.auditService.LogRecord(audit.LevelModify, auditRec)
+			return
+		}
+		auditRec.AddMeta("revokedLinks", revoked)
+	}
+
+	if changed || revoked > 0 {
+		s.logger.Info("Public sharing of boards changed",
+			mlog.Bool("enabled", enabled),
+			mlog.Int64("revoked_links", revoked),
+		)
+		auditRec.Success()
+		s.auditService.LogRecord(audit.LevelModify, auditRec)
+	}
+}
+
 // Local server
 
 func (s *Server) startLocalModeServer() error {
@@ -441,6 +705,9 @@ func initTelemetry(opts telemetryOptions) *telemetry.Service {
 			"port":                       opts.cfg.Port == config.DefaultPort,
 			"useSSL":                     opts.cfg.UseSSL,
 			"dbType":                     opts.cfg.DBType,
+			"db_max_open_conns":          opts.cfg.DBMaxOpenConns,
+			"db_max_idle_conns":          opts.cfg.DBMaxIdleConns,
+			"db_conn_max_lifetime":       opts.cfg.DBConnMaxLifetimeSeconds,
 			"single_user":                opts.singleUser,
 			"allow_public_shared_boards": opts.cfg.EnablePublicSharedBoards,
 		}, nil
@@ -0,0 +1,71 @@
+package auth
+
+// commonPasswords are the most frequent passwords of the public breach
+// compilations, lowercased. Their variants with a different case are
+// rejected too.
+var commonPasswords = map[string]bool{
+	"123456":        true,
+	"123456789":     true,
+	"12345678":      true,
+	"1234567890":    true,
+	"12345":         true,
+	"1234567":       true,
+	"123123":        true,
+	"111111":        true,
+	"000000":        true,
+	"654321":        true,
+	"666666":        true,
+	"121212":        true,
+	"112233":        true,
+	"11111111":      true,
+	"87654321":      true,
+	"123321":        true,
+	"1q2w3e4r":      true,
+	"1q2w3e4r5t":    true,
+	"1qaz2wsx":      true,
+	"qwerty":        true,
+	"qwerty123":     true,
+	"qwertyuiop":    true,
+	"qwe123":        true,
+	"asdfghjkl":     true,
+	"zxcvbnm":       true,
+	"abc123":        true,
+	"a1b2c3d4":      true,
+	"password":      true,
+	"password1":     true,
+	"password123":   true,
+	"passw0rd":      true,
+	"p@ssw0rd":      true,
+	"p@ssword":      true,
+	"pa$$word":      true,
+	"admin":         true,
+	"admin123":      true,
+	"administrator": true,
+	"root":          true,
+	"letmein":       true,
+	"welcome":       true,
+	"welcome1":      true,
+	"iloveyou":      true,
+	"monkey":        true,
+	"dragon":        true,
+	"sunshine":      true,
+	"princess":      true,
+	"football":      true,
+	"baseball":      true,
+	"superman":      true,
+	"starwars":      true,
+	"trustno1":      true,
+	"master":        true,
+	"shadow":        true,
+	"michael":       true,
+	"jennifer":      true,
+	"computer":      true,
+	"whatever":      true,
+	"freedom":       true,
+	"changeme":      true,
+	"secret":        true,
+	"login":         true,
+	"default":       true,
+	"focalboard":    true,
+	"mattermost":    true,
+}
@@ -21,6 +21,7 @@ const (
 	InvalidNumberPassword    = "number"
 	InvalidUppercasePassword = "uppercase"
 	InvalidSymbolPassword    = "symbol"
+	InvalidBannedPassword    = "banned"
 )
 
 var PasswordHashStrength = 10
@@ -59,6 +60,11 @@ type PasswordSettings struct {
 	Number        bool
 	Uppercase     bool
 	Symbol        bool
+	// BanCommon rejects the most common passwords
+	BanCommon bool
+	// Banned are rejected in addition to the common passwords, ignoring
+	// the case
+	Banned []string
 }
 
 func IsPasswordValid(password string, settings PasswordSettings) error {
@@ -98,9 +104,26 @@ func IsPasswordValid(password string, settings PasswordSettings) error {
 		}
 	}
 
+	if isPasswordBanned(password, settings) {
+		err.FailingCriterias = append(err.FailingCriterias, InvalidBannedPassword)
+	}
+
 	if len(err.FailingCriterias) > 0 {
 		return err
 	}
 
 	return nil
 }
+
+func isPasswordBanned(password string, settings PasswordSettings) bool {
+	lowered := strings.ToLower(password)
+	if settings.BanCommon && commonPasswords[lowered] {
+		return true
+	}
+	for _, banned := range settings.Banned {
+		if strings.ToLower(strings.TrimSpace(banned)) == lowered {
+			return true
+		}
+	}
+	return false
+}
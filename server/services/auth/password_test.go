@@ -118,6 +118,38 @@ func TestIsPasswordValidWithSettings(t *testing.T) {
 			},
 			ExpectedFailingCriterias: []string{"uppercase", "number", "symbol"},
 		},
+		"Common": {
+			Password: "Password123",
+			Settings: PasswordSettings{
+				MinimumLength: 3,
+				BanCommon:     true,
+			},
+			ExpectedFailingCriterias: []string{"banned"},
+		},
+		"CommonAllowed": {
+			Password: "Password123",
+			Settings: PasswordSettings{
+				MinimumLength: 3,
+				BanCommon:     false,
+			},
+		},
+		"Banned": {
+			Password: "BoardsRock",
+			Settings: PasswordSettings{
+				MinimumLength: 3,
+				BanCommon:     true,
+				Banned:        []string{"boardsrock"},
+			},
+			ExpectedFailingCriterias: []string{"banned"},
+		},
+		"ShortAndBanned": {
+			Password: "admin",
+			Settings: PasswordSettings{
+				MinimumLength: 8,
+				BanCommon:     true,
+			},
+			ExpectedFailingCriterias: []string{"min-length", "banned"},
+		},
 		"Everything": {
 			Password: "asdASD!@#123",
 			Settings: PasswordSettings{
@@ -0,0 +1,204 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	DefaultPath      = "backups"
+	DefaultRetention = 7
+
+	backupPrefix     = "backup-"
+	backupExtension  = ".boardarchive"
+	backupTimeLayout = "20060102-150405"
+)
+
+var ErrInvalidBackupName = errors.New("invalid backup name")
+
+// WriteFunc writes the archive of a backup.
+type WriteFunc func(w io.Writer) error
+
+type Params struct {
+	FilesBackend filestore.FileBackend
+	Logger       *mlog.Logger
+	// Path is the directory of the files storage holding the backups,
+	// with a directory per team.
+	Path string
+	// Retention is the number of backups kept for each team, the oldest
+	// ones are removed when a new backup is saved.
+	Retention int
+}
+
+// Service stores the backups of the teams in the files storage, which
+// can be a local directory or S3, and removes the ones past retention.
+type Service struct {
+	filesBackend filestore.FileBackend
+	logger       *mlog.Logger
+	path         string
+	retention    int
+
+	// mu serializes the backups, so retention doesn't run concurrently
+	// with a backup of the same team
+	mu sync.Mutex
+}
+
+func New(params Params) *Service {
+	if params.Path == "" {
+		params.Path = DefaultPath
+	}
+	if params.Retention <= 0 {
+		params.Retention = DefaultRetention
+	}
+	return &Service{
+		filesBackend: params.FilesBackend,
+		logger:       params.Logger,
+		path:         params.Path,
+		retention:    params.Retention,
+	}
+}
+
+// Save writes a new backup of the team and removes its backups past
+// retention.
+func (s *Service) Save(teamID string, write WriteFunc, now time.Time) (*model.Backup, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := backupPrefix + now.UTC().Format(backupTimeLayout) + backupExtension
+
+	// the archive is streamed to the files storage while it is written
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+
+	size, err := s.filesBackend.WriteFile(pr, s.backupPath(teamID, name))
+	pr.CloseWithError(err)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store the backup of team %s: %w", teamID, err)
+	}
+
+	if err := s.prune(teamID); err != nil {
+		// the backup is saved, the old ones are removed with the next one
+		s.logger.Error("unable to remove the old backups",
+			mlog.String("teamID", teamID),
+			mlog.Err(err),
+		)
+	}
+
+	return &model.Backup{
+		TeamID:   teamID,
+		Name:     name,
+		Size:     size,
+		CreateAt: now.UnixNano() / int64(time.Millisecond),
+	}, nil
+}
+
+// List returns the backups of the team, the most recent first.
+func (s *Service) List(teamID string) ([]*model.Backup, error) {
+	names, err := s.listNames(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]*model.Backup, 0, len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		size, err := s.filesBackend.FileSize(s.backupPath(teamID, names[i]))
+		if err != nil {
+			return nil, err
+		}
+		createAt, _ := parseBackupTime(names[i])
+		backups = append(backups, &model.Backup{
+			TeamID:   teamID,
+			Name:     names[i],
+			Size:     size,
+			CreateAt: createAt.UnixNano() / int64(time.Millisecond),
+		})
+	}
+	return backups, nil
+}
+
+// Open returns a reader of a backup of the team.
+func (s *Service) Open(teamID, name string) (filestore.ReadCloseSeeker, error) {
+	if _, err := parseBackupTime(name); err != nil || !isValidTeamID(teamID) {
+		return nil, ErrInvalidBackupName
+	}
+
+	backupPath := s.backupPath(teamID, name)
+	exists, err := s.filesBackend.FileExists(backupPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, model.NewErrNotFound(name)
+	}
+	return s.filesBackend.Reader(backupPath)
+}
+
+// prune removes the oldest backups of the team past retention.
+func (s *Service) prune(teamID string) error {
+	names, err := s.listNames(teamID)
+	if err != nil {
+		return err
+	}
+
+	for len(names) > s.retention {
+		if err := s.filesBackend.RemoveFile(s.backupPath(teamID, names[0])); err != nil {
+			return err
+		}
+		s.logger.Debug("removed old backup", mlog.String("teamID", teamID), mlog.String("name", names[0]))
+		names = names[1:]
+	}
+	return nil
+}
+
+// listNames returns the names of the backups of the team, the oldest
+// first as the names sort by time.
+func (s *Service) listNames(teamID string) ([]string, error) {
+	paths, err := s.filesBackend.ListDirectory(path.Join(s.path, teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(paths))
+	for _, p := range paths {
+		name := path.Base(p)
+		if _, err := parseBackupTime(name); err == nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *Service) backupPath(teamID, name string) string {
+	return path.Join(s.path, teamID, name)
+}
+
+// isValidTeamID checks that the team id can't point outside of the
+// directory of the backups.
+func isValidTeamID(teamID string) bool {
+	return teamID != "" && teamID != "." && teamID != ".." && !strings.ContainsAny(teamID, "/\\")
+}
+
+func parseBackupTime(name string) (time.Time, error) {
+	if !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupExtension) {
+		return time.Time{}, ErrInvalidBackupName
+	}
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupExtension)
+	return time.Parse(backupTimeLayout, timestamp)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package backup
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/filestore"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+func setupService(t *testing.T, retention int) *Service {
+	filesBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: "local",
+		Directory:  t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	return New(Params{
+		FilesBackend: filesBackend,
+		Logger:       mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+		Retention:    retention,
+	})
+}
+
+func writeString(content string) WriteFunc {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+}
+
+func TestSave(t *testing.T) {
+	s := setupService(t, 2)
+	start := time.Date(2022, 5, 26, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		backup, err := s.Save("team-1", writeString("archive"), start.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(len("archive")), backup.Size)
+	}
+	_, err := s.Save("team-2", writeString("other archive"), start)
+	require.NoError(t, err)
+
+	t.Run("the oldest backups past retention are removed", func(t *testing.T) {
+		backups, err := s.List("team-1")
+		require.NoError(t, err)
+		require.Len(t, backups, 2)
+		assert.Equal(t, "backup-20220526-120000.boardarchive", backups[0].Name)
+		assert.Equal(t, "backup-20220526-110000.boardarchive", backups[1].Name)
+		assert.Equal(t, start.Add(2*time.Hour).UnixNano()/int64(time.Millisecond), backups[0].CreateAt)
+	})
+
+	t.Run("the teams have their own backups", func(t *testing.T) {
+		backups, err := s.List("team-2")
+		require.NoError(t, err)
+		require.Len(t, backups, 1)
+
+		file, err := s.Open("team-2", backups[0].Name)
+		require.NoError(t, err)
+		defer file.Close()
+		content, err := ioutil.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "other archive", string(content))
+	})
+
+	t.Run("teams without backups", func(t *testing.T) {
+		backups, err := s.List("team-3")
+		require.NoError(t, err)
+		assert.Empty(t, backups)
+	})
+}
+
+func TestOpen(t *testing.T) {
+	s := setupService(t, 1)
+
+	t.Run("missing backup", func(t *testing.T) {
+		_, err := s.Open("team-1", "backup-20220526-100000.boardarchive")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("invalid names", func(t *testing.T) {
+		_, err := s.Open("team-1", "../config.json")
+		require.ErrorIs(t, err, ErrInvalidBackupName)
+
+		_, err = s.Open("..", "backup-20220526-100000.boardarchive")
+		require.ErrorIs(t, err, ErrInvalidBackupName)
+	})
+}
@@ -31,6 +31,9 @@ type Configuration struct {
 	DBType                   string            `json:"dbtype" mapstructure:"dbtype"`
 	DBConfigString           string            `json:"dbconfig" mapstructure:"dbconfig"`
 	DBTablePrefix            string            `json:"dbtableprefix" mapstructure:"dbtableprefix"`
+	DBMaxOpenConns           int               `json:"dbmaxopenconns" mapstructure:"dbmaxopenconns"`
+	DBMaxIdleConns           int               `json:"dbmaxidleconns" mapstructure:"dbmaxidleconns"`
+	DBConnMaxLifetimeSeconds int               `json:"dbconnmaxlifetimeseconds" mapstructure:"dbconnmaxlifetimeseconds"`
 	UseSSL                   bool              `json:"useSSL" mapstructure:"useSSL"`
 	SecureCookie             bool              `json:"secureCookie" mapstructure:"secureCookie"`
 	WebPath                  string            `json:"webpath" mapstructure:"webpath"`
@@ -38,17 +41,36 @@ type Configuration struct {
 	FilesS3Config            AmazonS3Config    `json:"filess3config" mapstructure:"filess3config"`
 	FilesPath                string            `json:"filespath" mapstructure:"filespath"`
 	MaxFileSize              int64             `json:"maxfilesize" mapstructure:"mafilesize"`
+	AllowedFileExtensions    []string          `json:"allowed_file_extensions" mapstructure:"allowed_file_extensions"`
+	BlockedFileExtensions    []string          `json:"blocked_file_extensions" mapstructure:"blocked_file_extensions"`
+	StripImageMetadata       bool              `json:"strip_image_metadata" mapstructure:"strip_image_metadata"`
 	Telemetry                bool              `json:"telemetry" mapstructure:"telemetry"`
 	TelemetryID              string            `json:"telemetryid" mapstructure:"telemetryid"`
 	PrometheusAddress        string            `json:"prometheusaddress" mapstructure:"prometheusaddress"`
-	WebhookUpdate            []string          `json:"webhook_update" mapstructure:"webhook_update"`
+	WebhookUpdate            []string          `json:"webhook_update" mapstructure:"webhook_update"` // Deprecated: registered as webhooks of the team at startup
 	Secret                   string            `json:"secret" mapstructure:"secret"`
 	SessionExpireTime        int64             `json:"session_expire_time" mapstructure:"session_expire_time"`
 	SessionRefreshTime       int64             `json:"session_refresh_time" mapstructure:"session_refresh_time"`
+	SessionIdleTimeout       int64             `json:"session_idle_timeout" mapstructure:"session_idle_timeout"`
+	SessionMaxLifetime       int64             `json:"session_max_lifetime" mapstructure:"session_max_lifetime"`
+	SessionReauthTime        int64             `json:"session_reauth_time" mapstructure:"session_reauth_time"`
+	PasswordMinimumLength    int               `json:"password_minimum_length" mapstructure:"password_minimum_length"`
+	PasswordRequireLowercase bool              `json:"password_require_lowercase" mapstructure:"password_require_lowercase"`
+	PasswordRequireUppercase bool              `json:"password_require_uppercase" mapstructure:"password_require_uppercase"`
+	PasswordRequireNumber    bool              `json:"password_require_number" mapstructure:"password_require_number"`
+	PasswordRequireSymbol    bool              `json:"password_require_symbol" mapstructure:"password_require_symbol"`
+	PasswordBanCommon        bool              `json:"password_ban_common" mapstructure:"password_ban_common"`
+	PasswordBannedList       []string          `json:"password_banned_list" mapstructure:"password_banned_list"`
+	EnableSignupToken        bool              `json:"enable_signup_token" mapstructure:"enable_signup_token"`
+	AdminUsers               []string          `json:"admin_users" mapstructure:"admin_users"`
+	EnableWelcomeBoard       bool              `json:"enable_welcome_board" mapstructure:"enable_welcome_board"`
+	WelcomeBoardTemplateID   string            `json:"welcome_board_template_id" mapstructure:"welcome_board_template_id"`
 	LocalOnly                bool              `json:"localonly" mapstructure:"localonly"`
 	EnableLocalMode          bool              `json:"enableLocalMode" mapstructure:"enableLocalMode"`
 	LocalModeSocketLocation  string            `json:"localModeSocketLocation" mapstructure:"localModeSocketLocation"`
 	EnablePublicSharedBoards bool              `json:"enablePublicSharedBoards" mapstructure:"enablePublicSharedBoards"`
+	SharedBoardsAllowedCIDRs []string          `json:"shared_boards_allowed_cidrs" mapstructure:"shared_boards_allowed_cidrs"`
+	TrustedProxies           []string          `json:"trusted_proxies" mapstructure:"trusted_proxies"`
 	FeatureFlags             map[string]string `json:"featureFlags" mapstructure:"featureFlags"`
 	EnableDataRetention      bool              `json:"enable_data_retention" mapstructure:"enable_data_retention"`
 	DataRetentionDays        int               `json:"data_retention_days" mapstructure:"data_retention_days"`
@@ -63,6 +85,52 @@ type Configuration struct {
 
 	NotifyFreqCardSeconds  int `json:"notify_freq_card_seconds" mapstructure:"notify_freq_card_seconds"`
 	NotifyFreqBoardSeconds int `json:"notify_freq_board_seconds" mapstructure:"notify_freq_board_seconds"`
+	MentionReminderHours   int `json:"mention_reminder_hours" mapstructure:"mention_reminder_hours"`
+	DormantBoardDays       int `json:"dormant_board_days" mapstructure:"dormant_board_days"`
+	TrashRetentionDays     int `json:"trash_retention_days" mapstructure:"trash_retention_days"`
+
+	CardLimit              int   `json:"card_limit" mapstructure:"card_limit"`
+	ViewLimit              int   `json:"view_limit" mapstructure:"view_limit"`
+	AttachmentStorageLimit int64 `json:"attachment_storage_limit" mapstructure:"attachment_storage_limit"`
+
+	SubmissionsPerMinute int    `json:"submissions_per_minute" mapstructure:"submissions_per_minute"`
+	CaptchaVerifyURL     string `json:"captcha_verify_url" mapstructure:"captcha_verify_url"`
+	CaptchaSecret        string `json:"captcha_secret" mapstructure:"captcha_secret"`
+
+	BackupIntervalHours int    `json:"backup_interval_hours" mapstructure:"backup_interval_hours"`
+	BackupRetention     int    `json:"backup_retention" mapstructure:"backup_retention"`
+	BackupPath          string `json:"backup_path" mapstructure:"backup_path"`
+
+	EnableGraphQL bool `json:"enable_graphql" mapstructure:"enable_graphql"`
+
+	RateLimitUserPerSecond float64 `json:"rate_limit_user_per_second" mapstructure:"rate_limit_user_per_second"`
+	RateLimitUserBurst     int     `json:"rate_limit_user_burst" mapstructure:"rate_limit_user_burst"`
+	RateLimitIPPerSecond   float64 `json:"rate_limit_ip_per_second" mapstructure:"rate_limit_ip_per_second"`
+	RateLimitIPBurst       int     `json:"rate_limit_ip_burst" mapstructure:"rate_limit_ip_burst"`
+	RateLimitRedisAddress  string  `json:"rate_limit_redis_address" mapstructure:"rate_limit_redis_address"`
+	RateLimitRedisPassword string  `json:"rate_limit_redis_password" mapstructure:"rate_limit_redis_password"`
+
+	WebsocketClusterRedisAddress  string `json:"websocket_cluster_redis_address" mapstructure:"websocket_cluster_redis_address"`
+	WebsocketClusterRedisPassword string `json:"websocket_cluster_redis_password" mapstructure:"websocket_cluster_redis_password"`
+
+	LoginMaxAttemptsPerIP      int `json:"login_max_attempts_per_ip" mapstructure:"login_max_attempts_per_ip"`
+	LoginMaxAttemptsPerAccount int `json:"login_max_attempts_per_account" mapstructure:"login_max_attempts_per_account"`
+	LoginAttemptWindow         int `json:"login_attempt_window" mapstructure:"login_attempt_window"`
+	LoginLockoutDuration       int `json:"login_lockout_duration" mapstructure:"login_lockout_duration"`
+
+	SMTPServer             string `json:"smtp_server" mapstructure:"smtp_server"`
+	SMTPPort               int    `json:"smtp_port" mapstructure:"smtp_port"`
+	SMTPUsername           string `json:"smtp_username" mapstructure:"smtp_username"`
+	SMTPPassword           string `json:"smtp_password" mapstructure:"smtp_password"`
+	SMTPConnectionSecurity string `json:"smtp_connection_security" mapstructure:"smtp_connection_security"`
+	SMTPFromAddress        string `json:"smtp_from_address" mapstructure:"smtp_from_address"`
+
+	SearchBackend         string `json:"search_backend" mapstructure:"search_backend"`
+	SearchIndexPath       string `json:"search_index_path" mapstructure:"search_index_path"`
+	ElasticsearchURL      string `json:"elasticsearch_url" mapstructure:"elasticsearch_url"`
+	ElasticsearchIndex    string `json:"elasticsearch_index" mapstructure:"elasticsearch_index"`
+	ElasticsearchUsername string `json:"elasticsearch_username" mapstructure:"elasticsearch_username"`
+	ElasticsearchPassword string `json:"elasticsearch_password" mapstructure:"elasticsearch_password"`
 }
 
 // ReadConfigFile read the configuration from the filesystem.
@@ -80,26 +148,85 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 	viper.SetDefault("DBType", "sqlite3")
 	viper.SetDefault("DBConfigString", "./focalboard.db")
 	viper.SetDefault("DBTablePrefix", "")
+	viper.SetDefault("DBMaxOpenConns", 20)             // 0 means unlimited
+	viper.SetDefault("DBMaxIdleConns", 10)             // 0 keeps no idle connections
+	viper.SetDefault("DBConnMaxLifetimeSeconds", 3600) // 0 reuses connections forever
 	viper.SetDefault("SecureCookie", false)
 	viper.SetDefault("WebPath", "./pack")
 	viper.SetDefault("FilesPath", "./files")
 	viper.SetDefault("FilesDriver", "local")
+	viper.SetDefault("AllowedFileExtensions", []string{}) // any extension when empty
+	viper.SetDefault("BlockedFileExtensions", []string{})
+	viper.SetDefault("StripImageMetadata", true) // removes the EXIF data of uploaded JPEG and PNG images
 	viper.SetDefault("Telemetry", true)
 	viper.SetDefault("TelemetryID", "")
 	viper.SetDefault("WebhookUpdate", nil)
 	viper.SetDefault("SessionExpireTime", 60*60*24*30) // 30 days session lifetime
 	viper.SetDefault("SessionRefreshTime", 60*60*5)    // 5 minutes session refresh
+	viper.SetDefault("SessionIdleTimeout", 0)          // 0 for sessions to only expire after the session expire time
+	viper.SetDefault("SessionMaxLifetime", 0)          // 0 for no limit since the login
+	viper.SetDefault("SessionReauthTime", 0)           // 0 for the sensitive endpoints not to require a recent login
+	viper.SetDefault("PasswordMinimumLength", 8)
+	viper.SetDefault("PasswordRequireLowercase", false)
+	viper.SetDefault("PasswordRequireUppercase", false)
+	viper.SetDefault("PasswordRequireNumber", false)
+	viper.SetDefault("PasswordRequireSymbol", false)
+	viper.SetDefault("PasswordBanCommon", true)        // rejects the most common passwords
+	viper.SetDefault("PasswordBannedList", []string{}) // passwords rejected in addition to the common ones
+	viper.SetDefault("EnableSignupToken", false)       // lets anyone with the shared team signup link register, instead of invites only
+	viper.SetDefault("AdminUsers", []string{})         // usernames of the users administering the teams of a standalone server
+	viper.SetDefault("EnableWelcomeBoard", false)      // creates a personal board from a template for every new user
+	viper.SetDefault("WelcomeBoardTemplateID", "")     // the template of the welcome board, the built-in welcome board if empty
 	viper.SetDefault("LocalOnly", false)
 	viper.SetDefault("EnableLocalMode", false)
 	viper.SetDefault("LocalModeSocketLocation", "/var/tmp/focalboard_local.socket")
 	viper.SetDefault("EnablePublicSharedBoards", false)
+	viper.SetDefault("SharedBoardsAllowedCIDRs", []string{}) // any address when empty
+	viper.SetDefault("TrustedProxies", []string{})           // CIDR ranges of the reverse proxies whose X-Forwarded-For and X-Real-IP headers are honored
 	viper.SetDefault("FeatureFlags", map[string]string{})
 	viper.SetDefault("AuthMode", "native")
 	viper.SetDefault("NotifyFreqCardSeconds", 120)    // 2 minutes after last card edit
 	viper.SetDefault("NotifyFreqBoardSeconds", 86400) // 1 day after last card edit
+	viper.SetDefault("MentionReminderHours", 24)      // remind unread mentions after 1 day, 0 disables
+	viper.SetDefault("DormantBoardDays", 90)          // days without activity before suggesting to archive a board, 0 disables
+	viper.SetDefault("TrashRetentionDays", 30)        // days deleted blocks can be restored, 0 keeps them forever
 	viper.SetDefault("EnableDataRetention", false)
 	viper.SetDefault("DataRetentionDays", 365) // 1 year is default
 	viper.SetDefault("PrometheusAddress", "")
+	viper.SetDefault("CardLimit", 0)              // maximum cards per board, 0 disables
+	viper.SetDefault("ViewLimit", 0)              // maximum views per board, 0 disables
+	viper.SetDefault("AttachmentStorageLimit", 0) // maximum bytes of all attachments, 0 disables
+	viper.SetDefault("SubmissionsPerMinute", 5)   // anonymous submissions per client and board
+	viper.SetDefault("CaptchaVerifyURL", "")
+	viper.SetDefault("CaptchaSecret", "")
+	viper.SetDefault("BackupIntervalHours", 0) // hours between the backups of the teams, 0 disables
+	viper.SetDefault("BackupRetention", 7)     // backups kept for each team
+	viper.SetDefault("BackupPath", "backups")  // directory of the backups in the files storage
+	viper.SetDefault("EnableGraphQL", false)
+	viper.SetDefault("RateLimitUserPerSecond", 0) // sustained API requests per second and user, 0 disables
+	viper.SetDefault("RateLimitUserBurst", 100)
+	viper.SetDefault("RateLimitIPPerSecond", 0) // sustained API requests per second and client address, 0 disables
+	viper.SetDefault("RateLimitIPBurst", 200)
+	viper.SetDefault("RateLimitRedisAddress", "") // shares the limits between servers, in memory when empty
+	viper.SetDefault("RateLimitRedisPassword", "")
+	viper.SetDefault("WebsocketClusterRedisAddress", "") // broadcasts the changes to the clients of all the servers and schedules the jobs on one of them, local only when empty
+	viper.SetDefault("WebsocketClusterRedisPassword", "")
+	viper.SetDefault("LoginMaxAttemptsPerIP", 50)      // failed logins of a client address before a lockout, 0 disables, as needed behind proxies missing from TrustedProxies
+	viper.SetDefault("LoginMaxAttemptsPerAccount", 10) // failed logins of an account before a lockout, 0 disables
+	viper.SetDefault("LoginAttemptWindow", 60*15)      // seconds the failed logins are counted over
+	viper.SetDefault("LoginLockoutDuration", 60*15)    // seconds of lockout, 0 disables the login throttling
+	viper.SetDefault("SMTPServer", "")                 // sends the email verification and password reset emails, disabled when empty
+	viper.SetDefault("SMTPPort", 25)
+	viper.SetDefault("SMTPUsername", "")
+	viper.SetDefault("SMTPPassword", "")
+	viper.SetDefault("SMTPConnectionSecurity", "") // "", "TLS" or "STARTTLS"
+	viper.SetDefault("SMTPFromAddress", "")
+	viper.SetDefault("SearchBackend", "bleve")            // "bleve", or "elasticsearch" for large deployments
+	viper.SetDefault("SearchIndexPath", "./search-index") // directory of the Bleve index, kept in memory when empty
+	viper.SetDefault("ElasticsearchURL", "")
+	viper.SetDefault("ElasticsearchIndex", "focalboard-cards")
+	viper.SetDefault("ElasticsearchUsername", "")
+	viper.SetDefault("ElasticsearchPassword", "")
 
 	err := viper.ReadInConfig() // Find and read the config file
 	if err != nil {             // Handle errors reading the config file
@@ -121,5 +248,8 @@ func ReadConfigFile(configFilePath string) (*Configuration, error) {
 
 func removeSecurityData(config Configuration) Configuration {
 	clean := config
+	clean.CaptchaSecret = ""
+	clean.ElasticsearchPassword = ""
+	clean.SMTPPassword = ""
 	return clean
 }
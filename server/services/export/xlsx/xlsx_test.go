@@ -0,0 +1,57 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "Tasks: [Q3]", [][]string{
+		{"Title", "Status"},
+		{"Fix <bug> & ship", ""},
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	require.Contains(t, files, "[Content_Types].xml")
+	require.Contains(t, files, "_rels/.rels")
+	require.Contains(t, files, "xl/_rels/workbook.xml.rels")
+	require.Contains(t, files["xl/workbook.xml"], `name="Tasks_ _Q3_"`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	require.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">Title</t></is></c>`)
+	require.Contains(t, sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">Status</t></is></c>`)
+	require.Contains(t, sheet, `<t xml:space="preserve">Fix &lt;bug&gt; &amp; ship</t>`)
+	require.NotContains(t, sheet, `r="B2"`)
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", ColumnName(0))
+	require.Equal(t, "Z", ColumnName(25))
+	require.Equal(t, "AA", ColumnName(26))
+	require.Equal(t, "AZ", ColumnName(51))
+	require.Equal(t, "BA", ColumnName(52))
+}
+
+func TestSheetName(t *testing.T) {
+	require.Equal(t, "Sheet1", SheetName("  "))
+	require.Equal(t, "a_b_c", SheetName("a/b\\c"))
+	require.Len(t, SheetName("a very long board title that does not fit"), maxSheetNameLength)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package featureflags
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// teamOverridesKeyPrefix prefixes the system setting holding the feature
+// flag overrides of a team, as a JSON object.
+const teamOverridesKeyPrefix = "FeatureFlags_"
+
+type Store interface {
+	GetSystemSetting(key string) (string, error)
+	SetSystemSetting(key, value string) error
+}
+
+type Params struct {
+	Store  Store
+	Logger *mlog.Logger
+	// Flags are the feature flags of the server configuration.
+	Flags map[string]string
+}
+
+// Service resolves the feature flags of the teams. Each team gets the
+// flags of the server configuration, overridden by the ones stored for
+// the team, so features can be rolled out a team at a time.
+type Service struct {
+	store  Store
+	logger *mlog.Logger
+
+	mu    sync.RWMutex
+	flags map[string]string
+}
+
+func New(params Params) *Service {
+	s := &Service{
+		store:  params.Store,
+		logger: params.Logger,
+	}
+	s.SetConfigFlags(params.Flags)
+	return s
+}
+
+// SetConfigFlags replaces the feature flags of the server configuration,
+// when it changes.
+func (s *Service) SetConfigFlags(flags map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flags = model.MergeFeatureFlags(flags, nil)
+}
+
+// GetConfigFlags returns the feature flags of the server configuration.
+func (s *Service) GetConfigFlags() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return model.MergeFeatureFlags(s.flags, nil)
+}
+
+// GetFlags returns the feature flags of a team.
+func (s *Service) GetFlags(teamID string) (map[string]string, error) {
+	overrides, err := s.GetTeamOverrides(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return model.MergeFeatureFlags(s.flags, overrides), nil
+}
+
+// IsEnabled returns true if the flag is enabled for the team. The flags
+// of the configuration are used if the ones of the team can't be read.
+func (s *Service) IsEnabled(teamID, flag string) bool {
+	flags, err := s.GetFlags(teamID)
+	if err != nil {
+		s.logger.Error("cannot get the feature flags of the team",
+			mlog.String("teamID", teamID),
+			mlog.Err(err),
+		)
+		flags = s.GetConfigFlags()
+	}
+	return flags[flag] == model.FeatureFlagEnabled
+}
+
+// GetTeamOverrides returns the feature flags stored for a team.
+func (s *Service) GetTeamOverrides(teamID string) (map[string]string, error) {
+	value, err := s.store.GetSystemSetting(teamOverridesKeyPrefix + teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := map[string]string{}
+	if value == "" {
+		return overrides, nil
+	}
+	if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// SetTeamOverrides replaces the feature flags stored for a team. Flags
+// missing from the overrides get their value from the configuration.
+func (s *Service) SetTeamOverrides(teamID string, overrides map[string]string) error {
+	if overrides == nil {
+		overrides = map[string]string{}
+	}
+	data, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+	return s.store.SetSystemSetting(teamOverridesKeyPrefix+teamID, string(data))
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package featureflags
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type fakeStore struct {
+	settings map[string]string
+	err      error
+}
+
+func (fs *fakeStore) GetSystemSetting(key string) (string, error) {
+	if fs.err != nil {
+		return "", fs.err
+	}
+	return fs.settings[key], nil
+}
+
+func (fs *fakeStore) SetSystemSetting(key, value string) error {
+	fs.settings[key] = value
+	return nil
+}
+
+func TestFeatureFlags(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	store := &fakeStore{settings: map[string]string{}}
+	s := New(Params{
+		Store:  store,
+		Logger: logger,
+		Flags:  map[string]string{"automation": "false", "search": "true"},
+	})
+
+	t.Run("teams without overrides get the configured flags", func(t *testing.T) {
+		flags, err := s.GetFlags("team-1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"automation": "false", "search": "true"}, flags)
+		assert.False(t, s.IsEnabled("team-1", "automation"))
+		assert.True(t, s.IsEnabled("team-1", "search"))
+	})
+
+	t.Run("overrides apply to their team only", func(t *testing.T) {
+		require.NoError(t, s.SetTeamOverrides("team-1", map[string]string{"automation": "true", "beta": "true"}))
+
+		flags, err := s.GetFlags("team-1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"automation": "true", "search": "true", "beta": "true"}, flags)
+		assert.True(t, s.IsEnabled("team-1", "automation"))
+		assert.False(t, s.IsEnabled("team-2", "automation"))
+	})
+
+	t.Run("config changes apply to the flags that are not overridden", func(t *testing.T) {
+		s.SetConfigFlags(map[string]string{"automation": "false", "search": "false"})
+		assert.True(t, s.IsEnabled("team-1", "automation"))
+		assert.False(t, s.IsEnabled("team-1", "search"))
+	})
+
+	t.Run("removed overrides fall back to the config", func(t *testing.T) {
+		require.NoError(t, s.SetTeamOverrides("team-1", nil))
+
+		overrides, err := s.GetTeamOverrides("team-1")
+		require.NoError(t, err)
+		assert.Empty(t, overrides)
+		assert.False(t, s.IsEnabled("team-1", "automation"))
+	})
+
+	t.Run("the config flags are used when the store fails", func(t *testing.T) {
+		s.SetConfigFlags(map[string]string{"automation": "true"})
+		store.err = errors.New("store error")
+		defer func() { store.err = nil }()
+
+		_, err := s.GetFlags("team-1")
+		require.Error(t, err)
+		assert.True(t, s.IsEnabled("team-1", "automation"))
+	})
+}
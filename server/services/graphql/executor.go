@@ -0,0 +1,200 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// executor resolves the fields of an operation, collecting the errors of
+// the resolvers.
+type executor struct {
+	variables map[string]interface{}
+	errors    []*Error
+}
+
+// resultMap is an object of the response, which keeps its fields in the
+// order they were selected.
+type resultMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func (m *resultMap) set(key string, value interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+func (m *resultMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// executeSelection resolves the selected fields of the source object. The
+// fields selected several times under the same key are resolved once,
+// with all their selections.
+func (e *executor) executeSelection(ctx context.Context, object *Object, source interface{}, selection []*selectedField, path []interface{}) *resultMap {
+	keys := []string{}
+	byKey := map[string][]*selectedField{}
+	for _, selected := range selection {
+		key := selected.responseKey()
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], selected)
+	}
+
+	result := &resultMap{values: map[string]interface{}{}}
+	for _, key := range keys {
+		fields := byKey[key]
+		selected := fields[0]
+		if selected.name == "__typename" {
+			result.set(key, object.Name)
+			continue
+		}
+
+		var subSelection []*selectedField
+		for _, f := range fields {
+			subSelection = append(subSelection, f.selection...)
+		}
+
+		fieldPath := appendPath(path, key)
+		field := object.Fields[selected.name]
+		value, err := field.Resolve(ctx, source, e.argumentValues(selected.arguments))
+		if err != nil {
+			e.addError(err, fieldPath)
+			result.set(key, nil)
+			continue
+		}
+		result.set(key, e.completeValue(ctx, field.Type, value, subSelection, fieldPath))
+	}
+	return result
+}
+
+// completeValue resolves the selected fields of the object, or objects,
+// returned by a resolver.
+func (e *executor) completeValue(ctx context.Context, object *Object, value interface{}, selection []*selectedField, path []interface{}) interface{} {
+	if isNil(value) {
+		return nil
+	}
+	if object == nil {
+		return value
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Slice {
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = e.completeValue(ctx, object, v.Index(i).Interface(), selection, appendPath(path, i))
+		}
+		return items
+	}
+	return e.executeSelection(ctx, object, value, selection, path)
+}
+
+func (e *executor) addError(err error, path []interface{}) {
+	e.errors = append(e.errors, &Error{Message: err.Error(), Path: path})
+}
+
+// argumentValues returns the arguments of a field, with the variables
+// replaced by their values.
+func (e *executor) argumentValues(arguments []*argument) map[string]interface{} {
+	values := make(map[string]interface{}, len(arguments))
+	for _, arg := range arguments {
+		values[arg.name] = e.value(arg.value)
+	}
+	return values
+}
+
+func (e *executor) value(v interface{}) interface{} {
+	switch v := v.(type) {
+	case variable:
+		return e.variables[v.name]
+	case enumValue:
+		return v.name
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = e.value(v[i])
+		}
+		return list
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for name, value := range v {
+			object[name] = e.value(value)
+		}
+		return object
+	}
+	return v
+}
+
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	p := make([]interface{}, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, key)
+}
+
+// isNil returns true for the nil values, including the typed nil pointers,
+// maps and slices returned by the resolvers.
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// StringArg returns the string argument, or "" if it is not given.
+func StringArg(args map[string]interface{}, name string) (string, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("argument %q must be a string", name)
+	}
+}
+
+// IntArg returns the integer argument, or the default value if it is not
+// given. Numbers from the JSON variables are accepted when they are whole.
+func IntArg(args map[string]interface{}, name string, defaultValue int) (int, error) {
+	switch v := args[name].(type) {
+	case nil:
+		return defaultValue, nil
+	case int:
+		return v, nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+			return int(v), nil
+		}
+	}
+	return 0, fmt.Errorf("argument %q must be an integer", name)
+}
@@ -0,0 +1,200 @@
+// Package graphql runs GraphQL queries against a schema of objects whose
+// fields are resolved by functions. It supports the queries with their
+// variables and aliases, which lets the integrations select the fields
+// they need, but not the mutations, the fragments, the directives or the
+// introspection of the schema.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ResolveFunc returns the value of a field of the source object, with the
+// arguments given to the field.
+type ResolveFunc func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)
+
+// Object is a type of the schema with fields.
+type Object struct {
+	Name   string
+	Fields map[string]*Field
+}
+
+// Field is a field of an object.
+type Field struct {
+	// Type is the object type of the value of the field, or of the items
+	// of the value when it is a slice. It is nil for the scalar values,
+	// which are returned as they are.
+	Type *Object
+
+	// Args are the names of the arguments accepted by the field, with
+	// whether they are required.
+	Args map[string]bool
+
+	Resolve ResolveFunc
+}
+
+// Schema is the graph of objects that can be queried, starting at the
+// fields of its query object.
+type Schema struct {
+	Query *Object
+
+	// MaxDepth is the maximum nesting of the selected fields, 0 for no
+	// limit.
+	MaxDepth int
+}
+
+// Request is a query with its variables, as posted by the clients.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the result of a query. The fields that failed to resolve
+// are null in the data and have an error.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error is an error of a query, with the path of the field it happened on
+// if any.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// ErrInvalidQuery is returned when a query can't be parsed or doesn't
+// match the schema.
+type ErrInvalidQuery struct {
+	msg string
+}
+
+func newErrInvalidQuery(msg string) *ErrInvalidQuery {
+	return &ErrInvalidQuery{msg: msg}
+}
+
+func (e *ErrInvalidQuery) Error() string {
+	return e.msg
+}
+
+// IsErrInvalidQuery returns true if the error is an ErrInvalidQuery.
+func IsErrInvalidQuery(err error) bool {
+	var e *ErrInvalidQuery
+	return errors.As(err, &e)
+}
+
+// Execute runs the query of the request. An ErrInvalidQuery is returned
+// if the query is invalid, in which case nothing is resolved, while the
+// errors of the resolvers are reported in the response.
+func (s *Schema) Execute(ctx context.Context, req Request) (*Response, error) {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.validate(s.Query, op.selection, 1); err != nil {
+		return nil, err
+	}
+
+	variables, err := op.variableValues(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &executor{variables: variables}
+	data := e.executeSelection(ctx, s.Query, nil, op.selection, nil)
+	return &Response{Data: data, Errors: e.errors}, nil
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, newErrInvalidQuery("the operationName is required for a query with several operations")
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, newErrInvalidQuery(fmt.Sprintf("unknown operation %q", name))
+}
+
+// variableValues returns the values of the variables of the operation,
+// with their defaults.
+func (op *operation) variableValues(given map[string]interface{}) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for _, definition := range op.variables {
+		value, ok := given[definition.name]
+		if !ok {
+			value = definition.defaultValue
+		}
+		if value == nil && definition.required {
+			return nil, newErrInvalidQuery(fmt.Sprintf("variable $%s is required", definition.name))
+		}
+		values[definition.name] = value
+	}
+	return values, nil
+}
+
+// validate checks that the selected fields exist, have valid arguments
+// and select the fields of the objects.
+func (s *Schema) validate(object *Object, selection []*selectedField, depth int) error {
+	if s.MaxDepth > 0 && depth > s.MaxDepth {
+		return newErrInvalidQuery(fmt.Sprintf("the query is nested deeper than %d levels", s.MaxDepth))
+	}
+
+	keys := map[string]*selectedField{}
+	for _, selected := range selection {
+		key := selected.responseKey()
+		if other, ok := keys[key]; ok && other.name != selected.name {
+			return newErrInvalidQuery(fmt.Sprintf("%q at %d:%d selects both %s and %s, use aliases", key, selected.line, selected.column, other.name, selected.name))
+		}
+		keys[key] = selected
+
+		if selected.name == "__typename" {
+			if len(selected.arguments) > 0 || selected.selection != nil {
+				return newErrInvalidQuery(fmt.Sprintf("__typename at %d:%d has no arguments or fields", selected.line, selected.column))
+			}
+			continue
+		}
+
+		field, ok := object.Fields[selected.name]
+		if !ok {
+			return newErrInvalidQuery(fmt.Sprintf("%s has no field %q at %d:%d", object.Name, selected.name, selected.line, selected.column))
+		}
+
+		given := map[string]bool{}
+		for _, arg := range selected.arguments {
+			if _, ok := field.Args[arg.name]; !ok {
+				return newErrInvalidQuery(fmt.Sprintf("field %q at %d:%d has no argument %q", selected.name, selected.line, selected.column, arg.name))
+			}
+			given[arg.name] = true
+		}
+		for name, required := range field.Args {
+			if required && !given[name] {
+				return newErrInvalidQuery(fmt.Sprintf("field %q at %d:%d needs the argument %q", selected.name, selected.line, selected.column, name))
+			}
+		}
+
+		switch {
+		case field.Type == nil && selected.selection != nil:
+			return newErrInvalidQuery(fmt.Sprintf("field %q at %d:%d is a scalar and has no fields", selected.name, selected.line, selected.column))
+		case field.Type != nil && selected.selection == nil:
+			return newErrInvalidQuery(fmt.Sprintf("field %q at %d:%d needs a selection of the fields of %s", selected.name, selected.line, selected.column, field.Type.Name))
+		case field.Type != nil:
+			if err := s.validate(field.Type, selected.selection, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCard struct {
+	ID    string
+	Title string
+}
+
+type testBoard struct {
+	ID    string
+	Title string
+	Cards []*testCard
+}
+
+func testSchema() *Schema {
+	card := &Object{
+		Name: "Card",
+		Fields: map[string]*Field{
+			"id": {Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+				return source.(*testCard).ID, nil
+			}},
+			"title": {Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+				return source.(*testCard).Title, nil
+			}},
+			"secret": {Resolve: func(_ context.Context, _ interface{}, _ map[string]interface{}) (interface{}, error) {
+				return nil, errors.New("access denied")
+			}},
+		},
+	}
+	board := &Object{
+		Name: "Board",
+		Fields: map[string]*Field{
+			"id": {Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+				return source.(*testBoard).ID, nil
+			}},
+			"title": {Resolve: func(_ context.Context, source interface{}, _ map[string]interface{}) (interface{}, error) {
+				return source.(*testBoard).Title, nil
+			}},
+			"cards": {
+				Type: card,
+				Args: map[string]bool{"first": false},
+				Resolve: func(_ context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+					cards := source.(*testBoard).Cards
+					first, err := IntArg(args, "first", len(cards))
+					if err != nil {
+						return nil, err
+					}
+					if first < len(cards) {
+						cards = cards[:first]
+					}
+					return cards, nil
+				},
+			},
+		},
+	}
+
+	boards := map[string]*testBoard{
+		"board-1": {ID: "board-1", Title: "Roadmap", Cards: []*testCard{{ID: "card-1", Title: "Plan"}, {ID: "card-2", Title: "Ship"}}},
+	}
+	return &Schema{
+		MaxDepth: 3,
+		Query: &Object{
+			Name: "Query",
+			Fields: map[string]*Field{
+				"board": {
+					Type: board,
+					Args: map[string]bool{"id": true},
+					Resolve: func(_ context.Context, _ interface{}, args map[string]interface{}) (interface{}, error) {
+						id, err := StringArg(args, "id")
+						if err != nil {
+							return nil, err
+						}
+						return boards[id], nil
+					},
+				},
+			},
+		},
+	}
+}
+
+func executeJSON(t *testing.T, req Request) string {
+	resp, err := testSchema().Execute(context.Background(), req)
+	require.NoError(t, err)
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	t.Run("selected fields in order", func(t *testing.T) {
+		data := executeJSON(t, Request{Query: `{ board(id: "board-1") { title id cards { title } } }`})
+		require.Equal(t, `{"data":{"board":{"title":"Roadmap","id":"board-1","cards":[{"title":"Plan"},{"title":"Ship"}]}}}`, data)
+	})
+
+	t.Run("variables, aliases and typename", func(t *testing.T) {
+		data := executeJSON(t, Request{
+			Query: `query GetBoard($id: String!, $first: Int = 5) {
+				# the first card only
+				b: board(id: $id) { __typename first: cards(first: $first) { id } }
+				missing: board(id: "nope") { id }
+			}`,
+			Variables: map[string]interface{}{"id": "board-1", "first": float64(1)},
+		})
+		require.Equal(t, `{"data":{"b":{"__typename":"Board","first":[{"id":"card-1"}]},"missing":null}}`, data)
+	})
+
+	t.Run("resolver errors", func(t *testing.T) {
+		data := executeJSON(t, Request{Query: `{ board(id: "board-1") { cards(first: 1) { id secret } } }`})
+		require.Equal(t, `{"data":{"board":{"cards":[{"id":"card-1","secret":null}]}},"errors":[{"message":"access denied","path":["board","cards",0,"secret"]}]}`, data)
+
+		data = executeJSON(t, Request{Query: `{ board(id: "board-1") { cards(first: "one") { id } } }`})
+		require.Equal(t, `{"data":{"board":{"cards":null}},"errors":[{"message":"argument \"first\" must be an integer","path":["board","cards"]}]}`, data)
+	})
+
+	t.Run("operation name", func(t *testing.T) {
+		query := `query A { board(id: "board-1") { id } } query B { board(id: "board-1") { title } }`
+		data := executeJSON(t, Request{Query: query, OperationName: "B"})
+		require.Equal(t, `{"data":{"board":{"title":"Roadmap"}}}`, data)
+
+		_, err := testSchema().Execute(context.Background(), Request{Query: query})
+		require.True(t, IsErrInvalidQuery(err))
+	})
+}
+
+func TestExecuteInvalidQueries(t *testing.T) {
+	queries := map[string]string{
+		"syntax":               `{ board(id: "board-1") { id }`,
+		"unknown field":        `{ board(id: "board-1") { name } }`,
+		"unknown argument":     `{ board(id: "board-1", team: "x") { id } }`,
+		"missing argument":     `{ board { id } }`,
+		"scalar selection":     `{ board(id: "board-1") { id { x } } }`,
+		"object selection":     `{ board(id: "board-1") }`,
+		"conflicting alias":    `{ board(id: "board-1") { id: title id } }`,
+		"anonymous operations": `{ board(id: "board-1") { cards { id } } } { board(id: "board-1") { cards { id } } }`,
+		"mutation":             `mutation { board(id: "board-1") { id } }`,
+		"fragment":             `{ board(id: "board-1") { ...Fields } }`,
+		"directive":            `{ board(id: "board-1") @include(if: true) { id } }`,
+		"missing variable":     `query ($id: String!) { board(id: $id) { id } }`,
+		"unterminated":         `{ board(id: "board-1) { id } }`,
+		"unexpected content":   `{ board(id: "board-1") { id } } ?`,
+		"empty":                ` `,
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			_, err := testSchema().Execute(context.Background(), Request{Query: query})
+			require.Error(t, err)
+			require.True(t, IsErrInvalidQuery(err), err.Error())
+		})
+	}
+
+	t.Run("depth limit", func(t *testing.T) {
+		schema := testSchema()
+		schema.MaxDepth = 2
+		_, err := schema.Execute(context.Background(), Request{Query: `{ board(id: "board-1") { cards { id } } }`})
+		require.True(t, IsErrInvalidQuery(err))
+	})
+}
+
+func TestParseValues(t *testing.T) {
+	doc, err := parse(`{ f(s: "a\"é\n", i: -12, f: 1.5e2, b: true, n: null, e: OPEN, l: [1, $v], o: {k: "v"}) { x } }`)
+	require.NoError(t, err)
+
+	e := &executor{variables: map[string]interface{}{"v": "var"}}
+	args := e.argumentValues(doc.operations[0].selection[0].arguments)
+	require.Equal(t, map[string]interface{}{
+		"s": "a\"é\n",
+		"i": -12,
+		"f": 150.0,
+		"b": true,
+		"n": nil,
+		"e": "OPEN",
+		"l": []interface{}{1, "var"},
+		"o": map[string]interface{}{"k": "v"},
+	}, args)
+}
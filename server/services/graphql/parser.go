@@ -0,0 +1,551 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	line   int
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.value)
+	default:
+		return fmt.Sprintf("%q", t.value)
+	}
+}
+
+// document is a parsed query.
+type document struct {
+	operations []*operation
+}
+
+type operation struct {
+	name      string
+	variables []*variableDefinition
+	selection []*selectedField
+}
+
+type variableDefinition struct {
+	name         string
+	required     bool
+	defaultValue interface{}
+}
+
+// selectedField is a field of a selection set, with the fields selected
+// on its value.
+type selectedField struct {
+	alias     string
+	name      string
+	arguments []*argument
+	selection []*selectedField
+	line      int
+	column    int
+}
+
+func (f *selectedField) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type argument struct {
+	name  string
+	value interface{}
+}
+
+// variable is a reference to a variable of the operation in an argument
+// value.
+type variable struct {
+	name string
+}
+
+// enumValue is an unquoted name used as an argument value.
+type enumValue struct {
+	name string
+}
+
+type parser struct {
+	src    string
+	pos    int
+	line   int
+	column int
+	tok    token
+}
+
+// parse parses the query. Only the operations are supported, the
+// fragments and the directives are rejected.
+func parse(query string) (*document, error) {
+	p := &parser{src: query, line: 1, column: 1}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{}
+	for p.tok.kind != tokenEOF {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		doc.operations = append(doc.operations, op)
+	}
+	if len(doc.operations) == 0 {
+		return nil, newErrInvalidQuery("the query has no operation")
+	}
+	return doc, nil
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return newErrInvalidQuery(fmt.Sprintf("syntax error at %d:%d: %s", p.tok.line, p.tok.column, fmt.Sprintf(format, args...)))
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && p.tok.value == value
+}
+
+func (p *parser) expect(kind tokenKind, value string) error {
+	if !p.peek(kind, value) {
+		return p.errorf("expected %q, found %s", value, p.tok)
+	}
+	return p.next()
+}
+
+func (p *parser) expectName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.errorf("expected a name, found %s", p.tok)
+	}
+	name := p.tok.value
+	return name, p.next()
+}
+
+func (p *parser) parseOperation() (*operation, error) {
+	op := &operation{}
+	if p.tok.kind == tokenName {
+		switch p.tok.value {
+		case "query":
+		case "mutation", "subscription":
+			return nil, p.errorf("only queries are supported")
+		case "fragment":
+			return nil, p.errorf("fragments are not supported")
+		default:
+			return nil, p.errorf("unexpected %s", p.tok)
+		}
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind == tokenName {
+			op.name = p.tok.value
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		if p.peek(tokenPunctuator, "(") {
+			variables, err := p.parseVariableDefinitions()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = variables
+		}
+	}
+
+	selection, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selection = selection
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*variableDefinition, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	definitions := []*variableDefinition{}
+	for !p.peek(tokenPunctuator, ")") {
+		if err := p.expect(tokenPunctuator, "$"); err != nil {
+			return nil, err
+		}
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		required, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		definition := &variableDefinition{name: name, required: required}
+		if p.peek(tokenPunctuator, "=") {
+			if err = p.next(); err != nil {
+				return nil, err
+			}
+			if definition.defaultValue, err = p.parseValue(true); err != nil {
+				return nil, err
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	return definitions, p.next()
+}
+
+// parseType parses the type of a variable, and returns whether it is non
+// null. The types themselves are not checked, the resolvers validate the
+// values of their arguments.
+func (p *parser) parseType() (bool, error) {
+	if p.peek(tokenPunctuator, "[") {
+		if err := p.next(); err != nil {
+			return false, err
+		}
+		if _, err := p.parseType(); err != nil {
+			return false, err
+		}
+		if err := p.expect(tokenPunctuator, "]"); err != nil {
+			return false, err
+		}
+	} else if _, err := p.expectName(); err != nil {
+		return false, err
+	}
+
+	if p.peek(tokenPunctuator, "!") {
+		return true, p.next()
+	}
+	return false, nil
+}
+
+func (p *parser) parseSelectionSet() ([]*selectedField, error) {
+	if err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	fields := []*selectedField{}
+	for !p.peek(tokenPunctuator, "}") {
+		if p.peek(tokenPunctuator, "...") {
+			return nil, p.errorf("fragments are not supported")
+		}
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, p.errorf("a selection set needs at least one field")
+	}
+	return fields, p.next()
+}
+
+func (p *parser) parseField() (*selectedField, error) {
+	field := &selectedField{line: p.tok.line, column: p.tok.column}
+	name, err := p.expectName()
+	if err != nil {
+		return nil, err
+	}
+	field.name = name
+
+	if p.peek(tokenPunctuator, ":") {
+		if err = p.next(); err != nil {
+			return nil, err
+		}
+		field.alias = name
+		if field.name, err = p.expectName(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek(tokenPunctuator, "(") {
+		if field.arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek(tokenPunctuator, "@") {
+		return nil, p.errorf("directives are not supported")
+	}
+	if p.peek(tokenPunctuator, "{") {
+		if field.selection, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return field, nil
+}
+
+func (p *parser) parseArguments() ([]*argument, error) {
+	if err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	arguments := []*argument{}
+	for !p.peek(tokenPunctuator, ")") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, &argument{name: name, value: value})
+	}
+	return arguments, p.next()
+}
+
+// parseValue parses an argument value. Constant values, like the default
+// values of the variables, can't reference variables.
+func (p *parser) parseValue(constant bool) (interface{}, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenInt:
+		value, err := strconv.Atoi(tok.value)
+		if err != nil {
+			return nil, p.errorf("invalid integer %s", tok)
+		}
+		return value, p.next()
+	case tokenFloat:
+		value, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", tok)
+		}
+		return value, p.next()
+	case tokenString:
+		return tok.value, p.next()
+	case tokenName:
+		var value interface{}
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value = enumValue{name: tok.value}
+		}
+		return value, p.next()
+	case tokenPunctuator:
+		switch tok.value {
+		case "$":
+			if constant {
+				return nil, p.errorf("variables can't be used in constant values")
+			}
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+			name, err := p.expectName()
+			if err != nil {
+				return nil, err
+			}
+			return variable{name: name}, nil
+		case "[":
+			return p.parseList(constant)
+		case "{":
+			return p.parseObject(constant)
+		}
+	}
+	return nil, p.errorf("expected a value, found %s", tok)
+}
+
+func (p *parser) parseList(constant bool) (interface{}, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	list := []interface{}{}
+	for !p.peek(tokenPunctuator, "]") {
+		value, err := p.parseValue(constant)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+	return list, p.next()
+}
+
+func (p *parser) parseObject(constant bool) (interface{}, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	for !p.peek(tokenPunctuator, "}") {
+		name, err := p.expectName()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		if object[name], err = p.parseValue(constant); err != nil {
+			return nil, err
+		}
+	}
+	return object, p.next()
+}
+
+// next reads the next token, skipping the whitespace, the commas and the
+// comments.
+func (p *parser) next() error {
+skip:
+	for p.pos < len(p.src) {
+		switch c := p.src[p.pos]; {
+		case c == '\n':
+			p.pos++
+			p.line++
+			p.column = 1
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			p.advance(1)
+		case c == '#':
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.advance(1)
+			}
+		default:
+			break skip
+		}
+	}
+
+	p.tok = token{line: p.line, column: p.column}
+	if p.pos >= len(p.src) {
+		p.tok.kind = tokenEOF
+		return nil
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.tok.kind, p.tok.value = tokenPunctuator, "..."
+		p.advance(3)
+	case strings.IndexByte("!$():=@[]{}|", c) >= 0:
+		p.tok.kind, p.tok.value = tokenPunctuator, string(c)
+		p.advance(1)
+	case isNameStart(c):
+		start := p.pos
+		for p.pos < len(p.src) && (isNameStart(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.advance(1)
+		}
+		p.tok.kind, p.tok.value = tokenName, p.src[start:p.pos]
+	case c == '-' || isDigit(c):
+		return p.readNumber()
+	case c == '"':
+		return p.readString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		return p.errorf("unexpected character %q", r)
+	}
+	return nil
+}
+
+func (p *parser) advance(n int) {
+	p.pos += n
+	p.column += n
+}
+
+func (p *parser) readNumber() error {
+	start := p.pos
+	kind := tokenInt
+	if p.src[p.pos] == '-' {
+		p.advance(1)
+	}
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch {
+		case isDigit(c):
+		case c == '.' || c == 'e' || c == 'E':
+			kind = tokenFloat
+		case (c == '+' || c == '-') && kind == tokenFloat:
+		default:
+			if isNameStart(c) {
+				return p.errorf("invalid number %q", p.src[start:p.pos+1])
+			}
+			p.tok.kind, p.tok.value = kind, p.src[start:p.pos]
+			return nil
+		}
+		p.advance(1)
+	}
+	p.tok.kind, p.tok.value = kind, p.src[start:p.pos]
+	return nil
+}
+
+func (p *parser) readString() error {
+	p.advance(1)
+	var s strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		switch c {
+		case '"':
+			p.advance(1)
+			p.tok.kind, p.tok.value = tokenString, s.String()
+			return nil
+		case '\n':
+			return p.errorf("unterminated string")
+		case '\\':
+			if p.pos+1 >= len(p.src) {
+				return p.errorf("unterminated string")
+			}
+			escape := p.src[p.pos+1]
+			switch escape {
+			case '"', '\\', '/':
+				s.WriteByte(escape)
+			case 'b':
+				s.WriteByte('\b')
+			case 'f':
+				s.WriteByte('\f')
+			case 'n':
+				s.WriteByte('\n')
+			case 'r':
+				s.WriteByte('\r')
+			case 't':
+				s.WriteByte('\t')
+			case 'u':
+				if p.pos+6 > len(p.src) {
+					return p.errorf("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(p.src[p.pos+2:p.pos+6], 16, 32)
+				if err != nil {
+					return p.errorf("invalid unicode escape")
+				}
+				s.WriteRune(rune(code))
+				p.advance(4)
+			default:
+				return p.errorf("invalid escape \\%c", escape)
+			}
+			p.advance(2)
+		default:
+			s.WriteByte(c)
+			p.advance(1)
+		}
+	}
+	return p.errorf("unterminated string")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
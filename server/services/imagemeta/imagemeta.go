@@ -0,0 +1,110 @@
+// Package imagemeta removes the metadata of the uploaded images, such as
+// the EXIF data with the GPS coordinates of the photos, and applies their
+// EXIF orientation to their pixels so that they show the same without it.
+package imagemeta
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+)
+
+const jpegQuality = 92
+
+var (
+	jpegSignature = []byte{0xFF, 0xD8}
+	pngSignature  = []byte("\x89PNG\r\n\x1a\n")
+)
+
+// ErrMalformedImage is returned when an image can't be parsed.
+var ErrMalformedImage = errors.New("malformed image")
+
+// IsSupported returns true if the metadata of the data can be stripped,
+// which are JPEG and PNG images.
+func IsSupported(data []byte) bool {
+	return bytes.HasPrefix(data, jpegSignature) || bytes.HasPrefix(data, pngSignature)
+}
+
+// Strip returns the image without its metadata. Images without an
+// orientation to apply are only stripped of their metadata and otherwise
+// kept as is; the others are decoded, rotated and encoded again. Data that
+// is not a supported image is returned unchanged.
+func Strip(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, jpegSignature):
+		stripped, orientation, err := stripJPEG(data)
+		if err != nil {
+			return nil, err
+		}
+		if orientation <= 1 || orientation > 8 {
+			return stripped, nil
+		}
+		img, err := jpeg.Decode(bytes.NewReader(stripped))
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, orient(img, orientation), &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	case bytes.HasPrefix(data, pngSignature):
+		stripped, orientation, err := stripPNG(data)
+		if err != nil {
+			return nil, err
+		}
+		if orientation <= 1 || orientation > 8 {
+			return stripped, nil
+		}
+		img, err := png.Decode(bytes.NewReader(stripped))
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, orient(img, orientation)); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return data, nil
+}
+
+// orient returns the image transformed by the EXIF orientation, from 2 to
+// 8, to show upright without it.
+func orient(img image.Image, orientation int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+	dst := image.NewRGBA64(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				sx, sy = w-1-x, y
+			case 3: // rotated 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90° clockwise to show
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotated 90° counterclockwise to show
+				sx, sy = w-1-y, x
+			}
+			dst.Set(x, y, img.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,131 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testTIFF returns EXIF data with the orientation.
+func testTIFF(orientation uint16) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("II*\x00")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(8))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1))
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{exifOrientationTag, 3})
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(1))
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{orientation, 0})
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(0))
+	return buf.Bytes()
+}
+
+func testImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 16), G: uint8(y * 32), A: 255})
+		}
+	}
+	return img
+}
+
+func testJPEG(t *testing.T, orientation uint16) ([]byte, []byte) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(), nil))
+	plain := buf.Bytes()
+
+	payload := append(append([]byte{}, exifHeader...), testTIFF(orientation)...)
+	segment := []byte{0xFF, markerAPP1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+	comment := []byte{0xFF, markerCOM, 0, 5, 'G', 'P', 'S'}
+
+	withExif := append(append(append(append([]byte{}, plain[:2]...), segment...), comment...), plain[2:]...)
+	return plain, withExif
+}
+
+func testPNG(t *testing.T, orientation uint16) ([]byte, []byte) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage()))
+	plain := buf.Bytes()
+
+	tiff := testTIFF(orientation)
+	chunk := make([]byte, 8, 12+len(tiff))
+	binary.BigEndian.PutUint32(chunk, uint32(len(tiff)))
+	copy(chunk[4:], "eXIf")
+	chunk = append(chunk, tiff...)
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(chunk[4:]))
+	chunk = append(chunk, crc...)
+
+	// the chunk goes after the IHDR chunk, of 25 bytes
+	headerEnd := len(pngSignature) + 25
+	withExif := append(append(append([]byte{}, plain[:headerEnd]...), chunk...), plain[headerEnd:]...)
+	return plain, withExif
+}
+
+func TestStripJPEG(t *testing.T) {
+	t.Run("without orientation the image is kept", func(t *testing.T) {
+		plain, withExif := testJPEG(t, 1)
+		stripped, err := Strip(withExif)
+		require.NoError(t, err)
+		require.Equal(t, plain, stripped)
+	})
+
+	t.Run("the orientation is applied", func(t *testing.T) {
+		_, withExif := testJPEG(t, 6)
+		stripped, err := Strip(withExif)
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(stripped, exifHeader))
+
+		config, err := jpeg.DecodeConfig(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		require.Equal(t, 8, config.Width)
+		require.Equal(t, 16, config.Height)
+	})
+
+	t.Run("malformed images", func(t *testing.T) {
+		_, withExif := testJPEG(t, 1)
+		_, err := Strip(withExif[:10])
+		require.ErrorIs(t, err, ErrMalformedImage)
+	})
+}
+
+func TestStripPNG(t *testing.T) {
+	t.Run("without orientation the image is kept", func(t *testing.T) {
+		plain, withExif := testPNG(t, 1)
+		stripped, err := Strip(withExif)
+		require.NoError(t, err)
+		require.Equal(t, plain, stripped)
+	})
+
+	t.Run("the orientation is applied", func(t *testing.T) {
+		_, withExif := testPNG(t, 8)
+		stripped, err := Strip(withExif)
+		require.NoError(t, err)
+
+		img, err := png.Decode(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, 8, 16), img.Bounds())
+
+		// rotated counterclockwise, the top right corner is now the top left one
+		r, g, _, _ := img.At(0, 0).RGBA()
+		require.Equal(t, uint32(15*16), r>>8)
+		require.Equal(t, uint32(0), g>>8)
+	})
+}
+
+func TestStripOtherData(t *testing.T) {
+	data := []byte("GIF89a")
+	require.False(t, IsSupported(data))
+	stripped, err := Strip(data)
+	require.NoError(t, err)
+	require.Equal(t, data, stripped)
+}
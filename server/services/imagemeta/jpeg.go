@@ -0,0 +1,114 @@
+package imagemeta
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	markerSOS  = 0xDA
+	markerAPP1 = 0xE1
+	// markerAPP13 holds the Photoshop resources, with the IPTC data.
+	markerAPP13 = 0xED
+	markerCOM   = 0xFE
+
+	exifOrientationTag = 0x0112
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// stripJPEG removes the EXIF, XMP, IPTC and comment segments of the JPEG
+// image, keeping its other segments and its compressed data unchanged, and
+// returns the orientation of its EXIF data, 0 if none.
+func stripJPEG(data []byte) ([]byte, int, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, data[:2]...)
+	orientation := 0
+
+	pos := 2
+	for {
+		// markers may be preceded by fill bytes
+		for pos < len(data) && data[pos] == 0xFF && pos+1 < len(data) && data[pos+1] == 0xFF {
+			pos++
+		}
+		if pos+1 >= len(data) || data[pos] != 0xFF {
+			return nil, 0, ErrMalformedImage
+		}
+		marker := data[pos+1]
+
+		// standalone markers have no length
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD9) {
+			out = append(out, data[pos:pos+2]...)
+			pos += 2
+			if marker == 0xD9 {
+				return out, orientation, nil
+			}
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return nil, 0, ErrMalformedImage
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, 0, ErrMalformedImage
+		}
+
+		if marker == markerSOS {
+			// the compressed data follows until the end of the image
+			out = append(out, data[pos:]...)
+			return out, orientation, nil
+		}
+
+		switch marker {
+		case markerAPP1:
+			payload := data[pos+4 : end]
+			if bytes.HasPrefix(payload, exifHeader) && orientation == 0 {
+				orientation = exifOrientation(payload[len(exifHeader):])
+			}
+		case markerAPP13, markerCOM:
+		default:
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+	}
+}
+
+// exifOrientation returns the orientation in the first IFD of the TIFF
+// structure of EXIF data, 0 if there is none.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) || offset < 8 {
+		return 0
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:entry+2]) != exifOrientationTag {
+			continue
+		}
+		// the orientation is a SHORT stored in the value field
+		if order.Uint16(tiff[entry+2:entry+4]) != 3 {
+			return 0
+		}
+		return int(order.Uint16(tiff[entry+8 : entry+10]))
+	}
+	return 0
+}
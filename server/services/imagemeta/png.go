@@ -0,0 +1,49 @@
+package imagemeta
+
+import (
+	"encoding/binary"
+)
+
+// strippedPNGChunks are the chunks holding metadata: the EXIF data, the
+// texts, with the XMP data, and the last modification time.
+var strippedPNGChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNG removes the metadata chunks of the PNG image, keeping the others
+// unchanged, and returns the orientation of its EXIF data, 0 if none.
+func stripPNG(data []byte) ([]byte, int, error) {
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	orientation := 0
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, 0, ErrMalformedImage
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if length < 0 || end > len(data) || end < pos {
+			return nil, 0, ErrMalformedImage
+		}
+
+		if chunkType == "eXIf" && orientation == 0 {
+			orientation = exifOrientation(data[pos+8 : pos+8+length])
+		}
+		if !strippedPNGChunks[chunkType] {
+			out = append(out, data[pos:end]...)
+		}
+		pos = end
+
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return out, orientation, nil
+}
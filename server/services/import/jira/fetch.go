@@ -0,0 +1,112 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	fetchPageSize    = 100
+	maxFetchedIssues = 5000
+	fetchTimeout     = 30 * time.Second
+
+	searchPath   = "/rest/api/2/search"
+	searchFields = "summary,description,issuetype,status,priority,assignee,labels,project"
+)
+
+var (
+	ErrInvalidSource = errors.New("invalid jira site")
+	ErrFetchFailed   = errors.New("cannot fetch the jira issues")
+)
+
+// Source is the Jira site to fetch the issues from. The token is sent with
+// basic authentication along with the email for Jira Cloud, and as a
+// personal access token otherwise.
+type Source struct {
+	URL   string
+	Email string
+	Token string
+	JQL   string
+}
+
+// Fetch reads the issues selected by the JQL query from the search API of
+// the site, up to maxFetchedIssues.
+func Fetch(ctx context.Context, client *http.Client, src Source) (*Export, error) {
+	siteURL, err := url.Parse(strings.TrimRight(src.URL, "/"))
+	if err != nil || (siteURL.Scheme != "http" && siteURL.Scheme != "https") || siteURL.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSource, src.URL)
+	}
+	if src.Token == "" {
+		return nil, fmt.Errorf("%w: a token is required", ErrInvalidSource)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: fetchTimeout}
+	}
+
+	export := &Export{}
+	for startAt := 0; startAt < maxFetchedIssues; startAt += fetchPageSize {
+		page, err := fetchPage(ctx, client, siteURL, src, startAt)
+		if err != nil {
+			return nil, err
+		}
+		appendJSONIssues(export, page.Issues, siteURL.String())
+		if len(page.Issues) == 0 || startAt+len(page.Issues) >= page.Total {
+			break
+		}
+	}
+
+	if len(export.Issues) == 0 {
+		return nil, fmt.Errorf("%w: no issues", ErrFetchFailed)
+	}
+	return export, nil
+}
+
+func fetchPage(ctx context.Context, client *http.Client, siteURL *url.URL, src Source, startAt int) (*jsonSearchResult, error) {
+	query := url.Values{}
+	query.Set("jql", src.JQL)
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(fetchPageSize))
+	query.Set("fields", searchFields)
+
+	pageURL := *siteURL
+	pageURL.Path += searchPath
+	pageURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if src.Email != "" {
+		req.SetBasicAuth(src.Email, src.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+src.Token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFetchFailed, err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", ErrFetchFailed, resp.Status)
+	}
+
+	var page jsonSearchResult
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrFetchFailed, err)
+	}
+	return &page, nil
+}
@@ -0,0 +1,83 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetch(t *testing.T) {
+	const total = 150
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != searchPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		email, token, ok := r.BasicAuth()
+		if !ok || email != "user@example.com" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "project = PROJ", r.URL.Query().Get("jql"))
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		issues := []map[string]interface{}{}
+		for i := startAt; i < total && i < startAt+fetchPageSize; i++ {
+			issues = append(issues, map[string]interface{}{
+				"key": fmt.Sprintf("PROJ-%d", i+1),
+				"fields": map[string]interface{}{
+					"summary": fmt.Sprintf("Issue %d", i+1),
+					"status":  map[string]interface{}{"name": "To Do"},
+				},
+			})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": startAt,
+			"total":   total,
+			"issues":  issues,
+		})
+	}))
+	defer server.Close()
+
+	t.Run("fetches all the pages", func(t *testing.T) {
+		export, err := Fetch(context.Background(), server.Client(), Source{
+			URL:   server.URL + "/",
+			Email: "user@example.com",
+			Token: "token",
+			JQL:   "project = PROJ",
+		})
+		require.NoError(t, err)
+		require.Len(t, export.Issues, total)
+		assert.Equal(t, "PROJ-150", export.Issues[total-1].Key)
+		assert.Equal(t, server.URL+"/browse/PROJ-1", export.Issues[0].Link)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		_, err := Fetch(context.Background(), server.Client(), Source{
+			URL:   server.URL,
+			Email: "user@example.com",
+			Token: "wrong",
+			JQL:   "project = PROJ",
+		})
+		require.ErrorIs(t, err, ErrFetchFailed)
+	})
+
+	t.Run("invalid sources", func(t *testing.T) {
+		for _, src := range []Source{
+			{URL: "ftp://example.com", Token: "token"},
+			{URL: "example.com", Token: "token"},
+			{URL: server.URL},
+		} {
+			_, err := Fetch(context.Background(), server.Client(), src)
+			require.ErrorIs(t, err, ErrInvalidSource, src.URL)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package jira
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// htmlToText extracts the text of the HTML description of an XML export,
+// ending the paragraphs and list items with line breaks.
+func htmlToText(description string) string {
+	var sb strings.Builder
+	z := html.NewTokenizer(strings.NewReader(description))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(blankLines.ReplaceAllString(sb.String(), "\n\n"))
+		case html.TextToken:
+			sb.WriteString(strings.TrimLeft(string(z.Text()), "\n"))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.Br:
+				sb.WriteString("\n")
+			case atom.Li:
+				sb.WriteString("- ")
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch atom.Lookup(name) {
+			case atom.P, atom.Div, atom.Pre, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				sb.WriteString("\n\n")
+			case atom.Li:
+				sb.WriteString("\n")
+			}
+		}
+	}
+}
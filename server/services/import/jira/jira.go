@@ -0,0 +1,540 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	defaultBoardTitle   = "Jira import"
+	boardViewTitle      = "Board View"
+	tableViewTitle      = "Table View"
+	originalURLProperty = "Original URL"
+
+	propertyTypeSelect      = "select"
+	propertyTypeMultiSelect = "multiSelect"
+	propertyTypeText        = "text"
+
+	// unassignedUsername is the username of the XML exports for issues
+	// without assignee.
+	unassignedUsername = "-1"
+)
+
+var (
+	ErrInvalidExport  = errors.New("invalid jira export")
+	ErrInvalidMapping = errors.New("invalid jira field mapping")
+)
+
+// optionColors are assigned in turn to the options created for each property.
+var optionColors = []string{
+	"propColorGray",
+	"propColorBrown",
+	"propColorOrange",
+	"propColorYellow",
+	"propColorGreen",
+	"propColorBlue",
+	"propColorPurple",
+	"propColorPink",
+	"propColorRed",
+}
+
+// defaultMappings are the proposed properties of the fields, in the order
+// of the card properties.
+var defaultMappings = []model.JiraFieldMapping{
+	{Field: model.JiraFieldIssueType, Property: "Type", Type: propertyTypeSelect},
+	{Field: model.JiraFieldStatus, Property: "Status", Type: propertyTypeSelect},
+	{Field: model.JiraFieldPriority, Property: "Priority", Type: propertyTypeSelect},
+	{Field: model.JiraFieldAssignee, Property: "Assignee", Type: propertyTypeSelect},
+	{Field: model.JiraFieldLabels, Property: "Labels", Type: propertyTypeMultiSelect},
+}
+
+// Export holds the issues read from a Jira export or fetched from a site.
+type Export struct {
+	Title  string
+	Issues []Issue
+}
+
+// Issue holds the imported fields of a Jira issue. Fields maps the Jira
+// fields that can be mapped to card properties to their values.
+type Issue struct {
+	Key         string
+	Summary     string
+	Description string
+	Link        string
+	Fields      map[string][]string
+}
+
+// Options holds the team and the user the imported board is created for.
+type Options struct {
+	TeamID string
+	UserID string
+}
+
+type jsonNamed struct {
+	Name string `json:"name"`
+}
+
+type jsonUser struct {
+	DisplayName string `json:"displayName"`
+}
+
+type jsonIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		// a string for the v2 API, a document for the v3 API
+		Description interface{} `json:"description"`
+		IssueType   *jsonNamed  `json:"issuetype"`
+		Status      *jsonNamed  `json:"status"`
+		Priority    *jsonNamed  `json:"priority"`
+		Assignee    *jsonUser   `json:"assignee"`
+		Labels      []string    `json:"labels"`
+		Project     *jsonNamed  `json:"project"`
+	} `json:"fields"`
+}
+
+// jsonSearchResult is the response of the issue search API, which is also
+// accepted as a JSON export.
+type jsonSearchResult struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []jsonIssue `json:"issues"`
+}
+
+type xmlValue struct {
+	Value    string `xml:",chardata"`
+	Username string `xml:"username,attr"`
+}
+
+type xmlItem struct {
+	Link        string   `xml:"link"`
+	Key         string   `xml:"key"`
+	Summary     string   `xml:"summary"`
+	Description string   `xml:"description"`
+	Project     string   `xml:"project"`
+	Type        string   `xml:"type"`
+	Status      string   `xml:"status"`
+	Priority    string   `xml:"priority"`
+	Assignee    xmlValue `xml:"assignee"`
+	Labels      []string `xml:"labels>label"`
+}
+
+type xmlExport struct {
+	Channel struct {
+		Items []xmlItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+// Parse reads a Jira export, either the XML of an issue search or the JSON
+// of the issue search API.
+func Parse(r io.Reader) (*Export, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty export", ErrInvalidExport)
+	}
+
+	var export *Export
+	if data[0] == '<' {
+		export, err = parseXML(data)
+	} else {
+		export, err = parseJSON(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidExport, err)
+	}
+	if len(export.Issues) == 0 {
+		return nil, fmt.Errorf("%w: no issues", ErrInvalidExport)
+	}
+	return export, nil
+}
+
+func parseXML(data []byte) (*Export, error) {
+	var xe xmlExport
+	if err := xml.Unmarshal(data, &xe); err != nil {
+		return nil, err
+	}
+
+	export := &Export{}
+	for _, item := range xe.Channel.Items {
+		if export.Title == "" {
+			export.Title = item.Project
+		}
+
+		var assignee []string
+		if item.Assignee.Value != "" && item.Assignee.Username != unassignedUsername {
+			assignee = []string{item.Assignee.Value}
+		}
+		export.Issues = append(export.Issues, Issue{
+			Key:         item.Key,
+			Summary:     item.Summary,
+			Description: htmlToText(item.Description),
+			Link:        item.Link,
+			Fields: map[string][]string{
+				model.JiraFieldIssueType: values(item.Type),
+				model.JiraFieldStatus:    values(item.Status),
+				model.JiraFieldPriority:  values(item.Priority),
+				model.JiraFieldAssignee:  assignee,
+				model.JiraFieldLabels:    item.Labels,
+			},
+		})
+	}
+	return export, nil
+}
+
+func parseJSON(data []byte) (*Export, error) {
+	var issues []jsonIssue
+	if data[0] == '[' {
+		if err := json.Unmarshal(data, &issues); err != nil {
+			return nil, err
+		}
+	} else {
+		var result jsonSearchResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, err
+		}
+		issues = result.Issues
+	}
+
+	export := &Export{}
+	appendJSONIssues(export, issues, "")
+	return export, nil
+}
+
+// appendJSONIssues adds the issues of the search API to the export. The
+// issue links are built from siteURL if it is set.
+func appendJSONIssues(export *Export, issues []jsonIssue, siteURL string) {
+	for _, ji := range issues {
+		if export.Title == "" && ji.Fields.Project != nil {
+			export.Title = ji.Fields.Project.Name
+		}
+
+		issue := Issue{
+			Key:         ji.Key,
+			Summary:     ji.Fields.Summary,
+			Description: descriptionText(ji.Fields.Description),
+			Fields: map[string][]string{
+				model.JiraFieldIssueType: namedValues(ji.Fields.IssueType),
+				model.JiraFieldStatus:    namedValues(ji.Fields.Status),
+				model.JiraFieldPriority:  namedValues(ji.Fields.Priority),
+				model.JiraFieldLabels:    ji.Fields.Labels,
+			},
+		}
+		if ji.Fields.Assignee != nil {
+			issue.Fields[model.JiraFieldAssignee] = values(ji.Fields.Assignee.DisplayName)
+		}
+		if siteURL != "" && ji.Key != "" {
+			issue.Link = siteURL + "/browse/" + ji.Key
+		}
+		export.Issues = append(export.Issues, issue)
+	}
+}
+
+func values(value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+func namedValues(named *jsonNamed) []string {
+	if named == nil {
+		return nil
+	}
+	return values(named.Name)
+}
+
+// descriptionText returns the text of a description of the search API,
+// which is wiki markup for the v2 API and a document for the v3 API.
+func descriptionText(description interface{}) string {
+	switch d := description.(type) {
+	case string:
+		return strings.TrimSpace(d)
+	case map[string]interface{}:
+		var sb strings.Builder
+		writeDocumentText(&sb, d)
+		return strings.TrimSpace(sb.String())
+	}
+	return ""
+}
+
+// writeDocumentText writes the text nodes of a document, ending each block
+// node with a blank line.
+func writeDocumentText(sb *strings.Builder, node map[string]interface{}) {
+	if text, ok := node["text"].(string); ok {
+		sb.WriteString(text)
+	}
+	content, _ := node["content"].([]interface{})
+	for _, child := range content {
+		if childNode, ok := child.(map[string]interface{}); ok {
+			writeDocumentText(sb, childNode)
+		}
+	}
+	switch node["type"] {
+	case "paragraph", "heading", "codeBlock", "blockquote":
+		sb.WriteString("\n\n")
+	case "listItem", "hardBreak":
+		sb.WriteString("\n")
+	}
+}
+
+// ProposeMapping returns the default mapping of the export, listing the
+// values found for each field. Fields without values are not imported.
+func ProposeMapping(export *Export) model.JiraImportMapping {
+	mapping := model.JiraImportMapping{
+		Title:  export.Title,
+		Fields: make([]model.JiraFieldMapping, 0, len(defaultMappings)),
+	}
+	if mapping.Title == "" {
+		mapping.Title = defaultBoardTitle
+	}
+
+	for _, fm := range defaultMappings {
+		fm.Values = fieldValues(export, fm.Field)
+		if len(fm.Values) == 0 {
+			fm.Property = ""
+		}
+		mapping.Fields = append(mapping.Fields, fm)
+	}
+
+	for _, fm := range mapping.Fields {
+		if fm.Field == model.JiraFieldStatus && fm.Property != "" {
+			mapping.GroupBy = fm.Field
+		}
+	}
+	return mapping
+}
+
+// fieldValues returns the distinct values of the field in the order they
+// appear in the issues.
+func fieldValues(export *Export, field string) []string {
+	seen := map[string]bool{}
+	found := []string{}
+	for _, issue := range export.Issues {
+		for _, value := range issue.Fields[field] {
+			if !seen[value] {
+				seen[value] = true
+				found = append(found, value)
+			}
+		}
+	}
+	return found
+}
+
+func validateMapping(mapping model.JiraImportMapping) error {
+	mapped := map[string]string{}
+	for _, fm := range mapping.Fields {
+		known := false
+		for _, dm := range defaultMappings {
+			if dm.Field == fm.Field {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidMapping, fm.Field)
+		}
+		if _, ok := mapped[fm.Field]; ok {
+			return fmt.Errorf("%w: field %q is mapped twice", ErrInvalidMapping, fm.Field)
+		}
+		switch fm.Type {
+		case propertyTypeSelect, propertyTypeMultiSelect, propertyTypeText:
+		default:
+			return fmt.Errorf("%w: invalid type %q for field %q", ErrInvalidMapping, fm.Type, fm.Field)
+		}
+		if fm.Property != "" {
+			mapped[fm.Field] = fm.Type
+		}
+	}
+
+	if mapping.GroupBy != "" && mapped[mapping.GroupBy] != propertyTypeSelect {
+		return fmt.Errorf("%w: the cards can only be grouped by a field mapped to a select property", ErrInvalidMapping)
+	}
+	return nil
+}
+
+// Convert maps the issues of the export to the cards of a new board,
+// following the mapping. The mapped fields become card properties, and the
+// issue descriptions become content blocks.
+func Convert(export *Export, mapping model.JiraImportMapping, opt Options) (*model.BoardsAndBlocks, error) {
+	if err := validateMapping(mapping); err != nil {
+		return nil, err
+	}
+
+	title := mapping.Title
+	if title == "" {
+		title = defaultBoardTitle
+	}
+
+	now := utils.GetMillis()
+	board := &model.Board{
+		ID:             utils.NewID(utils.IDTypeBoard),
+		TeamID:         opt.TeamID,
+		CreatedBy:      opt.UserID,
+		ModifiedBy:     opt.UserID,
+		Type:           model.BoardTypePrivate,
+		MinimumRole:    model.BoardRoleNone,
+		Title:          title,
+		Properties:     map[string]interface{}{},
+		CardProperties: []map[string]interface{}{},
+		CreateAt:       now,
+		UpdateAt:       now,
+	}
+
+	newBlock := func(blockType model.BlockType, idType utils.IDType, parentID, title string) model.Block {
+		return model.Block{
+			ID:         utils.NewID(idType),
+			BoardID:    board.ID,
+			ParentID:   parentID,
+			CreatedBy:  opt.UserID,
+			ModifiedBy: opt.UserID,
+			Schema:     1,
+			Type:       blockType,
+			Title:      title,
+			Fields:     map[string]interface{}{},
+			CreateAt:   now,
+			UpdateAt:   now,
+		}
+	}
+
+	// the mapped fields become card properties, whose options are the
+	// values of the mapping followed by the ones it misses
+	propertyIDs := map[string]string{}
+	optionIDs := map[string]map[string]string{}
+	colorIndex := 0
+	for _, fm := range mapping.Fields {
+		if fm.Property == "" {
+			continue
+		}
+
+		propertyID := utils.NewID(utils.IDTypeBlock)
+		propertyIDs[fm.Field] = propertyID
+		property := map[string]interface{}{
+			"id":      propertyID,
+			"name":    fm.Property,
+			"type":    fm.Type,
+			"options": []interface{}{},
+		}
+
+		if fm.Type != propertyTypeText {
+			ids := map[string]string{}
+			options := []interface{}{}
+			for _, value := range append(append([]string(nil), fm.Values...), fieldValues(export, fm.Field)...) {
+				if _, ok := ids[value]; ok || value == "" {
+					continue
+				}
+				ids[value] = utils.NewID(utils.IDTypeBlock)
+				options = append(options, map[string]interface{}{
+					"id":    ids[value],
+					"value": value,
+					"color": optionColors[colorIndex%len(optionColors)],
+				})
+				colorIndex++
+			}
+			optionIDs[fm.Field] = ids
+			property["options"] = options
+		}
+		board.CardProperties = append(board.CardProperties, property)
+	}
+
+	urlPropertyID := utils.NewID(utils.IDTypeBlock)
+	board.CardProperties = append(board.CardProperties, map[string]interface{}{
+		"id":      urlPropertyID,
+		"name":    originalURLProperty,
+		"type":    "url",
+		"options": []interface{}{},
+	})
+
+	// the cards are shown in a table unless they are grouped
+	viewType, viewTitle := "table", tableViewTitle
+	if mapping.GroupBy != "" {
+		viewType, viewTitle = "board", boardViewTitle
+	}
+	view := newBlock(model.TypeView, utils.IDTypeView, board.ID, viewTitle)
+	blocks := []model.Block{view}
+	cardOrder := make([]interface{}, 0, len(export.Issues))
+
+	for _, issue := range export.Issues {
+		title := issue.Summary
+		if title == "" {
+			title = issue.Key
+		}
+		card := newBlock(model.TypeCard, utils.IDTypeCard, board.ID, title)
+
+		properties := map[string]interface{}{}
+		for _, fm := range mapping.Fields {
+			propertyID, ok := propertyIDs[fm.Field]
+			fieldValues := issue.Fields[fm.Field]
+			if !ok || len(fieldValues) == 0 {
+				continue
+			}
+
+			switch fm.Type {
+			case propertyTypeText:
+				properties[propertyID] = strings.Join(fieldValues, ", ")
+			case propertyTypeSelect:
+				properties[propertyID] = optionIDs[fm.Field][fieldValues[0]]
+			case propertyTypeMultiSelect:
+				ids := make([]interface{}, 0, len(fieldValues))
+				for _, value := range fieldValues {
+					ids = append(ids, optionIDs[fm.Field][value])
+				}
+				properties[propertyID] = ids
+			}
+		}
+		if issue.Link != "" {
+			properties[urlPropertyID] = issue.Link
+		}
+
+		contentOrder := []interface{}{}
+		var content []model.Block
+		if issue.Description != "" {
+			text := newBlock(model.TypeText, utils.IDTypeBlock, card.ID, issue.Description)
+			content = append(content, text)
+			contentOrder = append(contentOrder, text.ID)
+		}
+
+		card.Fields["properties"] = properties
+		card.Fields["contentOrder"] = contentOrder
+		cardOrder = append(cardOrder, card.ID)
+		blocks = append(blocks, card)
+		blocks = append(blocks, content...)
+	}
+
+	blocks[0].Fields = map[string]interface{}{
+		"viewType":           viewType,
+		"groupById":          propertyIDs[mapping.GroupBy],
+		"sortOptions":        []interface{}{},
+		"visiblePropertyIds": []interface{}{},
+		"visibleOptionIds":   []interface{}{},
+		"hiddenOptionIds":    []interface{}{},
+		"collapsedOptionIds": []interface{}{},
+		"filter": map[string]interface{}{
+			"operation": "and",
+			"filters":   []interface{}{},
+		},
+		"cardOrder":          cardOrder,
+		"columnWidths":       map[string]interface{}{},
+		"columnCalculations": map[string]interface{}{},
+		"kanbanCalculations": map[string]interface{}{},
+		"defaultTemplateId":  "",
+	}
+
+	return &model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: blocks,
+	}, nil
+}
@@ -0,0 +1,224 @@
+package jira
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const xmlExportData = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="0.92">
+<channel>
+	<title>Jira</title>
+	<item>
+		<title>[PROJ-1] Fix login</title>
+		<link>https://example.atlassian.net/browse/PROJ-1</link>
+		<project id="1" key="PROJ">Project</project>
+		<description>&lt;p&gt;Users cannot log in.&lt;/p&gt;&lt;ul&gt;&lt;li&gt;Chrome&lt;/li&gt;&lt;li&gt;Firefox&lt;/li&gt;&lt;/ul&gt;</description>
+		<key id="10001">PROJ-1</key>
+		<summary>Fix login</summary>
+		<type id="1">Bug</type>
+		<priority id="2">High</priority>
+		<status id="3">In Progress</status>
+		<assignee username="jdoe">John Doe</assignee>
+		<labels>
+			<label>backend</label>
+			<label>auth</label>
+		</labels>
+	</item>
+	<item>
+		<title>[PROJ-2] Write docs</title>
+		<link>https://example.atlassian.net/browse/PROJ-2</link>
+		<project id="1" key="PROJ">Project</project>
+		<key id="10002">PROJ-2</key>
+		<summary>Write docs</summary>
+		<type id="2">Task</type>
+		<priority id="3">Medium</priority>
+		<status id="1">To Do</status>
+		<assignee username="-1">Unassigned</assignee>
+		<labels></labels>
+	</item>
+</channel>
+</rss>`
+
+const jsonExportData = `{
+	"startAt": 0,
+	"maxResults": 50,
+	"total": 1,
+	"issues": [
+		{
+			"key": "PROJ-3",
+			"fields": {
+				"summary": "Ship it",
+				"description": {
+					"type": "doc",
+					"content": [
+						{"type": "paragraph", "content": [{"type": "text", "text": "Tag the release."}]},
+						{"type": "paragraph", "content": [{"type": "text", "text": "Publish it."}]}
+					]
+				},
+				"issuetype": {"name": "Story"},
+				"status": {"name": "Done"},
+				"priority": null,
+				"assignee": {"displayName": "Jane Roe"},
+				"labels": ["release"],
+				"project": {"name": "Project"}
+			}
+		}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("xml export", func(t *testing.T) {
+		export, err := Parse(strings.NewReader(xmlExportData))
+		require.NoError(t, err)
+		assert.Equal(t, "Project", export.Title)
+		require.Len(t, export.Issues, 2)
+
+		issue := export.Issues[0]
+		assert.Equal(t, "PROJ-1", issue.Key)
+		assert.Equal(t, "Fix login", issue.Summary)
+		assert.Equal(t, "Users cannot log in.\n\n- Chrome\n- Firefox", issue.Description)
+		assert.Equal(t, "https://example.atlassian.net/browse/PROJ-1", issue.Link)
+		assert.Equal(t, []string{"Bug"}, issue.Fields[model.JiraFieldIssueType])
+		assert.Equal(t, []string{"John Doe"}, issue.Fields[model.JiraFieldAssignee])
+		assert.Equal(t, []string{"backend", "auth"}, issue.Fields[model.JiraFieldLabels])
+
+		assert.Empty(t, export.Issues[1].Fields[model.JiraFieldAssignee])
+	})
+
+	t.Run("json export", func(t *testing.T) {
+		export, err := Parse(strings.NewReader(jsonExportData))
+		require.NoError(t, err)
+		require.Len(t, export.Issues, 1)
+
+		issue := export.Issues[0]
+		assert.Equal(t, "PROJ-3", issue.Key)
+		assert.Equal(t, "Tag the release.\n\nPublish it.", issue.Description)
+		assert.Equal(t, []string{"Story"}, issue.Fields[model.JiraFieldIssueType])
+		assert.Empty(t, issue.Fields[model.JiraFieldPriority])
+		assert.Equal(t, []string{"Jane Roe"}, issue.Fields[model.JiraFieldAssignee])
+	})
+
+	t.Run("invalid exports", func(t *testing.T) {
+		for _, data := range []string{"", "{", "<rss>", `{"issues": []}`} {
+			_, err := Parse(strings.NewReader(data))
+			require.ErrorIs(t, err, ErrInvalidExport, data)
+		}
+	})
+}
+
+func TestProposeMapping(t *testing.T) {
+	export, err := Parse(strings.NewReader(xmlExportData))
+	require.NoError(t, err)
+
+	mapping := ProposeMapping(export)
+	assert.Equal(t, "Project", mapping.Title)
+	assert.Equal(t, model.JiraFieldStatus, mapping.GroupBy)
+	require.Len(t, mapping.Fields, 5)
+
+	status := mapping.Fields[1]
+	assert.Equal(t, model.JiraFieldStatus, status.Field)
+	assert.Equal(t, "Status", status.Property)
+	assert.Equal(t, []string{"In Progress", "To Do"}, status.Values)
+
+	labels := mapping.Fields[4]
+	assert.Equal(t, propertyTypeMultiSelect, labels.Type)
+	assert.Equal(t, []string{"backend", "auth"}, labels.Values)
+
+	t.Run("fields without values are not mapped", func(t *testing.T) {
+		export := &Export{Issues: []Issue{{Summary: "Issue", Fields: map[string][]string{}}}}
+		mapping := ProposeMapping(export)
+		assert.Equal(t, defaultBoardTitle, mapping.Title)
+		assert.Empty(t, mapping.GroupBy)
+		for _, fm := range mapping.Fields {
+			assert.Empty(t, fm.Property)
+		}
+	})
+}
+
+func TestConvert(t *testing.T) {
+	export, err := Parse(strings.NewReader(xmlExportData))
+	require.NoError(t, err)
+
+	t.Run("proposed mapping", func(t *testing.T) {
+		bab, err := Convert(export, ProposeMapping(export), Options{TeamID: "team-id", UserID: "user-id"})
+		require.NoError(t, err)
+
+		require.Len(t, bab.Boards, 1)
+		board := bab.Boards[0]
+		assert.Equal(t, "Project", board.Title)
+		assert.Equal(t, "team-id", board.TeamID)
+		// the five fields and the original URL
+		require.Len(t, board.CardProperties, 6)
+
+		status := board.CardProperties[1]
+		assert.Equal(t, "Status", status["name"])
+		options := status["options"].([]interface{})
+		require.Len(t, options, 2)
+		inProgress := options[0].(map[string]interface{})
+		assert.Equal(t, "In Progress", inProgress["value"])
+
+		// view, card with description, card without
+		require.Len(t, bab.Blocks, 4)
+		view := bab.Blocks[0]
+		assert.Equal(t, "board", view.Fields["viewType"])
+		assert.Equal(t, status["id"], view.Fields["groupById"])
+
+		card, text := bab.Blocks[1], bab.Blocks[2]
+		assert.Equal(t, "Fix login", card.Title)
+		properties := card.Fields["properties"].(map[string]interface{})
+		assert.Equal(t, inProgress["id"], properties[status["id"].(string)])
+		assert.Len(t, properties[board.CardProperties[4]["id"].(string)], 2)
+		assert.Equal(t, "https://example.atlassian.net/browse/PROJ-1", properties[board.CardProperties[5]["id"].(string)])
+		assert.EqualValues(t, model.TypeText, text.Type)
+		assert.Equal(t, card.ID, text.ParentID)
+		assert.Equal(t, []interface{}{text.ID}, card.Fields["contentOrder"])
+
+		assert.Equal(t, []interface{}{card.ID, bab.Blocks[3].ID}, view.Fields["cardOrder"])
+	})
+
+	t.Run("adjusted mapping", func(t *testing.T) {
+		mapping := ProposeMapping(export)
+		mapping.Title = "Bugs"
+		mapping.GroupBy = ""
+		mapping.Fields = []model.JiraFieldMapping{
+			{Field: model.JiraFieldAssignee, Property: "Owner", Type: propertyTypeText},
+			{Field: model.JiraFieldLabels, Type: propertyTypeMultiSelect},
+		}
+
+		bab, err := Convert(export, mapping, Options{})
+		require.NoError(t, err)
+
+		board := bab.Boards[0]
+		assert.Equal(t, "Bugs", board.Title)
+		require.Len(t, board.CardProperties, 2)
+		owner := board.CardProperties[0]
+		assert.Equal(t, "Owner", owner["name"])
+		assert.Equal(t, "text", owner["type"])
+
+		assert.Equal(t, "table", bab.Blocks[0].Fields["viewType"])
+		properties := bab.Blocks[1].Fields["properties"].(map[string]interface{})
+		assert.Equal(t, "John Doe", properties[owner["id"].(string)])
+	})
+
+	t.Run("invalid mappings", func(t *testing.T) {
+		mappings := []model.JiraImportMapping{
+			{Fields: []model.JiraFieldMapping{{Field: "reporter", Property: "Reporter", Type: propertyTypeSelect}}},
+			{Fields: []model.JiraFieldMapping{{Field: model.JiraFieldStatus, Property: "Status", Type: "date"}}},
+			{Fields: []model.JiraFieldMapping{
+				{Field: model.JiraFieldStatus, Property: "Status", Type: propertyTypeSelect},
+				{Field: model.JiraFieldStatus, Property: "State", Type: propertyTypeSelect},
+			}},
+			{GroupBy: model.JiraFieldLabels, Fields: []model.JiraFieldMapping{{Field: model.JiraFieldLabels, Property: "Labels", Type: propertyTypeMultiSelect}}},
+			{GroupBy: model.JiraFieldStatus, Fields: []model.JiraFieldMapping{{Field: model.JiraFieldStatus, Type: propertyTypeSelect}}},
+		}
+		for _, mapping := range mappings {
+			_, err := Convert(export, mapping, Options{})
+			require.ErrorIs(t, err, ErrInvalidMapping)
+		}
+	})
+}
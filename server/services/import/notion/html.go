@@ -0,0 +1,74 @@
+package notion
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// htmlToText extracts the text of an exported HTML page as Markdown-like
+// plain text. The page header, which repeats the title and the property
+// values of the row, is skipped.
+func htmlToText(data []byte) (string, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			// skip the indentation between elements
+			if strings.TrimSpace(n.Data) != "" {
+				sb.WriteString(n.Data)
+			}
+			return
+		}
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Head, atom.Header, atom.Script, atom.Style:
+				return
+			case atom.Br:
+				sb.WriteString("\n")
+				return
+			case atom.H1:
+				sb.WriteString("\n\n# ")
+			case atom.H2:
+				sb.WriteString("\n\n## ")
+			case atom.H3:
+				sb.WriteString("\n\n### ")
+			case atom.Li:
+				sb.WriteString("\n- ")
+			case atom.P, atom.Div, atom.Pre, atom.Blockquote, atom.Ul, atom.Ol, atom.Table, atom.Tr, atom.Figure:
+				sb.WriteString("\n")
+			}
+		}
+
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.H1, atom.H2, atom.H3, atom.P, atom.Pre, atom.Blockquote, atom.Ul, atom.Ol, atom.Table:
+				sb.WriteString("\n\n")
+			case atom.Td, atom.Th:
+				sb.WriteString(" ")
+			}
+		}
+	}
+	walk(doc)
+
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	text := blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text), nil
+}
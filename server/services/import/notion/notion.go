@@ -0,0 +1,384 @@
+package notion
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	// maxEntrySize caps the uncompressed size of every file read from the export.
+	maxEntrySize   = 10 * 1024 * 1024
+	boardViewTitle = "Board View"
+	allRowsSuffix  = "_all.csv"
+)
+
+var utf8BOM = []byte("\ufeff")
+
+var ErrInvalidExport = errors.New("invalid notion export")
+
+// optionColors are assigned in turn to the options created for each property.
+var optionColors = []string{
+	"propColorGray",
+	"propColorBrown",
+	"propColorOrange",
+	"propColorYellow",
+	"propColorGreen",
+	"propColorBlue",
+	"propColorPurple",
+	"propColorPink",
+	"propColorRed",
+}
+
+// notionIDSuffix matches the id Notion appends to the names of exported files.
+var notionIDSuffix = regexp.MustCompile(`\s[0-9a-f]{32}$`)
+
+// Options holds the team and the user the imported boards are created for.
+type Options struct {
+	TeamID string
+	UserID string
+}
+
+type converter struct {
+	opt    Options
+	now    int64
+	files  []*zip.File
+	used   map[string]bool
+	bab    *model.BoardsAndBlocks
+	errors []model.ImportItemError
+}
+
+// Convert reads a Notion "Markdown & CSV" export zip. Every database CSV
+// becomes a board with a select property per column, and every row a card
+// whose page, exported as Markdown or HTML, becomes a text block. Items that
+// cannot be converted are skipped and returned alongside the boards.
+func Convert(r io.ReaderAt, size int64, opt Options) (*model.BoardsAndBlocks, []model.ImportItemError, error) {
+	zipReader, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidExport, err)
+	}
+
+	c := &converter{
+		opt:  opt,
+		now:  utils.GetMillis(),
+		used: map[string]bool{},
+		bab: &model.BoardsAndBlocks{
+			Boards: []*model.Board{},
+			Blocks: []model.Block{},
+		},
+		errors: []model.ImportItemError{},
+	}
+
+	// Notion exports the rows visible in the database view and, as
+	// `<name>_all.csv`, all of them; the latter is preferred.
+	databases := map[string]*zip.File{}
+	for _, f := range zipReader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		c.files = append(c.files, f)
+
+		if !strings.EqualFold(path.Ext(f.Name), ".csv") {
+			continue
+		}
+		if strings.HasSuffix(f.Name, allRowsSuffix) {
+			databases[strings.TrimSuffix(f.Name, allRowsSuffix)+".csv"] = f
+		} else if _, ok := databases[f.Name]; !ok {
+			databases[f.Name] = f
+		}
+	}
+	if len(databases) == 0 {
+		return nil, nil, fmt.Errorf("%w: no databases found", ErrInvalidExport)
+	}
+
+	names := make([]string, 0, len(databases))
+	for name := range databases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		c.used[databases[name].Name] = true
+		c.convertDatabase(name, databases[name])
+	}
+
+	for _, f := range c.files {
+		if isPage(f.Name) && !c.used[f.Name] {
+			c.addError(f.Name, errors.New("page is not a row of any database"))
+		}
+	}
+
+	return c.bab, c.errors, nil
+}
+
+func (c *converter) addError(item string, err error) {
+	c.errors = append(c.errors, model.ImportItemError{Item: item, Error: err.Error()})
+}
+
+func (c *converter) newBlock(boardID string, blockType model.BlockType, idType utils.IDType, parentID, title string) model.Block {
+	return model.Block{
+		ID:         utils.NewID(idType),
+		BoardID:    boardID,
+		ParentID:   parentID,
+		CreatedBy:  c.opt.UserID,
+		ModifiedBy: c.opt.UserID,
+		Schema:     1,
+		Type:       blockType,
+		Title:      title,
+		Fields:     map[string]interface{}{},
+		CreateAt:   c.now,
+		UpdateAt:   c.now,
+	}
+}
+
+// convertDatabase adds the board for the database exported at name, whose
+// pages are in the folder of the same name.
+func (c *converter) convertDatabase(name string, f *zip.File) {
+	data, err := readFile(f)
+	if err != nil {
+		c.addError(f.Name, err)
+		return
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	reader.FieldsPerRecord = -1
+	columns, err := reader.Read()
+	if err != nil {
+		c.addError(f.Name, fmt.Errorf("cannot read the header: %w", err))
+		return
+	}
+
+	board := &model.Board{
+		ID:          utils.NewID(utils.IDTypeBoard),
+		TeamID:      c.opt.TeamID,
+		CreatedBy:   c.opt.UserID,
+		ModifiedBy:  c.opt.UserID,
+		Type:        model.BoardTypePrivate,
+		MinimumRole: model.BoardRoleNone,
+		Title:       cleanName(path.Base(name)),
+		Properties:  map[string]interface{}{},
+		CreateAt:    c.now,
+		UpdateAt:    c.now,
+	}
+
+	// the first column is the title, every other one a select property
+	propertyIDs := make([]string, len(columns))
+	propertyOptions := make([]map[string]string, len(columns))
+	properties := make([][]interface{}, len(columns))
+	for i := 1; i < len(columns); i++ {
+		propertyIDs[i] = utils.NewID(utils.IDTypeBlock)
+		propertyOptions[i] = map[string]string{}
+		properties[i] = []interface{}{}
+	}
+
+	pages := c.pagesInFolder(strings.TrimSuffix(name, path.Ext(name)) + "/")
+
+	view := c.newBlock(board.ID, model.TypeView, utils.IDTypeView, board.ID, boardViewTitle)
+	blocks := []model.Block{view}
+	cardOrder := []interface{}{}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		item := fmt.Sprintf("%s row %d", f.Name, row)
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			c.addError(item, err)
+			continue
+		}
+		if err != nil {
+			c.addError(item, err)
+			break
+		}
+		if len(record) != len(columns) {
+			c.addError(item, fmt.Errorf("expected %d columns, found %d", len(columns), len(record)))
+			continue
+		}
+
+		card := c.newBlock(board.ID, model.TypeCard, utils.IDTypeCard, board.ID, record[0])
+		values := map[string]interface{}{}
+		for i := 1; i < len(record); i++ {
+			value := strings.TrimSpace(record[i])
+			if value == "" {
+				continue
+			}
+			optionID, ok := propertyOptions[i][value]
+			if !ok {
+				optionID = utils.NewID(utils.IDTypeBlock)
+				propertyOptions[i][value] = optionID
+				properties[i] = append(properties[i], map[string]interface{}{
+					"id":    optionID,
+					"value": value,
+					"color": optionColors[len(properties[i])%len(optionColors)],
+				})
+			}
+			values[propertyIDs[i]] = optionID
+		}
+
+		contentOrder := []interface{}{}
+		var text *model.Block
+		if page := pages.take(record[0]); page != nil {
+			c.used[page.Name] = true
+			content, err := pageContent(page, columns)
+			if err != nil {
+				c.addError(page.Name, err)
+			} else if content != "" {
+				block := c.newBlock(board.ID, model.TypeText, utils.IDTypeBlock, card.ID, content)
+				text = &block
+				contentOrder = append(contentOrder, block.ID)
+			}
+		}
+
+		card.Fields["properties"] = values
+		card.Fields["contentOrder"] = contentOrder
+		cardOrder = append(cardOrder, card.ID)
+		blocks = append(blocks, card)
+		if text != nil {
+			blocks = append(blocks, *text)
+		}
+	}
+
+	board.CardProperties = make([]map[string]interface{}, 0, len(columns))
+	for i := 1; i < len(columns); i++ {
+		board.CardProperties = append(board.CardProperties, map[string]interface{}{
+			"id":      propertyIDs[i],
+			"name":    columns[i],
+			"type":    "select",
+			"options": properties[i],
+		})
+	}
+
+	blocks[0].Fields = map[string]interface{}{
+		"viewType":           "board",
+		"sortOptions":        []interface{}{},
+		"visiblePropertyIds": []interface{}{},
+		"visibleOptionIds":   []interface{}{},
+		"hiddenOptionIds":    []interface{}{},
+		"collapsedOptionIds": []interface{}{},
+		"filter": map[string]interface{}{
+			"operation": "and",
+			"filters":   []interface{}{},
+		},
+		"cardOrder":          cardOrder,
+		"columnWidths":       map[string]interface{}{},
+		"columnCalculations": map[string]interface{}{},
+		"kanbanCalculations": map[string]interface{}{},
+		"defaultTemplateId":  "",
+	}
+	if len(columns) > 1 {
+		blocks[0].Fields["groupById"] = propertyIDs[1]
+	}
+
+	c.bab.Boards = append(c.bab.Boards, board)
+	c.bab.Blocks = append(c.bab.Blocks, blocks...)
+}
+
+// pageFiles holds the pages of a database folder by their title. Rows with
+// the same title are matched to their pages in order.
+type pageFiles map[string][]*zip.File
+
+func (p pageFiles) take(title string) *zip.File {
+	files := p[title]
+	if len(files) == 0 {
+		return nil
+	}
+	p[title] = files[1:]
+	return files[0]
+}
+
+func (c *converter) pagesInFolder(folder string) pageFiles {
+	pages := pageFiles{}
+	for _, f := range c.files {
+		if !strings.HasPrefix(f.Name, folder) || !isPage(f.Name) {
+			continue
+		}
+		if strings.Contains(strings.TrimPrefix(f.Name, folder), "/") {
+			continue
+		}
+		title := cleanName(path.Base(f.Name))
+		pages[title] = append(pages[title], f)
+	}
+	return pages
+}
+
+func isPage(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".html":
+		return true
+	}
+	return false
+}
+
+// cleanName strips the extension and the Notion id from an exported file name.
+func cleanName(name string) string {
+	name = strings.TrimSuffix(name, path.Ext(name))
+	return notionIDSuffix.ReplaceAllString(name, "")
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxEntrySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxEntrySize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxEntrySize)
+	}
+	return data, nil
+}
+
+func pageContent(f *zip.File, columns []string) (string, error) {
+	data, err := readFile(f)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(path.Ext(f.Name), ".html") {
+		return htmlToText(data)
+	}
+	return markdownBody(string(data), columns), nil
+}
+
+// markdownBody drops the header Notion writes at the top of every page,
+// which repeats the title and the property values of the row.
+func markdownBody(markdown string, columns []string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "# ") {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 {
+		line := lines[0]
+		if strings.TrimSpace(line) == "" || isPropertyLine(line, columns) {
+			lines = lines[1:]
+			continue
+		}
+		break
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func isPropertyLine(line string, columns []string) bool {
+	for _, column := range columns {
+		if strings.HasPrefix(line, column+": ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package notion
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testID = " 0123456789abcdef0123456789abcdef"
+
+func buildExport(t *testing.T, files map[string]string) *bytes.Reader {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return bytes.NewReader(buf.Bytes())
+}
+
+func convertExport(t *testing.T, files map[string]string) (*model.BoardsAndBlocks, []model.ImportItemError) {
+	r := buildExport(t, files)
+	bab, itemErrors, err := Convert(r, r.Size(), Options{TeamID: "team-id", UserID: "user-id"})
+	require.NoError(t, err)
+	return bab, itemErrors
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("database with markdown pages", func(t *testing.T) {
+		bab, itemErrors := convertExport(t, map[string]string{
+			"Tasks" + testID + ".csv":                         "\ufeffName,Status\nWrite docs,Doing\nShip it,Done\nReview,Doing\n",
+			"Tasks" + testID + "/Write docs" + testID + ".md": "# Write docs\n\nStatus: Doing\n\nStart with the API.\n",
+			"Tasks" + testID + "/Ship it" + testID + ".html":  "<html><head><title>Ship it</title></head><body><header><h1>Ship it</h1></header><div class=\"page-body\"><p>Tag the release.</p><ul><li>Build</li><li>Publish</li></ul></div></body></html>",
+		})
+		assert.Empty(t, itemErrors)
+
+		require.Len(t, bab.Boards, 1)
+		board := bab.Boards[0]
+		assert.Equal(t, "Tasks", board.Title)
+		assert.Equal(t, "team-id", board.TeamID)
+
+		require.Len(t, board.CardProperties, 1)
+		property := board.CardProperties[0]
+		assert.Equal(t, "Status", property["name"])
+		options := property["options"].([]interface{})
+		require.Len(t, options, 2)
+		doing := options[0].(map[string]interface{})
+		assert.Equal(t, "Doing", doing["value"])
+
+		// view, two cards with pages, one card without
+		require.Len(t, bab.Blocks, 6)
+		view := bab.Blocks[0]
+		assert.EqualValues(t, model.TypeView, view.Type)
+		assert.Equal(t, property["id"], view.Fields["groupById"])
+
+		writeDocs, writeDocsText := bab.Blocks[1], bab.Blocks[2]
+		assert.Equal(t, "Write docs", writeDocs.Title)
+		assert.Equal(t, doing["id"], writeDocs.Fields["properties"].(map[string]interface{})[property["id"].(string)])
+		assert.EqualValues(t, model.TypeText, writeDocsText.Type)
+		assert.Equal(t, writeDocs.ID, writeDocsText.ParentID)
+		assert.Equal(t, "Start with the API.", writeDocsText.Title)
+		assert.Equal(t, []interface{}{writeDocsText.ID}, writeDocs.Fields["contentOrder"])
+
+		shipIt, shipItText := bab.Blocks[3], bab.Blocks[4]
+		assert.Equal(t, "Ship it", shipIt.Title)
+		assert.Equal(t, "Tag the release.\n\n- Build\n- Publish", shipItText.Title)
+
+		review := bab.Blocks[5]
+		assert.Equal(t, "Review", review.Title)
+		assert.Equal(t, []interface{}{}, review.Fields["contentOrder"])
+
+		assert.Equal(t, []interface{}{writeDocs.ID, shipIt.ID, review.ID}, view.Fields["cardOrder"])
+	})
+
+	t.Run("prefers the csv with all the rows", func(t *testing.T) {
+		bab, itemErrors := convertExport(t, map[string]string{
+			"Tasks" + testID + ".csv":     "Name\nVisible\n",
+			"Tasks" + testID + "_all.csv": "Name\nVisible\nHidden\n",
+		})
+		assert.Empty(t, itemErrors)
+		require.Len(t, bab.Boards, 1)
+		require.Len(t, bab.Blocks, 3)
+	})
+
+	t.Run("reports the items that cannot be converted", func(t *testing.T) {
+		bab, itemErrors := convertExport(t, map[string]string{
+			"Tasks" + testID + ".csv":   "Name,Status\nGood,Done\nBad\n",
+			"Empty" + testID + ".csv":   "",
+			"Notes" + testID + ".md":    "# Notes\n",
+			"Tasks" + testID + "/a.png": "image",
+		})
+
+		require.Len(t, bab.Boards, 1)
+		assert.Equal(t, "Tasks", bab.Boards[0].Title)
+		require.Len(t, bab.Blocks, 2)
+
+		items := make([]string, 0, len(itemErrors))
+		for _, itemError := range itemErrors {
+			items = append(items, itemError.Item)
+		}
+		assert.ElementsMatch(t, []string{
+			"Empty" + testID + ".csv",
+			"Tasks" + testID + ".csv row 2",
+			"Notes" + testID + ".md",
+		}, items)
+	})
+}
+
+func TestConvertInvalidExport(t *testing.T) {
+	t.Run("not a zip", func(t *testing.T) {
+		r := bytes.NewReader([]byte("not a zip"))
+		_, _, err := Convert(r, r.Size(), Options{})
+		require.ErrorIs(t, err, ErrInvalidExport)
+	})
+
+	t.Run("no databases", func(t *testing.T) {
+		r := buildExport(t, map[string]string{"Page" + testID + ".md": "# Page\n"})
+		_, _, err := Convert(r, r.Size(), Options{})
+		require.ErrorIs(t, err, ErrInvalidExport)
+	})
+}
@@ -0,0 +1,221 @@
+package trello
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+const (
+	listPropertyName = "List"
+	boardViewTitle   = "Board View"
+	checkItemDone    = "complete"
+)
+
+var ErrInvalidExport = errors.New("invalid trello export")
+
+// optionColors are assigned in turn to the options created for the lists.
+var optionColors = []string{
+	"propColorGray",
+	"propColorBrown",
+	"propColorOrange",
+	"propColorYellow",
+	"propColorGreen",
+	"propColorBlue",
+	"propColorPurple",
+	"propColorPink",
+	"propColorRed",
+}
+
+// Export is the subset of a Trello board JSON export used by the importer.
+type Export struct {
+	Name       string      `json:"name"`
+	Desc       string      `json:"desc"`
+	Lists      []List      `json:"lists"`
+	Cards      []Card      `json:"cards"`
+	Checklists []Checklist `json:"checklists"`
+}
+
+type List struct {
+	ID   string  `json:"id"`
+	Name string  `json:"name"`
+	Pos  float64 `json:"pos"`
+}
+
+type Card struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Desc         string   `json:"desc"`
+	IDList       string   `json:"idList"`
+	IDChecklists []string `json:"idChecklists"`
+	Pos          float64  `json:"pos"`
+}
+
+type Checklist struct {
+	ID         string      `json:"id"`
+	CheckItems []CheckItem `json:"checkItems"`
+}
+
+type CheckItem struct {
+	Name  string  `json:"name"`
+	State string  `json:"state"`
+	Pos   float64 `json:"pos"`
+}
+
+// Options holds the team and the user the imported board is created for.
+type Options struct {
+	TeamID string
+	UserID string
+}
+
+// Convert reads a Trello board export and maps it to a board. The lists
+// become the options of a select property, which the board view groups the
+// cards by, and the card descriptions and checklists become content blocks.
+func Convert(r io.Reader, opt Options) (*model.BoardsAndBlocks, error) {
+	var export Export
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidExport, err)
+	}
+	if export.Name == "" {
+		return nil, fmt.Errorf("%w: missing board name", ErrInvalidExport)
+	}
+	return convertExport(&export, opt), nil
+}
+
+func convertExport(export *Export, opt Options) *model.BoardsAndBlocks {
+	now := utils.GetMillis()
+	board := &model.Board{
+		ID:          utils.NewID(utils.IDTypeBoard),
+		TeamID:      opt.TeamID,
+		CreatedBy:   opt.UserID,
+		ModifiedBy:  opt.UserID,
+		Type:        model.BoardTypePrivate,
+		MinimumRole: model.BoardRoleNone,
+		Title:       export.Name,
+		Description: export.Desc,
+		Properties:  map[string]interface{}{},
+		CreateAt:    now,
+		UpdateAt:    now,
+	}
+
+	newBlock := func(blockType model.BlockType, idType utils.IDType, parentID, title string) model.Block {
+		return model.Block{
+			ID:         utils.NewID(idType),
+			BoardID:    board.ID,
+			ParentID:   parentID,
+			CreatedBy:  opt.UserID,
+			ModifiedBy: opt.UserID,
+			Schema:     1,
+			Type:       blockType,
+			Title:      title,
+			Fields:     map[string]interface{}{},
+			CreateAt:   now,
+			UpdateAt:   now,
+		}
+	}
+
+	// the lists become the options of a select property
+	lists := append([]List(nil), export.Lists...)
+	sort.SliceStable(lists, func(i, j int) bool { return lists[i].Pos < lists[j].Pos })
+
+	optionIDs := make(map[string]string, len(lists))
+	options := make([]interface{}, 0, len(lists))
+	for i, list := range lists {
+		optionID := utils.NewID(utils.IDTypeBlock)
+		optionIDs[list.ID] = optionID
+		options = append(options, map[string]interface{}{
+			"id":    optionID,
+			"value": list.Name,
+			"color": optionColors[i%len(optionColors)],
+		})
+	}
+
+	listPropertyID := utils.NewID(utils.IDTypeBlock)
+	board.CardProperties = []map[string]interface{}{
+		{
+			"id":      listPropertyID,
+			"name":    listPropertyName,
+			"type":    "select",
+			"options": options,
+		},
+	}
+
+	checklists := make(map[string]Checklist, len(export.Checklists))
+	for _, checklist := range export.Checklists {
+		checklists[checklist.ID] = checklist
+	}
+
+	cards := append([]Card(nil), export.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool { return cards[i].Pos < cards[j].Pos })
+
+	view := newBlock(model.TypeView, utils.IDTypeView, board.ID, boardViewTitle)
+	blocks := []model.Block{view}
+	cardOrder := make([]interface{}, 0, len(cards))
+
+	for _, card := range cards {
+		outCard := newBlock(model.TypeCard, utils.IDTypeCard, board.ID, card.Name)
+		properties := map[string]interface{}{}
+		if optionID, ok := optionIDs[card.IDList]; ok {
+			properties[listPropertyID] = optionID
+		}
+		contentOrder := []interface{}{}
+
+		var content []model.Block
+		if card.Desc != "" {
+			text := newBlock(model.TypeText, utils.IDTypeBlock, outCard.ID, card.Desc)
+			content = append(content, text)
+			contentOrder = append(contentOrder, text.ID)
+		}
+
+		for _, checklistID := range card.IDChecklists {
+			checklist, ok := checklists[checklistID]
+			if !ok {
+				continue
+			}
+			items := append([]CheckItem(nil), checklist.CheckItems...)
+			sort.SliceStable(items, func(i, j int) bool { return items[i].Pos < items[j].Pos })
+
+			for _, item := range items {
+				checkbox := newBlock(model.TypeCheckbox, utils.IDTypeBlock, outCard.ID, item.Name)
+				checkbox.Fields["value"] = item.State == checkItemDone
+				content = append(content, checkbox)
+				contentOrder = append(contentOrder, checkbox.ID)
+			}
+		}
+
+		outCard.Fields["properties"] = properties
+		outCard.Fields["contentOrder"] = contentOrder
+		cardOrder = append(cardOrder, outCard.ID)
+		blocks = append(blocks, outCard)
+		blocks = append(blocks, content...)
+	}
+
+	blocks[0].Fields = map[string]interface{}{
+		"viewType":           "board",
+		"groupById":          listPropertyID,
+		"sortOptions":        []interface{}{},
+		"visiblePropertyIds": []interface{}{},
+		"visibleOptionIds":   []interface{}{},
+		"hiddenOptionIds":    []interface{}{},
+		"collapsedOptionIds": []interface{}{},
+		"filter": map[string]interface{}{
+			"operation": "and",
+			"filters":   []interface{}{},
+		},
+		"cardOrder":          cardOrder,
+		"columnWidths":       map[string]interface{}{},
+		"columnCalculations": map[string]interface{}{},
+		"kanbanCalculations": map[string]interface{}{},
+		"defaultTemplateId":  "",
+	}
+
+	return &model.BoardsAndBlocks{
+		Boards: []*model.Board{board},
+		Blocks: blocks,
+	}
+}
@@ -0,0 +1,94 @@
+package trello
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExport = `{
+	"name": "Sprint board",
+	"desc": "Work for the sprint",
+	"lists": [
+		{"id": "list-done", "name": "Done", "pos": 2},
+		{"id": "list-todo", "name": "To Do", "pos": 1}
+	],
+	"cards": [
+		{"id": "card-2", "name": "Second", "idList": "list-done", "pos": 20},
+		{"id": "card-1", "name": "First", "desc": "Some details", "idList": "list-todo", "idChecklists": ["checklist-1", "missing"], "pos": 10}
+	],
+	"checklists": [
+		{"id": "checklist-1", "checkItems": [
+			{"name": "Second step", "state": "incomplete", "pos": 2},
+			{"name": "First step", "state": "complete", "pos": 1}
+		]}
+	]
+}`
+
+func TestConvert(t *testing.T) {
+	bab, err := Convert(strings.NewReader(testExport), Options{TeamID: "team-id", UserID: "user-id"})
+	require.NoError(t, err)
+
+	require.Len(t, bab.Boards, 1)
+	board := bab.Boards[0]
+	assert.Equal(t, "Sprint board", board.Title)
+	assert.Equal(t, "Work for the sprint", board.Description)
+	assert.Equal(t, "team-id", board.TeamID)
+	assert.Equal(t, "user-id", board.CreatedBy)
+
+	require.Len(t, board.CardProperties, 1)
+	property := board.CardProperties[0]
+	assert.Equal(t, "select", property["type"])
+	options := property["options"].([]interface{})
+	require.Len(t, options, 2)
+	todo := options[0].(map[string]interface{})
+	done := options[1].(map[string]interface{})
+	assert.Equal(t, "To Do", todo["value"])
+	assert.Equal(t, "Done", done["value"])
+
+	// view, first card with its text and two checkboxes, second card
+	require.Len(t, bab.Blocks, 6)
+	for _, block := range bab.Blocks {
+		assert.Equal(t, board.ID, block.BoardID)
+	}
+
+	view := bab.Blocks[0]
+	assert.EqualValues(t, model.TypeView, view.Type)
+	assert.Equal(t, "board", view.Fields["viewType"])
+	assert.Equal(t, property["id"], view.Fields["groupById"])
+
+	first, second := bab.Blocks[1], bab.Blocks[5]
+	assert.Equal(t, []interface{}{first.ID, second.ID}, view.Fields["cardOrder"])
+
+	assert.Equal(t, "First", first.Title)
+	assert.Equal(t, todo["id"], first.Fields["properties"].(map[string]interface{})[property["id"].(string)])
+	assert.Equal(t, "Second", second.Title)
+	assert.Equal(t, done["id"], second.Fields["properties"].(map[string]interface{})[property["id"].(string)])
+
+	text, firstStep, secondStep := bab.Blocks[2], bab.Blocks[3], bab.Blocks[4]
+	assert.EqualValues(t, model.TypeText, text.Type)
+	assert.Equal(t, "Some details", text.Title)
+	assert.Equal(t, first.ID, text.ParentID)
+	assert.EqualValues(t, model.TypeCheckbox, firstStep.Type)
+	assert.Equal(t, "First step", firstStep.Title)
+	assert.Equal(t, true, firstStep.Fields["value"])
+	assert.Equal(t, "Second step", secondStep.Title)
+	assert.Equal(t, false, secondStep.Fields["value"])
+	assert.Equal(t, []interface{}{text.ID, firstStep.ID, secondStep.ID}, first.Fields["contentOrder"])
+	assert.Equal(t, []interface{}{}, second.Fields["contentOrder"])
+}
+
+func TestConvertInvalidExport(t *testing.T) {
+	t.Run("not json", func(t *testing.T) {
+		_, err := Convert(strings.NewReader("not json"), Options{})
+		require.ErrorIs(t, err, ErrInvalidExport)
+	})
+
+	t.Run("missing board name", func(t *testing.T) {
+		_, err := Convert(strings.NewReader(`{"lists": []}`), Options{})
+		require.ErrorIs(t, err, ErrInvalidExport)
+	})
+}
@@ -0,0 +1,356 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	defaultWorkers          = 2
+	defaultPollInterval     = 5 * time.Second
+	defaultScheduleInterval = time.Minute
+
+	// staleJobTimeout is the time after which a running job that didn't
+	// report progress is considered abandoned and made pending again.
+	staleJobTimeout = time.Hour
+
+	// retryDelay is multiplied by the number of attempts to delay the
+	// next attempt of a failed job.
+	retryDelay = time.Minute
+)
+
+var ErrUnknownJobType = errors.New("unknown job type")
+
+type Store interface {
+	CreateJob(job *model.Job) (*model.Job, error)
+	GetLatestJob(jobType string) (*model.Job, error)
+	ClaimJob(types []string, now int64) (*model.Job, error)
+	UpdateJob(job *model.Job) error
+	ResetStaleJobs(updatedBefore int64) (int64, error)
+}
+
+// Locker serializes the scheduling of the recurring jobs between the nodes
+// of a cluster, so only the node holding the lock schedules at a time.
+type Locker interface {
+	Lock()
+	Unlock()
+}
+
+// TryLocker is a Locker that can be busy or unreachable, like a lock held
+// in Redis. The node doesn't wait for it: the jobs are scheduled by the
+// node holding it, or on the next interval.
+type TryLocker interface {
+	Locker
+	TryLock() (bool, error)
+}
+
+// ProgressFunc reports the progress of a running job in percent. It also
+// tells the other nodes that the job is still being worked on.
+type ProgressFunc func(percent int)
+
+// Worker runs a job. The returned result is stored with the job when it
+// succeeds, and failed jobs are retried until they run out of attempts.
+// Workers should stop when ctx is cancelled, the job then runs again
+// after a restart.
+type Worker func(ctx context.Context, job *model.Job, progress ProgressFunc) (map[string]interface{}, error)
+
+type Params struct {
+	Store  Store
+	Logger *mlog.Logger
+	// Locker defaults to a local mutex, for single node installations:
+	// with several nodes, each of them would schedule the recurring jobs.
+	Locker Locker
+	// Workers is the number of jobs run concurrently by this node.
+	Workers int
+}
+
+// Service runs the persisted background jobs. Jobs are claimed from the
+// store by the workers of any node, while the recurring jobs are
+// scheduled by the node holding the lock.
+type Service struct {
+	store  Store
+	logger *mlog.Logger
+	locker Locker
+
+	workers          int
+	pollInterval     time.Duration
+	scheduleInterval time.Duration
+
+	mu        sync.RWMutex
+	handlers  map[string]Worker
+	schedules map[string]time.Duration
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(params Params) *Service {
+	locker := params.Locker
+	if locker == nil {
+		locker = &sync.Mutex{}
+	}
+	workers := params.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	return &Service{
+		store:            params.Store,
+		logger:           params.Logger,
+		locker:           locker,
+		workers:          workers,
+		pollInterval:     defaultPollInterval,
+		scheduleInterval: defaultScheduleInterval,
+		handlers:         map[string]Worker{},
+		schedules:        map[string]time.Duration{},
+		wake:             make(chan struct{}, 1),
+	}
+}
+
+// RegisterWorker sets the worker running the jobs of the type.
+func (s *Service) RegisterWorker(jobType string, worker Worker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = worker
+}
+
+// Schedule creates a job of the type every interval. A new job is only
+// created once the previous one is done.
+func (s *Service) Schedule(jobType string, interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schedules[jobType] = interval
+}
+
+// Enqueue creates a job to be run as soon as a worker is free. The type,
+// data, creator and max attempts are taken from the given job.
+func (s *Service) Enqueue(job *model.Job) (*model.Job, error) {
+	s.mu.RLock()
+	_, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownJobType, job.Type)
+	}
+
+	created, err := s.store.CreateJob(&model.Job{
+		Type:        job.Type,
+		Data:        job.Data,
+		CreatedBy:   job.CreatedBy,
+		MaxAttempts: job.MaxAttempts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return created, nil
+}
+
+// Start starts the workers and the scheduler.
+func (s *Service) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.wg.Add(s.workers + 1)
+	for i := 0; i < s.workers; i++ {
+		go s.runWorker(ctx)
+	}
+	go s.runScheduler(ctx)
+}
+
+// Stop stops the workers and the scheduler, and waits for the running
+// jobs to return.
+func (s *Service) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	s.cancel = nil
+}
+
+func (s *Service) types() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	types := make([]string, 0, len(s.handlers))
+	for jobType := range s.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (s *Service) runScheduler(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.scheduleInterval)
+	defer ticker.Stop()
+
+	for {
+		s.schedule(time.Now())
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// schedule creates the recurring jobs that are due and makes the jobs
+// abandoned by a node pending again.
+func (s *Service) schedule(now time.Time) {
+	if tryLocker, ok := s.locker.(TryLocker); ok {
+		locked, err := tryLocker.TryLock()
+		if err != nil {
+			s.logger.Error("Cannot take the jobs scheduling lock", mlog.Err(err))
+			return
+		}
+		if !locked {
+			return
+		}
+	} else {
+		s.locker.Lock()
+	}
+	defer s.locker.Unlock()
+
+	reset, err := s.store.ResetStaleJobs(utils.GetMillisForTime(now.Add(-staleJobTimeout)))
+	if err != nil {
+		s.logger.Error("Cannot reset stale jobs", mlog.Err(err))
+	} else if reset > 0 {
+		s.logger.Warn("Reset stale jobs", mlog.Int64("count", reset))
+	}
+
+	s.mu.RLock()
+	schedules := make(map[string]time.Duration, len(s.schedules))
+	for jobType, interval := range s.schedules {
+		schedules[jobType] = interval
+	}
+	s.mu.RUnlock()
+
+	created := false
+	for jobType, interval := range schedules {
+		latest, err := s.store.GetLatestJob(jobType)
+		if err != nil {
+			s.logger.Error("Cannot get the latest job", mlog.String("type", jobType), mlog.Err(err))
+			continue
+		}
+		if latest != nil && (!latest.IsDone() || now.Sub(utils.GetTimeForMillis(latest.CreateAt)) < interval) {
+			continue
+		}
+
+		if _, err := s.store.CreateJob(&model.Job{Type: jobType}); err != nil {
+			s.logger.Error("Cannot schedule job", mlog.String("type", jobType), mlog.Err(err))
+			continue
+		}
+		created = true
+	}
+
+	if created {
+		select {
+		case s.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (s *Service) runWorker(ctx context.Context) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		// drain the due jobs before waiting
+		for ctx.Err() == nil {
+			job, err := s.store.ClaimJob(s.types(), utils.GetMillis())
+			if err != nil {
+				s.logger.Error("Cannot claim job", mlog.Err(err))
+				break
+			}
+			if job == nil {
+				break
+			}
+			s.run(ctx, job)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.wake:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// run runs a claimed job and stores its outcome.
+func (s *Service) run(ctx context.Context, job *model.Job) {
+	s.mu.RLock()
+	worker, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	progress := func(percent int) {
+		job.Progress = percent
+		if err := s.store.UpdateJob(job); err != nil {
+			s.logger.Warn("Cannot update job progress", mlog.String("id", job.ID), mlog.Err(err))
+		}
+	}
+
+	s.logger.Debug("Running job", mlog.String("id", job.ID), mlog.String("type", job.Type), mlog.Int("attempt", job.Attempts))
+	result, err := runWorker(ctx, worker, job, progress)
+
+	now := time.Now()
+	switch {
+	case err == nil:
+		job.Status = model.JobStatusSuccess
+		job.Progress = 100
+		job.Result = result
+		job.Error = ""
+		job.CompleteAt = utils.GetMillisForTime(now)
+	case ctx.Err() != nil:
+		// interrupted by a shutdown, the attempt doesn't count
+		job.Status = model.JobStatusPending
+		job.Attempts--
+	case job.Attempts < job.MaxAttempts:
+		s.logger.Warn("Job failed, retrying", mlog.String("id", job.ID), mlog.String("type", job.Type), mlog.Err(err))
+		job.Status = model.JobStatusPending
+		job.Error = err.Error()
+		job.RunAt = utils.GetMillisForTime(now.Add(retryDelay * time.Duration(job.Attempts)))
+	default:
+		s.logger.Error("Job failed", mlog.String("id", job.ID), mlog.String("type", job.Type), mlog.Err(err))
+		job.Status = model.JobStatusError
+		job.Error = err.Error()
+		job.CompleteAt = utils.GetMillisForTime(now)
+	}
+
+	if err := s.store.UpdateJob(job); err != nil {
+		s.logger.Error("Cannot update job", mlog.String("id", job.ID), mlog.Err(err))
+	}
+}
+
+// runWorker runs the worker, turning its panics into errors.
+func runWorker(ctx context.Context, worker Worker, job *model.Job, progress ProgressFunc) (result map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return worker(ctx, job, progress)
+}
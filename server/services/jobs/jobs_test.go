@@ -0,0 +1,336 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// fakeStore keeps the jobs in memory, following the semantics of the
+// sql store.
+type fakeStore struct {
+	mu   sync.Mutex
+	jobs map[string]*model.Job
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{jobs: map[string]*model.Job{}}
+}
+
+func (fs *fakeStore) CreateJob(job *model.Job) (*model.Job, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	now := utils.GetMillis()
+	newJob := *job
+	newJob.ID = utils.NewID(utils.IDTypeNone)
+	newJob.Status = model.JobStatusPending
+	if newJob.MaxAttempts <= 0 {
+		newJob.MaxAttempts = model.DefaultJobMaxAttempts
+	}
+	newJob.CreateAt = now
+	newJob.UpdateAt = now
+	if newJob.RunAt == 0 {
+		newJob.RunAt = now
+	}
+	fs.jobs[newJob.ID] = &newJob
+
+	stored := newJob
+	return &stored, nil
+}
+
+func (fs *fakeStore) get(jobID string) *model.Job {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	job := *fs.jobs[jobID]
+	return &job
+}
+
+func (fs *fakeStore) byType(jobType string) []*model.Job {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	jobs := []*model.Job{}
+	for _, job := range fs.jobs {
+		if job.Type == jobType {
+			j := *job
+			jobs = append(jobs, &j)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreateAt > jobs[j].CreateAt })
+	return jobs
+}
+
+func (fs *fakeStore) GetLatestJob(jobType string) (*model.Job, error) {
+	jobs := fs.byType(jobType)
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+func (fs *fakeStore) ClaimJob(types []string, now int64) (*model.Job, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	for _, job := range fs.jobs {
+		if job.Status != model.JobStatusPending || job.RunAt > now {
+			continue
+		}
+		for _, jobType := range types {
+			if job.Type == jobType {
+				job.Status = model.JobStatusRunning
+				job.Attempts++
+				job.StartAt = now
+				job.UpdateAt = now
+				claimed := *job
+				return &claimed, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (fs *fakeStore) UpdateJob(job *model.Job) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	job.UpdateAt = utils.GetMillis()
+	stored := *job
+	fs.jobs[job.ID] = &stored
+	return nil
+}
+
+func (fs *fakeStore) ResetStaleJobs(updatedBefore int64) (int64, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	var count int64
+	for _, job := range fs.jobs {
+		if job.Status == model.JobStatusRunning && job.UpdateAt < updatedBefore {
+			job.Status = model.JobStatusPending
+			count++
+		}
+	}
+	return count, nil
+}
+
+func setupService(t *testing.T) (*Service, *fakeStore) {
+	store := newFakeStore()
+	service := New(Params{
+		Store:  store,
+		Logger: mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+	})
+	service.pollInterval = 10 * time.Millisecond
+	return service, store
+}
+
+// claim creates a job and claims it like a worker would.
+func claim(t *testing.T, store *fakeStore, job *model.Job) *model.Job {
+	created, err := store.CreateJob(job)
+	require.NoError(t, err)
+	claimed, err := store.ClaimJob([]string{created.Type}, utils.GetMillis())
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	return claimed
+}
+
+func TestEnqueue(t *testing.T) {
+	t.Run("unknown type", func(t *testing.T) {
+		service, _ := setupService(t)
+
+		job, err := service.Enqueue(&model.Job{Type: "unknown", CreatedBy: "user-id"})
+		require.ErrorIs(t, err, ErrUnknownJobType)
+		require.Nil(t, job)
+	})
+
+	t.Run("runs the job", func(t *testing.T) {
+		service, store := setupService(t)
+		service.RegisterWorker("test", func(ctx context.Context, job *model.Job, progress ProgressFunc) (map[string]interface{}, error) {
+			progress(50)
+			return map[string]interface{}{"echo": job.Data["value"]}, nil
+		})
+		service.Start()
+		defer service.Stop()
+
+		job, err := service.Enqueue(&model.Job{
+			Type:      "test",
+			Data:      map[string]interface{}{"value": "hello"},
+			CreatedBy: "user-id",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "user-id", job.CreatedBy)
+
+		require.Eventually(t, func() bool {
+			return store.get(job.ID).IsDone()
+		}, time.Second, 10*time.Millisecond)
+
+		done := store.get(job.ID)
+		assert.Equal(t, model.JobStatusSuccess, done.Status)
+		assert.Equal(t, 100, done.Progress)
+		assert.Equal(t, 1, done.Attempts)
+		assert.Equal(t, "hello", done.Result["echo"])
+		assert.NotZero(t, done.CompleteAt)
+	})
+}
+
+func TestRun(t *testing.T) {
+	failing := errors.New("failing")
+
+	t.Run("retries a failed job", func(t *testing.T) {
+		service, store := setupService(t)
+		service.RegisterWorker("test", func(context.Context, *model.Job, ProgressFunc) (map[string]interface{}, error) {
+			return nil, failing
+		})
+
+		job := claim(t, store, &model.Job{Type: "test"})
+		before := utils.GetMillis()
+		service.run(context.Background(), job)
+
+		retried := store.get(job.ID)
+		assert.Equal(t, model.JobStatusPending, retried.Status)
+		assert.Equal(t, failing.Error(), retried.Error)
+		assert.GreaterOrEqual(t, retried.RunAt, before+retryDelay.Milliseconds())
+		assert.Zero(t, retried.CompleteAt)
+	})
+
+	t.Run("fails a job out of attempts", func(t *testing.T) {
+		service, store := setupService(t)
+		service.RegisterWorker("test", func(context.Context, *model.Job, ProgressFunc) (map[string]interface{}, error) {
+			return nil, failing
+		})
+
+		job := claim(t, store, &model.Job{Type: "test", MaxAttempts: 1})
+		service.run(context.Background(), job)
+
+		failed := store.get(job.ID)
+		assert.Equal(t, model.JobStatusError, failed.Status)
+		assert.Equal(t, failing.Error(), failed.Error)
+		assert.NotZero(t, failed.CompleteAt)
+	})
+
+	t.Run("recovers from panics", func(t *testing.T) {
+		service, store := setupService(t)
+		service.RegisterWorker("test", func(context.Context, *model.Job, ProgressFunc) (map[string]interface{}, error) {
+			panic("boom")
+		})
+
+		job := claim(t, store, &model.Job{Type: "test", MaxAttempts: 1})
+		service.run(context.Background(), job)
+
+		failed := store.get(job.ID)
+		assert.Equal(t, model.JobStatusError, failed.Status)
+		assert.Contains(t, failed.Error, "boom")
+	})
+
+	t.Run("interrupted job doesn't count the attempt", func(t *testing.T) {
+		service, store := setupService(t)
+		service.RegisterWorker("test", func(ctx context.Context, _ *model.Job, _ ProgressFunc) (map[string]interface{}, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+
+		job := claim(t, store, &model.Job{Type: "test"})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service.run(ctx, job)
+
+		interrupted := store.get(job.ID)
+		assert.Equal(t, model.JobStatusPending, interrupted.Status)
+		assert.Equal(t, 0, interrupted.Attempts)
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	service, store := setupService(t)
+	service.RegisterWorker("test", func(context.Context, *model.Job, ProgressFunc) (map[string]interface{}, error) {
+		return nil, nil
+	})
+	service.Schedule("test", time.Hour)
+
+	now := time.Now()
+	service.schedule(now)
+	jobs := store.byType("test")
+	require.Len(t, jobs, 1)
+
+	// the previous job is still pending
+	service.schedule(now.Add(2 * time.Hour))
+	require.Len(t, store.byType("test"), 1)
+
+	job := jobs[0]
+	job.Status = model.JobStatusSuccess
+	require.NoError(t, store.UpdateJob(job))
+
+	// the previous job is done but recent
+	service.schedule(now.Add(30 * time.Minute))
+	require.Len(t, store.byType("test"), 1)
+
+	service.schedule(now.Add(2 * time.Hour))
+	require.Len(t, store.byType("test"), 2)
+}
+
+func TestScheduleResetsStaleJobs(t *testing.T) {
+	service, store := setupService(t)
+
+	job := claim(t, store, &model.Job{Type: "test"})
+
+	service.schedule(time.Now())
+	assert.Equal(t, model.JobStatusRunning, store.get(job.ID).Status)
+
+	service.schedule(time.Now().Add(2 * staleJobTimeout))
+	assert.Equal(t, model.JobStatusPending, store.get(job.ID).Status)
+}
+
+// fakeTryLocker is a lock held by another node while busy.
+type fakeTryLocker struct {
+	held bool
+	busy bool
+	err  error
+}
+
+func (l *fakeTryLocker) Lock()   { l.held = true }
+func (l *fakeTryLocker) Unlock() { l.held = false }
+
+func (l *fakeTryLocker) TryLock() (bool, error) {
+	if l.err != nil || l.busy || l.held {
+		return false, l.err
+	}
+	l.held = true
+	return true, nil
+}
+
+func TestScheduleWithTryLocker(t *testing.T) {
+	service, store := setupService(t)
+	locker := &fakeTryLocker{busy: true}
+	service.locker = locker
+	service.RegisterWorker("test", func(context.Context, *model.Job, ProgressFunc) (map[string]interface{}, error) {
+		return nil, nil
+	})
+	service.Schedule("test", time.Hour)
+
+	// another node is scheduling
+	service.schedule(time.Now())
+	require.Empty(t, store.byType("test"))
+
+	locker.busy = false
+	locker.err = errors.New("unreachable")
+	service.schedule(time.Now())
+	require.Empty(t, store.byType("test"))
+
+	locker.err = nil
+	service.schedule(time.Now())
+	require.Len(t, store.byType("test"), 1)
+
+	require.False(t, locker.held)
+}
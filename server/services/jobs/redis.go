@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/redis"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+// redisLockTTL bounds the time a node that stopped while scheduling keeps
+// the other nodes from scheduling.
+const redisLockTTL = time.Minute
+
+// redisUnlockScript deletes the lock if it is still held by the token.
+const redisUnlockScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+// RedisLocker is a TryLocker held in Redis, so that the servers connected
+// to the same Redis schedule the recurring jobs one at a time. The lock
+// expires if the node holding it stops.
+type RedisLocker struct {
+	client *redis.Client
+	key    string
+	token  string
+}
+
+// NewRedisLocker returns a RedisLocker held in the given key.
+func NewRedisLocker(client *redis.Client, key string) *RedisLocker {
+	return &RedisLocker{
+		client: client,
+		key:    key,
+		token:  utils.NewID(utils.IDTypeNone),
+	}
+}
+
+// TryLock implements TryLocker.
+func (l *RedisLocker) TryLock() (bool, error) {
+	reply, err := l.client.Do("SET", l.key, l.token, "NX", "PX", redisLockTTL.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	return reply == "OK", nil
+}
+
+// Lock implements Locker, waiting for the lock until it is taken.
+func (l *RedisLocker) Lock() {
+	for {
+		if locked, err := l.TryLock(); err == nil && locked {
+			return
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// Unlock implements Locker. Failures are ignored, the lock expiring.
+func (l *RedisLocker) Unlock() {
+	_, _ = l.client.Do("EVAL", redisUnlockScript, 1, l.key, l.token)
+}
@@ -0,0 +1,158 @@
+// Package mail sends the plain text emails of the server through an SMTP
+// server.
+package mail
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ConnectionSecurityNone sends the emails in clear text.
+	ConnectionSecurityNone = ""
+	// ConnectionSecurityTLS connects to the SMTP server over TLS.
+	ConnectionSecurityTLS = "TLS"
+	// ConnectionSecuritySTARTTLS upgrades the connection to TLS with the
+	// STARTTLS command.
+	ConnectionSecuritySTARTTLS = "STARTTLS"
+
+	defaultPort    = 25
+	defaultTimeout = 10 * time.Second
+)
+
+// ErrNotConfigured is returned when sending an email without an SMTP
+// server.
+var ErrNotConfigured = errors.New("mail: no SMTP server configured")
+
+// Settings are the settings of the SMTP server.
+type Settings struct {
+	Server             string
+	Port               int
+	Username           string
+	Password           string
+	ConnectionSecurity string
+	FromAddress        string
+	// Timeout bounds the delivery of each email, defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+// Service sends emails through the SMTP server of its settings.
+type Service struct {
+	settings Settings
+}
+
+// New returns a service sending emails with the settings.
+func New(settings Settings) *Service {
+	if settings.Port <= 0 {
+		settings.Port = defaultPort
+	}
+	if settings.Timeout <= 0 {
+		settings.Timeout = defaultTimeout
+	}
+	return &Service{settings: settings}
+}
+
+// IsConfigured returns true if the service has a server to send the emails
+// through.
+func (s *Service) IsConfigured() bool {
+	return s != nil && s.settings.Server != "" && s.settings.FromAddress != ""
+}
+
+// Send sends a plain text email to the address.
+func (s *Service) Send(to, subject, body string) error {
+	if !s.IsConfigured() {
+		return ErrNotConfigured
+	}
+	if strings.ContainsAny(to, "\r\n") || strings.ContainsAny(subject, "\r\n") {
+		return errors.New("mail: line break in a header")
+	}
+
+	client, err := s.dial()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if s.settings.Username != "" {
+		auth := smtp.PlainAuth("", s.settings.Username, s.settings.Password, s.settings.Server)
+		if err = client.Auth(auth); err != nil {
+			return fmt.Errorf("mail: authentication failed: %w", err)
+		}
+	}
+
+	if err = client.Mail(s.settings.FromAddress); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(s.message(to, subject, body)); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (s *Service) dial() (*smtp.Client, error) {
+	address := net.JoinHostPort(s.settings.Server, strconv.Itoa(s.settings.Port))
+	tlsConfig := &tls.Config{ServerName: s.settings.Server, MinVersion: tls.VersionTLS12}
+	dialer := &net.Dialer{Timeout: s.settings.Timeout}
+
+	var conn net.Conn
+	var err error
+	if s.settings.ConnectionSecurity == ConnectionSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.SetDeadline(time.Now().Add(s.settings.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, s.settings.Server)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if s.settings.ConnectionSecurity == ConnectionSecuritySTARTTLS {
+		if err = client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func (s *Service) message(to, subject, body string) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", s.settings.FromAddress)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/mail/mailtest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSend(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		service := New(Settings{})
+		require.False(t, service.IsConfigured())
+		require.ErrorIs(t, service.Send("user@example.com", "subject", "body"), ErrNotConfigured)
+	})
+
+	t.Run("sends the email", func(t *testing.T) {
+		server := mailtest.NewServer(t)
+		service := New(Settings{
+			Server:      server.Host(),
+			Port:        server.Port(),
+			FromAddress: "boards@example.com",
+		})
+		require.True(t, service.IsConfigured())
+
+		require.NoError(t, service.Send("user@example.com", "Reset your password", "Follow the link\n"))
+
+		messages := server.WaitForMessages(1, 5*time.Second)
+		require.Len(t, messages, 1)
+		require.Equal(t, "boards@example.com", messages[0].From)
+		require.Equal(t, []string{"user@example.com"}, messages[0].To)
+		require.Contains(t, messages[0].Data, "Subject: Reset your password\n")
+		require.Contains(t, messages[0].Data, "Follow the link\n")
+	})
+
+	t.Run("rejects line breaks in the headers", func(t *testing.T) {
+		server := mailtest.NewServer(t)
+		service := New(Settings{
+			Server:      server.Host(),
+			Port:        server.Port(),
+			FromAddress: "boards@example.com",
+		})
+
+		require.Error(t, service.Send("user@example.com\r\nBcc: other@example.com", "subject", "body"))
+		require.Error(t, service.Send("user@example.com", "subject\r\nBcc: other@example.com", "body"))
+		require.Empty(t, server.Messages())
+	})
+}
@@ -0,0 +1,163 @@
+// Package mailtest provides an SMTP server recording the emails it
+// receives, for the tests of the code sending emails.
+package mailtest
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Message is an email received by the server.
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Server is a clear text SMTP server without authentication, listening on
+// a random local port.
+type Server struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []Message
+	received chan struct{}
+}
+
+// NewServer starts a server, which is closed at the end of the test.
+func NewServer(t *testing.T) *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("mailtest: cannot listen: %v", err)
+	}
+
+	s := &Server{
+		listener: listener,
+		received: make(chan struct{}, 100),
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+// Host returns the address the server listens on.
+func (s *Server) Host() string {
+	return s.listener.Addr().(*net.TCPAddr).IP.String()
+}
+
+// Port returns the port the server listens on.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// Messages returns the emails received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+// WaitForMessages waits until the server received count emails or the
+// timeout expires, and returns the emails received.
+func (s *Server) WaitForMessages(count int, timeout time.Duration) []Message {
+	deadline := time.After(timeout)
+	for {
+		messages := s.Messages()
+		if len(messages) >= count {
+			return messages
+		}
+		select {
+		case <-s.received:
+		case <-deadline:
+			return messages
+		}
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	text := textproto.NewConn(conn)
+
+	reply := func(format string, args ...interface{}) bool {
+		return text.PrintfLine(format, args...) == nil
+	}
+
+	if !reply("220 mailtest ready") {
+		return
+	}
+
+	var message Message
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg := line, ""
+		if i := strings.Index(line, " "); i >= 0 {
+			verb, arg = line[:i], line[i+1:]
+		}
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250 mailtest")
+		case "MAIL":
+			message = Message{From: addressOf(arg)}
+			reply("250 OK")
+		case "RCPT":
+			message.To = append(message.To, addressOf(arg))
+			reply("250 OK")
+		case "DATA":
+			if !reply("354 send the message") {
+				return
+			}
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			message.Data = string(data)
+			s.record(message)
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 %s not implemented", verb)
+		}
+	}
+}
+
+func (s *Server) record(message Message) {
+	s.mu.Lock()
+	s.messages = append(s.messages, message)
+	s.mu.Unlock()
+
+	select {
+	case s.received <- struct{}{}:
+	default:
+	}
+}
+
+// addressOf returns the address of a "FROM:<address>" or "TO:<address>"
+// argument.
+func addressOf(arg string) string {
+	start := strings.Index(arg, "<")
+	end := strings.LastIndex(arg, ">")
+	if start < 0 || end < start {
+		return arg
+	}
+	return arg[start+1 : end]
+}
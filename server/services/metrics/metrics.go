@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"database/sql"
 	"os"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,7 +26,8 @@ type InstanceInfo struct {
 
 // Metrics used to instrumentate metrics in prometheus.
 type Metrics struct {
-	registry *prometheus.Registry
+	registry         *prometheus.Registry
+	additionalLabels map[string]string
 
 	instance  *prometheus.GaugeVec
 	startTime prometheus.Gauge
@@ -59,6 +61,7 @@ func NewMetrics(info InstanceInfo) *Metrics {
 	if info.InstallationID != "" {
 		additionalLabels[MetricsCloudInstallationLabel] = os.Getenv("MM_CLOUD_INSTALLATION_ID")
 	}
+	m.additionalLabels = additionalLabels
 
 	m.loginCount = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   MetricsNamespace,
@@ -214,3 +217,11 @@ func (m *Metrics) ObserveTeamCount(count int64) {
 		m.teamCount.Set(float64(count))
 	}
 }
+
+// RegisterDBStatsCollector exports the connection pool statistics
+// returned by the stats function on every scrape.
+func (m *Metrics) RegisterDBStatsCollector(dbType string, stats func() sql.DBStats) {
+	if m != nil {
+		m.registry.MustRegister(newDBStatsCollector(dbType, stats, m.additionalLabels))
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyassignments
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyAssignments"
+)
+
+type BackendParams struct {
+	AppAPI      AppAPI
+	Permissions permissions.PermissionsService
+	Delivery    AssignmentDelivery
+	Logger      *mlog.Logger
+}
+
+// Backend provides the notification backend for changes of the assignee
+// properties of cards.
+type Backend struct {
+	appAPI      AppAPI
+	permissions permissions.PermissionsService
+	delivery    AssignmentDelivery
+	logger      *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:      params.AppAPI,
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	if evt.Board == nil || evt.BlockChanged == nil || evt.ModifiedBy == nil {
+		return nil
+	}
+
+	if evt.Action == notify.Delete {
+		return nil
+	}
+
+	if evt.BlockChanged.Type != model.TypeCard || model.IsCardTemplate(evt.BlockChanged) {
+		return nil
+	}
+
+	properties, err := model.CardPropertiesFromBoard(evt.Board)
+	if err != nil {
+		return fmt.Errorf("cannot read card properties of board %s: %w", evt.Board.ID, err)
+	}
+
+	changes := model.ChangedCardAssignments(properties, evt.BlockOld, evt.BlockChanged)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	notifyUnassigned := model.NotifiesUnassigned(evt.Board)
+	merr := merror.New()
+	for _, change := range changes {
+		if change.AssignedID != "" {
+			if err := b.deliverAssignmentNotification(change.AssignedID, true, change.Property.Name, evt); err != nil {
+				merr.Append(fmt.Errorf("cannot deliver assignment notification to %s: %w", change.AssignedID, err))
+			}
+			if err := b.deliverAssignmentWarning(change.AssignedID, change.Property.Name, evt); err != nil {
+				merr.Append(fmt.Errorf("cannot deliver assignment warning about %s: %w", change.AssignedID, err))
+			}
+		}
+		if change.UnassignedID != "" && notifyUnassigned {
+			if err := b.deliverAssignmentNotification(change.UnassignedID, false, change.Property.Name, evt); err != nil {
+				merr.Append(fmt.Errorf("cannot deliver unassignment notification to %s: %w", change.UnassignedID, err))
+			}
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+func (b *Backend) deliverAssignmentNotification(userID string, assigned bool, propertyName string, evt notify.BlockChangeEvent) error {
+	// don't notify users of their own changes.
+	if userID == evt.ModifiedBy.UserID {
+		return nil
+	}
+
+	if !b.permissions.HasPermissionToBoard(userID, evt.Board.ID, model.PermissionViewBoard) {
+		b.logger.Debug("Not notifying non-board member of card assignment",
+			mlog.String("user_id", userID),
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return nil
+	}
+
+	if !notify.ShouldNotify(b.appAPI, b.logger, userID, evt.Board.ID, false) {
+		b.logger.Debug("Assignment notification skipped by user notification settings",
+			mlog.String("user_id", userID),
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return nil
+	}
+
+	return b.delivery.AssignmentDeliver(userID, assigned, propertyName, evt)
+}
+
+// deliverAssignmentWarning warns the author of an assignment when the
+// assigned user is out of office or has a custom status telling they are
+// away, so that work doesn't silently pile up on absent users.
+func (b *Backend) deliverAssignmentWarning(userID string, propertyName string, evt notify.BlockChangeEvent) error {
+	if userID == evt.ModifiedBy.UserID {
+		return nil
+	}
+
+	availability, err := b.appAPI.GetUserAvailability(userID)
+	if err != nil {
+		b.logger.Warn("Cannot get availability of assigned user",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return nil
+	}
+	if !availability.IsAway() {
+		return nil
+	}
+
+	return b.delivery.AssignmentWarningDeliver(evt.ModifiedBy.UserID, availability, propertyName, evt)
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyassignments
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// AssignmentDelivery provides an interface for delivering card assignment notifications to other
+// systems, such as channels server via plugin API.
+type AssignmentDelivery interface {
+	AssignmentDeliver(userID string, assigned bool, propertyName string, evt notify.BlockChangeEvent) error
+	AssignmentWarningDeliver(userID string, availability *model.UserAvailability, propertyName string, evt notify.BlockChangeEvent) error
+}
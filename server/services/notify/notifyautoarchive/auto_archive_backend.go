@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautoarchive
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyAutoArchive"
+)
+
+type BackendParams struct {
+	Delivery AutoArchiveDelivery
+	Logger   *mlog.Logger
+}
+
+// Backend provides the notification backend that posts the cards archived
+// by the auto-archive rule of a board to the channel linked to the board.
+type Backend struct {
+	delivery AutoArchiveDelivery
+	logger   *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		delivery: params.Delivery,
+		logger:   params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged satisfies the `notify.Backend` interface; auto-archives are
+// not caused by block changes.
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	return nil
+}
+
+// CardsAutoArchived satisfies the `notify.AutoArchiveBackend` interface and
+// posts the archived cards to the channel linked to the board.
+func (b *Backend) CardsAutoArchived(evt notify.CardsAutoArchivedEvent) error {
+	if evt.Board.ChannelID == "" {
+		b.logger.Debug("Not reporting auto-archived cards of a board without a channel",
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return nil
+	}
+	if len(evt.Cards) == 0 {
+		return nil
+	}
+
+	if err := b.delivery.AutoArchiveDeliver(evt); err != nil {
+		return fmt.Errorf("cannot deliver auto-archive report to channel %s: %w", evt.Board.ChannelID, err)
+	}
+	return nil
+}
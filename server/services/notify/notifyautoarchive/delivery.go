@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyautoarchive
+
+import (
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// AutoArchiveDelivery provides an interface for delivering the reports of
+// auto-archived cards to other systems, such as channels server via plugin API.
+type AutoArchiveDelivery interface {
+	AutoArchiveDeliver(evt notify.CardsAutoArchivedEvent) error
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifydormant
+
+import (
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// DormantBoardDelivery provides an interface for delivering dormant board notifications to other
+// systems, such as channels server via plugin API.
+type DormantBoardDelivery interface {
+	DormantBoardDeliver(userID string, evt notify.BoardDormantEvent) error
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifydormant
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyDormant"
+)
+
+type BackendParams struct {
+	AppAPI      AppAPI
+	Permissions permissions.PermissionsService
+	Delivery    DormantBoardDelivery
+	Logger      *mlog.Logger
+}
+
+// Backend provides the notification backend that suggests the admins of
+// dormant boards to archive or export them.
+type Backend struct {
+	appAPI      AppAPI
+	permissions permissions.PermissionsService
+	delivery    DormantBoardDelivery
+	logger      *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:      params.AppAPI,
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged satisfies the `notify.Backend` interface; boards become
+// dormant for the lack of block changes.
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	return nil
+}
+
+// BoardDormant satisfies the `notify.DormantBoardBackend` interface and
+// notifies the admins of a dormant board.
+func (b *Backend) BoardDormant(evt notify.BoardDormantEvent) error {
+	merr := merror.New()
+	for _, userID := range evt.AdminIDs {
+		if !b.permissions.HasPermissionToBoard(userID, evt.Board.ID, model.PermissionDeleteBoard) {
+			b.logger.Debug("Not notifying non-admin of dormant board",
+				mlog.String("user_id", userID),
+				mlog.String("board_id", evt.Board.ID),
+			)
+			continue
+		}
+
+		if !notify.ShouldNotify(b.appAPI, b.logger, userID, evt.Board.ID, false) {
+			b.logger.Debug("Dormant board notification skipped by user notification settings",
+				mlog.String("user_id", userID),
+				mlog.String("board_id", evt.Board.ID),
+			)
+			continue
+		}
+
+		if err := b.delivery.DormantBoardDeliver(userID, evt); err != nil {
+			merr.Append(fmt.Errorf("cannot deliver dormant board notification to %s: %w", userID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
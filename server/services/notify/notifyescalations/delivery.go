@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyescalations
+
+import (
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// EscalationDelivery provides an interface for delivering card escalation notifications to other
+// systems, such as channels server via plugin API.
+type EscalationDelivery interface {
+	EscalationDeliver(userID string, evt notify.CardEscalationEvent) error
+}
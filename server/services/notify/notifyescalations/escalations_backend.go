@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyescalations
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyEscalations"
+)
+
+type BackendParams struct {
+	AppAPI      AppAPI
+	Permissions permissions.PermissionsService
+	Delivery    EscalationDelivery
+	Logger      *mlog.Logger
+}
+
+// Backend provides the notification backend for the cards escalated by
+// the escalation policies of their boards.
+type Backend struct {
+	appAPI      AppAPI
+	permissions permissions.PermissionsService
+	delivery    EscalationDelivery
+	logger      *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		appAPI:      params.AppAPI,
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged satisfies the `notify.Backend` interface; escalations are
+// not caused by block changes.
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	return nil
+}
+
+// CardEscalated satisfies the `notify.EscalationBackend` interface and
+// notifies the users of an escalated card.
+func (b *Backend) CardEscalated(evt notify.CardEscalationEvent) error {
+	merr := merror.New()
+	for _, userID := range evt.UserIDs {
+		if !b.permissions.HasPermissionToBoard(userID, evt.Board.ID, model.PermissionViewBoard) {
+			b.logger.Debug("Not notifying non-board member of card escalation",
+				mlog.String("user_id", userID),
+				mlog.String("board_id", evt.Board.ID),
+			)
+			continue
+		}
+
+		if !notify.ShouldNotify(b.appAPI, b.logger, userID, evt.Board.ID, false) {
+			b.logger.Debug("Escalation notification skipped by user notification settings",
+				mlog.String("user_id", userID),
+				mlog.String("board_id", evt.Board.ID),
+			)
+			continue
+		}
+
+		if err := b.delivery.EscalationDeliver(userID, evt); err != nil {
+			merr.Append(fmt.Errorf("cannot deliver escalation notification to %s: %w", userID, err))
+		}
+	}
+	return merr.ErrorOrNil()
+}
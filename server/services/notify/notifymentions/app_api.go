@@ -7,4 +7,9 @@ import "github.com/mattermost/focalboard/server/model"
 type AppAPI interface {
 	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
 	AddMemberToBoard(member *model.BoardMember) (*model.BoardMember, error)
+	GetUserByID(userID string) (*model.User, error)
+
+	CreateMention(mention *model.Mention) (*model.Mention, error)
+	GetMentionsToRemind(createdBefore int64) ([]*model.Mention, error)
+	MarkMentionsReminded(mentionIDs []string) error
 }
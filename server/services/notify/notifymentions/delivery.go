@@ -4,6 +4,7 @@
 package notifymentions
 
 import (
+	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
 
 	mm_model "github.com/mattermost/mattermost-server/v6/model"
@@ -15,4 +16,5 @@ import (
 type MentionDelivery interface {
 	MentionDeliver(mentionedUser *mm_model.User, extract string, evt notify.BlockChangeEvent) (string, error)
 	UserByUsername(mentionUsername string) (*mm_model.User, error)
+	MentionReminderDeliver(userID string, mentions []*model.Mention) error
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/services/notify"
@@ -33,6 +34,10 @@ type BackendParams struct {
 	Permissions permissions.PermissionsService
 	Delivery    MentionDelivery
 	Logger      *mlog.Logger
+
+	// ReminderAge is how long a mention stays unread before the user is
+	// reminded of it. Zero disables the reminders.
+	ReminderAge time.Duration
 }
 
 // Backend provides the notification backend for @mentions.
@@ -41,9 +46,12 @@ type Backend struct {
 	permissions permissions.PermissionsService
 	delivery    MentionDelivery
 	logger      *mlog.Logger
+	reminderAge time.Duration
 
 	mux       sync.RWMutex
 	listeners []MentionListener
+
+	done chan struct{}
 }
 
 func New(params BackendParams) *Backend {
@@ -52,14 +60,20 @@ func New(params BackendParams) *Backend {
 		permissions: params.Permissions,
 		delivery:    params.Delivery,
 		logger:      params.Logger,
+		reminderAge: params.ReminderAge,
+		done:        make(chan struct{}),
 	}
 }
 
 func (b *Backend) Start() error {
+	if b.reminderAge > 0 {
+		go b.remindLoop()
+	}
 	return nil
 }
 
 func (b *Backend) ShutDown() error {
+	close(b.done)
 	_ = b.logger.Flush()
 	return nil
 }
@@ -138,6 +152,8 @@ func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
 			continue
 		}
 
+		b.recordMention(userID, extract, evt)
+
 		b.logger.Debug("Mention notification delivered",
 			mlog.String("user", username),
 			mlog.Int("listener_count", len(listeners)),
@@ -232,5 +248,13 @@ func (b *Backend) deliverMentionNotification(username string, extract string, ev
 		}
 	}
 
+	if !notify.ShouldNotify(b.appAPI, b.logger, mentionedUser.Id, evt.Board.ID, true) {
+		b.logger.Debug("Mention notification skipped by user notification settings",
+			mlog.String("user_id", mentionedUser.Id),
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return mentionedUser.Id, nil
+	}
+
 	return b.delivery.MentionDeliver(mentionedUser, extract, evt)
 }
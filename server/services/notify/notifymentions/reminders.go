@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifymentions
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	remindFreq = time.Minute * 15
+)
+
+// recordMention stores the mention as unread, so that it can be listed
+// and reminded until the user reads it.
+func (b *Backend) recordMention(userID string, extract string, evt notify.BlockChangeEvent) {
+	mention := &model.Mention{
+		UserID:      userID,
+		TeamID:      evt.TeamID,
+		BoardID:     evt.Board.ID,
+		CardID:      evt.Card.ID,
+		BlockID:     evt.BlockChanged.ID,
+		MentionedBy: evt.ModifiedBy.UserID,
+		Extract:     extract,
+	}
+	if _, err := b.appAPI.CreateMention(mention); err != nil {
+		b.logger.Error("Cannot record mention",
+			mlog.String("user_id", userID),
+			mlog.String("block_id", evt.BlockChanged.ID),
+			mlog.Err(err),
+		)
+	}
+}
+
+func (b *Backend) remindLoop() {
+	ticker := time.NewTicker(remindFreq)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			if err := b.remindUnreadMentions(time.Now()); err != nil {
+				b.logger.Error("Cannot remind unread mentions", mlog.Err(err))
+			}
+		}
+	}
+}
+
+// remindUnreadMentions sends each user a single reminder with the
+// mentions they didn't read for longer than the reminder age. Mentions
+// held back by the notification settings of the user are reminded once
+// the settings allow it.
+func (b *Backend) remindUnreadMentions(now time.Time) error {
+	createdBefore := utils.GetMillisForTime(now.Add(-b.reminderAge))
+	mentions, err := b.appAPI.GetMentionsToRemind(createdBefore)
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[string][]*model.Mention)
+	userIDs := []string{}
+	for _, mention := range mentions {
+		if _, ok := byUser[mention.UserID]; !ok {
+			userIDs = append(userIDs, mention.UserID)
+		}
+		byUser[mention.UserID] = append(byUser[mention.UserID], mention)
+	}
+
+	for _, userID := range userIDs {
+		userMentions := b.filterMentionsToRemind(userID, byUser[userID], now)
+		if len(userMentions) == 0 {
+			continue
+		}
+
+		if err := b.delivery.MentionReminderDeliver(userID, userMentions); err != nil {
+			b.logger.Warn("Cannot deliver mention reminder",
+				mlog.String("user_id", userID),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		ids := make([]string, 0, len(userMentions))
+		for _, mention := range userMentions {
+			ids = append(ids, mention.ID)
+		}
+		if err := b.appAPI.MarkMentionsReminded(ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterMentionsToRemind returns the mentions that the notification
+// settings of the user allow to remind now.
+func (b *Backend) filterMentionsToRemind(userID string, mentions []*model.Mention, now time.Time) []*model.Mention {
+	user, err := b.appAPI.GetUserByID(userID)
+	if err != nil {
+		b.logger.Warn("Cannot load notification settings of mentioned user",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return mentions
+	}
+
+	settings := model.NotificationSettingsFromUser(user)
+	filtered := make([]*model.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		if settings.ShouldNotify(mention.BoardID, true, now) {
+			filtered = append(filtered, mention)
+		}
+	}
+	return filtered
+}
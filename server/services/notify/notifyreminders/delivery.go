@@ -0,0 +1,14 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import (
+	"github.com/mattermost/focalboard/server/services/notify"
+)
+
+// CardReminderDelivery provides an interface for delivering card reminders to other systems, such as
+// channels server via plugin API.
+type CardReminderDelivery interface {
+	CardReminderDeliver(evt notify.CardReminderEvent) error
+}
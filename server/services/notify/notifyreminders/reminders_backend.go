@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notifyreminders
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/services/permissions"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	backendName = "notifyReminders"
+)
+
+type BackendParams struct {
+	Permissions permissions.PermissionsService
+	Delivery    CardReminderDelivery
+	Logger      *mlog.Logger
+}
+
+// Backend provides the notification backend that delivers the reminders
+// the users set about cards.
+type Backend struct {
+	permissions permissions.PermissionsService
+	delivery    CardReminderDelivery
+	logger      *mlog.Logger
+}
+
+func New(params BackendParams) *Backend {
+	return &Backend{
+		permissions: params.Permissions,
+		delivery:    params.Delivery,
+		logger:      params.Logger,
+	}
+}
+
+func (b *Backend) Start() error {
+	return nil
+}
+
+func (b *Backend) ShutDown() error {
+	_ = b.logger.Flush()
+	return nil
+}
+
+func (b *Backend) Name() string {
+	return backendName
+}
+
+// BlockChanged satisfies the `notify.Backend` interface; reminders are
+// due at the time the users chose, not on block changes.
+func (b *Backend) BlockChanged(evt notify.BlockChangeEvent) error {
+	return nil
+}
+
+// CardReminderDue satisfies the `notify.CardReminderBackend` interface and
+// delivers the reminder to its user. The notification settings of the
+// user don't apply, as they asked for the reminder at this time.
+func (b *Backend) CardReminderDue(evt notify.CardReminderEvent) error {
+	if !b.permissions.HasPermissionToBoard(evt.Reminder.UserID, evt.Board.ID, model.PermissionViewBoard) {
+		b.logger.Debug("Not delivering card reminder to user without access to the board",
+			mlog.String("user_id", evt.Reminder.UserID),
+			mlog.String("board_id", evt.Board.ID),
+		)
+		return nil
+	}
+
+	if err := b.delivery.CardReminderDeliver(evt); err != nil {
+		return fmt.Errorf("cannot deliver card reminder to %s: %w", evt.Reminder.UserID, err)
+	}
+	return nil
+}
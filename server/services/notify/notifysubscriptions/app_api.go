@@ -22,4 +22,7 @@ type AppAPI interface {
 
 	UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
+
+	SaveNotificationThread(thread *model.NotificationThread) error
+	GetNotificationThread(cardID, channelID string) (*model.NotificationThread, error)
 }
@@ -14,4 +14,9 @@ import (
 type SubscriptionDelivery interface {
 	SubscriptionDeliverSlackAttachments(teamID string, subscriberID string, subscriberType model.SubscriberType,
 		attachments []*mm_model.SlackAttachment) error
+
+	// SubscriptionDeliverChannelThread posts a notification to a channel as
+	// a reply to the root post, or as a new post if rootID is empty, and
+	// returns the id of the root post of the thread.
+	SubscriptionDeliverChannelThread(channelID string, rootID string, attachments []*mm_model.SlackAttachment) (string, error)
 }
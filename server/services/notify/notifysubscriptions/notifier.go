@@ -10,10 +10,12 @@ import (
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
 	"github.com/mattermost/focalboard/server/services/permissions"
 	"github.com/mattermost/focalboard/server/utils"
 	"github.com/wiggin77/merror"
 
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
@@ -236,6 +238,16 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				continue
 			}
 
+			// respect the board mute and quiet hours settings of the user.
+			if sub.SubscriberType == model.SubTypeUser && !notify.ShouldNotify(n.store, n.logger, sub.SubscriberID, board.ID, false) {
+				n.logger.Debug("notifySubscribers - skipping by notification settings",
+					mlog.Any("hint", hint),
+					mlog.String("subscriber_id", sub.SubscriberID),
+					mlog.String("board_id", board.ID),
+				)
+				continue
+			}
+
 			n.logger.Debug("notifySubscribers - deliver",
 				mlog.Any("hint", hint),
 				mlog.String("modified_by_id", hint.ModifiedByID),
@@ -243,7 +255,12 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 				mlog.String("subscriber_type", string(sub.SubscriberType)),
 			)
 
-			if err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, attachments); err != nil {
+			if sub.SubscriberType == model.SubTypeChannel {
+				err = n.deliverToChannelThread(card, sub.SubscriberID, attachments)
+			} else {
+				err = n.delivery.SubscriptionDeliverSlackAttachments(board.TeamID, sub.SubscriberID, sub.SubscriberType, attachments)
+			}
+			if err != nil {
 				merr.Append(fmt.Errorf("cannot deliver notification to subscriber %s [%s]: %w",
 					sub.SubscriberID, sub.SubscriberType, err))
 			}
@@ -276,3 +293,44 @@ func (n *notifier) notifySubscribers(hint *model.NotificationHint) error {
 
 	return merr.ErrorOrNil()
 }
+
+// deliverToChannelThread posts the notification of a card to a channel in
+// the thread of the card, starting it with this notification if the card
+// has none yet or if its root post can't be replied to anymore.
+func (n *notifier) deliverToChannelThread(card *model.Block, channelID string, attachments []*mm_model.SlackAttachment) error {
+	var rootID string
+	thread, err := n.store.GetNotificationThread(card.ID, channelID)
+	switch {
+	case model.IsErrNotFound(err):
+	case err != nil:
+		return fmt.Errorf("cannot get notification thread of card %s: %w", card.ID, err)
+	default:
+		rootID = thread.RootPostID
+	}
+
+	postRootID, err := n.delivery.SubscriptionDeliverChannelThread(channelID, rootID, attachments)
+	if err != nil && rootID != "" {
+		// the root post was likely deleted
+		n.logger.Warn("deliverToChannelThread - cannot reply to thread, starting a new one",
+			mlog.String("card_id", card.ID),
+			mlog.String("channel_id", channelID),
+			mlog.String("root_post_id", rootID),
+			mlog.Err(err),
+		)
+		rootID = ""
+		postRootID, err = n.delivery.SubscriptionDeliverChannelThread(channelID, rootID, attachments)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rootID != "" {
+		return nil
+	}
+	return n.store.SaveNotificationThread(&model.NotificationThread{
+		CardID:     card.ID,
+		ChannelID:  channelID,
+		RootPostID: postRootID,
+		CreateAt:   utils.GetMillis(),
+	})
+}
@@ -0,0 +1,96 @@
+package notifysubscriptions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/stretchr/testify/require"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+type threadsStore struct {
+	AppAPI
+	threads map[string]*model.NotificationThread
+}
+
+func (s *threadsStore) SaveNotificationThread(thread *model.NotificationThread) error {
+	s.threads[thread.CardID+thread.ChannelID] = thread
+	return nil
+}
+
+func (s *threadsStore) GetNotificationThread(cardID, channelID string) (*model.NotificationThread, error) {
+	thread, ok := s.threads[cardID+channelID]
+	if !ok {
+		return nil, model.NewErrNotFound(cardID)
+	}
+	return thread, nil
+}
+
+type threadsDelivery struct {
+	SubscriptionDelivery
+	posts   []*mm_model.Post
+	deleted map[string]bool
+}
+
+func (d *threadsDelivery) SubscriptionDeliverChannelThread(channelID string, rootID string,
+	_ []*mm_model.SlackAttachment) (string, error) {
+	if d.deleted[rootID] {
+		return "", errors.New("root post deleted")
+	}
+	post := &mm_model.Post{Id: mm_model.NewId(), ChannelId: channelID, RootId: rootID}
+	d.posts = append(d.posts, post)
+	if rootID != "" {
+		return rootID, nil
+	}
+	return post.Id, nil
+}
+
+func TestDeliverToChannelThread(t *testing.T) {
+	store := &threadsStore{threads: map[string]*model.NotificationThread{}}
+	delivery := &threadsDelivery{deleted: map[string]bool{}}
+	n := &notifier{
+		store:    store,
+		delivery: delivery,
+		logger:   mlog.CreateConsoleTestLogger(false, mlog.LvlDebug),
+	}
+	card := &model.Block{ID: "card-1"}
+
+	t.Run("the first notification starts the thread", func(t *testing.T) {
+		require.NoError(t, n.deliverToChannelThread(card, "channel-1", nil))
+		require.Len(t, delivery.posts, 1)
+		require.Empty(t, delivery.posts[0].RootId)
+
+		thread, err := store.GetNotificationThread(card.ID, "channel-1")
+		require.NoError(t, err)
+		require.Equal(t, delivery.posts[0].Id, thread.RootPostID)
+	})
+
+	t.Run("the next notifications reply to it", func(t *testing.T) {
+		require.NoError(t, n.deliverToChannelThread(card, "channel-1", nil))
+		require.NoError(t, n.deliverToChannelThread(card, "channel-1", nil))
+		require.Len(t, delivery.posts, 3)
+		require.Equal(t, delivery.posts[0].Id, delivery.posts[1].RootId)
+		require.Equal(t, delivery.posts[0].Id, delivery.posts[2].RootId)
+	})
+
+	t.Run("each channel has its thread", func(t *testing.T) {
+		require.NoError(t, n.deliverToChannelThread(card, "channel-2", nil))
+		require.Len(t, delivery.posts, 4)
+		require.Empty(t, delivery.posts[3].RootId)
+	})
+
+	t.Run("a deleted root post starts a new thread", func(t *testing.T) {
+		delivery.deleted[delivery.posts[0].Id] = true
+
+		require.NoError(t, n.deliverToChannelThread(card, "channel-1", nil))
+		require.Len(t, delivery.posts, 5)
+		require.Empty(t, delivery.posts[4].RootId)
+
+		thread, err := store.GetNotificationThread(card.ID, "channel-1")
+		require.NoError(t, err)
+		require.Equal(t, delivery.posts[4].Id, thread.RootPostID)
+	})
+}
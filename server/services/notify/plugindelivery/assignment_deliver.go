@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// AssignmentDeliver notifies a user they have been assigned to, or unassigned from, a card via the plugin API.
+func (pd *PluginDelivery) AssignmentDeliver(userID string, assigned bool, propertyName string, evt notify.BlockChangeEvent) error {
+	author, err := pd.api.GetUserByID(evt.ModifiedBy.UserID)
+	if err != nil {
+		return fmt.Errorf("cannot find user: %w", err)
+	}
+
+	channel, err := pd.getDirectChannel(evt.TeamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.BlockChanged.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatAssignmentMessage(author.Username, assigned, evt.BlockChanged.Title, link, propertyName),
+	}
+	return pd.api.CreatePost(post)
+}
+
+// AssignmentWarningDeliver warns a user that the user they assigned to a card may be away via the plugin API.
+func (pd *PluginDelivery) AssignmentWarningDeliver(userID string, availability *model.UserAvailability, propertyName string, evt notify.BlockChangeEvent) error {
+	assignee, err := pd.api.GetUserByID(availability.UserID)
+	if err != nil {
+		return fmt.Errorf("cannot find user: %w", err)
+	}
+
+	channel, err := pd.getDirectChannel(evt.TeamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.BlockChanged.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatAssignmentWarningMessage(assignee.Username, availability, evt.BlockChanged.Title, link, propertyName),
+	}
+	return pd.api.CreatePost(post)
+}
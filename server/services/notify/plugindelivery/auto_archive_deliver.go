@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"github.com/mattermost/focalboard/server/services/notify"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// AutoArchiveDeliver posts the cards archived by the auto-archive rule of a
+// board to the channel linked to the board via the plugin API.
+func (pd *PluginDelivery) AutoArchiveDeliver(evt notify.CardsAutoArchivedEvent) error {
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: evt.Board.ChannelID,
+		Message:   formatAutoArchiveMessage(pd.serverRoot, evt.Board, evt.Cards, evt.Days),
+	}
+	return pd.api.CreatePost(post)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// CardReminderDeliver sends a user the reminder they set about a card via
+// the plugin API.
+func (pd *PluginDelivery) CardReminderDeliver(evt notify.CardReminderEvent) error {
+	channel, err := pd.getDirectChannel(evt.TeamID, evt.Reminder.UserID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatCardReminderMessage(evt.Card.Title, link, evt.Reminder.Note),
+	}
+	return pd.api.CreatePost(post)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// DormantBoardDeliver suggests an admin of a dormant board to archive or
+// export it via the plugin API.
+func (pd *PluginDelivery) DormantBoardDeliver(userID string, evt notify.BoardDormantEvent) error {
+	channel, err := pd.getDirectChannel(evt.TeamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeBoardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatDormantBoardMessage(evt.Board.Title, link, evt.Days),
+	}
+	return pd.api.CreatePost(post)
+}
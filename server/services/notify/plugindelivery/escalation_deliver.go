@@ -0,0 +1,29 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/services/notify"
+	"github.com/mattermost/focalboard/server/utils"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// EscalationDeliver notifies a user that a card was escalated via the plugin API.
+func (pd *PluginDelivery) EscalationDeliver(userID string, evt notify.CardEscalationEvent) error {
+	channel, err := pd.getDirectChannel(evt.TeamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+	link := utils.MakeCardLink(pd.serverRoot, evt.Board.TeamID, evt.Board.ID, evt.Card.ID)
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatEscalationMessage(evt.Card.Title, link, evt.PolicyName, evt.Days),
+	}
+	return pd.api.CreatePost(post)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugindelivery
+
+import (
+	"fmt"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	mm_model "github.com/mattermost/mattermost-server/v6/model"
+)
+
+// MentionReminderDeliver sends a user a single direct message listing the
+// mentions they didn't read yet.
+func (pd *PluginDelivery) MentionReminderDeliver(userID string, mentions []*model.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	channel, err := pd.getDirectChannel(mentions[0].TeamID, userID, pd.botID)
+	if err != nil {
+		return fmt.Errorf("cannot get direct channel: %w", err)
+	}
+
+	usernames := map[string]string{}
+	author := func(authorID string) string {
+		if username, ok := usernames[authorID]; ok {
+			return username
+		}
+		username := authorID
+		if user, err := pd.api.GetUserByID(authorID); err == nil {
+			username = user.Username
+		}
+		usernames[authorID] = username
+		return username
+	}
+
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channel.Id,
+		Message:   formatReminderMessage(pd.serverRoot, mentions, author),
+	}
+	return pd.api.CreatePost(post)
+}
@@ -5,14 +5,28 @@ package plugindelivery
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
 )
 
 const (
 	// TODO: localize these when i18n is available.
 	defCommentTemplate     = "@%s mentioned you in a comment on the card [%s](%s)\n> %s"
 	defDescriptionTemplate = "@%s mentioned you in the card [%s](%s)\n> %s"
+	defReminderHeader      = "You have unread mentions:"
+	defAssignedTemplate    = "@%s assigned you to the card [%s](%s) as %s"
+	defUnassignedTemplate  = "@%s unassigned you from the card [%s](%s) as %s"
+	defEscalationTemplate  = "The card [%s](%s) was escalated by the policy %q after %d days without changes of status"
+	defReminderTemplate    = "\n- @%s mentioned you in [a card](%s)\n  > %s"
+	defAutoArchiveHeader   = "%d cards were archived after %d days in the done status:"
+	defAutoArchiveTemplate = "\n- [%s](%s)"
+	defDormantTemplate     = "The board [%s](%s) has had no activity for %d days. Consider archiving it, or exporting it to keep a copy."
+	defCardRemindTemplate  = "Reminder about the card [%s](%s)"
+	defCardRemindNote      = "\n> %s"
+	defAssignOOOTemplate   = "@%s is out of office and may not see the card [%s](%s) you assigned to them as %s"
+	defAssignAwayTemplate  = "@%s is away (:%s: %s) and may not see the card [%s](%s) you assigned to them as %s"
 )
 
 func formatMessage(author string, extract string, card string, link string, block *model.Block) string {
@@ -22,3 +36,57 @@ func formatMessage(author string, extract string, card string, link string, bloc
 	}
 	return fmt.Sprintf(template, author, card, link, extract)
 }
+
+// mentionAuthorFunc returns the username of the author of a mention.
+type mentionAuthorFunc func(userID string) string
+
+func formatReminderMessage(serverRoot string, mentions []*model.Mention, author mentionAuthorFunc) string {
+	var sb strings.Builder
+	sb.WriteString(defReminderHeader)
+	for _, mention := range mentions {
+		link := utils.MakeCardLink(serverRoot, mention.TeamID, mention.BoardID, mention.CardID)
+		sb.WriteString(fmt.Sprintf(defReminderTemplate, author(mention.MentionedBy), link, mention.Extract))
+	}
+	return sb.String()
+}
+
+func formatAssignmentMessage(author string, assigned bool, card string, link string, propertyName string) string {
+	template := defUnassignedTemplate
+	if assigned {
+		template = defAssignedTemplate
+	}
+	return fmt.Sprintf(template, author, card, link, propertyName)
+}
+
+func formatAssignmentWarningMessage(assignee string, availability *model.UserAvailability, card string, link string, propertyName string) string {
+	if availability.OutOfOffice {
+		return fmt.Sprintf(defAssignOOOTemplate, assignee, card, link, propertyName)
+	}
+	return fmt.Sprintf(defAssignAwayTemplate, assignee, availability.StatusEmoji, availability.StatusText, card, link, propertyName)
+}
+
+func formatEscalationMessage(card string, link string, policy string, days int) string {
+	return fmt.Sprintf(defEscalationTemplate, card, link, policy, days)
+}
+
+func formatAutoArchiveMessage(serverRoot string, board *model.Board, cards []*model.Block, days int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(defAutoArchiveHeader, len(cards), days))
+	for _, card := range cards {
+		link := utils.MakeCardLink(serverRoot, board.TeamID, board.ID, card.ID)
+		sb.WriteString(fmt.Sprintf(defAutoArchiveTemplate, card.Title, link))
+	}
+	return sb.String()
+}
+
+func formatDormantBoardMessage(board string, link string, days int) string {
+	return fmt.Sprintf(defDormantTemplate, board, link, days)
+}
+
+func formatCardReminderMessage(card string, link string, note string) string {
+	message := fmt.Sprintf(defCardRemindTemplate, card, link)
+	if note != "" {
+		message += fmt.Sprintf(defCardRemindNote, note)
+	}
+	return message
+}
@@ -12,7 +12,7 @@ type PluginAPI interface {
 	// If the channel does not exist it will create it.
 	GetDirectChannel(userID1, userID2 string) (*mm_model.Channel, error)
 
-	// CreatePost creates a post.
+	// CreatePost creates a post, setting its id.
 	CreatePost(post *mm_model.Post) error
 
 	// GetUserByID gets a user by their ID.
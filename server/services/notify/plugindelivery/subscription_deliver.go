@@ -44,6 +44,27 @@ func (pd *PluginDelivery) SubscriptionDeliverSlackAttachments(teamID string, sub
 	return pd.api.CreatePost(post)
 }
 
+// SubscriptionDeliverChannelThread posts a notification to a channel as a reply to
+// the root post, or as the root post of a new thread if rootID is empty.
+func (pd *PluginDelivery) SubscriptionDeliverChannelThread(channelID string, rootID string,
+	attachments []*mm_model.SlackAttachment) (string, error) {
+	post := &mm_model.Post{
+		UserId:    pd.botID,
+		ChannelId: channelID,
+		RootId:    rootID,
+	}
+
+	mm_model.ParseSlackAttachment(post, attachments)
+
+	if err := pd.api.CreatePost(post); err != nil {
+		return "", err
+	}
+	if rootID != "" {
+		return rootID, nil
+	}
+	return post.Id, nil
+}
+
 func (pd *PluginDelivery) getDirectChannelID(teamID string, subscriberID string, subscriberType model.SubscriberType, botID string) (string, error) {
 	switch subscriberType {
 	case model.SubTypeUser:
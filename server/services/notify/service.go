@@ -38,6 +38,69 @@ type Backend interface {
 	Name() string
 }
 
+// CardEscalationEvent describes a card escalated by an escalation policy
+// of its board.
+type CardEscalationEvent struct {
+	TeamID     string
+	Board      *model.Board
+	Card       *model.Block
+	PolicyName string
+	Days       int
+	UserIDs    []string
+}
+
+// EscalationBackend is implemented by the backends that deliver the
+// notifications of escalated cards.
+type EscalationBackend interface {
+	CardEscalated(evt CardEscalationEvent) error
+}
+
+// CardsAutoArchivedEvent describes the cards of a board archived by the
+// auto-archive rule of the board.
+type CardsAutoArchivedEvent struct {
+	TeamID string
+	Board  *model.Board
+	Cards  []*model.Block
+	Days   int
+}
+
+// AutoArchiveBackend is implemented by the backends that report the cards
+// archived by the auto-archive rules.
+type AutoArchiveBackend interface {
+	CardsAutoArchived(evt CardsAutoArchivedEvent) error
+}
+
+// BoardDormantEvent describes a board that just reached the number of days
+// without activity after which it is dormant.
+type BoardDormantEvent struct {
+	TeamID         string
+	Board          *model.Board
+	LastActivityAt int64
+	Days           int
+	AdminIDs       []string
+}
+
+// DormantBoardBackend is implemented by the backends that suggest the
+// admins of dormant boards to archive or export them.
+type DormantBoardBackend interface {
+	BoardDormant(evt BoardDormantEvent) error
+}
+
+// CardReminderEvent describes a reminder that a user set about a card and
+// that is due.
+type CardReminderEvent struct {
+	TeamID   string
+	Board    *model.Board
+	Card     *model.Block
+	Reminder *model.CardReminder
+}
+
+// CardReminderBackend is implemented by the backends that deliver the
+// reminders the users set about cards.
+type CardReminderBackend interface {
+	CardReminderDue(evt CardReminderEvent) error
+}
+
 // Service is a service that sends notifications based on block activity using one or more backends.
 type Service struct {
 	mux      sync.RWMutex
@@ -106,3 +169,89 @@ func (s *Service) BlockChanged(evt BlockChangeEvent) {
 		}
 	}
 }
+
+// CardEscalated should be called whenever an escalation policy escalates
+// a card. The backends that deliver escalations are informed of the event.
+func (s *Service) CardEscalated(evt CardEscalationEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		escalationBackend, ok := backend.(EscalationBackend)
+		if !ok {
+			continue
+		}
+		if err := escalationBackend.CardEscalated(evt); err != nil {
+			s.logger.Error("Error delivering escalation notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("card_id", evt.Card.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// CardsAutoArchived should be called whenever the auto-archive rule of a
+// board archives cards. The backends that report archived cards are
+// informed of the event.
+func (s *Service) CardsAutoArchived(evt CardsAutoArchivedEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		autoArchiveBackend, ok := backend.(AutoArchiveBackend)
+		if !ok {
+			continue
+		}
+		if err := autoArchiveBackend.CardsAutoArchived(evt); err != nil {
+			s.logger.Error("Error delivering auto-archive report",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// BoardDormant should be called whenever a board becomes dormant. The
+// backends that notify the admins of dormant boards are informed of the
+// event.
+func (s *Service) BoardDormant(evt BoardDormantEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		dormantBackend, ok := backend.(DormantBoardBackend)
+		if !ok {
+			continue
+		}
+		if err := dormantBackend.BoardDormant(evt); err != nil {
+			s.logger.Error("Error delivering dormant board notification",
+				mlog.String("backend", backend.Name()),
+				mlog.String("board_id", evt.Board.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
+
+// CardReminderDue should be called whenever a reminder about a card is
+// due. The backends that deliver card reminders are informed of the event.
+func (s *Service) CardReminderDue(evt CardReminderEvent) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	for _, backend := range s.backends {
+		reminderBackend, ok := backend.(CardReminderBackend)
+		if !ok {
+			continue
+		}
+		if err := reminderBackend.CardReminderDue(evt); err != nil {
+			s.logger.Error("Error delivering card reminder",
+				mlog.String("backend", backend.Name()),
+				mlog.String("reminder_id", evt.Reminder.ID),
+				mlog.Err(err),
+			)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package notify
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// UserGetter loads the users whose notification settings are checked.
+type UserGetter interface {
+	GetUserByID(userID string) (*model.User, error)
+}
+
+// ShouldNotify checks the board mute and quiet hours settings of a user
+// for a notification about the board. Mentions are delivered during quiet
+// hours when the user allows it. Users whose settings can't be loaded are
+// notified.
+func ShouldNotify(users UserGetter, logger *mlog.Logger, userID, boardID string, mention bool) bool {
+	user, err := users.GetUserByID(userID)
+	if err != nil {
+		logger.Warn("Cannot load notification settings of user",
+			mlog.String("user_id", userID),
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return true
+	}
+	return model.NotificationSettingsFromUser(user).ShouldNotify(boardID, mention, time.Now())
+}
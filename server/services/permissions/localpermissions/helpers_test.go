@@ -30,7 +30,7 @@ func SetupTestHelper(t *testing.T) *TestHelper {
 		t:           t,
 		ctrl:        ctrl,
 		store:       mockStore,
-		permissions: New(mockStore, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)),
+		permissions: New(mockStore, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug), []string{"admin"}),
 	}
 }
 
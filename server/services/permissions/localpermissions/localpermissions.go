@@ -12,14 +12,22 @@ import (
 )
 
 type Service struct {
-	store  permissions.Store
-	logger *mlog.Logger
+	store      permissions.Store
+	logger     *mlog.Logger
+	adminUsers map[string]bool
 }
 
-func New(store permissions.Store, logger *mlog.Logger) *Service {
+// New returns the permissions of a standalone server. The users whose
+// username is in adminUsers administer its teams.
+func New(store permissions.Store, logger *mlog.Logger, adminUsers []string) *Service {
+	admins := make(map[string]bool, len(adminUsers))
+	for _, username := range adminUsers {
+		admins[username] = true
+	}
 	return &Service{
-		store:  store,
-		logger: logger,
+		store:      store,
+		logger:     logger,
+		adminUsers: admins,
 	}
 }
 
@@ -27,9 +35,36 @@ func (s *Service) HasPermissionToTeam(userID, teamID string, permission *mmModel
 	if userID == "" || teamID == "" || permission == nil {
 		return false
 	}
+	if permission == model.PermissionManageTeam {
+		return s.isAdmin(userID)
+	}
 	return true
 }
 
+// isAdmin returns true for the single user and the users configured as
+// admins of the server.
+func (s *Service) isAdmin(userID string) bool {
+	if userID == model.SingleUser {
+		return true
+	}
+	if len(s.adminUsers) == 0 {
+		return false
+	}
+
+	user, err := s.store.GetUserByID(userID)
+	if model.IsErrNotFound(err) {
+		return false
+	}
+	if err != nil {
+		s.logger.Error("error getting user to check admin permission",
+			mlog.String("userID", userID),
+			mlog.Err(err),
+		)
+		return false
+	}
+	return user.DeleteAt == 0 && s.adminUsers[user.Username]
+}
+
 func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmModel.Permission) bool {
 	if userID == "" || boardID == "" || permission == nil {
 		return false
@@ -60,10 +95,13 @@ func (s *Service) HasPermissionToBoard(userID, boardID string, permission *mmMod
 	}
 
 	switch permission {
-	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard:
+	case model.PermissionManageBoardType, model.PermissionDeleteBoard, model.PermissionManageBoardRoles, model.PermissionShareBoard,
+		model.PermissionEditLockedProperties, model.PermissionManageRestrictedViews:
 		return member.SchemeAdmin
 	case model.PermissionManageBoardCards, model.PermissionManageBoardProperties:
 		return member.SchemeAdmin || member.SchemeEditor
+	case model.PermissionCommentBoardCards:
+		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter
 	case model.PermissionViewBoard:
 		return member.SchemeAdmin || member.SchemeEditor || member.SchemeCommenter || member.SchemeViewer
 	default:
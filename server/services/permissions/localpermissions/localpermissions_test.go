@@ -10,6 +10,7 @@ import (
 	"github.com/mattermost/focalboard/server/model"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -27,6 +28,41 @@ func TestHasPermissionToTeam(t *testing.T) {
 		hasPermission := th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageBoardCards)
 		assert.True(t, hasPermission)
 	})
+
+	t.Run("only the admin users manage teams", func(t *testing.T) {
+		th.store.EXPECT().
+			GetUserByID("admin-id").
+			Return(&model.User{ID: "admin-id", Username: "admin"}, nil).
+			Times(1)
+		assert.True(t, th.permissions.HasPermissionToTeam("admin-id", "team-id", model.PermissionManageTeam))
+
+		th.store.EXPECT().
+			GetUserByID("user-id").
+			Return(&model.User{ID: "user-id", Username: "user"}, nil).
+			Times(1)
+		assert.False(t, th.permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageTeam))
+
+		th.store.EXPECT().
+			GetUserByID("deleted-id").
+			Return(&model.User{ID: "deleted-id", Username: "admin", DeleteAt: 1}, nil).
+			Times(1)
+		assert.False(t, th.permissions.HasPermissionToTeam("deleted-id", "team-id", model.PermissionManageTeam))
+
+		th.store.EXPECT().
+			GetUserByID("unknown-id").
+			Return(nil, model.NewErrNotFound("user")).
+			Times(1)
+		assert.False(t, th.permissions.HasPermissionToTeam("unknown-id", "team-id", model.PermissionManageTeam))
+	})
+
+	t.Run("the single user manages teams", func(t *testing.T) {
+		assert.True(t, th.permissions.HasPermissionToTeam(model.SingleUser, "team-id", model.PermissionManageTeam))
+	})
+
+	t.Run("no user manages teams without admin users", func(t *testing.T) {
+		permissions := New(th.store, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug), nil)
+		assert.False(t, permissions.HasPermissionToTeam("user-id", "team-id", model.PermissionManageTeam))
+	})
 }
 
 func TestHasPermissionToBoard(t *testing.T) {
@@ -65,7 +101,10 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
 			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
 			model.PermissionManageBoardProperties,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		hasNotPermissionTo := []*mmModel.Permission{}
@@ -83,6 +122,7 @@ func TestHasPermissionToBoard(t *testing.T) {
 		hasPermissionTo := []*mmModel.Permission{
 			model.PermissionManageBoardCards,
 			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
@@ -91,6 +131,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionDeleteBoard,
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		th.checkBoardPermissions("editor", member, hasPermissionTo, hasNotPermissionTo)
@@ -105,6 +147,7 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		hasPermissionTo := []*mmModel.Permission{
 			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
 		}
 
 		hasNotPermissionTo := []*mmModel.Permission{
@@ -114,6 +157,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
 			model.PermissionManageBoardProperties,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		th.checkBoardPermissions("commenter", member, hasPermissionTo, hasNotPermissionTo)
@@ -136,7 +181,10 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionCommentBoardCards,
 			model.PermissionManageBoardProperties,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		th.checkBoardPermissions("viewer", member, hasPermissionTo, hasNotPermissionTo)
@@ -143,7 +143,10 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
 			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
 			model.PermissionManageBoardProperties,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		hasNotPermissionTo := []*mmModel.Permission{}
@@ -161,6 +164,7 @@ func TestHasPermissionToBoard(t *testing.T) {
 		hasPermissionTo := []*mmModel.Permission{
 			model.PermissionManageBoardCards,
 			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
 			model.PermissionManageBoardProperties,
 		}
 
@@ -169,6 +173,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionDeleteBoard,
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		th.checkBoardPermissions("editor", member, teamID, hasPermissionTo, hasNotPermissionTo)
@@ -183,6 +189,7 @@ func TestHasPermissionToBoard(t *testing.T) {
 
 		hasPermissionTo := []*mmModel.Permission{
 			model.PermissionViewBoard,
+			model.PermissionCommentBoardCards,
 		}
 
 		hasNotPermissionTo := []*mmModel.Permission{
@@ -192,6 +199,8 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
 			model.PermissionManageBoardProperties,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		th.checkBoardPermissions("commenter", member, teamID, hasPermissionTo, hasNotPermissionTo)
@@ -214,7 +223,10 @@ func TestHasPermissionToBoard(t *testing.T) {
 			model.PermissionManageBoardRoles,
 			model.PermissionShareBoard,
 			model.PermissionManageBoardCards,
+			model.PermissionCommentBoardCards,
 			model.PermissionManageBoardProperties,
+			model.PermissionEditLockedProperties,
+			model.PermissionManageRestrictedViews,
 		}
 
 		th.checkBoardPermissions("viewer", member, teamID, hasPermissionTo, hasNotPermissionTo)
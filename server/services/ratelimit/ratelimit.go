@@ -0,0 +1,120 @@
+// Package ratelimit implements token bucket rate limiting, with the buckets
+// stored in memory for a single server or in Redis to share the limits
+// between the servers of a cluster.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const memoryCleanupInterval = time.Minute
+
+// Rate is the sustained rate and the burst of requests allowed for a key.
+// A bucket holds up to Burst tokens and refills at PerSecond tokens per
+// second; each request takes a token.
+type Rate struct {
+	PerSecond float64
+	Burst     int
+}
+
+// Enabled returns true if the rate limits the requests.
+func (r Rate) Enabled() bool {
+	return r.PerSecond > 0
+}
+
+func (r Rate) capacity() float64 {
+	if r.Burst < 1 {
+		return 1
+	}
+	return float64(r.Burst)
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed bool
+	// RetryAfter is the time until a token is available when the request
+	// is not allowed.
+	RetryAfter time.Duration
+}
+
+// Store holds the token buckets.
+type Store interface {
+	// Take takes a token from the bucket of the key.
+	Take(key string, rate Rate, now time.Time) (Result, error)
+}
+
+// MemoryStore is a Store holding the buckets in memory.
+type MemoryStore struct {
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	capacity float64
+	perSec   float64
+	updateAt time.Time
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Take implements Store.
+func (s *MemoryStore) Take(key string, rate Rate, now time.Time) (Result, error) {
+	if !rate.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastCleanup) > memoryCleanupInterval {
+		s.removeFull(now)
+		s.lastCleanup = now
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: rate.capacity(), updateAt: now}
+		s.buckets[key] = b
+	}
+	b.capacity = rate.capacity()
+	b.perSec = rate.PerSecond
+	b.refill(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return Result{Allowed: true}, nil
+	}
+	return Result{RetryAfter: secondsToDuration((1 - b.tokens) / rate.PerSecond)}, nil
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updateAt).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.perSec)
+		b.updateAt = now
+	}
+}
+
+// removeFull drops the buckets that have refilled, as they are the same as
+// a new bucket, so that the map doesn't grow with every client seen.
+func (s *MemoryStore) removeFull(now time.Time) {
+	for key, b := range s.buckets {
+		b.refill(now)
+		if b.tokens >= b.capacity {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	store := NewMemoryStore()
+	rate := Rate{PerSecond: 2, Burst: 3}
+	now := time.Now()
+
+	t.Run("the burst is allowed at once", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			result, err := store.Take("user", rate, now)
+			require.NoError(t, err)
+			require.True(t, result.Allowed)
+		}
+
+		result, err := store.Take("user", rate, now)
+		require.NoError(t, err)
+		require.False(t, result.Allowed)
+		require.Equal(t, 500*time.Millisecond, result.RetryAfter)
+	})
+
+	t.Run("the bucket refills at the sustained rate", func(t *testing.T) {
+		result, err := store.Take("user", rate, now.Add(500*time.Millisecond))
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+
+		result, err = store.Take("user", rate, now.Add(600*time.Millisecond))
+		require.NoError(t, err)
+		require.False(t, result.Allowed)
+		require.Equal(t, 400*time.Millisecond, result.RetryAfter)
+	})
+
+	t.Run("the keys have their own bucket", func(t *testing.T) {
+		result, err := store.Take("other", rate, now)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+	})
+
+	t.Run("a disabled rate allows everything", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			result, err := store.Take("user", Rate{}, now)
+			require.NoError(t, err)
+			require.True(t, result.Allowed)
+		}
+	})
+
+	t.Run("full buckets are removed", func(t *testing.T) {
+		_, err := store.Take("user", rate, now.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, store.buckets, 1)
+	})
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/redis"
+)
+
+// takeScript refills and takes a token from the bucket atomically. The
+// bucket is a hash with the tokens and the time of the last update, in
+// milliseconds, and expires once it would be full again.
+const takeScript = `
+local rate = tonumber(ARGV[1]) / 1000
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+if now > ts then
+  tokens = math.min(capacity, tokens + (now - ts) * rate)
+  ts = now
+end
+local allowed = 0
+local wait = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  wait = math.ceil((1 - tokens) / rate)
+end
+redis.call('HMSET', KEYS[1], 'tokens', tostring(tokens), 'ts', ts)
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate) + 1000)
+return {allowed, wait}
+`
+
+// RedisStore is a Store holding the buckets in Redis, so that all the
+// servers connected to the same Redis share the limits.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore returns a RedisStore with the keys of the buckets prefixed
+// by prefix.
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	return &RedisStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Take implements Store.
+func (s *RedisStore) Take(key string, rate Rate, now time.Time) (Result, error) {
+	if !rate.Enabled() {
+		return Result{Allowed: true}, nil
+	}
+
+	nowMillis := now.UnixNano() / int64(time.Millisecond)
+	reply, err := redis.Values(s.client.Do("EVAL", takeScript, 1, s.prefix+key, rate.PerSecond, rate.capacity(), nowMillis))
+	if err != nil {
+		return Result{}, err
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("ratelimit: unexpected reply %v", reply)
+	}
+
+	allowed, err := redis.Int64(reply[0], nil)
+	if err != nil {
+		return Result{}, err
+	}
+	wait, err := redis.Int64(reply[1], nil)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Allowed:    allowed == 1,
+		RetryAfter: time.Duration(wait) * time.Millisecond,
+	}, nil
+}
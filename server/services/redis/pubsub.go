@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// Message is a message published on a channel.
+type Message struct {
+	Channel string
+	Data    []byte
+}
+
+// PubSub receives the messages published on the channels it subscribed to.
+// It holds its own connection since a subscribed connection can't send
+// other commands.
+type PubSub struct {
+	client *goredis.Client
+	pubsub *goredis.PubSub
+}
+
+// Subscribe dials a connection subscribed to the channels, and returns
+// once the server confirmed the subscriptions.
+func Subscribe(opts Options, channels ...string) (*PubSub, error) {
+	goredisOpts := opts.goredisOptions()
+	goredisOpts.PoolSize = 1
+	client := goredis.NewClient(goredisOpts)
+	ps := &PubSub{client: client, pubsub: client.Subscribe(context.Background())}
+
+	if err := ps.subscribe(channels, goredisOpts.ReadTimeout); err != nil {
+		ps.Close()
+		return nil, err
+	}
+	return ps, nil
+}
+
+func (ps *PubSub) subscribe(channels []string, timeout time.Duration) error {
+	ctx := context.Background()
+	if err := ps.pubsub.Subscribe(ctx, channels...); err != nil {
+		return err
+	}
+
+	// the server confirms each channel with a reply of its own
+	for range channels {
+		reply, err := ps.pubsub.ReceiveTimeout(ctx, timeout)
+		if err != nil {
+			return err
+		}
+		if subscription, ok := reply.(*goredis.Subscription); !ok || subscription.Kind != "subscribe" {
+			return fmt.Errorf("redis: unexpected subscribe reply %v", reply)
+		}
+	}
+	return nil
+}
+
+// Receive blocks until a message is published on one of the channels. The
+// PubSub can't be used anymore after an error.
+func (ps *PubSub) Receive() (Message, error) {
+	message, err := ps.pubsub.ReceiveMessage(context.Background())
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{Channel: message.Channel, Data: []byte(message.Payload)}, nil
+}
+
+// Close closes the connection, which makes a blocked Receive return.
+func (ps *PubSub) Close() error {
+	err := ps.pubsub.Close()
+	if closeErr := ps.client.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// Publish publishes the message on the channel and returns the number of
+// subscribers that received it.
+func (c *Client) Publish(channel string, data []byte) (int64, error) {
+	return Int64(c.Do("PUBLISH", channel, data))
+}
@@ -0,0 +1,107 @@
+// Package redis wraps a pooled Redis client for the subset of the protocol
+// used by the server: plain commands, scripts and pub/sub.
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+const defaultTimeout = 5 * time.Second
+
+// ErrNil is returned when Redis replies with a nil value.
+var ErrNil = errors.New("redis: nil reply")
+
+// Options are the connection options of a Client.
+type Options struct {
+	Address  string
+	Password string
+	DB       int
+	// Timeout bounds dialing and each command, defaults to 5 seconds.
+	Timeout time.Duration
+	// PoolSize is the maximum number of connections, defaults to 10 per
+	// CPU.
+	PoolSize int
+}
+
+func (opts Options) goredisOptions() *goredis.Options {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &goredis.Options{
+		Addr:         opts.Address,
+		Password:     opts.Password,
+		DB:           opts.DB,
+		DialTimeout:  timeout,
+		ReadTimeout:  timeout,
+		WriteTimeout: timeout,
+		PoolTimeout:  timeout,
+		PoolSize:     opts.PoolSize,
+	}
+}
+
+// Client sends commands to a Redis server over a pool of connections,
+// which are dialed when needed and replaced after network errors. Client
+// is safe for concurrent use.
+type Client struct {
+	client *goredis.Client
+}
+
+// NewClient returns a client for the server at the address of the options.
+func NewClient(opts Options) *Client {
+	return &Client{client: goredis.NewClient(opts.goredisOptions())}
+}
+
+// Do sends a command and returns its reply, which is a string, an int64,
+// a []interface{} of replies or nil.
+func (c *Client) Do(args ...interface{}) (interface{}, error) {
+	reply, err := c.client.Do(context.Background(), args...).Result()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Close closes the connections to the server.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
+
+// Int64 converts a reply to an int64.
+func Int64(reply interface{}, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+	switch v := reply.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case nil:
+		return 0, ErrNil
+	}
+	return 0, fmt.Errorf("redis: unexpected reply type %T for an integer", reply)
+}
+
+// Values converts a reply to an array of replies.
+func Values(reply interface{}, err error) ([]interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	switch v := reply.(type) {
+	case []interface{}:
+		return v, nil
+	case nil:
+		return nil, ErrNil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply type %T for an array", reply)
+}
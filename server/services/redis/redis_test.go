@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serveReplies accepts a connection and answers its commands with the
+// replies, sending the commands on the returned channel.
+func serveReplies(t *testing.T, replies ...string) (string, chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	commands := make(chan []string, len(replies))
+	go func() {
+		conn, acceptErr := listener.Accept()
+		if acceptErr != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for _, reply := range replies {
+			command, readErr := readCommand(reader)
+			if readErr != nil {
+				return
+			}
+			commands <- command
+			_, _ = conn.Write([]byte(reply))
+		}
+		// wait for the client to close the connection
+		_, _ = reader.ReadByte()
+	}()
+	return listener.Addr().String(), commands
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	var count int
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &count); err != nil {
+		return nil, err
+	}
+	args := make([]string, count)
+	for i := range args {
+		var length int
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &length); err != nil {
+			return nil, err
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:length])
+	}
+	return args, nil
+}
+
+func TestClientDo(t *testing.T) {
+	address, commands := serveReplies(t, "+OK\r\n", ":7\r\n", "$-1\r\n", "-ERR unknown\r\n", "*2\r\n:1\r\n$1\r\na\r\n")
+
+	client := NewClient(Options{Address: address, Password: "secret"})
+	defer client.Close()
+
+	count, err := Int64(client.Do("INCR", "counter"))
+	require.NoError(t, err)
+	require.Equal(t, int64(7), count)
+	require.Equal(t, []string{"auth", "secret"}, <-commands)
+	require.Equal(t, []string{"INCR", "counter"}, <-commands)
+
+	// a nil reply isn't an error
+	reply, err := client.Do("SET", "lock", "token", "NX")
+	require.NoError(t, err)
+	require.Nil(t, reply)
+	<-commands
+
+	// an error replied by the server keeps the connection
+	_, err = client.Do("UNKNOWN")
+	require.EqualError(t, err, "ERR unknown")
+	<-commands
+
+	values, err := Values(client.Do("EVAL", "script", 1, "key", 1.5))
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(1), "a"}, values)
+	require.Equal(t, []string{"EVAL", "script", "1", "key", "1.5"}, <-commands)
+	require.Equal(t, uint32(1), client.client.PoolStats().TotalConns)
+}
+
+func TestSubscribe(t *testing.T) {
+	address, commands := serveReplies(t, "*3\r\n$9\r\nsubscribe\r\n$5\r\nboard\r\n:1\r\n"+
+		"*3\r\n$7\r\nmessage\r\n$5\r\nboard\r\n$5\r\nhello\r\n")
+
+	pubsub, err := Subscribe(Options{Address: address}, "board")
+	require.NoError(t, err)
+	defer pubsub.Close()
+	require.Equal(t, []string{"subscribe", "board"}, <-commands)
+
+	message, err := pubsub.Receive()
+	require.NoError(t, err)
+	require.Equal(t, Message{Channel: "board", Data: []byte("hello")}, message)
+}
+
+func TestInt64(t *testing.T) {
+	value, err := Int64("12", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), value)
+
+	_, err = Int64(nil, nil)
+	require.ErrorIs(t, err, ErrNil)
+
+	_, err = Values(nil, nil)
+	require.ErrorIs(t, err, ErrNil)
+}
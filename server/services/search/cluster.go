@@ -0,0 +1,196 @@
+package search
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/redis"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const clusterResubscribeDelay = 5 * time.Second
+
+// clusterMessage lists the boards changed on a server.
+type clusterMessage struct {
+	NodeID   string   `json:"nodeId"`
+	BoardIDs []string `json:"boardIds"`
+}
+
+// Cluster shares the boards changed on this server with the other servers
+// subscribed to the Redis channel. Each server holds its own Index, so the
+// servers receiving the changes remove the boards from it, to load them
+// again the next time they are searched. Elasticsearch is shared by all
+// the servers and doesn't need a cluster.
+type Cluster struct {
+	index  *Index
+	logger *mlog.Logger
+	nodeID string
+
+	mu      sync.Mutex
+	client  *redis.Client
+	channel string
+	pubsub  *redis.PubSub
+	done    chan struct{}
+}
+
+// NewCluster returns a cluster for the index, which publishes nothing
+// until it is started.
+func NewCluster(index *Index, logger *mlog.Logger) *Cluster {
+	return &Cluster{
+		index:  index,
+		logger: logger,
+		nodeID: utils.NewID(utils.IDTypeNone),
+	}
+}
+
+// Start subscribes to the Redis channel shared with the other servers.
+func (c *Cluster) Start(opts redis.Options, channel string) error {
+	pubsub, err := redis.Subscribe(opts, channel)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.client = redis.NewClient(opts)
+	c.channel = channel
+	c.pubsub = pubsub
+	c.done = make(chan struct{})
+
+	// the boards may have changed while this server wasn't subscribed
+	c.removeAll()
+
+	go c.receive(opts, channel, pubsub, c.done)
+
+	c.logger.Info("Search cluster started", mlog.String("channel", channel), mlog.String("nodeID", c.nodeID))
+	return nil
+}
+
+// Stop stops sharing the changes with the other servers.
+func (c *Cluster) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client == nil {
+		return
+	}
+
+	close(c.done)
+	if err := c.pubsub.Close(); err != nil {
+		c.logger.Warn("Cannot close the search cluster subscription", mlog.Err(err))
+	}
+	if err := c.client.Close(); err != nil {
+		c.logger.Warn("Cannot close the search cluster client", mlog.Err(err))
+	}
+	c.client = nil
+	c.pubsub = nil
+}
+
+// BoardsChanged publishes the boards changed on this server for the other
+// servers. It does nothing if the cluster isn't started.
+func (c *Cluster) BoardsChanged(boardIDs []string) {
+	if c == nil || len(boardIDs) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	client, channel := c.client, c.channel
+	c.mu.Unlock()
+
+	if client == nil {
+		return
+	}
+
+	data, err := json.Marshal(&clusterMessage{NodeID: c.nodeID, BoardIDs: boardIDs})
+	if err != nil {
+		c.logger.Error("Cannot marshal the search cluster message", mlog.Err(err))
+		return
+	}
+	if _, err := client.Publish(channel, data); err != nil {
+		c.logger.Error("Cannot publish the search cluster message", mlog.Err(err))
+	}
+}
+
+// receive removes the boards changed on the other servers from the index
+// until the cluster is stopped, subscribing again when the connection to
+// Redis is lost.
+func (c *Cluster) receive(opts redis.Options, channel string, pubsub *redis.PubSub, done chan struct{}) {
+	for {
+		message, err := pubsub.Receive()
+		if err == nil {
+			c.handleMessage(message.Data)
+			continue
+		}
+
+		select {
+		case <-done:
+			return
+		default:
+		}
+		c.logger.Warn("Search cluster subscription lost", mlog.Err(err))
+
+		if pubsub = c.resubscribe(opts, channel, done); pubsub == nil {
+			return
+		}
+	}
+}
+
+// resubscribe subscribes to the channel again, retrying until it succeeds
+// or the cluster is stopped, in which case it returns nil.
+func (c *Cluster) resubscribe(opts redis.Options, channel string, done chan struct{}) *redis.PubSub {
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-time.After(clusterResubscribeDelay):
+		}
+
+		pubsub, err := redis.Subscribe(opts, channel)
+		if err != nil {
+			c.logger.Warn("Cannot subscribe to the search cluster", mlog.Err(err))
+			continue
+		}
+
+		c.mu.Lock()
+		select {
+		case <-done:
+			c.mu.Unlock()
+			_ = pubsub.Close()
+			return nil
+		default:
+		}
+		c.pubsub = pubsub
+		// the changes published while disconnected were missed
+		c.removeAll()
+		c.mu.Unlock()
+		return pubsub
+	}
+}
+
+func (c *Cluster) handleMessage(data []byte) {
+	var message clusterMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		c.logger.Error("Cannot unmarshal the search cluster message", mlog.Err(err))
+		return
+	}
+
+	if message.NodeID == c.nodeID {
+		return
+	}
+
+	for _, boardID := range message.BoardIDs {
+		if err := c.index.RemoveBoard(boardID); err != nil {
+			c.logger.Warn("Cannot remove a changed board from the search index", mlog.String("boardID", boardID), mlog.Err(err))
+		}
+	}
+}
+
+func (c *Cluster) removeAll() {
+	if err := c.index.RemoveAll(); err != nil {
+		c.logger.Warn("Cannot remove the boards from the search index", mlog.Err(err))
+	}
+}
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterHandleMessage(t *testing.T) {
+	idx := testIndex(t)
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	defer func() { _ = logger.Shutdown() }()
+	c := NewCluster(idx, logger)
+
+	message := func(nodeID string, boardIDs ...string) []byte {
+		data, err := json.Marshal(&clusterMessage{NodeID: nodeID, BoardIDs: boardIDs})
+		require.NoError(t, err)
+		return data
+	}
+
+	// the changes of this server are already in its index
+	c.handleMessage(message(c.nodeID, "board-1"))
+	require.True(t, isLoaded(t, idx, "board-1"))
+
+	// the boards changed on another server are loaded again
+	c.handleMessage(message("other-node", "board-1"))
+	require.False(t, isLoaded(t, idx, "board-1"))
+	require.True(t, isLoaded(t, idx, "board-2"))
+}
@@ -0,0 +1,463 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	esRequestTimeout = 30 * time.Second
+	esQueueSize      = 10000
+	esBulkSize       = 500
+	esFlushInterval  = time.Second
+
+	// esSearchSize is the number of matching documents read by a search,
+	// which are then grouped by card.
+	esSearchSize = 1000
+
+	esFragmentSize = 160
+
+	// DefaultElasticsearchIndex is the name of the index when none is
+	// configured.
+	DefaultElasticsearchIndex = "focalboard-cards"
+
+	// esBoardPrefix prefixes the ids of the documents marking the loaded
+	// boards, which have no text to match.
+	esBoardPrefix = "board:"
+)
+
+// ErrIndexingQueueFull is returned when the changes are made faster than
+// Elasticsearch indexes them.
+var ErrIndexingQueueFull = errors.New("the indexing queue of Elasticsearch is full")
+
+// esMapping is the mapping of the documents of the index.
+const esMapping = `{
+  "mappings": {
+    "properties": {
+      "board_id": {"type": "keyword"},
+      "card_id": {"type": "keyword"},
+      "type": {"type": "keyword"},
+      "text": {"type": "text"},
+      "update_at": {"type": "long"}
+    }
+  }
+}`
+
+// ElasticsearchOptions are the connection options of an Elasticsearch
+// backend.
+type ElasticsearchOptions struct {
+	// URL is the address of the cluster, like http://localhost:9200.
+	URL      string
+	Index    string
+	Username string
+	Password string
+}
+
+// Elasticsearch is the Backend storing the index in Elasticsearch. The
+// index holds all the boards, which are loaded by a reindex or the first
+// time they are searched, and kept up to date by the changes. The changes
+// are sent in bulk in the background.
+type Elasticsearch struct {
+	opts       ElasticsearchOptions
+	logger     *mlog.Logger
+	httpClient *http.Client
+
+	indexMu      sync.Mutex
+	indexCreated bool
+
+	// loadedBoards caches the boards known to be loaded, as the index is
+	// searched far more often than the boards are removed.
+	loadedBoards sync.Map
+
+	queue     chan esOperation
+	done      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+type esOperation struct {
+	delete bool
+	doc    Document
+}
+
+type esDocument struct {
+	BoardID  string       `json:"board_id"`
+	CardID   string       `json:"card_id"`
+	Type     DocumentType `json:"type"`
+	Text     string       `json:"text"`
+	UpdateAt int64        `json:"update_at"`
+}
+
+// NewElasticsearch returns an Elasticsearch backend and starts sending the
+// changes to the cluster.
+func NewElasticsearch(opts ElasticsearchOptions, logger *mlog.Logger) *Elasticsearch {
+	opts.URL = strings.TrimSuffix(opts.URL, "/")
+	if opts.Index == "" {
+		opts.Index = DefaultElasticsearchIndex
+	}
+
+	es := &Elasticsearch{
+		opts:       opts,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: esRequestTimeout},
+		queue:      make(chan esOperation, esQueueSize),
+		done:       make(chan struct{}),
+	}
+	es.wg.Add(1)
+	go es.indexChanges()
+	return es
+}
+
+// IsLoaded implements Backend. The loaded boards have a marking document
+// in the index, which is removed with the board.
+func (es *Elasticsearch) IsLoaded(boardID string) (bool, error) {
+	if _, ok := es.loadedBoards.Load(boardID); ok {
+		return true, nil
+	}
+	if err := es.ensureIndex(); err != nil {
+		return false, err
+	}
+
+	_, err := es.do(http.MethodGet, "/"+url.PathEscape(es.opts.Index)+"/_doc/"+url.PathEscape(esBoardPrefix+boardID), "application/json", nil)
+	var errResponse *esErrorResponse
+	if errors.As(err, &errResponse) && errResponse.status == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	es.loadedBoards.Store(boardID, true)
+	return true, nil
+}
+
+// LoadBoard implements Backend.
+func (es *Elasticsearch) LoadBoard(boardID string, docs []Document) error {
+	if err := es.RemoveBoard(boardID); err != nil {
+		return err
+	}
+
+	ops := make([]esOperation, 0, esBulkSize)
+	for _, doc := range docs {
+		if doc.BoardID != boardID {
+			continue
+		}
+		ops = append(ops, esOperation{doc: doc})
+		if len(ops) == esBulkSize {
+			if err := es.bulk(ops); err != nil {
+				return err
+			}
+			ops = ops[:0]
+		}
+	}
+
+	// the board is marked as loaded once all its documents are indexed
+	if err := es.bulk(ops); err != nil {
+		return err
+	}
+	if err := es.bulk([]esOperation{{doc: Document{ID: esBoardPrefix + boardID, BoardID: boardID}}}); err != nil {
+		return err
+	}
+	es.loadedBoards.Store(boardID, true)
+	return nil
+}
+
+// RemoveBoard implements Backend.
+func (es *Elasticsearch) RemoveBoard(boardID string) error {
+	es.loadedBoards.Delete(boardID)
+	if err := es.ensureIndex(); err != nil {
+		return err
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{"board_id": boardID},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = es.do(http.MethodPost, "/"+url.PathEscape(es.opts.Index)+"/_delete_by_query?conflicts=proceed", "application/json", query)
+	return err
+}
+
+// Update implements Backend. The document is indexed in the background.
+func (es *Elasticsearch) Update(doc Document) error {
+	return es.enqueue(esOperation{doc: doc})
+}
+
+// Delete implements Backend. The document is removed in the background.
+func (es *Elasticsearch) Delete(documentID string) error {
+	return es.enqueue(esOperation{delete: true, doc: Document{ID: documentID}})
+}
+
+func (es *Elasticsearch) enqueue(op esOperation) error {
+	select {
+	case es.queue <- op:
+		return nil
+	default:
+		return ErrIndexingQueueFull
+	}
+}
+
+// Close implements Backend.
+func (es *Elasticsearch) Close() error {
+	es.closeOnce.Do(func() {
+		close(es.done)
+	})
+	es.wg.Wait()
+	return nil
+}
+
+// indexChanges sends the queued changes in bulk, when there are enough of
+// them or every flush interval, until the backend is closed.
+func (es *Elasticsearch) indexChanges() {
+	defer es.wg.Done()
+
+	ticker := time.NewTicker(esFlushInterval)
+	defer ticker.Stop()
+
+	ops := make([]esOperation, 0, esBulkSize)
+	flush := func() {
+		if len(ops) == 0 {
+			return
+		}
+		if err := es.bulk(ops); err != nil {
+			es.logger.Error("Cannot index the changes in Elasticsearch", mlog.Int("changes", len(ops)), mlog.Err(err))
+		}
+		ops = ops[:0]
+	}
+
+	for {
+		select {
+		case op := <-es.queue:
+			ops = append(ops, op)
+			if len(ops) >= esBulkSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-es.done:
+			for {
+				select {
+				case op := <-es.queue:
+					ops = append(ops, op)
+					if len(ops) >= esBulkSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (es *Elasticsearch) bulk(ops []esOperation) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	if err := es.ensureIndex(); err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, op := range ops {
+		action := "index"
+		if op.delete {
+			action = "delete"
+		}
+		if err := encoder.Encode(map[string]interface{}{
+			action: map[string]string{"_index": es.opts.Index, "_id": op.doc.ID},
+		}); err != nil {
+			return err
+		}
+		if op.delete {
+			continue
+		}
+		if err := encoder.Encode(esDocument{
+			BoardID:  op.doc.BoardID,
+			CardID:   op.doc.CardID,
+			Type:     op.doc.Type,
+			Text:     op.doc.Text,
+			UpdateAt: op.doc.UpdateAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	data, err := es.do(http.MethodPost, "/_bulk", "application/x-ndjson", body.Bytes())
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return fmt.Errorf("cannot parse the bulk response of Elasticsearch: %w", err)
+	}
+	if !response.Errors {
+		return nil
+	}
+	for _, item := range response.Items {
+		for action, result := range item {
+			// deleting a document that isn't indexed is not an error
+			if result.Error != nil && !(action == "delete" && result.Status == http.StatusNotFound) {
+				return fmt.Errorf("elasticsearch cannot %s a document: %s", action, result.Error)
+			}
+		}
+	}
+	return nil
+}
+
+// Search implements Backend.
+func (es *Elasticsearch) Search(boardIDs []string, query string) ([]*Hit, error) {
+	if len(boardIDs) == 0 || len(uniqueTerms(query)) == 0 {
+		return []*Hit{}, nil
+	}
+	if err := es.ensureIndex(); err != nil {
+		return nil, err
+	}
+
+	request, err := json.Marshal(map[string]interface{}{
+		"size": esSearchSize,
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"terms": map[string]interface{}{"board_id": boardIDs}},
+				},
+				"must": []interface{}{
+					map[string]interface{}{"match_bool_prefix": map[string]interface{}{"text": query}},
+				},
+			},
+		},
+		"highlight": map[string]interface{}{
+			"encoder":   "html",
+			"pre_tags":  []string{"<mark>"},
+			"post_tags": []string{"</mark>"},
+			"fields": map[string]interface{}{
+				"text": map[string]interface{}{
+					"fragment_size":       esFragmentSize,
+					"number_of_fragments": 1,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := es.do(http.MethodPost, "/"+url.PathEscape(es.opts.Index)+"/_search", "application/json", request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Hits struct {
+			Hits []struct {
+				ID        string              `json:"_id"`
+				Score     float64             `json:"_score"`
+				Source    esDocument          `json:"_source"`
+				Highlight map[string][]string `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("cannot parse the search response of Elasticsearch: %w", err)
+	}
+
+	matches := make([]match, 0, len(response.Hits.Hits))
+	for _, hit := range response.Hits.Hits {
+		m := match{
+			doc: Document{
+				ID:       hit.ID,
+				BoardID:  hit.Source.BoardID,
+				CardID:   hit.Source.CardID,
+				Type:     hit.Source.Type,
+				Text:     hit.Source.Text,
+				UpdateAt: hit.Source.UpdateAt,
+			},
+			score: hit.Score,
+		}
+		if fragments := hit.Highlight["text"]; len(fragments) > 0 {
+			m.fragment = fragments[0]
+		}
+		matches = append(matches, m)
+	}
+	return collectHits(matches, func(m *match) string {
+		return m.fragment
+	}), nil
+}
+
+// ensureIndex creates the index with its mapping if it doesn't exist.
+func (es *Elasticsearch) ensureIndex() error {
+	es.indexMu.Lock()
+	defer es.indexMu.Unlock()
+	if es.indexCreated {
+		return nil
+	}
+
+	_, err := es.do(http.MethodPut, "/"+url.PathEscape(es.opts.Index), "application/json", []byte(esMapping))
+	var errResponse *esErrorResponse
+	if err != nil && !(errors.As(err, &errResponse) && errResponse.isAlreadyExists()) {
+		return err
+	}
+	es.indexCreated = true
+	return nil
+}
+
+// esErrorResponse is an error answered by Elasticsearch.
+type esErrorResponse struct {
+	status int
+	body   []byte
+}
+
+func (e *esErrorResponse) Error() string {
+	return fmt.Sprintf("elasticsearch error %d: %s", e.status, e.body)
+}
+
+func (e *esErrorResponse) isAlreadyExists() bool {
+	return e.status == http.StatusBadRequest && bytes.Contains(e.body, []byte("resource_already_exists_exception"))
+}
+
+func (es *Elasticsearch) do(method, path, contentType string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, es.opts.URL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if es.opts.Username != "" {
+		req.SetBasicAuth(es.opts.Username, es.opts.Password)
+	}
+
+	resp, err := es.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &esErrorResponse{status: resp.StatusCode, body: data}
+	}
+	return data, nil
+}
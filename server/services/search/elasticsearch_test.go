@@ -0,0 +1,165 @@
+package search
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeElasticsearch records the requests and answers them like an
+// Elasticsearch cluster with an existing index.
+type fakeElasticsearch struct {
+	mu             sync.Mutex
+	bulkLines      []map[string]interface{}
+	docs           map[string]map[string]interface{}
+	deleteQueries  int
+	searchRequests []map[string]interface{}
+	searchResponse string
+}
+
+func (f *fakeElasticsearch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/cards":
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"error":{"type":"resource_already_exists_exception"},"status":400}`)
+	case r.URL.Path == "/_bulk":
+		scanner := bufio.NewScanner(r.Body)
+		indexID := ""
+		for scanner.Scan() {
+			line := map[string]interface{}{}
+			_ = json.Unmarshal(scanner.Bytes(), &line)
+			f.bulkLines = append(f.bulkLines, line)
+
+			switch {
+			case indexID != "":
+				f.docs[indexID] = line
+				indexID = ""
+			case line["index"] != nil:
+				indexID, _ = line["index"].(map[string]interface{})["_id"].(string)
+			case line["delete"] != nil:
+				id, _ := line["delete"].(map[string]interface{})["_id"].(string)
+				delete(f.docs, id)
+			}
+		}
+		_, _ = io.WriteString(w, `{"errors":false,"items":[]}`)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/cards/_doc/"):
+		if _, ok := f.docs[strings.TrimPrefix(r.URL.Path, "/cards/_doc/")]; ok {
+			_, _ = io.WriteString(w, `{"found":true}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"found":false}`)
+	case r.URL.Path == "/cards/_delete_by_query":
+		var request struct {
+			Query struct {
+				Term struct {
+					BoardID string `json:"board_id"`
+				} `json:"term"`
+			} `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		for id, doc := range f.docs {
+			if doc["board_id"] == request.Query.Term.BoardID {
+				delete(f.docs, id)
+			}
+		}
+		f.deleteQueries++
+		_, _ = io.WriteString(w, `{"deleted":0}`)
+	case r.URL.Path == "/cards/_search":
+		request := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		f.searchRequests = append(f.searchRequests, request)
+		_, _ = io.WriteString(w, f.searchResponse)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func setupElasticsearch(t *testing.T) (*Elasticsearch, *fakeElasticsearch) {
+	fake := &fakeElasticsearch{docs: map[string]map[string]interface{}{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	es := NewElasticsearch(ElasticsearchOptions{URL: server.URL + "/", Index: "cards"}, mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+	return es, fake
+}
+
+func TestElasticsearchSearch(t *testing.T) {
+	es, fake := setupElasticsearch(t)
+	defer es.Close()
+
+	fake.searchResponse = `{"hits":{"hits":[
+		{"_id":"text-1","_score":1.5,"_source":{"board_id":"board-1","card_id":"card-1","type":"text","text":"Draft the release notes","update_at":2},
+		 "highlight":{"text":["Draft the <mark>release</mark> notes"]}},
+		{"_id":"card-2","_score":1,"_source":{"board_id":"board-1","card_id":"card-2","type":"card","text":"Release","update_at":3},
+		 "highlight":{"text":["<mark>Release</mark>"]}}
+	]}}`
+
+	hits := searchHits(t, es, []string{"board-1"}, "release")
+	require.Len(t, hits, 2)
+	// the title is boosted
+	require.Equal(t, "card-2", hits[0].CardID)
+	require.Equal(t, int64(3), hits[0].UpdateAt)
+	require.Equal(t, Highlight{DocumentID: "card-2", Type: DocumentTypeTitle, Fragment: "<mark>Release</mark>"}, hits[0].Highlights[0])
+	require.Equal(t, "card-1", hits[1].CardID)
+
+	require.Len(t, fake.searchRequests, 1)
+	query := fake.searchRequests[0]["query"].(map[string]interface{})["bool"].(map[string]interface{})
+	boardFilter := query["filter"].([]interface{})[0].(map[string]interface{})["terms"].(map[string]interface{})
+	require.Equal(t, []interface{}{"board-1"}, boardFilter["board_id"])
+
+	t.Run("empty searches don't query the cluster", func(t *testing.T) {
+		require.Empty(t, searchHits(t, es, []string{}, "release"))
+		require.Empty(t, searchHits(t, es, []string{"board-1"}, " , "))
+		require.Len(t, fake.searchRequests, 1)
+	})
+}
+
+func TestElasticsearchIndexing(t *testing.T) {
+	es, fake := setupElasticsearch(t)
+
+	require.False(t, isLoaded(t, es, "board-1"))
+
+	require.NoError(t, es.LoadBoard("board-1", []Document{
+		{ID: "card-1", BoardID: "board-1", CardID: "card-1", Type: DocumentTypeTitle, Text: "Release planning"},
+		{ID: "card-2", BoardID: "board-2", CardID: "card-2", Type: DocumentTypeTitle, Text: "Other board"},
+	}))
+	require.Equal(t, 1, fake.deleteQueries)
+	require.Len(t, fake.bulkLines, 4)
+	require.Equal(t, map[string]interface{}{"_index": "cards", "_id": "card-1"}, fake.bulkLines[0]["index"])
+	require.Equal(t, "Release planning", fake.bulkLines[1]["text"])
+	// the board is marked as loaded after its documents
+	require.Equal(t, map[string]interface{}{"_index": "cards", "_id": "board:board-1"}, fake.bulkLines[2]["index"])
+	require.True(t, isLoaded(t, es, "board-1"))
+
+	// a removed board is loaded again, when it is restored or searched
+	require.NoError(t, es.RemoveBoard("board-1"))
+	require.Equal(t, 2, fake.deleteQueries)
+	require.False(t, isLoaded(t, es, "board-1"))
+	fake.mu.Lock()
+	fake.bulkLines = nil
+	fake.mu.Unlock()
+
+	// the changes are sent in the background, at the latest when closing
+	require.NoError(t, es.Update(Document{ID: "text-1", BoardID: "board-1", CardID: "card-1", Type: DocumentTypeText, Text: "Notes"}))
+	require.NoError(t, es.Delete("card-1"))
+	require.NoError(t, es.Close())
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	require.Len(t, fake.bulkLines, 3)
+	require.Equal(t, map[string]interface{}{"_index": "cards", "_id": "text-1"}, fake.bulkLines[0]["index"])
+	require.Equal(t, "board-1", fake.bulkLines[1]["board_id"])
+	require.Equal(t, map[string]interface{}{"_index": "cards", "_id": "card-1"}, fake.bulkLines[2]["delete"])
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	// fragmentSize is the maximum length of a highlighted fragment in
+	// bytes, before escaping.
+	fragmentSize = 160
+
+	// fragmentContext is the length of the text kept before the first
+	// match of a fragment.
+	fragmentContext = 40
+
+	ellipsis = "…"
+)
+
+// highlight returns the fragment of the text around its first matching
+// word, HTML escaped with the matching words in <mark> elements.
+func highlight(text string, weights map[string]float64) string {
+	tokens := tokenize(text)
+	first := -1
+	for i, token := range tokens {
+		if weights[token.term] > 0 {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	start, end := 0, len(text)
+	if len(text) > fragmentSize {
+		// start at a word boundary close enough to the first match
+		start = tokens[first].start
+		for i := first - 1; i >= 0 && tokens[first].start-tokens[i].start <= fragmentContext; i-- {
+			start = tokens[i].start
+		}
+		// end at the last word fitting in the fragment
+		end = tokens[first].end
+		for i := first + 1; i < len(tokens) && tokens[i].end-start <= fragmentSize; i++ {
+			end = tokens[i].end
+		}
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString(ellipsis)
+	}
+	last := start
+	for _, token := range tokens {
+		if token.start < start || token.end > end || weights[token.term] == 0 {
+			continue
+		}
+		sb.WriteString(html.EscapeString(text[last:token.start]))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(text[token.start:token.end]))
+		sb.WriteString("</mark>")
+		last = token.end
+	}
+	sb.WriteString(html.EscapeString(text[last:end]))
+	if end < len(text) {
+		sb.WriteString(ellipsis)
+	}
+	return strings.TrimSpace(sb.String())
+}
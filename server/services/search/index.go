@@ -0,0 +1,333 @@
+package search
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/standard"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+const (
+	// prefixWeight is the weight of the words only starting with a term of
+	// the query, relative to the words equal to it.
+	prefixWeight = 0.5
+
+	// bleveSearchSize is the number of matching documents read by a search,
+	// which are then grouped by card.
+	bleveSearchSize = 1000
+
+	// bleveBatchSize is the number of documents indexed or deleted at once.
+	bleveBatchSize = 500
+
+	// generationKey is the internal key of the generation of the index,
+	// which is increased to mark all the boards as not loaded.
+	generationKey = "generation"
+
+	// boardKeyPrefix prefixes the internal keys marking the loaded boards
+	// with the generation they were loaded in.
+	boardKeyPrefix = "board:"
+)
+
+// bleveDocument is a Document as stored in the Bleve index.
+type bleveDocument struct {
+	BoardID  string       `json:"board_id"`
+	CardID   string       `json:"card_id"`
+	Type     DocumentType `json:"type"`
+	Text     string       `json:"text"`
+	UpdateAt int64        `json:"update_at"`
+}
+
+// Index is the Backend holding the full-text index of the cards in Bleve,
+// on disk or in memory. The boards are loaded the first time they are
+// searched and kept in the index, across restarts when it is on disk. It
+// is safe for concurrent use.
+type Index struct {
+	mu    sync.Mutex
+	index bleve.Index
+}
+
+// NewIndex opens the Bleve index at the path, creating it if it doesn't
+// exist. The index is held in memory if the path is empty.
+func NewIndex(path string) (*Index, error) {
+	if path == "" {
+		index, err := bleve.NewMemOnly(newIndexMapping())
+		if err != nil {
+			return nil, err
+		}
+		return &Index{index: index}, nil
+	}
+
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		index, err = bleve.New(path, newIndexMapping())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Index{index: index}, nil
+}
+
+func newIndexMapping() mapping.IndexMapping {
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+
+	textField := bleve.NewTextFieldMapping()
+	textField.Analyzer = standard.Name
+
+	docMapping := bleve.NewDocumentMapping()
+	docMapping.AddFieldMappingsAt("board_id", keywordField)
+	docMapping.AddFieldMappingsAt("card_id", keywordField)
+	docMapping.AddFieldMappingsAt("type", keywordField)
+	docMapping.AddFieldMappingsAt("text", textField)
+	docMapping.AddFieldMappingsAt("update_at", bleve.NewNumericFieldMapping())
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = docMapping
+	return indexMapping
+}
+
+// IsLoaded implements Backend.
+func (idx *Index) IsLoaded(boardID string) (bool, error) {
+	generation, err := idx.generation()
+	if err != nil {
+		return false, err
+	}
+	loaded, err := idx.index.GetInternal([]byte(boardKeyPrefix + boardID))
+	if err != nil {
+		return false, err
+	}
+	return string(loaded) == strconv.FormatInt(generation, 10), nil
+}
+
+// generation returns the generation of the index, 0 until all the boards
+// are first removed.
+func (idx *Index) generation() (int64, error) {
+	value, err := idx.index.GetInternal([]byte(generationKey))
+	if err != nil || len(value) == 0 {
+		return 0, err
+	}
+	return strconv.ParseInt(string(value), 10, 64)
+}
+
+// LoadBoard implements Backend.
+func (idx *Index) LoadBoard(boardID string, docs []Document) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	if err := idx.removeBoard(boardID); err != nil {
+		return err
+	}
+
+	batch := idx.index.NewBatch()
+	for _, doc := range docs {
+		if doc.BoardID != boardID {
+			continue
+		}
+		if err := batch.Index(doc.ID, newBleveDocument(doc)); err != nil {
+			return err
+		}
+		if batch.Size() >= bleveBatchSize {
+			if err := idx.index.Batch(batch); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+
+	generation, err := idx.generation()
+	if err != nil {
+		return err
+	}
+	batch.SetInternal([]byte(boardKeyPrefix+boardID), []byte(strconv.FormatInt(generation, 10)))
+	return idx.index.Batch(batch)
+}
+
+// RemoveBoard implements Backend. The board is loaded again the next time
+// it is searched.
+func (idx *Index) RemoveBoard(boardID string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return idx.removeBoard(boardID)
+}
+
+// RemoveAll marks all the boards as not loaded, so that they are loaded
+// again the next time they are searched.
+func (idx *Index) RemoveAll() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	generation, err := idx.generation()
+	if err != nil {
+		return err
+	}
+	return idx.index.SetInternal([]byte(generationKey), []byte(strconv.FormatInt(generation+1, 10)))
+}
+
+// Update implements Backend. Documents of the boards that are not loaded
+// are ignored, as they are read when loading the board.
+func (idx *Index) Update(doc Document) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	loaded, err := idx.IsLoaded(doc.BoardID)
+	if err != nil || !loaded {
+		return err
+	}
+	return idx.index.Index(doc.ID, newBleveDocument(doc))
+}
+
+// Delete implements Backend.
+func (idx *Index) Delete(documentID string) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	return idx.index.Delete(documentID)
+}
+
+// Close implements Backend.
+func (idx *Index) Close() error {
+	return idx.index.Close()
+}
+
+// removeBoard deletes the documents of the board and its loaded mark.
+func (idx *Index) removeBoard(boardID string) error {
+	if err := idx.index.DeleteInternal([]byte(boardKeyPrefix + boardID)); err != nil {
+		return err
+	}
+
+	for {
+		req := bleve.NewSearchRequestOptions(boardQuery([]string{boardID}), bleveBatchSize, 0, false)
+		result, err := idx.index.Search(req)
+		if err != nil {
+			return err
+		}
+		if len(result.Hits) == 0 {
+			return nil
+		}
+
+		batch := idx.index.NewBatch()
+		for _, hit := range result.Hits {
+			batch.Delete(hit.ID)
+		}
+		if err := idx.index.Batch(batch); err != nil {
+			return err
+		}
+	}
+}
+
+// Search implements Backend.
+func (idx *Index) Search(boardIDs []string, q string) ([]*Hit, error) {
+	queryTerms := uniqueTerms(q)
+	if len(boardIDs) == 0 || len(queryTerms) == 0 {
+		return []*Hit{}, nil
+	}
+
+	termQueries := make([]query.Query, 0, 2*len(queryTerms))
+	for _, term := range queryTerms {
+		exact := bleve.NewTermQuery(term)
+		exact.SetField("text")
+		prefix := bleve.NewPrefixQuery(term)
+		prefix.SetField("text")
+		prefix.SetBoost(prefixWeight)
+		termQueries = append(termQueries, exact, prefix)
+	}
+
+	req := bleve.NewSearchRequestOptions(
+		bleve.NewConjunctionQuery(boardQuery(boardIDs), bleve.NewDisjunctionQuery(termQueries...)),
+		bleveSearchSize, 0, false,
+	)
+	req.Fields = []string{"board_id", "card_id", "type", "text", "update_at"}
+	req.IncludeLocations = true
+
+	result, err := idx.index.Search(req)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]match, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		doc := Document{ID: hit.ID}
+		doc.BoardID, _ = hit.Fields["board_id"].(string)
+		doc.CardID, _ = hit.Fields["card_id"].(string)
+		docType, _ := hit.Fields["type"].(string)
+		doc.Type = DocumentType(docType)
+		doc.Text, _ = hit.Fields["text"].(string)
+		updateAt, _ := hit.Fields["update_at"].(float64)
+		doc.UpdateAt = int64(updateAt)
+
+		// the words of the text matching the query, as indexed
+		weights := map[string]float64{}
+		for term := range hit.Locations["text"] {
+			weights[term] = 1
+		}
+		matches = append(matches, match{doc: doc, score: hit.Score, fragment: highlight(doc.Text, weights)})
+	}
+	return collectHits(matches, func(m *match) string {
+		return m.fragment
+	}), nil
+}
+
+// boardQuery matches the documents of the boards.
+func boardQuery(boardIDs []string) query.Query {
+	boardQueries := make([]query.Query, 0, len(boardIDs))
+	for _, boardID := range boardIDs {
+		q := bleve.NewTermQuery(boardID)
+		q.SetField("board_id")
+		boardQueries = append(boardQueries, q)
+	}
+	return bleve.NewDisjunctionQuery(boardQueries...)
+}
+
+func newBleveDocument(doc Document) bleveDocument {
+	return bleveDocument{
+		BoardID:  doc.BoardID,
+		CardID:   doc.CardID,
+		Type:     doc.Type,
+		Text:     doc.Text,
+		UpdateAt: doc.UpdateAt,
+	}
+}
+
+type token struct {
+	term       string
+	start, end int
+}
+
+// tokenize splits the text into its lowercase words, with their byte
+// offsets in the text.
+func tokenize(text string) []token {
+	tokens := []token{}
+	start := -1
+	for i, r := range text {
+		isWordRune := unicode.IsLetter(r) || unicode.IsNumber(r)
+		if isWordRune && start < 0 {
+			start = i
+		}
+		if !isWordRune && start >= 0 {
+			tokens = append(tokens, token{term: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{term: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return tokens
+}
+
+func uniqueTerms(query string) []string {
+	seen := map[string]bool{}
+	terms := []string{}
+	for _, token := range tokenize(query) {
+		if !seen[token.term] {
+			seen[token.term] = true
+			terms = append(terms, token.term)
+		}
+	}
+	return terms
+}
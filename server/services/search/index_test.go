@@ -0,0 +1,134 @@
+package search
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex(t *testing.T) *Index {
+	idx, err := NewIndex("")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = idx.Close() })
+
+	require.NoError(t, idx.LoadBoard("board-1", []Document{
+		{ID: "card-1", BoardID: "board-1", CardID: "card-1", Type: DocumentTypeTitle, Text: "Release planning", UpdateAt: 1},
+		{ID: "text-1", BoardID: "board-1", CardID: "card-1", Type: DocumentTypeText, Text: "Draft the <release> notes", UpdateAt: 2},
+		{ID: "card-2", BoardID: "board-1", CardID: "card-2", Type: DocumentTypeTitle, Text: "Fix the login page", UpdateAt: 3},
+		{ID: "comment-2", BoardID: "board-1", CardID: "card-2", Type: DocumentTypeComment, Text: "Blocked until the release is out", UpdateAt: 4},
+	}))
+	require.NoError(t, idx.LoadBoard("board-2", []Document{
+		{ID: "card-3", BoardID: "board-2", CardID: "card-3", Type: DocumentTypeTitle, Text: "Releases of the other board", UpdateAt: 5},
+	}))
+	return idx
+}
+
+func searchHits(t *testing.T, backend Backend, boardIDs []string, query string) []*Hit {
+	hits, err := backend.Search(boardIDs, query)
+	require.NoError(t, err)
+	return hits
+}
+
+func isLoaded(t *testing.T, backend Backend, boardID string) bool {
+	loaded, err := backend.IsLoaded(boardID)
+	require.NoError(t, err)
+	return loaded
+}
+
+func TestIndexSearch(t *testing.T) {
+	idx := testIndex(t)
+
+	t.Run("cards are ranked by their matching texts", func(t *testing.T) {
+		hits := searchHits(t, idx, []string{"board-1"}, "Release")
+		require.Len(t, hits, 2)
+		require.Equal(t, "card-1", hits[0].CardID)
+		require.Equal(t, "card-2", hits[1].CardID)
+		require.True(t, hits[0].Score > hits[1].Score)
+		require.Equal(t, int64(2), hits[0].UpdateAt)
+
+		require.Len(t, hits[0].Highlights, 2)
+		require.Equal(t, Highlight{DocumentID: "card-1", Type: DocumentTypeTitle, Fragment: "<mark>Release</mark> planning"}, hits[0].Highlights[0])
+		require.Equal(t, "Draft the &lt;<mark>release</mark>&gt; notes", hits[0].Highlights[1].Fragment)
+		require.Equal(t, "Blocked until the <mark>release</mark> is out", hits[1].Highlights[0].Fragment)
+	})
+
+	t.Run("words starting with a term match", func(t *testing.T) {
+		hits := searchHits(t, idx, []string{"board-2"}, "release")
+		require.Len(t, hits, 1)
+		require.Equal(t, "<mark>Releases</mark> of the other board", hits[0].Highlights[0].Fragment)
+	})
+
+	t.Run("only the searched boards match", func(t *testing.T) {
+		require.Empty(t, searchHits(t, idx, []string{"board-2"}, "login"))
+		require.Empty(t, searchHits(t, idx, []string{"board-1"}, "unknown"))
+		require.Empty(t, searchHits(t, idx, []string{"board-1"}, " ,. "))
+	})
+}
+
+func TestIndexUpdates(t *testing.T) {
+	idx := testIndex(t)
+
+	require.NoError(t, idx.Update(Document{ID: "card-2", BoardID: "board-1", CardID: "card-2", Type: DocumentTypeTitle, Text: "Fix the signup page"}))
+	require.Len(t, searchHits(t, idx, []string{"board-1"}, "signup"), 1)
+	require.Empty(t, searchHits(t, idx, []string{"board-1"}, "login"))
+
+	require.NoError(t, idx.Delete("comment-2"))
+	require.Len(t, searchHits(t, idx, []string{"board-1"}, "blocked"), 0)
+
+	// the documents of the boards that are not loaded are read when loading them
+	require.NoError(t, idx.Update(Document{ID: "card-4", BoardID: "board-3", CardID: "card-4", Type: DocumentTypeTitle, Text: "signup"}))
+	require.False(t, isLoaded(t, idx, "board-3"))
+	require.Len(t, searchHits(t, idx, []string{"board-1", "board-3"}, "signup"), 1)
+
+	require.NoError(t, idx.RemoveBoard("board-1"))
+	require.False(t, isLoaded(t, idx, "board-1"))
+	require.Empty(t, searchHits(t, idx, []string{"board-1"}, "signup"))
+	require.Len(t, searchHits(t, idx, []string{"board-2"}, "releases"), 1)
+}
+
+func TestIndexRemoveAll(t *testing.T) {
+	idx := testIndex(t)
+
+	require.NoError(t, idx.RemoveAll())
+	require.False(t, isLoaded(t, idx, "board-1"))
+	require.False(t, isLoaded(t, idx, "board-2"))
+
+	require.NoError(t, idx.LoadBoard("board-1", []Document{
+		{ID: "card-1", BoardID: "board-1", CardID: "card-1", Type: DocumentTypeTitle, Text: "Release planning"},
+	}))
+	require.True(t, isLoaded(t, idx, "board-1"))
+	require.False(t, isLoaded(t, idx, "board-2"))
+}
+
+func TestIndexOnDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index")
+
+	idx, err := NewIndex(path)
+	require.NoError(t, err)
+	require.NoError(t, idx.LoadBoard("board-1", []Document{
+		{ID: "card-1", BoardID: "board-1", CardID: "card-1", Type: DocumentTypeTitle, Text: "Release planning"},
+	}))
+	require.NoError(t, idx.Close())
+
+	// the loaded boards are kept across restarts
+	idx, err = NewIndex(path)
+	require.NoError(t, err)
+	defer idx.Close()
+	require.True(t, isLoaded(t, idx, "board-1"))
+	require.Len(t, searchHits(t, idx, []string{"board-1"}, "planning"), 1)
+}
+
+func TestHighlight(t *testing.T) {
+	weights := map[string]float64{"needle": 1}
+
+	long := strings.Repeat("hay ", 50) + "needle" + strings.Repeat(" hay", 50)
+	fragment := highlight(long, weights)
+	require.True(t, strings.HasPrefix(fragment, "…hay"))
+	require.True(t, strings.HasSuffix(fragment, "hay…"))
+	require.Contains(t, fragment, "<mark>needle</mark>")
+	require.True(t, len(fragment) < fragmentSize+len("<mark></mark>")+2*len(ellipsis))
+
+	require.Equal(t, "", highlight("no match", weights))
+}
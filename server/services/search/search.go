@@ -0,0 +1,144 @@
+// Package search maintains the full-text index of the cards of the boards,
+// with their titles, text and comments, and ranks the cards that match a
+// query.
+//
+// The index is held by a Backend: the Bleve Index of each server, which
+// loads the boards the first time they are searched, or Elasticsearch for
+// the large deployments, which keeps the index of all the boards.
+package search
+
+import (
+	"sort"
+)
+
+const (
+	// titleBoost multiplies the score of the matches in the card titles.
+	titleBoost = 2.0
+
+	// MaxHighlights is the maximum number of highlighted fragments of a hit.
+	MaxHighlights = 3
+)
+
+// DocumentType is the kind of text of a card a document holds.
+type DocumentType string
+
+const (
+	DocumentTypeTitle   DocumentType = "card"
+	DocumentTypeText    DocumentType = "text"
+	DocumentTypeComment DocumentType = "comment"
+)
+
+// Document is a searchable text of a card, identified by the id of the
+// block holding it.
+type Document struct {
+	ID       string
+	BoardID  string
+	CardID   string
+	Type     DocumentType
+	Text     string
+	UpdateAt int64
+}
+
+// Highlight is the fragment of a document matching the query, HTML escaped
+// with the matching words in <mark> elements.
+type Highlight struct {
+	DocumentID string
+	Type       DocumentType
+	Fragment   string
+}
+
+// Hit is a card with documents matching the query.
+type Hit struct {
+	BoardID string
+	CardID  string
+	Score   float64
+	// UpdateAt is the last update of the matching documents of the card.
+	UpdateAt   int64
+	Highlights []Highlight
+}
+
+// Backend stores the full-text index of the cards.
+type Backend interface {
+	// IsLoaded returns true if the documents of the board are indexed,
+	// false if the board must be loaded before searching it.
+	IsLoaded(boardID string) (bool, error)
+
+	// LoadBoard replaces the documents of the board in the index.
+	LoadBoard(boardID string, docs []Document) error
+
+	// RemoveBoard removes the documents of the board from the index.
+	RemoveBoard(boardID string) error
+
+	// Update adds or replaces the document in the index.
+	Update(doc Document) error
+
+	// Delete removes the document from the index.
+	Delete(documentID string) error
+
+	// Search returns the cards of the boards matching the query, most
+	// relevant first.
+	Search(boardIDs []string, query string) ([]*Hit, error)
+
+	// Close releases the resources of the backend, once the pending
+	// changes are indexed.
+	Close() error
+}
+
+// match is a document matching a query, with its score.
+type match struct {
+	doc      Document
+	score    float64
+	fragment string
+}
+
+// collectHits groups the matching documents by card, the score of a card
+// being the sum of the scores of its documents, with the title boosted.
+// The fragment function returns the highlighted fragment of a match, and
+// is only called for the highlights kept.
+func collectHits(matches []match, fragment func(m *match) string) []*Hit {
+	for i := range matches {
+		if matches[i].doc.Type == DocumentTypeTitle {
+			matches[i].score *= titleBoost
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].doc.ID < matches[j].doc.ID
+	})
+
+	hitsByCard := map[string]*Hit{}
+	hits := []*Hit{}
+	for i := range matches {
+		m := &matches[i]
+		hit, ok := hitsByCard[m.doc.CardID]
+		if !ok {
+			hit = &Hit{BoardID: m.doc.BoardID, CardID: m.doc.CardID}
+			hitsByCard[m.doc.CardID] = hit
+			hits = append(hits, hit)
+		}
+		hit.Score += m.score
+		if m.doc.UpdateAt > hit.UpdateAt {
+			hit.UpdateAt = m.doc.UpdateAt
+		}
+		if len(hit.Highlights) < MaxHighlights {
+			hit.Highlights = append(hit.Highlights, Highlight{
+				DocumentID: m.doc.ID,
+				Type:       m.doc.Type,
+				Fragment:   fragment(m),
+			})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].UpdateAt != hits[j].UpdateAt {
+			return hits[i].UpdateAt > hits[j].UpdateAt
+		}
+		return hits[i].CardID < hits[j].CardID
+	})
+	return hits
+}
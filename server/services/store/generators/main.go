@@ -48,10 +48,13 @@ func main() {
 	if err := buildTransactionalStore(); err != nil {
 		log.Fatal(err)
 	}
+	if err := buildTxStore(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func buildTransactionalStore() error {
-	code, err := generateLayer("TransactionalStore", "transactional_store.go.tmpl")
+	code, err := generateLayer("TransactionalStore", "Store", "transactional_store.go.tmpl")
 	if err != nil {
 		return err
 	}
@@ -63,6 +66,19 @@ func buildTransactionalStore() error {
 	return ioutil.WriteFile(path.Join("sqlstore/public_methods.go"), formatedCode, 0644) //nolint:gosec
 }
 
+func buildTxStore() error {
+	code, err := generateLayer("TxStore", "TxStore", "tx_store.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path.Join("sqlstore/tx_methods.go"), formatedCode, 0644) //nolint:gosec
+}
+
 type methodParam struct {
 	Name string
 	Type string
@@ -80,8 +96,10 @@ type storeMetadata struct {
 }
 
 var blacklistedStoreMethodNames = map[string]bool{
-	"Shutdown": true,
-	"DBType":   true,
+	"Shutdown":         true,
+	"DBType":           true,
+	"DBStats":          true,
+	"RunInTransaction": true,
 }
 
 func extractMethodMetadata(method *ast.Field, src []byte) methodData {
@@ -118,7 +136,9 @@ func extractMethodMetadata(method *ast.Field, src []byte) methodData {
 	return methodData{Params: params, Results: results, WithTransaction: withTransaction}
 }
 
-func extractStoreMetadata() (*storeMetadata, error) {
+// extractStoreMetadata returns the methods of the given interface of the
+// store, including the ones of the interfaces it embeds.
+func extractStoreMetadata(interfaceName string) (*storeMetadata, error) {
 	// Create the AST by parsing src.
 	fset := token.NewFileSet() // positions are relative to fset
 
@@ -136,32 +156,57 @@ func extractStoreMetadata() (*storeMetadata, error) {
 		return nil, err
 	}
 
-	metadata := storeMetadata{Methods: map[string]methodData{}}
-
+	interfaces := map[string]*ast.InterfaceType{}
 	ast.Inspect(f, func(n ast.Node) bool {
 		//nolint:gocritic
 		switch x := n.(type) {
 		case *ast.TypeSpec:
-			if x.Name.Name == "Store" {
-				for _, method := range x.Type.(*ast.InterfaceType).Methods.List {
-					methodName := method.Names[0].Name
-					if _, ok := blacklistedStoreMethodNames[methodName]; ok {
-						continue
-					}
-
-					metadata.Methods[methodName] = extractMethodMetadata(method, src)
-				}
+			if iface, ok := x.Type.(*ast.InterfaceType); ok {
+				interfaces[x.Name.Name] = iface
 			}
 		}
 		return true
 	})
 
+	metadata := storeMetadata{Methods: map[string]methodData{}}
+
+	var addMethods func(name string) error
+	addMethods = func(name string) error {
+		iface, ok := interfaces[name]
+		if !ok {
+			return fmt.Errorf("interface %s not found in store/store.go", name)
+		}
+		for _, method := range iface.Methods.List {
+			if len(method.Names) == 0 {
+				embedded, ok := method.Type.(*ast.Ident)
+				if !ok {
+					return fmt.Errorf("unsupported embedded interface in %s", name)
+				}
+				if err := addMethods(embedded.Name); err != nil {
+					return err
+				}
+				continue
+			}
+
+			methodName := method.Names[0].Name
+			if _, ok := blacklistedStoreMethodNames[methodName]; ok {
+				continue
+			}
+
+			metadata.Methods[methodName] = extractMethodMetadata(method, src)
+		}
+		return nil
+	}
+	if err := addMethods(interfaceName); err != nil {
+		return nil, err
+	}
+
 	return &metadata, nil
 }
 
-func generateLayer(name, templateFile string) ([]byte, error) {
+func generateLayer(name, interfaceName, templateFile string) ([]byte, error) {
 	out := bytes.NewBufferString("")
-	metadata, err := extractStoreMetadata()
+	metadata, err := extractStoreMetadata(interfaceName)
 	if err != nil {
 		return nil, err
 	}
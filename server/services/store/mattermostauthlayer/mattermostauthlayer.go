@@ -3,6 +3,7 @@ package mattermostauthlayer
 import (
 	"database/sql"
 	"encoding/json"
+	"time"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
@@ -77,6 +78,24 @@ func (s *MattermostAuthLayer) GetUserByID(userID string) (*model.User, error) {
 	return &user, nil
 }
 
+// GetUserAvailability reads the availability of a user from their
+// Mattermost status and custom status.
+func (s *MattermostAuthLayer) GetUserAvailability(userID string) (*model.UserAvailability, error) {
+	mmuser, err := s.pluginAPI.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	user := mmUserToFbUser(mmuser)
+	availability := model.UserAvailabilityFromProps(userID, user.Props, time.Now())
+
+	status, err := s.pluginAPI.GetUserStatus(userID)
+	if err != nil {
+		return nil, err
+	}
+	availability.OutOfOffice = status.Status == mmModel.StatusOutOfOffice
+	return availability, nil
+}
+
 func (s *MattermostAuthLayer) GetUserByEmail(email string) (*model.User, error) {
 	mmuser, err := s.pluginAPI.GetUserByEmail(email)
 	if err != nil {
@@ -176,6 +195,10 @@ func (s *MattermostAuthLayer) DeleteSession(sessionID string) error {
 	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
 }
 
+func (s *MattermostAuthLayer) DeleteSessionsForUser(userID string) error {
+	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
+}
+
 func (s *MattermostAuthLayer) CleanUpSessions(expireTime int64) error {
 	return NotSupportedError{"no update allowed from focalboard, update it using mattermost"}
 }
@@ -308,6 +331,51 @@ func (s *MattermostAuthLayer) SearchUsersByTeam(teamID string, searchQuery strin
 	return users, nil
 }
 
+// SearchUserDirectory returns the members of the team whose username,
+// nickname, first or last name starts with the prefix, sorted by
+// username.
+func (s *MattermostAuthLayer) SearchUserDirectory(teamID string, prefix string, offset int, limit int) ([]*model.UserDirectoryEntry, error) {
+	like := func(column string) sq.Sqlizer {
+		return sq.Expr("LOWER("+column+") LIKE ? ESCAPE '"+model.UserDirectoryEscapeChar+"'", model.UserDirectoryLikePattern(prefix))
+	}
+
+	query := s.getQueryBuilder().
+		Select("u.id", "u.username", "u.nickname", "u.firstname", "u.lastname", "b.UserId IS NOT NULL AS is_bot").
+		From("Users as u").
+		Join("TeamMembers as tm ON tm.UserID = u.id").
+		LeftJoin("Bots b ON ( b.UserId = u.id )").
+		Where(sq.Eq{"u.deleteAt": 0}).
+		Where(sq.Eq{"tm.TeamId": teamID}).
+		Where(sq.Eq{"tm.DeleteAt": 0}).
+		Where(sq.NotEq{"u.roles": "system_guest"}).
+		Where(sq.Or{
+			like("u.username"),
+			like("u.nickname"),
+			like("u.firstname"),
+			like("u.lastname"),
+		}).
+		OrderBy("u.username").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	rows, err := query.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	users := []*model.UserDirectoryEntry{}
+	for rows.Next() {
+		var user model.UserDirectoryEntry
+		err := rows.Scan(&user.ID, &user.Username, &user.Nickname, &user.FirstName, &user.LastName, &user.IsBot)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
 func (s *MattermostAuthLayer) usersFromRows(rows *sql.Rows) ([]*model.User, error) {
 	users := []*model.User{}
 
@@ -5,11 +5,13 @@
 package mockstore
 
 import (
+	sql "database/sql"
 	reflect "reflect"
 	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/mattermost/focalboard/server/model"
+	store "github.com/mattermost/focalboard/server/services/store"
 	model0 "github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -50,6 +52,21 @@ func (mr *MockStoreMockRecorder) AddUpdateCategoryBoard(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUpdateCategoryBoard", reflect.TypeOf((*MockStore)(nil).AddUpdateCategoryBoard), arg0, arg1, arg2)
 }
 
+// ClaimJob mocks base method.
+func (m *MockStore) ClaimJob(arg0 []string, arg1 int64) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", arg0, arg1)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob.
+func (mr *MockStoreMockRecorder) ClaimJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockStore)(nil).ClaimJob), arg0, arg1)
+}
+
 // CleanUpSessions mocks base method.
 func (m *MockStore) CleanUpSessions(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -64,6 +81,20 @@ func (mr *MockStoreMockRecorder) CleanUpSessions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpSessions", reflect.TypeOf((*MockStore)(nil).CleanUpSessions), arg0)
 }
 
+// CleanUpUserTokens mocks base method.
+func (m *MockStore) CleanUpUserTokens(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CleanUpUserTokens", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CleanUpUserTokens indicates an expected call of CleanUpUserTokens.
+func (mr *MockStoreMockRecorder) CleanUpUserTokens(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanUpUserTokens", reflect.TypeOf((*MockStore)(nil).CleanUpUserTokens), arg0)
+}
+
 // CreateBoardsAndBlocks mocks base method.
 func (m *MockStore) CreateBoardsAndBlocks(arg0 *model.BoardsAndBlocks, arg1 string) (*model.BoardsAndBlocks, error) {
 	m.ctrl.T.Helper()
@@ -95,6 +126,21 @@ func (mr *MockStoreMockRecorder) CreateBoardsAndBlocksWithAdmin(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBoardsAndBlocksWithAdmin", reflect.TypeOf((*MockStore)(nil).CreateBoardsAndBlocksWithAdmin), arg0, arg1)
 }
 
+// CreateCardReminder mocks base method.
+func (m *MockStore) CreateCardReminder(arg0 *model.CardReminder) (*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCardReminder", arg0)
+	ret0, _ := ret[0].(*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCardReminder indicates an expected call of CreateCardReminder.
+func (mr *MockStoreMockRecorder) CreateCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCardReminder", reflect.TypeOf((*MockStore)(nil).CreateCardReminder), arg0)
+}
+
 // CreateCategory mocks base method.
 func (m *MockStore) CreateCategory(arg0 model.Category) error {
 	m.ctrl.T.Helper()
@@ -109,6 +155,66 @@ func (mr *MockStoreMockRecorder) CreateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0)
 }
 
+// CreateInvite mocks base method.
+func (m *MockStore) CreateInvite(arg0 *model.Invite) (*model.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvite", arg0)
+	ret0, _ := ret[0].(*model.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvite indicates an expected call of CreateInvite.
+func (mr *MockStoreMockRecorder) CreateInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvite", reflect.TypeOf((*MockStore)(nil).CreateInvite), arg0)
+}
+
+// CreateJob mocks base method.
+func (m *MockStore) CreateJob(arg0 *model.Job) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateJob", arg0)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateJob indicates an expected call of CreateJob.
+func (mr *MockStoreMockRecorder) CreateJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockStore)(nil).CreateJob), arg0)
+}
+
+// CreateMention mocks base method.
+func (m *MockStore) CreateMention(arg0 *model.Mention) (*model.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMention", arg0)
+	ret0, _ := ret[0].(*model.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMention indicates an expected call of CreateMention.
+func (mr *MockStoreMockRecorder) CreateMention(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMention", reflect.TypeOf((*MockStore)(nil).CreateMention), arg0)
+}
+
+// CreateMilestone mocks base method.
+func (m *MockStore) CreateMilestone(arg0 *model.Milestone) (*model.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMilestone", arg0)
+	ret0, _ := ret[0].(*model.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMilestone indicates an expected call of CreateMilestone.
+func (mr *MockStoreMockRecorder) CreateMilestone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMilestone", reflect.TypeOf((*MockStore)(nil).CreateMilestone), arg0)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -152,6 +258,49 @@ func (mr *MockStoreMockRecorder) CreateUser(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0)
 }
 
+// CreateUserToken mocks base method.
+func (m *MockStore) CreateUserToken(arg0 *model.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserToken indicates an expected call of CreateUserToken.
+func (mr *MockStoreMockRecorder) CreateUserToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockStore)(nil).CreateUserToken), arg0)
+}
+
+// CreateWebhook mocks base method.
+func (m *MockStore) CreateWebhook(arg0 *model.Webhook) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", arg0)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockStoreMockRecorder) CreateWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockStore)(nil).CreateWebhook), arg0)
+}
+
+// DBStats mocks base method.
+func (m *MockStore) DBStats() sql.DBStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DBStats")
+	ret0, _ := ret[0].(sql.DBStats)
+	return ret0
+}
+
+// DBStats indicates an expected call of DBStats.
+func (mr *MockStoreMockRecorder) DBStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DBStats", reflect.TypeOf((*MockStore)(nil).DBStats))
+}
+
 // DBType mocks base method.
 func (m *MockStore) DBType() string {
 	m.ctrl.T.Helper()
@@ -180,6 +329,21 @@ func (mr *MockStoreMockRecorder) DeleteBlock(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockStore)(nil).DeleteBlock), arg0, arg1)
 }
 
+// DeleteBlockTree mocks base method.
+func (m *MockStore) DeleteBlockTree(arg0, arg1 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlockTree", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBlockTree indicates an expected call of DeleteBlockTree.
+func (mr *MockStoreMockRecorder) DeleteBlockTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlockTree", reflect.TypeOf((*MockStore)(nil).DeleteBlockTree), arg0, arg1)
+}
+
 // DeleteBoard mocks base method.
 func (m *MockStore) DeleteBoard(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -208,6 +372,20 @@ func (mr *MockStoreMockRecorder) DeleteBoardsAndBlocks(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBoardsAndBlocks", reflect.TypeOf((*MockStore)(nil).DeleteBoardsAndBlocks), arg0, arg1)
 }
 
+// DeleteCardReminder mocks base method.
+func (m *MockStore) DeleteCardReminder(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCardReminder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCardReminder indicates an expected call of DeleteCardReminder.
+func (mr *MockStoreMockRecorder) DeleteCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCardReminder", reflect.TypeOf((*MockStore)(nil).DeleteCardReminder), arg0)
+}
+
 // DeleteCategory mocks base method.
 func (m *MockStore) DeleteCategory(arg0, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -222,6 +400,34 @@ func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1, arg2)
 }
 
+// DeleteFileInfo mocks base method.
+func (m *MockStore) DeleteFileInfo(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFileInfo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFileInfo indicates an expected call of DeleteFileInfo.
+func (mr *MockStoreMockRecorder) DeleteFileInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileInfo", reflect.TypeOf((*MockStore)(nil).DeleteFileInfo), arg0)
+}
+
+// DeleteInvite mocks base method.
+func (m *MockStore) DeleteInvite(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteInvite", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteInvite indicates an expected call of DeleteInvite.
+func (mr *MockStoreMockRecorder) DeleteInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteInvite", reflect.TypeOf((*MockStore)(nil).DeleteInvite), arg0)
+}
+
 // DeleteMember mocks base method.
 func (m *MockStore) DeleteMember(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -236,6 +442,20 @@ func (mr *MockStoreMockRecorder) DeleteMember(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMember", reflect.TypeOf((*MockStore)(nil).DeleteMember), arg0, arg1)
 }
 
+// DeleteMilestone mocks base method.
+func (m *MockStore) DeleteMilestone(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMilestone", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMilestone indicates an expected call of DeleteMilestone.
+func (mr *MockStoreMockRecorder) DeleteMilestone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMilestone", reflect.TypeOf((*MockStore)(nil).DeleteMilestone), arg0)
+}
+
 // DeleteNotificationHint mocks base method.
 func (m *MockStore) DeleteNotificationHint(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -264,6 +484,20 @@ func (mr *MockStoreMockRecorder) DeleteSession(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0)
 }
 
+// DeleteSessionsForUser mocks base method.
+func (m *MockStore) DeleteSessionsForUser(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsForUser", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsForUser indicates an expected call of DeleteSessionsForUser.
+func (mr *MockStoreMockRecorder) DeleteSessionsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsForUser", reflect.TypeOf((*MockStore)(nil).DeleteSessionsForUser), arg0)
+}
+
 // DeleteSubscription mocks base method.
 func (m *MockStore) DeleteSubscription(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -278,6 +512,48 @@ func (mr *MockStoreMockRecorder) DeleteSubscription(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockStore)(nil).DeleteSubscription), arg0, arg1)
 }
 
+// DeleteUserToken mocks base method.
+func (m *MockStore) DeleteUserToken(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserToken", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserToken indicates an expected call of DeleteUserToken.
+func (mr *MockStoreMockRecorder) DeleteUserToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserToken", reflect.TypeOf((*MockStore)(nil).DeleteUserToken), arg0)
+}
+
+// DeleteUserTokensForUser mocks base method.
+func (m *MockStore) DeleteUserTokensForUser(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTokensForUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTokensForUser indicates an expected call of DeleteUserTokensForUser.
+func (mr *MockStoreMockRecorder) DeleteUserTokensForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTokensForUser", reflect.TypeOf((*MockStore)(nil).DeleteUserTokensForUser), arg0, arg1)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockStore) DeleteWebhook(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockStoreMockRecorder) DeleteWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockStore)(nil).DeleteWebhook), arg0)
+}
+
 // DuplicateBlock mocks base method.
 func (m *MockStore) DuplicateBlock(arg0, arg1, arg2 string, arg3 bool) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -354,6 +630,21 @@ func (mr *MockStoreMockRecorder) GetBlock(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockStore)(nil).GetBlock), arg0)
 }
 
+// GetBlockCountForBoard mocks base method.
+func (m *MockStore) GetBlockCountForBoard(arg0 string, arg1 model.QueryBlocksPageOptions) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockCountForBoard", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockCountForBoard indicates an expected call of GetBlockCountForBoard.
+func (mr *MockStoreMockRecorder) GetBlockCountForBoard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockCountForBoard", reflect.TypeOf((*MockStore)(nil).GetBlockCountForBoard), arg0, arg1)
+}
+
 // GetBlockCountsByType mocks base method.
 func (m *MockStore) GetBlockCountsByType() (map[string]int64, error) {
 	m.ctrl.T.Helper()
@@ -414,6 +705,51 @@ func (mr *MockStoreMockRecorder) GetBlocksForBoard(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksForBoard", reflect.TypeOf((*MockStore)(nil).GetBlocksForBoard), arg0)
 }
 
+// GetBlocksForBoardPage mocks base method.
+func (m *MockStore) GetBlocksForBoardPage(arg0 string, arg1 model.QueryBlocksPageOptions) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksForBoardPage", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksForBoardPage indicates an expected call of GetBlocksForBoardPage.
+func (mr *MockStoreMockRecorder) GetBlocksForBoardPage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksForBoardPage", reflect.TypeOf((*MockStore)(nil).GetBlocksForBoardPage), arg0, arg1)
+}
+
+// GetBlocksModifiedSince mocks base method.
+func (m *MockStore) GetBlocksModifiedSince(arg0 string, arg1 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksModifiedSince", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksModifiedSince indicates an expected call of GetBlocksModifiedSince.
+func (mr *MockStoreMockRecorder) GetBlocksModifiedSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksModifiedSince", reflect.TypeOf((*MockStore)(nil).GetBlocksModifiedSince), arg0, arg1)
+}
+
+// GetBlocksVersionForBoard mocks base method.
+func (m *MockStore) GetBlocksVersionForBoard(arg0 string) (*model.BlocksVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksVersionForBoard", arg0)
+	ret0, _ := ret[0].(*model.BlocksVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksVersionForBoard indicates an expected call of GetBlocksVersionForBoard.
+func (mr *MockStoreMockRecorder) GetBlocksVersionForBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksVersionForBoard", reflect.TypeOf((*MockStore)(nil).GetBlocksVersionForBoard), arg0)
+}
+
 // GetBlocksWithBoardID mocks base method.
 func (m *MockStore) GetBlocksWithBoardID(arg0 string) ([]model.Block, error) {
 	m.ctrl.T.Helper()
@@ -521,6 +857,21 @@ func (mr *MockStoreMockRecorder) GetBoardAndCardByID(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardAndCardByID", reflect.TypeOf((*MockStore)(nil).GetBoardAndCardByID), arg0)
 }
 
+// GetBoardChangesForUser mocks base method.
+func (m *MockStore) GetBoardChangesForUser(arg0, arg1 string) ([]*model.BoardChanges, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardChangesForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.BoardChanges)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardChangesForUser indicates an expected call of GetBoardChangesForUser.
+func (mr *MockStoreMockRecorder) GetBoardChangesForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardChangesForUser", reflect.TypeOf((*MockStore)(nil).GetBoardChangesForUser), arg0, arg1)
+}
+
 // GetBoardHistory mocks base method.
 func (m *MockStore) GetBoardHistory(arg0 string, arg1 model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -551,6 +902,21 @@ func (mr *MockStoreMockRecorder) GetBoardMemberHistory(arg0, arg1, arg2 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardMemberHistory", reflect.TypeOf((*MockStore)(nil).GetBoardMemberHistory), arg0, arg1, arg2)
 }
 
+// GetBoardsForTeam mocks base method.
+func (m *MockStore) GetBoardsForTeam(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsForTeam", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsForTeam indicates an expected call of GetBoardsForTeam.
+func (mr *MockStoreMockRecorder) GetBoardsForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForTeam), arg0)
+}
+
 // GetBoardsForUserAndTeam mocks base method.
 func (m *MockStore) GetBoardsForUserAndTeam(arg0, arg1 string) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -566,6 +932,51 @@ func (mr *MockStoreMockRecorder) GetBoardsForUserAndTeam(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsForUserAndTeam", reflect.TypeOf((*MockStore)(nil).GetBoardsForUserAndTeam), arg0, arg1)
 }
 
+// GetBoardsWithProperty mocks base method.
+func (m *MockStore) GetBoardsWithProperty(arg0 string) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoardsWithProperty", arg0)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoardsWithProperty indicates an expected call of GetBoardsWithProperty.
+func (mr *MockStoreMockRecorder) GetBoardsWithProperty(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoardsWithProperty", reflect.TypeOf((*MockStore)(nil).GetBoardsWithProperty), arg0)
+}
+
+// GetCardReminder mocks base method.
+func (m *MockStore) GetCardReminder(arg0 string) (*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardReminder", arg0)
+	ret0, _ := ret[0].(*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardReminder indicates an expected call of GetCardReminder.
+func (mr *MockStoreMockRecorder) GetCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardReminder", reflect.TypeOf((*MockStore)(nil).GetCardReminder), arg0)
+}
+
+// GetCardRemindersForUser mocks base method.
+func (m *MockStore) GetCardRemindersForUser(arg0, arg1 string) ([]*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCardRemindersForUser", arg0, arg1)
+	ret0, _ := ret[0].([]*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCardRemindersForUser indicates an expected call of GetCardRemindersForUser.
+func (mr *MockStoreMockRecorder) GetCardRemindersForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCardRemindersForUser", reflect.TypeOf((*MockStore)(nil).GetCardRemindersForUser), arg0, arg1)
+}
+
 // GetCategory mocks base method.
 func (m *MockStore) GetCategory(arg0 string) (*model.Category, error) {
 	m.ctrl.T.Helper()
@@ -581,6 +992,171 @@ func (mr *MockStoreMockRecorder) GetCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0)
 }
 
+// GetDeletedBoardsForTeam mocks base method.
+func (m *MockStore) GetDeletedBoardsForTeam(arg0 string, arg1 int64) ([]*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedBoardsForTeam", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedBoardsForTeam indicates an expected call of GetDeletedBoardsForTeam.
+func (mr *MockStoreMockRecorder) GetDeletedBoardsForTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedBoardsForTeam", reflect.TypeOf((*MockStore)(nil).GetDeletedBoardsForTeam), arg0, arg1)
+}
+
+// GetDeletedCardsForTeam mocks base method.
+func (m *MockStore) GetDeletedCardsForTeam(arg0 string, arg1 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedCardsForTeam", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedCardsForTeam indicates an expected call of GetDeletedCardsForTeam.
+func (mr *MockStoreMockRecorder) GetDeletedCardsForTeam(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedCardsForTeam", reflect.TypeOf((*MockStore)(nil).GetDeletedCardsForTeam), arg0, arg1)
+}
+
+// GetDueCardReminders mocks base method.
+func (m *MockStore) GetDueCardReminders(arg0 int64) ([]*model.CardReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueCardReminders", arg0)
+	ret0, _ := ret[0].([]*model.CardReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueCardReminders indicates an expected call of GetDueCardReminders.
+func (mr *MockStoreMockRecorder) GetDueCardReminders(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueCardReminders", reflect.TypeOf((*MockStore)(nil).GetDueCardReminders), arg0)
+}
+
+// GetFileStorageUsed mocks base method.
+func (m *MockStore) GetFileStorageUsed() (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFileStorageUsed")
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFileStorageUsed indicates an expected call of GetFileStorageUsed.
+func (mr *MockStoreMockRecorder) GetFileStorageUsed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFileStorageUsed", reflect.TypeOf((*MockStore)(nil).GetFileStorageUsed))
+}
+
+// GetInvite mocks base method.
+func (m *MockStore) GetInvite(arg0 string) (*model.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvite", arg0)
+	ret0, _ := ret[0].(*model.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvite indicates an expected call of GetInvite.
+func (mr *MockStoreMockRecorder) GetInvite(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvite", reflect.TypeOf((*MockStore)(nil).GetInvite), arg0)
+}
+
+// GetInviteByToken mocks base method.
+func (m *MockStore) GetInviteByToken(arg0 string) (*model.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInviteByToken", arg0)
+	ret0, _ := ret[0].(*model.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInviteByToken indicates an expected call of GetInviteByToken.
+func (mr *MockStoreMockRecorder) GetInviteByToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInviteByToken", reflect.TypeOf((*MockStore)(nil).GetInviteByToken), arg0)
+}
+
+// GetInvitesForTeam mocks base method.
+func (m *MockStore) GetInvitesForTeam(arg0 string) ([]*model.Invite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitesForTeam", arg0)
+	ret0, _ := ret[0].([]*model.Invite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitesForTeam indicates an expected call of GetInvitesForTeam.
+func (mr *MockStoreMockRecorder) GetInvitesForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitesForTeam", reflect.TypeOf((*MockStore)(nil).GetInvitesForTeam), arg0)
+}
+
+// GetJob mocks base method.
+func (m *MockStore) GetJob(arg0 string) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJob", arg0)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJob indicates an expected call of GetJob.
+func (mr *MockStoreMockRecorder) GetJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJob", reflect.TypeOf((*MockStore)(nil).GetJob), arg0)
+}
+
+// GetJobs mocks base method.
+func (m *MockStore) GetJobs(arg0 model.QueryJobsOptions) ([]*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobs", arg0)
+	ret0, _ := ret[0].([]*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobs indicates an expected call of GetJobs.
+func (mr *MockStoreMockRecorder) GetJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobs", reflect.TypeOf((*MockStore)(nil).GetJobs), arg0)
+}
+
+// GetLastBlockUpdatesForTeam mocks base method.
+func (m *MockStore) GetLastBlockUpdatesForTeam(arg0 string) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastBlockUpdatesForTeam", arg0)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastBlockUpdatesForTeam indicates an expected call of GetLastBlockUpdatesForTeam.
+func (mr *MockStoreMockRecorder) GetLastBlockUpdatesForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastBlockUpdatesForTeam", reflect.TypeOf((*MockStore)(nil).GetLastBlockUpdatesForTeam), arg0)
+}
+
+// GetLatestJob mocks base method.
+func (m *MockStore) GetLatestJob(arg0 string) (*model.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestJob", arg0)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestJob indicates an expected call of GetLatestJob.
+func (mr *MockStoreMockRecorder) GetLatestJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestJob", reflect.TypeOf((*MockStore)(nil).GetLatestJob), arg0)
+}
+
 // GetLicense mocks base method.
 func (m *MockStore) GetLicense() *model0.License {
 	m.ctrl.T.Helper()
@@ -640,6 +1216,51 @@ func (mr *MockStoreMockRecorder) GetMembersForUser(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembersForUser", reflect.TypeOf((*MockStore)(nil).GetMembersForUser), arg0)
 }
 
+// GetMentionsToRemind mocks base method.
+func (m *MockStore) GetMentionsToRemind(arg0 int64) ([]*model.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentionsToRemind", arg0)
+	ret0, _ := ret[0].([]*model.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentionsToRemind indicates an expected call of GetMentionsToRemind.
+func (mr *MockStoreMockRecorder) GetMentionsToRemind(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentionsToRemind", reflect.TypeOf((*MockStore)(nil).GetMentionsToRemind), arg0)
+}
+
+// GetMilestone mocks base method.
+func (m *MockStore) GetMilestone(arg0 string) (*model.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestone", arg0)
+	ret0, _ := ret[0].(*model.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestone indicates an expected call of GetMilestone.
+func (mr *MockStoreMockRecorder) GetMilestone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestone", reflect.TypeOf((*MockStore)(nil).GetMilestone), arg0)
+}
+
+// GetMilestonesForTeam mocks base method.
+func (m *MockStore) GetMilestonesForTeam(arg0 string) ([]*model.Milestone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestonesForTeam", arg0)
+	ret0, _ := ret[0].([]*model.Milestone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestonesForTeam indicates an expected call of GetMilestonesForTeam.
+func (mr *MockStoreMockRecorder) GetMilestonesForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestonesForTeam", reflect.TypeOf((*MockStore)(nil).GetMilestonesForTeam), arg0)
+}
+
 // GetNextNotificationHint mocks base method.
 func (m *MockStore) GetNextNotificationHint(arg0 bool) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -670,6 +1291,21 @@ func (mr *MockStoreMockRecorder) GetNotificationHint(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationHint", reflect.TypeOf((*MockStore)(nil).GetNotificationHint), arg0)
 }
 
+// GetNotificationThread mocks base method.
+func (m *MockStore) GetNotificationThread(arg0, arg1 string) (*model.NotificationThread, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationThread", arg0, arg1)
+	ret0, _ := ret[0].(*model.NotificationThread)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationThread indicates an expected call of GetNotificationThread.
+func (mr *MockStoreMockRecorder) GetNotificationThread(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationThread", reflect.TypeOf((*MockStore)(nil).GetNotificationThread), arg0, arg1)
+}
+
 // GetRegisteredUserCount mocks base method.
 func (m *MockStore) GetRegisteredUserCount() (int, error) {
 	m.ctrl.T.Helper()
@@ -790,6 +1426,36 @@ func (mr *MockStoreMockRecorder) GetSubscriptions(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockStore)(nil).GetSubscriptions), arg0)
 }
 
+// GetSyncOperations mocks base method.
+func (m *MockStore) GetSyncOperations(arg0 string, arg1 int64) ([]*model.SyncOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncOperations", arg0, arg1)
+	ret0, _ := ret[0].([]*model.SyncOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncOperations indicates an expected call of GetSyncOperations.
+func (mr *MockStoreMockRecorder) GetSyncOperations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncOperations", reflect.TypeOf((*MockStore)(nil).GetSyncOperations), arg0, arg1)
+}
+
+// GetSyncRevision mocks base method.
+func (m *MockStore) GetSyncRevision(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSyncRevision", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSyncRevision indicates an expected call of GetSyncRevision.
+func (mr *MockStoreMockRecorder) GetSyncRevision(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSyncRevision", reflect.TypeOf((*MockStore)(nil).GetSyncRevision), arg0)
+}
+
 // GetSystemSetting mocks base method.
 func (m *MockStore) GetSystemSetting(arg0 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -850,6 +1516,21 @@ func (mr *MockStoreMockRecorder) GetTeamCount() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamCount", reflect.TypeOf((*MockStore)(nil).GetTeamCount))
 }
 
+// GetTeamUsage mocks base method.
+func (m *MockStore) GetTeamUsage(arg0 string) (*model.TeamUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamUsage", arg0)
+	ret0, _ := ret[0].(*model.TeamUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamUsage indicates an expected call of GetTeamUsage.
+func (mr *MockStoreMockRecorder) GetTeamUsage(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamUsage", reflect.TypeOf((*MockStore)(nil).GetTeamUsage), arg0)
+}
+
 // GetTeamsForUser mocks base method.
 func (m *MockStore) GetTeamsForUser(arg0 string) ([]*model.Team, error) {
 	m.ctrl.T.Helper()
@@ -880,6 +1561,36 @@ func (mr *MockStoreMockRecorder) GetTemplateBoards(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateBoards", reflect.TypeOf((*MockStore)(nil).GetTemplateBoards), arg0, arg1)
 }
 
+// GetUnreadMentionsForUser mocks base method.
+func (m *MockStore) GetUnreadMentionsForUser(arg0 string) ([]*model.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadMentionsForUser", arg0)
+	ret0, _ := ret[0].([]*model.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadMentionsForUser indicates an expected call of GetUnreadMentionsForUser.
+func (mr *MockStoreMockRecorder) GetUnreadMentionsForUser(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadMentionsForUser", reflect.TypeOf((*MockStore)(nil).GetUnreadMentionsForUser), arg0)
+}
+
+// GetUserAvailability mocks base method.
+func (m *MockStore) GetUserAvailability(arg0 string) (*model.UserAvailability, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvailability", arg0)
+	ret0, _ := ret[0].(*model.UserAvailability)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvailability indicates an expected call of GetUserAvailability.
+func (mr *MockStoreMockRecorder) GetUserAvailability(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvailability", reflect.TypeOf((*MockStore)(nil).GetUserAvailability), arg0)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockStore) GetUserByEmail(arg0 string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -940,6 +1651,21 @@ func (mr *MockStoreMockRecorder) GetUserCategoryBoards(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCategoryBoards", reflect.TypeOf((*MockStore)(nil).GetUserCategoryBoards), arg0, arg1)
 }
 
+// GetUserToken mocks base method.
+func (m *MockStore) GetUserToken(arg0 string) (*model.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserToken", arg0)
+	ret0, _ := ret[0].(*model.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserToken indicates an expected call of GetUserToken.
+func (mr *MockStoreMockRecorder) GetUserToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserToken", reflect.TypeOf((*MockStore)(nil).GetUserToken), arg0)
+}
+
 // GetUsersByTeam mocks base method.
 func (m *MockStore) GetUsersByTeam(arg0 string) ([]*model.User, error) {
 	m.ctrl.T.Helper()
@@ -955,6 +1681,36 @@ func (mr *MockStoreMockRecorder) GetUsersByTeam(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTeam", reflect.TypeOf((*MockStore)(nil).GetUsersByTeam), arg0)
 }
 
+// GetWebhook mocks base method.
+func (m *MockStore) GetWebhook(arg0 string) (*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", arg0)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockStoreMockRecorder) GetWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockStore)(nil).GetWebhook), arg0)
+}
+
+// GetWebhooksForTeam mocks base method.
+func (m *MockStore) GetWebhooksForTeam(arg0 string) ([]*model.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksForTeam", arg0)
+	ret0, _ := ret[0].([]*model.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooksForTeam indicates an expected call of GetWebhooksForTeam.
+func (mr *MockStoreMockRecorder) GetWebhooksForTeam(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksForTeam", reflect.TypeOf((*MockStore)(nil).GetWebhooksForTeam), arg0)
+}
+
 // InsertBlock mocks base method.
 func (m *MockStore) InsertBlock(arg0 *model.Block, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1014,6 +1770,63 @@ func (mr *MockStoreMockRecorder) InsertBoardWithAdmin(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBoardWithAdmin", reflect.TypeOf((*MockStore)(nil).InsertBoardWithAdmin), arg0, arg1)
 }
 
+// InsertSyncOperation mocks base method.
+func (m *MockStore) InsertSyncOperation(arg0 *model.SyncOperation) (*model.SyncOperation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertSyncOperation", arg0)
+	ret0, _ := ret[0].(*model.SyncOperation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertSyncOperation indicates an expected call of InsertSyncOperation.
+func (mr *MockStoreMockRecorder) InsertSyncOperation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertSyncOperation", reflect.TypeOf((*MockStore)(nil).InsertSyncOperation), arg0)
+}
+
+// MarkCardRemindersDelivered mocks base method.
+func (m *MockStore) MarkCardRemindersDelivered(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCardRemindersDelivered", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCardRemindersDelivered indicates an expected call of MarkCardRemindersDelivered.
+func (mr *MockStoreMockRecorder) MarkCardRemindersDelivered(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCardRemindersDelivered", reflect.TypeOf((*MockStore)(nil).MarkCardRemindersDelivered), arg0)
+}
+
+// MarkMentionsRead mocks base method.
+func (m *MockStore) MarkMentionsRead(arg0 string, arg1 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMentionsRead", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMentionsRead indicates an expected call of MarkMentionsRead.
+func (mr *MockStoreMockRecorder) MarkMentionsRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMentionsRead", reflect.TypeOf((*MockStore)(nil).MarkMentionsRead), arg0, arg1)
+}
+
+// MarkMentionsReminded mocks base method.
+func (m *MockStore) MarkMentionsReminded(arg0 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMentionsReminded", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMentionsReminded indicates an expected call of MarkMentionsReminded.
+func (mr *MockStoreMockRecorder) MarkMentionsReminded(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMentionsReminded", reflect.TypeOf((*MockStore)(nil).MarkMentionsReminded), arg0)
+}
+
 // PatchBlock mocks base method.
 func (m *MockStore) PatchBlock(arg0 string, arg1 *model.BlockPatch, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -1086,6 +1899,51 @@ func (mr *MockStoreMockRecorder) PatchUserProps(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchUserProps", reflect.TypeOf((*MockStore)(nil).PatchUserProps), arg0, arg1)
 }
 
+// PurgeDeletedBlockTree mocks base method.
+func (m *MockStore) PurgeDeletedBlockTree(arg0 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBlockTree", arg0)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBlockTree indicates an expected call of PurgeDeletedBlockTree.
+func (mr *MockStoreMockRecorder) PurgeDeletedBlockTree(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBlockTree", reflect.TypeOf((*MockStore)(nil).PurgeDeletedBlockTree), arg0)
+}
+
+// PurgeDeletedBlocks mocks base method.
+func (m *MockStore) PurgeDeletedBlocks(arg0 int64, arg1 uint64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBlocks indicates an expected call of PurgeDeletedBlocks.
+func (mr *MockStoreMockRecorder) PurgeDeletedBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBlocks", reflect.TypeOf((*MockStore)(nil).PurgeDeletedBlocks), arg0, arg1)
+}
+
+// PurgeDeletedBoard mocks base method.
+func (m *MockStore) PurgeDeletedBoard(arg0 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBoard", arg0)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBoard indicates an expected call of PurgeDeletedBoard.
+func (mr *MockStoreMockRecorder) PurgeDeletedBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBoard", reflect.TypeOf((*MockStore)(nil).PurgeDeletedBoard), arg0)
+}
+
 // RefreshSession mocks base method.
 func (m *MockStore) RefreshSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -1114,6 +1972,36 @@ func (mr *MockStoreMockRecorder) RemoveDefaultTemplates(arg0 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDefaultTemplates", reflect.TypeOf((*MockStore)(nil).RemoveDefaultTemplates), arg0)
 }
 
+// ResetStaleJobs mocks base method.
+func (m *MockStore) ResetStaleJobs(arg0 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetStaleJobs", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetStaleJobs indicates an expected call of ResetStaleJobs.
+func (mr *MockStoreMockRecorder) ResetStaleJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetStaleJobs", reflect.TypeOf((*MockStore)(nil).ResetStaleJobs), arg0)
+}
+
+// RevokeAllSharing mocks base method.
+func (m *MockStore) RevokeAllSharing(arg0 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSharing", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSharing indicates an expected call of RevokeAllSharing.
+func (mr *MockStoreMockRecorder) RevokeAllSharing(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSharing", reflect.TypeOf((*MockStore)(nil).RevokeAllSharing), arg0)
+}
+
 // RunDataRetention mocks base method.
 func (m *MockStore) RunDataRetention(arg0, arg1 int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1129,6 +2017,62 @@ func (mr *MockStoreMockRecorder) RunDataRetention(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDataRetention", reflect.TypeOf((*MockStore)(nil).RunDataRetention), arg0, arg1)
 }
 
+// RunInTransaction mocks base method.
+func (m *MockStore) RunInTransaction(arg0 func(store.TxStore) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTransaction", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInTransaction indicates an expected call of RunInTransaction.
+func (mr *MockStoreMockRecorder) RunInTransaction(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTransaction", reflect.TypeOf((*MockStore)(nil).RunInTransaction), arg0)
+}
+
+// SaveBoardView mocks base method.
+func (m *MockStore) SaveBoardView(arg0 *model.BoardView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBoardView", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBoardView indicates an expected call of SaveBoardView.
+func (mr *MockStoreMockRecorder) SaveBoardView(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBoardView", reflect.TypeOf((*MockStore)(nil).SaveBoardView), arg0)
+}
+
+// SaveFileContent mocks base method.
+func (m *MockStore) SaveFileContent(arg0 *model.FileContent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFileContent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFileContent indicates an expected call of SaveFileContent.
+func (mr *MockStoreMockRecorder) SaveFileContent(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileContent", reflect.TypeOf((*MockStore)(nil).SaveFileContent), arg0)
+}
+
+// SaveFileInfo mocks base method.
+func (m *MockStore) SaveFileInfo(arg0 *model.FileInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveFileInfo", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveFileInfo indicates an expected call of SaveFileInfo.
+func (mr *MockStoreMockRecorder) SaveFileInfo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveFileInfo", reflect.TypeOf((*MockStore)(nil).SaveFileInfo), arg0)
+}
+
 // SaveMember mocks base method.
 func (m *MockStore) SaveMember(arg0 *model.BoardMember) (*model.BoardMember, error) {
 	m.ctrl.T.Helper()
@@ -1144,6 +2088,35 @@ func (mr *MockStoreMockRecorder) SaveMember(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMember", reflect.TypeOf((*MockStore)(nil).SaveMember), arg0)
 }
 
+// SaveNotificationThread mocks base method.
+func (m *MockStore) SaveNotificationThread(arg0 *model.NotificationThread) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotificationThread", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNotificationThread indicates an expected call of SaveNotificationThread.
+func (mr *MockStoreMockRecorder) SaveNotificationThread(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotificationThread", reflect.TypeOf((*MockStore)(nil).SaveNotificationThread), arg0)
+}
+
+// SearchBlocksForBoards mocks base method.
+func (m *MockStore) SearchBlocksForBoards(arg0 []string, arg1 string, arg2 []model.BlockType, arg3 model.SearchFilters) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBlocksForBoards", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBlocksForBoards indicates an expected call of SearchBlocksForBoards.
+func (mr *MockStoreMockRecorder) SearchBlocksForBoards(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBlocksForBoards", reflect.TypeOf((*MockStore)(nil).SearchBlocksForBoards), arg0, arg1, arg2, arg3)
+}
+
 // SearchBoardsForUser mocks base method.
 func (m *MockStore) SearchBoardsForUser(arg0, arg1 string) ([]*model.Board, error) {
 	m.ctrl.T.Helper()
@@ -1159,6 +2132,36 @@ func (mr *MockStoreMockRecorder) SearchBoardsForUser(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBoardsForUser", reflect.TypeOf((*MockStore)(nil).SearchBoardsForUser), arg0, arg1)
 }
 
+// SearchFileContents mocks base method.
+func (m *MockStore) SearchFileContents(arg0 []string, arg1 string) ([]*model.FileContent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFileContents", arg0, arg1)
+	ret0, _ := ret[0].([]*model.FileContent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFileContents indicates an expected call of SearchFileContents.
+func (mr *MockStoreMockRecorder) SearchFileContents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFileContents", reflect.TypeOf((*MockStore)(nil).SearchFileContents), arg0, arg1)
+}
+
+// SearchUserDirectory mocks base method.
+func (m *MockStore) SearchUserDirectory(arg0, arg1 string, arg2, arg3 int) ([]*model.UserDirectoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUserDirectory", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.UserDirectoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUserDirectory indicates an expected call of SearchUserDirectory.
+func (mr *MockStoreMockRecorder) SearchUserDirectory(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserDirectory", reflect.TypeOf((*MockStore)(nil).SearchUserDirectory), arg0, arg1, arg2, arg3)
+}
+
 // SearchUsersByTeam mocks base method.
 func (m *MockStore) SearchUsersByTeam(arg0, arg1 string) ([]*model.User, error) {
 	m.ctrl.T.Helper()
@@ -1216,6 +2219,21 @@ func (mr *MockStoreMockRecorder) UndeleteBlock(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlock", reflect.TypeOf((*MockStore)(nil).UndeleteBlock), arg0, arg1)
 }
 
+// UndeleteBlockTree mocks base method.
+func (m *MockStore) UndeleteBlockTree(arg0, arg1 string) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UndeleteBlockTree", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UndeleteBlockTree indicates an expected call of UndeleteBlockTree.
+func (mr *MockStoreMockRecorder) UndeleteBlockTree(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBlockTree", reflect.TypeOf((*MockStore)(nil).UndeleteBlockTree), arg0, arg1)
+}
+
 // UndeleteBoard mocks base method.
 func (m *MockStore) UndeleteBoard(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -1230,6 +2248,20 @@ func (mr *MockStoreMockRecorder) UndeleteBoard(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UndeleteBoard", reflect.TypeOf((*MockStore)(nil).UndeleteBoard), arg0, arg1)
 }
 
+// UpdateCardReminder mocks base method.
+func (m *MockStore) UpdateCardReminder(arg0 *model.CardReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCardReminder", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCardReminder indicates an expected call of UpdateCardReminder.
+func (mr *MockStoreMockRecorder) UpdateCardReminder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCardReminder", reflect.TypeOf((*MockStore)(nil).UpdateCardReminder), arg0)
+}
+
 // UpdateCategory mocks base method.
 func (m *MockStore) UpdateCategory(arg0 model.Category) error {
 	m.ctrl.T.Helper()
@@ -1244,6 +2276,34 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0)
 }
 
+// UpdateJob mocks base method.
+func (m *MockStore) UpdateJob(arg0 *model.Job) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJob indicates an expected call of UpdateJob.
+func (mr *MockStoreMockRecorder) UpdateJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJob", reflect.TypeOf((*MockStore)(nil).UpdateJob), arg0)
+}
+
+// UpdateMilestone mocks base method.
+func (m *MockStore) UpdateMilestone(arg0 *model.Milestone) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMilestone", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMilestone indicates an expected call of UpdateMilestone.
+func (mr *MockStoreMockRecorder) UpdateMilestone(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMilestone", reflect.TypeOf((*MockStore)(nil).UpdateMilestone), arg0)
+}
+
 // UpdateSession mocks base method.
 func (m *MockStore) UpdateSession(arg0 *model.Session) error {
 	m.ctrl.T.Helper()
@@ -1314,6 +2374,20 @@ func (mr *MockStoreMockRecorder) UpdateUserPasswordByID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPasswordByID", reflect.TypeOf((*MockStore)(nil).UpdateUserPasswordByID), arg0, arg1)
 }
 
+// UpdateWebhook mocks base method.
+func (m *MockStore) UpdateWebhook(arg0 *model.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockStoreMockRecorder) UpdateWebhook(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockStore)(nil).UpdateWebhook), arg0)
+}
+
 // UpsertNotificationHint mocks base method.
 func (m *MockStore) UpsertNotificationHint(arg0 *model.NotificationHint, arg1 time.Duration) (*model.NotificationHint, error) {
 	m.ctrl.T.Helper()
@@ -1370,3 +2444,18 @@ func (mr *MockStoreMockRecorder) UpsertTeamSignupToken(arg0 interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTeamSignupToken", reflect.TypeOf((*MockStore)(nil).UpsertTeamSignupToken), arg0)
 }
+
+// UseInvite mocks base method.
+func (m *MockStore) UseInvite(arg0 string, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseInvite", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseInvite indicates an expected call of UseInvite.
+func (mr *MockStoreMockRecorder) UseInvite(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseInvite", reflect.TypeOf((*MockStore)(nil).UseInvite), arg0, arg1)
+}
@@ -3,7 +3,9 @@ package sqlstore
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -64,7 +66,7 @@ func (s *SQLStore) blockFields() []string {
 }
 
 func (s *SQLStore) getBlocksWithParentAndType(db sq.BaseRunner, boardID, parentID string, blockType string) ([]model.Block, error) {
-	query := s.getQueryBuilder(db).
+	query := s.getQueryBuilder(s.withStmtCache(db)).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"board_id": boardID}).
@@ -183,6 +185,165 @@ func (s *SQLStore) getBlocksForBoard(db sq.BaseRunner, boardID string) ([]model.
 	return s.blocksFromRows(rows)
 }
 
+// getBlocksForBoardPage returns the blocks of the board sorted by id, so
+// they can be read in pages by passing the id of the last block read.
+func (s *SQLStore) getBlocksForBoardPage(db sq.BaseRunner, boardID string, opts model.QueryBlocksPageOptions) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		OrderBy("id")
+	query = filterBlocksPage(query, opts)
+
+	if opts.AfterID != "" {
+		query = query.Where(sq.Gt{"id": opts.AfterID})
+	}
+
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlocksForBoardPage ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+// getBlockCountForBoard returns the number of blocks of the board that
+// match the parent and type filters of the options, over all the pages.
+func (s *SQLStore) getBlockCountForBoard(db sq.BaseRunner, boardID string, opts model.QueryBlocksPageOptions) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID})
+	query = filterBlocksPage(query, opts)
+
+	var count int64
+	if err := query.QueryRow().Scan(&count); err != nil {
+		s.logger.Error(`getBlockCountForBoard ERROR`, mlog.Err(err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// getBlocksVersionForBoard returns the number of blocks of the board and
+// the time of the last update of one of them.
+func (s *SQLStore) getBlocksVersionForBoard(db sq.BaseRunner, boardID string) (*model.BlocksVersion, error) {
+	query := s.getQueryBuilder(db).
+		Select("COUNT(*)", "COALESCE(MAX(update_at), 0)").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID})
+
+	version := &model.BlocksVersion{}
+	if err := query.QueryRow().Scan(&version.Count, &version.LastUpdateAt); err != nil {
+		s.logger.Error(`getBlocksVersionForBoard ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	return version, nil
+}
+
+// getBlocksModifiedSince returns the blocks of the board updated after the
+// given time, followed by the blocks deleted after it as they were when
+// deleted, so that a client can catch up with the changes it missed.
+func (s *SQLStore) getBlocksModifiedSince(db sq.BaseRunner, boardID string, since int64) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Gt{"update_at": since})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBlocksModifiedSince ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	blocks, err := s.blocksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedQuery := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"board_id": boardID}).
+		Where("id NOT IN (SELECT id FROM " + s.tablePrefix + "blocks)").
+		GroupBy("id").
+		Having(sq.Gt{"MAX(delete_at)": since})
+
+	ids, err := s.queryIDs(deletedQuery)
+	if err != nil {
+		s.logger.Error(`getBlocksModifiedSince ERROR`, mlog.Err(err))
+		return nil, err
+	}
+
+	deleted, err := s.getLatestBlockVersions(db, ids)
+	if err != nil {
+		return nil, err
+	}
+	return append(blocks, deleted...), nil
+}
+
+// searchBlocksForBoards returns the blocks of the types of the boards
+// whose title contains any of the words of the term, updated within the
+// range of the filters. Without words, it returns every block in the
+// range, or none if the filters are empty. The card filters are applied
+// by the caller, which resolves them against the board properties.
+func (s *SQLStore) searchBlocksForBoards(db sq.BaseRunner, boardIDs []string, term string, blockTypes []model.BlockType, filters model.SearchFilters) ([]model.Block, error) {
+	words := strings.Fields(strings.ToLower(term))
+	if len(boardIDs) == 0 || len(blockTypes) == 0 || (len(words) == 0 && filters.IsEmpty()) {
+		return []model.Block{}, nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(s.blockFields()...).
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"board_id": boardIDs}).
+		Where(sq.Eq{"type": blockTypes})
+
+	if len(words) > 0 {
+		conditions := sq.Or{}
+		for _, word := range words {
+			conditions = append(conditions, sq.Like{"lower(title)": "%" + word + "%"})
+		}
+		query = query.Where(conditions)
+	}
+	if filters.UpdatedAfter != 0 {
+		query = query.Where(sq.GtOrEq{"update_at": filters.UpdatedAfter})
+	}
+	if filters.UpdatedBefore != 0 {
+		query = query.Where(sq.Lt{"update_at": filters.UpdatedBefore})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchBlocksForBoards ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.blocksFromRows(rows)
+}
+
+func filterBlocksPage(query sq.SelectBuilder, opts model.QueryBlocksPageOptions) sq.SelectBuilder {
+	if opts.ParentID != nil {
+		query = query.Where(sq.Eq{"parent_id": *opts.ParentID})
+	}
+	if opts.BlockType != "" {
+		query = query.Where(sq.Eq{"type": opts.BlockType})
+	}
+	if len(opts.ExcludedIDs) > 0 {
+		query = query.Where(sq.NotEq{"id": opts.ExcludedIDs}).
+			Where(sq.NotEq{"parent_id": opts.ExcludedIDs})
+	}
+	return query
+}
+
 func (s *SQLStore) blocksFromRows(rows *sql.Rows) ([]model.Block, error) {
 	results := []model.Block{}
 
@@ -249,7 +410,11 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 	block.UpdateAt = utils.GetMillis()
 	block.ModifiedBy = userID
 
-	insertQuery := s.getQueryBuilder(db).Insert("").
+	// the queries of InsertBlock and the patches built on it always have
+	// the same SQL, so their statements are prepared once
+	runner := s.withStmtCache(db)
+
+	insertQuery := s.getQueryBuilder(runner).Insert("").
 		Columns(
 			"channel_id",
 			"id",
@@ -284,7 +449,7 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 
 	if existingBlock != nil {
 		// block with ID exists, so this is an update operation
-		query := s.getQueryBuilder(db).Update(s.tablePrefix+"blocks").
+		query := s.getQueryBuilder(runner).Update(s.tablePrefix+"blocks").
 			Where(sq.Eq{"id": block.ID}).
 			Where(sq.Eq{"board_id": block.BoardID}).
 			Set("parent_id", block.ParentID).
@@ -318,7 +483,40 @@ func (s *SQLStore) insertBlock(db sq.BaseRunner, block *model.Block, userID stri
 	return nil
 }
 
+// checkBlockUnchangedSince returns an ErrBlockPatchConflict if the block
+// was updated after the given time. On MySQL and Postgres the row stays
+// locked until the end of the transaction, so that no other change can be
+// written between the check and the patch.
+func (s *SQLStore) checkBlockUnchangedSince(db sq.BaseRunner, blockID string, since int64) error {
+	query := s.getQueryBuilder(db).
+		Select("update_at").
+		From(s.tablePrefix + "blocks").
+		Where(sq.Eq{"id": blockID})
+	if s.dbType != model.SqliteDBType {
+		query = query.Suffix("FOR UPDATE")
+	}
+
+	var updateAt int64
+	if err := query.QueryRow().Scan(&updateAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return BlockNotFoundErr{blockID}
+		}
+		s.logger.Error(`checkBlockUnchangedSince ERROR`, mlog.String("blockID", blockID), mlog.Err(err))
+		return err
+	}
+	if updateAt > since {
+		return model.NewErrBlockPatchConflict(blockID, []string{})
+	}
+	return nil
+}
+
 func (s *SQLStore) patchBlock(db sq.BaseRunner, blockID string, blockPatch *model.BlockPatch, userID string) error {
+	if blockPatch.BaseUpdateAt != 0 {
+		if err := s.checkBlockUnchangedSince(db, blockID, blockPatch.BaseUpdateAt); err != nil {
+			return err
+		}
+	}
+
 	existingBlock, err := s.getBlock(db, blockID)
 	if err != nil {
 		return err
@@ -367,12 +565,16 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 		return nil // deleting non-exiting block is not considered an error (for now)
 	}
 
+	return s.deleteBlockAt(db, block, modifiedBy, utils.GetMillis())
+}
+
+// deleteBlockAt moves a block to the history as deleted at the given time.
+func (s *SQLStore) deleteBlockAt(db sq.BaseRunner, block *model.Block, modifiedBy string, now int64) error {
 	fieldsJSON, err := json.Marshal(block.Fields)
 	if err != nil {
 		return err
 	}
 
-	now := utils.GetMillis()
 	insertQuery := s.getQueryBuilder(db).Insert(s.tablePrefix+"blocks_history").
 		Columns(
 			"board_id",
@@ -409,7 +611,7 @@ func (s *SQLStore) deleteBlock(db sq.BaseRunner, blockID string, modifiedBy stri
 
 	deleteQuery := s.getQueryBuilder(db).
 		Delete(s.tablePrefix + "blocks").
-		Where(sq.Eq{"id": blockID})
+		Where(sq.Eq{"id": block.ID})
 
 	if _, err := deleteQuery.Exec(); err != nil {
 		return err
@@ -523,8 +725,41 @@ func (s *SQLStore) getBlockCountsByType(db sq.BaseRunner) (map[string]int64, err
 	return m, nil
 }
 
-func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, error) {
+// getLastBlockUpdatesForTeam returns the time of the last update of the
+// blocks of each board of the team that has blocks, by board id.
+func (s *SQLStore) getLastBlockUpdatesForTeam(db sq.BaseRunner, teamID string) (map[string]int64, error) {
 	query := s.getQueryBuilder(db).
+		Select(
+			"bl.board_id",
+			"MAX(bl.update_at) AS update_at",
+		).
+		From(s.tablePrefix + "blocks AS bl").
+		Join(s.tablePrefix + "boards AS b ON b.id = bl.board_id").
+		Where(sq.Eq{"b.team_id": teamID}).
+		GroupBy("bl.board_id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getLastBlockUpdatesForTeam ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	m := make(map[string]int64)
+	for rows.Next() {
+		var boardID string
+		var updateAt int64
+		if err := rows.Scan(&boardID, &updateAt); err != nil {
+			s.logger.Error("Failed to fetch last block update", mlog.Err(err))
+			return nil, err
+		}
+		m[boardID] = updateAt
+	}
+	return m, nil
+}
+
+func (s *SQLStore) getBlock(db sq.BaseRunner, blockID string) (*model.Block, error) {
+	query := s.getQueryBuilder(s.withStmtCache(db)).
 		Select(s.blockFields()...).
 		From(s.tablePrefix + "blocks").
 		Where(sq.Eq{"id": blockID})
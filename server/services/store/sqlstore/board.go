@@ -276,6 +276,24 @@ func (s *SQLStore) getBoardsForUserAndTeam(db sq.BaseRunner, userID, teamID stri
 	return s.boardsFromRows(rows)
 }
 
+// getBoardsForTeam returns all the boards and templates of a team,
+// regardless of their members.
+func (s *SQLStore) getBoardsForTeam(db sq.BaseRunner, teamID string) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"team_id": teamID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsForTeam ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.boardsFromRows(rows)
+}
+
 func (s *SQLStore) insertBoard(db sq.BaseRunner, board *model.Board, userID string) (*model.Board, error) {
 	propertiesBytes, err := s.MarshalJSONB(board.Properties)
 	if err != nil {
@@ -656,6 +674,42 @@ func (s *SQLStore) searchBoardsForUser(db sq.BaseRunner, term, userID string) ([
 	return s.boardsFromRows(rows)
 }
 
+// getBoardsWithProperty returns the boards that have a value for the
+// given key of their properties.
+func (s *SQLStore) getBoardsWithProperty(db sq.BaseRunner, key string) ([]*model.Board, error) {
+	column := "properties"
+	if s.dbType == model.PostgresDBType {
+		column = "CAST(properties AS TEXT)"
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(boardFields("")...).
+		From(s.tablePrefix+"boards").
+		Where(sq.Eq{"is_template": false}).
+		Where(column+" LIKE ?", "%\""+key+"\"%")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`getBoardsWithProperty ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	boards, err := s.boardsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// the key can also appear inside the values of other properties
+	filtered := make([]*model.Board, 0, len(boards))
+	for _, board := range boards {
+		if _, ok := board.Properties[key]; ok {
+			filtered = append(filtered, board)
+		}
+	}
+	return filtered, nil
+}
+
 func (s *SQLStore) getBoardHistory(db sq.BaseRunner, boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	var order string
 	if opts.Descending {
@@ -746,8 +800,8 @@ func (s *SQLStore) undeleteBoard(db sq.BaseRunner, boardID string, modifiedBy st
 		board.CreatedBy,
 		modifiedBy,
 		board.Type,
-		board.MinimumRole,
 		board.Title,
+		board.MinimumRole,
 		board.Description,
 		board.Icon,
 		board.ShowDescription,
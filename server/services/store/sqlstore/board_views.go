@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// saveBoardView records the last time the user viewed the board.
+func (s *SQLStore) saveBoardView(db sq.BaseRunner, view *model.BoardView) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"board_views").
+		Columns("board_id", "user_id", "viewed_at").
+		Values(view.BoardID, view.UserID, view.ViewedAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE viewed_at = ?", view.ViewedAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (board_id, user_id) DO UPDATE SET viewed_at = ?", view.ViewedAt)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save board view",
+			mlog.String("board_id", view.BoardID),
+			mlog.String("user_id", view.UserID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getBoardChangesForUser counts, for every board of the team the user
+// has viewed, the blocks changed by other users since the last view.
+func (s *SQLStore) getBoardChangesForUser(db sq.BaseRunner, userID, teamID string) ([]*model.BoardChanges, error) {
+	query := s.getQueryBuilder(db).
+		Select("bv.board_id", "bv.viewed_at", "COUNT(b.id)").
+		From(s.tablePrefix+"board_views AS bv").
+		Join(s.tablePrefix+"boards AS bo ON bo.id = bv.board_id").
+		LeftJoin(s.tablePrefix+"blocks AS b ON b.board_id = bv.board_id AND b.update_at > bv.viewed_at AND b.modified_by <> ?", userID).
+		Where(sq.Eq{"bv.user_id": userID}).
+		Where(sq.Eq{"bo.team_id": teamID}).
+		GroupBy("bv.board_id", "bv.viewed_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch board changes for user",
+			mlog.String("user_id", userID),
+			mlog.String("team_id", teamID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	changes := []*model.BoardChanges{}
+	for rows.Next() {
+		var boardChanges model.BoardChanges
+		if err := rows.Scan(&boardChanges.BoardID, &boardChanges.LastViewedAt, &boardChanges.ChangeCount); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &boardChanges)
+	}
+	return changes, nil
+}
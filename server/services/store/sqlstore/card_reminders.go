@@ -0,0 +1,227 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var cardReminderFields = []string{
+	"id",
+	"user_id",
+	"team_id",
+	"board_id",
+	"card_id",
+	"note",
+	"remind_at",
+	"create_at",
+	"update_at",
+	"delivered_at",
+}
+
+func (s *SQLStore) cardRemindersFromRows(rows *sql.Rows) ([]*model.CardReminder, error) {
+	reminders := []*model.CardReminder{}
+
+	for rows.Next() {
+		var reminder model.CardReminder
+		var teamID, note sql.NullString
+		var deliveredAt sql.NullInt64
+		err := rows.Scan(
+			&reminder.ID,
+			&reminder.UserID,
+			&teamID,
+			&reminder.BoardID,
+			&reminder.CardID,
+			&note,
+			&reminder.RemindAt,
+			&reminder.CreateAt,
+			&reminder.UpdateAt,
+			&deliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		reminder.TeamID = teamID.String
+		reminder.Note = note.String
+		reminder.DeliveredAt = deliveredAt.Int64
+
+		reminders = append(reminders, &reminder)
+	}
+	return reminders, nil
+}
+
+// createCardReminder stores a new pending reminder.
+func (s *SQLStore) createCardReminder(db sq.BaseRunner, reminder *model.CardReminder) (*model.CardReminder, error) {
+	if err := reminder.IsValid(); err != nil {
+		return nil, err
+	}
+
+	newReminder := *reminder
+	newReminder.ID = utils.NewID(utils.IDTypeNone)
+	now := utils.GetMillis()
+	newReminder.CreateAt = now
+	newReminder.UpdateAt = now
+	newReminder.DeliveredAt = 0
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"card_reminders").
+		Columns(cardReminderFields...).
+		Values(
+			newReminder.ID,
+			newReminder.UserID,
+			newReminder.TeamID,
+			newReminder.BoardID,
+			newReminder.CardID,
+			newReminder.Note,
+			newReminder.RemindAt,
+			newReminder.CreateAt,
+			newReminder.UpdateAt,
+			newReminder.DeliveredAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create card reminder",
+			mlog.String("user_id", reminder.UserID),
+			mlog.String("card_id", reminder.CardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &newReminder, nil
+}
+
+func (s *SQLStore) getCardReminder(db sq.BaseRunner, reminderID string) (*model.CardReminder, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderFields...).
+		From(s.tablePrefix + "card_reminders").
+		Where(sq.Eq{"id": reminderID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card reminder", mlog.String("id", reminderID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	reminders, err := s.cardRemindersFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(reminders) == 0 {
+		return nil, model.NewErrNotFound(reminderID)
+	}
+	return reminders[0], nil
+}
+
+// getCardRemindersForUser fetches the pending reminders of a user, the
+// soonest first. Only the reminders of the card are fetched if cardID
+// isn't empty.
+func (s *SQLStore) getCardRemindersForUser(db sq.BaseRunner, userID, cardID string) ([]*model.CardReminder, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderFields...).
+		From(s.tablePrefix+"card_reminders").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"delivered_at": 0}).
+		OrderBy("remind_at", "id")
+
+	if cardID != "" {
+		query = query.Where(sq.Eq{"card_id": cardID})
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch card reminders for user", mlog.String("user_id", userID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRemindersFromRows(rows)
+}
+
+// updateCardReminder replaces the time and the note of a reminder, which
+// becomes pending again.
+func (s *SQLStore) updateCardReminder(db sq.BaseRunner, reminder *model.CardReminder) error {
+	if err := reminder.IsValid(); err != nil {
+		return err
+	}
+
+	reminder.UpdateAt = utils.GetMillis()
+	reminder.DeliveredAt = 0
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_reminders").
+		Set("note", reminder.Note).
+		Set("remind_at", reminder.RemindAt).
+		Set("update_at", reminder.UpdateAt).
+		Set("delivered_at", reminder.DeliveredAt).
+		Where(sq.Eq{"id": reminder.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update card reminder", mlog.String("id", reminder.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteCardReminder(db sq.BaseRunner, reminderID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "card_reminders").
+		Where(sq.Eq{"id": reminderID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete card reminder", mlog.String("id", reminderID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound(reminderID)
+	}
+	return nil
+}
+
+// getDueCardReminders fetches the pending reminders due at the given time.
+func (s *SQLStore) getDueCardReminders(db sq.BaseRunner, remindBefore int64) ([]*model.CardReminder, error) {
+	query := s.getQueryBuilder(db).
+		Select(cardReminderFields...).
+		From(s.tablePrefix+"card_reminders").
+		Where(sq.Eq{"delivered_at": 0}).
+		Where(sq.LtOrEq{"remind_at": remindBefore}).
+		OrderBy("remind_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch due card reminders", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.cardRemindersFromRows(rows)
+}
+
+// markCardRemindersDelivered records that the reminders were delivered.
+func (s *SQLStore) markCardRemindersDelivered(db sq.BaseRunner, reminderIDs []string) error {
+	if len(reminderIDs) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"card_reminders").
+		Set("delivered_at", utils.GetMillis()).
+		Where(sq.Eq{"id": reminderIDs})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot mark card reminders as delivered", mlog.Err(err))
+		return err
+	}
+	return nil
+}
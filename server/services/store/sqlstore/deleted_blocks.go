@@ -0,0 +1,179 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// deleteBlockTree deletes a block and its descendants, moving them to the
+// history with the same delete time so that they can be restored
+// together. It returns the deleted blocks, the block first.
+func (s *SQLStore) deleteBlockTree(db sq.BaseRunner, blockID string, modifiedBy string) ([]model.Block, error) {
+	block, err := s.getBlock(db, blockID)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		s.logger.Warn("deleteBlockTree block not found", mlog.String("block_id", blockID))
+		return []model.Block{}, nil
+	}
+
+	deleted := []model.Block{*block}
+	for i := 0; i < len(deleted); i++ {
+		children, err := s.getBlocksWithParent(db, block.BoardID, deleted[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		deleted = append(deleted, children...)
+	}
+
+	now := utils.GetMillis()
+	for i := range deleted {
+		if err := s.deleteBlockAt(db, &deleted[i], modifiedBy, now); err != nil {
+			return nil, err
+		}
+	}
+	return deleted, nil
+}
+
+// undeleteBlockTree restores a deleted block along with the descendants
+// that were deleted with it. It returns the restored blocks, the block
+// first, or none if the block isn't deleted.
+func (s *SQLStore) undeleteBlockTree(db sq.BaseRunner, blockID string, modifiedBy string) ([]model.Block, error) {
+	latest, err := s.getBlockHistory(db, blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(latest) == 0 || latest[0].DeleteAt == 0 {
+		s.logger.Warn("undeleteBlockTree block not deleted", mlog.String("block_id", blockID))
+		return []model.Block{}, nil
+	}
+	boardID := latest[0].BoardID
+	deleteAt := latest[0].DeleteAt
+
+	restoredIDs := []string{blockID}
+	for parentIDs := restoredIDs; len(parentIDs) > 0; {
+		childIDs, err := s.getDeletedChildIDs(db, boardID, parentIDs, deleteAt)
+		if err != nil {
+			return nil, err
+		}
+		parentIDs = childIDs
+		restoredIDs = append(restoredIDs, childIDs...)
+	}
+
+	restored := make([]model.Block, 0, len(restoredIDs))
+	for _, id := range restoredIDs {
+		if err := s.undeleteBlock(db, id, modifiedBy); err != nil {
+			return nil, err
+		}
+		block, err := s.getBlock(db, id)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			restored = append(restored, *block)
+		}
+	}
+	return restored, nil
+}
+
+// getDeletedChildIDs returns the ids of the children of the blocks that
+// were deleted at the given time and weren't restored since.
+func (s *SQLStore) getDeletedChildIDs(db sq.BaseRunner, boardID string, parentIDs []string, deleteAt int64) ([]string, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		Distinct().
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Eq{"parent_id": parentIDs}).
+		Where(sq.Eq{"delete_at": deleteAt})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot get deleted children", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	candidateIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		candidateIDs = append(candidateIDs, id)
+	}
+
+	childIDs := []string{}
+	for _, id := range candidateIDs {
+		latest, err := s.getBlockHistory(db, id, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) != 0 && latest[0].DeleteAt == deleteAt {
+			childIDs = append(childIDs, id)
+		}
+	}
+	return childIDs, nil
+}
+
+// purgeDeletedBlocks removes the history of at most limit blocks deleted
+// before the given time, after which they can't be restored anymore. It
+// returns the blocks as they were when deleted.
+func (s *SQLStore) purgeDeletedBlocks(db sq.BaseRunner, deletedBefore int64, limit uint64) ([]model.Block, error) {
+	// the latest deletion of a block is the one that counts, as it may have
+	// been restored and deleted again since
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "blocks_history").
+		Where("id NOT IN (SELECT id FROM " + s.tablePrefix + "blocks)").
+		GroupBy("id").
+		Having(sq.Gt{"MAX(delete_at)": 0}).
+		Having(sq.Lt{"MAX(delete_at)": deletedBefore}).
+		OrderBy("id").
+		Limit(limit)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot get the deleted blocks to purge", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	purgedIDs := []string{}
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		purgedIDs = append(purgedIDs, id)
+	}
+
+	purged := make([]model.Block, 0, len(purgedIDs))
+	for _, id := range purgedIDs {
+		latest, err := s.getBlockHistory(db, id, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+		if err != nil {
+			return nil, err
+		}
+		purged = append(purged, latest...)
+	}
+	if len(purgedIDs) == 0 {
+		return purged, nil
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": purgedIDs})
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		s.logger.Error("Cannot purge the deleted blocks", mlog.Err(err))
+		return nil, err
+	}
+	return purged, nil
+}
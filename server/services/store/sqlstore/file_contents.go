@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var fileContentFields = []string{
+	"file_id",
+	"team_id",
+	"board_id",
+	"content",
+	"update_at",
+}
+
+func (s *SQLStore) fileContentsFromRows(rows *sql.Rows) ([]*model.FileContent, error) {
+	contents := []*model.FileContent{}
+
+	for rows.Next() {
+		var content model.FileContent
+		var text sql.NullString
+		err := rows.Scan(
+			&content.FileID,
+			&content.TeamID,
+			&content.BoardID,
+			&text,
+			&content.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		content.Content = text.String
+
+		contents = append(contents, &content)
+	}
+	return contents, nil
+}
+
+// saveFileContent stores the text extracted from a file, replacing the
+// previous one.
+func (s *SQLStore) saveFileContent(db sq.BaseRunner, content *model.FileContent) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"file_contents").
+		Columns(fileContentFields...).
+		Values(content.FileID, content.TeamID, content.BoardID, content.Content, content.UpdateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE content = ?, update_at = ?", content.Content, content.UpdateAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (file_id) DO UPDATE SET content = ?, update_at = ?", content.Content, content.UpdateAt)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save file content", mlog.String("file_id", content.FileID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// searchFileContents returns the texts of the files of the boards that
+// contain any of the words of the term.
+func (s *SQLStore) searchFileContents(db sq.BaseRunner, boardIDs []string, term string) ([]*model.FileContent, error) {
+	words := strings.Fields(strings.ToLower(term))
+	if len(boardIDs) == 0 || len(words) == 0 {
+		return []*model.FileContent{}, nil
+	}
+
+	conditions := sq.Or{}
+	for _, word := range words {
+		conditions = append(conditions, sq.Like{"lower(content)": "%" + word + "%"})
+	}
+
+	query := s.getQueryBuilder(db).
+		Select(fileContentFields...).
+		From(s.tablePrefix + "file_contents").
+		Where(sq.Eq{"board_id": boardIDs}).
+		Where(conditions)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot search file contents", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.fileContentsFromRows(rows)
+}
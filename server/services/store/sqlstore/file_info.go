@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var fileInfoFields = []string{
+	"file_id",
+	"team_id",
+	"board_id",
+	"size",
+	"create_at",
+}
+
+// saveFileInfo records the size of a stored file, replacing the previous
+// record of the file.
+func (s *SQLStore) saveFileInfo(db sq.BaseRunner, info *model.FileInfo) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"file_info").
+		Columns(fileInfoFields...).
+		Values(info.FileID, info.TeamID, info.BoardID, info.Size, info.CreateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE team_id = ?, board_id = ?, size = ?", info.TeamID, info.BoardID, info.Size)
+	} else {
+		query = query.Suffix("ON CONFLICT (file_id) DO UPDATE SET team_id = ?, board_id = ?, size = ?", info.TeamID, info.BoardID, info.Size)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save file info", mlog.String("file_id", info.FileID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteFileInfo(db sq.BaseRunner, fileID string) error {
+	_, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "file_info").
+		Where(sq.Eq{"file_id": fileID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete file info", mlog.String("file_id", fileID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// getFileStorageUsed returns the bytes of the files stored for all the
+// teams.
+func (s *SQLStore) getFileStorageUsed(db sq.BaseRunner) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COALESCE(SUM(size), 0)").
+		From(s.tablePrefix + "file_info")
+
+	var used int64
+	if err := query.QueryRow().Scan(&used); err != nil {
+		s.logger.Error("Cannot sum the size of the files", mlog.Err(err))
+		return 0, err
+	}
+	return used, nil
+}
+
+// getTeamFileStorageUsed returns the bytes of the files stored for the
+// team.
+func (s *SQLStore) getTeamFileStorageUsed(db sq.BaseRunner, teamID string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Select("COALESCE(SUM(size), 0)").
+		From(s.tablePrefix + "file_info").
+		Where(sq.Eq{"team_id": teamID})
+
+	var used int64
+	if err := query.QueryRow().Scan(&used); err != nil {
+		s.logger.Error("Cannot sum the size of the files of team", mlog.String("team_id", teamID), mlog.Err(err))
+		return 0, err
+	}
+	return used, nil
+}
@@ -0,0 +1,173 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var inviteFields = []string{
+	"id",
+	"team_id",
+	"token",
+	"role",
+	"max_uses",
+	"use_count",
+	"expires_at",
+	"created_by",
+	"create_at",
+}
+
+func (s *SQLStore) invitesFromRows(rows *sql.Rows) ([]*model.Invite, error) {
+	invites := []*model.Invite{}
+
+	for rows.Next() {
+		var invite model.Invite
+		var maxUses, useCount sql.NullInt64
+		var expiresAt sql.NullInt64
+		var createdBy sql.NullString
+		err := rows.Scan(
+			&invite.ID,
+			&invite.TeamID,
+			&invite.Token,
+			&invite.Role,
+			&maxUses,
+			&useCount,
+			&expiresAt,
+			&createdBy,
+			&invite.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invite.MaxUses = int(maxUses.Int64)
+		invite.UseCount = int(useCount.Int64)
+		invite.ExpiresAt = expiresAt.Int64
+		invite.CreatedBy = createdBy.String
+
+		invites = append(invites, &invite)
+	}
+	return invites, nil
+}
+
+func (s *SQLStore) createInvite(db sq.BaseRunner, invite *model.Invite) (*model.Invite, error) {
+	if err := invite.IsValid(); err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"invites").
+		Columns(inviteFields...).
+		Values(
+			invite.ID,
+			invite.TeamID,
+			invite.Token,
+			invite.Role,
+			invite.MaxUses,
+			invite.UseCount,
+			invite.ExpiresAt,
+			invite.CreatedBy,
+			invite.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create invite", mlog.String("team_id", invite.TeamID), mlog.Err(err))
+		return nil, err
+	}
+	return invite, nil
+}
+
+func (s *SQLStore) getInviteByCondition(db sq.BaseRunner, condition sq.Eq) (*model.Invite, error) {
+	query := s.getQueryBuilder(db).
+		Select(inviteFields...).
+		From(s.tablePrefix + "invites").
+		Where(condition)
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch invite", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	invites, err := s.invitesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(invites) == 0 {
+		return nil, model.NewErrNotFound("invite")
+	}
+	return invites[0], nil
+}
+
+func (s *SQLStore) getInvite(db sq.BaseRunner, inviteID string) (*model.Invite, error) {
+	return s.getInviteByCondition(db, sq.Eq{"id": inviteID})
+}
+
+func (s *SQLStore) getInviteByToken(db sq.BaseRunner, token string) (*model.Invite, error) {
+	return s.getInviteByCondition(db, sq.Eq{"token": token})
+}
+
+func (s *SQLStore) getInvitesForTeam(db sq.BaseRunner, teamID string) ([]*model.Invite, error) {
+	query := s.getQueryBuilder(db).
+		Select(inviteFields...).
+		From(s.tablePrefix+"invites").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch invites of team", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.invitesFromRows(rows)
+}
+
+// useInvite counts a registration with the invite if it is still usable
+// at the time, and returns false otherwise. The check and the count are a
+// single statement so that concurrent registrations can't exceed the
+// maximum number of uses.
+func (s *SQLStore) useInvite(db sq.BaseRunner, token string, now int64) (bool, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"invites").
+		Set("use_count", sq.Expr("use_count + 1")).
+		Where(sq.Eq{"token": token}).
+		Where(sq.Or{sq.Eq{"max_uses": 0}, sq.Expr("use_count < max_uses")}).
+		Where(sq.Or{sq.Eq{"expires_at": 0}, sq.Gt{"expires_at": now}}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot use invite", mlog.Err(err))
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+func (s *SQLStore) deleteInvite(db sq.BaseRunner, inviteID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "invites").
+		Where(sq.Eq{"id": inviteID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete invite", mlog.String("id", inviteID), mlog.Err(err))
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound(inviteID)
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// claimCandidates is the number of pending jobs looked at per claim, so a
+// node losing the race for a job can try the next ones.
+const claimCandidates = 10
+
+var jobFields = []string{
+	"id",
+	"type",
+	"status",
+	"data",
+	"progress",
+	"result",
+	"error",
+	"attempts",
+	"max_attempts",
+	"created_by",
+	"create_at",
+	"run_at",
+	"start_at",
+	"update_at",
+	"complete_at",
+}
+
+func marshalJobMap(m map[string]interface{}) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func unmarshalJobMap(data string) (map[string]interface{}, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *SQLStore) jobsFromRows(rows *sql.Rows) ([]*model.Job, error) {
+	jobs := []*model.Job{}
+
+	for rows.Next() {
+		var job model.Job
+		var data, result, jobErr sql.NullString
+		var createdBy sql.NullString
+		err := rows.Scan(
+			&job.ID,
+			&job.Type,
+			&job.Status,
+			&data,
+			&job.Progress,
+			&result,
+			&jobErr,
+			&job.Attempts,
+			&job.MaxAttempts,
+			&createdBy,
+			&job.CreateAt,
+			&job.RunAt,
+			&job.StartAt,
+			&job.UpdateAt,
+			&job.CompleteAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if job.Data, err = unmarshalJobMap(data.String); err != nil {
+			s.logger.Error("jobsFromRows cannot unmarshal data", mlog.String("id", job.ID), mlog.Err(err))
+			return nil, err
+		}
+		if job.Result, err = unmarshalJobMap(result.String); err != nil {
+			s.logger.Error("jobsFromRows cannot unmarshal result", mlog.String("id", job.ID), mlog.Err(err))
+			return nil, err
+		}
+		job.Error = jobErr.String
+		job.CreatedBy = createdBy.String
+
+		jobs = append(jobs, &job)
+	}
+	return jobs, nil
+}
+
+func (s *SQLStore) createJob(db sq.BaseRunner, job *model.Job) (*model.Job, error) {
+	now := utils.GetMillis()
+	newJob := *job
+	if newJob.ID == "" {
+		newJob.ID = utils.NewID(utils.IDTypeNone)
+	}
+	newJob.Status = model.JobStatusPending
+	newJob.Attempts = 0
+	if newJob.MaxAttempts <= 0 {
+		newJob.MaxAttempts = model.DefaultJobMaxAttempts
+	}
+	newJob.CreateAt = now
+	newJob.UpdateAt = now
+	if newJob.RunAt == 0 {
+		newJob.RunAt = now
+	}
+
+	data, err := marshalJobMap(newJob.Data)
+	if err != nil {
+		return nil, err
+	}
+	result, err := marshalJobMap(newJob.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"jobs").
+		Columns(jobFields...).
+		Values(
+			newJob.ID,
+			newJob.Type,
+			newJob.Status,
+			data,
+			newJob.Progress,
+			result,
+			newJob.Error,
+			newJob.Attempts,
+			newJob.MaxAttempts,
+			newJob.CreatedBy,
+			newJob.CreateAt,
+			newJob.RunAt,
+			newJob.StartAt,
+			newJob.UpdateAt,
+			newJob.CompleteAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create job", mlog.String("type", newJob.Type), mlog.Err(err))
+		return nil, err
+	}
+	return &newJob, nil
+}
+
+func (s *SQLStore) getJob(db sq.BaseRunner, jobID string) (*model.Job, error) {
+	query := s.getQueryBuilder(db).
+		Select(jobFields...).
+		From(s.tablePrefix + "jobs").
+		Where(sq.Eq{"id": jobID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch job", mlog.String("id", jobID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	jobs, err := s.jobsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, model.NewErrNotFound(jobID)
+	}
+	return jobs[0], nil
+}
+
+func (s *SQLStore) getJobs(db sq.BaseRunner, opts model.QueryJobsOptions) ([]*model.Job, error) {
+	query := s.getQueryBuilder(db).
+		Select(jobFields...).
+		From(s.tablePrefix+"jobs").
+		OrderBy("create_at DESC", "id")
+
+	if opts.Type != "" {
+		query = query.Where(sq.Eq{"type": opts.Type})
+	}
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"status": opts.Status})
+	}
+	if opts.Limit != 0 {
+		query = query.Limit(opts.Limit)
+	}
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch jobs", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.jobsFromRows(rows)
+}
+
+// getLatestJob returns the most recently created job of the type, nil if
+// there is none.
+func (s *SQLStore) getLatestJob(db sq.BaseRunner, jobType string) (*model.Job, error) {
+	jobs, err := s.getJobs(db, model.QueryJobsOptions{Type: jobType, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+	return jobs[0], nil
+}
+
+// claimJob marks the pending job of one of the types that is due the
+// longest as running and returns it, nil if no job is due. The status
+// check of the update makes sure only one node claims a job.
+func (s *SQLStore) claimJob(db sq.BaseRunner, types []string, now int64) (*model.Job, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix+"jobs").
+		Where(sq.Eq{"status": model.JobStatusPending}).
+		Where(sq.Eq{"type": types}).
+		Where(sq.LtOrEq{"run_at": now}).
+		OrderBy("run_at", "id").
+		Limit(claimCandidates).
+		Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch pending jobs", mlog.Err(err))
+		return nil, err
+	}
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			s.CloseRows(rows)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	s.CloseRows(rows)
+
+	for _, id := range ids {
+		result, err := s.getQueryBuilder(db).
+			Update(s.tablePrefix+"jobs").
+			Set("status", model.JobStatusRunning).
+			Set("attempts", sq.Expr("attempts + 1")).
+			Set("start_at", now).
+			Set("update_at", now).
+			Where(sq.Eq{"id": id}).
+			Where(sq.Eq{"status": model.JobStatusPending}).
+			Exec()
+		if err != nil {
+			s.logger.Error("Cannot claim job", mlog.String("id", id), mlog.Err(err))
+			return nil, err
+		}
+
+		claimed, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if claimed == 1 {
+			return s.getJob(db, id)
+		}
+	}
+	return nil, nil
+}
+
+func (s *SQLStore) updateJob(db sq.BaseRunner, job *model.Job) error {
+	data, err := marshalJobMap(job.Data)
+	if err != nil {
+		return err
+	}
+	result, err := marshalJobMap(job.Result)
+	if err != nil {
+		return err
+	}
+
+	job.UpdateAt = utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", job.Status).
+		Set("data", data).
+		Set("progress", job.Progress).
+		Set("result", result).
+		Set("error", job.Error).
+		Set("attempts", job.Attempts).
+		Set("run_at", job.RunAt).
+		Set("update_at", job.UpdateAt).
+		Set("complete_at", job.CompleteAt).
+		Where(sq.Eq{"id": job.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update job", mlog.String("id", job.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+// resetStaleJobs makes the running jobs not updated since the given time
+// pending again, as the node running them is assumed to be gone.
+func (s *SQLStore) resetStaleJobs(db sq.BaseRunner, updatedBefore int64) (int64, error) {
+	result, err := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"jobs").
+		Set("status", model.JobStatusPending).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Eq{"status": model.JobStatusRunning}).
+		Where(sq.Lt{"update_at": updatedBefore}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot reset stale jobs", mlog.Err(err))
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var mentionFields = []string{
+	"id",
+	"user_id",
+	"team_id",
+	"board_id",
+	"card_id",
+	"block_id",
+	"mentioned_by",
+	"extract",
+	"create_at",
+	"read_at",
+	"reminded_at",
+}
+
+func valuesForMention(mention *model.Mention) []interface{} {
+	return []interface{}{
+		mention.ID,
+		mention.UserID,
+		mention.TeamID,
+		mention.BoardID,
+		mention.CardID,
+		mention.BlockID,
+		mention.MentionedBy,
+		mention.Extract,
+		mention.CreateAt,
+		mention.ReadAt,
+		mention.RemindedAt,
+	}
+}
+
+func (s *SQLStore) mentionsFromRows(rows *sql.Rows) ([]*model.Mention, error) {
+	mentions := []*model.Mention{}
+
+	for rows.Next() {
+		var mention model.Mention
+		err := rows.Scan(
+			&mention.ID,
+			&mention.UserID,
+			&mention.TeamID,
+			&mention.BoardID,
+			&mention.CardID,
+			&mention.BlockID,
+			&mention.MentionedBy,
+			&mention.Extract,
+			&mention.CreateAt,
+			&mention.ReadAt,
+			&mention.RemindedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, &mention)
+	}
+	return mentions, nil
+}
+
+// createMention records a new unread mention.
+func (s *SQLStore) createMention(db sq.BaseRunner, mention *model.Mention) (*model.Mention, error) {
+	if err := mention.IsValid(); err != nil {
+		return nil, err
+	}
+
+	mentionAdd := *mention
+	mentionAdd.ID = utils.NewID(utils.IDTypeNone)
+	mentionAdd.CreateAt = utils.GetMillis()
+	mentionAdd.ReadAt = 0
+	mentionAdd.RemindedAt = 0
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix + "mentions").
+		Columns(mentionFields...).
+		Values(valuesForMention(&mentionAdd)...)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create mention",
+			mlog.String("user_id", mention.UserID),
+			mlog.String("block_id", mention.BlockID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &mentionAdd, nil
+}
+
+// getUnreadMentionsForUser fetches the unread mentions of a user, oldest first.
+func (s *SQLStore) getUnreadMentionsForUser(db sq.BaseRunner, userID string) ([]*model.Mention, error) {
+	query := s.getQueryBuilder(db).
+		Select(mentionFields...).
+		From(s.tablePrefix + "mentions").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"read_at": 0}).
+		OrderBy("create_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch unread mentions for user",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.mentionsFromRows(rows)
+}
+
+// markMentionsRead marks mentions of a user as read. All the unread
+// mentions of the user are marked if mentionIDs is empty.
+func (s *SQLStore) markMentionsRead(db sq.BaseRunner, userID string, mentionIDs []string) error {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"mentions").
+		Set("read_at", utils.GetMillis()).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"read_at": 0})
+
+	if len(mentionIDs) != 0 {
+		query = query.Where(sq.Eq{"id": mentionIDs})
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot mark mentions as read",
+			mlog.String("user_id", userID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getMentionsToRemind fetches the unread mentions created before the
+// given time that weren't reminded yet, grouped by user.
+func (s *SQLStore) getMentionsToRemind(db sq.BaseRunner, createdBefore int64) ([]*model.Mention, error) {
+	query := s.getQueryBuilder(db).
+		Select(mentionFields...).
+		From(s.tablePrefix+"mentions").
+		Where(sq.Eq{"read_at": 0}).
+		Where(sq.Eq{"reminded_at": 0}).
+		Where(sq.Lt{"create_at": createdBefore}).
+		OrderBy("user_id", "create_at")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch mentions to remind", mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.mentionsFromRows(rows)
+}
+
+// markMentionsReminded records that the users were reminded of the mentions.
+func (s *SQLStore) markMentionsReminded(db sq.BaseRunner, mentionIDs []string) error {
+	if len(mentionIDs) == 0 {
+		return nil
+	}
+
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"mentions").
+		Set("reminded_at", utils.GetMillis()).
+		Where(sq.Eq{"id": mentionIDs})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot mark mentions as reminded", mlog.Err(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}mentions (
+	id VARCHAR(36) NOT NULL,
+	user_id VARCHAR(36) NOT NULL,
+	team_id VARCHAR(36),
+	board_id VARCHAR(36) NOT NULL,
+	card_id VARCHAR(36) NOT NULL,
+	block_id VARCHAR(36) NOT NULL,
+	mentioned_by VARCHAR(36),
+	extract TEXT,
+	create_at BIGINT,
+	read_at BIGINT,
+	reminded_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_mentions_user_id_read_at ON {{.prefix}}mentions(user_id, read_at);
+CREATE INDEX idx_mentions_read_at_create_at ON {{.prefix}}mentions(read_at, create_at);
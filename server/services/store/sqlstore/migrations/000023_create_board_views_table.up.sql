@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}board_views (
+	board_id VARCHAR(36) NOT NULL,
+	user_id VARCHAR(36) NOT NULL,
+	viewed_at BIGINT,
+	PRIMARY KEY (board_id, user_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_board_views_user_id ON {{.prefix}}board_views(user_id);
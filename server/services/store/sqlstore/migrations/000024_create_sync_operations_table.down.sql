@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}sync_operations;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}sync_operations (
+	id VARCHAR(36) NOT NULL,
+	board_id VARCHAR(36) NOT NULL,
+	revision BIGINT NOT NULL,
+	type VARCHAR(10) NOT NULL,
+	block_id VARCHAR(36) NOT NULL,
+	data TEXT,
+	user_id VARCHAR(36),
+	create_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_sync_operations_board_id_revision ON {{.prefix}}sync_operations(board_id, revision);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}jobs (
+	id VARCHAR(36) NOT NULL,
+	type VARCHAR(64) NOT NULL,
+	status VARCHAR(16) NOT NULL,
+	data TEXT,
+	progress INT DEFAULT 0,
+	result TEXT,
+	error TEXT,
+	attempts INT DEFAULT 0,
+	max_attempts INT DEFAULT 0,
+	created_by VARCHAR(36),
+	create_at BIGINT,
+	run_at BIGINT,
+	start_at BIGINT,
+	update_at BIGINT,
+	complete_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_jobs_status_run_at ON {{.prefix}}jobs(status, run_at);
+CREATE INDEX idx_jobs_type_create_at ON {{.prefix}}jobs(type, create_at);
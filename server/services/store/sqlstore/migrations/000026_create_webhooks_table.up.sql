@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}webhooks (
+	id VARCHAR(36) NOT NULL,
+	team_id VARCHAR(36) NOT NULL,
+	url TEXT NOT NULL,
+	secret VARCHAR(100) NOT NULL,
+	block_types TEXT,
+	board_ids TEXT,
+	actions TEXT,
+	created_by VARCHAR(36),
+	create_at BIGINT,
+	update_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_webhooks_team_id ON {{.prefix}}webhooks(team_id);
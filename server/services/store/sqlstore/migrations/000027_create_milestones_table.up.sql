@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}milestones (
+	id VARCHAR(36) NOT NULL,
+	team_id VARCHAR(36) NOT NULL,
+	title VARCHAR(255) NOT NULL,
+	description TEXT,
+	due_date BIGINT,
+	created_by VARCHAR(36),
+	create_at BIGINT,
+	update_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_milestones_team_id ON {{.prefix}}milestones(team_id);
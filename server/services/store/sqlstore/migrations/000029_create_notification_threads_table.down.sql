@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}notification_threads;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}notification_threads (
+	card_id VARCHAR(36) NOT NULL,
+	channel_id VARCHAR(36) NOT NULL,
+	root_post_id VARCHAR(36) NOT NULL,
+	create_at BIGINT,
+	PRIMARY KEY (card_id, channel_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}card_reminders;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}card_reminders (
+	id VARCHAR(36) NOT NULL,
+	user_id VARCHAR(36) NOT NULL,
+	team_id VARCHAR(36),
+	board_id VARCHAR(36) NOT NULL,
+	card_id VARCHAR(36) NOT NULL,
+	note TEXT,
+	remind_at BIGINT NOT NULL,
+	create_at BIGINT,
+	update_at BIGINT,
+	delivered_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_card_reminders_user_id_card_id ON {{.prefix}}card_reminders(user_id, card_id);
+CREATE INDEX idx_card_reminders_delivered_at_remind_at ON {{.prefix}}card_reminders(delivered_at, remind_at);
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}sharing DROP COLUMN allowed_cidrs;
@@ -0,0 +1 @@
+ALTER TABLE {{.prefix}}sharing ADD COLUMN allowed_cidrs TEXT;
@@ -0,0 +1 @@
+DROP TABLE {{.prefix}}user_tokens;
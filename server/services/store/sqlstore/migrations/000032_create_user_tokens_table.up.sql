@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}user_tokens (
+	token VARCHAR(64) NOT NULL,
+	type VARCHAR(32) NOT NULL,
+	user_id VARCHAR(36) NOT NULL,
+	email VARCHAR(255),
+	create_at BIGINT,
+	PRIMARY KEY (token)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_user_tokens_user_id_type ON {{.prefix}}user_tokens(user_id, type);
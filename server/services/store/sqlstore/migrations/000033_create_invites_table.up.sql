@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}invites (
+	id VARCHAR(36) NOT NULL,
+	team_id VARCHAR(36) NOT NULL,
+	token VARCHAR(64) NOT NULL,
+	role VARCHAR(16) NOT NULL,
+	max_uses INT,
+	use_count INT,
+	expires_at BIGINT,
+	created_by VARCHAR(36),
+	create_at BIGINT,
+	PRIMARY KEY (id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE UNIQUE INDEX idx_invites_token ON {{.prefix}}invites(token);
+CREATE INDEX idx_invites_team_id ON {{.prefix}}invites(team_id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS {{.prefix}}file_info (
+	file_id VARCHAR(100) NOT NULL,
+	team_id VARCHAR(36) NOT NULL,
+	board_id VARCHAR(36) NOT NULL,
+	size BIGINT NOT NULL,
+	create_at BIGINT,
+	PRIMARY KEY (file_id)
+) {{if .mysql}}DEFAULT CHARACTER SET utf8mb4{{end}};
+
+CREATE INDEX idx_file_info_team_id ON {{.prefix}}file_info(team_id);
@@ -0,0 +1,160 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var milestoneFields = []string{
+	"id",
+	"team_id",
+	"title",
+	"description",
+	"due_date",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+func (s *SQLStore) milestonesFromRows(rows *sql.Rows) ([]*model.Milestone, error) {
+	milestones := []*model.Milestone{}
+
+	for rows.Next() {
+		var milestone model.Milestone
+		var description, createdBy sql.NullString
+		var dueDate sql.NullInt64
+		err := rows.Scan(
+			&milestone.ID,
+			&milestone.TeamID,
+			&milestone.Title,
+			&description,
+			&dueDate,
+			&createdBy,
+			&milestone.CreateAt,
+			&milestone.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		milestone.Description = description.String
+		milestone.DueDate = dueDate.Int64
+		milestone.CreatedBy = createdBy.String
+
+		milestones = append(milestones, &milestone)
+	}
+	return milestones, nil
+}
+
+func (s *SQLStore) createMilestone(db sq.BaseRunner, milestone *model.Milestone) (*model.Milestone, error) {
+	newMilestone := *milestone
+	if newMilestone.ID == "" {
+		newMilestone.ID = utils.NewID(utils.IDTypeNone)
+	}
+	now := utils.GetMillis()
+	newMilestone.CreateAt = now
+	newMilestone.UpdateAt = now
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"milestones").
+		Columns(milestoneFields...).
+		Values(
+			newMilestone.ID,
+			newMilestone.TeamID,
+			newMilestone.Title,
+			newMilestone.Description,
+			newMilestone.DueDate,
+			newMilestone.CreatedBy,
+			newMilestone.CreateAt,
+			newMilestone.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create milestone", mlog.String("team_id", newMilestone.TeamID), mlog.Err(err))
+		return nil, err
+	}
+	return &newMilestone, nil
+}
+
+func (s *SQLStore) getMilestone(db sq.BaseRunner, milestoneID string) (*model.Milestone, error) {
+	query := s.getQueryBuilder(db).
+		Select(milestoneFields...).
+		From(s.tablePrefix + "milestones").
+		Where(sq.Eq{"id": milestoneID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch milestone", mlog.String("id", milestoneID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	milestones, err := s.milestonesFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(milestones) == 0 {
+		return nil, model.NewErrNotFound(milestoneID)
+	}
+	return milestones[0], nil
+}
+
+func (s *SQLStore) getMilestonesForTeam(db sq.BaseRunner, teamID string) ([]*model.Milestone, error) {
+	query := s.getQueryBuilder(db).
+		Select(milestoneFields...).
+		From(s.tablePrefix+"milestones").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("due_date", "create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch milestones of team", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.milestonesFromRows(rows)
+}
+
+func (s *SQLStore) updateMilestone(db sq.BaseRunner, milestone *model.Milestone) error {
+	milestone.UpdateAt = utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"milestones").
+		Set("title", milestone.Title).
+		Set("description", milestone.Description).
+		Set("due_date", milestone.DueDate).
+		Set("update_at", milestone.UpdateAt).
+		Where(sq.Eq{"id": milestone.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update milestone", mlog.String("id", milestone.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteMilestone(db sq.BaseRunner, milestoneID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "milestones").
+		Where(sq.Eq{"id": milestoneID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete milestone", mlog.String("id", milestoneID), mlog.Err(err))
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound(milestoneID)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var notificationThreadFields = []string{
+	"card_id",
+	"channel_id",
+	"root_post_id",
+	"create_at",
+}
+
+func (s *SQLStore) notificationThreadsFromRows(rows *sql.Rows) ([]*model.NotificationThread, error) {
+	threads := []*model.NotificationThread{}
+
+	for rows.Next() {
+		var thread model.NotificationThread
+		err := rows.Scan(
+			&thread.CardID,
+			&thread.ChannelID,
+			&thread.RootPostID,
+			&thread.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		threads = append(threads, &thread)
+	}
+	return threads, nil
+}
+
+// saveNotificationThread stores the root post of the notifications of a
+// card in a channel, replacing the previous one.
+func (s *SQLStore) saveNotificationThread(db sq.BaseRunner, thread *model.NotificationThread) error {
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"notification_threads").
+		Columns(notificationThreadFields...).
+		Values(thread.CardID, thread.ChannelID, thread.RootPostID, thread.CreateAt)
+
+	if s.dbType == model.MysqlDBType {
+		query = query.Suffix("ON DUPLICATE KEY UPDATE root_post_id = ?, create_at = ?", thread.RootPostID, thread.CreateAt)
+	} else {
+		query = query.Suffix("ON CONFLICT (card_id, channel_id) DO UPDATE SET root_post_id = ?, create_at = ?", thread.RootPostID, thread.CreateAt)
+	}
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot save notification thread",
+			mlog.String("card_id", thread.CardID),
+			mlog.String("channel_id", thread.ChannelID),
+			mlog.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// getNotificationThread returns the root post of the notifications of a
+// card in a channel, or an ErrNotFound if none were posted yet.
+func (s *SQLStore) getNotificationThread(db sq.BaseRunner, cardID, channelID string) (*model.NotificationThread, error) {
+	query := s.getQueryBuilder(db).
+		Select(notificationThreadFields...).
+		From(s.tablePrefix + "notification_threads").
+		Where(sq.Eq{"card_id": cardID}).
+		Where(sq.Eq{"channel_id": channelID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch notification thread",
+			mlog.String("card_id", cardID),
+			mlog.String("channel_id", channelID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	threads, err := s.notificationThreadsFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(threads) == 0 {
+		return nil, model.NewErrNotFound(cardID)
+	}
+	return threads[0], nil
+}
@@ -46,11 +46,21 @@ func (s *SQLStore) AddUpdateCategoryBoard(userID string, categoryID string, bloc
 
 }
 
+func (s *SQLStore) ClaimJob(types []string, now int64) (*model.Job, error) {
+	return s.claimJob(s.db, types, now)
+
+}
+
 func (s *SQLStore) CleanUpSessions(expireTime int64) error {
 	return s.cleanUpSessions(s.db, expireTime)
 
 }
 
+func (s *SQLStore) CleanUpUserTokens(createdBefore int64) error {
+	return s.cleanUpUserTokens(s.db, createdBefore)
+
+}
+
 func (s *SQLStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.createBoardsAndBlocks(s.db, bab, userID)
@@ -99,11 +109,36 @@ func (s *SQLStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, us
 
 }
 
+func (s *SQLStore) CreateCardReminder(reminder *model.CardReminder) (*model.CardReminder, error) {
+	return s.createCardReminder(s.db, reminder)
+
+}
+
 func (s *SQLStore) CreateCategory(category model.Category) error {
 	return s.createCategory(s.db, category)
 
 }
 
+func (s *SQLStore) CreateInvite(invite *model.Invite) (*model.Invite, error) {
+	return s.createInvite(s.db, invite)
+
+}
+
+func (s *SQLStore) CreateJob(job *model.Job) (*model.Job, error) {
+	return s.createJob(s.db, job)
+
+}
+
+func (s *SQLStore) CreateMention(mention *model.Mention) (*model.Mention, error) {
+	return s.createMention(s.db, mention)
+
+}
+
+func (s *SQLStore) CreateMilestone(milestone *model.Milestone) (*model.Milestone, error) {
+	return s.createMilestone(s.db, milestone)
+
+}
+
 func (s *SQLStore) CreateSession(session *model.Session) error {
 	return s.createSession(s.db, session)
 
@@ -119,6 +154,16 @@ func (s *SQLStore) CreateUser(user *model.User) error {
 
 }
 
+func (s *SQLStore) CreateUserToken(token *model.UserToken) error {
+	return s.createUserToken(s.db, token)
+
+}
+
+func (s *SQLStore) CreateWebhook(webhook *model.Webhook) (*model.Webhook, error) {
+	return s.createWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBlock(s.db, blockID, modifiedBy)
@@ -143,6 +188,30 @@ func (s *SQLStore) DeleteBlock(blockID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) DeleteBlockTree(blockID string, modifiedBy string) ([]model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.deleteBlockTree(s.db, blockID, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.deleteBlockTree(tx, blockID, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "DeleteBlockTree"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) DeleteBoard(boardID string, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.deleteBoard(s.db, boardID, userID)
@@ -191,16 +260,36 @@ func (s *SQLStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, user
 
 }
 
+func (s *SQLStore) DeleteCardReminder(reminderID string) error {
+	return s.deleteCardReminder(s.db, reminderID)
+
+}
+
 func (s *SQLStore) DeleteCategory(categoryID string, userID string, teamID string) error {
 	return s.deleteCategory(s.db, categoryID, userID, teamID)
 
 }
 
+func (s *SQLStore) DeleteFileInfo(fileID string) error {
+	return s.deleteFileInfo(s.db, fileID)
+
+}
+
+func (s *SQLStore) DeleteInvite(inviteID string) error {
+	return s.deleteInvite(s.db, inviteID)
+
+}
+
 func (s *SQLStore) DeleteMember(boardID string, userID string) error {
 	return s.deleteMember(s.db, boardID, userID)
 
 }
 
+func (s *SQLStore) DeleteMilestone(milestoneID string) error {
+	return s.deleteMilestone(s.db, milestoneID)
+
+}
+
 func (s *SQLStore) DeleteNotificationHint(blockID string) error {
 	return s.deleteNotificationHint(s.db, blockID)
 
@@ -211,11 +300,31 @@ func (s *SQLStore) DeleteSession(sessionID string) error {
 
 }
 
+func (s *SQLStore) DeleteSessionsForUser(userID string) error {
+	return s.deleteSessionsForUser(s.db, userID)
+
+}
+
 func (s *SQLStore) DeleteSubscription(blockID string, subscriberID string) error {
 	return s.deleteSubscription(s.db, blockID, subscriberID)
 
 }
 
+func (s *SQLStore) DeleteUserToken(token string) error {
+	return s.deleteUserToken(s.db, token)
+
+}
+
+func (s *SQLStore) DeleteUserTokensForUser(userID string, tokenType string) error {
+	return s.deleteUserTokensForUser(s.db, userID, tokenType)
+
+}
+
+func (s *SQLStore) DeleteWebhook(webhookID string) error {
+	return s.deleteWebhook(s.db, webhookID)
+
+}
+
 func (s *SQLStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.duplicateBlock(s.db, boardID, blockID, userID, asTemplate)
@@ -279,6 +388,11 @@ func (s *SQLStore) GetBlock(blockID string) (*model.Block, error) {
 
 }
 
+func (s *SQLStore) GetBlockCountForBoard(boardID string, opts model.QueryBlocksPageOptions) (int64, error) {
+	return s.getBlockCountForBoard(s.db, boardID, opts)
+
+}
+
 func (s *SQLStore) GetBlockCountsByType() (map[string]int64, error) {
 	return s.getBlockCountsByType(s.db)
 
@@ -299,6 +413,21 @@ func (s *SQLStore) GetBlocksForBoard(boardID string) ([]model.Block, error) {
 
 }
 
+func (s *SQLStore) GetBlocksForBoardPage(boardID string, opts model.QueryBlocksPageOptions) ([]model.Block, error) {
+	return s.getBlocksForBoardPage(s.db, boardID, opts)
+
+}
+
+func (s *SQLStore) GetBlocksModifiedSince(boardID string, since int64) ([]model.Block, error) {
+	return s.getBlocksModifiedSince(s.db, boardID, since)
+
+}
+
+func (s *SQLStore) GetBlocksVersionForBoard(boardID string) (*model.BlocksVersion, error) {
+	return s.getBlocksVersionForBoard(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetBlocksWithBoardID(boardID string) ([]model.Block, error) {
 	return s.getBlocksWithBoardID(s.db, boardID)
 
@@ -334,6 +463,11 @@ func (s *SQLStore) GetBoardAndCardByID(blockID string) (*model.Board, *model.Blo
 
 }
 
+func (s *SQLStore) GetBoardChangesForUser(userID string, teamID string) ([]*model.BoardChanges, error) {
+	return s.getBoardChangesForUser(s.db, userID, teamID)
+
+}
+
 func (s *SQLStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
 	return s.getBoardHistory(s.db, boardID, opts)
 
@@ -344,16 +478,91 @@ func (s *SQLStore) GetBoardMemberHistory(boardID string, userID string, limit ui
 
 }
 
+func (s *SQLStore) GetBoardsForTeam(teamID string) ([]*model.Board, error) {
+	return s.getBoardsForTeam(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetBoardsForUserAndTeam(userID string, teamID string) ([]*model.Board, error) {
 	return s.getBoardsForUserAndTeam(s.db, userID, teamID)
 
 }
 
+func (s *SQLStore) GetBoardsWithProperty(key string) ([]*model.Board, error) {
+	return s.getBoardsWithProperty(s.db, key)
+
+}
+
+func (s *SQLStore) GetCardReminder(reminderID string) (*model.CardReminder, error) {
+	return s.getCardReminder(s.db, reminderID)
+
+}
+
+func (s *SQLStore) GetCardRemindersForUser(userID string, cardID string) ([]*model.CardReminder, error) {
+	return s.getCardRemindersForUser(s.db, userID, cardID)
+
+}
+
 func (s *SQLStore) GetCategory(id string) (*model.Category, error) {
 	return s.getCategory(s.db, id)
 
 }
 
+func (s *SQLStore) GetDeletedBoardsForTeam(teamID string, deletedAfter int64) ([]*model.Board, error) {
+	return s.getDeletedBoardsForTeam(s.db, teamID, deletedAfter)
+
+}
+
+func (s *SQLStore) GetDeletedCardsForTeam(teamID string, deletedAfter int64) ([]model.Block, error) {
+	return s.getDeletedCardsForTeam(s.db, teamID, deletedAfter)
+
+}
+
+func (s *SQLStore) GetDueCardReminders(remindBefore int64) ([]*model.CardReminder, error) {
+	return s.getDueCardReminders(s.db, remindBefore)
+
+}
+
+func (s *SQLStore) GetFileStorageUsed() (int64, error) {
+	return s.getFileStorageUsed(s.db)
+
+}
+
+func (s *SQLStore) GetInvite(inviteID string) (*model.Invite, error) {
+	return s.getInvite(s.db, inviteID)
+
+}
+
+func (s *SQLStore) GetInviteByToken(token string) (*model.Invite, error) {
+	return s.getInviteByToken(s.db, token)
+
+}
+
+func (s *SQLStore) GetInvitesForTeam(teamID string) ([]*model.Invite, error) {
+	return s.getInvitesForTeam(s.db, teamID)
+
+}
+
+func (s *SQLStore) GetJob(jobID string) (*model.Job, error) {
+	return s.getJob(s.db, jobID)
+
+}
+
+func (s *SQLStore) GetJobs(opts model.QueryJobsOptions) ([]*model.Job, error) {
+	return s.getJobs(s.db, opts)
+
+}
+
+func (s *SQLStore) GetLastBlockUpdatesForTeam(teamID string) (map[string]int64, error) {
+	return s.getLastBlockUpdatesForTeam(s.db, teamID)
+
+}
+
+func (s *SQLStore) GetLatestJob(jobType string) (*model.Job, error) {
+	return s.getLatestJob(s.db, jobType)
+
+}
+
 func (s *SQLStore) GetLicense() *mmModel.License {
 	return s.getLicense(s.db)
 
@@ -374,6 +583,21 @@ func (s *SQLStore) GetMembersForUser(userID string) ([]*model.BoardMember, error
 
 }
 
+func (s *SQLStore) GetMentionsToRemind(createdBefore int64) ([]*model.Mention, error) {
+	return s.getMentionsToRemind(s.db, createdBefore)
+
+}
+
+func (s *SQLStore) GetMilestone(milestoneID string) (*model.Milestone, error) {
+	return s.getMilestone(s.db, milestoneID)
+
+}
+
+func (s *SQLStore) GetMilestonesForTeam(teamID string) ([]*model.Milestone, error) {
+	return s.getMilestonesForTeam(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetNextNotificationHint(remove bool) (*model.NotificationHint, error) {
 	return s.getNextNotificationHint(s.db, remove)
 
@@ -384,6 +608,11 @@ func (s *SQLStore) GetNotificationHint(blockID string) (*model.NotificationHint,
 
 }
 
+func (s *SQLStore) GetNotificationThread(cardID string, channelID string) (*model.NotificationThread, error) {
+	return s.getNotificationThread(s.db, cardID, channelID)
+
+}
+
 func (s *SQLStore) GetRegisteredUserCount() (int, error) {
 	return s.getRegisteredUserCount(s.db)
 
@@ -424,6 +653,16 @@ func (s *SQLStore) GetSubscriptions(subscriberID string) ([]*model.Subscription,
 
 }
 
+func (s *SQLStore) GetSyncOperations(boardID string, afterRevision int64) ([]*model.SyncOperation, error) {
+	return s.getSyncOperations(s.db, boardID, afterRevision)
+
+}
+
+func (s *SQLStore) GetSyncRevision(boardID string) (int64, error) {
+	return s.getSyncRevision(s.db, boardID)
+
+}
+
 func (s *SQLStore) GetSystemSetting(key string) (string, error) {
 	return s.getSystemSetting(s.db, key)
 
@@ -444,6 +683,11 @@ func (s *SQLStore) GetTeamCount() (int64, error) {
 
 }
 
+func (s *SQLStore) GetTeamUsage(teamID string) (*model.TeamUsage, error) {
+	return s.getTeamUsage(s.db, teamID)
+
+}
+
 func (s *SQLStore) GetTeamsForUser(userID string) ([]*model.Team, error) {
 	return s.getTeamsForUser(s.db, userID)
 
@@ -454,6 +698,16 @@ func (s *SQLStore) GetTemplateBoards(teamID string, userID string) ([]*model.Boa
 
 }
 
+func (s *SQLStore) GetUnreadMentionsForUser(userID string) ([]*model.Mention, error) {
+	return s.getUnreadMentionsForUser(s.db, userID)
+
+}
+
+func (s *SQLStore) GetUserAvailability(userID string) (*model.UserAvailability, error) {
+	return s.getUserAvailability(s.db, userID)
+
+}
+
 func (s *SQLStore) GetUserByEmail(email string) (*model.User, error) {
 	return s.getUserByEmail(s.db, email)
 
@@ -474,11 +728,26 @@ func (s *SQLStore) GetUserCategoryBoards(userID string, teamID string) ([]model.
 
 }
 
+func (s *SQLStore) GetUserToken(token string) (*model.UserToken, error) {
+	return s.getUserToken(s.db, token)
+
+}
+
 func (s *SQLStore) GetUsersByTeam(teamID string) ([]*model.User, error) {
 	return s.getUsersByTeam(s.db, teamID)
 
 }
 
+func (s *SQLStore) GetWebhook(webhookID string) (*model.Webhook, error) {
+	return s.getWebhook(s.db, webhookID)
+
+}
+
+func (s *SQLStore) GetWebhooksForTeam(teamID string) ([]*model.Webhook, error) {
+	return s.getWebhooksForTeam(s.db, teamID)
+
+}
+
 func (s *SQLStore) InsertBlock(block *model.Block, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.insertBlock(s.db, block, userID)
@@ -556,6 +825,45 @@ func (s *SQLStore) InsertBoardWithAdmin(board *model.Board, userID string) (*mod
 
 }
 
+func (s *SQLStore) InsertSyncOperation(op *model.SyncOperation) (*model.SyncOperation, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.insertSyncOperation(s.db, op)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.insertSyncOperation(tx, op)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "InsertSyncOperation"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) MarkCardRemindersDelivered(reminderIDs []string) error {
+	return s.markCardRemindersDelivered(s.db, reminderIDs)
+
+}
+
+func (s *SQLStore) MarkMentionsRead(userID string, mentionIDs []string) error {
+	return s.markMentionsRead(s.db, userID, mentionIDs)
+
+}
+
+func (s *SQLStore) MarkMentionsReminded(mentionIDs []string) error {
+	return s.markMentionsReminded(s.db, mentionIDs)
+
+}
+
 func (s *SQLStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.patchBlock(s.db, blockID, blockPatch, userID)
@@ -657,6 +965,78 @@ func (s *SQLStore) PatchUserProps(userID string, patch model.UserPropPatch) erro
 
 }
 
+func (s *SQLStore) PurgeDeletedBlockTree(blockID string) ([]model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.purgeDeletedBlockTree(s.db, blockID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.purgeDeletedBlockTree(tx, blockID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PurgeDeletedBlockTree"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) PurgeDeletedBlocks(deletedBefore int64, limit uint64) ([]model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.purgeDeletedBlocks(s.db, deletedBefore, limit)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.purgeDeletedBlocks(tx, deletedBefore, limit)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PurgeDeletedBlocks"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (s *SQLStore) PurgeDeletedBoard(boardID string) ([]model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.purgeDeletedBoard(s.db, boardID)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.purgeDeletedBoard(tx, boardID)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "PurgeDeletedBoard"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) RefreshSession(session *model.Session) error {
 	return s.refreshSession(s.db, session)
 
@@ -667,6 +1047,16 @@ func (s *SQLStore) RemoveDefaultTemplates(boards []*model.Board) error {
 
 }
 
+func (s *SQLStore) ResetStaleJobs(updatedBefore int64) (int64, error) {
+	return s.resetStaleJobs(s.db, updatedBefore)
+
+}
+
+func (s *SQLStore) RevokeAllSharing(modifiedBy string) (int64, error) {
+	return s.revokeAllSharing(s.db, modifiedBy)
+
+}
+
 func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error) {
 	if s.dbType == model.SqliteDBType {
 		return s.runDataRetention(s.db, globalRetentionDate, batchSize)
@@ -691,16 +1081,51 @@ func (s *SQLStore) RunDataRetention(globalRetentionDate int64, batchSize int64)
 
 }
 
+func (s *SQLStore) SaveBoardView(view *model.BoardView) error {
+	return s.saveBoardView(s.db, view)
+
+}
+
+func (s *SQLStore) SaveFileContent(content *model.FileContent) error {
+	return s.saveFileContent(s.db, content)
+
+}
+
+func (s *SQLStore) SaveFileInfo(info *model.FileInfo) error {
+	return s.saveFileInfo(s.db, info)
+
+}
+
 func (s *SQLStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
 	return s.saveMember(s.db, bm)
 
 }
 
+func (s *SQLStore) SaveNotificationThread(thread *model.NotificationThread) error {
+	return s.saveNotificationThread(s.db, thread)
+
+}
+
+func (s *SQLStore) SearchBlocksForBoards(boardIDs []string, term string, blockTypes []model.BlockType, filters model.SearchFilters) ([]model.Block, error) {
+	return s.searchBlocksForBoards(s.db, boardIDs, term, blockTypes, filters)
+
+}
+
 func (s *SQLStore) SearchBoardsForUser(term string, userID string) ([]*model.Board, error) {
 	return s.searchBoardsForUser(s.db, term, userID)
 
 }
 
+func (s *SQLStore) SearchFileContents(boardIDs []string, term string) ([]*model.FileContent, error) {
+	return s.searchFileContents(s.db, boardIDs, term)
+
+}
+
+func (s *SQLStore) SearchUserDirectory(teamID string, prefix string, offset int, limit int) ([]*model.UserDirectoryEntry, error) {
+	return s.searchUserDirectory(s.db, teamID, prefix, offset, limit)
+
+}
+
 func (s *SQLStore) SearchUsersByTeam(teamID string, searchQuery string) ([]*model.User, error) {
 	return s.searchUsersByTeam(s.db, teamID, searchQuery)
 
@@ -735,6 +1160,30 @@ func (s *SQLStore) UndeleteBlock(blockID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) UndeleteBlockTree(blockID string, modifiedBy string) ([]model.Block, error) {
+	if s.dbType == model.SqliteDBType {
+		return s.undeleteBlockTree(s.db, blockID, modifiedBy)
+	}
+	tx, txErr := s.db.BeginTx(context.Background(), nil)
+	if txErr != nil {
+		return nil, txErr
+	}
+	result, err := s.undeleteBlockTree(tx, blockID, modifiedBy)
+	if err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "UndeleteBlockTree"))
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
 func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 	if s.dbType == model.SqliteDBType {
 		return s.undeleteBoard(s.db, boardID, modifiedBy)
@@ -759,11 +1208,26 @@ func (s *SQLStore) UndeleteBoard(boardID string, modifiedBy string) error {
 
 }
 
+func (s *SQLStore) UpdateCardReminder(reminder *model.CardReminder) error {
+	return s.updateCardReminder(s.db, reminder)
+
+}
+
 func (s *SQLStore) UpdateCategory(category model.Category) error {
 	return s.updateCategory(s.db, category)
 
 }
 
+func (s *SQLStore) UpdateJob(job *model.Job) error {
+	return s.updateJob(s.db, job)
+
+}
+
+func (s *SQLStore) UpdateMilestone(milestone *model.Milestone) error {
+	return s.updateMilestone(s.db, milestone)
+
+}
+
 func (s *SQLStore) UpdateSession(session *model.Session) error {
 	return s.updateSession(s.db, session)
 
@@ -789,6 +1253,11 @@ func (s *SQLStore) UpdateUserPasswordByID(userID string, password string) error
 
 }
 
+func (s *SQLStore) UpdateWebhook(webhook *model.Webhook) error {
+	return s.updateWebhook(s.db, webhook)
+
+}
+
 func (s *SQLStore) UpsertNotificationHint(hint *model.NotificationHint, notificationFreq time.Duration) (*model.NotificationHint, error) {
 	return s.upsertNotificationHint(s.db, hint, notificationFreq)
 
@@ -808,3 +1277,8 @@ func (s *SQLStore) UpsertTeamSignupToken(team model.Team) error {
 	return s.upsertTeamSignupToken(s.db, team)
 
 }
+
+func (s *SQLStore) UseInvite(token string, now int64) (bool, error) {
+	return s.useInvite(s.db, token, now)
+
+}
@@ -28,7 +28,7 @@ func (s *SQLStore) getActiveUserCount(db sq.BaseRunner, updatedSecondsAgo int64)
 
 func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds int64) (*model.Session, error) {
 	query := s.getQueryBuilder(db).
-		Select("id", "token", "user_id", "auth_service", "props").
+		Select("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
 		From(s.tablePrefix + "sessions").
 		Where(sq.Eq{"token": token}).
 		Where(sq.Gt{"update_at": utils.GetMillis() - utils.SecondsToMillis(expireTimeSeconds)})
@@ -37,7 +37,7 @@ func (s *SQLStore) getSession(db sq.BaseRunner, token string, expireTimeSeconds
 	session := model.Session{}
 
 	var propsBytes []byte
-	err := row.Scan(&session.ID, &session.Token, &session.UserID, &session.AuthService, &propsBytes)
+	err := row.Scan(&session.ID, &session.Token, &session.UserID, &session.AuthService, &propsBytes, &session.CreateAt, &session.UpdateAt)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +62,12 @@ func (s *SQLStore) createSession(db sq.BaseRunner, session *model.Session) error
 		Columns("id", "token", "user_id", "auth_service", "props", "create_at", "update_at").
 		Values(session.ID, session.Token, session.UserID, session.AuthService, propsBytes, now, now)
 
-	_, err = query.Exec()
-	return err
+	if _, err = query.Exec(); err != nil {
+		return err
+	}
+	session.CreateAt = now
+	session.UpdateAt = now
+	return nil
 }
 
 func (s *SQLStore) refreshSession(db sq.BaseRunner, session *model.Session) error {
@@ -73,8 +77,11 @@ func (s *SQLStore) refreshSession(db sq.BaseRunner, session *model.Session) erro
 		Where(sq.Eq{"token": session.Token}).
 		Set("update_at", now)
 
-	_, err := query.Exec()
-	return err
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+	session.UpdateAt = now
+	return nil
 }
 
 func (s *SQLStore) updateSession(db sq.BaseRunner, session *model.Session) error {
@@ -90,8 +97,11 @@ func (s *SQLStore) updateSession(db sq.BaseRunner, session *model.Session) error
 		Set("update_at", now).
 		Set("props", propsBytes)
 
-	_, err = query.Exec()
-	return err
+	if _, err = query.Exec(); err != nil {
+		return err
+	}
+	session.UpdateAt = now
+	return nil
 }
 
 func (s *SQLStore) deleteSession(db sq.BaseRunner, sessionID string) error {
@@ -109,3 +119,11 @@ func (s *SQLStore) cleanUpSessions(db sq.BaseRunner, expireTimeSeconds int64) er
 	_, err := query.Exec()
 	return err
 }
+
+func (s *SQLStore) deleteSessionsForUser(db sq.BaseRunner, userID string) error {
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "sessions").
+		Where(sq.Eq{"user_id": userID})
+
+	_, err := query.Exec()
+	return err
+}
@@ -1,6 +1,9 @@
 package sqlstore
 
 import (
+	"database/sql"
+	"encoding/json"
+
 	"github.com/mattermost/focalboard/server/model"
 	"github.com/mattermost/focalboard/server/utils"
 
@@ -10,6 +13,11 @@ import (
 func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error {
 	now := utils.GetMillis()
 
+	allowedCIDRs, err := marshalAllowedCIDRs(sharing.AllowedCIDRs)
+	if err != nil {
+		return err
+	}
+
 	query := s.getQueryBuilder(db).
 		Insert(s.tablePrefix+"sharing").
 		Columns(
@@ -18,6 +26,7 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 			"token",
 			"modified_by",
 			"update_at",
+			"allowed_cidrs",
 		).
 		Values(
 			sharing.ID,
@@ -25,18 +34,20 @@ func (s *SQLStore) upsertSharing(db sq.BaseRunner, sharing model.Sharing) error
 			sharing.Token,
 			sharing.ModifiedBy,
 			now,
+			allowedCIDRs,
 		)
 	if s.dbType == model.MysqlDBType {
-		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, token = ?, modified_by = ?, update_at = ?",
-			sharing.Enabled, sharing.Token, sharing.ModifiedBy, now)
+		query = query.Suffix("ON DUPLICATE KEY UPDATE enabled = ?, token = ?, modified_by = ?, update_at = ?, allowed_cidrs = ?",
+			sharing.Enabled, sharing.Token, sharing.ModifiedBy, now, allowedCIDRs)
 	} else {
 		query = query.Suffix(
 			`ON CONFLICT (id)
-			 DO UPDATE SET enabled = EXCLUDED.enabled, token = EXCLUDED.token, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at`,
+			 DO UPDATE SET enabled = EXCLUDED.enabled, token = EXCLUDED.token, modified_by = EXCLUDED.modified_by, update_at = EXCLUDED.update_at,
+			 allowed_cidrs = EXCLUDED.allowed_cidrs`,
 		)
 	}
 
-	_, err := query.Exec()
+	_, err = query.Exec()
 	return err
 }
 
@@ -48,22 +59,63 @@ func (s *SQLStore) getSharing(db sq.BaseRunner, boardID string) (*model.Sharing,
 			"token",
 			"modified_by",
 			"update_at",
+			"allowed_cidrs",
 		).
 		From(s.tablePrefix + "sharing").
 		Where(sq.Eq{"id": boardID})
 	row := query.QueryRow()
 	sharing := model.Sharing{}
 
+	var allowedCIDRs sql.NullString
 	err := row.Scan(
 		&sharing.ID,
 		&sharing.Enabled,
 		&sharing.Token,
 		&sharing.ModifiedBy,
 		&sharing.UpdateAt,
+		&allowedCIDRs,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	if allowedCIDRs.String != "" {
+		if err := json.Unmarshal([]byte(allowedCIDRs.String), &sharing.AllowedCIDRs); err != nil {
+			return nil, err
+		}
+	}
+
 	return &sharing, nil
 }
+
+// marshalAllowedCIDRs returns the allowed ranges of a sharing as stored,
+// a JSON array or NULL when there are none.
+func marshalAllowedCIDRs(cidrs []string) (interface{}, error) {
+	if len(cidrs) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(cidrs)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// revokeAllSharing disables the sharing of all the boards and clears their
+// tokens, so that the existing share links stop working for good. It
+// returns the number of boards whose sharing was revoked.
+func (s *SQLStore) revokeAllSharing(db sq.BaseRunner, modifiedBy string) (int64, error) {
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"sharing").
+		Set("enabled", false).
+		Set("token", "").
+		Set("modified_by", modifiedBy).
+		Set("update_at", utils.GetMillis()).
+		Where(sq.Or{sq.Eq{"enabled": true}, sq.NotEq{"token": ""}})
+
+	result, err := query.Exec()
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -9,6 +9,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
 	"github.com/mattermost/mattermost-plugin-api/cluster"
 
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
@@ -27,6 +28,7 @@ type SQLStore struct {
 	NewMutexFn       MutexFactory
 	pluginAPI        *plugin.API
 	isBinaryParam    bool
+	stmtCache        *stmtCache
 }
 
 // MutexFactory is used by the store in plugin mode to generate
@@ -51,6 +53,7 @@ func New(params Params) (*SQLStore, error) {
 		isSingleUser:     params.IsSingleUser,
 		NewMutexFn:       params.NewMutexFn,
 		pluginAPI:        params.PluginAPI,
+		stmtCache:        newStmtCache(params.DB, params.Logger),
 	}
 
 	var err error
@@ -85,9 +88,18 @@ func (s *SQLStore) computeBinaryParam() (bool, error) {
 
 // Shutdown close the connection with the store.
 func (s *SQLStore) Shutdown() error {
+	s.stmtCache.close()
 	return s.db.Close()
 }
 
+// WithLogger returns a copy of the store that logs with the given
+// logger. The copy shares the connection and the statement cache.
+func (s *SQLStore) WithLogger(logger *mlog.Logger) store.Store {
+	sqlStore := *s
+	sqlStore.logger = logger
+	return &sqlStore
+}
+
 // DBHandle returns the raw sql.DB handle.
 // It is used by the mattermostauthlayer to run their own
 // raw SQL queries.
@@ -95,6 +107,11 @@ func (s *SQLStore) DBHandle() *sql.DB {
 	return s.db
 }
 
+// DBStats returns the connection pool statistics of the store.
+func (s *SQLStore) DBStats() sql.DBStats {
+	return s.db.Stats()
+}
+
 // DBType returns the DB driver used for the store.
 func (s *SQLStore) DBType() string {
 	return s.dbType
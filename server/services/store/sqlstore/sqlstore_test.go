@@ -15,10 +15,25 @@ func TestSQLStore(t *testing.T) {
 	t.Run("SystemStore", func(t *testing.T) { storetests.StoreTestSystemStore(t, SetupTests) })
 	t.Run("UserStore", func(t *testing.T) { storetests.StoreTestUserStore(t, SetupTests) })
 	t.Run("SessionStore", func(t *testing.T) { storetests.StoreTestSessionStore(t, SetupTests) })
+	t.Run("UserTokenStore", func(t *testing.T) { storetests.StoreTestUserTokenStore(t, SetupTests) })
+	t.Run("InviteStore", func(t *testing.T) { storetests.StoreTestInviteStore(t, SetupTests) })
 	t.Run("TeamStore", func(t *testing.T) { storetests.StoreTestTeamStore(t, SetupTests) })
 	t.Run("BoardStore", func(t *testing.T) { storetests.StoreTestBoardStore(t, SetupTests) })
 	t.Run("BoardsAndBlocksStore", func(t *testing.T) { storetests.StoreTestBoardsAndBlocksStore(t, SetupTests) })
 	t.Run("SubscriptionStore", func(t *testing.T) { storetests.StoreTestSubscriptionsStore(t, SetupTests) })
 	t.Run("NotificationHintStore", func(t *testing.T) { storetests.StoreTestNotificationHintsStore(t, SetupTests) })
+	t.Run("MentionStore", func(t *testing.T) { storetests.StoreTestMentionsStore(t, SetupTests) })
+	t.Run("BoardViewsStore", func(t *testing.T) { storetests.StoreTestBoardViewsStore(t, SetupTests) })
+	t.Run("SyncOperationsStore", func(t *testing.T) { storetests.StoreTestSyncOperationsStore(t, SetupTests) })
+	t.Run("TeamUsageStore", func(t *testing.T) { storetests.StoreTestTeamUsageStore(t, SetupTests) })
+	t.Run("Transaction", func(t *testing.T) { storetests.StoreTestTransaction(t, SetupTests) })
 	t.Run("DataRetention", func(t *testing.T) { storetests.StoreTestDataRetention(t, SetupTests) })
+	t.Run("JobsStore", func(t *testing.T) { storetests.StoreTestJobsStore(t, SetupTests) })
+	t.Run("WebhooksStore", func(t *testing.T) { storetests.StoreTestWebhooksStore(t, SetupTests) })
+	t.Run("MilestonesStore", func(t *testing.T) { storetests.StoreTestMilestonesStore(t, SetupTests) })
+	t.Run("FileContentsStore", func(t *testing.T) { storetests.StoreTestFileContentsStore(t, SetupTests) })
+	t.Run("FileInfoStore", func(t *testing.T) { storetests.StoreTestFileInfoStore(t, SetupTests) })
+	t.Run("NotificationThreadsStore", func(t *testing.T) { storetests.StoreTestNotificationThreadsStore(t, SetupTests) })
+	t.Run("CardRemindersStore", func(t *testing.T) { storetests.StoreTestCardRemindersStore(t, SetupTests) })
+	t.Run("TrashStore", func(t *testing.T) { storetests.StoreTestTrashStore(t, SetupTests) })
 }
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// maxCachedStatements bounds the statements kept by the cache. Queries
+// beyond the limit run without being prepared.
+const maxCachedStatements = 100
+
+// stmtCache keeps the prepared statements of the hot query paths, keyed
+// by their SQL. Statements are prepared on the database handle, so the
+// driver prepares each of them once per connection, and are bound to the
+// transaction when the query runs in one.
+type stmtCache struct {
+	db     *sql.DB
+	logger *mlog.Logger
+
+	mu    sync.RWMutex
+	stmts map[string]*sql.Stmt
+}
+
+func newStmtCache(db *sql.DB, logger *mlog.Logger) *stmtCache {
+	return &stmtCache{
+		db:     db,
+		logger: logger,
+		stmts:  map[string]*sql.Stmt{},
+	}
+}
+
+// prepare returns the cached statement for query, preparing it if needed.
+// It returns nil when the cache is full.
+func (c *stmtCache) prepare(query string) (*sql.Stmt, error) {
+	c.mu.RLock()
+	stmt, ok := c.stmts[query]
+	c.mu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if stmt, ok := c.stmts[query]; ok {
+		return stmt, nil
+	}
+	if len(c.stmts) >= maxCachedStatements {
+		return nil, nil
+	}
+
+	stmt, err := c.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	c.stmts[query] = stmt
+	return stmt, nil
+}
+
+func (c *stmtCache) size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.stmts)
+}
+
+// close releases the prepared statements.
+func (c *stmtCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for query, stmt := range c.stmts {
+		if err := stmt.Close(); err != nil {
+			c.logger.Warn("cannot close prepared statement", mlog.String("query", query), mlog.Err(err))
+		}
+	}
+	c.stmts = map[string]*sql.Stmt{}
+}
+
+// cachedRunner runs queries with the statements of the cache, in the
+// transaction tx if it is set.
+type cachedRunner struct {
+	cache *stmtCache
+	tx    *sql.Tx
+}
+
+func (r cachedRunner) stmt(query string) (*sql.Stmt, error) {
+	stmt, err := r.cache.prepare(query)
+	if err != nil || stmt == nil {
+		return nil, err
+	}
+	if r.tx != nil {
+		return r.tx.Stmt(stmt), nil
+	}
+	return stmt, nil
+}
+
+func (r cachedRunner) runner() sq.StdSql {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.cache.db
+}
+
+func (r cachedRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := r.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return r.runner().Exec(query, args...)
+	}
+	return stmt.Exec(args...)
+}
+
+func (r cachedRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := r.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	if stmt == nil {
+		return r.runner().Query(query, args...)
+	}
+	return stmt.Query(args...)
+}
+
+func (r cachedRunner) QueryRow(query string, args ...interface{}) sq.RowScanner {
+	stmt, err := r.stmt(query)
+	if err != nil {
+		return errRow{err}
+	}
+	if stmt == nil {
+		return r.runner().QueryRow(query, args...)
+	}
+	return stmt.QueryRow(args...)
+}
+
+// errRow reports the error of preparing a QueryRow statement on Scan.
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...interface{}) error {
+	return r.err
+}
+
+// withStmtCache returns a runner that executes the queries of db with
+// cached prepared statements. It is meant for the hot query paths, whose
+// SQL doesn't change between calls.
+func (s *SQLStore) withStmtCache(db sq.BaseRunner) sq.BaseRunner {
+	switch runner := db.(type) {
+	case *sql.DB:
+		if runner == s.db {
+			return cachedRunner{cache: s.stmtCache}
+		}
+	case *sql.Tx:
+		return cachedRunner{cache: s.stmtCache, tx: runner}
+	}
+	return db
+}
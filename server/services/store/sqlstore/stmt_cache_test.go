@@ -0,0 +1,108 @@
+package sqlstore
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStmtCache(t *testing.T) {
+	t.Run("hot paths reuse their statements", func(t *testing.T) {
+		store, tearDown := SetupTests(t)
+		sqlStore := store.(*SQLStore)
+		defer tearDown()
+
+		boardID := utils.NewID(utils.IDTypeBoard)
+		blocks := make([]*model.Block, 3)
+		for i := range blocks {
+			blocks[i] = &model.Block{
+				ID:       utils.NewID(utils.IDTypeCard),
+				BoardID:  boardID,
+				ParentID: boardID,
+				Type:     model.TypeCard,
+				Title:    "card",
+			}
+			require.NoError(t, sqlStore.InsertBlock(blocks[i], "user-id"))
+		}
+
+		_, err := sqlStore.GetBlocksWithParentAndType(boardID, boardID, model.TypeCard)
+		require.NoError(t, err)
+		size := sqlStore.stmtCache.size()
+		require.NotZero(t, size)
+
+		// each block is written once more below, so that its history
+		// entries don't depend on the time between the writes
+		_, err = sqlStore.db.Exec("DELETE FROM " + sqlStore.tablePrefix + "blocks_history")
+		require.NoError(t, err)
+
+		// update and patch run the same statements again
+		blocks[0].Title = "updated"
+		require.NoError(t, sqlStore.InsertBlock(blocks[0], "user-id"))
+		title := "patched"
+		require.NoError(t, sqlStore.PatchBlock(blocks[1].ID, &model.BlockPatch{Title: &title}, "user-id"))
+		updated, err := sqlStore.GetBlock(blocks[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, "updated", updated.Title)
+		patched, err := sqlStore.GetBlock(blocks[1].ID)
+		require.NoError(t, err)
+		require.Equal(t, "patched", patched.Title)
+
+		// the update of an existing block adds its statement once
+		require.Equal(t, size+1, sqlStore.stmtCache.size())
+		require.NoError(t, sqlStore.PatchBlock(blocks[2].ID, &model.BlockPatch{Title: &title}, "user-id"))
+		require.Equal(t, size+1, sqlStore.stmtCache.size())
+	})
+
+	t.Run("statements run in the transaction", func(t *testing.T) {
+		store, tearDown := SetupTests(t)
+		sqlStore := store.(*SQLStore)
+		defer tearDown()
+
+		boardID := utils.NewID(utils.IDTypeBoard)
+		block := &model.Block{
+			ID:       utils.NewID(utils.IDTypeCard),
+			BoardID:  boardID,
+			ParentID: boardID,
+			Type:     model.TypeCard,
+		}
+
+		tx, err := sqlStore.db.Begin()
+		require.NoError(t, err)
+		require.NoError(t, sqlStore.insertBlock(tx, block, "user-id"))
+		inTx, err := sqlStore.getBlock(tx, block.ID)
+		require.NoError(t, err)
+		require.NotNil(t, inTx)
+		require.NoError(t, tx.Rollback())
+
+		rolledBack, err := sqlStore.GetBlock(block.ID)
+		require.NoError(t, err)
+		require.Nil(t, rolledBack)
+	})
+
+	t.Run("queries beyond the limit are not prepared", func(t *testing.T) {
+		store, tearDown := SetupTests(t)
+		sqlStore := store.(*SQLStore)
+		defer tearDown()
+
+		cache := newStmtCache(sqlStore.db, sqlStore.logger)
+		defer cache.close()
+
+		for i := 0; i < maxCachedStatements; i++ {
+			stmt, err := cache.prepare(fmt.Sprintf("SELECT %d", i))
+			require.NoError(t, err)
+			require.NotNil(t, stmt)
+		}
+
+		stmt, err := cache.prepare("SELECT 'one too many'")
+		require.NoError(t, err)
+		require.Nil(t, stmt)
+
+		var value string
+		runner := cachedRunner{cache: cache}
+		require.NoError(t, runner.QueryRow("SELECT 'one too many'").Scan(&value))
+		require.Equal(t, "one too many", value)
+	})
+}
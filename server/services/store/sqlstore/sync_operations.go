@@ -0,0 +1,145 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var syncOperationFields = []string{
+	"id",
+	"board_id",
+	"revision",
+	"type",
+	"block_id",
+	"data",
+	"user_id",
+	"create_at",
+}
+
+// syncOperationData is the payload of an operation stored in the data column.
+type syncOperationData struct {
+	Block *model.Block      `json:"block,omitempty"`
+	Patch *model.BlockPatch `json:"patch,omitempty"`
+}
+
+func (s *SQLStore) syncOperationsFromRows(rows *sql.Rows) ([]*model.SyncOperation, error) {
+	operations := []*model.SyncOperation{}
+
+	for rows.Next() {
+		var op model.SyncOperation
+		var data string
+		err := rows.Scan(
+			&op.ID,
+			&op.BoardID,
+			&op.Revision,
+			&op.Type,
+			&op.BlockID,
+			&data,
+			&op.UserID,
+			&op.CreateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		var payload syncOperationData
+		if err := json.Unmarshal([]byte(data), &payload); err != nil {
+			s.logger.Error("syncOperationsFromRows cannot unmarshal data", mlog.String("id", op.ID), mlog.Err(err))
+			return nil, err
+		}
+		op.Block = payload.Block
+		op.Patch = payload.Patch
+
+		operations = append(operations, &op)
+	}
+	return operations, nil
+}
+
+// insertSyncOperation appends an applied operation to the operation log
+// of its board, with the next revision of the board.
+func (s *SQLStore) insertSyncOperation(db sq.BaseRunner, op *model.SyncOperation) (*model.SyncOperation, error) {
+	revision, err := s.getSyncRevision(db, op.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(syncOperationData{Block: op.Block, Patch: op.Patch})
+	if err != nil {
+		return nil, err
+	}
+
+	opAdd := *op
+	opAdd.Revision = revision + 1
+	opAdd.CreateAt = utils.GetMillis()
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"sync_operations").
+		Columns(syncOperationFields...).
+		Values(
+			opAdd.ID,
+			opAdd.BoardID,
+			opAdd.Revision,
+			opAdd.Type,
+			opAdd.BlockID,
+			string(data),
+			opAdd.UserID,
+			opAdd.CreateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot insert sync operation",
+			mlog.String("board_id", op.BoardID),
+			mlog.String("id", op.ID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	return &opAdd, nil
+}
+
+// getSyncOperations fetches the operations of the board log after the
+// given revision, in revision order.
+func (s *SQLStore) getSyncOperations(db sq.BaseRunner, boardID string, afterRevision int64) ([]*model.SyncOperation, error) {
+	query := s.getQueryBuilder(db).
+		Select(syncOperationFields...).
+		From(s.tablePrefix + "sync_operations").
+		Where(sq.Eq{"board_id": boardID}).
+		Where(sq.Gt{"revision": afterRevision}).
+		OrderBy("revision")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch sync operations",
+			mlog.String("board_id", boardID),
+			mlog.Err(err),
+		)
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.syncOperationsFromRows(rows)
+}
+
+// getSyncRevision returns the latest revision of the board log, zero if
+// no operation was applied yet.
+func (s *SQLStore) getSyncRevision(db sq.BaseRunner, boardID string) (int64, error) {
+	var revision int64
+	row := s.getQueryBuilder(db).
+		Select("COALESCE(MAX(revision), 0)").
+		From(s.tablePrefix + "sync_operations").
+		Where(sq.Eq{"board_id": boardID}).
+		QueryRow()
+	if err := row.Scan(&revision); err != nil {
+		return 0, err
+	}
+	return revision, nil
+}
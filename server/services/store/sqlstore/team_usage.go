@@ -0,0 +1,69 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// getTeamUsage counts the boards of the team that aren't templates, and
+// the cards and views in them, and sums the size of the files of the
+// team.
+func (s *SQLStore) getTeamUsage(db sq.BaseRunner, teamID string) (*model.TeamUsage, error) {
+	usage := &model.TeamUsage{TeamID: teamID}
+
+	boardsQuery := s.getQueryBuilder(db).
+		Select("COUNT(*)").
+		From(s.tablePrefix + "boards").
+		Where(sq.Eq{"team_id": teamID}).
+		Where(sq.Eq{"is_template": false}).
+		Where(sq.Eq{"delete_at": 0})
+
+	if err := boardsQuery.QueryRow().Scan(&usage.Boards); err != nil {
+		s.logger.Error("Cannot count boards of team", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+
+	blocksQuery := s.getQueryBuilder(db).
+		Select("b.type", "COUNT(*)").
+		From(s.tablePrefix + "blocks AS b").
+		Join(s.tablePrefix + "boards AS bo ON bo.id = b.board_id").
+		Where(sq.Eq{"bo.team_id": teamID}).
+		Where(sq.Eq{"bo.is_template": false}).
+		Where(sq.Eq{"bo.delete_at": 0}).
+		Where(sq.Eq{"b.type": []string{model.TypeCard, model.TypeView}}).
+		Where(sq.Eq{"b.delete_at": 0}).
+		GroupBy("b.type")
+
+	rows, err := blocksQuery.Query()
+	if err != nil {
+		s.logger.Error("Cannot count blocks of team", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	for rows.Next() {
+		var blockType string
+		var count int64
+		if err := rows.Scan(&blockType, &count); err != nil {
+			return nil, err
+		}
+
+		switch blockType {
+		case model.TypeCard:
+			usage.Cards = count
+		case model.TypeView:
+			usage.Views = count
+		}
+	}
+
+	usage.UsedFileStorage, err = s.getTeamFileStorageUsed(db, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
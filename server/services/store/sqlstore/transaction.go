@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// txStore runs the store operations of a transaction scope. Its methods
+// are generated from the TxStore interface into tx_methods.go.
+type txStore struct {
+	store *SQLStore
+	tx    sq.BaseRunner
+}
+
+// RunInTransaction runs fn with a store whose operations all happen in
+// one transaction, committed if fn returns nil and rolled back otherwise.
+// As with the transactional methods, SQLite runs the operations directly.
+func (s *SQLStore) RunInTransaction(fn func(tx store.TxStore) error) error {
+	if s.dbType == model.SqliteDBType {
+		return fn(&txStore{store: s, tx: s.db})
+	}
+
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&txStore{store: s, tx: tx}); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			s.logger.Error("transaction rollback error", mlog.Err(rollbackErr), mlog.String("methodName", "RunInTransaction"))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("cannot commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// purgedBoardTables holds the tables with the data of a board, and the
+// column of each with the board id.
+var purgedBoardTables = map[string]string{
+	"blocks":                "board_id",
+	"blocks_history":        "board_id",
+	"boards_history":        "id",
+	"board_members":         "board_id",
+	"board_members_history": "board_id",
+	"sharing":               "id",
+	"category_boards":       "board_id",
+	"card_reminders":        "board_id",
+}
+
+// getDeletedBoardsForTeam fetches the boards of a team deleted after the
+// given time, as they were when deleted.
+func (s *SQLStore) getDeletedBoardsForTeam(db sq.BaseRunner, teamID string, deletedAfter int64) ([]*model.Board, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix + "boards_history").
+		Where(sq.Eq{"team_id": teamID}).
+		Where("id NOT IN (SELECT id FROM " + s.tablePrefix + "boards)").
+		GroupBy("id").
+		Having(sq.Gt{"MAX(delete_at)": deletedAfter})
+
+	ids, err := s.queryIDs(query)
+	if err != nil {
+		s.logger.Error("Cannot get the deleted boards", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+
+	boards := make([]*model.Board, 0, len(ids))
+	for _, id := range ids {
+		latest, err := s.getBoardHistory(db, id, model.QueryBoardHistoryOptions{Limit: 1, Descending: true})
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, latest...)
+	}
+	return boards, nil
+}
+
+// getDeletedCardsForTeam fetches the cards of the boards of a team deleted
+// after the given time, as they were when deleted. The cards of deleted
+// boards are restored with their board, so they aren't fetched.
+func (s *SQLStore) getDeletedCardsForTeam(db sq.BaseRunner, teamID string, deletedAfter int64) ([]model.Block, error) {
+	query := s.getQueryBuilder(db).
+		Select("id").
+		From(s.tablePrefix+"blocks_history").
+		Where(sq.Eq{"type": model.TypeCard}).
+		Where("board_id IN (SELECT id FROM "+s.tablePrefix+"boards WHERE team_id = ?)", teamID).
+		Where("id NOT IN (SELECT id FROM " + s.tablePrefix + "blocks)").
+		GroupBy("id").
+		Having(sq.Gt{"MAX(delete_at)": deletedAfter})
+
+	ids, err := s.queryIDs(query)
+	if err != nil {
+		s.logger.Error("Cannot get the deleted cards", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+	return s.getLatestBlockVersions(db, ids)
+}
+
+// purgeDeletedBlockTree removes the history of a deleted block and of the
+// descendants deleted with it, after which they can't be restored
+// anymore. It returns the blocks as they were when deleted.
+func (s *SQLStore) purgeDeletedBlockTree(db sq.BaseRunner, blockID string) ([]model.Block, error) {
+	latest, err := s.getBlockHistory(db, blockID, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return nil, err
+	}
+	if len(latest) == 0 || latest[0].DeleteAt == 0 {
+		return nil, model.NewErrNotFound(blockID)
+	}
+
+	purgedIDs := []string{blockID}
+	for parentIDs := purgedIDs; len(parentIDs) > 0; {
+		childIDs, err := s.getDeletedChildIDs(db, latest[0].BoardID, parentIDs, latest[0].DeleteAt)
+		if err != nil {
+			return nil, err
+		}
+		parentIDs = childIDs
+		purgedIDs = append(purgedIDs, childIDs...)
+	}
+
+	purged, err := s.getLatestBlockVersions(db, purgedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	deleteQuery := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"id": purgedIDs})
+
+	if _, err := deleteQuery.Exec(); err != nil {
+		s.logger.Error("Cannot purge the deleted block", mlog.String("block_id", blockID), mlog.Err(err))
+		return nil, err
+	}
+	return purged, nil
+}
+
+// purgeDeletedBoard removes a deleted board along with its blocks, members
+// and history, after which it can't be restored anymore. It returns the
+// blocks of the board, the deleted ones as they were when deleted.
+func (s *SQLStore) purgeDeletedBoard(db sq.BaseRunner, boardID string) ([]model.Block, error) {
+	if _, err := s.getBoard(db, boardID); !model.IsErrNotFound(err) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, model.NewErrNotFound(boardID)
+	}
+
+	blocks, err := s.getBlocksForBoard(db, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Select("id").
+		Distinct().
+		From(s.tablePrefix + "blocks_history").
+		Where(sq.Eq{"board_id": boardID}).
+		Where("id NOT IN (SELECT id FROM " + s.tablePrefix + "blocks)")
+
+	deletedIDs, err := s.queryIDs(query)
+	if err != nil {
+		s.logger.Error("Cannot get the deleted blocks of the board", mlog.String("board_id", boardID), mlog.Err(err))
+		return nil, err
+	}
+	deleted, err := s.getLatestBlockVersions(db, deletedIDs)
+	if err != nil {
+		return nil, err
+	}
+	blocks = append(blocks, deleted...)
+
+	for table, column := range purgedBoardTables {
+		deleteQuery := s.getQueryBuilder(db).
+			Delete(s.tablePrefix + table).
+			Where(sq.Eq{column: boardID})
+
+		if _, err := deleteQuery.Exec(); err != nil {
+			s.logger.Error("Cannot purge the deleted board",
+				mlog.String("board_id", boardID),
+				mlog.String("table", table),
+				mlog.Err(err),
+			)
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
+// getLatestBlockVersions returns the latest history entries of the blocks.
+func (s *SQLStore) getLatestBlockVersions(db sq.BaseRunner, blockIDs []string) ([]model.Block, error) {
+	blocks := make([]model.Block, 0, len(blockIDs))
+	for _, id := range blockIDs {
+		latest, err := s.getBlockHistory(db, id, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, latest...)
+	}
+	return blocks, nil
+}
+
+func (s *SQLStore) queryIDs(query sq.SelectBuilder) ([]string, error) {
+	rows, err := query.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make generate" from the TxStore interface
+// DO NOT EDIT
+
+// To add a method to the transaction scope, add it to one of the
+// interfaces embedded in TxStore and run `make generate`
+
+package sqlstore
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+func (s *txStore) CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	return s.store.createBoardsAndBlocks(s.tx, bab, userID)
+}
+
+func (s *txStore) CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	return s.store.createBoardsAndBlocksWithAdmin(s.tx, bab, userID)
+}
+
+func (s *txStore) DeleteBlock(blockID string, modifiedBy string) error {
+	return s.store.deleteBlock(s.tx, blockID, modifiedBy)
+}
+
+func (s *txStore) DeleteBlockTree(blockID string, modifiedBy string) ([]model.Block, error) {
+	return s.store.deleteBlockTree(s.tx, blockID, modifiedBy)
+}
+
+func (s *txStore) DeleteBoard(boardID string, userID string) error {
+	return s.store.deleteBoard(s.tx, boardID, userID)
+}
+
+func (s *txStore) DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error {
+	return s.store.deleteBoardsAndBlocks(s.tx, dbab, userID)
+}
+
+func (s *txStore) DeleteFileInfo(fileID string) error {
+	return s.store.deleteFileInfo(s.tx, fileID)
+}
+
+func (s *txStore) DeleteMember(boardID string, userID string) error {
+	return s.store.deleteMember(s.tx, boardID, userID)
+}
+
+func (s *txStore) DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error) {
+	return s.store.duplicateBlock(s.tx, boardID, blockID, userID, asTemplate)
+}
+
+func (s *txStore) DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error) {
+	return s.store.duplicateBoard(s.tx, boardID, userID, toTeam, asTemplate)
+}
+
+func (s *txStore) GetBlock(blockID string) (*model.Block, error) {
+	return s.store.getBlock(s.tx, blockID)
+}
+
+func (s *txStore) GetBlockCountForBoard(boardID string, opts model.QueryBlocksPageOptions) (int64, error) {
+	return s.store.getBlockCountForBoard(s.tx, boardID, opts)
+}
+
+func (s *txStore) GetBlockCountsByType() (map[string]int64, error) {
+	return s.store.getBlockCountsByType(s.tx)
+}
+
+func (s *txStore) GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
+	return s.store.getBlockHistory(s.tx, blockID, opts)
+}
+
+func (s *txStore) GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error) {
+	return s.store.getBlockHistoryDescendants(s.tx, boardID, opts)
+}
+
+func (s *txStore) GetBlocksForBoard(boardID string) ([]model.Block, error) {
+	return s.store.getBlocksForBoard(s.tx, boardID)
+}
+
+func (s *txStore) GetBlocksForBoardPage(boardID string, opts model.QueryBlocksPageOptions) ([]model.Block, error) {
+	return s.store.getBlocksForBoardPage(s.tx, boardID, opts)
+}
+
+func (s *txStore) GetBlocksModifiedSince(boardID string, since int64) ([]model.Block, error) {
+	return s.store.getBlocksModifiedSince(s.tx, boardID, since)
+}
+
+func (s *txStore) GetBlocksVersionForBoard(boardID string) (*model.BlocksVersion, error) {
+	return s.store.getBlocksVersionForBoard(s.tx, boardID)
+}
+
+func (s *txStore) GetBlocksWithBoardID(boardID string) ([]model.Block, error) {
+	return s.store.getBlocksWithBoardID(s.tx, boardID)
+}
+
+func (s *txStore) GetBlocksWithParent(boardID string, parentID string) ([]model.Block, error) {
+	return s.store.getBlocksWithParent(s.tx, boardID, parentID)
+}
+
+func (s *txStore) GetBlocksWithParentAndType(boardID string, parentID string, blockType string) ([]model.Block, error) {
+	return s.store.getBlocksWithParentAndType(s.tx, boardID, parentID, blockType)
+}
+
+func (s *txStore) GetBlocksWithType(boardID string, blockType string) ([]model.Block, error) {
+	return s.store.getBlocksWithType(s.tx, boardID, blockType)
+}
+
+func (s *txStore) GetBoard(id string) (*model.Board, error) {
+	return s.store.getBoard(s.tx, id)
+}
+
+func (s *txStore) GetBoardAndCard(block *model.Block) (*model.Board, *model.Block, error) {
+	return s.store.getBoardAndCard(s.tx, block)
+}
+
+func (s *txStore) GetBoardAndCardByID(blockID string) (*model.Board, *model.Block, error) {
+	return s.store.getBoardAndCardByID(s.tx, blockID)
+}
+
+func (s *txStore) GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error) {
+	return s.store.getBoardHistory(s.tx, boardID, opts)
+}
+
+func (s *txStore) GetBoardMemberHistory(boardID string, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error) {
+	return s.store.getBoardMemberHistory(s.tx, boardID, userID, limit)
+}
+
+func (s *txStore) GetBoardsForTeam(teamID string) ([]*model.Board, error) {
+	return s.store.getBoardsForTeam(s.tx, teamID)
+}
+
+func (s *txStore) GetBoardsForUserAndTeam(userID string, teamID string) ([]*model.Board, error) {
+	return s.store.getBoardsForUserAndTeam(s.tx, userID, teamID)
+}
+
+func (s *txStore) GetBoardsWithProperty(key string) ([]*model.Board, error) {
+	return s.store.getBoardsWithProperty(s.tx, key)
+}
+
+func (s *txStore) GetFileStorageUsed() (int64, error) {
+	return s.store.getFileStorageUsed(s.tx)
+}
+
+func (s *txStore) GetLastBlockUpdatesForTeam(teamID string) (map[string]int64, error) {
+	return s.store.getLastBlockUpdatesForTeam(s.tx, teamID)
+}
+
+func (s *txStore) GetMemberForBoard(boardID string, userID string) (*model.BoardMember, error) {
+	return s.store.getMemberForBoard(s.tx, boardID, userID)
+}
+
+func (s *txStore) GetMembersForBoard(boardID string) ([]*model.BoardMember, error) {
+	return s.store.getMembersForBoard(s.tx, boardID)
+}
+
+func (s *txStore) GetMembersForUser(userID string) ([]*model.BoardMember, error) {
+	return s.store.getMembersForUser(s.tx, userID)
+}
+
+func (s *txStore) GetSharing(rootID string) (*model.Sharing, error) {
+	return s.store.getSharing(s.tx, rootID)
+}
+
+func (s *txStore) GetSubTree2(boardID string, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error) {
+	return s.store.getSubTree2(s.tx, boardID, blockID, opts)
+}
+
+func (s *txStore) GetTeamUsage(teamID string) (*model.TeamUsage, error) {
+	return s.store.getTeamUsage(s.tx, teamID)
+}
+
+func (s *txStore) GetTemplateBoards(teamID string, userID string) ([]*model.Board, error) {
+	return s.store.getTemplateBoards(s.tx, teamID, userID)
+}
+
+func (s *txStore) InsertBlock(block *model.Block, userID string) error {
+	return s.store.insertBlock(s.tx, block, userID)
+}
+
+func (s *txStore) InsertBlocks(blocks []model.Block, userID string) error {
+	return s.store.insertBlocks(s.tx, blocks, userID)
+}
+
+func (s *txStore) InsertBoard(board *model.Board, userID string) (*model.Board, error) {
+	return s.store.insertBoard(s.tx, board, userID)
+}
+
+func (s *txStore) InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error) {
+	return s.store.insertBoardWithAdmin(s.tx, board, userID)
+}
+
+func (s *txStore) PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error {
+	return s.store.patchBlock(s.tx, blockID, blockPatch, userID)
+}
+
+func (s *txStore) PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error {
+	return s.store.patchBlocks(s.tx, blockPatches, userID)
+}
+
+func (s *txStore) PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error) {
+	return s.store.patchBoard(s.tx, boardID, boardPatch, userID)
+}
+
+func (s *txStore) PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error) {
+	return s.store.patchBoardsAndBlocks(s.tx, pbab, userID)
+}
+
+func (s *txStore) PurgeDeletedBlocks(deletedBefore int64, limit uint64) ([]model.Block, error) {
+	return s.store.purgeDeletedBlocks(s.tx, deletedBefore, limit)
+}
+
+func (s *txStore) RemoveDefaultTemplates(boards []*model.Board) error {
+	return s.store.removeDefaultTemplates(s.tx, boards)
+}
+
+func (s *txStore) RevokeAllSharing(modifiedBy string) (int64, error) {
+	return s.store.revokeAllSharing(s.tx, modifiedBy)
+}
+
+func (s *txStore) SaveFileInfo(info *model.FileInfo) error {
+	return s.store.saveFileInfo(s.tx, info)
+}
+
+func (s *txStore) SaveMember(bm *model.BoardMember) (*model.BoardMember, error) {
+	return s.store.saveMember(s.tx, bm)
+}
+
+func (s *txStore) SearchBlocksForBoards(boardIDs []string, term string, blockTypes []model.BlockType, filters model.SearchFilters) ([]model.Block, error) {
+	return s.store.searchBlocksForBoards(s.tx, boardIDs, term, blockTypes, filters)
+}
+
+func (s *txStore) SearchBoardsForUser(term string, userID string) ([]*model.Board, error) {
+	return s.store.searchBoardsForUser(s.tx, term, userID)
+}
+
+func (s *txStore) UndeleteBlock(blockID string, modifiedBy string) error {
+	return s.store.undeleteBlock(s.tx, blockID, modifiedBy)
+}
+
+func (s *txStore) UndeleteBlockTree(blockID string, modifiedBy string) ([]model.Block, error) {
+	return s.store.undeleteBlockTree(s.tx, blockID, modifiedBy)
+}
+
+func (s *txStore) UndeleteBoard(boardID string, modifiedBy string) error {
+	return s.store.undeleteBoard(s.tx, boardID, modifiedBy)
+}
+
+func (s *txStore) UpsertSharing(sharing model.Sharing) error {
+	return s.store.upsertSharing(s.tx, sharing)
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 
@@ -210,6 +211,37 @@ func (s *SQLStore) searchUsersByTeam(db sq.BaseRunner, _ string, searchQuery str
 	return s.getUsersByCondition(db, &sq.Like{"username": "%" + searchQuery + "%"}, 10)
 }
 
+// searchUserDirectory returns the users whose username starts with the
+// prefix, sorted by username. Standalone users are not part of teams, so
+// all the users are listed.
+func (s *SQLStore) searchUserDirectory(db sq.BaseRunner, _ string, prefix string, offset int, limit int) ([]*model.UserDirectoryEntry, error) {
+	query := s.getQueryBuilder(db).
+		Select("id", "username").
+		From(s.tablePrefix + "users").
+		Where(sq.Eq{"delete_at": 0}).
+		Where(sq.Expr("LOWER(username) LIKE ? ESCAPE '"+model.UserDirectoryEscapeChar+"'", model.UserDirectoryLikePattern(prefix))).
+		OrderBy("username").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error(`searchUserDirectory ERROR`, mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	users := []*model.UserDirectoryEntry{}
+	for rows.Next() {
+		var user model.UserDirectoryEntry
+		if err := rows.Scan(&user.ID, &user.Username); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
 func (s *SQLStore) usersFromRows(rows *sql.Rows) ([]*model.User, error) {
 	users := []*model.User{}
 
@@ -265,3 +297,13 @@ func (s *SQLStore) patchUserProps(db sq.BaseRunner, userID string, patch model.U
 
 	return s.updateUser(db, user)
 }
+
+// getUserAvailability reads the availability of a user from their custom
+// status, as there are no out of office statuses outside of Mattermost.
+func (s *SQLStore) getUserAvailability(db sq.BaseRunner, userID string) (*model.UserAvailability, error) {
+	user, err := s.getUserByID(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	return model.UserAvailabilityFromProps(userID, user.Props, time.Now()), nil
+}
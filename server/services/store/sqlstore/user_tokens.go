@@ -0,0 +1,70 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func (s *SQLStore) createUserToken(db sq.BaseRunner, token *model.UserToken) error {
+	now := utils.GetMillis()
+
+	query := s.getQueryBuilder(db).Insert(s.tablePrefix+"user_tokens").
+		Columns("token", "type", "user_id", "email", "create_at").
+		Values(token.Token, token.Type, token.UserID, token.Email, now)
+
+	if _, err := query.Exec(); err != nil {
+		return err
+	}
+	token.CreateAt = now
+	return nil
+}
+
+func (s *SQLStore) getUserToken(db sq.BaseRunner, token string) (*model.UserToken, error) {
+	query := s.getQueryBuilder(db).
+		Select("token", "type", "user_id", "email", "create_at").
+		From(s.tablePrefix + "user_tokens").
+		Where(sq.Eq{"token": token})
+
+	var userToken model.UserToken
+	var email sql.NullString
+	err := query.QueryRow().Scan(&userToken.Token, &userToken.Type, &userToken.UserID, &email, &userToken.CreateAt)
+	if err == sql.ErrNoRows {
+		return nil, model.NewErrNotFound("user token")
+	}
+	if err != nil {
+		return nil, err
+	}
+	userToken.Email = email.String
+
+	return &userToken, nil
+}
+
+func (s *SQLStore) deleteUserToken(db sq.BaseRunner, token string) error {
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "user_tokens").
+		Where(sq.Eq{"token": token})
+
+	_, err := query.Exec()
+	return err
+}
+
+// deleteUserTokensForUser deletes the tokens of the type issued for the
+// user, so that requesting a new token invalidates the previous ones.
+func (s *SQLStore) deleteUserTokensForUser(db sq.BaseRunner, userID, tokenType string) error {
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "user_tokens").
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Eq{"type": tokenType})
+
+	_, err := query.Exec()
+	return err
+}
+
+func (s *SQLStore) cleanUpUserTokens(db sq.BaseRunner, createdBefore int64) error {
+	query := s.getQueryBuilder(db).Delete(s.tablePrefix + "user_tokens").
+		Where(sq.Lt{"create_at": createdBefore})
+
+	_, err := query.Exec()
+	return err
+}
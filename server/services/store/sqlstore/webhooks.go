@@ -0,0 +1,224 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+var webhookFields = []string{
+	"id",
+	"team_id",
+	"url",
+	"secret",
+	"block_types",
+	"board_ids",
+	"actions",
+	"created_by",
+	"create_at",
+	"update_at",
+}
+
+type webhookFilters struct {
+	blockTypes string
+	boardIDs   string
+	actions    string
+}
+
+func marshalWebhookFilters(webhook *model.Webhook) (*webhookFilters, error) {
+	blockTypes, err := json.Marshal(webhook.BlockTypes)
+	if err != nil {
+		return nil, err
+	}
+	boardIDs, err := json.Marshal(webhook.BoardIDs)
+	if err != nil {
+		return nil, err
+	}
+	actions, err := json.Marshal(webhook.Actions)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookFilters{
+		blockTypes: string(blockTypes),
+		boardIDs:   string(boardIDs),
+		actions:    string(actions),
+	}, nil
+}
+
+func unmarshalWebhookFilter(data sql.NullString, v interface{}) error {
+	if !data.Valid || data.String == "" || data.String == "null" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data.String), v)
+}
+
+func (s *SQLStore) webhooksFromRows(rows *sql.Rows) ([]*model.Webhook, error) {
+	webhooks := []*model.Webhook{}
+
+	for rows.Next() {
+		var webhook model.Webhook
+		var blockTypes, boardIDs, actions, createdBy sql.NullString
+		err := rows.Scan(
+			&webhook.ID,
+			&webhook.TeamID,
+			&webhook.URL,
+			&webhook.Secret,
+			&blockTypes,
+			&boardIDs,
+			&actions,
+			&createdBy,
+			&webhook.CreateAt,
+			&webhook.UpdateAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.BlockTypes = []model.BlockType{}
+		webhook.BoardIDs = []string{}
+		webhook.Actions = []model.WebhookAction{}
+		if err := unmarshalWebhookFilter(blockTypes, &webhook.BlockTypes); err != nil {
+			s.logger.Error("webhooksFromRows cannot unmarshal block types", mlog.String("id", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+		if err := unmarshalWebhookFilter(boardIDs, &webhook.BoardIDs); err != nil {
+			s.logger.Error("webhooksFromRows cannot unmarshal board ids", mlog.String("id", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+		if err := unmarshalWebhookFilter(actions, &webhook.Actions); err != nil {
+			s.logger.Error("webhooksFromRows cannot unmarshal actions", mlog.String("id", webhook.ID), mlog.Err(err))
+			return nil, err
+		}
+		webhook.CreatedBy = createdBy.String
+
+		webhooks = append(webhooks, &webhook)
+	}
+	return webhooks, nil
+}
+
+func (s *SQLStore) createWebhook(db sq.BaseRunner, webhook *model.Webhook) (*model.Webhook, error) {
+	newWebhook := *webhook
+	if newWebhook.ID == "" {
+		newWebhook.ID = utils.NewID(utils.IDTypeNone)
+	}
+	now := utils.GetMillis()
+	newWebhook.CreateAt = now
+	newWebhook.UpdateAt = now
+
+	filters, err := marshalWebhookFilters(&newWebhook)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.getQueryBuilder(db).
+		Insert(s.tablePrefix+"webhooks").
+		Columns(webhookFields...).
+		Values(
+			newWebhook.ID,
+			newWebhook.TeamID,
+			newWebhook.URL,
+			newWebhook.Secret,
+			filters.blockTypes,
+			filters.boardIDs,
+			filters.actions,
+			newWebhook.CreatedBy,
+			newWebhook.CreateAt,
+			newWebhook.UpdateAt,
+		)
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot create webhook", mlog.String("team_id", newWebhook.TeamID), mlog.Err(err))
+		return nil, err
+	}
+	return &newWebhook, nil
+}
+
+func (s *SQLStore) getWebhook(db sq.BaseRunner, webhookID string) (*model.Webhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookFields...).
+		From(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"id": webhookID})
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch webhook", mlog.String("id", webhookID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	webhooks, err := s.webhooksFromRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, model.NewErrNotFound(webhookID)
+	}
+	return webhooks[0], nil
+}
+
+func (s *SQLStore) getWebhooksForTeam(db sq.BaseRunner, teamID string) ([]*model.Webhook, error) {
+	query := s.getQueryBuilder(db).
+		Select(webhookFields...).
+		From(s.tablePrefix+"webhooks").
+		Where(sq.Eq{"team_id": teamID}).
+		OrderBy("create_at", "id")
+
+	rows, err := query.Query()
+	if err != nil {
+		s.logger.Error("Cannot fetch webhooks of team", mlog.String("team_id", teamID), mlog.Err(err))
+		return nil, err
+	}
+	defer s.CloseRows(rows)
+
+	return s.webhooksFromRows(rows)
+}
+
+func (s *SQLStore) updateWebhook(db sq.BaseRunner, webhook *model.Webhook) error {
+	filters, err := marshalWebhookFilters(webhook)
+	if err != nil {
+		return err
+	}
+
+	webhook.UpdateAt = utils.GetMillis()
+	query := s.getQueryBuilder(db).
+		Update(s.tablePrefix+"webhooks").
+		Set("url", webhook.URL).
+		Set("block_types", filters.blockTypes).
+		Set("board_ids", filters.boardIDs).
+		Set("actions", filters.actions).
+		Set("update_at", webhook.UpdateAt).
+		Where(sq.Eq{"id": webhook.ID})
+
+	if _, err := query.Exec(); err != nil {
+		s.logger.Error("Cannot update webhook", mlog.String("id", webhook.ID), mlog.Err(err))
+		return err
+	}
+	return nil
+}
+
+func (s *SQLStore) deleteWebhook(db sq.BaseRunner, webhookID string) error {
+	result, err := s.getQueryBuilder(db).
+		Delete(s.tablePrefix + "webhooks").
+		Where(sq.Eq{"id": webhookID}).
+		Exec()
+	if err != nil {
+		s.logger.Error("Cannot delete webhook", mlog.String("id", webhookID), mlog.Err(err))
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return model.NewErrNotFound(webhookID)
+	}
+	return nil
+}
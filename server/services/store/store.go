@@ -3,6 +3,7 @@
 package store
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/mattermost/focalboard/server/model"
@@ -10,14 +11,19 @@ import (
 	mmModel "github.com/mattermost/mattermost-server/v6/model"
 )
 
-// Store represents the abstraction of the data storage.
-type Store interface {
+// BlocksStore holds the operations on the blocks of the boards.
+type BlocksStore interface {
 	GetBlocksWithParentAndType(boardID, parentID string, blockType string) ([]model.Block, error)
 	GetBlocksWithParent(boardID, parentID string) ([]model.Block, error)
 	GetBlocksWithBoardID(boardID string) ([]model.Block, error)
 	GetBlocksWithType(boardID, blockType string) ([]model.Block, error)
 	GetSubTree2(boardID, blockID string, opts model.QuerySubtreeOptions) ([]model.Block, error)
 	GetBlocksForBoard(boardID string) ([]model.Block, error)
+	GetBlocksForBoardPage(boardID string, opts model.QueryBlocksPageOptions) ([]model.Block, error)
+	GetBlockCountForBoard(boardID string, opts model.QueryBlocksPageOptions) (int64, error)
+	GetBlocksVersionForBoard(boardID string) (*model.BlocksVersion, error)
+	GetBlocksModifiedSince(boardID string, since int64) ([]model.Block, error)
+	SearchBlocksForBoards(boardIDs []string, term string, blockTypes []model.BlockType, filters model.SearchFilters) ([]model.Block, error)
 	// @withTransaction
 	InsertBlock(block *model.Block, userID string) error
 	// @withTransaction
@@ -27,29 +33,73 @@ type Store interface {
 	// @withTransaction
 	UndeleteBlock(blockID string, modifiedBy string) error
 	// @withTransaction
-	UndeleteBoard(boardID string, modifiedBy string) error
-	GetBlockCountsByType() (map[string]int64, error)
+	DeleteBlockTree(blockID string, modifiedBy string) ([]model.Block, error)
+	// @withTransaction
+	UndeleteBlockTree(blockID string, modifiedBy string) ([]model.Block, error)
+	// @withTransaction
+	PurgeDeletedBlocks(deletedBefore int64, limit uint64) ([]model.Block, error)
 	GetBlock(blockID string) (*model.Block, error)
 	// @withTransaction
 	PatchBlock(blockID string, blockPatch *model.BlockPatch, userID string) error
 	GetBlockHistory(blockID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
 	GetBlockHistoryDescendants(boardID string, opts model.QueryBlockHistoryOptions) ([]model.Block, error)
+	// @withTransaction
+	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error)
+	// @withTransaction
+	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
+}
+
+// BoardsStore holds the operations on the boards and their members.
+type BoardsStore interface {
+	InsertBoard(board *model.Board, userID string) (*model.Board, error)
+	// @withTransaction
+	InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error)
+	// @withTransaction
+	PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error)
+	GetBoard(id string) (*model.Board, error)
+	GetBoardsForUserAndTeam(userID, teamID string) ([]*model.Board, error)
+	GetBoardsForTeam(teamID string) ([]*model.Board, error)
+	// @withTransaction
+	DeleteBoard(boardID, userID string) error
+	// @withTransaction
+	UndeleteBoard(boardID string, modifiedBy string) error
 	GetBoardHistory(boardID string, opts model.QueryBoardHistoryOptions) ([]*model.Board, error)
 	GetBoardAndCardByID(blockID string) (board *model.Board, card *model.Block, err error)
 	GetBoardAndCard(block *model.Block) (board *model.Board, card *model.Block, err error)
 	// @withTransaction
 	DuplicateBoard(boardID string, userID string, toTeam string, asTemplate bool) (*model.BoardsAndBlocks, []*model.BoardMember, error)
+
+	SaveMember(bm *model.BoardMember) (*model.BoardMember, error)
+	DeleteMember(boardID, userID string) error
+	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
+	GetBoardMemberHistory(boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error)
+	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	GetMembersForUser(userID string) ([]*model.BoardMember, error)
+	SearchBoardsForUser(term, userID string) ([]*model.Board, error)
+	GetBoardsWithProperty(key string) ([]*model.Board, error)
+
 	// @withTransaction
-	DuplicateBlock(boardID string, blockID string, userID string, asTemplate bool) ([]model.Block, error)
+	CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error)
 	// @withTransaction
-	PatchBlocks(blockPatches *model.BlockPatchBatch, userID string) error
+	CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
+	// @withTransaction
+	PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
+	// @withTransaction
+	DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error
 
-	Shutdown() error
+	RemoveDefaultTemplates(boards []*model.Board) error
+	GetTemplateBoards(teamID, userID string) ([]*model.Board, error)
+}
 
-	GetSystemSetting(key string) (string, error)
-	GetSystemSettings() (map[string]string, error)
-	SetSystemSetting(key, value string) error
+// SharingStore holds the operations on the sharing settings of the boards.
+type SharingStore interface {
+	UpsertSharing(sharing model.Sharing) error
+	GetSharing(rootID string) (*model.Sharing, error)
+	RevokeAllSharing(modifiedBy string) (int64, error)
+}
 
+// UserStore holds the operations on the users.
+type UserStore interface {
 	GetRegisteredUserCount() (int, error)
 	GetUserByID(userID string) (*model.User, error)
 	GetUserByEmail(email string) (*model.User, error)
@@ -60,19 +110,179 @@ type Store interface {
 	UpdateUserPasswordByID(userID, password string) error
 	GetUsersByTeam(teamID string) ([]*model.User, error)
 	SearchUsersByTeam(teamID string, searchQuery string) ([]*model.User, error)
+	SearchUserDirectory(teamID string, prefix string, offset int, limit int) ([]*model.UserDirectoryEntry, error)
 	PatchUserProps(userID string, patch model.UserPropPatch) error
-
 	GetActiveUserCount(updatedSecondsAgo int64) (int, error)
+	GetUserAvailability(userID string) (*model.UserAvailability, error)
+}
+
+// LimitsStore holds the aggregate queries used to report the usage
+// against the limits, and the infos of the uploaded files that the
+// storage usage is computed from.
+type LimitsStore interface {
+	GetBlockCountsByType() (map[string]int64, error)
+	GetLastBlockUpdatesForTeam(teamID string) (map[string]int64, error)
+	GetTeamUsage(teamID string) (*model.TeamUsage, error)
+	SaveFileInfo(info *model.FileInfo) error
+	DeleteFileInfo(fileID string) error
+	GetFileStorageUsed() (int64, error)
+}
+
+// JobsStore holds the operations on the persisted background jobs.
+type JobsStore interface {
+	CreateJob(job *model.Job) (*model.Job, error)
+	GetJob(jobID string) (*model.Job, error)
+	GetJobs(opts model.QueryJobsOptions) ([]*model.Job, error)
+	GetLatestJob(jobType string) (*model.Job, error)
+	ClaimJob(types []string, now int64) (*model.Job, error)
+	UpdateJob(job *model.Job) error
+	ResetStaleJobs(updatedBefore int64) (int64, error)
+}
+
+// WebhooksStore holds the operations on the webhooks registered by the
+// teams.
+type WebhooksStore interface {
+	CreateWebhook(webhook *model.Webhook) (*model.Webhook, error)
+	GetWebhook(webhookID string) (*model.Webhook, error)
+	GetWebhooksForTeam(teamID string) ([]*model.Webhook, error)
+	UpdateWebhook(webhook *model.Webhook) error
+	DeleteWebhook(webhookID string) error
+}
+
+// MilestonesStore holds the operations on the milestones of the teams.
+type MilestonesStore interface {
+	CreateMilestone(milestone *model.Milestone) (*model.Milestone, error)
+	GetMilestone(milestoneID string) (*model.Milestone, error)
+	GetMilestonesForTeam(teamID string) ([]*model.Milestone, error)
+	UpdateMilestone(milestone *model.Milestone) error
+	DeleteMilestone(milestoneID string) error
+}
+
+// CardRemindersStore holds the operations on the reminders the users set
+// on the cards.
+type CardRemindersStore interface {
+	CreateCardReminder(reminder *model.CardReminder) (*model.CardReminder, error)
+	GetCardReminder(reminderID string) (*model.CardReminder, error)
+	GetCardRemindersForUser(userID, cardID string) ([]*model.CardReminder, error)
+	UpdateCardReminder(reminder *model.CardReminder) error
+	DeleteCardReminder(reminderID string) error
+	GetDueCardReminders(remindBefore int64) ([]*model.CardReminder, error)
+	MarkCardRemindersDelivered(reminderIDs []string) error
+}
+
+// MentionsStore holds the operations on the mentions of the users in the
+// cards and their reminders.
+type MentionsStore interface {
+	CreateMention(mention *model.Mention) (*model.Mention, error)
+	GetUnreadMentionsForUser(userID string) ([]*model.Mention, error)
+	MarkMentionsRead(userID string, mentionIDs []string) error
+	GetMentionsToRemind(createdBefore int64) ([]*model.Mention, error)
+	MarkMentionsReminded(mentionIDs []string) error
+}
+
+// TrashStore holds the operations on the deleted boards and cards of the
+// teams, which can be restored until they are purged.
+type TrashStore interface {
+	GetDeletedBoardsForTeam(teamID string, deletedAfter int64) ([]*model.Board, error)
+	GetDeletedCardsForTeam(teamID string, deletedAfter int64) ([]model.Block, error)
+	// @withTransaction
+	PurgeDeletedBlockTree(blockID string) ([]model.Block, error)
+	// @withTransaction
+	PurgeDeletedBoard(boardID string) ([]model.Block, error)
+}
+
+// BoardViewsStore holds the times the users last viewed the boards, used
+// to report the changes made since.
+type BoardViewsStore interface {
+	SaveBoardView(view *model.BoardView) error
+	GetBoardChangesForUser(userID, teamID string) ([]*model.BoardChanges, error)
+}
+
+// SyncOperationsStore holds the operation logs of the boards, ordered by
+// revision, that the clients synchronize through the sync API.
+type SyncOperationsStore interface {
+	// @withTransaction
+	InsertSyncOperation(op *model.SyncOperation) (*model.SyncOperation, error)
+	GetSyncOperations(boardID string, afterRevision int64) ([]*model.SyncOperation, error)
+	GetSyncRevision(boardID string) (int64, error)
+}
+
+// FileContentsStore holds the text extracted from the attached files, used
+// to search them.
+type FileContentsStore interface {
+	SaveFileContent(content *model.FileContent) error
+	SearchFileContents(boardIDs []string, term string) ([]*model.FileContent, error)
+}
+
+// UserTokensStore holds the single use tokens emailed to the users to
+// verify their email address or reset their password.
+type UserTokensStore interface {
+	CreateUserToken(token *model.UserToken) error
+	GetUserToken(token string) (*model.UserToken, error)
+	DeleteUserToken(token string) error
+	DeleteUserTokensForUser(userID, tokenType string) error
+	CleanUpUserTokens(createdBefore int64) error
+}
+
+// InvitesStore holds the invites to join the teams generated by their
+// admins.
+type InvitesStore interface {
+	CreateInvite(invite *model.Invite) (*model.Invite, error)
+	GetInvite(inviteID string) (*model.Invite, error)
+	GetInviteByToken(token string) (*model.Invite, error)
+	GetInvitesForTeam(teamID string) ([]*model.Invite, error)
+	UseInvite(token string, now int64) (bool, error)
+	DeleteInvite(inviteID string) error
+}
+
+// TxStore is the part of the store available to the multi-step
+// operations run with RunInTransaction. Users are left out as they can
+// come from a different source than the rest of the data, like the
+// Mattermost auth layer.
+type TxStore interface {
+	BlocksStore
+	BoardsStore
+	SharingStore
+	LimitsStore
+}
+
+// Store represents the abstraction of the data storage.
+type Store interface {
+	BlocksStore
+	BoardsStore
+	SharingStore
+	UserStore
+	LimitsStore
+	JobsStore
+	WebhooksStore
+	MilestonesStore
+	CardRemindersStore
+	MentionsStore
+	TrashStore
+	BoardViewsStore
+	SyncOperationsStore
+	FileContentsStore
+	UserTokensStore
+	InvitesStore
+
+	// RunInTransaction runs fn with a store scoped to a transaction,
+	// which is committed if fn returns nil and rolled back otherwise.
+	RunInTransaction(fn func(tx TxStore) error) error
+
+	Shutdown() error
+
+	GetSystemSetting(key string) (string, error)
+	GetSystemSettings() (map[string]string, error)
+	SetSystemSetting(key, value string) error
+
 	GetSession(token string, expireTime int64) (*model.Session, error)
 	CreateSession(session *model.Session) error
 	RefreshSession(session *model.Session) error
 	UpdateSession(session *model.Session) error
 	DeleteSession(sessionID string) error
+	DeleteSessionsForUser(userID string) error
 	CleanUpSessions(expireTime int64) error
 
-	UpsertSharing(sharing model.Sharing) error
-	GetSharing(rootID string) (*model.Sharing, error)
-
 	UpsertTeamSignupToken(team model.Team) error
 	UpsertTeamSettings(team model.Team) error
 	GetTeam(ID string) (*model.Team, error)
@@ -80,33 +290,6 @@ type Store interface {
 	GetAllTeams() ([]*model.Team, error)
 	GetTeamCount() (int64, error)
 
-	InsertBoard(board *model.Board, userID string) (*model.Board, error)
-	// @withTransaction
-	InsertBoardWithAdmin(board *model.Board, userID string) (*model.Board, *model.BoardMember, error)
-	// @withTransaction
-	PatchBoard(boardID string, boardPatch *model.BoardPatch, userID string) (*model.Board, error)
-	GetBoard(id string) (*model.Board, error)
-	GetBoardsForUserAndTeam(userID, teamID string) ([]*model.Board, error)
-	// @withTransaction
-	DeleteBoard(boardID, userID string) error
-
-	SaveMember(bm *model.BoardMember) (*model.BoardMember, error)
-	DeleteMember(boardID, userID string) error
-	GetMemberForBoard(boardID, userID string) (*model.BoardMember, error)
-	GetBoardMemberHistory(boardID, userID string, limit uint64) ([]*model.BoardMemberHistoryEntry, error)
-	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
-	GetMembersForUser(userID string) ([]*model.BoardMember, error)
-	SearchBoardsForUser(term, userID string) ([]*model.Board, error)
-
-	// @withTransaction
-	CreateBoardsAndBlocksWithAdmin(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, []*model.BoardMember, error)
-	// @withTransaction
-	CreateBoardsAndBlocks(bab *model.BoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
-	// @withTransaction
-	PatchBoardsAndBlocks(pbab *model.PatchBoardsAndBlocks, userID string) (*model.BoardsAndBlocks, error)
-	// @withTransaction
-	DeleteBoardsAndBlocks(dbab *model.DeleteBoardsAndBlocks, userID string) error
-
 	GetCategory(id string) (*model.Category, error)
 	CreateCategory(category model.Category) error
 	UpdateCategory(category model.Category) error
@@ -130,13 +313,14 @@ type Store interface {
 	GetNotificationHint(blockID string) (*model.NotificationHint, error)
 	GetNextNotificationHint(remove bool) (*model.NotificationHint, error)
 
-	RemoveDefaultTemplates(boards []*model.Board) error
-	GetTemplateBoards(teamID, userID string) ([]*model.Board, error)
+	SaveNotificationThread(thread *model.NotificationThread) error
+	GetNotificationThread(cardID, channelID string) (*model.NotificationThread, error)
 
 	// @withTransaction
 	RunDataRetention(globalRetentionDate int64, batchSize int64) (int64, error)
 
 	DBType() string
+	DBStats() sql.DBStats
 
 	GetLicense() *mmModel.License
 }
@@ -1,6 +1,7 @@
 package storetests
 
 import (
+	"sort"
 	"testing"
 	"time"
 
@@ -49,6 +50,21 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testUndeleteBlock(t, store)
 	})
+	t.Run("DeleteBlockTree", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteBlockTree(t, store)
+	})
+	t.Run("UndeleteBlockTree", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUndeleteBlockTree(t, store)
+	})
+	t.Run("PurgeDeletedBlocks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPurgeDeletedBlocks(t, store)
+	})
 	t.Run("GetSubTree2", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -74,6 +90,16 @@ func StoreTestBlocksStore(t *testing.T, setup func(t *testing.T) (store.Store, f
 		defer tearDown()
 		testGetBlockMetadata(t, store)
 	})
+	t.Run("GetLastBlockUpdatesForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetLastBlockUpdatesForTeam(t, store)
+	})
+	t.Run("SearchBlocksForBoards", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSearchBlocksForBoards(t, store)
+	})
 }
 
 func testInsertBlock(t *testing.T, store store.Store) {
@@ -357,6 +383,29 @@ func testPatchBlock(t *testing.T, store store.Store) {
 		require.Equal(t, "test value 2", retrievedBlock.Fields["test2"])
 		require.Equal(t, nil, retrievedBlock.Fields["test3"])
 	})
+
+	t.Run("base version", func(t *testing.T) {
+		existingBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+		baseUpdateAt := existingBlock.UpdateAt
+
+		// Wait for not colliding the ID+insert_at key
+		time.Sleep(1 * time.Millisecond)
+
+		newTitle := "Based title"
+		err = store.PatchBlock("id-test", &model.BlockPatch{Title: &newTitle, BaseUpdateAt: baseUpdateAt}, "user-id-2")
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+
+		staleTitle := "Stale title"
+		err = store.PatchBlock("id-test", &model.BlockPatch{Title: &staleTitle, BaseUpdateAt: baseUpdateAt}, "user-id-2")
+		require.True(t, model.IsErrBlockPatchConflict(err))
+
+		retrievedBlock, err := store.GetBlock("id-test")
+		require.NoError(t, err)
+		require.Equal(t, "Based title", retrievedBlock.Title)
+	})
 }
 
 func testPatchBlocks(t *testing.T, store store.Store) {
@@ -764,6 +813,131 @@ func testGetBlocks(t *testing.T, store store.Store) {
 		require.NoError(t, err)
 		require.Len(t, blocks, 5)
 	})
+
+	t.Run("blocks of a board in pages", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		opts := model.QueryBlocksPageOptions{Limit: 2}
+		blockIDs := []string{}
+		for {
+			blocks, err = store.GetBlocksForBoardPage(boardID, opts)
+			require.NoError(t, err)
+			for _, block := range blocks {
+				blockIDs = append(blockIDs, block.ID)
+			}
+			if len(blocks) < 2 {
+				break
+			}
+			opts.AfterID = blocks[len(blocks)-1].ID
+		}
+		require.Equal(t, []string{"block1", "block2", "block3", "block4", "block5"}, blockIDs)
+	})
+
+	t.Run("blocks of a parent in pages", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		parentID := "block1"
+		opts := model.QueryBlocksPageOptions{ParentID: &parentID, BlockType: "test", Limit: 1}
+		blocks, err = store.GetBlocksForBoardPage(boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "block2", blocks[0].ID)
+
+		opts.AfterID = blocks[0].ID
+		blocks, err = store.GetBlocksForBoardPage(boardID, opts)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+		require.Equal(t, "block3", blocks[0].ID)
+
+		opts.AfterID = blocks[0].ID
+		blocks, err = store.GetBlocksForBoardPage(boardID, opts)
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
+
+	t.Run("count blocks of a board", func(t *testing.T) {
+		count, err := store.GetBlockCountForBoard(boardID, model.QueryBlocksPageOptions{Limit: 1, AfterID: "block3"})
+		require.NoError(t, err)
+		require.Equal(t, int64(5), count)
+
+		rootID := ""
+		count, err = store.GetBlockCountForBoard(boardID, model.QueryBlocksPageOptions{ParentID: &rootID})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+
+		count, err = store.GetBlockCountForBoard(boardID, model.QueryBlocksPageOptions{BlockType: "test2"})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count)
+	})
+
+	t.Run("excluded blocks and their children", func(t *testing.T) {
+		opts := model.QueryBlocksPageOptions{ExcludedIDs: []string{"block2"}, Limit: 10}
+		blocks, err = store.GetBlocksForBoardPage(boardID, opts)
+		require.NoError(t, err)
+		blockIDs := []string{}
+		for _, block := range blocks {
+			blockIDs = append(blockIDs, block.ID)
+		}
+		require.Equal(t, []string{"block1", "block3", "block4"}, blockIDs)
+
+		count, err := store.GetBlockCountForBoard(boardID, opts)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), count)
+	})
+
+	t.Run("version of the blocks of a board", func(t *testing.T) {
+		version, err := store.GetBlocksVersionForBoard(boardID)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), version.Count)
+		require.NotZero(t, version.LastUpdateAt)
+
+		time.Sleep(10 * time.Millisecond)
+		extra := []model.Block{{ID: "block6", BoardID: boardID, ModifiedBy: testUserID, Type: "test"}}
+		InsertBlocks(t, store, extra, testUserID)
+
+		inserted, err := store.GetBlocksVersionForBoard(boardID)
+		require.NoError(t, err)
+		require.Equal(t, int64(6), inserted.Count)
+		require.Greater(t, inserted.LastUpdateAt, version.LastUpdateAt)
+
+		time.Sleep(10 * time.Millisecond)
+		DeleteBlocks(t, store, extra, testUserID)
+
+		deleted, err := store.GetBlocksVersionForBoard(boardID)
+		require.NoError(t, err)
+		require.Equal(t, int64(5), deleted.Count)
+
+		empty, err := store.GetBlocksVersionForBoard("not-exists")
+		require.NoError(t, err)
+		require.Equal(t, &model.BlocksVersion{}, empty)
+	})
+
+	t.Run("blocks modified since a time", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		since := utils.GetMillis()
+
+		blocks, err := store.GetBlocksModifiedSince(boardID, since)
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+
+		time.Sleep(10 * time.Millisecond)
+
+		added := []model.Block{{ID: "block7", BoardID: boardID, ModifiedBy: testUserID, Type: "test"}}
+		InsertBlocks(t, store, added, testUserID)
+		removed := []model.Block{{ID: "block8", BoardID: boardID, ModifiedBy: testUserID, Type: "test"}}
+		InsertBlocks(t, store, removed, testUserID)
+		DeleteBlocks(t, store, removed, testUserID)
+
+		blocks, err = store.GetBlocksModifiedSince(boardID, since)
+		require.NoError(t, err)
+		require.Len(t, blocks, 2)
+		require.Equal(t, "block7", blocks[0].ID)
+		require.Zero(t, blocks[0].DeleteAt)
+		require.Equal(t, "block8", blocks[1].ID)
+		require.NotZero(t, blocks[1].DeleteAt)
+
+		blocks, err = store.GetBlocksModifiedSince("not-exists", 0)
+		require.NoError(t, err)
+		require.Empty(t, blocks)
+	})
 }
 
 func testGetBlock(t *testing.T, store store.Store) {
@@ -1017,3 +1191,93 @@ func testGetBlockMetadata(t *testing.T, store store.Store) {
 		require.Equal(t, expectedBlock.ID, block.ID)
 	})
 }
+
+func testGetLastBlockUpdatesForTeam(t *testing.T, store store.Store) {
+	teamID := "team-id-dormant"
+	board := &model.Board{ID: "board-id-dormant", TeamID: teamID, Type: model.BoardTypeOpen}
+	_, err := store.InsertBoard(board, testUserID)
+	require.NoError(t, err)
+	otherBoard := &model.Board{ID: "board-id-other-team", TeamID: "other-team-id", Type: model.BoardTypeOpen}
+	_, err = store.InsertBoard(otherBoard, testUserID)
+	require.NoError(t, err)
+
+	t.Run("board without blocks", func(t *testing.T) {
+		updates, err := store.GetLastBlockUpdatesForTeam(teamID)
+		require.NoError(t, err)
+		require.Empty(t, updates)
+	})
+
+	t.Run("boards with blocks", func(t *testing.T) {
+		blocks := []model.Block{
+			{ID: "block-dormant-1", BoardID: board.ID, Type: model.TypeCard},
+			{ID: "block-dormant-2", BoardID: board.ID, Type: model.TypeCard},
+			{ID: "block-other-team", BoardID: otherBoard.ID, Type: model.TypeCard},
+		}
+		InsertBlocks(t, store, blocks[:1], testUserID)
+		time.Sleep(10 * time.Millisecond)
+		InsertBlocks(t, store, blocks[1:], testUserID)
+		defer DeleteBlocks(t, store, blocks, testUserID)
+
+		last, err := store.GetBlock("block-dormant-2")
+		require.NoError(t, err)
+
+		updates, err := store.GetLastBlockUpdatesForTeam(teamID)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{board.ID: last.UpdateAt}, updates)
+	})
+}
+
+func testSearchBlocksForBoards(t *testing.T, store store.Store) {
+	blocks := []model.Block{
+		{ID: "card-release", BoardID: "board-search-1", Type: model.TypeCard, Title: "Release Plan"},
+		{ID: "comment-release", BoardID: "board-search-1", Type: model.TypeComment, Title: "ready for the release?"},
+		{ID: "view-release", BoardID: "board-search-1", Type: model.TypeView, Title: "Release view"},
+		{ID: "card-roadmap", BoardID: "board-search-1", Type: model.TypeCard, Title: "Roadmap"},
+		{ID: "card-other-board", BoardID: "board-search-2", Type: model.TypeCard, Title: "Plan B"},
+	}
+	InsertBlocks(t, store, blocks, testUserID)
+	defer DeleteBlocks(t, store, blocks, testUserID)
+
+	blockIDs := func(blocks []model.Block) []string {
+		ids := []string{}
+		for _, block := range blocks {
+			ids = append(ids, block.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	t.Run("any of the words", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoards([]string{"board-search-1"}, "RELEASE plan", model.SearchableBlockTypes, model.SearchFilters{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"card-release", "comment-release"}, blockIDs(found))
+	})
+
+	t.Run("several boards and types", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoards([]string{"board-search-1", "board-search-2"}, "plan", []model.BlockType{model.TypeCard}, model.SearchFilters{})
+		require.NoError(t, err)
+		require.Equal(t, []string{"card-other-board", "card-release"}, blockIDs(found))
+	})
+
+	t.Run("no boards or words", func(t *testing.T) {
+		found, err := store.SearchBlocksForBoards([]string{}, "plan", model.SearchableBlockTypes, model.SearchFilters{})
+		require.NoError(t, err)
+		require.Empty(t, found)
+
+		found, err = store.SearchBlocksForBoards([]string{"board-search-1"}, "  ", model.SearchableBlockTypes, model.SearchFilters{})
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+
+	t.Run("updated range", func(t *testing.T) {
+		later := utils.GetMillis() + time.Hour.Milliseconds()
+
+		found, err := store.SearchBlocksForBoards([]string{"board-search-1"}, "", model.SearchableBlockTypes, model.SearchFilters{UpdatedBefore: later})
+		require.NoError(t, err)
+		require.Equal(t, []string{"card-release", "card-roadmap", "comment-release"}, blockIDs(found))
+
+		found, err = store.SearchBlocksForBoards([]string{"board-search-1"}, "release", model.SearchableBlockTypes, model.SearchFilters{UpdatedAfter: later})
+		require.NoError(t, err)
+		require.Empty(t, found)
+	})
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestBoardViewsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetBoardChangesForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardChangesForUser(t, store)
+	})
+}
+
+func testGetBoardChangesForUser(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	otherUserID := utils.NewID(utils.IDTypeUser)
+
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: testTeamID, Type: model.BoardTypeOpen}
+	_, err := store.InsertBoard(board, userID)
+	require.NoError(t, err)
+	unviewedBoard := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: testTeamID, Type: model.BoardTypeOpen}
+	_, err = store.InsertBoard(unviewedBoard, userID)
+	require.NoError(t, err)
+
+	t.Run("no views", func(t *testing.T) {
+		changes, err := store.GetBoardChangesForUser(userID, testTeamID)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	viewedAt := utils.GetMillis() - 1000
+	require.NoError(t, store.SaveBoardView(&model.BoardView{BoardID: board.ID, UserID: userID, ViewedAt: viewedAt}))
+
+	InsertBlocks(t, store, []model.Block{
+		{ID: "card-1", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		{ID: "card-2", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+	}, otherUserID)
+	InsertBlocks(t, store, []model.Block{
+		{ID: "card-3", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+	}, userID)
+
+	t.Run("changes by other users since the view", func(t *testing.T) {
+		changes, err := store.GetBoardChangesForUser(userID, testTeamID)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, board.ID, changes[0].BoardID)
+		require.Equal(t, viewedAt, changes[0].LastViewedAt)
+		require.EqualValues(t, 2, changes[0].ChangeCount)
+	})
+
+	t.Run("view updated", func(t *testing.T) {
+		viewedAt = utils.GetMillis() + 1000
+		require.NoError(t, store.SaveBoardView(&model.BoardView{BoardID: board.ID, UserID: userID, ViewedAt: viewedAt}))
+
+		changes, err := store.GetBoardChangesForUser(userID, testTeamID)
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, viewedAt, changes[0].LastViewedAt)
+		require.Zero(t, changes[0].ChangeCount)
+	})
+
+	t.Run("other team", func(t *testing.T) {
+		changes, err := store.GetBoardChangesForUser(userID, utils.NewID(utils.IDTypeTeam))
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+}
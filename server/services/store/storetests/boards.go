@@ -22,6 +22,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testGetBoardsForUserAndTeam(t, store)
 	})
+	t.Run("GetBoardsForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsForTeam(t, store)
+	})
 	t.Run("InsertBoard", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -72,6 +77,11 @@ func StoreTestBoardStore(t *testing.T, setup func(t *testing.T) (store.Store, fu
 		defer tearDown()
 		testSearchBoardsForUser(t, store)
 	})
+	t.Run("GetBoardsWithProperty", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetBoardsWithProperty(t, store)
+	})
 	t.Run("GetBoardHistory", func(t *testing.T) {
 		store, tearDown := setup(t)
 		defer tearDown()
@@ -186,6 +196,31 @@ func testGetBoardsForUserAndTeam(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBoardsForTeam(t *testing.T, store store.Store) {
+	teamID := "team-id-1"
+
+	open, err := store.InsertBoard(&model.Board{ID: "board-id-1", TeamID: teamID, Type: model.BoardTypeOpen}, "user-id-1")
+	require.NoError(t, err)
+	private, err := store.InsertBoard(&model.Board{ID: "board-id-2", TeamID: teamID, Type: model.BoardTypePrivate}, "user-id-2")
+	require.NoError(t, err)
+	template, err := store.InsertBoard(&model.Board{ID: "board-id-3", TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true}, "user-id-1")
+	require.NoError(t, err)
+	_, err = store.InsertBoard(&model.Board{ID: "board-id-4", TeamID: "team-id-2", Type: model.BoardTypeOpen}, "user-id-1")
+	require.NoError(t, err)
+
+	t.Run("should return all the boards of the team regardless of their members", func(t *testing.T) {
+		boards, err := store.GetBoardsForTeam(teamID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []*model.Board{open, private, template}, boards)
+	})
+
+	t.Run("should return no boards for an unknown team", func(t *testing.T) {
+		boards, err := store.GetBoardsForTeam("unknown-team")
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+}
+
 func testInsertBoard(t *testing.T, store store.Store) {
 	userID := testUserID
 
@@ -682,6 +717,41 @@ func testDeleteMember(t *testing.T, store store.Store) {
 	})
 }
 
+func testGetBoardsWithProperty(t *testing.T, store store.Store) {
+	userID := "user-id-1"
+
+	board1 := &model.Board{
+		ID:         "board-id-1",
+		TeamID:     "team-id-1",
+		Type:       model.BoardTypeOpen,
+		Properties: map[string]interface{}{"escalations": []interface{}{}},
+	}
+	_, err := store.InsertBoard(board1, userID)
+	require.NoError(t, err)
+
+	board2 := &model.Board{
+		ID:         "board-id-2",
+		TeamID:     "team-id-1",
+		Type:       model.BoardTypeOpen,
+		Properties: map[string]interface{}{"other": "escalations"},
+	}
+	_, err = store.InsertBoard(board2, userID)
+	require.NoError(t, err)
+
+	board3 := &model.Board{
+		ID:     "board-id-3",
+		TeamID: "team-id-1",
+		Type:   model.BoardTypeOpen,
+	}
+	_, err = store.InsertBoard(board3, userID)
+	require.NoError(t, err)
+
+	boards, err := store.GetBoardsWithProperty("escalations")
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	require.Equal(t, board1.ID, boards[0].ID)
+}
+
 func testSearchBoardsForUser(t *testing.T, store store.Store) {
 	teamID1 := "team-id-1"
 	teamID2 := "team-id-2"
@@ -0,0 +1,152 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestCardRemindersStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateCardReminder", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateCardReminder(t, store)
+	})
+
+	t.Run("UpdateCardReminder", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateCardReminder(t, store)
+	})
+
+	t.Run("DeleteCardReminder", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteCardReminder(t, store)
+	})
+
+	t.Run("GetDueCardReminders", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDueCardReminders(t, store)
+	})
+}
+
+func createTestCardReminder(t *testing.T, store store.Store, userID, cardID string, remindAt int64) *model.CardReminder {
+	reminder := &model.CardReminder{
+		UserID:   userID,
+		TeamID:   testTeamID,
+		BoardID:  testBoardID,
+		CardID:   cardID,
+		Note:     "have a look",
+		RemindAt: remindAt,
+	}
+	reminderNew, err := store.CreateCardReminder(reminder)
+	require.NoError(t, err, "create card reminder should not error")
+	return reminderNew
+}
+
+func testCreateCardReminder(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	cardID := utils.NewID(utils.IDTypeCard)
+
+	t.Run("create reminders", func(t *testing.T) {
+		later := createTestCardReminder(t, store, userID, cardID, 2000)
+		sooner := createTestCardReminder(t, store, userID, utils.NewID(utils.IDTypeCard), 1000)
+		assert.NotEmpty(t, later.ID)
+		assert.NotZero(t, later.CreateAt)
+
+		reminder, err := store.GetCardReminder(later.ID)
+		require.NoError(t, err)
+		assert.Equal(t, later, reminder)
+
+		reminders, err := store.GetCardRemindersForUser(userID, "")
+		require.NoError(t, err)
+		require.Len(t, reminders, 2)
+		assert.Equal(t, sooner.ID, reminders[0].ID)
+		assert.Equal(t, later.ID, reminders[1].ID)
+
+		reminders, err = store.GetCardRemindersForUser(userID, cardID)
+		require.NoError(t, err)
+		require.Len(t, reminders, 1)
+		assert.Equal(t, later.ID, reminders[0].ID)
+
+		reminders, err = store.GetCardRemindersForUser(utils.NewID(utils.IDTypeUser), "")
+		require.NoError(t, err)
+		assert.Empty(t, reminders)
+	})
+
+	t.Run("invalid reminder", func(t *testing.T) {
+		_, err := store.CreateCardReminder(&model.CardReminder{UserID: userID, BoardID: testBoardID, CardID: cardID})
+		require.True(t, model.IsErrInvalidCardReminder(err))
+	})
+
+	t.Run("unknown reminder", func(t *testing.T) {
+		reminder, err := store.GetCardReminder("unknown")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, reminder)
+	})
+}
+
+func testUpdateCardReminder(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	reminder := createTestCardReminder(t, store, userID, utils.NewID(utils.IDTypeCard), 1000)
+
+	require.NoError(t, store.MarkCardRemindersDelivered([]string{reminder.ID}))
+	reminders, err := store.GetCardRemindersForUser(userID, "")
+	require.NoError(t, err)
+	require.Empty(t, reminders)
+
+	// snoozing a delivered reminder makes it pending again
+	reminder.RemindAt = 5000
+	reminder.Note = "later"
+	require.NoError(t, store.UpdateCardReminder(reminder))
+
+	reminders, err = store.GetCardRemindersForUser(userID, "")
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, int64(5000), reminders[0].RemindAt)
+	assert.Equal(t, "later", reminders[0].Note)
+	assert.Zero(t, reminders[0].DeliveredAt)
+}
+
+func testDeleteCardReminder(t *testing.T, store store.Store) {
+	reminder := createTestCardReminder(t, store, utils.NewID(utils.IDTypeUser), utils.NewID(utils.IDTypeCard), 1000)
+
+	require.NoError(t, store.DeleteCardReminder(reminder.ID))
+
+	_, err := store.GetCardReminder(reminder.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteCardReminder(reminder.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
+
+func testGetDueCardReminders(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	due := createTestCardReminder(t, store, userID, utils.NewID(utils.IDTypeCard), 1000)
+	createTestCardReminder(t, store, userID, utils.NewID(utils.IDTypeCard), 3000)
+
+	reminders, err := store.GetDueCardReminders(2000)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+	assert.Equal(t, due.ID, reminders[0].ID)
+
+	require.NoError(t, store.MarkCardRemindersDelivered([]string{due.ID}))
+
+	reminders, err = store.GetDueCardReminders(2000)
+	require.NoError(t, err)
+	assert.Empty(t, reminders)
+
+	delivered, err := store.GetCardReminder(due.ID)
+	require.NoError(t, err)
+	assert.NotZero(t, delivered.DeliveredAt)
+}
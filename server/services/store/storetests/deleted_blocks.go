@@ -0,0 +1,159 @@
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func insertBlockTree(t *testing.T, store store.Store) []model.Block {
+	blocks := []model.Block{
+		{ID: "block1", BoardID: testBoardID, ParentID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+		{ID: "block2", BoardID: testBoardID, ParentID: "block1", ModifiedBy: testUserID, Type: model.TypeText},
+		{ID: "block3", BoardID: testBoardID, ParentID: "block1", ModifiedBy: testUserID, Type: model.TypeText},
+		{ID: "block4", BoardID: testBoardID, ParentID: "block2", ModifiedBy: testUserID, Type: model.TypeText},
+		{ID: "block5", BoardID: testBoardID, ParentID: testBoardID, ModifiedBy: testUserID, Type: model.TypeCard},
+	}
+	InsertBlocks(t, store, blocks, testUserID)
+	return blocks
+}
+
+func idsOfBlocks(blocks []model.Block) []string {
+	ids := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		ids = append(ids, block.ID)
+	}
+	return ids
+}
+
+func testDeleteBlockTree(t *testing.T, store store.Store) {
+	insertBlockTree(t, store)
+
+	t.Run("deletes the block and its descendants", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		deleted, err := store.DeleteBlockTree("block1", testUserID)
+		require.NoError(t, err)
+		require.Equal(t, "block1", deleted[0].ID)
+		require.ElementsMatch(t, []string{"block1", "block2", "block3", "block4"}, idsOfBlocks(deleted))
+
+		blocks, err := store.GetBlocksForBoard(testBoardID)
+		require.NoError(t, err)
+		require.Subset(t, idsOfBlocks(blocks), []string{"block5"})
+		require.NotContains(t, idsOfBlocks(blocks), "block1")
+		require.NotContains(t, idsOfBlocks(blocks), "block4")
+
+		// the whole subtree shares the delete time
+		var deleteAt int64
+		for _, id := range idsOfBlocks(deleted) {
+			history, err := store.GetBlockHistory(id, model.QueryBlockHistoryOptions{Limit: 1, Descending: true})
+			require.NoError(t, err)
+			require.Len(t, history, 1)
+			require.NotZero(t, history[0].DeleteAt)
+			if deleteAt == 0 {
+				deleteAt = history[0].DeleteAt
+			}
+			require.Equal(t, deleteAt, history[0].DeleteAt)
+		}
+	})
+
+	t.Run("not existing block", func(t *testing.T) {
+		deleted, err := store.DeleteBlockTree("not-exists", testUserID)
+		require.NoError(t, err)
+		require.Empty(t, deleted)
+	})
+}
+
+func testUndeleteBlockTree(t *testing.T, store store.Store) {
+	insertBlockTree(t, store)
+
+	t.Run("restores the descendants deleted with the block", func(t *testing.T) {
+		// block3 is deleted on its own before its parent
+		time.Sleep(1 * time.Millisecond)
+		_, err := store.DeleteBlockTree("block3", testUserID)
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		_, err = store.DeleteBlockTree("block1", testUserID)
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Millisecond)
+		restored, err := store.UndeleteBlockTree("block1", testUserID)
+		require.NoError(t, err)
+		require.Equal(t, "block1", restored[0].ID)
+		require.ElementsMatch(t, []string{"block1", "block2", "block4"}, idsOfBlocks(restored))
+
+		blocks, err := store.GetBlocksForBoard(testBoardID)
+		require.NoError(t, err)
+		require.Subset(t, idsOfBlocks(blocks), []string{"block1", "block2", "block4", "block5"})
+		require.NotContains(t, idsOfBlocks(blocks), "block3")
+	})
+
+	t.Run("not deleted block", func(t *testing.T) {
+		restored, err := store.UndeleteBlockTree("block5", testUserID)
+		require.NoError(t, err)
+		require.Empty(t, restored)
+
+		restored, err = store.UndeleteBlockTree("not-exists", testUserID)
+		require.NoError(t, err)
+		require.Empty(t, restored)
+	})
+}
+
+func testPurgeDeletedBlocks(t *testing.T, store store.Store) {
+	insertBlockTree(t, store)
+
+	time.Sleep(1 * time.Millisecond)
+	_, err := store.DeleteBlockTree("block1", testUserID)
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Millisecond)
+	deletedBefore := utils.GetMillis()
+
+	time.Sleep(1 * time.Millisecond)
+	_, err = store.DeleteBlockTree("block5", testUserID)
+	require.NoError(t, err)
+
+	t.Run("purges the blocks deleted before the time", func(t *testing.T) {
+		purged, err := store.PurgeDeletedBlocks(deletedBefore, 2)
+		require.NoError(t, err)
+		require.Len(t, purged, 2)
+
+		purged, err = store.PurgeDeletedBlocks(deletedBefore, 10)
+		require.NoError(t, err)
+		require.Len(t, purged, 2)
+
+		purged, err = store.PurgeDeletedBlocks(deletedBefore, 10)
+		require.NoError(t, err)
+		require.Empty(t, purged)
+
+		history, err := store.GetBlockHistory("block1", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Empty(t, history)
+
+		restored, err := store.UndeleteBlockTree("block1", testUserID)
+		require.NoError(t, err)
+		require.Empty(t, restored)
+	})
+
+	t.Run("keeps the blocks deleted later", func(t *testing.T) {
+		restored, err := store.UndeleteBlockTree("block5", testUserID)
+		require.NoError(t, err)
+		require.Len(t, restored, 1)
+	})
+
+	t.Run("keeps the restored blocks", func(t *testing.T) {
+		time.Sleep(1 * time.Millisecond)
+		purged, err := store.PurgeDeletedBlocks(utils.GetMillis(), 10)
+		require.NoError(t, err)
+		require.Empty(t, purged)
+
+		history, err := store.GetBlockHistory("block5", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestFileContentsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndSearchFileContents", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndSearchFileContents(t, store)
+	})
+}
+
+func testSaveAndSearchFileContents(t *testing.T, store store.Store) {
+	spec := &model.FileContent{FileID: "spec.pdf", TeamID: testTeamID, BoardID: "board-1", Content: "Payments API specification", UpdateAt: 1}
+	notes := &model.FileContent{FileID: "notes.docx", TeamID: testTeamID, BoardID: "board-2", Content: "Meeting notes about payments", UpdateAt: 1}
+	require.NoError(t, store.SaveFileContent(spec))
+	require.NoError(t, store.SaveFileContent(notes))
+
+	t.Run("matching any word", func(t *testing.T) {
+		contents, err := store.SearchFileContents([]string{"board-1", "board-2"}, "PAYMENTS roadmap")
+		require.NoError(t, err)
+		require.Len(t, contents, 2)
+
+		contents, err = store.SearchFileContents([]string{"board-1"}, "payments")
+		require.NoError(t, err)
+		require.Equal(t, []*model.FileContent{spec}, contents)
+	})
+
+	t.Run("replacing the content", func(t *testing.T) {
+		updated := &model.FileContent{FileID: "spec.pdf", TeamID: testTeamID, BoardID: "board-1", Content: "Billing API specification", UpdateAt: 2}
+		require.NoError(t, store.SaveFileContent(updated))
+
+		contents, err := store.SearchFileContents([]string{"board-1"}, "payments")
+		require.NoError(t, err)
+		require.Empty(t, contents)
+
+		contents, err = store.SearchFileContents([]string{"board-1"}, "billing")
+		require.NoError(t, err)
+		require.Equal(t, []*model.FileContent{updated}, contents)
+	})
+
+	t.Run("no boards or words", func(t *testing.T) {
+		contents, err := store.SearchFileContents([]string{}, "notes")
+		require.NoError(t, err)
+		require.Empty(t, contents)
+
+		contents, err = store.SearchFileContents([]string{"board-2"}, " ")
+		require.NoError(t, err)
+		require.Empty(t, contents)
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestFileInfoStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndDeleteFileInfo", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndDeleteFileInfo(t, store)
+	})
+}
+
+func testSaveAndDeleteFileInfo(t *testing.T, store store.Store) {
+	t.Run("no files", func(t *testing.T) {
+		used, err := store.GetFileStorageUsed()
+		require.NoError(t, err)
+		require.Zero(t, used)
+	})
+
+	require.NoError(t, store.SaveFileInfo(&model.FileInfo{FileID: "spec.pdf", TeamID: testTeamID, BoardID: "board-1", Size: 100, CreateAt: 1}))
+	require.NoError(t, store.SaveFileInfo(&model.FileInfo{FileID: "notes.docx", TeamID: "other-team-id", BoardID: "board-2", Size: 50, CreateAt: 1}))
+
+	t.Run("sums the files of all the teams", func(t *testing.T) {
+		used, err := store.GetFileStorageUsed()
+		require.NoError(t, err)
+		require.EqualValues(t, 150, used)
+	})
+
+	t.Run("a file saved again is counted once", func(t *testing.T) {
+		require.NoError(t, store.SaveFileInfo(&model.FileInfo{FileID: "spec.pdf", TeamID: testTeamID, BoardID: "board-1", Size: 120, CreateAt: 2}))
+
+		used, err := store.GetFileStorageUsed()
+		require.NoError(t, err)
+		require.EqualValues(t, 170, used)
+	})
+
+	t.Run("deleted files aren't counted", func(t *testing.T) {
+		require.NoError(t, store.DeleteFileInfo("spec.pdf"))
+		require.NoError(t, store.DeleteFileInfo("unknown.pdf"))
+
+		used, err := store.GetFileStorageUsed()
+		require.NoError(t, err)
+		require.EqualValues(t, 50, used)
+	})
+}
@@ -0,0 +1,125 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestInviteStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetAndDeleteInvite", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetAndDeleteInvite(t, store)
+	})
+
+	t.Run("GetInvitesForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetInvitesForTeam(t, store)
+	})
+
+	t.Run("UseInvite", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUseInvite(t, store)
+	})
+}
+
+func newTestInvite(teamID string, maxUses int, expiresAt int64) *model.Invite {
+	invite := &model.Invite{Role: model.BoardRoleViewer, MaxUses: maxUses, ExpiresAt: expiresAt}
+	invite.Hydrate(teamID, "user-id")
+	return invite
+}
+
+func testCreateAndGetAndDeleteInvite(t *testing.T, store store.Store) {
+	invite := newTestInvite("team-id", 3, 0)
+
+	t.Run("CreateAndGetInvite", func(t *testing.T) {
+		created, err := store.CreateInvite(invite)
+		require.NoError(t, err)
+		require.Equal(t, invite, created)
+
+		got, err := store.GetInvite(invite.ID)
+		require.NoError(t, err)
+		require.Equal(t, invite, got)
+
+		got, err = store.GetInviteByToken(invite.Token)
+		require.NoError(t, err)
+		require.Equal(t, invite, got)
+	})
+
+	t.Run("CreateInvalidInvite", func(t *testing.T) {
+		_, err := store.CreateInvite(&model.Invite{ID: "invalid", Role: "owner"})
+		require.True(t, model.IsErrInvalidInvite(err))
+	})
+
+	t.Run("DeleteAndGetInvite", func(t *testing.T) {
+		require.NoError(t, store.DeleteInvite(invite.ID))
+
+		_, err := store.GetInvite(invite.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		require.True(t, model.IsErrNotFound(store.DeleteInvite(invite.ID)))
+	})
+}
+
+func testGetInvitesForTeam(t *testing.T, store store.Store) {
+	for _, teamID := range []string{"team-1", "team-1", "team-2"} {
+		_, err := store.CreateInvite(newTestInvite(teamID, 0, 0))
+		require.NoError(t, err)
+	}
+
+	invites, err := store.GetInvitesForTeam("team-1")
+	require.NoError(t, err)
+	require.Len(t, invites, 2)
+
+	invites, err = store.GetInvitesForTeam("team-3")
+	require.NoError(t, err)
+	require.Empty(t, invites)
+}
+
+func testUseInvite(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+
+	t.Run("limited uses", func(t *testing.T) {
+		invite, err := store.CreateInvite(newTestInvite("team-id", 2, 0))
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			used, err := store.UseInvite(invite.Token, now)
+			require.NoError(t, err)
+			require.True(t, used)
+		}
+
+		used, err := store.UseInvite(invite.Token, now)
+		require.NoError(t, err)
+		require.False(t, used)
+
+		got, err := store.GetInvite(invite.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, got.UseCount)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		invite, err := store.CreateInvite(newTestInvite("team-id", 0, now+1000))
+		require.NoError(t, err)
+
+		used, err := store.UseInvite(invite.Token, now)
+		require.NoError(t, err)
+		require.True(t, used)
+
+		used, err = store.UseInvite(invite.Token, now+1000)
+		require.NoError(t, err)
+		require.False(t, used)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		used, err := store.UseInvite("unknown", now)
+		require.NoError(t, err)
+		require.False(t, used)
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestJobsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetJobs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetJobs(t, store)
+	})
+	t.Run("ClaimJob", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testClaimJob(t, store)
+	})
+	t.Run("ResetStaleJobs", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testResetStaleJobs(t, store)
+	})
+}
+
+func testCreateAndGetJobs(t *testing.T, store store.Store) {
+	t.Run("unknown job", func(t *testing.T) {
+		job, err := store.GetJob("unknown")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, job)
+
+		latest, err := store.GetLatestJob("test")
+		require.NoError(t, err)
+		require.Nil(t, latest)
+	})
+
+	job, err := store.CreateJob(&model.Job{
+		Type:      "test",
+		Data:      map[string]interface{}{"boardId": "board-1"},
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, job.ID)
+	require.Equal(t, model.JobStatusPending, job.Status)
+	require.Equal(t, model.DefaultJobMaxAttempts, job.MaxAttempts)
+	require.NotZero(t, job.RunAt)
+
+	other, err := store.CreateJob(&model.Job{Type: "other"})
+	require.NoError(t, err)
+
+	t.Run("get job", func(t *testing.T) {
+		fetched, err := store.GetJob(job.ID)
+		require.NoError(t, err)
+		require.Equal(t, "test", fetched.Type)
+		require.Equal(t, map[string]interface{}{"boardId": "board-1"}, fetched.Data)
+		require.Nil(t, fetched.Result)
+		require.Equal(t, testUserID, fetched.CreatedBy)
+	})
+
+	t.Run("filter jobs", func(t *testing.T) {
+		jobs, err := store.GetJobs(model.QueryJobsOptions{})
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+
+		jobs, err = store.GetJobs(model.QueryJobsOptions{Type: "other"})
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, other.ID, jobs[0].ID)
+
+		jobs, err = store.GetJobs(model.QueryJobsOptions{Status: model.JobStatusRunning})
+		require.NoError(t, err)
+		require.Empty(t, jobs)
+	})
+
+	t.Run("update job", func(t *testing.T) {
+		job.Status = model.JobStatusSuccess
+		job.Progress = 100
+		job.Result = map[string]interface{}{"count": float64(3)}
+		job.CompleteAt = utils.GetMillis()
+		require.NoError(t, store.UpdateJob(job))
+
+		latest, err := store.GetLatestJob("test")
+		require.NoError(t, err)
+		require.Equal(t, model.JobStatusSuccess, latest.Status)
+		require.Equal(t, 100, latest.Progress)
+		require.Equal(t, map[string]interface{}{"count": float64(3)}, latest.Result)
+		require.True(t, latest.IsDone())
+	})
+}
+
+func testClaimJob(t *testing.T, store store.Store) {
+	now := utils.GetMillis()
+
+	later, err := store.CreateJob(&model.Job{Type: "test", RunAt: now + 60000})
+	require.NoError(t, err)
+	first, err := store.CreateJob(&model.Job{Type: "test", RunAt: now - 2000})
+	require.NoError(t, err)
+	second, err := store.CreateJob(&model.Job{Type: "test", RunAt: now - 1000})
+	require.NoError(t, err)
+	_, err = store.CreateJob(&model.Job{Type: "unhandled", RunAt: now - 3000})
+	require.NoError(t, err)
+
+	claimed, err := store.ClaimJob([]string{"test"}, now)
+	require.NoError(t, err)
+	require.Equal(t, first.ID, claimed.ID)
+	require.Equal(t, model.JobStatusRunning, claimed.Status)
+	require.Equal(t, 1, claimed.Attempts)
+	require.Equal(t, now, claimed.StartAt)
+
+	claimed, err = store.ClaimJob([]string{"test"}, now)
+	require.NoError(t, err)
+	require.Equal(t, second.ID, claimed.ID)
+
+	// the remaining job is not due yet
+	claimed, err = store.ClaimJob([]string{"test"}, now)
+	require.NoError(t, err)
+	require.Nil(t, claimed)
+
+	claimed, err = store.ClaimJob([]string{"test"}, later.RunAt)
+	require.NoError(t, err)
+	require.Equal(t, later.ID, claimed.ID)
+
+	claimed, err = store.ClaimJob(nil, now)
+	require.NoError(t, err)
+	require.Nil(t, claimed)
+}
+
+func testResetStaleJobs(t *testing.T, store store.Store) {
+	job, err := store.CreateJob(&model.Job{Type: "test"})
+	require.NoError(t, err)
+	claimed, err := store.ClaimJob([]string{"test"}, job.RunAt)
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+
+	count, err := store.ResetStaleJobs(claimed.UpdateAt)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = store.ResetStaleJobs(claimed.UpdateAt + 1)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, count)
+
+	reset, err := store.GetJob(job.ID)
+	require.NoError(t, err)
+	require.Equal(t, model.JobStatusPending, reset.Status)
+	require.Equal(t, 1, reset.Attempts)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestMentionsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateMention", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateMention(t, store)
+	})
+
+	t.Run("MarkMentionsRead", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testMarkMentionsRead(t, store)
+	})
+
+	t.Run("GetMentionsToRemind", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetMentionsToRemind(t, store)
+	})
+}
+
+func createTestMention(t *testing.T, store store.Store, userID string) *model.Mention {
+	mention := &model.Mention{
+		UserID:      userID,
+		TeamID:      utils.NewID(utils.IDTypeTeam),
+		BoardID:     utils.NewID(utils.IDTypeBoard),
+		CardID:      utils.NewID(utils.IDTypeCard),
+		BlockID:     utils.NewID(utils.IDTypeBlock),
+		MentionedBy: utils.NewID(utils.IDTypeUser),
+		Extract:     "hello @someone",
+	}
+	mentionNew, err := store.CreateMention(mention)
+	require.NoError(t, err, "create mention should not error")
+	return mentionNew
+}
+
+func testCreateMention(t *testing.T, store store.Store) {
+	t.Run("create mention", func(t *testing.T) {
+		userID := utils.NewID(utils.IDTypeUser)
+		mention := createTestMention(t, store, userID)
+		assert.NotEmpty(t, mention.ID)
+		assert.NotZero(t, mention.CreateAt)
+
+		mentions, err := store.GetUnreadMentionsForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, mentions, 1)
+		assert.Equal(t, mention.ID, mentions[0].ID)
+		assert.Equal(t, "hello @someone", mentions[0].Extract)
+	})
+
+	t.Run("invalid mention", func(t *testing.T) {
+		_, err := store.CreateMention(&model.Mention{UserID: utils.NewID(utils.IDTypeUser)})
+		var errInvalid model.ErrInvalidMention
+		assert.ErrorAs(t, err, &errInvalid)
+	})
+}
+
+func testMarkMentionsRead(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	mention1 := createTestMention(t, store, userID)
+	createTestMention(t, store, userID)
+	other := createTestMention(t, store, utils.NewID(utils.IDTypeUser))
+
+	t.Run("mark a mention", func(t *testing.T) {
+		require.NoError(t, store.MarkMentionsRead(userID, []string{mention1.ID, other.ID}))
+
+		mentions, err := store.GetUnreadMentionsForUser(userID)
+		require.NoError(t, err)
+		require.Len(t, mentions, 1)
+		assert.NotEqual(t, mention1.ID, mentions[0].ID)
+
+		// mentions of other users are not marked
+		mentions, err = store.GetUnreadMentionsForUser(other.UserID)
+		require.NoError(t, err)
+		require.Len(t, mentions, 1)
+	})
+
+	t.Run("mark all mentions", func(t *testing.T) {
+		require.NoError(t, store.MarkMentionsRead(userID, nil))
+
+		mentions, err := store.GetUnreadMentionsForUser(userID)
+		require.NoError(t, err)
+		assert.Empty(t, mentions)
+	})
+}
+
+func testGetMentionsToRemind(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	unread := createTestMention(t, store, userID)
+	read := createTestMention(t, store, userID)
+	require.NoError(t, store.MarkMentionsRead(userID, []string{read.ID}))
+
+	t.Run("only old unread mentions", func(t *testing.T) {
+		mentions, err := store.GetMentionsToRemind(unread.CreateAt)
+		require.NoError(t, err)
+		assert.Empty(t, mentions)
+
+		mentions, err = store.GetMentionsToRemind(utils.GetMillis() + 1000)
+		require.NoError(t, err)
+		require.Len(t, mentions, 1)
+		assert.Equal(t, unread.ID, mentions[0].ID)
+	})
+
+	t.Run("reminded mentions are not returned", func(t *testing.T) {
+		require.NoError(t, store.MarkMentionsReminded([]string{unread.ID}))
+
+		mentions, err := store.GetMentionsToRemind(utils.GetMillis() + 1000)
+		require.NoError(t, err)
+		assert.Empty(t, mentions)
+	})
+}
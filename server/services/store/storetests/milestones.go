@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestMilestonesStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetMilestones", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetMilestones(t, store)
+	})
+	t.Run("UpdateAndDeleteMilestone", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateAndDeleteMilestone(t, store)
+	})
+}
+
+func testCreateAndGetMilestones(t *testing.T, store store.Store) {
+	t.Run("unknown milestone", func(t *testing.T) {
+		milestone, err := store.GetMilestone("unknown")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, milestone)
+	})
+
+	later, err := store.CreateMilestone(&model.Milestone{
+		TeamID:    testTeamID,
+		Title:     "GA",
+		DueDate:   2000,
+		CreatedBy: testUserID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, later.ID)
+	require.NotZero(t, later.CreateAt)
+
+	sooner, err := store.CreateMilestone(&model.Milestone{
+		TeamID:      testTeamID,
+		Title:       "Beta",
+		Description: "First public release",
+		DueDate:     1000,
+		CreatedBy:   testUserID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateMilestone(&model.Milestone{TeamID: "other-team", Title: "Other"})
+	require.NoError(t, err)
+
+	t.Run("get milestone", func(t *testing.T) {
+		fetched, err := store.GetMilestone(sooner.ID)
+		require.NoError(t, err)
+		require.Equal(t, "Beta", fetched.Title)
+		require.Equal(t, "First public release", fetched.Description)
+		require.Equal(t, int64(1000), fetched.DueDate)
+		require.Equal(t, testUserID, fetched.CreatedBy)
+	})
+
+	t.Run("get milestones of the team by due date", func(t *testing.T) {
+		milestones, err := store.GetMilestonesForTeam(testTeamID)
+		require.NoError(t, err)
+		require.Len(t, milestones, 2)
+		require.Equal(t, sooner.ID, milestones[0].ID)
+		require.Equal(t, later.ID, milestones[1].ID)
+	})
+}
+
+func testUpdateAndDeleteMilestone(t *testing.T, store store.Store) {
+	milestone, err := store.CreateMilestone(&model.Milestone{TeamID: testTeamID, Title: "Beta"})
+	require.NoError(t, err)
+
+	milestone.Title = "Public beta"
+	milestone.DueDate = 5000
+	require.NoError(t, store.UpdateMilestone(milestone))
+
+	fetched, err := store.GetMilestone(milestone.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Public beta", fetched.Title)
+	require.Equal(t, int64(5000), fetched.DueDate)
+
+	require.NoError(t, store.DeleteMilestone(milestone.ID))
+	_, err = store.GetMilestone(milestone.ID)
+	require.True(t, model.IsErrNotFound(err))
+
+	err = store.DeleteMilestone(milestone.ID)
+	require.True(t, model.IsErrNotFound(err))
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestNotificationThreadsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("SaveAndGetNotificationThread", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSaveAndGetNotificationThread(t, store)
+	})
+}
+
+func testSaveAndGetNotificationThread(t *testing.T, store store.Store) {
+	t.Run("no thread yet", func(t *testing.T) {
+		thread, err := store.GetNotificationThread("card-1", "channel-1")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, thread)
+	})
+
+	thread := &model.NotificationThread{CardID: "card-1", ChannelID: "channel-1", RootPostID: "post-1", CreateAt: 1}
+	require.NoError(t, store.SaveNotificationThread(thread))
+
+	t.Run("one thread per card and channel", func(t *testing.T) {
+		saved, err := store.GetNotificationThread("card-1", "channel-1")
+		require.NoError(t, err)
+		require.Equal(t, thread, saved)
+
+		_, err = store.GetNotificationThread("card-1", "channel-2")
+		require.True(t, model.IsErrNotFound(err))
+
+		_, err = store.GetNotificationThread("card-2", "channel-1")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("replacing the root post", func(t *testing.T) {
+		restarted := &model.NotificationThread{CardID: "card-1", ChannelID: "channel-1", RootPostID: "post-2", CreateAt: 2}
+		require.NoError(t, store.SaveNotificationThread(restarted))
+
+		saved, err := store.GetNotificationThread("card-1", "channel-1")
+		require.NoError(t, err)
+		require.Equal(t, restarted, saved)
+	})
+}
@@ -14,6 +14,12 @@ func StoreTestSharingStore(t *testing.T, setup func(t *testing.T) (store.Store,
 		defer tearDown()
 		testUpsertSharingAndGetSharing(t, store)
 	})
+
+	t.Run("RevokeAllSharing", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRevokeAllSharing(t, store)
+	})
 }
 
 func testUpsertSharingAndGetSharing(t *testing.T, store store.Store) {
@@ -52,8 +58,57 @@ func testUpsertSharingAndGetSharing(t *testing.T, store store.Store) {
 		newSharing.UpdateAt = 0
 		require.Equal(t, sharing, *newSharing)
 	})
+	t.Run("Upsert the allowed ranges and get them", func(t *testing.T) {
+		sharing := model.Sharing{
+			ID:           "sharing-id",
+			Enabled:      true,
+			Token:        "token2",
+			ModifiedBy:   "user-id2",
+			AllowedCIDRs: []string{"10.0.0.0/8", "2001:db8::/32"},
+		}
+
+		err := store.UpsertSharing(sharing)
+		require.NoError(t, err)
+		newSharing, err := store.GetSharing("sharing-id")
+		require.NoError(t, err)
+		newSharing.UpdateAt = 0
+		require.Equal(t, sharing, *newSharing)
+
+		sharing.AllowedCIDRs = nil
+		err = store.UpsertSharing(sharing)
+		require.NoError(t, err)
+		newSharing, err = store.GetSharing("sharing-id")
+		require.NoError(t, err)
+		require.Empty(t, newSharing.AllowedCIDRs)
+	})
 	t.Run("Get not existing sharing", func(t *testing.T) {
 		_, err := store.GetSharing("not-existing")
 		require.Error(t, err)
 	})
 }
+
+func testRevokeAllSharing(t *testing.T, store store.Store) {
+	for _, sharing := range []model.Sharing{
+		{ID: "enabled-1", Enabled: true, Token: "token-1", ModifiedBy: testUserID},
+		{ID: "enabled-2", Enabled: true, Token: "token-2", ModifiedBy: testUserID},
+		{ID: "disabled", Enabled: false, Token: "token-3", ModifiedBy: testUserID},
+	} {
+		require.NoError(t, store.UpsertSharing(sharing))
+	}
+
+	count, err := store.RevokeAllSharing("admin-id")
+	require.NoError(t, err)
+	require.EqualValues(t, 3, count)
+
+	for _, id := range []string{"enabled-1", "enabled-2", "disabled"} {
+		sharing, err := store.GetSharing(id)
+		require.NoError(t, err)
+		require.False(t, sharing.Enabled)
+		require.Empty(t, sharing.Token)
+		require.Equal(t, "admin-id", sharing.ModifiedBy)
+	}
+
+	count, err = store.RevokeAllSharing("admin-id")
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestSyncOperationsStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("InsertSyncOperation", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testInsertSyncOperation(t, store)
+	})
+}
+
+func testInsertSyncOperation(t *testing.T, store store.Store) {
+	boardID := utils.NewID(utils.IDTypeBoard)
+	otherBoardID := utils.NewID(utils.IDTypeBoard)
+	title := "new title"
+
+	t.Run("empty log", func(t *testing.T) {
+		revision, err := store.GetSyncRevision(boardID)
+		require.NoError(t, err)
+		require.Zero(t, revision)
+
+		operations, err := store.GetSyncOperations(boardID, 0)
+		require.NoError(t, err)
+		require.Empty(t, operations)
+	})
+
+	insert, err := store.InsertSyncOperation(&model.SyncOperation{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: boardID,
+		Type:    model.SyncOperationInsert,
+		BlockID: "block-1",
+		Block:   &model.Block{ID: "block-1", BoardID: boardID, Type: model.TypeCard, Title: "card"},
+		UserID:  testUserID,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, insert.Revision)
+	require.NotZero(t, insert.CreateAt)
+
+	patch, err := store.InsertSyncOperation(&model.SyncOperation{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: boardID,
+		Type:    model.SyncOperationPatch,
+		BlockID: "block-1",
+		Patch:   &model.BlockPatch{Title: &title},
+		UserID:  testUserID,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, patch.Revision)
+
+	other, err := store.InsertSyncOperation(&model.SyncOperation{
+		ID:      utils.NewID(utils.IDTypeNone),
+		BoardID: otherBoardID,
+		Type:    model.SyncOperationDelete,
+		BlockID: "block-2",
+		UserID:  testUserID,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, other.Revision)
+
+	t.Run("operations after a revision", func(t *testing.T) {
+		revision, err := store.GetSyncRevision(boardID)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, revision)
+
+		operations, err := store.GetSyncOperations(boardID, 0)
+		require.NoError(t, err)
+		require.Len(t, operations, 2)
+		require.Equal(t, insert.ID, operations[0].ID)
+		require.Equal(t, "card", operations[0].Block.Title)
+		require.Equal(t, patch.ID, operations[1].ID)
+		require.Equal(t, title, *operations[1].Patch.Title)
+
+		operations, err = store.GetSyncOperations(boardID, 1)
+		require.NoError(t, err)
+		require.Len(t, operations, 1)
+		require.Equal(t, patch.ID, operations[0].ID)
+	})
+}
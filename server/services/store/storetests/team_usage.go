@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestTeamUsageStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetTeamUsage", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetTeamUsage(t, store)
+	})
+}
+
+func testGetTeamUsage(t *testing.T, store store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+	teamID := utils.NewID(utils.IDTypeTeam)
+
+	t.Run("empty team", func(t *testing.T) {
+		usage, err := store.GetTeamUsage(teamID)
+		require.NoError(t, err)
+		require.Equal(t, &model.TeamUsage{TeamID: teamID}, usage)
+	})
+
+	board := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: teamID, Type: model.BoardTypeOpen}
+	_, err := store.InsertBoard(board, userID)
+	require.NoError(t, err)
+	template := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: teamID, Type: model.BoardTypeOpen, IsTemplate: true}
+	_, err = store.InsertBoard(template, userID)
+	require.NoError(t, err)
+	otherTeamID := utils.NewID(utils.IDTypeTeam)
+	otherTeamBoard := &model.Board{ID: utils.NewID(utils.IDTypeBoard), TeamID: otherTeamID, Type: model.BoardTypeOpen}
+	_, err = store.InsertBoard(otherTeamBoard, userID)
+	require.NoError(t, err)
+
+	InsertBlocks(t, store, []model.Block{
+		{ID: "card-1", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		{ID: "card-2", BoardID: board.ID, ParentID: board.ID, Type: model.TypeCard},
+		{ID: "view-1", BoardID: board.ID, ParentID: board.ID, Type: model.TypeView},
+		{ID: "text-1", BoardID: board.ID, ParentID: "card-1", Type: model.TypeText},
+		{ID: "card-3", BoardID: template.ID, ParentID: template.ID, Type: model.TypeCard},
+		{ID: "card-4", BoardID: otherTeamBoard.ID, ParentID: otherTeamBoard.ID, Type: model.TypeCard},
+	}, userID)
+
+	t.Run("counts the boards, cards and views", func(t *testing.T) {
+		usage, err := store.GetTeamUsage(teamID)
+		require.NoError(t, err)
+		require.Equal(t, teamID, usage.TeamID)
+		require.EqualValues(t, 1, usage.Boards)
+		require.EqualValues(t, 2, usage.Cards)
+		require.EqualValues(t, 1, usage.Views)
+	})
+
+	require.NoError(t, store.SaveFileInfo(&model.FileInfo{FileID: "spec.pdf", TeamID: teamID, BoardID: board.ID, Size: 100}))
+	require.NoError(t, store.SaveFileInfo(&model.FileInfo{FileID: "logo.png", TeamID: teamID, BoardID: template.ID, Size: 20}))
+	require.NoError(t, store.SaveFileInfo(&model.FileInfo{FileID: "notes.docx", TeamID: otherTeamID, BoardID: otherTeamBoard.ID, Size: 50}))
+
+	t.Run("sums the files of each team", func(t *testing.T) {
+		usage, err := store.GetTeamUsage(teamID)
+		require.NoError(t, err)
+		require.EqualValues(t, 120, usage.UsedFileStorage)
+
+		usage, err = store.GetTeamUsage(otherTeamID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, usage.Boards)
+		require.EqualValues(t, 1, usage.Cards)
+		require.EqualValues(t, 50, usage.UsedFileStorage)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+)
+
+func StoreTestTransaction(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("RunInTransaction", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testRunInTransaction(t, store)
+	})
+}
+
+func testRunInTransaction(t *testing.T, s store.Store) {
+	userID := utils.NewID(utils.IDTypeUser)
+
+	t.Run("commit", func(t *testing.T) {
+		boardID := utils.NewID(utils.IDTypeBoard)
+		err := s.RunInTransaction(func(tx store.TxStore) error {
+			board := &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}
+			if _, err := tx.InsertBoard(board, userID); err != nil {
+				return err
+			}
+			block := &model.Block{ID: utils.NewID(utils.IDTypeCard), BoardID: boardID, ParentID: boardID, Type: model.TypeCard}
+			return tx.InsertBlock(block, userID)
+		})
+		require.NoError(t, err)
+
+		board, err := s.GetBoard(boardID)
+		require.NoError(t, err)
+		require.Equal(t, boardID, board.ID)
+
+		blocks, err := s.GetBlocksForBoard(boardID)
+		require.NoError(t, err)
+		require.Len(t, blocks, 1)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		if s.DBType() == model.SqliteDBType {
+			t.Skip("SQLite doesn't run the operations in a transaction")
+		}
+
+		boardID := utils.NewID(utils.IDTypeBoard)
+		errFailed := errors.New("failed")
+		err := s.RunInTransaction(func(tx store.TxStore) error {
+			board := &model.Board{ID: boardID, TeamID: testTeamID, Type: model.BoardTypeOpen}
+			if _, err := tx.InsertBoard(board, userID); err != nil {
+				return err
+			}
+			return errFailed
+		})
+		require.ErrorIs(t, err, errFailed)
+
+		_, err = s.GetBoard(boardID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestTrashStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("GetDeletedBoardsForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDeletedBoardsForTeam(t, store)
+	})
+
+	t.Run("GetDeletedCardsForTeam", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testGetDeletedCardsForTeam(t, store)
+	})
+
+	t.Run("PurgeDeletedBlockTree", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPurgeDeletedBlockTree(t, store)
+	})
+
+	t.Run("PurgeDeletedBoard", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testPurgeDeletedBoard(t, store)
+	})
+}
+
+func testGetDeletedBoardsForTeam(t *testing.T, store store.Store) {
+	for _, board := range []*model.Board{
+		{ID: "board-deleted", TeamID: testTeamID, Type: model.BoardTypeOpen, Title: "Deleted"},
+		{ID: "board-kept", TeamID: testTeamID, Type: model.BoardTypeOpen},
+		{ID: "board-other-team", TeamID: "other-team-id", Type: model.BoardTypeOpen},
+	} {
+		_, err := store.InsertBoard(board, testUserID)
+		require.NoError(t, err)
+	}
+
+	// wait to avoid hitting pk uniqueness constraint in history
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, store.DeleteBoard("board-deleted", "deleter-id"))
+	require.NoError(t, store.DeleteBoard("board-other-team", "deleter-id"))
+
+	t.Run("lists the deleted boards of the team", func(t *testing.T) {
+		boards, err := store.GetDeletedBoardsForTeam(testTeamID, 0)
+		require.NoError(t, err)
+		require.Len(t, boards, 1)
+		require.Equal(t, "board-deleted", boards[0].ID)
+		require.Equal(t, "Deleted", boards[0].Title)
+		require.Equal(t, "deleter-id", boards[0].ModifiedBy)
+		require.NotZero(t, boards[0].DeleteAt)
+	})
+
+	t.Run("boards deleted before the cutoff", func(t *testing.T) {
+		boards, err := store.GetDeletedBoardsForTeam(testTeamID, time.Now().Add(time.Hour).UnixNano()/int64(time.Millisecond))
+		require.NoError(t, err)
+		require.Empty(t, boards)
+	})
+
+	t.Run("restored boards", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		require.NoError(t, store.UndeleteBoard("board-deleted", testUserID))
+
+		boards, err := store.GetDeletedBoardsForTeam(testTeamID, 0)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+
+		board, err := store.GetBoard("board-deleted")
+		require.NoError(t, err)
+		require.Equal(t, "Deleted", board.Title)
+		require.Equal(t, model.BoardTypeOpen, board.Type)
+	})
+}
+
+func testGetDeletedCardsForTeam(t *testing.T, store store.Store) {
+	_, err := store.InsertBoard(&model.Board{ID: testBoardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+	require.NoError(t, err)
+	insertBlockTree(t, store)
+
+	time.Sleep(1 * time.Millisecond)
+	_, err = store.DeleteBlockTree("block1", "deleter-id")
+	require.NoError(t, err)
+
+	t.Run("lists the deleted cards only", func(t *testing.T) {
+		cards, err := store.GetDeletedCardsForTeam(testTeamID, 0)
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		require.Equal(t, "block1", cards[0].ID)
+		require.Equal(t, "deleter-id", cards[0].ModifiedBy)
+		require.NotZero(t, cards[0].DeleteAt)
+	})
+
+	t.Run("other teams", func(t *testing.T) {
+		cards, err := store.GetDeletedCardsForTeam("other-team-id", 0)
+		require.NoError(t, err)
+		require.Empty(t, cards)
+	})
+}
+
+func testPurgeDeletedBlockTree(t *testing.T, store store.Store) {
+	insertBlockTree(t, store)
+
+	time.Sleep(1 * time.Millisecond)
+	_, err := store.DeleteBlockTree("block1", testUserID)
+	require.NoError(t, err)
+
+	t.Run("block not deleted", func(t *testing.T) {
+		_, err := store.PurgeDeletedBlockTree("block5")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("purges the block and its descendants", func(t *testing.T) {
+		purged, err := store.PurgeDeletedBlockTree("block1")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"block1", "block2", "block3", "block4"}, idsOfBlocks(purged))
+
+		for _, id := range idsOfBlocks(purged) {
+			history, err := store.GetBlockHistory(id, model.QueryBlockHistoryOptions{})
+			require.NoError(t, err)
+			require.Empty(t, history)
+		}
+
+		history, err := store.GetBlockHistory("block5", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.NotEmpty(t, history)
+	})
+}
+
+func testPurgeDeletedBoard(t *testing.T, store store.Store) {
+	_, err := store.InsertBoard(&model.Board{ID: testBoardID, TeamID: testTeamID, Type: model.BoardTypeOpen}, testUserID)
+	require.NoError(t, err)
+	insertBlockTree(t, store)
+
+	t.Run("board not deleted", func(t *testing.T) {
+		_, err := store.PurgeDeletedBoard(testBoardID)
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	time.Sleep(10 * time.Millisecond)
+	_, err = store.DeleteBlockTree("block5", testUserID)
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteBoard(testBoardID, testUserID))
+
+	t.Run("purges the board and its blocks", func(t *testing.T) {
+		blocks, err := store.PurgeDeletedBoard(testBoardID)
+		require.NoError(t, err)
+		require.Subset(t, idsOfBlocks(blocks), []string{"block1", "block4", "block5"})
+
+		boards, err := store.GetDeletedBoardsForTeam(testTeamID, 0)
+		require.NoError(t, err)
+		require.Empty(t, boards)
+
+		remaining, err := store.GetBlocksForBoard(testBoardID)
+		require.NoError(t, err)
+		require.Empty(t, remaining)
+
+		history, err := store.GetBlockHistory("block5", model.QueryBlockHistoryOptions{})
+		require.NoError(t, err)
+		require.Empty(t, history)
+	})
+}
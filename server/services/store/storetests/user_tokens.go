@@ -0,0 +1,95 @@
+package storetests
+
+import (
+	"testing"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+	"github.com/mattermost/focalboard/server/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func StoreTestUserTokenStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetAndDeleteUserToken", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetAndDeleteUserToken(t, store)
+	})
+
+	t.Run("DeleteUserTokensForUser", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testDeleteUserTokensForUser(t, store)
+	})
+
+	t.Run("CleanUpUserTokens", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCleanUpUserTokens(t, store)
+	})
+}
+
+func testCreateAndGetAndDeleteUserToken(t *testing.T, store store.Store) {
+	token := &model.UserToken{
+		Token:  "token",
+		Type:   model.UserTokenTypePasswordReset,
+		UserID: "user-id",
+		Email:  "user@example.com",
+	}
+
+	t.Run("CreateAndGetUserToken", func(t *testing.T) {
+		err := store.CreateUserToken(token)
+		require.NoError(t, err)
+		require.NotZero(t, token.CreateAt)
+
+		got, err := store.GetUserToken(token.Token)
+		require.NoError(t, err)
+		require.Equal(t, token, got)
+	})
+
+	t.Run("GetMissingUserToken", func(t *testing.T) {
+		_, err := store.GetUserToken("missing")
+		require.True(t, model.IsErrNotFound(err))
+	})
+
+	t.Run("DeleteAndGetUserToken", func(t *testing.T) {
+		err := store.DeleteUserToken(token.Token)
+		require.NoError(t, err)
+
+		_, err = store.GetUserToken(token.Token)
+		require.True(t, model.IsErrNotFound(err))
+	})
+}
+
+func testDeleteUserTokensForUser(t *testing.T, store store.Store) {
+	tokens := []*model.UserToken{
+		{Token: "token-1", Type: model.UserTokenTypePasswordReset, UserID: "user-1"},
+		{Token: "token-2", Type: model.UserTokenTypeVerifyEmail, UserID: "user-1"},
+		{Token: "token-3", Type: model.UserTokenTypePasswordReset, UserID: "user-2"},
+	}
+	for _, token := range tokens {
+		require.NoError(t, store.CreateUserToken(token))
+	}
+
+	require.NoError(t, store.DeleteUserTokensForUser("user-1", model.UserTokenTypePasswordReset))
+
+	_, err := store.GetUserToken("token-1")
+	require.True(t, model.IsErrNotFound(err))
+	_, err = store.GetUserToken("token-2")
+	require.NoError(t, err)
+	_, err = store.GetUserToken("token-3")
+	require.NoError(t, err)
+}
+
+func testCleanUpUserTokens(t *testing.T, store store.Store) {
+	token := &model.UserToken{Token: "token", Type: model.UserTokenTypeVerifyEmail, UserID: "user-id"}
+	require.NoError(t, store.CreateUserToken(token))
+
+	require.NoError(t, store.CleanUpUserTokens(token.CreateAt))
+	_, err := store.GetUserToken(token.Token)
+	require.NoError(t, err)
+
+	require.NoError(t, store.CleanUpUserTokens(utils.GetMillis()+1))
+	_, err = store.GetUserToken(token.Token)
+	require.True(t, model.IsErrNotFound(err))
+}
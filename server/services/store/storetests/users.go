@@ -43,6 +43,12 @@ func StoreTestUserStore(t *testing.T, setup func(t *testing.T) (store.Store, fun
 		defer tearDown()
 		testPatchUserProps(t, store)
 	})
+
+	t.Run("SearchUserDirectory", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testSearchUserDirectory(t, store)
+	})
 }
 
 func testGetTeamUsers(t *testing.T, store store.Store) {
@@ -226,3 +232,55 @@ func testPatchUserProps(t *testing.T, store store.Store) {
 	require.False(t, ok)
 	require.Equal(t, fetchedUser.Props["new_key_3"], "new_value_3_new_again")
 }
+
+func testSearchUserDirectory(t *testing.T, store store.Store) {
+	for _, username := range []string{"john.doe", "John_Smith", "johnny", "jane", "joh%n"} {
+		err := store.CreateUser(&model.User{
+			ID:       utils.NewID(utils.IDTypeUser),
+			Username: username,
+		})
+		require.NoError(t, err)
+	}
+
+	usernames := func(users []*model.UserDirectoryEntry) []string {
+		names := []string{}
+		for _, user := range users {
+			names = append(names, user.Username)
+		}
+		return names
+	}
+
+	t.Run("all the users are listed without a prefix", func(t *testing.T) {
+		users, err := store.SearchUserDirectory("team-id", "", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, users, 5)
+		require.NotEmpty(t, users[0].ID)
+	})
+
+	t.Run("the prefix is case insensitive", func(t *testing.T) {
+		users, err := store.SearchUserDirectory("team-id", "JOHN", 0, 10)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"john.doe", "John_Smith", "johnny"}, usernames(users))
+	})
+
+	t.Run("the wildcards of the prefix are escaped", func(t *testing.T) {
+		users, err := store.SearchUserDirectory("team-id", "john_", 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"John_Smith"}, usernames(users))
+
+		users, err = store.SearchUserDirectory("team-id", "joh%", 0, 10)
+		require.NoError(t, err)
+		require.Equal(t, []string{"joh%n"}, usernames(users))
+	})
+
+	t.Run("the users are paginated", func(t *testing.T) {
+		firstPage, err := store.SearchUserDirectory("team-id", "j", 0, 2)
+		require.NoError(t, err)
+		require.Len(t, firstPage, 2)
+
+		lastPage, err := store.SearchUserDirectory("team-id", "j", 4, 2)
+		require.NoError(t, err)
+		require.Len(t, lastPage, 1)
+		require.NotContains(t, usernames(firstPage), lastPage[0].Username)
+	})
+}
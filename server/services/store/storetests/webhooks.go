@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/store"
+)
+
+func StoreTestWebhooksStore(t *testing.T, setup func(t *testing.T) (store.Store, func())) {
+	t.Run("CreateAndGetWebhooks", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testCreateAndGetWebhooks(t, store)
+	})
+	t.Run("UpdateAndDeleteWebhook", func(t *testing.T) {
+		store, tearDown := setup(t)
+		defer tearDown()
+		testUpdateAndDeleteWebhook(t, store)
+	})
+}
+
+func testCreateAndGetWebhooks(t *testing.T, store store.Store) {
+	t.Run("unknown webhook", func(t *testing.T) {
+		webhook, err := store.GetWebhook("unknown")
+		require.True(t, model.IsErrNotFound(err))
+		require.Nil(t, webhook)
+	})
+
+	webhook, err := store.CreateWebhook(&model.Webhook{
+		TeamID:     testTeamID,
+		URL:        "https://example.com/hook",
+		Secret:     "secret",
+		BlockTypes: []model.BlockType{model.TypeCard},
+		BoardIDs:   []string{"board-1"},
+		Actions:    []model.WebhookAction{model.WebhookActionAdd},
+		CreatedBy:  testUserID,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, webhook.ID)
+	require.NotZero(t, webhook.CreateAt)
+
+	_, err = store.CreateWebhook(&model.Webhook{TeamID: "other-team", URL: "https://example.com/other", Secret: "secret"})
+	require.NoError(t, err)
+
+	t.Run("get webhook", func(t *testing.T) {
+		fetched, err := store.GetWebhook(webhook.ID)
+		require.NoError(t, err)
+		require.Equal(t, webhook.URL, fetched.URL)
+		require.Equal(t, "secret", fetched.Secret)
+		require.Equal(t, []model.BlockType{model.TypeCard}, fetched.BlockTypes)
+		require.Equal(t, []string{"board-1"}, fetched.BoardIDs)
+		require.Equal(t, []model.WebhookAction{model.WebhookActionAdd}, fetched.Actions)
+		require.Equal(t, testUserID, fetched.CreatedBy)
+	})
+
+	t.Run("get webhooks of a team", func(t *testing.T) {
+		webhooks, err := store.GetWebhooksForTeam(testTeamID)
+		require.NoError(t, err)
+		require.Len(t, webhooks, 1)
+		require.Equal(t, webhook.ID, webhooks[0].ID)
+	})
+}
+
+func testUpdateAndDeleteWebhook(t *testing.T, store store.Store) {
+	webhook, err := store.CreateWebhook(&model.Webhook{TeamID: testTeamID, URL: "https://example.com/hook", Secret: "secret"})
+	require.NoError(t, err)
+
+	t.Run("update webhook", func(t *testing.T) {
+		webhook.URL = "https://example.com/updated"
+		webhook.Actions = []model.WebhookAction{model.WebhookActionDelete}
+		require.NoError(t, store.UpdateWebhook(webhook))
+
+		fetched, err := store.GetWebhook(webhook.ID)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/updated", fetched.URL)
+		require.Equal(t, []model.WebhookAction{model.WebhookActionDelete}, fetched.Actions)
+		require.Equal(t, []model.BlockType{}, fetched.BlockTypes)
+		require.Equal(t, "secret", fetched.Secret)
+	})
+
+	t.Run("delete webhook", func(t *testing.T) {
+		require.NoError(t, store.DeleteWebhook(webhook.ID))
+		_, err := store.GetWebhook(webhook.ID)
+		require.True(t, model.IsErrNotFound(err))
+
+		require.True(t, model.IsErrNotFound(store.DeleteWebhook(webhook.ID)))
+	})
+}
@@ -0,0 +1,169 @@
+package textextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"strings"
+)
+
+var (
+	pdfStreamStart = []byte("stream")
+	pdfStreamEnd   = []byte("endstream")
+	pdfObjStart    = []byte("obj")
+)
+
+// extractPDF returns the text shown by the content streams of the PDF.
+// This is a best effort: only uncompressed and Flate compressed streams
+// are read, and the strings are taken as written, so fonts with custom
+// encodings give no useful text.
+func extractPDF(data []byte) string {
+	var text strings.Builder
+	for len(data) > 0 {
+		start := bytes.Index(data, pdfStreamStart)
+		if start < 0 {
+			break
+		}
+		if bytes.HasSuffix(data[:start], []byte("end")) {
+			data = data[start+len(pdfStreamStart):]
+			continue
+		}
+
+		dict := data[:start]
+		if obj := bytes.LastIndex(dict, pdfObjStart); obj >= 0 {
+			dict = dict[obj:]
+		}
+
+		body := data[start+len(pdfStreamStart):]
+		body = bytes.TrimPrefix(body, []byte("\r"))
+		body = bytes.TrimPrefix(body, []byte("\n"))
+		end := bytes.Index(body, pdfStreamEnd)
+		if end < 0 {
+			break
+		}
+		data = body[end+len(pdfStreamEnd):]
+
+		content, ok := decodePDFStream(dict, body[:end])
+		if ok {
+			extractPDFContentText(content, &text)
+		}
+	}
+	return text.String()
+}
+
+func decodePDFStream(dict, stream []byte) ([]byte, bool) {
+	if !bytes.Contains(dict, []byte("/Filter")) {
+		return stream, true
+	}
+	if !bytes.Contains(dict, []byte("/FlateDecode")) || bytes.Contains(dict, []byte("/DCTDecode")) {
+		return nil, false
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(stream))
+	if err != nil {
+		return nil, false
+	}
+	defer r.Close()
+
+	// a truncated stream still gives the text before the error
+	content, _ := io.ReadAll(io.LimitReader(r, MaxDocumentSize))
+	return content, len(content) > 0
+}
+
+// extractPDFContentText writes the strings shown by the text operators
+// of a content stream, separating the operators with spaces and the text
+// objects with new lines.
+func extractPDFContentText(content []byte, text *strings.Builder) {
+	inText := false
+	var pending strings.Builder
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '(':
+			s, next := readPDFString(content, i+1)
+			if inText {
+				pending.WriteString(s)
+			}
+			i = next - 1
+		case c == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case isPDFOperatorChar(c):
+			start := i
+			for i < len(content) && isPDFOperatorChar(content[i]) {
+				i++
+			}
+			operator := string(content[start:i])
+			i--
+
+			switch operator {
+			case "BT":
+				inText = true
+			case "ET":
+				inText = false
+				text.WriteString("\n")
+			case "Tj", "TJ", "'", "\"":
+				text.WriteString(pending.String())
+				text.WriteString(" ")
+				pending.Reset()
+			}
+		}
+	}
+}
+
+func isPDFOperatorChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '*' || c == '\'' || c == '"'
+}
+
+// readPDFString reads a literal string starting after its opening
+// parenthesis, and returns it with the index after its end.
+func readPDFString(content []byte, i int) (string, int) {
+	var s strings.Builder
+	depth := 1
+	for ; i < len(content); i++ {
+		c := content[i]
+		switch c {
+		case '\\':
+			i++
+			if i >= len(content) {
+				return s.String(), i
+			}
+			switch e := content[i]; e {
+			case 'n':
+				s.WriteByte('\n')
+			case 'r':
+				s.WriteByte('\r')
+			case 't':
+				s.WriteByte('\t')
+			case 'b', 'f':
+			case '\r', '\n':
+				// line continuation
+			default:
+				if e >= '0' && e <= '7' {
+					value := 0
+					j := i
+					for ; j < len(content) && j < i+3 && content[j] >= '0' && content[j] <= '7'; j++ {
+						value = value*8 + int(content[j]-'0')
+					}
+					s.WriteByte(byte(value))
+					i = j - 1
+				} else {
+					s.WriteByte(e)
+				}
+			}
+		case '(':
+			depth++
+			s.WriteByte(c)
+		case ')':
+			depth--
+			if depth == 0 {
+				return s.String(), i + 1
+			}
+			s.WriteByte(c)
+		default:
+			s.WriteByte(c)
+		}
+	}
+	return s.String(), i
+}
@@ -0,0 +1,203 @@
+// Package textextract extracts the plain text of uploaded documents, so
+// that their content can be searched.
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxDocumentSize is the largest document, in bytes, whose text is
+// extracted.
+const MaxDocumentSize = 50 * 1024 * 1024
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported document format")
+	ErrDocumentTooLarge  = errors.New("document too large to extract its text")
+)
+
+// officeFormat describes where an Office Open XML or OpenDocument file
+// keeps its text: the archive entries, the elements holding text, all
+// of them if empty, and the elements ending a line.
+type officeFormat struct {
+	entries   func(name string) bool
+	textNames map[string]bool
+	lineNames map[string]bool
+}
+
+var officeFormats = map[string]officeFormat{
+	".docx": {
+		entries:   isEntry("word/document.xml"),
+		textNames: map[string]bool{"t": true},
+		lineNames: map[string]bool{"p": true},
+	},
+	".pptx": {
+		entries: func(name string) bool {
+			return strings.HasPrefix(name, "ppt/slides/slide") && strings.HasSuffix(name, ".xml")
+		},
+		textNames: map[string]bool{"t": true},
+		lineNames: map[string]bool{"p": true},
+	},
+	".xlsx": {
+		entries:   isEntry("xl/sharedStrings.xml"),
+		textNames: map[string]bool{"t": true},
+		lineNames: map[string]bool{"si": true},
+	},
+	".odt": {
+		entries:   isEntry("content.xml"),
+		lineNames: map[string]bool{"p": true, "h": true},
+	},
+	".ods": {
+		entries:   isEntry("content.xml"),
+		lineNames: map[string]bool{"p": true},
+	},
+	".odp": {
+		entries:   isEntry("content.xml"),
+		lineNames: map[string]bool{"p": true},
+	},
+}
+
+var plainTextExtensions = map[string]bool{
+	".txt": true,
+	".md":  true,
+	".csv": true,
+}
+
+func isEntry(entry string) func(name string) bool {
+	return func(name string) bool {
+		return name == entry
+	}
+}
+
+// Supported returns true if the text of the file can be extracted, going
+// by the extension of its name.
+func Supported(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	_, office := officeFormats[ext]
+	return office || plainTextExtensions[ext] || ext == ".pdf"
+}
+
+// Extract returns the text of the document, with its whitespace collapsed
+// and cut to maxLength bytes, going by the extension of the file name for
+// its format.
+func Extract(filename string, r io.Reader, maxLength int) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !Supported(filename) {
+		return "", ErrUnsupportedFormat
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxDocumentSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > MaxDocumentSize {
+		return "", ErrDocumentTooLarge
+	}
+
+	var text string
+	switch {
+	case plainTextExtensions[ext]:
+		text = string(data)
+	case ext == ".pdf":
+		text = extractPDF(data)
+	default:
+		text, err = extractOffice(data, officeFormats[ext])
+		if err != nil {
+			return "", err
+		}
+	}
+	return truncate(normalize(text), maxLength), nil
+}
+
+func extractOffice(data []byte, format officeFormat) (string, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	files := []*zip.File{}
+	for _, file := range archive.File {
+		if format.entries(file.Name) {
+			files = append(files, file)
+		}
+	}
+	// slides and sheets are numbered, keep them in order
+	sort.Slice(files, func(i, j int) bool {
+		if len(files[i].Name) != len(files[j].Name) {
+			return len(files[i].Name) < len(files[j].Name)
+		}
+		return files[i].Name < files[j].Name
+	})
+
+	var text strings.Builder
+	for _, file := range files {
+		rc, err := file.Open()
+		if err != nil {
+			return "", err
+		}
+		err = extractXMLText(io.LimitReader(rc, MaxDocumentSize), format, &text)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return text.String(), nil
+}
+
+func extractXMLText(r io.Reader, format officeFormat, text *strings.Builder) error {
+	decoder := xml.NewDecoder(r)
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if format.textNames[t.Name.Local] {
+				depth++
+			}
+		case xml.EndElement:
+			if format.textNames[t.Name.Local] {
+				depth--
+			}
+			if format.lineNames[t.Name.Local] {
+				text.WriteString("\n")
+			}
+		case xml.CharData:
+			if len(format.textNames) == 0 || depth > 0 {
+				text.Write(t)
+			}
+		}
+	}
+}
+
+// normalize collapses the whitespace of the text and drops the invalid
+// UTF-8 sequences.
+func normalize(text string) string {
+	return strings.Join(strings.Fields(strings.ToValidUTF8(text, " ")), " ")
+}
+
+// truncate cuts the text to at most maxLength bytes without splitting a
+// character.
+func truncate(text string, maxLength int) string {
+	if maxLength <= 0 || len(text) <= maxLength {
+		return text
+	}
+	text = text[:maxLength]
+	for len(text) > 0 && !utf8.ValidString(text) {
+		text = text[:len(text)-1]
+	}
+	return text
+}
@@ -0,0 +1,109 @@
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func zipDocument(t *testing.T, entries map[string]string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range entries {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestSupported(t *testing.T) {
+	require.True(t, Supported("Spec.DOCX"))
+	require.True(t, Supported("notes.pdf"))
+	require.True(t, Supported("readme.md"))
+	require.False(t, Supported("photo.png"))
+	require.False(t, Supported("archive"))
+}
+
+func TestExtractOffice(t *testing.T) {
+	t.Run("docx", func(t *testing.T) {
+		doc := zipDocument(t, map[string]string{
+			"word/document.xml": `<w:document xmlns:w="w"><w:body>` +
+				`<w:p><w:r><w:t>Payments</w:t></w:r><w:r><w:t xml:space="preserve"> API spec</w:t></w:r></w:p>` +
+				`<w:p><w:r><w:instrText>IGNORED</w:instrText><w:t>Version 2</w:t></w:r></w:p>` +
+				`</w:body></w:document>`,
+			"word/styles.xml": `<w:styles xmlns:w="w"><w:t>Heading</w:t></w:styles>`,
+		})
+
+		text, err := Extract("spec.docx", bytes.NewReader(doc), 0)
+		require.NoError(t, err)
+		require.Equal(t, "Payments API spec Version 2", text)
+	})
+
+	t.Run("pptx slides in order", func(t *testing.T) {
+		doc := zipDocument(t, map[string]string{
+			"ppt/slides/slide10.xml": `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>Last</a:t></a:p></p:sld>`,
+			"ppt/slides/slide2.xml":  `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>Second</a:t></a:p></p:sld>`,
+			"ppt/slides/slide1.xml":  `<p:sld xmlns:a="a" xmlns:p="p"><a:p><a:t>First</a:t></a:p></p:sld>`,
+		})
+
+		text, err := Extract("deck.pptx", bytes.NewReader(doc), 0)
+		require.NoError(t, err)
+		require.Equal(t, "First Second Last", text)
+	})
+
+	t.Run("odt", func(t *testing.T) {
+		doc := zipDocument(t, map[string]string{
+			"content.xml": `<office:document-content xmlns:office="o" xmlns:text="t"><text:h>Title</text:h><text:p>Body text</text:p></office:document-content>`,
+		})
+
+		text, err := Extract("notes.odt", bytes.NewReader(doc), 0)
+		require.NoError(t, err)
+		require.Equal(t, "Title Body text", text)
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		_, err := Extract("spec.docx", strings.NewReader("plain text"), 0)
+		require.Error(t, err)
+	})
+}
+
+func TestExtractPDF(t *testing.T) {
+	content := "BT /F1 12 Tf 72 712 Td (Release \\(v2\\) plan) Tj ET\nBT [(Road) -250 (map)] TJ ET"
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	_, err := zw.Write([]byte("BT (Compressed text) Tj ET"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	pdf := fmt.Sprintf("%%PDF-1.4\n"+
+		"4 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n"+
+		"5 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream\nendobj\n"+
+		"6 0 obj\n<< /Length 3 /Filter /DCTDecode >>\nstream\n(x) Tj\nendstream\nendobj\n%%%%EOF",
+		len(content), content, compressed.Len(), compressed.String())
+
+	text, err := Extract("spec.pdf", strings.NewReader(pdf), 0)
+	require.NoError(t, err)
+	require.Equal(t, "Release (v2) plan Roadmap Compressed text", text)
+}
+
+func TestExtractPlainText(t *testing.T) {
+	text, err := Extract("notes.txt", strings.NewReader("  héllo\n\tworld  "), 0)
+	require.NoError(t, err)
+	require.Equal(t, "héllo world", text)
+
+	text, err = Extract("notes.txt", strings.NewReader("héllo world"), 2)
+	require.NoError(t, err)
+	require.Equal(t, "h", text)
+
+	_, err = Extract("photo.png", strings.NewReader(""), 0)
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
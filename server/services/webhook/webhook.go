@@ -2,45 +2,204 @@ package webhook
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"io/ioutil"
+	"fmt"
+	"io"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
-	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-// NotifyUpdate calls webhooks.
-func (wh *Client) NotifyUpdate(block model.Block) {
-	if len(wh.config.WebhookUpdate) < 1 {
+const (
+	// SignatureHeader holds the HMAC-SHA256 signature of the payload,
+	// computed with the secret of the webhook.
+	SignatureHeader = "X-Focalboard-Signature"
+	// ActionHeader holds the action of the payload.
+	ActionHeader = "X-Focalboard-Action"
+	// WebhookIDHeader holds the id of the webhook the payload is sent to.
+	WebhookIDHeader = "X-Focalboard-Webhook-Id"
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = time.Second
+	requestTimeout        = 10 * time.Second
+)
+
+// Store is the part of the store used to find the webhooks of a team.
+type Store interface {
+	GetWebhooksForTeam(teamID string) ([]*model.Webhook, error)
+}
+
+// BlockFilter returns true if the user can see the block.
+type BlockFilter func(userID string, block model.Block) bool
+
+// Client delivers the block changes to the webhooks registered by the
+// teams.
+type Client struct {
+	store          Store
+	logger         *mlog.Logger
+	filterMu       sync.RWMutex
+	filter         BlockFilter
+	httpClient     *http.Client
+	maxAttempts    int
+	initialBackoff time.Duration
+
+	wg           sync.WaitGroup
+	done         chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewClient creates a new Client. A nil store disables the webhooks.
+func NewClient(store Store, logger *mlog.Logger) *Client {
+	return &Client{
+		store:          store,
+		logger:         logger,
+		httpClient:     &http.Client{Timeout: requestTimeout},
+		maxAttempts:    defaultMaxAttempts,
+		initialBackoff: defaultInitialBackoff,
+		done:           make(chan struct{}),
+	}
+}
+
+// SetBlockFilter sets the check of the changes the webhooks receive: each
+// webhook only receives the changes of the blocks its owner can see. No
+// change is sent until the filter is set.
+func (wh *Client) SetBlockFilter(filter BlockFilter) {
+	wh.filterMu.Lock()
+	defer wh.filterMu.Unlock()
+	wh.filter = filter
+}
+
+// canReceive returns true if the owner of the webhook can see the block.
+// The webhooks of the system, migrated from the deprecated webhook_update
+// setting of the server, receive all the changes.
+func (wh *Client) canReceive(webhook *model.Webhook, block model.Block) bool {
+	if webhook.CreatedBy == model.SystemUserID {
+		return true
+	}
+
+	wh.filterMu.RLock()
+	filter := wh.filter
+	wh.filterMu.RUnlock()
+	return filter != nil && filter(webhook.CreatedBy, block)
+}
+
+// Sign returns the signature of the payload sent in the SignatureHeader.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NotifyBlockChange posts the block change to the webhooks of the team
+// whose filters match it and whose owner can see the block. The
+// deliveries run in the background and are retried with an exponential
+// backoff.
+func (wh *Client) NotifyBlockChange(teamID string, action model.WebhookAction, block model.Block) {
+	if wh.store == nil {
 		return
 	}
 
-	json, err := json.Marshal(block)
+	webhooks, err := wh.store.GetWebhooksForTeam(teamID)
 	if err != nil {
-		wh.logger.Fatal("NotifyUpdate: json.Marshal", mlog.Err(err))
+		wh.logger.Error("Cannot fetch the webhooks of the team", mlog.String("teamID", teamID), mlog.Err(err))
+		return
 	}
-	for _, url := range wh.config.WebhookUpdate {
-		resp, _ := http.Post(url, "application/json", bytes.NewBuffer(json)) //nolint:gosec
-		_, _ = ioutil.ReadAll(resp.Body)
-		resp.Body.Close()
 
-		wh.logger.Debug("webhook.NotifyUpdate", mlog.String("url", url))
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.Matches(action, &block) || !wh.canReceive(webhook, block) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(model.WebhookPayload{
+				Action:    action,
+				TeamID:    teamID,
+				BoardID:   block.BoardID,
+				Block:     block,
+				Timestamp: utils.GetMillis(),
+			})
+			if err != nil {
+				wh.logger.Error("Cannot marshal the webhook payload", mlog.String("blockID", block.ID), mlog.Err(err))
+				return
+			}
+		}
+
+		wh.wg.Add(1)
+		go wh.deliver(webhook, action, payload)
 	}
 }
 
-// Client is a webhook client.
-type Client struct {
-	config *config.Configuration
-	logger *mlog.Logger
+func (wh *Client) deliver(webhook *model.Webhook, action model.WebhookAction, payload []byte) {
+	defer wh.wg.Done()
+
+	backoff := wh.initialBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := wh.post(webhook, action, payload)
+		if err == nil {
+			wh.logger.Debug("webhook.NotifyBlockChange", mlog.String("webhookID", webhook.ID), mlog.Int("attempt", attempt))
+			return
+		}
+
+		if !retry || attempt >= wh.maxAttempts {
+			wh.logger.Error("Cannot deliver webhook",
+				mlog.String("webhookID", webhook.ID),
+				mlog.Int("attempts", attempt),
+				mlog.Err(err),
+			)
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-wh.done:
+			return
+		}
+		backoff *= 2
+	}
 }
 
-// NewClient creates a new Client.
-func NewClient(config *config.Configuration, logger *mlog.Logger) *Client {
-	return &Client{
-		config: config,
-		logger: logger,
+// post sends the payload to the webhook and returns whether a failed
+// delivery should be retried.
+func (wh *Client) post(webhook *model.Webhook, action model.WebhookAction, payload []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, payload))
+	req.Header.Set(ActionHeader, string(action))
+	req.Header.Set(WebhookIDHeader, webhook.ID)
+
+	resp, err := wh.httpClient.Do(req)
+	if err != nil {
+		return true, err
 	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+}
+
+// Shutdown stops the retries and waits for the deliveries in progress.
+func (wh *Client) Shutdown() {
+	wh.shutdownOnce.Do(func() {
+		close(wh.done)
+	})
+	wh.wg.Wait()
 }
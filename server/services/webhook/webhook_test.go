@@ -1,39 +1,167 @@
 package webhook
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/model"
-	"github.com/mattermost/focalboard/server/services/config"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
-func TestClientUpdateNotify(t *testing.T) {
-	var isNotified bool
-	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		isNotified = true
-	}))
-	defer ts.Close()
+type testStore struct {
+	webhooks []*model.Webhook
+}
 
-	cfg := &config.Configuration{
-		WebhookUpdate: []string{ts.URL},
-	}
+func (s *testStore) GetWebhooksForTeam(teamID string) ([]*model.Webhook, error) {
+	return s.webhooks, nil
+}
 
+func newTestClient(t *testing.T, webhooks ...*model.Webhook) *Client {
 	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
-	defer func() {
+	t.Cleanup(func() {
 		err := logger.Shutdown()
 		assert.NoError(t, err)
-	}()
+	})
+
+	client := NewClient(&testStore{webhooks: webhooks}, logger)
+	client.initialBackoff = time.Millisecond
+	client.SetBlockFilter(func(userID string, block model.Block) bool { return true })
+	return client
+}
+
+func TestClientNotifyBlockChange(t *testing.T) {
+	var payload model.WebhookPayload
+	var signature, action string
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+		signature = r.Header.Get(SignatureHeader)
+		action = r.Header.Get(ActionHeader)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t, &model.Webhook{ID: "webhook-id", URL: ts.URL, Secret: "secret"})
+	client.NotifyBlockChange("team-id", model.WebhookActionAdd, model.Block{ID: "card-id", BoardID: "board-id", Type: model.TypeCard})
+	client.Shutdown()
+
+	require.Equal(t, "card-id", payload.Block.ID)
+	require.Equal(t, "board-id", payload.BoardID)
+	require.Equal(t, "team-id", payload.TeamID)
+	require.Equal(t, model.WebhookActionAdd, payload.Action)
+	require.Equal(t, string(model.WebhookActionAdd), action)
+	require.Equal(t, Sign("secret", body), signature)
+}
+
+func TestClientNotifyBlockChangeFilters(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer ts.Close()
+
+	client := newTestClient(t,
+		&model.Webhook{ID: "cards", URL: ts.URL, BlockTypes: []model.BlockType{model.TypeCard}},
+		&model.Webhook{ID: "other-board", URL: ts.URL, BoardIDs: []string{"other-board-id"}},
+		&model.Webhook{ID: "deletes", URL: ts.URL, Actions: []model.WebhookAction{model.WebhookActionDelete}},
+	)
+	client.NotifyBlockChange("team-id", model.WebhookActionUpdate, model.Block{ID: "card-id", BoardID: "board-id", Type: model.TypeCard})
+	client.Shutdown()
+
+	require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestClientNotifyBlockChangeBlockFilter(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer ts.Close()
+
+	block := model.Block{ID: "card-id", BoardID: "board-id", Type: model.TypeCard}
+
+	t.Run("only the owners seeing the block receive it", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		client := newTestClient(t,
+			&model.Webhook{ID: "member", URL: ts.URL, CreatedBy: "member-id"},
+			&model.Webhook{ID: "stranger", URL: ts.URL, CreatedBy: "stranger-id"},
+			&model.Webhook{ID: "system", URL: ts.URL, CreatedBy: model.SystemUserID},
+		)
+		var checked []string
+		client.SetBlockFilter(func(userID string, b model.Block) bool {
+			checked = append(checked, userID)
+			return userID == "member-id" && b.ID == block.ID
+		})
+		client.NotifyBlockChange("team-id", model.WebhookActionAdd, block)
+		client.Shutdown()
+
+		require.EqualValues(t, 2, atomic.LoadInt32(&calls))
+		require.ElementsMatch(t, []string{"member-id", "stranger-id"}, checked)
+	})
+
+	t.Run("nothing is sent without a filter", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		client := newTestClient(t, &model.Webhook{ID: "member", URL: ts.URL, CreatedBy: "member-id"})
+		client.SetBlockFilter(nil)
+		client.NotifyBlockChange("team-id", model.WebhookActionAdd, block)
+		client.Shutdown()
+
+		require.EqualValues(t, 0, atomic.LoadInt32(&calls))
+	})
+}
+
+func TestClientNotifyBlockChangeRetries(t *testing.T) {
+	t.Run("server errors are retried", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer ts.Close()
+
+		client := newTestClient(t, &model.Webhook{ID: "webhook-id", URL: ts.URL})
+		client.NotifyBlockChange("team-id", model.WebhookActionAdd, model.Block{ID: "card-id"})
+		client.wg.Wait()
+
+		require.EqualValues(t, 3, atomic.LoadInt32(&calls))
+	})
+
+	t.Run("attempts are limited", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer ts.Close()
+
+		client := newTestClient(t, &model.Webhook{ID: "webhook-id", URL: ts.URL})
+		client.NotifyBlockChange("team-id", model.WebhookActionAdd, model.Block{ID: "card-id"})
+		client.wg.Wait()
+
+		require.EqualValues(t, defaultMaxAttempts, atomic.LoadInt32(&calls))
+	})
 
-	client := NewClient(cfg, logger)
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusBadRequest)
+		}))
+		defer ts.Close()
 
-	client.NotifyUpdate(model.Block{})
+		client := newTestClient(t, &model.Webhook{ID: "webhook-id", URL: ts.URL})
+		client.NotifyBlockChange("team-id", model.WebhookActionAdd, model.Block{ID: "card-id"})
+		client.wg.Wait()
 
-	if !isNotified {
-		t.Error("webhook url not be notified")
-	}
+		require.EqualValues(t, 1, atomic.LoadInt32(&calls))
+	})
 }
@@ -0,0 +1,12 @@
+// Package swagger holds the API specification generated by `make swagger`
+// from the comments in the server code.
+package swagger
+
+import (
+	_ "embed"
+)
+
+// OpenAPISpec is the OpenAPI 3 specification of the server API, as JSON.
+//
+//go:embed openapi/openapi.json
+var OpenAPISpec []byte
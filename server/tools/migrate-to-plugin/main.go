@@ -0,0 +1,155 @@
+// migrate-to-plugin moves the boards of a standalone Focalboard server to
+// the boards plugin of a Mattermost server.
+//
+// It reads the database and the files of the standalone server directly,
+// so the standalone server should be stopped, and writes a team migration
+// archive. When the URL of the plugin is given, the archive is imported
+// into a Mattermost team, matching the users by email, and the number of
+// imported blocks is checked against the source database.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mattermost/focalboard/server/app"
+	"github.com/mattermost/focalboard/server/client"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/server"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const defArchiveFilename = "team-migration.boardarchive"
+
+var errCountMismatch = errors.New("the number of imported blocks doesn't match the source")
+
+type appConfig struct {
+	configFile string
+	teamID     string
+	out        string
+	url        string
+	token      string
+	targetTeam string
+}
+
+func main() {
+	cfg := appConfig{}
+
+	flag.StringVar(&cfg.configFile, "config", "./config.json", "config file of the standalone server")
+	flag.StringVar(&cfg.teamID, "team", model.GlobalTeamID, "team of the standalone server to migrate")
+	flag.StringVar(&cfg.out, "out", defArchiveFilename, "file the migration archive is written to")
+	flag.StringVar(&cfg.url, "url", "", "URL of the boards plugin, e.g. https://mattermost.example.com/plugins/focalboard. Without it the archive is only written")
+	flag.StringVar(&cfg.token, "token", "", "access token of a Mattermost user allowed to manage the target team")
+	flag.StringVar(&cfg.targetTeam, "target-team", "", "id of the Mattermost team that receives the boards")
+	flag.Parse()
+
+	if cfg.url != "" && (cfg.token == "" || cfg.targetTeam == "") {
+		flag.Usage()
+		os.Exit(-1)
+	}
+
+	var code int
+	if err := migrate(cfg); err != nil {
+		code = -1
+		fmt.Fprintf(os.Stderr, "error migrating team: %v\n", err)
+	}
+	os.Exit(code)
+}
+
+func migrate(cfg appConfig) error {
+	serverConfig, err := config.ReadConfigFile(cfg.configFile)
+	if err != nil {
+		return fmt.Errorf("cannot read config: %w", err)
+	}
+
+	logger, _ := mlog.NewLogger()
+	defer func() { _ = logger.Shutdown() }()
+
+	db, err := server.NewStore(serverConfig, false, logger)
+	if err != nil {
+		return fmt.Errorf("cannot open the database: %w", err)
+	}
+	defer func() { _ = db.Shutdown() }()
+
+	filesBackend, err := server.NewFilesBackend(serverConfig)
+	if err != nil {
+		return fmt.Errorf("cannot open the files storage: %w", err)
+	}
+
+	a := app.New(serverConfig, nil, app.Services{
+		Store:            db,
+		FilesBackend:     filesBackend,
+		Logger:           logger,
+		SkipTemplateInit: true,
+	})
+	defer a.Shutdown()
+
+	counts, err := countBlocks(db, cfg.teamID)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(cfg.out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := a.ExportTeamMigration(f, cfg.teamID, ""); err != nil {
+		return fmt.Errorf("cannot export team %s: %w", cfg.teamID, err)
+	}
+	fmt.Fprintf(os.Stdout, "exported %d boards to %s\n", len(counts), cfg.out)
+
+	if cfg.url == "" {
+		return nil
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+
+	c := client.NewClient(cfg.url, cfg.token)
+	result, resp := c.ImportTeamMigration(cfg.targetTeam, f, nil, true)
+	if resp.Error != nil {
+		return fmt.Errorf("cannot import the archive: %w", resp.Error)
+	}
+
+	expected := 0
+	for _, boardID := range result.BoardIDs {
+		expected += counts[boardID]
+	}
+
+	fmt.Fprintf(os.Stdout, "imported %d boards with %d blocks, skipped %d existing boards\n",
+		len(result.BoardIDs), result.BlockCount, len(result.SkippedBoardIDs))
+	for _, userID := range result.UnmappedUserIDs {
+		fmt.Fprintf(os.Stdout, "no Mattermost user found for user %s\n", userID)
+	}
+
+	if result.BlockCount != expected {
+		return fmt.Errorf("%w: %d imported, %d expected", errCountMismatch, result.BlockCount, expected)
+	}
+	return nil
+}
+
+// countBlocks returns the number of blocks of each board of the team.
+func countBlocks(db store.Store, teamID string) (map[string]int, error) {
+	boards, err := db.GetBoardsForTeam(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(boards))
+	for _, board := range boards {
+		blocks, err := db.GetBlocksForBoard(board.ID)
+		if err != nil {
+			return nil, err
+		}
+		counts[board.ID] = len(blocks)
+	}
+	return counts, nil
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []string{"10.0.0.0/8"}
+
+	request := func(remoteAddr string, headers map[string]string) *http.Request {
+		r := &http.Request{RemoteAddr: remoteAddr, Header: http.Header{}}
+		for key, value := range headers {
+			r.Header.Set(key, value)
+		}
+		return r
+	}
+
+	testCases := []struct {
+		name     string
+		r        *http.Request
+		proxies  []string
+		expected string
+	}{
+		{"peer without proxies", request("203.0.113.5:1234", nil), trustedProxies, "203.0.113.5"},
+		{"address without port", request("203.0.113.5", nil), trustedProxies, "203.0.113.5"},
+		{
+			"headers of an untrusted peer",
+			request("203.0.113.5:1234", map[string]string{"X-Forwarded-For": "198.51.100.1", "X-Real-IP": "198.51.100.2"}),
+			trustedProxies,
+			"203.0.113.5",
+		},
+		{
+			"no trusted proxies",
+			request("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.1"}),
+			nil,
+			"10.0.0.1",
+		},
+		{
+			"forwarded by a trusted proxy",
+			request("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "198.51.100.1"}),
+			trustedProxies,
+			"198.51.100.1",
+		},
+		{
+			"spoofed hops before the last untrusted one",
+			request("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "192.0.2.9, 198.51.100.1, 10.0.0.2"}),
+			trustedProxies,
+			"198.51.100.1",
+		},
+		{
+			"only trusted hops",
+			request("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}),
+			trustedProxies,
+			"10.0.0.3",
+		},
+		{
+			"invalid hop",
+			request("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "unknown, 10.0.0.2"}),
+			trustedProxies,
+			"10.0.0.2",
+		},
+		{
+			"real ip of a trusted proxy",
+			request("10.0.0.1:1234", map[string]string{"X-Real-IP": "198.51.100.2"}),
+			trustedProxies,
+			"198.51.100.2",
+		},
+		{
+			"invalid real ip",
+			request("10.0.0.1:1234", map[string]string{"X-Real-IP": "unknown"}),
+			trustedProxies,
+			"10.0.0.1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ClientIP(tc.r, tc.proxies))
+		})
+	}
+}
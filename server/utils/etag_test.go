@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	etag := ETag("board-id", int64(42))
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, ETag("board-id", int64(42)))
+	assert.NotEqual(t, etag, ETag("board-id", int64(43)))
+	assert.NotEqual(t, ETag("ab", "c"), ETag("a", "bc"))
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `W/"abc"`
+
+	assert.True(t, ETagMatches(`W/"abc"`, etag))
+	assert.True(t, ETagMatches(`"abc"`, etag))
+	assert.True(t, ETagMatches(`"xyz", W/"abc"`, etag))
+	assert.True(t, ETagMatches(`*`, etag))
+
+	assert.False(t, ETagMatches(``, etag))
+	assert.False(t, ETagMatches(`"xyz"`, etag))
+	assert.False(t, ETagMatches(`W/"abcd"`, etag))
+	assert.False(t, ETagMatches(`*`, ``))
+}
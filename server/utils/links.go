@@ -9,3 +9,8 @@ import "fmt"
 func MakeCardLink(serverRoot string, teamID string, boardID string, cardID string) string {
 	return fmt.Sprintf("%s/team/%s/%s/0/%s", serverRoot, teamID, boardID, cardID)
 }
+
+// MakeBoardLink creates fully qualified board links based on board id and team.
+func MakeBoardLink(serverRoot string, teamID string, boardID string) string {
+	return fmt.Sprintf("%s/team/%s/%s", serverRoot, teamID, boardID)
+}
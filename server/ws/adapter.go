@@ -15,20 +15,45 @@ const (
 	websocketActionUpdateMember        = "UPDATE_MEMBER"
 	websocketActionDeleteMember        = "DELETE_MEMBER"
 	websocketActionUpdateBlock         = "UPDATE_BLOCK"
+	websocketActionUpdateBlocks        = "UPDATE_BLOCKS"
 	websocketActionUpdateConfig        = "UPDATE_CLIENT_CONFIG"
 	websocketActionUpdateCategory      = "UPDATE_CATEGORY"
 	websocketActionUpdateCategoryBoard = "UPDATE_BOARD_CATEGORY"
 	websocketActionUpdateSubscription  = "UPDATE_SUBSCRIPTION"
+	websocketActionSyncText            = "SYNC_TEXT"
+	websocketActionEditText            = "EDIT_TEXT"
+	websocketActionUpdateText          = "UPDATE_TEXT"
+	websocketActionAckText             = "ACK_TEXT"
+	websocketActionResyncText          = "RESYNC_TEXT"
+	websocketActionUpdateBoardLimits   = "UPDATE_BOARD_LIMITS"
+	websocketActionViewBoard           = "VIEW_BOARD"
+	websocketActionLeaveBoard          = "LEAVE_BOARD"
+	websocketActionPresenceJoin        = "PRESENCE_JOIN"
+	websocketActionPresenceLeave       = "PRESENCE_LEAVE"
+	websocketActionStartEditingCard    = "START_EDITING_CARD"
+	websocketActionStopEditingCard     = "STOP_EDITING_CARD"
+	websocketActionCardEditing         = "CARD_EDITING"
+	websocketActionSyncSince           = "SYNC_SINCE"
+	websocketActionSyncBlocks          = "SYNC_BLOCKS"
+	websocketActionJoinCRDTText        = "JOIN_CRDT_TEXT"
+	websocketActionLeaveCRDTText       = "LEAVE_CRDT_TEXT"
+	websocketActionUpdateCRDTText      = "UPDATE_CRDT_TEXT"
+	websocketActionAckCRDTText         = "ACK_CRDT_TEXT"
+	websocketActionSnapshotCRDTText    = "SNAPSHOT_CRDT_TEXT"
+	websocketActionRequestCRDTSnapshot = "REQUEST_CRDT_SNAPSHOT"
+	websocketActionSyncCRDTText        = "SYNC_CRDT_TEXT"
 )
 
 type Store interface {
 	GetBlock(blockID string) (*model.Block, error)
+	GetBoard(boardID string) (*model.Board, error)
 	GetMembersForBoard(boardID string) ([]*model.BoardMember, error)
+	GetBlocksModifiedSince(boardID string, since int64) ([]model.Block, error)
 }
 
 type Adapter interface {
 	BroadcastBlockChange(teamID string, block model.Block)
-	BroadcastBlockDelete(teamID, blockID, boardID string)
+	BroadcastBlockDelete(teamID, blockID, boardID, parentID string)
 	BroadcastBoardChange(teamID string, board *model.Board)
 	BroadcastBoardDelete(teamID, boardID string)
 	BroadcastMemberChange(teamID, boardID string, member *model.BoardMember)
@@ -37,4 +62,5 @@ type Adapter interface {
 	BroadcastCategoryChange(category model.Category)
 	BroadcastCategoryBoardChange(teamID, userID string, blockCategory model.BoardCategoryWebsocketData)
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
+	BroadcastBoardLimitsChange(limits model.BoardLimits)
 }
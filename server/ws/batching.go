@@ -0,0 +1,97 @@
+package ws
+
+import (
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// blockChangeBatchWindow is how long the block changes sent to a client
+// are held to be sent together, so that bulk updates like a drag-reorder
+// of many cards take a single frame instead of one per block.
+const blockChangeBatchWindow = 50 * time.Millisecond
+
+// UpdateBlocksMsg is sent instead of UpdateBlockMsg when several blocks of
+// a team changed within the batch window.
+type UpdateBlocksMsg struct {
+	Action string        `json:"action"`
+	TeamID string        `json:"teamId"`
+	Blocks []model.Block `json:"blocks"`
+}
+
+// queueBlockChange holds the block change to send it with the next ones
+// of the batch window. A newer change of a block already in the batch
+// replaces the previous one.
+func (wss *websocketSession) queueBlockChange(message UpdateBlockMsg) error {
+	wss.mu.Lock()
+	defer wss.mu.Unlock()
+
+	if wss.batchWindow <= 0 {
+		return wss.conn.WriteJSON(message)
+	}
+
+	for i, pending := range wss.pendingBlocks {
+		if pending.TeamID == message.TeamID && pending.Block.ID == message.Block.ID {
+			wss.pendingBlocks[i] = message
+			return nil
+		}
+	}
+	wss.pendingBlocks = append(wss.pendingBlocks, message)
+
+	if wss.batchTimer == nil {
+		wss.batchTimer = time.AfterFunc(wss.batchWindow, wss.flushBlockChanges)
+	}
+	return nil
+}
+
+// flushBlockChanges sends the batched block changes at the end of the
+// batch window.
+func (wss *websocketSession) flushBlockChanges() {
+	wss.mu.Lock()
+	defer wss.mu.Unlock()
+
+	if err := wss.flushBlockChangesLocked(); err != nil {
+		wss.conn.Close()
+	}
+}
+
+// flushBlockChangesLocked sends the batched block changes, a single one as
+// an UPDATE_BLOCK message and several ones of a team as an UPDATE_BLOCKS
+// message. It is also called before any other message is sent to the
+// client, so that the messages keep their order. The caller must hold the
+// session lock.
+func (wss *websocketSession) flushBlockChangesLocked() error {
+	if wss.batchTimer != nil {
+		wss.batchTimer.Stop()
+		wss.batchTimer = nil
+	}
+
+	pending := wss.pendingBlocks
+	wss.pendingBlocks = nil
+
+	for len(pending) > 0 {
+		count := 1
+		for count < len(pending) && pending[count].TeamID == pending[0].TeamID {
+			count++
+		}
+
+		var message interface{} = pending[0]
+		if count > 1 {
+			blocks := make([]model.Block, 0, count)
+			for _, m := range pending[:count] {
+				blocks = append(blocks, m.Block)
+			}
+			message = UpdateBlocksMsg{
+				Action: websocketActionUpdateBlocks,
+				TeamID: pending[0].TeamID,
+				Blocks: blocks,
+			}
+		}
+
+		if err := wss.conn.WriteJSON(message); err != nil {
+			return err
+		}
+		pending = pending[count:]
+	}
+	return nil
+}
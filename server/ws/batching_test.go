@@ -0,0 +1,94 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockChangeBatching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := wsMocks.NewMockStore(ctrl)
+	store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{{BoardID: "board-id", UserID: "user-id"}}, nil).AnyTimes()
+
+	server := NewServer(&auth.Auth{}, "", false, mlog.CreateConsoleTestLogger(true, mlog.LvlDebug), store)
+
+	sessions := make(chan *websocketSession)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := server.upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		sessions <- &websocketSession{conn: conn, userID: "user-id", batchWindow: 100 * time.Millisecond}
+	}))
+	defer httpServer.Close()
+
+	dialer := websocket.Dialer{EnableCompression: true}
+	client, resp, err := dialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+
+	session := <-sessions
+	server.addListener(session)
+	server.subscribeListenerToTeam(session, "team-id")
+
+	card := func(id string) model.Block {
+		return model.Block{ID: id, BoardID: "board-id", ParentID: "board-id", Type: model.TypeCard}
+	}
+
+	t.Run("permessage-deflate is negotiated", func(t *testing.T) {
+		require.Contains(t, resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate")
+	})
+
+	t.Run("the changes of a bulk update take a single frame", func(t *testing.T) {
+		server.BroadcastBlockChange("team-id", card("card-1"))
+		server.BroadcastBlockChange("team-id", card("card-2"))
+		updated := card("card-1")
+		updated.Title = "updated"
+		server.BroadcastBlockChange("team-id", updated)
+
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var message UpdateBlocksMsg
+		require.NoError(t, client.ReadJSON(&message))
+		require.Equal(t, websocketActionUpdateBlocks, message.Action)
+		require.Equal(t, "team-id", message.TeamID)
+		require.Len(t, message.Blocks, 2)
+		require.Equal(t, "card-1", message.Blocks[0].ID)
+		require.Equal(t, "updated", message.Blocks[0].Title)
+		require.Equal(t, "card-2", message.Blocks[1].ID)
+	})
+
+	t.Run("a single change is sent as before", func(t *testing.T) {
+		server.BroadcastBlockChange("team-id", card("card-3"))
+
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var message UpdateBlockMsg
+		require.NoError(t, client.ReadJSON(&message))
+		require.Equal(t, websocketActionUpdateBlock, message.Action)
+		require.Equal(t, "card-3", message.Block.ID)
+	})
+
+	t.Run("the batch is sent before other messages", func(t *testing.T) {
+		server.BroadcastBlockChange("team-id", card("card-4"))
+		server.BroadcastBoardLimitsChange(model.BoardLimits{CardLimit: 42})
+
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(50*time.Millisecond)))
+		var blockMessage UpdateBlockMsg
+		require.NoError(t, client.ReadJSON(&blockMessage))
+		require.Equal(t, "card-4", blockMessage.Block.ID)
+
+		var limitsMessage UpdateBoardLimitsMsg
+		require.NoError(t, client.ReadJSON(&limitsMessage))
+		require.Equal(t, websocketActionUpdateBoardLimits, limitsMessage.Action)
+	})
+}
@@ -0,0 +1,80 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// maxBlockSubtreeDepth bounds the ancestors looked up to route a block
+// change to the clients subscribed to a block above it.
+const maxBlockSubtreeDepth = 8
+
+// canSubscribeToBlocks returns true if the session can receive the
+// changes of the blocks of the command. Clients of shared boards need a
+// valid read token, authenticated users need to be able to view the
+// boards of the blocks.
+func (ws *Server) canSubscribeToBlocks(wsSession *websocketSession, command WebsocketCommand) bool {
+	if command.ReadToken != "" || !wsSession.isAuthenticated() {
+		return ws.isCommandReadTokenValid(wsSession, command)
+	}
+
+	viewableBoards := map[string]bool{}
+	for _, blockID := range command.BlockIDs {
+		block, err := ws.store.GetBlock(blockID)
+		if err != nil {
+			return false
+		}
+		if viewableBoards[block.BoardID] {
+			continue
+		}
+
+		board, err := ws.store.GetBoard(block.BoardID)
+		if err != nil {
+			return false
+		}
+		if !ws.canViewBoard(wsSession.userID, board) {
+			return false
+		}
+		viewableBoards[block.BoardID] = true
+	}
+	return true
+}
+
+// getBlockIDsToNotify returns the ids of the block and of its ancestors,
+// as the clients subscribed to a block, like a card, receive the changes
+// of its whole subtree. The ancestors above the parent are only looked up
+// while some clients are subscribed to blocks.
+func (ws *Server) getBlockIDsToNotify(block model.Block) []string {
+	blockIDs := []string{block.ID, block.ParentID}
+	if len(ws.listenersByBlock) == 0 {
+		return blockIDs
+	}
+
+	parentID := block.ParentID
+	for depth := 0; depth < maxBlockSubtreeDepth; depth++ {
+		if parentID == "" || parentID == block.BoardID {
+			break
+		}
+		parent, err := ws.store.GetBlock(parentID)
+		if err != nil || parent == nil {
+			// the parent was deleted along with the block
+			break
+		}
+		parentID = parent.ParentID
+		blockIDs = append(blockIDs, parentID)
+	}
+	return blockIDs
+}
+
+// uniqueListeners returns the listeners without the duplicates, in their
+// original order.
+func uniqueListeners(listeners []*websocketSession) []*websocketSession {
+	seen := make(map[*websocketSession]bool, len(listeners))
+	unique := []*websocketSession{}
+	for _, listener := range listeners {
+		if !seen[listener] {
+			seen[listener] = true
+			unique = append(unique, listener)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,123 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanSubscribeToBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := wsMocks.NewMockStore(ctrl)
+	server := NewServer(&auth.Auth{}, "token", false, mlog.CreateConsoleTestLogger(true, mlog.LvlDebug), store)
+
+	card := &model.Block{ID: "card-id", BoardID: "board-id", ParentID: "board-id", Type: model.TypeCard}
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypeOpen}
+	command := WebsocketCommand{Action: websocketActionSubscribeBlocks, TeamID: "team-id", BlockIDs: []string{"card-id"}}
+
+	t.Run("users who can view the board", func(t *testing.T) {
+		store.EXPECT().GetBlock("card-id").Return(card, nil)
+		store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+		session := &websocketSession{userID: model.SingleUser}
+		require.True(t, server.canSubscribeToBlocks(session, command))
+	})
+
+	t.Run("users who can't view the board", func(t *testing.T) {
+		store.EXPECT().GetBlock("card-id").Return(card, nil)
+		store.EXPECT().GetBoard("board-id").Return(board, nil)
+
+		session := &websocketSession{userID: "other-user-id"}
+		require.False(t, server.canSubscribeToBlocks(session, command))
+	})
+
+	t.Run("unknown blocks", func(t *testing.T) {
+		store.EXPECT().GetBlock("unknown").Return(nil, model.NewErrNotFound("unknown"))
+
+		session := &websocketSession{userID: model.SingleUser}
+		unknown := WebsocketCommand{Action: websocketActionSubscribeBlocks, TeamID: "team-id", BlockIDs: []string{"unknown"}}
+		require.False(t, server.canSubscribeToBlocks(session, unknown))
+	})
+}
+
+func TestCardSubtreeSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := wsMocks.NewMockStore(ctrl)
+	server := NewServer(&auth.Auth{}, "token", false, mlog.CreateConsoleTestLogger(true, mlog.LvlDebug), store)
+
+	card1 := &model.Block{ID: "card-1", BoardID: "board-id", ParentID: "board-id", Type: model.TypeCard}
+	card2 := &model.Block{ID: "card-2", BoardID: "board-id", ParentID: "board-id", Type: model.TypeCard}
+	store.EXPECT().GetBlock("card-1").Return(card1, nil).AnyTimes()
+	store.EXPECT().GetBlock("card-2").Return(card2, nil).AnyTimes()
+	store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	sessions := make(chan *websocketSession)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := server.upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		sessions <- &websocketSession{conn: conn, userID: model.SingleUser}
+	}))
+	defer httpServer.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+
+	session := <-sessions
+	server.addListener(session)
+	server.subscribeListenerToBlocks(session, []string{"card-1"})
+
+	readBlockChange := func(t *testing.T) (UpdateBlockMsg, error) {
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+		var message UpdateBlockMsg
+		err := client.ReadJSON(&message)
+		return message, err
+	}
+
+	t.Run("the changes of the card subtree are received", func(t *testing.T) {
+		server.BroadcastBlockChange("team-id", *card1)
+		message, err := readBlockChange(t)
+		require.NoError(t, err)
+		require.Equal(t, "card-1", message.Block.ID)
+
+		server.BroadcastBlockChange("team-id", model.Block{ID: "text-1", BoardID: "board-id", ParentID: "card-1", Type: model.TypeText})
+		message, err = readBlockChange(t)
+		require.NoError(t, err)
+		require.Equal(t, "text-1", message.Block.ID)
+	})
+
+	t.Run("the deletions of the card subtree are received", func(t *testing.T) {
+		server.BroadcastBlockDelete("team-id", "text-1", "board-id", "card-1")
+		message, err := readBlockChange(t)
+		require.NoError(t, err)
+		require.Equal(t, "text-1", message.Block.ID)
+		require.NotZero(t, message.Block.DeleteAt)
+	})
+
+	t.Run("the changes of other cards are not received", func(t *testing.T) {
+		server.BroadcastBlockChange("team-id", *card2)
+		server.BroadcastBlockChange("team-id", model.Block{ID: "text-2", BoardID: "board-id", ParentID: "card-2", Type: model.TypeText})
+		_, err := readBlockChange(t)
+		require.Error(t, err)
+	})
+}
+
+func TestUniqueListeners(t *testing.T) {
+	session1 := &websocketSession{userID: "user-1"}
+	session2 := &websocketSession{userID: "user-2"}
+
+	listeners := uniqueListeners([]*websocketSession{session1, session2, session1})
+	require.Equal(t, []*websocketSession{session1, session2}, listeners)
+}
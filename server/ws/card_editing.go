@@ -0,0 +1,96 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	cardEditingFieldTitle       = "title"
+	cardEditingFieldDescription = "description"
+)
+
+// startEditingCard announces to the other clients of the board that the
+// user of the session is editing a field of the card. The announcement is
+// not stored; the session stops editing the card when it starts editing
+// another one or disconnects.
+func (ws *Server) startEditingCard(wsSession *websocketSession, command WebsocketCommand) {
+	if command.Field != cardEditingFieldTitle && command.Field != cardEditingFieldDescription {
+		ws.logger.Error("invalid card editing field", mlog.String("field", command.Field))
+		return
+	}
+	if wsSession.editingCardID == command.BlockID && wsSession.editingField == command.Field {
+		return
+	}
+
+	card, err := ws.store.GetBlock(command.BlockID)
+	if err != nil || card == nil {
+		ws.logger.Error("cannot get card to announce editing", mlog.String("blockID", command.BlockID), mlog.Err(err))
+		return
+	}
+	if card.Type != model.TypeCard {
+		ws.logger.Error("editing can only be announced for cards", mlog.String("blockID", card.ID))
+		return
+	}
+	if !ws.canEditText(wsSession.userID, card) {
+		ws.logger.Error("WS user can't edit the card",
+			mlog.String("blockID", card.ID),
+			mlog.String("userID", wsSession.userID),
+		)
+		return
+	}
+
+	ws.stopEditingCard(wsSession)
+
+	wsSession.editingCardID = card.ID
+	wsSession.editingField = command.Field
+	ws.broadcastCardEditing(wsSession, card, command.Field, true)
+}
+
+// stopEditingCard announces that the user of the session stopped editing
+// its card, if any.
+func (ws *Server) stopEditingCard(wsSession *websocketSession) {
+	if wsSession.editingCardID == "" {
+		return
+	}
+
+	cardID, field := wsSession.editingCardID, wsSession.editingField
+	wsSession.editingCardID = ""
+	wsSession.editingField = ""
+
+	card, err := ws.store.GetBlock(cardID)
+	if err != nil || card == nil {
+		// the card may have been deleted while it was edited
+		ws.logger.Debug("cannot get card to announce the end of editing", mlog.String("blockID", cardID), mlog.Err(err))
+		return
+	}
+	ws.broadcastCardEditing(wsSession, card, field, false)
+}
+
+func (ws *Server) broadcastCardEditing(wsSession *websocketSession, card *model.Block, field string, editing bool) {
+	board, err := ws.store.GetBoard(card.BoardID)
+	if err != nil {
+		ws.logger.Error("cannot get board to announce card editing", mlog.String("boardID", card.BoardID), mlog.Err(err))
+		return
+	}
+
+	message := CardEditingMsg{
+		Action:  websocketActionCardEditing,
+		TeamID:  board.TeamID,
+		BoardID: board.ID,
+		CardID:  card.ID,
+		UserID:  wsSession.userID,
+		Field:   field,
+		Editing: editing,
+	}
+	for _, listener := range ws.getListenersForText(board.TeamID, card) {
+		if listener == wsSession {
+			continue
+		}
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("broadcast card editing error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
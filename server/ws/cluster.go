@@ -0,0 +1,241 @@
+package ws
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/redis"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	clusterEventBlockChange         = "blockChange"
+	clusterEventBoardChange         = "boardChange"
+	clusterEventMemberChange        = "memberChange"
+	clusterEventMemberDelete        = "memberDelete"
+	clusterEventConfigChange        = "configChange"
+	clusterEventCategoryChange      = "categoryChange"
+	clusterEventCategoryBoardChange = "categoryBoardChange"
+	clusterEventBoardLimitsChange   = "boardLimitsChange"
+	clusterEventTextCommand         = "textCommand"
+	clusterEventTextMessage         = "textMessage"
+	clusterEventTextBroadcast       = "textBroadcast"
+	clusterEventCRDTTextUpdate      = "crdtTextUpdate"
+
+	clusterResubscribeDelay = 5 * time.Second
+)
+
+// clusterEvent is a broadcast published for the other nodes, which send it
+// to their own clients. The events of the collaborative text operations
+// are addressed to a single node when ToNodeID is set: the commands are
+// run by the node owning their block, and the replies are sent to the
+// session of the client that sent them. The CRDT text updates go to all
+// the nodes, which relay them to their clients editing the block.
+type clusterEvent struct {
+	NodeID        string                            `json:"nodeId"`
+	Type          string                            `json:"type"`
+	ToNodeID      string                            `json:"toNodeId,omitempty"`
+	SessionID     string                            `json:"sessionId,omitempty"`
+	TeamID        string                            `json:"teamId,omitempty"`
+	BoardID       string                            `json:"boardId,omitempty"`
+	UserID        string                            `json:"userId,omitempty"`
+	Block         *model.Block                      `json:"block,omitempty"`
+	Board         *model.Board                      `json:"board,omitempty"`
+	Member        *model.BoardMember                `json:"member,omitempty"`
+	ClientConfig  *model.ClientConfig               `json:"clientConfig,omitempty"`
+	Category      *model.Category                   `json:"category,omitempty"`
+	BoardCategory *model.BoardCategoryWebsocketData `json:"boardCategory,omitempty"`
+	BoardLimits   *model.BoardLimits                `json:"boardLimits,omitempty"`
+	TextCommand   *textCommand                      `json:"textCommand,omitempty"`
+	TextMessage   *UpdateTextMsg                    `json:"textMessage,omitempty"`
+	CRDTText      *CRDTTextUpdateMsg                `json:"crdtText,omitempty"`
+}
+
+// StartCluster shares the broadcasts of the server with the other nodes
+// subscribed to the Redis channel, so that the clients connected to any
+// node receive the changes. The owners of the text blocks edited
+// collaboratively are kept in Redis too, under keys prefixed by the
+// channel.
+func (ws *Server) StartCluster(opts redis.Options, channel string) error {
+	pubsub, err := redis.Subscribe(opts, channel)
+	if err != nil {
+		return err
+	}
+
+	ws.clusterMu.Lock()
+	defer ws.clusterMu.Unlock()
+
+	ws.clusterClient = redis.NewClient(opts)
+	ws.clusterChannel = channel
+	ws.clusterPubSub = pubsub
+	ws.clusterDone = make(chan struct{})
+	ws.textOwners.start(ws.clusterClient, channel+":text:", ws.textEditor.drop)
+
+	go ws.receiveClusterEvents(opts, channel, pubsub, ws.clusterDone)
+
+	ws.logger.Info("Websocket cluster started", mlog.String("channel", channel), mlog.String("nodeID", ws.nodeID))
+	return nil
+}
+
+// StopCluster stops sharing the broadcasts with the other nodes.
+func (ws *Server) StopCluster() {
+	ws.clusterMu.Lock()
+	defer ws.clusterMu.Unlock()
+
+	if ws.clusterClient == nil {
+		return
+	}
+
+	close(ws.clusterDone)
+	ws.textOwners.stop()
+	if err := ws.clusterPubSub.Close(); err != nil {
+		ws.logger.Warn("Cannot close the websocket cluster subscription", mlog.Err(err))
+	}
+	if err := ws.clusterClient.Close(); err != nil {
+		ws.logger.Warn("Cannot close the websocket cluster client", mlog.Err(err))
+	}
+	ws.clusterClient = nil
+	ws.clusterPubSub = nil
+}
+
+// publishClusterEvent publishes a broadcast already sent to the clients of
+// this node for the other nodes. It does nothing without a cluster.
+func (ws *Server) publishClusterEvent(event *clusterEvent) {
+	ws.clusterMu.Lock()
+	client, channel := ws.clusterClient, ws.clusterChannel
+	ws.clusterMu.Unlock()
+
+	if client == nil {
+		return
+	}
+
+	event.NodeID = ws.nodeID
+	data, err := json.Marshal(event)
+	if err != nil {
+		ws.logger.Error("Cannot marshal the websocket cluster event", mlog.String("type", event.Type), mlog.Err(err))
+		return
+	}
+
+	if _, err := client.Publish(channel, data); err != nil {
+		ws.logger.Error("Cannot publish the websocket cluster event", mlog.String("type", event.Type), mlog.Err(err))
+	}
+}
+
+// receiveClusterEvents sends the broadcasts of the other nodes to the
+// clients of this node until the cluster is stopped, subscribing again
+// when the connection to Redis is lost.
+func (ws *Server) receiveClusterEvents(opts redis.Options, channel string, pubsub *redis.PubSub, done chan struct{}) {
+	for {
+		message, err := pubsub.Receive()
+		if err == nil {
+			ws.handleClusterMessage(message.Data)
+			continue
+		}
+
+		select {
+		case <-done:
+			return
+		default:
+		}
+		ws.logger.Warn("Websocket cluster subscription lost", mlog.Err(err))
+
+		if pubsub = ws.resubscribeCluster(opts, channel, done); pubsub == nil {
+			return
+		}
+	}
+}
+
+// resubscribeCluster subscribes to the channel again, retrying until it
+// succeeds or the cluster is stopped, in which case it returns nil.
+func (ws *Server) resubscribeCluster(opts redis.Options, channel string, done chan struct{}) *redis.PubSub {
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-time.After(clusterResubscribeDelay):
+		}
+
+		pubsub, err := redis.Subscribe(opts, channel)
+		if err != nil {
+			ws.logger.Warn("Cannot subscribe to the websocket cluster", mlog.Err(err))
+			continue
+		}
+
+		ws.clusterMu.Lock()
+		select {
+		case <-done:
+			ws.clusterMu.Unlock()
+			_ = pubsub.Close()
+			return nil
+		default:
+		}
+		ws.clusterPubSub = pubsub
+		ws.clusterMu.Unlock()
+		return pubsub
+	}
+}
+
+func (ws *Server) handleClusterMessage(data []byte) {
+	var event clusterEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		ws.logger.Error("Cannot unmarshal the websocket cluster event", mlog.Err(err))
+		return
+	}
+
+	if event.NodeID == ws.nodeID || (event.ToNodeID != "" && event.ToNodeID != ws.nodeID) {
+		return
+	}
+
+	switch event.Type {
+	case clusterEventBlockChange:
+		if event.Block != nil {
+			ws.broadcastBlockChange(event.TeamID, *event.Block)
+		}
+	case clusterEventBoardChange:
+		if event.Board != nil {
+			ws.broadcastBoardChange(event.TeamID, event.Board)
+		}
+	case clusterEventMemberChange:
+		if event.Member != nil {
+			ws.broadcastMemberChange(event.TeamID, event.BoardID, event.Member)
+		}
+	case clusterEventMemberDelete:
+		ws.broadcastMemberDelete(event.TeamID, event.BoardID, event.UserID)
+	case clusterEventConfigChange:
+		if event.ClientConfig != nil {
+			ws.broadcastConfigChange(*event.ClientConfig)
+		}
+	case clusterEventCategoryChange:
+		if event.Category != nil {
+			ws.broadcastCategoryChange(*event.Category)
+		}
+	case clusterEventCategoryBoardChange:
+		if event.BoardCategory != nil {
+			ws.broadcastCategoryBoardChange(event.TeamID, event.UserID, *event.BoardCategory)
+		}
+	case clusterEventBoardLimitsChange:
+		if event.BoardLimits != nil {
+			ws.broadcastBoardLimitsChange(*event.BoardLimits)
+		}
+	case clusterEventTextCommand:
+		if event.TextCommand != nil {
+			ws.handleClusterTextCommand(event.TextCommand)
+		}
+	case clusterEventTextMessage:
+		if event.TextMessage != nil {
+			ws.sendTextMessage(ws.nodeID, event.SessionID, *event.TextMessage)
+		}
+	case clusterEventTextBroadcast:
+		if event.Block != nil && event.TextMessage != nil {
+			ws.sendTextMessageToListeners(event.TeamID, event.Block, *event.TextMessage, event.SessionID)
+		}
+	case clusterEventCRDTTextUpdate:
+		if event.CRDTText != nil {
+			ws.receiveCRDTTextUpdate(*event.CRDTText)
+		}
+	default:
+		ws.logger.Warn("Unknown websocket cluster event", mlog.String("type", event.Type))
+	}
+}
@@ -0,0 +1,361 @@
+package ws
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/redis"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis implements the SUBSCRIBE and PUBLISH commands of Redis for a
+// single channel, and runs the scripts keeping the owners of the text
+// blocks.
+type fakeRedis struct {
+	listener    net.Listener
+	mu          sync.Mutex
+	subscribers []net.Conn
+	values      map[string]string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	fr := &fakeRedis{listener: listener, values: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go fr.serve(conn)
+		}
+	}()
+	return fr
+}
+
+func (fr *fakeRedis) serve(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readCommand(reader)
+		if err != nil {
+			conn.Close()
+			return
+		}
+
+		fr.mu.Lock()
+		switch strings.ToUpper(args[0]) {
+		case "SUBSCRIBE":
+			fr.subscribers = append(fr.subscribers, conn)
+			fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n$%d\r\n%s\r\n:1\r\n", len(args[1]), args[1])
+		case "PUBLISH":
+			for _, subscriber := range fr.subscribers {
+				fmt.Fprintf(subscriber, "*3\r\n$7\r\nmessage\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(args[1]), args[1], len(args[2]), args[2])
+			}
+			fmt.Fprintf(conn, ":%d\r\n", len(fr.subscribers))
+		case "EVAL":
+			fr.eval(conn, args[1], args[3], args[4])
+		}
+		fr.mu.Unlock()
+	}
+}
+
+func (fr *fakeRedis) eval(conn net.Conn, script, key, nodeID string) {
+	owner, ok := fr.values[key]
+	switch script {
+	case textClaimScript:
+		if !ok {
+			owner = nodeID
+			fr.values[key] = owner
+		}
+		fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(owner), owner)
+	case textReleaseScript:
+		if ok && owner == nodeID {
+			delete(fr.values, key)
+			fmt.Fprint(conn, ":1\r\n")
+			return
+		}
+		fmt.Fprint(conn, ":0\r\n")
+	default:
+		fmt.Fprint(conn, "-ERR unknown script\r\n")
+	}
+}
+
+func (fr *fakeRedis) Close() {
+	fr.listener.Close()
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	for _, subscriber := range fr.subscribers {
+		subscriber.Close()
+	}
+}
+
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	var count int
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &count); err != nil {
+		return nil, err
+	}
+	args := make([]string, count)
+	for i := range args {
+		var length int
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &length); err != nil {
+			return nil, err
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:length])
+	}
+	return args, nil
+}
+
+// connectListener adds a listener to the server and returns the client end
+// of its connection.
+func connectListener(t *testing.T, server *Server) *websocket.Conn {
+	sessions := make(chan *websocketSession)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := server.upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		sessions <- &websocketSession{conn: conn}
+	}))
+	t.Cleanup(httpServer.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	server.addListener(<-sessions)
+	return client
+}
+
+// connectTextEditor adds a listener of the single user, following the
+// block, to the server and returns the client end of its connection.
+func connectTextEditor(t *testing.T, server *Server, sessionID, blockID string) *websocket.Conn {
+	sessions := make(chan *websocketSession)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := server.upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		sessions <- &websocketSession{id: sessionID, conn: conn, userID: model.SingleUser}
+	}))
+	t.Cleanup(httpServer.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	session := <-sessions
+	server.addListener(session)
+	server.subscribeListenerToBlocks(session, []string{blockID})
+	return client
+}
+
+func TestCluster(t *testing.T) {
+	fr := newFakeRedis(t)
+	defer fr.Close()
+
+	opts := redis.Options{Address: fr.listener.Addr().String()}
+	logger := mlog.CreateConsoleTestLogger(true, mlog.LvlDebug)
+
+	node1 := NewServer(&auth.Auth{}, "", false, logger, nil)
+	require.NoError(t, node1.StartCluster(opts, "focalboard:ws"))
+	defer node1.StopCluster()
+
+	node2 := NewServer(&auth.Auth{}, "", false, logger, nil)
+	require.NoError(t, node2.StartCluster(opts, "focalboard:ws"))
+	defer node2.StopCluster()
+
+	client1 := connectListener(t, node1)
+	client2 := connectListener(t, node2)
+
+	t.Run("the broadcasts reach the clients of all the nodes", func(t *testing.T) {
+		node1.BroadcastBoardLimitsChange(model.BoardLimits{CardLimit: 42})
+
+		for _, client := range []*websocket.Conn{client1, client2} {
+			require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+			var message UpdateBoardLimitsMsg
+			require.NoError(t, client.ReadJSON(&message))
+			require.Equal(t, websocketActionUpdateBoardLimits, message.Action)
+			require.Equal(t, 42, message.BoardLimits.CardLimit)
+		}
+	})
+
+	t.Run("the events published by the node itself are ignored", func(t *testing.T) {
+		node1.handleClusterMessage([]byte(`{"nodeId":"` + node1.nodeID + `","type":"boardLimitsChange","boardLimits":{"cardLimit":1}}`))
+		node2.handleClusterMessage([]byte(`{"nodeId":"` + node1.nodeID + `","type":"boardLimitsChange","boardLimits":{"cardLimit":2}}`))
+
+		require.NoError(t, client2.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var message UpdateBoardLimitsMsg
+		require.NoError(t, client2.ReadJSON(&message))
+		require.Equal(t, 2, message.BoardLimits.CardLimit)
+
+		require.NoError(t, client1.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+		require.Error(t, client1.ReadJSON(&message))
+	})
+}
+
+func TestTextCluster(t *testing.T) {
+	fr := newFakeRedis(t)
+	defer fr.Close()
+
+	opts := redis.Options{Address: fr.listener.Addr().String()}
+	logger := mlog.CreateConsoleTestLogger(true, mlog.LvlDebug)
+
+	block := &model.Block{ID: "block-id", BoardID: "board-id", Type: model.TypeText, Title: "hello"}
+	ctrl := gomock.NewController(t)
+	store := wsMocks.NewMockStore(ctrl)
+	store.EXPECT().GetBlock("block-id").Return(block, nil).AnyTimes()
+	store.EXPECT().GetBoard("board-id").Return(&model.Board{ID: "board-id", TeamID: "team-id"}, nil).AnyTimes()
+	store.EXPECT().GetMembersForBoard("board-id").Return([]*model.BoardMember{}, nil).AnyTimes()
+
+	node1 := NewServer(&auth.Auth{}, "token", false, logger, store)
+	node1.textEditor.persistDelay = time.Hour
+	require.NoError(t, node1.StartCluster(opts, "focalboard:ws"))
+	defer node1.StopCluster()
+
+	node2 := NewServer(&auth.Auth{}, "token", false, logger, store)
+	node2.textEditor.persistDelay = time.Hour
+	require.NoError(t, node2.StartCluster(opts, "focalboard:ws"))
+	defer node2.StopCluster()
+
+	client1 := connectTextEditor(t, node1, "session-1", "block-id")
+	client2 := connectTextEditor(t, node2, "session-2", "block-id")
+	require.NoError(t, client1.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, client2.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	// the second node loads the document and owns the block
+	node2.handleTextCommand(node2.getListenerByID("session-2"), WebsocketCommand{Action: websocketActionSyncText, BlockID: "block-id"})
+	var resync UpdateTextMsg
+	require.NoError(t, client2.ReadJSON(&resync))
+	require.Equal(t, websocketActionResyncText, resync.Action)
+	require.Equal(t, "hello", *resync.Text)
+
+	// the operation of the client of the first node is applied by the
+	// second one
+	node1.handleTextCommand(node1.getListenerByID("session-1"), WebsocketCommand{
+		Action:    websocketActionEditText,
+		BlockID:   "block-id",
+		Revision:  resync.Revision,
+		Operation: model.TextOperation{{Retain: 5}, {Insert: "!"}},
+	})
+
+	var ack UpdateTextMsg
+	require.NoError(t, client1.ReadJSON(&ack))
+	require.Equal(t, websocketActionAckText, ack.Action)
+	require.Equal(t, resync.Revision+1, ack.Revision)
+
+	var update UpdateTextMsg
+	require.NoError(t, client2.ReadJSON(&update))
+	require.Equal(t, websocketActionUpdateText, update.Action)
+	require.Equal(t, resync.Revision+1, update.Revision)
+	require.Equal(t, model.TextOperation{{Retain: 5}, {Insert: "!"}}, update.Operation)
+
+	text, _, err := node2.textEditor.snapshot(block)
+	require.NoError(t, err)
+	require.Equal(t, "hello!", text)
+
+	node1.textEditor.mu.Lock()
+	require.Empty(t, node1.textEditor.documents)
+	node1.textEditor.mu.Unlock()
+
+	// the client doesn't get its own operation back
+	require.NoError(t, client1.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	require.Error(t, client1.ReadJSON(&update))
+}
+
+func TestCRDTTextCluster(t *testing.T) {
+	fr := newFakeRedis(t)
+	defer fr.Close()
+
+	opts := redis.Options{Address: fr.listener.Addr().String()}
+	logger := mlog.CreateConsoleTestLogger(true, mlog.LvlDebug)
+
+	block := &model.Block{ID: "block-id", BoardID: "board-id", Type: model.TypeText, Title: "hello", UpdateAt: 100}
+	ctrl := gomock.NewController(t)
+	store := wsMocks.NewMockStore(ctrl)
+	store.EXPECT().GetBlock("block-id").Return(block, nil).AnyTimes()
+
+	node1 := NewServer(&auth.Auth{}, "token", false, logger, store)
+	node1.crdtText.persistDelay = time.Hour
+	require.NoError(t, node1.StartCluster(opts, "focalboard:ws"))
+	defer node1.StopCluster()
+
+	node2 := NewServer(&auth.Auth{}, "token", false, logger, store)
+	node2.crdtText.persistDelay = time.Hour
+	require.NoError(t, node2.StartCluster(opts, "focalboard:ws"))
+	defer node2.StopCluster()
+
+	client1 := connectTextEditor(t, node1, "session-1", "block-id")
+	client2 := connectTextEditor(t, node2, "session-2", "block-id")
+	require.NoError(t, client1.SetReadDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, client2.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	// each node loads its copy of the document
+	for _, peer := range []struct {
+		node   *Server
+		client *websocket.Conn
+		id     string
+	}{{node1, client1, "session-1"}, {node2, client2, "session-2"}} {
+		peer.node.handleCRDTTextCommand(peer.node.getListenerByID(peer.id), WebsocketCommand{Action: websocketActionJoinCRDTText, BlockID: "block-id"})
+		var sync CRDTTextSyncMsg
+		require.NoError(t, peer.client.ReadJSON(&sync))
+		require.Equal(t, websocketActionSyncCRDTText, sync.Action)
+		require.Equal(t, int64(100), sync.Generation)
+		require.Equal(t, "hello", sync.Seed)
+	}
+
+	// the update of the client of the first node reaches the client of the
+	// second one
+	node1.handleCRDTTextCommand(node1.getListenerByID("session-1"), WebsocketCommand{
+		Action:     websocketActionUpdateCRDTText,
+		BlockID:    "block-id",
+		Generation: 100,
+		Update:     []byte{1, 2},
+	})
+
+	var ack CRDTTextUpdateMsg
+	require.NoError(t, client1.ReadJSON(&ack))
+	require.Equal(t, websocketActionAckCRDTText, ack.Action)
+	require.Equal(t, model.NewTextCRDTUpdate([]byte{1, 2}).ID, ack.UpdateID)
+
+	var update CRDTTextUpdateMsg
+	require.NoError(t, client2.ReadJSON(&update))
+	require.Equal(t, websocketActionUpdateCRDTText, update.Action)
+	require.Equal(t, ack.UpdateID, update.UpdateID)
+	require.Equal(t, []byte{1, 2}, update.Update)
+	require.Equal(t, model.SingleUser, update.UserID)
+
+	// the copy of the second node has the update, which the first node
+	// saves
+	sync, err := node2.crdtText.sync("block-id", node2.getListenerByID("session-2"))
+	require.NoError(t, err)
+	require.Equal(t, []model.TextCRDTUpdate{model.NewTextCRDTUpdate([]byte{1, 2})}, sync.Updates)
+
+	node1.crdtText.mu.Lock()
+	require.True(t, node1.crdtText.documents["block-id"].dirty)
+	node1.crdtText.mu.Unlock()
+	node2.crdtText.mu.Lock()
+	require.False(t, node2.crdtText.documents["block-id"].dirty)
+	node2.crdtText.mu.Unlock()
+
+	// the client doesn't get its own update back
+	require.NoError(t, client1.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	require.Error(t, client1.ReadJSON(&update))
+}
@@ -39,11 +39,112 @@ type UpdateSubscription struct {
 	Subscription *model.Subscription `json:"subscription"`
 }
 
+// UpdateBoardLimitsMsg is sent when the card, view or attachment storage
+// limits change.
+type UpdateBoardLimitsMsg struct {
+	Action      string            `json:"action"`
+	BoardLimits model.BoardLimits `json:"boardLimits"`
+}
+
+// UpdatePresenceMsg is sent when a user opens or leaves a board, on their
+// first and last connection viewing it.
+type UpdatePresenceMsg struct {
+	Action  string `json:"action"`
+	TeamID  string `json:"teamId"`
+	BoardID string `json:"boardId"`
+	UserID  string `json:"userId"`
+}
+
+// CardEditingMsg is sent when a user starts or stops editing the title
+// or the description of a card, so that the other clients can show it.
+type CardEditingMsg struct {
+	Action  string `json:"action"`
+	TeamID  string `json:"teamId"`
+	BoardID string `json:"boardId"`
+	CardID  string `json:"cardId"`
+	UserID  string `json:"userId"`
+	Field   string `json:"field"`
+	Editing bool   `json:"editing"`
+}
+
+// UpdateTextMsg is sent while a text block is edited collaboratively.
+// UPDATE_TEXT carries an operation of another user, ACK_TEXT confirms
+// the operation of the client and RESYNC_TEXT carries the whole text,
+// in reply to SYNC_TEXT or when the client revision can't be transformed
+// anymore. Clients send SYNC_TEXT before editing a block, to get the
+// revision to base their operations on, and apply the operations of the
+// other users in the order of their revisions.
+type UpdateTextMsg struct {
+	Action    string              `json:"action"`
+	BlockID   string              `json:"blockId"`
+	BoardID   string              `json:"boardId"`
+	Field     string              `json:"field"`
+	Revision  int64               `json:"revision"`
+	Operation model.TextOperation `json:"operation,omitempty"`
+	UserID    string              `json:"userId,omitempty"`
+	Text      *string             `json:"text,omitempty"`
+}
+
+// CRDTTextSyncMsg brings a client editing a text block with CRDT updates
+// up to date, in reply to JOIN_CRDT_TEXT, to an update of an older
+// generation, or when the document is seeded again after the title of the
+// block was changed through other means. The client replaces its document
+// with one seeded with Seed using the client id 0, so that the seeds of
+// all the clients merge as one, then applies the snapshot and the updates.
+type CRDTTextSyncMsg struct {
+	Action     string                 `json:"action"`
+	BlockID    string                 `json:"blockId"`
+	BoardID    string                 `json:"boardId"`
+	Generation int64                  `json:"generation"`
+	Seed       string                 `json:"seed"`
+	Snapshot   []byte                 `json:"snapshot,omitempty"`
+	Updates    []model.TextCRDTUpdate `json:"updates"`
+}
+
+// CRDTTextUpdateMsg is sent while a text block is edited with CRDT
+// updates. UPDATE_CRDT_TEXT carries an update of another user,
+// ACK_CRDT_TEXT confirms the update of the client with its ID and
+// REQUEST_CRDT_SNAPSHOT asks the client for SNAPSHOT_CRDT_TEXT: its
+// document merged in a single update, with the text it renders, which
+// becomes the title of the block, and the IDs of the updates it merged.
+// The updates are Yjs-compatible and may be received more than once, or
+// in another order than they were made.
+type CRDTTextUpdateMsg struct {
+	Action     string `json:"action"`
+	BlockID    string `json:"blockId"`
+	Generation int64  `json:"generation"`
+	UpdateID   string `json:"updateId,omitempty"`
+	Update     []byte `json:"update,omitempty"`
+	UserID     string `json:"userId,omitempty"`
+}
+
+// SyncBlocksMsg replies to a SYNC_SINCE command with the blocks of a board
+// changed since the last update the client saw, the deleted ones with their
+// DeleteAt set.
+type SyncBlocksMsg struct {
+	Action  string        `json:"action"`
+	TeamID  string        `json:"teamId"`
+	BoardID string        `json:"boardId"`
+	Since   int64         `json:"since"`
+	Blocks  []model.Block `json:"blocks"`
+}
+
 // WebsocketCommand is an incoming command from the client.
 type WebsocketCommand struct {
-	Action    string   `json:"action"`
-	TeamID    string   `json:"teamId"`
-	Token     string   `json:"token"`
-	ReadToken string   `json:"readToken"`
-	BlockIDs  []string `json:"blockIds"`
+	Action     string              `json:"action"`
+	TeamID     string              `json:"teamId"`
+	Token      string              `json:"token"`
+	ReadToken  string              `json:"readToken"`
+	BlockIDs   []string            `json:"blockIds"`
+	BlockID    string              `json:"blockId"`
+	BoardID    string              `json:"boardId"`
+	Field      string              `json:"field"`
+	Revision   int64               `json:"revision"`
+	Operation  model.TextOperation `json:"operation"`
+	Since      map[string]int64    `json:"since"`
+	Generation int64               `json:"generation"`
+	Update     []byte              `json:"update"`
+	Snapshot   []byte              `json:"snapshot"`
+	Text       string              `json:"text"`
+	Merged     []string            `json:"merged"`
 }
@@ -0,0 +1,107 @@
+package ws
+
+import (
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// SetCRDTTextPersister sets where the state of the text blocks edited
+// with CRDT updates is saved.
+func (ws *Server) SetCRDTTextPersister(persister CRDTTextPersister) {
+	ws.crdtText.setPersister(persister)
+}
+
+// handleCRDTTextCommand checks that the client can edit the text block
+// and runs its JOIN_CRDT_TEXT, UPDATE_CRDT_TEXT or SNAPSHOT_CRDT_TEXT
+// command.
+func (ws *Server) handleCRDTTextCommand(wsSession *websocketSession, command WebsocketCommand) {
+	block, err := ws.store.GetBlock(command.BlockID)
+	if err != nil || block == nil {
+		ws.logger.Error("cannot get block to edit its CRDT text", mlog.String("blockID", command.BlockID), mlog.Err(err))
+		return
+	}
+	if block.Type != model.TypeText {
+		ws.logger.Error("CRDT editing is only supported for text blocks",
+			mlog.String("blockID", block.ID),
+			mlog.String("type", string(block.Type)),
+		)
+		return
+	}
+
+	if !ws.canEditText(wsSession.userID, block) {
+		ws.logger.Error("WS user can't edit the text block",
+			mlog.String("blockID", block.ID),
+			mlog.String("userID", wsSession.userID),
+		)
+		ws.crdtText.leave(block.ID, wsSession)
+		return
+	}
+
+	switch command.Action {
+	case websocketActionJoinCRDTText:
+		ws.crdtText.send([]*websocketSession{wsSession}, ws.crdtText.join(block, wsSession))
+	case websocketActionUpdateCRDTText:
+		ws.updateCRDTText(wsSession, block, command)
+	case websocketActionSnapshotCRDTText:
+		err := ws.crdtText.mergeSnapshot(block.ID, wsSession, command.Generation, command.Snapshot, command.Text, command.Merged)
+		ws.handleCRDTTextError(wsSession, block.ID, err)
+	}
+}
+
+// updateCRDTText relays the update of the client to the other clients
+// editing the block, on all the nodes, and acknowledges it.
+func (ws *Server) updateCRDTText(wsSession *websocketSession, block *model.Block, command WebsocketCommand) {
+	update, peers, snapshotRequested, err := ws.crdtText.update(block.ID, wsSession, command.Generation, command.Update)
+	if err != nil {
+		ws.handleCRDTTextError(wsSession, block.ID, err)
+		return
+	}
+
+	message := CRDTTextUpdateMsg{
+		Action:     websocketActionUpdateCRDTText,
+		BlockID:    block.ID,
+		Generation: command.Generation,
+		UpdateID:   update.ID,
+		Update:     update.Data,
+		UserID:     wsSession.userID,
+	}
+	ws.crdtText.send(peers, message)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventCRDTTextUpdate, CRDTText: &message})
+
+	ws.crdtText.send([]*websocketSession{wsSession}, CRDTTextUpdateMsg{
+		Action:     websocketActionAckCRDTText,
+		BlockID:    block.ID,
+		Generation: command.Generation,
+		UpdateID:   update.ID,
+	})
+	if snapshotRequested {
+		ws.crdtText.requestSnapshot(wsSession, block.ID, command.Generation)
+	}
+}
+
+// handleCRDTTextError logs the error of a command, and brings the client
+// up to date if it edited an older generation of the document.
+func (ws *Server) handleCRDTTextError(wsSession *websocketSession, blockID string, err error) {
+	if err == nil {
+		return
+	}
+
+	ws.logger.Debug("rejected CRDT text command", mlog.String("blockID", blockID), mlog.Err(err))
+	if !errors.Is(err, errCRDTTextGenerationChanged) {
+		return
+	}
+	if message, err := ws.crdtText.sync(blockID, wsSession); err == nil {
+		ws.crdtText.send([]*websocketSession{wsSession}, message)
+	}
+}
+
+// receiveCRDTTextUpdate relays an update published by another node to
+// the clients of this node editing the block.
+func (ws *Server) receiveCRDTTextUpdate(message CRDTTextUpdateMsg) {
+	update := model.NewTextCRDTUpdate(message.Update)
+	message.UpdateID = update.ID
+	ws.crdtText.send(ws.crdtText.received(message.BlockID, message.Generation, update), message)
+}
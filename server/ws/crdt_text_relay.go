@@ -0,0 +1,582 @@
+package ws
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	crdtTextPersistDelay = 2 * time.Second
+
+	// crdtTextSnapshotThreshold is the number of updates received after
+	// the last snapshot from which the clients are asked for a new one.
+	crdtTextSnapshotThreshold = 100
+
+	maxCRDTTextUpdateSize = 1 << 20
+)
+
+var (
+	errCRDTTextNotJoined         = errors.New("the client didn't join the CRDT text")
+	errCRDTTextUpdateInvalid     = errors.New("the CRDT update is empty or too large")
+	errCRDTTextGenerationChanged = errors.New("the CRDT text was seeded again")
+)
+
+// CRDTTextPersister saves the state of the text blocks edited
+// collaboratively with CRDT updates.
+type CRDTTextPersister interface {
+	SaveBlockCRDTText(blockID, text string, state *model.TextCRDTState, userID string) error
+}
+
+// crdtTextDocument is the copy of the CRDT document of a text block kept
+// by a node while some of its clients edit the block. The text is the one
+// the document is saved with as the title of the block: the seed, or the
+// text of the last snapshot as rendered by the client that merged it,
+// which the server can't check, the updates not being decoded. Base is
+// the ID of the snapshot the snapshot was merged over and merged holds
+// the updates it replaced.
+type crdtTextDocument struct {
+	boardID    string
+	generation int64
+	seed       string
+	snapshot   []byte
+	base       string
+	text       string
+	updates    []model.TextCRDTUpdate
+	merged     []model.TextCRDTUpdate
+
+	peers []*websocketSession
+	// the peer that sent the last update, asked for a snapshot when the
+	// document is saved
+	lastAuthor *websocketSession
+
+	userID string
+	dirty  bool
+	timer  *time.Timer
+}
+
+// newCRDTTextDocument returns the document saved with the block or, if
+// there is none, or if the title of the block was changed through other
+// means since, a new one seeded with the title.
+func newCRDTTextDocument(block *model.Block) *crdtTextDocument {
+	doc := &crdtTextDocument{boardID: block.BoardID}
+	if state := model.TextCRDTStateFromBlock(block); state != nil {
+		doc.setState(state, block.Title)
+	} else {
+		doc.seedWith(block)
+	}
+	return doc
+}
+
+// seedWith starts a new generation of the document, seeded with the
+// title of the block. The generation is the time the block was updated
+// at, so all the nodes seed the same one from the same change.
+func (doc *crdtTextDocument) seedWith(block *model.Block) {
+	doc.generation = block.UpdateAt
+	doc.seed = block.Title
+	doc.snapshot = nil
+	doc.base = ""
+	doc.text = block.Title
+	doc.updates = nil
+	doc.merged = nil
+}
+
+func (doc *crdtTextDocument) setState(state *model.TextCRDTState, text string) {
+	doc.generation = state.Generation
+	doc.seed = state.Seed
+	doc.snapshot = state.Snapshot
+	doc.base = state.Base
+	doc.text = text
+	doc.updates = state.Updates
+	doc.merged = state.Merged
+}
+
+func (doc *crdtTextDocument) state() *model.TextCRDTState {
+	return &model.TextCRDTState{
+		Generation: doc.generation,
+		Seed:       doc.seed,
+		Snapshot:   doc.snapshot,
+		Base:       doc.base,
+		Updates:    append([]model.TextCRDTUpdate{}, doc.updates...),
+		Merged:     append([]model.TextCRDTUpdate{}, doc.merged...),
+		TextHash:   model.TextCRDTHash(doc.text),
+	}
+}
+
+// snapshotID returns the ID of the snapshot, empty if there is none.
+func (doc *crdtTextDocument) snapshotID() string {
+	if doc.snapshot == nil {
+		return ""
+	}
+	return model.NewTextCRDTUpdate(doc.snapshot).ID
+}
+
+// hasUpdate returns true if the update is in the document, on its own or
+// merged in the snapshot.
+func (doc *crdtTextDocument) hasUpdate(id string) bool {
+	return hasCRDTTextUpdate(doc.updates, id) || hasCRDTTextUpdate(doc.merged, id)
+}
+
+func (doc *crdtTextDocument) hasPeer(session *websocketSession) bool {
+	for _, peer := range doc.peers {
+		if peer == session {
+			return true
+		}
+	}
+	return false
+}
+
+func (doc *crdtTextDocument) otherPeers(session *websocketSession) []*websocketSession {
+	peers := make([]*websocketSession, 0, len(doc.peers))
+	for _, peer := range doc.peers {
+		if peer != session {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+func (doc *crdtTextDocument) syncMessage(blockID string) CRDTTextSyncMsg {
+	return CRDTTextSyncMsg{
+		Action:     websocketActionSyncCRDTText,
+		BlockID:    blockID,
+		BoardID:    doc.boardID,
+		Generation: doc.generation,
+		Seed:       doc.seed,
+		Snapshot:   doc.snapshot,
+		Updates:    append([]model.TextCRDTUpdate{}, doc.updates...),
+	}
+}
+
+func hasCRDTTextUpdate(updates []model.TextCRDTUpdate, id string) bool {
+	for _, update := range updates {
+		if update.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// crdtTextMessage is a message to send to some peers.
+type crdtTextMessage struct {
+	peers   []*websocketSession
+	message interface{}
+}
+
+// crdtTextRelay relays the CRDT updates of the clients editing the same
+// text blocks and saves their documents. Each node keeps a copy of the
+// documents its clients edit, the updates being published to the other
+// nodes, and saves the updates its own clients made. As updates can be
+// applied more than once and in any order, the copies merge the states
+// the other nodes save, so that an update missed by a node, or dropped by
+// a concurrent save, is saved again.
+type crdtTextRelay struct {
+	mu           sync.Mutex
+	persister    CRDTTextPersister
+	logger       *mlog.Logger
+	documents    map[string]*crdtTextDocument
+	persistDelay time.Duration
+}
+
+func newCRDTTextRelay(logger *mlog.Logger) *crdtTextRelay {
+	return &crdtTextRelay{
+		logger:       logger,
+		documents:    map[string]*crdtTextDocument{},
+		persistDelay: crdtTextPersistDelay,
+	}
+}
+
+func (r *crdtTextRelay) setPersister(persister CRDTTextPersister) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.persister = persister
+}
+
+// join adds the session to the peers of the document of the block,
+// loading it from the block if no client of the node is editing it, and
+// returns the message bringing the session up to date.
+func (r *crdtTextRelay) join(block *model.Block, session *websocketSession) CRDTTextSyncMsg {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, ok := r.documents[block.ID]
+	if !ok {
+		doc = newCRDTTextDocument(block)
+		r.documents[block.ID] = doc
+	}
+	if !doc.hasPeer(session) {
+		doc.peers = append(doc.peers, session)
+	}
+	return doc.syncMessage(block.ID)
+}
+
+// sync returns the message bringing a peer up to date with the document
+// of the block.
+func (r *crdtTextRelay) sync(blockID string, session *websocketSession) (CRDTTextSyncMsg, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, ok := r.documents[blockID]
+	if !ok || !doc.hasPeer(session) {
+		return CRDTTextSyncMsg{}, errCRDTTextNotJoined
+	}
+	return doc.syncMessage(blockID), nil
+}
+
+// leave removes the session from the peers of the document of the block.
+// The document is dropped, once saved, when its last peer leaves.
+func (r *crdtTextRelay) leave(blockID string, session *websocketSession) {
+	r.mu.Lock()
+	doc, ok := r.documents[blockID]
+	if !ok || !doc.hasPeer(session) {
+		r.mu.Unlock()
+		return
+	}
+
+	doc.peers = doc.otherPeers(session)
+	if doc.lastAuthor == session {
+		doc.lastAuthor = nil
+	}
+	if len(doc.peers) != 0 {
+		r.mu.Unlock()
+		return
+	}
+
+	delete(r.documents, blockID)
+	if doc.timer != nil {
+		doc.timer.Stop()
+	}
+	dirty, text, state, userID := doc.dirty, doc.text, doc.state(), doc.userID
+	r.mu.Unlock()
+
+	if dirty {
+		r.persist(blockID, text, state, userID)
+	}
+}
+
+// leaveAll removes the session from the peers of all the documents, when
+// it disconnects.
+func (r *crdtTextRelay) leaveAll(session *websocketSession) {
+	r.mu.Lock()
+	blockIDs := []string{}
+	for blockID, doc := range r.documents {
+		if doc.hasPeer(session) {
+			blockIDs = append(blockIDs, blockID)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, blockID := range blockIDs {
+		r.leave(blockID, session)
+	}
+}
+
+// update adds an update a peer made on the given generation of the
+// document of the block. It returns the update, the other peers to relay
+// it to and whether the peer should be asked for a snapshot.
+func (r *crdtTextRelay) update(blockID string, session *websocketSession, generation int64, data []byte) (model.TextCRDTUpdate, []*websocketSession, bool, error) {
+	if len(data) == 0 || len(data) > maxCRDTTextUpdateSize {
+		return model.TextCRDTUpdate{}, nil, false, errCRDTTextUpdateInvalid
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, ok := r.documents[blockID]
+	if !ok || !doc.hasPeer(session) {
+		return model.TextCRDTUpdate{}, nil, false, errCRDTTextNotJoined
+	}
+	if generation != doc.generation {
+		return model.TextCRDTUpdate{}, nil, false, errCRDTTextGenerationChanged
+	}
+
+	update := model.NewTextCRDTUpdate(data)
+	if !doc.hasUpdate(update.ID) {
+		doc.updates = append(doc.updates, update)
+	}
+	doc.lastAuthor = session
+	doc.userID = session.userID
+	doc.dirty = true
+	r.schedule(blockID, doc)
+
+	return update, doc.otherPeers(session), len(doc.updates) >= crdtTextSnapshotThreshold, nil
+}
+
+// received adds an update published by another node, whose clients made
+// it, and returns the peers to relay it to, none if the update is already
+// known or belongs to another generation of the document. The update is
+// saved by the node of its author.
+func (r *crdtTextRelay) received(blockID string, generation int64, update model.TextCRDTUpdate) []*websocketSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, ok := r.documents[blockID]
+	if !ok || generation != doc.generation || doc.hasUpdate(update.ID) {
+		return nil
+	}
+	doc.updates = append(doc.updates, update)
+	return append([]*websocketSession{}, doc.peers...)
+}
+
+// mergeSnapshot replaces the snapshot of the document of the block with
+// the one a peer merged on the given generation, rendering the text, and
+// drops the updates it merged. The updates the peer hadn't received yet
+// are kept.
+func (r *crdtTextRelay) mergeSnapshot(blockID string, session *websocketSession, generation int64, snapshot []byte, text string, merged []string) error {
+	if len(snapshot) == 0 || len(snapshot) > maxCRDTTextUpdateSize {
+		return errCRDTTextUpdateInvalid
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	doc, ok := r.documents[blockID]
+	if !ok || !doc.hasPeer(session) {
+		return errCRDTTextNotJoined
+	}
+	if generation != doc.generation {
+		return errCRDTTextGenerationChanged
+	}
+
+	mergedIDs := make(map[string]bool, len(merged))
+	for _, id := range merged {
+		mergedIDs[id] = true
+	}
+	updates := []model.TextCRDTUpdate{}
+	doc.merged = []model.TextCRDTUpdate{}
+	for _, update := range doc.updates {
+		if mergedIDs[update.ID] {
+			doc.merged = append(doc.merged, update)
+		} else {
+			updates = append(updates, update)
+		}
+	}
+
+	doc.base = doc.snapshotID()
+	doc.snapshot = snapshot
+	doc.text = text
+	doc.updates = updates
+	if doc.lastAuthor == session {
+		doc.lastAuthor = nil
+	}
+	doc.userID = session.userID
+	doc.dirty = true
+	r.schedule(blockID, doc)
+	return nil
+}
+
+// blockChanged updates the document of a block saved by any node, or
+// changed through other means, and brings its peers up to date. A
+// document with pending changes keeps them, as they are saved on top of
+// the block, but still gets the updates it misses.
+func (r *crdtTextRelay) blockChanged(block model.Block) {
+	r.mu.Lock()
+	doc, ok := r.documents[block.ID]
+	if !ok {
+		r.mu.Unlock()
+		return
+	}
+
+	if block.DeleteAt != 0 {
+		if doc.timer != nil {
+			doc.timer.Stop()
+		}
+		delete(r.documents, block.ID)
+		r.mu.Unlock()
+		return
+	}
+
+	messages := r.mergeBlock(doc, &block)
+	r.mu.Unlock()
+
+	for _, m := range messages {
+		r.send(m.peers, m.message)
+	}
+}
+
+// mergeBlock merges the state saved in the block into the document. It
+// must be called with the lock held.
+func (r *crdtTextRelay) mergeBlock(doc *crdtTextDocument, block *model.Block) []crdtTextMessage {
+	state := model.TextCRDTStateFromBlock(block)
+	if state == nil {
+		// the title was changed through other means, the document is
+		// seeded again with it
+		if doc.dirty || block.Title == doc.text {
+			return nil
+		}
+		doc.seedWith(block)
+		return []crdtTextMessage{{doc.peers, doc.syncMessage(block.ID)}}
+	}
+
+	if state.Generation != doc.generation {
+		if doc.dirty {
+			return nil
+		}
+		doc.setState(state, block.Title)
+		return []crdtTextMessage{{doc.peers, doc.syncMessage(block.ID)}}
+	}
+
+	if !doc.dirty {
+		r.adoptSnapshot(doc, state, block.Title)
+	}
+
+	messages := []crdtTextMessage{}
+	for _, update := range state.Updates {
+		if doc.hasUpdate(update.ID) {
+			continue
+		}
+		doc.updates = append(doc.updates, update)
+		messages = append(messages, crdtTextMessage{doc.peers, CRDTTextUpdateMsg{
+			Action:     websocketActionUpdateCRDTText,
+			BlockID:    block.ID,
+			Generation: doc.generation,
+			UpdateID:   update.ID,
+			Update:     update.Data,
+		}})
+	}
+
+	// the updates missing from the saved state, dropped by a concurrent
+	// save, are saved again
+	for _, update := range doc.updates {
+		if !hasCRDTTextUpdate(state.Updates, update.ID) {
+			doc.dirty = true
+			r.schedule(block.ID, doc)
+			break
+		}
+	}
+	return messages
+}
+
+// adoptSnapshot replaces the snapshot of the document with the saved one,
+// unless the saved one is the snapshot it was merged over. If the saved
+// snapshot wasn't merged over the one of the document, but concurrently
+// or before it, the updates merged in the snapshot of the document are
+// kept. It must be called with the lock held.
+func (r *crdtTextRelay) adoptSnapshot(doc *crdtTextDocument, state *model.TextCRDTState, text string) {
+	if bytes.Equal(state.Snapshot, doc.snapshot) || (state.Snapshot != nil && doc.base == model.NewTextCRDTUpdate(state.Snapshot).ID) {
+		return
+	}
+
+	kept := []model.TextCRDTUpdate{}
+	keep := func(updates []model.TextCRDTUpdate) {
+		for _, update := range updates {
+			if !hasCRDTTextUpdate(state.Merged, update.ID) {
+				kept = append(kept, update)
+			}
+		}
+	}
+	keep(doc.updates)
+	if state.Base != doc.snapshotID() {
+		keep(doc.merged)
+	}
+
+	doc.snapshot = state.Snapshot
+	doc.base = state.Base
+	doc.text = text
+	doc.updates = kept
+	doc.merged = state.Merged
+}
+
+// schedule sets the next time the document is saved. It must be called
+// with the lock held.
+func (r *crdtTextRelay) schedule(blockID string, doc *crdtTextDocument) {
+	if doc.timer != nil {
+		doc.timer.Stop()
+	}
+	doc.timer = time.AfterFunc(r.persistDelay, func() { r.onTimer(blockID) })
+}
+
+// onTimer saves the document and asks the author of its last update for a
+// snapshot, so that the title of the block gets the text of the updates.
+func (r *crdtTextRelay) onTimer(blockID string) {
+	r.mu.Lock()
+	doc, ok := r.documents[blockID]
+	if !ok || !doc.dirty {
+		r.mu.Unlock()
+		return
+	}
+
+	text, state, userID := doc.text, doc.state(), doc.userID
+	doc.dirty = false
+	author := doc.lastAuthor
+	doc.lastAuthor = nil
+	generation := doc.generation
+	r.mu.Unlock()
+
+	r.persist(blockID, text, state, userID)
+	if author != nil && len(state.Updates) != 0 {
+		r.requestSnapshot(author, blockID, generation)
+	}
+}
+
+func (r *crdtTextRelay) requestSnapshot(peer *websocketSession, blockID string, generation int64) {
+	r.send([]*websocketSession{peer}, CRDTTextUpdateMsg{
+		Action:     websocketActionRequestCRDTSnapshot,
+		BlockID:    blockID,
+		Generation: generation,
+	})
+}
+
+// persist saves the document outside of the lock, as saving broadcasts
+// the block change back to the relay. Failed saves are retried with the
+// next update.
+func (r *crdtTextRelay) persist(blockID, text string, state *model.TextCRDTState, userID string) {
+	r.mu.Lock()
+	persister := r.persister
+	r.mu.Unlock()
+
+	if persister == nil {
+		r.logger.Warn("no persister to save CRDT text edits", mlog.String("blockID", blockID))
+		return
+	}
+
+	if err := persister.SaveBlockCRDTText(blockID, text, state, userID); err != nil {
+		r.logger.Error("cannot save CRDT text edits",
+			mlog.String("blockID", blockID),
+			mlog.Err(err),
+		)
+		r.mu.Lock()
+		if doc, ok := r.documents[blockID]; ok {
+			doc.dirty = true
+		}
+		r.mu.Unlock()
+	}
+}
+
+// flush saves the pending changes of all the documents.
+func (r *crdtTextRelay) flush() {
+	type pending struct {
+		blockID, text, userID string
+		state                 *model.TextCRDTState
+	}
+
+	r.mu.Lock()
+	toSave := []pending{}
+	for blockID, doc := range r.documents {
+		if doc.dirty {
+			toSave = append(toSave, pending{blockID: blockID, text: doc.text, userID: doc.userID, state: doc.state()})
+			doc.dirty = false
+		}
+	}
+	r.mu.Unlock()
+
+	for _, p := range toSave {
+		r.persist(p.blockID, p.text, p.state, p.userID)
+	}
+}
+
+// send writes the message to the peers, closing the connections that
+// fail.
+func (r *crdtTextRelay) send(peers []*websocketSession, message interface{}) {
+	for _, peer := range peers {
+		if err := peer.WriteJSON(message); err != nil {
+			r.logger.Error("CRDT text message error", mlog.Err(err))
+			peer.conn.Close()
+		}
+	}
+}
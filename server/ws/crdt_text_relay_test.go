@@ -0,0 +1,298 @@
+package ws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/stretchr/testify/require"
+)
+
+type savedCRDTText struct {
+	blockID, text, userID string
+	state                 *model.TextCRDTState
+}
+
+type testCRDTTextPersister struct {
+	mu    sync.Mutex
+	saved []savedCRDTText
+}
+
+func (p *testCRDTTextPersister) SaveBlockCRDTText(blockID, text string, state *model.TextCRDTState, userID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.saved = append(p.saved, savedCRDTText{blockID, text, userID, state})
+	return nil
+}
+
+func newTestCRDTTextRelay() (*crdtTextRelay, *testCRDTTextPersister) {
+	relay := newCRDTTextRelay(mlog.CreateConsoleTestLogger(false, mlog.LvlDebug))
+	relay.persistDelay = time.Hour
+	persister := &testCRDTTextPersister{}
+	relay.setPersister(persister)
+	return relay, persister
+}
+
+// savedBlock returns the block as saved with the state.
+func savedBlock(block model.Block, text string, state *model.TextCRDTState, updateAt int64) model.Block {
+	block.Title = text
+	block.Fields = map[string]interface{}{model.TextFieldCRDT: state.FieldValue()}
+	block.UpdateAt = updateAt
+	return block
+}
+
+func TestCRDTTextRelay(t *testing.T) {
+	block := &model.Block{ID: "block-id", BoardID: "board-id", Type: model.TypeText, Title: "hello", UpdateAt: 100}
+	peer1 := &websocketSession{id: "session-1", userID: "user-1"}
+	peer2 := &websocketSession{id: "session-2", userID: "user-2"}
+
+	t.Run("updates are relayed to the other peers and saved", func(t *testing.T) {
+		relay, persister := newTestCRDTTextRelay()
+
+		sync := relay.join(block, peer1)
+		require.Equal(t, websocketActionSyncCRDTText, sync.Action)
+		require.Equal(t, int64(100), sync.Generation)
+		require.Equal(t, "hello", sync.Seed)
+		require.Empty(t, sync.Updates)
+		relay.join(block, peer2)
+
+		update, peers, snapshotRequested, err := relay.update("block-id", peer1, 100, []byte{1})
+		require.NoError(t, err)
+		require.Equal(t, model.NewTextCRDTUpdate([]byte{1}), update)
+		require.Equal(t, []*websocketSession{peer2}, peers)
+		require.False(t, snapshotRequested)
+
+		// an update sent twice is only kept once
+		_, _, _, err = relay.update("block-id", peer1, 100, []byte{1})
+		require.NoError(t, err)
+
+		sync, err = relay.sync("block-id", peer2)
+		require.NoError(t, err)
+		require.Equal(t, []model.TextCRDTUpdate{update}, sync.Updates)
+
+		relay.leave("block-id", peer1)
+		require.Empty(t, persister.saved)
+		relay.leave("block-id", peer2)
+		require.Len(t, persister.saved, 1)
+		saved := persister.saved[0]
+		require.Equal(t, "hello", saved.text)
+		require.Equal(t, "user-1", saved.userID)
+		require.Equal(t, []model.TextCRDTUpdate{update}, saved.state.Updates)
+		require.Equal(t, model.TextCRDTHash("hello"), saved.state.TextHash)
+
+		relay.mu.Lock()
+		require.Empty(t, relay.documents)
+		relay.mu.Unlock()
+	})
+
+	t.Run("commands of clients that didn't join or of another generation are rejected", func(t *testing.T) {
+		relay, _ := newTestCRDTTextRelay()
+		relay.join(block, peer1)
+
+		_, _, _, err := relay.update("block-id", peer2, 100, []byte{1})
+		require.ErrorIs(t, err, errCRDTTextNotJoined)
+		_, _, _, err = relay.update("block-id", peer1, 99, []byte{1})
+		require.ErrorIs(t, err, errCRDTTextGenerationChanged)
+		_, _, _, err = relay.update("block-id", peer1, 100, nil)
+		require.ErrorIs(t, err, errCRDTTextUpdateInvalid)
+		err = relay.mergeSnapshot("block-id", peer1, 99, []byte{1}, "hello", nil)
+		require.ErrorIs(t, err, errCRDTTextGenerationChanged)
+	})
+
+	t.Run("snapshots replace the updates they merged", func(t *testing.T) {
+		relay, persister := newTestCRDTTextRelay()
+		relay.join(block, peer1)
+		relay.join(block, peer2)
+
+		update1, _, _, err := relay.update("block-id", peer1, 100, []byte{1})
+		require.NoError(t, err)
+		update2, _, _, err := relay.update("block-id", peer2, 100, []byte{2})
+		require.NoError(t, err)
+
+		// the first peer hadn't received the update of the second one
+		require.NoError(t, relay.mergeSnapshot("block-id", peer1, 100, []byte{9}, "hello!", []string{update1.ID}))
+		relay.flush()
+
+		require.Len(t, persister.saved, 1)
+		saved := persister.saved[0]
+		require.Equal(t, "hello!", saved.text)
+		require.Equal(t, []byte{9}, saved.state.Snapshot)
+		require.Equal(t, []model.TextCRDTUpdate{update2}, saved.state.Updates)
+		require.Equal(t, []model.TextCRDTUpdate{update1}, saved.state.Merged)
+		require.Equal(t, model.TextCRDTHash("hello!"), saved.state.TextHash)
+
+		// the document is loaded back from the block
+		other, _ := newTestCRDTTextRelay()
+		reloaded := savedBlock(*block, saved.text, saved.state, 200)
+		sync := other.join(&reloaded, peer1)
+		require.Equal(t, int64(100), sync.Generation)
+		require.Equal(t, "hello", sync.Seed)
+		require.Equal(t, []byte{9}, sync.Snapshot)
+		require.Equal(t, []model.TextCRDTUpdate{update2}, sync.Updates)
+	})
+
+	t.Run("the clients are asked for a snapshot after many updates", func(t *testing.T) {
+		relay, _ := newTestCRDTTextRelay()
+		relay.join(block, peer1)
+
+		for i := 1; i <= crdtTextSnapshotThreshold; i++ {
+			_, _, snapshotRequested, err := relay.update("block-id", peer1, 100, []byte{byte(i), byte(i >> 8)})
+			require.NoError(t, err)
+			require.Equal(t, i == crdtTextSnapshotThreshold, snapshotRequested)
+		}
+	})
+
+	t.Run("updates of other nodes are relayed once", func(t *testing.T) {
+		relay, persister := newTestCRDTTextRelay()
+		relay.join(block, peer1)
+
+		update := model.NewTextCRDTUpdate([]byte{1})
+		require.Equal(t, []*websocketSession{peer1}, relay.received("block-id", 100, update))
+		require.Empty(t, relay.received("block-id", 100, update))
+		require.Empty(t, relay.received("block-id", 99, model.NewTextCRDTUpdate([]byte{2})))
+		require.Empty(t, relay.received("other-block-id", 100, update))
+
+		// the node of the author saves the update
+		relay.flush()
+		require.Empty(t, persister.saved)
+	})
+}
+
+func TestCRDTTextRelayBlockChanged(t *testing.T) {
+	block := &model.Block{ID: "block-id", BoardID: "board-id", Type: model.TypeText, Title: "hello", UpdateAt: 100}
+	peer := &websocketSession{id: "session-1", userID: "user-1"}
+
+	mergeBlock := func(relay *crdtTextRelay, block model.Block) []crdtTextMessage {
+		relay.mu.Lock()
+		defer relay.mu.Unlock()
+		return relay.mergeBlock(relay.documents[block.ID], &block)
+	}
+	document := func(relay *crdtTextRelay) crdtTextDocument {
+		relay.mu.Lock()
+		defer relay.mu.Unlock()
+		return *relay.documents["block-id"]
+	}
+
+	t.Run("the document is seeded again when the title is changed through other means", func(t *testing.T) {
+		relay, _ := newTestCRDTTextRelay()
+		relay.join(block, peer)
+		_, _, _, err := relay.update("block-id", peer, 100, []byte{1})
+		require.NoError(t, err)
+		relay.flush()
+
+		changed := *block
+		changed.Title = "hello world"
+		changed.UpdateAt = 300
+		messages := mergeBlock(relay, changed)
+		require.Len(t, messages, 1)
+		sync := messages[0].message.(CRDTTextSyncMsg)
+		require.Equal(t, int64(300), sync.Generation)
+		require.Equal(t, "hello world", sync.Seed)
+		require.Empty(t, sync.Updates)
+	})
+
+	t.Run("pending changes are kept over a change of the title", func(t *testing.T) {
+		relay, _ := newTestCRDTTextRelay()
+		relay.join(block, peer)
+		_, _, _, err := relay.update("block-id", peer, 100, []byte{1})
+		require.NoError(t, err)
+
+		changed := *block
+		changed.Title = "hello world"
+		changed.UpdateAt = 300
+		require.Empty(t, mergeBlock(relay, changed))
+		require.Equal(t, int64(100), document(relay).generation)
+	})
+
+	t.Run("the states saved by other nodes are merged", func(t *testing.T) {
+		relay, persister := newTestCRDTTextRelay()
+		relay.join(block, peer)
+		own, _, _, err := relay.update("block-id", peer, 100, []byte{1})
+		require.NoError(t, err)
+		relay.flush()
+		require.Len(t, persister.saved, 1)
+
+		// another node merged a snapshot over the document without the
+		// update of this node, which it hadn't received yet
+		other := model.NewTextCRDTUpdate([]byte{2})
+		merged := model.NewTextCRDTUpdate([]byte{3})
+		state := &model.TextCRDTState{
+			Generation: 100,
+			Seed:       "hello",
+			Snapshot:   []byte{9},
+			Updates:    []model.TextCRDTUpdate{other},
+			Merged:     []model.TextCRDTUpdate{merged},
+			TextHash:   model.TextCRDTHash("hello!"),
+		}
+		messages := mergeBlock(relay, savedBlock(*block, "hello!", state, 200))
+
+		// the peers get the update they miss
+		require.Len(t, messages, 1)
+		message := messages[0].message.(CRDTTextUpdateMsg)
+		require.Equal(t, websocketActionUpdateCRDTText, message.Action)
+		require.Equal(t, other.ID, message.UpdateID)
+
+		doc := document(relay)
+		require.Equal(t, []byte{9}, doc.snapshot)
+		require.Equal(t, "hello!", doc.text)
+		require.ElementsMatch(t, []model.TextCRDTUpdate{own, other}, doc.updates)
+
+		// the update dropped by the save is saved again
+		require.True(t, doc.dirty)
+		relay.flush()
+		require.Len(t, persister.saved, 2)
+		require.ElementsMatch(t, []model.TextCRDTUpdate{own, other}, persister.saved[1].state.Updates)
+
+		// the save of the node doesn't change its document
+		saved := persister.saved[1]
+		require.Empty(t, mergeBlock(relay, savedBlock(*block, saved.text, saved.state, 300)))
+		require.False(t, document(relay).dirty)
+	})
+
+	t.Run("the snapshots merged concurrently keep their updates", func(t *testing.T) {
+		relay, persister := newTestCRDTTextRelay()
+		relay.join(block, peer)
+		update1, _, _, err := relay.update("block-id", peer, 100, []byte{1})
+		require.NoError(t, err)
+		require.NoError(t, relay.mergeSnapshot("block-id", peer, 100, []byte{8}, "hello!", []string{update1.ID}))
+		relay.flush()
+		require.Len(t, persister.saved, 1)
+
+		// another node merged a snapshot over the seed without the update
+		update2 := model.NewTextCRDTUpdate([]byte{2})
+		state := &model.TextCRDTState{
+			Generation: 100,
+			Seed:       "hello",
+			Snapshot:   []byte{9},
+			Merged:     []model.TextCRDTUpdate{update2},
+			TextHash:   model.TextCRDTHash("hello?"),
+		}
+		require.Empty(t, mergeBlock(relay, savedBlock(*block, "hello?", state, 200)))
+
+		doc := document(relay)
+		require.Equal(t, []byte{9}, doc.snapshot)
+		require.Equal(t, []model.TextCRDTUpdate{update1}, doc.updates)
+		require.True(t, doc.dirty)
+	})
+
+	t.Run("the document is dropped when the block is deleted", func(t *testing.T) {
+		relay, persister := newTestCRDTTextRelay()
+		relay.join(block, peer)
+		_, _, _, err := relay.update("block-id", peer, 100, []byte{1})
+		require.NoError(t, err)
+
+		deleted := *block
+		deleted.DeleteAt = 200
+		relay.blockChanged(deleted)
+
+		relay.mu.Lock()
+		require.Empty(t, relay.documents)
+		relay.mu.Unlock()
+		relay.flush()
+		require.Empty(t, persister.saved)
+	})
+}
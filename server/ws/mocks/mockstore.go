@@ -49,6 +49,36 @@ func (mr *MockStoreMockRecorder) GetBlock(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlock", reflect.TypeOf((*MockStore)(nil).GetBlock), arg0)
 }
 
+// GetBlocksModifiedSince mocks base method.
+func (m *MockStore) GetBlocksModifiedSince(arg0 string, arg1 int64) ([]model.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocksModifiedSince", arg0, arg1)
+	ret0, _ := ret[0].([]model.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksModifiedSince indicates an expected call of GetBlocksModifiedSince.
+func (mr *MockStoreMockRecorder) GetBlocksModifiedSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksModifiedSince", reflect.TypeOf((*MockStore)(nil).GetBlocksModifiedSince), arg0, arg1)
+}
+
+// GetBoard mocks base method.
+func (m *MockStore) GetBoard(arg0 string) (*model.Board, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", arg0)
+	ret0, _ := ret[0].(*model.Board)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockStoreMockRecorder) GetBoard(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockStore)(nil).GetBoard), arg0)
+}
+
 // GetMembersForBoard mocks base method.
 func (m *MockStore) GetMembersForBoard(arg0 string) ([]*model.BoardMember, error) {
 	m.ctrl.T.Helper()
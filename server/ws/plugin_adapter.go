@@ -28,7 +28,7 @@ type PluginAdapterInterface interface {
 	WebSocketMessageHasBeenPosted(webConnID, userID string, req *mmModel.WebSocketRequest)
 	BroadcastConfigChange(clientConfig model.ClientConfig)
 	BroadcastBlockChange(teamID string, block model.Block)
-	BroadcastBlockDelete(teamID, blockID, parentID string)
+	BroadcastBlockDelete(teamID, blockID, boardID, parentID string)
 	BroadcastSubscriptionChange(teamID string, subscription *model.Subscription)
 	HandleClusterEvent(ev mmModel.PluginClusterEvent)
 }
@@ -348,8 +348,19 @@ func (pa *PluginAdapter) WebSocketMessageHasBeenPosted(webConnID, userID string,
 	switch command.Action {
 	// The block-related commands are not implemented in the adapter
 	// as there is no such thing as unauthenticated websocket
-	// connections in plugin mode. Only a debug line is logged
-	case websocketActionSubscribeBlocks, websocketActionUnsubscribeBlocks:
+	// connections in plugin mode. Collaborative text editing, board
+	// presence and card editing indicators keep their state in
+	// memory, which doesn't work across the nodes of a cluster. The
+	// Mattermost websocket replays the missed events itself on
+	// reconnection, so there is no delta sync either. Only a debug
+	// line is logged
+	case websocketActionSubscribeBlocks, websocketActionUnsubscribeBlocks,
+		websocketActionSyncText, websocketActionEditText,
+		websocketActionJoinCRDTText, websocketActionLeaveCRDTText,
+		websocketActionUpdateCRDTText, websocketActionSnapshotCRDTText,
+		websocketActionViewBoard, websocketActionLeaveBoard,
+		websocketActionStartEditingCard, websocketActionStopEditingCard,
+		websocketActionSyncSince:
 		pa.logger.Debug(`Command not implemented in plugin mode`,
 			mlog.String("command", command.Action),
 			mlog.String("webConnID", webConnID),
@@ -391,6 +402,10 @@ func (pa *PluginAdapter) BroadcastConfigChange(pluginConfig model.ClientConfig)
 	pa.sendMessageToAll(websocketActionUpdateConfig, utils.StructToMap(pluginConfig))
 }
 
+func (pa *PluginAdapter) BroadcastBoardLimitsChange(limits model.BoardLimits) {
+	pa.sendMessageToAll(websocketActionUpdateBoardLimits, utils.StructToMap(limits))
+}
+
 // sendUserMessageSkipCluster sends the message to specific users.
 func (pa *PluginAdapter) sendUserMessageSkipCluster(event string, payload map[string]interface{}, userIDs ...string) {
 	for _, userID := range userIDs {
@@ -446,6 +461,35 @@ func (pa *PluginAdapter) sendBoardMessage(teamID, boardID string, payload map[st
 	pa.sendBoardMessageSkipCluster(teamID, boardID, payload, ensureUserIDs...)
 }
 
+// sendRestrictedBoardMessageSkipCluster sends a message to the users
+// subscribed to a given team that belong to one of its boards, limited
+// to the users of the onlyUserIDs list.
+func (pa *PluginAdapter) sendRestrictedBoardMessageSkipCluster(teamID, boardID string, payload map[string]interface{}, onlyUserIDs []string) {
+	userIDs := filterUserIDs(pa.getUserIDsForTeamAndBoard(teamID, boardID), onlyUserIDs)
+	pa.sendUserMessageSkipCluster(websocketActionUpdateBoard, payload, userIDs...)
+}
+
+// sendRestrictedBoardMessage sends and propagates a message that is
+// aimed for the board members of the onlyUserIDs list.
+func (pa *PluginAdapter) sendRestrictedBoardMessage(teamID, boardID string, payload map[string]interface{}, onlyUserIDs []string) {
+	if len(onlyUserIDs) == 0 {
+		return
+	}
+
+	go func() {
+		clusterMessage := &ClusterMessage{
+			TeamID:    teamID,
+			BoardID:   boardID,
+			Payload:   payload,
+			OnlyUsers: onlyUserIDs,
+		}
+
+		pa.sendMessageToCluster("websocket_message", clusterMessage)
+	}()
+
+	pa.sendRestrictedBoardMessageSkipCluster(teamID, boardID, payload, onlyUserIDs)
+}
+
 func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block model.Block) {
 	pa.logger.Debug("BroadcastingBlockChange",
 		mlog.String("teamID", teamID),
@@ -459,6 +503,19 @@ func (pa *PluginAdapter) BroadcastBlockChange(teamID string, block model.Block)
 		Block:  block,
 	}
 
+	viewers, restricted, err := getRestrictedBlockViewers(pa.store, block)
+	if err != nil {
+		pa.logger.Error("error getting viewers for restricted block",
+			mlog.String("blockID", block.ID),
+			mlog.Err(err),
+		)
+		return
+	}
+	if restricted {
+		pa.sendRestrictedBoardMessage(teamID, block.BoardID, utils.StructToMap(message), viewers)
+		return
+	}
+
 	pa.sendBoardMessage(teamID, block.BoardID, utils.StructToMap(message))
 }
 
@@ -507,11 +564,12 @@ func (pa *PluginAdapter) BroadcastCategoryBoardChange(teamID, userID string, boa
 	pa.sendTeamMessage(websocketActionUpdateCategoryBoard, teamID, utils.StructToMap(message))
 }
 
-func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID string) {
+func (pa *PluginAdapter) BroadcastBlockDelete(teamID, blockID, boardID, parentID string) {
 	now := utils.GetMillis()
 	block := model.Block{}
 	block.ID = blockID
 	block.BoardID = boardID
+	block.ParentID = parentID
 	block.UpdateAt = now
 	block.DeleteAt = now
 
@@ -11,6 +11,7 @@ type ClusterMessage struct {
 	BoardID     string
 	Payload     map[string]interface{}
 	EnsureUsers []string
+	OnlyUsers   []string
 }
 
 func (pa *PluginAdapter) sendMessageToCluster(id string, clusterMessage *ClusterMessage) {
@@ -48,6 +49,11 @@ func (pa *PluginAdapter) HandleClusterEvent(ev mmModel.PluginClusterEvent) {
 		return
 	}
 
+	if clusterMessage.BoardID != "" && len(clusterMessage.OnlyUsers) != 0 {
+		pa.sendRestrictedBoardMessageSkipCluster(clusterMessage.TeamID, clusterMessage.BoardID, clusterMessage.Payload, clusterMessage.OnlyUsers)
+		return
+	}
+
 	if clusterMessage.BoardID != "" {
 		pa.sendBoardMessageSkipCluster(clusterMessage.TeamID, clusterMessage.BoardID, clusterMessage.Payload, clusterMessage.EnsureUsers...)
 		return
@@ -0,0 +1,176 @@
+package ws
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// PresenceTracker is implemented by the adapters that know which users
+// are viewing each board.
+type PresenceTracker interface {
+	GetBoardPresence(boardID string) []string
+}
+
+// boardPresence counts the connections of each user viewing each board, so
+// that a user viewing a board in several tabs leaves it with the last one.
+type boardPresence struct {
+	mu     sync.Mutex
+	boards map[string]map[string]int
+}
+
+func newBoardPresence() *boardPresence {
+	return &boardPresence{
+		boards: map[string]map[string]int{},
+	}
+}
+
+// join records a connection of the user viewing the board and returns
+// true if it is the first one.
+func (bp *boardPresence) join(boardID, userID string) bool {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	users, ok := bp.boards[boardID]
+	if !ok {
+		users = map[string]int{}
+		bp.boards[boardID] = users
+	}
+	users[userID]++
+	return users[userID] == 1
+}
+
+// leave removes a connection of the user from the board and returns true
+// if it was the last one.
+func (bp *boardPresence) leave(boardID, userID string) bool {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	users, ok := bp.boards[boardID]
+	if !ok || users[userID] == 0 {
+		return false
+	}
+
+	users[userID]--
+	if users[userID] > 0 {
+		return false
+	}
+	delete(users, userID)
+	if len(users) == 0 {
+		delete(bp.boards, boardID)
+	}
+	return true
+}
+
+// viewers returns the sorted IDs of the users viewing the board.
+func (bp *boardPresence) viewers(boardID string) []string {
+	bp.mu.Lock()
+	defer bp.mu.Unlock()
+
+	userIDs := make([]string, 0, len(bp.boards[boardID]))
+	for userID := range bp.boards[boardID] {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+	return userIDs
+}
+
+// GetBoardPresence returns the IDs of the users viewing the board through
+// a connection to this server.
+func (ws *Server) GetBoardPresence(boardID string) []string {
+	return ws.presence.viewers(boardID)
+}
+
+// viewBoard records that the listener is viewing the board, leaving the
+// board it was viewing before, if any.
+func (ws *Server) viewBoard(listener *websocketSession, boardID string) {
+	if listener.viewingBoardID == boardID {
+		return
+	}
+
+	board, err := ws.store.GetBoard(boardID)
+	if err != nil {
+		ws.logger.Error("error getting the viewed board", mlog.String("boardID", boardID), mlog.Err(err))
+		return
+	}
+	if !ws.canViewBoard(listener.userID, board) {
+		ws.logger.Error("WS user doesn't have board access", mlog.String("boardID", boardID), mlog.String("userID", listener.userID))
+		return
+	}
+
+	ws.leaveBoard(listener)
+
+	listener.viewingBoardID = board.ID
+	listener.viewingTeamID = board.TeamID
+	if ws.presence.join(board.ID, listener.userID) {
+		ws.broadcastPresence(websocketActionPresenceJoin, board.TeamID, board.ID, listener.userID)
+	}
+}
+
+// leaveBoard records that the listener is not viewing its board anymore.
+func (ws *Server) leaveBoard(listener *websocketSession) {
+	if listener.viewingBoardID == "" {
+		return
+	}
+
+	boardID, teamID := listener.viewingBoardID, listener.viewingTeamID
+	listener.viewingBoardID = ""
+	listener.viewingTeamID = ""
+	if ws.presence.leave(boardID, listener.userID) {
+		ws.broadcastPresence(websocketActionPresenceLeave, teamID, boardID, listener.userID)
+	}
+}
+
+// canViewBoard returns true if the user can open the board: team members
+// can open the open boards, the private ones are restricted to their
+// members.
+func (ws *Server) canViewBoard(userID string, board *model.Board) bool {
+	if len(ws.singleUserToken) != 0 {
+		return userID == model.SingleUser
+	}
+
+	if !ws.auth.DoesUserHaveTeamAccess(userID, board.TeamID) {
+		return false
+	}
+	if board.Type == model.BoardTypeOpen {
+		return true
+	}
+
+	members, err := ws.store.GetMembersForBoard(board.ID)
+	if err != nil {
+		ws.logger.Error("error getting members for board", mlog.String("boardID", board.ID), mlog.Err(err))
+		return false
+	}
+	for _, member := range members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (ws *Server) broadcastPresence(action, teamID, boardID, userID string) {
+	message := UpdatePresenceMsg{
+		Action:  action,
+		TeamID:  teamID,
+		BoardID: boardID,
+		UserID:  userID,
+	}
+
+	listeners := ws.getListenersForTeamAndBoard(teamID, boardID)
+	ws.logger.Trace("listener(s) for board presence",
+		mlog.Int("listener_count", len(listeners)),
+		mlog.String("boardID", boardID),
+		mlog.String("action", action),
+	)
+
+	for _, listener := range listeners {
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("broadcast presence error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
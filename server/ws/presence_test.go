@@ -0,0 +1,36 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardPresence(t *testing.T) {
+	presence := newBoardPresence()
+
+	require.True(t, presence.join("board-1", "user-2"))
+	require.True(t, presence.join("board-1", "user-1"))
+	require.Equal(t, []string{"user-1", "user-2"}, presence.viewers("board-1"))
+	require.Empty(t, presence.viewers("board-2"))
+
+	t.Run("a user leaves with its last connection", func(t *testing.T) {
+		require.False(t, presence.join("board-1", "user-1"))
+		require.False(t, presence.leave("board-1", "user-1"))
+		require.Equal(t, []string{"user-1", "user-2"}, presence.viewers("board-1"))
+
+		require.True(t, presence.leave("board-1", "user-1"))
+		require.Equal(t, []string{"user-2"}, presence.viewers("board-1"))
+	})
+
+	t.Run("leaving a board not viewed has no effect", func(t *testing.T) {
+		require.False(t, presence.leave("board-1", "user-1"))
+		require.False(t, presence.leave("board-2", "user-2"))
+		require.Equal(t, []string{"user-2"}, presence.viewers("board-1"))
+	})
+
+	t.Run("the boards without viewers are removed", func(t *testing.T) {
+		require.True(t, presence.leave("board-1", "user-2"))
+		require.Empty(t, presence.boards)
+	})
+}
@@ -0,0 +1,65 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+)
+
+// getRestrictedBlockViewers returns, for a block that is or belongs to a
+// restricted card, or that is a restricted view, the ids of the users
+// allowed to receive its changes. The second return value is false for
+// blocks without restrictions.
+func getRestrictedBlockViewers(store Store, block model.Block) ([]string, bool, error) {
+	if model.IsViewRestricted(&block) {
+		members, err := store.GetMembersForBoard(block.BoardID)
+		if err != nil {
+			return nil, false, err
+		}
+		return model.RestrictedViewViewers(members), true, nil
+	}
+
+	card := &block
+	if block.Type != model.TypeCard {
+		if block.ParentID == "" {
+			return nil, false, nil
+		}
+		parent, err := store.GetBlock(block.ParentID)
+		if err != nil {
+			return nil, false, err
+		}
+		card = parent
+	}
+
+	if !model.IsCardRestricted(card) {
+		return nil, false, nil
+	}
+
+	board, err := store.GetBoard(card.BoardID)
+	if err != nil {
+		return nil, false, err
+	}
+	properties, err := model.CardPropertiesFromBoard(board)
+	if err != nil {
+		return nil, false, err
+	}
+	members, err := store.GetMembersForBoard(card.BoardID)
+	if err != nil {
+		return nil, false, err
+	}
+	return model.RestrictedCardViewers(properties, card, members), true, nil
+}
+
+// filterUserIDs returns the user ids that are part of the allowed list.
+func filterUserIDs(userIDs, allowed []string) []string {
+	allowedMap := make(map[string]bool, len(allowed))
+	for _, userID := range allowed {
+		allowedMap[userID] = true
+	}
+
+	filtered := []string{}
+	for _, userID := range userIDs {
+		if allowedMap[userID] {
+			filtered = append(filtered, userID)
+		}
+	}
+	return filtered
+}
@@ -4,19 +4,35 @@ import (
 	"encoding/json"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/redis"
 	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 )
 
+// sessionCheckInterval is how often the session an open connection was
+// authenticated with is checked against the session policies.
+const sessionCheckInterval = time.Minute
+
+// pingInterval is how often the connections are pinged, which also checks
+// the session of the idle ones.
+const pingInterval = 30 * time.Second
+
+// pingWriteTimeout bounds the time a ping waits for a slow connection.
+const pingWriteTimeout = 10 * time.Second
+
 func (wss *websocketSession) WriteJSON(v interface{}) error {
 	wss.mu.Lock()
 	defer wss.mu.Unlock()
+	if err := wss.flushBlockChangesLocked(); err != nil {
+		return err
+	}
 	err := wss.conn.WriteJSON(v)
 	return err
 }
@@ -44,6 +60,7 @@ func (wss *websocketSession) isSubscribedToBlock(blockID string) bool {
 // Server is a WebSocket server.
 type Server struct {
 	upgrader         websocket.Upgrader
+	batchWindow      time.Duration
 	listeners        map[*websocketSession]bool
 	listenersByTeam  map[string][]*websocketSession
 	listenersByBlock map[string][]*websocketSession
@@ -53,6 +70,17 @@ type Server struct {
 	isMattermostAuth bool
 	logger           *mlog.Logger
 	store            Store
+	textEditor       *textEditor
+	textOwners       *textOwners
+	crdtText         *crdtTextRelay
+	presence         *boardPresence
+
+	nodeID         string
+	clusterMu      sync.Mutex
+	clusterClient  *redis.Client
+	clusterChannel string
+	clusterPubSub  *redis.PubSub
+	clusterDone    chan struct{}
 }
 
 // UpdateClientConfig is sent on block updates.
@@ -62,11 +90,33 @@ type UpdateClientConfig struct {
 }
 
 type websocketSession struct {
+	id     string
 	conn   *websocket.Conn
 	userID string
 	mu     sync.Mutex
 	teams  []string
 	blocks []string
+
+	// the address of the client, as seen through the trusted proxies
+	clientIP string
+
+	// the board the client has open, to track the presence of its user
+	viewingBoardID string
+	viewingTeamID  string
+
+	// the card field the client announced it is editing
+	editingCardID string
+	editingField  string
+
+	// the token of the session the client authenticated with and the last
+	// time the session was checked, guarded by mu
+	sessionToken     string
+	sessionCheckedAt time.Time
+
+	// the block changes held to be sent in a single frame, guarded by mu
+	batchWindow   time.Duration
+	pendingBlocks []UpdateBlockMsg
+	batchTimer    *time.Timer
 }
 
 func (wss *websocketSession) isAuthenticated() bool {
@@ -75,6 +125,8 @@ func (wss *websocketSession) isAuthenticated() bool {
 
 // NewServer creates a new Server.
 func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, logger *mlog.Logger, store Store) *Server {
+	nodeID := utils.NewID(utils.IDTypeNone)
+	textOwners := newTextOwners(nodeID, logger)
 	return &Server{
 		listeners:        make(map[*websocketSession]bool),
 		listenersByTeam:  make(map[string][]*websocketSession),
@@ -83,12 +135,20 @@ func NewServer(auth *auth.Auth, singleUserToken string, isMattermostAuth bool, l
 			CheckOrigin: func(r *http.Request) bool {
 				return true
 			},
+			// negotiates permessage-deflate with the clients supporting it
+			EnableCompression: true,
 		},
+		batchWindow:      blockChangeBatchWindow,
 		auth:             auth,
 		singleUserToken:  singleUserToken,
 		isMattermostAuth: isMattermostAuth,
 		logger:           logger,
 		store:            store,
+		textEditor:       newTextEditor(logger, textOwners),
+		textOwners:       textOwners,
+		crdtText:         newCRDTTextRelay(logger),
+		presence:         newBoardPresence(),
+		nodeID:           nodeID,
 	}
 }
 
@@ -107,11 +167,14 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	// create an empty session with websocket client
 	wsSession := &websocketSession{
-		conn:   client,
-		userID: "",
-		mu:     sync.Mutex{},
-		teams:  []string{},
-		blocks: []string{},
+		id:          utils.NewID(utils.IDTypeNone),
+		conn:        client,
+		clientIP:    ws.auth.ClientIP(r),
+		userID:      "",
+		mu:          sync.Mutex{},
+		teams:       []string{},
+		blocks:      []string{},
+		batchWindow: ws.batchWindow,
 	}
 
 	if ws.isMattermostAuth {
@@ -120,11 +183,15 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 
 	ws.addListener(wsSession)
 
+	done := make(chan struct{})
+	go ws.pingListener(wsSession, done)
+
 	// Make sure we close the connection when the function returns
 	defer func() {
 		ws.logger.Debug("DISCONNECT WebSocket", mlog.Stringer("client", wsSession.conn.RemoteAddr()))
 
 		// Remove session from listeners
+		close(done)
 		ws.removeListener(wsSession)
 		wsSession.conn.Close()
 	}()
@@ -158,19 +225,28 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
+		// the messages of the client are an activity of its user,
+		// refreshing its session
+		if !ws.checkListenerSession(wsSession, true) {
+			break
+		}
+
 		// if the client wants to subscribe to a set of blocks and it
 		// is sending a read token, we don't need to check for
-		// authentication
+		// authentication. Authenticated clients can subscribe to the
+		// blocks of the boards they can view, like a card open in a
+		// modal, without subscribing to the whole team
 		if command.Action == websocketActionSubscribeBlocks {
 			ws.logger.Debug(`Command: SUBSCRIBE_BLOCKS`,
 				mlog.String("teamID", command.TeamID),
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 			)
 
-			if !ws.isCommandReadTokenValid(command) {
-				ws.logger.Error(`Rejected invalid read token`,
+			if !ws.canSubscribeToBlocks(wsSession, command) {
+				ws.logger.Error(`Rejected block subscription`,
 					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 					mlog.String("action", command.Action),
+					mlog.String("userID", wsSession.userID),
 					mlog.String("readToken", command.ReadToken),
 				)
 
@@ -187,7 +263,9 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 			)
 
-			if !ws.isCommandReadTokenValid(command) {
+			// authenticated users can always drop their own
+			// subscriptions, even to blocks deleted since
+			if !wsSession.isAuthenticated() && !ws.isCommandReadTokenValid(wsSession, command) {
 				ws.logger.Error(`Rejected invalid read token`,
 					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
 					mlog.String("action", command.Action),
@@ -244,6 +322,81 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 			)
 
 			ws.unsubscribeListenerFromTeam(wsSession, command.TeamID)
+		case websocketActionSyncText:
+			ws.logger.Debug(`Command: SYNC_TEXT`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.handleTextCommand(wsSession, command)
+		case websocketActionEditText:
+			ws.logger.Trace(`Command: EDIT_TEXT`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.handleTextCommand(wsSession, command)
+		case websocketActionJoinCRDTText:
+			ws.logger.Debug(`Command: JOIN_CRDT_TEXT`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.handleCRDTTextCommand(wsSession, command)
+		case websocketActionLeaveCRDTText:
+			ws.logger.Debug(`Command: LEAVE_CRDT_TEXT`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.crdtText.leave(command.BlockID, wsSession)
+		case websocketActionUpdateCRDTText:
+			ws.logger.Trace(`Command: UPDATE_CRDT_TEXT`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.handleCRDTTextCommand(wsSession, command)
+		case websocketActionSnapshotCRDTText:
+			ws.logger.Trace(`Command: SNAPSHOT_CRDT_TEXT`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.handleCRDTTextCommand(wsSession, command)
+		case websocketActionViewBoard:
+			ws.logger.Debug(`Command: VIEW_BOARD`,
+				mlog.String("boardID", command.BoardID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.viewBoard(wsSession, command.BoardID)
+		case websocketActionLeaveBoard:
+			ws.logger.Debug(`Command: LEAVE_BOARD`,
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.leaveBoard(wsSession)
+		case websocketActionStartEditingCard:
+			ws.logger.Trace(`Command: START_EDITING_CARD`,
+				mlog.String("blockID", command.BlockID),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.startEditingCard(wsSession, command)
+		case websocketActionStopEditingCard:
+			ws.logger.Trace(`Command: STOP_EDITING_CARD`,
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.stopEditingCard(wsSession)
+		case websocketActionSyncSince:
+			ws.logger.Debug(`Command: SYNC_SINCE`,
+				mlog.Int("boardCount", len(command.Since)),
+				mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			)
+
+			ws.syncSince(wsSession, command)
 		default:
 			ws.logger.Error(`ERROR webSocket command, invalid action`, mlog.String("action", command.Action))
 		}
@@ -251,8 +404,9 @@ func (ws *Server) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 }
 
 // isCommandReadTokenValid ensures that a command contains a read
-// token and a set of block ids that said token is valid for.
-func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
+// token and a set of block ids that said token is valid for, and that
+// the token is used from an allowed address.
+func (ws *Server) isCommandReadTokenValid(wsSession *websocketSession, command WebsocketCommand) bool {
 	if len(command.TeamID) == 0 {
 		return false
 	}
@@ -284,8 +438,20 @@ func (ws *Server) isCommandReadTokenValid(command WebsocketCommand) bool {
 		)
 		return false
 	}
+	if !isValid {
+		return false
+	}
+
+	isAllowed, err := ws.auth.IsSharedBoardAddressAllowed(boardID, wsSession.clientIP)
+	if err != nil {
+		ws.logger.Error(`ERROR when checking token address`,
+			mlog.String("teamID", command.TeamID),
+			mlog.Err(err),
+		)
+		return false
+	}
 
-	return isValid
+	return isAllowed
 }
 
 // addListener adds a listener to the websocket server. The listener
@@ -298,9 +464,27 @@ func (ws *Server) addListener(listener *websocketSession) {
 	ws.listeners[listener] = true
 }
 
+// getListenerByID returns the listener with the session id, or nil if it
+// is disconnected.
+func (ws *Server) getListenerByID(sessionID string) *websocketSession {
+	ws.mu.RLock()
+	defer ws.mu.RUnlock()
+
+	for listener := range ws.listeners {
+		if listener.id == sessionID {
+			return listener
+		}
+	}
+	return nil
+}
+
 // removeListener removes a listener and all its subscriptions, if
 // any, from the websockets server.
 func (ws *Server) removeListener(listener *websocketSession) {
+	ws.stopEditingCard(listener)
+	ws.leaveBoard(listener)
+	ws.crdtText.leaveAll(listener)
+
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
@@ -409,7 +593,11 @@ func (ws *Server) removeListenerFromBlock(listener *websocketSession, blockID st
 			newBlockListeners = append(newBlockListeners, l)
 		}
 	}
-	ws.listenersByBlock[blockID] = newBlockListeners
+	if len(newBlockListeners) == 0 {
+		delete(ws.listenersByBlock, blockID)
+	} else {
+		ws.listenersByBlock[blockID] = newBlockListeners
+	}
 
 	// we remove the block from the listener subscription list
 	newListenerBlocks := []string{}
@@ -462,9 +650,75 @@ func (ws *Server) authenticateListener(wsSession *websocketSession, token string
 
 	// Authenticated
 	wsSession.userID = userID
+	if len(ws.singleUserToken) == 0 {
+		wsSession.mu.Lock()
+		wsSession.sessionToken = token
+		wsSession.sessionCheckedAt = time.Now()
+		wsSession.mu.Unlock()
+	}
 	ws.logger.Debug("authenticateListener: Authenticated", mlog.String("userID", userID), mlog.Stringer("client", wsSession.conn.RemoteAddr()))
 }
 
+// checkListenerSession closes the connection and returns false if the
+// session the client authenticated with was revoked or expired since it
+// was last checked. The sessions checked on an activity of their user are
+// refreshed.
+func (ws *Server) checkListenerSession(wsSession *websocketSession, activity bool) bool {
+	wsSession.mu.Lock()
+	token := wsSession.sessionToken
+	due := token != "" && time.Since(wsSession.sessionCheckedAt) >= sessionCheckInterval
+	if due {
+		wsSession.sessionCheckedAt = time.Now()
+	}
+	wsSession.mu.Unlock()
+
+	if !due {
+		return true
+	}
+
+	var err error
+	if activity {
+		_, err = ws.auth.GetSession(token)
+	} else {
+		err = ws.auth.CheckSession(token)
+	}
+	if err != nil {
+		ws.logger.Debug("Closing the WebSocket of an expired session",
+			mlog.String("userID", wsSession.userID),
+			mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+			mlog.Err(err),
+		)
+		wsSession.conn.Close()
+		return false
+	}
+	return true
+}
+
+// pingListener pings the connection until done is closed, closing it when
+// the session of the client expires while it is idle.
+func (ws *Server) pingListener(wsSession *websocketSession, done <-chan struct{}) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if !ws.checkListenerSession(wsSession, false) {
+				return
+			}
+			if err := wsSession.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(pingWriteTimeout)); err != nil {
+				ws.logger.Debug("Cannot ping the WebSocket",
+					mlog.Stringer("client", wsSession.conn.RemoteAddr()),
+					mlog.Err(err),
+				)
+				return
+			}
+		}
+	}
+}
+
 // getListenersForBlock returns the listeners subscribed to a
 // block changes.
 func (ws *Server) getListenersForBlock(blockID string) []*websocketSession {
@@ -514,12 +768,31 @@ func (ws *Server) getListenersForTeamAndBoard(teamID, boardID string, ensureUser
 	return listeners
 }
 
-// BroadcastBlockDelete broadcasts delete messages to clients.
-func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID string) {
+// filterListenersByUserIDs returns the listeners authenticated as one
+// of the users.
+func (ws *Server) filterListenersByUserIDs(listeners []*websocketSession, userIDs []string) []*websocketSession {
+	userMap := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		userMap[userID] = true
+	}
+
+	filtered := []*websocketSession{}
+	for _, listener := range listeners {
+		if userMap[listener.userID] {
+			filtered = append(filtered, listener)
+		}
+	}
+	return filtered
+}
+
+// BroadcastBlockDelete broadcasts delete messages to clients. The parent
+// of the block routes the message to the clients subscribed to it.
+func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID, parentID string) {
 	now := utils.GetMillis()
 	block := model.Block{}
 	block.ID = blockID
 	block.BoardID = boardID
+	block.ParentID = parentID
 	block.UpdateAt = now
 	block.DeleteAt = now
 
@@ -528,7 +801,16 @@ func (ws *Server) BroadcastBlockDelete(teamID, blockID, boardID string) {
 
 // BroadcastBlockChange broadcasts update messages to clients.
 func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
-	blockIDsToNotify := []string{block.ID, block.ParentID}
+	ws.broadcastBlockChange(teamID, block)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventBlockChange, TeamID: teamID, Block: &block})
+}
+
+func (ws *Server) broadcastBlockChange(teamID string, block model.Block) {
+	blockIDsToNotify := ws.getBlockIDsToNotify(block)
+	if resync, text, revision := ws.textEditor.blockChanged(block); resync {
+		ws.broadcastTextMessage(teamID, &block, textResyncMessage(block.ID, text, revision), "")
+	}
+	ws.crdtText.blockChanged(block)
 
 	message := UpdateBlockMsg{
 		Action: websocketActionUpdateBlock,
@@ -550,6 +832,25 @@ func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
 			mlog.String("blockID", blockID),
 		)
 	}
+	// a client subscribed to the team and to a card of the board would
+	// get the change twice otherwise
+	listeners = uniqueListeners(listeners)
+
+	if len(listeners) == 0 {
+		return
+	}
+
+	viewers, restricted, err := getRestrictedBlockViewers(ws.store, block)
+	if err != nil {
+		ws.logger.Error("error getting viewers for restricted block",
+			mlog.String("blockID", block.ID),
+			mlog.Err(err),
+		)
+		return
+	}
+	if restricted {
+		listeners = ws.filterListenersByUserIDs(listeners, viewers)
+	}
 
 	for _, listener := range listeners {
 		ws.logger.Debug("Broadcast block change",
@@ -558,7 +859,7 @@ func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
 			mlog.Stringer("remoteAddr", listener.conn.RemoteAddr()),
 		)
 
-		err := listener.WriteJSON(message)
+		err := listener.queueBlockChange(message)
 		if err != nil {
 			ws.logger.Error("broadcast error", mlog.Err(err))
 			listener.conn.Close()
@@ -567,6 +868,11 @@ func (ws *Server) BroadcastBlockChange(teamID string, block model.Block) {
 }
 
 func (ws *Server) BroadcastCategoryChange(category model.Category) {
+	ws.broadcastCategoryChange(category)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventCategoryChange, Category: &category})
+}
+
+func (ws *Server) broadcastCategoryChange(category model.Category) {
 	message := UpdateCategoryMessage{
 		Action:   websocketActionUpdateCategory,
 		TeamID:   category.TeamID,
@@ -596,6 +902,11 @@ func (ws *Server) BroadcastCategoryChange(category model.Category) {
 }
 
 func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCategory model.BoardCategoryWebsocketData) {
+	ws.broadcastCategoryBoardChange(teamID, userID, boardCategory)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventCategoryBoardChange, TeamID: teamID, UserID: userID, BoardCategory: &boardCategory})
+}
+
+func (ws *Server) broadcastCategoryBoardChange(teamID, userID string, boardCategory model.BoardCategoryWebsocketData) {
 	message := UpdateCategoryMessage{
 		Action:          websocketActionUpdateCategoryBoard,
 		TeamID:          teamID,
@@ -626,8 +937,39 @@ func (ws *Server) BroadcastCategoryBoardChange(teamID, userID string, boardCateg
 	}
 }
 
+// BroadcastBoardLimitsChange sends the new limits to all the clients so
+// they can re-render the limited cards.
+func (ws *Server) BroadcastBoardLimitsChange(limits model.BoardLimits) {
+	ws.broadcastBoardLimitsChange(limits)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventBoardLimitsChange, BoardLimits: &limits})
+}
+
+func (ws *Server) broadcastBoardLimitsChange(limits model.BoardLimits) {
+	message := UpdateBoardLimitsMsg{
+		Action:      websocketActionUpdateBoardLimits,
+		BoardLimits: limits,
+	}
+
+	listeners := ws.listeners
+	ws.logger.Debug("broadcasting board limits change to listener(s)",
+		mlog.Int("listener_count", len(listeners)),
+	)
+
+	for listener := range listeners {
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("broadcast board limits change error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
 // BroadcastConfigChange broadcasts update messages to clients.
 func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
+	ws.broadcastConfigChange(clientConfig)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventConfigChange, ClientConfig: &clientConfig})
+}
+
+func (ws *Server) broadcastConfigChange(clientConfig model.ClientConfig) {
 	message := UpdateClientConfig{
 		Action:       websocketActionUpdateConfig,
 		ClientConfig: clientConfig,
@@ -651,6 +993,11 @@ func (ws *Server) BroadcastConfigChange(clientConfig model.ClientConfig) {
 }
 
 func (ws *Server) BroadcastBoardChange(teamID string, board *model.Board) {
+	ws.broadcastBoardChange(teamID, board)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventBoardChange, TeamID: teamID, Board: board})
+}
+
+func (ws *Server) broadcastBoardChange(teamID string, board *model.Board) {
 	message := UpdateBoardMsg{
 		Action: websocketActionUpdateBoard,
 		TeamID: teamID,
@@ -691,6 +1038,11 @@ func (ws *Server) BroadcastBoardDelete(teamID, boardID string) {
 }
 
 func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
+	ws.broadcastMemberChange(teamID, boardID, member)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventMemberChange, TeamID: teamID, BoardID: boardID, Member: member})
+}
+
+func (ws *Server) broadcastMemberChange(teamID, boardID string, member *model.BoardMember) {
 	message := UpdateMemberMsg{
 		Action: websocketActionUpdateMember,
 		TeamID: teamID,
@@ -720,6 +1072,11 @@ func (ws *Server) BroadcastMemberChange(teamID, boardID string, member *model.Bo
 }
 
 func (ws *Server) BroadcastMemberDelete(teamID, boardID, userID string) {
+	ws.broadcastMemberDelete(teamID, boardID, userID)
+	ws.publishClusterEvent(&clusterEvent{Type: clusterEventMemberDelete, TeamID: teamID, BoardID: boardID, UserID: userID})
+}
+
+func (ws *Server) broadcastMemberDelete(teamID, boardID, userID string) {
 	message := UpdateMemberMsg{
 		Action: websocketActionDeleteMember,
 		TeamID: teamID,
@@ -1,14 +1,24 @@
 package ws
 
 import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/mattermost/focalboard/server/auth"
 	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/config"
+	"github.com/mattermost/focalboard/server/services/store/mockstore"
+	"github.com/mattermost/focalboard/server/utils"
 
 	"github.com/mattermost/mattermost-server/v6/shared/mlog"
 
+	"github.com/golang/mock/gomock"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/require"
 )
@@ -239,3 +249,58 @@ func TestGetUserIDForTokenInSingleUserMode(t *testing.T) {
 		require.Equal(t, model.SingleUser, server.getUserIDForToken(singleUserToken))
 	})
 }
+
+func TestCheckListenerSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockStore := mockstore.NewMockStore(ctrl)
+	cfg := &config.Configuration{SessionIdleTimeout: 60}
+	server := NewServer(auth.New(cfg, mockStore, nil), "", false, mlog.CreateConsoleTestLogger(true, mlog.LvlDebug), nil)
+
+	sessions := make(chan *websocketSession)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := server.upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		sessions <- &websocketSession{conn: conn, userID: "user-id", sessionToken: "token"}
+	}))
+	defer httpServer.Close()
+
+	connect := func() (*websocket.Conn, *websocketSession) {
+		client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+		require.NoError(t, err)
+		return client, <-sessions
+	}
+
+	t.Run("sessions checked recently aren't checked again", func(t *testing.T) {
+		client, session := connect()
+		defer client.Close()
+		session.sessionCheckedAt = time.Now()
+
+		require.True(t, server.checkListenerSession(session, false))
+	})
+
+	t.Run("active sessions keep the connection open", func(t *testing.T) {
+		client, session := connect()
+		defer client.Close()
+		now := utils.GetMillis()
+		mockStore.EXPECT().GetSession("token", gomock.Any()).Return(&model.Session{ID: "session-id", Token: "token", CreateAt: now, UpdateAt: now}, nil)
+
+		require.True(t, server.checkListenerSession(session, false))
+		require.False(t, session.sessionCheckedAt.IsZero())
+	})
+
+	t.Run("idle sessions close the connection", func(t *testing.T) {
+		client, session := connect()
+		defer client.Close()
+		now := utils.GetMillis()
+		mockStore.EXPECT().GetSession("token", gomock.Any()).Return(&model.Session{ID: "session-id", Token: "token", CreateAt: now, UpdateAt: now - utils.SecondsToMillis(120)}, nil)
+		mockStore.EXPECT().DeleteSession("session-id").Return(nil)
+
+		require.False(t, server.checkListenerSession(session, false))
+
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+		_, _, err := client.ReadMessage()
+		require.Error(t, err)
+		var netErr net.Error
+		require.False(t, errors.As(err, &netErr) && netErr.Timeout(), "the connection should be closed")
+	})
+}
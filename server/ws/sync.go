@@ -0,0 +1,65 @@
+package ws
+
+import (
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// syncSince replies to a SYNC_SINCE command, sent by a client reconnecting
+// with the last update it saw on each board, with the blocks of the boards
+// changed since then, so that it doesn't have to fetch the whole boards
+// again. The boards the user can't view are skipped.
+func (ws *Server) syncSince(listener *websocketSession, command WebsocketCommand) {
+	for boardID, since := range command.Since {
+		board, err := ws.store.GetBoard(boardID)
+		if err != nil {
+			ws.logger.Error("error getting the synced board", mlog.String("boardID", boardID), mlog.Err(err))
+			continue
+		}
+		if !ws.canViewBoard(listener.userID, board) {
+			ws.logger.Error("WS user doesn't have board access", mlog.String("boardID", boardID), mlog.String("userID", listener.userID))
+			continue
+		}
+
+		blocks, err := ws.store.GetBlocksModifiedSince(boardID, since)
+		if err != nil {
+			ws.logger.Error("error getting the blocks modified since", mlog.String("boardID", boardID), mlog.Err(err))
+			continue
+		}
+
+		message := SyncBlocksMsg{
+			Action:  websocketActionSyncBlocks,
+			TeamID:  board.TeamID,
+			BoardID: boardID,
+			Since:   since,
+			Blocks:  ws.filterViewableBlocks(listener.userID, blocks),
+		}
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("sync error", mlog.Err(err))
+			listener.conn.Close()
+			return
+		}
+	}
+}
+
+// filterViewableBlocks removes the blocks of restricted cards and views the
+// user isn't allowed to see, like the broadcasts of their changes do.
+func (ws *Server) filterViewableBlocks(userID string, blocks []model.Block) []model.Block {
+	filtered := make([]model.Block, 0, len(blocks))
+	for _, block := range blocks {
+		viewers, restricted, err := getRestrictedBlockViewers(ws.store, block)
+		if err != nil {
+			ws.logger.Error("error getting viewers for restricted block",
+				mlog.String("blockID", block.ID),
+				mlog.Err(err),
+			)
+			continue
+		}
+		if restricted && len(filterUserIDs([]string{userID}, viewers)) == 0 {
+			continue
+		}
+		filtered = append(filtered, block)
+	}
+	return filtered
+}
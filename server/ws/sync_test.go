@@ -0,0 +1,73 @@
+package ws
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/auth"
+	"github.com/mattermost/focalboard/server/model"
+	wsMocks "github.com/mattermost/focalboard/server/ws/mocks"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	store := wsMocks.NewMockStore(ctrl)
+	server := NewServer(&auth.Auth{}, "token", false, mlog.CreateConsoleTestLogger(true, mlog.LvlDebug), store)
+
+	sessions := make(chan *websocketSession)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := server.upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		sessions <- &websocketSession{conn: conn, userID: model.SingleUser}
+	}))
+	defer httpServer.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+	session := <-sessions
+
+	board := &model.Board{ID: "board-id", TeamID: "team-id", Type: model.BoardTypeOpen}
+	changed := []model.Block{
+		{ID: "card-1", BoardID: "board-id", Type: model.TypeCard, UpdateAt: 200},
+		{ID: "card-2", BoardID: "board-id", Type: model.TypeCard, UpdateAt: 300, DeleteAt: 300},
+	}
+
+	t.Run("the blocks changed since the last update are sent", func(t *testing.T) {
+		store.EXPECT().GetBoard("board-id").Return(board, nil)
+		store.EXPECT().GetBlocksModifiedSince("board-id", int64(100)).Return(changed, nil)
+
+		server.syncSince(session, WebsocketCommand{Action: websocketActionSyncSince, Since: map[string]int64{"board-id": 100}})
+
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var message SyncBlocksMsg
+		require.NoError(t, client.ReadJSON(&message))
+		require.Equal(t, websocketActionSyncBlocks, message.Action)
+		require.Equal(t, "team-id", message.TeamID)
+		require.Equal(t, "board-id", message.BoardID)
+		require.Equal(t, int64(100), message.Since)
+		require.Len(t, message.Blocks, 2)
+		require.Equal(t, "card-1", message.Blocks[0].ID)
+		require.Equal(t, int64(300), message.Blocks[1].DeleteAt)
+	})
+
+	t.Run("unknown boards are skipped", func(t *testing.T) {
+		store.EXPECT().GetBoard("unknown").Return(nil, errors.New("not found"))
+
+		server.syncSince(session, WebsocketCommand{Action: websocketActionSyncSince, Since: map[string]int64{"unknown": 100}})
+
+		require.NoError(t, client.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+		var message SyncBlocksMsg
+		require.Error(t, client.ReadJSON(&message))
+	})
+}
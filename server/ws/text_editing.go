@@ -0,0 +1,274 @@
+package ws
+
+import (
+	"errors"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+// textCommand is a command of a client on a text block edited
+// collaboratively, run by the node owning the block. NodeID and SessionID
+// identify the client the replies are sent to.
+type textCommand struct {
+	Action    string              `json:"action"`
+	NodeID    string              `json:"nodeId"`
+	SessionID string              `json:"sessionId"`
+	UserID    string              `json:"userId"`
+	BlockID   string              `json:"blockId"`
+	Revision  int64               `json:"revision"`
+	Operation model.TextOperation `json:"operation,omitempty"`
+}
+
+// SetTextPersister sets where the text of blocks edited collaboratively
+// is saved.
+func (ws *Server) SetTextPersister(persister TextPersister) {
+	ws.textEditor.setPersister(persister)
+}
+
+// FlushTextEdits saves the pending collaborative text edits, made with
+// text operations or CRDT updates.
+func (ws *Server) FlushTextEdits() {
+	ws.textEditor.flush()
+	ws.crdtText.flush()
+}
+
+// handleTextCommand checks that the client can edit the text block and
+// runs its SYNC_TEXT or EDIT_TEXT command on the node owning the block.
+func (ws *Server) handleTextCommand(wsSession *websocketSession, command WebsocketCommand) {
+	block, err := ws.store.GetBlock(command.BlockID)
+	if err != nil || block == nil {
+		ws.logger.Error("cannot get block to edit text", mlog.String("blockID", command.BlockID), mlog.Err(err))
+		return
+	}
+	if block.Type != model.TypeText {
+		ws.logger.Error("collaborative editing is only supported for text blocks",
+			mlog.String("blockID", block.ID),
+			mlog.String("type", string(block.Type)),
+		)
+		return
+	}
+
+	if !ws.canEditText(wsSession.userID, block) {
+		ws.logger.Error("WS user can't edit the text block",
+			mlog.String("blockID", block.ID),
+			mlog.String("userID", wsSession.userID),
+		)
+		return
+	}
+
+	if command.Action == websocketActionEditText {
+		if err := command.Operation.IsValid(); err != nil {
+			ws.logger.Error("invalid text operation", mlog.String("blockID", block.ID), mlog.Err(err))
+			return
+		}
+	}
+
+	ws.routeTextCommand(block, &textCommand{
+		Action:    command.Action,
+		NodeID:    ws.nodeID,
+		SessionID: wsSession.id,
+		UserID:    wsSession.userID,
+		BlockID:   block.ID,
+		Revision:  command.Revision,
+		Operation: command.Operation,
+	})
+}
+
+// routeTextCommand runs the command if the node owns the block, or
+// forwards it to the node owning it through the cluster.
+func (ws *Server) routeTextCommand(block *model.Block, command *textCommand) {
+	owner, err := ws.textOwners.owner(block.ID)
+	if err != nil {
+		ws.logger.Error("cannot get the node owning the text block", mlog.String("blockID", block.ID), mlog.Err(err))
+		return
+	}
+
+	if owner != ws.nodeID {
+		ws.publishClusterEvent(&clusterEvent{Type: clusterEventTextCommand, ToNodeID: owner, TextCommand: command})
+		return
+	}
+	ws.runTextCommand(block, command)
+}
+
+// runTextCommand runs a command on the document of a block owned by the
+// node. The operations are acknowledged to their client and sent to the
+// other clients following the block, on all the nodes. Clients whose
+// revision can't be transformed, or whose operation doesn't apply, get
+// the whole text back.
+func (ws *Server) runTextCommand(block *model.Block, command *textCommand) {
+	if command.Action == websocketActionSyncText {
+		ws.syncText(block, command)
+		return
+	}
+
+	board, err := ws.store.GetBoard(block.BoardID)
+	if err != nil {
+		ws.logger.Error("cannot get board to edit text", mlog.String("boardID", block.BoardID), mlog.Err(err))
+		return
+	}
+
+	op, revision, err := ws.textEditor.apply(block, command.UserID, command.Revision, command.Operation)
+	if errors.Is(err, errTextNotOwner) {
+		// the document was unloaded and the block released meanwhile
+		ws.routeTextCommand(block, command)
+		return
+	}
+	if err != nil {
+		ws.logger.Debug("resyncing collaborative text edit",
+			mlog.String("blockID", block.ID),
+			mlog.Int64("revision", command.Revision),
+			mlog.Err(err),
+		)
+		ws.syncText(block, command)
+		return
+	}
+
+	ws.sendTextMessage(command.NodeID, command.SessionID, UpdateTextMsg{
+		Action:   websocketActionAckText,
+		BlockID:  block.ID,
+		Revision: revision,
+	})
+
+	ws.broadcastTextMessage(board.TeamID, block, UpdateTextMsg{
+		Action:    websocketActionUpdateText,
+		BlockID:   block.ID,
+		Revision:  revision,
+		Operation: op,
+		UserID:    command.UserID,
+	}, command.SessionID)
+}
+
+// syncText sends the current text of the block and its revision to the
+// client of the command.
+func (ws *Server) syncText(block *model.Block, command *textCommand) {
+	text, revision, err := ws.textEditor.snapshot(block)
+	if errors.Is(err, errTextNotOwner) {
+		ws.routeTextCommand(block, command)
+		return
+	}
+	if err != nil {
+		ws.logger.Error("cannot load the collaborative text", mlog.String("blockID", block.ID), mlog.Err(err))
+		return
+	}
+	ws.sendTextMessage(command.NodeID, command.SessionID, textResyncMessage(block.ID, text, revision))
+}
+
+// handleClusterTextCommand runs a command forwarded by another node.
+func (ws *Server) handleClusterTextCommand(command *textCommand) {
+	block, err := ws.store.GetBlock(command.BlockID)
+	if err != nil || block == nil {
+		ws.logger.Error("cannot get block to edit text", mlog.String("blockID", command.BlockID), mlog.Err(err))
+		return
+	}
+	ws.runTextCommand(block, command)
+}
+
+// sendTextMessage sends a message to a client, through the cluster if it
+// is connected to another node.
+func (ws *Server) sendTextMessage(nodeID, sessionID string, message UpdateTextMsg) {
+	if nodeID != ws.nodeID {
+		ws.publishClusterEvent(&clusterEvent{Type: clusterEventTextMessage, ToNodeID: nodeID, SessionID: sessionID, TextMessage: &message})
+		return
+	}
+
+	listener := ws.getListenerByID(sessionID)
+	if listener == nil {
+		return
+	}
+	if err := listener.WriteJSON(message); err != nil {
+		ws.logger.Error("text message error", mlog.Err(err))
+		listener.conn.Close()
+	}
+}
+
+// broadcastTextMessage sends a message to the clients following the block
+// on all the nodes, but to the client with the given session, if any.
+func (ws *Server) broadcastTextMessage(teamID string, block *model.Block, message UpdateTextMsg, exceptSessionID string) {
+	ws.sendTextMessageToListeners(teamID, block, message, exceptSessionID)
+	ws.publishClusterEvent(&clusterEvent{
+		Type:        clusterEventTextBroadcast,
+		TeamID:      teamID,
+		Block:       block,
+		SessionID:   exceptSessionID,
+		TextMessage: &message,
+	})
+}
+
+func (ws *Server) sendTextMessageToListeners(teamID string, block *model.Block, message UpdateTextMsg, exceptSessionID string) {
+	for _, listener := range ws.getListenersForText(teamID, block) {
+		if exceptSessionID != "" && listener.id == exceptSessionID {
+			continue
+		}
+		if err := listener.WriteJSON(message); err != nil {
+			ws.logger.Error("broadcast text error", mlog.Err(err))
+			listener.conn.Close()
+		}
+	}
+}
+
+// canEditText returns true if the user is an editor of the board of the
+// block and can see its card.
+func (ws *Server) canEditText(userID string, block *model.Block) bool {
+	if len(ws.singleUserToken) != 0 {
+		return userID == model.SingleUser
+	}
+
+	members, err := ws.store.GetMembersForBoard(block.BoardID)
+	if err != nil {
+		ws.logger.Error("cannot get board members to edit text", mlog.String("boardID", block.BoardID), mlog.Err(err))
+		return false
+	}
+	isEditor := false
+	for _, member := range members {
+		if member.UserID == userID && (member.SchemeAdmin || member.SchemeEditor) {
+			isEditor = true
+			break
+		}
+	}
+	if !isEditor {
+		return false
+	}
+
+	viewers, restricted, err := getRestrictedBlockViewers(ws.store, *block)
+	if err != nil {
+		ws.logger.Error("cannot get viewers to edit text", mlog.String("blockID", block.ID), mlog.Err(err))
+		return false
+	}
+	if restricted {
+		return len(filterUserIDs([]string{userID}, viewers)) == 1
+	}
+	return true
+}
+
+// getListenersForText returns the listeners that receive the changes of
+// the block, as for block broadcasts.
+func (ws *Server) getListenersForText(teamID string, block *model.Block) []*websocketSession {
+	listeners := ws.getListenersForTeamAndBoard(teamID, block.BoardID)
+	listeners = append(listeners, ws.getListenersForBlock(block.ID)...)
+	listeners = append(listeners, ws.getListenersForBlock(block.ParentID)...)
+	listeners = uniqueListeners(listeners)
+
+	viewers, restricted, err := getRestrictedBlockViewers(ws.store, *block)
+	if err != nil {
+		ws.logger.Error("error getting viewers for restricted block",
+			mlog.String("blockID", block.ID),
+			mlog.Err(err),
+		)
+		return nil
+	}
+	if restricted {
+		listeners = ws.filterListenersByUserIDs(listeners, viewers)
+	}
+	return listeners
+}
+
+func textResyncMessage(blockID, text string, revision int64) UpdateTextMsg {
+	return UpdateTextMsg{
+		Action:   websocketActionResyncText,
+		BlockID:  blockID,
+		Revision: revision,
+		Text:     &text,
+	}
+}
@@ -0,0 +1,290 @@
+package ws
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const (
+	textPersistDelay = 2 * time.Second
+	textIdleTimeout  = 5 * time.Minute
+	maxTextHistory   = 500
+)
+
+var (
+	errTextRevisionUnknown = errors.New("text revision is not known")
+	errTextNotOwner        = errors.New("the text block is owned by another node")
+)
+
+// TextPersister saves the text of a block merged from collaborative edits.
+type TextPersister interface {
+	SaveBlockText(blockID, text, userID string) error
+}
+
+// textDocument is the server state of a text block being edited
+// collaboratively. The history holds the operations that produced the
+// last len(history) revisions.
+type textDocument struct {
+	text     string
+	revision int64
+	history  []model.TextOperation
+	userID   string
+	dirty    bool
+	lastEdit time.Time
+	timer    *time.Timer
+}
+
+// textEditor merges the concurrent operations of the clients editing the
+// same text blocks. Operations are transformed against the ones applied
+// since the revision the client based them on, so no edit overwrites
+// another, and the merged text is saved shortly after the last edit.
+// Documents are only loaded on the node owning their block, which runs
+// the commands of the clients of all the nodes.
+type textEditor struct {
+	mu           sync.Mutex
+	persister    TextPersister
+	logger       *mlog.Logger
+	owners       *textOwners
+	documents    map[string]*textDocument
+	persistDelay time.Duration
+	idleTimeout  time.Duration
+}
+
+func newTextEditor(logger *mlog.Logger, owners *textOwners) *textEditor {
+	return &textEditor{
+		logger:       logger,
+		owners:       owners,
+		documents:    map[string]*textDocument{},
+		persistDelay: textPersistDelay,
+		idleTimeout:  textIdleTimeout,
+	}
+}
+
+func (te *textEditor) setPersister(persister TextPersister) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+	te.persister = persister
+}
+
+// firstTextRevision returns the revision of a document when it is
+// loaded, the current time in microseconds. It follows the revisions of
+// the previous copies of the document, on this node or on the node that
+// owned the block before, so the operations based on them are resynced
+// rather than transformed against the wrong history.
+func firstTextRevision() int64 {
+	return time.Now().UnixNano() / int64(time.Microsecond)
+}
+
+// load returns the document of the block, loading it from the block if
+// it isn't being edited. It must be called with the lock held.
+func (te *textEditor) load(block *model.Block) (*textDocument, error) {
+	if doc, ok := te.documents[block.ID]; ok {
+		return doc, nil
+	}
+
+	if !te.owners.holds(block.ID) {
+		return nil, errTextNotOwner
+	}
+	doc := &textDocument{text: block.Title, revision: firstTextRevision(), lastEdit: time.Now()}
+	te.documents[block.ID] = doc
+	te.schedule(block.ID, doc, te.idleTimeout)
+	return doc, nil
+}
+
+// apply transforms an operation the user made on the given revision of
+// the block text, applies it and returns the transformed operation with
+// the new revision.
+func (te *textEditor) apply(block *model.Block, userID string, revision int64, op model.TextOperation) (model.TextOperation, int64, error) {
+	if err := op.IsValid(); err != nil {
+		return nil, 0, err
+	}
+
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	doc, err := te.load(block)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	oldest := doc.revision - int64(len(doc.history))
+	if revision < oldest || revision > doc.revision {
+		return nil, 0, errTextRevisionUnknown
+	}
+
+	for _, concurrent := range doc.history[revision-oldest:] {
+		transformed, _, err := model.TransformTextOperations(op, concurrent)
+		if err != nil {
+			return nil, 0, err
+		}
+		op = transformed
+	}
+
+	text, err := op.Apply(doc.text)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	doc.text = text
+	doc.revision++
+	doc.history = append(doc.history, op)
+	if len(doc.history) > maxTextHistory {
+		doc.history = doc.history[len(doc.history)-maxTextHistory:]
+	}
+	doc.userID = userID
+	doc.dirty = true
+	doc.lastEdit = time.Now()
+	te.schedule(block.ID, doc, te.persistDelay)
+
+	return op, doc.revision, nil
+}
+
+// snapshot returns the current text and revision of a block, loading it
+// from the block if it isn't being edited.
+func (te *textEditor) snapshot(block *model.Block) (string, int64, error) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	doc, err := te.load(block)
+	if err != nil {
+		return "", 0, err
+	}
+	return doc.text, doc.revision, nil
+}
+
+// blockChanged updates the document of a block changed through other
+// means than collaborative edits. It returns true with the new text and
+// revision if the clients editing the block need to resync.
+func (te *textEditor) blockChanged(block model.Block) (bool, string, int64) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	doc, ok := te.documents[block.ID]
+	if !ok {
+		return false, "", 0
+	}
+
+	if block.DeleteAt != 0 {
+		te.unload(block.ID, doc)
+		return false, "", 0
+	}
+
+	// the change is either our own save or older than the pending edits,
+	// which will be saved on top of it
+	if block.Title == doc.text || doc.dirty {
+		return false, "", 0
+	}
+
+	doc.text = block.Title
+	doc.revision++
+	doc.history = nil
+	return true, doc.text, doc.revision
+}
+
+// unload drops the document of a block and frees the block for the
+// other nodes. It must be called with the lock held.
+func (te *textEditor) unload(blockID string, doc *textDocument) {
+	doc.timer.Stop()
+	delete(te.documents, blockID)
+	te.owners.release(blockID)
+}
+
+// drop drops the document of a block whose ownership was lost, the new
+// owner loading it from the block.
+func (te *textEditor) drop(blockID string) {
+	te.mu.Lock()
+	defer te.mu.Unlock()
+
+	doc, ok := te.documents[blockID]
+	if !ok {
+		return
+	}
+	if doc.dirty {
+		te.logger.Warn("dropping unsaved collaborative text edits", mlog.String("blockID", blockID))
+	}
+	doc.timer.Stop()
+	delete(te.documents, blockID)
+}
+
+// schedule sets the next time the document is saved or, if it has no
+// pending edits, evicted. It must be called with the lock held.
+func (te *textEditor) schedule(blockID string, doc *textDocument, delay time.Duration) {
+	if doc.timer != nil {
+		doc.timer.Stop()
+	}
+	doc.timer = time.AfterFunc(delay, func() { te.onTimer(blockID) })
+}
+
+func (te *textEditor) onTimer(blockID string) {
+	te.mu.Lock()
+	doc, ok := te.documents[blockID]
+	if !ok {
+		te.mu.Unlock()
+		return
+	}
+
+	if !doc.dirty {
+		if time.Since(doc.lastEdit) >= te.idleTimeout {
+			te.unload(blockID, doc)
+		}
+		te.mu.Unlock()
+		return
+	}
+
+	text, userID := doc.text, doc.userID
+	doc.dirty = false
+	te.schedule(blockID, doc, te.idleTimeout)
+	te.mu.Unlock()
+
+	te.persist(blockID, text, userID)
+}
+
+// persist saves the text outside of the lock, as saving broadcasts the
+// block change back to the editor. Failed saves are retried on the next
+// timer.
+func (te *textEditor) persist(blockID, text, userID string) {
+	te.mu.Lock()
+	persister := te.persister
+	te.mu.Unlock()
+
+	if persister == nil {
+		te.logger.Warn("no persister to save collaborative text edits", mlog.String("blockID", blockID))
+		return
+	}
+
+	if err := persister.SaveBlockText(blockID, text, userID); err != nil {
+		te.logger.Error("cannot save collaborative text edits",
+			mlog.String("blockID", blockID),
+			mlog.Err(err),
+		)
+		te.mu.Lock()
+		if doc, ok := te.documents[blockID]; ok {
+			doc.dirty = true
+		}
+		te.mu.Unlock()
+	}
+}
+
+// flush saves the pending edits of all the documents.
+func (te *textEditor) flush() {
+	type pending struct{ blockID, text, userID string }
+
+	te.mu.Lock()
+	toSave := []pending{}
+	for blockID, doc := range te.documents {
+		if doc.dirty {
+			toSave = append(toSave, pending{blockID, doc.text, doc.userID})
+			doc.dirty = false
+		}
+	}
+	te.mu.Unlock()
+
+	for _, p := range toSave {
+		te.persist(p.blockID, p.text, p.userID)
+	}
+}
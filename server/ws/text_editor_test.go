@@ -0,0 +1,135 @@
+package ws
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattermost/focalboard/server/model"
+	"github.com/mattermost/focalboard/server/services/redis"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+
+	"github.com/stretchr/testify/require"
+)
+
+type savedText struct {
+	blockID, text, userID string
+}
+
+type testTextPersister struct {
+	mu    sync.Mutex
+	saved []savedText
+}
+
+func (p *testTextPersister) SaveBlockText(blockID, text, userID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.saved = append(p.saved, savedText{blockID, text, userID})
+	return nil
+}
+
+func newTestTextEditor() *textEditor {
+	logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+	return newTextEditor(logger, newTextOwners("node-id", logger))
+}
+
+func TestTextEditor(t *testing.T) {
+	block := &model.Block{ID: "block-id", BoardID: "board-id", Type: model.TypeText, Title: "hello world"}
+
+	t.Run("concurrent operations are merged", func(t *testing.T) {
+		editor := newTestTextEditor()
+		_, start, err := editor.snapshot(block)
+		require.NoError(t, err)
+
+		// both users edit the first revision
+		_, revision, err := editor.apply(block, "user-1", start, model.TextOperation{{Retain: 5}, {Insert: ","}, {Retain: 6}})
+		require.NoError(t, err)
+		require.Equal(t, start+1, revision)
+
+		op, revision, err := editor.apply(block, "user-2", start, model.TextOperation{{Retain: 11}, {Insert: "!"}})
+		require.NoError(t, err)
+		require.Equal(t, start+2, revision)
+		require.Equal(t, model.TextOperation{{Retain: 12}, {Insert: "!"}}, op)
+
+		text, revision, err := editor.snapshot(block)
+		require.NoError(t, err)
+		require.Equal(t, "hello, world!", text)
+		require.Equal(t, start+2, revision)
+	})
+
+	t.Run("unknown revision", func(t *testing.T) {
+		editor := newTestTextEditor()
+
+		_, _, err := editor.apply(block, "user-1", 3, model.TextOperation{{Retain: 11}})
+		require.ErrorIs(t, err, errTextRevisionUnknown)
+	})
+
+	t.Run("a document loaded again starts after the revisions of the previous one", func(t *testing.T) {
+		editor := newTestTextEditor()
+		_, start, err := editor.snapshot(block)
+		require.NoError(t, err)
+		_, _, err = editor.apply(block, "user-1", start, model.TextOperation{{Retain: 11}, {Insert: "!"}})
+		require.NoError(t, err)
+
+		editor.drop(block.ID)
+		_, _, err = editor.apply(block, "user-1", start+1, model.TextOperation{{Retain: 11}, {Insert: "?"}})
+		require.ErrorIs(t, err, errTextRevisionUnknown)
+	})
+
+	t.Run("only the blocks owned by the node are loaded", func(t *testing.T) {
+		logger := mlog.CreateConsoleTestLogger(false, mlog.LvlDebug)
+		owners := newTextOwners("node-id", logger)
+		owners.client = redis.NewClient(redis.Options{Address: "127.0.0.1:0"})
+		editor := newTextEditor(logger, owners)
+
+		_, _, err := editor.snapshot(block)
+		require.ErrorIs(t, err, errTextNotOwner)
+	})
+
+	t.Run("edits are saved after a delay", func(t *testing.T) {
+		persister := &testTextPersister{}
+		editor := newTestTextEditor()
+		editor.persistDelay = 10 * time.Millisecond
+		editor.setPersister(persister)
+		_, start, err := editor.snapshot(block)
+		require.NoError(t, err)
+
+		_, _, err = editor.apply(block, "user-1", start, model.TextOperation{{Delete: 6}, {Retain: 5}})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			persister.mu.Lock()
+			defer persister.mu.Unlock()
+			return len(persister.saved) == 1
+		}, time.Second, 10*time.Millisecond)
+		require.Equal(t, savedText{"block-id", "world", "user-1"}, persister.saved[0])
+
+		// the save is broadcast back and doesn't need a resync
+		saved := *block
+		saved.Title = "world"
+		resync, _, _ := editor.blockChanged(saved)
+		require.False(t, resync)
+	})
+
+	t.Run("external changes resync the clients", func(t *testing.T) {
+		editor := newTestTextEditor()
+		editor.persistDelay = time.Hour
+		_, start, err := editor.snapshot(block)
+		require.NoError(t, err)
+
+		_, _, err = editor.apply(block, "user-1", start, model.TextOperation{{Retain: 11}, {Insert: "!"}})
+		require.NoError(t, err)
+		editor.flush()
+
+		changed := *block
+		changed.Title = "goodbye"
+		resync, text, revision := editor.blockChanged(changed)
+		require.True(t, resync)
+		require.Equal(t, "goodbye", text)
+		require.Equal(t, start+2, revision)
+
+		_, _, err = editor.apply(block, "user-1", start+1, model.TextOperation{{Retain: 7}})
+		require.ErrorIs(t, err, errTextRevisionUnknown)
+	})
+}
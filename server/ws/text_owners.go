@@ -0,0 +1,181 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mattermost/focalboard/server/services/redis"
+
+	"github.com/mattermost/mattermost-server/v6/shared/mlog"
+)
+
+const textOwnerLease = 30 * time.Second
+
+// textClaimScript sets the node as the owner of a text block if the block
+// has no owner, or renews the lease if the node already owns it, and
+// returns the owner.
+const textClaimScript = `
+local owner = redis.call('GET', KEYS[1])
+if owner == false or owner == ARGV[1] then
+  redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+  return ARGV[1]
+end
+return owner
+`
+
+// textReleaseScript frees a text block if the node owns it.
+const textReleaseScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+  return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+// textOwners pins each text block edited collaboratively to the node
+// holding its document, so that the operations of the clients of all the
+// nodes are transformed against the same history. The owner of a block
+// is kept in Redis with a lease that the node renews while it holds the
+// document, and that expires if the node stops. Without a cluster, the
+// node owns all the blocks.
+type textOwners struct {
+	nodeID string
+	logger *mlog.Logger
+
+	mu     sync.Mutex
+	client *redis.Client
+	prefix string
+	held   map[string]bool
+	lost   func(blockID string)
+	done   chan struct{}
+}
+
+func newTextOwners(nodeID string, logger *mlog.Logger) *textOwners {
+	return &textOwners{
+		nodeID: nodeID,
+		logger: logger,
+		held:   map[string]bool{},
+	}
+}
+
+// start keeps the owners in Redis, with the keys prefixed by prefix. The
+// blocks whose lease is lost are passed to lost.
+func (o *textOwners) start(client *redis.Client, prefix string, lost func(blockID string)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.client = client
+	o.prefix = prefix
+	o.lost = lost
+	o.done = make(chan struct{})
+	go o.renew(o.done)
+}
+
+// stop releases the blocks owned by the node.
+func (o *textOwners) stop() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.client == nil {
+		return
+	}
+
+	close(o.done)
+	for blockID := range o.held {
+		o.releaseLocked(blockID)
+	}
+	o.client = nil
+	o.held = map[string]bool{}
+}
+
+// owner returns the node owning the block, claiming it for this node if
+// nobody does.
+func (o *textOwners) owner(blockID string) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.client == nil || o.held[blockID] {
+		return o.nodeID, nil
+	}
+
+	owner, err := o.claimLocked(blockID)
+	if err != nil {
+		return "", err
+	}
+	if owner == o.nodeID {
+		o.held[blockID] = true
+	}
+	return owner, nil
+}
+
+// holds returns true if the node owns the block.
+func (o *textOwners) holds(blockID string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.client == nil || o.held[blockID]
+}
+
+// release frees the block, once the node doesn't hold its document
+// anymore.
+func (o *textOwners) release(blockID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.releaseLocked(blockID)
+}
+
+func (o *textOwners) releaseLocked(blockID string) {
+	if !o.held[blockID] {
+		return
+	}
+	delete(o.held, blockID)
+
+	if _, err := o.client.Do("EVAL", textReleaseScript, 1, o.prefix+blockID, o.nodeID); err != nil {
+		o.logger.Warn("Cannot release a collaborative text block", mlog.String("blockID", blockID), mlog.Err(err))
+	}
+}
+
+func (o *textOwners) claimLocked(blockID string) (string, error) {
+	reply, err := o.client.Do("EVAL", textClaimScript, 1, o.prefix+blockID, o.nodeID, textOwnerLease.Milliseconds())
+	if err != nil {
+		return "", err
+	}
+	owner, _ := reply.(string)
+	return owner, nil
+}
+
+// renew renews the leases of the blocks owned by the node until the
+// owners are stopped. A block whose lease was taken by another node,
+// which happens only if the node couldn't reach Redis for the whole
+// lease, is given up.
+func (o *textOwners) renew(done chan struct{}) {
+	ticker := time.NewTicker(textOwnerLease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		o.mu.Lock()
+		lostBlockIDs := []string{}
+		for blockID := range o.held {
+			owner, err := o.claimLocked(blockID)
+			if err != nil {
+				o.logger.Warn("Cannot renew a collaborative text block", mlog.String("blockID", blockID), mlog.Err(err))
+				continue
+			}
+			if owner != o.nodeID {
+				delete(o.held, blockID)
+				lostBlockIDs = append(lostBlockIDs, blockID)
+			}
+		}
+		lost := o.lost
+		o.mu.Unlock()
+
+		for _, blockID := range lostBlockIDs {
+			o.logger.Warn("Collaborative text block taken by another node", mlog.String("blockID", blockID))
+			lost(blockID)
+		}
+	}
+}
@@ -79,6 +79,7 @@ interface IPropertyOption {
     id: string
     value: string
     color: string
+    icon?: string
 }
 
 // A template for card properties attached to a board
@@ -15,7 +15,7 @@ import React, {
 import {debounce} from "lodash"
 
 import {useAppSelector} from '../../store/hooks'
-import {IUser} from '../../user'
+import {IUser, IUserDirectoryEntry} from '../../user'
 import {getBoardUsersList} from '../../store/users'
 import createLiveMarkdownPlugin from '../live-markdown-plugin/liveMarkdownPlugin'
 
@@ -53,10 +53,10 @@ const MarkdownEditorInput = (props: Props): ReactElement => {
     const [suggestions, setSuggestions] = useState<Array<MentionUser>>([])
 
     const loadSuggestions = async (term: string) => {
-        let users: Array<IUser>
+        let users: Array<IUser | IUserDirectoryEntry>
 
         if (board && board.type === BoardTypeOpen) {
-            users = await octoClient.searchTeamUsers(term)
+            users = (await octoClient.searchUserDirectory(term)).users
         } else {
             users = boardUsers
         }
@@ -74,7 +74,7 @@ const MarkdownEditorInput = (props: Props): ReactElement => {
 
     useEffect(() => {
         // Get the ball rolling. Searching for empty string
-        // returns first 10 users in alphabetical order.
+        // returns the first page of users in alphabetical order.
         loadSuggestions('')
     }, [])
 
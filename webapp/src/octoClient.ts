@@ -4,7 +4,7 @@ import {Block, BlockPatch} from './blocks/block'
 import {Board, BoardsAndBlocks, BoardsAndBlocksPatch, BoardPatch, BoardMember} from './blocks/board'
 import {ISharing} from './blocks/sharing'
 import {OctoUtils} from './octoUtils'
-import {IUser, UserConfigPatch} from './user'
+import {IUser, UserConfigPatch, UserDirectoryPage} from './user'
 import {Utils} from './utils'
 import {ClientConfig} from './config/clientConfig'
 import {UserSettings} from './userSettings'
@@ -313,9 +313,9 @@ class OctoClient {
         const blockIds = blocks.map((block) => block.id)
         const body = JSON.stringify({block_ids: blockIds, block_patches: blockPatches})
 
-        const path = this.getBaseURL() + this.teamPath() + '/blocks'
+        const path = this.getBaseURL() + this.teamPath() + '/blocks/patch'
         const response = fetch(path, {
-            method: 'PATCH',
+            method: 'POST',
             headers: this.headers(),
             body,
         })
@@ -608,6 +608,18 @@ class OctoClient {
         return (await this.getJson(response, [])) as IUser[]
     }
 
+    // searchUserDirectory returns a page of the team members whose names
+    // start with the prefix, instead of downloading all of them.
+    async searchUserDirectory(prefix: string, page = 0, perPage = 20): Promise<UserDirectoryPage> {
+        const query = new URLSearchParams({prefix, page: String(page), per_page: String(perPage)})
+        const path = this.teamPath() + `/users/directory?${query.toString()}`
+        const response = await fetch(this.getBaseURL() + path, {headers: this.headers()})
+        if (response.status !== 200) {
+            return {users: [], page, perPage, hasNext: false}
+        }
+        return this.getJson<UserDirectoryPage>(response, {users: [], page, perPage, hasNext: false})
+    }
+
     async getTeamTemplates(teamId?: string): Promise<Board[]> {
         const path = this.teamPath(teamId) + '/templates'
         return this.getBoardsWithPath(path)
@@ -23,6 +23,24 @@ interface UserConfigPatch {
     deletedFields?: string[]
 }
 
+// A team member, as listed by the user directory for the mention
+// autocomplete and the person property pickers
+interface IUserDirectoryEntry {
+    id: string,
+    username: string,
+    nickname?: string,
+    firstName?: string,
+    lastName?: string,
+    is_bot: boolean,
+}
+
+interface UserDirectoryPage {
+    users: IUserDirectoryEntry[],
+    page: number,
+    perPage: number,
+    hasNext: boolean,
+}
+
 const UserPropPrefix = 'focalboard_'
 
-export {IUser, UserWorkspace, UserConfigPatch, UserPropPrefix}
+export {IUser, IUserDirectoryEntry, UserDirectoryPage, UserWorkspace, UserConfigPatch, UserPropPrefix}
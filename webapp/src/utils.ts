@@ -74,7 +74,16 @@ class Utils {
     static getProfilePicture(userId?: string): string {
         const defaultImageUrl = 'data:image/svg+xml,<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 100 100" style="fill: rgb(192, 192, 192);"><rect width="100" height="100" /></svg>'
 
-        return imageURLForUser && userId ? imageURLForUser(userId) : defaultImageUrl
+        if (!userId) {
+            return defaultImageUrl
+        }
+        if (imageURLForUser) {
+            return imageURLForUser(userId)
+        }
+
+        // the standalone server serves the uploaded avatars, or generates
+        // one with the initials of the user
+        return Utils.buildURL(`/api/v2/users/${encodeURIComponent(userId)}/avatar`)
     }
 
     static randomArray(size: number): Uint8Array {
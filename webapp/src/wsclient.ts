@@ -15,17 +15,20 @@ type WSCommand = {
     teamId?: string
     readToken?: string
     blockIds?: string[]
+    since?: Record<string, number>
 }
 
 // These are messages from the server
 export type WSMessage = {
     action?: string
     block?: Block
+    blocks?: Block[]
     board?: Board
     category?: Category
     blockCategories?: BoardCategoryWebsocketData
     error?: string
     teamId?: string
+    boardId?: string
     member?: BoardMember
 }
 
@@ -33,6 +36,7 @@ export const ACTION_UPDATE_BOARD = 'UPDATE_BOARD'
 export const ACTION_UPDATE_MEMBER = 'UPDATE_MEMBER'
 export const ACTION_DELETE_MEMBER = 'DELETE_MEMBER'
 export const ACTION_UPDATE_BLOCK = 'UPDATE_BLOCK'
+export const ACTION_UPDATE_BLOCKS = 'UPDATE_BLOCKS'
 export const ACTION_AUTH = 'AUTH'
 export const ACTION_SUBSCRIBE_BLOCKS = 'SUBSCRIBE_BLOCKS'
 export const ACTION_SUBSCRIBE_TEAM = 'SUBSCRIBE_TEAM'
@@ -42,6 +46,8 @@ export const ACTION_UPDATE_CLIENT_CONFIG = 'UPDATE_CLIENT_CONFIG'
 export const ACTION_UPDATE_CATEGORY = 'UPDATE_CATEGORY'
 export const ACTION_UPDATE_BOARD_CATEGORY = 'UPDATE_BOARD_CATEGORY'
 export const ACTION_UPDATE_SUBSCRIPTION = 'UPDATE_SUBSCRIPTION'
+export const ACTION_SYNC_SINCE = 'SYNC_SINCE'
+export const ACTION_SYNC_BLOCKS = 'SYNC_BLOCKS'
 
 type WSSubscriptionMsg = {
     action?: string
@@ -115,6 +121,10 @@ class WSClient {
     private notificationDelay = 100
     private reopenDelay = 3000
     private updatedData: UpdatedData = {Blocks: [], Categories: [], BoardCategories: [], Boards: [], BoardMembers: []}
+
+    // the last block update received for each board, to fetch only the
+    // changes missed while disconnected when subscribing again
+    private lastUpdateAtByBoard: Record<string, number> = {}
     private updateTimeout?: NodeJS.Timeout
     private errorPollId?: NodeJS.Timeout
 
@@ -382,6 +392,14 @@ class WSClient {
                 case ACTION_UPDATE_BLOCK:
                     this.updateHandler(message)
                     break
+                case ACTION_UPDATE_BLOCKS:
+                case ACTION_SYNC_BLOCKS:
+                    // block changes batched by the server in a single
+                    // frame, or missed while disconnected
+                    for (const block of message.blocks || []) {
+                        this.updateHandler({action: ACTION_UPDATE_BLOCK, teamId: message.teamId, block})
+                    }
+                    break
                 case ACTION_UPDATE_CATEGORY:
                     this.updateHandler(message)
                     break
@@ -536,6 +554,25 @@ class WSClient {
             teamId,
         }
 
+        this.sendCommand(command)
+        this.syncSince(teamId)
+    }
+
+    // syncSince asks the server for the blocks changed since the last
+    // update received on each board, after the connection was lost.
+    // Plugin mode relies on the Mattermost websocket replaying the
+    // missed events instead.
+    private syncSince(teamId: string): void {
+        if (this.client !== null || Object.keys(this.lastUpdateAtByBoard).length === 0) {
+            return
+        }
+
+        const command: WSCommand = {
+            action: ACTION_SYNC_SINCE,
+            teamId,
+            since: this.lastUpdateAtByBoard,
+        }
+
         this.sendCommand(command)
     }
 
@@ -562,6 +599,10 @@ class WSClient {
 
         // Remove existing queued update
         if (type === 'block') {
+            const block = data as Block
+            if (block.updateAt > (this.lastUpdateAtByBoard[block.boardId] || 0)) {
+                this.lastUpdateAtByBoard[block.boardId] = block.updateAt
+            }
             this.updatedData.Blocks = this.updatedData.Blocks.filter((o) => o.id !== (data as Block).id)
             this.updatedData.Blocks.push(OctoUtils.hydrateBlock(data as Block))
         } else if (type === 'category') {